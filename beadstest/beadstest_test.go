@@ -0,0 +1,125 @@
+package beadstest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/beadstest"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+func getJSON(t *testing.T, url string, v any) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s: %s", url, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer(t *testing.T) {
+	srv := beadstest.NewServer(t)
+	epic := beadstest.Bead("Launch").Type("epic").Priority(1).Build()
+	docs := beadstest.Bead("Write docs").Labels("docs").BlockedBy(epic.ID).Build()
+	srv.Store.AddBeads(t, epic, docs)
+	srv.Store.AddEvents(t,
+		beadstest.Event(events.TopicBeadCreated, epic.ID).Actor("alice").Payload(events.BeadCreated{Bead: epic}).Build())
+
+	var ready struct {
+		Beads []*model.Bead `json:"beads"`
+	}
+	getJSON(t, srv.URL+"/v1/ready", &ready)
+	if len(ready.Beads) != 1 || ready.Beads[0].ID != epic.ID {
+		t.Fatalf("ready = %+v, want only %s", ready.Beads, epic.ID)
+	}
+
+	var got model.Bead
+	getJSON(t, srv.URL+"/v1/beads/"+docs.ID, &got)
+	if got.Title != "Write docs" || len(got.Labels) != 1 || got.Labels[0] != "docs" {
+		t.Fatalf("bead = %+v", got)
+	}
+
+	var page struct {
+		Events []*model.Event `json:"events"`
+	}
+	getJSON(t, srv.URL+"/v1/events", &page)
+	if len(page.Events) != 1 || page.Events[0].Actor != "alice" || page.Events[0].ID == 0 {
+		t.Fatalf("events = %+v", page.Events)
+	}
+}
+
+func TestServerConcurrentWrites(t *testing.T) {
+	srv := beadstest.NewServer(t)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			body, _ := json.Marshal(map[string]any{"title": "Parallel", "type": "task", "created_by": "alice"})
+			resp, err := http.Post(srv.URL+"/v1/beads", "application/json", bytes.NewReader(body))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusCreated {
+				t.Errorf("create: %s", resp.Status)
+			}
+		})
+	}
+	wg.Wait()
+
+	beads, total, err := srv.Store.ListBeads(context.Background(), model.BeadFilter{})
+	if err != nil || total != 10 || len(beads) != 10 {
+		t.Fatalf("ListBeads = %d beads, total %d, %v", len(beads), total, err)
+	}
+}
+
+func TestStoreUpdateConflict(t *testing.T) {
+	st := beadstest.NewStore()
+	b := beadstest.Bead("Race").Build()
+	st.AddBeads(t, b)
+	ctx := context.Background()
+
+	first, _ := st.GetBead(ctx, b.ID)
+	second, _ := st.GetBead(ctx, b.ID)
+	first.Title = "First"
+	if err := st.UpdateBead(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	second.Title = "Second"
+	if err := st.UpdateBead(ctx, second); !errors.Is(err, store.ErrConflict) {
+		t.Fatalf("stale update: err = %v, want store.ErrConflict", err)
+	}
+	if got, _ := st.GetBead(ctx, b.ID); got.Title != "First" {
+		t.Fatalf("title = %q", got.Title)
+	}
+}
+
+func TestBuilders(t *testing.T) {
+	b := beadstest.Bead("Fix login").Type("bug").Status(model.StatusClosed).Labels("auth")
+	one, two := b.Build(), b.Build()
+	if one.ClosedAt == nil || one.Kind != model.KindIssue || one.ID != two.ID {
+		t.Fatalf("bead = %+v", one)
+	}
+	one.Labels[0] = "changed"
+	if two.Labels[0] != "auth" {
+		t.Fatal("built beads share labels")
+	}
+
+	d := beadstest.Dep("bd-a", "bd-b").Type(model.DepParentChild).Metadata(`{"why":"scope"}`).Build()
+	if d.Type != model.DepParentChild || string(d.Metadata) != `{"why":"scope"}` {
+		t.Fatalf("dep = %+v", d)
+	}
+}
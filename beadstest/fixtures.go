@@ -0,0 +1,224 @@
+package beadstest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/idgen"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// BeadBuilder builds a bead. Start one with Bead; each setter returns the
+// builder, and Build may be called more than once.
+type BeadBuilder struct {
+	bead model.Bead
+}
+
+// Bead starts an open task titled title, with a new ID, created and updated
+// now.
+func Bead(title string) *BeadBuilder {
+	id, err := idgen.Generate()
+	if err != nil {
+		panic(err)
+	}
+	now := time.Now().UTC()
+	return &BeadBuilder{bead: model.Bead{
+		ID:        id,
+		Kind:      model.KindIssue,
+		Type:      "task",
+		Title:     title,
+		Status:    model.StatusOpen,
+		Priority:  2,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}}
+}
+
+// ID sets the bead's ID.
+func (b *BeadBuilder) ID(id string) *BeadBuilder { b.bead.ID = id; return b }
+
+// Kind sets the bead's kind.
+func (b *BeadBuilder) Kind(kind model.Kind) *BeadBuilder { b.bead.Kind = kind; return b }
+
+// Type sets the bead's type.
+func (b *BeadBuilder) Type(typ model.BeadType) *BeadBuilder { b.bead.Type = typ; return b }
+
+// Description sets the bead's description.
+func (b *BeadBuilder) Description(text string) *BeadBuilder { b.bead.Description = text; return b }
+
+// Priority sets the bead's priority, 0 (most urgent) to 4.
+func (b *BeadBuilder) Priority(p int) *BeadBuilder { b.bead.Priority = p; return b }
+
+// Assignee sets the bead's assignee.
+func (b *BeadBuilder) Assignee(actor string) *BeadBuilder { b.bead.Assignee = actor; return b }
+
+// CreatedBy sets who created the bead.
+func (b *BeadBuilder) CreatedBy(actor string) *BeadBuilder { b.bead.CreatedBy = actor; return b }
+
+// Labels adds labels to the bead.
+func (b *BeadBuilder) Labels(labels ...string) *BeadBuilder {
+	b.bead.Labels = append(b.bead.Labels, labels...)
+	return b
+}
+
+// Fields sets the bead's custom fields from a JSON object.
+func (b *BeadBuilder) Fields(fields string) *BeadBuilder {
+	b.bead.Fields = json.RawMessage(fields)
+	return b
+}
+
+// CreatedAt sets when the bead was created and last updated.
+func (b *BeadBuilder) CreatedAt(t time.Time) *BeadBuilder {
+	b.bead.CreatedAt, b.bead.UpdatedAt = t, t
+	return b
+}
+
+// Status sets the bead's status. A closed bead is closed when it was last
+// updated.
+func (b *BeadBuilder) Status(status model.Status) *BeadBuilder {
+	b.bead.Status = status
+	b.bead.ClosedAt = nil
+	if status == model.StatusClosed {
+		closed := b.bead.UpdatedAt
+		b.bead.ClosedAt = &closed
+	}
+	return b
+}
+
+// BlockedBy makes the bead depend on each of ids with a blocks dependency.
+func (b *BeadBuilder) BlockedBy(ids ...string) *BeadBuilder {
+	for _, id := range ids {
+		b.bead.Dependencies = append(b.bead.Dependencies, Dep(b.bead.ID, id).Build())
+	}
+	return b
+}
+
+// Build returns the bead.
+func (b *BeadBuilder) Build() *model.Bead {
+	bead := b.bead
+	bead.Labels = append([]string(nil), b.bead.Labels...)
+	bead.Dependencies = nil
+	for _, d := range b.bead.Dependencies {
+		dep := *d
+		dep.BeadID = bead.ID
+		bead.Dependencies = append(bead.Dependencies, &dep)
+	}
+	return &bead
+}
+
+// DepBuilder builds a dependency. Start one with Dep.
+type DepBuilder struct {
+	dep model.Dependency
+}
+
+// Dep starts a dependency of beadID on dependsOnID: by default it blocks
+// beadID until dependsOnID is closed.
+func Dep(beadID, dependsOnID string) *DepBuilder {
+	return &DepBuilder{dep: model.Dependency{
+		BeadID:      beadID,
+		DependsOnID: dependsOnID,
+		Type:        model.DepBlocks,
+		CreatedAt:   time.Now().UTC(),
+	}}
+}
+
+// Type sets the dependency's type.
+func (d *DepBuilder) Type(typ model.DependencyType) *DepBuilder { d.dep.Type = typ; return d }
+
+// CreatedBy sets who added the dependency.
+func (d *DepBuilder) CreatedBy(actor string) *DepBuilder { d.dep.CreatedBy = actor; return d }
+
+// Metadata sets the dependency's metadata from a JSON object.
+func (d *DepBuilder) Metadata(metadata string) *DepBuilder {
+	d.dep.Metadata = json.RawMessage(metadata)
+	return d
+}
+
+// Build returns the dependency.
+func (d *DepBuilder) Build() *model.Dependency {
+	dep := d.dep
+	return &dep
+}
+
+// EventBuilder builds an event. Start one with Event.
+type EventBuilder struct {
+	event model.Event
+}
+
+// Event starts an event on topic, such as events.TopicBeadCreated, about
+// beadID, recorded now with an empty payload.
+func Event(topic, beadID string) *EventBuilder {
+	return &EventBuilder{event: model.Event{
+		Topic:     topic,
+		BeadID:    beadID,
+		Payload:   json.RawMessage(`{}`),
+		CreatedAt: time.Now().UTC(),
+	}}
+}
+
+// Actor sets who caused the event.
+func (e *EventBuilder) Actor(actor string) *EventBuilder { e.event.Actor = actor; return e }
+
+// At sets when the event was recorded.
+func (e *EventBuilder) At(t time.Time) *EventBuilder { e.event.CreatedAt = t; return e }
+
+// Payload sets the event's payload to v encoded as JSON, such as an
+// events.BeadUpdated.
+func (e *EventBuilder) Payload(v any) *EventBuilder {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	e.event.Payload = data
+	return e
+}
+
+// Build returns the event.
+func (e *EventBuilder) Build() *model.Event {
+	event := e.event
+	return &event
+}
+
+// AddBeads creates beads in the store with their labels and dependencies,
+// failing t if one cannot be created.
+func (s *Store) AddBeads(t testing.TB, beads ...*model.Bead) {
+	t.Helper()
+	ctx := context.Background()
+	for _, b := range beads {
+		labels, deps := b.Labels, b.Dependencies
+		bead := *b
+		bead.Labels, bead.Dependencies = nil, nil
+		if err := s.CreateBead(ctx, &bead); err != nil {
+			t.Fatalf("beadstest: create bead %s: %v", b.ID, err)
+		}
+		for _, l := range labels {
+			if err := s.AddLabel(ctx, b.ID, l); err != nil {
+				t.Fatalf("beadstest: label bead %s: %v", b.ID, err)
+			}
+		}
+		s.AddDeps(t, deps...)
+	}
+}
+
+// AddDeps adds dependencies to the store, failing t if one cannot be added.
+func (s *Store) AddDeps(t testing.TB, deps ...*model.Dependency) {
+	t.Helper()
+	for _, d := range deps {
+		if err := s.AddDependency(context.Background(), d); err != nil {
+			t.Fatalf("beadstest: add dependency %s -> %s: %v", d.BeadID, d.DependsOnID, err)
+		}
+	}
+}
+
+// AddEvents records events in the store, in order, failing t if one cannot
+// be recorded. Each event's ID is set.
+func (s *Store) AddEvents(t testing.TB, events ...*model.Event) {
+	t.Helper()
+	for _, e := range events {
+		if err := s.RecordEvent(context.Background(), e); err != nil {
+			t.Fatalf("beadstest: record event %s: %v", e.Topic, err)
+		}
+	}
+}
@@ -0,0 +1,1438 @@
+package beadstest
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// memStore is the unsynchronized in-memory store behind Store; its methods
+// may call each other freely.
+type memStore struct {
+	beads         map[string]*model.Bead
+	trash         map[string]*model.Bead // soft-deleted beads
+	configs       map[string]*model.Config
+	configRevs    map[string][]*model.ConfigRevision
+	events        []*model.Event
+	published     map[int64]bool // event IDs marked published
+	summaries     []*model.EventSummary
+	deps          map[string][]*model.Dependency
+	labels        map[string][]string
+	aliases       map[string]*model.Alias // alias -> alias
+	comments      map[string][]*model.Comment
+	reactions     map[reactionKey]bool
+	commentNextID int64
+	notes         map[string][]*model.Note
+	agents        map[string]*model.Agent
+	actors        map[string]*model.Actor
+	externals     map[string][]*model.ExternalDep
+	externalID    int64
+	commits       []*model.Commit
+	checklist     []*model.ChecklistItem
+	watchers      map[string][]string
+	adviceAcks    map[string][]string // actor -> acknowledged advice bead IDs
+	locks         map[string]*model.BeadLock
+	notifications []*model.Notification
+	digests       []*model.Digest
+	createKeys    map[string]string // idempotency key -> bead ID
+	mirrors       map[string][]*model.MirroredBead
+}
+
+// reactionKey is one actor's reaction to a comment.
+type reactionKey struct {
+	commentID    int64
+	actor, emoji string
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		beads:      make(map[string]*model.Bead),
+		trash:      make(map[string]*model.Bead),
+		configs:    make(map[string]*model.Config),
+		deps:       make(map[string][]*model.Dependency),
+		labels:     make(map[string][]string),
+		aliases:    make(map[string]*model.Alias),
+		comments:   make(map[string][]*model.Comment),
+		reactions:  make(map[reactionKey]bool),
+		notes:      make(map[string][]*model.Note),
+		agents:     make(map[string]*model.Agent),
+		actors:     make(map[string]*model.Actor),
+		externals:  make(map[string][]*model.ExternalDep),
+		watchers:   make(map[string][]string),
+		adviceAcks: make(map[string][]string),
+		locks:      make(map[string]*model.BeadLock),
+		published:  make(map[int64]bool),
+		createKeys: make(map[string]string),
+		mirrors:    make(map[string][]*model.MirroredBead),
+	}
+}
+
+func (m *memStore) CreateBead(_ context.Context, bead *model.Bead) error {
+	for _, b := range m.beads {
+		if bead.Slug != "" && b.Slug == bead.Slug {
+			return store.ErrSlugTaken
+		}
+	}
+	m.beads[bead.ID] = bead
+	return nil
+}
+
+func (m *memStore) GetBead(_ context.Context, id string) (*model.Bead, error) {
+	b, ok := m.beads[id]
+	if !ok {
+		return nil, nil
+	}
+	// Clone and attach labels so callers see the latest label state.
+	clone := *b
+	clone.Labels = m.labels[id]
+	return &clone, nil
+}
+
+func (m *memStore) ListBeads(_ context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	var result []*model.Bead
+outer:
+	for _, b := range m.beads {
+		if len(filter.Status) > 0 {
+			found := false
+			for _, s := range filter.Status {
+				if b.Status == s {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		if len(filter.Type) > 0 {
+			found := false
+			for _, t := range filter.Type {
+				if b.Type == t {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		if len(filter.Kind) > 0 {
+			found := false
+			for _, k := range filter.Kind {
+				if b.Kind == k {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		if filter.Priority != nil && b.Priority != *filter.Priority {
+			continue
+		}
+		if filter.Assignee != "" && b.Assignee != filter.Assignee {
+			continue
+		}
+		if b.ArchivedAt != nil && !filter.IncludeArchived {
+			continue
+		}
+		if filter.ClosedAfter != nil && (b.ClosedAt == nil || b.ClosedAt.Before(*filter.ClosedAfter)) {
+			continue
+		}
+		if len(filter.Labels) > 0 {
+			beadLabels := m.labels[b.ID]
+			for _, want := range filter.Labels {
+				found := false
+				for _, have := range beadLabels {
+					if model.MatchLabel(want, have) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					continue outer
+				}
+			}
+		}
+		if filter.Search != "" {
+			if !strings.Contains(strings.ToLower(b.Title), strings.ToLower(filter.Search)) &&
+				!strings.Contains(strings.ToLower(b.Description), strings.ToLower(filter.Search)) {
+				continue
+			}
+		}
+		result = append(result, b)
+	}
+	return result, len(result), nil
+}
+
+func (m *memStore) ListReadyBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	return m.listByBlocked(ctx, filter, false)
+}
+
+func (m *memStore) ListBlockedBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	return m.listByBlocked(ctx, filter, true)
+}
+
+func (m *memStore) ClaimReadyBead(ctx context.Context, actor string, labels []string) (*model.Bead, error) {
+	ready, _, _ := m.listByBlocked(ctx, model.BeadFilter{}, false)
+	sort.SliceStable(ready, func(i, j int) bool {
+		if ready[i].Priority != ready[j].Priority {
+			return ready[i].Priority < ready[j].Priority
+		}
+		return ready[i].CreatedAt.Before(ready[j].CreatedAt)
+	})
+	for _, b := range ready {
+		if b.Kind != model.KindIssue || b.Type == "gate" {
+			continue
+		}
+		if b.Assignee != "" && b.Assignee != actor {
+			continue
+		}
+		if len(labels) > 0 && !slices.ContainsFunc(m.labels[b.ID], func(l string) bool { return slices.Contains(labels, l) }) {
+			continue
+		}
+		b.Status, b.Assignee, b.UpdatedAt = model.StatusInProgress, actor, time.Now().UTC()
+		return b, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+// listByBlocked lists the open beads matching filter whose blocked state
+// (an unclosed "blocks" dependency) is blocked, most urgent first.
+func (m *memStore) listByBlocked(ctx context.Context, filter model.BeadFilter, blocked bool) ([]*model.Bead, int, error) {
+	if len(filter.Status) == 0 {
+		filter.Status = []model.Status{model.StatusOpen}
+	}
+	page := filter
+	page.Limit, page.Offset = 0, 0
+	candidates, _, _ := m.ListBeads(ctx, page)
+
+	var matched []*model.Bead
+	for _, b := range candidates {
+		isBlocked := false
+		for _, d := range m.deps[b.ID] {
+			if blocker, ok := m.beads[d.DependsOnID]; ok && d.Type == model.DepBlocks && blocker.Status != model.StatusClosed {
+				isBlocked = true
+				break
+			}
+		}
+		for _, x := range m.externals[b.ID] {
+			if x.Status == model.ExternalWaiting {
+				isBlocked = true
+			}
+		}
+		if isBlocked == blocked {
+			matched = append(matched, b)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Priority != matched[j].Priority {
+			return matched[i].Priority < matched[j].Priority
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	total := len(matched)
+	matched = matched[min(filter.Offset, total):]
+	if filter.Limit > 0 && filter.Limit < len(matched) {
+		matched = matched[:filter.Limit]
+	}
+	return matched, total, nil
+}
+
+func (m *memStore) StreamBeads(ctx context.Context, filter model.BeadFilter, fn func(*model.Bead) error) error {
+	beads, _, _ := m.ListBeads(ctx, filter)
+	for _, b := range beads {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memStore) UpdateBead(_ context.Context, bead *model.Bead) error {
+	old, ok := m.beads[bead.ID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	if !old.UpdatedAt.Equal(bead.UpdatedAt) {
+		return store.ErrConflict
+	}
+	bead.UpdatedAt = time.Now().UTC()
+	if !bead.UpdatedAt.After(old.UpdatedAt) {
+		bead.UpdatedAt = old.UpdatedAt.Add(time.Microsecond)
+	}
+	if bead.Status != model.StatusClosed {
+		bead.ArchivedAt = nil
+	}
+	m.beads[bead.ID] = bead
+	return nil
+}
+
+func (m *memStore) CloseBead(_ context.Context, id string, closedBy string) (*model.Bead, error) {
+	b, ok := m.beads[id]
+	if !ok {
+		return nil, nil
+	}
+	now := time.Now().UTC()
+	b.Status = model.StatusClosed
+	b.ClosedAt = &now
+	b.ClosedBy = closedBy
+	b.UpdatedAt = now
+	return b, nil
+}
+
+func (m *memStore) DeleteBead(_ context.Context, id string) error {
+	_, live := m.beads[id]
+	_, trashed := m.trash[id]
+	if !live && !trashed {
+		return sql.ErrNoRows
+	}
+	delete(m.beads, id)
+	delete(m.trash, id)
+	delete(m.labels, id)
+	// Mirror ON DELETE CASCADE on deps.
+	delete(m.deps, id)
+	for beadID, deps := range m.deps {
+		kept := deps[:0]
+		for _, d := range deps {
+			if d.DependsOnID != id {
+				kept = append(kept, d)
+			}
+		}
+		m.deps[beadID] = kept
+	}
+	return nil
+}
+
+func (m *memStore) SoftDeleteBead(_ context.Context, id, deletedBy string) error {
+	b, ok := m.beads[id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	now := time.Now().UTC()
+	b.DeletedAt = &now
+	b.DeletedBy = deletedBy
+	m.trash[id] = b
+	delete(m.beads, id)
+	return nil
+}
+
+func (m *memStore) RestoreBead(_ context.Context, id string) (*model.Bead, error) {
+	b, ok := m.trash[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	b.DeletedAt = nil
+	b.DeletedBy = ""
+	m.beads[id] = b
+	delete(m.trash, id)
+	return b, nil
+}
+
+func (m *memStore) ListDeletedBeads(_ context.Context) ([]*model.Bead, error) {
+	var result []*model.Bead
+	for _, b := range m.trash {
+		result = append(result, b)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (m *memStore) ArchiveClosedBeads(_ context.Context, closedBefore time.Time) ([]string, error) {
+	now := time.Now().UTC()
+	var ids []string
+	for id, b := range m.beads {
+		if b.Status == model.StatusClosed && b.ClosedAt != nil && b.ClosedAt.Before(closedBefore) && b.ArchivedAt == nil {
+			b.ArchivedAt = &now
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (m *memStore) PurgeDeletedBeads(ctx context.Context, before time.Time) ([]string, error) {
+	var ids []string
+	for id, b := range m.trash {
+		if b.DeletedAt.Before(before) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		_ = m.DeleteBead(ctx, id)
+	}
+	return ids, nil
+}
+
+func (m *memStore) MergeBead(ctx context.Context, sourceID, targetID string) error {
+	m.comments[targetID] = append(m.comments[targetID], m.comments[sourceID]...)
+	delete(m.comments, sourceID)
+	m.notes[targetID] = append(m.notes[targetID], m.notes[sourceID]...)
+	delete(m.notes, sourceID)
+	for _, l := range m.labels[sourceID] {
+		_ = m.AddLabel(ctx, targetID, l)
+	}
+	delete(m.labels, sourceID)
+	for _, d := range m.deps[sourceID] {
+		if d.DependsOnID != targetID {
+			m.deps[targetID] = append(m.deps[targetID], &model.Dependency{BeadID: targetID, DependsOnID: d.DependsOnID, Type: d.Type})
+		}
+	}
+	delete(m.deps, sourceID)
+	for id, deps := range m.deps {
+		for _, d := range deps {
+			if d.DependsOnID == sourceID && id != targetID {
+				d.DependsOnID = targetID
+			}
+		}
+	}
+	for _, e := range m.events {
+		if e.BeadID == sourceID {
+			e.BeadID = targetID
+		}
+	}
+	for _, a := range m.aliases {
+		if a.BeadID == sourceID {
+			a.BeadID = targetID
+		}
+	}
+	return nil
+}
+
+// SimilarBeads scores titles by the fraction of shared lowercase words, a
+// rough stand-in for trigram similarity.
+func (m *memStore) SimilarBeads(_ context.Context, title, excludeID string, limit int) ([]*model.SimilarBead, error) {
+	words := func(s string) map[string]bool {
+		set := make(map[string]bool)
+		for _, w := range strings.Fields(strings.ToLower(s)) {
+			set[w] = true
+		}
+		return set
+	}
+	want := words(title)
+	var result []*model.SimilarBead
+	for id, b := range m.beads {
+		if id == excludeID || b.Status == model.StatusClosed {
+			continue
+		}
+		got, shared := words(b.Title), 0
+		for w := range got {
+			if want[w] {
+				shared++
+			}
+		}
+		if score := float64(shared) / float64(len(want)+len(got)-shared); score >= 0.3 {
+			result = append(result, &model.SimilarBead{Bead: b, Similarity: score})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Similarity > result[j].Similarity })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *memStore) AddDependency(_ context.Context, dep *model.Dependency) error {
+	m.deps[dep.BeadID] = append(m.deps[dep.BeadID], dep)
+	return nil
+}
+
+func (m *memStore) UpdateDependencyMetadata(_ context.Context, dep *model.Dependency) error {
+	for _, d := range m.deps[dep.BeadID] {
+		if d.DependsOnID == dep.DependsOnID && d.Type == dep.Type {
+			d.Metadata = dep.Metadata
+			dep.CreatedAt, dep.CreatedBy = d.CreatedAt, d.CreatedBy
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (m *memStore) RemoveDependency(_ context.Context, beadID, dependsOnID string, depType model.DependencyType) error {
+	deps := m.deps[beadID]
+	for i, d := range deps {
+		if d.DependsOnID == dependsOnID && d.Type == depType {
+			m.deps[beadID] = append(deps[:i], deps[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *memStore) GetDependencies(_ context.Context, beadID string) ([]*model.Dependency, error) {
+	return m.deps[beadID], nil
+}
+
+func (m *memStore) GetDependents(_ context.Context, beadID string) ([]*model.Dependency, error) {
+	var result []*model.Dependency
+	for id, deps := range m.deps {
+		if _, trashed := m.trash[id]; trashed {
+			continue
+		}
+		for _, d := range deps {
+			if d.DependsOnID == beadID {
+				result = append(result, d)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].BeadID < result[j].BeadID })
+	return result, nil
+}
+
+func (m *memStore) AddLabel(_ context.Context, beadID string, label string) error {
+	// Skip duplicates (mirrors ON CONFLICT DO NOTHING).
+	for _, l := range m.labels[beadID] {
+		if l == label {
+			return nil
+		}
+	}
+	m.labels[beadID] = append(m.labels[beadID], label)
+	return nil
+}
+
+func (m *memStore) RemoveLabel(_ context.Context, beadID string, label string) error {
+	labels := m.labels[beadID]
+	for i, l := range labels {
+		if l == label {
+			m.labels[beadID] = append(labels[:i], labels[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *memStore) GetLabels(_ context.Context, beadID string) ([]string, error) {
+	return m.labels[beadID], nil
+}
+
+func (m *memStore) ListLabels(_ context.Context) ([]*model.LabelCount, error) {
+	counts := map[string]int{}
+	for id, labels := range m.labels {
+		if _, ok := m.beads[id]; !ok {
+			continue
+		}
+		for _, l := range labels {
+			counts[l]++
+		}
+	}
+	var out []*model.LabelCount
+	for l, n := range counts {
+		ns, v := model.SplitLabel(l)
+		out = append(out, &model.LabelCount{Label: l, Namespace: ns, Value: v, Count: n})
+	}
+	slices.SortFunc(out, func(a, b *model.LabelCount) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Value, b.Value))
+	})
+	return out, nil
+}
+
+func (m *memStore) AggregateBeads(ctx context.Context, filter model.BeadFilter, groupBy string) ([]*model.AggregateGroup, error) {
+	filter.Limit, filter.Offset = 0, 0
+	beads, _, err := m.ListBeads(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	byKey := map[string]*model.AggregateGroup{}
+	add := func(key string, b *model.Bead) {
+		g, ok := byKey[key]
+		if !ok {
+			g = &model.AggregateGroup{Key: key}
+			byKey[key] = g
+		}
+		end := time.Now()
+		if b.ClosedAt != nil {
+			end = *b.ClosedAt
+		}
+		age := end.Sub(b.CreatedAt).Hours()
+		g.AvgAgeHours = (g.AvgAgeHours*float64(g.Count) + age) / float64(g.Count+1)
+		g.Count++
+	}
+	for _, b := range beads {
+		switch groupBy {
+		case model.GroupByStatus:
+			add(string(b.Status), b)
+		case model.GroupByType:
+			add(string(b.Type), b)
+		case model.GroupByAssignee:
+			add(b.Assignee, b)
+		case model.GroupByPriority:
+			add(strconv.Itoa(b.Priority), b)
+		case model.GroupByCreatedWeek:
+			day := b.CreatedAt.UTC().Truncate(24 * time.Hour)
+			add(day.AddDate(0, 0, -(int(day.Weekday())+6)%7).Format(time.DateOnly), b)
+		case model.GroupByLabel:
+			for _, l := range m.labels[b.ID] {
+				add(l, b)
+			}
+		default:
+			return nil, fmt.Errorf("unknown group-by dimension %q", groupBy)
+		}
+	}
+	var out []*model.AggregateGroup
+	for _, g := range byKey {
+		out = append(out, g)
+	}
+	slices.SortFunc(out, func(a, b *model.AggregateGroup) int { return cmp.Compare(a.Key, b.Key) })
+	return out, nil
+}
+
+func (m *memStore) RollupBeads(ctx context.Context, filter model.BeadFilter, since time.Time) (*model.Rollup, error) {
+	filter.Limit, filter.Offset = 0, 0
+	beads, _, err := m.ListBeads(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	blocked, _, _ := m.listByBlocked(ctx, model.BeadFilter{Status: []model.Status{model.StatusOpen, model.StatusInProgress, model.StatusDeferred}}, true)
+	r := &model.Rollup{ByStatus: map[string]int{}}
+	for _, b := range beads {
+		weight, ok := b.Field("weight").(float64)
+		if !ok {
+			weight = 1
+		}
+		r.ByStatus[string(b.Status)]++
+		r.Total++
+		r.TotalWeight += weight
+		if b.Status == model.StatusClosed {
+			r.CompletedWeight += weight
+			if b.ClosedAt != nil && !b.ClosedAt.Before(since) {
+				r.RecentWeight += weight
+			}
+		} else if slices.ContainsFunc(blocked, func(x *model.Bead) bool { return x.ID == b.ID }) {
+			r.Blocked++
+		}
+	}
+	return r, nil
+}
+
+func (m *memStore) ResolveBeadRef(_ context.Context, ref string) (string, error) {
+	if _, ok := m.beads[ref]; ok {
+		return ref, nil
+	}
+	for id, b := range m.beads {
+		if b.Slug == ref {
+			return id, nil
+		}
+	}
+	if a, ok := m.aliases[ref]; ok {
+		if _, ok := m.beads[a.BeadID]; ok {
+			return a.BeadID, nil
+		}
+	}
+	return "", sql.ErrNoRows
+}
+
+func (m *memStore) AddAlias(_ context.Context, alias *model.Alias) error {
+	if _, ok := m.aliases[alias.Alias]; ok {
+		return fmt.Errorf("duplicate alias %q", alias.Alias)
+	}
+	alias.CreatedAt = time.Now()
+	m.aliases[alias.Alias] = alias
+	return nil
+}
+
+func (m *memStore) RemoveAlias(_ context.Context, beadID, alias string) error {
+	if a, ok := m.aliases[alias]; !ok || a.BeadID != beadID {
+		return sql.ErrNoRows
+	}
+	delete(m.aliases, alias)
+	return nil
+}
+
+func (m *memStore) GetAliases(_ context.Context, beadID string) ([]*model.Alias, error) {
+	var out []*model.Alias
+	for _, a := range m.aliases {
+		if a.BeadID == beadID {
+			out = append(out, a)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Alias < out[j].Alias })
+	return out, nil
+}
+
+func (m *memStore) AddComment(_ context.Context, comment *model.Comment) error {
+	m.commentNextID++
+	comment.ID = m.commentNextID
+	m.comments[comment.BeadID] = append(m.comments[comment.BeadID], comment)
+	return nil
+}
+
+func (m *memStore) GetComments(_ context.Context, beadID string) ([]*model.Comment, error) {
+	return m.comments[beadID], nil
+}
+
+func (m *memStore) GetComment(_ context.Context, id int64) (*model.Comment, error) {
+	for _, cs := range m.comments {
+		for _, c := range cs {
+			if c.ID == id {
+				return c, nil
+			}
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *memStore) AddReaction(ctx context.Context, commentID int64, actor, emoji string) error {
+	return m.react(ctx, commentID, actor, emoji, true)
+}
+
+func (m *memStore) RemoveReaction(ctx context.Context, commentID int64, actor, emoji string) error {
+	return m.react(ctx, commentID, actor, emoji, false)
+}
+
+// react adds or removes actor's emoji on a comment and recounts its
+// reactions.
+func (m *memStore) react(ctx context.Context, commentID int64, actor, emoji string, add bool) error {
+	c, err := m.GetComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	key := reactionKey{commentID, actor, emoji}
+	if add {
+		m.reactions[key] = true
+	} else {
+		delete(m.reactions, key)
+	}
+	c.Reactions = nil
+	for k := range m.reactions {
+		if k.commentID == commentID {
+			if c.Reactions == nil {
+				c.Reactions = map[string]int{}
+			}
+			c.Reactions[k.emoji]++
+		}
+	}
+	return nil
+}
+
+func (m *memStore) ResolveComment(ctx context.Context, commentID int64, actor string, resolved bool) error {
+	c, err := m.GetComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if !resolved {
+		c.ResolvedAt, c.ResolvedBy = nil, ""
+	} else if c.ResolvedAt == nil {
+		now := time.Now().UTC()
+		c.ResolvedAt, c.ResolvedBy = &now, actor
+	}
+	return nil
+}
+
+func (m *memStore) ImportComment(_ context.Context, comment *model.Comment) error {
+	m.comments[comment.BeadID] = append(m.comments[comment.BeadID], comment)
+	m.commentNextID = max(m.commentNextID, comment.ID)
+	return nil
+}
+
+func (m *memStore) LoadRelations(_ context.Context, beads []*model.Bead) error {
+	for _, b := range beads {
+		b.Labels = m.labels[b.ID]
+		b.Dependencies = m.deps[b.ID]
+		b.Comments = m.comments[b.ID]
+	}
+	return nil
+}
+
+func (m *memStore) ListCommentsByAuthor(_ context.Context, author string, limit int) ([]*model.Comment, error) {
+	var result []*model.Comment
+	for _, cs := range m.comments {
+		for _, c := range cs {
+			if c.Author == author {
+				result = append(result, c)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *memStore) AppendNote(_ context.Context, note *model.Note) error {
+	b, ok := m.beads[note.BeadID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	if b.Notes != "" {
+		b.Notes += "\n"
+	}
+	b.Notes += note.Entry()
+	note.ID = int64(len(m.notes[note.BeadID]) + 1)
+	m.notes[note.BeadID] = append(m.notes[note.BeadID], note)
+	return nil
+}
+
+func (m *memStore) GetNotes(_ context.Context, beadID string) ([]*model.Note, error) {
+	return m.notes[beadID], nil
+}
+
+func (m *memStore) RecordCreateKey(_ context.Context, key, beadID string) (string, error) {
+	if id, ok := m.createKeys[key]; ok {
+		return id, nil
+	}
+	m.createKeys[key] = beadID
+	return beadID, nil
+}
+
+func (m *memStore) AppendDescription(_ context.Context, id, text string) (string, error) {
+	b, ok := m.beads[id]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	if b.Description != "" {
+		b.Description += "\n\n"
+	}
+	b.Description += text
+	return b.Description, nil
+}
+
+func (m *memStore) RecordEvent(_ context.Context, event *model.Event) error {
+	event.ID = int64(len(m.events) + 1)
+	m.events = append(m.events, event)
+	for _, w := range m.watchers[event.BeadID] {
+		if w != event.Actor {
+			m.notifications = append(m.notifications, &model.Notification{
+				ID: int64(len(m.notifications) + 1), Actor: w, Event: event, CreatedAt: event.CreatedAt,
+			})
+		}
+	}
+	return nil
+}
+
+func (m *memStore) NotifyActors(_ context.Context, eventID int64, actors []string) error {
+	event := m.events[eventID-1]
+	for _, a := range actors {
+		notified := a == event.Actor
+		for _, n := range m.notifications {
+			notified = notified || (n.Event.ID == eventID && n.Actor == a)
+		}
+		if !notified {
+			m.notifications = append(m.notifications, &model.Notification{
+				ID: int64(len(m.notifications) + 1), Actor: a, Event: event, CreatedAt: event.CreatedAt,
+			})
+		}
+	}
+	return nil
+}
+
+func (m *memStore) GetEvents(_ context.Context, beadID string) ([]*model.Event, error) {
+	var result []*model.Event
+	for _, e := range m.events {
+		if e.BeadID == beadID {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *memStore) ListEventsByActor(_ context.Context, actor string, limit int) ([]*model.Event, error) {
+	var result []*model.Event
+	for i := len(m.events) - 1; i >= 0 && len(result) < limit; i-- {
+		if m.events[i].Actor == actor {
+			result = append(result, m.events[i])
+		}
+	}
+	return result, nil
+}
+
+func (m *memStore) ListEventsBetween(_ context.Context, from, to time.Time, topics []string) ([]*model.Event, error) {
+	var result []*model.Event
+	for _, e := range m.events {
+		if e.CreatedAt.Before(from) || !e.CreatedAt.Before(to) {
+			continue
+		}
+		if len(topics) > 0 && !slices.Contains(topics, e.Topic) {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+func (m *memStore) ListEvents(_ context.Context, filter model.EventFilter) ([]*model.Event, error) {
+	var result []*model.Event
+	for _, e := range m.events {
+		switch {
+		case e.ID <= filter.AfterID,
+			len(filter.Topics) > 0 && !slices.Contains(filter.Topics, e.Topic),
+			filter.BeadID != "" && e.BeadID != filter.BeadID,
+			filter.Actor != "" && e.Actor != filter.Actor,
+			e.CreatedAt.Before(filter.Since),
+			filter.Label != "" && !slices.Contains(m.labels[e.BeadID], filter.Label),
+			filter.Project != "" && !m.inProject(e.BeadID, filter.Project):
+			continue
+		}
+		result = append(result, e)
+		if filter.Limit > 0 && len(result) == filter.Limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// inProject reports whether id is project or one of its parent-child
+// descendants.
+func (m *memStore) inProject(id, project string) bool {
+	seen := map[string]bool{}
+	for id != "" && !seen[id] {
+		if id == project {
+			return true
+		}
+		seen[id] = true
+		parent := ""
+		for _, d := range m.deps[id] {
+			if d.Type == model.DepParentChild {
+				parent = d.DependsOnID
+			}
+		}
+		id = parent
+	}
+	return false
+}
+
+func (m *memStore) ListUnpublishedEvents(_ context.Context, limit int) ([]*model.Event, error) {
+	var result []*model.Event
+	for _, e := range m.events {
+		if !m.published[e.ID] && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *memStore) MarkEventPublished(_ context.Context, id int64) error {
+	m.published[id] = true
+	return nil
+}
+
+func (m *memStore) ImportEvent(_ context.Context, event *model.Event) error {
+	m.events = append(m.events, event)
+	m.published[event.ID] = true
+	return nil
+}
+
+func (m *memStore) CompactEvents(_ context.Context, topics, except []string, cutoff time.Time) (int64, error) {
+	matches := func(patterns []string, topic string) bool {
+		return slices.ContainsFunc(patterns, func(p string) bool { return model.RetentionRule{Topic: p}.Covers(topic) })
+	}
+	var kept []*model.Event
+	var n int64
+	for _, e := range m.events {
+		if !m.published[e.ID] || !e.CreatedAt.Before(cutoff) || !matches(topics, e.Topic) || matches(except, e.Topic) {
+			kept = append(kept, e)
+			continue
+		}
+		n++
+		day := e.CreatedAt.UTC().Format(time.DateOnly)
+		i := slices.IndexFunc(m.summaries, func(s *model.EventSummary) bool {
+			return s.Topic == e.Topic && s.BeadID == e.BeadID && s.Day == day
+		})
+		if i < 0 {
+			m.summaries = append(m.summaries, &model.EventSummary{Topic: e.Topic, BeadID: e.BeadID, Day: day, FirstAt: e.CreatedAt, LastAt: e.CreatedAt})
+			i = len(m.summaries) - 1
+		}
+		sum := m.summaries[i]
+		sum.Count++
+		if e.Actor != "" && !slices.Contains(sum.Actors, e.Actor) {
+			sum.Actors = append(sum.Actors, e.Actor)
+			slices.Sort(sum.Actors)
+		}
+		if e.CreatedAt.Before(sum.FirstAt) {
+			sum.FirstAt = e.CreatedAt
+		}
+		if e.CreatedAt.After(sum.LastAt) {
+			sum.LastAt = e.CreatedAt
+		}
+	}
+	m.events = kept
+	return n, nil
+}
+
+func (m *memStore) ListEventSummaries(_ context.Context, filter model.EventSummaryFilter) ([]*model.EventSummary, error) {
+	var result []*model.EventSummary
+	for _, s := range m.summaries {
+		if (filter.BeadID == "" || s.BeadID == filter.BeadID) && (filter.Topic == "" || s.Topic == filter.Topic) {
+			result = append(result, s)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b *model.EventSummary) int { return strings.Compare(b.Day, a.Day) })
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
+func (m *memStore) SetConfig(_ context.Context, config *model.Config) error {
+	m.configs[config.Key] = config
+	config.Rev = m.addConfigRevision(&model.ConfigRevision{Key: config.Key, Value: config.Value, Actor: config.UpdatedBy})
+	return nil
+}
+
+func (m *memStore) addConfigRevision(r *model.ConfigRevision) int64 {
+	if m.configRevs == nil {
+		m.configRevs = make(map[string][]*model.ConfigRevision)
+	}
+	r.Rev = int64(len(m.configRevs[r.Key]) + 1)
+	r.CreatedAt = time.Now()
+	m.configRevs[r.Key] = append(m.configRevs[r.Key], r)
+	return r.Rev
+}
+
+func (m *memStore) ListConfigRevisions(_ context.Context, key string) ([]*model.ConfigRevision, error) {
+	var result []*model.ConfigRevision
+	for i := len(m.configRevs[key]) - 1; i >= 0; i-- {
+		result = append(result, m.configRevs[key][i])
+	}
+	return result, nil
+}
+
+func (m *memStore) GetConfigRevision(_ context.Context, key string, rev int64) (*model.ConfigRevision, error) {
+	revs := m.configRevs[key]
+	if rev < 1 || rev > int64(len(revs)) {
+		return nil, sql.ErrNoRows
+	}
+	return revs[rev-1], nil
+}
+
+func (m *memStore) GetConfig(_ context.Context, key string) (*model.Config, error) {
+	c, ok := m.configs[key]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return c, nil
+}
+
+func (m *memStore) ListConfigs(_ context.Context, namespace string) ([]*model.Config, error) {
+	prefix := namespace + ":"
+	var result []*model.Config
+	for k, c := range m.configs {
+		if strings.HasPrefix(k, prefix) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (m *memStore) ListAllConfigs(_ context.Context) ([]*model.Config, error) {
+	var result []*model.Config
+	for _, c := range m.configs {
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+func (m *memStore) DeleteConfig(_ context.Context, key string) error {
+	if _, ok := m.configs[key]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(m.configs, key)
+	m.addConfigRevision(&model.ConfigRevision{Key: key, Deleted: true})
+	return nil
+}
+
+func (m *memStore) AddWatcher(_ context.Context, beadID, actor string) error {
+	if slices.Contains(m.watchers[beadID], actor) {
+		return nil
+	}
+	m.watchers[beadID] = append(m.watchers[beadID], actor)
+	return nil
+}
+
+func (m *memStore) RemoveWatcher(_ context.Context, beadID, actor string) error {
+	m.watchers[beadID] = slices.DeleteFunc(m.watchers[beadID], func(w string) bool { return w == actor })
+	return nil
+}
+
+func (m *memStore) GetWatchers(_ context.Context, beadID string) ([]string, error) {
+	return m.watchers[beadID], nil
+}
+
+func (m *memStore) AckAdvice(_ context.Context, beadID, actor string) error {
+	if !slices.Contains(m.adviceAcks[actor], beadID) {
+		m.adviceAcks[actor] = append(m.adviceAcks[actor], beadID)
+	}
+	return nil
+}
+
+func (m *memStore) ListAdviceAcks(_ context.Context, actor string) ([]string, error) {
+	return m.adviceAcks[actor], nil
+}
+
+func (m *memStore) AcquireBeadLock(_ context.Context, lock *model.BeadLock) (*model.BeadLock, error) {
+	l := *lock
+	if held := m.locks[lock.BeadID]; held.HeldAt(lock.AcquiredAt) {
+		if held.Owner != lock.Owner {
+			return held, nil
+		}
+		l.AcquiredAt = held.AcquiredAt
+	}
+	m.locks[lock.BeadID] = &l
+	return &l, nil
+}
+
+func (m *memStore) GetBeadLock(_ context.Context, beadID string) (*model.BeadLock, error) {
+	l, ok := m.locks[beadID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return l, nil
+}
+
+func (m *memStore) ReleaseBeadLock(_ context.Context, beadID string) error {
+	delete(m.locks, beadID)
+	return nil
+}
+
+func (m *memStore) ListNotifications(_ context.Context, actor string, unreadOnly bool, limit int) ([]*model.Notification, error) {
+	var result []*model.Notification
+	for i := len(m.notifications) - 1; i >= 0 && len(result) < limit; i-- {
+		n := m.notifications[i]
+		if n.Actor == actor && (!unreadOnly || n.ReadAt == nil) {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+func (m *memStore) MarkNotificationsRead(_ context.Context, actor string, ids []int64) (int64, error) {
+	now := time.Now().UTC()
+	var marked int64
+	for _, n := range m.notifications {
+		if n.Actor == actor && n.ReadAt == nil && (len(ids) == 0 || slices.Contains(ids, n.ID)) {
+			n.ReadAt = &now
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+func (m *memStore) CreateDigest(_ context.Context, digest *model.Digest) error {
+	digest.ID = int64(len(m.digests) + 1)
+	m.digests = append(m.digests, digest)
+	return nil
+}
+
+func (m *memStore) GetLatestDigest(_ context.Context, subscription string) (*model.Digest, error) {
+	for i := len(m.digests) - 1; i >= 0; i-- {
+		if m.digests[i].Subscription == subscription {
+			return m.digests[i], nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *memStore) ReplaceMirroredBeads(_ context.Context, remote string, beads []*model.Bead) error {
+	var mirrored []*model.MirroredBead
+	for _, b := range beads {
+		mirrored = append(mirrored, &model.MirroredBead{Remote: remote, Bead: b, SyncedAt: time.Now().UTC()})
+	}
+	m.mirrors[remote] = mirrored
+	return nil
+}
+
+func (m *memStore) ListMirroredBeads(_ context.Context, remote string) ([]*model.MirroredBead, error) {
+	return m.mirrors[remote], nil
+}
+
+func (m *memStore) GetMirroredBead(_ context.Context, remote, id string) (*model.MirroredBead, error) {
+	for _, mb := range m.mirrors[remote] {
+		if mb.Bead.ID == id {
+			return mb, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *memStore) CreateAgent(_ context.Context, agent *model.Agent) error {
+	if _, ok := m.agents[agent.Name]; ok {
+		return fmt.Errorf("agent %s already exists", agent.Name)
+	}
+	agent.CreatedAt = time.Now().UTC()
+	m.agents[agent.Name] = agent
+	return nil
+}
+
+func (m *memStore) GetAgent(_ context.Context, name string) (*model.Agent, error) {
+	a, ok := m.agents[name]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return a, nil
+}
+
+func (m *memStore) GetAgentByTokenHash(_ context.Context, tokenHash string) (*model.Agent, error) {
+	for _, a := range m.agents {
+		if a.TokenHash == tokenHash {
+			return a, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *memStore) GetAgentByHookTokenHash(_ context.Context, tokenHash string) (*model.Agent, error) {
+	for _, a := range m.agents {
+		if a.HookTokenHash == tokenHash {
+			return a, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *memStore) SetAgentHookToken(_ context.Context, name, tokenHash string) error {
+	a, ok := m.agents[name]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	a.HookTokenHash = tokenHash
+	return nil
+}
+
+func (m *memStore) ListAgents(_ context.Context) ([]*model.Agent, error) {
+	var agents []*model.Agent
+	for _, a := range m.agents {
+		agents = append(agents, a)
+	}
+	sort.Slice(agents, func(i, j int) bool { return agents[i].Name < agents[j].Name })
+	return agents, nil
+}
+
+func (m *memStore) CreateActor(_ context.Context, actor *model.Actor) error {
+	if _, ok := m.actors[actor.ID]; ok {
+		return fmt.Errorf("actor %s already exists", actor.ID)
+	}
+	actor.CreatedAt = time.Now().UTC()
+	if actor.Aliases == nil {
+		actor.Aliases = []string{}
+	}
+	m.actors[actor.ID] = actor
+	return nil
+}
+
+func (m *memStore) GetActor(_ context.Context, id string) (*model.Actor, error) {
+	a, ok := m.actors[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	clone := *a
+	clone.Aliases = append([]string{}, a.Aliases...)
+	return &clone, nil
+}
+
+func (m *memStore) ListActors(ctx context.Context) ([]*model.Actor, error) {
+	var actors []*model.Actor
+	for id := range m.actors {
+		a, _ := m.GetActor(ctx, id)
+		actors = append(actors, a)
+	}
+	sort.Slice(actors, func(i, j int) bool { return actors[i].ID < actors[j].ID })
+	return actors, nil
+}
+
+func (m *memStore) UpdateActor(_ context.Context, actor *model.Actor) error {
+	a, ok := m.actors[actor.ID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	a.DisplayName, a.Type = actor.DisplayName, actor.Type
+	return nil
+}
+
+func (m *memStore) DeleteActor(_ context.Context, id string) error {
+	if _, ok := m.actors[id]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(m.actors, id)
+	return nil
+}
+
+func (m *memStore) AddActorAlias(_ context.Context, id, alias string) error {
+	a, ok := m.actors[id]
+	if !ok {
+		return fmt.Errorf("actor %s does not exist", id)
+	}
+	a.Aliases = append(a.Aliases, alias)
+	sort.Strings(a.Aliases)
+	return nil
+}
+
+func (m *memStore) RemoveActorAlias(_ context.Context, id, alias string) error {
+	a, ok := m.actors[id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	for i, have := range a.Aliases {
+		if have == alias {
+			a.Aliases = append(a.Aliases[:i], a.Aliases[i+1:]...)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (m *memStore) ResolveActor(_ context.Context, name string) (string, error) {
+	for id := range m.actors {
+		if strings.EqualFold(id, name) {
+			return id, nil
+		}
+	}
+	for id, a := range m.actors {
+		for _, alias := range a.Aliases {
+			if alias == strings.ToLower(name) {
+				return id, nil
+			}
+		}
+	}
+	return "", sql.ErrNoRows
+}
+
+func (m *memStore) LinkCommit(_ context.Context, c *model.Commit) (bool, error) {
+	for _, have := range m.commits {
+		if have.BeadID == c.BeadID && have.Repo == c.Repo && have.SHA == c.SHA {
+			*c = *have
+			return false, nil
+		}
+	}
+	c.ID, c.CreatedAt = int64(len(m.commits)+1), time.Now().UTC()
+	clone := *c
+	m.commits = append(m.commits, &clone)
+	return true, nil
+}
+
+func (m *memStore) GetCommits(_ context.Context, beadID string) ([]*model.Commit, error) {
+	var result []*model.Commit
+	for i := len(m.commits) - 1; i >= 0; i-- {
+		if m.commits[i].BeadID == beadID {
+			clone := *m.commits[i]
+			result = append(result, &clone)
+		}
+	}
+	return result, nil
+}
+
+func (m *memStore) AddChecklistItems(_ context.Context, beadID string, texts []string, actor string) ([]*model.ChecklistItem, error) {
+	last := 0
+	for _, it := range m.checklist {
+		if it.BeadID == beadID {
+			last = max(last, it.Index)
+		}
+	}
+	var added []*model.ChecklistItem
+	for i, text := range texts {
+		it := &model.ChecklistItem{BeadID: beadID, Index: last + i + 1, Text: text, CreatedBy: actor, CreatedAt: time.Now().UTC()}
+		m.checklist = append(m.checklist, it)
+		clone := *it
+		added = append(added, &clone)
+	}
+	return added, nil
+}
+
+func (m *memStore) GetChecklist(_ context.Context, beadID string) ([]*model.ChecklistItem, error) {
+	var result []*model.ChecklistItem
+	for _, it := range m.checklist {
+		if it.BeadID == beadID {
+			clone := *it
+			result = append(result, &clone)
+		}
+	}
+	return result, nil
+}
+
+func (m *memStore) CheckChecklistItem(_ context.Context, beadID string, index int, actor string, checked bool) (*model.ChecklistItem, error) {
+	for _, it := range m.checklist {
+		if it.BeadID != beadID || it.Index != index {
+			continue
+		}
+		switch {
+		case !checked:
+			it.Checked, it.CheckedAt, it.CheckedBy = false, nil, ""
+		case !it.Checked:
+			now := time.Now().UTC()
+			it.Checked, it.CheckedAt, it.CheckedBy = true, &now, actor
+		}
+		clone := *it
+		return &clone, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *memStore) AddExternalDep(_ context.Context, dep *model.ExternalDep) error {
+	m.externalID++
+	dep.ID, dep.CreatedAt = m.externalID, time.Now().UTC()
+	clone := *dep
+	m.externals[dep.BeadID] = append(m.externals[dep.BeadID], &clone)
+	return nil
+}
+
+func (m *memStore) GetExternalDeps(_ context.Context, beadID string) ([]*model.ExternalDep, error) {
+	var deps []*model.ExternalDep
+	for _, d := range m.externals[beadID] {
+		clone := *d
+		deps = append(deps, &clone)
+	}
+	return deps, nil
+}
+
+func (m *memStore) ListProbedExternalDeps(_ context.Context) ([]*model.ExternalDep, error) {
+	var deps []*model.ExternalDep
+	for beadID, list := range m.externals {
+		if b, ok := m.beads[beadID]; !ok || b.Status == model.StatusClosed {
+			continue
+		}
+		for _, d := range list {
+			if d.Status == model.ExternalWaiting && d.Probe != "" {
+				clone := *d
+				deps = append(deps, &clone)
+			}
+		}
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].ID < deps[j].ID })
+	return deps, nil
+}
+
+func (m *memStore) UpdateExternalDep(_ context.Context, dep *model.ExternalDep) error {
+	for _, d := range m.externals[dep.BeadID] {
+		if d.ID == dep.ID {
+			d.Status, d.CheckedAt, d.LastError = dep.Status, dep.CheckedAt, dep.LastError
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (m *memStore) RemoveExternalDep(_ context.Context, beadID string, id int64) error {
+	for i, d := range m.externals[beadID] {
+		if d.ID == id {
+			m.externals[beadID] = append(m.externals[beadID][:i], m.externals[beadID][i+1:]...)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (m *memStore) RunInTransaction(_ context.Context, fn func(tx store.Store) error) error {
+	return fn(m)
+}
+
+func (m *memStore) RunInSnapshot(_ context.Context, fn func(tx store.Store) error) error {
+	return fn(m)
+}
+
+func (m *memStore) Close() error {
+	return nil
+}
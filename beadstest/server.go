@@ -0,0 +1,39 @@
+// Package beadstest provides what services built on beads need to test
+// against it without a database: an in-memory Store, a Server running the
+// real HTTP API on it, and builders for beads, dependencies and events.
+//
+//	srv := beadstest.NewServer(t)
+//	epic := beadstest.Bead("Launch").Type("epic").Build()
+//	srv.Store.AddBeads(t, epic, beadstest.Bead("Write docs").BlockedBy(epic.ID).Build())
+//	resp, err := http.Get(srv.URL + "/v1/ready")
+package beadstest
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/server"
+)
+
+// Server is the beads HTTP API, as served by bd's server, on a local
+// httptest server backed by a Store. Events are recorded in the store and
+// not published.
+type Server struct {
+	*httptest.Server
+	Store *Store
+	// Beads is the server behind the API, for configuring it, such as with
+	// SetRegistrationTokens.
+	Beads *server.BeadsServer
+}
+
+// NewServer starts a Server on an empty Store. It is closed when the test
+// ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	st := NewStore()
+	bs := server.NewBeadsServer(st, &events.NoopPublisher{})
+	srv := &Server{Server: httptest.NewServer(bs.NewHTTPHandler()), Store: st, Beads: bs}
+	t.Cleanup(srv.Close)
+	return srv
+}
@@ -0,0 +1,673 @@
+package beadstest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// Store is an in-memory store.Store. It keeps everything in maps, computes
+// what the Postgres store computes only where the server relies on it, and
+// is safe for concurrent use: each call holds the whole store while it
+// runs.
+type Store struct {
+	mu  sync.Mutex
+	mem *memStore
+}
+
+var _ store.Store = (*Store)(nil)
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{mem: newMemStore()}
+}
+
+func (s *Store) CreateBead(ctx context.Context, bead *model.Bead) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.CreateBead(ctx, bead)
+}
+
+func (s *Store) GetBead(ctx context.Context, id string) (*model.Bead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetBead(ctx, id)
+}
+
+func (s *Store) ListBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListBeads(ctx, filter)
+}
+
+func (s *Store) ListReadyBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListReadyBeads(ctx, filter)
+}
+
+func (s *Store) ListBlockedBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListBlockedBeads(ctx, filter)
+}
+
+func (s *Store) ClaimReadyBead(ctx context.Context, actor string, labels []string) (*model.Bead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ClaimReadyBead(ctx, actor, labels)
+}
+
+// StreamBeads reads the matching beads, then calls fn with each without
+// holding the store, so fn may use it.
+func (s *Store) StreamBeads(ctx context.Context, filter model.BeadFilter, fn func(*model.Bead) error) error {
+	var beads []*model.Bead
+	s.mu.Lock()
+	err := s.mem.StreamBeads(ctx, filter, func(b *model.Bead) error {
+		beads = append(beads, b)
+		return nil
+	})
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	for _, b := range beads {
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) AggregateBeads(ctx context.Context, filter model.BeadFilter, groupBy string) ([]*model.AggregateGroup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AggregateBeads(ctx, filter, groupBy)
+}
+
+func (s *Store) RollupBeads(ctx context.Context, filter model.BeadFilter, since time.Time) (*model.Rollup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.RollupBeads(ctx, filter, since)
+}
+
+func (s *Store) UpdateBead(ctx context.Context, bead *model.Bead) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.UpdateBead(ctx, bead)
+}
+
+func (s *Store) CloseBead(ctx context.Context, id string, closedBy string) (*model.Bead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.CloseBead(ctx, id, closedBy)
+}
+
+func (s *Store) RecordCreateKey(ctx context.Context, key, beadID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.RecordCreateKey(ctx, key, beadID)
+}
+
+func (s *Store) DeleteBead(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.DeleteBead(ctx, id)
+}
+
+func (s *Store) SoftDeleteBead(ctx context.Context, id, deletedBy string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.SoftDeleteBead(ctx, id, deletedBy)
+}
+
+func (s *Store) RestoreBead(ctx context.Context, id string) (*model.Bead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.RestoreBead(ctx, id)
+}
+
+func (s *Store) ListDeletedBeads(ctx context.Context) ([]*model.Bead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListDeletedBeads(ctx)
+}
+
+func (s *Store) PurgeDeletedBeads(ctx context.Context, before time.Time) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.PurgeDeletedBeads(ctx, before)
+}
+
+func (s *Store) ArchiveClosedBeads(ctx context.Context, closedBefore time.Time) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ArchiveClosedBeads(ctx, closedBefore)
+}
+
+func (s *Store) MergeBead(ctx context.Context, sourceID, targetID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.MergeBead(ctx, sourceID, targetID)
+}
+
+func (s *Store) SimilarBeads(ctx context.Context, title, excludeID string, limit int) ([]*model.SimilarBead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.SimilarBeads(ctx, title, excludeID, limit)
+}
+
+func (s *Store) AddDependency(ctx context.Context, dep *model.Dependency) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AddDependency(ctx, dep)
+}
+
+func (s *Store) RemoveDependency(ctx context.Context, beadID, dependsOnID string, depType model.DependencyType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.RemoveDependency(ctx, beadID, dependsOnID, depType)
+}
+
+func (s *Store) GetDependencies(ctx context.Context, beadID string) ([]*model.Dependency, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetDependencies(ctx, beadID)
+}
+
+func (s *Store) GetDependents(ctx context.Context, beadID string) ([]*model.Dependency, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetDependents(ctx, beadID)
+}
+
+func (s *Store) UpdateDependencyMetadata(ctx context.Context, dep *model.Dependency) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.UpdateDependencyMetadata(ctx, dep)
+}
+
+func (s *Store) AddLabel(ctx context.Context, beadID string, label string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AddLabel(ctx, beadID, label)
+}
+
+func (s *Store) RemoveLabel(ctx context.Context, beadID string, label string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.RemoveLabel(ctx, beadID, label)
+}
+
+func (s *Store) GetLabels(ctx context.Context, beadID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetLabels(ctx, beadID)
+}
+
+func (s *Store) ListLabels(ctx context.Context) ([]*model.LabelCount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListLabels(ctx)
+}
+
+func (s *Store) ResolveBeadRef(ctx context.Context, ref string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ResolveBeadRef(ctx, ref)
+}
+
+func (s *Store) AddAlias(ctx context.Context, alias *model.Alias) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AddAlias(ctx, alias)
+}
+
+func (s *Store) RemoveAlias(ctx context.Context, beadID, alias string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.RemoveAlias(ctx, beadID, alias)
+}
+
+func (s *Store) GetAliases(ctx context.Context, beadID string) ([]*model.Alias, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetAliases(ctx, beadID)
+}
+
+func (s *Store) AddComment(ctx context.Context, comment *model.Comment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AddComment(ctx, comment)
+}
+
+func (s *Store) GetComments(ctx context.Context, beadID string) ([]*model.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetComments(ctx, beadID)
+}
+
+func (s *Store) GetComment(ctx context.Context, id int64) (*model.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetComment(ctx, id)
+}
+
+func (s *Store) AddReaction(ctx context.Context, commentID int64, actor, emoji string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AddReaction(ctx, commentID, actor, emoji)
+}
+
+func (s *Store) RemoveReaction(ctx context.Context, commentID int64, actor, emoji string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.RemoveReaction(ctx, commentID, actor, emoji)
+}
+
+func (s *Store) ResolveComment(ctx context.Context, commentID int64, actor string, resolved bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ResolveComment(ctx, commentID, actor, resolved)
+}
+
+func (s *Store) ImportComment(ctx context.Context, comment *model.Comment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ImportComment(ctx, comment)
+}
+
+func (s *Store) LoadRelations(ctx context.Context, beads []*model.Bead) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.LoadRelations(ctx, beads)
+}
+
+func (s *Store) ListCommentsByAuthor(ctx context.Context, author string, limit int) ([]*model.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListCommentsByAuthor(ctx, author, limit)
+}
+
+func (s *Store) AppendNote(ctx context.Context, note *model.Note) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AppendNote(ctx, note)
+}
+
+func (s *Store) GetNotes(ctx context.Context, beadID string) ([]*model.Note, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetNotes(ctx, beadID)
+}
+
+func (s *Store) AppendDescription(ctx context.Context, id, text string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AppendDescription(ctx, id, text)
+}
+
+func (s *Store) AddChecklistItems(ctx context.Context, beadID string, texts []string, actor string) ([]*model.ChecklistItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AddChecklistItems(ctx, beadID, texts, actor)
+}
+
+func (s *Store) GetChecklist(ctx context.Context, beadID string) ([]*model.ChecklistItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetChecklist(ctx, beadID)
+}
+
+func (s *Store) CheckChecklistItem(ctx context.Context, beadID string, index int, actor string, checked bool) (*model.ChecklistItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.CheckChecklistItem(ctx, beadID, index, actor, checked)
+}
+
+func (s *Store) RecordEvent(ctx context.Context, event *model.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.RecordEvent(ctx, event)
+}
+
+func (s *Store) GetEvents(ctx context.Context, beadID string) ([]*model.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetEvents(ctx, beadID)
+}
+
+func (s *Store) ListEventsByActor(ctx context.Context, actor string, limit int) ([]*model.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListEventsByActor(ctx, actor, limit)
+}
+
+func (s *Store) ListEventsBetween(ctx context.Context, from, to time.Time, topics []string) ([]*model.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListEventsBetween(ctx, from, to, topics)
+}
+
+func (s *Store) ListEvents(ctx context.Context, filter model.EventFilter) ([]*model.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListEvents(ctx, filter)
+}
+
+func (s *Store) ListUnpublishedEvents(ctx context.Context, limit int) ([]*model.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListUnpublishedEvents(ctx, limit)
+}
+
+func (s *Store) MarkEventPublished(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.MarkEventPublished(ctx, id)
+}
+
+func (s *Store) ImportEvent(ctx context.Context, event *model.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ImportEvent(ctx, event)
+}
+
+func (s *Store) CompactEvents(ctx context.Context, topics, except []string, cutoff time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.CompactEvents(ctx, topics, except, cutoff)
+}
+
+func (s *Store) ListEventSummaries(ctx context.Context, filter model.EventSummaryFilter) ([]*model.EventSummary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListEventSummaries(ctx, filter)
+}
+
+func (s *Store) AddWatcher(ctx context.Context, beadID, actor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AddWatcher(ctx, beadID, actor)
+}
+
+func (s *Store) RemoveWatcher(ctx context.Context, beadID, actor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.RemoveWatcher(ctx, beadID, actor)
+}
+
+func (s *Store) GetWatchers(ctx context.Context, beadID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetWatchers(ctx, beadID)
+}
+
+func (s *Store) ListNotifications(ctx context.Context, actor string, unreadOnly bool, limit int) ([]*model.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListNotifications(ctx, actor, unreadOnly, limit)
+}
+
+func (s *Store) MarkNotificationsRead(ctx context.Context, actor string, ids []int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.MarkNotificationsRead(ctx, actor, ids)
+}
+
+func (s *Store) NotifyActors(ctx context.Context, eventID int64, actors []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.NotifyActors(ctx, eventID, actors)
+}
+
+func (s *Store) AckAdvice(ctx context.Context, beadID, actor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AckAdvice(ctx, beadID, actor)
+}
+
+func (s *Store) ListAdviceAcks(ctx context.Context, actor string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListAdviceAcks(ctx, actor)
+}
+
+func (s *Store) AcquireBeadLock(ctx context.Context, lock *model.BeadLock) (*model.BeadLock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AcquireBeadLock(ctx, lock)
+}
+
+func (s *Store) GetBeadLock(ctx context.Context, beadID string) (*model.BeadLock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetBeadLock(ctx, beadID)
+}
+
+func (s *Store) ReleaseBeadLock(ctx context.Context, beadID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ReleaseBeadLock(ctx, beadID)
+}
+
+func (s *Store) CreateDigest(ctx context.Context, digest *model.Digest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.CreateDigest(ctx, digest)
+}
+
+func (s *Store) GetLatestDigest(ctx context.Context, subscription string) (*model.Digest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetLatestDigest(ctx, subscription)
+}
+
+func (s *Store) ReplaceMirroredBeads(ctx context.Context, remote string, beads []*model.Bead) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ReplaceMirroredBeads(ctx, remote, beads)
+}
+
+func (s *Store) ListMirroredBeads(ctx context.Context, remote string) ([]*model.MirroredBead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListMirroredBeads(ctx, remote)
+}
+
+func (s *Store) GetMirroredBead(ctx context.Context, remote, id string) (*model.MirroredBead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetMirroredBead(ctx, remote, id)
+}
+
+func (s *Store) SetConfig(ctx context.Context, config *model.Config) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.SetConfig(ctx, config)
+}
+
+func (s *Store) GetConfig(ctx context.Context, key string) (*model.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetConfig(ctx, key)
+}
+
+func (s *Store) ListConfigs(ctx context.Context, namespace string) ([]*model.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListConfigs(ctx, namespace)
+}
+
+func (s *Store) ListAllConfigs(ctx context.Context) ([]*model.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListAllConfigs(ctx)
+}
+
+func (s *Store) DeleteConfig(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.DeleteConfig(ctx, key)
+}
+
+func (s *Store) ListConfigRevisions(ctx context.Context, key string) ([]*model.ConfigRevision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListConfigRevisions(ctx, key)
+}
+
+func (s *Store) GetConfigRevision(ctx context.Context, key string, rev int64) (*model.ConfigRevision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetConfigRevision(ctx, key, rev)
+}
+
+func (s *Store) CreateAgent(ctx context.Context, agent *model.Agent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.CreateAgent(ctx, agent)
+}
+
+func (s *Store) GetAgent(ctx context.Context, name string) (*model.Agent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetAgent(ctx, name)
+}
+
+func (s *Store) GetAgentByTokenHash(ctx context.Context, tokenHash string) (*model.Agent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetAgentByTokenHash(ctx, tokenHash)
+}
+
+func (s *Store) GetAgentByHookTokenHash(ctx context.Context, tokenHash string) (*model.Agent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetAgentByHookTokenHash(ctx, tokenHash)
+}
+
+func (s *Store) SetAgentHookToken(ctx context.Context, name, tokenHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.SetAgentHookToken(ctx, name, tokenHash)
+}
+
+func (s *Store) ListAgents(ctx context.Context) ([]*model.Agent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListAgents(ctx)
+}
+
+func (s *Store) AddExternalDep(ctx context.Context, dep *model.ExternalDep) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AddExternalDep(ctx, dep)
+}
+
+func (s *Store) GetExternalDeps(ctx context.Context, beadID string) ([]*model.ExternalDep, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetExternalDeps(ctx, beadID)
+}
+
+func (s *Store) ListProbedExternalDeps(ctx context.Context) ([]*model.ExternalDep, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListProbedExternalDeps(ctx)
+}
+
+func (s *Store) UpdateExternalDep(ctx context.Context, dep *model.ExternalDep) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.UpdateExternalDep(ctx, dep)
+}
+
+func (s *Store) RemoveExternalDep(ctx context.Context, beadID string, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.RemoveExternalDep(ctx, beadID, id)
+}
+
+func (s *Store) LinkCommit(ctx context.Context, c *model.Commit) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.LinkCommit(ctx, c)
+}
+
+func (s *Store) GetCommits(ctx context.Context, beadID string) ([]*model.Commit, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetCommits(ctx, beadID)
+}
+
+func (s *Store) CreateActor(ctx context.Context, actor *model.Actor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.CreateActor(ctx, actor)
+}
+
+func (s *Store) GetActor(ctx context.Context, id string) (*model.Actor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.GetActor(ctx, id)
+}
+
+func (s *Store) ListActors(ctx context.Context) ([]*model.Actor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ListActors(ctx)
+}
+
+func (s *Store) UpdateActor(ctx context.Context, actor *model.Actor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.UpdateActor(ctx, actor)
+}
+
+func (s *Store) DeleteActor(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.DeleteActor(ctx, id)
+}
+
+func (s *Store) AddActorAlias(ctx context.Context, id, alias string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.AddActorAlias(ctx, id, alias)
+}
+
+func (s *Store) RemoveActorAlias(ctx context.Context, id, alias string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.RemoveActorAlias(ctx, id, alias)
+}
+
+func (s *Store) ResolveActor(ctx context.Context, name string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.ResolveActor(ctx, name)
+}
+
+// RunInTransaction calls fn with the store itself. Each of fn's calls holds
+// the store on its own, as callers of a transaction may also use the store
+// outside it, so fn is not isolated from other callers, and nothing is
+// rolled back when it fails.
+func (s *Store) RunInTransaction(_ context.Context, fn func(tx store.Store) error) error {
+	return fn(s)
+}
+
+// RunInSnapshot calls fn with the store itself; like RunInTransaction, fn
+// may see concurrent writes.
+func (s *Store) RunInSnapshot(_ context.Context, fn func(tx store.Store) error) error {
+	return fn(s)
+}
+
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mem.Close()
+}
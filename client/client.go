@@ -0,0 +1,95 @@
+// Package client talks to a beads server's HTTP API for services built on
+// beads.
+//
+//	c := client.New("http://beads:8080")
+//	c.Token = os.Getenv("BEADS_TOKEN")
+//	events, err := c.WatchEvents(ctx, client.EventFilter{Topics: []string{"beads.bead.closed"}})
+//	for e := range events {
+//		...
+//	}
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// BeadsClient is a client of a beads server's HTTP API. Set its fields
+// before the first call; it is then safe for concurrent use.
+type BeadsClient struct {
+	// BaseURL is the server's HTTP address, such as http://beads:8080.
+	BaseURL string
+	// Token, when set, is sent as a bearer token.
+	Token string
+	// HTTPClient sends the requests; nil means http.DefaultClient. Event
+	// streams stay open indefinitely, so it should have no Timeout.
+	HTTPClient *http.Client
+	// Header is added to every request, such as X-Beads-Client-Version.
+	Header http.Header
+	// OnStreamLost, when set, is called with the error each time an event
+	// stream drops without the server asking the client to reconnect,
+	// before it is reopened.
+	OnStreamLost func(err error)
+}
+
+// New returns a client of the server at baseURL.
+func New(baseURL string) *BeadsClient {
+	return &BeadsClient{BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// APIError is an error response from the server, or a response other than
+// the one expected.
+type APIError struct {
+	StatusCode int    `json:"-"`     // e.g. 404
+	Status     string `json:"-"`     // e.g. "404 Not Found"
+	Message    string `json:"error"` // human-readable
+	Code       string `json:"code"`  // machine-readable, e.g. "bead_not_found"
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return e.Status
+	}
+	return e.Status + ": " + e.Message
+}
+
+// newRequest returns a request for path with the client's headers and
+// token set.
+func (c *BeadsClient) newRequest(ctx context.Context, method, path string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.BaseURL, "/")+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range c.Header {
+		req.Header[k] = v
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return req, nil
+}
+
+// do sends req and returns the response, or an *APIError if its status is
+// not want.
+func (c *BeadsClient) do(req *http.Request, want int) (*http.Response, error) {
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != want {
+		defer resp.Body.Close()
+		apiErr := &APIError{StatusCode: resp.StatusCode, Status: resp.Status}
+		if data, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024)); err == nil {
+			_ = json.Unmarshal(data, apiErr)
+		}
+		return nil, apiErr
+	}
+	return resp, nil
+}
@@ -0,0 +1,218 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Delays before reopening a dropped event stream, doubling after each
+// failed attempt.
+const (
+	reconnectMin = time.Second
+	reconnectMax = 30 * time.Second
+)
+
+// EventFilter narrows the events WatchEvents delivers. The zero filter
+// delivers every event as it happens.
+type EventFilter struct {
+	Topics  []string // any of; empty means every topic
+	BeadID  string   // a bead ID, slug or alias
+	Actor   string
+	Label   string // the event's bead carries this label
+	Project string // the event's bead is this epic or one of its descendants
+
+	// Coalesce, when set, has the server sum up each bead's events over a
+	// window of up to a minute and send one Event per changed bead when the
+	// window ends.
+	Coalesce time.Duration
+
+	// AfterID resumes a watch after the event with this ID, such as the
+	// last one a consumer handled before it restarted: the matching events
+	// recorded since are delivered first.
+	AfterID int64
+}
+
+// query returns the stream parameters for f.
+func (f EventFilter) query() url.Values {
+	q := url.Values{}
+	if f.Coalesce > 0 {
+		q.Set("coalesce", f.Coalesce.String())
+	}
+	if len(f.Topics) > 0 {
+		q.Set("topic", strings.Join(f.Topics, ","))
+	}
+	for k, v := range map[string]string{"bead_id": f.BeadID, "actor": f.Actor, "label": f.Label, "project": f.Project} {
+		if v != "" {
+			q.Set(k, v)
+		}
+	}
+	return q
+}
+
+// Event is a change to a bead delivered by WatchEvents. Without coalescing
+// it is one event and Count is 1. Coalesced, it sums up Count events on the
+// bead, with their distinct Topics and Actors in the order first seen, and
+// the other fields are those of the last.
+type Event struct {
+	ID        int64           `json:"id"`
+	Topic     string          `json:"topic"`
+	BeadID    string          `json:"bead_id"`
+	Actor     string          `json:"actor,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+
+	Count  int      `json:"count"`
+	Topics []string `json:"topics"`
+	Actors []string `json:"actors,omitempty"`
+}
+
+// WatchEvents opens the server's event stream (GET /v1/events/stream),
+// narrowed by filter, and delivers events until ctx is cancelled, when the
+// channel is closed. When the server advises a reconnect or the connection
+// drops, it reopens the stream from the last event delivered, so none is
+// lost, backing off while the server is unreachable. Only failing to open
+// the first connection is an error; a response other than 200 is an
+// *APIError.
+func (c *BeadsClient) WatchEvents(ctx context.Context, filter EventFilter) (<-chan Event, error) {
+	body, err := c.openEventStream(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan Event, 64)
+	go func() {
+		defer close(ch)
+		delay := reconnectMin
+		for {
+			retry, err := readEvents(ctx, body, ch, &filter.AfterID)
+			body.Close()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if c.OnStreamLost != nil {
+					c.OnStreamLost(err)
+				}
+				retry = delay
+			}
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(retry):
+				}
+				if body, err = c.openEventStream(ctx, filter); err == nil {
+					delay = reconnectMin
+					break
+				}
+				delay = min(2*delay, reconnectMax)
+				retry = delay
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// openEventStream opens the event stream for filter, resuming after
+// filter.AfterID when it is set, and returns the response body.
+func (c *BeadsClient) openEventStream(ctx context.Context, filter EventFilter) (io.ReadCloser, error) {
+	path := "/v1/events/stream"
+	if q := filter.query(); len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if filter.AfterID > 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatInt(filter.AfterID, 10))
+	}
+	resp, err := c.do(req, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("opening event stream: %w", err)
+	}
+	return resp.Body, nil
+}
+
+// errStreamEnded is reported when the server closes an event stream without
+// advising a reconnect.
+var errStreamEnded = errors.New("event stream ended")
+
+// readEvents sends the events read from an event stream to ch, keeping
+// lastID at the highest event ID seen, until the stream ends or ctx is
+// cancelled. When the server advises a reconnect it returns how long to wait
+// first; otherwise it returns why the stream ended.
+func readEvents(ctx context.Context, body io.Reader, ch chan<- Event, lastID *int64) (time.Duration, error) {
+	sc := bufio.NewScanner(body)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	var event, data string
+	var id int64
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "id: "):
+			id, _ = strconv.ParseInt(strings.TrimPrefix(line, "id: "), 10, 64)
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		case line == "":
+			switch event {
+			case "update":
+				if e, ok := decodeUpdate(data, id); ok {
+					select {
+					case ch <- e:
+					case <-ctx.Done():
+						return 0, ctx.Err()
+					}
+				}
+				*lastID = max(*lastID, id)
+			case "reconnect":
+				var advice struct {
+					RetryMS int64 `json:"retry_ms"`
+				}
+				_ = json.Unmarshal([]byte(data), &advice)
+				return time.Duration(advice.RetryMS) * time.Millisecond, nil
+			}
+			event, data, id = "", "", 0
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return 0, err
+	}
+	return 0, errStreamEnded
+}
+
+// decodeUpdate decodes the data of an "update" stream event with the given
+// SSE id: a summary of a bead's events carrying the last of them.
+func decodeUpdate(data string, id int64) (Event, bool) {
+	var u struct {
+		BeadID string   `json:"bead_id"`
+		Count  int      `json:"count"`
+		Topics []string `json:"topics"`
+		Actors []string `json:"actors"`
+		Last   *Event   `json:"last"`
+	}
+	if err := json.Unmarshal([]byte(data), &u); err != nil {
+		return Event{}, false
+	}
+	var e Event
+	if u.Last != nil {
+		e = *u.Last
+	}
+	e.BeadID, e.Count, e.Topics, e.Actors = u.BeadID, u.Count, u.Topics, u.Actors
+	if id > 0 {
+		e.ID = id
+	}
+	return e, true
+}
@@ -0,0 +1,122 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/beadstest"
+	"github.com/alfredjeanlab/beads/client"
+)
+
+// createBead creates a bead through the server's API and returns its ID.
+func createBead(t *testing.T, srv *beadstest.Server, title string) string {
+	t.Helper()
+	body, _ := json.Marshal(map[string]any{"title": title, "type": "task", "created_by": "alice"})
+	resp, err := http.Post(srv.URL+"/v1/beads", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var b struct {
+		ID string `json:"id"`
+	}
+	if resp.StatusCode != http.StatusCreated || json.NewDecoder(resp.Body).Decode(&b) != nil {
+		t.Fatalf("create %q: %s", title, resp.Status)
+	}
+	return b.ID
+}
+
+// next returns the next event from events, failing after a few seconds.
+func next(t *testing.T, events <-chan client.Event) client.Event {
+	t.Helper()
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("event channel closed")
+		}
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event")
+	}
+	return client.Event{}
+}
+
+func TestWatchEvents(t *testing.T) {
+	srv := beadstest.NewServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := client.New(srv.URL)
+	events, err := c.WatchEvents(ctx, client.EventFilter{Topics: []string{"beads.bead.created"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := createBead(t, srv, "Watched")
+	e := next(t, events)
+	if e.BeadID != id || e.Topic != "beads.bead.created" || e.Count != 1 || e.ID == 0 || len(e.Payload) == 0 {
+		t.Fatalf("event = %+v", e)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatchEventsResumes(t *testing.T) {
+	srv := beadstest.NewServer(t)
+	// Streams are closed with a reconnect event every 300ms.
+	srv.Beads.SetStreamTuning(0, 300*time.Millisecond)
+	first := createBead(t, srv, "Before the watch")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var lost atomic.Int32
+	c := client.New(srv.URL)
+	c.OnStreamLost = func(error) { lost.Add(1) }
+	events, err := c.WatchEvents(ctx, client.EventFilter{Topics: []string{"beads.bead.created"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var last int64
+	want := []string{}
+	for i := range 3 {
+		want = append(want, createBead(t, srv, "During the watch"))
+		if i < 2 {
+			time.Sleep(400 * time.Millisecond) // across a reconnect
+		}
+	}
+	for _, id := range want {
+		e := next(t, events)
+		if e.BeadID != id || e.ID <= last {
+			t.Fatalf("event = %+v, want bead %s after event %d", e, id, last)
+		}
+		last = e.ID
+	}
+
+	// A new watch resuming after the first bead's creation replays the rest.
+	events, err = c.WatchEvents(ctx, client.EventFilter{Topics: []string{"beads.bead.created"}, AfterID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := next(t, events); e.BeadID != want[0] {
+		t.Fatalf("resumed at %+v, want the bead after %s", e, first)
+	}
+	if n := lost.Load(); n != 0 {
+		t.Fatalf("%d streams lost, want only advised reconnects", n)
+	}
+}
+
+func TestWatchEventsError(t *testing.T) {
+	srv := beadstest.NewServer(t)
+	_, err := client.New(srv.URL+"/nope").WatchEvents(context.Background(), client.EventFilter{})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("err = %v, want a 404 *APIError", err)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/spf13/cobra"
+)
+
+// actorRecord is an actor as returned by /v1/actors.
+type actorRecord struct {
+	ID          string    `json:"id"`
+	DisplayName string    `json:"display_name,omitempty"`
+	Type        string    `json:"type"`
+	Aliases     []string  `json:"aliases"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+var actorCmd = &cobra.Command{
+	Use:   "actor",
+	Short: "Manage the actor registry",
+	Long: `Registered actors give each person or agent one canonical name. Once
+alice is registered with the alias alice@corp, writes naming "Alice",
+"ALICE" or "alice@corp" are all recorded as alice:
+
+  bd actor add alice --name "Alice Liddell" --alias alice@corp
+  bd actor alias alice a.liddell
+  bd actor list`,
+	GroupID: "system",
+}
+
+// actorPath returns the API path for an actor, escaping "/" in its name.
+func actorPath(id string) string {
+	return "/v1/actors/" + url.PathEscape(id)
+}
+
+// actorRequest sends a request for the actor API and prints the actor it
+// returns, or exits on error.
+func actorRequest(method, path string, body any) {
+	resp, err := httpDo(context.Background(), method, path, bearerTokenFromEnv(), body)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOutput {
+		fmt.Println(string(resp))
+		return
+	}
+	var a actorRecord
+	if err := json.Unmarshal(resp, &a); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+		os.Exit(1)
+	}
+	printActor(a)
+}
+
+func printActor(a actorRecord) {
+	fmt.Printf("ID:       %s\n", a.ID)
+	if a.DisplayName != "" {
+		fmt.Printf("Name:     %s\n", a.DisplayName)
+	}
+	fmt.Printf("Type:     %s\n", a.Type)
+	if len(a.Aliases) > 0 {
+		fmt.Printf("Aliases:  %s\n", strings.Join(a.Aliases, ", "))
+	}
+}
+
+var actorListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered actors",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd actor list", server.FeatureActors)
+		body, err := httpGet(context.Background(), "/v1/actors")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			fmt.Println(string(body))
+			return nil
+		}
+		var resp struct {
+			Actors []actorRecord `json:"actors"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+			os.Exit(1)
+		}
+		if len(resp.Actors) == 0 {
+			fmt.Println("No actors.")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ACTOR\tTYPE\tNAME\tALIASES")
+		for _, a := range resp.Actors {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.ID, a.Type, a.DisplayName, strings.Join(a.Aliases, ", "))
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var actorShowCmd = &cobra.Command{
+	Use:   "show <id-or-alias>",
+	Short: "Show an actor",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		body, err := httpGet(context.Background(), actorPath(args[0]))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			fmt.Println(string(body))
+			return nil
+		}
+		var a actorRecord
+		if err := json.Unmarshal(body, &a); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+			os.Exit(1)
+		}
+		printActor(a)
+		return nil
+	},
+}
+
+var actorAddCmd = &cobra.Command{
+	Use:   "add <id>",
+	Short: "Register an actor",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		typ, _ := cmd.Flags().GetString("type")
+		aliases, _ := cmd.Flags().GetStringSlice("alias")
+		actorRequest(http.MethodPost, "/v1/actors", map[string]any{
+			"id":           args[0],
+			"display_name": name,
+			"type":         typ,
+			"aliases":      aliases,
+			"created_by":   actor,
+		})
+		return nil
+	},
+}
+
+var actorUpdateCmd = &cobra.Command{
+	Use:   "update <id-or-alias>",
+	Short: "Change an actor's display name or type",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req := map[string]any{}
+		if cmd.Flags().Changed("name") {
+			name, _ := cmd.Flags().GetString("name")
+			req["display_name"] = name
+		}
+		if cmd.Flags().Changed("type") {
+			typ, _ := cmd.Flags().GetString("type")
+			req["type"] = typ
+		}
+		if len(req) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: nothing to update; pass --name or --type\n")
+			os.Exit(1)
+		}
+		actorRequest(http.MethodPatch, actorPath(args[0]), req)
+		return nil
+	},
+}
+
+var actorAliasCmd = &cobra.Command{
+	Use:   "alias <id-or-alias> <alias>",
+	Short: "Add an alias to an actor, or remove one with --remove",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if remove, _ := cmd.Flags().GetBool("remove"); remove {
+			actorRequest(http.MethodDelete, actorPath(args[0])+"/aliases/"+url.PathEscape(args[1]), nil)
+			return nil
+		}
+		actorRequest(http.MethodPost, actorPath(args[0])+"/aliases", map[string]any{"alias": args[1]})
+		return nil
+	},
+}
+
+var actorDeleteCmd = &cobra.Command{
+	Use:   "delete <id-or-alias>",
+	Short: "Delete an actor and its aliases",
+	Long: `Deletes an actor and its aliases. History already recorded under its ID
+is unchanged; later writes are recorded under whatever name they give.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := httpDo(context.Background(), http.MethodDelete, actorPath(args[0]), bearerTokenFromEnv(), nil); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Deleted actor %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	actorAddCmd.Flags().String("name", "", "display name")
+	actorAddCmd.Flags().String("type", "human", "human or agent")
+	actorAddCmd.Flags().StringSlice("alias", nil, "other name for the actor (repeatable)")
+	actorUpdateCmd.Flags().String("name", "", "display name")
+	actorUpdateCmd.Flags().String("type", "", "human or agent")
+	actorAliasCmd.Flags().Bool("remove", false, "remove the alias instead of adding it")
+
+	actorCmd.AddCommand(actorListCmd)
+	actorCmd.AddCommand(actorShowCmd)
+	actorCmd.AddCommand(actorAddCmd)
+	actorCmd.AddCommand(actorUpdateCmd)
+	actorCmd.AddCommand(actorAliasCmd)
+	actorCmd.AddCommand(actorDeleteCmd)
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var adminCmd = &cobra.Command{
+	Use:     "admin",
+	Short:   "Server administration (requires the admin token)",
+	GroupID: "system",
+}
+
+var adminSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Write a snapshot of the database to the server's object store",
+	Long: `Asks the server for a consistent logical dump of beads, labels,
+dependencies, comments, events and configs, written to its S3 bucket under
+BEADS_SNAPSHOT_PREFIX. Prints the snapshot's key, which bd admin restore
+takes.
+
+Requires the server's admin token, read from --token or BEADS_ADMIN_TOKEN.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		body, err := httpPost(context.Background(), "/v1/admin/snapshot", adminToken(cmd), struct{}{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		var resp struct {
+			Key   string `json:"key"`
+			Bytes int64  `json:"bytes"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			fmt.Println(string(body))
+			return nil
+		}
+		fmt.Printf("Wrote snapshot %s (%d bytes)\n", resp.Key, resp.Bytes)
+		return nil
+	},
+}
+
+var adminRestoreCmd = &cobra.Command{
+	Use:   "restore <key>",
+	Short: "Restore a snapshot into an empty database",
+	Long: `Loads the snapshot stored under key into the server's database in one
+transaction. The database must have no beads. Beads, comments and events
+keep their IDs and timestamps, and restored events are not republished.
+
+Requires the server's admin token, read from --token or BEADS_ADMIN_TOKEN.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		body, err := httpPost(context.Background(), "/v1/admin/restore", adminToken(cmd), map[string]string{"key": args[0]})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		var resp struct {
+			Restored map[string]int `json:"restored"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			fmt.Println(string(body))
+			return nil
+		}
+		r := resp.Restored
+		fmt.Printf("Restored %s: %d beads, %d labels, %d dependencies, %d comments, %d events, %d configs\n",
+			args[0], r["beads"], r["labels"], r["dependencies"], r["comments"], r["events"], r["configs"])
+		return nil
+	},
+}
+
+var adminCompactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Compact old events into daily summaries",
+	Long: `Asks the server to compact events past their retention now instead of
+waiting for the next scheduled run: each topic's events on a bead are
+counted per day into the event summaries (GET /v1/events/summaries), then
+deleted. Without --older-than-days the server's BEADS_EVENT_RETENTION rules
+apply. With --status, shows those rules and the last run instead.
+
+Requires the server's admin token, read from --token or BEADS_ADMIN_TOKEN.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd admin compact", server.FeatureEventCompaction)
+		if status, _ := cmd.Flags().GetBool("status"); status {
+			return showCompaction(cmd)
+		}
+		path := "/v1/admin/compaction/run"
+		if days, _ := cmd.Flags().GetInt("older-than-days"); days > 0 {
+			path += "?older_than_days=" + strconv.Itoa(days)
+		}
+		body, err := httpPost(context.Background(), path, adminToken(cmd), struct{}{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		var run compactionRun
+		if err := json.Unmarshal(body, &run); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			fmt.Println(string(body))
+			return nil
+		}
+		printCompactionRun(&run)
+		return nil
+	},
+}
+
+// compactionRun is the result of an event compaction run.
+type compactionRun struct {
+	StartedAt  time.Time        `json:"started_at"`
+	FinishedAt time.Time        `json:"finished_at"`
+	Compacted  map[string]int64 `json:"compacted"`
+	Total      int64            `json:"total"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// showCompaction prints the server's retention rules and last compaction.
+func showCompaction(cmd *cobra.Command) error {
+	body, err := httpDo(context.Background(), http.MethodGet, "/v1/admin/compaction", adminToken(cmd), nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	var resp struct {
+		Rules []struct {
+			Topic  string `json:"topic"`
+			MaxAge string `json:"max_age"`
+		} `json:"rules"`
+		LastRun *compactionRun `json:"last_run"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOutput {
+		fmt.Println(string(body))
+		return nil
+	}
+	if len(resp.Rules) == 0 {
+		fmt.Println("No retention policy: every event is kept.")
+	} else {
+		fmt.Println("Retention:")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, r := range resp.Rules {
+			age := r.MaxAge
+			if age == "0s" {
+				age = "forever"
+			}
+			fmt.Fprintf(w, "  %s\t%s\n", r.Topic, age)
+		}
+		w.Flush()
+	}
+	if resp.LastRun == nil {
+		fmt.Println("No compaction has run since the server started.")
+		return nil
+	}
+	fmt.Printf("Last run %s: ", resp.LastRun.StartedAt.Local().Format("2006-01-02 15:04:05"))
+	printCompactionRun(resp.LastRun)
+	return nil
+}
+
+// printCompactionRun prints how many events a run compacted, by rule.
+func printCompactionRun(run *compactionRun) {
+	fmt.Printf("compacted %d events\n", run.Total)
+	topics := slices.Sorted(maps.Keys(run.Compacted))
+	for _, topic := range topics {
+		if n := run.Compacted[topic]; n > 0 {
+			fmt.Printf("  %s: %d\n", topic, n)
+		}
+	}
+	if run.Error != "" {
+		fmt.Printf("Stopped early: %s\n", run.Error)
+	}
+}
+
+// adminToken returns --token, falling back to BEADS_ADMIN_TOKEN.
+func adminToken(cmd *cobra.Command) string {
+	if token, _ := cmd.Flags().GetString("token"); token != "" {
+		return token
+	}
+	return os.Getenv("BEADS_ADMIN_TOKEN")
+}
+
+func init() {
+	adminCmd.PersistentFlags().String("token", "", "admin token (default $BEADS_ADMIN_TOKEN)")
+	adminCmd.AddCommand(adminSnapshotCmd)
+	adminCmd.AddCommand(adminRestoreCmd)
+	adminCompactCmd.Flags().Int("older-than-days", 0, "compact events of every topic older than this instead of applying the retention rules")
+	adminCompactCmd.Flags().Bool("status", false, "show the retention rules and last run instead of compacting")
+	adminCmd.AddCommand(adminCompactCmd)
+}
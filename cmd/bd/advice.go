@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var adviceCmd = &cobra.Command{
+	Use:   "advice",
+	Short: "Show advice you have not acknowledged",
+	Long: `Advice beads are standing guidance for agents, such as "run make lint before
+pushing". Without a subcommand, prints the open advice the actor has not
+acknowledged and that has not passed its expires_at; --all prints every open
+advice bead. Acknowledge advice with bd advice ack once it has been read.`,
+	GroupID: "views",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		raw, _ := cmd.Flags().GetBool("raw")
+		resp, err := client.ListAdvice(context.Background(), &beadsv1.ListAdviceRequest{Actor: actor, All: all})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			data, _ := json.MarshalIndent(resp.GetAdvice(), "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		if len(resp.GetAdvice()) == 0 {
+			fmt.Println("No new advice.")
+			return nil
+		}
+		for i, b := range resp.GetAdvice() {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s  %s\n", b.GetId(), b.GetTitle())
+			d := strings.TrimSpace(b.GetDescription())
+			switch {
+			case d == "":
+			case raw:
+				fmt.Println("  " + strings.ReplaceAll(d, "\n", "\n  "))
+			default:
+				fmt.Println(renderMarkdown(d, "  "))
+			}
+		}
+		return nil
+	},
+}
+
+var adviceAddCmd = &cobra.Command{
+	Use:   "add <title>",
+	Short: "Create an advice bead",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		description, _ := cmd.Flags().GetString("description")
+		expires, _ := cmd.Flags().GetString("expires")
+
+		req := &beadsv1.CreateBeadRequest{
+			Title:       args[0],
+			Type:        "advice",
+			Description: description,
+			CreatedBy:   actor,
+		}
+		if expires != "" {
+			at, err := parseAdviceExpiry(expires, time.Now())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			req.Fields, _ = json.Marshal(map[string]string{"expires_at": at.UTC().Format(time.RFC3339)})
+		}
+
+		resp, err := client.CreateBead(context.Background(), req)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			data, _ := json.MarshalIndent(resp.GetBead(), "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		fmt.Printf("Created advice %s\n", resp.GetBead().GetId())
+		return nil
+	},
+}
+
+// parseAdviceExpiry accepts an RFC 3339 time or a duration from now, such
+// as 72h.
+func parseAdviceExpiry(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --expires %q: want a duration such as 72h or an RFC 3339 time", s)
+	}
+	return t, nil
+}
+
+var adviceAckCmd = &cobra.Command{
+	Use:   "ack <id>...",
+	Short: "Acknowledge advice so it is no longer shown",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, id := range args {
+			if _, err := client.AckAdvice(context.Background(), &beadsv1.AckAdviceRequest{BeadId: id, Actor: actor}); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if !jsonOutput {
+				fmt.Printf("Acknowledged %s\n", id)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	adviceCmd.Flags().Bool("all", false, "include acknowledged and expired advice")
+	adviceCmd.Flags().Bool("raw", false, "print advice text without rendering Markdown")
+	adviceAddCmd.Flags().String("description", "", "advice text")
+	adviceAddCmd.Flags().String("expires", "", "expiry: a duration such as 72h, or an RFC 3339 time")
+	adviceCmd.AddCommand(adviceAddCmd)
+	adviceCmd.AddCommand(adviceAckCmd)
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/metadata"
+)
+
+var agentCmd = &cobra.Command{
+	Use:     "agent",
+	Short:   "Manage agent identities",
+	GroupID: "system",
+}
+
+var agentRegisterCmd = &cobra.Command{
+	Use:   "register --name <name>",
+	Short: "Register an agent and print its credentials",
+	Long: `Provisions an agent bead, its gates and a bearer token in one step, then
+prints shell exports for the new identity:
+
+  eval "$(bd agent register --name crew/test-agent)"
+
+Registration requires the server's admin or bootstrap token, read from
+--token or BEADS_BOOTSTRAP_TOKEN. The agent token is only shown once.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		if name == "" {
+			fmt.Fprintln(os.Stderr, "Error: --name is required")
+			os.Exit(1)
+		}
+		token, _ := cmd.Flags().GetString("token")
+		if token == "" {
+			token = os.Getenv("BEADS_BOOTSTRAP_TOKEN")
+		}
+		subscriptions, _ := cmd.Flags().GetStringSlice("subscribe")
+		gates, _ := cmd.Flags().GetStringSlice("gate")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		}
+		resp, err := client.RegisterAgent(ctx, &beadsv1.RegisterAgentRequest{
+			Name:          name,
+			Subscriptions: subscriptions,
+			Gates:         gates,
+			Labels:        labels,
+			CreatedBy:     actor,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			data, err := json.MarshalIndent(map[string]any{
+				"agent":  resp.GetAgent(),
+				"gates":  resp.GetGates(),
+				"token":  resp.GetToken(),
+				"env":    resp.GetEnv(),
+				"server": serverAddr,
+			}, "", "  ")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Fprintf(os.Stderr, "Registered agent %s (%s)\n", name, resp.GetAgent().GetId())
+		for _, g := range resp.GetGates() {
+			fmt.Fprintf(os.Stderr, "  gate %s  %s\n", g.GetId(), g.GetTitle())
+		}
+		fmt.Printf("export BEADS_SERVER='%s'\n", serverAddr)
+		fmt.Print(resp.GetExports())
+		return nil
+	},
+}
+
+var agentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered agents and the beads they hold",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.ListAgents(context.Background(), &beadsv1.ListAgentsRequest{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			printJSON(resp.GetAgents())
+			return nil
+		}
+		if len(resp.GetAgents()) == 0 {
+			fmt.Println("No agents.")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "AGENT\tROLE\tSTATUS\tBEAD\tHELD")
+		for _, a := range resp.GetAgents() {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", a.GetName(), a.GetRole(), a.GetStatus(), a.GetBeadId(), strings.Join(a.GetHeld(), ", "))
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var agentHookTokenCmd = &cobra.Command{
+	Use:   "hook-token <name>",
+	Short: "Issue or revoke the token an agent's hooks must carry",
+	Long: `Issues a hook token bound to the agent and prints its shell export:
+
+  eval "$(bd agent hook-token crew/test-agent)"
+
+Once an agent has a hook token, hooks emitted for it (bd gate check,
+POST /v1/hooks/emit) are only accepted with that token, so nobody else can
+report its presence or pass its gates. Issuing again replaces the token;
+--revoke removes it. The agent's own token or the admin token is required,
+read from --token, BEADS_ADMIN_TOKEN or BEADS_TOKEN.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd agent hook-token", server.FeatureHookTokens)
+		token, _ := cmd.Flags().GetString("token")
+		if token == "" {
+			token = os.Getenv("BEADS_ADMIN_TOKEN")
+		}
+		if token == "" {
+			token = bearerTokenFromEnv()
+		}
+		path := "/v1/agents/" + url.PathEscape(args[0]) + "/hook-token"
+
+		if revoke, _ := cmd.Flags().GetBool("revoke"); revoke {
+			if _, err := httpDo(context.Background(), http.MethodDelete, path, token, nil); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "Revoked the hook token of %s\n", args[0])
+			return nil
+		}
+
+		body, err := httpDo(context.Background(), http.MethodPost, path, token, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		var grant struct {
+			Agent   string `json:"agent"`
+			BeadID  string `json:"bead_id"`
+			Token   string `json:"token"`
+			Exports string `json:"exports"`
+		}
+		if err := json.Unmarshal(body, &grant); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			printJSON(grant)
+			return nil
+		}
+		fmt.Fprintf(os.Stderr, "Issued a hook token for %s (%s)\n", grant.Agent, grant.BeadID)
+		fmt.Print(grant.Exports)
+		return nil
+	},
+}
+
+func init() {
+	agentRegisterCmd.Flags().String("name", "", "agent name, e.g. crew/test-agent (required)")
+	agentRegisterCmd.Flags().String("token", "", "admin or bootstrap token (default $BEADS_BOOTSTRAP_TOKEN)")
+	agentRegisterCmd.Flags().StringSlice("subscribe", nil, "event topics to subscribe to (repeatable)")
+	agentRegisterCmd.Flags().StringSlice("gate", nil, "gate to create, blocking the agent until closed (repeatable)")
+	agentRegisterCmd.Flags().StringSlice("label", nil, "label for the agent bead (repeatable)")
+
+	agentHookTokenCmd.Flags().String("token", "", "the agent's or the admin token (default $BEADS_ADMIN_TOKEN, then $BEADS_TOKEN)")
+	agentHookTokenCmd.Flags().Bool("revoke", false, "remove the hook token instead of issuing one")
+
+	agentCmd.AddCommand(agentRegisterCmd)
+	agentCmd.AddCommand(agentListCmd)
+	agentCmd.AddCommand(agentHookTokenCmd)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias <bead-id> [name]",
+	Short: "Give a bead a hand-picked alias, or list its aliases",
+	Long: `Aliases, like the slug each bead gets from its title, are accepted
+anywhere a bead ID is:
+
+  bd alias kd-a1b2 login
+  bd show login
+  bd alias login --remove login
+
+Without a name, lists the bead's aliases.`,
+	GroupID: "beads",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		remove, _ := cmd.Flags().GetBool("remove")
+		ctx := context.Background()
+
+		if len(args) == 1 {
+			if remove {
+				fmt.Fprintf(os.Stderr, "Error: --remove needs an alias name\n")
+				os.Exit(1)
+			}
+			resp, err := client.ListAliases(ctx, &beadsv1.ListAliasesRequest{BeadId: args[0]})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if jsonOutput {
+				printJSON(resp.GetAliases())
+				return nil
+			}
+			if len(resp.GetAliases()) == 0 {
+				fmt.Println("No aliases.")
+				return nil
+			}
+			for _, a := range resp.GetAliases() {
+				fmt.Println(a.GetAlias())
+			}
+			return nil
+		}
+
+		if remove {
+			if _, err := client.RemoveAlias(ctx, &beadsv1.RemoveAliasRequest{BeadId: args[0], Alias: args[1]}); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Removed alias %s from %s\n", args[1], args[0])
+			return nil
+		}
+
+		resp, err := client.AddAlias(ctx, &beadsv1.AddAliasRequest{
+			BeadId:    args[0],
+			Alias:     args[1],
+			CreatedBy: actor,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			printJSON(resp.GetAlias())
+		} else {
+			fmt.Printf("%s is now also %s\n", resp.GetAlias().GetBeadId(), resp.GetAlias().GetAlias())
+		}
+		return nil
+	},
+}
+
+func init() {
+	aliasCmd.Flags().Bool("remove", false, "remove the alias instead of adding it")
+}
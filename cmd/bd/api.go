@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var apiCmd = &cobra.Command{
+	Use:     "api",
+	Short:   "Inspect the server's HTTP API",
+	GroupID: "system",
+}
+
+var apiDocsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Print the OpenAPI document for the HTTP API",
+	Long: `Print the OpenAPI 3 document served at /v1/openapi.json.
+
+With --local the document built into this client is printed instead of
+fetching it from the server.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		local, _ := cmd.Flags().GetBool("local")
+
+		spec := server.OpenAPISpec
+		if !local {
+			var err error
+			spec, err = fetchOpenAPI(context.Background())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		var out bytes.Buffer
+		if err := json.Indent(&out, spec, "", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid OpenAPI document: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(out.String())
+		return nil
+	},
+}
+
+func init() {
+	apiDocsCmd.Flags().Bool("local", false, "print the document built into this client")
+	apiCmd.AddCommand(apiDocsCmd)
+}
+
+// fetchOpenAPI downloads the server's OpenAPI document.
+func fetchOpenAPI(ctx context.Context) ([]byte, error) {
+	spec, err := httpGet(ctx, "/v1/openapi.json")
+	if err != nil {
+		return nil, fmt.Errorf("fetching OpenAPI document: %w", err)
+	}
+	return spec, nil
+}
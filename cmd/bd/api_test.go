@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/server"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFetchOpenAPI(t *testing.T) {
+	var gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"openapi":"3.0.3"}`))
+	}))
+	defer ts.Close()
+	t.Setenv("BEADS_HTTP_URL", ts.URL)
+
+	spec, err := fetchOpenAPI(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/v1/openapi.json" || string(spec) != `{"openapi":"3.0.3"}` {
+		t.Fatalf("path %q, spec %s", gotPath, spec)
+	}
+
+	ts.Config.Handler = http.NotFoundHandler()
+	if _, err := fetchOpenAPI(context.Background()); err == nil {
+		t.Fatal("expected an error for a server without the document")
+	}
+}
+
+func TestHTTPGet_ServerError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"bead bd-x not found in graph","code":"bead_not_found"}`))
+	}))
+	defer ts.Close()
+	t.Setenv("BEADS_HTTP_URL", ts.URL)
+
+	_, err := httpGet(context.Background(), "/v1/export/graph?root=bd-x")
+	if err == nil || !strings.Contains(err.Error(), "bead bd-x not found in graph") {
+		t.Fatalf("err = %v, want the server's message", err)
+	}
+	if code := errorCode(err); code != server.CodeBeadNotFound {
+		t.Errorf("code = %q, want %s", code, server.CodeBeadNotFound)
+	}
+}
+
+func TestErrorCode_GRPC(t *testing.T) {
+	st, _ := status.New(codes.FailedPrecondition, "dependency cycle").WithDetails(&errdetails.ErrorInfo{
+		Reason: "DEPENDENCY_CYCLE", Domain: "beads",
+	})
+	if code := errorCode(st.Err()); code != server.CodeDependencyCycle {
+		t.Errorf("code = %q, want %s", code, server.CodeDependencyCycle)
+	}
+	if code := errorCode(status.Error(codes.Internal, "boom")); code != "" {
+		t.Errorf("code without details = %q", code)
+	}
+}
+
+func TestGetServerVersion(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"version":"v1.5.0","features":["prefs","transactions"]}`))
+	}))
+	defer ts.Close()
+	t.Setenv("BEADS_HTTP_URL", ts.URL)
+
+	v, err := getServerVersion(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Version != "v1.5.0" || len(v.Features) != 2 || v.Features[1] != server.FeatureTransactions {
+		t.Fatalf("got %+v", v)
+	}
+
+	// A server predating /v1/version supports no features.
+	ts.Config.Handler = http.NotFoundHandler()
+	v, err = getServerVersion(context.Background())
+	if err != nil || v.Version != "" || len(v.Features) != 0 {
+		t.Fatalf("old server: got %+v, %v", v, err)
+	}
+}
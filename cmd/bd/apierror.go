@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// APIError is an error response from the server's HTTP API.
+type APIError struct {
+	Status  string         `json:"-"`     // e.g. "404 Not Found"
+	Message string         `json:"error"` // human-readable
+	Code    string         `json:"code"`  // machine-readable, e.g. "bead_not_found"
+	Details map[string]any `json:"details,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return e.Status
+	}
+	return e.Status + ": " + e.Message
+}
+
+// errorCode returns the server's error code for err: the Code of an
+// *APIError, or the lower-cased reason of a gRPC ErrorInfo from the beads
+// domain. It returns "" for errors that carry no code.
+func errorCode(err error) string {
+	var ae *APIError
+	if errors.As(err, &ae) {
+		return ae.Code
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.GetDomain() == "beads" {
+			return strings.ToLower(info.GetReason())
+		}
+	}
+	return ""
+}
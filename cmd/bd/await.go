@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	beadsclient "github.com/alfredjeanlab/beads/client"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of bd await other than 0 (reached) and 1 (error).
+const (
+	awaitExitTimeout = 2
+	awaitExitClosed  = 3
+	awaitExitDeleted = 4
+)
+
+var awaitCmd = &cobra.Command{
+	Use:     "await <id>",
+	Short:   "Block until a bead reaches a status",
+	GroupID: "workflow",
+	Long: `Block until a bead reaches one of the given statuses (closed by default),
+so scripts and CI steps can wait on upstream work instead of sleeping in a
+loop. Changes are followed on the server's event stream; if it cannot be
+opened, the bead is polled every --interval.
+
+Exit codes:
+  0  the bead reached one of the statuses
+  1  an error occurred
+  2  --timeout passed first
+  3  the bead was closed without reaching the statuses
+  4  the bead was deleted`,
+	Example: `  bd await bd-a1b2 && make deploy
+  bd await bd-a1b2 --status in_progress,closed --timeout 30m`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		statuses, _ := cmd.Flags().GetStringSlice("status")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		ctx := context.Background()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		res, err := awaitBead(ctx, args[0], statuses, interval)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			printJSON(res)
+		} else {
+			fmt.Println(res.message(args[0]))
+		}
+		if res.code != 0 {
+			os.Exit(res.code)
+		}
+		return nil
+	},
+}
+
+// awaitResult is the outcome of awaitBead. Status is empty once the bead
+// is deleted or was never seen before the timeout.
+type awaitResult struct {
+	ID      string `json:"id"`
+	Status  string `json:"status,omitempty"`
+	Outcome string `json:"outcome"` // reached, timeout, closed or deleted
+	code    int
+}
+
+func (r *awaitResult) message(ref string) string {
+	id := r.ID
+	if id == "" {
+		id = ref
+	}
+	switch r.Outcome {
+	case "reached":
+		return fmt.Sprintf("%s is %s", id, r.Status)
+	case "timeout":
+		return fmt.Sprintf("Timed out waiting for %s (status %s)", id, r.Status)
+	case "closed":
+		return fmt.Sprintf("%s was closed", id)
+	}
+	return fmt.Sprintf("%s was deleted", id)
+}
+
+// awaitBead waits until bead ref has one of statuses, it is closed or
+// deleted, or ctx is done, which is a timeout rather than an error. The
+// bead is re-read after each change seen on the event stream, or every
+// interval when the stream cannot be opened.
+func awaitBead(ctx context.Context, ref string, statuses []string, interval time.Duration) (*awaitResult, error) {
+	if len(statuses) == 0 {
+		statuses = []string{"closed"}
+	}
+	res := &awaitResult{}
+	check := func() (bool, error) {
+		status, err := fetchBeadStatus(ctx, ref, res)
+		switch {
+		case err != nil:
+			return false, err
+		case status == "":
+			res.Outcome, res.code = "deleted", awaitExitDeleted
+		case slices.Contains(statuses, status):
+			res.Outcome = "reached"
+		case status == "closed":
+			res.Outcome, res.code = "closed", awaitExitClosed
+		default:
+			return false, nil
+		}
+		return true, nil
+	}
+	timedOut := func() (*awaitResult, error) {
+		res.Outcome, res.code = "timeout", awaitExitTimeout
+		return res, nil
+	}
+
+	// Resolve the bead before subscribing, so the stream can be narrowed to
+	// its ID, then check again once subscribed so no change is missed.
+	if done, err := check(); done || err != nil {
+		if ctx.Err() != nil {
+			return timedOut()
+		}
+		return res, err
+	}
+	updates, err := streamUpdates(ctx, beadsclient.EventFilter{BeadID: res.ID})
+	if err != nil {
+		if ctx.Err() != nil {
+			return timedOut()
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v; polling every %s\n", err, interval)
+	}
+	var tick <-chan time.Time
+	if updates == nil {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		tick = t.C
+	}
+	for {
+		done, err := check()
+		if ctx.Err() != nil {
+			return timedOut()
+		}
+		if done || err != nil {
+			return res, err
+		}
+		select {
+		case <-ctx.Done():
+			return timedOut()
+		case <-tick:
+		case _, ok := <-updates:
+			if !ok {
+				return timedOut()
+			}
+		}
+	}
+}
+
+// fetchBeadStatus returns the status of bead ref, recording its ID and
+// status in res, or "" if the bead does not exist.
+func fetchBeadStatus(ctx context.Context, ref string, res *awaitResult) (string, error) {
+	body, err := httpGet(ctx, "/v1/beads/"+url.PathEscape(ref))
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && strings.HasPrefix(apiErr.Status, "404") {
+		if res.ID == "" {
+			return "", fmt.Errorf("bead %s not found", ref)
+		}
+		res.Status = ""
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	var bead struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &bead); err != nil {
+		return "", fmt.Errorf("decoding bead: %w", err)
+	}
+	res.ID, res.Status = bead.ID, bead.Status
+	return bead.Status, nil
+}
+
+func init() {
+	awaitCmd.Flags().StringSlice("status", []string{"closed"}, "statuses to wait for (repeatable or comma-separated)")
+	awaitCmd.Flags().Duration("timeout", 10*time.Minute, "give up after this long (0 waits forever)")
+	awaitCmd.Flags().Duration("interval", 5*time.Second, "polling interval when the event stream is unavailable")
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// awaitServer serves bead bd-1 with the given statuses in turn, repeating
+// the last; "" answers 404. The event stream sends an update for each
+// status change, or is unavailable when stream is false.
+func awaitServer(t *testing.T, stream bool, statuses ...string) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	n := 0
+	changed := make(chan struct{}, len(statuses))
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/beads/{id}", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		status := statuses[min(n, len(statuses)-1)]
+		n++
+		mu.Unlock()
+		if status == "" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"bead not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"id":"bd-1","slug":"fix-it","status":%q}`, status)
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	mux.HandleFunc("GET /v1/events/stream", func(w http.ResponseWriter, r *http.Request) {
+		if !stream {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("bead_id"); got != "bd-1" {
+			t.Errorf("stream bead_id = %q, want bd-1", got)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: ready\ndata: {}\n\n")
+		w.(http.Flusher).Flush()
+		for id := 1; ; id++ {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-changed:
+			}
+			fmt.Fprintf(w, "event: update\nid: %d\ndata: {\"bead_id\":\"bd-1\",\"count\":1}\n\n", id)
+			w.(http.Flusher).Flush()
+		}
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestAwaitBead(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		stream   bool
+		statuses []string
+		want     []string
+		outcome  string
+		code     int
+	}{
+		{"already closed", true, []string{"closed"}, nil, "reached", 0},
+		{"stream", true, []string{"open", "open", "in_progress", "closed"}, nil, "reached", 0},
+		{"poll", false, []string{"open", "in_progress"}, []string{"in_progress"}, "reached", 0},
+		{"closed instead", true, []string{"open", "open", "closed"}, []string{"in_progress"}, "closed", awaitExitClosed},
+		{"deleted", false, []string{"open", ""}, nil, "deleted", awaitExitDeleted},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("BEADS_HTTP_URL", awaitServer(t, tc.stream, tc.statuses...).URL)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			res, err := awaitBead(ctx, "fix-it", tc.want, 10*time.Millisecond)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Outcome != tc.outcome || res.code != tc.code || res.ID != "bd-1" {
+				t.Fatalf("result = %+v, want %s (%d)", res, tc.outcome, tc.code)
+			}
+		})
+	}
+}
+
+func TestAwaitBead_Timeout(t *testing.T) {
+	t.Setenv("BEADS_HTTP_URL", awaitServer(t, true, "open").URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	res, err := awaitBead(ctx, "bd-1", nil, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Outcome != "timeout" || res.code != awaitExitTimeout || res.Status != "open" {
+		t.Fatalf("result = %+v", res)
+	}
+}
+
+func TestAwaitBead_NotFound(t *testing.T) {
+	t.Setenv("BEADS_HTTP_URL", awaitServer(t, true, "").URL)
+	if _, err := awaitBead(context.Background(), "bd-nope", nil, time.Second); err == nil {
+		t.Fatal("want an error for a missing bead")
+	}
+}
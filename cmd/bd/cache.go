@@ -0,0 +1,652 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"syscall"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// beadCache is a local copy of the beads the CLI has seen, with a queue of
+// mutations made while offline. Each server gets its own cache directory.
+type beadCache struct {
+	dir string
+}
+
+// cachedBeads is the on-disk bead snapshot.
+type cachedBeads struct {
+	SyncedAt time.Time                `json:"synced_at"`
+	Beads    map[string]*beadsv1.Bead `json:"beads"`
+}
+
+// pendingOp is a mutation queued while the server was unreachable. Queued
+// creates carry an idempotency key, so replaying one that already reached
+// the server returns the bead it created instead of a second one.
+type pendingOp struct {
+	Op       string                     `json:"op"` // only "create" today
+	QueuedAt time.Time                  `json:"queued_at"`
+	Create   *beadsv1.CreateBeadRequest `json:"create,omitempty"`
+}
+
+// syncConflict is a queued mutation the server rejected on replay.
+type syncConflict struct {
+	pendingOp
+	Error      string    `json:"error"`
+	RejectedAt time.Time `json:"rejected_at"`
+}
+
+const (
+	cacheBeadsFile     = "beads.json"
+	cachePendingFile   = "pending.jsonl"
+	cacheConflictsFile = "conflicts.jsonl"
+
+	// cacheQueueLock guards reading and rewriting the queue; cacheSyncLock
+	// is held for a whole replay so two bd processes never replay at once.
+	cacheQueueLock = "pending.lock"
+	cacheSyncLock  = "sync.lock"
+)
+
+// errSyncBusy means another bd process is replaying the queue.
+var errSyncBusy = errors.New("another bd process is syncing the cache")
+
+var unsafeCacheChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// openCache returns the cache for server, under
+// ~/.local/state/beads/cache. Its directory is created on first write.
+func openCache(server string) (*beadCache, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(home, ".local", "state", "beads", "cache", unsafeCacheChars.ReplaceAllString(server, "_"))
+	return &beadCache{dir: dir}, nil
+}
+
+// isUnreachable reports whether err means the server could not be reached,
+// as opposed to the server answering with an error. A request that timed out
+// may still have been applied; queued creates are safe to replay anyway
+// because of their idempotency keys.
+func isUnreachable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+func (c *beadCache) path(name string) string { return filepath.Join(c.dir, name) }
+
+// load reads the bead snapshot; a missing snapshot is empty.
+func (c *beadCache) load() (cachedBeads, error) {
+	snap := cachedBeads{Beads: map[string]*beadsv1.Bead{}}
+	data, err := os.ReadFile(c.path(cacheBeadsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return snap, nil
+	}
+	if err != nil {
+		return snap, err
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return snap, fmt.Errorf("reading cache: %w", err)
+	}
+	if snap.Beads == nil {
+		snap.Beads = map[string]*beadsv1.Bead{}
+	}
+	return snap, nil
+}
+
+// put merges beads into the snapshot and marks it synced now.
+func (c *beadCache) put(beads ...*beadsv1.Bead) error {
+	snap, err := c.load()
+	if err != nil {
+		return err
+	}
+	for _, b := range beads {
+		snap.Beads[b.GetId()] = b
+	}
+	snap.SyncedAt = time.Now().UTC()
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.path(cacheBeadsFile), data)
+}
+
+// get returns a cached bead, or nil if it was never seen.
+func (c *beadCache) get(id string) (*beadsv1.Bead, time.Time, error) {
+	snap, err := c.load()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return snap.Beads[id], snap.SyncedAt, nil
+}
+
+// list applies req's filters to the cached beads the way the server would,
+// newest first, and returns the page and the total match count. Sort keys
+// are not applied.
+func (c *beadCache) list(req *beadsv1.ListBeadsRequest) ([]*beadsv1.Bead, int32, time.Time, error) {
+	snap, err := c.load()
+	if err != nil {
+		return nil, 0, time.Time{}, err
+	}
+	var matched []*beadsv1.Bead
+	for _, b := range snap.Beads {
+		if cachedBeadMatches(b, req) {
+			matched = append(matched, b)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		ti, tj := matched[i].GetCreatedAt().AsTime(), matched[j].GetCreatedAt().AsTime()
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return matched[i].GetId() < matched[j].GetId()
+	})
+
+	total := int32(len(matched))
+	if off := int(req.GetOffset()); off < len(matched) {
+		matched = matched[off:]
+	} else {
+		matched = nil
+	}
+	if limit := int(req.GetLimit()); limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, total, snap.SyncedAt, nil
+}
+
+func cachedBeadMatches(b *beadsv1.Bead, req *beadsv1.ListBeadsRequest) bool {
+	// The query language is only evaluated by the server.
+	if req.GetQuery() != "" {
+		return false
+	}
+	if b.GetArchivedAt() != nil && !req.GetIncludeArchived() {
+		return false
+	}
+	if len(req.GetStatus()) > 0 && !slices.Contains(req.GetStatus(), b.GetStatus()) {
+		return false
+	}
+	if len(req.GetType()) > 0 && !slices.Contains(req.GetType(), b.GetType()) {
+		return false
+	}
+	if len(req.GetKind()) > 0 && !slices.Contains(req.GetKind(), b.GetKind()) {
+		return false
+	}
+	if req.GetAssignee() != "" && b.GetAssignee() != req.GetAssignee() {
+		return false
+	}
+	for _, l := range req.GetLabels() {
+		if !slices.Contains(b.GetLabels(), l) {
+			return false
+		}
+	}
+	for _, r := range []struct{ at, after, before *timestamppb.Timestamp }{
+		{b.GetCreatedAt(), req.GetCreatedAfter(), req.GetCreatedBefore()},
+		{b.GetUpdatedAt(), req.GetUpdatedAfter(), req.GetUpdatedBefore()},
+		{b.GetClosedAt(), req.GetClosedAfter(), req.GetClosedBefore()},
+	} {
+		if r.after == nil && r.before == nil {
+			continue
+		}
+		if r.at == nil ||
+			r.after != nil && r.at.AsTime().Before(r.after.AsTime()) ||
+			r.before != nil && !r.at.AsTime().Before(r.before.AsTime()) {
+			return false
+		}
+	}
+	if len(req.GetFieldFilters()) > 0 {
+		var fields map[string]any
+		if err := json.Unmarshal(b.GetFields(), &fields); err != nil {
+			return false
+		}
+		for k, want := range req.GetFieldFilters() {
+			if v, ok := fields[k]; !ok || fmt.Sprint(v) != want {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// lock takes an exclusive lock on the named lock file in the cache
+// directory, failing with errSyncBusy instead of waiting if wait is false.
+func (c *beadCache) lock(name string, wait bool) (unlock func(), err error) {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(c.path(name), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	how := syscall.LOCK_EX
+	if !wait {
+		how |= syscall.LOCK_NB
+	}
+	if err := syscall.Flock(int(f.Fd()), how); err != nil {
+		f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, errSyncBusy
+		}
+		return nil, err
+	}
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// queue appends a mutation to the pending queue, giving a create an
+// idempotency key if it has none.
+func (c *beadCache) queue(op pendingOp) error {
+	if op.Create != nil && op.Create.GetIdempotencyKey() == "" {
+		key, err := newIdempotencyKey()
+		if err != nil {
+			return err
+		}
+		op.Create.IdempotencyKey = key
+	}
+	unlock, err := c.lock(cacheQueueLock, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return appendJSONLine(c.path(cachePendingFile), op)
+}
+
+// keyedPending returns the queued mutations, first giving any create queued
+// without an idempotency key (by an older bd) one and saving it, so every
+// replay of it sends the same key.
+func (c *beadCache) keyedPending() ([]pendingOp, error) {
+	unlock, err := c.lock(cacheQueueLock, true)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	ops, err := c.pending()
+	if err != nil {
+		return nil, err
+	}
+	keyed := false
+	for _, op := range ops {
+		if op.Create != nil && op.Create.GetIdempotencyKey() == "" {
+			if op.Create.IdempotencyKey, err = newIdempotencyKey(); err != nil {
+				return nil, err
+			}
+			keyed = true
+		}
+	}
+	if keyed {
+		if err := writeJSONLines(c.path(cachePendingFile), ops); err != nil {
+			return nil, err
+		}
+	}
+	return ops, nil
+}
+
+// dequeue removes replayed mutations from the queue, keeping any queued
+// since replay began.
+func (c *beadCache) dequeue(replayed []pendingOp) error {
+	unlock, err := c.lock(cacheQueueLock, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	ops, err := c.pending()
+	if err != nil {
+		return err
+	}
+	done := make(map[string]bool, len(replayed))
+	for _, op := range replayed {
+		done[op.Create.GetIdempotencyKey()] = true
+	}
+	ops = slices.DeleteFunc(ops, func(op pendingOp) bool {
+		return done[op.Create.GetIdempotencyKey()]
+	})
+	return writeJSONLines(c.path(cachePendingFile), ops)
+}
+
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// pending returns the queued mutations, oldest first.
+func (c *beadCache) pending() ([]pendingOp, error) {
+	return readJSONLines[pendingOp](c.path(cachePendingFile))
+}
+
+// conflicts returns the mutations the server rejected on replay.
+func (c *beadCache) conflicts() ([]syncConflict, error) {
+	return readJSONLines[syncConflict](c.path(cacheConflictsFile))
+}
+
+// sync replays the queued mutations in order through create. A rejected
+// mutation becomes a conflict and replay moves on; losing the server stops
+// replay and keeps the rest queued. It returns the beads created and the
+// new conflicts, or errSyncBusy if another process is already replaying.
+func (c *beadCache) sync(ctx context.Context, create func(context.Context, *beadsv1.CreateBeadRequest) (*beadsv1.Bead, error)) ([]*beadsv1.Bead, []syncConflict, error) {
+	unlock, err := c.lock(cacheSyncLock, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+	ops, err := c.keyedPending()
+	if err != nil || len(ops) == 0 {
+		return nil, nil, err
+	}
+
+	var created []*beadsv1.Bead
+	var rejected []syncConflict
+	done := 0
+	var syncErr error
+	for _, op := range ops {
+		var b *beadsv1.Bead
+		err := fmt.Errorf("unknown queued operation %q", op.Op)
+		if op.Op == "create" {
+			b, err = create(ctx, op.Create)
+		}
+		if isUnreachable(err) {
+			syncErr = err
+			break
+		}
+		done++
+		if err != nil {
+			rejected = append(rejected, syncConflict{pendingOp: op, Error: err.Error(), RejectedAt: time.Now().UTC()})
+			continue
+		}
+		created = append(created, b)
+	}
+
+	// Drop the replayed mutations first so a later failure cannot replay
+	// them twice.
+	if err := c.dequeue(ops[:done]); err != nil {
+		return created, rejected, err
+	}
+	for _, conflict := range rejected {
+		if err := appendJSONLine(c.path(cacheConflictsFile), conflict); err != nil {
+			return created, rejected, err
+		}
+	}
+	if len(created) > 0 {
+		if err := c.put(created...); err != nil {
+			return created, rejected, err
+		}
+	}
+	return created, rejected, syncErr
+}
+
+// clear removes the snapshot, the queue and the conflicts.
+func (c *beadCache) clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func appendJSONLine(path string, v any) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeJSONLines[T any](path string, items []T) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, it := range items {
+		if err := enc.Encode(it); err != nil {
+			return err
+		}
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+func readJSONLines[T any](path string) ([]T, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var items []T
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var it T
+		if err := json.Unmarshal(sc.Bytes(), &it); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+		}
+		items = append(items, it)
+	}
+	return items, sc.Err()
+}
+
+// cacheBeads records beads the server returned; the cache is best effort.
+func cacheBeads(beads ...*beadsv1.Bead) {
+	if len(beads) == 0 {
+		return
+	}
+	if c, err := openCache(serverAddr); err == nil {
+		_ = c.put(beads...)
+	}
+}
+
+// warnCached tells the user they are looking at cached data.
+func warnCached(err error, syncedAt time.Time) {
+	when := "never"
+	if !syncedAt.IsZero() {
+		when = syncedAt.Local().Format("2006-01-02 15:04")
+	}
+	fmt.Fprintf(os.Stderr, "Warning: server unreachable (%s); showing cached beads as of %s\n", status.Convert(err).Message(), when)
+}
+
+// autoSyncTimeout bounds the replay attempted before each command.
+const autoSyncTimeout = 5 * time.Second
+
+// autoSync replays queued mutations before a command runs, if there are any.
+// An unreachable server is not an error; the queue waits for the next run.
+func autoSync() {
+	c, err := openCache(serverAddr)
+	if err != nil {
+		return
+	}
+	if ops, err := c.pending(); err != nil || len(ops) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), autoSyncTimeout)
+	defer cancel()
+	created, rejected, _ := c.sync(ctx, createQueued)
+	reportSync(created, rejected)
+}
+
+func createQueued(ctx context.Context, req *beadsv1.CreateBeadRequest) (*beadsv1.Bead, error) {
+	resp, err := client.CreateBead(ctx, req)
+	return resp.GetBead(), err
+}
+
+func reportSync(created []*beadsv1.Bead, rejected []syncConflict) {
+	for _, b := range created {
+		fmt.Fprintf(os.Stderr, "Synced queued bead %s: %s\n", b.GetId(), b.GetTitle())
+	}
+	for _, c := range rejected {
+		fmt.Fprintf(os.Stderr, "Conflict: queued %s %q was rejected: %s\n", c.Op, c.Create.GetTitle(), c.Error)
+	}
+	if len(rejected) > 0 {
+		fmt.Fprintln(os.Stderr, "Rejected changes are kept; see 'bd cache status'.")
+	}
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and sync the offline cache",
+	Long: `bd list and bd show keep a local copy of the beads they fetch and fall
+back to it, read-only, when the server is unreachable. bd create --offline
+queues a bead instead of creating it; the queue is replayed before the next
+command that reaches the server, or with bd cache sync.`,
+	GroupID: "system",
+}
+
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show cached beads, queued changes and conflicts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := openCache(serverAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		snap, err := c.load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		ops, err := c.pending()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		conflicts, err := c.conflicts()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printJSON(map[string]any{
+				"dir":       c.dir,
+				"beads":     len(snap.Beads),
+				"synced_at": snap.SyncedAt,
+				"pending":   ops,
+				"conflicts": conflicts,
+			})
+			return nil
+		}
+		fmt.Printf("Cache:     %s\n", c.dir)
+		fmt.Printf("Beads:     %d\n", len(snap.Beads))
+		if !snap.SyncedAt.IsZero() {
+			fmt.Printf("Synced:    %s\n", snap.SyncedAt.Local().Format("2006-01-02 15:04"))
+		}
+		fmt.Printf("Pending:   %d\n", len(ops))
+		for _, op := range ops {
+			fmt.Printf("  %s %q (queued %s)\n", op.Op, op.Create.GetTitle(), op.QueuedAt.Local().Format("2006-01-02 15:04"))
+		}
+		fmt.Printf("Conflicts: %d\n", len(conflicts))
+		for _, cf := range conflicts {
+			fmt.Printf("  %s %q: %s\n", cf.Op, cf.Create.GetTitle(), cf.Error)
+		}
+		return nil
+	},
+}
+
+var cacheSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Replay queued changes against the server",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := openCache(serverAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		created, rejected, err := c.sync(context.Background(), createQueued)
+		reportSync(created, rejected)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(created) == 0 && len(rejected) == 0 {
+			fmt.Println("Nothing to sync.")
+		}
+		if len(rejected) > 0 {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete the cache, including queued changes and conflicts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := openCache(serverAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if ops, _ := c.pending(); len(ops) > 0 {
+			if force, _ := cmd.Flags().GetBool("force"); !force {
+				fmt.Fprintf(os.Stderr, "Error: %d queued change(s) would be lost; sync first or pass --force\n", len(ops))
+				os.Exit(1)
+			}
+		}
+		if err := c.clear(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Cache cleared.")
+		return nil
+	},
+}
+
+func init() {
+	cacheClearCmd.Flags().Bool("force", false, "discard queued changes")
+	cacheCmd.AddCommand(cacheStatusCmd)
+	cacheCmd.AddCommand(cacheSyncCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBeadCache_ListFiltersCachedBeads(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	c, err := openCache("beads.example.com:9090")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t0 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	if err := c.put(
+		&beadsv1.Bead{Id: "bd-1", Title: "Old bug", Type: "bug", Status: "open", Labels: []string{"auth"}, CreatedAt: timestamppb.New(t0)},
+		&beadsv1.Bead{Id: "bd-2", Title: "New bug", Type: "bug", Status: "open", CreatedAt: timestamppb.New(t0.Add(time.Hour))},
+		&beadsv1.Bead{Id: "bd-3", Title: "Done task", Type: "task", Status: "closed", CreatedAt: timestamppb.New(t0.Add(2 * time.Hour))},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	beads, total, syncedAt, err := c.list(&beadsv1.ListBeadsRequest{Type: []string{"bug"}, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(beads) != 1 || beads[0].GetId() != "bd-2" || syncedAt.IsZero() {
+		t.Fatalf("type=bug limit=1: got %v (total %d, synced %v)", beads, total, syncedAt)
+	}
+
+	beads, _, _, _ = c.list(&beadsv1.ListBeadsRequest{Labels: []string{"auth"}})
+	if len(beads) != 1 || beads[0].GetId() != "bd-1" {
+		t.Fatalf("labels=auth: got %v", beads)
+	}
+
+	beads, _, _, _ = c.list(&beadsv1.ListBeadsRequest{CreatedAfter: timestamppb.New(t0.Add(time.Hour)), CreatedBefore: timestamppb.New(t0.Add(2 * time.Hour))})
+	if len(beads) != 1 || beads[0].GetId() != "bd-2" {
+		t.Fatalf("created range: got %v", beads)
+	}
+
+	// A later put updates beads in place and keeps the others.
+	if err := c.put(&beadsv1.Bead{Id: "bd-1", Title: "Old bug", Type: "bug", Status: "closed"}); err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := c.get("bd-1")
+	if err != nil || b.GetStatus() != "closed" {
+		t.Fatalf("get bd-1 = %v, %v", b, err)
+	}
+	if b, _, _ := c.get("bd-2"); b == nil {
+		t.Fatal("bd-2 dropped from the cache")
+	}
+}
+
+func TestBeadCache_Sync(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	c, err := openCache("localhost:9090")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, title := range []string{"first", "rejected", "third"} {
+		if err := c.queue(pendingOp{Op: "create", QueuedAt: time.Now(), Create: &beadsv1.CreateBeadRequest{Title: title}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The server goes away after the first create.
+	calls := 0
+	created, rejected, err := c.sync(context.Background(), func(_ context.Context, req *beadsv1.CreateBeadRequest) (*beadsv1.Bead, error) {
+		calls++
+		if calls > 1 {
+			return nil, status.Error(codes.Unavailable, "connection refused")
+		}
+		return &beadsv1.Bead{Id: "bd-1", Title: req.GetTitle()}, nil
+	})
+	if !isUnreachable(err) || len(created) != 1 || len(rejected) != 0 {
+		t.Fatalf("first sync: created %v, rejected %v, err %v", created, rejected, err)
+	}
+	if ops, _ := c.pending(); len(ops) != 2 || ops[0].Create.GetTitle() != "rejected" {
+		t.Fatalf("pending after first sync = %+v", ops)
+	}
+	if b, _, _ := c.get("bd-1"); b == nil {
+		t.Fatal("created bead not cached")
+	}
+
+	created, rejected, err = c.sync(context.Background(), func(_ context.Context, req *beadsv1.CreateBeadRequest) (*beadsv1.Bead, error) {
+		if req.GetTitle() == "rejected" {
+			return nil, status.Error(codes.InvalidArgument, "unknown type")
+		}
+		return &beadsv1.Bead{Id: "bd-3", Title: req.GetTitle()}, nil
+	})
+	if err != nil || len(created) != 1 || len(rejected) != 1 {
+		t.Fatalf("second sync: created %v, rejected %v, err %v", created, rejected, err)
+	}
+	if ops, _ := c.pending(); len(ops) != 0 {
+		t.Fatalf("pending after second sync = %+v", ops)
+	}
+	conflicts, err := c.conflicts()
+	if err != nil || len(conflicts) != 1 || conflicts[0].Create.GetTitle() != "rejected" || conflicts[0].Error == "" {
+		t.Fatalf("conflicts = %+v, %v", conflicts, err)
+	}
+}
+
+func TestBeadCache_SyncKeepsOpsQueuedDuringReplay(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	c, err := openCache("localhost:9090")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.queue(pendingOp{Op: "create", QueuedAt: time.Now(), Create: &beadsv1.CreateBeadRequest{Title: "first"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	_, _, err = c.sync(context.Background(), func(_ context.Context, req *beadsv1.CreateBeadRequest) (*beadsv1.Bead, error) {
+		keys = append(keys, req.GetIdempotencyKey())
+		// Another bd queues a bead and tries to sync while this replay runs.
+		if err := c.queue(pendingOp{Op: "create", QueuedAt: time.Now(), Create: &beadsv1.CreateBeadRequest{Title: "late"}}); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := c.sync(context.Background(), nil); !errors.Is(err, errSyncBusy) {
+			t.Fatalf("concurrent sync err = %v, want errSyncBusy", err)
+		}
+		return &beadsv1.Bead{Id: "bd-1", Title: req.GetTitle()}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0] == "" {
+		t.Fatalf("idempotency keys sent = %q", keys)
+	}
+	ops, _ := c.pending()
+	if len(ops) != 1 || ops[0].Create.GetTitle() != "late" || ops[0].Create.GetIdempotencyKey() == "" {
+		t.Fatalf("pending after sync = %+v", ops)
+	}
+}
+
+func TestBeadCache_SyncResendsSameKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	c, err := openCache("localhost:9090")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Queued by an older bd, without a key.
+	if err := appendJSONLine(c.path(cachePendingFile), pendingOp{Op: "create", QueuedAt: time.Now(), Create: &beadsv1.CreateBeadRequest{Title: "slow"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	create := func(_ context.Context, req *beadsv1.CreateBeadRequest) (*beadsv1.Bead, error) {
+		keys = append(keys, req.GetIdempotencyKey())
+		if len(keys) == 1 {
+			return nil, status.Error(codes.DeadlineExceeded, "timeout")
+		}
+		return &beadsv1.Bead{Id: "bd-1", Title: req.GetTitle()}, nil
+	}
+	if _, _, err := c.sync(context.Background(), create); !isUnreachable(err) {
+		t.Fatalf("first sync err = %v", err)
+	}
+	if _, _, err := c.sync(context.Background(), create); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Fatalf("keys sent = %q, want the same key twice", keys)
+	}
+}
+
+func TestIsUnreachable(t *testing.T) {
+	for err, want := range map[error]bool{
+		status.Error(codes.Unavailable, "down"):         true,
+		status.Error(codes.DeadlineExceeded, "timeout"): true,
+		status.Error(codes.NotFound, "missing"):         false,
+		errors.New("plain"):                             false,
+		nil:                                             false,
+	} {
+		if got := isUnreachable(err); got != want {
+			t.Errorf("isUnreachable(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var checkCmd = &cobra.Command{
+	Use:     "check <id> [<item>...]",
+	Short:   "Check off a bead's acceptance criteria",
+	GroupID: "beads",
+	Long: `Check off items on a bead's acceptance-criteria checklist by number, as
+shown by bd show. --uncheck reverses it, and --add appends items to the
+checklist first. Without items or --add, the checklist is printed.`,
+	Example: `  bd check bd-a1b2 --add "Docs updated" --add "Tests pass"
+  bd check bd-a1b2 1 2
+  bd check bd-a1b2 2 --uncheck`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd check", server.FeatureChecklist)
+		add, _ := cmd.Flags().GetStringArray("add")
+		uncheck, _ := cmd.Flags().GetBool("uncheck")
+		indexes := make([]int, len(args)-1)
+		for i, a := range args[1:] {
+			n, err := strconv.Atoi(a)
+			if err != nil || n < 1 {
+				fmt.Fprintf(os.Stderr, "Error: invalid item number %q\n", a)
+				os.Exit(1)
+			}
+			indexes[i] = n
+		}
+
+		items, err := updateChecklist(context.Background(), args[0], add, indexes, !uncheck)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			printJSON(items)
+			return nil
+		}
+		done := 0
+		for _, it := range items {
+			fmt.Printf("%d. %s %s\n", it.Index, checkBox(it.Checked), it.Text)
+			if it.Checked {
+				done++
+			}
+		}
+		fmt.Printf("%d/%d done\n", done, len(items))
+		return nil
+	},
+}
+
+// updateChecklist appends add to bead ref's checklist, then checks each of
+// indexes (or unchecks them when checked is false), and returns the whole
+// checklist afterwards.
+func updateChecklist(ctx context.Context, ref string, add []string, indexes []int, checked bool) ([]*model.ChecklistItem, error) {
+	path := "/v1/beads/" + url.PathEscape(ref) + "/checklist"
+	token := bearerTokenFromEnv()
+	if len(add) > 0 {
+		if _, err := httpPost(ctx, path, token, map[string]any{"items": add, "actor": actor}); err != nil {
+			return nil, err
+		}
+	}
+	for _, n := range indexes {
+		if _, err := httpPost(ctx, fmt.Sprintf("%s/%d/check", path, n), token, map[string]any{"checked": checked, "actor": actor}); err != nil {
+			return nil, fmt.Errorf("item %d: %w", n, err)
+		}
+	}
+	body, err := httpGet(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Items []*model.ChecklistItem `json:"items"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("decoding checklist: %w", err)
+	}
+	return resp.Items, nil
+}
+
+func checkBox(checked bool) string {
+	if checked {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+func init() {
+	checkCmd.Flags().StringArray("add", nil, "append an item to the checklist (repeatable)")
+	checkCmd.Flags().Bool("uncheck", false, "uncheck the items instead")
+}
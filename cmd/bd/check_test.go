@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestUpdateChecklist(t *testing.T) {
+	type item struct {
+		Index   int    `json:"index"`
+		Text    string `json:"text"`
+		Checked bool   `json:"checked"`
+	}
+	items := []*item{{Index: 1, Text: "Docs"}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/beads/{id}/checklist", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"items": items})
+	})
+	mux.HandleFunc("POST /v1/beads/{id}/checklist", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Items []string `json:"items"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, text := range req.Items {
+			items = append(items, &item{Index: len(items) + 1, Text: text})
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"items":[]}`)
+	})
+	mux.HandleFunc("POST /v1/beads/{id}/checklist/{index}/check", func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(r.PathValue("index"))
+		if n > len(items) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"bead or checklist item not found"}`)
+			return
+		}
+		var req struct {
+			Checked bool `json:"checked"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		items[n-1].Checked = req.Checked
+		json.NewEncoder(w).Encode(items[n-1])
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	t.Setenv("BEADS_HTTP_URL", ts.URL)
+
+	got, err := updateChecklist(context.Background(), "bd-1", []string{"Tests"}, []int{2}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Checked || !got[1].Checked || got[1].Text != "Tests" {
+		t.Fatalf("checklist = %+v", got)
+	}
+
+	got, err = updateChecklist(context.Background(), "bd-1", nil, []int{2}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[1].Checked {
+		t.Fatal("item 2 still checked")
+	}
+
+	if _, err := updateChecklist(context.Background(), "bd-1", nil, []int{9}, true); err == nil {
+		t.Fatal("want an error for a missing item")
+	}
+}
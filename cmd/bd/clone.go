@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <id>",
+	Short: "Create a new bead from an existing one",
+	Long: `Creates a new open bead with the type, priority, description, owner,
+assignee, labels, custom fields and outgoing dependencies of <id>. Notes,
+comments and history are not copied.
+
+  bd clone bd-abc123 --title "Release 1.4 checklist" --assignee bob`,
+	GroupID: "beads",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req := &beadsv1.CloneBeadRequest{Id: args[0], ClonedBy: actor}
+		if cmd.Flags().Changed("title") {
+			v, _ := cmd.Flags().GetString("title")
+			req.Title = &v
+		}
+		if cmd.Flags().Changed("assignee") {
+			v, _ := cmd.Flags().GetString("assignee")
+			req.Assignee = &v
+		}
+		for flag, dst := range map[string]**bool{
+			"no-labels": &req.CopyLabels,
+			"no-fields": &req.CopyFields,
+			"no-deps":   &req.CopyDependencies,
+		} {
+			if skip, _ := cmd.Flags().GetBool(flag); skip {
+				off := false
+				*dst = &off
+			}
+		}
+
+		resp, err := client.CloneBead(context.Background(), req)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printBeadJSON(resp.GetBead())
+		} else {
+			fmt.Printf("Cloned %s as %s: %s\n", args[0], resp.GetBead().GetId(), resp.GetBead().GetTitle())
+		}
+		return nil
+	},
+}
+
+func init() {
+	cloneCmd.Flags().String("title", "", "title of the new bead (default the source's)")
+	cloneCmd.Flags().String("assignee", "", "assignee of the new bead; empty leaves it unassigned (default the source's)")
+	cloneCmd.Flags().Bool("no-labels", false, "do not copy labels")
+	cloneCmd.Flags().Bool("no-fields", false, "do not copy custom fields")
+	cloneCmd.Flags().Bool("no-deps", false, "do not copy dependencies")
+}
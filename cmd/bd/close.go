@@ -12,18 +12,27 @@ import (
 var closeCmd = &cobra.Command{
 	Use:     "close <id>...",
 	Short:   "Close one or more beads",
+	Long: `Closes beads and lists the dependents each close unblocked. A warning is
+printed when a bead is closed before its own blockers. With --cascade, the
+bead's unclosed children (parent-child, recursively) are closed too, except
+those in progress.`,
 	GroupID: "workflow",
 	Args:  cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		cascade, _ := cmd.Flags().GetBool("cascade")
 		for _, id := range args {
 			resp, err := client.CloseBead(context.Background(), &beadsv1.CloseBeadRequest{
 				Id:       id,
 				ClosedBy: actor,
+				Cascade:  cascade,
 			})
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
 				os.Exit(1)
 			}
+			for _, w := range resp.GetWarnings() {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+			}
 
 			if jsonOutput {
 				printBeadJSON(resp.GetBead())
@@ -33,8 +42,24 @@ var closeCmd = &cobra.Command{
 				} else {
 					printBeadTable(resp.GetBead())
 				}
+				printCloseEffects(resp)
 			}
 		}
 		return nil
 	},
 }
+
+// printCloseEffects lists the children closed with a bead and the
+// dependents it unblocked.
+func printCloseEffects(resp *beadsv1.CloseBeadResponse) {
+	for _, b := range resp.GetCascaded() {
+		fmt.Printf("  closed child %s  %s\n", b.GetId(), b.GetTitle())
+	}
+	for _, b := range resp.GetUnblocked() {
+		fmt.Printf("  unblocked %s  %s\n", b.GetId(), b.GetTitle())
+	}
+}
+
+func init() {
+	closeCmd.Flags().Bool("cascade", false, "also close unclosed children that are not in progress")
+}
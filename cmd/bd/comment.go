@@ -47,6 +47,9 @@ var commentAddCmd = &cobra.Command{
 			fmt.Printf("ID:         %d\n", c.GetId())
 			fmt.Printf("Bead:       %s\n", c.GetBeadId())
 			fmt.Printf("Author:     %s\n", c.GetAuthor())
+			if c.GetClaimedAuthor() != "" {
+				fmt.Printf("Claimed:    %s\n", c.GetClaimedAuthor())
+			}
 			fmt.Printf("Text:       %s\n", c.GetText())
 			if c.GetCreatedAt() != nil {
 				fmt.Printf("Created At: %s\n", c.GetCreatedAt().AsTime().Format("2006-01-02 15:04:05"))
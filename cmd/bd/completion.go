@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion bash|zsh|fish",
+	Short: "Print a shell completion script",
+	Long: `Prints a completion script for your shell. Bead IDs, labels, assignees
+and view names are completed from the server as you type.
+
+  bash:  source <(bd completion bash)
+  zsh:   bd completion zsh > "${fpath[1]}/_bd"
+  fish:  bd completion fish > ~/.config/fish/completions/bd.fish`,
+	GroupID:   "system",
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	// Printing a script needs no server connection.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		}
+		return fmt.Errorf("unsupported shell %q (want bash, zsh or fish)", args[0])
+	},
+}
+
+// completionTimeout bounds each server lookup made while completing, so a
+// slow or unreachable server does not hang the shell.
+const completionTimeout = 2 * time.Second
+
+// completionLimit caps the beads fetched per completion.
+const completionLimit = 500
+
+// Statuses offered when completing bead IDs.
+var (
+	activeStatuses   = []string{"open", "in_progress", "deferred"}
+	closedStatuses   = []string{"closed"}
+	deferredStatuses = []string{"deferred"}
+)
+
+type completeFunc = func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective)
+
+// beadIDs lists "id<TAB>title" for beads with the given statuses whose ID
+// starts with prefix, falling back to the local cache when the server is
+// unreachable.
+func beadIDs(statuses []string, prefix string) []cobra.Completion {
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	req := &beadsv1.ListBeadsRequest{Status: statuses, Limit: completionLimit}
+	var beads []*beadsv1.Bead
+	resp, err := client.ListBeads(ctx, req)
+	if err == nil {
+		beads = resp.GetBeads()
+	} else if c, cerr := openCache(serverAddr); cerr == nil {
+		beads, _, _, _ = c.list(req)
+	}
+
+	var out []cobra.Completion
+	for _, b := range beads {
+		if strings.HasPrefix(b.GetId(), prefix) {
+			out = append(out, cobra.CompletionWithDesc(b.GetId(), b.GetTitle()))
+		}
+	}
+	return out
+}
+
+// completeBeads completes the positional arguments at the given indexes
+// with bead IDs; any index completes every argument when none are given.
+func completeBeads(statuses []string, positions ...int) completeFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(positions) > 0 && !slices.Contains(positions, len(args)) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return beadIDs(statuses, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// labelNames lists the labels in use.
+func labelNames() []cobra.Completion {
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	body, err := httpGet(ctx, "/v1/labels")
+	if err != nil {
+		return nil
+	}
+	var resp struct {
+		Labels []labelCount `json:"labels"`
+	}
+	if json.Unmarshal(body, &resp) != nil {
+		return nil
+	}
+	out := make([]cobra.Completion, 0, len(resp.Labels))
+	for _, l := range resp.Labels {
+		out = append(out, l.Label)
+	}
+	return out
+}
+
+func completeLabels(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return labelNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLabelArgs completes "<bead-id> <label>...": a bead, then labels
+// in use (add) or the bead's own labels (remove).
+func completeLabelArgs(own bool) completeFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return beadIDs(activeStatuses, toComplete), cobra.ShellCompDirectiveNoFileComp
+		}
+		if !own {
+			return labelNames(), cobra.ShellCompDirectiveNoFileComp
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+		defer cancel()
+		resp, err := client.GetBead(ctx, &beadsv1.GetBeadRequest{Id: args[0]})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return resp.GetBead().GetLabels(), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeAssignees completes the assignees of active beads.
+func completeAssignees(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	resp, err := client.ListBeads(ctx, &beadsv1.ListBeadsRequest{Status: activeStatuses, Limit: completionLimit})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var out []cobra.Completion
+	for _, b := range resp.GetBeads() {
+		if a := b.GetAssignee(); a != "" && !slices.Contains(out, a) {
+			out = append(out, a)
+		}
+	}
+	slices.Sort(out)
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeViews completes the names of saved views.
+func completeViews(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	resp, err := client.ListConfigs(ctx, &beadsv1.ListConfigsRequest{Namespace: "view"})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var out []cobra.Completion
+	for _, c := range resp.GetConfigs() {
+		out = append(out, strings.TrimPrefix(c.GetKey(), "view:"))
+	}
+	slices.Sort(out)
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerCompletions attaches the dynamic completions. It runs from main,
+// after every command's flags have been defined.
+func registerCompletions() {
+	// Commands taking any number of bead IDs.
+	for _, cmd := range []*cobra.Command{closeCmd, doneCmd, unclaimCmd, deferCmd, deleteCmd, adviceAckCmd} {
+		cmd.ValidArgsFunction = completeBeads(activeStatuses)
+	}
+	reopenCmd.ValidArgsFunction = completeBeads(closedStatuses)
+	undeferCmd.ValidArgsFunction = completeBeads(deferredStatuses)
+
+	// Commands whose first argument is a bead ID.
+	for _, cmd := range []*cobra.Command{
+		showCmd, updateCmd, claimCmd, mergeCmd, cloneCmd, aliasCmd, treeCmd, followCmd, unfollowCmd,
+		commentAddCmd, commentListCmd, noteAddCmd, noteListCmd, depListCmd, relationListCmd,
+		decisionShowCmd, decisionResolveCmd, labelListCmd,
+	} {
+		cmd.ValidArgsFunction = completeBeads(activeStatuses, 0)
+	}
+	for _, cmd := range []*cobra.Command{depAddCmd, depRemoveCmd, depMetaCmd} {
+		cmd.ValidArgsFunction = completeBeads(activeStatuses, 0, 1)
+	}
+	for _, cmd := range []*cobra.Command{relationAddCmd, relationRemoveCmd} {
+		cmd.ValidArgsFunction = completeBeads(activeStatuses, 0, 2)
+	}
+	labelAddCmd.ValidArgsFunction = completeLabelArgs(false)
+	labelRemoveCmd.ValidArgsFunction = completeLabelArgs(true)
+	viewCmd.ValidArgsFunction = completeViews
+
+	for _, cmd := range []*cobra.Command{listCmd, readyCmd, blockedCmd, exportCmd, createCmd, updateCmd, cloneCmd} {
+		_ = cmd.RegisterFlagCompletionFunc("assignee", completeAssignees)
+	}
+	for _, cmd := range []*cobra.Command{createCmd, nextCmd, eventsCmd} {
+		_ = cmd.RegisterFlagCompletionFunc("label", completeLabels)
+	}
+	_ = eventsCmd.RegisterFlagCompletionFunc("bead", completeBeads(nil))
+	_ = eventsCmd.RegisterFlagCompletionFunc("project", completeBeads(activeStatuses))
+	_ = mergeCmd.RegisterFlagCompletionFunc("into", completeBeads(activeStatuses))
+}
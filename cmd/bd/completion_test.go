@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRegisterCompletions(t *testing.T) {
+	registerCompletions()
+
+	for _, cmd := range []*cobra.Command{closeCmd, showCmd, reopenCmd, labelAddCmd, depAddCmd, viewCmd} {
+		if cmd.ValidArgsFunction == nil {
+			t.Errorf("%s has no argument completion", cmd.CommandPath())
+		}
+	}
+	for cmd, flag := range map[*cobra.Command]string{listCmd: "assignee", createCmd: "label", mergeCmd: "into"} {
+		if _, ok := cmd.GetFlagCompletionFunc(flag); !ok {
+			t.Errorf("%s --%s has no completion", cmd.CommandPath(), flag)
+		}
+	}
+}
+
+func TestCompleteBeads_SkipsOtherPositions(t *testing.T) {
+	// The second argument of relation add is a relation type, not a bead,
+	// so no server lookup happens.
+	got, directive := completeBeads(activeStatuses, 0, 2)(relationAddCmd, []string{"bd-1"}, "")
+	if got != nil || directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("completions = %v, %v; want none", got, directive)
+	}
+}
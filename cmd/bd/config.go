@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"text/tabwriter"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
 	"github.com/spf13/cobra"
@@ -32,8 +34,9 @@ var configCreateCmd = &cobra.Command{
 		}
 
 		resp, err := client.SetConfig(context.Background(), &beadsv1.SetConfigRequest{
-			Key:   key,
-			Value: value,
+			Key:       key,
+			Value:     value,
+			UpdatedBy: actor,
 		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -113,6 +116,71 @@ var configDeleteCmd = &cobra.Command{
 	},
 }
 
+var configHistoryCmd = &cobra.Command{
+	Use:   "history <key>",
+	Short: "List a config's revisions, newest first",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.GetConfigHistory(context.Background(), &beadsv1.GetConfigHistoryRequest{
+			Key: args[0],
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			data, _ := json.MarshalIndent(resp.GetRevisions(), "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		if len(resp.GetRevisions()) == 0 {
+			fmt.Println("No revisions found.")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "REV\tWHEN\tACTOR\tVALUE")
+		for _, r := range resp.GetRevisions() {
+			value := string(r.GetValue())
+			if r.GetDeleted() {
+				value = "(deleted)"
+			} else if len(value) > 60 {
+				value = value[:57] + "..."
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", r.GetRev(),
+				r.GetCreatedAt().AsTime().Format("2006-01-02 15:04:05"), r.GetActor(), value)
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+var configRollbackCmd = &cobra.Command{
+	Use:   "rollback <key> <rev>",
+	Short: "Restore a config to an earlier revision",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rev, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || rev <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: invalid revision %q\n", args[1])
+			os.Exit(1)
+		}
+
+		resp, err := client.RollbackConfig(context.Background(), &beadsv1.RollbackConfigRequest{
+			Key:       args[0],
+			Rev:       rev,
+			UpdatedBy: actor,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		printConfigJSON(resp.GetConfig())
+		return nil
+	},
+}
+
 func printConfigJSON(c *beadsv1.Config) {
 	// Pretty-print by unmarshalling the value bytes so they render as JSON, not base64.
 	var valueObj any
@@ -128,6 +196,9 @@ func printConfigJSON(c *beadsv1.Config) {
 	if c.GetUpdatedAt() != nil {
 		out["updated_at"] = c.GetUpdatedAt().AsTime().Format("2006-01-02T15:04:05Z")
 	}
+	if c.GetRev() > 0 {
+		out["rev"] = c.GetRev()
+	}
 
 	data, _ := json.MarshalIndent(out, "", "  ")
 	fmt.Println(string(data))
@@ -138,4 +209,6 @@ func init() {
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configListCmd)
 	configCmd.AddCommand(configDeleteCmd)
+	configCmd.AddCommand(configHistoryCmd)
+	configCmd.AddCommand(configRollbackCmd)
 }
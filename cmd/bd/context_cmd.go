@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/server"
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -24,8 +27,16 @@ type contextSection struct {
 	Format string   `json:"format"` // "table" (default), "list", "count", "detail", "tree"
 	Fields []string `json:"fields"` // for "list" and "detail" formats
 	Depth  int      `json:"depth"`  // for "tree" format; default 3
+	Report string   `json:"report"` // "starvation" renders the report instead of a view
+	Limit  int      `json:"limit"`  // beads in a report; default 5
+
+	starving *starvationReport // already fetched
 }
 
+// primeStarvationLimit is how many starving beads a context section lists,
+// unless it sets a limit.
+const primeStarvationLimit = 5
+
 var contextCmd = &cobra.Command{
 	Use:     "context <name>",
 	Short:   "Compose and render a context template",
@@ -49,6 +60,15 @@ var contextCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		// The prime context always shows the longest waiting ready beads, so
+		// agents see old work before they pick fresh beads.
+		if name == "prime" && !slices.ContainsFunc(cc.Sections, func(s contextSection) bool { return s.Report == "starvation" }) &&
+			serverSupports(server.FeatureStarvation) {
+			if r, err := fetchStarvationReport(context.Background(), primeStarvationLimit); err == nil && r.Total > 0 {
+				cc.Sections = append(cc.Sections, contextSection{Header: "Starving work", Report: "starvation", starving: r})
+			}
+		}
+
 		// 2. Render each section.
 		for i, section := range cc.Sections {
 			if i > 0 {
@@ -59,6 +79,13 @@ var contextCmd = &cobra.Command{
 				fmt.Println()
 			}
 
+			if section.Report != "" {
+				if err := printSectionReport(section); err != nil {
+					fmt.Fprintf(os.Stderr, "Error rendering report %q: %v\n", section.Report, err)
+				}
+				continue
+			}
+
 			// Resolve the named view.
 			viewResp, err := client.GetConfig(context.Background(), &beadsv1.GetConfigRequest{
 				Key: "view:" + section.View,
@@ -82,6 +109,7 @@ var contextCmd = &cobra.Command{
 				Labels:   vc.Filter.Labels,
 				Assignee: expandVar(vc.Filter.Assignee),
 				Search:   vc.Filter.Search,
+				Query:    expandVar(vc.Filter.Query),
 				Sort:     vc.Sort,
 				Limit:    vc.Limit,
 			}
@@ -124,6 +152,40 @@ var contextCmd = &cobra.Command{
 	},
 }
 
+// fetchStarvationReport downloads the starvation report with its default
+// threshold, listing up to limit beads.
+func fetchStarvationReport(ctx context.Context, limit int) (*starvationReport, error) {
+	body, err := httpGet(ctx, "/v1/reports/starvation?limit="+strconv.Itoa(limit))
+	if err != nil {
+		return nil, err
+	}
+	var r starvationReport
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("invalid starvation report: %w", err)
+	}
+	return &r, nil
+}
+
+// printSectionReport renders a section's report.
+func printSectionReport(section contextSection) error {
+	if section.Report != "starvation" {
+		return fmt.Errorf("unknown report")
+	}
+	r := section.starving
+	if r == nil {
+		limit := section.Limit
+		if limit <= 0 {
+			limit = primeStarvationLimit
+		}
+		var err error
+		if r, err = fetchStarvationReport(context.Background(), limit); err != nil {
+			return err
+		}
+	}
+	printStarvationReport(os.Stdout, r, false)
+	return nil
+}
+
 // printSectionList prints beads as bullet points with selected fields.
 func printSectionList(beads []*beadsv1.Bead, fields []string) {
 	if len(fields) == 0 {
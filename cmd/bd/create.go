@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
 	"github.com/spf13/cobra"
@@ -34,10 +35,22 @@ func parseFields(pairs []string) ([]byte, error) {
 var createCmd = &cobra.Command{
 	Use:     "create <title>",
 	Short:   "Create a new bead",
+	Long: `Create a new bead.
+
+With -i, prompt for the type, title, priority, labels, dependencies and the
+type's fields, then show a preview before creating it. Flags given alongside
+-i become the defaults offered at each prompt.`,
 	GroupID: "beads",
-	Args:  cobra.ExactArgs(1),
+	Args:    cobra.RangeArgs(0, 1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		title := args[0]
+		interactive, _ := cmd.Flags().GetBool("interactive")
+		if len(args) == 0 && !interactive {
+			return fmt.Errorf("a title is required unless -i is given")
+		}
+		var title string
+		if len(args) > 0 {
+			title = args[0]
+		}
 
 		description, _ := cmd.Flags().GetString("description")
 		beadType, _ := cmd.Flags().GetString("type")
@@ -46,6 +59,8 @@ var createCmd = &cobra.Command{
 		labels, _ := cmd.Flags().GetStringSlice("label")
 		assignee, _ := cmd.Flags().GetString("assignee")
 		owner, _ := cmd.Flags().GetString("owner")
+		estimate, _ := cmd.Flags().GetString("estimate")
+		actual, _ := cmd.Flags().GetString("actual")
 
 		fieldPairs, _ := cmd.Flags().GetStringArray("field")
 		fieldsJSON, err := parseFields(fieldPairs)
@@ -63,20 +78,60 @@ var createCmd = &cobra.Command{
 			Labels:      labels,
 			Assignee:    assignee,
 			Owner:       owner,
+			Estimate:    estimate,
+			Actual:      actual,
 			CreatedBy:   actor,
 			Fields:      fieldsJSON,
 		}
 
+		if offline, _ := cmd.Flags().GetBool("offline"); offline {
+			if interactive {
+				return fmt.Errorf("--offline cannot be combined with -i")
+			}
+			queueCreate(req)
+			return nil
+		}
+
+		var deps []*beadsv1.AddDependencyRequest
+		if interactive {
+			w, err := loadCreateWizard(context.Background(), os.Stdin, os.Stderr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			res, err := w.run(req)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if res == nil {
+				fmt.Fprintln(os.Stderr, "Aborted.")
+				return nil
+			}
+			req, deps = res.Bead, res.Deps
+		}
+
 		resp, err := client.CreateBead(context.Background(), req)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
+		cacheBeads(resp.GetBead())
+
+		for _, d := range deps {
+			d.BeadId = resp.GetBead().GetId()
+			d.CreatedBy = actor
+			if _, err := client.AddDependency(context.Background(), d); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: adding dependency on %s: %v\n", d.GetDependsOnId(), err)
+			}
+		}
+
 		if jsonOutput {
 			printBeadJSON(resp.GetBead())
 		} else {
 			printBeadTable(resp.GetBead())
+			warnSimilar(resp.GetBead().GetId())
 		}
 		return nil
 	},
@@ -90,5 +145,28 @@ func init() {
 	createCmd.Flags().StringSliceP("label", "l", nil, "labels (repeatable)")
 	createCmd.Flags().String("assignee", "", "assignee")
 	createCmd.Flags().String("owner", "", "owner")
+	createCmd.Flags().String("estimate", "", "estimate, in points (3) or a duration (4h)")
+	createCmd.Flags().String("actual", "", "work actually spent, in points or a duration")
 	createCmd.Flags().StringArrayP("field", "f", nil, "typed field (key=value, repeatable)")
+	createCmd.Flags().BoolP("interactive", "i", false, "prompt for each part of the bead")
+	createCmd.Flags().Bool("offline", false, "queue the bead locally; it is created when the server is next reachable")
+}
+
+// queueCreate adds a bead to the offline queue instead of creating it.
+func queueCreate(req *beadsv1.CreateBeadRequest) {
+	c, err := openCache(serverAddr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	op := pendingOp{Op: "create", QueuedAt: time.Now().UTC(), Create: req}
+	if err := c.queue(op); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOutput {
+		printJSON(op)
+		return
+	}
+	fmt.Printf("Queued %q; it will be created when the server is reachable (bd cache sync).\n", req.GetTitle())
 }
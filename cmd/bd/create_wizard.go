@@ -0,0 +1,432 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+)
+
+// wizardType is a bead type offered by the creation wizard, read from its
+// type:{name} config.
+type wizardType struct {
+	Name   string
+	Kind   string        `json:"kind"`
+	Fields []wizardField `json:"fields"`
+}
+
+// wizardField is one typed field of a wizardType.
+type wizardField struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Values   []string `json:"values"`
+}
+
+// createWizard walks the user through creating a bead, one prompt per line,
+// so it also works when input is piped.
+type createWizard struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	types  []wizardType    // sorted by name
+	labels []string        // existing labels, sorted
+	beads  []*beadsv1.Bead // open beads offered as dependencies
+}
+
+// wizardResult is what the wizard collected: the bead to create and the
+// dependencies to add once it exists (BeadId is left empty).
+type wizardResult struct {
+	Bead *beadsv1.CreateBeadRequest
+	Deps []*beadsv1.AddDependencyRequest
+}
+
+// loadCreateWizard fetches the type configs and open beads the wizard
+// offers.
+func loadCreateWizard(ctx context.Context, in io.Reader, out io.Writer) (*createWizard, error) {
+	w := &createWizard{in: bufio.NewReader(in), out: out}
+
+	configs, err := client.ListConfigs(ctx, &beadsv1.ListConfigsRequest{Namespace: "type"})
+	if err != nil {
+		return nil, fmt.Errorf("loading types: %w", err)
+	}
+	for _, c := range configs.GetConfigs() {
+		t := wizardType{Name: strings.TrimPrefix(c.GetKey(), "type:")}
+		if err := json.Unmarshal(c.GetValue(), &t); err != nil {
+			continue
+		}
+		w.types = append(w.types, t)
+	}
+	sort.Slice(w.types, func(i, j int) bool { return w.types[i].Name < w.types[j].Name })
+
+	open, err := client.ListBeads(ctx, &beadsv1.ListBeadsRequest{
+		Status: []string{"open", "in_progress"},
+		Sort:   "priority",
+		Limit:  500,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading open beads: %w", err)
+	}
+	w.beads = open.GetBeads()
+	seen := map[string]bool{}
+	for _, b := range w.beads {
+		for _, l := range b.GetLabels() {
+			if !seen[l] {
+				seen[l] = true
+				w.labels = append(w.labels, l)
+			}
+		}
+	}
+	sort.Strings(w.labels)
+	return w, nil
+}
+
+// run asks for each part of the bead, starting from the values in defaults,
+// and returns nil if the user declines the preview.
+func (w *createWizard) run(defaults *beadsv1.CreateBeadRequest) (*wizardResult, error) {
+	res := &wizardResult{Bead: defaults}
+	t, err := w.askType(defaults.GetType())
+	if err != nil {
+		return nil, err
+	}
+	res.Bead.Type = t.Name
+	if res.Bead.Title, err = w.askRequired("Title", defaults.GetTitle()); err != nil {
+		return nil, err
+	}
+	if res.Bead.Priority, err = w.askPriority(defaults.GetPriority()); err != nil {
+		return nil, err
+	}
+	if res.Bead.Labels, err = w.askLabels(defaults.GetLabels()); err != nil {
+		return nil, err
+	}
+	if res.Deps, err = w.askDeps(); err != nil {
+		return nil, err
+	}
+	if res.Bead.Fields, err = w.askFields(t.Fields); err != nil {
+		return nil, err
+	}
+	if res.Bead.Description, err = w.ask("Description", defaults.GetDescription()); err != nil {
+		return nil, err
+	}
+
+	w.preview(res)
+	ok, err := w.ask("Create this bead? [Y/n]", "")
+	if err != nil {
+		return nil, err
+	}
+	if ok = strings.ToLower(ok); ok != "" && ok != "y" && ok != "yes" {
+		return nil, nil
+	}
+	return res, nil
+}
+
+// ask prints a prompt and returns the trimmed reply, or def if it is blank.
+// End of input is an error so a short script cannot loop forever.
+func (w *createWizard) ask(prompt, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(w.out, "%s [%s]: ", prompt, def)
+	} else {
+		fmt.Fprintf(w.out, "%s: ", prompt)
+	}
+	line, err := w.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("reading %s: %w", strings.ToLower(prompt), err)
+	}
+	if line = strings.TrimSpace(line); line == "" {
+		return def, nil
+	}
+	return line, nil
+}
+
+func (w *createWizard) askRequired(prompt, def string) (string, error) {
+	for {
+		v, err := w.ask(prompt, def)
+		if err != nil || v != "" {
+			return v, err
+		}
+		fmt.Fprintf(w.out, "%s is required.\n", prompt)
+	}
+}
+
+// askType offers the configured types by number or name; a unique prefix
+// of a name is enough.
+func (w *createWizard) askType(def string) (wizardType, error) {
+	if len(w.types) == 0 {
+		return wizardType{Name: def}, nil
+	}
+	fmt.Fprintln(w.out, "Types:")
+	for i, t := range w.types {
+		fmt.Fprintf(w.out, "  %2d) %-12s %s\n", i+1, t.Name, t.Kind)
+	}
+	for {
+		v, err := w.askRequired("Type", def)
+		if err != nil {
+			return wizardType{}, err
+		}
+		if n, err := strconv.Atoi(v); err == nil && n >= 1 && n <= len(w.types) {
+			return w.types[n-1], nil
+		}
+		var match []wizardType
+		for _, t := range w.types {
+			if t.Name == v {
+				return t, nil
+			}
+			if strings.HasPrefix(t.Name, v) {
+				match = append(match, t)
+			}
+		}
+		if len(match) == 1 {
+			return match[0], nil
+		}
+		fmt.Fprintf(w.out, "Unknown type %q; enter a number or name from the list.\n", v)
+	}
+}
+
+func (w *createWizard) askPriority(def int32) (int32, error) {
+	for {
+		v, err := w.ask("Priority (0 highest - 4 lowest)", strconv.Itoa(int(def)))
+		if err != nil {
+			return 0, err
+		}
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 4 {
+			return int32(n), nil
+		}
+		fmt.Fprintln(w.out, "Priority must be a number from 0 to 4.")
+	}
+}
+
+// askLabels reads comma-separated labels, completing each one that is a
+// unique prefix of an existing label. "?" lists the existing labels.
+func (w *createWizard) askLabels(def []string) ([]string, error) {
+	for {
+		v, err := w.ask("Labels (comma-separated, ? to list)", strings.Join(def, ","))
+		if err != nil {
+			return nil, err
+		}
+		if v == "?" {
+			if len(w.labels) == 0 {
+				fmt.Fprintln(w.out, "No labels in use yet.")
+			} else {
+				fmt.Fprintln(w.out, "  "+strings.Join(w.labels, ", "))
+			}
+			continue
+		}
+		var labels []string
+		for _, l := range strings.Split(v, ",") {
+			if l = strings.TrimSpace(l); l == "" {
+				continue
+			}
+			if full := completeLabel(l, w.labels); full != l {
+				fmt.Fprintf(w.out, "  %s -> %s\n", l, full)
+				l = full
+			}
+			labels = append(labels, l)
+		}
+		return labels, nil
+	}
+}
+
+// completeLabel returns the existing label that prefix uniquely starts, or
+// prefix itself when it names a label exactly, matches none, or is ambiguous.
+func completeLabel(prefix string, labels []string) string {
+	var match string
+	for _, l := range labels {
+		if l == prefix {
+			return l
+		}
+		if strings.HasPrefix(l, prefix) {
+			if match != "" {
+				return prefix
+			}
+			match = l
+		}
+	}
+	if match == "" {
+		return prefix
+	}
+	return match
+}
+
+// askDeps repeatedly searches the open beads and adds the one picked as a
+// dependency, until the search is left blank.
+func (w *createWizard) askDeps() ([]*beadsv1.AddDependencyRequest, error) {
+	var deps []*beadsv1.AddDependencyRequest
+	for {
+		q, err := w.ask("Depends on (search open beads, blank to finish)", "")
+		if err != nil || q == "" {
+			return deps, err
+		}
+		matches := fuzzyFindBeads(q, w.beads, 5)
+		if len(matches) == 0 {
+			fmt.Fprintln(w.out, "No matching open beads.")
+			continue
+		}
+		for i, b := range matches {
+			fmt.Fprintf(w.out, "  %d) %s  %s\n", i+1, b.GetId(), b.GetTitle())
+		}
+		pick, err := w.ask("Pick a number (blank to search again)", "")
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(pick)
+		if err != nil || n < 1 || n > len(matches) {
+			continue
+		}
+		depType, err := w.ask("Dependency type", "blocks")
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, &beadsv1.AddDependencyRequest{DependsOnId: matches[n-1].GetId(), Type: depType})
+	}
+}
+
+// fuzzyFindBeads ranks beads by how well query matches their ID and title:
+// substrings first, earliest first, then in-order subsequences, tightest
+// first. At most limit beads are returned.
+func fuzzyFindBeads(query string, beads []*beadsv1.Bead, limit int) []*beadsv1.Bead {
+	type scored struct {
+		b     *beadsv1.Bead
+		score int
+	}
+	query = strings.ToLower(query)
+	var hits []scored
+	for _, b := range beads {
+		text := strings.ToLower(b.GetId() + " " + b.GetTitle())
+		if i := strings.Index(text, query); i >= 0 {
+			hits = append(hits, scored{b, i})
+		} else if span, ok := subsequenceSpan(query, text); ok {
+			hits = append(hits, scored{b, len(text) + span})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score < hits[j].score })
+	out := make([]*beadsv1.Bead, 0, min(limit, len(hits)))
+	for _, h := range hits[:min(limit, len(hits))] {
+		out = append(out, h.b)
+	}
+	return out
+}
+
+// subsequenceSpan reports whether the runes of q appear in order in text,
+// and how long the stretch of text covering them is.
+func subsequenceSpan(q, text string) (int, bool) {
+	qr := []rune(q)
+	if len(qr) == 0 {
+		return 0, true
+	}
+	start, j := -1, 0
+	for i, r := range []rune(text) {
+		if r != qr[j] {
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		if j++; j == len(qr) {
+			return i - start + 1, true
+		}
+	}
+	return 0, false
+}
+
+// askFields prompts for each field of the type and returns them as a JSON
+// object, or nil if none was given.
+func (w *createWizard) askFields(defs []wizardField) ([]byte, error) {
+	if len(defs) == 0 {
+		return nil, nil
+	}
+	fields := map[string]any{}
+	for _, f := range defs {
+		hint := f.Type
+		if len(f.Values) > 0 {
+			hint += ": " + strings.Join(f.Values, "|")
+		}
+		if f.Required {
+			hint += ", required"
+		}
+		prompt := fmt.Sprintf("%s (%s)", f.Name, hint)
+		for {
+			v, err := w.ask(prompt, "")
+			if err != nil {
+				return nil, err
+			}
+			if v == "" {
+				if f.Required {
+					fmt.Fprintf(w.out, "%s is required.\n", f.Name)
+					continue
+				}
+				break
+			}
+			val, err := fieldValue(f, v)
+			if err != nil {
+				fmt.Fprintln(w.out, err)
+				continue
+			}
+			fields[f.Name] = val
+			break
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(fields)
+}
+
+// fieldValue converts a typed reply to its JSON value. List types take
+// comma-separated items; enum values must be one of the allowed values. The
+// server validates everything else.
+func fieldValue(f wizardField, v string) (any, error) {
+	var items []string
+	switch f.Type {
+	case "string[]", "enum[]":
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				items = append(items, s)
+			}
+		}
+	case "enum":
+		items = []string{v}
+	case "string", "timestamp":
+		return v, nil
+	default:
+		return rawOrString(v), nil
+	}
+	if len(f.Values) > 0 {
+		for _, s := range items {
+			if !slices.Contains(f.Values, s) {
+				return nil, fmt.Errorf("%q is not one of %s", s, strings.Join(f.Values, ", "))
+			}
+		}
+	}
+	if f.Type == "enum" {
+		return v, nil
+	}
+	return items, nil
+}
+
+func (w *createWizard) preview(res *wizardResult) {
+	b := res.Bead
+	fmt.Fprintln(w.out)
+	fmt.Fprintf(w.out, "  Title:     %s\n", b.GetTitle())
+	fmt.Fprintf(w.out, "  Type:      %s\n", b.GetType())
+	fmt.Fprintf(w.out, "  Priority:  %d\n", b.GetPriority())
+	if len(b.GetLabels()) > 0 {
+		fmt.Fprintf(w.out, "  Labels:    %s\n", strings.Join(b.GetLabels(), ", "))
+	}
+	for _, d := range res.Deps {
+		fmt.Fprintf(w.out, "  Depends:   %s (%s)\n", d.GetDependsOnId(), d.GetType())
+	}
+	if len(b.GetFields()) > 0 {
+		fmt.Fprintf(w.out, "  Fields:    %s\n", b.GetFields())
+	}
+	if b.GetDescription() != "" {
+		fmt.Fprintf(w.out, "  Description: %s\n", b.GetDescription())
+	}
+	fmt.Fprintln(w.out)
+}
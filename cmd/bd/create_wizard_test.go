@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+)
+
+func newTestWizard(input string) (*createWizard, *strings.Builder) {
+	out := &strings.Builder{}
+	return &createWizard{
+		in:  bufio.NewReader(strings.NewReader(input)),
+		out: out,
+		types: []wizardType{
+			{Name: "bug", Kind: "issue", Fields: []wizardField{
+				{Name: "severity", Type: "enum", Required: true, Values: []string{"low", "high"}},
+				{Name: "tags", Type: "string[]"},
+			}},
+			{Name: "task", Kind: "issue"},
+		},
+		labels: []string{"backend", "frontend", "infra"},
+		beads: []*beadsv1.Bead{
+			{Id: "bd-a1", Title: "Fix login redirect"},
+			{Id: "bd-b2", Title: "Migrate billing schema"},
+		},
+	}, out
+}
+
+func TestCreateWizard_Run(t *testing.T) {
+	input := strings.Join([]string{
+		"bu", // type by prefix
+		"",   // title required
+		"Crash on save",
+		"7", // priority out of range
+		"1",
+		"?",         // list labels
+		"back, ops", // back completes to backend
+		"billing",   // dependency search
+		"1",         // pick bd-b2
+		"",          // default dep type
+		"",          // finish deps
+		"urgent",    // not an enum value
+		"high",
+		"ui, editor",
+		"Steps attached",
+		"y",
+	}, "\n") + "\n"
+	w, out := newTestWizard(input)
+
+	res, err := w.run(&beadsv1.CreateBeadRequest{Type: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("run: %v\n%s", err, out)
+	}
+	b := res.Bead
+	if b.Type != "bug" || b.Title != "Crash on save" || b.Priority != 1 || b.Description != "Steps attached" {
+		t.Fatalf("bead = %+v", b)
+	}
+	if strings.Join(b.Labels, ",") != "backend,ops" {
+		t.Fatalf("labels = %v", b.Labels)
+	}
+	if len(res.Deps) != 1 || res.Deps[0].DependsOnId != "bd-b2" || res.Deps[0].Type != "blocks" {
+		t.Fatalf("deps = %v", res.Deps)
+	}
+	if string(b.Fields) != `{"severity":"high","tags":["ui","editor"]}` {
+		t.Fatalf("fields = %s", b.Fields)
+	}
+	for _, want := range []string{"Title is required.", "Priority must be", "backend, frontend, infra", `"urgent" is not one of low, high`, "Depends:   bd-b2 (blocks)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
+
+func TestCreateWizard_Decline(t *testing.T) {
+	w, _ := newTestWizard("task\nTitle\n\n\n\n\nn\n")
+	res, err := w.run(&beadsv1.CreateBeadRequest{Type: "task", Priority: 2})
+	if err != nil || res != nil {
+		t.Fatalf("res = %v, err = %v; want nil, nil", res, err)
+	}
+}
+
+func TestCreateWizard_EOF(t *testing.T) {
+	w, _ := newTestWizard("task\n")
+	if _, err := w.run(&beadsv1.CreateBeadRequest{Type: "task"}); err == nil {
+		t.Fatal("expected an error when input ends early")
+	}
+}
+
+func TestCompleteLabel(t *testing.T) {
+	labels := []string{"backend", "bug", "frontend"}
+	for prefix, want := range map[string]string{
+		"back": "backend",
+		"b":    "b", // ambiguous
+		"bug":  "bug",
+		"ops":  "ops",
+	} {
+		if got := completeLabel(prefix, labels); got != want {
+			t.Errorf("completeLabel(%q) = %q, want %q", prefix, got, want)
+		}
+	}
+}
+
+func TestFuzzyFindBeads(t *testing.T) {
+	beads := []*beadsv1.Bead{
+		{Id: "bd-1", Title: "Update docs"},
+		{Id: "bd-2", Title: "Login page"},
+		{Id: "bd-3", Title: "Log in via SSO"},
+	}
+	got := fuzzyFindBeads("login", beads, 5)
+	if len(got) != 2 || got[0].Id != "bd-2" || got[1].Id != "bd-3" {
+		t.Fatalf("got %v, want bd-2 (substring) then bd-3 (subsequence)", got)
+	}
+	if got := fuzzyFindBeads("zzz", beads, 5); len(got) != 0 {
+		t.Fatalf("got %v, want no matches", got)
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var decisionCmd = &cobra.Command{
+	Use:     "decision",
+	Short:   "Create, show and resolve decisions",
+	GroupID: "workflow",
+}
+
+var decisionCreateCmd = &cobra.Command{
+	Use:   "create [<title>]",
+	Short: "Create a decision, optionally from a template",
+	Long: `Creates a decision bead. With --template, the decision-template:<name>
+config supplies the title, prompt, options, default option, urgency,
+approvers and expiry; --context fills the template's {context} placeholder
+and flags override the rest. Only the approvers, when listed, may resolve
+the decision.
+
+A template is stored as config:
+
+  bd config create decision-template:deploy-approval \
+    '{"title":"Deploy {context}?","prompt":"Approve deploying {context} to production.",
+      "options":["approve","reject","defer"],"default_option":"defer",
+      "urgency":"high","approvers":["alice","bob"],"expires_in":"4h"}'`,
+	Example: `  bd decision create --template deploy-approval --context "api v2.3 (PR #412)"
+  bd decision create "Ship Friday?" --option yes --option no --default no --expires-in 24h`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		name, _ := cmd.Flags().GetString("template")
+		var tmpl model.DecisionTemplate
+		if name != "" {
+			resp, err := client.GetConfig(ctx, &beadsv1.GetConfigRequest{Key: model.DecisionTemplatePrefix + name})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: decision template %s: %v\n", name, err)
+				os.Exit(1)
+			}
+			if err := json.Unmarshal(resp.GetConfig().GetValue(), &tmpl); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid decision template %s: %v\n", name, err)
+				os.Exit(1)
+			}
+		}
+		if len(args) > 0 {
+			tmpl.Title = args[0]
+		}
+		if cmd.Flags().Changed("option") {
+			tmpl.Options, _ = cmd.Flags().GetStringArray("option")
+		}
+		for flag, dst := range map[string]*string{
+			"default":    &tmpl.DefaultOption,
+			"urgency":    &tmpl.Urgency,
+			"expires-in": &tmpl.ExpiresIn,
+		} {
+			if cmd.Flags().Changed(flag) {
+				*dst, _ = cmd.Flags().GetString(flag)
+			}
+		}
+		if cmd.Flags().Changed("approver") {
+			tmpl.Approvers, _ = cmd.Flags().GetStringSlice("approver")
+		}
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		tmpl.Labels = append(tmpl.Labels, labels...)
+		decisionContext, _ := cmd.Flags().GetString("context")
+
+		req, err := decisionRequest(&tmpl, name, decisionContext, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		resp, err := client.CreateBead(ctx, req)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			printBeadJSON(resp.GetBead())
+			return nil
+		}
+		fmt.Printf("Created decision %s: %s\n", resp.GetBead().GetId(), resp.GetBead().GetTitle())
+		return nil
+	},
+}
+
+// decisionRequest builds the CreateBead request for a decision from tmpl,
+// filled with decisionContext, expiring tmpl.ExpiresIn after now. template
+// names the template used, if any, and is recorded on the decision.
+func decisionRequest(tmpl *model.DecisionTemplate, template, decisionContext string, now time.Time) (*beadsv1.CreateBeadRequest, error) {
+	if err := tmpl.Validate(); err != nil {
+		if len(tmpl.Options) == 0 {
+			return nil, fmt.Errorf("a decision needs options: pass --option or --template")
+		}
+		return nil, err
+	}
+	title, prompt := tmpl.Render(decisionContext)
+	if title == "" {
+		return nil, fmt.Errorf("a title is required: pass one, --context or a template with a title")
+	}
+
+	fields := map[string]any{"options": tmpl.Options}
+	if tmpl.DefaultOption != "" {
+		fields["default_option"] = tmpl.DefaultOption
+	}
+	if len(tmpl.Approvers) > 0 {
+		fields["approvers"] = tmpl.Approvers
+	}
+	if template != "" {
+		fields["template"] = template
+	}
+	if tmpl.ExpiresIn != "" {
+		d, _ := time.ParseDuration(tmpl.ExpiresIn) // checked by Validate
+		fields["expires_at"] = now.UTC().Add(d).Truncate(time.Second).Format(time.RFC3339)
+	}
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	priority := int32(2)
+	if p, ok := model.UrgencyPriority(tmpl.Urgency); ok {
+		priority = int32(p)
+	}
+	return &beadsv1.CreateBeadRequest{
+		Title:       title,
+		Description: prompt,
+		Type:        "decision",
+		Priority:    priority,
+		Labels:      tmpl.Labels,
+		CreatedBy:   actor,
+		Fields:      fieldsJSON,
+	}, nil
+}
+
+var decisionShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show a decision with its options, diff, links and linked beads",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.GetDecisionContext(context.Background(), &beadsv1.GetDecisionContextRequest{Id: args[0]})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			printJSON(resp)
+			return nil
+		}
+		printDecisionContext(os.Stdout, resp)
+		return nil
+	},
+}
+
+var decisionResolveCmd = &cobra.Command{
+	Use:   "resolve <id> <option>",
+	Short: "Choose a decision's option and close it",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.ResolveDecision(context.Background(), &beadsv1.ResolveDecisionRequest{
+			Id:         args[0],
+			Option:     args[1],
+			ResolvedBy: actor,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			printBeadJSON(resp.GetBead())
+			return nil
+		}
+		fmt.Printf("Resolved %s: %s\n", resp.GetBead().GetId(), args[1])
+		return nil
+	},
+}
+
+func init() {
+	decisionCreateCmd.Flags().String("template", "", "decision template to start from (config decision-template:<name>)")
+	decisionCreateCmd.Flags().String("context", "", "text filling the template's {context} placeholder")
+	decisionCreateCmd.Flags().StringArray("option", nil, "an option (repeatable; replaces the template's)")
+	decisionCreateCmd.Flags().String("default", "", "option chosen when the decision expires")
+	decisionCreateCmd.Flags().String("urgency", "", "critical, high, normal or low; sets the priority")
+	decisionCreateCmd.Flags().StringSlice("approver", nil, "actor allowed to resolve it (repeatable; replaces the template's)")
+	decisionCreateCmd.Flags().String("expires-in", "", "duration until the decision expires, e.g. 4h")
+	decisionCreateCmd.Flags().StringSliceP("label", "l", nil, "labels (repeatable)")
+	decisionCmd.AddCommand(decisionCreateCmd)
+	decisionCmd.AddCommand(decisionShowCmd)
+	decisionCmd.AddCommand(decisionResolveCmd)
+}
+
+func printDecisionContext(w io.Writer, dc *beadsv1.GetDecisionContextResponse) {
+	d := dc.GetDecision()
+	fmt.Fprintf(w, "%s  %s (%s)\n", d.GetId(), d.GetTitle(), d.GetStatus())
+	if desc := d.GetDescription(); desc != "" {
+		fmt.Fprintf(w, "\n%s\n", desc)
+	}
+	if len(dc.GetOptions()) > 0 {
+		fmt.Fprintf(w, "\nOptions: %s\n", strings.Join(dc.GetOptions(), ", "))
+	}
+	if len(dc.GetLinks()) > 0 {
+		fmt.Fprintln(w, "\nLinks:")
+		for _, l := range dc.GetLinks() {
+			fmt.Fprintf(w, "  %s\n", l)
+		}
+	}
+	if len(dc.GetBeads()) > 0 || len(dc.GetMissing()) > 0 {
+		fmt.Fprintln(w, "\nContext:")
+		for _, b := range dc.GetBeads() {
+			fmt.Fprintf(w, "  %s  [%s] %s\n", b.GetId(), b.GetStatus(), b.GetTitle())
+		}
+		for _, id := range dc.GetMissing() {
+			fmt.Fprintf(w, "  %s  (missing)\n", id)
+		}
+	}
+	if diff := dc.GetDiff(); diff != "" {
+		fmt.Fprintf(w, "\nDiff:\n%s\n", diff)
+	}
+}
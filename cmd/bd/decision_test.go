@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestPrintDecisionContext(t *testing.T) {
+	var out strings.Builder
+	printDecisionContext(&out, &beadsv1.GetDecisionContextResponse{
+		Decision: &beadsv1.Bead{Id: "bd-d1", Title: "Quarantine the test?", Status: "open"},
+		Options:  []string{"quarantine", "fix now"},
+		Links:    []string{"https://ci.example.com/run/42"},
+		Beads:    []*beadsv1.BeadSummary{{Id: "bd-ctx1", Title: "Flaky login test", Status: "in_progress"}},
+		Missing:  []string{"bd-gone"},
+		Diff:     "-retries: 0\n+retries: 3",
+	})
+	for _, want := range []string{
+		"bd-d1  Quarantine the test? (open)",
+		"Options: quarantine, fix now",
+		"  https://ci.example.com/run/42",
+		"  bd-ctx1  [in_progress] Flaky login test",
+		"  bd-gone  (missing)",
+		"Diff:\n-retries: 0\n+retries: 3",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestDecisionRequest(t *testing.T) {
+	tmpl := model.DecisionTemplate{
+		Title:         "Deploy {context}?",
+		Prompt:        "Approve deploying {context} to production.",
+		Options:       []string{"approve", "reject", "defer"},
+		DefaultOption: "defer",
+		Urgency:       "high",
+		Approvers:     []string{"alice", "bob"},
+		ExpiresIn:     "4h",
+		Labels:        []string{"deploy"},
+	}
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	req, err := decisionRequest(&tmpl, "deploy-approval", "api v2.3", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.GetTitle() != "Deploy api v2.3?" || req.GetDescription() != "Approve deploying api v2.3 to production." ||
+		req.GetType() != "decision" || req.GetPriority() != 1 || !slices.Equal(req.GetLabels(), []string{"deploy"}) {
+		t.Fatalf("request = %+v", req)
+	}
+	var fields struct {
+		Options       []string `json:"options"`
+		DefaultOption string   `json:"default_option"`
+		Approvers     []string `json:"approvers"`
+		Template      string   `json:"template"`
+		ExpiresAt     string   `json:"expires_at"`
+	}
+	if err := json.Unmarshal(req.GetFields(), &fields); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(fields.Options, tmpl.Options) || fields.DefaultOption != "defer" || !slices.Equal(fields.Approvers, tmpl.Approvers) ||
+		fields.Template != "deploy-approval" || fields.ExpiresAt != "2026-03-02T13:00:00Z" {
+		t.Fatalf("fields = %+v", fields)
+	}
+
+	if _, err := decisionRequest(&model.DecisionTemplate{Title: "Ship?"}, "", "", now); err == nil || !strings.Contains(err.Error(), "--option") {
+		t.Fatalf("expected a missing options error, got %v", err)
+	}
+	if _, err := decisionRequest(&model.DecisionTemplate{Options: []string{"yes", "no"}}, "", "", now); err == nil {
+		t.Fatal("expected a missing title error")
+	}
+}
@@ -1,31 +1,103 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var deleteCmd = &cobra.Command{
-	Use:     "delete <id>...",
-	Short:   "Delete one or more beads",
+	Use:   "delete <id>...",
+	Short: "Delete one or more beads",
+	Long: `Delete one or more beads.
+
+Deleted beads go to the trash, where they can be restored until the server
+purges them (BEADS_TRASH_RETENTION). Use --hard to delete permanently.
+
+A bead that other beads depend on (through a blocking or parent-child
+dependency) is not deleted unless --cascade is given:
+  detach  remove the inbound dependencies and keep the dependent beads
+  delete  also delete every bead that transitively depends on it
+Relations and other non-blocking links to a deleted bead are always removed.
+
+Without --cascade on an interactive terminal, bd lists the dependents and asks
+which to do.`,
 	GroupID: "beads",
-	Args:  cobra.MinimumNArgs(1),
+	Args:    cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		cascade, _ := cmd.Flags().GetString("cascade")
+		hard, _ := cmd.Flags().GetBool("hard")
+		interactive := cascade == "" && term.IsTerminal(int(os.Stdin.Fd()))
+
 		for _, id := range args {
-			_, err := client.DeleteBead(context.Background(), &beadsv1.DeleteBeadRequest{
-				Id: id,
+			resp, err := client.DeleteBead(context.Background(), &beadsv1.DeleteBeadRequest{
+				Id:        id,
+				Cascade:   cascade,
+				Hard:      hard,
+				DeletedBy: actor,
 			})
+			if status.Code(err) == codes.FailedPrecondition && interactive {
+				fmt.Fprintln(os.Stderr, status.Convert(err).Message())
+				choice := promptCascade(os.Stdin, os.Stderr)
+				if choice == "" {
+					fmt.Fprintf(os.Stderr, "Skipped %s\n", id)
+					continue
+				}
+				resp, err = client.DeleteBead(context.Background(), &beadsv1.DeleteBeadRequest{
+					Id:        id,
+					Cascade:   choice,
+					Hard:      hard,
+					DeletedBy: actor,
+				})
+			}
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error deleting %s: %v\n", id, err)
 				os.Exit(1)
 			}
 
-			fmt.Printf("Deleted %s\n", id)
+			for _, d := range resp.GetDetached() {
+				fmt.Printf("Detached %s -> %s (%s)\n", d.BeadId, d.DependsOnId, d.Type)
+			}
+			deleted := resp.GetDeletedIds()
+			if len(deleted) == 0 {
+				deleted = []string{id}
+			}
+			for _, d := range deleted {
+				if hard {
+					fmt.Printf("Deleted %s\n", d)
+				} else {
+					fmt.Printf("Moved %s to trash\n", d)
+				}
+			}
 		}
 		return nil
 	},
 }
+
+// promptCascade asks how to handle a bead's dependents and returns the chosen
+// cascade mode, or "" to abort.
+func promptCascade(r io.Reader, w io.Writer) string {
+	fmt.Fprint(w, "[d]etach dependents, delete [a]ll dependents, or abort [N]: ")
+	line, _ := bufio.NewReader(r).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "d", "detach":
+		return "detach"
+	case "a", "all", "delete":
+		return "delete"
+	}
+	return ""
+}
+
+func init() {
+	deleteCmd.Flags().String("cascade", "", "handle dependents: detach or delete")
+	deleteCmd.Flags().Bool("hard", false, "delete permanently instead of moving to the trash")
+}
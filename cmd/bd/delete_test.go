@@ -0,0 +1,22 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPromptCascade(t *testing.T) {
+	for in, want := range map[string]string{
+		"d\n":      "detach",
+		"detach\n": "detach",
+		"A\n":      "delete",
+		"\n":       "",
+		"n\n":      "",
+		"":         "",
+	} {
+		if got := promptCascade(strings.NewReader(in), io.Discard); got != want {
+			t.Errorf("promptCascade(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"text/tabwriter"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/server"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
 )
 
 var depCmd = &cobra.Command{
@@ -20,18 +22,30 @@ var depCmd = &cobra.Command{
 var depAddCmd = &cobra.Command{
 	Use:   "add <bead-id> <depends-on-id>",
 	Short: "Add a dependency between beads",
-	Args:  cobra.ExactArgs(2),
+	Long: `Adds a dependency. Types beyond the builtin ones are defined with deptype:
+configs; a blocking type keeps the bead out of bd ready until the other bead
+closes:
+
+  bd config create deptype:needs-review '{"blocking":true,"label":"awaiting review from"}'
+  bd dep add kd-abc kd-def --type needs-review --metadata '{"reviewer":"bob"}'`,
+	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		beadID := args[0]
 		dependsOnID := args[1]
 		depType, _ := cmd.Flags().GetString("type")
+		metadata, _ := cmd.Flags().GetString("metadata")
 
 		resp, err := client.AddDependency(context.Background(), &beadsv1.AddDependencyRequest{
 			BeadId:      beadID,
 			DependsOnId: dependsOnID,
 			Type:        depType,
 			CreatedBy:   actor,
+			Metadata:    metadata,
 		})
+		if errorCode(err) == server.CodeDependencyCycle {
+			fmt.Fprintf(os.Stderr, "Error: %s would block itself: %s\n", beadID, status.Convert(err).Message())
+			os.Exit(1)
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -53,6 +67,47 @@ var depAddCmd = &cobra.Command{
 			if dep.GetCreatedAt() != nil {
 				fmt.Printf("Created At:  %s\n", dep.GetCreatedAt().AsTime().Format("2006-01-02 15:04:05"))
 			}
+			if dep.GetMetadata() != "" {
+				fmt.Printf("Metadata:    %s\n", dep.GetMetadata())
+			}
+		}
+		return nil
+	},
+}
+
+var depMetaCmd = &cobra.Command{
+	Use:   "meta <bead-id> <depends-on-id> [json]",
+	Short: "Set or clear the metadata on a dependency",
+	Long: `Replaces a dependency's metadata with a JSON object. With no JSON argument
+the metadata is cleared.`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		depType, _ := cmd.Flags().GetString("type")
+		req := &beadsv1.UpdateDependencyRequest{
+			BeadId:      args[0],
+			DependsOnId: args[1],
+			Type:        depType,
+			UpdatedBy:   actor,
+		}
+		if len(args) == 3 {
+			req.Metadata = args[2]
+		}
+
+		resp, err := client.UpdateDependency(context.Background(), req)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			data, _ := json.MarshalIndent(resp.GetDependency(), "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		if req.Metadata == "" {
+			fmt.Println("Cleared dependency metadata")
+		} else {
+			fmt.Println("Updated dependency metadata")
 		}
 		return nil
 	},
@@ -111,17 +166,18 @@ var depListCmd = &cobra.Command{
 				return nil
 			}
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			fmt.Fprintln(w, "DEPENDS_ON\tTYPE\tCREATED_BY\tCREATED_AT")
+			fmt.Fprintln(w, "DEPENDS_ON\tTYPE\tCREATED_BY\tCREATED_AT\tMETADATA")
 			for _, d := range deps {
 				createdAt := ""
 				if d.GetCreatedAt() != nil {
 					createdAt = d.GetCreatedAt().AsTime().Format("2006-01-02 15:04:05")
 				}
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
 					d.GetDependsOnId(),
 					d.GetType(),
 					d.GetCreatedBy(),
 					createdAt,
+					d.GetMetadata(),
 				)
 			}
 			w.Flush()
@@ -132,9 +188,12 @@ var depListCmd = &cobra.Command{
 
 func init() {
 	depAddCmd.Flags().StringP("type", "t", "blocks", "dependency type")
+	depAddCmd.Flags().String("metadata", "", "edge metadata as a JSON object")
+	depMetaCmd.Flags().StringP("type", "t", "blocks", "dependency type")
 	depRemoveCmd.Flags().StringP("type", "t", "blocks", "dependency type")
 
 	depCmd.AddCommand(depAddCmd)
+	depCmd.AddCommand(depMetaCmd)
 	depCmd.AddCommand(depRemoveCmd)
 	depCmd.AddCommand(depListCmd)
 }
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest <owner:name>",
+	Short: "Show what is new in a saved search subscription",
+	Long: `Shows the latest digest of a saved search subscription: the beads that
+started matching its filter since the previous digest. Subscriptions are
+configs, for example:
+
+  bd config create subscription:alice:overdue-backend \
+    '{"filter":{"status":["open"],"labels":["backend"]},"every":"24h"}'
+  bd digest alice:overdue-backend`,
+	GroupID: "views",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		refresh, _ := cmd.Flags().GetBool("refresh")
+
+		resp, err := client.GetDigest(context.Background(), &beadsv1.GetDigestRequest{
+			Name:    args[0],
+			Refresh: refresh,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printBeadListJSON(resp.GetNew())
+			return nil
+		}
+
+		generated := resp.GetGeneratedAt().AsTime().Local().Format("2006-01-02 15:04")
+		fmt.Printf("Digest %s (%s): %d new of %d matching\n\n", resp.GetSubscription(), generated, len(resp.GetNew()), resp.GetTotal())
+		if len(resp.GetNew()) == 0 {
+			fmt.Println("Nothing new.")
+			return nil
+		}
+		printBeadListTable(resp.GetNew(), resp.GetTotal())
+		return nil
+	},
+}
+
+func init() {
+	digestCmd.Flags().Bool("refresh", false, "generate a fresh digest now instead of showing the latest")
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/spf13/cobra"
+)
+
+// eventPage mirrors the server's GET /v1/events response.
+type eventPage struct {
+	Events []struct {
+		ID        int64     `json:"id"`
+		Topic     string    `json:"topic"`
+		BeadID    string    `json:"bead_id"`
+		Actor     string    `json:"actor"`
+		CreatedAt time.Time `json:"created_at"`
+	} `json:"events"`
+	NextAfter int64 `json:"next_after"`
+}
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Read the global event log",
+	Long: `Prints recorded events across all beads, oldest first:
+
+  bd events --since 2h --topic beads.bead.closed
+  bd events --after 1200 --all
+
+--since takes a duration back from now or an RFC 3339 time. Without --all,
+prints one page; --after resumes from the last event ID seen.`,
+	GroupID: "views",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd events", server.FeatureEventPagination)
+		q := url.Values{}
+		for flag, param := range map[string]string{"topic": "topic", "bead": "bead_id", "by": "actor", "label": "label", "project": "project"} {
+			if v, _ := cmd.Flags().GetString(flag); v != "" {
+				q.Set(param, v)
+			}
+		}
+		if v, _ := cmd.Flags().GetString("since"); v != "" {
+			since, err := parseSince(v, time.Now())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			q.Set("since", since.Format(time.RFC3339))
+		}
+		if n, _ := cmd.Flags().GetInt("limit"); n > 0 {
+			q.Set("limit", strconv.Itoa(n))
+		}
+		after, _ := cmd.Flags().GetInt64("after")
+		all, _ := cmd.Flags().GetBool("all")
+
+		ctx := context.Background()
+		for {
+			if after > 0 {
+				q.Set("after", strconv.FormatInt(after, 10))
+			}
+			body, err := httpGet(ctx, "/v1/events?"+q.Encode())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			var page eventPage
+			if err := json.Unmarshal(body, &page); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid event page: %v\n", err)
+				os.Exit(1)
+			}
+			if jsonOutput {
+				printJSON(page.Events)
+			} else {
+				printEvents(os.Stdout, &page)
+			}
+			if !all || page.NextAfter == 0 {
+				return nil
+			}
+			after = page.NextAfter
+		}
+	},
+}
+
+func init() {
+	eventsCmd.Flags().String("since", "", "only events since this long ago (e.g. 2h) or this RFC 3339 time")
+	eventsCmd.Flags().String("topic", "", "comma-separated topics to include")
+	eventsCmd.Flags().String("bead", "", "only events on this bead")
+	eventsCmd.Flags().String("by", "", "only events recorded by this actor")
+	eventsCmd.Flags().String("label", "", "only events on beads with this label")
+	eventsCmd.Flags().String("project", "", "only events on this bead and its children")
+	eventsCmd.Flags().Int64("after", 0, "only events after this event ID")
+	eventsCmd.Flags().Int("limit", 0, "events per page (server default 100)")
+	eventsCmd.Flags().Bool("all", false, "follow pages until the end of the log")
+}
+
+// parseSince reads --since as a duration back from now or an RFC 3339 time.
+func parseSince(v string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(v); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--since must be a duration or RFC 3339 time, got %q", v)
+	}
+	return t, nil
+}
+
+func printEvents(w io.Writer, page *eventPage) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, e := range page.Events {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", e.ID, e.CreatedAt.Local().Format("2006-01-02 15:04:05"), e.Topic, e.BeadID, e.Actor)
+	}
+	tw.Flush()
+}
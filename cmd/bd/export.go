@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+// exportPageSize is how many beads bd export fetches per ListBeads call.
+const exportPageSize = 500
+
+var exportCmd = &cobra.Command{
+	Use:     "export",
+	Short:   "Export every matching bead as CSV, Markdown or JSON",
+	GroupID: "beads",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, _ := cmd.Flags().GetStringSlice("status")
+		beadType, _ := cmd.Flags().GetStringSlice("type")
+		kind, _ := cmd.Flags().GetStringSlice("kind")
+		assignee, _ := cmd.Flags().GetString("assignee")
+
+		format, columns, err := listFormatFromFlags(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		req := &beadsv1.ListBeadsRequest{
+			Status:   status,
+			Type:     beadType,
+			Kind:     kind,
+			Assignee: assignee,
+			Limit:    exportPageSize,
+			Sort:     "id",
+		}
+		var beads []*beadsv1.Bead
+		var total int32
+		for {
+			resp, err := client.ListBeads(context.Background(), req)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			beads = append(beads, resp.GetBeads()...)
+			total = resp.GetTotal()
+			if len(resp.GetBeads()) < exportPageSize || int32(len(beads)) >= total {
+				break
+			}
+			req.Offset += exportPageSize
+		}
+
+		printBeadList(beads, total, format, columns)
+		return nil
+	},
+}
+
+func init() {
+	addListFormatFlags(exportCmd, "csv")
+	exportCmd.Flags().StringSliceP("status", "s", nil, "filter by status (repeatable)")
+	exportCmd.Flags().StringSliceP("type", "t", nil, "filter by type (repeatable)")
+	exportCmd.Flags().StringSliceP("kind", "k", nil, "filter by kind (repeatable)")
+	exportCmd.Flags().String("assignee", "", "filter by assignee")
+}
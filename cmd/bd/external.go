@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var externalCmd = &cobra.Command{
+	Use:   "external",
+	Short: "Manage a bead's dependencies on external URLs",
+	Long: `An external dependency makes a bead wait on something outside the bead
+graph. While it is waiting the bead is not ready:
+
+  bd external add kd-api https://github.com/acme/sdk/pull/42 --probe github-pr
+  bd external add kd-deploy https://staging.example.com/healthz --probe http
+  bd external satisfy kd-api 1
+
+With a probe, the server marks the dependency satisfied itself: http once the
+URL answers GET with 200, github-pr once the pull request is merged.`,
+	GroupID: "beads",
+}
+
+// externalRef parses the <bead-id> <id> arguments.
+func externalRef(args []string) (string, int64) {
+	id, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid external dependency id %q\n", args[1])
+		os.Exit(1)
+	}
+	return args[0], id
+}
+
+var externalAddCmd = &cobra.Command{
+	Use:   "add <bead-id> <url>",
+	Short: "Make a bead wait on an external URL",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		desc, _ := cmd.Flags().GetString("desc")
+		probe, _ := cmd.Flags().GetString("probe")
+		resp, err := client.AddExternalDep(context.Background(), &beadsv1.AddExternalDepRequest{
+			BeadId:      args[0],
+			Url:         args[1],
+			Description: desc,
+			Probe:       probe,
+			CreatedBy:   actor,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printJSON(resp.GetDep())
+		} else {
+			fmt.Printf("%s waits on %s (#%d)\n", args[0], args[1], resp.GetDep().GetId())
+		}
+		return nil
+	},
+}
+
+var externalListCmd = &cobra.Command{
+	Use:   "list <bead-id>",
+	Short: "List a bead's external dependencies",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.ListExternalDeps(context.Background(), &beadsv1.ListExternalDepsRequest{BeadId: args[0]})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printJSON(resp.GetDeps())
+			return nil
+		}
+		if len(resp.GetDeps()) == 0 {
+			fmt.Println("No external dependencies.")
+			return nil
+		}
+		printExternalDeps(resp.GetDeps())
+		return nil
+	},
+}
+
+// setExternalStatus returns a command that sets an external dependency's
+// status.
+func setExternalStatus(use, short, st string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " <bead-id> <id>",
+		Short: short,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			beadID, id := externalRef(args)
+			resp, err := client.UpdateExternalDep(context.Background(), &beadsv1.UpdateExternalDepRequest{
+				BeadId:    beadID,
+				Id:        id,
+				Status:    st,
+				UpdatedBy: actor,
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			if jsonOutput {
+				printJSON(resp.GetDep())
+			} else {
+				fmt.Printf("%s: %s is %s\n", beadID, resp.GetDep().GetUrl(), resp.GetDep().GetStatus())
+			}
+			return nil
+		},
+	}
+}
+
+var externalRemoveCmd = &cobra.Command{
+	Use:   "remove <bead-id> <id>",
+	Short: "Remove an external dependency",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		beadID, id := externalRef(args)
+		_, err := client.RemoveExternalDep(context.Background(), &beadsv1.RemoveExternalDepRequest{
+			BeadId:    beadID,
+			Id:        id,
+			RemovedBy: actor,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Removed external dependency #%d from %s\n", id, beadID)
+		return nil
+	},
+}
+
+func init() {
+	externalAddCmd.Flags().String("desc", "", "what the bead is waiting for")
+	externalAddCmd.Flags().String("probe", "", "let the server check it: http or github-pr")
+
+	externalCmd.AddCommand(externalAddCmd)
+	externalCmd.AddCommand(externalListCmd)
+	externalCmd.AddCommand(setExternalStatus("satisfy", "Mark an external dependency satisfied", "satisfied"))
+	externalCmd.AddCommand(setExternalStatus("wait", "Mark an external dependency waiting again", "waiting"))
+	externalCmd.AddCommand(externalRemoveCmd)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// forensicsReport mirrors the server's GET /v1/agents/{id}/forensics
+// response, keeping the fields the text report prints.
+type forensicsReport struct {
+	Agent     string           `json:"agent"`
+	AgentBead *forensicsBead   `json:"agent_bead"`
+	Presence  []forensicsEvent `json:"presence"`
+	Held      []forensicsBead  `json:"held"`
+	Events    []forensicsEvent `json:"events"`
+	Comments  []forensicsNote  `json:"comments"`
+	Gates     []forensicsGate  `json:"gates"`
+}
+
+type forensicsBead struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+type forensicsEvent struct {
+	Topic     string    `json:"topic"`
+	BeadID    string    `json:"bead_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type forensicsNote struct {
+	BeadID    string    `json:"bead_id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type forensicsGate struct {
+	Name      string `json:"name"`
+	Severity  string `json:"severity"`
+	Satisfied bool   `json:"satisfied"`
+}
+
+var agentForensicsCmd = &cobra.Command{
+	Use:   "forensics <actor>",
+	Short: "Show what an agent was doing, so another can pick up its work",
+	Long: `Prints one report of an agent's last known context: the in-progress beads
+assigned to it, its latest events and comments, recent events on its agent
+bead, and its gate states. Any actor can be named; the agent bead and gates
+are only shown for registered agents.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		body, err := fetchForensics(context.Background(), args[0], limit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			fmt.Println(string(body))
+			return nil
+		}
+
+		var report forensicsReport
+		if err := json.Unmarshal(body, &report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid forensics report: %v\n", err)
+			os.Exit(1)
+		}
+		printForensics(os.Stdout, &report)
+		return nil
+	},
+}
+
+func init() {
+	agentForensicsCmd.Flags().Int("limit", 20, "events and comments to include")
+	agentCmd.AddCommand(agentForensicsCmd)
+}
+
+// fetchForensics downloads the forensics report for actor.
+func fetchForensics(ctx context.Context, actor string, limit int) ([]byte, error) {
+	path := "/v1/agents/" + url.PathEscape(actor) + "/forensics"
+	if limit > 0 {
+		path += "?limit=" + strconv.Itoa(limit)
+	}
+	return httpGet(ctx, path)
+}
+
+func printForensics(w io.Writer, r *forensicsReport) {
+	const ts = "2006-01-02 15:04:05"
+	fmt.Fprintf(w, "Agent:       %s\n", r.Agent)
+	if r.AgentBead != nil {
+		fmt.Fprintf(w, "Agent Bead:  %s (%s)\n", r.AgentBead.ID, r.AgentBead.Status)
+	}
+	if len(r.Presence) > 0 {
+		fmt.Fprintf(w, "Last Seen:   %s (%s)\n", r.Presence[0].CreatedAt.Format(ts), r.Presence[0].Topic)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Held:")
+	if len(r.Held) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	for _, b := range r.Held {
+		fmt.Fprintf(w, "  %s  %s\n", b.ID, b.Title)
+	}
+
+	if len(r.Gates) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Gates:")
+		for _, g := range r.Gates {
+			mark := " "
+			if g.Satisfied {
+				mark = "x"
+			}
+			fmt.Fprintf(w, "  [%s] %s (%s)\n", mark, g.Name, g.Severity)
+		}
+	}
+
+	if len(r.Comments) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Recent Comments:")
+		for _, c := range r.Comments {
+			fmt.Fprintf(w, "  [%s] %s: %s\n", c.CreatedAt.Format(ts), c.BeadID, c.Text)
+		}
+	}
+
+	if len(r.Events) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Recent Events:")
+		for _, e := range r.Events {
+			fmt.Fprintf(w, "  [%s] %s %s\n", e.CreatedAt.Format(ts), e.Topic, e.BeadID)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchForensics(t *testing.T) {
+	var gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.RequestURI()
+		w.Write([]byte(`{"agent":"crew/bot"}`))
+	}))
+	defer ts.Close()
+	t.Setenv("BEADS_HTTP_URL", ts.URL)
+
+	if _, err := fetchForensics(context.Background(), "crew/bot", 5); err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/v1/agents/crew%2Fbot/forensics?limit=5" {
+		t.Fatalf("path = %q", gotPath)
+	}
+}
+
+func TestPrintForensics(t *testing.T) {
+	var r forensicsReport
+	if err := json.Unmarshal([]byte(`{
+		"agent": "crew/bot",
+		"agent_bead": {"id": "bd-agent", "status": "open"},
+		"presence": [{"topic": "beads.bead.updated", "bead_id": "bd-agent", "created_at": "2026-01-02T03:04:05Z"}],
+		"held": [{"id": "bd-work", "title": "Port the parser"}],
+		"comments": [{"bead_id": "bd-work", "text": "lexer done", "created_at": "2026-01-02T03:00:00Z"}],
+		"gates": [{"name": "tests-passed", "severity": "block", "satisfied": true}]
+	}`), &r); err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	printForensics(&out, &r)
+	for _, want := range []string{
+		"Agent Bead:  bd-agent (open)",
+		"Last Seen:   2026-01-02 03:04:05 (beads.bead.updated)",
+		"  bd-work  Port the parser",
+		"  [x] tests-passed (block)",
+		"  [2026-01-02 03:00:00] bd-work: lexer done",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, out.String())
+		}
+	}
+}
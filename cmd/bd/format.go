@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+// listFormats are the values accepted by --format on list commands.
+var listFormats = []string{"table", "json", "csv", "md"}
+
+// beadColumns are the column names accepted by --columns.
+var beadColumns = []string{"id", "title", "status", "type", "kind", "priority", "effective_priority", "assignee", "owner", "created_by", "labels", "checklist", "estimate", "actual"}
+
+// defaultColumns mirror printBeadListTable.
+var defaultColumns = []string{"id", "status", "type", "priority", "title", "assignee"}
+
+// addListFormatFlags registers --format and --columns on a list command.
+func addListFormatFlags(cmd *cobra.Command, def string) {
+	cmd.Flags().String("format", def, "output format: table, json, csv or md")
+	cmd.Flags().StringSlice("columns", nil, "columns to print (e.g. id,title,assignee,priority)")
+}
+
+// listFormatFromFlags reads --format and --columns, validating both. --json
+// selects the json format unless --format says otherwise. Without --columns
+// the columns preference applies.
+func listFormatFromFlags(cmd *cobra.Command) (string, []string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	columns, _ := cmd.Flags().GetStringSlice("columns")
+	if len(prefs.Columns) > 0 && !cmd.Flags().Changed("columns") {
+		columns = slices.Clone(prefs.Columns)
+	}
+	if jsonOutput && !cmd.Flags().Changed("format") {
+		format = "json"
+	}
+	format = strings.ToLower(format)
+	if format == "markdown" {
+		format = "md"
+	}
+	if !slices.Contains(listFormats, format) {
+		return "", nil, fmt.Errorf("unknown format %q (want one of %s)", format, strings.Join(listFormats, ", "))
+	}
+	for i, c := range columns {
+		columns[i] = strings.ToLower(strings.TrimSpace(c))
+		if !slices.Contains(beadColumns, columns[i]) {
+			return "", nil, fmt.Errorf("unknown column %q (want one of %s)", c, strings.Join(beadColumns, ", "))
+		}
+	}
+	return format, columns, nil
+}
+
+// printBeadList prints beads to stdout in format. Columns apply to the table,
+// csv and md formats; json always prints whole beads.
+func printBeadList(beads []*beadsv1.Bead, total int32, format string, columns []string) {
+	switch format {
+	case "json":
+		printBeadListJSON(beads)
+	case "csv":
+		if err := writeBeadListCSV(os.Stdout, beads, orDefaultColumns(columns)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing CSV: %v\n", err)
+		}
+	case "md":
+		writeBeadListMarkdown(os.Stdout, beads, orDefaultColumns(columns))
+	default:
+		if len(columns) > 0 {
+			printBeadListColumns(beads, total, columns)
+		} else {
+			printBeadListTable(beads, total)
+		}
+	}
+}
+
+func orDefaultColumns(columns []string) []string {
+	if len(columns) == 0 {
+		return defaultColumns
+	}
+	return columns
+}
+
+// writeBeadListCSV writes a header row and one record per bead. Values are
+// not truncated.
+func writeBeadListCSV(w io.Writer, beads []*beadsv1.Bead, columns []string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, b := range beads {
+		rec := make([]string, len(columns))
+		for i, col := range columns {
+			rec[i] = beadValue(b, col)
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeBeadListMarkdown writes a GitHub-flavoured Markdown table.
+func writeBeadListMarkdown(w io.Writer, beads []*beadsv1.Bead, columns []string) {
+	headers := make([]string, len(columns))
+	rule := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c
+		rule[i] = "---"
+	}
+	fmt.Fprintf(w, "| %s |\n", strings.Join(headers, " | "))
+	fmt.Fprintf(w, "| %s |\n", strings.Join(rule, " | "))
+	for _, b := range beads {
+		vals := make([]string, len(columns))
+		for i, col := range columns {
+			vals[i] = markdownCell(beadValue(b, col))
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(vals, " | "))
+	}
+}
+
+// markdownCell escapes a value so it stays inside one table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	return strings.ReplaceAll(s, "\n", " ")
+}
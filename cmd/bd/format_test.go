@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var formatTestBeads = []*beadsv1.Bead{
+	{Id: "bd-1", Title: "Fix login, again", Assignee: "alice", Priority: 1},
+	{Id: "bd-2", Title: "Pipes | and\nnewlines", Priority: 3},
+}
+
+func TestWriteBeadListCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeBeadListCSV(&buf, formatTestBeads, []string{"id", "title", "assignee", "priority"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "id,title,assignee,priority\n" +
+		"bd-1,\"Fix login, again\",alice,1\n" +
+		"bd-2,\"Pipes | and\nnewlines\",,3\n"
+	if buf.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteBeadListMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	writeBeadListMarkdown(&buf, formatTestBeads, []string{"id", "title"})
+	want := "| id | title |\n" +
+		"| --- | --- |\n" +
+		"| bd-1 | Fix login, again |\n" +
+		"| bd-2 | Pipes \\| and newlines |\n"
+	if buf.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteBeadListCSVKeepsLongTitles(t *testing.T) {
+	long := strings.Repeat("x", 80)
+	var buf bytes.Buffer
+	if err := writeBeadListCSV(&buf, []*beadsv1.Bead{{Id: "bd-1", Title: long}}, []string{"title"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), long) {
+		t.Fatalf("title was truncated: %q", buf.String())
+	}
+}
+
+func TestListFormatFromFlags(t *testing.T) {
+	parse := func(def string, json bool, args ...string) (string, []string, error) {
+		t.Helper()
+		cmd := &cobra.Command{}
+		addListFormatFlags(cmd, def)
+		if err := cmd.Flags().Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		old := jsonOutput
+		jsonOutput = json
+		defer func() { jsonOutput = old }()
+		return listFormatFromFlags(cmd)
+	}
+
+	if f, _, _ := parse("table", false); f != "table" {
+		t.Errorf("default format = %q", f)
+	}
+	if f, _, _ := parse("csv", true); f != "json" {
+		t.Errorf("--json format = %q", f)
+	}
+	if f, _, _ := parse("table", true, "--format", "md"); f != "md" {
+		t.Errorf("--format should win over --json, got %q", f)
+	}
+	if f, _, _ := parse("table", false, "--format", "markdown"); f != "md" {
+		t.Errorf("markdown alias = %q", f)
+	}
+	_, cols, err := parse("table", false, "--columns", "ID, Title,priority")
+	if err != nil || strings.Join(cols, ",") != "id,title,priority" {
+		t.Errorf("columns = %v, %v", cols, err)
+	}
+	if _, _, err := parse("table", false, "--format", "xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if _, _, err := parse("table", false, "--columns", "id,bogus"); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
+
+func TestListFormatFromFlags_ColumnsPref(t *testing.T) {
+	old := prefs
+	prefs = cliPrefs{Columns: []string{"id", "title"}}
+	defer func() { prefs = old }()
+
+	cmd := &cobra.Command{}
+	addListFormatFlags(cmd, "table")
+	if _, cols, err := listFormatFromFlags(cmd); err != nil || strings.Join(cols, ",") != "id,title" {
+		t.Errorf("preference columns = %v, %v", cols, err)
+	}
+	cmd = &cobra.Command{}
+	addListFormatFlags(cmd, "table")
+	_ = cmd.Flags().Parse([]string{"--columns", "status"})
+	if _, cols, _ := listFormatFromFlags(cmd); strings.Join(cols, ",") != "status" {
+		t.Errorf("--columns should win over the preference, got %v", cols)
+	}
+}
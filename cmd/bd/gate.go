@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/metadata"
+)
+
+var gateCmd = &cobra.Command{
+	Use:   "gate",
+	Short: "List, set and clear an agent's gates",
+	Long: `Gates are named checks an agent must pass, such as tests-passed. A role's
+checklist is a gate:<role> config, for example:
+
+  bd config create gate:crew '{"gates":[{"name":"tests-passed"},{"name":"commit-pushed","severity":"warn","hooks":["stop"]}]}'
+
+The agent defaults to --agent, then $BEADS_ACTOR.`,
+	GroupID: "workflow",
+}
+
+// gateAgent returns the --agent flag, defaulting to the current actor.
+func gateAgent(cmd *cobra.Command) string {
+	if agent, _ := cmd.Flags().GetString("agent"); agent != "" {
+		return agent
+	}
+	return actor
+}
+
+var gateListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"status"},
+	Short:   "List an agent's gates and whether each is satisfied or waived",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.ListGates(context.Background(), &beadsv1.ListGatesRequest{Agent: gateAgent(cmd)})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			data, _ := json.MarshalIndent(resp, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		fmt.Printf("%s (role %s)\n", resp.GetAgent(), resp.GetRole())
+		printGates(resp.GetGates())
+		return nil
+	},
+}
+
+func printGates(gates []*beadsv1.Gate) {
+	if len(gates) == 0 {
+		fmt.Println("No gates.")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GATE\tSEVERITY\tSTATE\tHOOKS\tBEAD")
+	var waivers []*beadsv1.Gate
+	for _, g := range gates {
+		state := "pending"
+		switch {
+		case g.GetSatisfied():
+			state = "satisfied"
+		case g.GetWaivedUntil() != nil:
+			state = "waived"
+			waivers = append(waivers, g)
+		}
+		hooks := strings.Join(g.GetHooks(), ",")
+		if hooks == "" {
+			hooks = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", g.GetName(), g.GetSeverity(), state, hooks, g.GetBeadId())
+	}
+	w.Flush()
+	for _, g := range waivers {
+		until := g.GetWaivedUntil().AsTime()
+		fmt.Printf("  %s waived by %s until %s (%s left): %s\n", g.GetName(), g.GetWaivedBy(),
+			until.Local().Format("2006-01-02 15:04"), time.Until(until).Round(time.Minute), g.GetWaiveReason())
+	}
+}
+
+// setGate marks a gate satisfied or unsatisfied and reports its new state.
+func setGate(cmd *cobra.Command, gate string, satisfied bool) {
+	resp, err := client.SetGate(context.Background(), &beadsv1.SetGateRequest{
+		Agent:     gateAgent(cmd),
+		Gate:      gate,
+		Satisfied: satisfied,
+		Actor:     actor,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOutput {
+		data, _ := json.MarshalIndent(resp.GetGate(), "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+	state := "cleared"
+	if satisfied {
+		state = "satisfied"
+	}
+	fmt.Printf("Gate %s %s (%s)\n", gate, state, resp.GetGate().GetBeadId())
+}
+
+var gateSetCmd = &cobra.Command{
+	Use:   "set <gate>",
+	Short: "Mark a gate satisfied",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		setGate(cmd, args[0], true)
+		return nil
+	},
+}
+
+var gateClearCmd = &cobra.Command{
+	Use:   "clear <gate>",
+	Short: "Mark a gate unsatisfied",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		setGate(cmd, args[0], false)
+		return nil
+	},
+}
+
+var gateWaiveCmd = &cobra.Command{
+	Use:   "waive <gate> --ttl 2h --reason <why>",
+	Short: "Suppress an unsatisfied gate for a while",
+	Long: `Waives a gate so it no longer blocks or warns hooks until the waiver
+expires (at most a week). Who waived it and why are recorded on the gate
+bead and shown by bd gate status.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ttl, _ := cmd.Flags().GetDuration("ttl")
+		reason, _ := cmd.Flags().GetString("reason")
+		resp, err := client.WaiveGate(context.Background(), &beadsv1.WaiveGateRequest{
+			Agent:  gateAgent(cmd),
+			Gate:   args[0],
+			Ttl:    ttl.String(),
+			Reason: reason,
+			Actor:  actor,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			data, _ := json.MarshalIndent(resp.GetGate(), "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+		printGates([]*beadsv1.Gate{resp.GetGate()})
+		return nil
+	},
+}
+
+var gateCheckCmd = &cobra.Command{
+	Use:   "check <hook>",
+	Short: "Evaluate the gates for a hook; exits 1 if one blocks it",
+	Long: `Emits a hook (POST /v1/hooks/emit) and prints the decision. Unsatisfied
+gates of severity warn are reported on stderr; any unsatisfied gate of
+severity block makes the command exit 1, so it can guard agent hooks:
+
+  bd gate check stop || exit 2
+
+An agent with a hook token (bd agent hook-token) must send it: it is read
+from BEADS_HOOK_TOKEN.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		if token := os.Getenv("BEADS_HOOK_TOKEN"); token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "x-beads-hook-token", token)
+		}
+		resp, err := client.EmitHook(ctx, &beadsv1.EmitHookRequest{
+			Agent: gateAgent(cmd),
+			Hook:  args[0],
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			data, _ := json.MarshalIndent(resp, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			fmt.Println(resp.GetDecision())
+		}
+		switch resp.GetDecision() {
+		case "block":
+			fmt.Fprintf(os.Stderr, "Blocked: %s\n", resp.GetMessage())
+			os.Exit(1)
+		case "warn":
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", resp.GetMessage())
+		}
+		return nil
+	},
+}
+
+func init() {
+	gateCmd.PersistentFlags().String("agent", "", "agent name (default $BEADS_ACTOR)")
+	gateCmd.AddCommand(gateListCmd)
+	gateCmd.AddCommand(gateSetCmd)
+	gateCmd.AddCommand(gateClearCmd)
+	gateWaiveCmd.Flags().Duration("ttl", 2*time.Hour, "how long the waiver lasts")
+	gateWaiveCmd.Flags().String("reason", "", "why the gate is waived (required)")
+	gateCmd.AddCommand(gateWaiveCmd)
+	gateCmd.AddCommand(gateCheckCmd)
+}
@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 var healthCmd = &cobra.Command{
@@ -15,13 +19,12 @@ var healthCmd = &cobra.Command{
 	Short:   "Check the health of the beads service",
 	GroupID: "system",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		resp, err := client.Health(context.Background(), &beadsv1.HealthRequest{})
+		status, err := checkHealth(context.Background())
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		status := resp.GetStatus()
 		if jsonOutput {
 			out := map[string]string{"status": status}
 			data, err := json.MarshalIndent(out, "", "  ")
@@ -40,3 +43,30 @@ var healthCmd = &cobra.Command{
 		return nil
 	},
 }
+
+// checkHealth queries the standard grpc.health.v1 service for BeadsService,
+// falling back to the BeadsService Health RPC on servers that predate it.
+// SERVING is reported as "ok"; other states are lowercased (e.g. "not_serving").
+func checkHealth(ctx context.Context) (string, error) {
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: beadsv1.BeadsService_ServiceDesc.ServiceName,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		legacy, err := client.Health(ctx, &beadsv1.HealthRequest{})
+		if err != nil {
+			return "", err
+		}
+		return legacy.GetStatus(), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return healthStatusString(resp.GetStatus()), nil
+}
+
+func healthStatusString(s healthpb.HealthCheckResponse_ServingStatus) string {
+	if s == healthpb.HealthCheckResponse_SERVING {
+		return "ok"
+	}
+	return strings.ToLower(s.String())
+}
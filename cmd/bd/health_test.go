@@ -0,0 +1,19 @@
+package main
+
+import (
+	"testing"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthStatusString(t *testing.T) {
+	for s, want := range map[healthpb.HealthCheckResponse_ServingStatus]string{
+		healthpb.HealthCheckResponse_SERVING:         "ok",
+		healthpb.HealthCheckResponse_NOT_SERVING:     "not_serving",
+		healthpb.HealthCheckResponse_SERVICE_UNKNOWN: "service_unknown",
+	} {
+		if got := healthStatusString(s); got != want {
+			t.Errorf("healthStatusString(%v) = %q, want %q", s, got, want)
+		}
+	}
+}
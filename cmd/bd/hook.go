@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/idgen"
+	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// gitHookMarker identifies post-commit hooks written by bd hook install-git,
+// which it may overwrite.
+const gitHookMarker = "# Installed by bd hook install-git"
+
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Link git commits to the beads their messages name",
+	Long: `bd hook install-git installs a post-commit hook in the current repository.
+After each commit it links the commit to every bead ID or slug in its message,
+e.g. "Fix token refresh (bd-a1b2c3)", so bd show lists the commit.`,
+	GroupID: "system",
+}
+
+var hookInstallGitCmd = &cobra.Command{
+	Use:   "install-git",
+	Short: "Install the post-commit hook in this git repository",
+	Args:  cobra.NoArgs,
+	// Installing needs no server.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+		prefix, _ := cmd.Flags().GetString("prefix")
+		out, err := git("rev-parse", "--git-path", "hooks")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: not in a git repository: %v\n", err)
+			os.Exit(1)
+		}
+		path, err := installGitHook(out, prefix, force)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Installed %s\n", path)
+		return nil
+	},
+}
+
+var hookPostCommitCmd = &cobra.Command{
+	Use:    "post-commit",
+	Short:  "Link HEAD to the beads its message names (run by the git hook)",
+	Args:   cobra.NoArgs,
+	Hidden: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !serverSupports(server.FeatureCommits) {
+			fmt.Fprintln(os.Stderr, "bd: the server does not support commit links; not linking")
+			return nil
+		}
+		prefix, _ := cmd.Flags().GetString("prefix")
+		head, err := git("log", "-1", "--format=%H%x00%an%x00%B")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		parts := strings.SplitN(head, "\x00", 3)
+		if len(parts) != 3 {
+			fmt.Fprintln(os.Stderr, "Error: unexpected git log output")
+			os.Exit(1)
+		}
+		sha, author, message := parts[0], parts[1], parts[2]
+		repo, err := git("config", "--get", "remote.origin.url")
+		if err != nil || repo == "" {
+			repo, _ = git("rev-parse", "--show-toplevel")
+		}
+
+		for _, id := range beadRefs(message, prefix) {
+			_, err := client.LinkCommit(context.Background(), &beadsv1.LinkCommitRequest{
+				BeadId:   id,
+				Repo:     repo,
+				Sha:      sha,
+				Message:  message,
+				Author:   author,
+				LinkedBy: actor,
+			})
+			switch {
+			case status.Code(err) == codes.NotFound:
+				// Something that looks like a bead ID but is not one.
+			case err != nil:
+				fmt.Fprintf(os.Stderr, "bd: linking %s to %s: %v\n", sha[:7], id, err)
+			default:
+				fmt.Printf("bd: linked %s to %s\n", sha[:7], id)
+			}
+		}
+		return nil
+	},
+}
+
+// git runs git with args and returns its trimmed output.
+func git(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	c := exec.Command("git", args...)
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// gitHookScript returns the post-commit hook. It never fails the commit.
+func gitHookScript(prefix string) string {
+	return "#!/bin/sh\n" + gitHookMarker + ": links each commit to the beads its message names.\n" +
+		"bd hook post-commit --prefix '" + prefix + "' || true\n"
+}
+
+// installGitHook writes the post-commit hook into hooksDir and returns its
+// path. A hook bd did not write is only replaced with force.
+func installGitHook(hooksDir, prefix string, force bool) (string, error) {
+	if strings.ContainsAny(prefix, "'\n") {
+		return "", fmt.Errorf("invalid prefix %q", prefix)
+	}
+	path := filepath.Join(hooksDir, "post-commit")
+	if old, err := os.ReadFile(path); err == nil && !force && !bytes.Contains(old, []byte(gitHookMarker)) {
+		return "", fmt.Errorf("%s already exists; rerun with --force to replace it", path)
+	}
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(gitHookScript(prefix)), 0o755); err != nil {
+		return "", err
+	}
+	return path, os.Chmod(path, 0o755)
+}
+
+// beadRefs returns the bead IDs and slugs starting with prefix in a commit
+// message, in order and without duplicates.
+func beadRefs(message, prefix string) []string {
+	re := regexp.MustCompile(`(?:^|[^\w-])(` + regexp.QuoteMeta(prefix) + `[A-Za-z0-9]+(?:-[A-Za-z0-9]+)*)`)
+	var refs []string
+	for _, m := range re.FindAllStringSubmatch(message, -1) {
+		if !slices.Contains(refs, m[1]) {
+			refs = append(refs, m[1])
+		}
+	}
+	return refs
+}
+
+func init() {
+	hookCmd.PersistentFlags().String("prefix", idgen.DefaultPrefix, "bead ID prefix to look for in commit messages")
+	hookInstallGitCmd.Flags().Bool("force", false, "replace an existing post-commit hook")
+	hookCmd.AddCommand(hookInstallGitCmd)
+	hookCmd.AddCommand(hookPostCommitCmd)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBeadRefs(t *testing.T) {
+	msg := "Fix token refresh (bd-a1B2c3)\n\nAlso bd-fix-login-bug, bd-a1B2c3 again.\nNot abd-x or post-bd-y."
+	got := beadRefs(msg, "bd-")
+	if strings.Join(got, " ") != "bd-a1B2c3 bd-fix-login-bug" {
+		t.Fatalf("beadRefs = %q", got)
+	}
+}
+
+func TestInstallGitHook(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+	path, err := installGitHook(dir, "bd-", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "bd hook post-commit --prefix 'bd-'") {
+		t.Fatalf("hook = %q", data)
+	}
+	// Reinstalling over our own hook is fine; someone else's needs --force.
+	if _, err := installGitHook(dir, "kd-", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nmake lint\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := installGitHook(dir, "bd-", false); err == nil {
+		t.Fatal("expected an error replacing a foreign hook")
+	}
+	if _, err := installGitHook(dir, "bd-", true); err != nil {
+		t.Fatal(err)
+	}
+}
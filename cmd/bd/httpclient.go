@@ -0,0 +1,134 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// httpClientOptions tunes the transport shared by every HTTP call bd makes
+// to the server. Each field is read from the environment so pods running a
+// fleet of agents can size it without flags.
+type httpClientOptions struct {
+	MaxIdleConnsPerHost   int           // BEADS_HTTP_MAX_IDLE_CONNS_PER_HOST (default 16)
+	IdleConnTimeout       time.Duration // BEADS_HTTP_IDLE_CONN_TIMEOUT (default 90s)
+	DialTimeout           time.Duration // BEADS_HTTP_DIAL_TIMEOUT (default 10s)
+	KeepAlive             time.Duration // BEADS_HTTP_KEEPALIVE (TCP keep-alive period; default 30s, negative disables)
+	TLSHandshakeTimeout   time.Duration // BEADS_HTTP_TLS_HANDSHAKE_TIMEOUT (default 10s)
+	ResponseHeaderTimeout time.Duration // BEADS_HTTP_RESPONSE_HEADER_TIMEOUT (default 0 = none)
+	HTTP2                 bool          // BEADS_HTTP2 (default true; false forces HTTP/1.1)
+}
+
+func defaultHTTPClientOptions() httpClientOptions {
+	return httpClientOptions{
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     90 * time.Second,
+		DialTimeout:         10 * time.Second,
+		KeepAlive:           30 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+		HTTP2:               true,
+	}
+}
+
+// httpClientOptionsFromEnv returns the default options overridden by any
+// BEADS_HTTP_* variables that are set.
+func httpClientOptionsFromEnv() (httpClientOptions, error) {
+	o := defaultHTTPClientOptions()
+	if v := os.Getenv("BEADS_HTTP_MAX_IDLE_CONNS_PER_HOST"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return o, fmt.Errorf("BEADS_HTTP_MAX_IDLE_CONNS_PER_HOST: invalid count %q", v)
+		}
+		o.MaxIdleConnsPerHost = n
+	}
+	for _, d := range []struct {
+		env string
+		dst *time.Duration
+	}{
+		{"BEADS_HTTP_IDLE_CONN_TIMEOUT", &o.IdleConnTimeout},
+		{"BEADS_HTTP_DIAL_TIMEOUT", &o.DialTimeout},
+		{"BEADS_HTTP_KEEPALIVE", &o.KeepAlive},
+		{"BEADS_HTTP_TLS_HANDSHAKE_TIMEOUT", &o.TLSHandshakeTimeout},
+		{"BEADS_HTTP_RESPONSE_HEADER_TIMEOUT", &o.ResponseHeaderTimeout},
+	} {
+		v := os.Getenv(d.env)
+		if v == "" {
+			continue
+		}
+		dur, err := time.ParseDuration(v)
+		if err != nil {
+			return o, fmt.Errorf("%s: %w", d.env, err)
+		}
+		*d.dst = dur
+	}
+	if v := os.Getenv("BEADS_HTTP2"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return o, fmt.Errorf("BEADS_HTTP2: invalid boolean %q", v)
+		}
+		o.HTTP2 = b
+	}
+	return o, nil
+}
+
+// transport builds an *http.Transport from the options, dialing TLS with
+// the client's TLS config when it is non-nil.
+func (o httpClientOptions) transport() (*http.Transport, error) {
+	cfg, err := clientTLSConfig(serverAddr)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: o.DialTimeout, KeepAlive: o.KeepAlive}
+	t := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       cfg,
+		MaxIdleConns:          max(100, o.MaxIdleConnsPerHost),
+		MaxIdleConnsPerHost:   o.MaxIdleConnsPerHost,
+		IdleConnTimeout:       o.IdleConnTimeout,
+		TLSHandshakeTimeout:   o.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: o.ResponseHeaderTimeout,
+		ExpectContinueTimeout: time.Second,
+		ForceAttemptHTTP2:     o.HTTP2,
+	}
+	if !o.HTTP2 {
+		// A non-nil, empty map disables the transport's HTTP/2 upgrade.
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return t, nil
+}
+
+var (
+	sharedClientOnce sync.Once
+	sharedClient     *http.Client
+	sharedClientErr  error
+)
+
+// serverHTTPClient returns the HTTP client for calls to the server. It is
+// built once per process, so every call reuses the same pool of
+// connections rather than opening (and leaving in TIME_WAIT) its own. No
+// overall timeout is set because the event stream is long-lived; callers
+// bound requests with their context.
+func serverHTTPClient() (*http.Client, error) {
+	sharedClientOnce.Do(func() {
+		opts, err := httpClientOptionsFromEnv()
+		if err != nil {
+			sharedClientErr = err
+			return
+		}
+		t, err := opts.transport()
+		if err != nil {
+			sharedClientErr = err
+			return
+		}
+		sharedClient = &http.Client{Transport: otelhttp.NewTransport(t)}
+	})
+	return sharedClient, sharedClientErr
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHTTPClientOptionsFromEnv(t *testing.T) {
+	o, err := httpClientOptionsFromEnv()
+	if err != nil || o != defaultHTTPClientOptions() {
+		t.Fatalf("defaults = %+v, %v", o, err)
+	}
+
+	t.Setenv("BEADS_HTTP_MAX_IDLE_CONNS_PER_HOST", "64")
+	t.Setenv("BEADS_HTTP_IDLE_CONN_TIMEOUT", "5m")
+	t.Setenv("BEADS_HTTP_RESPONSE_HEADER_TIMEOUT", "20s")
+	t.Setenv("BEADS_HTTP2", "false")
+	o, err = httpClientOptionsFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.MaxIdleConnsPerHost != 64 || o.IdleConnTimeout != 5*time.Minute || o.ResponseHeaderTimeout != 20*time.Second || o.HTTP2 {
+		t.Fatalf("options = %+v", o)
+	}
+
+	insecureConn = true
+	t.Cleanup(func() { insecureConn = false })
+	tr, err := o.transport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.MaxIdleConnsPerHost != 64 || tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+		t.Errorf("transport = %+v", tr)
+	}
+
+	for env, v := range map[string]string{
+		"BEADS_HTTP_MAX_IDLE_CONNS_PER_HOST": "-1",
+		"BEADS_HTTP_DIAL_TIMEOUT":            "soon",
+		"BEADS_HTTP2":                        "maybe",
+	} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv(env, v)
+			if _, err := httpClientOptionsFromEnv(); err == nil {
+				t.Errorf("%s=%s: expected an error", env, v)
+			}
+		})
+	}
+}
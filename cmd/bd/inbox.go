@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var followCmd = &cobra.Command{
+	Use:     "follow <bead-id>",
+	Short:   "Get notified about every change to a bead",
+	GroupID: "workflow",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.WatchBead(context.Background(), &beadsv1.WatchBeadRequest{
+			BeadId: args[0],
+			Actor:  actor,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printWatchersJSON(resp.GetWatchers())
+		} else {
+			fmt.Printf("Following %s (watchers: %s)\n", args[0], strings.Join(resp.GetWatchers(), ", "))
+		}
+		return nil
+	},
+}
+
+var unfollowCmd = &cobra.Command{
+	Use:     "unfollow <bead-id>",
+	Short:   "Stop notifications for a bead",
+	GroupID: "workflow",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.UnwatchBead(context.Background(), &beadsv1.UnwatchBeadRequest{
+			BeadId: args[0],
+			Actor:  actor,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printWatchersJSON(resp.GetWatchers())
+		} else {
+			fmt.Printf("Unfollowed %s\n", args[0])
+		}
+		return nil
+	},
+}
+
+var inboxCmd = &cobra.Command{
+	Use:     "inbox",
+	Short:   "Show notifications for followed beads and mentions, and mark them read",
+	GroupID: "views",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		peek, _ := cmd.Flags().GetBool("peek")
+		limit, _ := cmd.Flags().GetInt32("limit")
+
+		resp, err := client.ListNotifications(context.Background(), &beadsv1.ListNotificationsRequest{
+			Actor:      actor,
+			UnreadOnly: !all,
+			Limit:      limit,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		notifications := resp.GetNotifications()
+
+		if jsonOutput {
+			data, err := json.MarshalIndent(notifications, "", "  ")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+		} else if len(notifications) == 0 {
+			fmt.Println("No new notifications.")
+		} else {
+			for _, n := range notifications {
+				e := n.GetEvent()
+				createdAt := ""
+				if e.GetCreatedAt() != nil {
+					createdAt = e.GetCreatedAt().AsTime().Format("2006-01-02 15:04:05")
+				}
+				marker := "*"
+				if n.GetReadAt() != nil {
+					marker = " "
+				}
+				fmt.Printf("%s [%s] %s  %s by %s\n", marker, createdAt, e.GetBeadId(), strings.TrimPrefix(e.GetTopic(), "beads."), e.GetActor())
+			}
+		}
+
+		if peek {
+			return nil
+		}
+		ids := make([]int64, 0, len(notifications))
+		for _, n := range notifications {
+			if n.GetReadAt() == nil {
+				ids = append(ids, n.GetId())
+			}
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if _, err := client.MarkNotificationsRead(context.Background(), &beadsv1.MarkNotificationsReadRequest{
+			Actor: actor,
+			Ids:   ids,
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func printWatchersJSON(watchers []string) {
+	data, err := json.MarshalIndent(map[string]any{"watchers": watchers}, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
+
+func init() {
+	inboxCmd.Flags().Bool("all", false, "include notifications already read")
+	inboxCmd.Flags().Bool("peek", false, "do not mark the listed notifications read")
+	inboxCmd.Flags().Int32("limit", 0, "maximum notifications to show (default 50)")
+}
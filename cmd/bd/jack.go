@@ -0,0 +1,417 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+// jackOutputLimit caps how much of each of a command's output streams is
+// kept in a jack change entry; the command's own output is not cut.
+const jackOutputLimit = 16 << 10
+
+var jackCmd = &cobra.Command{
+	Use:   "jack",
+	Short: "Work with jacks: temporary changes to shared state",
+	Long: `Jacks are time-boxed changes to shared state, such as a raised limit or a
+paused job, that must be taken down again. Every change made while a jack is
+up belongs in its change log.`,
+	GroupID: "workflow",
+}
+
+var jackExecCmd = &cobra.Command{
+	Use:   "exec [--jack <id>] -- <kubectl command>",
+	Short: "Run a kubectl command and log it to the active jack",
+	Long: `Runs the command, then appends a change entry to the active jack with the
+command line, its exit status, its output and a diff of the resources it
+touched. The resources are read with kubectl get -o yaml before and after
+the command; commands that name no resources, such as kubectl get, are
+logged without a diff.
+
+The active jack is --jack, else $BEADS_JACK, else the one jack you raised
+that is still up. The command's exit status is bd's.`,
+	Example: `  bd jack exec -- kubectl scale deploy/api --replicas=6 -n prod
+  bd jack exec --jack bd-a1b2 -- kubectl apply -f limits.yaml`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jackID, _ := cmd.Flags().GetString("jack")
+		ctx := context.Background()
+		jackID, err := activeJack(ctx, jackID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		targets := kubectlTargets(args)
+		var before []byte
+		var snapErr error
+		if targets != nil {
+			before, snapErr = kubectlSnapshot(args[0], targets)
+		}
+
+		var stdout, stderr cappedBuffer
+		c := exec.Command(args[0], args[1:]...)
+		c.Stdin = os.Stdin
+		c.Stdout = io.MultiWriter(os.Stdout, &stdout)
+		c.Stderr = io.MultiWriter(os.Stderr, &stderr)
+		runErr := c.Run()
+		code := 0
+		var exitErr *exec.ExitError
+		switch {
+		case errors.As(runErr, &exitErr):
+			code = exitErr.ExitCode()
+		case runErr != nil:
+			fmt.Fprintf(os.Stderr, "Error: %v\n", runErr)
+			os.Exit(1)
+		}
+
+		entry := jackChange{Args: args, ExitCode: code, Stdout: stdout.String(), Stderr: stderr.String()}
+		if targets != nil {
+			var after []byte
+			if snapErr == nil {
+				after, snapErr = kubectlSnapshot(args[0], targets)
+			}
+			if snapErr != nil {
+				entry.DiffErr = snapErr.Error()
+			} else {
+				entry.Diff = lineDiff(string(before), string(after), 3)
+			}
+		}
+
+		path := "/v1/jacks/" + url.PathEscape(jackID) + "/change"
+		body := map[string]string{"text": entry.String(), "author": actor}
+		if _, err := httpPost(ctx, path, bearerTokenFromEnv(), body); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: recording the change on jack %s failed: %v\nLog it by hand:\n\n%s\n", jackID, err, entry.String())
+		} else if !jsonOutput {
+			fmt.Fprintf(os.Stderr, "Logged to jack %s\n", jackID)
+		}
+		if code != 0 {
+			os.Exit(code)
+		}
+		return nil
+	},
+}
+
+func init() {
+	jackExecCmd.Flags().String("jack", "", "jack to log the change to (default $BEADS_JACK, or your only up jack)")
+	jackCmd.AddCommand(jackExecCmd)
+}
+
+// activeJack returns id if set, else $BEADS_JACK, else the only up jack
+// raised by the current actor.
+func activeJack(ctx context.Context, id string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+	if id := os.Getenv("BEADS_JACK"); id != "" {
+		return id, nil
+	}
+	resp, err := client.ListBeads(ctx, &beadsv1.ListBeadsRequest{
+		Type:   []string{"jack"},
+		Status: []string{"open", "in_progress", "blocked", "deferred"},
+	})
+	if err != nil {
+		return "", fmt.Errorf("finding your jack: %w", err)
+	}
+	var mine []string
+	for _, b := range resp.GetBeads() {
+		if b.GetCreatedBy() == actor {
+			mine = append(mine, b.GetId())
+		}
+	}
+	switch len(mine) {
+	case 0:
+		return "", fmt.Errorf("%s has no jack up; raise one or pass --jack", actor)
+	case 1:
+		return mine[0], nil
+	}
+	return "", fmt.Errorf("%s has %d jacks up (%s); pick one with --jack", actor, len(mine), strings.Join(mine, ", "))
+}
+
+// jackChange is a command run under a jack, as logged to its change log.
+type jackChange struct {
+	Args     []string
+	ExitCode int
+	Stdout   string
+	Stderr   string
+	Diff     string // empty when nothing changed or no resources were named
+	DiffErr  string // why the resources could not be read
+}
+
+// String renders the change as the text of a jack change entry.
+func (c jackChange) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ %s\nexit: %d\n", shellJoin(c.Args), c.ExitCode)
+	for _, s := range []struct{ name, text string }{
+		{"stdout", c.Stdout},
+		{"stderr", c.Stderr},
+		{"diff", c.Diff},
+	} {
+		if s.text != "" {
+			fmt.Fprintf(&b, "\n%s:\n%s", s.name, s.text)
+			if !strings.HasSuffix(s.text, "\n") {
+				b.WriteString("\n")
+			}
+		}
+	}
+	if c.DiffErr != "" {
+		fmt.Fprintf(&b, "\ndiff unavailable: %s\n", c.DiffErr)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// shellJoin joins args as a shell would need them typed, quoting those with
+// spaces or shell metacharacters.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t\n'\"\\$`&|;<>(){}*?!#~") {
+			a = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+		}
+		quoted[i] = a
+	}
+	return strings.Join(quoted, " ")
+}
+
+// cappedBuffer keeps the first jackOutputLimit bytes written to it and
+// notes how much more was dropped.
+type cappedBuffer struct {
+	buf     bytes.Buffer
+	dropped int
+}
+
+func (c *cappedBuffer) Write(p []byte) (int, error) {
+	n := min(len(p), jackOutputLimit-c.buf.Len())
+	c.buf.Write(p[:n])
+	c.dropped += len(p) - n
+	return len(p), nil
+}
+
+func (c *cappedBuffer) String() string {
+	if c.dropped == 0 {
+		return c.buf.String()
+	}
+	return fmt.Sprintf("%s\n[%d more bytes not kept]\n", c.buf.String(), c.dropped)
+}
+
+// kubectlValueFlags are the kubectl flags that take a separate value, so
+// the value is not mistaken for a resource name.
+var kubectlValueFlags = []string{
+	"-n", "--namespace", "--context", "--cluster", "--user", "--kubeconfig", "-s", "--server", "--token", "--as",
+	"-f", "--filename", "-k", "--kustomize", "-l", "--selector", "-o", "--output", "-p", "--patch", "--patch-file",
+	"--type", "--replicas", "-c", "--container", "--timeout", "--field-manager", "--to-revision", "--grace-period",
+	"--image", "--port", "--name", "--reason",
+}
+
+// kubectlGlobalFlags select the cluster and namespace, and are passed on to
+// the kubectl get that reads the resources.
+var kubectlGlobalFlags = []string{
+	"-n", "--namespace", "--context", "--cluster", "--user", "--kubeconfig", "-s", "--server", "--token", "--as",
+	"-A", "--all-namespaces",
+}
+
+// kubectlMutatingVerbs are the kubectl commands whose resources are diffed.
+// For set and rollout the resources follow a subcommand.
+var kubectlMutatingVerbs = []string{
+	"apply", "create", "replace", "patch", "edit", "delete", "scale", "autoscale", "label", "annotate",
+	"set", "rollout", "cordon", "uncordon", "drain", "taint",
+}
+
+// kubectlTargets returns the kubectl get arguments that read the resources
+// a kubectl command changes, or nil when it is not kubectl, does not change
+// resources, or names none that can be read again, such as -f -.
+func kubectlTargets(args []string) []string {
+	if len(args) < 2 || filepath.Base(args[0]) != "kubectl" {
+		return nil
+	}
+	var global, files, selector, positional []string
+	for i := 1; i < len(args); i++ {
+		a := args[i]
+		name, value, inline := strings.Cut(a, "=")
+		if !strings.HasPrefix(a, "-") {
+			positional = append(positional, a)
+			continue
+		}
+		if !inline && slices.Contains(kubectlValueFlags, name) && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+		switch name {
+		case "-f", "--filename":
+			if value == "-" {
+				return nil
+			}
+			files = append(files, "-f", value)
+		case "-R", "--recursive":
+			files = append(files, "-R")
+		case "-l", "--selector":
+			selector = []string{"-l", value}
+		default:
+			if slices.Contains(kubectlGlobalFlags, name) {
+				global = append(global, name)
+				if slices.Contains(kubectlValueFlags, name) {
+					global = append(global, value)
+				}
+			}
+		}
+	}
+	if len(positional) == 0 || !slices.Contains(kubectlMutatingVerbs, positional[0]) {
+		return nil
+	}
+	verb, refs := positional[0], positional[1:]
+	if (verb == "set" || verb == "rollout") && len(refs) > 0 {
+		refs = refs[1:]
+	}
+	// Drop label, annotation, taint and env assignments and removals.
+	refs = slices.DeleteFunc(slices.Clone(refs), func(r string) bool {
+		return strings.ContainsAny(r, "=:") || (verb != "delete" && strings.HasSuffix(r, "-"))
+	})
+	switch verb {
+	case "cordon", "uncordon", "drain", "taint":
+		if len(refs) > 0 && refs[0] != "node" && refs[0] != "nodes" {
+			refs = append([]string{"node"}, refs...)
+		}
+	}
+
+	var get []string
+	switch {
+	case len(files) > 0:
+		get = files
+	case len(refs) > 0 && (strings.Contains(refs[0], "/") || len(refs) > 1 || selector != nil):
+		get = append(refs, selector...)
+	default:
+		return nil
+	}
+	return append(global, get...)
+}
+
+// kubectlSnapshot reads the resources targets names as YAML.
+func kubectlSnapshot(kubectl string, targets []string) ([]byte, error) {
+	args := append([]string{"get"}, targets...)
+	args = append(args, "-o", "yaml", "--ignore-not-found")
+	var stderr bytes.Buffer
+	c := exec.Command(kubectl, args...)
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("kubectl get: %s", msg)
+		}
+		return nil, fmt.Errorf("kubectl get: %w", err)
+	}
+	return out, nil
+}
+
+// lineDiffMax bounds the lines, after the common prefix and suffix are
+// trimmed, that lineDiff matches up; past it every line counts as changed.
+const lineDiffMax = 4000
+
+// lineDiff returns the lines removed from a (-) and added in b (+), with
+// up to context unchanged lines around each run of changes and "@@" between
+// runs. It returns "" when a and b are equal.
+func lineDiff(a, b string, context int) string {
+	if a == b {
+		return ""
+	}
+	x, y := strings.Split(strings.TrimSuffix(a, "\n"), "\n"), strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+	if a == "" {
+		x = nil
+	}
+	if b == "" {
+		y = nil
+	}
+
+	// ops is the edit script: ' ' keeps a line, '-' removes one of x and
+	// '+' adds one of y.
+	type op struct {
+		kind byte
+		line string
+	}
+	var ops []op
+	pre := 0
+	for pre < len(x) && pre < len(y) && x[pre] == y[pre] {
+		ops = append(ops, op{' ', x[pre]})
+		pre++
+	}
+	suf := 0
+	for suf < len(x)-pre && suf < len(y)-pre && x[len(x)-1-suf] == y[len(y)-1-suf] {
+		suf++
+	}
+	mx, my := x[pre:len(x)-suf], y[pre:len(y)-suf]
+	if len(mx) > lineDiffMax || len(my) > lineDiffMax {
+		for _, l := range mx {
+			ops = append(ops, op{'-', l})
+		}
+		for _, l := range my {
+			ops = append(ops, op{'+', l})
+		}
+	} else {
+		// lcs[i][j] is the longest common subsequence of mx[i:] and my[j:].
+		lcs := make([][]int, len(mx)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(my)+1)
+		}
+		for i := len(mx) - 1; i >= 0; i-- {
+			for j := len(my) - 1; j >= 0; j-- {
+				if mx[i] == my[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < len(mx) || j < len(my) {
+			switch {
+			case i < len(mx) && j < len(my) && mx[i] == my[j]:
+				ops = append(ops, op{' ', mx[i]})
+				i++
+				j++
+			case i < len(mx) && (j == len(my) || lcs[i+1][j] >= lcs[i][j+1]):
+				ops = append(ops, op{'-', mx[i]})
+				i++
+			default:
+				ops = append(ops, op{'+', my[j]})
+				j++
+			}
+		}
+	}
+	for _, l := range x[len(x)-suf:] {
+		ops = append(ops, op{' ', l})
+	}
+
+	// Keep the changes and the unchanged lines within context of one.
+	keep := make([]bool, len(ops))
+	for i, o := range ops {
+		if o.kind == ' ' {
+			continue
+		}
+		for k := max(0, i-context); k <= min(len(ops)-1, i+context); k++ {
+			keep[k] = true
+		}
+	}
+	var out strings.Builder
+	for i, o := range ops {
+		if !keep[i] {
+			continue
+		}
+		if i > 0 && !keep[i-1] && out.Len() > 0 {
+			out.WriteString("@@\n")
+		}
+		out.WriteByte(o.kind)
+		out.WriteString(o.line)
+		out.WriteByte('\n')
+	}
+	return out.String()
+}
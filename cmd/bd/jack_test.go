@@ -0,0 +1,90 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestKubectlTargets(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		want []string // nil for no diff
+	}{
+		{"kubectl scale deploy/api --replicas=6 -n prod", []string{"-n", "prod", "deploy/api"}},
+		{"kubectl -n prod scale deployment api --replicas 6", []string{"-n", "prod", "deployment", "api"}},
+		{"kubectl apply -f limits.yaml --context staging", []string{"--context", "staging", "-f", "limits.yaml"}},
+		{"kubectl apply -R -f manifests/", []string{"-R", "-f", "manifests/"}},
+		{"kubectl label deploy/api tier=web owner-", []string{"deploy/api"}},
+		{"kubectl set image deploy/api api=api:v2", []string{"deploy/api"}},
+		{"kubectl rollout restart deploy/api --namespace=prod", []string{"--namespace", "prod", "deploy/api"}},
+		{"kubectl cordon node-1", []string{"node", "node-1"}},
+		{"kubectl delete pods -l app=api", []string{"pods", "-l", "app=api"}},
+		{"/usr/local/bin/kubectl patch svc/web -p {}", []string{"svc/web"}},
+		{"kubectl get pods", nil},
+		{"kubectl logs deploy/api", nil},
+		{"kubectl apply -f -", nil},
+		{"kubectl edit deploy", nil},
+		{"helm upgrade api ./chart", nil},
+	}
+	for _, tt := range tests {
+		got := kubectlTargets(strings.Fields(tt.cmd))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("kubectlTargets(%q) = %q, want %q", tt.cmd, got, tt.want)
+		}
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	after := "a\nb\nc\nD\ne\nf\ng\nh\ni\nj\nk\n"
+	want := " c\n-d\n+D\n e\n@@\n j\n+k\n"
+	if got := lineDiff(before, after, 1); got != want {
+		t.Errorf("lineDiff =\n%s\nwant\n%s", got, want)
+	}
+	if got := lineDiff(before, before, 3); got != "" {
+		t.Errorf("lineDiff of equal text = %q", got)
+	}
+	if got := lineDiff("", "x: 1\n", 3); got != "+x: 1\n" {
+		t.Errorf("lineDiff from nothing = %q", got)
+	}
+	if got := lineDiff("x: 1\n", "", 3); got != "-x: 1\n" {
+		t.Errorf("lineDiff to nothing = %q", got)
+	}
+}
+
+func TestJackChange_String(t *testing.T) {
+	c := jackChange{
+		Args:     []string{"kubectl", "annotate", "deploy/api", "note=raised for the sale"},
+		ExitCode: 0,
+		Stdout:   "deployment.apps/api annotated\n",
+		Diff:     "+    note: raised for the sale\n",
+	}
+	want := `$ kubectl annotate deploy/api 'note=raised for the sale'
+exit: 0
+
+stdout:
+deployment.apps/api annotated
+
+diff:
++    note: raised for the sale`
+	if got := c.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+
+	c = jackChange{Args: []string{"kubectl", "scale", "deploy/api"}, ExitCode: 1, Stderr: "error: --replicas is required", DiffErr: "kubectl get: forbidden"}
+	if got := c.String(); !strings.Contains(got, "exit: 1\n\nstderr:\nerror: --replicas is required\n\ndiff unavailable: kubectl get: forbidden") {
+		t.Errorf("String() =\n%s", got)
+	}
+}
+
+func TestCappedBuffer(t *testing.T) {
+	var c cappedBuffer
+	c.Write([]byte(strings.Repeat("x", jackOutputLimit-1)))
+	if n, err := c.Write([]byte("yz")); n != 2 || err != nil {
+		t.Fatalf("Write = %d, %v", n, err)
+	}
+	if got := c.String(); !strings.HasSuffix(got, "y\n[1 more bytes not kept]\n") {
+		t.Fatalf("String() ends %q", got[len(got)-40:])
+	}
+}
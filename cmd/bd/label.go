@@ -1,11 +1,18 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/server"
 	"github.com/spf13/cobra"
 )
 
@@ -63,7 +70,100 @@ var labelRemoveCmd = &cobra.Command{
 	},
 }
 
+// labelCount mirrors an entry of the server's GET /v1/labels response.
+type labelCount struct {
+	Label     string `json:"label"`
+	Namespace string `json:"namespace,omitempty"`
+	Value     string `json:"value"`
+	Count     int    `json:"count"`
+}
+
+var labelListCmd = &cobra.Command{
+	Use:     "ls [bead-id]",
+	Aliases: []string{"list"},
+	Short:   "List labels grouped by namespace",
+	Long: `Lists every label in use with the number of beads carrying it, or the
+labels of one bead. Namespaced labels ("team:backend") are grouped under
+their namespace. The list API matches a whole namespace with
+labels=team:*.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var labels []labelCount
+		if len(args) == 1 {
+			resp, err := client.GetBead(context.Background(), &beadsv1.GetBeadRequest{Id: args[0]})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			for _, l := range resp.GetBead().GetLabels() {
+				labels = append(labels, newLabelCount(l))
+			}
+		} else {
+			requireFeature("bd label list", server.FeatureLabelCounts)
+			body, err := httpGet(context.Background(), "/v1/labels")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			var resp struct {
+				Labels []labelCount `json:"labels"`
+			}
+			if err := json.Unmarshal(body, &resp); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid label list: %v\n", err)
+				os.Exit(1)
+			}
+			labels = resp.Labels
+		}
+
+		if jsonOutput {
+			printJSON(labels)
+			return nil
+		}
+		printLabelGroups(os.Stdout, labels, len(args) == 0)
+		return nil
+	},
+}
+
 func init() {
 	labelCmd.AddCommand(labelAddCmd)
 	labelCmd.AddCommand(labelRemoveCmd)
+	labelCmd.AddCommand(labelListCmd)
+}
+
+// newLabelCount splits a label the way the server does: the namespace is
+// the part before the first colon, if any.
+func newLabelCount(label string) labelCount {
+	if i := strings.IndexByte(label, ':'); i > 0 {
+		return labelCount{Label: label, Namespace: label[:i], Value: label[i+1:]}
+	}
+	return labelCount{Label: label, Value: label}
+}
+
+// printLabelGroups prints labels under their namespace, labels without one
+// first. Counts are shown when withCounts is set.
+func printLabelGroups(w io.Writer, labels []labelCount, withCounts bool) {
+	slices.SortFunc(labels, func(a, b labelCount) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Value, b.Value))
+	})
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, l := range labels {
+		if i == 0 || l.Namespace != labels[i-1].Namespace {
+			if i > 0 {
+				fmt.Fprintln(tw)
+			}
+			if l.Namespace != "" {
+				fmt.Fprintf(tw, "%s:\n", l.Namespace)
+			}
+		}
+		indent := ""
+		if l.Namespace != "" {
+			indent = "  "
+		}
+		if withCounts {
+			fmt.Fprintf(tw, "%s%s\t%d\n", indent, l.Value, l.Count)
+		} else {
+			fmt.Fprintf(tw, "%s%s\n", indent, l.Value)
+		}
+	}
+	tw.Flush()
 }
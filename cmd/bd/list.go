@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	beadquery "github.com/alfredjeanlab/beads/internal/query"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var listCmd = &cobra.Command{
@@ -14,56 +17,132 @@ var listCmd = &cobra.Command{
 	Short:   "List beads",
 	GroupID: "beads",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		status, _ := cmd.Flags().GetStringSlice("status")
-		beadType, _ := cmd.Flags().GetStringSlice("type")
-		kind, _ := cmd.Flags().GetStringSlice("kind")
-		limit, _ := cmd.Flags().GetInt32("limit")
-		assignee, _ := cmd.Flags().GetString("assignee")
-		offset, _ := cmd.Flags().GetInt32("offset")
-		fieldFlags, _ := cmd.Flags().GetStringArray("field")
+		req, err := listRequestFromFlags(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		req.IncludeArchived, _ = cmd.Flags().GetBool("archived")
 
-		req := &beadsv1.ListBeadsRequest{
-			Status:   status,
-			Type:     beadType,
-			Kind:     kind,
-			Limit:    limit,
-			Assignee: assignee,
-			Offset:   offset,
+		format, columns, err := listFormatFromFlags(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
 
-		if len(fieldFlags) > 0 {
-			req.FieldFilters = make(map[string]string, len(fieldFlags))
-			for _, f := range fieldFlags {
-				k, v, ok := splitField(f)
-				if !ok {
-					fmt.Fprintf(os.Stderr, "Error: invalid field filter %q (expected key=value)\n", f)
-					os.Exit(1)
+		resp, err := client.ListBeads(context.Background(), req)
+		if isUnreachable(err) {
+			if c, cerr := openCache(serverAddr); cerr == nil {
+				if beads, total, syncedAt, cerr := c.list(req); cerr == nil {
+					warnCached(err, syncedAt)
+					printBeadList(beads, total, format, columns)
+					return nil
 				}
-				req.FieldFilters[k] = v
 			}
 		}
-
-		resp, err := client.ListBeads(context.Background(), req)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		if jsonOutput {
-			printBeadListJSON(resp.GetBeads())
-		} else {
-			printBeadListTable(resp.GetBeads(), resp.GetTotal())
-		}
+		cacheBeads(resp.GetBeads()...)
+		printBeadList(resp.GetBeads(), resp.GetTotal(), format, columns)
 		return nil
 	},
 }
 
+// addListFilterFlags registers the bead filter flags read by
+// listRequestFromFlags.
+func addListFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceP("status", "s", nil, "filter by status (repeatable)")
+	cmd.Flags().StringSliceP("type", "t", nil, "filter by type (repeatable)")
+	cmd.Flags().StringSliceP("kind", "k", nil, "filter by kind (repeatable)")
+	cmd.Flags().Int32("limit", 20, "maximum number of beads to return")
+	cmd.Flags().String("assignee", "", "filter by assignee")
+	cmd.Flags().Int32("offset", 0, "offset for pagination")
+	cmd.Flags().StringArrayP("field", "f", nil, "filter by custom field (key=value, repeatable)")
+	cmd.Flags().String("sort", "", "sort key, prefix with - for descending (e.g. -blocked_count, last_activity_at, effective_priority, urgency)")
+	for _, f := range listTimeFlags {
+		cmd.Flags().String(f, "", "only beads "+strings.ReplaceAll(f, "-", " ")+" this time (2006-01-02, RFC 3339, or relative like -24h)")
+	}
+	cmd.Flags().StringP("query", "q", "", `query language filter (e.g. "status:open AND (label:urgent OR priority<=1) AND updated>-7d")`)
+}
+
+// listTimeFlags are the time range filter flags; "since" bounds are
+// inclusive, "before" bounds exclusive.
+var listTimeFlags = []string{"created-since", "created-before", "updated-since", "updated-before", "closed-since", "closed-before"}
+
+// listRequestFromFlags builds a ListBeadsRequest from the filter flags. The
+// limit and sort preferences replace the flag defaults.
+func listRequestFromFlags(cmd *cobra.Command) (*beadsv1.ListBeadsRequest, error) {
+	status, _ := cmd.Flags().GetStringSlice("status")
+	beadType, _ := cmd.Flags().GetStringSlice("type")
+	kind, _ := cmd.Flags().GetStringSlice("kind")
+	limit, _ := cmd.Flags().GetInt32("limit")
+	assignee, _ := cmd.Flags().GetString("assignee")
+	offset, _ := cmd.Flags().GetInt32("offset")
+	fieldFlags, _ := cmd.Flags().GetStringArray("field")
+	sort, _ := cmd.Flags().GetString("sort")
+	query, _ := cmd.Flags().GetString("query")
+	if prefs.Limit > 0 && !cmd.Flags().Changed("limit") {
+		limit = prefs.Limit
+	}
+	if prefs.Sort != "" && !cmd.Flags().Changed("sort") {
+		sort = prefs.Sort
+	}
+
+	req := &beadsv1.ListBeadsRequest{
+		Status:   status,
+		Type:     beadType,
+		Kind:     kind,
+		Limit:    limit,
+		Assignee: assignee,
+		Offset:   offset,
+		Sort:     sort,
+		Query:    query,
+	}
+
+	for _, f := range listTimeFlags {
+		v, _ := cmd.Flags().GetString(f)
+		if v == "" {
+			continue
+		}
+		t, err := beadquery.ParseTime(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s: %w", f, err)
+		}
+		ts := timestamppb.New(t)
+		switch f {
+		case "created-since":
+			req.CreatedAfter = ts
+		case "created-before":
+			req.CreatedBefore = ts
+		case "updated-since":
+			req.UpdatedAfter = ts
+		case "updated-before":
+			req.UpdatedBefore = ts
+		case "closed-since":
+			req.ClosedAfter = ts
+		case "closed-before":
+			req.ClosedBefore = ts
+		}
+	}
+
+	if len(fieldFlags) > 0 {
+		req.FieldFilters = make(map[string]string, len(fieldFlags))
+		for _, f := range fieldFlags {
+			k, v, ok := splitField(f)
+			if !ok {
+				return nil, fmt.Errorf("invalid field filter %q (expected key=value)", f)
+			}
+			req.FieldFilters[k] = v
+		}
+	}
+	return req, nil
+}
+
 func init() {
-	listCmd.Flags().StringSliceP("status", "s", nil, "filter by status (repeatable)")
-	listCmd.Flags().StringSliceP("type", "t", nil, "filter by type (repeatable)")
-	listCmd.Flags().StringSliceP("kind", "k", nil, "filter by kind (repeatable)")
-	listCmd.Flags().Int32("limit", 20, "maximum number of beads to return")
-	listCmd.Flags().String("assignee", "", "filter by assignee")
-	listCmd.Flags().Int32("offset", 0, "offset for pagination")
-	listCmd.Flags().StringArrayP("field", "f", nil, "filter by custom field (key=value, repeatable)")
+	addListFormatFlags(listCmd, "table")
+	addListFilterFlags(listCmd)
+	listCmd.Flags().Bool("archived", false, "include archived beads")
 }
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/spf13/cobra"
+)
+
+func lockPath(id string) string {
+	return "/v1/beads/" + url.PathEscape(id) + "/lock"
+}
+
+var lockCmd = &cobra.Command{
+	Use:   "lock <bead-id>",
+	Short: "Lock a bead so only you can update it for a while",
+	Long: `Take a short-lived advisory lock on a bead, or renew yours. While it is
+held, updates to the bead by other actors fail with "bead is locked", so two
+agents cannot rewrite the same fields at once. The lock expires after --ttl
+(at most 1h); release it sooner with bd unlock.`,
+	GroupID: "workflow",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd lock", server.FeatureLocks)
+		ttl, _ := cmd.Flags().GetDuration("ttl")
+
+		body, err := httpPost(context.Background(), lockPath(args[0]), bearerTokenFromEnv(), map[string]string{
+			"owner": actor,
+			"ttl":   ttl.String(),
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		var lock model.BeadLock
+		if err := json.Unmarshal(body, &lock); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printJSON(lock)
+		} else {
+			fmt.Printf("Locked %s until %s\n", lock.BeadID, lock.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+		}
+		return nil
+	},
+}
+
+var unlockCmd = &cobra.Command{
+	Use:     "unlock <bead-id>",
+	Short:   "Release your lock on a bead",
+	GroupID: "workflow",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd unlock", server.FeatureLocks)
+		path := lockPath(args[0])
+		if actor != "" {
+			path += "?owner=" + url.QueryEscape(actor)
+		}
+		if _, err := httpDo(context.Background(), http.MethodDelete, path, bearerTokenFromEnv(), nil); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Unlocked %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	lockCmd.Flags().Duration("ttl", 5*time.Minute, "how long the lock lasts")
+}
@@ -1,16 +1,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/tracing"
 	"github.com/alfredjeanlab/beads/internal/ui"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 var (
@@ -20,9 +22,15 @@ var (
 
 	conn   *grpc.ClientConn
 	client beadsv1.BeadsServiceClient
+
+	// shutdownTracing flushes client spans when a command finishes.
+	shutdownTracing func(context.Context) error
 )
 
 func defaultActor() string {
+	if a := os.Getenv("BEADS_ACTOR"); a != "" {
+		return a
+	}
 	out, err := exec.Command("git", "config", "user.name").Output()
 	if err == nil {
 		name := strings.TrimSpace(string(out))
@@ -47,22 +55,46 @@ var rootCmd = &cobra.Command{
 	Use:   "bd <command>",
 	Short: "CLI client for the Beads service",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
-		if tok := activeRemoteToken(); tok != "" {
-			opts = append(opts, grpc.WithUnaryInterceptor(bearerTokenInterceptor(tok)))
+		creds, err := transportCredentials(serverAddr)
+		if err != nil {
+			return err
+		}
+		interceptors := []grpc.UnaryClientInterceptor{clientVersionInterceptor}
+		if tok := bearerTokenFromEnv(); tok != "" {
+			interceptors = append(interceptors, bearerTokenInterceptor(tok))
+		}
+		if retryMax > 0 {
+			interceptors = append(interceptors, retryInterceptor(retryMax))
+		}
+		shutdownTracing, err = tracing.Setup(context.Background(), "bd", Version)
+		if err != nil {
+			return err
+		}
+		opts := []grpc.DialOption{
+			grpc.WithTransportCredentials(creds),
+			grpc.WithChainUnaryInterceptor(interceptors...),
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 		}
-		var err error
 		conn, err = grpc.NewClient(serverAddr, opts...)
 		if err != nil {
 			return fmt.Errorf("failed to connect to server: %w", err)
 		}
 		client = beadsv1.NewBeadsServiceClient(conn)
+		if offline, _ := cmd.Flags().GetBool("offline"); !offline && cmd.Parent() != cacheCmd && cmd.Name() != cobra.ShellCompRequestCmd {
+			autoSync()
+			if cmd != prefCmd && cmd.Parent() != prefCmd {
+				loadPrefs()
+			}
+		}
 		return nil
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
 		if conn != nil {
 			conn.Close()
 		}
+		if shutdownTracing != nil {
+			_ = shutdownTracing(context.Background())
+		}
 	},
 }
 
@@ -73,7 +105,9 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&serverAddr, "server", defaultServer(), "gRPC server address")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "output as JSON")
+	rootCmd.PersistentFlags().BoolVar(&insecureConn, "insecure", false, "dial the server without TLS")
 	rootCmd.PersistentFlags().StringVar(&actor, "actor", defaultActor(), "actor name for created_by fields")
+	rootCmd.PersistentFlags().IntVar(&retryMax, "retries", defaultRetries(), "times to retry read-only calls on transient errors")
 
 	rootCmd.AddGroup(
 		&cobra.Group{ID: "beads", Title: "Beads:"},
@@ -89,37 +123,77 @@ func init() {
 	rootCmd.AddCommand(showCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(exportCmd)
 	rootCmd.AddCommand(createCmd)
 	rootCmd.AddCommand(updateCmd)
+	rootCmd.AddCommand(patchCmd)
 	rootCmd.AddCommand(deleteCmd)
+	rootCmd.AddCommand(mergeCmd)
+	rootCmd.AddCommand(cloneCmd)
 	rootCmd.AddCommand(depCmd)
+	rootCmd.AddCommand(relationCmd)
+	rootCmd.AddCommand(externalCmd)
 	rootCmd.AddCommand(labelCmd)
+	rootCmd.AddCommand(aliasCmd)
 	rootCmd.AddCommand(commentCmd)
+	rootCmd.AddCommand(noteCmd)
+	rootCmd.AddCommand(checkCmd)
 
 	// Workflows
 	rootCmd.AddCommand(claimCmd)
+	rootCmd.AddCommand(nextCmd)
 	rootCmd.AddCommand(unclaimCmd)
 	rootCmd.AddCommand(closeCmd)
 	rootCmd.AddCommand(reopenCmd)
 	rootCmd.AddCommand(doneCmd)
+	rootCmd.AddCommand(awaitCmd)
 	rootCmd.AddCommand(deferCmd)
 	rootCmd.AddCommand(undeferCmd)
+	rootCmd.AddCommand(followCmd)
+	rootCmd.AddCommand(unfollowCmd)
+	rootCmd.AddCommand(gateCmd)
+	rootCmd.AddCommand(decisionCmd)
+	rootCmd.AddCommand(jackCmd)
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(unlockCmd)
 
 	// Views
 	rootCmd.AddCommand(viewCmd)
+	rootCmd.AddCommand(readyCmd)
+	rootCmd.AddCommand(blockedCmd)
+	rootCmd.AddCommand(whyCmd)
 	rootCmd.AddCommand(contextCmd)
 	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(inboxCmd)
+	rootCmd.AddCommand(digestCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(rollupCmd)
+	rootCmd.AddCommand(eventsCmd)
+	rootCmd.AddCommand(adviceCmd)
 	rootCmd.AddCommand(treeCmd)
+	rootCmd.AddCommand(uiCmd)
 
 	// System
 	rootCmd.AddCommand(serveCmd)
 	rootCmd.AddCommand(healthCmd)
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(cacheCmd)
 	rootCmd.AddCommand(remoteCmd)
+	rootCmd.AddCommand(agentCmd)
+	rootCmd.AddCommand(actorCmd)
+	rootCmd.AddCommand(prefCmd)
+	rootCmd.AddCommand(hookCmd)
+	rootCmd.AddCommand(adminCmd)
+	rootCmd.AddCommand(apiCmd)
+	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(selfUpdateCmd)
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
 }
 
 func main() {
+	registerCompletions()
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
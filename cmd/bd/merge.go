@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:     "merge <id> --into <target>",
+	Short:   "Merge a duplicate bead into another",
+	Long:    "Moves comments, notes, labels, dependencies and events from <id> to the target, then closes <id> as a duplicate of it.",
+	GroupID: "beads",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		into, _ := cmd.Flags().GetString("into")
+		if into == "" {
+			fmt.Fprintln(os.Stderr, "Error: --into is required")
+			os.Exit(1)
+		}
+
+		resp, err := client.MergeBead(context.Background(), &beadsv1.MergeBeadRequest{
+			Id:       args[0],
+			Into:     into,
+			MergedBy: actor,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printBeadJSON(resp.GetTarget())
+		} else {
+			fmt.Printf("Merged %s into %s\n", resp.GetSource().GetId(), resp.GetTarget().GetId())
+		}
+		return nil
+	},
+}
+
+// warnSimilar prints beads whose titles resemble the given bead's to stderr.
+// Lookup failures are ignored; the warning is advisory.
+func warnSimilar(id string) {
+	resp, err := client.FindSimilarBeads(context.Background(), &beadsv1.FindSimilarBeadsRequest{Id: id})
+	if err != nil || len(resp.GetSimilar()) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "Possible duplicates:")
+	for _, sb := range resp.GetSimilar() {
+		b := sb.GetBead()
+		fmt.Fprintf(os.Stderr, "  %s  %s (%.0f%% similar)\n", b.GetId(), b.GetTitle(), sb.GetSimilarity()*100)
+	}
+	fmt.Fprintf(os.Stderr, "Use 'bd merge %s --into <id>' if this is a duplicate.\n", id)
+}
+
+func init() {
+	mergeCmd.Flags().String("into", "", "bead to merge into (required)")
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Claim the most urgent ready bead",
+	Long: `Atomically claims the most urgent ready open bead that is unassigned or
+already yours, and sets it in progress. With --label, the bead must carry at
+least one of the labels. Concurrent agents never receive the same bead.`,
+	Args:    cobra.NoArgs,
+	GroupID: "workflow",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		labels, _ := cmd.Flags().GetStringSlice("label")
+
+		resp, err := client.PopQueue(context.Background(), &beadsv1.PopQueueRequest{
+			Actor:  actor,
+			Labels: labels,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if resp.GetBead() == nil {
+			if jsonOutput {
+				fmt.Println("null")
+			} else {
+				fmt.Println("No ready beads.")
+			}
+			return nil
+		}
+		if jsonOutput {
+			printBeadJSON(resp.GetBead())
+		} else {
+			printBeadTable(resp.GetBead())
+		}
+		return nil
+	},
+}
+
+func init() {
+	nextCmd.Flags().StringSlice("label", nil, "capability label; the bead must have at least one (repeatable)")
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var noteCmd = &cobra.Command{
+	Use:     "note",
+	Short:   "Append to and read bead notes",
+	GroupID: "beads",
+}
+
+var noteAddCmd = &cobra.Command{
+	Use:   "add <bead-id> <text>...",
+	Short: "Append a timestamped note entry to a bead",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		beadID := args[0]
+		text := strings.Join(args[1:], " ")
+
+		resp, err := client.AddNote(context.Background(), &beadsv1.AddNoteRequest{
+			BeadId: beadID,
+			Author: actor,
+			Text:   text,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		n := resp.GetNote()
+		if jsonOutput {
+			data, err := json.MarshalIndent(n, "", "  ")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+		} else {
+			fmt.Printf("Appended note %d to %s\n", n.GetId(), n.GetBeadId())
+		}
+		return nil
+	},
+}
+
+var noteListCmd = &cobra.Command{
+	Use:   "list <bead-id>",
+	Short: "Show the note history of a bead",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		beadID := args[0]
+
+		resp, err := client.GetNotes(context.Background(), &beadsv1.GetNotesRequest{
+			BeadId: beadID,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		notes := resp.GetNotes()
+		if jsonOutput {
+			data, err := json.MarshalIndent(notes, "", "  ")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+		} else {
+			if len(notes) == 0 {
+				fmt.Println("No notes found.")
+				return nil
+			}
+			for _, n := range notes {
+				createdAt := ""
+				if n.GetCreatedAt() != nil {
+					createdAt = n.GetCreatedAt().AsTime().Format("2006-01-02 15:04:05")
+				}
+				fmt.Printf("[%s] %s: %s\n", createdAt, n.GetAuthor(), n.GetText())
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	noteCmd.AddCommand(noteAddCmd)
+	noteCmd.AddCommand(noteListCmd)
+}
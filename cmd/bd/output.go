@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/ui"
+	"golang.org/x/term"
+	"google.golang.org/protobuf/proto"
 )
 
 func printBeadJSON(bead *beadsv1.Bead) {
@@ -28,6 +32,9 @@ func printBeadTable(bead *beadsv1.Bead) {
 	fmt.Printf("Kind:        %s\n", bead.GetKind())
 	fmt.Printf("Status:      %s\n", bead.GetStatus())
 	fmt.Printf("Priority:    %d\n", bead.GetPriority())
+	if bead.EffectivePriority != nil && bead.GetEffectivePriority() < bead.GetPriority() {
+		fmt.Printf("Effective:   %d%s\n", bead.GetEffectivePriority(), priorityChainSuffix(bead.GetPriorityChain()))
+	}
 	fmt.Printf("Assignee:    %s\n", bead.GetAssignee())
 	fmt.Printf("Owner:       %s\n", bead.GetOwner())
 	if bead.GetDescription() != "" {
@@ -43,6 +50,25 @@ func printBeadTable(bead *beadsv1.Bead) {
 	if bead.GetUpdatedAt() != nil {
 		fmt.Printf("Updated At:  %s\n", bead.GetUpdatedAt().AsTime().Format("2006-01-02 15:04:05"))
 	}
+	fmt.Printf("Age:         %dd\n", bead.GetAgeDays())
+	if bead.GetBlockedCount() > 0 {
+		fmt.Printf("Blocking:    %d\n", bead.GetBlockedCount())
+	}
+	if bead.GetLastActivityAt() != nil {
+		fmt.Printf("Last Active: %s\n", bead.GetLastActivityAt().AsTime().Format("2006-01-02 15:04:05"))
+	}
+	if bead.GetChecklistTotal() > 0 {
+		fmt.Printf("Checklist:   %d/%d\n", bead.GetChecklistDone(), bead.GetChecklistTotal())
+	}
+	if bead.GetEstimate() != "" {
+		fmt.Printf("Estimate:    %s\n", bead.GetEstimate())
+	}
+	if bead.GetActual() != "" {
+		fmt.Printf("Actual:      %s\n", bead.GetActual())
+	}
+	if bead.GetArchivedAt() != nil {
+		fmt.Printf("Archived At: %s\n", bead.GetArchivedAt().AsTime().Format("2006-01-02 15:04:05"))
+	}
 }
 
 func printBeadListJSON(beads []*beadsv1.Bead) {
@@ -162,6 +188,8 @@ func printBeadTableFiltered(bead *beadsv1.Bead, fields []string) {
 		{"Priority", "priority", fmt.Sprintf("%d", bead.GetPriority())},
 		{"Assignee", "assignee", bead.GetAssignee()},
 		{"Owner", "owner", bead.GetOwner()},
+		{"Estimate", "estimate", bead.GetEstimate()},
+		{"Actual", "actual", bead.GetActual()},
 	}
 	for _, r := range rows {
 		if fieldSet[r.key] {
@@ -183,6 +211,15 @@ func printBeadTableFiltered(bead *beadsv1.Bead, fields []string) {
 	if fieldSet["updated_at"] && bead.GetUpdatedAt() != nil {
 		fmt.Printf("%-13s%s\n", "Updated At:", bead.GetUpdatedAt().AsTime().Format("2006-01-02 15:04:05"))
 	}
+	if fieldSet["age_days"] {
+		fmt.Printf("%-13s%dd\n", "Age:", bead.GetAgeDays())
+	}
+	if fieldSet["blocked_count"] {
+		fmt.Printf("%-13s%d\n", "Blocking:", bead.GetBlockedCount())
+	}
+	if fieldSet["last_activity_at"] && bead.GetLastActivityAt() != nil {
+		fmt.Printf("%-13s%s\n", "Last Active:", bead.GetLastActivityAt().AsTime().Format("2006-01-02 15:04:05"))
+	}
 }
 
 // printComments prints bead comments in a standard format.
@@ -197,6 +234,185 @@ func printComments(comments []*beadsv1.Comment) {
 		if c.GetCreatedAt() != nil {
 			ts = c.GetCreatedAt().AsTime().Format("2006-01-02 15:04:05")
 		}
-		fmt.Printf("  [%s] %s: %s\n", ts, c.GetAuthor(), c.GetText())
+		fmt.Printf("  [%s] %s: %s%s\n", ts, c.GetAuthor(), c.GetText(), commentState(c))
+	}
+}
+
+// commentState describes a comment's resolution and reaction counts, as a
+// suffix for its line, e.g. " (resolved by bob) +1×2 eyes×1".
+func commentState(c *beadsv1.Comment) string {
+	var s string
+	if c.GetClaimedAuthor() != "" {
+		s = " (claimed to be " + c.GetClaimedAuthor() + ")"
+	}
+	if c.GetResolvedAt() != nil {
+		s += " (resolved"
+		if c.GetResolvedBy() != "" {
+			s += " by " + c.GetResolvedBy()
+		}
+		s += ")"
+	}
+	emojis := make([]string, 0, len(c.GetReactions()))
+	for e := range c.GetReactions() {
+		emojis = append(emojis, e)
+	}
+	sort.Strings(emojis)
+	for _, e := range emojis {
+		s += fmt.Sprintf(" %s×%d", e, c.GetReactions()[e])
+	}
+	return s
+}
+
+// printMarkdownComments prints bead comments like printComments, with each
+// comment's text rendered as Markdown beneath its header.
+func printMarkdownComments(comments []*beadsv1.Comment) {
+	if len(comments) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Comments:")
+	for _, c := range comments {
+		ts := ""
+		if c.GetCreatedAt() != nil {
+			ts = c.GetCreatedAt().AsTime().Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("  [%s] %s:%s\n", ts, c.GetAuthor(), commentState(c))
+		fmt.Println(renderMarkdown(c.GetText(), "    "))
+	}
+}
+
+// printBeadMarkdown prints bead like printBeadTable, with the description
+// rendered as Markdown in its own section.
+func printBeadMarkdown(bead *beadsv1.Bead) {
+	fields := proto.Clone(bead).(*beadsv1.Bead)
+	fields.Description = ""
+	printBeadTable(fields)
+	if strings.TrimSpace(bead.GetDescription()) != "" {
+		fmt.Println()
+		fmt.Println("Description:")
+		fmt.Println(renderMarkdown(bead.GetDescription(), "  "))
 	}
 }
+
+// renderMarkdown renders s as Markdown with every line indented by indent,
+// wrapping to the terminal width (at most 100 columns) when stdout is one.
+func renderMarkdown(s, indent string) string {
+	width := 0
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		width = min(w, 100) - len(indent)
+	}
+	lines := strings.Split(ui.RenderMarkdown(s, width), "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = indent + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// printActivity prints a bead's activity feed, oldest first. Comments are
+// part of the feed.
+func printActivity(activity []*beadsv1.ActivityEntry) {
+	if len(activity) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Activity:")
+	for _, a := range activity {
+		ts := ""
+		if a.GetCreatedAt() != nil {
+			ts = a.GetCreatedAt().AsTime().Format("2006-01-02 15:04:05")
+		}
+		who := a.GetActor()
+		if who == "" {
+			who = "-"
+		}
+		fmt.Printf("  [%s] %s %s\n", ts, who, a.GetSummary())
+	}
+}
+
+func printRelations(relations []*beadsv1.Relation) {
+	if len(relations) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Relations:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, r := range relations {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", r.GetLabel(), r.GetBeadId(), r.GetStatus(), r.GetTitle())
+	}
+	w.Flush()
+}
+
+func printExternalDeps(deps []*beadsv1.ExternalDep) {
+	if len(deps) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("External:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, d := range deps {
+		note := d.GetDescription()
+		if d.GetProbe() != "" {
+			note = strings.TrimSpace(note + " [" + d.GetProbe() + "]")
+		}
+		if d.GetStatus() == "waiting" && d.GetLastError() != "" {
+			note += " (" + d.GetLastError() + ")"
+		}
+		fmt.Fprintf(w, "  #%d\t%s\t%s\t%s\n", d.GetId(), d.GetStatus(), d.GetUrl(), note)
+	}
+	w.Flush()
+}
+
+// printCommits prints a bead's linked commits, one line each: the short
+// SHA, the repository and the message's first line.
+func printCommits(commits []*beadsv1.Commit) {
+	if len(commits) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Commits:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, c := range commits {
+		sha := c.GetSha()
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		subject, _, _ := strings.Cut(c.GetMessage(), "\n")
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", sha, c.GetRepo(), subject)
+	}
+	w.Flush()
+}
+
+// priorityChainSuffix describes the blocking chain a bead inherits its
+// effective priority through, e.g. " (blocks bd-b → bd-c)". The chain starts
+// at the bead itself.
+func priorityChainSuffix(chain []string) string {
+	if len(chain) < 2 {
+		return ""
+	}
+	return " (blocks " + strings.Join(chain[1:], " → ") + ")"
+}
+
+// printChecklist prints a bead's checklist, one line per item with its
+// number and check box.
+func printChecklist(items []*beadsv1.ChecklistItem) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Checklist:")
+	for _, it := range items {
+		fmt.Printf("  %d. %s %s\n", it.GetIndex(), checkBox(it.GetChecked()), it.GetText())
+	}
+}
+
+// printJSON prints v as indented JSON.
+func printJSON(v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
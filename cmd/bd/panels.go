@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	beadsclient "github.com/alfredjeanlab/beads/client"
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"golang.org/x/term"
+)
+
+// watchPanel is one pane of bd watch --panel: a named query and the beads
+// it matched on the last refresh.
+type watchPanel struct {
+	name  string
+	query string
+	beads []*beadsv1.Bead
+	fresh map[string]bool // IDs that started matching on the last refresh
+	err   error
+}
+
+// Panel layouts: panes stacked top to bottom, or side by side.
+const (
+	layoutRows    = "rows"
+	layoutColumns = "columns"
+)
+
+// defaultPanelCoalesce is the event stream window of bd watch --panel when
+// --coalesce is not set.
+const defaultPanelCoalesce = time.Second
+
+// meValue matches "me" as the value of an assignee or owner condition.
+var meValue = regexp.MustCompile(`\b(assignee|owner)(:|!=|=)((?:[^\s,()]+,)*)me\b`)
+
+// parsePanels parses --panel specs of the form name=query. In the query,
+// "me" as an assignee or owner stands for the caller.
+func parsePanels(specs []string, me string) ([]*watchPanel, error) {
+	panels := make([]*watchPanel, 0, len(specs))
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		name, query, ok := strings.Cut(spec, "=")
+		name, query = strings.TrimSpace(name), strings.TrimSpace(query)
+		if !ok || name == "" || query == "" {
+			return nil, fmt.Errorf("invalid panel %q: want name=query, e.g. ready='status=open'", spec)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("panel %q is given twice", name)
+		}
+		seen[name] = true
+		if me != "" {
+			query = meValue.ReplaceAllString(query, "${1}${2}${3}"+me)
+		}
+		panels = append(panels, &watchPanel{name: name, query: expandVar(query)})
+	}
+	return panels, nil
+}
+
+// refresh re-runs the panel's query and returns how many beads newly match
+// it. Nothing counts as new on the first refresh.
+func (p *watchPanel) refresh(ctx context.Context, limit int32) int {
+	resp, err := client.ListBeads(ctx, &beadsv1.ListBeadsRequest{Query: p.query, Limit: limit})
+	if err != nil {
+		p.err = err
+		return 0
+	}
+	first := p.fresh == nil
+	prev := make(map[string]bool, len(p.beads))
+	for _, b := range p.beads {
+		prev[b.GetId()] = true
+	}
+	p.beads, p.err = resp.GetBeads(), nil
+	if p.beads == nil {
+		p.beads = []*beadsv1.Bead{}
+	}
+	p.fresh = make(map[string]bool)
+	for _, b := range p.beads {
+		if !first && !prev[b.GetId()] {
+			p.fresh[b.GetId()] = true
+		}
+	}
+	return len(p.fresh)
+}
+
+// lines renders the panel as a header and up to height-1 rows, each at most
+// width runes. New matches are marked with "+".
+func (p *watchPanel) lines(width, height int) []string {
+	lines := []string{truncate(fmt.Sprintf("── %s (%d) %s", p.name, len(p.beads), strings.Repeat("─", width)), width)}
+	switch {
+	case p.err != nil:
+		lines = append(lines, truncate(fmt.Sprintf("Error: %v", p.err), width))
+	case len(p.beads) == 0:
+		lines = append(lines, "No beads.")
+	}
+	for i, b := range p.beads {
+		if len(lines) >= height {
+			break
+		}
+		if i == height-2 && len(p.beads) > height-1 {
+			lines = append(lines, fmt.Sprintf("  ... %d more", len(p.beads)-i))
+			break
+		}
+		vals := make([]string, len(uiDefaultColumns))
+		for j, col := range uiDefaultColumns {
+			vals[j] = beadField(b, col)
+		}
+		mark := "  "
+		if p.fresh[b.GetId()] {
+			mark = "+ "
+		}
+		lines = append(lines, truncate(mark+strings.Join(vals, "  "), width))
+	}
+	return lines
+}
+
+// renderPanels lays the panels out on a screen of width by height.
+func renderPanels(panels []*watchPanel, layout string, width, height int) string {
+	if len(panels) == 0 {
+		return ""
+	}
+	if layout == layoutColumns {
+		colWidth := (width - (len(panels) - 1)) / len(panels)
+		cols := make([][]string, len(panels))
+		rows := 0
+		for i, p := range panels {
+			cols[i] = p.lines(colWidth, height)
+			rows = max(rows, len(cols[i]))
+		}
+		out := make([]string, rows)
+		for r := range out {
+			cells := make([]string, len(cols))
+			for i, col := range cols {
+				if r < len(col) {
+					cells[i] = col[r]
+				}
+				if i < len(cols)-1 {
+					cells[i] += strings.Repeat(" ", max(colWidth-len([]rune(cells[i])), 0))
+				}
+			}
+			out[r] = strings.TrimRight(strings.Join(cells, " "), " ")
+		}
+		return strings.Join(out, "\n")
+	}
+
+	// Rows: each panel gets an equal share of the height, less a blank
+	// line between panels.
+	paneHeight := max((height-(len(panels)-1))/len(panels), 2)
+	var out []string
+	for i, p := range panels {
+		if i > 0 {
+			out = append(out, "")
+		}
+		out = append(out, p.lines(width, paneHeight)...)
+	}
+	return strings.Join(out, "\n")
+}
+
+// watchPanels refreshes the panels after each batch of changes from one
+// event stream and redraws them, ringing the terminal bell when a panel
+// gains a bead. When stdout is not a terminal it prints each new match as
+// a line instead, prefixed with its panel's name.
+func watchPanels(ctx context.Context, panels []*watchPanel, layout string, limit int32, coalesce time.Duration, once, bell bool) error {
+	tty := term.IsTerminal(int(os.Stdout.Fd())) && !jsonOutput
+	show := func(initial bool) {
+		fresh := 0
+		for _, p := range panels {
+			fresh += p.refresh(ctx, limit)
+		}
+		if tty {
+			width, height, err := term.GetSize(int(os.Stdout.Fd()))
+			if err != nil {
+				width, height = 80, 24
+			}
+			fmt.Print("\x1b[H\x1b[2J" + renderPanels(panels, layout, width, height-1) + "\n")
+		} else {
+			printPanelMatches(panels, initial)
+		}
+		if bell && fresh > 0 {
+			fmt.Fprint(os.Stderr, "\a")
+		}
+	}
+
+	show(true)
+	if once {
+		return nil
+	}
+	updates, err := streamUpdates(ctx, beadsclient.EventFilter{Coalesce: coalesce})
+	if err != nil {
+		return err
+	}
+
+	debounce := time.NewTimer(0)
+	debounce.Stop()
+	select {
+	case <-debounce.C:
+	default:
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-updates:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("event stream closed")
+			}
+			debounce.Reset(200 * time.Millisecond)
+		case <-debounce.C:
+			show(false)
+		}
+	}
+}
+
+// printPanelMatches prints the beads of each panel, all of them initially
+// and then only new matches, as "panel  id  title" lines or JSON objects.
+func printPanelMatches(panels []*watchPanel, initial bool) {
+	for _, p := range panels {
+		if p.err != nil {
+			fmt.Fprintf(os.Stderr, "Error: panel %s: %v\n", p.name, p.err)
+			continue
+		}
+		for _, b := range p.beads {
+			if !initial && !p.fresh[b.GetId()] {
+				continue
+			}
+			if jsonOutput {
+				printJSON(map[string]any{"panel": p.name, "bead": b})
+			} else {
+				fmt.Printf("%s\t%s\t%s\n", p.name, b.GetId(), b.GetTitle())
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var patchCmd = &cobra.Command{
+	Use:   "patch <bead-id> [patch]",
+	Short: "Apply a JSON Patch to a bead's fields",
+	Long: `Apply an RFC 6902 JSON Patch to a bead's custom fields, read from the
+argument or, without one, from stdin. The server applies it to the fields as
+stored, so concurrent patches do not overwrite each other; add a "test"
+operation to make the patch conditional.
+
+  bd patch bd-a1b2 '[{"op":"add","path":"/changes/-","value":"raised limit"}]'
+  bd patch bd-a1b2 '[{"op":"test","path":"/owner","value":"alice"},{"op":"replace","path":"/owner","value":"bob"}]'`,
+	GroupID: "beads",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd patch", server.FeatureFieldsPatch)
+		var data []byte
+		if len(args) == 2 {
+			data = []byte(args[1])
+		} else {
+			var err error
+			if data, err = io.ReadAll(os.Stdin); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		var ops []model.PatchOp
+		if err := json.Unmarshal(data, &ops); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: patch must be a JSON array of operations: %v\n", err)
+			os.Exit(1)
+		}
+
+		path := "/v1/beads/" + url.PathEscape(args[0]) + "/fields"
+		if actor != "" {
+			path += "?actor=" + url.QueryEscape(actor)
+		}
+		body, err := httpDo(context.Background(), http.MethodPatch, path, bearerTokenFromEnv(), ops)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		var bead model.Bead
+		if err := json.Unmarshal(body, &bead); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printJSON(bead)
+		} else {
+			fmt.Printf("Patched %s: %s\n", bead.ID, bead.Fields)
+		}
+		return nil
+	},
+}
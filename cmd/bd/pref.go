@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/alfredjeanlab/beads/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// cliPrefs are the caller's preferences as stored by /v1/prefs. Zero values
+// mean unset.
+type cliPrefs struct {
+	Columns []string `json:"columns"`
+	Sort    string   `json:"sort"`
+	Theme   string   `json:"theme"`
+	Limit   int32    `json:"limit"`
+}
+
+// prefsRecord is the response of the /v1/prefs endpoints.
+type prefsRecord struct {
+	Actor string                     `json:"actor"`
+	Prefs map[string]json.RawMessage `json:"prefs"`
+}
+
+// prefs holds the preferences loaded before the command ran; list commands
+// use them in place of flag defaults.
+var prefs cliPrefs
+
+// prefLoadTimeout bounds the preference fetch attempted before each command.
+const prefLoadTimeout = 2 * time.Second
+
+// loadPrefs fetches the caller's preferences and applies the theme. An
+// unreachable server or invalid response leaves the flag defaults alone.
+func loadPrefs() {
+	ctx, cancel := context.WithTimeout(context.Background(), prefLoadTimeout)
+	defer cancel()
+	rec, err := fetchPrefs(ctx)
+	if err != nil {
+		return
+	}
+	var p cliPrefs
+	for name, v := range rec.Prefs {
+		switch name {
+		case "columns":
+			_ = json.Unmarshal(v, &p.Columns)
+		case "sort":
+			_ = json.Unmarshal(v, &p.Sort)
+		case "theme":
+			_ = json.Unmarshal(v, &p.Theme)
+		case "limit":
+			_ = json.Unmarshal(v, &p.Limit)
+		}
+	}
+	prefs = p
+	switch prefs.Theme {
+	case "none":
+		ui.ForceNoColor()
+	case "color":
+		ui.ForceColor()
+	}
+}
+
+// prefsPath returns path with the --actor query parameter, if set.
+func prefsPath(path string) string {
+	if actor != "" {
+		path += "?actor=" + url.QueryEscape(actor)
+	}
+	return path
+}
+
+func fetchPrefs(ctx context.Context) (*prefsRecord, error) {
+	body, err := httpGet(ctx, prefsPath("/v1/prefs"))
+	if err != nil {
+		return nil, err
+	}
+	var rec prefsRecord
+	if err := json.Unmarshal(body, &rec); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	return &rec, nil
+}
+
+// printPrefs prints rec one preference per line, or as JSON.
+func printPrefs(rec *prefsRecord) {
+	if jsonOutput {
+		printJSON(rec)
+		return
+	}
+	if len(rec.Prefs) == 0 {
+		fmt.Printf("No preferences set for %s.\n", rec.Actor)
+		return
+	}
+	names := make([]string, 0, len(rec.Prefs))
+	for name := range rec.Prefs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("%-12s %s\n", name, rec.Prefs[name])
+	}
+}
+
+// prefValue converts the command-line value of preference name to JSON.
+func prefValue(name, value string) any {
+	switch name {
+	case "columns", "email_notify":
+		cols := strings.Split(value, ",")
+		for i, c := range cols {
+			cols[i] = strings.ToLower(strings.TrimSpace(c))
+		}
+		return cols
+	case "limit":
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return value
+}
+
+var prefCmd = &cobra.Command{
+	Use:   "pref",
+	Short: "Show or change your CLI preferences",
+	Long: `Preferences are stored on the server per actor, so every machine and agent
+pod running bd as that actor shares them. bd loads them before each command:
+
+  columns  default --columns for list, ready, search and export (id,title,...)
+  sort     default --sort for list, ready and blocked (e.g. -priority)
+  limit    default --limit for list, ready and blocked
+  theme    auto, color or none
+
+Flags given on the command line always win. Email notifications, sent when
+the server has an integration:email config, are opted in to here too:
+
+  email         address to send notifications to
+  email_notify  kinds to send (assigned,mentioned,decision,overdue,digest);
+                all of them when unset
+  email_batch   collect notifications into one email this often (e.g. 1h);
+                each is sent at once when unset`,
+	GroupID: "system",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd pref", server.FeaturePrefs)
+		rec, err := fetchPrefs(context.Background())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		printPrefs(rec)
+		return nil
+	},
+}
+
+var prefSetCmd = &cobra.Command{
+	Use:   "set <name> <value>",
+	Short: "Set a preference",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd pref set", server.FeaturePrefs)
+		body, err := httpDo(context.Background(), http.MethodPut, "/v1/prefs/"+url.PathEscape(args[0]), bearerTokenFromEnv(),
+			map[string]any{"value": prefValue(args[0], args[1]), "actor": actor})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		var rec prefsRecord
+		if err := json.Unmarshal(body, &rec); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+			os.Exit(1)
+		}
+		printPrefs(&rec)
+		return nil
+	},
+}
+
+var prefUnsetCmd = &cobra.Command{
+	Use:   "unset <name>",
+	Short: "Unset a preference",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd pref unset", server.FeaturePrefs)
+		body, err := httpDo(context.Background(), http.MethodDelete, prefsPath("/v1/prefs/"+url.PathEscape(args[0])), bearerTokenFromEnv(), nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		var rec prefsRecord
+		if err := json.Unmarshal(body, &rec); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+			os.Exit(1)
+		}
+		printPrefs(&rec)
+		return nil
+	},
+}
+
+func init() {
+	prefCmd.AddCommand(prefSetCmd)
+	prefCmd.AddCommand(prefUnsetCmd)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var readyCmd = &cobra.Command{
+	Use:   "ready",
+	Short: "List open beads that nothing blocks",
+	Long: `Lists the beads with no unclosed blocking dependency, most urgent first.
+Status defaults to open; the filters are those of bd list.`,
+	Args:    cobra.NoArgs,
+	GroupID: "views",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req, err := listRequestFromFlags(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		format, columns, err := listFormatFromFlags(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		resp, err := client.ListReadyBeads(context.Background(), req)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		printBeadList(resp.GetBeads(), resp.GetTotal(), format, columns)
+		return nil
+	},
+}
+
+var blockedCmd = &cobra.Command{
+	Use:   "blocked",
+	Short: "List open beads waiting on an unclosed blocker",
+	Long: `Lists the beads with at least one unclosed blocking dependency, most
+urgent first, with the beads blocking each. Status defaults to open; the
+filters are those of bd list.`,
+	Args:    cobra.NoArgs,
+	GroupID: "views",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req, err := listRequestFromFlags(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		resp, err := client.ListBlockedBeads(context.Background(), req)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printJSON(resp.GetBeads())
+			return nil
+		}
+		printBlockedBeads(resp.GetBeads(), resp.GetTotal())
+		return nil
+	},
+}
+
+func init() {
+	addListFormatFlags(readyCmd, "table")
+	addListFilterFlags(readyCmd)
+	addListFilterFlags(blockedCmd)
+}
+
+func printBlockedBeads(beads []*beadsv1.BlockedBead, total int32) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tPRIORITY\tTITLE\tBLOCKED BY")
+	for _, bb := range beads {
+		b := bb.GetBead()
+		title := b.GetTitle()
+		if len(title) > 50 {
+			title = title[:47] + "..."
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+			b.GetId(),
+			b.GetStatus(),
+			b.GetPriority(),
+			title,
+			strings.Join(append(bb.GetBlockedBy(), bb.GetWaitingOn()...), ", "),
+		)
+	}
+	w.Flush()
+	fmt.Printf("\n%d beads (%d total)\n", len(beads), total)
+}
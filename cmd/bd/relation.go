@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+)
+
+var relationCmd = &cobra.Command{
+	Use:   "relation",
+	Short: "Manage non-blocking relations between beads",
+	Long: `Relations link beads without blocking either one. The types are
+duplicate-of, relates-to, caused-by and fixed-by:
+
+  bd relation add kd-bug caused-by kd-refactor
+  bd relation add kd-bug fixed-by kd-patch
+
+Relations are listed by bd show in both directions, read from the bead shown
+(kd-refactor "causes" kd-bug).`,
+	GroupID: "beads",
+}
+
+var relationAddCmd = &cobra.Command{
+	Use:   "add <bead-id> <type> <other-id>",
+	Short: "Relate a bead to another",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.AddRelation(context.Background(), &beadsv1.AddRelationRequest{
+			BeadId:    args[0],
+			Type:      args[1],
+			OtherId:   args[2],
+			CreatedBy: actor,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printJSON(resp.GetDependency())
+		} else {
+			fmt.Printf("%s %s %s\n", args[0], args[1], args[2])
+		}
+		return nil
+	},
+}
+
+var relationRemoveCmd = &cobra.Command{
+	Use:   "remove <bead-id> <type> <other-id>",
+	Short: "Remove a relation",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := client.RemoveDependency(context.Background(), &beadsv1.RemoveDependencyRequest{
+			BeadId:      args[0],
+			Type:        args[1],
+			DependsOnId: args[2],
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Removed relation %s %s %s\n", args[0], args[1], args[2])
+		return nil
+	},
+}
+
+var relationListCmd = &cobra.Command{
+	Use:   "list <bead-id>",
+	Short: "List a bead's relations in both directions",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.ListRelations(context.Background(), &beadsv1.ListRelationsRequest{BeadId: args[0]})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printJSON(resp.GetRelations())
+			return nil
+		}
+		if len(resp.GetRelations()) == 0 {
+			fmt.Println("No relations.")
+			return nil
+		}
+		printRelations(resp.GetRelations())
+		return nil
+	},
+}
+
+func init() {
+	relationCmd.AddCommand(relationAddCmd)
+	relationCmd.AddCommand(relationRemoveCmd)
+	relationCmd.AddCommand(relationListCmd)
+}
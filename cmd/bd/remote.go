@@ -100,11 +100,22 @@ func activeRemoteURL() string   { loadActiveRemoteOnce(); return cachedRemoteURL
 func activeRemoteToken() string  { loadActiveRemoteOnce(); return cachedToken }
 func activeRemoteNATSURL() string { loadActiveRemoteOnce(); return cachedNATSURL }
 
+// bearerTokenFromEnv returns the agent token from BEADS_TOKEN, falling back
+// to the active remote's token.
+func bearerTokenFromEnv() string {
+	if tok := os.Getenv("BEADS_TOKEN"); tok != "" {
+		return tok
+	}
+	return activeRemoteToken()
+}
+
 // bearerTokenInterceptor returns a gRPC unary interceptor that attaches a
-// Bearer token to every outgoing call.
+// Bearer token to every outgoing call that does not already carry one.
 func bearerTokenInterceptor(token string) grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		if md, ok := metadata.FromOutgoingContext(ctx); !ok || len(md.Get("authorization")) == 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		}
 		return invoker(ctx, method, req, reply, cc, opts...)
 	}
 }
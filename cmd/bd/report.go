@@ -0,0 +1,419 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/spf13/cobra"
+)
+
+// dailyReport mirrors the server's GET /v1/reports/daily response.
+type dailyReport struct {
+	Date              string `json:"date"`
+	Created           int    `json:"created"`
+	Closed            int    `json:"closed"`
+	Claimed           int    `json:"claimed"`
+	DecisionsResolved int    `json:"decisions_resolved"`
+	DecisionsExpired  int    `json:"decisions_expired"`
+	JacksOpened       int    `json:"jacks_opened"`
+	JacksExpired      int    `json:"jacks_expired"`
+	Resurfacing       int    `json:"resurfacing"`
+	Actors            []struct {
+		Actor             string `json:"actor"`
+		Created           int    `json:"created"`
+		Closed            int    `json:"closed"`
+		Claimed           int    `json:"claimed"`
+		DecisionsResolved int    `json:"decisions_resolved"`
+	} `json:"actors"`
+}
+
+var reportCmd = &cobra.Command{
+	Use:     "report",
+	Short:   "Summaries of activity across all beads",
+	GroupID: "views",
+}
+
+var reportDailyCmd = &cobra.Command{
+	Use:   "daily",
+	Short: "Summarize a day's activity per actor",
+	Long: `Prints how many beads each actor created, closed and claimed on one UTC day,
+how many decisions were resolved or expired, and how many jacks were raised
+or expired while still up, and how many deferred beads were due back. The
+day defaults to
+yesterday. --slack prints the summary as Slack mrkdwn, ready to post to a
+standup channel.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		date, _ := cmd.Flags().GetString("date")
+		slack, _ := cmd.Flags().GetBool("slack")
+
+		body, err := fetchDailyReport(context.Background(), date)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			fmt.Println(string(body))
+			return nil
+		}
+
+		var report dailyReport
+		if err := json.Unmarshal(body, &report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid daily report: %v\n", err)
+			os.Exit(1)
+		}
+		if slack {
+			printDailyReportSlack(os.Stdout, &report)
+		} else {
+			printDailyReport(os.Stdout, &report)
+		}
+		return nil
+	},
+}
+
+// aggregateReport mirrors the server's GET /v1/aggregate response.
+type aggregateReport struct {
+	GroupBy string `json:"group_by"`
+	Metric  string `json:"metric"`
+	Groups  []struct {
+		Key         string  `json:"key"`
+		Count       int     `json:"count"`
+		AvgAgeHours float64 `json:"avg_age_hours"`
+	} `json:"groups"`
+}
+
+var reportGroupByCmd = &cobra.Command{
+	Use:   "group-by <status|type|assignee|label|priority|created_week>",
+	Short: "Count beads and their average age per group",
+	Long: `Groups the beads matching the filters by one dimension and prints each
+group's bead count and average age, largest --metric first. The server
+computes both in the database. Age runs from creation to closing, or to now
+for beads not closed. A bead counts once for each of its labels.
+
+  bd report group-by assignee --metric=count --status open
+  bd report group-by created_week --type bug`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd report group-by", server.FeatureAggregate)
+		metric, _ := cmd.Flags().GetString("metric")
+		status, _ := cmd.Flags().GetStringSlice("status")
+		types, _ := cmd.Flags().GetStringSlice("type")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		query, _ := cmd.Flags().GetString("query")
+
+		q := url.Values{"group_by": {args[0]}, "metric": {metric}}
+		for param, v := range map[string]string{
+			"status":   strings.Join(status, ","),
+			"type":     strings.Join(types, ","),
+			"labels":   strings.Join(labels, ","),
+			"assignee": assignee,
+			"q":        query,
+		} {
+			if v != "" {
+				q.Set(param, v)
+			}
+		}
+		body, err := httpGet(context.Background(), "/v1/aggregate?"+q.Encode())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			fmt.Println(string(body))
+			return nil
+		}
+
+		var report aggregateReport
+		if err := json.Unmarshal(body, &report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid aggregate: %v\n", err)
+			os.Exit(1)
+		}
+		printAggregateReport(os.Stdout, &report)
+		return nil
+	},
+}
+
+// velocityReport mirrors the server's GET /v1/reports/velocity response.
+type velocityReport struct {
+	Window string            `json:"window"`
+	Group  string            `json:"group"`
+	Total  *model.Velocity   `json:"total"`
+	Groups []*model.Velocity `json:"groups"`
+}
+
+var reportVelocityCmd = &cobra.Command{
+	Use:   "velocity",
+	Short: "Total the estimates of recently closed beads",
+	Long: `Totals the estimates and actuals of the beads matching the filters that were
+closed in the last --window, with points and hours closed per week. Points
+and duration estimates are totalled apart. --group splits the report by
+assignee, type or label; a bead counts once for each of its labels.
+
+  bd report velocity --window 2w --group assignee
+  bd report velocity --window 30d --label team:backend`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd report velocity", server.FeatureVelocity)
+		window, _ := cmd.Flags().GetString("window")
+		group, _ := cmd.Flags().GetString("group")
+		types, _ := cmd.Flags().GetStringSlice("type")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		query, _ := cmd.Flags().GetString("query")
+
+		q := url.Values{"window": {window}}
+		for param, v := range map[string]string{
+			"group":    group,
+			"type":     strings.Join(types, ","),
+			"labels":   strings.Join(labels, ","),
+			"assignee": assignee,
+			"q":        query,
+		} {
+			if v != "" {
+				q.Set(param, v)
+			}
+		}
+		body, err := httpGet(context.Background(), "/v1/reports/velocity?"+q.Encode())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			fmt.Println(string(body))
+			return nil
+		}
+
+		var report velocityReport
+		if err := json.Unmarshal(body, &report); err != nil || report.Total == nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid velocity report: %v\n", err)
+			os.Exit(1)
+		}
+		printVelocityReport(os.Stdout, &report)
+		return nil
+	},
+}
+
+// starvationReport mirrors the server's GET /v1/reports/starvation response.
+type starvationReport struct {
+	OlderThan string `json:"older_than"`
+	Group     string `json:"group"`
+	Total     int    `json:"total"`
+	Groups    []struct {
+		Key   string `json:"key"`
+		Count int    `json:"count"`
+	} `json:"groups"`
+	Beads []struct {
+		Bead    *model.Bead `json:"bead"`
+		Waiting string      `json:"waiting"`
+	} `json:"beads"`
+}
+
+var reportStarvationCmd = &cobra.Command{
+	Use:   "starvation",
+	Short: "List ready beads nobody has claimed",
+	Long: `Lists the open, ready, unassigned issues that have waited longer than
+--older-than since they last became ready (created, unblocked, reopened,
+undeferred or released by a claimant), longest waiting first, and counts them
+by priority or label. bd context prime shows the top few, so agents see the
+old work before picking fresh beads.
+
+  bd report starvation
+  bd report starvation --older-than 1w --group label --label team:backend`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd report starvation", server.FeatureStarvation)
+		olderThan, _ := cmd.Flags().GetString("older-than")
+		group, _ := cmd.Flags().GetString("group")
+		limit, _ := cmd.Flags().GetInt("limit")
+		types, _ := cmd.Flags().GetStringSlice("type")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		query, _ := cmd.Flags().GetString("query")
+
+		q := url.Values{"older_than": {olderThan}, "limit": {fmt.Sprint(limit)}}
+		for param, v := range map[string]string{
+			"group":  group,
+			"type":   strings.Join(types, ","),
+			"labels": strings.Join(labels, ","),
+			"q":      query,
+		} {
+			if v != "" {
+				q.Set(param, v)
+			}
+		}
+		body, err := httpGet(context.Background(), "/v1/reports/starvation?"+q.Encode())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			fmt.Println(string(body))
+			return nil
+		}
+
+		var report starvationReport
+		if err := json.Unmarshal(body, &report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid starvation report: %v\n", err)
+			os.Exit(1)
+		}
+		printStarvationReport(os.Stdout, &report, true)
+		return nil
+	},
+}
+
+func init() {
+	reportDailyCmd.Flags().String("date", "", "day to report, as YYYY-MM-DD in UTC (default yesterday)")
+	reportDailyCmd.Flags().Bool("slack", false, "format the report as a Slack message")
+	reportCmd.AddCommand(reportDailyCmd)
+
+	reportGroupByCmd.Flags().String("metric", "count", "order groups by count or avg_age")
+	reportGroupByCmd.Flags().StringSliceP("status", "s", nil, "only beads with this status (repeatable)")
+	reportGroupByCmd.Flags().StringSliceP("type", "t", nil, "only beads of this type (repeatable)")
+	reportGroupByCmd.Flags().StringSliceP("label", "l", nil, "only beads with this label (repeatable)")
+	reportGroupByCmd.Flags().String("assignee", "", "only beads assigned to this actor")
+	reportGroupByCmd.Flags().StringP("query", "q", "", "query language filter")
+	reportCmd.AddCommand(reportGroupByCmd)
+
+	reportVelocityCmd.Flags().String("window", "2w", "how far back to count closures: days (10d), weeks (2w) or a duration")
+	reportVelocityCmd.Flags().String("group", "", "split by assignee, type or label")
+	reportVelocityCmd.Flags().StringSliceP("type", "t", nil, "only beads of this type (repeatable)")
+	reportVelocityCmd.Flags().StringSliceP("label", "l", nil, "only beads with this label (repeatable)")
+	reportVelocityCmd.Flags().String("assignee", "", "only beads assigned to this actor")
+	reportVelocityCmd.Flags().StringP("query", "q", "", "query language filter")
+	reportCmd.AddCommand(reportVelocityCmd)
+
+	reportStarvationCmd.Flags().String("older-than", "3d", "how long beads must have waited: days (3d), weeks (1w) or a duration")
+	reportStarvationCmd.Flags().String("group", "priority", "count by priority or label")
+	reportStarvationCmd.Flags().Int("limit", 20, "most beads to list")
+	reportStarvationCmd.Flags().StringSliceP("type", "t", nil, "only beads of this type (repeatable)")
+	reportStarvationCmd.Flags().StringSliceP("label", "l", nil, "only beads with this label (repeatable)")
+	reportStarvationCmd.Flags().StringP("query", "q", "", "query language filter")
+	reportCmd.AddCommand(reportStarvationCmd)
+}
+
+// fetchDailyReport downloads the daily report for date, or for yesterday
+// when date is empty.
+func fetchDailyReport(ctx context.Context, date string) ([]byte, error) {
+	path := "/v1/reports/daily"
+	if date != "" {
+		path += "?date=" + url.QueryEscape(date)
+	}
+	return httpGet(ctx, path)
+}
+
+func printDailyReport(w io.Writer, r *dailyReport) {
+	fmt.Fprintf(w, "Daily report for %s\n\n", r.Date)
+	fmt.Fprintf(w, "Created:     %d\n", r.Created)
+	fmt.Fprintf(w, "Closed:      %d\n", r.Closed)
+	fmt.Fprintf(w, "Claimed:     %d\n", r.Claimed)
+	fmt.Fprintf(w, "Decisions:   %d resolved, %d expired\n", r.DecisionsResolved, r.DecisionsExpired)
+	fmt.Fprintf(w, "Jacks:       %d opened, %d expired\n", r.JacksOpened, r.JacksExpired)
+	fmt.Fprintf(w, "Resurfacing: %d\n", r.Resurfacing)
+	if len(r.Actors) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ACTOR\tCREATED\tCLOSED\tCLAIMED\tDECISIONS")
+	for _, a := range r.Actors {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\n", a.Actor, a.Created, a.Closed, a.Claimed, a.DecisionsResolved)
+	}
+	tw.Flush()
+}
+
+// printDailyReportSlack prints r as Slack mrkdwn.
+func printDailyReportSlack(w io.Writer, r *dailyReport) {
+	fmt.Fprintf(w, "*Daily report for %s*\n", r.Date)
+	fmt.Fprintf(w, "%d created · %d closed · %d claimed · %d decisions resolved · %d expired · %d jacks opened · %d expired · %d resurfacing\n",
+		r.Created, r.Closed, r.Claimed, r.DecisionsResolved, r.DecisionsExpired, r.JacksOpened, r.JacksExpired, r.Resurfacing)
+	for _, a := range r.Actors {
+		fmt.Fprintf(w, "• *%s*: %d created, %d closed, %d claimed, %d decisions\n",
+			a.Actor, a.Created, a.Closed, a.Claimed, a.DecisionsResolved)
+	}
+}
+
+// printAggregateReport prints one row per group with its count and average
+// age in days.
+func printAggregateReport(w io.Writer, r *aggregateReport) {
+	if len(r.Groups) == 0 {
+		fmt.Fprintln(w, "No beads match.")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\tCOUNT\tAVG AGE\n", strings.ToUpper(r.GroupBy))
+	for _, g := range r.Groups {
+		key := g.Key
+		if key == "" {
+			key = "(none)"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%.1fd\n", key, g.Count, g.AvgAgeHours/24)
+	}
+	tw.Flush()
+}
+
+// printVelocityReport prints the window's totals, then one row per group.
+func printVelocityReport(w io.Writer, r *velocityReport) {
+	t := r.Total
+	fmt.Fprintf(w, "Velocity over %s\n\n", r.Window)
+	fmt.Fprintf(w, "Closed:    %d (%d estimated)\n", t.Closed, t.Estimated)
+	fmt.Fprintf(w, "Estimated: %g points, %.1fh\n", t.Points, t.Hours)
+	fmt.Fprintf(w, "Actual:    %g points, %.1fh\n", t.ActualPoints, t.ActualHours)
+	fmt.Fprintf(w, "Per week:  %.1f points, %.1fh\n", t.PointsPerWeek, t.HoursPerWeek)
+	if len(r.Groups) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\tCLOSED\tPOINTS\tHOURS\tPOINTS/WK\tHOURS/WK\n", strings.ToUpper(r.Group))
+	for _, g := range r.Groups {
+		key := g.Key
+		if key == "" {
+			key = "(none)"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%g\t%.1f\t%.1f\t%.1f\n", key, g.Closed, g.Points, g.Hours, g.PointsPerWeek, g.HoursPerWeek)
+	}
+	tw.Flush()
+}
+
+// printStarvationReport prints the longest waiting beads, then, when groups
+// is set, one row per group.
+func printStarvationReport(w io.Writer, r *starvationReport, groups bool) {
+	if r.Total == 0 {
+		fmt.Fprintf(w, "No ready beads have waited over %s.\n", r.OlderThan)
+		return
+	}
+	fmt.Fprintf(w, "%d ready beads unclaimed for over %s\n\n", r.Total, r.OlderThan)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tPRIORITY\tWAITING\tTITLE")
+	for _, sb := range r.Beads {
+		waiting, _ := time.ParseDuration(sb.Waiting)
+		fmt.Fprintf(tw, "%s\t%d\t%.1fd\t%s\n", sb.Bead.ID, sb.Bead.Priority, waiting.Hours()/24, sb.Bead.Title)
+	}
+	tw.Flush()
+	if more := r.Total - len(r.Beads); more > 0 {
+		fmt.Fprintf(w, "... and %d more\n", more)
+	}
+	if !groups || len(r.Groups) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\tCOUNT\n", strings.ToUpper(r.Group))
+	for _, g := range r.Groups {
+		key := g.Key
+		if key == "" {
+			key = "(none)"
+		}
+		fmt.Fprintf(tw, "%s\t%d\n", key, g.Count)
+	}
+	tw.Flush()
+}
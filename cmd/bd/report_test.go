@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchDailyReport(t *testing.T) {
+	var gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.RequestURI()
+		w.Write([]byte(`{"date":"2026-03-02"}`))
+	}))
+	defer ts.Close()
+	t.Setenv("BEADS_HTTP_URL", ts.URL)
+
+	if _, err := fetchDailyReport(context.Background(), "2026-03-02"); err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/v1/reports/daily?date=2026-03-02" {
+		t.Fatalf("path = %q", gotPath)
+	}
+}
+
+func TestPrintDailyReport(t *testing.T) {
+	var r dailyReport
+	if err := json.Unmarshal([]byte(`{
+		"date": "2026-03-02", "created": 2, "closed": 1, "claimed": 1, "decisions_resolved": 1, "decisions_expired": 0,
+		"actors": [{"actor": "alice", "created": 2}, {"actor": "bob", "closed": 1, "claimed": 1}]
+	}`), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	printDailyReport(&out, &r)
+	for _, want := range []string{"Daily report for 2026-03-02", "Created:     2", "Decisions:   1 resolved, 0 expired", "alice  2"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	printDailyReportSlack(&out, &r)
+	for _, want := range []string{"*Daily report for 2026-03-02*", "• *bob*: 0 created, 1 closed, 1 claimed, 0 decisions"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("slack report missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestPrintAggregateReport(t *testing.T) {
+	var r aggregateReport
+	if err := json.Unmarshal([]byte(`{
+		"group_by": "assignee", "metric": "count",
+		"groups": [{"key": "alice", "count": 3, "avg_age_hours": 36}, {"key": "", "count": 1, "avg_age_hours": 12}]
+	}`), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	printAggregateReport(&out, &r)
+	for _, want := range []string{"ASSIGNEE  COUNT  AVG AGE", "alice     3      1.5d", "(none)    1      0.5d"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestPrintVelocityReport(t *testing.T) {
+	var r velocityReport
+	if err := json.Unmarshal([]byte(`{
+		"window": "2w", "group": "assignee",
+		"total": {"closed": 3, "estimated": 2, "points": 3, "hours": 4, "actual_points": 5, "points_per_week": 1.5, "hours_per_week": 2},
+		"groups": [{"key": "alice", "closed": 2, "estimated": 1, "points": 3, "points_per_week": 1.5}, {"key": "bob", "closed": 1, "estimated": 1, "hours": 4, "hours_per_week": 2}]
+	}`), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	printVelocityReport(&out, &r)
+	for _, want := range []string{"Velocity over 2w", "Closed:    3 (2 estimated)", "Estimated: 3 points, 4.0h", "Per week:  1.5 points, 2.0h",
+		"ASSIGNEE  CLOSED  POINTS", "alice     2       3", "bob       1       0       4.0"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestPrintStarvationReport(t *testing.T) {
+	var r starvationReport
+	if err := json.Unmarshal([]byte(`{
+		"older_than": "3d", "group": "label", "total": 3,
+		"groups": [{"key": "area:api", "count": 2}, {"key": "", "count": 1}],
+		"beads": [{"bead": {"id": "bd-1", "title": "Fix login", "priority": 1}, "waiting": "180h0m0s"}]
+	}`), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	printStarvationReport(&out, &r, true)
+	for _, want := range []string{"3 ready beads unclaimed for over 3d", "bd-1  1         7.5d     Fix login", "... and 2 more", "LABEL     COUNT", "(none)    1"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	printStarvationReport(&out, &r, false)
+	if strings.Contains(out.String(), "LABEL") {
+		t.Errorf("groups printed without groups:\n%s", out.String())
+	}
+	out.Reset()
+	printStarvationReport(&out, &starvationReport{OlderThan: "1w"}, true)
+	if out.String() != "No ready beads have waited over 1w.\n" {
+		t.Errorf("empty report = %q", out.String())
+	}
+}
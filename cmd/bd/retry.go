@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Backoff between retries: the wait before attempt n is a random duration up
+// to retryBaseDelay·2ⁿ, capped at retryMaxDelay ("full jitter").
+const (
+	defaultRetryMax = 3
+	retryBaseDelay  = 200 * time.Millisecond
+	retryMaxDelay   = 5 * time.Second
+)
+
+// retryMax is the number of times an idempotent call is retried after a
+// transient failure; set by --retries or BEADS_RETRY_MAX. Zero disables it.
+var retryMax int
+
+func defaultRetries() int {
+	if v := os.Getenv("BEADS_RETRY_MAX"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return defaultRetryMax
+}
+
+// retryDelay returns the jittered wait before retry attempt (0-based).
+func retryDelay(attempt int) time.Duration {
+	ceiling := retryMaxDelay
+	if attempt < 16 {
+		ceiling = min(retryBaseDelay<<attempt, retryMaxDelay)
+	}
+	return rand.N(ceiling) + 1
+}
+
+// sleepCtx waits for d, returning ctx's error if it is cancelled first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// idempotentMethod reports whether a gRPC method only reads, so repeating it
+// after a failure that may have reached the server is harmless.
+func idempotentMethod(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, prefix := range []string{"Get", "List", "Find", "Health"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryInterceptor returns a gRPC unary interceptor that retries idempotent
+// calls failing with Unavailable — the server restarting, or a 502 from an
+// ingress in front of it — up to max times with exponential backoff.
+func retryInterceptor(max int) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if !idempotentMethod(method) {
+			return err
+		}
+		for attempt := 0; attempt < max && status.Code(err) == codes.Unavailable; attempt++ {
+			if sleepCtx(ctx, retryDelay(attempt)) != nil {
+				return err
+			}
+			err = invoker(ctx, method, req, reply, cc, opts...)
+		}
+		return err
+	}
+}
+
+// retryableStatus reports whether an HTTP status is a transient gateway
+// failure worth retrying.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// doWithRetry sends an idempotent request, retrying connection errors and
+// gateway failures up to max times with exponential backoff. The request
+// must have no body.
+func doWithRetry(c *http.Client, req *http.Request, max int) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.Do(req)
+		if attempt >= max || (err == nil && !retryableStatus(resp.StatusCode)) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if err := sleepCtx(req.Context(), retryDelay(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryDelay(t *testing.T) {
+	for attempt := range 40 {
+		d := retryDelay(attempt)
+		ceiling := retryMaxDelay
+		if attempt < 5 {
+			ceiling = retryBaseDelay << attempt
+		}
+		if d <= 0 || d > ceiling {
+			t.Errorf("retryDelay(%d) = %s, want (0, %s]", attempt, d, ceiling)
+		}
+	}
+}
+
+func TestRetryInterceptor(t *testing.T) {
+	calls := 0
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		calls++
+		if calls < 3 {
+			return status.Error(codes.Unavailable, "connection refused")
+		}
+		return nil
+	}
+	retry := retryInterceptor(3)
+
+	if err := retry(context.Background(), beadsv1.BeadsService_GetBead_FullMethodName, nil, nil, nil, invoker); err != nil || calls != 3 {
+		t.Fatalf("GetBead: err %v after %d calls, want success on the third", err, calls)
+	}
+
+	calls = 0
+	err := retry(context.Background(), beadsv1.BeadsService_CreateBead_FullMethodName, nil, nil, nil, invoker)
+	if status.Code(err) != codes.Unavailable || calls != 1 {
+		t.Errorf("CreateBead: err %v after %d calls, want no retry", err, calls)
+	}
+
+	calls = 0
+	notFound := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.NotFound, "no such bead")
+	}
+	if err := retry(context.Background(), beadsv1.BeadsService_ListBeads_FullMethodName, nil, nil, nil, notFound); status.Code(err) != codes.NotFound || calls != 1 {
+		t.Errorf("ListBeads: err %v after %d calls, want no retry", err, calls)
+	}
+}
+
+func TestDoWithRetry(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := doWithRetry(srv.Client(), req, 2)
+	if err != nil || resp.StatusCode != http.StatusOK || calls != 2 {
+		t.Fatalf("got %v, %v after %d calls", resp, err, calls)
+	}
+	resp.Body.Close()
+
+	calls = 0
+	resp, err = doWithRetry(srv.Client(), req, 0)
+	if err != nil || resp.StatusCode != http.StatusBadGateway || calls != 1 {
+		t.Fatalf("retries disabled: got %v, %v after %d calls", resp, err, calls)
+	}
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	down, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1:1", nil)
+	if _, err := doWithRetry(http.DefaultClient, down, 100); err == nil {
+		t.Error("unreachable server succeeded")
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/spf13/cobra"
+)
+
+// rollupBarWidth is the width of the progress bar bd rollup draws.
+const rollupBarWidth = 30
+
+var rollupCmd = &cobra.Command{
+	Use:     "rollup",
+	Short:   "Show progress across the beads matching a filter",
+	GroupID: "views",
+	Long: `Summarizes the progress of the beads matching the filters, such as an
+epic's label: a progress bar of the completed weight, counts by status, how
+many are blocked, and an ETA projected from the weight closed in the last
+--window days. Each bead weighs the number in its "weight" field, or 1
+without one.
+
+  bd rollup --label epic:payments
+  bd rollup --label team:backend --type bug --window 28`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd rollup", server.FeatureRollup)
+		types, _ := cmd.Flags().GetStringSlice("type")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		query, _ := cmd.Flags().GetString("query")
+		window, _ := cmd.Flags().GetInt("window")
+
+		q := url.Values{"window_days": {strconv.Itoa(window)}}
+		for param, v := range map[string]string{
+			"type":     strings.Join(types, ","),
+			"labels":   strings.Join(labels, ","),
+			"assignee": assignee,
+			"q":        query,
+		} {
+			if v != "" {
+				q.Set(param, v)
+			}
+		}
+		body, err := httpGet(context.Background(), "/v1/rollup?"+q.Encode())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			fmt.Println(string(body))
+			return nil
+		}
+
+		var r model.Rollup
+		if err := json.Unmarshal(body, &r); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid rollup: %v\n", err)
+			os.Exit(1)
+		}
+		printRollup(os.Stdout, &r)
+		return nil
+	},
+}
+
+// printRollup prints a progress bar of r's completed weight, then its
+// counts and ETA.
+func printRollup(w io.Writer, r *model.Rollup) {
+	done := 0.0
+	if r.TotalWeight > 0 {
+		done = r.CompletedWeight / r.TotalWeight
+	}
+	filled := int(done * rollupBarWidth)
+	fmt.Fprintf(w, "[%s%s] %3.0f%%  %s/%s\n", strings.Repeat("#", filled), strings.Repeat("-", rollupBarWidth-filled),
+		done*100, formatWeight(r.CompletedWeight), formatWeight(r.TotalWeight))
+
+	var counts []string
+	for _, s := range []model.Status{model.StatusOpen, model.StatusInProgress, model.StatusDeferred, model.StatusClosed} {
+		if n := r.ByStatus[string(s)]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, s))
+		}
+	}
+	fmt.Fprintf(w, "Beads:    %d (%s)\n", r.Total, strings.Join(counts, ", "))
+	fmt.Fprintf(w, "Blocked:  %d\n", r.Blocked)
+	fmt.Fprintf(w, "Closed:   %s in the last %dd\n", formatWeight(r.RecentWeight), r.WindowDays)
+	switch {
+	case r.ETA != nil:
+		fmt.Fprintf(w, "ETA:      %s\n", r.ETA.Format("2006-01-02"))
+	case r.CompletedWeight < r.TotalWeight:
+		fmt.Fprintln(w, "ETA:      unknown (nothing closed recently)")
+	}
+}
+
+// formatWeight prints a weight without a fraction when it is whole.
+func formatWeight(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func init() {
+	rollupCmd.Flags().StringSliceP("label", "l", nil, "only beads with this label (repeatable)")
+	rollupCmd.Flags().StringSliceP("type", "t", nil, "only beads of this type (repeatable)")
+	rollupCmd.Flags().String("assignee", "", "only beads assigned to this actor")
+	rollupCmd.Flags().StringP("query", "q", "", "query language filter")
+	rollupCmd.Flags().Int("window", 14, "days of closures to project the ETA from")
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestPrintRollup(t *testing.T) {
+	eta := time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC)
+	r := &model.Rollup{
+		Total:           4,
+		ByStatus:        map[string]int{"open": 1, "in_progress": 1, "closed": 2},
+		TotalWeight:     8,
+		CompletedWeight: 2.5,
+		Blocked:         1,
+		RecentWeight:    2.5,
+		WindowDays:      14,
+		ETA:             &eta,
+	}
+
+	var out strings.Builder
+	printRollup(&out, r)
+	for _, want := range []string{
+		"[#########---------------------]  31%  2.5/8",
+		"Beads:    4 (1 open, 1 in_progress, 2 closed)",
+		"Blocked:  1",
+		"ETA:      2026-04-02",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("rollup missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	r.ETA, r.RecentWeight = nil, 0
+	printRollup(&out, r)
+	if !strings.Contains(out.String(), "ETA:      unknown") {
+		t.Errorf("rollup without recent closures:\n%s", out.String())
+	}
+}
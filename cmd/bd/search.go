@@ -43,22 +43,25 @@ var searchCmd = &cobra.Command{
 			}
 		}
 
-		resp, err := client.ListBeads(context.Background(), req)
+		format, columns, err := listFormatFromFlags(cmd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		if jsonOutput {
-			printBeadListJSON(resp.GetBeads())
-		} else {
-			printBeadListTable(resp.GetBeads(), resp.GetTotal())
+		resp, err := client.ListBeads(context.Background(), req)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
+
+		printBeadList(resp.GetBeads(), resp.GetTotal(), format, columns)
 		return nil
 	},
 }
 
 func init() {
+	addListFormatFlags(searchCmd, "table")
 	searchCmd.Flags().StringSliceP("status", "s", nil, "filter by status (repeatable)")
 	searchCmd.Flags().StringSliceP("type", "t", nil, "filter by type (repeatable)")
 	searchCmd.Flags().StringSliceP("kind", "k", nil, "filter by kind (repeatable)")
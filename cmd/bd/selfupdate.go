@@ -0,0 +1,239 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/version"
+	"github.com/spf13/cobra"
+)
+
+// maxReleaseSize bounds how much of a release archive is downloaded.
+const maxReleaseSize = 256 << 20
+
+var selfUpdateCmd = &cobra.Command{
+	Use:     "self-update",
+	Short:   "Install the bd release the server recommends",
+	GroupID: "system",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, _ := cmd.Flags().GetString("version")
+		check, _ := cmd.Flags().GetBool("check")
+		force, _ := cmd.Flags().GetBool("force")
+		releaseURL, _ := cmd.Flags().GetString("release-url")
+		if releaseURL == "" {
+			releaseURL = os.Getenv("BEADS_RELEASE_URL")
+		}
+		if releaseURL == "" {
+			releaseURL = ReleaseURL
+		}
+		key, err := releaseKey(ReleaseKey)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		ctx := context.Background()
+
+		info, err := client.GetServerInfo(ctx, &beadsv1.GetServerInfoRequest{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if target == "" {
+			target = info.GetClientVersion()
+		}
+		if !version.Valid(target) {
+			fmt.Fprintf(os.Stderr, "Error: server does not advertise a release version to install (got %q); pass --version\n", target)
+			os.Exit(1)
+		}
+
+		if target == Version && !force {
+			fmt.Printf("bd %s is up to date\n", Version)
+			return nil
+		}
+		if check {
+			fmt.Printf("Update available: %s -> %s\n", Version, target)
+			return nil
+		}
+
+		exe, err := os.Executable()
+		if err == nil {
+			exe, err = filepath.EvalSymlinks(exe)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: locating bd: %v\n", err)
+			os.Exit(1)
+		}
+
+		bin, err := downloadRelease(ctx, http.DefaultClient, releaseURL, key, target, runtime.GOOS, runtime.GOARCH)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := installBinary(exe, bin); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: installing %s: %v\n", exe, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Updated bd %s -> %s (%s)\n", Version, target, exe)
+		return nil
+	},
+}
+
+// releaseAsset returns the archive name CI publishes for a platform, e.g.
+// "bd-linux-x86_64.tar.gz".
+func releaseAsset(goos, goarch string) string {
+	arch := goarch
+	switch goarch {
+	case "amd64":
+		arch = "x86_64"
+	case "arm64":
+		arch = "aarch64"
+	}
+	return fmt.Sprintf("bd-%s-%s.tar.gz", goos, arch)
+}
+
+// releaseKey decodes a base64 Ed25519 public key; empty means none.
+func releaseKey(s string) (ed25519.PublicKey, error) {
+	if s == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("bd was built with an invalid release key %q", s)
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// downloadRelease fetches the bd binary of release tag for a platform from
+// baseURL/<tag>/, verifying the archive against the release's checksums.txt.
+// With a key, checksums.txt must carry a valid signature in
+// checksums.txt.sig.
+func downloadRelease(ctx context.Context, hc *http.Client, baseURL string, key ed25519.PublicKey, tag, goos, goarch string) ([]byte, error) {
+	base := strings.TrimRight(baseURL, "/") + "/" + tag + "/"
+	asset := releaseAsset(goos, goarch)
+
+	sums, err := fetch(ctx, hc, base+"checksums.txt")
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		sig, err := fetch(ctx, hc, base+"checksums.txt.sig")
+		if err != nil {
+			return nil, err
+		}
+		if !ed25519.Verify(key, sums, sig) {
+			return nil, errors.New("checksums.txt signature does not match the release key")
+		}
+	}
+	want, err := checksumFor(sums, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := fetch(ctx, hc, base+asset)
+	if err != nil {
+		return nil, err
+	}
+	got := sha256.Sum256(archive)
+	if hex.EncodeToString(got[:]) != want {
+		return nil, fmt.Errorf("checksum mismatch for %s: got %x, want %s", asset, got, want)
+	}
+	return extractBinary(archive)
+}
+
+// fetch GETs url and returns its body.
+func fetch(ctx context.Context, hc *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxReleaseSize))
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", url, err)
+	}
+	return data, nil
+}
+
+// checksumFor finds the SHA-256 of name in sha256sum output.
+func checksumFor(sums []byte, name string) (string, error) {
+	sc := bufio.NewScanner(bytes.NewReader(sums))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("no checksum for %s in checksums.txt", name)
+}
+
+// extractBinary returns the bd executable from a release archive.
+func extractBinary(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("archive does not contain bd")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == "bd" {
+			return io.ReadAll(io.LimitReader(tr, maxReleaseSize))
+		}
+	}
+}
+
+// installBinary atomically replaces the executable at path with data.
+func installBinary(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".bd-update-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func init() {
+	selfUpdateCmd.Flags().String("version", "", "release to install (default: the server's recommended client version)")
+	selfUpdateCmd.Flags().Bool("check", false, "only report whether an update is available")
+	selfUpdateCmd.Flags().Bool("force", false, "reinstall even if already at the target version")
+	selfUpdateCmd.Flags().String("release-url", "", "base URL to download releases from (default: $BEADS_RELEASE_URL, or the one bd was built with)")
+}
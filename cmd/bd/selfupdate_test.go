@@ -0,0 +1,139 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// releaseArchive builds a tar.gz holding a bd binary with the given contents.
+func releaseArchive(t *testing.T, contents string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: "bd", Mode: 0o755, Size: int64(len(contents)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	tw.Write([]byte(contents))
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func releaseServer(t *testing.T, archive []byte, sum string) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.4.0/checksums.txt":
+			fmt.Fprintf(w, "%s  bd-linux-x86_64.tar.gz\n", sum)
+		case "/v1.4.0/bd-linux-x86_64.tar.gz":
+			w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestDownloadRelease(t *testing.T) {
+	archive := releaseArchive(t, "#!new-bd")
+	ts := releaseServer(t, archive, fmt.Sprintf("%x", sha256.Sum256(archive)))
+
+	bin, err := downloadRelease(context.Background(), ts.Client(), ts.URL+"/", nil, "v1.4.0", "linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bin) != "#!new-bd" {
+		t.Fatalf("got %q", bin)
+	}
+
+	if _, err := downloadRelease(context.Background(), ts.Client(), ts.URL, nil, "v1.4.0", "darwin", "arm64"); err == nil {
+		t.Fatal("expected an error for a platform without a release")
+	}
+}
+
+func TestDownloadRelease_ChecksumMismatch(t *testing.T) {
+	archive := releaseArchive(t, "#!tampered")
+	ts := releaseServer(t, archive, strings.Repeat("0", 64))
+
+	_, err := downloadRelease(context.Background(), ts.Client(), ts.URL, nil, "v1.4.0", "linux", "amd64")
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+}
+
+func TestDownloadRelease_Signature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := releaseArchive(t, "#!signed-bd")
+	sums := fmt.Sprintf("%x  bd-linux-x86_64.tar.gz\n", sha256.Sum256(archive))
+	sig := ed25519.Sign(priv, []byte(sums))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.4.0/checksums.txt":
+			io.WriteString(w, sums)
+		case "/v1.4.0/checksums.txt.sig":
+			w.Write(sig)
+		case "/v1.4.0/bd-linux-x86_64.tar.gz":
+			w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	bin, err := downloadRelease(context.Background(), ts.Client(), ts.URL, pub, "v1.4.0", "linux", "amd64")
+	if err != nil || string(bin) != "#!signed-bd" {
+		t.Fatalf("got %q, %v", bin, err)
+	}
+
+	other, _, _ := ed25519.GenerateKey(nil)
+	_, err = downloadRelease(context.Background(), ts.Client(), ts.URL, other, "v1.4.0", "linux", "amd64")
+	if err == nil || !strings.Contains(err.Error(), "signature") {
+		t.Fatalf("expected a signature error, got %v", err)
+	}
+}
+
+func TestReleaseKey(t *testing.T) {
+	if key, err := releaseKey(""); key != nil || err != nil {
+		t.Fatalf("empty key: got %v, %v", key, err)
+	}
+	pub, _, _ := ed25519.GenerateKey(nil)
+	if key, err := releaseKey(base64.StdEncoding.EncodeToString(pub)); err != nil || !key.Equal(pub) {
+		t.Fatalf("valid key: got %v, %v", key, err)
+	}
+	if _, err := releaseKey("c2hvcnQ="); err == nil {
+		t.Fatal("expected an error for a short key")
+	}
+}
+
+func TestInstallBinary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bd")
+	if err := os.WriteFile(path, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := installBinary(path, []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := os.ReadFile(path)
+	info, _ := os.Stat(path)
+	if string(got) != "new" || info.Mode().Perm() != 0o755 {
+		t.Fatalf("got %q mode %v", got, info.Mode())
+	}
+}
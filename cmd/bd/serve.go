@@ -10,12 +10,25 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/alfredjeanlab/beads/internal/alerts"
 	"github.com/alfredjeanlab/beads/internal/config"
+	"github.com/alfredjeanlab/beads/internal/email"
 	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/metrics"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/oidc"
 	"github.com/alfredjeanlab/beads/internal/server"
+	"github.com/alfredjeanlab/beads/internal/shadow"
+	"github.com/alfredjeanlab/beads/internal/slack"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"github.com/alfredjeanlab/beads/internal/store/cached"
 	"github.com/alfredjeanlab/beads/internal/store/postgres"
+	"github.com/alfredjeanlab/beads/internal/store/sealed"
 	beadsync "github.com/alfredjeanlab/beads/internal/sync"
+	"github.com/alfredjeanlab/beads/internal/tracing"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var serveCmd = &cobra.Command{
@@ -32,6 +45,25 @@ var serveCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		for flag, dst := range map[string]*string{
+			"tls-cert":      &cfg.TLSCert,
+			"tls-key":       &cfg.TLSKey,
+			"tls-client-ca": &cfg.TLSClientCA,
+		} {
+			if v, _ := cmd.Flags().GetString(flag); v != "" {
+				*dst = v
+			}
+		}
+		tlsConfig, err := server.LoadTLSConfig(cfg.TLSCert, cfg.TLSKey, cfg.TLSClientCA)
+		if err != nil {
+			return err
+		}
+
+		// Install the tracer provider before anything that creates spans.
+		shutdownTracing, err := tracing.Setup(context.Background(), "beads", Version)
+		if err != nil {
+			return err
+		}
 
 		// Connect to Postgres.
 		store, err := postgres.New(cfg.DatabaseURL)
@@ -39,24 +71,100 @@ var serveCmd = &cobra.Command{
 			return err
 		}
 
+		// Everything but sync reads configs through configStore, which
+		// opens sealed secrets; sync backups keep them sealed.
+		configStore, err := withSealedConfigs(store, cfg)
+		if err != nil {
+			store.Close()
+			return err
+		}
+		if len(cfg.ConfigEncryptionKey) > 0 {
+			logger.Info("config secrets encrypted at rest")
+		}
+
+		serverStore, readCache := withReadCache(configStore, cfg)
+		if readCache != nil {
+			logger.Info("read cache enabled", "bead_ttl", cfg.CacheBeadTTL, "list_ttl", cfg.CacheListTTL)
+		}
+
 		// Create event publisher.
-		var publisher events.Publisher
-		if cfg.NATSURL != "" {
-			pub, err := events.NewNATSPublisher(cfg.NATSURL)
+		publisher, err := newEventPublisher(cfg)
+		if err != nil {
+			store.Close()
+			return err
+		}
+		if cfg.EventBackend == config.EventBackendNone {
+			logger.Info("events disabled (BEADS_EVENT_BACKEND=none)")
+		} else {
+			logger.Info("events enabled", "backend", cfg.EventBackend)
+		}
+		publisher = tracing.NewPublisher(publisher)
+		// Slack notifications ride on the event stream; they are inert until
+		// an integration:slack config exists.
+		publisher = slack.NewBridge(publisher, configStore, logger)
+		// Email notifications likewise wait for an integration:email config,
+		// and go only to actors with an email preference.
+		notifier := email.NewNotifier(publisher, configStore, logger)
+		publisher = notifier
+		// Custom gauges are recomputed after any bead event.
+		collector := metrics.NewCollector(publisher, configStore, logger)
+		publisher = collector
+		if readCache != nil {
+			// Bead events drop cached reads before anything else sees them.
+			publisher = cached.NewPublisher(publisher, readCache)
+		}
+
+		// Create server components.
+		beadsServer := server.NewBeadsServer(serverStore, publisher)
+		beadsServer.SetMetricsCollector(collector)
+		if readCache != nil {
+			beadsServer.SetReadCache(readCache)
+		}
+		beadsServer.SetRegistrationTokens(cfg.AdminToken, cfg.BootstrapToken)
+		beadsServer.SetStreamTuning(cfg.StreamKeepalive, cfg.StreamMaxLifetime)
+		beadsServer.SetStrictAuthors(cfg.StrictAuthors)
+		beadsServer.SetConfigPolicy(cfg.ConfigAdminNamespaces)
+		if cfg.OIDCIssuer != "" {
+			verifier, err := oidc.NewVerifier(oidc.Config{
+				Issuer:     cfg.OIDCIssuer,
+				Audience:   cfg.OIDCAudience,
+				JWKSURL:    cfg.OIDCJWKSURL,
+				ActorClaim: cfg.OIDCActorClaim,
+				RolesClaim: cfg.OIDCRolesClaim,
+				Refresh:    cfg.OIDCJWKSRefresh,
+			})
 			if err != nil {
+				publisher.Close()
 				store.Close()
 				return err
 			}
-			publisher = pub
-			logger.Info("events enabled", "nats_url", cfg.NATSURL)
-		} else {
-			publisher = &events.NoopPublisher{}
-			logger.Info("events disabled (BEADS_NATS_URL not set)")
+			beadsServer.SetOIDC(verifier, cfg.OIDCAdminRole)
+			logger.Info("OIDC bearer tokens accepted", "issuer", cfg.OIDCIssuer, "audience", cfg.OIDCAudience)
 		}
-
-		// Create server components.
-		beadsServer := server.NewBeadsServer(store, publisher)
-		grpcServer := server.NewGRPCServer(beadsServer)
+		clientVersion := cfg.ClientVersion
+		if clientVersion == "" {
+			clientVersion = Version
+		}
+		beadsServer.SetVersionPolicy(server.VersionPolicy{
+			ServerVersion:    Version,
+			MinClientVersion: cfg.MinClientVersion,
+			ClientVersion:    clientVersion,
+			ClientReleaseURL: cfg.ClientReleaseURL,
+		})
+		if len(cfg.ShadowRates) > 0 {
+			beadsServer.SetShadow(shadow.New(cfg.ShadowRates, logger))
+			logger.Info("request shadowing enabled", "routes", cfg.ShadowRates)
+		}
+		var evaluator *alerts.Evaluator
+		if cfg.AlertInterval > 0 {
+			evaluator = alerts.NewEvaluator(configStore, publisher, cfg.AlertInterval, logger)
+			beadsServer.SetAlertEvaluator(evaluator)
+		}
+		var grpcOpts []grpc.ServerOption
+		if tlsConfig != nil {
+			grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+		}
+		grpcServer := server.NewGRPCServer(beadsServer, grpcOpts...)
 
 		// Start gRPC listener.
 		lis, err := net.Listen("tcp", cfg.GRPCAddr)
@@ -76,17 +184,211 @@ var serveCmd = &cobra.Command{
 		// Start HTTP server.
 		httpHandler := beadsServer.NewHTTPHandler()
 		httpServer := &http.Server{
-			Addr:    cfg.HTTPAddr,
-			Handler: httpHandler,
+			Addr:      cfg.HTTPAddr,
+			Handler:   httpHandler,
+			TLSConfig: tlsConfig,
 		}
 
 		go func() {
 			logger.Info("HTTP server listening", "addr", cfg.HTTPAddr)
-			if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			var err error
+			if tlsConfig != nil {
+				err = httpServer.ListenAndServeTLS("", "")
+			} else {
+				err = httpServer.ListenAndServe()
+			}
+			if err != nil && err != http.ErrServerClosed {
 				logger.Error("HTTP server error", "err", err)
 			}
 		}()
 
+		// Start alert evaluation.
+		if evaluator != nil {
+			evaluator.Start()
+			logger.Info("alert evaluator started", "interval", cfg.AlertInterval)
+		}
+
+		// Start decision expiry.
+		expiryCtx, stopExpiry := context.WithCancel(context.Background())
+		expiryDone := make(chan struct{})
+		if cfg.DecisionExpiryInterval > 0 {
+			go func() {
+				defer close(expiryDone)
+				beadsServer.RunDecisionExpiry(expiryCtx, cfg.DecisionExpiryInterval)
+			}()
+			logger.Info("decision expiry started", "interval", cfg.DecisionExpiryInterval)
+		} else {
+			close(expiryDone)
+		}
+
+		// Start advice expiry.
+		adviceCtx, stopAdvice := context.WithCancel(context.Background())
+		adviceDone := make(chan struct{})
+		if cfg.AdviceExpiryInterval > 0 {
+			go func() {
+				defer close(adviceDone)
+				beadsServer.RunAdviceExpiry(adviceCtx, cfg.AdviceExpiryInterval)
+			}()
+			logger.Info("advice expiry started", "interval", cfg.AdviceExpiryInterval)
+		} else {
+			close(adviceDone)
+		}
+
+		// Start trash purge. Check hourly, or more often for short retentions.
+		purgeCtx, stopPurge := context.WithCancel(context.Background())
+		purgeDone := make(chan struct{})
+		if cfg.TrashRetention > 0 {
+			go func() {
+				defer close(purgeDone)
+				beadsServer.RunTrashPurge(purgeCtx, min(time.Hour, cfg.TrashRetention), cfg.TrashRetention)
+			}()
+			logger.Info("trash purge started", "retention", cfg.TrashRetention)
+		} else {
+			close(purgeDone)
+		}
+
+		// Start archival of long-closed beads. Check hourly, or more often
+		// for short policies.
+		beadsServer.SetArchivePolicy(cfg.ArchiveAfter)
+		archiveCtx, stopArchive := context.WithCancel(context.Background())
+		archiveDone := make(chan struct{})
+		if cfg.ArchiveAfter > 0 {
+			go func() {
+				defer close(archiveDone)
+				beadsServer.RunArchiver(archiveCtx, min(time.Hour, cfg.ArchiveAfter))
+			}()
+			logger.Info("archival started", "after", cfg.ArchiveAfter)
+		} else {
+			close(archiveDone)
+		}
+
+		// Start event compaction. Check hourly, or more often when a rule
+		// keeps events for less than an hour.
+		beadsServer.SetEventRetention(cfg.EventRetention)
+		compactCtx, stopCompact := context.WithCancel(context.Background())
+		compactDone := make(chan struct{})
+		if interval := compactionInterval(cfg.EventRetention); interval > 0 {
+			go func() {
+				defer close(compactDone)
+				beadsServer.RunEventCompactor(compactCtx, interval)
+			}()
+			logger.Info("event compaction started", "rules", len(cfg.EventRetention))
+		} else {
+			close(compactDone)
+		}
+
+		// Start unassigning the beads of reaped or stale agents. Check every
+		// minute, or more often for short policies.
+		beadsServer.SetUnassignPolicy(cfg.AgentUnassignAfter)
+		reaperCtx, stopReaper := context.WithCancel(context.Background())
+		reaperDone := make(chan struct{})
+		if cfg.AgentUnassignAfter > 0 {
+			go func() {
+				defer close(reaperDone)
+				beadsServer.RunAgentReaper(reaperCtx, min(time.Minute, cfg.AgentUnassignAfter))
+			}()
+			logger.Info("agent reaper started", "after", cfg.AgentUnassignAfter)
+		} else {
+			close(reaperDone)
+		}
+
+		// Start probing external dependencies.
+		beadsServer.SetExternalProber(nil, cfg.GitHubToken)
+		proberCtx, stopProber := context.WithCancel(context.Background())
+		proberDone := make(chan struct{})
+		if cfg.ExternalProbeInterval > 0 {
+			go func() {
+				defer close(proberDone)
+				beadsServer.RunExternalProber(proberCtx, cfg.ExternalProbeInterval)
+			}()
+			logger.Info("external prober started", "interval", cfg.ExternalProbeInterval)
+		} else {
+			close(proberDone)
+		}
+
+		// Start reopening deferred beads whose defer_until has passed.
+		undeferCtx, stopUndefer := context.WithCancel(context.Background())
+		undeferDone := make(chan struct{})
+		if cfg.UndeferInterval > 0 {
+			go func() {
+				defer close(undeferDone)
+				beadsServer.RunUndeferrer(undeferCtx, cfg.UndeferInterval)
+			}()
+			logger.Info("undeferrer started", "interval", cfg.UndeferInterval)
+		} else {
+			close(undeferDone)
+		}
+
+		// Start digest generation for saved search subscriptions.
+		digestCtx, stopDigests := context.WithCancel(context.Background())
+		digestDone := make(chan struct{})
+		if cfg.DigestInterval > 0 {
+			go func() {
+				defer close(digestDone)
+				beadsServer.RunDigests(digestCtx, cfg.DigestInterval)
+			}()
+			logger.Info("digest generation started", "interval", cfg.DigestInterval)
+		} else {
+			close(digestDone)
+		}
+
+		// Start reporting overdue beads and sending batched emails.
+		emailCtx, stopEmail := context.WithCancel(context.Background())
+		emailDone := make(chan struct{})
+		if cfg.EmailInterval > 0 {
+			go func() {
+				defer close(emailDone)
+				notifier.Run(emailCtx, cfg.EmailInterval)
+			}()
+			logger.Info("email sweep started", "interval", cfg.EmailInterval)
+		} else {
+			close(emailDone)
+		}
+
+		// Start the mirror worker, which copies beads from remote servers.
+		mirrorCtx, stopMirrors := context.WithCancel(context.Background())
+		mirrorDone := make(chan struct{})
+		if cfg.MirrorInterval > 0 {
+			go func() {
+				defer close(mirrorDone)
+				beadsServer.RunMirrors(mirrorCtx, cfg.MirrorInterval)
+			}()
+			logger.Info("mirror sync started", "interval", cfg.MirrorInterval)
+		} else {
+			close(mirrorDone)
+		}
+
+		// Start the outbox dispatcher, which retries events whose publish
+		// failed or was cut short by a restart.
+		outboxCtx, stopOutbox := context.WithCancel(context.Background())
+		outboxDone := make(chan struct{})
+		if cfg.OutboxInterval > 0 {
+			go func() {
+				defer close(outboxDone)
+				beadsServer.RunOutbox(outboxCtx, cfg.OutboxInterval)
+			}()
+			logger.Info("event outbox started", "interval", cfg.OutboxInterval)
+		} else {
+			close(outboxDone)
+		}
+
+		// Admin snapshots go to the sync bucket.
+		if cfg.SyncS3Bucket != "" {
+			snapshots, err := beadsync.NewS3Destination(
+				context.Background(),
+				cfg.SyncS3Bucket,
+				cfg.SyncS3Key,
+				cfg.SyncS3Region,
+				cfg.SyncS3Endpoint,
+			)
+			if err != nil {
+				logger.Error("failed to create S3 snapshot store", "err", err)
+			} else {
+				beadsServer.SetSnapshotStore(snapshots, cfg.SnapshotPrefix)
+				logger.Info("admin snapshots enabled", "bucket", cfg.SyncS3Bucket, "prefix", cfg.SnapshotPrefix)
+			}
+		}
+
 		// Start sync scheduler if any destinations are configured.
 		var scheduler *beadsync.Scheduler
 		if cfg.SyncInterval > 0 {
@@ -125,6 +427,8 @@ var serveCmd = &cobra.Command{
 		logger.Info("beads server started",
 			"grpc_addr", cfg.GRPCAddr,
 			"http_addr", cfg.HTTPAddr,
+			"tls", tlsConfig != nil,
+			"mtls", cfg.TLSClientCA != "",
 		)
 
 		// Wait for SIGINT or SIGTERM.
@@ -134,11 +438,40 @@ var serveCmd = &cobra.Command{
 		logger.Info("received signal, shutting down", "signal", sig)
 
 		// Graceful shutdown.
+		if evaluator != nil {
+			evaluator.Stop()
+			logger.Info("alert evaluator stopped")
+		}
+		stopExpiry()
+		<-expiryDone
+		stopAdvice()
+		<-adviceDone
+		stopPurge()
+		<-purgeDone
+		stopArchive()
+		<-archiveDone
+		stopCompact()
+		<-compactDone
+		stopReaper()
+		<-reaperDone
+		stopProber()
+		<-proberDone
+		stopUndefer()
+		<-undeferDone
+		stopDigests()
+		<-digestDone
+		stopEmail()
+		<-emailDone
+		stopMirrors()
+		<-mirrorDone
+		stopOutbox()
+		<-outboxDone
 		if scheduler != nil {
 			scheduler.Stop()
 			logger.Info("sync scheduler stopped")
 		}
 
+		beadsServer.Shutdown()
 		grpcServer.GracefulStop()
 		logger.Info("gRPC server stopped")
 
@@ -155,8 +488,65 @@ var serveCmd = &cobra.Command{
 		if err := store.Close(); err != nil {
 			logger.Error("error closing store", "err", err)
 		}
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			logger.Error("error flushing traces", "err", err)
+		}
 
 		logger.Info("shutdown complete")
 		return nil
 	},
 }
+
+func init() {
+	serveCmd.Flags().String("tls-cert", "", "TLS certificate file (overrides BEADS_TLS_CERT)")
+	serveCmd.Flags().String("tls-key", "", "TLS private key file (overrides BEADS_TLS_KEY)")
+	serveCmd.Flags().String("tls-client-ca", "", "CA bundle for verifying client certificates; enables mTLS (overrides BEADS_TLS_CLIENT_CA)")
+}
+
+// withReadCache wraps st in a read cache when either cache TTL is set,
+// returning the store the server should use and the cache, if any.
+func withReadCache(st store.Store, cfg *config.Config) (store.Store, *cached.Store) {
+	if cfg.CacheBeadTTL <= 0 && cfg.CacheListTTL <= 0 {
+		return st, nil
+	}
+	c := cached.New(st, cfg.CacheBeadTTL, cfg.CacheListTTL)
+	return c, c
+}
+
+// withSealedConfigs wraps st so secret config fields are encrypted at rest
+// when cfg sets an encryption key.
+func withSealedConfigs(st store.Store, cfg *config.Config) (store.Store, error) {
+	if len(cfg.ConfigEncryptionKey) == 0 {
+		return st, nil
+	}
+	return sealed.New(st, cfg.ConfigEncryptionKey)
+}
+
+// compactionInterval returns how often to compact events under rules: hourly,
+// or as often as the shortest retention below an hour. It is 0 when no rule
+// deletes anything.
+func compactionInterval(rules []model.RetentionRule) time.Duration {
+	var shortest time.Duration
+	for _, r := range rules {
+		if r.MaxAge > 0 && (shortest == 0 || r.MaxAge < shortest) {
+			shortest = r.MaxAge
+		}
+	}
+	return min(shortest, time.Hour)
+}
+
+// newEventPublisher returns the publisher for cfg.EventBackend.
+func newEventPublisher(cfg *config.Config) (events.Publisher, error) {
+	switch cfg.EventBackend {
+	case config.EventBackendNATS:
+		return events.NewNATSPublisher(cfg.NATSURL)
+	case config.EventBackendJetStream:
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		return events.NewJetStreamPublisher(ctx, cfg.NATSURL, cfg.JetStreamStream)
+	case config.EventBackendKafka:
+		return events.NewKafkaPublisher(cfg.KafkaRESTURL, cfg.KafkaTopic), nil
+	default:
+		return &events.NoopPublisher{}, nil
+	}
+}
@@ -4,34 +4,159 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
 )
 
 var showCmd = &cobra.Command{
-	Use:     "show <id>",
+	Use:     "show <id>|<remote>:<id>",
 	Short:   "Show details of a bead",
 	GroupID: "beads",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
 
+		if bead, ok, err := getRemoteBead(context.Background(), id); ok {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if jsonOutput {
+				printBeadJSON(bead)
+			} else {
+				printBeadMarkdown(bead)
+				printMarkdownComments(bead.GetComments())
+			}
+			return nil
+		}
+
 		resp, err := client.GetBead(context.Background(), &beadsv1.GetBeadRequest{
 			Id: id,
 		})
+		if isUnreachable(err) {
+			if showCached(id, err) {
+				return nil
+			}
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
 		bead := resp.GetBead()
+		cacheBeads(bead)
+		showActivity, _ := cmd.Flags().GetBool("activity")
+		var activity []*beadsv1.ActivityEntry
+		if showActivity {
+			ar, err := client.GetActivity(context.Background(), &beadsv1.GetActivityRequest{BeadId: bead.GetId()})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			activity = ar.GetActivity()
+		}
+
 		if jsonOutput {
-			printBeadJSON(bead)
+			if showActivity {
+				printJSON(map[string]any{"bead": bead, "activity": activity})
+			} else {
+				printBeadJSON(bead)
+			}
 		} else {
-			printBeadTable(bead)
-			printComments(bead.GetComments())
+			raw, _ := cmd.Flags().GetBool("raw")
+			if raw {
+				printBeadTable(bead)
+			} else {
+				printBeadMarkdown(bead)
+			}
+			printChecklist(bead.GetChecklist())
+			// Relations, external dependencies and commits are best effort:
+			// older servers don't serve them.
+			if rels, err := client.ListRelations(context.Background(), &beadsv1.ListRelationsRequest{BeadId: bead.GetId()}); err == nil {
+				printRelations(rels.GetRelations())
+			}
+			if ext, err := client.ListExternalDeps(context.Background(), &beadsv1.ListExternalDepsRequest{BeadId: bead.GetId()}); err == nil {
+				printExternalDeps(ext.GetDeps())
+			}
+			if commits, err := client.ListCommits(context.Background(), &beadsv1.ListCommitsRequest{BeadId: bead.GetId()}); err == nil {
+				printCommits(commits.GetCommits())
+			}
+			switch {
+			case showActivity:
+				printActivity(activity)
+			case raw:
+				printComments(bead.GetComments())
+			default:
+				printMarkdownComments(bead.GetComments())
+			}
 		}
 		return nil
 	},
 }
+
+// getRemoteBead fetches a bead from another server when id has the form
+// "<remote>:<id>" and <remote> is a configured remote, dialing it with the
+// remote's own token. ok is false for any other id. Remote beads are not
+// cached.
+func getRemoteBead(ctx context.Context, id string) (bead *beadsv1.Bead, ok bool, err error) {
+	name, beadID, found := strings.Cut(id, ":")
+	if !found {
+		return nil, false, nil
+	}
+	cfg, err := loadRemotesConfig()
+	if err != nil {
+		return nil, false, nil
+	}
+	r, found := cfg.Remotes[name]
+	if !found {
+		return nil, false, nil
+	}
+
+	creds, err := transportCredentials(r.URL)
+	if err != nil {
+		return nil, true, err
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if r.Token != "" {
+		opts = append(opts, grpc.WithUnaryInterceptor(bearerTokenInterceptor(r.Token)))
+	}
+	rc, err := grpc.NewClient(r.URL, opts...)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to connect to remote %q: %w", name, err)
+	}
+	defer rc.Close()
+	resp, err := beadsv1.NewBeadsServiceClient(rc).GetBead(ctx, &beadsv1.GetBeadRequest{Id: beadID})
+	if err != nil {
+		return nil, true, fmt.Errorf("remote %q: %w", name, err)
+	}
+	return resp.GetBead(), true, nil
+}
+
+// showCached prints the cached copy of a bead after the server could not be
+// reached, reporting whether there was one.
+func showCached(id string, err error) bool {
+	c, cerr := openCache(serverAddr)
+	if cerr != nil {
+		return false
+	}
+	bead, syncedAt, cerr := c.get(id)
+	if cerr != nil || bead == nil {
+		return false
+	}
+	warnCached(err, syncedAt)
+	if jsonOutput {
+		printBeadJSON(bead)
+	} else {
+		printBeadTable(bead)
+		printComments(bead.GetComments())
+	}
+	return true
+}
+
+func init() {
+	showCmd.Flags().Bool("activity", false, "show the bead's events and comments as one feed")
+	showCmd.Flags().Bool("raw", false, "print the description and comments without rendering Markdown")
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
 	"github.com/spf13/cobra"
@@ -34,13 +35,37 @@ var statusCmd = &cobra.Command{
 			total += resp.GetTotal()
 		}
 
+		// Deferred beads due back by the end of the UTC day, including any
+		// the server has not reopened yet. Older servers without the defer
+		// query field are tolerated.
+		resurfacing := int32(-1)
+		tomorrow := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+		if resp, err := client.ListBeads(ctx, &beadsv1.ListBeadsRequest{
+			Status: []string{"deferred"},
+			Query:  "defer<" + tomorrow.Format(time.DateOnly),
+		}); err == nil {
+			resurfacing = resp.GetTotal()
+		}
+
+		// Alerts are informational; older servers without ListAlerts are tolerated.
+		var firing []*beadsv1.Alert
+		if resp, err := client.ListAlerts(ctx, &beadsv1.ListAlertsRequest{}); err == nil {
+			for _, a := range resp.GetAlerts() {
+				if a.GetFiring() {
+					firing = append(firing, a)
+				}
+			}
+		}
+
 		if jsonOutput {
-			out := map[string]int32{
+			out := map[string]any{
 				"open":        counts["open"],
 				"in_progress": counts["in_progress"],
 				"deferred":    counts["deferred"],
 				"closed":      counts["closed"],
 				"total":       total,
+				"resurfacing": max(resurfacing, 0),
+				"alerts":      alertsJSON(firing),
 			}
 			data, err := json.MarshalIndent(out, "", "  ")
 			if err != nil {
@@ -55,7 +80,31 @@ var statusCmd = &cobra.Command{
 			fmt.Printf("  Deferred:    %d\n", counts["deferred"])
 			fmt.Printf("  Closed:      %d\n", counts["closed"])
 			fmt.Printf("  Total:       %d\n", total)
+			if resurfacing >= 0 {
+				fmt.Printf("  Resurfacing today: %d\n", resurfacing)
+			}
+			if len(firing) > 0 {
+				fmt.Println()
+				fmt.Println("Alerts")
+				for _, a := range firing {
+					fmt.Printf("  %s: %s=%g (threshold %g)\n", a.GetName(), a.GetMetric(), a.GetValue(), a.GetThreshold())
+				}
+			}
 		}
 		return nil
 	},
 }
+
+// alertsJSON converts firing alerts to a JSON-friendly slice (never nil).
+func alertsJSON(list []*beadsv1.Alert) []map[string]any {
+	out := make([]map[string]any, 0, len(list))
+	for _, a := range list {
+		out = append(out, map[string]any{
+			"name":      a.GetName(),
+			"metric":    a.GetMetric(),
+			"value":     a.GetValue(),
+			"threshold": a.GetThreshold(),
+		})
+	}
+	return out
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	beadsclient "github.com/alfredjeanlab/beads/client"
+	"github.com/alfredjeanlab/beads/internal/server"
+)
+
+// beadUpdate is one entry of the server's event stream: the events on a
+// bead within a coalescing window.
+type beadUpdate struct {
+	BeadID string   `json:"bead_id"`
+	Count  int      `json:"count"`
+	Topics []string `json:"topics"`
+	Actors []string `json:"actors"`
+}
+
+// summary renders an update as "3 changes (bead.updated, comment.added) by alice".
+func (u beadUpdate) summary() string {
+	topics := make([]string, len(u.Topics))
+	for i, t := range u.Topics {
+		topics[i] = strings.TrimPrefix(t, "beads.")
+	}
+	s := fmt.Sprintf("%d change", u.Count)
+	if u.Count != 1 {
+		s += "s"
+	}
+	s += " (" + strings.Join(topics, ", ") + ")"
+	if len(u.Actors) > 0 {
+		s += " by " + strings.Join(u.Actors, ", ")
+	}
+	return s
+}
+
+// httpBaseURL returns the server's HTTP address: BEADS_HTTP_URL if set,
+// otherwise the --server host on the default HTTP port.
+func httpBaseURL() (string, error) {
+	if u := os.Getenv("BEADS_HTTP_URL"); u != "" {
+		return strings.TrimRight(u, "/"), nil
+	}
+	host, _, err := net.SplitHostPort(serverAddr)
+	if err != nil {
+		host = serverAddr
+	}
+	cfg, err := clientTLSConfig(serverAddr)
+	if err != nil {
+		return "", err
+	}
+	scheme := "http"
+	if cfg != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + net.JoinHostPort(host, "8080"), nil
+}
+
+// httpGet fetches path from the server's HTTP API and returns the body. A
+// non-200 response is an *APIError carrying the server's message and code.
+// Transient failures are retried per --retries.
+func httpGet(ctx context.Context, path string) ([]byte, error) {
+	base, err := httpBaseURL()
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := serverHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(server.ClientVersionHeader, Version)
+	if tok := bearerTokenFromEnv(); tok != "" {
+		req.Header.Set("Authorization", "Bearer "+tok)
+	}
+	resp, err := doWithRetry(httpClient, req, retryMax)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{Status: resp.Status}
+		_ = json.Unmarshal(body, apiErr)
+		return nil, apiErr
+	}
+	return body, nil
+}
+
+// httpPost sends body as JSON to path on the server's HTTP API,
+// authorized with token, and returns the response body. A non-2xx response
+// is an *APIError. The request is not retried.
+func httpPost(ctx context.Context, path, token string, body any) ([]byte, error) {
+	return httpDo(ctx, http.MethodPost, path, token, body)
+}
+
+// httpDo sends a method request to path on the server's HTTP API, with
+// body as JSON unless it is nil, authorized with token, and returns the
+// response body. A non-2xx response is an *APIError. The request is not
+// retried.
+func httpDo(ctx context.Context, method, path, token string, body any) ([]byte, error) {
+	base, err := httpBaseURL()
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := serverHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, base+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(server.ClientVersionHeader, Version)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{Status: resp.Status}
+		_ = json.Unmarshal(respBody, apiErr)
+		return nil, apiErr
+	}
+	return respBody, nil
+}
+
+// streamUpdates opens the server's event stream narrowed by filter (nil
+// topics, bead and so on for everything, and a coalescing window) and
+// delivers updates until ctx is cancelled, when the channel is closed. The
+// stream is reopened without losing updates when it drops; only failing to
+// open it, after retrying transient failures per --retries, is an error.
+func streamUpdates(ctx context.Context, filter beadsclient.EventFilter) (<-chan beadUpdate, error) {
+	c, err := eventClient()
+	if err != nil {
+		return nil, err
+	}
+	var events <-chan beadsclient.Event
+	for attempt := 0; ; attempt++ {
+		events, err = c.WatchEvents(ctx, filter)
+		var apiErr *beadsclient.APIError
+		if err == nil || attempt >= retryMax || (errors.As(err, &apiErr) && !retryableStatus(apiErr.StatusCode)) {
+			break
+		}
+		if sleepCtx(ctx, retryDelay(attempt)) != nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan beadUpdate, 64)
+	go func() {
+		defer close(ch)
+		for e := range events {
+			select {
+			case ch <- beadUpdate{BeadID: e.BeadID, Count: e.Count, Topics: e.Topics, Actors: e.Actors}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// eventClient returns a client of the server's HTTP API for event streams,
+// sending bd's version and token.
+func eventClient() (*beadsclient.BeadsClient, error) {
+	base, err := httpBaseURL()
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := serverHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	c := beadsclient.New(base)
+	c.HTTPClient = httpClient
+	c.Token = bearerTokenFromEnv()
+	c.Header = http.Header{server.ClientVersionHeader: {Version}}
+	c.OnStreamLost = func(error) {
+		fmt.Fprintln(os.Stderr, "Warning: event stream lost; reconnecting")
+	}
+	return c, nil
+}
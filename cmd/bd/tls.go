@@ -0,0 +1,81 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/alfredjeanlab/beads/internal/server"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// insecureConn disables TLS when dialing the server.
+var insecureConn bool
+
+// transportCredentials returns the credentials used to dial addr.
+func transportCredentials(addr string) (credentials.TransportCredentials, error) {
+	cfg, err := clientTLSConfig(addr)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return insecure.NewCredentials(), nil
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// clientTLSConfig returns the TLS config used to reach the server at addr,
+// or nil to connect in plaintext.
+//
+// TLS is used unless --insecure is set. BEADS_TLS_CA adds a CA bundle to
+// verify the server against (system roots otherwise), and
+// BEADS_TLS_CLIENT_CERT/BEADS_TLS_CLIENT_KEY present a client certificate
+// for mTLS. With none of these set, loopback addresses are dialed in
+// plaintext so a local `bd serve` keeps working out of the box.
+func clientTLSConfig(addr string) (*tls.Config, error) {
+	if insecureConn {
+		return nil, nil
+	}
+	caFile := os.Getenv("BEADS_TLS_CA")
+	certFile := os.Getenv("BEADS_TLS_CLIENT_CERT")
+	keyFile := os.Getenv("BEADS_TLS_CLIENT_KEY")
+	if caFile == "" && certFile == "" && keyFile == "" && isLoopback(addr) {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pool, err := server.LoadCertPool(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("BEADS_TLS_CA: %w", err)
+		}
+		cfg.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// isLoopback reports whether a gRPC target names a loopback host.
+func isLoopback(addr string) bool {
+	if i := strings.LastIndex(addr, "///"); i >= 0 {
+		addr = addr[i+3:]
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestIsLoopback(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"localhost:9090":        true,
+		"127.0.0.1:9090":        true,
+		"[::1]:9090":            true,
+		"dns:///localhost:9090": true,
+		"beads.example.com:443": false,
+		"dns:///10.0.0.5:9090":  false,
+		"beads.example.com":     false,
+	} {
+		if got := isLoopback(addr); got != want {
+			t.Errorf("isLoopback(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestTransportCredentials(t *testing.T) {
+	t.Setenv("BEADS_TLS_CA", "")
+	t.Setenv("BEADS_TLS_CLIENT_CERT", "")
+	t.Setenv("BEADS_TLS_CLIENT_KEY", "")
+
+	for _, tc := range []struct {
+		addr     string
+		insecure bool
+		want     string
+	}{
+		{"localhost:9090", false, "insecure"},
+		{"beads.example.com:443", false, "tls"},
+		{"beads.example.com:443", true, "insecure"},
+	} {
+		insecureConn = tc.insecure
+		creds, err := transportCredentials(tc.addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := creds.Info().SecurityProtocol; got != tc.want {
+			t.Errorf("transportCredentials(%q, insecure=%v) = %q, want %q", tc.addr, tc.insecure, got, tc.want)
+		}
+	}
+	insecureConn = false
+
+	t.Setenv("BEADS_TLS_CA", "/nonexistent/ca.crt")
+	if _, err := transportCredentials("localhost:9090"); err == nil {
+		t.Error("expected error for missing BEADS_TLS_CA file")
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
 	"text/tabwriter"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/server"
 	"github.com/spf13/cobra"
 )
 
@@ -21,7 +24,11 @@ var treeCmd = &cobra.Command{
 		depth, _ := cmd.Flags().GetInt("depth")
 		flat, _ := cmd.Flags().GetBool("flat")
 		filterType, _ := cmd.Flags().GetString("type")
+		format, _ := cmd.Flags().GetString("format")
 
+		if format != "" && format != "text" {
+			return runTreeExport(beadID, depth, filterType, format)
+		}
 		if flat {
 			return runTreeFlat(beadID, filterType)
 		}
@@ -49,6 +56,23 @@ func runTreeGraph(beadID string, depth int, filterType string) error {
 	return nil
 }
 
+// runTreeExport prints the bead's dependency graph, rendered by the server
+// in format (dot, mermaid, graphml or jsongraph).
+func runTreeExport(beadID string, depth int, filterType, format string) error {
+	q := url.Values{"root": {beadID}, "depth": {strconv.Itoa(depth)}, "format": {format}}
+	if filterType != "" {
+		q.Set("dep_type", filterType)
+	}
+	requireFeature("bd tree --format "+format, server.FeatureGraphExport)
+	body, err := httpGet(context.Background(), "/v1/export/graph?"+q.Encode())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: exporting graph: %v\n", err)
+		os.Exit(1)
+	}
+	os.Stdout.Write(body)
+	return nil
+}
+
 func runTreeFlat(beadID string, filterType string) error {
 	var types []string
 	if filterType != "" {
@@ -157,4 +181,5 @@ func init() {
 	treeCmd.Flags().Int("depth", 3, "maximum depth to traverse")
 	treeCmd.Flags().Bool("flat", false, "flat table instead of ASCII tree")
 	treeCmd.Flags().StringP("type", "t", "", "filter by dependency type (e.g. parent-child, blocks)")
+	treeCmd.Flags().String("format", "text", "output format: text, mermaid, dot, graphml or jsongraph")
 }
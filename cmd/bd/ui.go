@@ -0,0 +1,480 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	beadsclient "github.com/alfredjeanlab/beads/client"
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"google.golang.org/protobuf/proto"
+)
+
+var uiCmd = &cobra.Command{
+	Use:     "ui",
+	Short:   "Full-screen terminal dashboard: board, inbox, decisions, roster, jacks and saved views",
+	GroupID: "views",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		coalesce, _ := cmd.Flags().GetDuration("coalesce")
+
+		fd := int(os.Stdin.Fd())
+		if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+			fmt.Fprintln(os.Stderr, "Error: bd ui requires an interactive terminal")
+			os.Exit(1)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		m := &uiModel{tabs: uiTabs(ctx)}
+		m.refresh(ctx)
+
+		oldState, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("entering raw mode: %w", err)
+		}
+		defer term.Restore(fd, oldState)
+
+		// Alternate screen, hidden cursor; restored on exit.
+		fmt.Print("\x1b[?1049h\x1b[?25l")
+		defer fmt.Print("\x1b[?25h\x1b[?1049l")
+
+		keys := make(chan string, 16)
+		go readKeys(os.Stdin, keys)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// With --coalesce, refresh as the server reports changes; the
+		// ticker remains as a fallback if the stream ends.
+		var updates <-chan beadUpdate
+		if coalesce > 0 {
+			if updates, err = streamUpdates(ctx, beadsclient.EventFilter{Coalesce: coalesce}); err != nil {
+				m.message = fmt.Sprintf("Error: %v", err)
+			}
+		}
+
+		for {
+			draw(m)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				m.refresh(ctx)
+			case _, ok := <-updates:
+				if !ok {
+					updates = nil
+					continue
+				}
+				m.refresh(ctx)
+			case k, ok := <-keys:
+				if !ok {
+					return nil
+				}
+				switch m.handleKey(k) {
+				case uiQuit:
+					return nil
+				case uiRefresh:
+					m.refresh(ctx)
+				case uiClaim:
+					m.act(ctx, "claimed", func(id string) error {
+						_, err := client.UpdateBead(ctx, &beadsv1.UpdateBeadRequest{
+							Id:       id,
+							Assignee: proto.String(actor),
+							Status:   proto.String("in_progress"),
+						})
+						return err
+					})
+				case uiUnclaim:
+					m.act(ctx, "unclaimed", func(id string) error {
+						_, err := client.UpdateBead(ctx, &beadsv1.UpdateBeadRequest{
+							Id:       id,
+							Assignee: proto.String(""),
+							Status:   proto.String("open"),
+						})
+						return err
+					})
+				case uiClose:
+					m.act(ctx, "closed", func(id string) error {
+						_, err := client.CloseBead(ctx, &beadsv1.CloseBeadRequest{
+							Id:       id,
+							ClosedBy: actor,
+						})
+						return err
+					})
+				}
+			}
+		}
+	},
+}
+
+// uiAction is the effect of a key press that the event loop must carry out.
+type uiAction int
+
+const (
+	uiNone uiAction = iota
+	uiQuit
+	uiRefresh
+	uiClaim
+	uiUnclaim
+	uiClose
+)
+
+// uiTab is one tab of the dashboard: a named ListBeads query, or a load func
+// for tabs that are not a single query, and its last result.
+type uiTab struct {
+	name    string
+	req     *beadsv1.ListBeadsRequest
+	load    func(ctx context.Context) ([]*beadsv1.Bead, error)
+	columns []string
+	beads   []*beadsv1.Bead
+	total   int32
+	err     error
+}
+
+// uiModel is the full dashboard state. It is rendered by renderUI and
+// mutated only from the event loop.
+type uiModel struct {
+	tabs    []*uiTab
+	active  int
+	cursor  int
+	message string
+}
+
+var uiDefaultColumns = []string{"id", "status", "priority", "assignee", "title"}
+
+// uiTabs returns the fixed tabs (board, mine, inbox, decisions, roster,
+// jacks) followed by one tab per saved view. Views that fail to parse are
+// skipped.
+func uiTabs(ctx context.Context) []*uiTab {
+	tabs := []*uiTab{
+		{
+			name: "board",
+			req: &beadsv1.ListBeadsRequest{
+				Status: []string{"open", "in_progress", "deferred"},
+				Sort:   "priority",
+			},
+		},
+		{
+			name: "mine",
+			req: &beadsv1.ListBeadsRequest{
+				Status:   []string{"open", "in_progress"},
+				Assignee: actor,
+				Sort:     "priority",
+			},
+		},
+		{
+			name: "inbox",
+			load: inboxBeads,
+		},
+		{
+			name: "decisions",
+			req: &beadsv1.ListBeadsRequest{
+				Type:   []string{"decision"},
+				Status: []string{"open", "in_progress"},
+				Sort:   "created_at",
+			},
+		},
+		{
+			name: "roster",
+			req: &beadsv1.ListBeadsRequest{
+				Type: []string{"agent"},
+				Sort: "title",
+			},
+			columns: []string{"id", "status", "title"},
+		},
+		{
+			name: "jacks",
+			req: &beadsv1.ListBeadsRequest{
+				Type:   []string{"jack"},
+				Status: []string{"open", "in_progress"},
+				Sort:   "created_at",
+			},
+		},
+	}
+
+	resp, err := client.ListConfigs(ctx, &beadsv1.ListConfigsRequest{Namespace: "view"})
+	if err != nil {
+		return tabs
+	}
+	configs := resp.GetConfigs()
+	sort.Slice(configs, func(i, j int) bool { return configs[i].GetKey() < configs[j].GetKey() })
+	for _, c := range configs {
+		var vc viewConfig
+		if err := json.Unmarshal(c.GetValue(), &vc); err != nil {
+			continue
+		}
+		tabs = append(tabs, &uiTab{
+			name:    strings.TrimPrefix(c.GetKey(), "view:"),
+			req:     vc.listRequest(),
+			columns: vc.Columns,
+		})
+	}
+	return tabs
+}
+
+// inboxBeads returns the beads with unread notifications for the current
+// actor, most recently notified first. Unlike bd inbox it leaves the
+// notifications unread.
+func inboxBeads(ctx context.Context) ([]*beadsv1.Bead, error) {
+	resp, err := client.ListNotifications(ctx, &beadsv1.ListNotificationsRequest{
+		Actor:      actor,
+		UnreadOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var beads []*beadsv1.Bead
+	seen := make(map[string]bool)
+	for _, n := range resp.GetNotifications() {
+		id := n.GetEvent().GetBeadId()
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		b, err := client.GetBead(ctx, &beadsv1.GetBeadRequest{Id: id})
+		if err != nil {
+			continue // deleted since the notification
+		}
+		beads = append(beads, b.GetBead())
+	}
+	return beads, nil
+}
+
+// refresh re-runs the query for every tab.
+func (m *uiModel) refresh(ctx context.Context) {
+	for _, t := range m.tabs {
+		if t.load != nil {
+			t.beads, t.err = t.load(ctx)
+			t.total = int32(len(t.beads))
+			continue
+		}
+		resp, err := client.ListBeads(ctx, t.req)
+		t.err = err
+		if err != nil {
+			continue
+		}
+		t.beads = resp.GetBeads()
+		t.total = resp.GetTotal()
+	}
+	m.clampCursor()
+}
+
+// act applies fn to the selected bead, records the outcome in the status
+// line, and refreshes all tabs.
+func (m *uiModel) act(ctx context.Context, verb string, fn func(id string) error) {
+	b := m.selected()
+	if b == nil {
+		return
+	}
+	if err := fn(b.GetId()); err != nil {
+		m.message = fmt.Sprintf("Error: %v", err)
+	} else {
+		m.message = fmt.Sprintf("%s %s", verb, b.GetId())
+	}
+	m.refresh(ctx)
+}
+
+// selected returns the bead under the cursor, or nil if the tab is empty.
+func (m *uiModel) selected() *beadsv1.Bead {
+	if len(m.tabs) == 0 {
+		return nil
+	}
+	beads := m.tabs[m.active].beads
+	if m.cursor < 0 || m.cursor >= len(beads) {
+		return nil
+	}
+	return beads[m.cursor]
+}
+
+func (m *uiModel) clampCursor() {
+	if len(m.tabs) == 0 {
+		m.cursor = 0
+		return
+	}
+	n := len(m.tabs[m.active].beads)
+	if m.cursor >= n {
+		m.cursor = n - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// handleKey updates navigation state for k and returns any action the event
+// loop should perform.
+func (m *uiModel) handleKey(k string) uiAction {
+	switch k {
+	case "q", "ctrl-c":
+		return uiQuit
+	case "r":
+		return uiRefresh
+	case "c":
+		return uiClaim
+	case "u":
+		return uiUnclaim
+	case "x":
+		return uiClose
+	case "right", "l", "tab":
+		if len(m.tabs) > 0 {
+			m.active = (m.active + 1) % len(m.tabs)
+			m.cursor = 0
+		}
+	case "left", "h":
+		if len(m.tabs) > 0 {
+			m.active = (m.active + len(m.tabs) - 1) % len(m.tabs)
+			m.cursor = 0
+		}
+	case "down", "j":
+		m.cursor++
+		m.clampCursor()
+	case "up", "k":
+		m.cursor--
+		m.clampCursor()
+	}
+	return uiNone
+}
+
+// readKeys reads raw terminal input and sends decoded key names to out.
+// It closes out when the input is exhausted.
+func readKeys(f *os.File, out chan<- string) {
+	defer close(out)
+	buf := make([]byte, 64)
+	for {
+		n, err := f.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, k := range parseKeys(buf[:n]) {
+			out <- k
+		}
+	}
+}
+
+// parseKeys decodes a chunk of raw terminal input into key names. Arrow keys
+// become "up", "down", "left", "right"; other printable bytes are returned
+// as-is; unknown escape sequences are dropped.
+func parseKeys(b []byte) []string {
+	var keys []string
+	for i := 0; i < len(b); i++ {
+		switch c := b[i]; {
+		case c == 0x03:
+			keys = append(keys, "ctrl-c")
+		case c == '\t':
+			keys = append(keys, "tab")
+		case c == 0x1b && i+2 < len(b) && b[i+1] == '[':
+			switch b[i+2] {
+			case 'A':
+				keys = append(keys, "up")
+			case 'B':
+				keys = append(keys, "down")
+			case 'C':
+				keys = append(keys, "right")
+			case 'D':
+				keys = append(keys, "left")
+			}
+			i += 2
+		case c == 0x1b:
+			keys = append(keys, "esc")
+		case c >= 0x20 && c < 0x7f:
+			keys = append(keys, string(c))
+		}
+	}
+	return keys
+}
+
+func draw(m *uiModel) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		width, height = 80, 24
+	}
+	fmt.Print("\x1b[H\x1b[2J" + renderUI(m, width, height))
+}
+
+// renderUI renders the model as a full screen of text. Lines are separated
+// by "\r\n" because the terminal is in raw mode.
+func renderUI(m *uiModel, width, height int) string {
+	var lines []string
+
+	// Tab bar; the active tab is shown in reverse video.
+	var bar strings.Builder
+	for i, t := range m.tabs {
+		label := fmt.Sprintf(" %s (%d) ", t.name, len(t.beads))
+		if i == m.active {
+			label = "\x1b[7m" + label + "\x1b[0m"
+		}
+		bar.WriteString(label)
+	}
+	lines = append(lines, bar.String(), "")
+
+	// Body: rows of the active tab, scrolled to keep the cursor visible.
+	bodyHeight := height - 4
+	if bodyHeight < 1 {
+		bodyHeight = 1
+	}
+	if len(m.tabs) > 0 {
+		t := m.tabs[m.active]
+		columns := t.columns
+		if len(columns) == 0 {
+			columns = uiDefaultColumns
+		}
+		switch {
+		case t.err != nil:
+			lines = append(lines, fmt.Sprintf("Error: %v", t.err))
+		case len(t.beads) == 0:
+			lines = append(lines, "No beads.")
+		default:
+			start := 0
+			if m.cursor >= bodyHeight {
+				start = m.cursor - bodyHeight + 1
+			}
+			for i := start; i < len(t.beads) && i < start+bodyHeight; i++ {
+				vals := make([]string, len(columns))
+				for j, col := range columns {
+					vals[j] = beadField(t.beads[i], col)
+				}
+				row := truncate(strings.Join(vals, "  "), width-2)
+				if i == m.cursor {
+					row = "\x1b[7m> " + row + "\x1b[0m"
+				} else {
+					row = "  " + row
+				}
+				lines = append(lines, row)
+			}
+		}
+	}
+
+	// Pad so the footer sits on the last two lines.
+	for len(lines) < height-2 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, m.message,
+		"←/→ tab  ↑/↓ select  c claim  u unclaim  x close  r refresh  q quit")
+	return strings.Join(lines, "\r\n")
+}
+
+// truncate shortens s to at most n runes, marking the cut with "...".
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if n <= 0 || len(r) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(r[:n])
+	}
+	return string(r[:n-3]) + "..."
+}
+
+func init() {
+	uiCmd.Flags().Duration("interval", 5*time.Second, "refresh interval")
+	uiCmd.Flags().Duration("coalesce", 0, "refresh from the server event stream, merging each bead's changes over this window (e.g. 2s)")
+}
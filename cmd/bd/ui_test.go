@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+)
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("j\x1b[A\x1b[Cq\t\x03"))
+	want := []string{"j", "up", "right", "q", "tab", "ctrl-c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseKeys = %v, want %v", got, want)
+	}
+}
+
+func testUIModel() *uiModel {
+	return &uiModel{tabs: []*uiTab{
+		{name: "board", beads: []*beadsv1.Bead{
+			{Id: "kd-1", Status: "open", Title: "First"},
+			{Id: "kd-2", Status: "in_progress", Title: "Second"},
+		}},
+		{name: "mine"},
+	}}
+}
+
+func TestUIHandleKey(t *testing.T) {
+	m := testUIModel()
+
+	m.handleKey("j")
+	m.handleKey("j") // clamped at last row
+	if m.cursor != 1 {
+		t.Errorf("cursor = %d, want 1", m.cursor)
+	}
+	if b := m.selected(); b == nil || b.GetId() != "kd-2" {
+		t.Errorf("selected = %v, want kd-2", b)
+	}
+
+	m.handleKey("right")
+	if m.active != 1 || m.cursor != 0 {
+		t.Errorf("after right: active=%d cursor=%d, want 1, 0", m.active, m.cursor)
+	}
+	if m.selected() != nil {
+		t.Error("selected in empty tab should be nil")
+	}
+	m.handleKey("right") // wraps
+	if m.active != 0 {
+		t.Errorf("active = %d, want 0 after wrap", m.active)
+	}
+
+	for k, want := range map[string]uiAction{"q": uiQuit, "c": uiClaim, "u": uiUnclaim, "x": uiClose, "r": uiRefresh} {
+		if got := m.handleKey(k); got != want {
+			t.Errorf("handleKey(%q) = %v, want %v", k, got, want)
+		}
+	}
+}
+
+func TestRenderUI(t *testing.T) {
+	m := testUIModel()
+	m.cursor = 1
+	m.message = "claimed kd-2"
+
+	out := renderUI(m, 80, 10)
+	lines := strings.Split(out, "\r\n")
+	if len(lines) != 10 {
+		t.Fatalf("got %d lines, want 10", len(lines))
+	}
+	if !strings.Contains(lines[0], "\x1b[7m board (2) \x1b[0m") {
+		t.Errorf("active tab not highlighted: %q", lines[0])
+	}
+	if !strings.Contains(lines[2], "  kd-1") || !strings.Contains(lines[3], "> kd-2") {
+		t.Errorf("unexpected rows: %q / %q", lines[2], lines[3])
+	}
+	if lines[8] != "claimed kd-2" {
+		t.Errorf("status line = %q", lines[8])
+	}
+}
+
+func TestUIRefresh_LoadTab(t *testing.T) {
+	m := &uiModel{tabs: []*uiTab{{
+		name: "inbox",
+		load: func(context.Context) ([]*beadsv1.Bead, error) {
+			return []*beadsv1.Bead{{Id: "kd-3"}}, nil
+		},
+	}}}
+	m.refresh(context.Background())
+	if tab := m.tabs[0]; tab.err != nil || tab.total != 1 || tab.beads[0].GetId() != "kd-3" {
+		t.Errorf("inbox tab = %+v, want kd-3", tab)
+	}
+}
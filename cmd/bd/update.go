@@ -18,8 +18,11 @@ var updateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
 
+		appendText, _ := cmd.Flags().GetBool("append")
 		req := &beadsv1.UpdateBeadRequest{
-			Id: id,
+			Id:        id,
+			Append:    appendText,
+			UpdatedBy: actor,
 		}
 
 		if cmd.Flags().Changed("title") {
@@ -50,6 +53,14 @@ var updateCmd = &cobra.Command{
 			v, _ := cmd.Flags().GetString("notes")
 			req.Notes = proto.String(v)
 		}
+		if cmd.Flags().Changed("estimate") {
+			v, _ := cmd.Flags().GetString("estimate")
+			req.Estimate = proto.String(v)
+		}
+		if cmd.Flags().Changed("actual") {
+			v, _ := cmd.Flags().GetString("actual")
+			req.Actual = proto.String(v)
+		}
 		if cmd.Flags().Changed("field") {
 			fieldPairs, _ := cmd.Flags().GetStringArray("field")
 			fieldsJSON, err := parseFields(fieldPairs)
@@ -59,6 +70,7 @@ var updateCmd = &cobra.Command{
 			}
 			req.Fields = fieldsJSON
 		}
+		req.Clear, _ = cmd.Flags().GetStringSlice("clear")
 		resp, err := client.UpdateBead(context.Background(), req)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -82,5 +94,9 @@ func init() {
 	updateCmd.Flags().String("assignee", "", "assignee")
 	updateCmd.Flags().String("owner", "", "owner")
 	updateCmd.Flags().String("notes", "", "notes")
+	updateCmd.Flags().String("estimate", "", "estimate, in points (3) or a duration (4h)")
+	updateCmd.Flags().String("actual", "", "work actually spent, in points or a duration")
 	updateCmd.Flags().StringArrayP("field", "f", nil, "typed field (key=value, repeatable)")
+	updateCmd.Flags().Bool("append", false, "append --description and --notes instead of replacing them")
+	updateCmd.Flags().StringSlice("clear", nil, "fields to clear: description, notes, assignee, owner, due_at, defer_until, estimate, actual, labels (repeatable)")
 }
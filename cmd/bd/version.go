@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/version"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Version and Build are set at release time with
+// -ldflags "-X main.Version=v1.2.3 -X main.Build=abc1234".
+var (
+	Version = "dev"
+	Build   = "unknown"
+)
+
+// ReleaseURL is where bd self-update downloads releases from, and
+// ReleaseKey the base64 Ed25519 public key their checksums.txt must be
+// signed with (unchecked when empty). Builds for a private mirror override
+// them with -ldflags "-X main.ReleaseURL=... -X main.ReleaseKey=...". The
+// server only chooses which version to install, never where it comes from.
+var (
+	ReleaseURL = "https://github.com/alfredjeanlab/beads/releases/download"
+	ReleaseKey = ""
+)
+
+// clientVersionInterceptor reports this binary's version to the server on
+// every call, so it can enforce its minimum client version.
+func clientVersionInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-beads-client-version", Version)
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// serverVersion mirrors the server's GET /v1/version response.
+type serverVersion struct {
+	Version          string   `json:"version"`
+	MinClientVersion string   `json:"min_client_version"`
+	ClientVersion    string   `json:"client_version"`
+	Features         []string `json:"features"`
+}
+
+// versionFetchTimeout bounds the GET /v1/version request.
+const versionFetchTimeout = 2 * time.Second
+
+// fetchServerVersion returns getServerVersion, fetched at most once per run.
+var fetchServerVersion = sync.OnceValues(func() (*serverVersion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), versionFetchTimeout)
+	defer cancel()
+	return getServerVersion(ctx)
+})
+
+// getServerVersion fetches the server's GET /v1/version. A server older
+// than the endpoint answers 404 and is returned with no version and no
+// features.
+func getServerVersion(ctx context.Context) (*serverVersion, error) {
+	body, err := httpGet(ctx, "/v1/version")
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && strings.HasPrefix(apiErr.Status, "404") {
+		return &serverVersion{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var v serverVersion
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	return &v, nil
+}
+
+// serverSupports reports whether the server advertises feature. A server
+// that cannot be asked is assumed to, so the call itself reports the error.
+func serverSupports(feature string) bool {
+	v, err := fetchServerVersion()
+	return err != nil || slices.Contains(v.Features, feature)
+}
+
+// requireFeature exits with an explanation when the server does not support
+// feature, which command needs, instead of letting the call fail with a bare
+// 404.
+func requireFeature(command, feature string) {
+	if serverSupports(feature) {
+		return
+	}
+	server := "an older version"
+	if v, _ := fetchServerVersion(); v.Version != "" {
+		server = v.Version
+	}
+	fmt.Fprintf(os.Stderr, "Error: %s needs a newer server: %s does not support %s. Upgrade the server to use it.\n", command, server, feature)
+	os.Exit(1)
+}
+
+var versionCmd = &cobra.Command{
+	Use:     "version",
+	Short:   "Show the client and server versions",
+	GroupID: "system",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// The server half is informational; an unreachable server is not an error.
+		info, err := client.GetServerInfo(context.Background(), &beadsv1.GetServerInfoRequest{})
+
+		if jsonOutput {
+			out := map[string]any{"version": Version, "build": Build}
+			if err == nil {
+				out["server"] = info
+			}
+			data, err := json.MarshalIndent(out, "", "  ")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("bd %s (%s)\n", Version, Build)
+		if err != nil {
+			fmt.Printf("Server: unavailable (%v)\n", err)
+			return nil
+		}
+		fmt.Printf("Server: %s\n", info.GetVersion())
+		if version.Older(info.GetVersion(), Version) {
+			fmt.Println("Warning: the server is older than this bd; commands needing newer features will say so.")
+		}
+		if f := info.GetFeatures(); len(f) > 0 {
+			fmt.Printf("Features: %s\n", strings.Join(f, ", "))
+		}
+		if v := info.GetClientVersion(); v != "" && v != Version {
+			fmt.Printf("Recommended client: %s (run `bd self-update`)\n", v)
+		}
+		if v := info.GetMinClientVersion(); v != "" {
+			fmt.Printf("Minimum client: %s\n", v)
+		}
+		return nil
+	},
+}
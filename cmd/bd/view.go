@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 	"text/tabwriter"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/server"
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -35,6 +37,7 @@ type viewFilter struct {
 	Labels   []string          `json:"labels"`
 	Assignee string            `json:"assignee"`
 	Search   string            `json:"search"`
+	Query    string            `json:"q,omitempty"` // query language expression
 	Priority *int32            `json:"priority"`
 	Fields   map[string]string `json:"fields,omitempty"`
 }
@@ -64,22 +67,7 @@ var viewCmd = &cobra.Command{
 		}
 
 		// 2. Build the ListBeads request.
-		req := &beadsv1.ListBeadsRequest{
-			Status:   vc.Filter.Status,
-			Type:     vc.Filter.Type,
-			Kind:     vc.Filter.Kind,
-			Labels:   vc.Filter.Labels,
-			Assignee: expandVar(vc.Filter.Assignee),
-			Search:   vc.Filter.Search,
-			Sort:     vc.Sort,
-			Limit:    vc.Limit,
-		}
-		if vc.Filter.Priority != nil {
-			req.Priority = wrapperspb.Int32(*vc.Filter.Priority)
-		}
-		if len(vc.Filter.Fields) > 0 {
-			req.FieldFilters = vc.Filter.Fields
-		}
+		req := vc.listRequest()
 		if limitOverride > 0 {
 			req.Limit = limitOverride
 		}
@@ -108,6 +96,84 @@ var viewCmd = &cobra.Command{
 	},
 }
 
+// viewCount is a view's entry in /v1/views/counts.
+type viewCount struct {
+	Name    string `json:"name"`
+	Count   int    `json:"count"`
+	Builtin bool   `json:"builtin,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+var viewListCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List views with how many beads each matches",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requireFeature("bd view ls", server.FeatureViewCounts)
+		path := "/v1/views/counts"
+		if actor != "" {
+			path += "?actor=" + url.QueryEscape(actor)
+		}
+		body, err := httpGet(context.Background(), path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		var resp struct {
+			Views []viewCount `json:"views"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid view list: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printJSON(resp.Views)
+			return nil
+		}
+		if len(resp.Views) == 0 {
+			fmt.Println("No views.")
+			return nil
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, v := range resp.Views {
+			switch {
+			case v.Error != "":
+				fmt.Fprintf(tw, "%s\t-\terror: %s\n", v.Name, v.Error)
+			case v.Builtin:
+				fmt.Fprintf(tw, "%s\t%d\t(builtin)\n", v.Name, v.Count)
+			default:
+				fmt.Fprintf(tw, "%s\t%d\n", v.Name, v.Count)
+			}
+		}
+		tw.Flush()
+		return nil
+	},
+}
+
+// listRequest builds the ListBeads request described by the view.
+func (vc viewConfig) listRequest() *beadsv1.ListBeadsRequest {
+	req := &beadsv1.ListBeadsRequest{
+		Status:   vc.Filter.Status,
+		Type:     vc.Filter.Type,
+		Kind:     vc.Filter.Kind,
+		Labels:   vc.Filter.Labels,
+		Assignee: expandVar(vc.Filter.Assignee),
+		Search:   vc.Filter.Search,
+		Query:    expandVar(vc.Filter.Query),
+		Sort:     vc.Sort,
+		Limit:    vc.Limit,
+	}
+	if vc.Filter.Priority != nil {
+		req.Priority = wrapperspb.Int32(*vc.Filter.Priority)
+	}
+	if len(vc.Filter.Fields) > 0 {
+		req.FieldFilters = vc.Filter.Fields
+	}
+	return req
+}
+
 // expandVar replaces well-known variables in filter values.
 func expandVar(s string) string {
 	s = strings.ReplaceAll(s, "$BEADS_ACTOR", actor)
@@ -136,17 +202,23 @@ func printBeadListColumns(beads []*beadsv1.Bead, total int32, columns []string)
 	fmt.Printf("\n%d beads (%d total)\n", len(beads), total)
 }
 
-// beadField returns the string value of a bead field by column name.
+// beadField returns the string value of a bead field by column name, with
+// long titles truncated for terminal tables.
 func beadField(b *beadsv1.Bead, col string) string {
+	v := beadValue(b, col)
+	if strings.ToLower(col) == "title" && len(v) > 50 {
+		v = v[:47] + "..."
+	}
+	return v
+}
+
+// beadValue returns the full string value of a bead field by column name.
+func beadValue(b *beadsv1.Bead, col string) string {
 	switch strings.ToLower(col) {
 	case "id":
 		return b.GetId()
 	case "title":
-		title := b.GetTitle()
-		if len(title) > 50 {
-			title = title[:47] + "..."
-		}
-		return title
+		return b.GetTitle()
 	case "status":
 		return b.GetStatus()
 	case "type":
@@ -155,6 +227,11 @@ func beadField(b *beadsv1.Bead, col string) string {
 		return b.GetKind()
 	case "priority":
 		return fmt.Sprintf("%d", b.GetPriority())
+	case "effective_priority":
+		if b.EffectivePriority == nil {
+			return ""
+		}
+		return fmt.Sprintf("%d", b.GetEffectivePriority())
 	case "assignee":
 		return b.GetAssignee()
 	case "owner":
@@ -163,6 +240,15 @@ func beadField(b *beadsv1.Bead, col string) string {
 		return b.GetCreatedBy()
 	case "labels":
 		return strings.Join(b.GetLabels(), ",")
+	case "estimate":
+		return b.GetEstimate()
+	case "actual":
+		return b.GetActual()
+	case "checklist":
+		if b.GetChecklistTotal() == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%d/%d", b.GetChecklistDone(), b.GetChecklistTotal())
 	default:
 		return ""
 	}
@@ -184,4 +270,5 @@ func printViewDeps(beads []*beadsv1.Bead, dc *depConfig) {
 
 func init() {
 	viewCmd.Flags().Int32("limit", 0, "override the view's limit")
+	viewCmd.AddCommand(viewListCmd)
 }
@@ -9,22 +9,63 @@ import (
 	"os/signal"
 	"time"
 
+	beadsclient "github.com/alfredjeanlab/beads/client"
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
 	"github.com/alfredjeanlab/beads/internal/events"
 	"github.com/nats-io/nats.go"
 	"github.com/spf13/cobra"
-	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var watchCmd = &cobra.Command{
-	Use:     "watch <view-name>",
-	Short:   "Watch for beads matching a saved view",
+	Use:   "watch <view-name> | --panel name=query...",
+	Short: "Watch for beads matching a saved view, or several queries at once",
+	Long: `Watch for beads matching a saved view, printing them as they change.
+
+With --panel, watch several queries at once instead: each panel is a name and
+a query language filter, and "me" as an assignee or owner is you. The panels
+are redrawn as live panes, stacked or side by side per --layout, whenever the
+server's event stream reports changes, and the terminal bell rings when a
+panel gains a bead. New matches are marked with "+". When stdout is not a
+terminal, each new match is printed as a line prefixed with its panel.
+
+  bd watch --panel ready='status=open' --panel mine='assignee=me status!=closed'`,
 	GroupID: "views",
-	Args:  cobra.ExactArgs(1),
+	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		name := args[0]
 		interval, _ := cmd.Flags().GetDuration("interval")
 		once, _ := cmd.Flags().GetBool("once")
+		coalesce, _ := cmd.Flags().GetDuration("coalesce")
+		panelSpecs, _ := cmd.Flags().GetStringArray("panel")
+
+		if len(panelSpecs) > 0 {
+			if len(args) > 0 {
+				fmt.Fprintln(os.Stderr, "Error: give a view name or --panel, not both")
+				os.Exit(1)
+			}
+			layout, _ := cmd.Flags().GetString("layout")
+			if layout != layoutRows && layout != layoutColumns {
+				fmt.Fprintf(os.Stderr, "Error: --layout must be %s or %s\n", layoutRows, layoutColumns)
+				os.Exit(1)
+			}
+			panels, err := parsePanels(panelSpecs, actor)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			limit, _ := cmd.Flags().GetInt32("limit")
+			noBell, _ := cmd.Flags().GetBool("no-bell")
+			if coalesce <= 0 {
+				coalesce = defaultPanelCoalesce
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			return watchPanels(ctx, panels, layout, limit, coalesce, once, !noBell)
+		}
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "Error: give a view name or at least one --panel")
+			os.Exit(1)
+		}
+		name := args[0]
 
 		// 1. Fetch the view config.
 		resp, err := client.GetConfig(context.Background(), &beadsv1.GetConfigRequest{
@@ -42,22 +83,7 @@ var watchCmd = &cobra.Command{
 		}
 
 		// 2. Build the ListBeads request.
-		req := &beadsv1.ListBeadsRequest{
-			Status:   vc.Filter.Status,
-			Type:     vc.Filter.Type,
-			Kind:     vc.Filter.Kind,
-			Labels:   vc.Filter.Labels,
-			Assignee: expandVar(vc.Filter.Assignee),
-			Search:   vc.Filter.Search,
-			Sort:     vc.Sort,
-			Limit:    vc.Limit,
-		}
-		if vc.Filter.Priority != nil {
-			req.Priority = wrapperspb.Int32(*vc.Filter.Priority)
-		}
-		if len(vc.Filter.Fields) > 0 {
-			req.FieldFilters = vc.Filter.Fields
-		}
+		req := vc.listRequest()
 
 		// 3. Setup signal handling.
 		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
@@ -74,6 +100,9 @@ var watchCmd = &cobra.Command{
 		}
 
 		// 5. Choose event-driven or polling mode.
+		if coalesce > 0 {
+			return watchStream(ctx, coalesce, req, seen)
+		}
 		natsURL := os.Getenv("BEADS_NATS_URL")
 		if natsURL == "" {
 			natsURL = activeRemoteNATSURL()
@@ -141,6 +170,52 @@ func watchNATS(ctx context.Context, natsURL string, req *beadsv1.ListBeadsReques
 	}
 }
 
+// watchStream follows the server's event stream, which sends at most one
+// summary per bead per coalescing window, and re-queries after each batch.
+// Summaries are printed for the beads in the view.
+func watchStream(ctx context.Context, window time.Duration, req *beadsv1.ListBeadsRequest, seen map[string]time.Time) error {
+	updates, err := streamUpdates(ctx, beadsclient.EventFilter{Coalesce: window})
+	if err != nil {
+		return err
+	}
+
+	debounce := time.NewTimer(0)
+	debounce.Stop()
+	select {
+	case <-debounce.C:
+	default:
+	}
+
+	var pending []beadUpdate
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case u, ok := <-updates:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("event stream closed")
+			}
+			pending = append(pending, u)
+			debounce.Reset(200 * time.Millisecond)
+		case <-debounce.C:
+			if err := queryAndPrint(ctx, req, seen); err != nil {
+				return err
+			}
+			if !jsonOutput {
+				for _, u := range pending {
+					if _, ok := seen[u.BeadID]; ok {
+						fmt.Printf("  %s: %s\n", u.BeadID, u.summary())
+					}
+				}
+			}
+			pending = nil
+		}
+	}
+}
+
 // watchPoll polls for changes at the given interval.
 func watchPoll(ctx context.Context, interval time.Duration, req *beadsv1.ListBeadsRequest, seen map[string]time.Time) error {
 	for {
@@ -208,4 +283,9 @@ func diffBeads(beads []*beadsv1.Bead, seen map[string]time.Time) []*beadsv1.Bead
 func init() {
 	watchCmd.Flags().Duration("interval", 5*time.Second, "polling interval")
 	watchCmd.Flags().Bool("once", false, "exit after first poll")
+	watchCmd.Flags().Duration("coalesce", 0, "follow the server event stream, merging each bead's changes over this window (e.g. 2s)")
+	watchCmd.Flags().StringArray("panel", nil, "watch a named query as a live pane, as name=query (repeatable)")
+	watchCmd.Flags().String("layout", layoutRows, "how panels are laid out: rows or columns")
+	watchCmd.Flags().Int32("limit", 50, "most beads listed per panel")
+	watchCmd.Flags().Bool("no-bell", false, "do not ring the terminal bell when a panel gains a bead")
 }
@@ -1,9 +1,16 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	beadsclient "github.com/alfredjeanlab/beads/client"
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -102,3 +109,113 @@ func TestDiffBeads_NilUpdatedAt(t *testing.T) {
 		t.Fatalf("got %d changed on second call, want 0", len(changed))
 	}
 }
+
+func TestBeadUpdateSummary(t *testing.T) {
+	u := beadUpdate{BeadID: "bd-1", Count: 3, Topics: []string{"beads.bead.updated", "beads.comment.added"}, Actors: []string{"alice"}}
+	if got, want := u.summary(), "3 changes (bead.updated, comment.added) by alice"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestStreamUpdates(t *testing.T) {
+	var gotQuery, gotAuth string
+	var resumedFrom []string
+	var mu sync.Mutex
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		gotQuery, gotAuth = r.URL.RawQuery, r.Header.Get("Authorization")
+		resumedFrom = append(resumedFrom, r.Header.Get("Last-Event-ID"))
+		n := len(resumedFrom)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "retry: 1000\nevent: ready\ndata: {\"coalesce_ms\":2000}\n\n")
+		fmt.Fprint(w, ": keepalive\n\n")
+		switch n {
+		case 1:
+			fmt.Fprint(w, "event: update\nid: 5\ndata: {\"bead_id\":\"bd-1\",\"count\":2,\"topics\":[\"beads.bead.updated\"]}\n\n")
+			fmt.Fprint(w, "event: reconnect\ndata: {\"retry_ms\":0}\n\n")
+		case 2:
+			// A dropped connection is reopened too.
+			fmt.Fprint(w, "event: update\nid: 7\ndata: {\"bead_id\":\"bd-2\",\"count\":1}\n\n")
+		default:
+			fmt.Fprint(w, "event: update\nid: 8\ndata: {\"bead_id\":\"bd-3\",\"count\":1}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer ts.Close()
+	t.Setenv("BEADS_HTTP_URL", ts.URL)
+	t.Setenv("BEADS_TOKEN", "bd_secret")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	updates, err := streamUpdates(ctx, beadsclient.EventFilter{Coalesce: 2 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for u := range updates {
+		got = append(got, u.BeadID)
+		if len(got) == 3 {
+			cancel()
+		}
+	}
+	if strings.Join(got, ",") != "bd-1,bd-2,bd-3" {
+		t.Fatalf("unexpected updates: %v", got)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(resumedFrom, ",") != ",5,7" {
+		t.Fatalf("resumed from %q, want after 5 then 7", resumedFrom)
+	}
+	if gotQuery != "coalesce=2s" || gotAuth != "Bearer bd_secret" {
+		t.Fatalf("query %q, auth %q", gotQuery, gotAuth)
+	}
+}
+
+func TestParsePanels(t *testing.T) {
+	panels, err := parsePanels([]string{"ready=status=open", " mine = assignee:bob,me status!=closed", "theirs=owner!=me"}, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct{ name, query string }{
+		{"ready", "status=open"},
+		{"mine", "assignee:bob,alice status!=closed"},
+		{"theirs", "owner!=alice"},
+	}
+	for i, w := range want {
+		if panels[i].name != w.name || panels[i].query != w.query {
+			t.Errorf("panel %d = %q %q, want %q %q", i, panels[i].name, panels[i].query, w.name, w.query)
+		}
+	}
+
+	for _, specs := range [][]string{{"ready"}, {"=status=open"}, {"ready="}, {"a=x", "a=y"}} {
+		if _, err := parsePanels(specs, "alice"); err == nil {
+			t.Errorf("parsePanels(%q) succeeded", specs)
+		}
+	}
+}
+
+func TestRenderPanels(t *testing.T) {
+	ready := &watchPanel{name: "ready", beads: []*beadsv1.Bead{{Id: "kd-1", Title: "One"}, {Id: "kd-2", Title: "Two"}, {Id: "kd-3", Title: "Three"}}, fresh: map[string]bool{"kd-2": true}}
+	mine := &watchPanel{name: "mine", beads: []*beadsv1.Bead{}}
+
+	lines := strings.Split(renderPanels([]*watchPanel{ready, mine}, layoutRows, 40, 7), "\n")
+	if len(lines) != 6 || !strings.HasPrefix(lines[0], "── ready (3) ─") || len([]rune(lines[0])) != 40 {
+		t.Fatalf("rows layout:\n%s", strings.Join(lines, "\n"))
+	}
+	if !strings.HasPrefix(lines[1], "  kd-1") || !strings.HasPrefix(lines[2], "  ... 2 more") {
+		t.Errorf("ready pane rows = %q", lines[1:3])
+	}
+	if !strings.HasPrefix(lines[4], "── mine (0)") || lines[5] != "No beads." {
+		t.Errorf("mine pane = %q", lines[4:])
+	}
+
+	lines = strings.Split(renderPanels([]*watchPanel{ready, mine}, layoutColumns, 41, 5), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "── ready (3)") || !strings.Contains(lines[0], " ── mine (0)") {
+		t.Fatalf("columns layout:\n%s", strings.Join(lines, "\n"))
+	}
+	if !strings.HasPrefix(lines[2], "+ kd-2") || !strings.HasSuffix(lines[1], "No beads.") {
+		t.Errorf("columns rows = %q", lines[1:])
+	}
+}
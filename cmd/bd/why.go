@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// readinessRecord is a bead's readiness as returned by
+// /v1/beads/{id}/readiness.
+type readinessRecord struct {
+	BeadID    string `json:"bead_id"`
+	Ready     bool   `json:"ready"`
+	Claimable bool   `json:"claimable"`
+	Actor     string `json:"actor"`
+	Reasons   []struct {
+		Kind    string `json:"kind"`
+		Message string `json:"message"`
+	} `json:"reasons"`
+}
+
+var whyCmd = &cobra.Command{
+	Use:   "why <id>",
+	Short: "Explain why a bead is or isn't ready",
+	Long: `Explains why a bead does or does not appear in bd ready, and whether bd
+claim could take it: its status, unclosed blockers and gates, waiting
+external dependencies, and who it is assigned to.`,
+	Args:    cobra.ExactArgs(1),
+	GroupID: "views",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "/v1/beads/" + url.PathEscape(args[0]) + "/readiness"
+		if actor != "" {
+			path += "?actor=" + url.QueryEscape(actor)
+		}
+		body, err := httpGet(context.Background(), path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			fmt.Println(string(body))
+			return nil
+		}
+		var rd readinessRecord
+		if err := json.Unmarshal(body, &rd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid response: %v\n", err)
+			os.Exit(1)
+		}
+		switch {
+		case rd.Claimable:
+			fmt.Printf("%s is ready and can be claimed.\n", rd.BeadID)
+		case rd.Ready:
+			fmt.Printf("%s is ready but cannot be claimed:\n", rd.BeadID)
+		default:
+			fmt.Printf("%s is not ready:\n", rd.BeadID)
+		}
+		for _, r := range rd.Reasons {
+			fmt.Printf("  - %s\n", r.Message)
+		}
+		return nil
+	},
+}
@@ -25,20 +25,26 @@ const (
 
 // CreateBeadRequest contains the fields needed to create a new bead.
 type CreateBeadRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
-	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Notes         string                 `protobuf:"bytes,5,opt,name=notes,proto3" json:"notes,omitempty"`
-	Priority      int32                  `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
-	Assignee      string                 `protobuf:"bytes,7,opt,name=assignee,proto3" json:"assignee,omitempty"`
-	Owner         string                 `protobuf:"bytes,8,opt,name=owner,proto3" json:"owner,omitempty"`
-	DueAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=due_at,json=dueAt,proto3,oneof" json:"due_at,omitempty"`
-	DeferUntil    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=defer_until,json=deferUntil,proto3,oneof" json:"defer_until,omitempty"`
-	Fields        []byte                 `protobuf:"bytes,11,opt,name=fields,proto3" json:"fields,omitempty"`
-	Labels        []string               `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty"`
-	CreatedBy     string                 `protobuf:"bytes,13,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Kind        string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Type        string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Notes       string                 `protobuf:"bytes,5,opt,name=notes,proto3" json:"notes,omitempty"`
+	Priority    int32                  `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	Assignee    string                 `protobuf:"bytes,7,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Owner       string                 `protobuf:"bytes,8,opt,name=owner,proto3" json:"owner,omitempty"`
+	DueAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=due_at,json=dueAt,proto3,oneof" json:"due_at,omitempty"`
+	DeferUntil  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=defer_until,json=deferUntil,proto3,oneof" json:"defer_until,omitempty"`
+	Fields      []byte                 `protobuf:"bytes,11,opt,name=fields,proto3" json:"fields,omitempty"`
+	Labels      []string               `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty"`
+	CreatedBy   string                 `protobuf:"bytes,13,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// When set, a retry with the same key returns the bead the first request
+	// created instead of creating another.
+	IdempotencyKey string `protobuf:"bytes,14,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Points, such as "3", or a duration of effort, such as "4h".
+	Estimate      string `protobuf:"bytes,15,opt,name=estimate,proto3" json:"estimate,omitempty"`
+	Actual        string `protobuf:"bytes,16,opt,name=actual,proto3" json:"actual,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -164,6 +170,27 @@ func (x *CreateBeadRequest) GetCreatedBy() string {
 	return ""
 }
 
+func (x *CreateBeadRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateBeadRequest) GetEstimate() string {
+	if x != nil {
+		return x.Estimate
+	}
+	return ""
+}
+
+func (x *CreateBeadRequest) GetActual() string {
+	if x != nil {
+		return x.Actual
+	}
+	return ""
+}
+
 // CreateBeadResponse returns the newly created bead.
 type CreateBeadResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -301,18 +328,29 @@ func (x *GetBeadResponse) GetBead() *Bead {
 
 // ListBeadsRequest contains filter criteria for listing beads.
 type ListBeadsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        []string               `protobuf:"bytes,1,rep,name=status,proto3" json:"status,omitempty"`
-	Type          []string               `protobuf:"bytes,2,rep,name=type,proto3" json:"type,omitempty"`
-	Kind          []string               `protobuf:"bytes,3,rep,name=kind,proto3" json:"kind,omitempty"`
-	Priority      *wrapperspb.Int32Value `protobuf:"bytes,4,opt,name=priority,proto3" json:"priority,omitempty"`
-	Assignee      string                 `protobuf:"bytes,5,opt,name=assignee,proto3" json:"assignee,omitempty"`
-	Labels        []string               `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty"`
-	Search        string                 `protobuf:"bytes,7,opt,name=search,proto3" json:"search,omitempty"`
-	Limit         int32                  `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,9,opt,name=offset,proto3" json:"offset,omitempty"`
-	Sort          string                 `protobuf:"bytes,10,opt,name=sort,proto3" json:"sort,omitempty"`
-	FieldFilters  map[string]string      `protobuf:"bytes,11,rep,name=field_filters,json=fieldFilters,proto3" json:"field_filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Status          []string               `protobuf:"bytes,1,rep,name=status,proto3" json:"status,omitempty"`
+	Type            []string               `protobuf:"bytes,2,rep,name=type,proto3" json:"type,omitempty"`
+	Kind            []string               `protobuf:"bytes,3,rep,name=kind,proto3" json:"kind,omitempty"`
+	Priority        *wrapperspb.Int32Value `protobuf:"bytes,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	Assignee        string                 `protobuf:"bytes,5,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Labels          []string               `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty"`
+	Search          string                 `protobuf:"bytes,7,opt,name=search,proto3" json:"search,omitempty"`
+	Limit           int32                  `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset          int32                  `protobuf:"varint,9,opt,name=offset,proto3" json:"offset,omitempty"`
+	Sort            string                 `protobuf:"bytes,10,opt,name=sort,proto3" json:"sort,omitempty"`
+	FieldFilters    map[string]string      `protobuf:"bytes,11,rep,name=field_filters,json=fieldFilters,proto3" json:"field_filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	IncludeArchived bool                   `protobuf:"varint,12,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"` // archived beads are left out unless set
+	// Query language expression, ANDed with the other filters, e.g.
+	// "status:open AND (label:urgent OR priority<=1) AND updated>-7d".
+	Query string `protobuf:"bytes,13,opt,name=query,proto3" json:"query,omitempty"`
+	// Time ranges: an "after" bound is inclusive, a "before" bound exclusive.
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	UpdatedAfter  *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_after,json=updatedAfter,proto3" json:"updated_after,omitempty"`
+	UpdatedBefore *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`
+	ClosedAfter   *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=closed_after,json=closedAfter,proto3" json:"closed_after,omitempty"`
+	ClosedBefore  *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=closed_before,json=closedBefore,proto3" json:"closed_before,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -424,6 +462,62 @@ func (x *ListBeadsRequest) GetFieldFilters() map[string]string {
 	return nil
 }
 
+func (x *ListBeadsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+func (x *ListBeadsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListBeadsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListBeadsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListBeadsRequest) GetUpdatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAfter
+	}
+	return nil
+}
+
+func (x *ListBeadsRequest) GetUpdatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return nil
+}
+
+func (x *ListBeadsRequest) GetClosedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAfter
+	}
+	return nil
+}
+
+func (x *ListBeadsRequest) GetClosedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedBefore
+	}
+	return nil
+}
+
 // ListBeadsResponse returns a page of beads and the total count.
 type ListBeadsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -481,19 +575,28 @@ func (x *ListBeadsResponse) GetTotal() int32 {
 // Optional scalar fields use the optional keyword so the server can
 // distinguish between "not provided" and "set to zero/empty".
 type UpdateBeadRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
-	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	Notes         *string                `protobuf:"bytes,4,opt,name=notes,proto3,oneof" json:"notes,omitempty"`
-	Status        *string                `protobuf:"bytes,5,opt,name=status,proto3,oneof" json:"status,omitempty"`
-	Priority      *int32                 `protobuf:"varint,6,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
-	Assignee      *string                `protobuf:"bytes,7,opt,name=assignee,proto3,oneof" json:"assignee,omitempty"`
-	Owner         *string                `protobuf:"bytes,8,opt,name=owner,proto3,oneof" json:"owner,omitempty"`
-	DueAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=due_at,json=dueAt,proto3,oneof" json:"due_at,omitempty"`
-	DeferUntil    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=defer_until,json=deferUntil,proto3,oneof" json:"defer_until,omitempty"`
-	Fields        []byte                 `protobuf:"bytes,11,opt,name=fields,proto3,oneof" json:"fields,omitempty"`
-	Labels        []string               `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Notes       *string                `protobuf:"bytes,4,opt,name=notes,proto3,oneof" json:"notes,omitempty"`
+	Status      *string                `protobuf:"bytes,5,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	Priority    *int32                 `protobuf:"varint,6,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	Assignee    *string                `protobuf:"bytes,7,opt,name=assignee,proto3,oneof" json:"assignee,omitempty"`
+	Owner       *string                `protobuf:"bytes,8,opt,name=owner,proto3,oneof" json:"owner,omitempty"`
+	DueAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=due_at,json=dueAt,proto3,oneof" json:"due_at,omitempty"`
+	DeferUntil  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=defer_until,json=deferUntil,proto3,oneof" json:"defer_until,omitempty"`
+	Fields      []byte                 `protobuf:"bytes,11,opt,name=fields,proto3,oneof" json:"fields,omitempty"`
+	Labels      []string               `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty"`
+	// When set, description and notes are appended rather than replaced.
+	Append    bool   `protobuf:"varint,13,opt,name=append,proto3" json:"append,omitempty"`
+	UpdatedBy string `protobuf:"bytes,14,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	// Fields to clear: description, notes, assignee, owner, due_at,
+	// defer_until, estimate, actual or labels. A field may not be both set
+	// and cleared.
+	Clear         []string `protobuf:"bytes,15,rep,name=clear,proto3" json:"clear,omitempty"`
+	Estimate      *string  `protobuf:"bytes,16,opt,name=estimate,proto3,oneof" json:"estimate,omitempty"`
+	Actual        *string  `protobuf:"bytes,17,opt,name=actual,proto3,oneof" json:"actual,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -612,6 +715,41 @@ func (x *UpdateBeadRequest) GetLabels() []string {
 	return nil
 }
 
+func (x *UpdateBeadRequest) GetAppend() bool {
+	if x != nil {
+		return x.Append
+	}
+	return false
+}
+
+func (x *UpdateBeadRequest) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *UpdateBeadRequest) GetClear() []string {
+	if x != nil {
+		return x.Clear
+	}
+	return nil
+}
+
+func (x *UpdateBeadRequest) GetEstimate() string {
+	if x != nil && x.Estimate != nil {
+		return *x.Estimate
+	}
+	return ""
+}
+
+func (x *UpdateBeadRequest) GetActual() string {
+	if x != nil && x.Actual != nil {
+		return *x.Actual
+	}
+	return ""
+}
+
 // UpdateBeadResponse returns the updated bead.
 type UpdateBeadResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -657,11 +795,13 @@ func (x *UpdateBeadResponse) GetBead() *Bead {
 	return nil
 }
 
-// CloseBeadRequest marks a bead as closed.
+// CloseBeadRequest marks a bead as closed. With cascade, its unclosed
+// children that are not in progress are closed with it.
 type CloseBeadRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	ClosedBy      string                 `protobuf:"bytes,2,opt,name=closed_by,json=closedBy,proto3" json:"closed_by,omitempty"`
+	Cascade       bool                   `protobuf:"varint,3,opt,name=cascade,proto3" json:"cascade,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -710,10 +850,21 @@ func (x *CloseBeadRequest) GetClosedBy() string {
 	return ""
 }
 
-// CloseBeadResponse returns the closed bead.
+func (x *CloseBeadRequest) GetCascade() bool {
+	if x != nil {
+		return x.Cascade
+	}
+	return false
+}
+
+// CloseBeadResponse returns the closed bead, the dependents it unblocked,
+// the children closed with it and any ordering warnings.
 type CloseBeadResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Bead          *Bead                  `protobuf:"bytes,1,opt,name=bead,proto3" json:"bead,omitempty"`
+	Unblocked     []*Bead                `protobuf:"bytes,2,rep,name=unblocked,proto3" json:"unblocked,omitempty"`
+	Cascaded      []*Bead                `protobuf:"bytes,3,rep,name=cascaded,proto3" json:"cascaded,omitempty"`
+	Warnings      []string               `protobuf:"bytes,4,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -755,28 +906,51 @@ func (x *CloseBeadResponse) GetBead() *Bead {
 	return nil
 }
 
-// DeleteBeadRequest identifies a bead to delete.
-type DeleteBeadRequest struct {
+func (x *CloseBeadResponse) GetUnblocked() []*Bead {
+	if x != nil {
+		return x.Unblocked
+	}
+	return nil
+}
+
+func (x *CloseBeadResponse) GetCascaded() []*Bead {
+	if x != nil {
+		return x.Cascaded
+	}
+	return nil
+}
+
+func (x *CloseBeadResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// ResolveDecisionRequest records a decision's chosen option and closes it.
+type ResolveDecisionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Option        string                 `protobuf:"bytes,2,opt,name=option,proto3" json:"option,omitempty"`
+	ResolvedBy    string                 `protobuf:"bytes,3,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteBeadRequest) Reset() {
-	*x = DeleteBeadRequest{}
+func (x *ResolveDecisionRequest) Reset() {
+	*x = ResolveDecisionRequest{}
 	mi := &file_beads_v1_beads_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBeadRequest) String() string {
+func (x *ResolveDecisionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBeadRequest) ProtoMessage() {}
+func (*ResolveDecisionRequest) ProtoMessage() {}
 
-func (x *DeleteBeadRequest) ProtoReflect() protoreflect.Message {
+func (x *ResolveDecisionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_beads_v1_beads_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -788,39 +962,54 @@ func (x *DeleteBeadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBeadRequest.ProtoReflect.Descriptor instead.
-func (*DeleteBeadRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResolveDecisionRequest.ProtoReflect.Descriptor instead.
+func (*ResolveDecisionRequest) Descriptor() ([]byte, []int) {
 	return file_beads_v1_beads_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *DeleteBeadRequest) GetId() string {
+func (x *ResolveDecisionRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-// DeleteBeadResponse is empty on success.
-type DeleteBeadResponse struct {
+func (x *ResolveDecisionRequest) GetOption() string {
+	if x != nil {
+		return x.Option
+	}
+	return ""
+}
+
+func (x *ResolveDecisionRequest) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+// ResolveDecisionResponse returns the closed decision.
+type ResolveDecisionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bead          *Bead                  `protobuf:"bytes,1,opt,name=bead,proto3" json:"bead,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteBeadResponse) Reset() {
-	*x = DeleteBeadResponse{}
+func (x *ResolveDecisionResponse) Reset() {
+	*x = ResolveDecisionResponse{}
 	mi := &file_beads_v1_beads_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBeadResponse) String() string {
+func (x *ResolveDecisionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBeadResponse) ProtoMessage() {}
+func (*ResolveDecisionResponse) ProtoMessage() {}
 
-func (x *DeleteBeadResponse) ProtoReflect() protoreflect.Message {
+func (x *ResolveDecisionResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_beads_v1_beads_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -832,36 +1021,40 @@ func (x *DeleteBeadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBeadResponse.ProtoReflect.Descriptor instead.
-func (*DeleteBeadResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResolveDecisionResponse.ProtoReflect.Descriptor instead.
+func (*ResolveDecisionResponse) Descriptor() ([]byte, []int) {
 	return file_beads_v1_beads_proto_rawDescGZIP(), []int{11}
 }
 
-// AddDependencyRequest creates a dependency between two beads.
-type AddDependencyRequest struct {
+func (x *ResolveDecisionResponse) GetBead() *Bead {
+	if x != nil {
+		return x.Bead
+	}
+	return nil
+}
+
+// GetDecisionContextRequest identifies a decision.
+type GetDecisionContextRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
-	DependsOnId   string                 `protobuf:"bytes,2,opt,name=depends_on_id,json=dependsOnId,proto3" json:"depends_on_id,omitempty"`
-	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	CreatedBy     string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddDependencyRequest) Reset() {
-	*x = AddDependencyRequest{}
+func (x *GetDecisionContextRequest) Reset() {
+	*x = GetDecisionContextRequest{}
 	mi := &file_beads_v1_beads_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddDependencyRequest) String() string {
+func (x *GetDecisionContextRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddDependencyRequest) ProtoMessage() {}
+func (*GetDecisionContextRequest) ProtoMessage() {}
 
-func (x *AddDependencyRequest) ProtoReflect() protoreflect.Message {
+func (x *GetDecisionContextRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_beads_v1_beads_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -873,61 +1066,45 @@ func (x *AddDependencyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddDependencyRequest.ProtoReflect.Descriptor instead.
-func (*AddDependencyRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDecisionContextRequest.ProtoReflect.Descriptor instead.
+func (*GetDecisionContextRequest) Descriptor() ([]byte, []int) {
 	return file_beads_v1_beads_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *AddDependencyRequest) GetBeadId() string {
-	if x != nil {
-		return x.BeadId
-	}
-	return ""
-}
-
-func (x *AddDependencyRequest) GetDependsOnId() string {
-	if x != nil {
-		return x.DependsOnId
-	}
-	return ""
-}
-
-func (x *AddDependencyRequest) GetType() string {
-	if x != nil {
-		return x.Type
-	}
-	return ""
-}
-
-func (x *AddDependencyRequest) GetCreatedBy() string {
+func (x *GetDecisionContextRequest) GetId() string {
 	if x != nil {
-		return x.CreatedBy
+		return x.Id
 	}
 	return ""
 }
 
-// AddDependencyResponse returns the created dependency.
-type AddDependencyResponse struct {
+// GetDecisionContextResponse is everything needed to answer a decision.
+type GetDecisionContextResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Dependency    *Dependency            `protobuf:"bytes,1,opt,name=dependency,proto3" json:"dependency,omitempty"`
+	Decision      *Bead                  `protobuf:"bytes,1,opt,name=decision,proto3" json:"decision,omitempty"`
+	Options       []string               `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
+	Diff          string                 `protobuf:"bytes,3,opt,name=diff,proto3" json:"diff,omitempty"`
+	Links         []string               `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	Beads         []*BeadSummary         `protobuf:"bytes,5,rep,name=beads,proto3" json:"beads,omitempty"`
+	Missing       []string               `protobuf:"bytes,6,rep,name=missing,proto3" json:"missing,omitempty"` // linked IDs that no longer exist
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddDependencyResponse) Reset() {
-	*x = AddDependencyResponse{}
+func (x *GetDecisionContextResponse) Reset() {
+	*x = GetDecisionContextResponse{}
 	mi := &file_beads_v1_beads_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddDependencyResponse) String() string {
+func (x *GetDecisionContextResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddDependencyResponse) ProtoMessage() {}
+func (*GetDecisionContextResponse) ProtoMessage() {}
 
-func (x *AddDependencyResponse) ProtoReflect() protoreflect.Message {
+func (x *GetDecisionContextResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_beads_v1_beads_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -939,42 +1116,76 @@ func (x *AddDependencyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddDependencyResponse.ProtoReflect.Descriptor instead.
-func (*AddDependencyResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDecisionContextResponse.ProtoReflect.Descriptor instead.
+func (*GetDecisionContextResponse) Descriptor() ([]byte, []int) {
 	return file_beads_v1_beads_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *AddDependencyResponse) GetDependency() *Dependency {
+func (x *GetDecisionContextResponse) GetDecision() *Bead {
 	if x != nil {
-		return x.Dependency
+		return x.Decision
 	}
 	return nil
 }
 
-// RemoveDependencyRequest removes a dependency between two beads.
-type RemoveDependencyRequest struct {
+func (x *GetDecisionContextResponse) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *GetDecisionContextResponse) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+func (x *GetDecisionContextResponse) GetLinks() []string {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *GetDecisionContextResponse) GetBeads() []*BeadSummary {
+	if x != nil {
+		return x.Beads
+	}
+	return nil
+}
+
+func (x *GetDecisionContextResponse) GetMissing() []string {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+// ListBlockedBeadsResponse returns one page of blocked beads.
+type ListBlockedBeadsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
-	DependsOnId   string                 `protobuf:"bytes,2,opt,name=depends_on_id,json=dependsOnId,proto3" json:"depends_on_id,omitempty"`
-	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Beads         []*BlockedBead         `protobuf:"bytes,1,rep,name=beads,proto3" json:"beads,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveDependencyRequest) Reset() {
-	*x = RemoveDependencyRequest{}
+func (x *ListBlockedBeadsResponse) Reset() {
+	*x = ListBlockedBeadsResponse{}
 	mi := &file_beads_v1_beads_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveDependencyRequest) String() string {
+func (x *ListBlockedBeadsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveDependencyRequest) ProtoMessage() {}
+func (*ListBlockedBeadsResponse) ProtoMessage() {}
 
-func (x *RemoveDependencyRequest) ProtoReflect() protoreflect.Message {
+func (x *ListBlockedBeadsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_beads_v1_beads_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -986,53 +1197,49 @@ func (x *RemoveDependencyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveDependencyRequest.ProtoReflect.Descriptor instead.
-func (*RemoveDependencyRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListBlockedBeadsResponse.ProtoReflect.Descriptor instead.
+func (*ListBlockedBeadsResponse) Descriptor() ([]byte, []int) {
 	return file_beads_v1_beads_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *RemoveDependencyRequest) GetBeadId() string {
-	if x != nil {
-		return x.BeadId
-	}
-	return ""
-}
-
-func (x *RemoveDependencyRequest) GetDependsOnId() string {
+func (x *ListBlockedBeadsResponse) GetBeads() []*BlockedBead {
 	if x != nil {
-		return x.DependsOnId
+		return x.Beads
 	}
-	return ""
+	return nil
 }
 
-func (x *RemoveDependencyRequest) GetType() string {
+func (x *ListBlockedBeadsResponse) GetTotal() int32 {
 	if x != nil {
-		return x.Type
+		return x.Total
 	}
-	return ""
+	return 0
 }
 
-// RemoveDependencyResponse is empty on success.
-type RemoveDependencyResponse struct {
+// PopQueueRequest claims the most urgent ready bead for actor. When labels
+// is non-empty the bead must carry at least one of them.
+type PopQueueRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actor         string                 `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	Labels        []string               `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveDependencyResponse) Reset() {
-	*x = RemoveDependencyResponse{}
+func (x *PopQueueRequest) Reset() {
+	*x = PopQueueRequest{}
 	mi := &file_beads_v1_beads_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveDependencyResponse) String() string {
+func (x *PopQueueRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveDependencyResponse) ProtoMessage() {}
+func (*PopQueueRequest) ProtoMessage() {}
 
-func (x *RemoveDependencyResponse) ProtoReflect() protoreflect.Message {
+func (x *PopQueueRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_beads_v1_beads_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1044,33 +1251,48 @@ func (x *RemoveDependencyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveDependencyResponse.ProtoReflect.Descriptor instead.
-func (*RemoveDependencyResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PopQueueRequest.ProtoReflect.Descriptor instead.
+func (*PopQueueRequest) Descriptor() ([]byte, []int) {
 	return file_beads_v1_beads_proto_rawDescGZIP(), []int{15}
 }
 
-// GetDependenciesRequest retrieves dependencies for a bead.
-type GetDependenciesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PopQueueRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
 }
 
-func (x *GetDependenciesRequest) Reset() {
-	*x = GetDependenciesRequest{}
+func (x *PopQueueRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// PopQueueResponse returns the claimed bead, or no bead when the queue is
+// empty.
+type PopQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bead          *Bead                  `protobuf:"bytes,1,opt,name=bead,proto3" json:"bead,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PopQueueResponse) Reset() {
+	*x = PopQueueResponse{}
 	mi := &file_beads_v1_beads_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDependenciesRequest) String() string {
+func (x *PopQueueResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDependenciesRequest) ProtoMessage() {}
+func (*PopQueueResponse) ProtoMessage() {}
 
-func (x *GetDependenciesRequest) ProtoReflect() protoreflect.Message {
+func (x *PopQueueResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_beads_v1_beads_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1082,40 +1304,48 @@ func (x *GetDependenciesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDependenciesRequest.ProtoReflect.Descriptor instead.
-func (*GetDependenciesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PopQueueResponse.ProtoReflect.Descriptor instead.
+func (*PopQueueResponse) Descriptor() ([]byte, []int) {
 	return file_beads_v1_beads_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetDependenciesRequest) GetBeadId() string {
+func (x *PopQueueResponse) GetBead() *Bead {
 	if x != nil {
-		return x.BeadId
+		return x.Bead
 	}
-	return ""
+	return nil
 }
 
-// GetDependenciesResponse returns the list of dependencies.
-type GetDependenciesResponse struct {
+// DeleteBeadRequest identifies a bead to delete.
+// A bead that other beads depend on is only deleted when cascade is set:
+// "detach" removes the inbound dependencies, "delete" also deletes every
+// bead that (transitively) depends on it.
+// Deleted beads go to the trash and can be restored until purged; hard
+// deletes them permanently (and purges a bead already in the trash).
+type DeleteBeadRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Dependencies  []*Dependency          `protobuf:"bytes,1,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Cascade       string                 `protobuf:"bytes,2,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	Hard          bool                   `protobuf:"varint,3,opt,name=hard,proto3" json:"hard,omitempty"`
+	DeletedBy     string                 `protobuf:"bytes,4,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDependenciesResponse) Reset() {
-	*x = GetDependenciesResponse{}
+func (x *DeleteBeadRequest) Reset() {
+	*x = DeleteBeadRequest{}
 	mi := &file_beads_v1_beads_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDependenciesResponse) String() string {
+func (x *DeleteBeadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDependenciesResponse) ProtoMessage() {}
+func (*DeleteBeadRequest) ProtoMessage() {}
 
-func (x *GetDependenciesResponse) ProtoReflect() protoreflect.Message {
+func (x *DeleteBeadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_beads_v1_beads_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1127,41 +1357,62 @@ func (x *GetDependenciesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDependenciesResponse.ProtoReflect.Descriptor instead.
-func (*GetDependenciesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteBeadRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBeadRequest) Descriptor() ([]byte, []int) {
 	return file_beads_v1_beads_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetDependenciesResponse) GetDependencies() []*Dependency {
+func (x *DeleteBeadRequest) GetId() string {
 	if x != nil {
-		return x.Dependencies
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-// AddLabelRequest adds a label to a bead.
-type AddLabelRequest struct {
+func (x *DeleteBeadRequest) GetCascade() string {
+	if x != nil {
+		return x.Cascade
+	}
+	return ""
+}
+
+func (x *DeleteBeadRequest) GetHard() bool {
+	if x != nil {
+		return x.Hard
+	}
+	return false
+}
+
+func (x *DeleteBeadRequest) GetDeletedBy() string {
+	if x != nil {
+		return x.DeletedBy
+	}
+	return ""
+}
+
+// DeleteBeadResponse lists what the delete touched.
+type DeleteBeadResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
-	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	DeletedIds    []string               `protobuf:"bytes,1,rep,name=deleted_ids,json=deletedIds,proto3" json:"deleted_ids,omitempty"`
+	Detached      []*Dependency          `protobuf:"bytes,2,rep,name=detached,proto3" json:"detached,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddLabelRequest) Reset() {
-	*x = AddLabelRequest{}
+func (x *DeleteBeadResponse) Reset() {
+	*x = DeleteBeadResponse{}
 	mi := &file_beads_v1_beads_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddLabelRequest) String() string {
+func (x *DeleteBeadResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddLabelRequest) ProtoMessage() {}
+func (*DeleteBeadResponse) ProtoMessage() {}
 
-func (x *AddLabelRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteBeadResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_beads_v1_beads_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1173,47 +1424,49 @@ func (x *AddLabelRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddLabelRequest.ProtoReflect.Descriptor instead.
-func (*AddLabelRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteBeadResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBeadResponse) Descriptor() ([]byte, []int) {
 	return file_beads_v1_beads_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *AddLabelRequest) GetBeadId() string {
+func (x *DeleteBeadResponse) GetDeletedIds() []string {
 	if x != nil {
-		return x.BeadId
+		return x.DeletedIds
 	}
-	return ""
+	return nil
 }
 
-func (x *AddLabelRequest) GetLabel() string {
+func (x *DeleteBeadResponse) GetDetached() []*Dependency {
 	if x != nil {
-		return x.Label
+		return x.Detached
 	}
-	return ""
+	return nil
 }
 
-// AddLabelResponse returns the updated bead.
-type AddLabelResponse struct {
+// MergeBeadRequest merges bead id into bead into, closing id as a duplicate.
+type MergeBeadRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bead          *Bead                  `protobuf:"bytes,1,opt,name=bead,proto3" json:"bead,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Into          string                 `protobuf:"bytes,2,opt,name=into,proto3" json:"into,omitempty"`
+	MergedBy      string                 `protobuf:"bytes,3,opt,name=merged_by,json=mergedBy,proto3" json:"merged_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddLabelResponse) Reset() {
-	*x = AddLabelResponse{}
+func (x *MergeBeadRequest) Reset() {
+	*x = MergeBeadRequest{}
 	mi := &file_beads_v1_beads_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddLabelResponse) String() string {
+func (x *MergeBeadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddLabelResponse) ProtoMessage() {}
+func (*MergeBeadRequest) ProtoMessage() {}
 
-func (x *AddLabelResponse) ProtoReflect() protoreflect.Message {
+func (x *MergeBeadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_beads_v1_beads_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1225,41 +1478,55 @@ func (x *AddLabelResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddLabelResponse.ProtoReflect.Descriptor instead.
-func (*AddLabelResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MergeBeadRequest.ProtoReflect.Descriptor instead.
+func (*MergeBeadRequest) Descriptor() ([]byte, []int) {
 	return file_beads_v1_beads_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *AddLabelResponse) GetBead() *Bead {
+func (x *MergeBeadRequest) GetId() string {
 	if x != nil {
-		return x.Bead
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-// RemoveLabelRequest removes a label from a bead.
-type RemoveLabelRequest struct {
+func (x *MergeBeadRequest) GetInto() string {
+	if x != nil {
+		return x.Into
+	}
+	return ""
+}
+
+func (x *MergeBeadRequest) GetMergedBy() string {
+	if x != nil {
+		return x.MergedBy
+	}
+	return ""
+}
+
+// MergeBeadResponse returns the closed source and the updated target.
+type MergeBeadResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
-	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Source        *Bead                  `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Target        *Bead                  `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveLabelRequest) Reset() {
-	*x = RemoveLabelRequest{}
+func (x *MergeBeadResponse) Reset() {
+	*x = MergeBeadResponse{}
 	mi := &file_beads_v1_beads_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveLabelRequest) String() string {
+func (x *MergeBeadResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveLabelRequest) ProtoMessage() {}
+func (*MergeBeadResponse) ProtoMessage() {}
 
-func (x *RemoveLabelRequest) ProtoReflect() protoreflect.Message {
+func (x *MergeBeadResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_beads_v1_beads_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1271,47 +1538,3742 @@ func (x *RemoveLabelRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveLabelRequest.ProtoReflect.Descriptor instead.
-func (*RemoveLabelRequest) Descriptor() ([]byte, []int) {
-	return file_beads_v1_beads_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use MergeBeadResponse.ProtoReflect.Descriptor instead.
+func (*MergeBeadResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MergeBeadResponse) GetSource() *Bead {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *MergeBeadResponse) GetTarget() *Bead {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+// CloneBeadRequest creates a new open bead from bead id. Unset options
+// keep the source's value: title and assignee are copied, and labels,
+// custom fields and outgoing dependencies are copied unless turned off.
+type CloneBeadRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title            *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Assignee         *string                `protobuf:"bytes,3,opt,name=assignee,proto3,oneof" json:"assignee,omitempty"` // "" leaves the clone unassigned
+	CopyLabels       *bool                  `protobuf:"varint,4,opt,name=copy_labels,json=copyLabels,proto3,oneof" json:"copy_labels,omitempty"`
+	CopyFields       *bool                  `protobuf:"varint,5,opt,name=copy_fields,json=copyFields,proto3,oneof" json:"copy_fields,omitempty"`
+	CopyDependencies *bool                  `protobuf:"varint,6,opt,name=copy_dependencies,json=copyDependencies,proto3,oneof" json:"copy_dependencies,omitempty"`
+	ClonedBy         string                 `protobuf:"bytes,7,opt,name=cloned_by,json=clonedBy,proto3" json:"cloned_by,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CloneBeadRequest) Reset() {
+	*x = CloneBeadRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneBeadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneBeadRequest) ProtoMessage() {}
+
+func (x *CloneBeadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneBeadRequest.ProtoReflect.Descriptor instead.
+func (*CloneBeadRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CloneBeadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CloneBeadRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *CloneBeadRequest) GetAssignee() string {
+	if x != nil && x.Assignee != nil {
+		return *x.Assignee
+	}
+	return ""
+}
+
+func (x *CloneBeadRequest) GetCopyLabels() bool {
+	if x != nil && x.CopyLabels != nil {
+		return *x.CopyLabels
+	}
+	return false
+}
+
+func (x *CloneBeadRequest) GetCopyFields() bool {
+	if x != nil && x.CopyFields != nil {
+		return *x.CopyFields
+	}
+	return false
+}
+
+func (x *CloneBeadRequest) GetCopyDependencies() bool {
+	if x != nil && x.CopyDependencies != nil {
+		return *x.CopyDependencies
+	}
+	return false
+}
+
+func (x *CloneBeadRequest) GetClonedBy() string {
+	if x != nil {
+		return x.ClonedBy
+	}
+	return ""
+}
+
+// CloneBeadResponse returns the new bead.
+type CloneBeadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bead          *Bead                  `protobuf:"bytes,1,opt,name=bead,proto3" json:"bead,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneBeadResponse) Reset() {
+	*x = CloneBeadResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneBeadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneBeadResponse) ProtoMessage() {}
+
+func (x *CloneBeadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneBeadResponse.ProtoReflect.Descriptor instead.
+func (*CloneBeadResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CloneBeadResponse) GetBead() *Bead {
+	if x != nil {
+		return x.Bead
+	}
+	return nil
+}
+
+// FindSimilarBeadsRequest looks up open beads with titles like bead id's.
+type FindSimilarBeadsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSimilarBeadsRequest) Reset() {
+	*x = FindSimilarBeadsRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSimilarBeadsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarBeadsRequest) ProtoMessage() {}
+
+func (x *FindSimilarBeadsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarBeadsRequest.ProtoReflect.Descriptor instead.
+func (*FindSimilarBeadsRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *FindSimilarBeadsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FindSimilarBeadsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// FindSimilarBeadsResponse returns likely duplicates, best match first.
+type FindSimilarBeadsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Similar       []*SimilarBead         `protobuf:"bytes,1,rep,name=similar,proto3" json:"similar,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSimilarBeadsResponse) Reset() {
+	*x = FindSimilarBeadsResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSimilarBeadsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarBeadsResponse) ProtoMessage() {}
+
+func (x *FindSimilarBeadsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarBeadsResponse.ProtoReflect.Descriptor instead.
+func (*FindSimilarBeadsResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *FindSimilarBeadsResponse) GetSimilar() []*SimilarBead {
+	if x != nil {
+		return x.Similar
+	}
+	return nil
+}
+
+// WatchBeadRequest subscribes an actor to a bead's events.
+type WatchBeadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBeadRequest) Reset() {
+	*x = WatchBeadRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBeadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBeadRequest) ProtoMessage() {}
+
+func (x *WatchBeadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBeadRequest.ProtoReflect.Descriptor instead.
+func (*WatchBeadRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WatchBeadRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *WatchBeadRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+// WatchBeadResponse returns the bead's watchers.
+type WatchBeadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Watchers      []string               `protobuf:"bytes,1,rep,name=watchers,proto3" json:"watchers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBeadResponse) Reset() {
+	*x = WatchBeadResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBeadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBeadResponse) ProtoMessage() {}
+
+func (x *WatchBeadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBeadResponse.ProtoReflect.Descriptor instead.
+func (*WatchBeadResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WatchBeadResponse) GetWatchers() []string {
+	if x != nil {
+		return x.Watchers
+	}
+	return nil
+}
+
+// UnwatchBeadRequest unsubscribes an actor from a bead's events.
+type UnwatchBeadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnwatchBeadRequest) Reset() {
+	*x = UnwatchBeadRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnwatchBeadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnwatchBeadRequest) ProtoMessage() {}
+
+func (x *UnwatchBeadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnwatchBeadRequest.ProtoReflect.Descriptor instead.
+func (*UnwatchBeadRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UnwatchBeadRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *UnwatchBeadRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+// UnwatchBeadResponse returns the bead's remaining watchers.
+type UnwatchBeadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Watchers      []string               `protobuf:"bytes,1,rep,name=watchers,proto3" json:"watchers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnwatchBeadResponse) Reset() {
+	*x = UnwatchBeadResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnwatchBeadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnwatchBeadResponse) ProtoMessage() {}
+
+func (x *UnwatchBeadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnwatchBeadResponse.ProtoReflect.Descriptor instead.
+func (*UnwatchBeadResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UnwatchBeadResponse) GetWatchers() []string {
+	if x != nil {
+		return x.Watchers
+	}
+	return nil
+}
+
+// ListNotificationsRequest lists an actor's notifications, newest first.
+type ListNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actor         string                 `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	UnreadOnly    bool                   `protobuf:"varint,2,opt,name=unread_only,json=unreadOnly,proto3" json:"unread_only,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListNotificationsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetUnreadOnly() bool {
+	if x != nil {
+		return x.UnreadOnly
+	}
+	return false
+}
+
+func (x *ListNotificationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListNotificationsResponse returns the notifications.
+type ListNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+// MarkNotificationsReadRequest marks notifications read; empty ids marks all.
+type MarkNotificationsReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actor         string                 `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	Ids           []int64                `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkNotificationsReadRequest) Reset() {
+	*x = MarkNotificationsReadRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationsReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationsReadRequest) ProtoMessage() {}
+
+func (x *MarkNotificationsReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationsReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkNotificationsReadRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MarkNotificationsReadRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *MarkNotificationsReadRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// MarkNotificationsReadResponse returns how many notifications were marked.
+type MarkNotificationsReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Marked        int64                  `protobuf:"varint,1,opt,name=marked,proto3" json:"marked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkNotificationsReadResponse) Reset() {
+	*x = MarkNotificationsReadResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationsReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationsReadResponse) ProtoMessage() {}
+
+func (x *MarkNotificationsReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationsReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkNotificationsReadResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MarkNotificationsReadResponse) GetMarked() int64 {
+	if x != nil {
+		return x.Marked
+	}
+	return 0
+}
+
+// GetDigestRequest fetches the latest digest of a saved search subscription
+// ("subscription:<name>" config), generating one first if refresh is set.
+type GetDigestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Refresh       bool                   `protobuf:"varint,2,opt,name=refresh,proto3" json:"refresh,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDigestRequest) Reset() {
+	*x = GetDigestRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDigestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDigestRequest) ProtoMessage() {}
+
+func (x *GetDigestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDigestRequest.ProtoReflect.Descriptor instead.
+func (*GetDigestRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetDigestRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetDigestRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
+// GetDigestResponse lists the beads that started matching since the
+// previous digest.
+type GetDigestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  string                 `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	New           []*Bead                `protobuf:"bytes,4,rep,name=new,proto3" json:"new,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDigestResponse) Reset() {
+	*x = GetDigestResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDigestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDigestResponse) ProtoMessage() {}
+
+func (x *GetDigestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDigestResponse.ProtoReflect.Descriptor instead.
+func (*GetDigestResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetDigestResponse) GetSubscription() string {
+	if x != nil {
+		return x.Subscription
+	}
+	return ""
+}
+
+func (x *GetDigestResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+func (x *GetDigestResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetDigestResponse) GetNew() []*Bead {
+	if x != nil {
+		return x.New
+	}
+	return nil
+}
+
+// GetServerInfoRequest is an empty request for the server's version policy.
+type GetServerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoRequest) Reset() {
+	*x = GetServerInfoRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoRequest) ProtoMessage() {}
+
+func (x *GetServerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetServerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{35}
+}
+
+// GetServerInfoResponse advertises the server version, its API features and
+// the client versions it accepts and recommends.
+type GetServerInfoResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Version          string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	MinClientVersion string                 `protobuf:"bytes,2,opt,name=min_client_version,json=minClientVersion,proto3" json:"min_client_version,omitempty"` // empty when any client is accepted
+	ClientVersion    string                 `protobuf:"bytes,3,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`            // release `bd self-update` installs
+	ClientReleaseUrl string                 `protobuf:"bytes,4,opt,name=client_release_url,json=clientReleaseUrl,proto3" json:"client_release_url,omitempty"` // for old clients; bd uses its own release URL
+	Features         []string               `protobuf:"bytes,5,rep,name=features,proto3" json:"features,omitempty"`                                           // API features supported, as in GET /v1/version
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetServerInfoResponse) Reset() {
+	*x = GetServerInfoResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoResponse) ProtoMessage() {}
+
+func (x *GetServerInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetServerInfoResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetServerInfoResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetMinClientVersion() string {
+	if x != nil {
+		return x.MinClientVersion
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetClientReleaseUrl() string {
+	if x != nil {
+		return x.ClientReleaseUrl
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// ListGatesRequest lists an agent's gates. agent defaults to the caller.
+type ListGatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         string                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGatesRequest) Reset() {
+	*x = ListGatesRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGatesRequest) ProtoMessage() {}
+
+func (x *ListGatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGatesRequest.ProtoReflect.Descriptor instead.
+func (*ListGatesRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListGatesRequest) GetAgent() string {
+	if x != nil {
+		return x.Agent
+	}
+	return ""
+}
+
+// ListGatesResponse returns the agent's role and gate checklist.
+type ListGatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         string                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Gates         []*Gate                `protobuf:"bytes,3,rep,name=gates,proto3" json:"gates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGatesResponse) Reset() {
+	*x = ListGatesResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGatesResponse) ProtoMessage() {}
+
+func (x *ListGatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGatesResponse.ProtoReflect.Descriptor instead.
+func (*ListGatesResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListGatesResponse) GetAgent() string {
+	if x != nil {
+		return x.Agent
+	}
+	return ""
+}
+
+func (x *ListGatesResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListGatesResponse) GetGates() []*Gate {
+	if x != nil {
+		return x.Gates
+	}
+	return nil
+}
+
+// ListAgentsRequest is an empty request for the agent roster.
+type ListAgentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsRequest) ProtoMessage() {}
+
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{39}
+}
+
+// ListAgentsResponse returns every registered agent in name order.
+type ListAgentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agents        []*Agent               `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsResponse) Reset() {
+	*x = ListAgentsResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsResponse) ProtoMessage() {}
+
+func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListAgentsResponse) GetAgents() []*Agent {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+// SetGateRequest marks an agent's gate satisfied or unsatisfied.
+type SetGateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         string                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	Gate          string                 `protobuf:"bytes,2,opt,name=gate,proto3" json:"gate,omitempty"`
+	Satisfied     bool                   `protobuf:"varint,3,opt,name=satisfied,proto3" json:"satisfied,omitempty"`
+	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetGateRequest) Reset() {
+	*x = SetGateRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetGateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetGateRequest) ProtoMessage() {}
+
+func (x *SetGateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetGateRequest.ProtoReflect.Descriptor instead.
+func (*SetGateRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SetGateRequest) GetAgent() string {
+	if x != nil {
+		return x.Agent
+	}
+	return ""
+}
+
+func (x *SetGateRequest) GetGate() string {
+	if x != nil {
+		return x.Gate
+	}
+	return ""
+}
+
+func (x *SetGateRequest) GetSatisfied() bool {
+	if x != nil {
+		return x.Satisfied
+	}
+	return false
+}
+
+func (x *SetGateRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+// SetGateResponse returns the gate's new state.
+type SetGateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gate          *Gate                  `protobuf:"bytes,1,opt,name=gate,proto3" json:"gate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetGateResponse) Reset() {
+	*x = SetGateResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetGateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetGateResponse) ProtoMessage() {}
+
+func (x *SetGateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetGateResponse.ProtoReflect.Descriptor instead.
+func (*SetGateResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SetGateResponse) GetGate() *Gate {
+	if x != nil {
+		return x.Gate
+	}
+	return nil
+}
+
+// WaiveGateRequest suppresses an agent's gate for ttl.
+type WaiveGateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         string                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	Gate          string                 `protobuf:"bytes,2,opt,name=gate,proto3" json:"gate,omitempty"`
+	Ttl           string                 `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"` // Go duration, e.g. "2h"; at most a week
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaiveGateRequest) Reset() {
+	*x = WaiveGateRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaiveGateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaiveGateRequest) ProtoMessage() {}
+
+func (x *WaiveGateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaiveGateRequest.ProtoReflect.Descriptor instead.
+func (*WaiveGateRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *WaiveGateRequest) GetAgent() string {
+	if x != nil {
+		return x.Agent
+	}
+	return ""
+}
+
+func (x *WaiveGateRequest) GetGate() string {
+	if x != nil {
+		return x.Gate
+	}
+	return ""
+}
+
+func (x *WaiveGateRequest) GetTtl() string {
+	if x != nil {
+		return x.Ttl
+	}
+	return ""
+}
+
+func (x *WaiveGateRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *WaiveGateRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+// WaiveGateResponse returns the gate's new state.
+type WaiveGateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gate          *Gate                  `protobuf:"bytes,1,opt,name=gate,proto3" json:"gate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaiveGateResponse) Reset() {
+	*x = WaiveGateResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaiveGateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaiveGateResponse) ProtoMessage() {}
+
+func (x *WaiveGateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaiveGateResponse.ProtoReflect.Descriptor instead.
+func (*WaiveGateResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *WaiveGateResponse) GetGate() *Gate {
+	if x != nil {
+		return x.Gate
+	}
+	return nil
+}
+
+// EmitHookRequest evaluates the gates of an agent that apply to a hook.
+type EmitHookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         string                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	Hook          string                 `protobuf:"bytes,2,opt,name=hook,proto3" json:"hook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmitHookRequest) Reset() {
+	*x = EmitHookRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmitHookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmitHookRequest) ProtoMessage() {}
+
+func (x *EmitHookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmitHookRequest.ProtoReflect.Descriptor instead.
+func (*EmitHookRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *EmitHookRequest) GetAgent() string {
+	if x != nil {
+		return x.Agent
+	}
+	return ""
+}
+
+func (x *EmitHookRequest) GetHook() string {
+	if x != nil {
+		return x.Hook
+	}
+	return ""
+}
+
+// EmitHookResponse is the hook decision: "allow", "warn" or "block".
+type EmitHookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         string                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Decision      string                 `protobuf:"bytes,3,opt,name=decision,proto3" json:"decision,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Gates         []*Gate                `protobuf:"bytes,5,rep,name=gates,proto3" json:"gates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmitHookResponse) Reset() {
+	*x = EmitHookResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmitHookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmitHookResponse) ProtoMessage() {}
+
+func (x *EmitHookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmitHookResponse.ProtoReflect.Descriptor instead.
+func (*EmitHookResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *EmitHookResponse) GetAgent() string {
+	if x != nil {
+		return x.Agent
+	}
+	return ""
+}
+
+func (x *EmitHookResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *EmitHookResponse) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *EmitHookResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EmitHookResponse) GetGates() []*Gate {
+	if x != nil {
+		return x.Gates
+	}
+	return nil
+}
+
+// ListAdviceRequest lists open advice beads for an actor. Unless all is set,
+// advice the actor has acknowledged and expired advice are left out.
+type ListAdviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actor         string                 `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	All           bool                   `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAdviceRequest) Reset() {
+	*x = ListAdviceRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAdviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAdviceRequest) ProtoMessage() {}
+
+func (x *ListAdviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAdviceRequest.ProtoReflect.Descriptor instead.
+func (*ListAdviceRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListAdviceRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListAdviceRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+// ListAdviceResponse returns the matching advice beads.
+type ListAdviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Advice        []*Bead                `protobuf:"bytes,1,rep,name=advice,proto3" json:"advice,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAdviceResponse) Reset() {
+	*x = ListAdviceResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAdviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAdviceResponse) ProtoMessage() {}
+
+func (x *ListAdviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAdviceResponse.ProtoReflect.Descriptor instead.
+func (*ListAdviceResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListAdviceResponse) GetAdvice() []*Bead {
+	if x != nil {
+		return x.Advice
+	}
+	return nil
+}
+
+// AckAdviceRequest records that an actor has read an advice bead.
+type AckAdviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckAdviceRequest) Reset() {
+	*x = AckAdviceRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckAdviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckAdviceRequest) ProtoMessage() {}
+
+func (x *AckAdviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckAdviceRequest.ProtoReflect.Descriptor instead.
+func (*AckAdviceRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *AckAdviceRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *AckAdviceRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+// AckAdviceResponse is empty.
+type AckAdviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckAdviceResponse) Reset() {
+	*x = AckAdviceResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckAdviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckAdviceResponse) ProtoMessage() {}
+
+func (x *AckAdviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckAdviceResponse.ProtoReflect.Descriptor instead.
+func (*AckAdviceResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{50}
+}
+
+// RegisterAgentRequest provisions an agent bead, gates, and a bearer token.
+// The call must carry the admin or bootstrap token as a bearer token.
+type RegisterAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Subscriptions []string               `protobuf:"bytes,2,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	Gates         []string               `protobuf:"bytes,3,rep,name=gates,proto3" json:"gates,omitempty"`
+	Labels        []string               `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterAgentRequest) Reset() {
+	*x = RegisterAgentRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterAgentRequest) ProtoMessage() {}
+
+func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterAgentRequest.ProtoReflect.Descriptor instead.
+func (*RegisterAgentRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RegisterAgentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterAgentRequest) GetSubscriptions() []string {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+func (x *RegisterAgentRequest) GetGates() []string {
+	if x != nil {
+		return x.Gates
+	}
+	return nil
+}
+
+func (x *RegisterAgentRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *RegisterAgentRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+// RegisterAgentResponse returns the agent's credentials. The token is only
+// ever returned here.
+type RegisterAgentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         *Bead                  `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	Gates         []*Bead                `protobuf:"bytes,2,rep,name=gates,proto3" json:"gates,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	Env           map[string]string      `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Exports       string                 `protobuf:"bytes,5,opt,name=exports,proto3" json:"exports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterAgentResponse) Reset() {
+	*x = RegisterAgentResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterAgentResponse) ProtoMessage() {}
+
+func (x *RegisterAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterAgentResponse.ProtoReflect.Descriptor instead.
+func (*RegisterAgentResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *RegisterAgentResponse) GetAgent() *Bead {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+func (x *RegisterAgentResponse) GetGates() []*Bead {
+	if x != nil {
+		return x.Gates
+	}
+	return nil
+}
+
+func (x *RegisterAgentResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RegisterAgentResponse) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *RegisterAgentResponse) GetExports() string {
+	if x != nil {
+		return x.Exports
+	}
+	return ""
+}
+
+// AddDependencyRequest creates a dependency between two beads.
+type AddDependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	DependsOnId   string                 `protobuf:"bytes,2,opt,name=depends_on_id,json=dependsOnId,proto3" json:"depends_on_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Metadata      string                 `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"` // JSON object
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDependencyRequest) Reset() {
+	*x = AddDependencyRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDependencyRequest) ProtoMessage() {}
+
+func (x *AddDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDependencyRequest.ProtoReflect.Descriptor instead.
+func (*AddDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *AddDependencyRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *AddDependencyRequest) GetDependsOnId() string {
+	if x != nil {
+		return x.DependsOnId
+	}
+	return ""
+}
+
+func (x *AddDependencyRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AddDependencyRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *AddDependencyRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+// AddDependencyResponse returns the created dependency.
+type AddDependencyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dependency    *Dependency            `protobuf:"bytes,1,opt,name=dependency,proto3" json:"dependency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDependencyResponse) Reset() {
+	*x = AddDependencyResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDependencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDependencyResponse) ProtoMessage() {}
+
+func (x *AddDependencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDependencyResponse.ProtoReflect.Descriptor instead.
+func (*AddDependencyResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *AddDependencyResponse) GetDependency() *Dependency {
+	if x != nil {
+		return x.Dependency
+	}
+	return nil
+}
+
+// UpdateDependencyRequest replaces the metadata of a dependency.
+type UpdateDependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	DependsOnId   string                 `protobuf:"bytes,2,opt,name=depends_on_id,json=dependsOnId,proto3" json:"depends_on_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Metadata      string                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"` // JSON object; empty clears it
+	UpdatedBy     string                 `protobuf:"bytes,5,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDependencyRequest) Reset() {
+	*x = UpdateDependencyRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDependencyRequest) ProtoMessage() {}
+
+func (x *UpdateDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDependencyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *UpdateDependencyRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *UpdateDependencyRequest) GetDependsOnId() string {
+	if x != nil {
+		return x.DependsOnId
+	}
+	return ""
+}
+
+func (x *UpdateDependencyRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateDependencyRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *UpdateDependencyRequest) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+// UpdateDependencyResponse returns the updated dependency.
+type UpdateDependencyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dependency    *Dependency            `protobuf:"bytes,1,opt,name=dependency,proto3" json:"dependency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDependencyResponse) Reset() {
+	*x = UpdateDependencyResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDependencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDependencyResponse) ProtoMessage() {}
+
+func (x *UpdateDependencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDependencyResponse.ProtoReflect.Descriptor instead.
+func (*UpdateDependencyResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *UpdateDependencyResponse) GetDependency() *Dependency {
+	if x != nil {
+		return x.Dependency
+	}
+	return nil
+}
+
+// RemoveDependencyRequest removes a dependency between two beads.
+type RemoveDependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	DependsOnId   string                 `protobuf:"bytes,2,opt,name=depends_on_id,json=dependsOnId,proto3" json:"depends_on_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDependencyRequest) Reset() {
+	*x = RemoveDependencyRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDependencyRequest) ProtoMessage() {}
+
+func (x *RemoveDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDependencyRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RemoveDependencyRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *RemoveDependencyRequest) GetDependsOnId() string {
+	if x != nil {
+		return x.DependsOnId
+	}
+	return ""
+}
+
+func (x *RemoveDependencyRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// RemoveDependencyResponse is empty on success.
+type RemoveDependencyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDependencyResponse) Reset() {
+	*x = RemoveDependencyResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDependencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDependencyResponse) ProtoMessage() {}
+
+func (x *RemoveDependencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDependencyResponse.ProtoReflect.Descriptor instead.
+func (*RemoveDependencyResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{58}
+}
+
+// GetDependenciesRequest retrieves dependencies for a bead.
+type GetDependenciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDependenciesRequest) Reset() {
+	*x = GetDependenciesRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDependenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDependenciesRequest) ProtoMessage() {}
+
+func (x *GetDependenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDependenciesRequest.ProtoReflect.Descriptor instead.
+func (*GetDependenciesRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetDependenciesRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+// GetDependenciesResponse returns the list of dependencies.
+type GetDependenciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dependencies  []*Dependency          `protobuf:"bytes,1,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDependenciesResponse) Reset() {
+	*x = GetDependenciesResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDependenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDependenciesResponse) ProtoMessage() {}
+
+func (x *GetDependenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDependenciesResponse.ProtoReflect.Descriptor instead.
+func (*GetDependenciesResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetDependenciesResponse) GetDependencies() []*Dependency {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+// AddRelationRequest relates bead_id to other_id with a non-blocking
+// relation type: duplicate-of, relates-to, caused-by or fixed-by.
+type AddRelationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	OtherId       string                 `protobuf:"bytes,2,opt,name=other_id,json=otherId,proto3" json:"other_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddRelationRequest) Reset() {
+	*x = AddRelationRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddRelationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRelationRequest) ProtoMessage() {}
+
+func (x *AddRelationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRelationRequest.ProtoReflect.Descriptor instead.
+func (*AddRelationRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *AddRelationRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *AddRelationRequest) GetOtherId() string {
+	if x != nil {
+		return x.OtherId
+	}
+	return ""
+}
+
+func (x *AddRelationRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AddRelationRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+// AddRelationResponse returns the relation as a dependency of bead_id.
+type AddRelationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dependency    *Dependency            `protobuf:"bytes,1,opt,name=dependency,proto3" json:"dependency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddRelationResponse) Reset() {
+	*x = AddRelationResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddRelationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRelationResponse) ProtoMessage() {}
+
+func (x *AddRelationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRelationResponse.ProtoReflect.Descriptor instead.
+func (*AddRelationResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *AddRelationResponse) GetDependency() *Dependency {
+	if x != nil {
+		return x.Dependency
+	}
+	return nil
+}
+
+// ListRelationsRequest lists the relations of a bead in both directions.
+type ListRelationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelationsRequest) Reset() {
+	*x = ListRelationsRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelationsRequest) ProtoMessage() {}
+
+func (x *ListRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelationsRequest.ProtoReflect.Descriptor instead.
+func (*ListRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListRelationsRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+// ListRelationsResponse returns the relations, outgoing first.
+type ListRelationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relations     []*Relation            `protobuf:"bytes,1,rep,name=relations,proto3" json:"relations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelationsResponse) Reset() {
+	*x = ListRelationsResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelationsResponse) ProtoMessage() {}
+
+func (x *ListRelationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelationsResponse.ProtoReflect.Descriptor instead.
+func (*ListRelationsResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListRelationsResponse) GetRelations() []*Relation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+// AddExternalDepRequest makes bead_id wait on url. A probe, if set, lets
+// the server mark it satisfied once the resource is ready.
+type AddExternalDepRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Probe         string                 `protobuf:"bytes,4,opt,name=probe,proto3" json:"probe,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddExternalDepRequest) Reset() {
+	*x = AddExternalDepRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddExternalDepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddExternalDepRequest) ProtoMessage() {}
+
+func (x *AddExternalDepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddExternalDepRequest.ProtoReflect.Descriptor instead.
+func (*AddExternalDepRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *AddExternalDepRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *AddExternalDepRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *AddExternalDepRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AddExternalDepRequest) GetProbe() string {
+	if x != nil {
+		return x.Probe
+	}
+	return ""
+}
+
+func (x *AddExternalDepRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+// AddExternalDepResponse returns the new external dependency.
+type AddExternalDepResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dep           *ExternalDep           `protobuf:"bytes,1,opt,name=dep,proto3" json:"dep,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddExternalDepResponse) Reset() {
+	*x = AddExternalDepResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddExternalDepResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddExternalDepResponse) ProtoMessage() {}
+
+func (x *AddExternalDepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddExternalDepResponse.ProtoReflect.Descriptor instead.
+func (*AddExternalDepResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *AddExternalDepResponse) GetDep() *ExternalDep {
+	if x != nil {
+		return x.Dep
+	}
+	return nil
+}
+
+// ListExternalDepsRequest lists a bead's external dependencies.
+type ListExternalDepsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExternalDepsRequest) Reset() {
+	*x = ListExternalDepsRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExternalDepsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExternalDepsRequest) ProtoMessage() {}
+
+func (x *ListExternalDepsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExternalDepsRequest.ProtoReflect.Descriptor instead.
+func (*ListExternalDepsRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListExternalDepsRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+// ListExternalDepsResponse returns them in the order they were added.
+type ListExternalDepsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deps          []*ExternalDep         `protobuf:"bytes,1,rep,name=deps,proto3" json:"deps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExternalDepsResponse) Reset() {
+	*x = ListExternalDepsResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExternalDepsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExternalDepsResponse) ProtoMessage() {}
+
+func (x *ListExternalDepsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExternalDepsResponse.ProtoReflect.Descriptor instead.
+func (*ListExternalDepsResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ListExternalDepsResponse) GetDeps() []*ExternalDep {
+	if x != nil {
+		return x.Deps
+	}
+	return nil
+}
+
+// UpdateExternalDepRequest sets an external dependency's status to
+// "waiting" or "satisfied".
+type UpdateExternalDepRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,4,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateExternalDepRequest) Reset() {
+	*x = UpdateExternalDepRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateExternalDepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateExternalDepRequest) ProtoMessage() {}
+
+func (x *UpdateExternalDepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateExternalDepRequest.ProtoReflect.Descriptor instead.
+func (*UpdateExternalDepRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *UpdateExternalDepRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *UpdateExternalDepRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateExternalDepRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateExternalDepRequest) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+// UpdateExternalDepResponse returns the updated external dependency.
+type UpdateExternalDepResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dep           *ExternalDep           `protobuf:"bytes,1,opt,name=dep,proto3" json:"dep,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateExternalDepResponse) Reset() {
+	*x = UpdateExternalDepResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateExternalDepResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateExternalDepResponse) ProtoMessage() {}
+
+func (x *UpdateExternalDepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateExternalDepResponse.ProtoReflect.Descriptor instead.
+func (*UpdateExternalDepResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *UpdateExternalDepResponse) GetDep() *ExternalDep {
+	if x != nil {
+		return x.Dep
+	}
+	return nil
+}
+
+// RemoveExternalDepRequest removes an external dependency.
+type RemoveExternalDepRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	RemovedBy     string                 `protobuf:"bytes,3,opt,name=removed_by,json=removedBy,proto3" json:"removed_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveExternalDepRequest) Reset() {
+	*x = RemoveExternalDepRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveExternalDepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveExternalDepRequest) ProtoMessage() {}
+
+func (x *RemoveExternalDepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveExternalDepRequest.ProtoReflect.Descriptor instead.
+func (*RemoveExternalDepRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *RemoveExternalDepRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *RemoveExternalDepRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RemoveExternalDepRequest) GetRemovedBy() string {
+	if x != nil {
+		return x.RemovedBy
+	}
+	return ""
+}
+
+// RemoveExternalDepResponse is empty on success.
+type RemoveExternalDepResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveExternalDepResponse) Reset() {
+	*x = RemoveExternalDepResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveExternalDepResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveExternalDepResponse) ProtoMessage() {}
+
+func (x *RemoveExternalDepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveExternalDepResponse.ProtoReflect.Descriptor instead.
+func (*RemoveExternalDepResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{72}
+}
+
+// LinkCommitRequest links a git commit to bead_id. Linking the same repo
+// and sha again returns the existing link.
+type LinkCommitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Repo          string                 `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
+	Sha           string                 `protobuf:"bytes,3,opt,name=sha,proto3" json:"sha,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Author        string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	LinkedBy      string                 `protobuf:"bytes,6,opt,name=linked_by,json=linkedBy,proto3" json:"linked_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkCommitRequest) Reset() {
+	*x = LinkCommitRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkCommitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkCommitRequest) ProtoMessage() {}
+
+func (x *LinkCommitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkCommitRequest.ProtoReflect.Descriptor instead.
+func (*LinkCommitRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *LinkCommitRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *LinkCommitRequest) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *LinkCommitRequest) GetSha() string {
+	if x != nil {
+		return x.Sha
+	}
+	return ""
+}
+
+func (x *LinkCommitRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LinkCommitRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *LinkCommitRequest) GetLinkedBy() string {
+	if x != nil {
+		return x.LinkedBy
+	}
+	return ""
+}
+
+// LinkCommitResponse returns the link; created is false if it existed.
+type LinkCommitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Commit        *Commit                `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkCommitResponse) Reset() {
+	*x = LinkCommitResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkCommitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkCommitResponse) ProtoMessage() {}
+
+func (x *LinkCommitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkCommitResponse.ProtoReflect.Descriptor instead.
+func (*LinkCommitResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *LinkCommitResponse) GetCommit() *Commit {
+	if x != nil {
+		return x.Commit
+	}
+	return nil
+}
+
+func (x *LinkCommitResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+// ListCommitsRequest lists the commits linked to a bead.
+type ListCommitsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommitsRequest) Reset() {
+	*x = ListCommitsRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommitsRequest) ProtoMessage() {}
+
+func (x *ListCommitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommitsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommitsRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ListCommitsRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+// ListCommitsResponse returns them newest first.
+type ListCommitsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Commits       []*Commit              `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommitsResponse) Reset() {
+	*x = ListCommitsResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommitsResponse) ProtoMessage() {}
+
+func (x *ListCommitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommitsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommitsResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ListCommitsResponse) GetCommits() []*Commit {
+	if x != nil {
+		return x.Commits
+	}
+	return nil
+}
+
+// AddLabelRequest adds a label to a bead.
+type AddLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddLabelRequest) Reset() {
+	*x = AddLabelRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddLabelRequest) ProtoMessage() {}
+
+func (x *AddLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddLabelRequest.ProtoReflect.Descriptor instead.
+func (*AddLabelRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *AddLabelRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *AddLabelRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// AddLabelResponse returns the updated bead.
+type AddLabelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bead          *Bead                  `protobuf:"bytes,1,opt,name=bead,proto3" json:"bead,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddLabelResponse) Reset() {
+	*x = AddLabelResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddLabelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddLabelResponse) ProtoMessage() {}
+
+func (x *AddLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddLabelResponse.ProtoReflect.Descriptor instead.
+func (*AddLabelResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *AddLabelResponse) GetBead() *Bead {
+	if x != nil {
+		return x.Bead
+	}
+	return nil
+}
+
+// RemoveLabelRequest removes a label from a bead.
+type RemoveLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveLabelRequest) Reset() {
+	*x = RemoveLabelRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveLabelRequest) ProtoMessage() {}
+
+func (x *RemoveLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveLabelRequest.ProtoReflect.Descriptor instead.
+func (*RemoveLabelRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *RemoveLabelRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *RemoveLabelRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// RemoveLabelResponse is empty on success.
+type RemoveLabelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveLabelResponse) Reset() {
+	*x = RemoveLabelResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveLabelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveLabelResponse) ProtoMessage() {}
+
+func (x *RemoveLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveLabelResponse.ProtoReflect.Descriptor instead.
+func (*RemoveLabelResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{80}
+}
+
+// GetLabelsRequest retrieves labels for a bead.
+type GetLabelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLabelsRequest) Reset() {
+	*x = GetLabelsRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLabelsRequest) ProtoMessage() {}
+
+func (x *GetLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLabelsRequest.ProtoReflect.Descriptor instead.
+func (*GetLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *GetLabelsRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+// GetLabelsResponse returns the list of labels.
+type GetLabelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Labels        []string               `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLabelsResponse) Reset() {
+	*x = GetLabelsResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLabelsResponse) ProtoMessage() {}
+
+func (x *GetLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLabelsResponse.ProtoReflect.Descriptor instead.
+func (*GetLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetLabelsResponse) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// AddAliasRequest gives a bead a hand-picked alias. Aliases must be unique
+// and may not shadow a bead ID or slug.
+type AddAliasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Alias         string                 `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddAliasRequest) Reset() {
+	*x = AddAliasRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddAliasRequest) ProtoMessage() {}
+
+func (x *AddAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddAliasRequest.ProtoReflect.Descriptor instead.
+func (*AddAliasRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *AddAliasRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *AddAliasRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *AddAliasRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+// AddAliasResponse returns the created alias.
+type AddAliasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alias         *Alias                 `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddAliasResponse) Reset() {
+	*x = AddAliasResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddAliasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddAliasResponse) ProtoMessage() {}
+
+func (x *AddAliasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddAliasResponse.ProtoReflect.Descriptor instead.
+func (*AddAliasResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *AddAliasResponse) GetAlias() *Alias {
+	if x != nil {
+		return x.Alias
+	}
+	return nil
+}
+
+// RemoveAliasRequest removes one of a bead's aliases.
+type RemoveAliasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Alias         string                 `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveAliasRequest) Reset() {
+	*x = RemoveAliasRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAliasRequest) ProtoMessage() {}
+
+func (x *RemoveAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveAliasRequest.ProtoReflect.Descriptor instead.
+func (*RemoveAliasRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *RemoveAliasRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *RemoveAliasRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+// RemoveAliasResponse is empty on success.
+type RemoveAliasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveAliasResponse) Reset() {
+	*x = RemoveAliasResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveAliasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAliasResponse) ProtoMessage() {}
+
+func (x *RemoveAliasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveAliasResponse.ProtoReflect.Descriptor instead.
+func (*RemoveAliasResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{86}
+}
+
+// ListAliasesRequest lists a bead's aliases.
+type ListAliasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAliasesRequest) Reset() {
+	*x = ListAliasesRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAliasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAliasesRequest) ProtoMessage() {}
+
+func (x *ListAliasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAliasesRequest.ProtoReflect.Descriptor instead.
+func (*ListAliasesRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ListAliasesRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+// ListAliasesResponse returns the aliases in alphabetical order.
+type ListAliasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Aliases       []*Alias               `protobuf:"bytes,1,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAliasesResponse) Reset() {
+	*x = ListAliasesResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAliasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAliasesResponse) ProtoMessage() {}
+
+func (x *ListAliasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAliasesResponse.ProtoReflect.Descriptor instead.
+func (*ListAliasesResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ListAliasesResponse) GetAliases() []*Alias {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+// AddCommentRequest adds a comment to a bead.
+type AddCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentRequest) Reset() {
+	*x = AddCommentRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentRequest) ProtoMessage() {}
+
+func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *AddCommentRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
 }
 
-func (x *RemoveLabelRequest) GetBeadId() string {
+func (x *AddCommentRequest) GetAuthor() string {
 	if x != nil {
-		return x.BeadId
+		return x.Author
 	}
 	return ""
 }
 
-func (x *RemoveLabelRequest) GetLabel() string {
+func (x *AddCommentRequest) GetText() string {
 	if x != nil {
-		return x.Label
+		return x.Text
 	}
 	return ""
 }
 
-// RemoveLabelResponse is empty on success.
-type RemoveLabelResponse struct {
+// AddCommentResponse returns the created comment.
+type AddCommentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveLabelResponse) Reset() {
-	*x = RemoveLabelResponse{}
-	mi := &file_beads_v1_beads_proto_msgTypes[21]
+func (x *AddCommentResponse) Reset() {
+	*x = AddCommentResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveLabelResponse) String() string {
+func (x *AddCommentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveLabelResponse) ProtoMessage() {}
+func (*AddCommentResponse) ProtoMessage() {}
 
-func (x *RemoveLabelResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_beads_proto_msgTypes[21]
+func (x *AddCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1322,34 +5284,41 @@ func (x *RemoveLabelResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveLabelResponse.ProtoReflect.Descriptor instead.
-func (*RemoveLabelResponse) Descriptor() ([]byte, []int) {
-	return file_beads_v1_beads_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use AddCommentResponse.ProtoReflect.Descriptor instead.
+func (*AddCommentResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{90}
 }
 
-// GetLabelsRequest retrieves labels for a bead.
-type GetLabelsRequest struct {
+func (x *AddCommentResponse) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+// GetCommentsRequest retrieves comments for a bead.
+type GetCommentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLabelsRequest) Reset() {
-	*x = GetLabelsRequest{}
-	mi := &file_beads_v1_beads_proto_msgTypes[22]
+func (x *GetCommentsRequest) Reset() {
+	*x = GetCommentsRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLabelsRequest) String() string {
+func (x *GetCommentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLabelsRequest) ProtoMessage() {}
+func (*GetCommentsRequest) ProtoMessage() {}
 
-func (x *GetLabelsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_beads_proto_msgTypes[22]
+func (x *GetCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1360,41 +5329,41 @@ func (x *GetLabelsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLabelsRequest.ProtoReflect.Descriptor instead.
-func (*GetLabelsRequest) Descriptor() ([]byte, []int) {
-	return file_beads_v1_beads_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use GetCommentsRequest.ProtoReflect.Descriptor instead.
+func (*GetCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *GetLabelsRequest) GetBeadId() string {
+func (x *GetCommentsRequest) GetBeadId() string {
 	if x != nil {
 		return x.BeadId
 	}
 	return ""
 }
 
-// GetLabelsResponse returns the list of labels.
-type GetLabelsResponse struct {
+// GetCommentsResponse returns the list of comments.
+type GetCommentsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Labels        []string               `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty"`
+	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLabelsResponse) Reset() {
-	*x = GetLabelsResponse{}
-	mi := &file_beads_v1_beads_proto_msgTypes[23]
+func (x *GetCommentsResponse) Reset() {
+	*x = GetCommentsResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLabelsResponse) String() string {
+func (x *GetCommentsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLabelsResponse) ProtoMessage() {}
+func (*GetCommentsResponse) ProtoMessage() {}
 
-func (x *GetLabelsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_beads_proto_msgTypes[23]
+func (x *GetCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1405,20 +5374,20 @@ func (x *GetLabelsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLabelsResponse.ProtoReflect.Descriptor instead.
-func (*GetLabelsResponse) Descriptor() ([]byte, []int) {
-	return file_beads_v1_beads_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use GetCommentsResponse.ProtoReflect.Descriptor instead.
+func (*GetCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *GetLabelsResponse) GetLabels() []string {
+func (x *GetCommentsResponse) GetComments() []*Comment {
 	if x != nil {
-		return x.Labels
+		return x.Comments
 	}
 	return nil
 }
 
-// AddCommentRequest adds a comment to a bead.
-type AddCommentRequest struct {
+// AddNoteRequest appends a note entry to a bead.
+type AddNoteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
 	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
@@ -1427,21 +5396,21 @@ type AddCommentRequest struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddCommentRequest) Reset() {
-	*x = AddCommentRequest{}
-	mi := &file_beads_v1_beads_proto_msgTypes[24]
+func (x *AddNoteRequest) Reset() {
+	*x = AddNoteRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddCommentRequest) String() string {
+func (x *AddNoteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddCommentRequest) ProtoMessage() {}
+func (*AddNoteRequest) ProtoMessage() {}
 
-func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_beads_proto_msgTypes[24]
+func (x *AddNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1452,55 +5421,55 @@ func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
-func (*AddCommentRequest) Descriptor() ([]byte, []int) {
-	return file_beads_v1_beads_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use AddNoteRequest.ProtoReflect.Descriptor instead.
+func (*AddNoteRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *AddCommentRequest) GetBeadId() string {
+func (x *AddNoteRequest) GetBeadId() string {
 	if x != nil {
 		return x.BeadId
 	}
 	return ""
 }
 
-func (x *AddCommentRequest) GetAuthor() string {
+func (x *AddNoteRequest) GetAuthor() string {
 	if x != nil {
 		return x.Author
 	}
 	return ""
 }
 
-func (x *AddCommentRequest) GetText() string {
+func (x *AddNoteRequest) GetText() string {
 	if x != nil {
 		return x.Text
 	}
 	return ""
 }
 
-// AddCommentResponse returns the created comment.
-type AddCommentResponse struct {
+// AddNoteResponse returns the appended note.
+type AddNoteResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	Note          *Note                  `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddCommentResponse) Reset() {
-	*x = AddCommentResponse{}
-	mi := &file_beads_v1_beads_proto_msgTypes[25]
+func (x *AddNoteResponse) Reset() {
+	*x = AddNoteResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddCommentResponse) String() string {
+func (x *AddNoteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddCommentResponse) ProtoMessage() {}
+func (*AddNoteResponse) ProtoMessage() {}
 
-func (x *AddCommentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_beads_proto_msgTypes[25]
+func (x *AddNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1511,41 +5480,41 @@ func (x *AddCommentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddCommentResponse.ProtoReflect.Descriptor instead.
-func (*AddCommentResponse) Descriptor() ([]byte, []int) {
-	return file_beads_v1_beads_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use AddNoteResponse.ProtoReflect.Descriptor instead.
+func (*AddNoteResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *AddCommentResponse) GetComment() *Comment {
+func (x *AddNoteResponse) GetNote() *Note {
 	if x != nil {
-		return x.Comment
+		return x.Note
 	}
 	return nil
 }
 
-// GetCommentsRequest retrieves comments for a bead.
-type GetCommentsRequest struct {
+// GetNotesRequest retrieves the note history for a bead.
+type GetNotesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCommentsRequest) Reset() {
-	*x = GetCommentsRequest{}
-	mi := &file_beads_v1_beads_proto_msgTypes[26]
+func (x *GetNotesRequest) Reset() {
+	*x = GetNotesRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCommentsRequest) String() string {
+func (x *GetNotesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCommentsRequest) ProtoMessage() {}
+func (*GetNotesRequest) ProtoMessage() {}
 
-func (x *GetCommentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_beads_proto_msgTypes[26]
+func (x *GetNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1556,41 +5525,41 @@ func (x *GetCommentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCommentsRequest.ProtoReflect.Descriptor instead.
-func (*GetCommentsRequest) Descriptor() ([]byte, []int) {
-	return file_beads_v1_beads_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use GetNotesRequest.ProtoReflect.Descriptor instead.
+func (*GetNotesRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *GetCommentsRequest) GetBeadId() string {
+func (x *GetNotesRequest) GetBeadId() string {
 	if x != nil {
 		return x.BeadId
 	}
 	return ""
 }
 
-// GetCommentsResponse returns the list of comments.
-type GetCommentsResponse struct {
+// GetNotesResponse returns the note history, oldest first.
+type GetNotesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	Notes         []*Note                `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCommentsResponse) Reset() {
-	*x = GetCommentsResponse{}
-	mi := &file_beads_v1_beads_proto_msgTypes[27]
+func (x *GetNotesResponse) Reset() {
+	*x = GetNotesResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCommentsResponse) String() string {
+func (x *GetNotesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCommentsResponse) ProtoMessage() {}
+func (*GetNotesResponse) ProtoMessage() {}
 
-func (x *GetCommentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_beads_proto_msgTypes[27]
+func (x *GetNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1601,14 +5570,14 @@ func (x *GetCommentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCommentsResponse.ProtoReflect.Descriptor instead.
-func (*GetCommentsResponse) Descriptor() ([]byte, []int) {
-	return file_beads_v1_beads_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use GetNotesResponse.ProtoReflect.Descriptor instead.
+func (*GetNotesResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *GetCommentsResponse) GetComments() []*Comment {
+func (x *GetNotesResponse) GetNotes() []*Note {
 	if x != nil {
-		return x.Comments
+		return x.Notes
 	}
 	return nil
 }
@@ -1623,7 +5592,7 @@ type GetEventsRequest struct {
 
 func (x *GetEventsRequest) Reset() {
 	*x = GetEventsRequest{}
-	mi := &file_beads_v1_beads_proto_msgTypes[28]
+	mi := &file_beads_v1_beads_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1635,7 +5604,7 @@ func (x *GetEventsRequest) String() string {
 func (*GetEventsRequest) ProtoMessage() {}
 
 func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_beads_proto_msgTypes[28]
+	mi := &file_beads_v1_beads_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1648,7 +5617,7 @@ func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEventsRequest.ProtoReflect.Descriptor instead.
 func (*GetEventsRequest) Descriptor() ([]byte, []int) {
-	return file_beads_v1_beads_proto_rawDescGZIP(), []int{28}
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{97}
 }
 
 func (x *GetEventsRequest) GetBeadId() string {
@@ -1668,7 +5637,7 @@ type GetEventsResponse struct {
 
 func (x *GetEventsResponse) Reset() {
 	*x = GetEventsResponse{}
-	mi := &file_beads_v1_beads_proto_msgTypes[29]
+	mi := &file_beads_v1_beads_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1680,7 +5649,7 @@ func (x *GetEventsResponse) String() string {
 func (*GetEventsResponse) ProtoMessage() {}
 
 func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_beads_proto_msgTypes[29]
+	mi := &file_beads_v1_beads_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1693,7 +5662,7 @@ func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEventsResponse.ProtoReflect.Descriptor instead.
 func (*GetEventsResponse) Descriptor() ([]byte, []int) {
-	return file_beads_v1_beads_proto_rawDescGZIP(), []int{29}
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{98}
 }
 
 func (x *GetEventsResponse) GetEvents() []*Event {
@@ -1703,11 +5672,110 @@ func (x *GetEventsResponse) GetEvents() []*Event {
 	return nil
 }
 
+// GetActivityRequest retrieves a bead's activity feed. A positive limit
+// keeps only the latest entries.
+type GetActivityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivityRequest) Reset() {
+	*x = GetActivityRequest{}
+	mi := &file_beads_v1_beads_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivityRequest) ProtoMessage() {}
+
+func (x *GetActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivityRequest.ProtoReflect.Descriptor instead.
+func (*GetActivityRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *GetActivityRequest) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *GetActivityRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// GetActivityResponse returns the feed, oldest first.
+type GetActivityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Activity      []*ActivityEntry       `protobuf:"bytes,1,rep,name=activity,proto3" json:"activity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivityResponse) Reset() {
+	*x = GetActivityResponse{}
+	mi := &file_beads_v1_beads_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivityResponse) ProtoMessage() {}
+
+func (x *GetActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_beads_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivityResponse.ProtoReflect.Descriptor instead.
+func (*GetActivityResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_beads_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *GetActivityResponse) GetActivity() []*ActivityEntry {
+	if x != nil {
+		return x.Activity
+	}
+	return nil
+}
+
 var File_beads_v1_beads_proto protoreflect.FileDescriptor
 
 const file_beads_v1_beads_proto_rawDesc = "" +
 	"\n" +
-	"\x14beads/v1/beads.proto\x12\bbeads.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x14beads/v1/types.proto\"\xbb\x03\n" +
+	"\x14beads/v1/beads.proto\x12\bbeads.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x14beads/v1/types.proto\"\x98\x04\n" +
 	"\x11CreateBeadRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
 	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x12\n" +
@@ -1724,7 +5792,10 @@ const file_beads_v1_beads_proto_rawDesc = "" +
 	"\x06fields\x18\v \x01(\fR\x06fields\x12\x16\n" +
 	"\x06labels\x18\f \x03(\tR\x06labels\x12\x1d\n" +
 	"\n" +
-	"created_by\x18\r \x01(\tR\tcreatedByB\t\n" +
+	"created_by\x18\r \x01(\tR\tcreatedBy\x12'\n" +
+	"\x0fidempotency_key\x18\x0e \x01(\tR\x0eidempotencyKey\x12\x1a\n" +
+	"\bestimate\x18\x0f \x01(\tR\bestimate\x12\x16\n" +
+	"\x06actual\x18\x10 \x01(\tR\x06actualB\t\n" +
 	"\a_due_atB\x0e\n" +
 	"\f_defer_until\"8\n" +
 	"\x12CreateBeadResponse\x12\"\n" +
@@ -1732,7 +5803,7 @@ const file_beads_v1_beads_proto_rawDesc = "" +
 	"\x0eGetBeadRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"5\n" +
 	"\x0fGetBeadResponse\x12\"\n" +
-	"\x04bead\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x04bead\"\xad\x03\n" +
+	"\x04bead\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x04bead\"\xf6\x06\n" +
 	"\x10ListBeadsRequest\x12\x16\n" +
 	"\x06status\x18\x01 \x03(\tR\x06status\x12\x12\n" +
 	"\x04type\x18\x02 \x03(\tR\x04type\x12\x12\n" +
@@ -1745,13 +5816,21 @@ const file_beads_v1_beads_proto_rawDesc = "" +
 	"\x06offset\x18\t \x01(\x05R\x06offset\x12\x12\n" +
 	"\x04sort\x18\n" +
 	" \x01(\tR\x04sort\x12Q\n" +
-	"\rfield_filters\x18\v \x03(\v2,.beads.v1.ListBeadsRequest.FieldFiltersEntryR\ffieldFilters\x1a?\n" +
+	"\rfield_filters\x18\v \x03(\v2,.beads.v1.ListBeadsRequest.FieldFiltersEntryR\ffieldFilters\x12)\n" +
+	"\x10include_archived\x18\f \x01(\bR\x0fincludeArchived\x12\x14\n" +
+	"\x05query\x18\r \x01(\tR\x05query\x12?\n" +
+	"\rcreated_after\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12?\n" +
+	"\rupdated_after\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedAfter\x12A\n" +
+	"\x0eupdated_before\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\rupdatedBefore\x12=\n" +
+	"\fclosed_after\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\vclosedAfter\x12?\n" +
+	"\rclosed_before\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\fclosedBefore\x1a?\n" +
 	"\x11FieldFiltersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"O\n" +
 	"\x11ListBeadsResponse\x12$\n" +
 	"\x05beads\x18\x01 \x03(\v2\x0e.beads.v1.BeadR\x05beads\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"\xa2\x04\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xc5\x05\n" +
 	"\x11UpdateBeadRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
@@ -1766,7 +5845,14 @@ const file_beads_v1_beads_proto_rawDesc = "" +
 	" \x01(\v2\x1a.google.protobuf.TimestampH\bR\n" +
 	"deferUntil\x88\x01\x01\x12\x1b\n" +
 	"\x06fields\x18\v \x01(\fH\tR\x06fields\x88\x01\x01\x12\x16\n" +
-	"\x06labels\x18\f \x03(\tR\x06labelsB\b\n" +
+	"\x06labels\x18\f \x03(\tR\x06labels\x12\x16\n" +
+	"\x06append\x18\r \x01(\bR\x06append\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x0e \x01(\tR\tupdatedBy\x12\x14\n" +
+	"\x05clear\x18\x0f \x03(\tR\x05clear\x12\x1f\n" +
+	"\bestimate\x18\x10 \x01(\tH\n" +
+	"R\bestimate\x88\x01\x01\x12\x1b\n" +
+	"\x06actual\x18\x11 \x01(\tH\vR\x06actual\x88\x01\x01B\b\n" +
 	"\x06_titleB\x0e\n" +
 	"\f_descriptionB\b\n" +
 	"\x06_notesB\t\n" +
@@ -1776,26 +5862,199 @@ const file_beads_v1_beads_proto_rawDesc = "" +
 	"\x06_ownerB\t\n" +
 	"\a_due_atB\x0e\n" +
 	"\f_defer_untilB\t\n" +
-	"\a_fields\"8\n" +
+	"\a_fieldsB\v\n" +
+	"\t_estimateB\t\n" +
+	"\a_actual\"8\n" +
 	"\x12UpdateBeadResponse\x12\"\n" +
-	"\x04bead\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x04bead\"?\n" +
+	"\x04bead\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x04bead\"Y\n" +
 	"\x10CloseBeadRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
-	"\tclosed_by\x18\x02 \x01(\tR\bclosedBy\"7\n" +
+	"\tclosed_by\x18\x02 \x01(\tR\bclosedBy\x12\x18\n" +
+	"\acascade\x18\x03 \x01(\bR\acascade\"\xad\x01\n" +
 	"\x11CloseBeadResponse\x12\"\n" +
-	"\x04bead\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x04bead\"#\n" +
+	"\x04bead\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x04bead\x12,\n" +
+	"\tunblocked\x18\x02 \x03(\v2\x0e.beads.v1.BeadR\tunblocked\x12*\n" +
+	"\bcascaded\x18\x03 \x03(\v2\x0e.beads.v1.BeadR\bcascaded\x12\x1a\n" +
+	"\bwarnings\x18\x04 \x03(\tR\bwarnings\"a\n" +
+	"\x16ResolveDecisionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06option\x18\x02 \x01(\tR\x06option\x12\x1f\n" +
+	"\vresolved_by\x18\x03 \x01(\tR\n" +
+	"resolvedBy\"=\n" +
+	"\x17ResolveDecisionResponse\x12\"\n" +
+	"\x04bead\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x04bead\"+\n" +
+	"\x19GetDecisionContextRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xd3\x01\n" +
+	"\x1aGetDecisionContextResponse\x12*\n" +
+	"\bdecision\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\bdecision\x12\x18\n" +
+	"\aoptions\x18\x02 \x03(\tR\aoptions\x12\x12\n" +
+	"\x04diff\x18\x03 \x01(\tR\x04diff\x12\x14\n" +
+	"\x05links\x18\x04 \x03(\tR\x05links\x12+\n" +
+	"\x05beads\x18\x05 \x03(\v2\x15.beads.v1.BeadSummaryR\x05beads\x12\x18\n" +
+	"\amissing\x18\x06 \x03(\tR\amissing\"]\n" +
+	"\x18ListBlockedBeadsResponse\x12+\n" +
+	"\x05beads\x18\x01 \x03(\v2\x15.beads.v1.BlockedBeadR\x05beads\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"?\n" +
+	"\x0fPopQueueRequest\x12\x14\n" +
+	"\x05actor\x18\x01 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06labels\x18\x02 \x03(\tR\x06labels\"6\n" +
+	"\x10PopQueueResponse\x12\"\n" +
+	"\x04bead\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x04bead\"p\n" +
 	"\x11DeleteBeadRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
-	"\x12DeleteBeadResponse\"\x86\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acascade\x18\x02 \x01(\tR\acascade\x12\x12\n" +
+	"\x04hard\x18\x03 \x01(\bR\x04hard\x12\x1d\n" +
+	"\n" +
+	"deleted_by\x18\x04 \x01(\tR\tdeletedBy\"g\n" +
+	"\x12DeleteBeadResponse\x12\x1f\n" +
+	"\vdeleted_ids\x18\x01 \x03(\tR\n" +
+	"deletedIds\x120\n" +
+	"\bdetached\x18\x02 \x03(\v2\x14.beads.v1.DependencyR\bdetached\"S\n" +
+	"\x10MergeBeadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04into\x18\x02 \x01(\tR\x04into\x12\x1b\n" +
+	"\tmerged_by\x18\x03 \x01(\tR\bmergedBy\"c\n" +
+	"\x11MergeBeadResponse\x12&\n" +
+	"\x06source\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x06source\x12&\n" +
+	"\x06target\x18\x02 \x01(\v2\x0e.beads.v1.BeadR\x06target\"\xc6\x02\n" +
+	"\x10CloneBeadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1f\n" +
+	"\bassignee\x18\x03 \x01(\tH\x01R\bassignee\x88\x01\x01\x12$\n" +
+	"\vcopy_labels\x18\x04 \x01(\bH\x02R\n" +
+	"copyLabels\x88\x01\x01\x12$\n" +
+	"\vcopy_fields\x18\x05 \x01(\bH\x03R\n" +
+	"copyFields\x88\x01\x01\x120\n" +
+	"\x11copy_dependencies\x18\x06 \x01(\bH\x04R\x10copyDependencies\x88\x01\x01\x12\x1b\n" +
+	"\tcloned_by\x18\a \x01(\tR\bclonedByB\b\n" +
+	"\x06_titleB\v\n" +
+	"\t_assigneeB\x0e\n" +
+	"\f_copy_labelsB\x0e\n" +
+	"\f_copy_fieldsB\x14\n" +
+	"\x12_copy_dependencies\"7\n" +
+	"\x11CloneBeadResponse\x12\"\n" +
+	"\x04bead\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x04bead\"?\n" +
+	"\x17FindSimilarBeadsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"K\n" +
+	"\x18FindSimilarBeadsResponse\x12/\n" +
+	"\asimilar\x18\x01 \x03(\v2\x15.beads.v1.SimilarBeadR\asimilar\"A\n" +
+	"\x10WatchBeadRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\"/\n" +
+	"\x11WatchBeadResponse\x12\x1a\n" +
+	"\bwatchers\x18\x01 \x03(\tR\bwatchers\"C\n" +
+	"\x12UnwatchBeadRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\"1\n" +
+	"\x13UnwatchBeadResponse\x12\x1a\n" +
+	"\bwatchers\x18\x01 \x03(\tR\bwatchers\"g\n" +
+	"\x18ListNotificationsRequest\x12\x14\n" +
+	"\x05actor\x18\x01 \x01(\tR\x05actor\x12\x1f\n" +
+	"\vunread_only\x18\x02 \x01(\bR\n" +
+	"unreadOnly\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"Y\n" +
+	"\x19ListNotificationsResponse\x12<\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x16.beads.v1.NotificationR\rnotifications\"F\n" +
+	"\x1cMarkNotificationsReadRequest\x12\x14\n" +
+	"\x05actor\x18\x01 \x01(\tR\x05actor\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\x03R\x03ids\"7\n" +
+	"\x1dMarkNotificationsReadResponse\x12\x16\n" +
+	"\x06marked\x18\x01 \x01(\x03R\x06marked\"@\n" +
+	"\x10GetDigestRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\arefresh\x18\x02 \x01(\bR\arefresh\"\xae\x01\n" +
+	"\x11GetDigestResponse\x12\"\n" +
+	"\fsubscription\x18\x01 \x01(\tR\fsubscription\x12=\n" +
+	"\fgenerated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12 \n" +
+	"\x03new\x18\x04 \x03(\v2\x0e.beads.v1.BeadR\x03new\"\x16\n" +
+	"\x14GetServerInfoRequest\"\xd0\x01\n" +
+	"\x15GetServerInfoResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12,\n" +
+	"\x12min_client_version\x18\x02 \x01(\tR\x10minClientVersion\x12%\n" +
+	"\x0eclient_version\x18\x03 \x01(\tR\rclientVersion\x12,\n" +
+	"\x12client_release_url\x18\x04 \x01(\tR\x10clientReleaseUrl\x12\x1a\n" +
+	"\bfeatures\x18\x05 \x03(\tR\bfeatures\"(\n" +
+	"\x10ListGatesRequest\x12\x14\n" +
+	"\x05agent\x18\x01 \x01(\tR\x05agent\"c\n" +
+	"\x11ListGatesResponse\x12\x14\n" +
+	"\x05agent\x18\x01 \x01(\tR\x05agent\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12$\n" +
+	"\x05gates\x18\x03 \x03(\v2\x0e.beads.v1.GateR\x05gates\"\x13\n" +
+	"\x11ListAgentsRequest\"=\n" +
+	"\x12ListAgentsResponse\x12'\n" +
+	"\x06agents\x18\x01 \x03(\v2\x0f.beads.v1.AgentR\x06agents\"n\n" +
+	"\x0eSetGateRequest\x12\x14\n" +
+	"\x05agent\x18\x01 \x01(\tR\x05agent\x12\x12\n" +
+	"\x04gate\x18\x02 \x01(\tR\x04gate\x12\x1c\n" +
+	"\tsatisfied\x18\x03 \x01(\bR\tsatisfied\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\"5\n" +
+	"\x0fSetGateResponse\x12\"\n" +
+	"\x04gate\x18\x01 \x01(\v2\x0e.beads.v1.GateR\x04gate\"|\n" +
+	"\x10WaiveGateRequest\x12\x14\n" +
+	"\x05agent\x18\x01 \x01(\tR\x05agent\x12\x12\n" +
+	"\x04gate\x18\x02 \x01(\tR\x04gate\x12\x10\n" +
+	"\x03ttl\x18\x03 \x01(\tR\x03ttl\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\"7\n" +
+	"\x11WaiveGateResponse\x12\"\n" +
+	"\x04gate\x18\x01 \x01(\v2\x0e.beads.v1.GateR\x04gate\";\n" +
+	"\x0fEmitHookRequest\x12\x14\n" +
+	"\x05agent\x18\x01 \x01(\tR\x05agent\x12\x12\n" +
+	"\x04hook\x18\x02 \x01(\tR\x04hook\"\x98\x01\n" +
+	"\x10EmitHookResponse\x12\x14\n" +
+	"\x05agent\x18\x01 \x01(\tR\x05agent\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x1a\n" +
+	"\bdecision\x18\x03 \x01(\tR\bdecision\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12$\n" +
+	"\x05gates\x18\x05 \x03(\v2\x0e.beads.v1.GateR\x05gates\";\n" +
+	"\x11ListAdviceRequest\x12\x14\n" +
+	"\x05actor\x18\x01 \x01(\tR\x05actor\x12\x10\n" +
+	"\x03all\x18\x02 \x01(\bR\x03all\"<\n" +
+	"\x12ListAdviceResponse\x12&\n" +
+	"\x06advice\x18\x01 \x03(\v2\x0e.beads.v1.BeadR\x06advice\"A\n" +
+	"\x10AckAdviceRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\"\x13\n" +
+	"\x11AckAdviceResponse\"\x9d\x01\n" +
+	"\x14RegisterAgentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12$\n" +
+	"\rsubscriptions\x18\x02 \x03(\tR\rsubscriptions\x12\x14\n" +
+	"\x05gates\x18\x03 \x03(\tR\x05gates\x12\x16\n" +
+	"\x06labels\x18\x04 \x03(\tR\x06labels\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\"\x87\x02\n" +
+	"\x15RegisterAgentResponse\x12$\n" +
+	"\x05agent\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x05agent\x12$\n" +
+	"\x05gates\x18\x02 \x03(\v2\x0e.beads.v1.BeadR\x05gates\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12:\n" +
+	"\x03env\x18\x04 \x03(\v2(.beads.v1.RegisterAgentResponse.EnvEntryR\x03env\x12\x18\n" +
+	"\aexports\x18\x05 \x01(\tR\aexports\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa2\x01\n" +
 	"\x14AddDependencyRequest\x12\x17\n" +
 	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\"\n" +
 	"\rdepends_on_id\x18\x02 \x01(\tR\vdependsOnId\x12\x12\n" +
 	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1d\n" +
 	"\n" +
-	"created_by\x18\x04 \x01(\tR\tcreatedBy\"M\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\x1a\n" +
+	"\bmetadata\x18\x05 \x01(\tR\bmetadata\"M\n" +
 	"\x15AddDependencyResponse\x124\n" +
 	"\n" +
 	"dependency\x18\x01 \x01(\v2\x14.beads.v1.DependencyR\n" +
+	"dependency\"\xa5\x01\n" +
+	"\x17UpdateDependencyRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\"\n" +
+	"\rdepends_on_id\x18\x02 \x01(\tR\vdependsOnId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1a\n" +
+	"\bmetadata\x18\x04 \x01(\tR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x05 \x01(\tR\tupdatedBy\"P\n" +
+	"\x18UpdateDependencyResponse\x124\n" +
+	"\n" +
+	"dependency\x18\x01 \x01(\v2\x14.beads.v1.DependencyR\n" +
 	"dependency\"j\n" +
 	"\x17RemoveDependencyRequest\x12\x17\n" +
 	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\"\n" +
@@ -1805,7 +6064,62 @@ const file_beads_v1_beads_proto_rawDesc = "" +
 	"\x16GetDependenciesRequest\x12\x17\n" +
 	"\abead_id\x18\x01 \x01(\tR\x06beadId\"S\n" +
 	"\x17GetDependenciesResponse\x128\n" +
-	"\fdependencies\x18\x01 \x03(\v2\x14.beads.v1.DependencyR\fdependencies\"@\n" +
+	"\fdependencies\x18\x01 \x03(\v2\x14.beads.v1.DependencyR\fdependencies\"{\n" +
+	"\x12AddRelationRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x19\n" +
+	"\bother_id\x18\x02 \x01(\tR\aotherId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\"K\n" +
+	"\x13AddRelationResponse\x124\n" +
+	"\n" +
+	"dependency\x18\x01 \x01(\v2\x14.beads.v1.DependencyR\n" +
+	"dependency\"/\n" +
+	"\x14ListRelationsRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\"I\n" +
+	"\x15ListRelationsResponse\x120\n" +
+	"\trelations\x18\x01 \x03(\v2\x12.beads.v1.RelationR\trelations\"\x99\x01\n" +
+	"\x15AddExternalDepRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05probe\x18\x04 \x01(\tR\x05probe\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\"A\n" +
+	"\x16AddExternalDepResponse\x12'\n" +
+	"\x03dep\x18\x01 \x01(\v2\x15.beads.v1.ExternalDepR\x03dep\"2\n" +
+	"\x17ListExternalDepsRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\"E\n" +
+	"\x18ListExternalDepsResponse\x12)\n" +
+	"\x04deps\x18\x01 \x03(\v2\x15.beads.v1.ExternalDepR\x04deps\"z\n" +
+	"\x18UpdateExternalDepRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x04 \x01(\tR\tupdatedBy\"D\n" +
+	"\x19UpdateExternalDepResponse\x12'\n" +
+	"\x03dep\x18\x01 \x01(\v2\x15.beads.v1.ExternalDepR\x03dep\"b\n" +
+	"\x18RemoveExternalDepRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"removed_by\x18\x03 \x01(\tR\tremovedBy\"\x1b\n" +
+	"\x19RemoveExternalDepResponse\"\xa1\x01\n" +
+	"\x11LinkCommitRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x12\n" +
+	"\x04repo\x18\x02 \x01(\tR\x04repo\x12\x10\n" +
+	"\x03sha\x18\x03 \x01(\tR\x03sha\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x12\x1b\n" +
+	"\tlinked_by\x18\x06 \x01(\tR\blinkedBy\"X\n" +
+	"\x12LinkCommitResponse\x12(\n" +
+	"\x06commit\x18\x01 \x01(\v2\x10.beads.v1.CommitR\x06commit\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\"-\n" +
+	"\x12ListCommitsRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\"A\n" +
+	"\x13ListCommitsResponse\x12*\n" +
+	"\acommits\x18\x01 \x03(\v2\x10.beads.v1.CommitR\acommits\"@\n" +
 	"\x0fAddLabelRequest\x12\x17\n" +
 	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x14\n" +
 	"\x05label\x18\x02 \x01(\tR\x05label\"6\n" +
@@ -1818,7 +6132,22 @@ const file_beads_v1_beads_proto_rawDesc = "" +
 	"\x10GetLabelsRequest\x12\x17\n" +
 	"\abead_id\x18\x01 \x01(\tR\x06beadId\"+\n" +
 	"\x11GetLabelsResponse\x12\x16\n" +
-	"\x06labels\x18\x01 \x03(\tR\x06labels\"X\n" +
+	"\x06labels\x18\x01 \x03(\tR\x06labels\"_\n" +
+	"\x0fAddAliasRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x14\n" +
+	"\x05alias\x18\x02 \x01(\tR\x05alias\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\"9\n" +
+	"\x10AddAliasResponse\x12%\n" +
+	"\x05alias\x18\x01 \x01(\v2\x0f.beads.v1.AliasR\x05alias\"C\n" +
+	"\x12RemoveAliasRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x14\n" +
+	"\x05alias\x18\x02 \x01(\tR\x05alias\"\x15\n" +
+	"\x13RemoveAliasResponse\"-\n" +
+	"\x12ListAliasesRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\"@\n" +
+	"\x13ListAliasesResponse\x12)\n" +
+	"\aaliases\x18\x01 \x03(\v2\x0f.beads.v1.AliasR\aaliases\"X\n" +
 	"\x11AddCommentRequest\x12\x17\n" +
 	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x16\n" +
 	"\x06author\x18\x02 \x01(\tR\x06author\x12\x12\n" +
@@ -1828,11 +6157,26 @@ const file_beads_v1_beads_proto_rawDesc = "" +
 	"\x12GetCommentsRequest\x12\x17\n" +
 	"\abead_id\x18\x01 \x01(\tR\x06beadId\"D\n" +
 	"\x13GetCommentsResponse\x12-\n" +
-	"\bcomments\x18\x01 \x03(\v2\x11.beads.v1.CommentR\bcomments\"+\n" +
+	"\bcomments\x18\x01 \x03(\v2\x11.beads.v1.CommentR\bcomments\"U\n" +
+	"\x0eAddNoteRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"5\n" +
+	"\x0fAddNoteResponse\x12\"\n" +
+	"\x04note\x18\x01 \x01(\v2\x0e.beads.v1.NoteR\x04note\"*\n" +
+	"\x0fGetNotesRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\"8\n" +
+	"\x10GetNotesResponse\x12$\n" +
+	"\x05notes\x18\x01 \x03(\v2\x0e.beads.v1.NoteR\x05notes\"+\n" +
 	"\x10GetEventsRequest\x12\x17\n" +
 	"\abead_id\x18\x01 \x01(\tR\x06beadId\"<\n" +
 	"\x11GetEventsResponse\x12'\n" +
-	"\x06events\x18\x01 \x03(\v2\x0f.beads.v1.EventR\x06eventsB5Z3github.com/alfredjeanlab/beads/gen/beads/v1;beadsv1b\x06proto3"
+	"\x06events\x18\x01 \x03(\v2\x0f.beads.v1.EventR\x06events\"C\n" +
+	"\x12GetActivityRequest\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"J\n" +
+	"\x13GetActivityResponse\x123\n" +
+	"\bactivity\x18\x01 \x03(\v2\x17.beads.v1.ActivityEntryR\bactivityB5Z3github.com/alfredjeanlab/beads/gen/beads/v1;beadsv1b\x06proto3"
 
 var (
 	file_beads_v1_beads_proto_rawDescOnce sync.Once
@@ -1846,69 +6190,196 @@ func file_beads_v1_beads_proto_rawDescGZIP() []byte {
 	return file_beads_v1_beads_proto_rawDescData
 }
 
-var file_beads_v1_beads_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_beads_v1_beads_proto_msgTypes = make([]protoimpl.MessageInfo, 103)
 var file_beads_v1_beads_proto_goTypes = []any{
-	(*CreateBeadRequest)(nil),        // 0: beads.v1.CreateBeadRequest
-	(*CreateBeadResponse)(nil),       // 1: beads.v1.CreateBeadResponse
-	(*GetBeadRequest)(nil),           // 2: beads.v1.GetBeadRequest
-	(*GetBeadResponse)(nil),          // 3: beads.v1.GetBeadResponse
-	(*ListBeadsRequest)(nil),         // 4: beads.v1.ListBeadsRequest
-	(*ListBeadsResponse)(nil),        // 5: beads.v1.ListBeadsResponse
-	(*UpdateBeadRequest)(nil),        // 6: beads.v1.UpdateBeadRequest
-	(*UpdateBeadResponse)(nil),       // 7: beads.v1.UpdateBeadResponse
-	(*CloseBeadRequest)(nil),         // 8: beads.v1.CloseBeadRequest
-	(*CloseBeadResponse)(nil),        // 9: beads.v1.CloseBeadResponse
-	(*DeleteBeadRequest)(nil),        // 10: beads.v1.DeleteBeadRequest
-	(*DeleteBeadResponse)(nil),       // 11: beads.v1.DeleteBeadResponse
-	(*AddDependencyRequest)(nil),     // 12: beads.v1.AddDependencyRequest
-	(*AddDependencyResponse)(nil),    // 13: beads.v1.AddDependencyResponse
-	(*RemoveDependencyRequest)(nil),  // 14: beads.v1.RemoveDependencyRequest
-	(*RemoveDependencyResponse)(nil), // 15: beads.v1.RemoveDependencyResponse
-	(*GetDependenciesRequest)(nil),   // 16: beads.v1.GetDependenciesRequest
-	(*GetDependenciesResponse)(nil),  // 17: beads.v1.GetDependenciesResponse
-	(*AddLabelRequest)(nil),          // 18: beads.v1.AddLabelRequest
-	(*AddLabelResponse)(nil),         // 19: beads.v1.AddLabelResponse
-	(*RemoveLabelRequest)(nil),       // 20: beads.v1.RemoveLabelRequest
-	(*RemoveLabelResponse)(nil),      // 21: beads.v1.RemoveLabelResponse
-	(*GetLabelsRequest)(nil),         // 22: beads.v1.GetLabelsRequest
-	(*GetLabelsResponse)(nil),        // 23: beads.v1.GetLabelsResponse
-	(*AddCommentRequest)(nil),        // 24: beads.v1.AddCommentRequest
-	(*AddCommentResponse)(nil),       // 25: beads.v1.AddCommentResponse
-	(*GetCommentsRequest)(nil),       // 26: beads.v1.GetCommentsRequest
-	(*GetCommentsResponse)(nil),      // 27: beads.v1.GetCommentsResponse
-	(*GetEventsRequest)(nil),         // 28: beads.v1.GetEventsRequest
-	(*GetEventsResponse)(nil),        // 29: beads.v1.GetEventsResponse
-	nil,                              // 30: beads.v1.ListBeadsRequest.FieldFiltersEntry
-	(*timestamppb.Timestamp)(nil),    // 31: google.protobuf.Timestamp
-	(*Bead)(nil),                     // 32: beads.v1.Bead
-	(*wrapperspb.Int32Value)(nil),    // 33: google.protobuf.Int32Value
-	(*Dependency)(nil),               // 34: beads.v1.Dependency
-	(*Comment)(nil),                  // 35: beads.v1.Comment
-	(*Event)(nil),                    // 36: beads.v1.Event
+	(*CreateBeadRequest)(nil),             // 0: beads.v1.CreateBeadRequest
+	(*CreateBeadResponse)(nil),            // 1: beads.v1.CreateBeadResponse
+	(*GetBeadRequest)(nil),                // 2: beads.v1.GetBeadRequest
+	(*GetBeadResponse)(nil),               // 3: beads.v1.GetBeadResponse
+	(*ListBeadsRequest)(nil),              // 4: beads.v1.ListBeadsRequest
+	(*ListBeadsResponse)(nil),             // 5: beads.v1.ListBeadsResponse
+	(*UpdateBeadRequest)(nil),             // 6: beads.v1.UpdateBeadRequest
+	(*UpdateBeadResponse)(nil),            // 7: beads.v1.UpdateBeadResponse
+	(*CloseBeadRequest)(nil),              // 8: beads.v1.CloseBeadRequest
+	(*CloseBeadResponse)(nil),             // 9: beads.v1.CloseBeadResponse
+	(*ResolveDecisionRequest)(nil),        // 10: beads.v1.ResolveDecisionRequest
+	(*ResolveDecisionResponse)(nil),       // 11: beads.v1.ResolveDecisionResponse
+	(*GetDecisionContextRequest)(nil),     // 12: beads.v1.GetDecisionContextRequest
+	(*GetDecisionContextResponse)(nil),    // 13: beads.v1.GetDecisionContextResponse
+	(*ListBlockedBeadsResponse)(nil),      // 14: beads.v1.ListBlockedBeadsResponse
+	(*PopQueueRequest)(nil),               // 15: beads.v1.PopQueueRequest
+	(*PopQueueResponse)(nil),              // 16: beads.v1.PopQueueResponse
+	(*DeleteBeadRequest)(nil),             // 17: beads.v1.DeleteBeadRequest
+	(*DeleteBeadResponse)(nil),            // 18: beads.v1.DeleteBeadResponse
+	(*MergeBeadRequest)(nil),              // 19: beads.v1.MergeBeadRequest
+	(*MergeBeadResponse)(nil),             // 20: beads.v1.MergeBeadResponse
+	(*CloneBeadRequest)(nil),              // 21: beads.v1.CloneBeadRequest
+	(*CloneBeadResponse)(nil),             // 22: beads.v1.CloneBeadResponse
+	(*FindSimilarBeadsRequest)(nil),       // 23: beads.v1.FindSimilarBeadsRequest
+	(*FindSimilarBeadsResponse)(nil),      // 24: beads.v1.FindSimilarBeadsResponse
+	(*WatchBeadRequest)(nil),              // 25: beads.v1.WatchBeadRequest
+	(*WatchBeadResponse)(nil),             // 26: beads.v1.WatchBeadResponse
+	(*UnwatchBeadRequest)(nil),            // 27: beads.v1.UnwatchBeadRequest
+	(*UnwatchBeadResponse)(nil),           // 28: beads.v1.UnwatchBeadResponse
+	(*ListNotificationsRequest)(nil),      // 29: beads.v1.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil),     // 30: beads.v1.ListNotificationsResponse
+	(*MarkNotificationsReadRequest)(nil),  // 31: beads.v1.MarkNotificationsReadRequest
+	(*MarkNotificationsReadResponse)(nil), // 32: beads.v1.MarkNotificationsReadResponse
+	(*GetDigestRequest)(nil),              // 33: beads.v1.GetDigestRequest
+	(*GetDigestResponse)(nil),             // 34: beads.v1.GetDigestResponse
+	(*GetServerInfoRequest)(nil),          // 35: beads.v1.GetServerInfoRequest
+	(*GetServerInfoResponse)(nil),         // 36: beads.v1.GetServerInfoResponse
+	(*ListGatesRequest)(nil),              // 37: beads.v1.ListGatesRequest
+	(*ListGatesResponse)(nil),             // 38: beads.v1.ListGatesResponse
+	(*ListAgentsRequest)(nil),             // 39: beads.v1.ListAgentsRequest
+	(*ListAgentsResponse)(nil),            // 40: beads.v1.ListAgentsResponse
+	(*SetGateRequest)(nil),                // 41: beads.v1.SetGateRequest
+	(*SetGateResponse)(nil),               // 42: beads.v1.SetGateResponse
+	(*WaiveGateRequest)(nil),              // 43: beads.v1.WaiveGateRequest
+	(*WaiveGateResponse)(nil),             // 44: beads.v1.WaiveGateResponse
+	(*EmitHookRequest)(nil),               // 45: beads.v1.EmitHookRequest
+	(*EmitHookResponse)(nil),              // 46: beads.v1.EmitHookResponse
+	(*ListAdviceRequest)(nil),             // 47: beads.v1.ListAdviceRequest
+	(*ListAdviceResponse)(nil),            // 48: beads.v1.ListAdviceResponse
+	(*AckAdviceRequest)(nil),              // 49: beads.v1.AckAdviceRequest
+	(*AckAdviceResponse)(nil),             // 50: beads.v1.AckAdviceResponse
+	(*RegisterAgentRequest)(nil),          // 51: beads.v1.RegisterAgentRequest
+	(*RegisterAgentResponse)(nil),         // 52: beads.v1.RegisterAgentResponse
+	(*AddDependencyRequest)(nil),          // 53: beads.v1.AddDependencyRequest
+	(*AddDependencyResponse)(nil),         // 54: beads.v1.AddDependencyResponse
+	(*UpdateDependencyRequest)(nil),       // 55: beads.v1.UpdateDependencyRequest
+	(*UpdateDependencyResponse)(nil),      // 56: beads.v1.UpdateDependencyResponse
+	(*RemoveDependencyRequest)(nil),       // 57: beads.v1.RemoveDependencyRequest
+	(*RemoveDependencyResponse)(nil),      // 58: beads.v1.RemoveDependencyResponse
+	(*GetDependenciesRequest)(nil),        // 59: beads.v1.GetDependenciesRequest
+	(*GetDependenciesResponse)(nil),       // 60: beads.v1.GetDependenciesResponse
+	(*AddRelationRequest)(nil),            // 61: beads.v1.AddRelationRequest
+	(*AddRelationResponse)(nil),           // 62: beads.v1.AddRelationResponse
+	(*ListRelationsRequest)(nil),          // 63: beads.v1.ListRelationsRequest
+	(*ListRelationsResponse)(nil),         // 64: beads.v1.ListRelationsResponse
+	(*AddExternalDepRequest)(nil),         // 65: beads.v1.AddExternalDepRequest
+	(*AddExternalDepResponse)(nil),        // 66: beads.v1.AddExternalDepResponse
+	(*ListExternalDepsRequest)(nil),       // 67: beads.v1.ListExternalDepsRequest
+	(*ListExternalDepsResponse)(nil),      // 68: beads.v1.ListExternalDepsResponse
+	(*UpdateExternalDepRequest)(nil),      // 69: beads.v1.UpdateExternalDepRequest
+	(*UpdateExternalDepResponse)(nil),     // 70: beads.v1.UpdateExternalDepResponse
+	(*RemoveExternalDepRequest)(nil),      // 71: beads.v1.RemoveExternalDepRequest
+	(*RemoveExternalDepResponse)(nil),     // 72: beads.v1.RemoveExternalDepResponse
+	(*LinkCommitRequest)(nil),             // 73: beads.v1.LinkCommitRequest
+	(*LinkCommitResponse)(nil),            // 74: beads.v1.LinkCommitResponse
+	(*ListCommitsRequest)(nil),            // 75: beads.v1.ListCommitsRequest
+	(*ListCommitsResponse)(nil),           // 76: beads.v1.ListCommitsResponse
+	(*AddLabelRequest)(nil),               // 77: beads.v1.AddLabelRequest
+	(*AddLabelResponse)(nil),              // 78: beads.v1.AddLabelResponse
+	(*RemoveLabelRequest)(nil),            // 79: beads.v1.RemoveLabelRequest
+	(*RemoveLabelResponse)(nil),           // 80: beads.v1.RemoveLabelResponse
+	(*GetLabelsRequest)(nil),              // 81: beads.v1.GetLabelsRequest
+	(*GetLabelsResponse)(nil),             // 82: beads.v1.GetLabelsResponse
+	(*AddAliasRequest)(nil),               // 83: beads.v1.AddAliasRequest
+	(*AddAliasResponse)(nil),              // 84: beads.v1.AddAliasResponse
+	(*RemoveAliasRequest)(nil),            // 85: beads.v1.RemoveAliasRequest
+	(*RemoveAliasResponse)(nil),           // 86: beads.v1.RemoveAliasResponse
+	(*ListAliasesRequest)(nil),            // 87: beads.v1.ListAliasesRequest
+	(*ListAliasesResponse)(nil),           // 88: beads.v1.ListAliasesResponse
+	(*AddCommentRequest)(nil),             // 89: beads.v1.AddCommentRequest
+	(*AddCommentResponse)(nil),            // 90: beads.v1.AddCommentResponse
+	(*GetCommentsRequest)(nil),            // 91: beads.v1.GetCommentsRequest
+	(*GetCommentsResponse)(nil),           // 92: beads.v1.GetCommentsResponse
+	(*AddNoteRequest)(nil),                // 93: beads.v1.AddNoteRequest
+	(*AddNoteResponse)(nil),               // 94: beads.v1.AddNoteResponse
+	(*GetNotesRequest)(nil),               // 95: beads.v1.GetNotesRequest
+	(*GetNotesResponse)(nil),              // 96: beads.v1.GetNotesResponse
+	(*GetEventsRequest)(nil),              // 97: beads.v1.GetEventsRequest
+	(*GetEventsResponse)(nil),             // 98: beads.v1.GetEventsResponse
+	(*GetActivityRequest)(nil),            // 99: beads.v1.GetActivityRequest
+	(*GetActivityResponse)(nil),           // 100: beads.v1.GetActivityResponse
+	nil,                                   // 101: beads.v1.ListBeadsRequest.FieldFiltersEntry
+	nil,                                   // 102: beads.v1.RegisterAgentResponse.EnvEntry
+	(*timestamppb.Timestamp)(nil),         // 103: google.protobuf.Timestamp
+	(*Bead)(nil),                          // 104: beads.v1.Bead
+	(*wrapperspb.Int32Value)(nil),         // 105: google.protobuf.Int32Value
+	(*BeadSummary)(nil),                   // 106: beads.v1.BeadSummary
+	(*BlockedBead)(nil),                   // 107: beads.v1.BlockedBead
+	(*Dependency)(nil),                    // 108: beads.v1.Dependency
+	(*SimilarBead)(nil),                   // 109: beads.v1.SimilarBead
+	(*Notification)(nil),                  // 110: beads.v1.Notification
+	(*Gate)(nil),                          // 111: beads.v1.Gate
+	(*Agent)(nil),                         // 112: beads.v1.Agent
+	(*Relation)(nil),                      // 113: beads.v1.Relation
+	(*ExternalDep)(nil),                   // 114: beads.v1.ExternalDep
+	(*Commit)(nil),                        // 115: beads.v1.Commit
+	(*Alias)(nil),                         // 116: beads.v1.Alias
+	(*Comment)(nil),                       // 117: beads.v1.Comment
+	(*Note)(nil),                          // 118: beads.v1.Note
+	(*Event)(nil),                         // 119: beads.v1.Event
+	(*ActivityEntry)(nil),                 // 120: beads.v1.ActivityEntry
 }
 var file_beads_v1_beads_proto_depIdxs = []int32{
-	31, // 0: beads.v1.CreateBeadRequest.due_at:type_name -> google.protobuf.Timestamp
-	31, // 1: beads.v1.CreateBeadRequest.defer_until:type_name -> google.protobuf.Timestamp
-	32, // 2: beads.v1.CreateBeadResponse.bead:type_name -> beads.v1.Bead
-	32, // 3: beads.v1.GetBeadResponse.bead:type_name -> beads.v1.Bead
-	33, // 4: beads.v1.ListBeadsRequest.priority:type_name -> google.protobuf.Int32Value
-	30, // 5: beads.v1.ListBeadsRequest.field_filters:type_name -> beads.v1.ListBeadsRequest.FieldFiltersEntry
-	32, // 6: beads.v1.ListBeadsResponse.beads:type_name -> beads.v1.Bead
-	31, // 7: beads.v1.UpdateBeadRequest.due_at:type_name -> google.protobuf.Timestamp
-	31, // 8: beads.v1.UpdateBeadRequest.defer_until:type_name -> google.protobuf.Timestamp
-	32, // 9: beads.v1.UpdateBeadResponse.bead:type_name -> beads.v1.Bead
-	32, // 10: beads.v1.CloseBeadResponse.bead:type_name -> beads.v1.Bead
-	34, // 11: beads.v1.AddDependencyResponse.dependency:type_name -> beads.v1.Dependency
-	34, // 12: beads.v1.GetDependenciesResponse.dependencies:type_name -> beads.v1.Dependency
-	32, // 13: beads.v1.AddLabelResponse.bead:type_name -> beads.v1.Bead
-	35, // 14: beads.v1.AddCommentResponse.comment:type_name -> beads.v1.Comment
-	35, // 15: beads.v1.GetCommentsResponse.comments:type_name -> beads.v1.Comment
-	36, // 16: beads.v1.GetEventsResponse.events:type_name -> beads.v1.Event
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	103, // 0: beads.v1.CreateBeadRequest.due_at:type_name -> google.protobuf.Timestamp
+	103, // 1: beads.v1.CreateBeadRequest.defer_until:type_name -> google.protobuf.Timestamp
+	104, // 2: beads.v1.CreateBeadResponse.bead:type_name -> beads.v1.Bead
+	104, // 3: beads.v1.GetBeadResponse.bead:type_name -> beads.v1.Bead
+	105, // 4: beads.v1.ListBeadsRequest.priority:type_name -> google.protobuf.Int32Value
+	101, // 5: beads.v1.ListBeadsRequest.field_filters:type_name -> beads.v1.ListBeadsRequest.FieldFiltersEntry
+	103, // 6: beads.v1.ListBeadsRequest.created_after:type_name -> google.protobuf.Timestamp
+	103, // 7: beads.v1.ListBeadsRequest.created_before:type_name -> google.protobuf.Timestamp
+	103, // 8: beads.v1.ListBeadsRequest.updated_after:type_name -> google.protobuf.Timestamp
+	103, // 9: beads.v1.ListBeadsRequest.updated_before:type_name -> google.protobuf.Timestamp
+	103, // 10: beads.v1.ListBeadsRequest.closed_after:type_name -> google.protobuf.Timestamp
+	103, // 11: beads.v1.ListBeadsRequest.closed_before:type_name -> google.protobuf.Timestamp
+	104, // 12: beads.v1.ListBeadsResponse.beads:type_name -> beads.v1.Bead
+	103, // 13: beads.v1.UpdateBeadRequest.due_at:type_name -> google.protobuf.Timestamp
+	103, // 14: beads.v1.UpdateBeadRequest.defer_until:type_name -> google.protobuf.Timestamp
+	104, // 15: beads.v1.UpdateBeadResponse.bead:type_name -> beads.v1.Bead
+	104, // 16: beads.v1.CloseBeadResponse.bead:type_name -> beads.v1.Bead
+	104, // 17: beads.v1.CloseBeadResponse.unblocked:type_name -> beads.v1.Bead
+	104, // 18: beads.v1.CloseBeadResponse.cascaded:type_name -> beads.v1.Bead
+	104, // 19: beads.v1.ResolveDecisionResponse.bead:type_name -> beads.v1.Bead
+	104, // 20: beads.v1.GetDecisionContextResponse.decision:type_name -> beads.v1.Bead
+	106, // 21: beads.v1.GetDecisionContextResponse.beads:type_name -> beads.v1.BeadSummary
+	107, // 22: beads.v1.ListBlockedBeadsResponse.beads:type_name -> beads.v1.BlockedBead
+	104, // 23: beads.v1.PopQueueResponse.bead:type_name -> beads.v1.Bead
+	108, // 24: beads.v1.DeleteBeadResponse.detached:type_name -> beads.v1.Dependency
+	104, // 25: beads.v1.MergeBeadResponse.source:type_name -> beads.v1.Bead
+	104, // 26: beads.v1.MergeBeadResponse.target:type_name -> beads.v1.Bead
+	104, // 27: beads.v1.CloneBeadResponse.bead:type_name -> beads.v1.Bead
+	109, // 28: beads.v1.FindSimilarBeadsResponse.similar:type_name -> beads.v1.SimilarBead
+	110, // 29: beads.v1.ListNotificationsResponse.notifications:type_name -> beads.v1.Notification
+	103, // 30: beads.v1.GetDigestResponse.generated_at:type_name -> google.protobuf.Timestamp
+	104, // 31: beads.v1.GetDigestResponse.new:type_name -> beads.v1.Bead
+	111, // 32: beads.v1.ListGatesResponse.gates:type_name -> beads.v1.Gate
+	112, // 33: beads.v1.ListAgentsResponse.agents:type_name -> beads.v1.Agent
+	111, // 34: beads.v1.SetGateResponse.gate:type_name -> beads.v1.Gate
+	111, // 35: beads.v1.WaiveGateResponse.gate:type_name -> beads.v1.Gate
+	111, // 36: beads.v1.EmitHookResponse.gates:type_name -> beads.v1.Gate
+	104, // 37: beads.v1.ListAdviceResponse.advice:type_name -> beads.v1.Bead
+	104, // 38: beads.v1.RegisterAgentResponse.agent:type_name -> beads.v1.Bead
+	104, // 39: beads.v1.RegisterAgentResponse.gates:type_name -> beads.v1.Bead
+	102, // 40: beads.v1.RegisterAgentResponse.env:type_name -> beads.v1.RegisterAgentResponse.EnvEntry
+	108, // 41: beads.v1.AddDependencyResponse.dependency:type_name -> beads.v1.Dependency
+	108, // 42: beads.v1.UpdateDependencyResponse.dependency:type_name -> beads.v1.Dependency
+	108, // 43: beads.v1.GetDependenciesResponse.dependencies:type_name -> beads.v1.Dependency
+	108, // 44: beads.v1.AddRelationResponse.dependency:type_name -> beads.v1.Dependency
+	113, // 45: beads.v1.ListRelationsResponse.relations:type_name -> beads.v1.Relation
+	114, // 46: beads.v1.AddExternalDepResponse.dep:type_name -> beads.v1.ExternalDep
+	114, // 47: beads.v1.ListExternalDepsResponse.deps:type_name -> beads.v1.ExternalDep
+	114, // 48: beads.v1.UpdateExternalDepResponse.dep:type_name -> beads.v1.ExternalDep
+	115, // 49: beads.v1.LinkCommitResponse.commit:type_name -> beads.v1.Commit
+	115, // 50: beads.v1.ListCommitsResponse.commits:type_name -> beads.v1.Commit
+	104, // 51: beads.v1.AddLabelResponse.bead:type_name -> beads.v1.Bead
+	116, // 52: beads.v1.AddAliasResponse.alias:type_name -> beads.v1.Alias
+	116, // 53: beads.v1.ListAliasesResponse.aliases:type_name -> beads.v1.Alias
+	117, // 54: beads.v1.AddCommentResponse.comment:type_name -> beads.v1.Comment
+	117, // 55: beads.v1.GetCommentsResponse.comments:type_name -> beads.v1.Comment
+	118, // 56: beads.v1.AddNoteResponse.note:type_name -> beads.v1.Note
+	118, // 57: beads.v1.GetNotesResponse.notes:type_name -> beads.v1.Note
+	119, // 58: beads.v1.GetEventsResponse.events:type_name -> beads.v1.Event
+	120, // 59: beads.v1.GetActivityResponse.activity:type_name -> beads.v1.ActivityEntry
+	60,  // [60:60] is the sub-list for method output_type
+	60,  // [60:60] is the sub-list for method input_type
+	60,  // [60:60] is the sub-list for extension type_name
+	60,  // [60:60] is the sub-list for extension extendee
+	0,   // [0:60] is the sub-list for field type_name
 }
 
 func init() { file_beads_v1_beads_proto_init() }
@@ -1919,13 +6390,14 @@ func file_beads_v1_beads_proto_init() {
 	file_beads_v1_types_proto_init()
 	file_beads_v1_beads_proto_msgTypes[0].OneofWrappers = []any{}
 	file_beads_v1_beads_proto_msgTypes[6].OneofWrappers = []any{}
+	file_beads_v1_beads_proto_msgTypes[21].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_beads_v1_beads_proto_rawDesc), len(file_beads_v1_beads_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   103,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
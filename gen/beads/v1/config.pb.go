@@ -26,6 +26,7 @@ type SetConfigRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,3,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,6 +75,13 @@ func (x *SetConfigRequest) GetValue() []byte {
 	return nil
 }
 
+func (x *SetConfigRequest) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
 // SetConfigResponse returns the saved config.
 type SetConfigResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -381,14 +389,212 @@ func (*DeleteConfigResponse) Descriptor() ([]byte, []int) {
 	return file_beads_v1_config_proto_rawDescGZIP(), []int{7}
 }
 
+// GetConfigHistoryRequest lists the revisions of a config.
+type GetConfigHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigHistoryRequest) Reset() {
+	*x = GetConfigHistoryRequest{}
+	mi := &file_beads_v1_config_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigHistoryRequest) ProtoMessage() {}
+
+func (x *GetConfigHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_config_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_config_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetConfigHistoryRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// GetConfigHistoryResponse returns revisions, newest first.
+type GetConfigHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*ConfigRevision      `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigHistoryResponse) Reset() {
+	*x = GetConfigHistoryResponse{}
+	mi := &file_beads_v1_config_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigHistoryResponse) ProtoMessage() {}
+
+func (x *GetConfigHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_config_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_config_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetConfigHistoryResponse) GetRevisions() []*ConfigRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+// RollbackConfigRequest restores a config to the value it had at rev.
+type RollbackConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Rev           int64                  `protobuf:"varint,2,opt,name=rev,proto3" json:"rev,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,3,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackConfigRequest) Reset() {
+	*x = RollbackConfigRequest{}
+	mi := &file_beads_v1_config_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackConfigRequest) ProtoMessage() {}
+
+func (x *RollbackConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_config_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackConfigRequest.ProtoReflect.Descriptor instead.
+func (*RollbackConfigRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_config_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RollbackConfigRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *RollbackConfigRequest) GetRev() int64 {
+	if x != nil {
+		return x.Rev
+	}
+	return 0
+}
+
+func (x *RollbackConfigRequest) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+// RollbackConfigResponse returns the config as saved by the rollback.
+type RollbackConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *Config                `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackConfigResponse) Reset() {
+	*x = RollbackConfigResponse{}
+	mi := &file_beads_v1_config_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackConfigResponse) ProtoMessage() {}
+
+func (x *RollbackConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_config_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackConfigResponse.ProtoReflect.Descriptor instead.
+func (*RollbackConfigResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_config_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RollbackConfigResponse) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
 var File_beads_v1_config_proto protoreflect.FileDescriptor
 
 const file_beads_v1_config_proto_rawDesc = "" +
 	"\n" +
-	"\x15beads/v1/config.proto\x12\bbeads.v1\x1a\x14beads/v1/types.proto\":\n" +
+	"\x15beads/v1/config.proto\x12\bbeads.v1\x1a\x14beads/v1/types.proto\"Y\n" +
 	"\x10SetConfigRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\fR\x05value\"=\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x03 \x01(\tR\tupdatedBy\"=\n" +
 	"\x11SetConfigResponse\x12(\n" +
 	"\x06config\x18\x01 \x01(\v2\x10.beads.v1.ConfigR\x06config\"$\n" +
 	"\x10GetConfigRequest\x12\x10\n" +
@@ -401,7 +607,18 @@ const file_beads_v1_config_proto_rawDesc = "" +
 	"\aconfigs\x18\x01 \x03(\v2\x10.beads.v1.ConfigR\aconfigs\"'\n" +
 	"\x13DeleteConfigRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\"\x16\n" +
-	"\x14DeleteConfigResponseB5Z3github.com/alfredjeanlab/beads/gen/beads/v1;beadsv1b\x06proto3"
+	"\x14DeleteConfigResponse\"+\n" +
+	"\x17GetConfigHistoryRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"R\n" +
+	"\x18GetConfigHistoryResponse\x126\n" +
+	"\trevisions\x18\x01 \x03(\v2\x18.beads.v1.ConfigRevisionR\trevisions\"Z\n" +
+	"\x15RollbackConfigRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\x03R\x03rev\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x03 \x01(\tR\tupdatedBy\"B\n" +
+	"\x16RollbackConfigResponse\x12(\n" +
+	"\x06config\x18\x01 \x01(\v2\x10.beads.v1.ConfigR\x06configB5Z3github.com/alfredjeanlab/beads/gen/beads/v1;beadsv1b\x06proto3"
 
 var (
 	file_beads_v1_config_proto_rawDescOnce sync.Once
@@ -415,27 +632,34 @@ func file_beads_v1_config_proto_rawDescGZIP() []byte {
 	return file_beads_v1_config_proto_rawDescData
 }
 
-var file_beads_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_beads_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_beads_v1_config_proto_goTypes = []any{
-	(*SetConfigRequest)(nil),     // 0: beads.v1.SetConfigRequest
-	(*SetConfigResponse)(nil),    // 1: beads.v1.SetConfigResponse
-	(*GetConfigRequest)(nil),     // 2: beads.v1.GetConfigRequest
-	(*GetConfigResponse)(nil),    // 3: beads.v1.GetConfigResponse
-	(*ListConfigsRequest)(nil),   // 4: beads.v1.ListConfigsRequest
-	(*ListConfigsResponse)(nil),  // 5: beads.v1.ListConfigsResponse
-	(*DeleteConfigRequest)(nil),  // 6: beads.v1.DeleteConfigRequest
-	(*DeleteConfigResponse)(nil), // 7: beads.v1.DeleteConfigResponse
-	(*Config)(nil),               // 8: beads.v1.Config
+	(*SetConfigRequest)(nil),         // 0: beads.v1.SetConfigRequest
+	(*SetConfigResponse)(nil),        // 1: beads.v1.SetConfigResponse
+	(*GetConfigRequest)(nil),         // 2: beads.v1.GetConfigRequest
+	(*GetConfigResponse)(nil),        // 3: beads.v1.GetConfigResponse
+	(*ListConfigsRequest)(nil),       // 4: beads.v1.ListConfigsRequest
+	(*ListConfigsResponse)(nil),      // 5: beads.v1.ListConfigsResponse
+	(*DeleteConfigRequest)(nil),      // 6: beads.v1.DeleteConfigRequest
+	(*DeleteConfigResponse)(nil),     // 7: beads.v1.DeleteConfigResponse
+	(*GetConfigHistoryRequest)(nil),  // 8: beads.v1.GetConfigHistoryRequest
+	(*GetConfigHistoryResponse)(nil), // 9: beads.v1.GetConfigHistoryResponse
+	(*RollbackConfigRequest)(nil),    // 10: beads.v1.RollbackConfigRequest
+	(*RollbackConfigResponse)(nil),   // 11: beads.v1.RollbackConfigResponse
+	(*Config)(nil),                   // 12: beads.v1.Config
+	(*ConfigRevision)(nil),           // 13: beads.v1.ConfigRevision
 }
 var file_beads_v1_config_proto_depIdxs = []int32{
-	8, // 0: beads.v1.SetConfigResponse.config:type_name -> beads.v1.Config
-	8, // 1: beads.v1.GetConfigResponse.config:type_name -> beads.v1.Config
-	8, // 2: beads.v1.ListConfigsResponse.configs:type_name -> beads.v1.Config
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	12, // 0: beads.v1.SetConfigResponse.config:type_name -> beads.v1.Config
+	12, // 1: beads.v1.GetConfigResponse.config:type_name -> beads.v1.Config
+	12, // 2: beads.v1.ListConfigsResponse.configs:type_name -> beads.v1.Config
+	13, // 3: beads.v1.GetConfigHistoryResponse.revisions:type_name -> beads.v1.ConfigRevision
+	12, // 4: beads.v1.RollbackConfigResponse.config:type_name -> beads.v1.Config
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_beads_v1_config_proto_init() }
@@ -450,7 +674,7 @@ func file_beads_v1_config_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_beads_v1_config_proto_rawDesc), len(file_beads_v1_config_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
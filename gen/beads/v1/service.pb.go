@@ -7,6 +7,7 @@
 package beadsv1
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -103,39 +104,173 @@ func (x *HealthResponse) GetStatus() string {
 	return ""
 }
 
+// ListAlertsRequest is an empty request for the current alert state.
+type ListAlertsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertsRequest) Reset() {
+	*x = ListAlertsRequest{}
+	mi := &file_beads_v1_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertsRequest) ProtoMessage() {}
+
+func (x *ListAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertsRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_beads_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
+// ListAlertsResponse returns all evaluated alert rules.
+type ListAlertsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alerts        []*Alert               `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertsResponse) Reset() {
+	*x = ListAlertsResponse{}
+	mi := &file_beads_v1_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertsResponse) ProtoMessage() {}
+
+func (x *ListAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertsResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_beads_v1_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListAlertsResponse) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
 var File_beads_v1_service_proto protoreflect.FileDescriptor
 
 const file_beads_v1_service_proto_rawDesc = "" +
 	"\n" +
-	"\x16beads/v1/service.proto\x12\bbeads.v1\x1a\x14beads/v1/beads.proto\x1a\x15beads/v1/config.proto\"\x0f\n" +
+	"\x16beads/v1/service.proto\x12\bbeads.v1\x1a\x14beads/v1/beads.proto\x1a\x15beads/v1/config.proto\x1a\x14beads/v1/types.proto\x1a\x1cgoogle/api/annotations.proto\"\x0f\n" +
 	"\rHealthRequest\"(\n" +
 	"\x0eHealthResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status2\xce\v\n" +
-	"\fBeadsService\x12G\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\x13\n" +
+	"\x11ListAlertsRequest\"=\n" +
+	"\x12ListAlertsResponse\x12'\n" +
+	"\x06alerts\x18\x01 \x03(\v2\x0f.beads.v1.AlertR\x06alerts2\xf83\n" +
+	"\fBeadsService\x12]\n" +
+	"\n" +
+	"CreateBead\x12\x1b.beads.v1.CreateBeadRequest\x1a\x1c.beads.v1.CreateBeadResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/beads\x12V\n" +
+	"\aGetBead\x12\x18.beads.v1.GetBeadRequest\x1a\x19.beads.v1.GetBeadResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/beads/{id}\x12W\n" +
+	"\tListBeads\x12\x1a.beads.v1.ListBeadsRequest\x1a\x1b.beads.v1.ListBeadsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/beads\x12\\\n" +
+	"\x0eListReadyBeads\x12\x1a.beads.v1.ListBeadsRequest\x1a\x1b.beads.v1.ListBeadsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/ready\x12g\n" +
+	"\x10ListBlockedBeads\x12\x1a.beads.v1.ListBeadsRequest\x1a\".beads.v1.ListBlockedBeadsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/blocked\x12Y\n" +
+	"\bPopQueue\x12\x19.beads.v1.PopQueueRequest\x1a\x1a.beads.v1.PopQueueResponse\"\x16\x82\xd3\xe4\x93\x02\x10\"\x0e/v1/queue/next\x12b\n" +
+	"\n" +
+	"UpdateBead\x12\x1b.beads.v1.UpdateBeadRequest\x1a\x1c.beads.v1.UpdateBeadResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*2\x0e/v1/beads/{id}\x12e\n" +
+	"\tCloseBead\x12\x1a.beads.v1.CloseBeadRequest\x1a\x1b.beads.v1.CloseBeadResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/beads/{id}/close\x12y\n" +
+	"\x0fResolveDecision\x12 .beads.v1.ResolveDecisionRequest\x1a!.beads.v1.ResolveDecisionResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/beads/{id}/resolve\x12\x83\x01\n" +
+	"\x12GetDecisionContext\x12#.beads.v1.GetDecisionContextRequest\x1a$.beads.v1.GetDecisionContextResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/decisions/{id}/context\x12_\n" +
+	"\n" +
+	"DeleteBead\x12\x1b.beads.v1.DeleteBeadRequest\x1a\x1c.beads.v1.DeleteBeadResponse\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/v1/beads/{id}\x12e\n" +
+	"\tMergeBead\x12\x1a.beads.v1.MergeBeadRequest\x1a\x1b.beads.v1.MergeBeadResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/beads/{id}/merge\x12e\n" +
+	"\tCloneBead\x12\x1a.beads.v1.CloneBeadRequest\x1a\x1b.beads.v1.CloneBeadResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/beads/{id}/clone\x12y\n" +
+	"\x10FindSimilarBeads\x12!.beads.v1.FindSimilarBeadsRequest\x1a\".beads.v1.FindSimilarBeadsResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/beads/{id}/similar\x12}\n" +
+	"\rAddDependency\x12\x1e.beads.v1.AddDependencyRequest\x1a\x1f.beads.v1.AddDependencyResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/beads/{bead_id}/dependencies\x12\x86\x01\n" +
+	"\x10UpdateDependency\x12!.beads.v1.UpdateDependencyRequest\x1a\".beads.v1.UpdateDependencyResponse\"+\x82\xd3\xe4\x93\x02%:\x01*2 /v1/beads/{bead_id}/dependencies\x12\x83\x01\n" +
+	"\x10RemoveDependency\x12!.beads.v1.RemoveDependencyRequest\x1a\".beads.v1.RemoveDependencyResponse\"(\x82\xd3\xe4\x93\x02\"* /v1/beads/{bead_id}/dependencies\x12\x80\x01\n" +
+	"\x0fGetDependencies\x12 .beads.v1.GetDependenciesRequest\x1a!.beads.v1.GetDependenciesResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /v1/beads/{bead_id}/dependencies\x12t\n" +
+	"\vAddRelation\x12\x1c.beads.v1.AddRelationRequest\x1a\x1d.beads.v1.AddRelationResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/beads/{bead_id}/relations\x12w\n" +
+	"\rListRelations\x12\x1e.beads.v1.ListRelationsRequest\x1a\x1f.beads.v1.ListRelationsResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/beads/{bead_id}/relations\x12|\n" +
+	"\x0eAddExternalDep\x12\x1f.beads.v1.AddExternalDepRequest\x1a .beads.v1.AddExternalDepResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/beads/{bead_id}/external\x12\x7f\n" +
+	"\x10ListExternalDeps\x12!.beads.v1.ListExternalDepsRequest\x1a\".beads.v1.ListExternalDepsResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/beads/{bead_id}/external\x12\x8a\x01\n" +
+	"\x11UpdateExternalDep\x12\".beads.v1.UpdateExternalDepRequest\x1a#.beads.v1.UpdateExternalDepResponse\",\x82\xd3\xe4\x93\x02&:\x01*2!/v1/beads/{bead_id}/external/{id}\x12\x87\x01\n" +
+	"\x11RemoveExternalDep\x12\".beads.v1.RemoveExternalDepRequest\x1a#.beads.v1.RemoveExternalDepResponse\")\x82\xd3\xe4\x93\x02#*!/v1/beads/{bead_id}/external/{id}\x12o\n" +
+	"\n" +
+	"LinkCommit\x12\x1b.beads.v1.LinkCommitRequest\x1a\x1c.beads.v1.LinkCommitResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/beads/{bead_id}/commits\x12o\n" +
+	"\vListCommits\x12\x1c.beads.v1.ListCommitsRequest\x1a\x1d.beads.v1.ListCommitsResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/beads/{bead_id}/commits\x12h\n" +
+	"\bAddLabel\x12\x19.beads.v1.AddLabelRequest\x1a\x1a.beads.v1.AddLabelResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/beads/{bead_id}/labels\x12v\n" +
+	"\vRemoveLabel\x12\x1c.beads.v1.RemoveLabelRequest\x1a\x1d.beads.v1.RemoveLabelResponse\"*\x82\xd3\xe4\x93\x02$*\"/v1/beads/{bead_id}/labels/{label}\x12h\n" +
+	"\tGetLabels\x12\x1a.beads.v1.GetLabelsRequest\x1a\x1b.beads.v1.GetLabelsResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/beads/{bead_id}/labels\x12i\n" +
+	"\bAddAlias\x12\x19.beads.v1.AddAliasRequest\x1a\x1a.beads.v1.AddAliasResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/beads/{bead_id}/aliases\x12w\n" +
+	"\vRemoveAlias\x12\x1c.beads.v1.RemoveAliasRequest\x1a\x1d.beads.v1.RemoveAliasResponse\"+\x82\xd3\xe4\x93\x02%*#/v1/beads/{bead_id}/aliases/{alias}\x12o\n" +
+	"\vListAliases\x12\x1c.beads.v1.ListAliasesRequest\x1a\x1d.beads.v1.ListAliasesResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/beads/{bead_id}/aliases\x12p\n" +
 	"\n" +
-	"CreateBead\x12\x1b.beads.v1.CreateBeadRequest\x1a\x1c.beads.v1.CreateBeadResponse\x12>\n" +
-	"\aGetBead\x12\x18.beads.v1.GetBeadRequest\x1a\x19.beads.v1.GetBeadResponse\x12D\n" +
-	"\tListBeads\x12\x1a.beads.v1.ListBeadsRequest\x1a\x1b.beads.v1.ListBeadsResponse\x12G\n" +
+	"AddComment\x12\x1b.beads.v1.AddCommentRequest\x1a\x1c.beads.v1.AddCommentResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/beads/{bead_id}/comments\x12p\n" +
+	"\vGetComments\x12\x1c.beads.v1.GetCommentsRequest\x1a\x1d.beads.v1.GetCommentsResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/beads/{bead_id}/comments\x12d\n" +
+	"\aAddNote\x12\x18.beads.v1.AddNoteRequest\x1a\x19.beads.v1.AddNoteResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/beads/{bead_id}/notes\x12d\n" +
+	"\bGetNotes\x12\x19.beads.v1.GetNotesRequest\x1a\x1a.beads.v1.GetNotesResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/beads/{bead_id}/notes\x12h\n" +
+	"\tGetEvents\x12\x1a.beads.v1.GetEventsRequest\x1a\x1b.beads.v1.GetEventsResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/beads/{bead_id}/events\x12p\n" +
+	"\vGetActivity\x12\x1c.beads.v1.GetActivityRequest\x1a\x1d.beads.v1.GetActivityResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/beads/{bead_id}/activity\x12m\n" +
+	"\tWatchBead\x12\x1a.beads.v1.WatchBeadRequest\x1a\x1b.beads.v1.WatchBeadResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/beads/{bead_id}/watchers\x12p\n" +
+	"\vUnwatchBead\x12\x1c.beads.v1.UnwatchBeadRequest\x1a\x1d.beads.v1.UnwatchBeadResponse\"$\x82\xd3\xe4\x93\x02\x1e*\x1c/v1/beads/{bead_id}/watchers\x12w\n" +
+	"\x11ListNotifications\x12\".beads.v1.ListNotificationsRequest\x1a#.beads.v1.ListNotificationsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/notifications\x12\x8b\x01\n" +
+	"\x15MarkNotificationsRead\x12&.beads.v1.MarkNotificationsReadRequest\x1a'.beads.v1.MarkNotificationsReadResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/notifications/read\x12`\n" +
+	"\tGetDigest\x12\x1a.beads.v1.GetDigestRequest\x1a\x1b.beads.v1.GetDigestResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/digests/{name}\x12e\n" +
+	"\tSetConfig\x12\x1a.beads.v1.SetConfigRequest\x1a\x1b.beads.v1.SetConfigResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\x1a\x14/v1/configs/{key=**}\x12b\n" +
+	"\tGetConfig\x12\x1a.beads.v1.GetConfigRequest\x1a\x1b.beads.v1.GetConfigResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/configs/{key=**}\x12_\n" +
+	"\vListConfigs\x12\x1c.beads.v1.ListConfigsRequest\x1a\x1d.beads.v1.ListConfigsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/configs\x12k\n" +
+	"\fDeleteConfig\x12\x1d.beads.v1.DeleteConfigRequest\x1a\x1e.beads.v1.DeleteConfigResponse\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/v1/configs/{key=**}\x12|\n" +
+	"\x10GetConfigHistory\x12!.beads.v1.GetConfigHistoryRequest\x1a\".beads.v1.GetConfigHistoryResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/configs/{key}/history\x12w\n" +
+	"\x0eRollbackConfig\x12\x1f.beads.v1.RollbackConfigRequest\x1a .beads.v1.RollbackConfigResponse\"\"\x82\xd3\xe4\x93\x02\x1c\"\x1a/v1/configs/{key}/rollback\x12[\n" +
 	"\n" +
-	"UpdateBead\x12\x1b.beads.v1.UpdateBeadRequest\x1a\x1c.beads.v1.UpdateBeadResponse\x12D\n" +
-	"\tCloseBead\x12\x1a.beads.v1.CloseBeadRequest\x1a\x1b.beads.v1.CloseBeadResponse\x12G\n" +
+	"ListAlerts\x12\x1b.beads.v1.ListAlertsRequest\x1a\x1c.beads.v1.ListAlertsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/alerts\x12O\n" +
+	"\x06Health\x12\x17.beads.v1.HealthRequest\x1a\x18.beads.v1.HealthResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/health\x12b\n" +
+	"\rGetServerInfo\x12\x1e.beads.v1.GetServerInfoRequest\x1a\x1f.beads.v1.GetServerInfoResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/v1/info\x12p\n" +
+	"\rRegisterAgent\x12\x1e.beads.v1.RegisterAgentRequest\x1a\x1f.beads.v1.RegisterAgentResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/agents/register\x12[\n" +
 	"\n" +
-	"DeleteBead\x12\x1b.beads.v1.DeleteBeadRequest\x1a\x1c.beads.v1.DeleteBeadResponse\x12P\n" +
-	"\rAddDependency\x12\x1e.beads.v1.AddDependencyRequest\x1a\x1f.beads.v1.AddDependencyResponse\x12Y\n" +
-	"\x10RemoveDependency\x12!.beads.v1.RemoveDependencyRequest\x1a\".beads.v1.RemoveDependencyResponse\x12V\n" +
-	"\x0fGetDependencies\x12 .beads.v1.GetDependenciesRequest\x1a!.beads.v1.GetDependenciesResponse\x12A\n" +
-	"\bAddLabel\x12\x19.beads.v1.AddLabelRequest\x1a\x1a.beads.v1.AddLabelResponse\x12J\n" +
-	"\vRemoveLabel\x12\x1c.beads.v1.RemoveLabelRequest\x1a\x1d.beads.v1.RemoveLabelResponse\x12D\n" +
-	"\tGetLabels\x12\x1a.beads.v1.GetLabelsRequest\x1a\x1b.beads.v1.GetLabelsResponse\x12G\n" +
+	"ListAgents\x12\x1b.beads.v1.ListAgentsRequest\x1a\x1c.beads.v1.ListAgentsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/agents\x12W\n" +
+	"\tListGates\x12\x1a.beads.v1.ListGatesRequest\x1a\x1b.beads.v1.ListGatesResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/gates\x12l\n" +
+	"\aSetGate\x12\x18.beads.v1.SetGateRequest\x1a\x19.beads.v1.SetGateResponse\",\x82\xd3\xe4\x93\x02&Z\x12*\x10/v1/gates/{gate}\x1a\x10/v1/gates/{gate}\x12s\n" +
+	"\tWaiveGate\x12\x1a.beads.v1.WaiveGateRequest\x1a\x1b.beads.v1.WaiveGateResponse\"-\x82\xd3\xe4\x93\x02'\"%/v1/agents/{agent}/gates/{gate}/waive\x12\\\n" +
+	"\bEmitHook\x12\x19.beads.v1.EmitHookRequest\x1a\x1a.beads.v1.EmitHookResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/hooks/emit\x12[\n" +
 	"\n" +
-	"AddComment\x12\x1b.beads.v1.AddCommentRequest\x1a\x1c.beads.v1.AddCommentResponse\x12J\n" +
-	"\vGetComments\x12\x1c.beads.v1.GetCommentsRequest\x1a\x1d.beads.v1.GetCommentsResponse\x12D\n" +
-	"\tGetEvents\x12\x1a.beads.v1.GetEventsRequest\x1a\x1b.beads.v1.GetEventsResponse\x12D\n" +
-	"\tSetConfig\x12\x1a.beads.v1.SetConfigRequest\x1a\x1b.beads.v1.SetConfigResponse\x12D\n" +
-	"\tGetConfig\x12\x1a.beads.v1.GetConfigRequest\x1a\x1b.beads.v1.GetConfigResponse\x12J\n" +
-	"\vListConfigs\x12\x1c.beads.v1.ListConfigsRequest\x1a\x1d.beads.v1.ListConfigsResponse\x12M\n" +
-	"\fDeleteConfig\x12\x1d.beads.v1.DeleteConfigRequest\x1a\x1e.beads.v1.DeleteConfigResponse\x12;\n" +
-	"\x06Health\x12\x17.beads.v1.HealthRequest\x1a\x18.beads.v1.HealthResponseB5Z3github.com/alfredjeanlab/beads/gen/beads/v1;beadsv1b\x06proto3"
+	"ListAdvice\x12\x1b.beads.v1.ListAdviceRequest\x1a\x1c.beads.v1.ListAdviceResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/advice\x12i\n" +
+	"\tAckAdvice\x12\x1a.beads.v1.AckAdviceRequest\x1a\x1b.beads.v1.AckAdviceResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/advice/{bead_id}/ackB5Z3github.com/alfredjeanlab/beads/gen/beads/v1;beadsv1b\x06proto3"
 
 var (
 	file_beads_v1_service_proto_rawDescOnce sync.Once
@@ -149,95 +284,254 @@ func file_beads_v1_service_proto_rawDescGZIP() []byte {
 	return file_beads_v1_service_proto_rawDescData
 }
 
-var file_beads_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_beads_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_beads_v1_service_proto_goTypes = []any{
-	(*HealthRequest)(nil),            // 0: beads.v1.HealthRequest
-	(*HealthResponse)(nil),           // 1: beads.v1.HealthResponse
-	(*CreateBeadRequest)(nil),        // 2: beads.v1.CreateBeadRequest
-	(*GetBeadRequest)(nil),           // 3: beads.v1.GetBeadRequest
-	(*ListBeadsRequest)(nil),         // 4: beads.v1.ListBeadsRequest
-	(*UpdateBeadRequest)(nil),        // 5: beads.v1.UpdateBeadRequest
-	(*CloseBeadRequest)(nil),         // 6: beads.v1.CloseBeadRequest
-	(*DeleteBeadRequest)(nil),        // 7: beads.v1.DeleteBeadRequest
-	(*AddDependencyRequest)(nil),     // 8: beads.v1.AddDependencyRequest
-	(*RemoveDependencyRequest)(nil),  // 9: beads.v1.RemoveDependencyRequest
-	(*GetDependenciesRequest)(nil),   // 10: beads.v1.GetDependenciesRequest
-	(*AddLabelRequest)(nil),          // 11: beads.v1.AddLabelRequest
-	(*RemoveLabelRequest)(nil),       // 12: beads.v1.RemoveLabelRequest
-	(*GetLabelsRequest)(nil),         // 13: beads.v1.GetLabelsRequest
-	(*AddCommentRequest)(nil),        // 14: beads.v1.AddCommentRequest
-	(*GetCommentsRequest)(nil),       // 15: beads.v1.GetCommentsRequest
-	(*GetEventsRequest)(nil),         // 16: beads.v1.GetEventsRequest
-	(*SetConfigRequest)(nil),         // 17: beads.v1.SetConfigRequest
-	(*GetConfigRequest)(nil),         // 18: beads.v1.GetConfigRequest
-	(*ListConfigsRequest)(nil),       // 19: beads.v1.ListConfigsRequest
-	(*DeleteConfigRequest)(nil),      // 20: beads.v1.DeleteConfigRequest
-	(*CreateBeadResponse)(nil),       // 21: beads.v1.CreateBeadResponse
-	(*GetBeadResponse)(nil),          // 22: beads.v1.GetBeadResponse
-	(*ListBeadsResponse)(nil),        // 23: beads.v1.ListBeadsResponse
-	(*UpdateBeadResponse)(nil),       // 24: beads.v1.UpdateBeadResponse
-	(*CloseBeadResponse)(nil),        // 25: beads.v1.CloseBeadResponse
-	(*DeleteBeadResponse)(nil),       // 26: beads.v1.DeleteBeadResponse
-	(*AddDependencyResponse)(nil),    // 27: beads.v1.AddDependencyResponse
-	(*RemoveDependencyResponse)(nil), // 28: beads.v1.RemoveDependencyResponse
-	(*GetDependenciesResponse)(nil),  // 29: beads.v1.GetDependenciesResponse
-	(*AddLabelResponse)(nil),         // 30: beads.v1.AddLabelResponse
-	(*RemoveLabelResponse)(nil),      // 31: beads.v1.RemoveLabelResponse
-	(*GetLabelsResponse)(nil),        // 32: beads.v1.GetLabelsResponse
-	(*AddCommentResponse)(nil),       // 33: beads.v1.AddCommentResponse
-	(*GetCommentsResponse)(nil),      // 34: beads.v1.GetCommentsResponse
-	(*GetEventsResponse)(nil),        // 35: beads.v1.GetEventsResponse
-	(*SetConfigResponse)(nil),        // 36: beads.v1.SetConfigResponse
-	(*GetConfigResponse)(nil),        // 37: beads.v1.GetConfigResponse
-	(*ListConfigsResponse)(nil),      // 38: beads.v1.ListConfigsResponse
-	(*DeleteConfigResponse)(nil),     // 39: beads.v1.DeleteConfigResponse
+	(*HealthRequest)(nil),                 // 0: beads.v1.HealthRequest
+	(*HealthResponse)(nil),                // 1: beads.v1.HealthResponse
+	(*ListAlertsRequest)(nil),             // 2: beads.v1.ListAlertsRequest
+	(*ListAlertsResponse)(nil),            // 3: beads.v1.ListAlertsResponse
+	(*Alert)(nil),                         // 4: beads.v1.Alert
+	(*CreateBeadRequest)(nil),             // 5: beads.v1.CreateBeadRequest
+	(*GetBeadRequest)(nil),                // 6: beads.v1.GetBeadRequest
+	(*ListBeadsRequest)(nil),              // 7: beads.v1.ListBeadsRequest
+	(*PopQueueRequest)(nil),               // 8: beads.v1.PopQueueRequest
+	(*UpdateBeadRequest)(nil),             // 9: beads.v1.UpdateBeadRequest
+	(*CloseBeadRequest)(nil),              // 10: beads.v1.CloseBeadRequest
+	(*ResolveDecisionRequest)(nil),        // 11: beads.v1.ResolveDecisionRequest
+	(*GetDecisionContextRequest)(nil),     // 12: beads.v1.GetDecisionContextRequest
+	(*DeleteBeadRequest)(nil),             // 13: beads.v1.DeleteBeadRequest
+	(*MergeBeadRequest)(nil),              // 14: beads.v1.MergeBeadRequest
+	(*CloneBeadRequest)(nil),              // 15: beads.v1.CloneBeadRequest
+	(*FindSimilarBeadsRequest)(nil),       // 16: beads.v1.FindSimilarBeadsRequest
+	(*AddDependencyRequest)(nil),          // 17: beads.v1.AddDependencyRequest
+	(*UpdateDependencyRequest)(nil),       // 18: beads.v1.UpdateDependencyRequest
+	(*RemoveDependencyRequest)(nil),       // 19: beads.v1.RemoveDependencyRequest
+	(*GetDependenciesRequest)(nil),        // 20: beads.v1.GetDependenciesRequest
+	(*AddRelationRequest)(nil),            // 21: beads.v1.AddRelationRequest
+	(*ListRelationsRequest)(nil),          // 22: beads.v1.ListRelationsRequest
+	(*AddExternalDepRequest)(nil),         // 23: beads.v1.AddExternalDepRequest
+	(*ListExternalDepsRequest)(nil),       // 24: beads.v1.ListExternalDepsRequest
+	(*UpdateExternalDepRequest)(nil),      // 25: beads.v1.UpdateExternalDepRequest
+	(*RemoveExternalDepRequest)(nil),      // 26: beads.v1.RemoveExternalDepRequest
+	(*LinkCommitRequest)(nil),             // 27: beads.v1.LinkCommitRequest
+	(*ListCommitsRequest)(nil),            // 28: beads.v1.ListCommitsRequest
+	(*AddLabelRequest)(nil),               // 29: beads.v1.AddLabelRequest
+	(*RemoveLabelRequest)(nil),            // 30: beads.v1.RemoveLabelRequest
+	(*GetLabelsRequest)(nil),              // 31: beads.v1.GetLabelsRequest
+	(*AddAliasRequest)(nil),               // 32: beads.v1.AddAliasRequest
+	(*RemoveAliasRequest)(nil),            // 33: beads.v1.RemoveAliasRequest
+	(*ListAliasesRequest)(nil),            // 34: beads.v1.ListAliasesRequest
+	(*AddCommentRequest)(nil),             // 35: beads.v1.AddCommentRequest
+	(*GetCommentsRequest)(nil),            // 36: beads.v1.GetCommentsRequest
+	(*AddNoteRequest)(nil),                // 37: beads.v1.AddNoteRequest
+	(*GetNotesRequest)(nil),               // 38: beads.v1.GetNotesRequest
+	(*GetEventsRequest)(nil),              // 39: beads.v1.GetEventsRequest
+	(*GetActivityRequest)(nil),            // 40: beads.v1.GetActivityRequest
+	(*WatchBeadRequest)(nil),              // 41: beads.v1.WatchBeadRequest
+	(*UnwatchBeadRequest)(nil),            // 42: beads.v1.UnwatchBeadRequest
+	(*ListNotificationsRequest)(nil),      // 43: beads.v1.ListNotificationsRequest
+	(*MarkNotificationsReadRequest)(nil),  // 44: beads.v1.MarkNotificationsReadRequest
+	(*GetDigestRequest)(nil),              // 45: beads.v1.GetDigestRequest
+	(*SetConfigRequest)(nil),              // 46: beads.v1.SetConfigRequest
+	(*GetConfigRequest)(nil),              // 47: beads.v1.GetConfigRequest
+	(*ListConfigsRequest)(nil),            // 48: beads.v1.ListConfigsRequest
+	(*DeleteConfigRequest)(nil),           // 49: beads.v1.DeleteConfigRequest
+	(*GetConfigHistoryRequest)(nil),       // 50: beads.v1.GetConfigHistoryRequest
+	(*RollbackConfigRequest)(nil),         // 51: beads.v1.RollbackConfigRequest
+	(*GetServerInfoRequest)(nil),          // 52: beads.v1.GetServerInfoRequest
+	(*RegisterAgentRequest)(nil),          // 53: beads.v1.RegisterAgentRequest
+	(*ListAgentsRequest)(nil),             // 54: beads.v1.ListAgentsRequest
+	(*ListGatesRequest)(nil),              // 55: beads.v1.ListGatesRequest
+	(*SetGateRequest)(nil),                // 56: beads.v1.SetGateRequest
+	(*WaiveGateRequest)(nil),              // 57: beads.v1.WaiveGateRequest
+	(*EmitHookRequest)(nil),               // 58: beads.v1.EmitHookRequest
+	(*ListAdviceRequest)(nil),             // 59: beads.v1.ListAdviceRequest
+	(*AckAdviceRequest)(nil),              // 60: beads.v1.AckAdviceRequest
+	(*CreateBeadResponse)(nil),            // 61: beads.v1.CreateBeadResponse
+	(*GetBeadResponse)(nil),               // 62: beads.v1.GetBeadResponse
+	(*ListBeadsResponse)(nil),             // 63: beads.v1.ListBeadsResponse
+	(*ListBlockedBeadsResponse)(nil),      // 64: beads.v1.ListBlockedBeadsResponse
+	(*PopQueueResponse)(nil),              // 65: beads.v1.PopQueueResponse
+	(*UpdateBeadResponse)(nil),            // 66: beads.v1.UpdateBeadResponse
+	(*CloseBeadResponse)(nil),             // 67: beads.v1.CloseBeadResponse
+	(*ResolveDecisionResponse)(nil),       // 68: beads.v1.ResolveDecisionResponse
+	(*GetDecisionContextResponse)(nil),    // 69: beads.v1.GetDecisionContextResponse
+	(*DeleteBeadResponse)(nil),            // 70: beads.v1.DeleteBeadResponse
+	(*MergeBeadResponse)(nil),             // 71: beads.v1.MergeBeadResponse
+	(*CloneBeadResponse)(nil),             // 72: beads.v1.CloneBeadResponse
+	(*FindSimilarBeadsResponse)(nil),      // 73: beads.v1.FindSimilarBeadsResponse
+	(*AddDependencyResponse)(nil),         // 74: beads.v1.AddDependencyResponse
+	(*UpdateDependencyResponse)(nil),      // 75: beads.v1.UpdateDependencyResponse
+	(*RemoveDependencyResponse)(nil),      // 76: beads.v1.RemoveDependencyResponse
+	(*GetDependenciesResponse)(nil),       // 77: beads.v1.GetDependenciesResponse
+	(*AddRelationResponse)(nil),           // 78: beads.v1.AddRelationResponse
+	(*ListRelationsResponse)(nil),         // 79: beads.v1.ListRelationsResponse
+	(*AddExternalDepResponse)(nil),        // 80: beads.v1.AddExternalDepResponse
+	(*ListExternalDepsResponse)(nil),      // 81: beads.v1.ListExternalDepsResponse
+	(*UpdateExternalDepResponse)(nil),     // 82: beads.v1.UpdateExternalDepResponse
+	(*RemoveExternalDepResponse)(nil),     // 83: beads.v1.RemoveExternalDepResponse
+	(*LinkCommitResponse)(nil),            // 84: beads.v1.LinkCommitResponse
+	(*ListCommitsResponse)(nil),           // 85: beads.v1.ListCommitsResponse
+	(*AddLabelResponse)(nil),              // 86: beads.v1.AddLabelResponse
+	(*RemoveLabelResponse)(nil),           // 87: beads.v1.RemoveLabelResponse
+	(*GetLabelsResponse)(nil),             // 88: beads.v1.GetLabelsResponse
+	(*AddAliasResponse)(nil),              // 89: beads.v1.AddAliasResponse
+	(*RemoveAliasResponse)(nil),           // 90: beads.v1.RemoveAliasResponse
+	(*ListAliasesResponse)(nil),           // 91: beads.v1.ListAliasesResponse
+	(*AddCommentResponse)(nil),            // 92: beads.v1.AddCommentResponse
+	(*GetCommentsResponse)(nil),           // 93: beads.v1.GetCommentsResponse
+	(*AddNoteResponse)(nil),               // 94: beads.v1.AddNoteResponse
+	(*GetNotesResponse)(nil),              // 95: beads.v1.GetNotesResponse
+	(*GetEventsResponse)(nil),             // 96: beads.v1.GetEventsResponse
+	(*GetActivityResponse)(nil),           // 97: beads.v1.GetActivityResponse
+	(*WatchBeadResponse)(nil),             // 98: beads.v1.WatchBeadResponse
+	(*UnwatchBeadResponse)(nil),           // 99: beads.v1.UnwatchBeadResponse
+	(*ListNotificationsResponse)(nil),     // 100: beads.v1.ListNotificationsResponse
+	(*MarkNotificationsReadResponse)(nil), // 101: beads.v1.MarkNotificationsReadResponse
+	(*GetDigestResponse)(nil),             // 102: beads.v1.GetDigestResponse
+	(*SetConfigResponse)(nil),             // 103: beads.v1.SetConfigResponse
+	(*GetConfigResponse)(nil),             // 104: beads.v1.GetConfigResponse
+	(*ListConfigsResponse)(nil),           // 105: beads.v1.ListConfigsResponse
+	(*DeleteConfigResponse)(nil),          // 106: beads.v1.DeleteConfigResponse
+	(*GetConfigHistoryResponse)(nil),      // 107: beads.v1.GetConfigHistoryResponse
+	(*RollbackConfigResponse)(nil),        // 108: beads.v1.RollbackConfigResponse
+	(*GetServerInfoResponse)(nil),         // 109: beads.v1.GetServerInfoResponse
+	(*RegisterAgentResponse)(nil),         // 110: beads.v1.RegisterAgentResponse
+	(*ListAgentsResponse)(nil),            // 111: beads.v1.ListAgentsResponse
+	(*ListGatesResponse)(nil),             // 112: beads.v1.ListGatesResponse
+	(*SetGateResponse)(nil),               // 113: beads.v1.SetGateResponse
+	(*WaiveGateResponse)(nil),             // 114: beads.v1.WaiveGateResponse
+	(*EmitHookResponse)(nil),              // 115: beads.v1.EmitHookResponse
+	(*ListAdviceResponse)(nil),            // 116: beads.v1.ListAdviceResponse
+	(*AckAdviceResponse)(nil),             // 117: beads.v1.AckAdviceResponse
 }
 var file_beads_v1_service_proto_depIdxs = []int32{
-	2,  // 0: beads.v1.BeadsService.CreateBead:input_type -> beads.v1.CreateBeadRequest
-	3,  // 1: beads.v1.BeadsService.GetBead:input_type -> beads.v1.GetBeadRequest
-	4,  // 2: beads.v1.BeadsService.ListBeads:input_type -> beads.v1.ListBeadsRequest
-	5,  // 3: beads.v1.BeadsService.UpdateBead:input_type -> beads.v1.UpdateBeadRequest
-	6,  // 4: beads.v1.BeadsService.CloseBead:input_type -> beads.v1.CloseBeadRequest
-	7,  // 5: beads.v1.BeadsService.DeleteBead:input_type -> beads.v1.DeleteBeadRequest
-	8,  // 6: beads.v1.BeadsService.AddDependency:input_type -> beads.v1.AddDependencyRequest
-	9,  // 7: beads.v1.BeadsService.RemoveDependency:input_type -> beads.v1.RemoveDependencyRequest
-	10, // 8: beads.v1.BeadsService.GetDependencies:input_type -> beads.v1.GetDependenciesRequest
-	11, // 9: beads.v1.BeadsService.AddLabel:input_type -> beads.v1.AddLabelRequest
-	12, // 10: beads.v1.BeadsService.RemoveLabel:input_type -> beads.v1.RemoveLabelRequest
-	13, // 11: beads.v1.BeadsService.GetLabels:input_type -> beads.v1.GetLabelsRequest
-	14, // 12: beads.v1.BeadsService.AddComment:input_type -> beads.v1.AddCommentRequest
-	15, // 13: beads.v1.BeadsService.GetComments:input_type -> beads.v1.GetCommentsRequest
-	16, // 14: beads.v1.BeadsService.GetEvents:input_type -> beads.v1.GetEventsRequest
-	17, // 15: beads.v1.BeadsService.SetConfig:input_type -> beads.v1.SetConfigRequest
-	18, // 16: beads.v1.BeadsService.GetConfig:input_type -> beads.v1.GetConfigRequest
-	19, // 17: beads.v1.BeadsService.ListConfigs:input_type -> beads.v1.ListConfigsRequest
-	20, // 18: beads.v1.BeadsService.DeleteConfig:input_type -> beads.v1.DeleteConfigRequest
-	0,  // 19: beads.v1.BeadsService.Health:input_type -> beads.v1.HealthRequest
-	21, // 20: beads.v1.BeadsService.CreateBead:output_type -> beads.v1.CreateBeadResponse
-	22, // 21: beads.v1.BeadsService.GetBead:output_type -> beads.v1.GetBeadResponse
-	23, // 22: beads.v1.BeadsService.ListBeads:output_type -> beads.v1.ListBeadsResponse
-	24, // 23: beads.v1.BeadsService.UpdateBead:output_type -> beads.v1.UpdateBeadResponse
-	25, // 24: beads.v1.BeadsService.CloseBead:output_type -> beads.v1.CloseBeadResponse
-	26, // 25: beads.v1.BeadsService.DeleteBead:output_type -> beads.v1.DeleteBeadResponse
-	27, // 26: beads.v1.BeadsService.AddDependency:output_type -> beads.v1.AddDependencyResponse
-	28, // 27: beads.v1.BeadsService.RemoveDependency:output_type -> beads.v1.RemoveDependencyResponse
-	29, // 28: beads.v1.BeadsService.GetDependencies:output_type -> beads.v1.GetDependenciesResponse
-	30, // 29: beads.v1.BeadsService.AddLabel:output_type -> beads.v1.AddLabelResponse
-	31, // 30: beads.v1.BeadsService.RemoveLabel:output_type -> beads.v1.RemoveLabelResponse
-	32, // 31: beads.v1.BeadsService.GetLabels:output_type -> beads.v1.GetLabelsResponse
-	33, // 32: beads.v1.BeadsService.AddComment:output_type -> beads.v1.AddCommentResponse
-	34, // 33: beads.v1.BeadsService.GetComments:output_type -> beads.v1.GetCommentsResponse
-	35, // 34: beads.v1.BeadsService.GetEvents:output_type -> beads.v1.GetEventsResponse
-	36, // 35: beads.v1.BeadsService.SetConfig:output_type -> beads.v1.SetConfigResponse
-	37, // 36: beads.v1.BeadsService.GetConfig:output_type -> beads.v1.GetConfigResponse
-	38, // 37: beads.v1.BeadsService.ListConfigs:output_type -> beads.v1.ListConfigsResponse
-	39, // 38: beads.v1.BeadsService.DeleteConfig:output_type -> beads.v1.DeleteConfigResponse
-	1,  // 39: beads.v1.BeadsService.Health:output_type -> beads.v1.HealthResponse
-	20, // [20:40] is the sub-list for method output_type
-	0,  // [0:20] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+	4,   // 0: beads.v1.ListAlertsResponse.alerts:type_name -> beads.v1.Alert
+	5,   // 1: beads.v1.BeadsService.CreateBead:input_type -> beads.v1.CreateBeadRequest
+	6,   // 2: beads.v1.BeadsService.GetBead:input_type -> beads.v1.GetBeadRequest
+	7,   // 3: beads.v1.BeadsService.ListBeads:input_type -> beads.v1.ListBeadsRequest
+	7,   // 4: beads.v1.BeadsService.ListReadyBeads:input_type -> beads.v1.ListBeadsRequest
+	7,   // 5: beads.v1.BeadsService.ListBlockedBeads:input_type -> beads.v1.ListBeadsRequest
+	8,   // 6: beads.v1.BeadsService.PopQueue:input_type -> beads.v1.PopQueueRequest
+	9,   // 7: beads.v1.BeadsService.UpdateBead:input_type -> beads.v1.UpdateBeadRequest
+	10,  // 8: beads.v1.BeadsService.CloseBead:input_type -> beads.v1.CloseBeadRequest
+	11,  // 9: beads.v1.BeadsService.ResolveDecision:input_type -> beads.v1.ResolveDecisionRequest
+	12,  // 10: beads.v1.BeadsService.GetDecisionContext:input_type -> beads.v1.GetDecisionContextRequest
+	13,  // 11: beads.v1.BeadsService.DeleteBead:input_type -> beads.v1.DeleteBeadRequest
+	14,  // 12: beads.v1.BeadsService.MergeBead:input_type -> beads.v1.MergeBeadRequest
+	15,  // 13: beads.v1.BeadsService.CloneBead:input_type -> beads.v1.CloneBeadRequest
+	16,  // 14: beads.v1.BeadsService.FindSimilarBeads:input_type -> beads.v1.FindSimilarBeadsRequest
+	17,  // 15: beads.v1.BeadsService.AddDependency:input_type -> beads.v1.AddDependencyRequest
+	18,  // 16: beads.v1.BeadsService.UpdateDependency:input_type -> beads.v1.UpdateDependencyRequest
+	19,  // 17: beads.v1.BeadsService.RemoveDependency:input_type -> beads.v1.RemoveDependencyRequest
+	20,  // 18: beads.v1.BeadsService.GetDependencies:input_type -> beads.v1.GetDependenciesRequest
+	21,  // 19: beads.v1.BeadsService.AddRelation:input_type -> beads.v1.AddRelationRequest
+	22,  // 20: beads.v1.BeadsService.ListRelations:input_type -> beads.v1.ListRelationsRequest
+	23,  // 21: beads.v1.BeadsService.AddExternalDep:input_type -> beads.v1.AddExternalDepRequest
+	24,  // 22: beads.v1.BeadsService.ListExternalDeps:input_type -> beads.v1.ListExternalDepsRequest
+	25,  // 23: beads.v1.BeadsService.UpdateExternalDep:input_type -> beads.v1.UpdateExternalDepRequest
+	26,  // 24: beads.v1.BeadsService.RemoveExternalDep:input_type -> beads.v1.RemoveExternalDepRequest
+	27,  // 25: beads.v1.BeadsService.LinkCommit:input_type -> beads.v1.LinkCommitRequest
+	28,  // 26: beads.v1.BeadsService.ListCommits:input_type -> beads.v1.ListCommitsRequest
+	29,  // 27: beads.v1.BeadsService.AddLabel:input_type -> beads.v1.AddLabelRequest
+	30,  // 28: beads.v1.BeadsService.RemoveLabel:input_type -> beads.v1.RemoveLabelRequest
+	31,  // 29: beads.v1.BeadsService.GetLabels:input_type -> beads.v1.GetLabelsRequest
+	32,  // 30: beads.v1.BeadsService.AddAlias:input_type -> beads.v1.AddAliasRequest
+	33,  // 31: beads.v1.BeadsService.RemoveAlias:input_type -> beads.v1.RemoveAliasRequest
+	34,  // 32: beads.v1.BeadsService.ListAliases:input_type -> beads.v1.ListAliasesRequest
+	35,  // 33: beads.v1.BeadsService.AddComment:input_type -> beads.v1.AddCommentRequest
+	36,  // 34: beads.v1.BeadsService.GetComments:input_type -> beads.v1.GetCommentsRequest
+	37,  // 35: beads.v1.BeadsService.AddNote:input_type -> beads.v1.AddNoteRequest
+	38,  // 36: beads.v1.BeadsService.GetNotes:input_type -> beads.v1.GetNotesRequest
+	39,  // 37: beads.v1.BeadsService.GetEvents:input_type -> beads.v1.GetEventsRequest
+	40,  // 38: beads.v1.BeadsService.GetActivity:input_type -> beads.v1.GetActivityRequest
+	41,  // 39: beads.v1.BeadsService.WatchBead:input_type -> beads.v1.WatchBeadRequest
+	42,  // 40: beads.v1.BeadsService.UnwatchBead:input_type -> beads.v1.UnwatchBeadRequest
+	43,  // 41: beads.v1.BeadsService.ListNotifications:input_type -> beads.v1.ListNotificationsRequest
+	44,  // 42: beads.v1.BeadsService.MarkNotificationsRead:input_type -> beads.v1.MarkNotificationsReadRequest
+	45,  // 43: beads.v1.BeadsService.GetDigest:input_type -> beads.v1.GetDigestRequest
+	46,  // 44: beads.v1.BeadsService.SetConfig:input_type -> beads.v1.SetConfigRequest
+	47,  // 45: beads.v1.BeadsService.GetConfig:input_type -> beads.v1.GetConfigRequest
+	48,  // 46: beads.v1.BeadsService.ListConfigs:input_type -> beads.v1.ListConfigsRequest
+	49,  // 47: beads.v1.BeadsService.DeleteConfig:input_type -> beads.v1.DeleteConfigRequest
+	50,  // 48: beads.v1.BeadsService.GetConfigHistory:input_type -> beads.v1.GetConfigHistoryRequest
+	51,  // 49: beads.v1.BeadsService.RollbackConfig:input_type -> beads.v1.RollbackConfigRequest
+	2,   // 50: beads.v1.BeadsService.ListAlerts:input_type -> beads.v1.ListAlertsRequest
+	0,   // 51: beads.v1.BeadsService.Health:input_type -> beads.v1.HealthRequest
+	52,  // 52: beads.v1.BeadsService.GetServerInfo:input_type -> beads.v1.GetServerInfoRequest
+	53,  // 53: beads.v1.BeadsService.RegisterAgent:input_type -> beads.v1.RegisterAgentRequest
+	54,  // 54: beads.v1.BeadsService.ListAgents:input_type -> beads.v1.ListAgentsRequest
+	55,  // 55: beads.v1.BeadsService.ListGates:input_type -> beads.v1.ListGatesRequest
+	56,  // 56: beads.v1.BeadsService.SetGate:input_type -> beads.v1.SetGateRequest
+	57,  // 57: beads.v1.BeadsService.WaiveGate:input_type -> beads.v1.WaiveGateRequest
+	58,  // 58: beads.v1.BeadsService.EmitHook:input_type -> beads.v1.EmitHookRequest
+	59,  // 59: beads.v1.BeadsService.ListAdvice:input_type -> beads.v1.ListAdviceRequest
+	60,  // 60: beads.v1.BeadsService.AckAdvice:input_type -> beads.v1.AckAdviceRequest
+	61,  // 61: beads.v1.BeadsService.CreateBead:output_type -> beads.v1.CreateBeadResponse
+	62,  // 62: beads.v1.BeadsService.GetBead:output_type -> beads.v1.GetBeadResponse
+	63,  // 63: beads.v1.BeadsService.ListBeads:output_type -> beads.v1.ListBeadsResponse
+	63,  // 64: beads.v1.BeadsService.ListReadyBeads:output_type -> beads.v1.ListBeadsResponse
+	64,  // 65: beads.v1.BeadsService.ListBlockedBeads:output_type -> beads.v1.ListBlockedBeadsResponse
+	65,  // 66: beads.v1.BeadsService.PopQueue:output_type -> beads.v1.PopQueueResponse
+	66,  // 67: beads.v1.BeadsService.UpdateBead:output_type -> beads.v1.UpdateBeadResponse
+	67,  // 68: beads.v1.BeadsService.CloseBead:output_type -> beads.v1.CloseBeadResponse
+	68,  // 69: beads.v1.BeadsService.ResolveDecision:output_type -> beads.v1.ResolveDecisionResponse
+	69,  // 70: beads.v1.BeadsService.GetDecisionContext:output_type -> beads.v1.GetDecisionContextResponse
+	70,  // 71: beads.v1.BeadsService.DeleteBead:output_type -> beads.v1.DeleteBeadResponse
+	71,  // 72: beads.v1.BeadsService.MergeBead:output_type -> beads.v1.MergeBeadResponse
+	72,  // 73: beads.v1.BeadsService.CloneBead:output_type -> beads.v1.CloneBeadResponse
+	73,  // 74: beads.v1.BeadsService.FindSimilarBeads:output_type -> beads.v1.FindSimilarBeadsResponse
+	74,  // 75: beads.v1.BeadsService.AddDependency:output_type -> beads.v1.AddDependencyResponse
+	75,  // 76: beads.v1.BeadsService.UpdateDependency:output_type -> beads.v1.UpdateDependencyResponse
+	76,  // 77: beads.v1.BeadsService.RemoveDependency:output_type -> beads.v1.RemoveDependencyResponse
+	77,  // 78: beads.v1.BeadsService.GetDependencies:output_type -> beads.v1.GetDependenciesResponse
+	78,  // 79: beads.v1.BeadsService.AddRelation:output_type -> beads.v1.AddRelationResponse
+	79,  // 80: beads.v1.BeadsService.ListRelations:output_type -> beads.v1.ListRelationsResponse
+	80,  // 81: beads.v1.BeadsService.AddExternalDep:output_type -> beads.v1.AddExternalDepResponse
+	81,  // 82: beads.v1.BeadsService.ListExternalDeps:output_type -> beads.v1.ListExternalDepsResponse
+	82,  // 83: beads.v1.BeadsService.UpdateExternalDep:output_type -> beads.v1.UpdateExternalDepResponse
+	83,  // 84: beads.v1.BeadsService.RemoveExternalDep:output_type -> beads.v1.RemoveExternalDepResponse
+	84,  // 85: beads.v1.BeadsService.LinkCommit:output_type -> beads.v1.LinkCommitResponse
+	85,  // 86: beads.v1.BeadsService.ListCommits:output_type -> beads.v1.ListCommitsResponse
+	86,  // 87: beads.v1.BeadsService.AddLabel:output_type -> beads.v1.AddLabelResponse
+	87,  // 88: beads.v1.BeadsService.RemoveLabel:output_type -> beads.v1.RemoveLabelResponse
+	88,  // 89: beads.v1.BeadsService.GetLabels:output_type -> beads.v1.GetLabelsResponse
+	89,  // 90: beads.v1.BeadsService.AddAlias:output_type -> beads.v1.AddAliasResponse
+	90,  // 91: beads.v1.BeadsService.RemoveAlias:output_type -> beads.v1.RemoveAliasResponse
+	91,  // 92: beads.v1.BeadsService.ListAliases:output_type -> beads.v1.ListAliasesResponse
+	92,  // 93: beads.v1.BeadsService.AddComment:output_type -> beads.v1.AddCommentResponse
+	93,  // 94: beads.v1.BeadsService.GetComments:output_type -> beads.v1.GetCommentsResponse
+	94,  // 95: beads.v1.BeadsService.AddNote:output_type -> beads.v1.AddNoteResponse
+	95,  // 96: beads.v1.BeadsService.GetNotes:output_type -> beads.v1.GetNotesResponse
+	96,  // 97: beads.v1.BeadsService.GetEvents:output_type -> beads.v1.GetEventsResponse
+	97,  // 98: beads.v1.BeadsService.GetActivity:output_type -> beads.v1.GetActivityResponse
+	98,  // 99: beads.v1.BeadsService.WatchBead:output_type -> beads.v1.WatchBeadResponse
+	99,  // 100: beads.v1.BeadsService.UnwatchBead:output_type -> beads.v1.UnwatchBeadResponse
+	100, // 101: beads.v1.BeadsService.ListNotifications:output_type -> beads.v1.ListNotificationsResponse
+	101, // 102: beads.v1.BeadsService.MarkNotificationsRead:output_type -> beads.v1.MarkNotificationsReadResponse
+	102, // 103: beads.v1.BeadsService.GetDigest:output_type -> beads.v1.GetDigestResponse
+	103, // 104: beads.v1.BeadsService.SetConfig:output_type -> beads.v1.SetConfigResponse
+	104, // 105: beads.v1.BeadsService.GetConfig:output_type -> beads.v1.GetConfigResponse
+	105, // 106: beads.v1.BeadsService.ListConfigs:output_type -> beads.v1.ListConfigsResponse
+	106, // 107: beads.v1.BeadsService.DeleteConfig:output_type -> beads.v1.DeleteConfigResponse
+	107, // 108: beads.v1.BeadsService.GetConfigHistory:output_type -> beads.v1.GetConfigHistoryResponse
+	108, // 109: beads.v1.BeadsService.RollbackConfig:output_type -> beads.v1.RollbackConfigResponse
+	3,   // 110: beads.v1.BeadsService.ListAlerts:output_type -> beads.v1.ListAlertsResponse
+	1,   // 111: beads.v1.BeadsService.Health:output_type -> beads.v1.HealthResponse
+	109, // 112: beads.v1.BeadsService.GetServerInfo:output_type -> beads.v1.GetServerInfoResponse
+	110, // 113: beads.v1.BeadsService.RegisterAgent:output_type -> beads.v1.RegisterAgentResponse
+	111, // 114: beads.v1.BeadsService.ListAgents:output_type -> beads.v1.ListAgentsResponse
+	112, // 115: beads.v1.BeadsService.ListGates:output_type -> beads.v1.ListGatesResponse
+	113, // 116: beads.v1.BeadsService.SetGate:output_type -> beads.v1.SetGateResponse
+	114, // 117: beads.v1.BeadsService.WaiveGate:output_type -> beads.v1.WaiveGateResponse
+	115, // 118: beads.v1.BeadsService.EmitHook:output_type -> beads.v1.EmitHookResponse
+	116, // 119: beads.v1.BeadsService.ListAdvice:output_type -> beads.v1.ListAdviceResponse
+	117, // 120: beads.v1.BeadsService.AckAdvice:output_type -> beads.v1.AckAdviceResponse
+	61,  // [61:121] is the sub-list for method output_type
+	1,   // [1:61] is the sub-list for method input_type
+	1,   // [1:1] is the sub-list for extension type_name
+	1,   // [1:1] is the sub-list for extension extendee
+	0,   // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_beads_v1_service_proto_init() }
@@ -247,13 +541,14 @@ func file_beads_v1_service_proto_init() {
 	}
 	file_beads_v1_beads_proto_init()
 	file_beads_v1_config_proto_init()
+	file_beads_v1_types_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_beads_v1_service_proto_rawDesc), len(file_beads_v1_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,26 +19,66 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BeadsService_CreateBead_FullMethodName       = "/beads.v1.BeadsService/CreateBead"
-	BeadsService_GetBead_FullMethodName          = "/beads.v1.BeadsService/GetBead"
-	BeadsService_ListBeads_FullMethodName        = "/beads.v1.BeadsService/ListBeads"
-	BeadsService_UpdateBead_FullMethodName       = "/beads.v1.BeadsService/UpdateBead"
-	BeadsService_CloseBead_FullMethodName        = "/beads.v1.BeadsService/CloseBead"
-	BeadsService_DeleteBead_FullMethodName       = "/beads.v1.BeadsService/DeleteBead"
-	BeadsService_AddDependency_FullMethodName    = "/beads.v1.BeadsService/AddDependency"
-	BeadsService_RemoveDependency_FullMethodName = "/beads.v1.BeadsService/RemoveDependency"
-	BeadsService_GetDependencies_FullMethodName  = "/beads.v1.BeadsService/GetDependencies"
-	BeadsService_AddLabel_FullMethodName         = "/beads.v1.BeadsService/AddLabel"
-	BeadsService_RemoveLabel_FullMethodName      = "/beads.v1.BeadsService/RemoveLabel"
-	BeadsService_GetLabels_FullMethodName        = "/beads.v1.BeadsService/GetLabels"
-	BeadsService_AddComment_FullMethodName       = "/beads.v1.BeadsService/AddComment"
-	BeadsService_GetComments_FullMethodName      = "/beads.v1.BeadsService/GetComments"
-	BeadsService_GetEvents_FullMethodName        = "/beads.v1.BeadsService/GetEvents"
-	BeadsService_SetConfig_FullMethodName        = "/beads.v1.BeadsService/SetConfig"
-	BeadsService_GetConfig_FullMethodName        = "/beads.v1.BeadsService/GetConfig"
-	BeadsService_ListConfigs_FullMethodName      = "/beads.v1.BeadsService/ListConfigs"
-	BeadsService_DeleteConfig_FullMethodName     = "/beads.v1.BeadsService/DeleteConfig"
-	BeadsService_Health_FullMethodName           = "/beads.v1.BeadsService/Health"
+	BeadsService_CreateBead_FullMethodName            = "/beads.v1.BeadsService/CreateBead"
+	BeadsService_GetBead_FullMethodName               = "/beads.v1.BeadsService/GetBead"
+	BeadsService_ListBeads_FullMethodName             = "/beads.v1.BeadsService/ListBeads"
+	BeadsService_ListReadyBeads_FullMethodName        = "/beads.v1.BeadsService/ListReadyBeads"
+	BeadsService_ListBlockedBeads_FullMethodName      = "/beads.v1.BeadsService/ListBlockedBeads"
+	BeadsService_PopQueue_FullMethodName              = "/beads.v1.BeadsService/PopQueue"
+	BeadsService_UpdateBead_FullMethodName            = "/beads.v1.BeadsService/UpdateBead"
+	BeadsService_CloseBead_FullMethodName             = "/beads.v1.BeadsService/CloseBead"
+	BeadsService_ResolveDecision_FullMethodName       = "/beads.v1.BeadsService/ResolveDecision"
+	BeadsService_GetDecisionContext_FullMethodName    = "/beads.v1.BeadsService/GetDecisionContext"
+	BeadsService_DeleteBead_FullMethodName            = "/beads.v1.BeadsService/DeleteBead"
+	BeadsService_MergeBead_FullMethodName             = "/beads.v1.BeadsService/MergeBead"
+	BeadsService_CloneBead_FullMethodName             = "/beads.v1.BeadsService/CloneBead"
+	BeadsService_FindSimilarBeads_FullMethodName      = "/beads.v1.BeadsService/FindSimilarBeads"
+	BeadsService_AddDependency_FullMethodName         = "/beads.v1.BeadsService/AddDependency"
+	BeadsService_UpdateDependency_FullMethodName      = "/beads.v1.BeadsService/UpdateDependency"
+	BeadsService_RemoveDependency_FullMethodName      = "/beads.v1.BeadsService/RemoveDependency"
+	BeadsService_GetDependencies_FullMethodName       = "/beads.v1.BeadsService/GetDependencies"
+	BeadsService_AddRelation_FullMethodName           = "/beads.v1.BeadsService/AddRelation"
+	BeadsService_ListRelations_FullMethodName         = "/beads.v1.BeadsService/ListRelations"
+	BeadsService_AddExternalDep_FullMethodName        = "/beads.v1.BeadsService/AddExternalDep"
+	BeadsService_ListExternalDeps_FullMethodName      = "/beads.v1.BeadsService/ListExternalDeps"
+	BeadsService_UpdateExternalDep_FullMethodName     = "/beads.v1.BeadsService/UpdateExternalDep"
+	BeadsService_RemoveExternalDep_FullMethodName     = "/beads.v1.BeadsService/RemoveExternalDep"
+	BeadsService_LinkCommit_FullMethodName            = "/beads.v1.BeadsService/LinkCommit"
+	BeadsService_ListCommits_FullMethodName           = "/beads.v1.BeadsService/ListCommits"
+	BeadsService_AddLabel_FullMethodName              = "/beads.v1.BeadsService/AddLabel"
+	BeadsService_RemoveLabel_FullMethodName           = "/beads.v1.BeadsService/RemoveLabel"
+	BeadsService_GetLabels_FullMethodName             = "/beads.v1.BeadsService/GetLabels"
+	BeadsService_AddAlias_FullMethodName              = "/beads.v1.BeadsService/AddAlias"
+	BeadsService_RemoveAlias_FullMethodName           = "/beads.v1.BeadsService/RemoveAlias"
+	BeadsService_ListAliases_FullMethodName           = "/beads.v1.BeadsService/ListAliases"
+	BeadsService_AddComment_FullMethodName            = "/beads.v1.BeadsService/AddComment"
+	BeadsService_GetComments_FullMethodName           = "/beads.v1.BeadsService/GetComments"
+	BeadsService_AddNote_FullMethodName               = "/beads.v1.BeadsService/AddNote"
+	BeadsService_GetNotes_FullMethodName              = "/beads.v1.BeadsService/GetNotes"
+	BeadsService_GetEvents_FullMethodName             = "/beads.v1.BeadsService/GetEvents"
+	BeadsService_GetActivity_FullMethodName           = "/beads.v1.BeadsService/GetActivity"
+	BeadsService_WatchBead_FullMethodName             = "/beads.v1.BeadsService/WatchBead"
+	BeadsService_UnwatchBead_FullMethodName           = "/beads.v1.BeadsService/UnwatchBead"
+	BeadsService_ListNotifications_FullMethodName     = "/beads.v1.BeadsService/ListNotifications"
+	BeadsService_MarkNotificationsRead_FullMethodName = "/beads.v1.BeadsService/MarkNotificationsRead"
+	BeadsService_GetDigest_FullMethodName             = "/beads.v1.BeadsService/GetDigest"
+	BeadsService_SetConfig_FullMethodName             = "/beads.v1.BeadsService/SetConfig"
+	BeadsService_GetConfig_FullMethodName             = "/beads.v1.BeadsService/GetConfig"
+	BeadsService_ListConfigs_FullMethodName           = "/beads.v1.BeadsService/ListConfigs"
+	BeadsService_DeleteConfig_FullMethodName          = "/beads.v1.BeadsService/DeleteConfig"
+	BeadsService_GetConfigHistory_FullMethodName      = "/beads.v1.BeadsService/GetConfigHistory"
+	BeadsService_RollbackConfig_FullMethodName        = "/beads.v1.BeadsService/RollbackConfig"
+	BeadsService_ListAlerts_FullMethodName            = "/beads.v1.BeadsService/ListAlerts"
+	BeadsService_Health_FullMethodName                = "/beads.v1.BeadsService/Health"
+	BeadsService_GetServerInfo_FullMethodName         = "/beads.v1.BeadsService/GetServerInfo"
+	BeadsService_RegisterAgent_FullMethodName         = "/beads.v1.BeadsService/RegisterAgent"
+	BeadsService_ListAgents_FullMethodName            = "/beads.v1.BeadsService/ListAgents"
+	BeadsService_ListGates_FullMethodName             = "/beads.v1.BeadsService/ListGates"
+	BeadsService_SetGate_FullMethodName               = "/beads.v1.BeadsService/SetGate"
+	BeadsService_WaiveGate_FullMethodName             = "/beads.v1.BeadsService/WaiveGate"
+	BeadsService_EmitHook_FullMethodName              = "/beads.v1.BeadsService/EmitHook"
+	BeadsService_ListAdvice_FullMethodName            = "/beads.v1.BeadsService/ListAdvice"
+	BeadsService_AckAdvice_FullMethodName             = "/beads.v1.BeadsService/AckAdvice"
 )
 
 // BeadsServiceClient is the client API for BeadsService service.
@@ -50,23 +90,63 @@ type BeadsServiceClient interface {
 	CreateBead(ctx context.Context, in *CreateBeadRequest, opts ...grpc.CallOption) (*CreateBeadResponse, error)
 	GetBead(ctx context.Context, in *GetBeadRequest, opts ...grpc.CallOption) (*GetBeadResponse, error)
 	ListBeads(ctx context.Context, in *ListBeadsRequest, opts ...grpc.CallOption) (*ListBeadsResponse, error)
+	ListReadyBeads(ctx context.Context, in *ListBeadsRequest, opts ...grpc.CallOption) (*ListBeadsResponse, error)
+	ListBlockedBeads(ctx context.Context, in *ListBeadsRequest, opts ...grpc.CallOption) (*ListBlockedBeadsResponse, error)
+	PopQueue(ctx context.Context, in *PopQueueRequest, opts ...grpc.CallOption) (*PopQueueResponse, error)
 	UpdateBead(ctx context.Context, in *UpdateBeadRequest, opts ...grpc.CallOption) (*UpdateBeadResponse, error)
 	CloseBead(ctx context.Context, in *CloseBeadRequest, opts ...grpc.CallOption) (*CloseBeadResponse, error)
+	ResolveDecision(ctx context.Context, in *ResolveDecisionRequest, opts ...grpc.CallOption) (*ResolveDecisionResponse, error)
+	GetDecisionContext(ctx context.Context, in *GetDecisionContextRequest, opts ...grpc.CallOption) (*GetDecisionContextResponse, error)
 	DeleteBead(ctx context.Context, in *DeleteBeadRequest, opts ...grpc.CallOption) (*DeleteBeadResponse, error)
+	MergeBead(ctx context.Context, in *MergeBeadRequest, opts ...grpc.CallOption) (*MergeBeadResponse, error)
+	CloneBead(ctx context.Context, in *CloneBeadRequest, opts ...grpc.CallOption) (*CloneBeadResponse, error)
+	FindSimilarBeads(ctx context.Context, in *FindSimilarBeadsRequest, opts ...grpc.CallOption) (*FindSimilarBeadsResponse, error)
 	AddDependency(ctx context.Context, in *AddDependencyRequest, opts ...grpc.CallOption) (*AddDependencyResponse, error)
+	UpdateDependency(ctx context.Context, in *UpdateDependencyRequest, opts ...grpc.CallOption) (*UpdateDependencyResponse, error)
 	RemoveDependency(ctx context.Context, in *RemoveDependencyRequest, opts ...grpc.CallOption) (*RemoveDependencyResponse, error)
 	GetDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependenciesResponse, error)
+	AddRelation(ctx context.Context, in *AddRelationRequest, opts ...grpc.CallOption) (*AddRelationResponse, error)
+	ListRelations(ctx context.Context, in *ListRelationsRequest, opts ...grpc.CallOption) (*ListRelationsResponse, error)
+	AddExternalDep(ctx context.Context, in *AddExternalDepRequest, opts ...grpc.CallOption) (*AddExternalDepResponse, error)
+	ListExternalDeps(ctx context.Context, in *ListExternalDepsRequest, opts ...grpc.CallOption) (*ListExternalDepsResponse, error)
+	UpdateExternalDep(ctx context.Context, in *UpdateExternalDepRequest, opts ...grpc.CallOption) (*UpdateExternalDepResponse, error)
+	RemoveExternalDep(ctx context.Context, in *RemoveExternalDepRequest, opts ...grpc.CallOption) (*RemoveExternalDepResponse, error)
+	LinkCommit(ctx context.Context, in *LinkCommitRequest, opts ...grpc.CallOption) (*LinkCommitResponse, error)
+	ListCommits(ctx context.Context, in *ListCommitsRequest, opts ...grpc.CallOption) (*ListCommitsResponse, error)
 	AddLabel(ctx context.Context, in *AddLabelRequest, opts ...grpc.CallOption) (*AddLabelResponse, error)
 	RemoveLabel(ctx context.Context, in *RemoveLabelRequest, opts ...grpc.CallOption) (*RemoveLabelResponse, error)
 	GetLabels(ctx context.Context, in *GetLabelsRequest, opts ...grpc.CallOption) (*GetLabelsResponse, error)
+	AddAlias(ctx context.Context, in *AddAliasRequest, opts ...grpc.CallOption) (*AddAliasResponse, error)
+	RemoveAlias(ctx context.Context, in *RemoveAliasRequest, opts ...grpc.CallOption) (*RemoveAliasResponse, error)
+	ListAliases(ctx context.Context, in *ListAliasesRequest, opts ...grpc.CallOption) (*ListAliasesResponse, error)
 	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*AddCommentResponse, error)
 	GetComments(ctx context.Context, in *GetCommentsRequest, opts ...grpc.CallOption) (*GetCommentsResponse, error)
+	AddNote(ctx context.Context, in *AddNoteRequest, opts ...grpc.CallOption) (*AddNoteResponse, error)
+	GetNotes(ctx context.Context, in *GetNotesRequest, opts ...grpc.CallOption) (*GetNotesResponse, error)
 	GetEvents(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (*GetEventsResponse, error)
+	GetActivity(ctx context.Context, in *GetActivityRequest, opts ...grpc.CallOption) (*GetActivityResponse, error)
+	WatchBead(ctx context.Context, in *WatchBeadRequest, opts ...grpc.CallOption) (*WatchBeadResponse, error)
+	UnwatchBead(ctx context.Context, in *UnwatchBeadRequest, opts ...grpc.CallOption) (*UnwatchBeadResponse, error)
+	ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error)
+	MarkNotificationsRead(ctx context.Context, in *MarkNotificationsReadRequest, opts ...grpc.CallOption) (*MarkNotificationsReadResponse, error)
+	GetDigest(ctx context.Context, in *GetDigestRequest, opts ...grpc.CallOption) (*GetDigestResponse, error)
 	SetConfig(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*SetConfigResponse, error)
 	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
 	ListConfigs(ctx context.Context, in *ListConfigsRequest, opts ...grpc.CallOption) (*ListConfigsResponse, error)
 	DeleteConfig(ctx context.Context, in *DeleteConfigRequest, opts ...grpc.CallOption) (*DeleteConfigResponse, error)
+	GetConfigHistory(ctx context.Context, in *GetConfigHistoryRequest, opts ...grpc.CallOption) (*GetConfigHistoryResponse, error)
+	RollbackConfig(ctx context.Context, in *RollbackConfigRequest, opts ...grpc.CallOption) (*RollbackConfigResponse, error)
+	ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...grpc.CallOption) (*ListAlertsResponse, error)
 	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error)
+	RegisterAgent(ctx context.Context, in *RegisterAgentRequest, opts ...grpc.CallOption) (*RegisterAgentResponse, error)
+	ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	ListGates(ctx context.Context, in *ListGatesRequest, opts ...grpc.CallOption) (*ListGatesResponse, error)
+	SetGate(ctx context.Context, in *SetGateRequest, opts ...grpc.CallOption) (*SetGateResponse, error)
+	WaiveGate(ctx context.Context, in *WaiveGateRequest, opts ...grpc.CallOption) (*WaiveGateResponse, error)
+	EmitHook(ctx context.Context, in *EmitHookRequest, opts ...grpc.CallOption) (*EmitHookResponse, error)
+	ListAdvice(ctx context.Context, in *ListAdviceRequest, opts ...grpc.CallOption) (*ListAdviceResponse, error)
+	AckAdvice(ctx context.Context, in *AckAdviceRequest, opts ...grpc.CallOption) (*AckAdviceResponse, error)
 }
 
 type beadsServiceClient struct {
@@ -107,6 +187,36 @@ func (c *beadsServiceClient) ListBeads(ctx context.Context, in *ListBeadsRequest
 	return out, nil
 }
 
+func (c *beadsServiceClient) ListReadyBeads(ctx context.Context, in *ListBeadsRequest, opts ...grpc.CallOption) (*ListBeadsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBeadsResponse)
+	err := c.cc.Invoke(ctx, BeadsService_ListReadyBeads_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) ListBlockedBeads(ctx context.Context, in *ListBeadsRequest, opts ...grpc.CallOption) (*ListBlockedBeadsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBlockedBeadsResponse)
+	err := c.cc.Invoke(ctx, BeadsService_ListBlockedBeads_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) PopQueue(ctx context.Context, in *PopQueueRequest, opts ...grpc.CallOption) (*PopQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PopQueueResponse)
+	err := c.cc.Invoke(ctx, BeadsService_PopQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *beadsServiceClient) UpdateBead(ctx context.Context, in *UpdateBeadRequest, opts ...grpc.CallOption) (*UpdateBeadResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateBeadResponse)
@@ -127,6 +237,26 @@ func (c *beadsServiceClient) CloseBead(ctx context.Context, in *CloseBeadRequest
 	return out, nil
 }
 
+func (c *beadsServiceClient) ResolveDecision(ctx context.Context, in *ResolveDecisionRequest, opts ...grpc.CallOption) (*ResolveDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveDecisionResponse)
+	err := c.cc.Invoke(ctx, BeadsService_ResolveDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) GetDecisionContext(ctx context.Context, in *GetDecisionContextRequest, opts ...grpc.CallOption) (*GetDecisionContextResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDecisionContextResponse)
+	err := c.cc.Invoke(ctx, BeadsService_GetDecisionContext_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *beadsServiceClient) DeleteBead(ctx context.Context, in *DeleteBeadRequest, opts ...grpc.CallOption) (*DeleteBeadResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteBeadResponse)
@@ -137,6 +267,36 @@ func (c *beadsServiceClient) DeleteBead(ctx context.Context, in *DeleteBeadReque
 	return out, nil
 }
 
+func (c *beadsServiceClient) MergeBead(ctx context.Context, in *MergeBeadRequest, opts ...grpc.CallOption) (*MergeBeadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeBeadResponse)
+	err := c.cc.Invoke(ctx, BeadsService_MergeBead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) CloneBead(ctx context.Context, in *CloneBeadRequest, opts ...grpc.CallOption) (*CloneBeadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloneBeadResponse)
+	err := c.cc.Invoke(ctx, BeadsService_CloneBead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) FindSimilarBeads(ctx context.Context, in *FindSimilarBeadsRequest, opts ...grpc.CallOption) (*FindSimilarBeadsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindSimilarBeadsResponse)
+	err := c.cc.Invoke(ctx, BeadsService_FindSimilarBeads_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *beadsServiceClient) AddDependency(ctx context.Context, in *AddDependencyRequest, opts ...grpc.CallOption) (*AddDependencyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddDependencyResponse)
@@ -147,6 +307,16 @@ func (c *beadsServiceClient) AddDependency(ctx context.Context, in *AddDependenc
 	return out, nil
 }
 
+func (c *beadsServiceClient) UpdateDependency(ctx context.Context, in *UpdateDependencyRequest, opts ...grpc.CallOption) (*UpdateDependencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateDependencyResponse)
+	err := c.cc.Invoke(ctx, BeadsService_UpdateDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *beadsServiceClient) RemoveDependency(ctx context.Context, in *RemoveDependencyRequest, opts ...grpc.CallOption) (*RemoveDependencyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RemoveDependencyResponse)
@@ -167,6 +337,86 @@ func (c *beadsServiceClient) GetDependencies(ctx context.Context, in *GetDepende
 	return out, nil
 }
 
+func (c *beadsServiceClient) AddRelation(ctx context.Context, in *AddRelationRequest, opts ...grpc.CallOption) (*AddRelationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddRelationResponse)
+	err := c.cc.Invoke(ctx, BeadsService_AddRelation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) ListRelations(ctx context.Context, in *ListRelationsRequest, opts ...grpc.CallOption) (*ListRelationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRelationsResponse)
+	err := c.cc.Invoke(ctx, BeadsService_ListRelations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) AddExternalDep(ctx context.Context, in *AddExternalDepRequest, opts ...grpc.CallOption) (*AddExternalDepResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddExternalDepResponse)
+	err := c.cc.Invoke(ctx, BeadsService_AddExternalDep_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) ListExternalDeps(ctx context.Context, in *ListExternalDepsRequest, opts ...grpc.CallOption) (*ListExternalDepsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExternalDepsResponse)
+	err := c.cc.Invoke(ctx, BeadsService_ListExternalDeps_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) UpdateExternalDep(ctx context.Context, in *UpdateExternalDepRequest, opts ...grpc.CallOption) (*UpdateExternalDepResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateExternalDepResponse)
+	err := c.cc.Invoke(ctx, BeadsService_UpdateExternalDep_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) RemoveExternalDep(ctx context.Context, in *RemoveExternalDepRequest, opts ...grpc.CallOption) (*RemoveExternalDepResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveExternalDepResponse)
+	err := c.cc.Invoke(ctx, BeadsService_RemoveExternalDep_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) LinkCommit(ctx context.Context, in *LinkCommitRequest, opts ...grpc.CallOption) (*LinkCommitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LinkCommitResponse)
+	err := c.cc.Invoke(ctx, BeadsService_LinkCommit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) ListCommits(ctx context.Context, in *ListCommitsRequest, opts ...grpc.CallOption) (*ListCommitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCommitsResponse)
+	err := c.cc.Invoke(ctx, BeadsService_ListCommits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *beadsServiceClient) AddLabel(ctx context.Context, in *AddLabelRequest, opts ...grpc.CallOption) (*AddLabelResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddLabelResponse)
@@ -197,6 +447,36 @@ func (c *beadsServiceClient) GetLabels(ctx context.Context, in *GetLabelsRequest
 	return out, nil
 }
 
+func (c *beadsServiceClient) AddAlias(ctx context.Context, in *AddAliasRequest, opts ...grpc.CallOption) (*AddAliasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddAliasResponse)
+	err := c.cc.Invoke(ctx, BeadsService_AddAlias_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) RemoveAlias(ctx context.Context, in *RemoveAliasRequest, opts ...grpc.CallOption) (*RemoveAliasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveAliasResponse)
+	err := c.cc.Invoke(ctx, BeadsService_RemoveAlias_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) ListAliases(ctx context.Context, in *ListAliasesRequest, opts ...grpc.CallOption) (*ListAliasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAliasesResponse)
+	err := c.cc.Invoke(ctx, BeadsService_ListAliases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *beadsServiceClient) AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*AddCommentResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddCommentResponse)
@@ -217,6 +497,26 @@ func (c *beadsServiceClient) GetComments(ctx context.Context, in *GetCommentsReq
 	return out, nil
 }
 
+func (c *beadsServiceClient) AddNote(ctx context.Context, in *AddNoteRequest, opts ...grpc.CallOption) (*AddNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddNoteResponse)
+	err := c.cc.Invoke(ctx, BeadsService_AddNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) GetNotes(ctx context.Context, in *GetNotesRequest, opts ...grpc.CallOption) (*GetNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNotesResponse)
+	err := c.cc.Invoke(ctx, BeadsService_GetNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *beadsServiceClient) GetEvents(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (*GetEventsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetEventsResponse)
@@ -227,6 +527,66 @@ func (c *beadsServiceClient) GetEvents(ctx context.Context, in *GetEventsRequest
 	return out, nil
 }
 
+func (c *beadsServiceClient) GetActivity(ctx context.Context, in *GetActivityRequest, opts ...grpc.CallOption) (*GetActivityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetActivityResponse)
+	err := c.cc.Invoke(ctx, BeadsService_GetActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) WatchBead(ctx context.Context, in *WatchBeadRequest, opts ...grpc.CallOption) (*WatchBeadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WatchBeadResponse)
+	err := c.cc.Invoke(ctx, BeadsService_WatchBead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) UnwatchBead(ctx context.Context, in *UnwatchBeadRequest, opts ...grpc.CallOption) (*UnwatchBeadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnwatchBeadResponse)
+	err := c.cc.Invoke(ctx, BeadsService_UnwatchBead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationsResponse)
+	err := c.cc.Invoke(ctx, BeadsService_ListNotifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) MarkNotificationsRead(ctx context.Context, in *MarkNotificationsReadRequest, opts ...grpc.CallOption) (*MarkNotificationsReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkNotificationsReadResponse)
+	err := c.cc.Invoke(ctx, BeadsService_MarkNotificationsRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) GetDigest(ctx context.Context, in *GetDigestRequest, opts ...grpc.CallOption) (*GetDigestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDigestResponse)
+	err := c.cc.Invoke(ctx, BeadsService_GetDigest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *beadsServiceClient) SetConfig(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*SetConfigResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SetConfigResponse)
@@ -267,6 +627,36 @@ func (c *beadsServiceClient) DeleteConfig(ctx context.Context, in *DeleteConfigR
 	return out, nil
 }
 
+func (c *beadsServiceClient) GetConfigHistory(ctx context.Context, in *GetConfigHistoryRequest, opts ...grpc.CallOption) (*GetConfigHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigHistoryResponse)
+	err := c.cc.Invoke(ctx, BeadsService_GetConfigHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) RollbackConfig(ctx context.Context, in *RollbackConfigRequest, opts ...grpc.CallOption) (*RollbackConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackConfigResponse)
+	err := c.cc.Invoke(ctx, BeadsService_RollbackConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...grpc.CallOption) (*ListAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlertsResponse)
+	err := c.cc.Invoke(ctx, BeadsService_ListAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *beadsServiceClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(HealthResponse)
@@ -277,41 +667,171 @@ func (c *beadsServiceClient) Health(ctx context.Context, in *HealthRequest, opts
 	return out, nil
 }
 
-// BeadsServiceServer is the server API for BeadsService service.
-// All implementations must embed UnimplementedBeadsServiceServer
-// for forward compatibility.
-//
-// BeadsService provides RPCs for managing beads.
-type BeadsServiceServer interface {
-	CreateBead(context.Context, *CreateBeadRequest) (*CreateBeadResponse, error)
-	GetBead(context.Context, *GetBeadRequest) (*GetBeadResponse, error)
-	ListBeads(context.Context, *ListBeadsRequest) (*ListBeadsResponse, error)
-	UpdateBead(context.Context, *UpdateBeadRequest) (*UpdateBeadResponse, error)
-	CloseBead(context.Context, *CloseBeadRequest) (*CloseBeadResponse, error)
-	DeleteBead(context.Context, *DeleteBeadRequest) (*DeleteBeadResponse, error)
-	AddDependency(context.Context, *AddDependencyRequest) (*AddDependencyResponse, error)
-	RemoveDependency(context.Context, *RemoveDependencyRequest) (*RemoveDependencyResponse, error)
-	GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error)
-	AddLabel(context.Context, *AddLabelRequest) (*AddLabelResponse, error)
-	RemoveLabel(context.Context, *RemoveLabelRequest) (*RemoveLabelResponse, error)
-	GetLabels(context.Context, *GetLabelsRequest) (*GetLabelsResponse, error)
-	AddComment(context.Context, *AddCommentRequest) (*AddCommentResponse, error)
-	GetComments(context.Context, *GetCommentsRequest) (*GetCommentsResponse, error)
-	GetEvents(context.Context, *GetEventsRequest) (*GetEventsResponse, error)
-	SetConfig(context.Context, *SetConfigRequest) (*SetConfigResponse, error)
-	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
-	ListConfigs(context.Context, *ListConfigsRequest) (*ListConfigsResponse, error)
-	DeleteConfig(context.Context, *DeleteConfigRequest) (*DeleteConfigResponse, error)
-	Health(context.Context, *HealthRequest) (*HealthResponse, error)
-	mustEmbedUnimplementedBeadsServiceServer()
+func (c *beadsServiceClient) GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetServerInfoResponse)
+	err := c.cc.Invoke(ctx, BeadsService_GetServerInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedBeadsServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedBeadsServiceServer struct{}
+func (c *beadsServiceClient) RegisterAgent(ctx context.Context, in *RegisterAgentRequest, opts ...grpc.CallOption) (*RegisterAgentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterAgentResponse)
+	err := c.cc.Invoke(ctx, BeadsService_RegisterAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAgentsResponse)
+	err := c.cc.Invoke(ctx, BeadsService_ListAgents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) ListGates(ctx context.Context, in *ListGatesRequest, opts ...grpc.CallOption) (*ListGatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGatesResponse)
+	err := c.cc.Invoke(ctx, BeadsService_ListGates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) SetGate(ctx context.Context, in *SetGateRequest, opts ...grpc.CallOption) (*SetGateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetGateResponse)
+	err := c.cc.Invoke(ctx, BeadsService_SetGate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) WaiveGate(ctx context.Context, in *WaiveGateRequest, opts ...grpc.CallOption) (*WaiveGateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WaiveGateResponse)
+	err := c.cc.Invoke(ctx, BeadsService_WaiveGate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) EmitHook(ctx context.Context, in *EmitHookRequest, opts ...grpc.CallOption) (*EmitHookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmitHookResponse)
+	err := c.cc.Invoke(ctx, BeadsService_EmitHook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) ListAdvice(ctx context.Context, in *ListAdviceRequest, opts ...grpc.CallOption) (*ListAdviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAdviceResponse)
+	err := c.cc.Invoke(ctx, BeadsService_ListAdvice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsServiceClient) AckAdvice(ctx context.Context, in *AckAdviceRequest, opts ...grpc.CallOption) (*AckAdviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AckAdviceResponse)
+	err := c.cc.Invoke(ctx, BeadsService_AckAdvice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BeadsServiceServer is the server API for BeadsService service.
+// All implementations must embed UnimplementedBeadsServiceServer
+// for forward compatibility.
+//
+// BeadsService provides RPCs for managing beads.
+type BeadsServiceServer interface {
+	CreateBead(context.Context, *CreateBeadRequest) (*CreateBeadResponse, error)
+	GetBead(context.Context, *GetBeadRequest) (*GetBeadResponse, error)
+	ListBeads(context.Context, *ListBeadsRequest) (*ListBeadsResponse, error)
+	ListReadyBeads(context.Context, *ListBeadsRequest) (*ListBeadsResponse, error)
+	ListBlockedBeads(context.Context, *ListBeadsRequest) (*ListBlockedBeadsResponse, error)
+	PopQueue(context.Context, *PopQueueRequest) (*PopQueueResponse, error)
+	UpdateBead(context.Context, *UpdateBeadRequest) (*UpdateBeadResponse, error)
+	CloseBead(context.Context, *CloseBeadRequest) (*CloseBeadResponse, error)
+	ResolveDecision(context.Context, *ResolveDecisionRequest) (*ResolveDecisionResponse, error)
+	GetDecisionContext(context.Context, *GetDecisionContextRequest) (*GetDecisionContextResponse, error)
+	DeleteBead(context.Context, *DeleteBeadRequest) (*DeleteBeadResponse, error)
+	MergeBead(context.Context, *MergeBeadRequest) (*MergeBeadResponse, error)
+	CloneBead(context.Context, *CloneBeadRequest) (*CloneBeadResponse, error)
+	FindSimilarBeads(context.Context, *FindSimilarBeadsRequest) (*FindSimilarBeadsResponse, error)
+	AddDependency(context.Context, *AddDependencyRequest) (*AddDependencyResponse, error)
+	UpdateDependency(context.Context, *UpdateDependencyRequest) (*UpdateDependencyResponse, error)
+	RemoveDependency(context.Context, *RemoveDependencyRequest) (*RemoveDependencyResponse, error)
+	GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error)
+	AddRelation(context.Context, *AddRelationRequest) (*AddRelationResponse, error)
+	ListRelations(context.Context, *ListRelationsRequest) (*ListRelationsResponse, error)
+	AddExternalDep(context.Context, *AddExternalDepRequest) (*AddExternalDepResponse, error)
+	ListExternalDeps(context.Context, *ListExternalDepsRequest) (*ListExternalDepsResponse, error)
+	UpdateExternalDep(context.Context, *UpdateExternalDepRequest) (*UpdateExternalDepResponse, error)
+	RemoveExternalDep(context.Context, *RemoveExternalDepRequest) (*RemoveExternalDepResponse, error)
+	LinkCommit(context.Context, *LinkCommitRequest) (*LinkCommitResponse, error)
+	ListCommits(context.Context, *ListCommitsRequest) (*ListCommitsResponse, error)
+	AddLabel(context.Context, *AddLabelRequest) (*AddLabelResponse, error)
+	RemoveLabel(context.Context, *RemoveLabelRequest) (*RemoveLabelResponse, error)
+	GetLabels(context.Context, *GetLabelsRequest) (*GetLabelsResponse, error)
+	AddAlias(context.Context, *AddAliasRequest) (*AddAliasResponse, error)
+	RemoveAlias(context.Context, *RemoveAliasRequest) (*RemoveAliasResponse, error)
+	ListAliases(context.Context, *ListAliasesRequest) (*ListAliasesResponse, error)
+	AddComment(context.Context, *AddCommentRequest) (*AddCommentResponse, error)
+	GetComments(context.Context, *GetCommentsRequest) (*GetCommentsResponse, error)
+	AddNote(context.Context, *AddNoteRequest) (*AddNoteResponse, error)
+	GetNotes(context.Context, *GetNotesRequest) (*GetNotesResponse, error)
+	GetEvents(context.Context, *GetEventsRequest) (*GetEventsResponse, error)
+	GetActivity(context.Context, *GetActivityRequest) (*GetActivityResponse, error)
+	WatchBead(context.Context, *WatchBeadRequest) (*WatchBeadResponse, error)
+	UnwatchBead(context.Context, *UnwatchBeadRequest) (*UnwatchBeadResponse, error)
+	ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error)
+	MarkNotificationsRead(context.Context, *MarkNotificationsReadRequest) (*MarkNotificationsReadResponse, error)
+	GetDigest(context.Context, *GetDigestRequest) (*GetDigestResponse, error)
+	SetConfig(context.Context, *SetConfigRequest) (*SetConfigResponse, error)
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	ListConfigs(context.Context, *ListConfigsRequest) (*ListConfigsResponse, error)
+	DeleteConfig(context.Context, *DeleteConfigRequest) (*DeleteConfigResponse, error)
+	GetConfigHistory(context.Context, *GetConfigHistoryRequest) (*GetConfigHistoryResponse, error)
+	RollbackConfig(context.Context, *RollbackConfigRequest) (*RollbackConfigResponse, error)
+	ListAlerts(context.Context, *ListAlertsRequest) (*ListAlertsResponse, error)
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error)
+	RegisterAgent(context.Context, *RegisterAgentRequest) (*RegisterAgentResponse, error)
+	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error)
+	ListGates(context.Context, *ListGatesRequest) (*ListGatesResponse, error)
+	SetGate(context.Context, *SetGateRequest) (*SetGateResponse, error)
+	WaiveGate(context.Context, *WaiveGateRequest) (*WaiveGateResponse, error)
+	EmitHook(context.Context, *EmitHookRequest) (*EmitHookResponse, error)
+	ListAdvice(context.Context, *ListAdviceRequest) (*ListAdviceResponse, error)
+	AckAdvice(context.Context, *AckAdviceRequest) (*AckAdviceResponse, error)
+	mustEmbedUnimplementedBeadsServiceServer()
+}
+
+// UnimplementedBeadsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBeadsServiceServer struct{}
 
 func (UnimplementedBeadsServiceServer) CreateBead(context.Context, *CreateBeadRequest) (*CreateBeadResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateBead not implemented")
@@ -322,24 +842,75 @@ func (UnimplementedBeadsServiceServer) GetBead(context.Context, *GetBeadRequest)
 func (UnimplementedBeadsServiceServer) ListBeads(context.Context, *ListBeadsRequest) (*ListBeadsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListBeads not implemented")
 }
+func (UnimplementedBeadsServiceServer) ListReadyBeads(context.Context, *ListBeadsRequest) (*ListBeadsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListReadyBeads not implemented")
+}
+func (UnimplementedBeadsServiceServer) ListBlockedBeads(context.Context, *ListBeadsRequest) (*ListBlockedBeadsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBlockedBeads not implemented")
+}
+func (UnimplementedBeadsServiceServer) PopQueue(context.Context, *PopQueueRequest) (*PopQueueResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PopQueue not implemented")
+}
 func (UnimplementedBeadsServiceServer) UpdateBead(context.Context, *UpdateBeadRequest) (*UpdateBeadResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateBead not implemented")
 }
 func (UnimplementedBeadsServiceServer) CloseBead(context.Context, *CloseBeadRequest) (*CloseBeadResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CloseBead not implemented")
 }
+func (UnimplementedBeadsServiceServer) ResolveDecision(context.Context, *ResolveDecisionRequest) (*ResolveDecisionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolveDecision not implemented")
+}
+func (UnimplementedBeadsServiceServer) GetDecisionContext(context.Context, *GetDecisionContextRequest) (*GetDecisionContextResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDecisionContext not implemented")
+}
 func (UnimplementedBeadsServiceServer) DeleteBead(context.Context, *DeleteBeadRequest) (*DeleteBeadResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteBead not implemented")
 }
+func (UnimplementedBeadsServiceServer) MergeBead(context.Context, *MergeBeadRequest) (*MergeBeadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeBead not implemented")
+}
+func (UnimplementedBeadsServiceServer) CloneBead(context.Context, *CloneBeadRequest) (*CloneBeadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CloneBead not implemented")
+}
+func (UnimplementedBeadsServiceServer) FindSimilarBeads(context.Context, *FindSimilarBeadsRequest) (*FindSimilarBeadsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindSimilarBeads not implemented")
+}
 func (UnimplementedBeadsServiceServer) AddDependency(context.Context, *AddDependencyRequest) (*AddDependencyResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddDependency not implemented")
 }
+func (UnimplementedBeadsServiceServer) UpdateDependency(context.Context, *UpdateDependencyRequest) (*UpdateDependencyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateDependency not implemented")
+}
 func (UnimplementedBeadsServiceServer) RemoveDependency(context.Context, *RemoveDependencyRequest) (*RemoveDependencyResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RemoveDependency not implemented")
 }
 func (UnimplementedBeadsServiceServer) GetDependencies(context.Context, *GetDependenciesRequest) (*GetDependenciesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetDependencies not implemented")
 }
+func (UnimplementedBeadsServiceServer) AddRelation(context.Context, *AddRelationRequest) (*AddRelationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddRelation not implemented")
+}
+func (UnimplementedBeadsServiceServer) ListRelations(context.Context, *ListRelationsRequest) (*ListRelationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRelations not implemented")
+}
+func (UnimplementedBeadsServiceServer) AddExternalDep(context.Context, *AddExternalDepRequest) (*AddExternalDepResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddExternalDep not implemented")
+}
+func (UnimplementedBeadsServiceServer) ListExternalDeps(context.Context, *ListExternalDepsRequest) (*ListExternalDepsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListExternalDeps not implemented")
+}
+func (UnimplementedBeadsServiceServer) UpdateExternalDep(context.Context, *UpdateExternalDepRequest) (*UpdateExternalDepResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateExternalDep not implemented")
+}
+func (UnimplementedBeadsServiceServer) RemoveExternalDep(context.Context, *RemoveExternalDepRequest) (*RemoveExternalDepResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveExternalDep not implemented")
+}
+func (UnimplementedBeadsServiceServer) LinkCommit(context.Context, *LinkCommitRequest) (*LinkCommitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LinkCommit not implemented")
+}
+func (UnimplementedBeadsServiceServer) ListCommits(context.Context, *ListCommitsRequest) (*ListCommitsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCommits not implemented")
+}
 func (UnimplementedBeadsServiceServer) AddLabel(context.Context, *AddLabelRequest) (*AddLabelResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddLabel not implemented")
 }
@@ -349,15 +920,48 @@ func (UnimplementedBeadsServiceServer) RemoveLabel(context.Context, *RemoveLabel
 func (UnimplementedBeadsServiceServer) GetLabels(context.Context, *GetLabelsRequest) (*GetLabelsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetLabels not implemented")
 }
+func (UnimplementedBeadsServiceServer) AddAlias(context.Context, *AddAliasRequest) (*AddAliasResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddAlias not implemented")
+}
+func (UnimplementedBeadsServiceServer) RemoveAlias(context.Context, *RemoveAliasRequest) (*RemoveAliasResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveAlias not implemented")
+}
+func (UnimplementedBeadsServiceServer) ListAliases(context.Context, *ListAliasesRequest) (*ListAliasesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAliases not implemented")
+}
 func (UnimplementedBeadsServiceServer) AddComment(context.Context, *AddCommentRequest) (*AddCommentResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddComment not implemented")
 }
 func (UnimplementedBeadsServiceServer) GetComments(context.Context, *GetCommentsRequest) (*GetCommentsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetComments not implemented")
 }
+func (UnimplementedBeadsServiceServer) AddNote(context.Context, *AddNoteRequest) (*AddNoteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddNote not implemented")
+}
+func (UnimplementedBeadsServiceServer) GetNotes(context.Context, *GetNotesRequest) (*GetNotesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNotes not implemented")
+}
 func (UnimplementedBeadsServiceServer) GetEvents(context.Context, *GetEventsRequest) (*GetEventsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetEvents not implemented")
 }
+func (UnimplementedBeadsServiceServer) GetActivity(context.Context, *GetActivityRequest) (*GetActivityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetActivity not implemented")
+}
+func (UnimplementedBeadsServiceServer) WatchBead(context.Context, *WatchBeadRequest) (*WatchBeadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WatchBead not implemented")
+}
+func (UnimplementedBeadsServiceServer) UnwatchBead(context.Context, *UnwatchBeadRequest) (*UnwatchBeadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnwatchBead not implemented")
+}
+func (UnimplementedBeadsServiceServer) ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListNotifications not implemented")
+}
+func (UnimplementedBeadsServiceServer) MarkNotificationsRead(context.Context, *MarkNotificationsReadRequest) (*MarkNotificationsReadResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkNotificationsRead not implemented")
+}
+func (UnimplementedBeadsServiceServer) GetDigest(context.Context, *GetDigestRequest) (*GetDigestResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDigest not implemented")
+}
 func (UnimplementedBeadsServiceServer) SetConfig(context.Context, *SetConfigRequest) (*SetConfigResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SetConfig not implemented")
 }
@@ -370,386 +974,1142 @@ func (UnimplementedBeadsServiceServer) ListConfigs(context.Context, *ListConfigs
 func (UnimplementedBeadsServiceServer) DeleteConfig(context.Context, *DeleteConfigRequest) (*DeleteConfigResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteConfig not implemented")
 }
-func (UnimplementedBeadsServiceServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method Health not implemented")
+func (UnimplementedBeadsServiceServer) GetConfigHistory(context.Context, *GetConfigHistoryRequest) (*GetConfigHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetConfigHistory not implemented")
+}
+func (UnimplementedBeadsServiceServer) RollbackConfig(context.Context, *RollbackConfigRequest) (*RollbackConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RollbackConfig not implemented")
+}
+func (UnimplementedBeadsServiceServer) ListAlerts(context.Context, *ListAlertsRequest) (*ListAlertsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAlerts not implemented")
+}
+func (UnimplementedBeadsServiceServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Health not implemented")
+}
+func (UnimplementedBeadsServiceServer) GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetServerInfo not implemented")
+}
+func (UnimplementedBeadsServiceServer) RegisterAgent(context.Context, *RegisterAgentRequest) (*RegisterAgentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterAgent not implemented")
+}
+func (UnimplementedBeadsServiceServer) ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAgents not implemented")
+}
+func (UnimplementedBeadsServiceServer) ListGates(context.Context, *ListGatesRequest) (*ListGatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListGates not implemented")
+}
+func (UnimplementedBeadsServiceServer) SetGate(context.Context, *SetGateRequest) (*SetGateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetGate not implemented")
+}
+func (UnimplementedBeadsServiceServer) WaiveGate(context.Context, *WaiveGateRequest) (*WaiveGateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WaiveGate not implemented")
+}
+func (UnimplementedBeadsServiceServer) EmitHook(context.Context, *EmitHookRequest) (*EmitHookResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EmitHook not implemented")
+}
+func (UnimplementedBeadsServiceServer) ListAdvice(context.Context, *ListAdviceRequest) (*ListAdviceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAdvice not implemented")
+}
+func (UnimplementedBeadsServiceServer) AckAdvice(context.Context, *AckAdviceRequest) (*AckAdviceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AckAdvice not implemented")
+}
+func (UnimplementedBeadsServiceServer) mustEmbedUnimplementedBeadsServiceServer() {}
+func (UnimplementedBeadsServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeBeadsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BeadsServiceServer will
+// result in compilation errors.
+type UnsafeBeadsServiceServer interface {
+	mustEmbedUnimplementedBeadsServiceServer()
+}
+
+func RegisterBeadsServiceServer(s grpc.ServiceRegistrar, srv BeadsServiceServer) {
+	// If the following call panics, it indicates UnimplementedBeadsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BeadsService_ServiceDesc, srv)
+}
+
+func _BeadsService_CreateBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).CreateBead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_CreateBead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).CreateBead(ctx, req.(*CreateBeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_GetBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).GetBead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_GetBead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).GetBead(ctx, req.(*GetBeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_ListBeads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBeadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).ListBeads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_ListBeads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).ListBeads(ctx, req.(*ListBeadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_ListReadyBeads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBeadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).ListReadyBeads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_ListReadyBeads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).ListReadyBeads(ctx, req.(*ListBeadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_ListBlockedBeads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBeadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).ListBlockedBeads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_ListBlockedBeads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).ListBlockedBeads(ctx, req.(*ListBeadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_PopQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PopQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).PopQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_PopQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).PopQueue(ctx, req.(*PopQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_UpdateBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).UpdateBead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_UpdateBead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).UpdateBead(ctx, req.(*UpdateBeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_CloseBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseBeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).CloseBead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_CloseBead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).CloseBead(ctx, req.(*CloseBeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_ResolveDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).ResolveDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_ResolveDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).ResolveDecision(ctx, req.(*ResolveDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_GetDecisionContext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDecisionContextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).GetDecisionContext(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_GetDecisionContext_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).GetDecisionContext(ctx, req.(*GetDecisionContextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_DeleteBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).DeleteBead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_DeleteBead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).DeleteBead(ctx, req.(*DeleteBeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_MergeBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeBeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).MergeBead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_MergeBead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).MergeBead(ctx, req.(*MergeBeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_CloneBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneBeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).CloneBead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_CloneBead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).CloneBead(ctx, req.(*CloneBeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_FindSimilarBeads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSimilarBeadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).FindSimilarBeads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_FindSimilarBeads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).FindSimilarBeads(ctx, req.(*FindSimilarBeadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_AddDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).AddDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_AddDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).AddDependency(ctx, req.(*AddDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_UpdateDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).UpdateDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_UpdateDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).UpdateDependency(ctx, req.(*UpdateDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_RemoveDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).RemoveDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_RemoveDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).RemoveDependency(ctx, req.(*RemoveDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_GetDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).GetDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_GetDependencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).GetDependencies(ctx, req.(*GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_AddRelation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRelationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).AddRelation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_AddRelation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).AddRelation(ctx, req.(*AddRelationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_ListRelations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRelationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).ListRelations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_ListRelations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).ListRelations(ctx, req.(*ListRelationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_AddExternalDep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddExternalDepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).AddExternalDep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_AddExternalDep_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).AddExternalDep(ctx, req.(*AddExternalDepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_ListExternalDeps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExternalDepsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).ListExternalDeps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_ListExternalDeps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).ListExternalDeps(ctx, req.(*ListExternalDepsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_UpdateExternalDep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExternalDepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).UpdateExternalDep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_UpdateExternalDep_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).UpdateExternalDep(ctx, req.(*UpdateExternalDepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_RemoveExternalDep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveExternalDepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).RemoveExternalDep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_RemoveExternalDep_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).RemoveExternalDep(ctx, req.(*RemoveExternalDepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_LinkCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).LinkCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_LinkCommit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).LinkCommit(ctx, req.(*LinkCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_ListCommits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).ListCommits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_ListCommits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).ListCommits(ctx, req.(*ListCommitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_AddLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).AddLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_AddLabel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).AddLabel(ctx, req.(*AddLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_RemoveLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).RemoveLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_RemoveLabel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).RemoveLabel(ctx, req.(*RemoveLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_GetLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).GetLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_GetLabels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).GetLabels(ctx, req.(*GetLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_AddAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).AddAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_AddAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).AddAlias(ctx, req.(*AddAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_RemoveAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).RemoveAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_RemoveAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).RemoveAlias(ctx, req.(*RemoveAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_ListAliases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAliasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).ListAliases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_ListAliases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).ListAliases(ctx, req.(*ListAliasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).AddComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_AddComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).AddComment(ctx, req.(*AddCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_GetComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).GetComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_GetComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).GetComments(ctx, req.(*GetCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_AddNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).AddNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_AddNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).AddNote(ctx, req.(*AddNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_GetNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).GetNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_GetNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).GetNotes(ctx, req.(*GetNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_GetEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).GetEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_GetEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).GetEvents(ctx, req.(*GetEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeadsService_GetActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).GetActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_GetActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).GetActivity(ctx, req.(*GetActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedBeadsServiceServer) mustEmbedUnimplementedBeadsServiceServer() {}
-func (UnimplementedBeadsServiceServer) testEmbeddedByValue()                      {}
 
-// UnsafeBeadsServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to BeadsServiceServer will
-// result in compilation errors.
-type UnsafeBeadsServiceServer interface {
-	mustEmbedUnimplementedBeadsServiceServer()
+func _BeadsService_WatchBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchBeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).WatchBead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_WatchBead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).WatchBead(ctx, req.(*WatchBeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterBeadsServiceServer(s grpc.ServiceRegistrar, srv BeadsServiceServer) {
-	// If the following call panics, it indicates UnimplementedBeadsServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _BeadsService_UnwatchBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnwatchBeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&BeadsService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(BeadsServiceServer).UnwatchBead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BeadsService_UnwatchBead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServiceServer).UnwatchBead(ctx, req.(*UnwatchBeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_CreateBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateBeadRequest)
+func _BeadsService_ListNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).CreateBead(ctx, in)
+		return srv.(BeadsServiceServer).ListNotifications(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_CreateBead_FullMethodName,
+		FullMethod: BeadsService_ListNotifications_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).CreateBead(ctx, req.(*CreateBeadRequest))
+		return srv.(BeadsServiceServer).ListNotifications(ctx, req.(*ListNotificationsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_GetBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBeadRequest)
+func _BeadsService_MarkNotificationsRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkNotificationsReadRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).GetBead(ctx, in)
+		return srv.(BeadsServiceServer).MarkNotificationsRead(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_GetBead_FullMethodName,
+		FullMethod: BeadsService_MarkNotificationsRead_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).GetBead(ctx, req.(*GetBeadRequest))
+		return srv.(BeadsServiceServer).MarkNotificationsRead(ctx, req.(*MarkNotificationsReadRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_ListBeads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListBeadsRequest)
+func _BeadsService_GetDigest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDigestRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).ListBeads(ctx, in)
+		return srv.(BeadsServiceServer).GetDigest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_ListBeads_FullMethodName,
+		FullMethod: BeadsService_GetDigest_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).ListBeads(ctx, req.(*ListBeadsRequest))
+		return srv.(BeadsServiceServer).GetDigest(ctx, req.(*GetDigestRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_UpdateBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateBeadRequest)
+func _BeadsService_SetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConfigRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).UpdateBead(ctx, in)
+		return srv.(BeadsServiceServer).SetConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_UpdateBead_FullMethodName,
+		FullMethod: BeadsService_SetConfig_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).UpdateBead(ctx, req.(*UpdateBeadRequest))
+		return srv.(BeadsServiceServer).SetConfig(ctx, req.(*SetConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_CloseBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CloseBeadRequest)
+func _BeadsService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).CloseBead(ctx, in)
+		return srv.(BeadsServiceServer).GetConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_CloseBead_FullMethodName,
+		FullMethod: BeadsService_GetConfig_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).CloseBead(ctx, req.(*CloseBeadRequest))
+		return srv.(BeadsServiceServer).GetConfig(ctx, req.(*GetConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_DeleteBead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteBeadRequest)
+func _BeadsService_ListConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConfigsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).DeleteBead(ctx, in)
+		return srv.(BeadsServiceServer).ListConfigs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_DeleteBead_FullMethodName,
+		FullMethod: BeadsService_ListConfigs_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).DeleteBead(ctx, req.(*DeleteBeadRequest))
+		return srv.(BeadsServiceServer).ListConfigs(ctx, req.(*ListConfigsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_AddDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddDependencyRequest)
+func _BeadsService_DeleteConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConfigRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).AddDependency(ctx, in)
+		return srv.(BeadsServiceServer).DeleteConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_AddDependency_FullMethodName,
+		FullMethod: BeadsService_DeleteConfig_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).AddDependency(ctx, req.(*AddDependencyRequest))
+		return srv.(BeadsServiceServer).DeleteConfig(ctx, req.(*DeleteConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_RemoveDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveDependencyRequest)
+func _BeadsService_GetConfigHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigHistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).RemoveDependency(ctx, in)
+		return srv.(BeadsServiceServer).GetConfigHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_RemoveDependency_FullMethodName,
+		FullMethod: BeadsService_GetConfigHistory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).RemoveDependency(ctx, req.(*RemoveDependencyRequest))
+		return srv.(BeadsServiceServer).GetConfigHistory(ctx, req.(*GetConfigHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_GetDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDependenciesRequest)
+func _BeadsService_RollbackConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackConfigRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).GetDependencies(ctx, in)
+		return srv.(BeadsServiceServer).RollbackConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_GetDependencies_FullMethodName,
+		FullMethod: BeadsService_RollbackConfig_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).GetDependencies(ctx, req.(*GetDependenciesRequest))
+		return srv.(BeadsServiceServer).RollbackConfig(ctx, req.(*RollbackConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_AddLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddLabelRequest)
+func _BeadsService_ListAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).AddLabel(ctx, in)
+		return srv.(BeadsServiceServer).ListAlerts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_AddLabel_FullMethodName,
+		FullMethod: BeadsService_ListAlerts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).AddLabel(ctx, req.(*AddLabelRequest))
+		return srv.(BeadsServiceServer).ListAlerts(ctx, req.(*ListAlertsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_RemoveLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveLabelRequest)
+func _BeadsService_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).RemoveLabel(ctx, in)
+		return srv.(BeadsServiceServer).Health(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_RemoveLabel_FullMethodName,
+		FullMethod: BeadsService_Health_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).RemoveLabel(ctx, req.(*RemoveLabelRequest))
+		return srv.(BeadsServiceServer).Health(ctx, req.(*HealthRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_GetLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetLabelsRequest)
+func _BeadsService_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).GetLabels(ctx, in)
+		return srv.(BeadsServiceServer).GetServerInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_GetLabels_FullMethodName,
+		FullMethod: BeadsService_GetServerInfo_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).GetLabels(ctx, req.(*GetLabelsRequest))
+		return srv.(BeadsServiceServer).GetServerInfo(ctx, req.(*GetServerInfoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddCommentRequest)
+func _BeadsService_RegisterAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterAgentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).AddComment(ctx, in)
+		return srv.(BeadsServiceServer).RegisterAgent(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_AddComment_FullMethodName,
+		FullMethod: BeadsService_RegisterAgent_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).AddComment(ctx, req.(*AddCommentRequest))
+		return srv.(BeadsServiceServer).RegisterAgent(ctx, req.(*RegisterAgentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_GetComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetCommentsRequest)
+func _BeadsService_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAgentsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).GetComments(ctx, in)
+		return srv.(BeadsServiceServer).ListAgents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_GetComments_FullMethodName,
+		FullMethod: BeadsService_ListAgents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).GetComments(ctx, req.(*GetCommentsRequest))
+		return srv.(BeadsServiceServer).ListAgents(ctx, req.(*ListAgentsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_GetEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetEventsRequest)
+func _BeadsService_ListGates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).GetEvents(ctx, in)
+		return srv.(BeadsServiceServer).ListGates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_GetEvents_FullMethodName,
+		FullMethod: BeadsService_ListGates_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).GetEvents(ctx, req.(*GetEventsRequest))
+		return srv.(BeadsServiceServer).ListGates(ctx, req.(*ListGatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_SetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetConfigRequest)
+func _BeadsService_SetGate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).SetConfig(ctx, in)
+		return srv.(BeadsServiceServer).SetGate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_SetConfig_FullMethodName,
+		FullMethod: BeadsService_SetGate_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).SetConfig(ctx, req.(*SetConfigRequest))
+		return srv.(BeadsServiceServer).SetGate(ctx, req.(*SetGateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetConfigRequest)
+func _BeadsService_WaiveGate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaiveGateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).GetConfig(ctx, in)
+		return srv.(BeadsServiceServer).WaiveGate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_GetConfig_FullMethodName,
+		FullMethod: BeadsService_WaiveGate_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).GetConfig(ctx, req.(*GetConfigRequest))
+		return srv.(BeadsServiceServer).WaiveGate(ctx, req.(*WaiveGateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_ListConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListConfigsRequest)
+func _BeadsService_EmitHook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmitHookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).ListConfigs(ctx, in)
+		return srv.(BeadsServiceServer).EmitHook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_ListConfigs_FullMethodName,
+		FullMethod: BeadsService_EmitHook_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).ListConfigs(ctx, req.(*ListConfigsRequest))
+		return srv.(BeadsServiceServer).EmitHook(ctx, req.(*EmitHookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_DeleteConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteConfigRequest)
+func _BeadsService_ListAdvice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAdviceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).DeleteConfig(ctx, in)
+		return srv.(BeadsServiceServer).ListAdvice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_DeleteConfig_FullMethodName,
+		FullMethod: BeadsService_ListAdvice_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).DeleteConfig(ctx, req.(*DeleteConfigRequest))
+		return srv.(BeadsServiceServer).ListAdvice(ctx, req.(*ListAdviceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BeadsService_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HealthRequest)
+func _BeadsService_AckAdvice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckAdviceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BeadsServiceServer).Health(ctx, in)
+		return srv.(BeadsServiceServer).AckAdvice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BeadsService_Health_FullMethodName,
+		FullMethod: BeadsService_AckAdvice_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BeadsServiceServer).Health(ctx, req.(*HealthRequest))
+		return srv.(BeadsServiceServer).AckAdvice(ctx, req.(*AckAdviceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -773,6 +2133,18 @@ var BeadsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListBeads",
 			Handler:    _BeadsService_ListBeads_Handler,
 		},
+		{
+			MethodName: "ListReadyBeads",
+			Handler:    _BeadsService_ListReadyBeads_Handler,
+		},
+		{
+			MethodName: "ListBlockedBeads",
+			Handler:    _BeadsService_ListBlockedBeads_Handler,
+		},
+		{
+			MethodName: "PopQueue",
+			Handler:    _BeadsService_PopQueue_Handler,
+		},
 		{
 			MethodName: "UpdateBead",
 			Handler:    _BeadsService_UpdateBead_Handler,
@@ -781,14 +2153,38 @@ var BeadsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CloseBead",
 			Handler:    _BeadsService_CloseBead_Handler,
 		},
+		{
+			MethodName: "ResolveDecision",
+			Handler:    _BeadsService_ResolveDecision_Handler,
+		},
+		{
+			MethodName: "GetDecisionContext",
+			Handler:    _BeadsService_GetDecisionContext_Handler,
+		},
 		{
 			MethodName: "DeleteBead",
 			Handler:    _BeadsService_DeleteBead_Handler,
 		},
+		{
+			MethodName: "MergeBead",
+			Handler:    _BeadsService_MergeBead_Handler,
+		},
+		{
+			MethodName: "CloneBead",
+			Handler:    _BeadsService_CloneBead_Handler,
+		},
+		{
+			MethodName: "FindSimilarBeads",
+			Handler:    _BeadsService_FindSimilarBeads_Handler,
+		},
 		{
 			MethodName: "AddDependency",
 			Handler:    _BeadsService_AddDependency_Handler,
 		},
+		{
+			MethodName: "UpdateDependency",
+			Handler:    _BeadsService_UpdateDependency_Handler,
+		},
 		{
 			MethodName: "RemoveDependency",
 			Handler:    _BeadsService_RemoveDependency_Handler,
@@ -797,6 +2193,38 @@ var BeadsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDependencies",
 			Handler:    _BeadsService_GetDependencies_Handler,
 		},
+		{
+			MethodName: "AddRelation",
+			Handler:    _BeadsService_AddRelation_Handler,
+		},
+		{
+			MethodName: "ListRelations",
+			Handler:    _BeadsService_ListRelations_Handler,
+		},
+		{
+			MethodName: "AddExternalDep",
+			Handler:    _BeadsService_AddExternalDep_Handler,
+		},
+		{
+			MethodName: "ListExternalDeps",
+			Handler:    _BeadsService_ListExternalDeps_Handler,
+		},
+		{
+			MethodName: "UpdateExternalDep",
+			Handler:    _BeadsService_UpdateExternalDep_Handler,
+		},
+		{
+			MethodName: "RemoveExternalDep",
+			Handler:    _BeadsService_RemoveExternalDep_Handler,
+		},
+		{
+			MethodName: "LinkCommit",
+			Handler:    _BeadsService_LinkCommit_Handler,
+		},
+		{
+			MethodName: "ListCommits",
+			Handler:    _BeadsService_ListCommits_Handler,
+		},
 		{
 			MethodName: "AddLabel",
 			Handler:    _BeadsService_AddLabel_Handler,
@@ -809,6 +2237,18 @@ var BeadsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLabels",
 			Handler:    _BeadsService_GetLabels_Handler,
 		},
+		{
+			MethodName: "AddAlias",
+			Handler:    _BeadsService_AddAlias_Handler,
+		},
+		{
+			MethodName: "RemoveAlias",
+			Handler:    _BeadsService_RemoveAlias_Handler,
+		},
+		{
+			MethodName: "ListAliases",
+			Handler:    _BeadsService_ListAliases_Handler,
+		},
 		{
 			MethodName: "AddComment",
 			Handler:    _BeadsService_AddComment_Handler,
@@ -817,10 +2257,42 @@ var BeadsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetComments",
 			Handler:    _BeadsService_GetComments_Handler,
 		},
+		{
+			MethodName: "AddNote",
+			Handler:    _BeadsService_AddNote_Handler,
+		},
+		{
+			MethodName: "GetNotes",
+			Handler:    _BeadsService_GetNotes_Handler,
+		},
 		{
 			MethodName: "GetEvents",
 			Handler:    _BeadsService_GetEvents_Handler,
 		},
+		{
+			MethodName: "GetActivity",
+			Handler:    _BeadsService_GetActivity_Handler,
+		},
+		{
+			MethodName: "WatchBead",
+			Handler:    _BeadsService_WatchBead_Handler,
+		},
+		{
+			MethodName: "UnwatchBead",
+			Handler:    _BeadsService_UnwatchBead_Handler,
+		},
+		{
+			MethodName: "ListNotifications",
+			Handler:    _BeadsService_ListNotifications_Handler,
+		},
+		{
+			MethodName: "MarkNotificationsRead",
+			Handler:    _BeadsService_MarkNotificationsRead_Handler,
+		},
+		{
+			MethodName: "GetDigest",
+			Handler:    _BeadsService_GetDigest_Handler,
+		},
 		{
 			MethodName: "SetConfig",
 			Handler:    _BeadsService_SetConfig_Handler,
@@ -837,10 +2309,58 @@ var BeadsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteConfig",
 			Handler:    _BeadsService_DeleteConfig_Handler,
 		},
+		{
+			MethodName: "GetConfigHistory",
+			Handler:    _BeadsService_GetConfigHistory_Handler,
+		},
+		{
+			MethodName: "RollbackConfig",
+			Handler:    _BeadsService_RollbackConfig_Handler,
+		},
+		{
+			MethodName: "ListAlerts",
+			Handler:    _BeadsService_ListAlerts_Handler,
+		},
 		{
 			MethodName: "Health",
 			Handler:    _BeadsService_Health_Handler,
 		},
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _BeadsService_GetServerInfo_Handler,
+		},
+		{
+			MethodName: "RegisterAgent",
+			Handler:    _BeadsService_RegisterAgent_Handler,
+		},
+		{
+			MethodName: "ListAgents",
+			Handler:    _BeadsService_ListAgents_Handler,
+		},
+		{
+			MethodName: "ListGates",
+			Handler:    _BeadsService_ListGates_Handler,
+		},
+		{
+			MethodName: "SetGate",
+			Handler:    _BeadsService_SetGate_Handler,
+		},
+		{
+			MethodName: "WaiveGate",
+			Handler:    _BeadsService_WaiveGate_Handler,
+		},
+		{
+			MethodName: "EmitHook",
+			Handler:    _BeadsService_EmitHook_Handler,
+		},
+		{
+			MethodName: "ListAdvice",
+			Handler:    _BeadsService_ListAdvice_Handler,
+		},
+		{
+			MethodName: "AckAdvice",
+			Handler:    _BeadsService_AckAdvice_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "beads/v1/service.proto",
@@ -24,28 +24,46 @@ const (
 
 // Bead is the core work-item record.
 type Bead struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
-	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
-	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
-	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
-	Notes         string                 `protobuf:"bytes,7,opt,name=notes,proto3" json:"notes,omitempty"`
-	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
-	Priority      int32                  `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
-	Assignee      string                 `protobuf:"bytes,10,opt,name=assignee,proto3" json:"assignee,omitempty"`
-	Owner         string                 `protobuf:"bytes,11,opt,name=owner,proto3" json:"owner,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	CreatedBy     string                 `protobuf:"bytes,13,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	ClosedAt      *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=closed_at,json=closedAt,proto3,oneof" json:"closed_at,omitempty"`
-	DueAt         *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=due_at,json=dueAt,proto3,oneof" json:"due_at,omitempty"`
-	DeferUntil    *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=defer_until,json=deferUntil,proto3,oneof" json:"defer_until,omitempty"`
-	Fields        []byte                 `protobuf:"bytes,18,opt,name=fields,proto3" json:"fields,omitempty"`
-	Labels        []string               `protobuf:"bytes,19,rep,name=labels,proto3" json:"labels,omitempty"`
-	Dependencies  []*Dependency          `protobuf:"bytes,20,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
-	Comments      []*Comment             `protobuf:"bytes,21,rep,name=comments,proto3" json:"comments,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Slug         string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Kind         string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Type         string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Title        string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	Description  string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Notes        string                 `protobuf:"bytes,7,opt,name=notes,proto3" json:"notes,omitempty"`
+	Status       string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Priority     int32                  `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	Assignee     string                 `protobuf:"bytes,10,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Owner        string                 `protobuf:"bytes,11,opt,name=owner,proto3" json:"owner,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy    string                 `protobuf:"bytes,13,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedAt    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ClosedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=closed_at,json=closedAt,proto3,oneof" json:"closed_at,omitempty"`
+	DueAt        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=due_at,json=dueAt,proto3,oneof" json:"due_at,omitempty"`
+	DeferUntil   *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=defer_until,json=deferUntil,proto3,oneof" json:"defer_until,omitempty"`
+	Fields       []byte                 `protobuf:"bytes,18,opt,name=fields,proto3" json:"fields,omitempty"`
+	Labels       []string               `protobuf:"bytes,19,rep,name=labels,proto3" json:"labels,omitempty"`
+	Dependencies []*Dependency          `protobuf:"bytes,20,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	Comments     []*Comment             `protobuf:"bytes,21,rep,name=comments,proto3" json:"comments,omitempty"`
+	// Computed by the server on read; ignored on write.
+	AgeDays        int32                  `protobuf:"varint,22,opt,name=age_days,json=ageDays,proto3" json:"age_days,omitempty"`                             // whole days since created_at
+	BlockedCount   int32                  `protobuf:"varint,23,opt,name=blocked_count,json=blockedCount,proto3" json:"blocked_count,omitempty"`              // unclosed beads this one blocks
+	LastActivityAt *timestamppb.Timestamp `protobuf:"bytes,24,opt,name=last_activity_at,json=lastActivityAt,proto3,oneof" json:"last_activity_at,omitempty"` // latest of updated_at, comments, events
+	// Set on closed beads hidden from default listings by the archival policy.
+	ArchivedAt     *timestamppb.Timestamp `protobuf:"bytes,25,opt,name=archived_at,json=archivedAt,proto3,oneof" json:"archived_at,omitempty"`
+	ChecklistDone  int32                  `protobuf:"varint,26,opt,name=checklist_done,json=checklistDone,proto3" json:"checklist_done,omitempty"`    // checked checklist items; computed on read
+	ChecklistTotal int32                  `protobuf:"varint,27,opt,name=checklist_total,json=checklistTotal,proto3" json:"checklist_total,omitempty"` // checklist items; computed on read
+	Checklist      []*ChecklistItem       `protobuf:"bytes,28,rep,name=checklist,proto3" json:"checklist,omitempty"`                                  // set by GetBead
+	// The highest priority (lowest number) among the bead and the unclosed
+	// beads it blocks, directly or transitively; computed on read.
+	EffectivePriority *int32 `protobuf:"varint,29,opt,name=effective_priority,json=effectivePriority,proto3,oneof" json:"effective_priority,omitempty"`
+	// Set by GetBead when effective_priority is inherited: the blocking chain
+	// of bead IDs from this bead to the one it is inherited from.
+	PriorityChain []string `protobuf:"bytes,30,rep,name=priority_chain,json=priorityChain,proto3" json:"priority_chain,omitempty"`
+	// Points, such as "3", or a duration of effort, such as "4h".
+	Estimate      string `protobuf:"bytes,31,opt,name=estimate,proto3" json:"estimate,omitempty"`
+	Actual        string `protobuf:"bytes,32,opt,name=actual,proto3" json:"actual,omitempty"` // the effort spent, in the same form
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -227,6 +245,161 @@ func (x *Bead) GetComments() []*Comment {
 	return nil
 }
 
+func (x *Bead) GetAgeDays() int32 {
+	if x != nil {
+		return x.AgeDays
+	}
+	return 0
+}
+
+func (x *Bead) GetBlockedCount() int32 {
+	if x != nil {
+		return x.BlockedCount
+	}
+	return 0
+}
+
+func (x *Bead) GetLastActivityAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return nil
+}
+
+func (x *Bead) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Bead) GetChecklistDone() int32 {
+	if x != nil {
+		return x.ChecklistDone
+	}
+	return 0
+}
+
+func (x *Bead) GetChecklistTotal() int32 {
+	if x != nil {
+		return x.ChecklistTotal
+	}
+	return 0
+}
+
+func (x *Bead) GetChecklist() []*ChecklistItem {
+	if x != nil {
+		return x.Checklist
+	}
+	return nil
+}
+
+func (x *Bead) GetEffectivePriority() int32 {
+	if x != nil && x.EffectivePriority != nil {
+		return *x.EffectivePriority
+	}
+	return 0
+}
+
+func (x *Bead) GetPriorityChain() []string {
+	if x != nil {
+		return x.PriorityChain
+	}
+	return nil
+}
+
+func (x *Bead) GetEstimate() string {
+	if x != nil {
+		return x.Estimate
+	}
+	return ""
+}
+
+func (x *Bead) GetActual() string {
+	if x != nil {
+		return x.Actual
+	}
+	return ""
+}
+
+// ChecklistItem is one entry of a bead's checklist. index is its 1-based
+// position, fixed when it is added.
+type ChecklistItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Checked       bool                   `protobuf:"varint,3,opt,name=checked,proto3" json:"checked,omitempty"`
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=checked_at,json=checkedAt,proto3,oneof" json:"checked_at,omitempty"`
+	CheckedBy     string                 `protobuf:"bytes,5,opt,name=checked_by,json=checkedBy,proto3" json:"checked_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChecklistItem) Reset() {
+	*x = ChecklistItem{}
+	mi := &file_beads_v1_types_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChecklistItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecklistItem) ProtoMessage() {}
+
+func (x *ChecklistItem) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecklistItem.ProtoReflect.Descriptor instead.
+func (*ChecklistItem) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ChecklistItem) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ChecklistItem) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ChecklistItem) GetChecked() bool {
+	if x != nil {
+		return x.Checked
+	}
+	return false
+}
+
+func (x *ChecklistItem) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *ChecklistItem) GetCheckedBy() string {
+	if x != nil {
+		return x.CheckedBy
+	}
+	return ""
+}
+
 // Dependency represents a directional relationship between two beads.
 type Dependency struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -235,14 +408,14 @@ type Dependency struct {
 	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	Metadata      string                 `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Metadata      string                 `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"` // JSON object
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Dependency) Reset() {
 	*x = Dependency{}
-	mi := &file_beads_v1_types_proto_msgTypes[1]
+	mi := &file_beads_v1_types_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -254,7 +427,7 @@ func (x *Dependency) String() string {
 func (*Dependency) ProtoMessage() {}
 
 func (x *Dependency) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_types_proto_msgTypes[1]
+	mi := &file_beads_v1_types_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -267,7 +440,7 @@ func (x *Dependency) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Dependency.ProtoReflect.Descriptor instead.
 func (*Dependency) Descriptor() ([]byte, []int) {
-	return file_beads_v1_types_proto_rawDescGZIP(), []int{1}
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Dependency) GetBeadId() string {
@@ -312,33 +485,40 @@ func (x *Dependency) GetMetadata() string {
 	return ""
 }
 
-// Comment represents a comment on a bead.
-type Comment struct {
+// ExternalDep is a dependency of a bead on a URL outside the bead graph.
+// While status is "waiting" it blocks the bead. probe is "", "http" or
+// "github-pr".
+type ExternalDep struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	BeadId        string                 `protobuf:"bytes,2,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
-	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
-	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Probe         string                 `protobuf:"bytes,6,opt,name=probe,proto3" json:"probe,omitempty"`
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	LastError     string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Comment) Reset() {
-	*x = Comment{}
-	mi := &file_beads_v1_types_proto_msgTypes[2]
+func (x *ExternalDep) Reset() {
+	*x = ExternalDep{}
+	mi := &file_beads_v1_types_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Comment) String() string {
+func (x *ExternalDep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Comment) ProtoMessage() {}
+func (*ExternalDep) ProtoMessage() {}
 
-func (x *Comment) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_types_proto_msgTypes[2]
+func (x *ExternalDep) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,74 +529,111 @@ func (x *Comment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
-func (*Comment) Descriptor() ([]byte, []int) {
-	return file_beads_v1_types_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ExternalDep.ProtoReflect.Descriptor instead.
+func (*ExternalDep) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Comment) GetId() int64 {
+func (x *ExternalDep) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Comment) GetBeadId() string {
+func (x *ExternalDep) GetBeadId() string {
 	if x != nil {
 		return x.BeadId
 	}
 	return ""
 }
 
-func (x *Comment) GetAuthor() string {
+func (x *ExternalDep) GetUrl() string {
 	if x != nil {
-		return x.Author
+		return x.Url
 	}
 	return ""
 }
 
-func (x *Comment) GetText() string {
+func (x *ExternalDep) GetDescription() string {
 	if x != nil {
-		return x.Text
+		return x.Description
 	}
 	return ""
 }
 
-func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
+func (x *ExternalDep) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExternalDep) GetProbe() string {
+	if x != nil {
+		return x.Probe
+	}
+	return ""
+}
+
+func (x *ExternalDep) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *ExternalDep) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ExternalDep) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ExternalDep) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return nil
 }
 
-// Event is a persisted event record.
-type Event struct {
+// Commit is a git commit linked to a bead.
+type Commit struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
-	BeadId        string                 `protobuf:"bytes,3,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
-	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
-	Payload       []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	BeadId        string                 `protobuf:"bytes,2,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Repo          string                 `protobuf:"bytes,3,opt,name=repo,proto3" json:"repo,omitempty"`
+	Sha           string                 `protobuf:"bytes,4,opt,name=sha,proto3" json:"sha,omitempty"`
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Author        string                 `protobuf:"bytes,6,opt,name=author,proto3" json:"author,omitempty"`
+	LinkedBy      string                 `protobuf:"bytes,7,opt,name=linked_by,json=linkedBy,proto3" json:"linked_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Event) Reset() {
-	*x = Event{}
-	mi := &file_beads_v1_types_proto_msgTypes[3]
+func (x *Commit) Reset() {
+	*x = Commit{}
+	mi := &file_beads_v1_types_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Event) String() string {
+func (x *Commit) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Event) ProtoMessage() {}
+func (*Commit) ProtoMessage() {}
 
-func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_types_proto_msgTypes[3]
+func (x *Commit) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -427,79 +644,99 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Event.ProtoReflect.Descriptor instead.
-func (*Event) Descriptor() ([]byte, []int) {
-	return file_beads_v1_types_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Commit.ProtoReflect.Descriptor instead.
+func (*Commit) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Event) GetId() int64 {
+func (x *Commit) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Event) GetTopic() string {
+func (x *Commit) GetBeadId() string {
 	if x != nil {
-		return x.Topic
+		return x.BeadId
 	}
 	return ""
 }
 
-func (x *Event) GetBeadId() string {
+func (x *Commit) GetRepo() string {
 	if x != nil {
-		return x.BeadId
+		return x.Repo
 	}
 	return ""
 }
 
-func (x *Event) GetActor() string {
+func (x *Commit) GetSha() string {
 	if x != nil {
-		return x.Actor
+		return x.Sha
 	}
 	return ""
 }
 
-func (x *Event) GetPayload() []byte {
+func (x *Commit) GetMessage() string {
 	if x != nil {
-		return x.Payload
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-func (x *Event) GetCreatedAt() *timestamppb.Timestamp {
+func (x *Commit) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Commit) GetLinkedBy() string {
+	if x != nil {
+		return x.LinkedBy
+	}
+	return ""
+}
+
+func (x *Commit) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.CreatedAt
 	}
 	return nil
 }
 
-// Config is a key-value configuration record.
-type Config struct {
+// Relation is a non-blocking relation seen from one bead. direction is
+// "outgoing" when the relation was made from that bead and "incoming" when
+// it points at it; label reads from that bead, e.g. "caused by" or "causes".
+type Relation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	BeadId        string                 `protobuf:"bytes,1,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"` // the bead at the other end
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Direction     string                 `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"`
+	Label         string                 `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Config) Reset() {
-	*x = Config{}
-	mi := &file_beads_v1_types_proto_msgTypes[4]
+func (x *Relation) Reset() {
+	*x = Relation{}
+	mi := &file_beads_v1_types_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Config) String() string {
+func (x *Relation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Config) ProtoMessage() {}
+func (*Relation) ProtoMessage() {}
 
-func (x *Config) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_v1_types_proto_msgTypes[4]
+func (x *Relation) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,44 +747,1267 @@ func (x *Config) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Config.ProtoReflect.Descriptor instead.
-func (*Config) Descriptor() ([]byte, []int) {
-	return file_beads_v1_types_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Relation.ProtoReflect.Descriptor instead.
+func (*Relation) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Config) GetKey() string {
+func (x *Relation) GetBeadId() string {
 	if x != nil {
-		return x.Key
+		return x.BeadId
 	}
 	return ""
 }
 
-func (x *Config) GetValue() []byte {
+func (x *Relation) GetTitle() string {
 	if x != nil {
-		return x.Value
+		return x.Title
 	}
-	return nil
+	return ""
 }
 
-func (x *Config) GetCreatedAt() *timestamppb.Timestamp {
+func (x *Relation) GetStatus() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Status
 	}
-	return nil
+	return ""
 }
 
-func (x *Config) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *Relation) GetType() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Type
 	}
-	return nil
+	return ""
 }
 
-var File_beads_v1_types_proto protoreflect.FileDescriptor
+func (x *Relation) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
 
-const file_beads_v1_types_proto_rawDesc = "" +
-	"\n" +
-	"\x14beads/v1/types.proto\x12\bbeads.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x95\x06\n" +
+func (x *Relation) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Relation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Relation) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+// Comment represents a comment on a bead.
+type Comment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BeadId        string                 `protobuf:"bytes,2,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Reactions     map[string]int32       `protobuf:"bytes,6,rep,name=reactions,proto3" json:"reactions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // emoji -> number of actors who reacted
+	ResolvedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`                                                        // set once the discussion is marked handled
+	ResolvedBy    string                 `protobuf:"bytes,8,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	Verified      bool                   `protobuf:"varint,9,opt,name=verified,proto3" json:"verified,omitempty"`                                // author is the caller's authenticated identity
+	ClaimedAuthor string                 `protobuf:"bytes,10,opt,name=claimed_author,json=claimedAuthor,proto3" json:"claimed_author,omitempty"` // author the caller claimed, when someone else
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_beads_v1_types_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Comment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Comment) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *Comment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Comment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Comment) GetReactions() map[string]int32 {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *Comment) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *Comment) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *Comment) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *Comment) GetClaimedAuthor() string {
+	if x != nil {
+		return x.ClaimedAuthor
+	}
+	return ""
+}
+
+// Alias is a hand-picked name for a bead, accepted wherever its ID is.
+type Alias struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alias         string                 `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	BeadId        string                 `protobuf:"bytes,2,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alias) Reset() {
+	*x = Alias{}
+	mi := &file_beads_v1_types_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alias) ProtoMessage() {}
+
+func (x *Alias) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alias.ProtoReflect.Descriptor instead.
+func (*Alias) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Alias) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *Alias) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *Alias) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Alias) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// SimilarBead is a bead with a title similar to another, scored by trigram
+// similarity from 0 to 1.
+type SimilarBead struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bead          *Bead                  `protobuf:"bytes,1,opt,name=bead,proto3" json:"bead,omitempty"`
+	Similarity    float64                `protobuf:"fixed64,2,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarBead) Reset() {
+	*x = SimilarBead{}
+	mi := &file_beads_v1_types_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarBead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarBead) ProtoMessage() {}
+
+func (x *SimilarBead) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarBead.ProtoReflect.Descriptor instead.
+func (*SimilarBead) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SimilarBead) GetBead() *Bead {
+	if x != nil {
+		return x.Bead
+	}
+	return nil
+}
+
+func (x *SimilarBead) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+// Note is a timestamped, attributed entry appended to a bead's notes.
+type Note struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BeadId        string                 `protobuf:"bytes,2,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Note) Reset() {
+	*x = Note{}
+	mi := &file_beads_v1_types_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Note) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Note) ProtoMessage() {}
+
+func (x *Note) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Note.ProtoReflect.Descriptor instead.
+func (*Note) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Note) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Note) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *Note) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Note) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Note) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Event is a persisted event record.
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	BeadId        string                 `protobuf:"bytes,3,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_beads_v1_types_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Event) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Event) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *Event) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Event) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Event) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ActivityEntry is one event or comment in a bead's activity feed.
+type ActivityEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`   // bead, comment, label, dependency, note, decision, rule, other
+	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"` // empty for comments
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Summary       string                 `protobuf:"bytes,4,opt,name=summary,proto3" json:"summary,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	EventId       int64                  `protobuf:"varint,6,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	CommentId     int64                  `protobuf:"varint,7,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivityEntry) Reset() {
+	*x = ActivityEntry{}
+	mi := &file_beads_v1_types_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivityEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivityEntry) ProtoMessage() {}
+
+func (x *ActivityEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivityEntry.ProtoReflect.Descriptor instead.
+func (*ActivityEntry) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ActivityEntry) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ActivityEntry) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *ActivityEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ActivityEntry) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *ActivityEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ActivityEntry) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *ActivityEntry) GetCommentId() int64 {
+	if x != nil {
+		return x.CommentId
+	}
+	return 0
+}
+
+// Notification tells an actor about an event on a bead they watch.
+type Notification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	Event         *Event                 `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReadAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_beads_v1_types_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Notification) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Notification) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Notification) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *Notification) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Notification) GetReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadAt
+	}
+	return nil
+}
+
+// Config is a key-value configuration record.
+type Config struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Rev           int64                  `protobuf:"varint,5,opt,name=rev,proto3" json:"rev,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	mi := &file_beads_v1_types_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Config) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Config) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Config) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Config) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Config) GetRev() int64 {
+	if x != nil {
+		return x.Rev
+	}
+	return 0
+}
+
+// ConfigRevision is one saved version of a config. Deletions are recorded
+// as revisions with deleted set and no value.
+type ConfigRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Rev           int64                  `protobuf:"varint,2,opt,name=rev,proto3" json:"rev,omitempty"`
+	Value         []byte                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Deleted       bool                   `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigRevision) Reset() {
+	*x = ConfigRevision{}
+	mi := &file_beads_v1_types_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigRevision) ProtoMessage() {}
+
+func (x *ConfigRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigRevision.ProtoReflect.Descriptor instead.
+func (*ConfigRevision) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ConfigRevision) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigRevision) GetRev() int64 {
+	if x != nil {
+		return x.Rev
+	}
+	return 0
+}
+
+func (x *ConfigRevision) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ConfigRevision) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *ConfigRevision) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ConfigRevision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Gate is one gate of an agent's checklist and whether it is satisfied.
+type Gate struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Severity    string                 `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"` // "block" or "warn"
+	Hooks       []string               `protobuf:"bytes,4,rep,name=hooks,proto3" json:"hooks,omitempty"`
+	Satisfied   bool                   `protobuf:"varint,5,opt,name=satisfied,proto3" json:"satisfied,omitempty"`
+	BeadId      string                 `protobuf:"bytes,6,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	// Set while a waiver suppresses the gate.
+	WaivedUntil   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=waived_until,json=waivedUntil,proto3" json:"waived_until,omitempty"`
+	WaivedBy      string                 `protobuf:"bytes,8,opt,name=waived_by,json=waivedBy,proto3" json:"waived_by,omitempty"`
+	WaiveReason   string                 `protobuf:"bytes,9,opt,name=waive_reason,json=waiveReason,proto3" json:"waive_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Gate) Reset() {
+	*x = Gate{}
+	mi := &file_beads_v1_types_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Gate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Gate) ProtoMessage() {}
+
+func (x *Gate) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Gate.ProtoReflect.Descriptor instead.
+func (*Gate) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Gate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Gate) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Gate) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Gate) GetHooks() []string {
+	if x != nil {
+		return x.Hooks
+	}
+	return nil
+}
+
+func (x *Gate) GetSatisfied() bool {
+	if x != nil {
+		return x.Satisfied
+	}
+	return false
+}
+
+func (x *Gate) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *Gate) GetWaivedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WaivedUntil
+	}
+	return nil
+}
+
+func (x *Gate) GetWaivedBy() string {
+	if x != nil {
+		return x.WaivedBy
+	}
+	return ""
+}
+
+func (x *Gate) GetWaiveReason() string {
+	if x != nil {
+		return x.WaiveReason
+	}
+	return ""
+}
+
+// BeadSummary is the compact view of a bead linked from a decision.
+type BeadSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Priority      int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Assignee      string                 `protobuf:"bytes,6,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Labels        []string               `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty"`
+	Summary       string                 `protobuf:"bytes,8,opt,name=summary,proto3" json:"summary,omitempty"` // description, truncated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeadSummary) Reset() {
+	*x = BeadSummary{}
+	mi := &file_beads_v1_types_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeadSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeadSummary) ProtoMessage() {}
+
+func (x *BeadSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeadSummary.ProtoReflect.Descriptor instead.
+func (*BeadSummary) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BeadSummary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BeadSummary) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *BeadSummary) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *BeadSummary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BeadSummary) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *BeadSummary) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *BeadSummary) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *BeadSummary) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+// BlockedBead is a bead, the unclosed beads blocking it and the URLs of
+// the external dependencies it is waiting on.
+type BlockedBead struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bead          *Bead                  `protobuf:"bytes,1,opt,name=bead,proto3" json:"bead,omitempty"`
+	BlockedBy     []string               `protobuf:"bytes,2,rep,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"`
+	WaitingOn     []string               `protobuf:"bytes,3,rep,name=waiting_on,json=waitingOn,proto3" json:"waiting_on,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockedBead) Reset() {
+	*x = BlockedBead{}
+	mi := &file_beads_v1_types_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockedBead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockedBead) ProtoMessage() {}
+
+func (x *BlockedBead) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockedBead.ProtoReflect.Descriptor instead.
+func (*BlockedBead) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BlockedBead) GetBead() *Bead {
+	if x != nil {
+		return x.Bead
+	}
+	return nil
+}
+
+func (x *BlockedBead) GetBlockedBy() []string {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return nil
+}
+
+func (x *BlockedBead) GetWaitingOn() []string {
+	if x != nil {
+		return x.WaitingOn
+	}
+	return nil
+}
+
+// Agent is one entry of the agent roster.
+type Agent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BeadId        string                 `protobuf:"bytes,2,opt,name=bead_id,json=beadId,proto3" json:"bead_id,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // status of the agent bead
+	Held          []string               `protobuf:"bytes,5,rep,name=held,proto3" json:"held,omitempty"`     // in-progress beads assigned to the agent
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Agent) Reset() {
+	*x = Agent{}
+	mi := &file_beads_v1_types_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Agent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Agent) ProtoMessage() {}
+
+func (x *Agent) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Agent.ProtoReflect.Descriptor instead.
+func (*Agent) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Agent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Agent) GetBeadId() string {
+	if x != nil {
+		return x.BeadId
+	}
+	return ""
+}
+
+func (x *Agent) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Agent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Agent) GetHeld() []string {
+	if x != nil {
+		return x.Held
+	}
+	return nil
+}
+
+func (x *Agent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Agent) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+// Alert is the current state of a threshold alert rule.
+type Alert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Metric        string                 `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric,omitempty"`
+	Threshold     float64                `protobuf:"fixed64,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Value         float64                `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	Firing        bool                   `protobuf:"varint,5,opt,name=firing,proto3" json:"firing,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=since,proto3,oneof" json:"since,omitempty"`
+	EvaluatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=evaluated_at,json=evaluatedAt,proto3" json:"evaluated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_beads_v1_types_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_v1_types_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_beads_v1_types_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Alert) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Alert) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *Alert) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *Alert) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Alert) GetFiring() bool {
+	if x != nil {
+		return x.Firing
+	}
+	return false
+}
+
+func (x *Alert) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *Alert) GetEvaluatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EvaluatedAt
+	}
+	return nil
+}
+
+var File_beads_v1_types_proto protoreflect.FileDescriptor
+
+const file_beads_v1_types_proto_rawDesc = "" +
+	"\n" +
+	"\x14beads/v1/types.proto\x12\bbeads.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb4\n" +
+	"\n" +
 	"\x04Bead\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x12\n" +
@@ -574,11 +2034,35 @@ const file_beads_v1_types_proto_rawDesc = "" +
 	"\x06fields\x18\x12 \x01(\fR\x06fields\x12\x16\n" +
 	"\x06labels\x18\x13 \x03(\tR\x06labels\x128\n" +
 	"\fdependencies\x18\x14 \x03(\v2\x14.beads.v1.DependencyR\fdependencies\x12-\n" +
-	"\bcomments\x18\x15 \x03(\v2\x11.beads.v1.CommentR\bcommentsB\f\n" +
+	"\bcomments\x18\x15 \x03(\v2\x11.beads.v1.CommentR\bcomments\x12\x19\n" +
+	"\bage_days\x18\x16 \x01(\x05R\aageDays\x12#\n" +
+	"\rblocked_count\x18\x17 \x01(\x05R\fblockedCount\x12I\n" +
+	"\x10last_activity_at\x18\x18 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\x0elastActivityAt\x88\x01\x01\x12@\n" +
+	"\varchived_at\x18\x19 \x01(\v2\x1a.google.protobuf.TimestampH\x04R\n" +
+	"archivedAt\x88\x01\x01\x12%\n" +
+	"\x0echecklist_done\x18\x1a \x01(\x05R\rchecklistDone\x12'\n" +
+	"\x0fchecklist_total\x18\x1b \x01(\x05R\x0echecklistTotal\x125\n" +
+	"\tchecklist\x18\x1c \x03(\v2\x17.beads.v1.ChecklistItemR\tchecklist\x122\n" +
+	"\x12effective_priority\x18\x1d \x01(\x05H\x05R\x11effectivePriority\x88\x01\x01\x12%\n" +
+	"\x0epriority_chain\x18\x1e \x03(\tR\rpriorityChain\x12\x1a\n" +
+	"\bestimate\x18\x1f \x01(\tR\bestimate\x12\x16\n" +
+	"\x06actual\x18  \x01(\tR\x06actualB\f\n" +
 	"\n" +
 	"_closed_atB\t\n" +
 	"\a_due_atB\x0e\n" +
-	"\f_defer_until\"\xd3\x01\n" +
+	"\f_defer_untilB\x13\n" +
+	"\x11_last_activity_atB\x0e\n" +
+	"\f_archived_atB\x15\n" +
+	"\x13_effective_priority\"\xc1\x01\n" +
+	"\rChecklistItem\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x18\n" +
+	"\achecked\x18\x03 \x01(\bR\achecked\x12>\n" +
+	"\n" +
+	"checked_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tcheckedAt\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"checked_by\x18\x05 \x01(\tR\tcheckedByB\r\n" +
+	"\v_checked_at\"\xd3\x01\n" +
 	"\n" +
 	"Dependency\x12\x17\n" +
 	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\"\n" +
@@ -588,13 +2072,80 @@ const file_beads_v1_types_proto_rawDesc = "" +
 	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"created_by\x18\x05 \x01(\tR\tcreatedBy\x12\x1a\n" +
-	"\bmetadata\x18\x06 \x01(\tR\bmetadata\"\x99\x01\n" +
+	"\bmetadata\x18\x06 \x01(\tR\bmetadata\"\xcc\x02\n" +
+	"\vExternalDep\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\abead_id\x18\x02 \x01(\tR\x06beadId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x14\n" +
+	"\x05probe\x18\x06 \x01(\tR\x05probe\x129\n" +
+	"\n" +
+	"checked_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xe1\x01\n" +
+	"\x06Commit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\abead_id\x18\x02 \x01(\tR\x06beadId\x12\x12\n" +
+	"\x04repo\x18\x03 \x01(\tR\x04repo\x12\x10\n" +
+	"\x03sha\x18\x04 \x01(\tR\x03sha\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x16\n" +
+	"\x06author\x18\x06 \x01(\tR\x06author\x12\x1b\n" +
+	"\tlinked_by\x18\a \x01(\tR\blinkedBy\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf3\x01\n" +
+	"\bRelation\x12\x17\n" +
+	"\abead_id\x18\x01 \x01(\tR\x06beadId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1c\n" +
+	"\tdirection\x18\x05 \x01(\tR\tdirection\x12\x14\n" +
+	"\x05label\x18\x06 \x01(\tR\x05label\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\"\xb8\x03\n" +
 	"\aComment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\abead_id\x18\x02 \x01(\tR\x06beadId\x12\x16\n" +
 	"\x06author\x18\x03 \x01(\tR\x06author\x12\x12\n" +
 	"\x04text\x18\x04 \x01(\tR\x04text\x129\n" +
 	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
+	"\treactions\x18\x06 \x03(\v2 .beads.v1.Comment.ReactionsEntryR\treactions\x12;\n" +
+	"\vresolved_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\x12\x1f\n" +
+	"\vresolved_by\x18\b \x01(\tR\n" +
+	"resolvedBy\x12\x1a\n" +
+	"\bverified\x18\t \x01(\bR\bverified\x12%\n" +
+	"\x0eclaimed_author\x18\n" +
+	" \x01(\tR\rclaimedAuthor\x1a<\n" +
+	"\x0eReactionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x90\x01\n" +
+	"\x05Alias\x12\x14\n" +
+	"\x05alias\x18\x01 \x01(\tR\x05alias\x12\x17\n" +
+	"\abead_id\x18\x02 \x01(\tR\x06beadId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"Q\n" +
+	"\vSimilarBead\x12\"\n" +
+	"\x04bead\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x04bead\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x02 \x01(\x01R\n" +
+	"similarity\"\x96\x01\n" +
+	"\x04Note\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\abead_id\x18\x02 \x01(\tR\x06beadId\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x129\n" +
+	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xb1\x01\n" +
 	"\x05Event\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
@@ -603,14 +2154,84 @@ const file_beads_v1_types_proto_rawDesc = "" +
 	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x18\n" +
 	"\apayload\x18\x05 \x01(\fR\apayload\x129\n" +
 	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa6\x01\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xde\x01\n" +
+	"\rActivityEntry\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x18\n" +
+	"\asummary\x18\x04 \x01(\tR\asummary\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x19\n" +
+	"\bevent_id\x18\x06 \x01(\x03R\aeventId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\a \x01(\x03R\tcommentId\"\xcb\x01\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12%\n" +
+	"\x05event\x18\x03 \x01(\v2\x0f.beads.v1.EventR\x05event\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x123\n" +
+	"\aread_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\"\xb8\x01\n" +
 	"\x06Config\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\fR\x05value\x129\n" +
 	"\n" +
 	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB5Z3github.com/alfredjeanlab/beads/gen/beads/v1;beadsv1b\x06proto3"
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x10\n" +
+	"\x03rev\x18\x05 \x01(\x03R\x03rev\"\xb5\x01\n" +
+	"\x0eConfigRevision\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x10\n" +
+	"\x03rev\x18\x02 \x01(\x03R\x03rev\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\fR\x05value\x12\x18\n" +
+	"\adeleted\x18\x04 \x01(\bR\adeleted\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa4\x02\n" +
+	"\x04Gate\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bseverity\x18\x03 \x01(\tR\bseverity\x12\x14\n" +
+	"\x05hooks\x18\x04 \x03(\tR\x05hooks\x12\x1c\n" +
+	"\tsatisfied\x18\x05 \x01(\bR\tsatisfied\x12\x17\n" +
+	"\abead_id\x18\x06 \x01(\tR\x06beadId\x12=\n" +
+	"\fwaived_until\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vwaivedUntil\x12\x1b\n" +
+	"\twaived_by\x18\b \x01(\tR\bwaivedBy\x12!\n" +
+	"\fwaive_reason\x18\t \x01(\tR\vwaiveReason\"\xc9\x01\n" +
+	"\vBeadSummary\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x12\x1a\n" +
+	"\bassignee\x18\x06 \x01(\tR\bassignee\x12\x16\n" +
+	"\x06labels\x18\a \x03(\tR\x06labels\x12\x18\n" +
+	"\asummary\x18\b \x01(\tR\asummary\"o\n" +
+	"\vBlockedBead\x12\"\n" +
+	"\x04bead\x18\x01 \x01(\v2\x0e.beads.v1.BeadR\x04bead\x12\x1d\n" +
+	"\n" +
+	"blocked_by\x18\x02 \x03(\tR\tblockedBy\x12\x1d\n" +
+	"\n" +
+	"waiting_on\x18\x03 \x03(\tR\twaitingOn\"\xce\x01\n" +
+	"\x05Agent\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x17\n" +
+	"\abead_id\x18\x02 \x01(\tR\x06beadId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x12\n" +
+	"\x04held\x18\x05 \x03(\tR\x04held\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\"\xff\x01\n" +
+	"\x05Alert\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06metric\x18\x02 \x01(\tR\x06metric\x12\x1c\n" +
+	"\tthreshold\x18\x03 \x01(\x01R\tthreshold\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x12\x16\n" +
+	"\x06firing\x18\x05 \x01(\bR\x06firing\x125\n" +
+	"\x05since\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x05since\x88\x01\x01\x12=\n" +
+	"\fevaluated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vevaluatedAtB\b\n" +
+	"\x06_sinceB5Z3github.com/alfredjeanlab/beads/gen/beads/v1;beadsv1b\x06proto3"
 
 var (
 	file_beads_v1_types_proto_rawDescOnce sync.Once
@@ -624,33 +2245,72 @@ func file_beads_v1_types_proto_rawDescGZIP() []byte {
 	return file_beads_v1_types_proto_rawDescData
 }
 
-var file_beads_v1_types_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_beads_v1_types_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_beads_v1_types_proto_goTypes = []any{
 	(*Bead)(nil),                  // 0: beads.v1.Bead
-	(*Dependency)(nil),            // 1: beads.v1.Dependency
-	(*Comment)(nil),               // 2: beads.v1.Comment
-	(*Event)(nil),                 // 3: beads.v1.Event
-	(*Config)(nil),                // 4: beads.v1.Config
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*ChecklistItem)(nil),         // 1: beads.v1.ChecklistItem
+	(*Dependency)(nil),            // 2: beads.v1.Dependency
+	(*ExternalDep)(nil),           // 3: beads.v1.ExternalDep
+	(*Commit)(nil),                // 4: beads.v1.Commit
+	(*Relation)(nil),              // 5: beads.v1.Relation
+	(*Comment)(nil),               // 6: beads.v1.Comment
+	(*Alias)(nil),                 // 7: beads.v1.Alias
+	(*SimilarBead)(nil),           // 8: beads.v1.SimilarBead
+	(*Note)(nil),                  // 9: beads.v1.Note
+	(*Event)(nil),                 // 10: beads.v1.Event
+	(*ActivityEntry)(nil),         // 11: beads.v1.ActivityEntry
+	(*Notification)(nil),          // 12: beads.v1.Notification
+	(*Config)(nil),                // 13: beads.v1.Config
+	(*ConfigRevision)(nil),        // 14: beads.v1.ConfigRevision
+	(*Gate)(nil),                  // 15: beads.v1.Gate
+	(*BeadSummary)(nil),           // 16: beads.v1.BeadSummary
+	(*BlockedBead)(nil),           // 17: beads.v1.BlockedBead
+	(*Agent)(nil),                 // 18: beads.v1.Agent
+	(*Alert)(nil),                 // 19: beads.v1.Alert
+	nil,                           // 20: beads.v1.Comment.ReactionsEntry
+	(*timestamppb.Timestamp)(nil), // 21: google.protobuf.Timestamp
 }
 var file_beads_v1_types_proto_depIdxs = []int32{
-	5,  // 0: beads.v1.Bead.created_at:type_name -> google.protobuf.Timestamp
-	5,  // 1: beads.v1.Bead.updated_at:type_name -> google.protobuf.Timestamp
-	5,  // 2: beads.v1.Bead.closed_at:type_name -> google.protobuf.Timestamp
-	5,  // 3: beads.v1.Bead.due_at:type_name -> google.protobuf.Timestamp
-	5,  // 4: beads.v1.Bead.defer_until:type_name -> google.protobuf.Timestamp
-	1,  // 5: beads.v1.Bead.dependencies:type_name -> beads.v1.Dependency
-	2,  // 6: beads.v1.Bead.comments:type_name -> beads.v1.Comment
-	5,  // 7: beads.v1.Dependency.created_at:type_name -> google.protobuf.Timestamp
-	5,  // 8: beads.v1.Comment.created_at:type_name -> google.protobuf.Timestamp
-	5,  // 9: beads.v1.Event.created_at:type_name -> google.protobuf.Timestamp
-	5,  // 10: beads.v1.Config.created_at:type_name -> google.protobuf.Timestamp
-	5,  // 11: beads.v1.Config.updated_at:type_name -> google.protobuf.Timestamp
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	21, // 0: beads.v1.Bead.created_at:type_name -> google.protobuf.Timestamp
+	21, // 1: beads.v1.Bead.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 2: beads.v1.Bead.closed_at:type_name -> google.protobuf.Timestamp
+	21, // 3: beads.v1.Bead.due_at:type_name -> google.protobuf.Timestamp
+	21, // 4: beads.v1.Bead.defer_until:type_name -> google.protobuf.Timestamp
+	2,  // 5: beads.v1.Bead.dependencies:type_name -> beads.v1.Dependency
+	6,  // 6: beads.v1.Bead.comments:type_name -> beads.v1.Comment
+	21, // 7: beads.v1.Bead.last_activity_at:type_name -> google.protobuf.Timestamp
+	21, // 8: beads.v1.Bead.archived_at:type_name -> google.protobuf.Timestamp
+	1,  // 9: beads.v1.Bead.checklist:type_name -> beads.v1.ChecklistItem
+	21, // 10: beads.v1.ChecklistItem.checked_at:type_name -> google.protobuf.Timestamp
+	21, // 11: beads.v1.Dependency.created_at:type_name -> google.protobuf.Timestamp
+	21, // 12: beads.v1.ExternalDep.checked_at:type_name -> google.protobuf.Timestamp
+	21, // 13: beads.v1.ExternalDep.created_at:type_name -> google.protobuf.Timestamp
+	21, // 14: beads.v1.Commit.created_at:type_name -> google.protobuf.Timestamp
+	21, // 15: beads.v1.Relation.created_at:type_name -> google.protobuf.Timestamp
+	21, // 16: beads.v1.Comment.created_at:type_name -> google.protobuf.Timestamp
+	20, // 17: beads.v1.Comment.reactions:type_name -> beads.v1.Comment.ReactionsEntry
+	21, // 18: beads.v1.Comment.resolved_at:type_name -> google.protobuf.Timestamp
+	21, // 19: beads.v1.Alias.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 20: beads.v1.SimilarBead.bead:type_name -> beads.v1.Bead
+	21, // 21: beads.v1.Note.created_at:type_name -> google.protobuf.Timestamp
+	21, // 22: beads.v1.Event.created_at:type_name -> google.protobuf.Timestamp
+	21, // 23: beads.v1.ActivityEntry.created_at:type_name -> google.protobuf.Timestamp
+	10, // 24: beads.v1.Notification.event:type_name -> beads.v1.Event
+	21, // 25: beads.v1.Notification.created_at:type_name -> google.protobuf.Timestamp
+	21, // 26: beads.v1.Notification.read_at:type_name -> google.protobuf.Timestamp
+	21, // 27: beads.v1.Config.created_at:type_name -> google.protobuf.Timestamp
+	21, // 28: beads.v1.Config.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 29: beads.v1.ConfigRevision.created_at:type_name -> google.protobuf.Timestamp
+	21, // 30: beads.v1.Gate.waived_until:type_name -> google.protobuf.Timestamp
+	0,  // 31: beads.v1.BlockedBead.bead:type_name -> beads.v1.Bead
+	21, // 32: beads.v1.Agent.created_at:type_name -> google.protobuf.Timestamp
+	21, // 33: beads.v1.Alert.since:type_name -> google.protobuf.Timestamp
+	21, // 34: beads.v1.Alert.evaluated_at:type_name -> google.protobuf.Timestamp
+	35, // [35:35] is the sub-list for method output_type
+	35, // [35:35] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_beads_v1_types_proto_init() }
@@ -659,13 +2319,15 @@ func file_beads_v1_types_proto_init() {
 		return
 	}
 	file_beads_v1_types_proto_msgTypes[0].OneofWrappers = []any{}
+	file_beads_v1_types_proto_msgTypes[1].OneofWrappers = []any{}
+	file_beads_v1_types_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_beads_v1_types_proto_rawDesc), len(file_beads_v1_types_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,295 @@
+// Package alerts evaluates built-in threshold rules against the store and
+// publishes firing/resolved transitions to the event bus.
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// Metric names a value the evaluator knows how to compute.
+type Metric string
+
+const (
+	// MetricOpenP0 is the number of open or in-progress priority-0 beads.
+	MetricOpenP0 Metric = "open_p0_count"
+	// MetricDBLatency is the round-trip time of a trivial store query, in milliseconds.
+	MetricDBLatency Metric = "db_latency_ms"
+	// MetricDecisionLatency is how long the oldest open decision has been
+	// waiting for an answer, in minutes.
+	MetricDecisionLatency Metric = "decision_latency_minutes"
+	// MetricStreamDrops is the number of events dropped for slow event stream
+	// subscribers since the previous evaluation. It is reported by a gauge
+	// registered with SetGauge and is zero when none is.
+	MetricStreamDrops Metric = "stream_drops"
+)
+
+// Rule is a threshold alert definition. Rules are stored as configs under the
+// "alert" namespace (e.g. "alert:open-p0"); the rule fires when the metric
+// value is greater than or equal to Threshold.
+type Rule struct {
+	Name      string  `json:"-"`
+	Metric    Metric  `json:"metric"`
+	Threshold float64 `json:"threshold"`
+}
+
+// DefaultRules are evaluated when no config overrides them.
+var DefaultRules = []Rule{
+	{Name: "open-p0", Metric: MetricOpenP0, Threshold: 1},
+	{Name: "db-latency", Metric: MetricDBLatency, Threshold: 500},
+	{Name: "decision-latency", Metric: MetricDecisionLatency, Threshold: 240},
+	{Name: "stream-drops", Metric: MetricStreamDrops, Threshold: 1},
+}
+
+// Alert is the most recent evaluation result for a single rule.
+type Alert struct {
+	Name        string     `json:"name"`
+	Metric      Metric     `json:"metric"`
+	Threshold   float64    `json:"threshold"`
+	Value       float64    `json:"value"`
+	Firing      bool       `json:"firing"`
+	Since       *time.Time `json:"since,omitempty"` // when the alert started firing
+	EvaluatedAt time.Time  `json:"evaluated_at"`
+}
+
+// Source is the subset of store.Store the evaluator reads from.
+type Source interface {
+	ListBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error)
+	ListConfigs(ctx context.Context, namespace string) ([]*model.Config, error)
+}
+
+// Evaluator periodically evaluates alert rules and tracks their state.
+type Evaluator struct {
+	source    Source
+	publisher events.Publisher
+	interval  time.Duration
+	logger    *slog.Logger
+
+	mu     sync.RWMutex
+	alerts map[string]*Alert
+	gauges map[Metric]func() float64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewEvaluator creates an evaluator that checks rules at the given interval
+// and publishes state transitions to p.
+func NewEvaluator(src Source, p events.Publisher, interval time.Duration, logger *slog.Logger) *Evaluator {
+	return &Evaluator{
+		source:    src,
+		publisher: p,
+		interval:  interval,
+		logger:    logger,
+		alerts:    make(map[string]*Alert),
+		gauges:    make(map[Metric]func() float64),
+	}
+}
+
+// SetGauge registers fn as the source of metric m, for metrics the store
+// cannot answer (such as MetricStreamDrops). fn is called once per
+// evaluation and must be safe for concurrent use.
+func (e *Evaluator) SetGauge(m Metric, fn func() float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.gauges[m] = fn
+}
+
+// Start begins periodic evaluation. It evaluates once immediately, then on
+// each tick.
+func (e *Evaluator) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		e.Evaluate(ctx)
+
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				e.Evaluate(ctx)
+			}
+		}
+	}()
+}
+
+// Stop cancels the evaluator and waits for the current evaluation to finish.
+func (e *Evaluator) Stop() {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.wg.Wait()
+}
+
+// Alerts returns a snapshot of the current alert state, sorted by name.
+func (e *Evaluator) Alerts() []Alert {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	out := make([]Alert, 0, len(e.alerts))
+	for _, a := range e.alerts {
+		out = append(out, *a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Evaluate runs a single pass over all rules, updating state and publishing
+// an event for every rule that starts or stops firing.
+func (e *Evaluator) Evaluate(ctx context.Context) {
+	rules, err := e.rules(ctx)
+	if err != nil {
+		e.logger.Error("alert rules load failed", "err", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	active := make(map[string]struct{}, len(rules))
+	for _, r := range rules {
+		value, err := e.measure(ctx, r.Metric)
+		if err != nil {
+			e.logger.Warn("alert metric failed", "alert", r.Name, "metric", r.Metric, "err", err)
+			continue
+		}
+		active[r.Name] = struct{}{}
+		e.update(ctx, r, value, now)
+	}
+
+	// Drop state for rules that were deleted since the last pass.
+	e.mu.Lock()
+	for name := range e.alerts {
+		if _, ok := active[name]; !ok {
+			delete(e.alerts, name)
+		}
+	}
+	e.mu.Unlock()
+}
+
+func (e *Evaluator) update(ctx context.Context, r Rule, value float64, now time.Time) {
+	firing := value >= r.Threshold
+
+	e.mu.Lock()
+	prev := e.alerts[r.Name]
+	a := &Alert{
+		Name:        r.Name,
+		Metric:      r.Metric,
+		Threshold:   r.Threshold,
+		Value:       value,
+		Firing:      firing,
+		EvaluatedAt: now,
+	}
+	wasFiring := prev != nil && prev.Firing
+	if firing {
+		if wasFiring {
+			a.Since = prev.Since
+		} else {
+			a.Since = &now
+		}
+	}
+	e.alerts[r.Name] = a
+	e.mu.Unlock()
+
+	switch {
+	case firing && !wasFiring:
+		e.logger.Warn("alert firing", "alert", r.Name, "value", value, "threshold", r.Threshold)
+		e.publish(ctx, events.TopicAlertFired, events.AlertFired{Name: r.Name, Metric: string(r.Metric), Threshold: r.Threshold, Value: value})
+	case !firing && wasFiring:
+		e.logger.Info("alert resolved", "alert", r.Name, "value", value, "threshold", r.Threshold)
+		e.publish(ctx, events.TopicAlertResolved, events.AlertResolved{Name: r.Name, Metric: string(r.Metric), Threshold: r.Threshold, Value: value})
+	}
+}
+
+func (e *Evaluator) publish(ctx context.Context, topic string, event any) {
+	if err := e.publisher.Publish(ctx, topic, event); err != nil {
+		e.logger.Warn("failed to publish alert event", "topic", topic, "err", err)
+	}
+}
+
+// rules returns the default rules merged with any "alert:*" configs.
+// A stored config replaces the default rule of the same name.
+func (e *Evaluator) rules(ctx context.Context) ([]Rule, error) {
+	byName := make(map[string]Rule, len(DefaultRules))
+	for _, r := range DefaultRules {
+		byName[r.Name] = r
+	}
+
+	configs, err := e.source.ListConfigs(ctx, "alert")
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range configs {
+		var r Rule
+		if err := json.Unmarshal(c.Value, &r); err != nil {
+			e.logger.Warn("invalid alert config", "key", c.Key, "err", err)
+			continue
+		}
+		r.Name = strings.TrimPrefix(c.Key, "alert:")
+		byName[r.Name] = r
+	}
+
+	rules := make([]Rule, 0, len(byName))
+	for _, r := range byName {
+		rules = append(rules, r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules, nil
+}
+
+// measure computes the current value of a metric.
+func (e *Evaluator) measure(ctx context.Context, m Metric) (float64, error) {
+	switch m {
+	case MetricOpenP0:
+		p0 := 0
+		_, total, err := e.source.ListBeads(ctx, model.BeadFilter{
+			Status:   []model.Status{model.StatusOpen, model.StatusInProgress},
+			Priority: &p0,
+			Limit:    1,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return float64(total), nil
+	case MetricDBLatency:
+		start := time.Now()
+		if _, _, err := e.source.ListBeads(ctx, model.BeadFilter{Limit: 1}); err != nil {
+			return 0, err
+		}
+		return float64(time.Since(start).Microseconds()) / 1000, nil
+	case MetricDecisionLatency:
+		oldest, _, err := e.source.ListBeads(ctx, model.BeadFilter{
+			Type:   []model.BeadType{"decision"},
+			Status: []model.Status{model.StatusOpen, model.StatusInProgress},
+			Sort:   "created_at",
+			Limit:  1,
+		})
+		if err != nil || len(oldest) == 0 {
+			return 0, err
+		}
+		return time.Since(oldest[0].CreatedAt).Minutes(), nil
+	case MetricStreamDrops:
+		e.mu.RLock()
+		fn := e.gauges[m]
+		e.mu.RUnlock()
+		if fn == nil {
+			return 0, nil
+		}
+		return fn(), nil
+	default:
+		return 0, fmt.Errorf("unknown metric %q", m)
+	}
+}
@@ -0,0 +1,188 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// fakeSource serves a fixed bead list and alert configs.
+type fakeSource struct {
+	beads   []*model.Bead
+	configs []*model.Config
+}
+
+func (f *fakeSource) ListBeads(_ context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	var out []*model.Bead
+	for _, b := range f.beads {
+		if filter.Priority != nil && b.Priority != *filter.Priority {
+			continue
+		}
+		if len(filter.Type) > 0 && b.Type != filter.Type[0] {
+			continue
+		}
+		if len(filter.Status) > 0 {
+			found := false
+			for _, s := range filter.Status {
+				if b.Status == s {
+					found = true
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		out = append(out, b)
+	}
+	return out, len(out), nil
+}
+
+func (f *fakeSource) ListConfigs(_ context.Context, _ string) ([]*model.Config, error) {
+	return f.configs, nil
+}
+
+// recordingPublisher captures published topics.
+type recordingPublisher struct {
+	mu     sync.Mutex
+	topics []string
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, topic string, _ any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.topics = append(p.topics, topic)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+func newTestEvaluator(src Source, pub events.Publisher) *Evaluator {
+	return NewEvaluator(src, pub, 0, slog.New(slog.NewTextHandler(os.Stderr, nil)))
+}
+
+func findAlert(t *testing.T, list []Alert, name string) Alert {
+	t.Helper()
+	for _, a := range list {
+		if a.Name == name {
+			return a
+		}
+	}
+	t.Fatalf("alert %q not found in %+v", name, list)
+	return Alert{}
+}
+
+func TestEvaluate_OpenP0FiresAndResolves(t *testing.T) {
+	src := &fakeSource{}
+	pub := &recordingPublisher{}
+	e := newTestEvaluator(src, pub)
+	ctx := context.Background()
+
+	e.Evaluate(ctx)
+	if a := findAlert(t, e.Alerts(), "open-p0"); a.Firing {
+		t.Fatalf("expected open-p0 not firing with no beads, got %+v", a)
+	}
+
+	src.beads = []*model.Bead{{ID: "bd-1", Priority: 0, Status: model.StatusOpen}}
+	e.Evaluate(ctx)
+	a := findAlert(t, e.Alerts(), "open-p0")
+	if !a.Firing || a.Value != 1 || a.Since == nil {
+		t.Fatalf("expected open-p0 firing with value 1, got %+v", a)
+	}
+	since := *a.Since
+
+	// A second firing evaluation keeps the original start time and does not republish.
+	e.Evaluate(ctx)
+	if a := findAlert(t, e.Alerts(), "open-p0"); !a.Since.Equal(since) {
+		t.Fatalf("expected since to be preserved, got %v want %v", a.Since, since)
+	}
+
+	src.beads[0].Status = model.StatusClosed
+	e.Evaluate(ctx)
+	if a := findAlert(t, e.Alerts(), "open-p0"); a.Firing || a.Since != nil {
+		t.Fatalf("expected open-p0 resolved, got %+v", a)
+	}
+
+	want := []string{events.TopicAlertFired, events.TopicAlertResolved}
+	if len(pub.topics) != len(want) {
+		t.Fatalf("published %v, want %v", pub.topics, want)
+	}
+	for i := range want {
+		if pub.topics[i] != want[i] {
+			t.Fatalf("published %v, want %v", pub.topics, want)
+		}
+	}
+}
+
+func TestEvaluate_ConfigOverridesDefault(t *testing.T) {
+	src := &fakeSource{
+		beads: []*model.Bead{{ID: "bd-1", Priority: 0, Status: model.StatusOpen}},
+		configs: []*model.Config{
+			{Key: "alert:open-p0", Value: json.RawMessage(`{"metric":"open_p0_count","threshold":5}`)},
+			{Key: "alert:custom", Value: json.RawMessage(`{"metric":"open_p0_count","threshold":1}`)},
+		},
+	}
+	e := newTestEvaluator(src, &events.NoopPublisher{})
+	e.Evaluate(context.Background())
+
+	list := e.Alerts()
+	if a := findAlert(t, list, "open-p0"); a.Threshold != 5 || a.Firing {
+		t.Fatalf("expected overridden threshold 5 not firing, got %+v", a)
+	}
+	if a := findAlert(t, list, "custom"); !a.Firing {
+		t.Fatalf("expected custom alert firing, got %+v", a)
+	}
+	findAlert(t, list, "db-latency")
+}
+
+func TestEvaluate_DecisionLatency(t *testing.T) {
+	src := &fakeSource{beads: []*model.Bead{
+		{ID: "bd-1", Type: "decision", Priority: 2, Status: model.StatusOpen, CreatedAt: time.Now().Add(-5 * time.Hour)},
+	}}
+	e := newTestEvaluator(src, &events.NoopPublisher{})
+	e.Evaluate(context.Background())
+
+	if a := findAlert(t, e.Alerts(), "decision-latency"); !a.Firing || a.Value < 300 {
+		t.Fatalf("expected decision-latency firing at ~300 minutes, got %+v", a)
+	}
+}
+
+func TestEvaluate_StreamDropsGauge(t *testing.T) {
+	e := newTestEvaluator(&fakeSource{}, &events.NoopPublisher{})
+	e.Evaluate(context.Background())
+	if a := findAlert(t, e.Alerts(), "stream-drops"); a.Firing {
+		t.Fatalf("expected stream-drops not firing without a gauge, got %+v", a)
+	}
+
+	e.SetGauge(MetricStreamDrops, func() float64 { return 3 })
+	e.Evaluate(context.Background())
+	if a := findAlert(t, e.Alerts(), "stream-drops"); !a.Firing || a.Value != 3 {
+		t.Fatalf("expected stream-drops firing with value 3, got %+v", a)
+	}
+}
+
+func TestEvaluate_UnknownMetricSkipped(t *testing.T) {
+	src := &fakeSource{configs: []*model.Config{
+		{Key: "alert:bogus", Value: json.RawMessage(`{"metric":"nope","threshold":1}`)},
+	}}
+	e := newTestEvaluator(src, &events.NoopPublisher{})
+	e.Evaluate(context.Background())
+
+	for _, a := range e.Alerts() {
+		if a.Name == "bogus" {
+			t.Fatalf("expected unknown metric to be skipped, got %+v", a)
+		}
+	}
+}
+
+func TestEvaluator_StopWithoutStart(t *testing.T) {
+	e := newTestEvaluator(&fakeSource{}, &events.NoopPublisher{})
+	// Stop without Start should not panic.
+	e.Stop()
+}
@@ -1,16 +1,24 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/shadow"
+	"github.com/alfredjeanlab/beads/internal/store/sealed"
+	"github.com/alfredjeanlab/beads/internal/version"
 )
 
 type Config struct {
 	DatabaseURL string // BEADS_DATABASE_URL (required)
 	GRPCAddr    string // BEADS_GRPC_ADDR (default ":9090")
 	HTTPAddr    string // BEADS_HTTP_ADDR (default ":8080")
-	NATSURL     string // BEADS_NATS_URL (optional, empty = no events)
+	NATSURL     string // BEADS_NATS_URL (nats and jetstream backends)
 
 	// Sync settings
 	SyncInterval   time.Duration // BEADS_SYNC_INTERVAL (default 3m; 0 = disabled)
@@ -18,41 +26,283 @@ type Config struct {
 	SyncS3Endpoint string        // BEADS_SYNC_S3_ENDPOINT (custom endpoint for MinIO)
 	SyncS3Region   string        // BEADS_SYNC_S3_REGION (default "us-east-1")
 	SyncS3Key      string        // BEADS_SYNC_S3_KEY (default "beads/backup.jsonl")
+	SnapshotPrefix string        // BEADS_SNAPSHOT_PREFIX (admin snapshot keys in the S3 bucket; default "beads/snapshots/")
 	SyncGitRepo    string        // BEADS_SYNC_GIT_REPO (enables git when set; path to clone)
 	SyncGitFile    string        // BEADS_SYNC_GIT_FILE (default "beads.jsonl")
 	SyncGitBranch  string        // BEADS_SYNC_GIT_BRANCH (default "main")
+
+	// Alerting
+	AlertInterval time.Duration // BEADS_ALERT_INTERVAL (default 1m; 0 = disabled)
+
+	// Decisions
+	DecisionExpiryInterval time.Duration // BEADS_DECISION_EXPIRY_INTERVAL (default 30s; 0 = disabled)
+
+	// Advice
+	AdviceExpiryInterval time.Duration // BEADS_ADVICE_EXPIRY_INTERVAL (default 1m; 0 = disabled)
+
+	// Deferred beads
+	UndeferInterval time.Duration // BEADS_UNDEFER_INTERVAL (how often deferred beads past defer_until are reopened; default 1m; 0 = disabled)
+
+	// Digests
+	DigestInterval time.Duration // BEADS_DIGEST_INTERVAL (default 1m; 0 = disabled)
+
+	// Email notifications
+	EmailInterval time.Duration // BEADS_EMAIL_INTERVAL (how often overdue beads are reported and batched emails sent; default 1m; 0 = disabled)
+
+	// Mirrors
+	MirrorInterval time.Duration // BEADS_MIRROR_INTERVAL (default 5m; 0 = disabled)
+
+	// Events
+	OutboxInterval  time.Duration // BEADS_OUTBOX_INTERVAL (default 5s; 0 = no retry loop)
+	EventBackend    string        // BEADS_EVENT_BACKEND: none, nats, jetstream or kafka (default nats if BEADS_NATS_URL is set, else none)
+	JetStreamStream string        // BEADS_JETSTREAM_STREAM (default "BEADS")
+	KafkaRESTURL    string        // BEADS_KAFKA_REST_URL (Kafka REST Proxy; required by the kafka backend)
+	KafkaTopic      string        // BEADS_KAFKA_TOPIC (default "beads-events")
+
+	// Event stream (GET /v1/events/stream)
+	StreamKeepalive   time.Duration // BEADS_STREAM_KEEPALIVE (idle keepalive comment interval; default 15s)
+	StreamMaxLifetime time.Duration // BEADS_STREAM_MAX_LIFETIME (time before clients are told to reconnect; default 0 = never)
+
+	// Trash
+	TrashRetention time.Duration // BEADS_TRASH_RETENTION (default 720h; 0 = never purge)
+
+	// Archive
+	ArchiveAfter time.Duration // BEADS_ARCHIVE_AFTER (time closed before a bead is archived; default 0 = never)
+
+	// Event retention
+	EventRetention []model.RetentionRule // BEADS_EVENT_RETENTION (e.g. "*=2160h,beads.comment.*=8760h"; empty = keep every event)
+
+	// Agents
+	AgentUnassignAfter time.Duration // BEADS_AGENT_UNASSIGN_AFTER (time an agent is reaped or stale before its beads are unassigned; default 0 = never)
+
+	// External dependencies
+	ExternalProbeInterval time.Duration // BEADS_EXTERNAL_PROBE_INTERVAL (how often probed external dependencies are checked; default 0 = never)
+	GitHubToken           string        // BEADS_GITHUB_TOKEN (optional; sent by github-pr probes)
+
+	// TLS (both listeners; plaintext when TLSCert is empty)
+	TLSCert     string // BEADS_TLS_CERT (PEM certificate file)
+	TLSKey      string // BEADS_TLS_KEY (PEM private key file)
+	TLSClientCA string // BEADS_TLS_CLIENT_CA (enables mTLS; CA bundle for client certs)
+
+	// Agent registration (disabled when both are empty)
+	AdminToken     string // BEADS_ADMIN_TOKEN
+	BootstrapToken string // BEADS_BOOTSTRAP_TOKEN (may only register agents)
+
+	// OIDC bearer tokens (disabled when OIDCIssuer is empty)
+	OIDCIssuer      string        // BEADS_OIDC_ISSUER
+	OIDCAudience    string        // BEADS_OIDC_AUDIENCE (required with an issuer)
+	OIDCJWKSURL     string        // BEADS_OIDC_JWKS_URL (default: discovered from the issuer)
+	OIDCActorClaim  string        // BEADS_OIDC_ACTOR_CLAIM (default "preferred_username", then sub)
+	OIDCRolesClaim  string        // BEADS_OIDC_ROLES_CLAIM (default "roles"; "a.b" reaches nested claims)
+	OIDCAdminRole   string        // BEADS_OIDC_ADMIN_ROLE (role granting admin-token access; default "beads-admin")
+	OIDCJWKSRefresh time.Duration // BEADS_OIDC_JWKS_REFRESH (default 1h)
+
+	// Config access
+	ConfigAdminNamespaces []string // BEADS_CONFIG_ADMIN_NAMESPACES (config namespaces only the admin token may read or write; default "integration"; "none" for none)
+	ConfigEncryptionKey   []byte   // BEADS_CONFIG_ENCRYPTION_KEY (base64 32-byte key; secret config fields are encrypted at rest when set)
+
+	// Comment authors
+	StrictAuthors bool // BEADS_STRICT_AUTHORS (reject comments claiming an author other than the authenticated caller; default false)
+
+	// Read cache (0 = disabled)
+	CacheBeadTTL time.Duration // BEADS_CACHE_BEAD_TTL (how long GetBead results are cached; default 0)
+	CacheListTTL time.Duration // BEADS_CACHE_LIST_TTL (how long ListBeads results are cached; default 0)
+
+	// Request shadowing (empty = off)
+	ShadowRates map[string]float64 // BEADS_SHADOW (e.g. "ready=0.1,list=0.05")
+
+	// Client version policy
+	MinClientVersion string // BEADS_MIN_CLIENT_VERSION (optional; older clients are rejected)
+	ClientVersion    string // BEADS_CLIENT_VERSION (release `bd self-update` installs; default the server's own)
+	ClientReleaseURL string // BEADS_CLIENT_RELEASE_URL (default GitHub releases; only old bd releases use it)
 }
 
 func Load() (*Config, error) {
 	c := &Config{
-		DatabaseURL:    os.Getenv("BEADS_DATABASE_URL"),
-		GRPCAddr:       envOrDefault("BEADS_GRPC_ADDR", ":9090"),
-		HTTPAddr:       envOrDefault("BEADS_HTTP_ADDR", ":8080"),
-		NATSURL:        os.Getenv("BEADS_NATS_URL"),
-		SyncS3Bucket:   os.Getenv("BEADS_SYNC_S3_BUCKET"),
-		SyncS3Endpoint: os.Getenv("BEADS_SYNC_S3_ENDPOINT"),
-		SyncS3Region:   envOrDefault("BEADS_SYNC_S3_REGION", "us-east-1"),
-		SyncS3Key:      envOrDefault("BEADS_SYNC_S3_KEY", "beads/backup.jsonl"),
-		SyncGitRepo:    os.Getenv("BEADS_SYNC_GIT_REPO"),
-		SyncGitFile:    envOrDefault("BEADS_SYNC_GIT_FILE", "beads.jsonl"),
-		SyncGitBranch:  envOrDefault("BEADS_SYNC_GIT_BRANCH", "main"),
+		DatabaseURL:     os.Getenv("BEADS_DATABASE_URL"),
+		GRPCAddr:        envOrDefault("BEADS_GRPC_ADDR", ":9090"),
+		HTTPAddr:        envOrDefault("BEADS_HTTP_ADDR", ":8080"),
+		NATSURL:         os.Getenv("BEADS_NATS_URL"),
+		EventBackend:    os.Getenv("BEADS_EVENT_BACKEND"),
+		JetStreamStream: envOrDefault("BEADS_JETSTREAM_STREAM", "BEADS"),
+		KafkaRESTURL:    os.Getenv("BEADS_KAFKA_REST_URL"),
+		KafkaTopic:      envOrDefault("BEADS_KAFKA_TOPIC", "beads-events"),
+		SyncS3Bucket:    os.Getenv("BEADS_SYNC_S3_BUCKET"),
+		SyncS3Endpoint:  os.Getenv("BEADS_SYNC_S3_ENDPOINT"),
+		SyncS3Region:    envOrDefault("BEADS_SYNC_S3_REGION", "us-east-1"),
+		SyncS3Key:       envOrDefault("BEADS_SYNC_S3_KEY", "beads/backup.jsonl"),
+		SnapshotPrefix:  envOrDefault("BEADS_SNAPSHOT_PREFIX", "beads/snapshots/"),
+		SyncGitRepo:     os.Getenv("BEADS_SYNC_GIT_REPO"),
+		SyncGitFile:     envOrDefault("BEADS_SYNC_GIT_FILE", "beads.jsonl"),
+		SyncGitBranch:   envOrDefault("BEADS_SYNC_GIT_BRANCH", "main"),
+		TLSCert:         os.Getenv("BEADS_TLS_CERT"),
+		TLSKey:          os.Getenv("BEADS_TLS_KEY"),
+		TLSClientCA:     os.Getenv("BEADS_TLS_CLIENT_CA"),
+		AdminToken:      os.Getenv("BEADS_ADMIN_TOKEN"),
+		BootstrapToken:  os.Getenv("BEADS_BOOTSTRAP_TOKEN"),
+		GitHubToken:     os.Getenv("BEADS_GITHUB_TOKEN"),
+		OIDCIssuer:      os.Getenv("BEADS_OIDC_ISSUER"),
+		OIDCAudience:    os.Getenv("BEADS_OIDC_AUDIENCE"),
+		OIDCJWKSURL:     os.Getenv("BEADS_OIDC_JWKS_URL"),
+		OIDCActorClaim:  envOrDefault("BEADS_OIDC_ACTOR_CLAIM", "preferred_username"),
+		OIDCRolesClaim:  envOrDefault("BEADS_OIDC_ROLES_CLAIM", "roles"),
+		OIDCAdminRole:   envOrDefault("BEADS_OIDC_ADMIN_ROLE", "beads-admin"),
+
+		MinClientVersion: os.Getenv("BEADS_MIN_CLIENT_VERSION"),
+		ClientVersion:    os.Getenv("BEADS_CLIENT_VERSION"),
+		ClientReleaseURL: envOrDefault("BEADS_CLIENT_RELEASE_URL", "https://github.com/alfredjeanlab/beads/releases/download"),
 	}
 	if c.DatabaseURL == "" {
 		return nil, fmt.Errorf("BEADS_DATABASE_URL is required")
 	}
 
-	intervalStr := envOrDefault("BEADS_SYNC_INTERVAL", "3m")
-	if intervalStr != "" {
-		d, err := time.ParseDuration(intervalStr)
-		if err != nil {
-			return nil, fmt.Errorf("BEADS_SYNC_INTERVAL: %w", err)
+	var err error
+	if c.SyncInterval, err = envDuration("BEADS_SYNC_INTERVAL", "3m"); err != nil {
+		return nil, err
+	}
+	if c.AlertInterval, err = envDuration("BEADS_ALERT_INTERVAL", "1m"); err != nil {
+		return nil, err
+	}
+	if c.DecisionExpiryInterval, err = envDuration("BEADS_DECISION_EXPIRY_INTERVAL", "30s"); err != nil {
+		return nil, err
+	}
+	if c.AdviceExpiryInterval, err = envDuration("BEADS_ADVICE_EXPIRY_INTERVAL", "1m"); err != nil {
+		return nil, err
+	}
+	if c.UndeferInterval, err = envDuration("BEADS_UNDEFER_INTERVAL", "1m"); err != nil {
+		return nil, err
+	}
+	if c.DigestInterval, err = envDuration("BEADS_DIGEST_INTERVAL", "1m"); err != nil {
+		return nil, err
+	}
+	if c.EmailInterval, err = envDuration("BEADS_EMAIL_INTERVAL", "1m"); err != nil {
+		return nil, err
+	}
+	if c.MirrorInterval, err = envDuration("BEADS_MIRROR_INTERVAL", "5m"); err != nil {
+		return nil, err
+	}
+	if c.OutboxInterval, err = envDuration("BEADS_OUTBOX_INTERVAL", "5s"); err != nil {
+		return nil, err
+	}
+	if c.StreamKeepalive, err = envDuration("BEADS_STREAM_KEEPALIVE", "15s"); err != nil {
+		return nil, err
+	}
+	if c.StreamKeepalive <= 0 {
+		return nil, fmt.Errorf("BEADS_STREAM_KEEPALIVE must be positive")
+	}
+	if c.StreamMaxLifetime, err = envDuration("BEADS_STREAM_MAX_LIFETIME", "0"); err != nil {
+		return nil, err
+	}
+	if c.TrashRetention, err = envDuration("BEADS_TRASH_RETENTION", "720h"); err != nil {
+		return nil, err
+	}
+	if c.ArchiveAfter, err = envDuration("BEADS_ARCHIVE_AFTER", "0"); err != nil {
+		return nil, err
+	}
+	if c.EventRetention, err = model.ParseRetention(os.Getenv("BEADS_EVENT_RETENTION")); err != nil {
+		return nil, fmt.Errorf("BEADS_EVENT_RETENTION: %w", err)
+	}
+	if c.AgentUnassignAfter, err = envDuration("BEADS_AGENT_UNASSIGN_AFTER", "0"); err != nil {
+		return nil, err
+	}
+	if c.ExternalProbeInterval, err = envDuration("BEADS_EXTERNAL_PROBE_INTERVAL", "0"); err != nil {
+		return nil, err
+	}
+	if c.CacheBeadTTL, err = envDuration("BEADS_CACHE_BEAD_TTL", "0"); err != nil {
+		return nil, err
+	}
+	if c.CacheListTTL, err = envDuration("BEADS_CACHE_LIST_TTL", "0"); err != nil {
+		return nil, err
+	}
+	if c.OIDCJWKSRefresh, err = envDuration("BEADS_OIDC_JWKS_REFRESH", "1h"); err != nil {
+		return nil, err
+	}
+	if ns := envOrDefault("BEADS_CONFIG_ADMIN_NAMESPACES", "integration"); ns != "none" {
+		for _, n := range strings.Split(ns, ",") {
+			if n = strings.TrimSpace(n); n != "" {
+				c.ConfigAdminNamespaces = append(c.ConfigAdminNamespaces, n)
+			}
+		}
+	}
+	if v := os.Getenv("BEADS_CONFIG_ENCRYPTION_KEY"); v != "" {
+		if c.ConfigEncryptionKey, err = base64.StdEncoding.DecodeString(v); err != nil || len(c.ConfigEncryptionKey) != sealed.KeySize {
+			return nil, fmt.Errorf("BEADS_CONFIG_ENCRYPTION_KEY must be %d bytes, base64-encoded", sealed.KeySize)
 		}
-		c.SyncInterval = d
+	}
+	if c.StrictAuthors, err = envBool("BEADS_STRICT_AUTHORS"); err != nil {
+		return nil, err
+	}
+	if c.OIDCIssuer != "" && c.OIDCAudience == "" {
+		return nil, fmt.Errorf("BEADS_OIDC_ISSUER requires BEADS_OIDC_AUDIENCE")
+	}
+	if c.ShadowRates, err = shadow.ParseRates(os.Getenv("BEADS_SHADOW")); err != nil {
+		return nil, fmt.Errorf("BEADS_SHADOW: %w", err)
+	}
+	if err := c.resolveEventBackend(); err != nil {
+		return nil, err
+	}
+	if c.MinClientVersion != "" && !version.Valid(c.MinClientVersion) {
+		return nil, fmt.Errorf("BEADS_MIN_CLIENT_VERSION: %q is not a release version", c.MinClientVersion)
 	}
 
 	return c, nil
 }
 
+// Event backends accepted by BEADS_EVENT_BACKEND.
+const (
+	EventBackendNone      = "none"
+	EventBackendNATS      = "nats"
+	EventBackendJetStream = "jetstream"
+	EventBackendKafka     = "kafka"
+)
+
+// resolveEventBackend defaults EventBackend and checks that the chosen
+// backend has the settings it needs.
+func (c *Config) resolveEventBackend() error {
+	if c.EventBackend == "" {
+		c.EventBackend = EventBackendNone
+		if c.NATSURL != "" {
+			c.EventBackend = EventBackendNATS
+		}
+	}
+	switch c.EventBackend {
+	case EventBackendNone:
+	case EventBackendNATS, EventBackendJetStream:
+		if c.NATSURL == "" {
+			return fmt.Errorf("BEADS_EVENT_BACKEND=%s requires BEADS_NATS_URL", c.EventBackend)
+		}
+	case EventBackendKafka:
+		if c.KafkaRESTURL == "" {
+			return fmt.Errorf("BEADS_EVENT_BACKEND=kafka requires BEADS_KAFKA_REST_URL")
+		}
+	default:
+		return fmt.Errorf("BEADS_EVENT_BACKEND: unknown backend %q (want none, nats, jetstream or kafka)", c.EventBackend)
+	}
+	return nil
+}
+
+// envDuration parses the duration in the given env var, or fallback if unset.
+func envDuration(key, fallback string) (time.Duration, error) {
+	d, err := time.ParseDuration(envOrDefault(key, fallback))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	return d, nil
+}
+
+// envBool parses the boolean in the given env var, or false if unset.
+func envBool(key string) (bool, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("%s: %q is not a boolean", key, v)
+	}
+	return b, nil
+}
+
 func envOrDefault(key, fallback string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
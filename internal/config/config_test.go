@@ -1,6 +1,8 @@
 package config
 
 import (
+	"encoding/base64"
+	"slices"
 	"testing"
 	"time"
 )
@@ -20,6 +22,35 @@ func clearAllEnv(t *testing.T) {
 	for _, key := range syncEnvVars {
 		t.Setenv(key, "")
 	}
+	t.Setenv("BEADS_ALERT_INTERVAL", "")
+	t.Setenv("BEADS_DECISION_EXPIRY_INTERVAL", "")
+	t.Setenv("BEADS_ADVICE_EXPIRY_INTERVAL", "")
+	t.Setenv("BEADS_TRASH_RETENTION", "")
+	t.Setenv("BEADS_ARCHIVE_AFTER", "")
+	t.Setenv("BEADS_EVENT_RETENTION", "")
+	t.Setenv("BEADS_DIGEST_INTERVAL", "")
+	t.Setenv("BEADS_EMAIL_INTERVAL", "")
+	t.Setenv("BEADS_OUTBOX_INTERVAL", "")
+	t.Setenv("BEADS_STREAM_KEEPALIVE", "")
+	t.Setenv("BEADS_STREAM_MAX_LIFETIME", "")
+	for _, key := range []string{"BEADS_EVENT_BACKEND", "BEADS_JETSTREAM_STREAM", "BEADS_KAFKA_REST_URL", "BEADS_KAFKA_TOPIC"} {
+		t.Setenv(key, "")
+	}
+	for _, key := range []string{"BEADS_TLS_CERT", "BEADS_TLS_KEY", "BEADS_TLS_CLIENT_CA"} {
+		t.Setenv(key, "")
+	}
+	t.Setenv("BEADS_ADMIN_TOKEN", "")
+	t.Setenv("BEADS_BOOTSTRAP_TOKEN", "")
+	for _, key := range []string{"BEADS_OIDC_ISSUER", "BEADS_OIDC_AUDIENCE", "BEADS_OIDC_JWKS_URL", "BEADS_OIDC_ACTOR_CLAIM", "BEADS_OIDC_ROLES_CLAIM", "BEADS_OIDC_ADMIN_ROLE", "BEADS_OIDC_JWKS_REFRESH"} {
+		t.Setenv(key, "")
+	}
+	t.Setenv("BEADS_STRICT_AUTHORS", "")
+	t.Setenv("BEADS_CONFIG_ADMIN_NAMESPACES", "")
+	t.Setenv("BEADS_CONFIG_ENCRYPTION_KEY", "")
+	t.Setenv("BEADS_SHADOW", "")
+	t.Setenv("BEADS_MIN_CLIENT_VERSION", "")
+	t.Setenv("BEADS_CLIENT_VERSION", "")
+	t.Setenv("BEADS_CLIENT_RELEASE_URL", "")
 }
 
 func TestLoad(t *testing.T) {
@@ -179,6 +210,377 @@ func TestLoadSyncDisabled(t *testing.T) {
 	}
 }
 
+func TestLoadAlertInterval(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AlertInterval != time.Minute {
+		t.Errorf("AlertInterval = %v, want 1m", cfg.AlertInterval)
+	}
+
+	t.Setenv("BEADS_ALERT_INTERVAL", "bogus")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for invalid BEADS_ALERT_INTERVAL")
+	}
+}
+
+func TestLoadDecisionExpiryInterval(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.DecisionExpiryInterval != 30*time.Second {
+		t.Errorf("DecisionExpiryInterval = %v, want 30s", cfg.DecisionExpiryInterval)
+	}
+
+	t.Setenv("BEADS_DECISION_EXPIRY_INTERVAL", "0")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.DecisionExpiryInterval != 0 {
+		t.Errorf("DecisionExpiryInterval = %v, want 0 (disabled)", cfg.DecisionExpiryInterval)
+	}
+}
+
+func TestLoadTrashRetention(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TrashRetention != 30*24*time.Hour {
+		t.Errorf("TrashRetention = %v, want 720h", cfg.TrashRetention)
+	}
+
+	t.Setenv("BEADS_TRASH_RETENTION", "bogus")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for invalid BEADS_TRASH_RETENTION")
+	}
+}
+
+func TestLoadArchiveAfter(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ArchiveAfter != 0 {
+		t.Errorf("ArchiveAfter = %v, want 0 (never)", cfg.ArchiveAfter)
+	}
+
+	t.Setenv("BEADS_ARCHIVE_AFTER", "2160h")
+	if cfg, err = Load(); err != nil || cfg.ArchiveAfter != 90*24*time.Hour {
+		t.Errorf("ArchiveAfter = %v, %v; want 2160h", cfg.ArchiveAfter, err)
+	}
+}
+
+func TestLoadEventRetention(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.EventRetention) != 0 {
+		t.Errorf("EventRetention = %v, want none", cfg.EventRetention)
+	}
+
+	t.Setenv("BEADS_EVENT_RETENTION", "beads.comment.*=8760h,*=2160h")
+	if cfg, err = Load(); err != nil || len(cfg.EventRetention) != 2 || cfg.EventRetention[0].Topic != "*" {
+		t.Errorf("EventRetention = %v, %v", cfg.EventRetention, err)
+	}
+
+	t.Setenv("BEADS_EVENT_RETENTION", "*=forever")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for an invalid age")
+	}
+}
+
+func TestLoadStrictAuthors(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.StrictAuthors {
+		t.Error("StrictAuthors = true, want false by default")
+	}
+
+	t.Setenv("BEADS_STRICT_AUTHORS", "true")
+	if cfg, err = Load(); err != nil || !cfg.StrictAuthors {
+		t.Errorf("StrictAuthors = %v, %v", cfg.StrictAuthors, err)
+	}
+
+	t.Setenv("BEADS_STRICT_AUTHORS", "sometimes")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a non-boolean value")
+	}
+}
+
+func TestLoadConfigAccess(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(cfg.ConfigAdminNamespaces, []string{"integration"}) || cfg.ConfigEncryptionKey != nil {
+		t.Errorf("defaults = %v, %v", cfg.ConfigAdminNamespaces, cfg.ConfigEncryptionKey)
+	}
+
+	t.Setenv("BEADS_CONFIG_ADMIN_NAMESPACES", "integration, mirror")
+	t.Setenv("BEADS_CONFIG_ENCRYPTION_KEY", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	if cfg, err = Load(); err != nil || !slices.Equal(cfg.ConfigAdminNamespaces, []string{"integration", "mirror"}) || len(cfg.ConfigEncryptionKey) != 32 {
+		t.Errorf("ConfigAdminNamespaces = %v, key %d bytes, %v", cfg.ConfigAdminNamespaces, len(cfg.ConfigEncryptionKey), err)
+	}
+
+	t.Setenv("BEADS_CONFIG_ADMIN_NAMESPACES", "none")
+	if cfg, err = Load(); err != nil || len(cfg.ConfigAdminNamespaces) != 0 {
+		t.Errorf("ConfigAdminNamespaces = %v, %v", cfg.ConfigAdminNamespaces, err)
+	}
+
+	t.Setenv("BEADS_CONFIG_ENCRYPTION_KEY", base64.StdEncoding.EncodeToString([]byte("too short")))
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a short key")
+	}
+}
+
+func TestLoadDigestInterval(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.DigestInterval != time.Minute {
+		t.Errorf("DigestInterval = %v, want 1m", cfg.DigestInterval)
+	}
+
+	t.Setenv("BEADS_DIGEST_INTERVAL", "0")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.DigestInterval != 0 {
+		t.Errorf("DigestInterval = %v, want 0 (disabled)", cfg.DigestInterval)
+	}
+}
+
+func TestLoadEmailInterval(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.EmailInterval != time.Minute {
+		t.Errorf("EmailInterval = %v, want 1m", cfg.EmailInterval)
+	}
+
+	t.Setenv("BEADS_EMAIL_INTERVAL", "0")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.EmailInterval != 0 {
+		t.Errorf("EmailInterval = %v, want 0 (disabled)", cfg.EmailInterval)
+	}
+}
+
+func TestLoadOutboxInterval(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.OutboxInterval != 5*time.Second {
+		t.Errorf("OutboxInterval = %v, want 5s", cfg.OutboxInterval)
+	}
+
+	t.Setenv("BEADS_OUTBOX_INTERVAL", "0")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.OutboxInterval != 0 {
+		t.Errorf("OutboxInterval = %v, want 0 (disabled)", cfg.OutboxInterval)
+	}
+}
+
+func TestLoadStreamTuning(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.StreamKeepalive != 15*time.Second || cfg.StreamMaxLifetime != 0 {
+		t.Errorf("unexpected stream defaults: keepalive %v, max lifetime %v", cfg.StreamKeepalive, cfg.StreamMaxLifetime)
+	}
+
+	t.Setenv("BEADS_STREAM_KEEPALIVE", "5s")
+	t.Setenv("BEADS_STREAM_MAX_LIFETIME", "30m")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.StreamKeepalive != 5*time.Second || cfg.StreamMaxLifetime != 30*time.Minute {
+		t.Errorf("unexpected stream tuning: keepalive %v, max lifetime %v", cfg.StreamKeepalive, cfg.StreamMaxLifetime)
+	}
+
+	t.Setenv("BEADS_STREAM_KEEPALIVE", "0")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a zero keepalive")
+	}
+}
+
+func TestLoadEventBackend(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		env     map[string]string
+		want    string
+		wantErr bool
+	}{
+		{"Default", nil, EventBackendNone, false},
+		{"NATSFromURL", map[string]string{"BEADS_NATS_URL": "nats://localhost:4222"}, EventBackendNATS, false},
+		{"JetStream", map[string]string{"BEADS_EVENT_BACKEND": "jetstream", "BEADS_NATS_URL": "nats://localhost:4222"}, EventBackendJetStream, false},
+		{"JetStreamNeedsURL", map[string]string{"BEADS_EVENT_BACKEND": "jetstream"}, "", true},
+		{"Kafka", map[string]string{"BEADS_EVENT_BACKEND": "kafka", "BEADS_KAFKA_REST_URL": "http://kafka-rest:8082"}, EventBackendKafka, false},
+		{"KafkaNeedsURL", map[string]string{"BEADS_EVENT_BACKEND": "kafka"}, "", true},
+		{"Unknown", map[string]string{"BEADS_EVENT_BACKEND": "sqs"}, "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			clearAllEnv(t)
+			t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			cfg, err := Load()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got backend %q", cfg.EventBackend)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.EventBackend != tc.want {
+				t.Errorf("EventBackend = %q, want %q", cfg.EventBackend, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadTLS(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+	t.Setenv("BEADS_TLS_CERT", "/etc/beads/tls.crt")
+	t.Setenv("BEADS_TLS_KEY", "/etc/beads/tls.key")
+	t.Setenv("BEADS_TLS_CLIENT_CA", "/etc/beads/ca.crt")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TLSCert != "/etc/beads/tls.crt" || cfg.TLSKey != "/etc/beads/tls.key" || cfg.TLSClientCA != "/etc/beads/ca.crt" {
+		t.Errorf("unexpected TLS config: %+v", cfg)
+	}
+}
+
+func TestLoadRegistrationTokens(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+	t.Setenv("BEADS_ADMIN_TOKEN", "admin-secret")
+	t.Setenv("BEADS_BOOTSTRAP_TOKEN", "bootstrap-secret")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AdminToken != "admin-secret" || cfg.BootstrapToken != "bootstrap-secret" {
+		t.Errorf("unexpected tokens: admin=%q bootstrap=%q", cfg.AdminToken, cfg.BootstrapToken)
+	}
+}
+
+func TestLoadOIDC(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+	t.Setenv("BEADS_OIDC_ISSUER", "https://sso.example.com")
+	t.Setenv("BEADS_OIDC_AUDIENCE", "beads")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.OIDCActorClaim != "preferred_username" || cfg.OIDCRolesClaim != "roles" || cfg.OIDCAdminRole != "beads-admin" || cfg.OIDCJWKSRefresh != time.Hour {
+		t.Errorf("unexpected OIDC defaults: %+v", cfg)
+	}
+
+	t.Setenv("BEADS_OIDC_AUDIENCE", "")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for an issuer without an audience")
+	}
+}
+
+func TestLoadShadowRates(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+	t.Setenv("BEADS_SHADOW", "ready=0.25")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ShadowRates["ready"] != 0.25 {
+		t.Errorf("unexpected shadow rates: %v", cfg.ShadowRates)
+	}
+
+	t.Setenv("BEADS_SHADOW", "ready=1.5")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for out-of-range rate")
+	}
+}
+
+func TestLoadClientVersionPolicy(t *testing.T) {
+	clearAllEnv(t)
+	t.Setenv("BEADS_DATABASE_URL", "postgres://localhost/beads")
+	t.Setenv("BEADS_MIN_CLIENT_VERSION", "v0.9.0")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MinClientVersion != "v0.9.0" || cfg.ClientReleaseURL != "https://github.com/alfredjeanlab/beads/releases/download" {
+		t.Errorf("unexpected policy: min %q, release URL %q", cfg.MinClientVersion, cfg.ClientReleaseURL)
+	}
+
+	t.Setenv("BEADS_MIN_CLIENT_VERSION", "latest")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a non-release minimum version")
+	}
+}
+
 func TestEnvOrDefault(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
@@ -0,0 +1,484 @@
+// Package email sends beads notifications by SMTP: assignments, @mentions,
+// decision requests, overdue beads and saved search digests, to the actors
+// who opted in with an email preference. Each actor chooses which kinds to
+// receive and whether to get them at once or batched into one message.
+package email
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// ConfigKey is the config entry holding the SMTP settings.
+const ConfigKey = "integration:email"
+
+// DefaultPort is the SMTP submission port used when the config sets none.
+const DefaultPort = 587
+
+// Config is the value stored under ConfigKey.
+type Config struct {
+	Host      string             `json:"host"`
+	Port      int                `json:"port,omitempty"` // default 587
+	Username  string             `json:"username,omitempty"`
+	Password  string             `json:"password,omitempty"`
+	From      string             `json:"from"`
+	Templates map[Kind]*Template `json:"templates,omitempty"` // overrides of the default messages
+}
+
+// addr returns the host:port to dial.
+func (c *Config) addr() string {
+	port := c.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+	return net.JoinHostPort(c.Host, strconv.Itoa(port))
+}
+
+// auth returns PLAIN auth when the config has a username.
+func (c *Config) auth() smtp.Auth {
+	if c.Username == "" {
+		return nil
+	}
+	return smtp.PlainAuth("", c.Username, c.Password, c.Host)
+}
+
+// Kind names a kind of notification an actor can opt in to.
+type Kind string
+
+const (
+	KindAssigned  Kind = "assigned"  // a bead was assigned to the actor
+	KindMentioned Kind = "mentioned" // a comment @mentioned the actor
+	KindDecision  Kind = "decision"  // a decision names the actor as an approver, or is assigned to them
+	KindOverdue   Kind = "overdue"   // a bead assigned to the actor passed its due time
+	KindDigest    Kind = "digest"    // a saved search subscription of the actor has new matches
+)
+
+// Kinds are the notification kinds, in the order they are documented.
+var Kinds = []Kind{KindAssigned, KindMentioned, KindDecision, KindOverdue, KindDigest}
+
+// KindBatch names the template of a batched message, which collects
+// several notifications; it is not a kind actors opt in to.
+const KindBatch Kind = "batch"
+
+// Preference names, stored per actor as "pref:<actor>:<name>" configs.
+const (
+	PrefAddress = "email"        // address to send to; setting it opts in
+	PrefNotify  = "email_notify" // kinds to send; all of them when unset
+	PrefBatch   = "email_batch"  // Go duration to batch notifications over; unset sends each at once
+)
+
+// Prefs are an actor's email preferences.
+type Prefs struct {
+	Address string
+	Notify  []Kind        // empty means every kind
+	Batch   time.Duration // 0 sends immediately
+}
+
+// Wants reports whether the actor receives notifications of kind.
+func (p *Prefs) Wants(kind Kind) bool {
+	return p.Address != "" && (len(p.Notify) == 0 || slices.Contains(p.Notify, kind))
+}
+
+// Source is the subset of store.Store the notifier reads from.
+type Source interface {
+	GetConfig(ctx context.Context, key string) (*model.Config, error)
+	GetBead(ctx context.Context, id string) (*model.Bead, error)
+	ListBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error)
+}
+
+// getConfig returns the value of key, or nil if it is not set.
+func getConfig(ctx context.Context, src Source, key string) (json.RawMessage, error) {
+	c, err := src.GetConfig(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && c == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c.Value, nil
+}
+
+// LoadConfig reads the SMTP config. It returns nil, nil when email is not
+// configured.
+func LoadConfig(ctx context.Context, src Source) (*Config, error) {
+	v, err := getConfig(ctx, src, ConfigKey)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := json.Unmarshal(v, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s config: %w", ConfigKey, err)
+	}
+	if cfg.Host == "" || cfg.From == "" {
+		return nil, nil
+	}
+	for kind, t := range cfg.Templates {
+		if t == nil {
+			delete(cfg.Templates, kind)
+			continue
+		}
+		if err := t.parse(); err != nil {
+			return nil, fmt.Errorf("invalid %s config: template %s: %w", ConfigKey, kind, err)
+		}
+	}
+	return &cfg, nil
+}
+
+// LoadPrefs reads actor's email preferences. Values that do not parse are
+// treated as unset.
+func LoadPrefs(ctx context.Context, src Source, actor string) (*Prefs, error) {
+	var p Prefs
+	for _, name := range []string{PrefAddress, PrefNotify, PrefBatch} {
+		v, err := getConfig(ctx, src, "pref:"+actor+":"+name)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			continue
+		}
+		switch name {
+		case PrefAddress:
+			_ = json.Unmarshal(v, &p.Address)
+		case PrefNotify:
+			_ = json.Unmarshal(v, &p.Notify)
+		case PrefBatch:
+			var s string
+			if json.Unmarshal(v, &s) == nil {
+				p.Batch, _ = time.ParseDuration(s)
+			}
+		}
+	}
+	return &p, nil
+}
+
+// Notification is one thing an actor is told about. It is the data the
+// message templates are executed with.
+type Notification struct {
+	Kind  Kind
+	To    string      // actor notified
+	By    string      // actor whose change caused it, when known
+	Bead  *model.Bead // the bead concerned; nil for digests
+	Text  string      // the comment of a mention
+	Beads []*model.Bead
+	// Subscription is the saved search of a digest; Beads are its new
+	// matches.
+	Subscription string
+	Options      []string // the options of a decision
+}
+
+// batch is the notifications held for an actor who batches them.
+type batch struct {
+	address string
+	every   time.Duration
+	started time.Time
+	items   []*Notification
+}
+
+// Notifier is an events.Publisher that forwards every event to an inner
+// publisher and, as a side effect, emails the notifications it triggers.
+// Sends run in the background so publishing never blocks on SMTP. Overdue
+// beads and batched messages are handled by Run.
+type Notifier struct {
+	inner  events.Publisher
+	source Source
+	logger *slog.Logger
+
+	// SendMail delivers a message; it may be overridden before the first
+	// Publish.
+	SendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+	// Now returns the current time; it may be overridden for tests.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	batches   map[string]*batch // by actor
+	lastSweep time.Time
+
+	wg sync.WaitGroup
+}
+
+// NewNotifier wraps inner with email notifications configured from src.
+func NewNotifier(inner events.Publisher, src Source, logger *slog.Logger) *Notifier {
+	return &Notifier{
+		inner:    inner,
+		source:   src,
+		logger:   logger,
+		SendMail: smtp.SendMail,
+		Now:      time.Now,
+		batches:  make(map[string]*batch),
+	}
+}
+
+// Publish forwards the event to the inner publisher and schedules any
+// notifications it triggers. Nobody is notified of their own change.
+func (n *Notifier) Publish(ctx context.Context, topic string, event any) error {
+	err := n.inner.Publish(ctx, topic, event)
+
+	by := events.ActorFrom(ctx)
+	switch e := event.(type) {
+	case events.BeadCreated:
+		if e.Bead == nil {
+			break
+		}
+		if by == "" {
+			by = e.Bead.CreatedBy
+		}
+		if e.Bead.Type == "decision" {
+			n.async(n.decisionNotifications(e.Bead, by))
+		} else if e.Bead.Assignee != "" {
+			n.async([]*Notification{{Kind: KindAssigned, To: e.Bead.Assignee, By: by, Bead: e.Bead}})
+		}
+	case events.BeadUpdated:
+		if assignee, _ := e.Changes["assignee"].(string); assignee != "" && e.Bead != nil {
+			n.async([]*Notification{{Kind: KindAssigned, To: assignee, By: by, Bead: e.Bead}})
+		}
+	case events.Mention:
+		if e.Comment == nil {
+			break
+		}
+		ns := make([]*Notification, 0, len(e.Actors))
+		for _, a := range e.Actors {
+			ns = append(ns, &Notification{Kind: KindMentioned, To: a, By: e.Comment.Author, Text: e.Comment.Text,
+				Bead: &model.Bead{ID: e.Comment.BeadID}})
+		}
+		n.async(ns)
+	case events.DigestGenerated:
+		if d := e.Digest; d != nil && len(d.NewIDs) > 0 {
+			owner, _, _ := strings.Cut(d.Subscription, ":")
+			n.async([]*Notification{{Kind: KindDigest, To: owner, Subscription: d.Subscription,
+				Beads: idBeads(d.NewIDs)}})
+		}
+	}
+	return err
+}
+
+// decisionNotifications returns the notifications of a new decision: to its
+// approvers, or to its assignee when it lists none.
+func (n *Notifier) decisionNotifications(bead *model.Bead, by string) []*Notification {
+	var df struct {
+		Options   []string `json:"options"`
+		Approvers []string `json:"approvers"`
+	}
+	if len(bead.Fields) > 0 {
+		if err := json.Unmarshal(bead.Fields, &df); err != nil {
+			n.logger.Warn("invalid decision fields", "bead_id", bead.ID, "err", err)
+		}
+	}
+	to := df.Approvers
+	if len(to) == 0 && bead.Assignee != "" {
+		to = []string{bead.Assignee}
+	}
+	ns := make([]*Notification, 0, len(to))
+	for _, a := range to {
+		ns = append(ns, &Notification{Kind: KindDecision, To: a, By: by, Bead: bead, Options: df.Options})
+	}
+	return ns
+}
+
+// idBeads returns placeholder beads for ids, resolved before sending.
+func idBeads(ids []string) []*model.Bead {
+	beads := make([]*model.Bead, len(ids))
+	for i, id := range ids {
+		beads[i] = &model.Bead{ID: id}
+	}
+	return beads
+}
+
+// Close waits for in-flight sends and closes the inner publisher. Batched
+// notifications not yet due are dropped.
+func (n *Notifier) Close() error {
+	n.wg.Wait()
+	return n.inner.Close()
+}
+
+func (n *Notifier) async(ns []*Notification) {
+	ns = slices.DeleteFunc(ns, func(x *Notification) bool { return x.To == "" || x.To == x.By })
+	if len(ns) == 0 {
+		return
+	}
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		n.deliver(ctx, ns)
+	}()
+}
+
+// deliver sends or batches each notification per its recipient's
+// preferences. It does nothing until email is configured.
+func (n *Notifier) deliver(ctx context.Context, ns []*Notification) {
+	cfg, err := LoadConfig(ctx, n.source)
+	if err != nil {
+		n.logger.Warn("email config load failed", "err", err)
+		return
+	}
+	if cfg == nil {
+		return
+	}
+	for _, x := range ns {
+		prefs, err := LoadPrefs(ctx, n.source, x.To)
+		if err != nil {
+			n.logger.Warn("email preferences load failed", "actor", x.To, "err", err)
+			continue
+		}
+		if !prefs.Wants(x.Kind) {
+			continue
+		}
+		n.resolve(ctx, x)
+		if prefs.Batch > 0 {
+			n.queue(x, prefs)
+			continue
+		}
+		if err := n.send(cfg, prefs.Address, x.Kind, x); err != nil {
+			n.logger.Warn("email notification failed", "actor", x.To, "kind", x.Kind, "err", err)
+		}
+	}
+}
+
+// resolve replaces the placeholder beads of a notification with the
+// stored beads, keeping the placeholder of one that cannot be read.
+func (n *Notifier) resolve(ctx context.Context, x *Notification) {
+	get := func(b *model.Bead) *model.Bead {
+		if b == nil || b.Title != "" {
+			return b
+		}
+		got, err := n.source.GetBead(ctx, b.ID)
+		if err != nil || got == nil {
+			return b
+		}
+		return got
+	}
+	x.Bead = get(x.Bead)
+	for i, b := range x.Beads {
+		x.Beads[i] = get(b)
+	}
+}
+
+// queue holds x until its recipient's batch is due.
+func (n *Notifier) queue(x *Notification, prefs *Prefs) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	b := n.batches[x.To]
+	if b == nil {
+		b = &batch{started: n.Now()}
+		n.batches[x.To] = b
+	}
+	b.address, b.every = prefs.Address, prefs.Batch
+	b.items = append(b.items, x)
+}
+
+// send renders one message from the template of kind and sends it to
+// address.
+func (n *Notifier) send(cfg *Config, address string, kind Kind, data any) error {
+	t := cfg.Templates[kind]
+	if t == nil {
+		t = defaultTemplates[kind]
+	}
+	subject, body, err := t.render(data)
+	if err != nil {
+		return fmt.Errorf("template %s: %w", kind, err)
+	}
+	msg := message(cfg.From, address, subject, body, n.Now())
+	return n.SendMail(cfg.addr(), cfg.auth(), cfg.From, []string{address}, msg)
+}
+
+// Run reports overdue beads and sends due batches every interval until ctx
+// is cancelled. Beads that fall due while the server is down are not
+// reported.
+func (n *Notifier) Run(ctx context.Context, interval time.Duration) {
+	n.mu.Lock()
+	n.lastSweep = n.Now()
+	n.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := n.Sweep(ctx); err != nil {
+				n.logger.Error("email sweep failed", "err", err)
+			}
+		}
+	}
+}
+
+// Sweep notifies the assignees of open beads that fell due since the
+// previous sweep, then sends every batch that has waited its period. The
+// first sweep only marks the time.
+func (n *Notifier) Sweep(ctx context.Context) error {
+	now := n.Now()
+	n.mu.Lock()
+	since := n.lastSweep
+	n.lastSweep = now
+	n.mu.Unlock()
+
+	if !since.IsZero() {
+		beads, _, err := n.source.ListBeads(ctx, model.BeadFilter{
+			Status: []model.Status{model.StatusOpen, model.StatusInProgress},
+			Query:  "due>=" + since.UTC().Format(time.RFC3339Nano) + " AND due<" + now.UTC().Format(time.RFC3339Nano),
+		})
+		if err != nil {
+			return fmt.Errorf("listing overdue beads: %w", err)
+		}
+		var ns []*Notification
+		for _, b := range beads {
+			if b.Assignee != "" {
+				ns = append(ns, &Notification{Kind: KindOverdue, To: b.Assignee, Bead: b})
+			}
+		}
+		if len(ns) > 0 {
+			n.deliver(ctx, ns)
+		}
+	}
+	n.flushBatches(ctx, now)
+	return nil
+}
+
+// flushBatches sends each batch that has waited its period as one message.
+func (n *Notifier) flushBatches(ctx context.Context, now time.Time) {
+	n.mu.Lock()
+	var due []*batch
+	for actor, b := range n.batches {
+		if now.Sub(b.started) >= b.every {
+			due = append(due, b)
+			delete(n.batches, actor)
+		}
+	}
+	n.mu.Unlock()
+	if len(due) == 0 {
+		return
+	}
+
+	cfg, err := LoadConfig(ctx, n.source)
+	if err != nil || cfg == nil {
+		if err != nil {
+			n.logger.Warn("email config load failed", "err", err)
+		}
+		return
+	}
+	for _, b := range due {
+		data := struct {
+			To            string
+			Notifications []*Notification
+		}{b.items[0].To, b.items}
+		if err := n.send(cfg, b.address, KindBatch, data); err != nil {
+			n.logger.Warn("email batch failed", "actor", data.To, "count", len(b.items), "err", err)
+		}
+	}
+}
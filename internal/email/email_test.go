@@ -0,0 +1,226 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// fakeSource serves configs and beads from maps. ListBeads returns the
+// due beads once, as if they fell due before the next sweep.
+type fakeSource struct {
+	configs map[string]any
+	beads   map[string]*model.Bead
+	due     []*model.Bead
+	filters []model.BeadFilter
+}
+
+func (f *fakeSource) GetConfig(_ context.Context, key string) (*model.Config, error) {
+	v, ok := f.configs[key]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	data, _ := json.Marshal(v)
+	return &model.Config{Key: key, Value: data}, nil
+}
+
+func (f *fakeSource) GetBead(_ context.Context, id string) (*model.Bead, error) {
+	if b, ok := f.beads[id]; ok {
+		return b, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (f *fakeSource) ListBeads(_ context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	f.filters = append(f.filters, filter)
+	out := f.due
+	f.due = nil
+	return out, len(out), nil
+}
+
+// sentMail is a message handed to SendMail.
+type sentMail struct {
+	addr string
+	to   []string
+	msg  string
+}
+
+type mailbox struct {
+	mu   sync.Mutex
+	sent []sentMail
+}
+
+func (m *mailbox) send(addr string, _ smtp.Auth, _ string, to []string, msg []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, sentMail{addr, to, string(msg)})
+	return nil
+}
+
+func newTestNotifier(src *fakeSource) (*Notifier, *mailbox) {
+	mb := &mailbox{}
+	n := NewNotifier(&events.NoopPublisher{}, src, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	n.SendMail = mb.send
+	return n, mb
+}
+
+func testSource() *fakeSource {
+	return &fakeSource{
+		configs: map[string]any{
+			ConfigKey:        Config{Host: "smtp.example.com", From: "beads@example.com"},
+			"pref:bob:email": "bob@example.com",
+		},
+		beads: map[string]*model.Bead{
+			"bd-1": {ID: "bd-1", Title: "Fix login", Status: model.StatusOpen},
+		},
+	}
+}
+
+func TestNotifierAssignments(t *testing.T) {
+	src := testSource()
+	n, mb := newTestNotifier(src)
+	ctx := events.WithActor(context.Background(), "alice")
+
+	bead := &model.Bead{ID: "bd-1", Title: "Fix login", Status: model.StatusOpen, Assignee: "bob"}
+	_ = n.Publish(ctx, events.TopicBeadUpdated, events.BeadUpdated{Bead: bead, Changes: map[string]any{"assignee": "bob"}})
+	// Nobody is told about their own change, nor without an address.
+	_ = n.Publish(events.WithActor(context.Background(), "bob"), events.TopicBeadUpdated,
+		events.BeadUpdated{Bead: bead, Changes: map[string]any{"assignee": "bob"}})
+	_ = n.Publish(ctx, events.TopicBeadCreated, events.BeadCreated{Bead: &model.Bead{ID: "bd-2", Title: "x", Assignee: "carol"}})
+	if err := n.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mb.sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(mb.sent))
+	}
+	m := mb.sent[0]
+	if m.addr != "smtp.example.com:587" || len(m.to) != 1 || m.to[0] != "bob@example.com" {
+		t.Errorf("sent to %s %v", m.addr, m.to)
+	}
+	for _, want := range []string{"To: bob@example.com\r\n", "Subject: [beads] bd-1 assigned to you: Fix login\r\n", "alice assigned bd-1 to you."} {
+		if !strings.Contains(m.msg, want) {
+			t.Errorf("message lacks %q:\n%s", want, m.msg)
+		}
+	}
+}
+
+func TestNotifierOptIn(t *testing.T) {
+	src := testSource()
+	src.configs["pref:bob:email_notify"] = []string{"decision"}
+	n, mb := newTestNotifier(src)
+	ctx := events.WithActor(context.Background(), "alice")
+
+	_ = n.Publish(ctx, events.TopicMention, events.Mention{
+		Comment: &model.Comment{BeadID: "bd-1", Author: "alice", Text: "@bob look"}, Actors: []string{"bob"},
+	})
+	_ = n.Publish(ctx, events.TopicBeadCreated, events.BeadCreated{Bead: &model.Bead{
+		ID: "bd-3", Type: "decision", Title: "Ship it?", Fields: json.RawMessage(`{"options":["yes","no"],"approvers":["bob"]}`),
+	}})
+	if err := n.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mb.sent) != 1 {
+		t.Fatalf("sent %d messages, want only the decision", len(mb.sent))
+	}
+	if msg := mb.sent[0].msg; !strings.Contains(msg, "Decision needed: Ship it?") || !strings.Contains(msg, "Options: yes, no") {
+		t.Errorf("unexpected message:\n%s", msg)
+	}
+}
+
+func TestNotifierTemplates(t *testing.T) {
+	src := testSource()
+	src.configs[ConfigKey] = Config{Host: "smtp.example.com", Port: 25, From: "beads@example.com", Templates: map[Kind]*Template{
+		KindMentioned: {Subject: "{{.By}} pinged you", Body: "{{.Bead.Title}}: {{.Text}}"},
+	}}
+	n, mb := newTestNotifier(src)
+
+	_ = n.Publish(context.Background(), events.TopicMention, events.Mention{
+		Comment: &model.Comment{BeadID: "bd-1", Author: "alice", Text: "@bob look"}, Actors: []string{"bob"},
+	})
+	if err := n.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mb.sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(mb.sent))
+	}
+	m := mb.sent[0]
+	if m.addr != "smtp.example.com:25" || !strings.Contains(m.msg, "Subject: alice pinged you\r\n") ||
+		!strings.HasSuffix(m.msg, "\r\n\r\nFix login: @bob look") {
+		t.Errorf("unexpected message to %s:\n%s", m.addr, m.msg)
+	}
+}
+
+func TestNotifierSweep(t *testing.T) {
+	src := testSource()
+	src.configs["pref:bob:email_batch"] = "1h"
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	due := now.Add(30 * time.Second)
+	src.due = []*model.Bead{{ID: "bd-9", Title: "Renew cert", Status: model.StatusOpen, Assignee: "bob", DueAt: &due}}
+	n, mb := newTestNotifier(src)
+	n.Now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// The first sweep only marks the time.
+	if err := n.Sweep(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(src.filters) != 0 {
+		t.Fatalf("first sweep listed beads: %+v", src.filters)
+	}
+
+	now = now.Add(time.Minute)
+	if err := n.Sweep(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if q := src.filters[0].Query; q != "due>=2026-03-01T12:00:00Z AND due<2026-03-01T12:01:00Z" {
+		t.Errorf("query = %q", q)
+	}
+	_ = n.Publish(events.WithActor(ctx, "alice"), events.TopicDigestGenerated, events.DigestGenerated{Digest: &model.Digest{
+		Subscription: "bob:mine", NewIDs: []string{"bd-1"},
+	}})
+	n.wg.Wait()
+	if len(mb.sent) != 0 {
+		t.Fatalf("batched notifications were sent at once: %+v", mb.sent)
+	}
+
+	// Once the batch has waited its hour, both go out in one message.
+	now = now.Add(time.Hour)
+	if err := n.Sweep(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(mb.sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(mb.sent))
+	}
+	msg := mb.sent[0].msg
+	for _, want := range []string{"Subject: [beads] 2 notifications", "[beads] bd-9 is overdue: Renew cert", "[beads] 1 new in bob:mine"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("batch lacks %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestNotifierUnconfigured(t *testing.T) {
+	src := testSource()
+	delete(src.configs, ConfigKey)
+	n, mb := newTestNotifier(src)
+	_ = n.Publish(context.Background(), events.TopicBeadCreated, events.BeadCreated{Bead: &model.Bead{ID: "bd-2", Assignee: "bob"}})
+	if err := n.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(mb.sent) != 0 {
+		t.Fatalf("sent %d messages without a config", len(mb.sent))
+	}
+}
@@ -0,0 +1,131 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Template is a message as Go text/template source. Templates of a
+// notification kind are executed with its Notification; the batch template
+// with .To and .Notifications.
+type Template struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+
+	subject, body *template.Template
+}
+
+// parse compiles the template source.
+func (t *Template) parse() error {
+	var err error
+	if t.subject, err = template.New("subject").Funcs(funcs).Parse(t.Subject); err != nil {
+		return err
+	}
+	t.body, err = template.New("body").Funcs(funcs).Parse(t.Body)
+	return err
+}
+
+// mustTemplate returns the compiled template of subject and body.
+func mustTemplate(subject, body string) *Template {
+	t := &Template{Subject: subject, Body: body}
+	if err := t.parse(); err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// render executes the compiled template. A subject is kept to its first
+// line.
+func (t *Template) render(data any) (subject, body string, err error) {
+	var s, b bytes.Buffer
+	if err := t.subject.Execute(&s, data); err != nil {
+		return "", "", err
+	}
+	if err := t.body.Execute(&b, data); err != nil {
+		return "", "", err
+	}
+	subject, _, _ = strings.Cut(strings.TrimSpace(s.String()), "\n")
+	return subject, b.String(), nil
+}
+
+// funcs are the functions available to templates besides the builtins.
+var funcs = template.FuncMap{
+	"join": strings.Join,
+	"time": func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format("2006-01-02 15:04 UTC")
+	},
+}
+
+// Summary returns the default subject of x's message, for listing it in a
+// batch.
+func (x *Notification) Summary() string {
+	s, _, _ := defaultTemplates[x.Kind].render(x)
+	return s
+}
+
+// defaultTemplates are the messages sent when the config does not override
+// them.
+var defaultTemplates = map[Kind]*Template{
+	KindAssigned: mustTemplate(`[beads] {{.Bead.ID}} assigned to you: {{.Bead.Title}}`,
+		`{{if .By}}{{.By}} assigned{{else}}Assigned{{end}} {{.Bead.ID}} to you.
+
+  {{.Bead.Title}}
+  status {{.Bead.Status}}, priority {{.Bead.Priority}}{{with .Bead.DueAt}}, due {{time .}}{{end}}
+
+  bd show {{.Bead.ID}}
+`),
+	KindMentioned: mustTemplate(`[beads] {{.By}} mentioned you on {{.Bead.ID}}`,
+		`{{.By}} mentioned you on {{.Bead.ID}}{{with .Bead.Title}} ({{.}}){{end}}:
+
+{{.Text}}
+
+  bd show {{.Bead.ID}}
+`),
+	KindDecision: mustTemplate(`[beads] Decision needed: {{.Bead.Title}}`,
+		`{{if .By}}{{.By}} asks{{else}}You are asked{{end}} for a decision on {{.Bead.ID}}:
+
+  {{.Bead.Title}}
+{{with .Options}}
+Options: {{join . ", "}}
+{{end}}
+  bd decision resolve {{.Bead.ID}} <option>
+`),
+	KindOverdue: mustTemplate(`[beads] {{.Bead.ID}} is overdue: {{.Bead.Title}}`,
+		`{{.Bead.ID}} was due {{time .Bead.DueAt}} and is still {{.Bead.Status}}.
+
+  {{.Bead.Title}}
+
+  bd show {{.Bead.ID}}
+`),
+	KindDigest: mustTemplate(`[beads] {{len .Beads}} new in {{.Subscription}}`,
+		`New matches of {{.Subscription}}:
+{{range .Beads}}
+  {{.ID}}  {{.Title}}{{end}}
+
+  bd digest {{.Subscription}}
+`),
+	KindBatch: mustTemplate(`[beads] {{len .Notifications}} notifications`,
+		`{{range .Notifications}}{{.Summary}}
+{{end}}`),
+}
+
+// message formats an RFC 5322 plain text message.
+func message(from, to, subject, body string, date time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.Bytes()
+}
@@ -2,6 +2,10 @@ package events
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/alfredjeanlab/beads/internal/model"
 )
@@ -12,11 +16,40 @@ const (
 	TopicBeadUpdated       = "beads.bead.updated"
 	TopicBeadClosed        = "beads.bead.closed"
 	TopicBeadDeleted       = "beads.bead.deleted"
+	TopicBeadRestored      = "beads.bead.restored"
+	TopicBeadMerged        = "beads.bead.merged"
+	TopicBeadArchived      = "beads.bead.archived"
+	TopicBeadReopened      = "beads.bead.reopened"
+	TopicBeadDeferred      = "beads.bead.deferred"
+	TopicBeadUndeferred    = "beads.bead.undeferred"
 	TopicDependencyAdded   = "beads.dependency.added"
+	TopicDependencyUpdated = "beads.dependency.updated"
 	TopicDependencyRemoved = "beads.dependency.removed"
+	TopicExternalAdded     = "beads.external.added"
+	TopicExternalUpdated   = "beads.external.updated"
+	TopicExternalRemoved   = "beads.external.removed"
+	TopicCommitLinked      = "beads.commit.linked"
 	TopicLabelAdded        = "beads.label.added"
 	TopicLabelRemoved      = "beads.label.removed"
 	TopicCommentAdded      = "beads.comment.added"
+	TopicCommentResolved   = "beads.comment.resolved"
+	TopicMention           = "beads.mention"
+	TopicNoteAppended      = "beads.note.appended"
+	TopicChecklistAdded    = "beads.checklist.added"
+	TopicChecklistChecked  = "beads.checklist.checked"
+	TopicAlertFired        = "beads.alert.fired"
+	TopicAlertResolved     = "beads.alert.resolved"
+	TopicDecisionResolved  = "beads.decision.resolved"
+	TopicDecisionExpired   = "beads.decision.expired"
+	TopicJackRaised        = "beads.jack.raised"
+	TopicJackExtended      = "beads.jack.extended"
+	TopicJackChanged       = "beads.jack.changed"
+	TopicJackDown          = "beads.jack.down"
+	TopicAgentRegistered   = "beads.agent.registered"
+	TopicAgentUnassigned   = "beads.agent.unassigned"
+	TopicDigestGenerated   = "beads.digest.generated"
+	TopicConfigChanged     = "beads.config.changed"
+	TopicRuleFired         = "beads.rule.fired"
 )
 
 // Event types
@@ -37,18 +70,87 @@ type BeadClosed struct {
 
 type BeadDeleted struct {
 	BeadID string `json:"bead_id"`
+	Soft   bool   `json:"soft,omitempty"` // moved to the trash; may be restored
+}
+
+type BeadRestored struct {
+	Bead       *model.Bead `json:"bead"`
+	RestoredBy string      `json:"restored_by,omitempty"`
+}
+
+type BeadMerged struct {
+	SourceID string      `json:"source_id"`
+	Target   *model.Bead `json:"target"`
+	MergedBy string      `json:"merged_by,omitempty"`
+}
+
+// BeadArchived records that the archival policy hid a closed bead from
+// default listings.
+type BeadArchived struct {
+	BeadID     string `json:"bead_id"`
+	ArchivedBy string `json:"archived_by,omitempty"`
+}
+
+// BeadReopened records an update that moved a closed bead back to an open
+// status. It is recorded alongside the bead.updated event.
+type BeadReopened struct {
+	Bead       *model.Bead `json:"bead"`
+	ReopenedBy string      `json:"reopened_by,omitempty"`
+}
+
+// BeadDeferred records an update that set a bead aside; Bead.DeferUntil is
+// when it comes back, if set. It is recorded alongside the bead.updated
+// event.
+type BeadDeferred struct {
+	Bead       *model.Bead `json:"bead"`
+	DeferredBy string      `json:"deferred_by,omitempty"`
+}
+
+// BeadUndeferred records an update that moved a deferred bead back to open,
+// by hand or because its defer_until passed (UndeferredBy "beads:undefer").
+// It is recorded alongside the bead.updated event.
+type BeadUndeferred struct {
+	Bead         *model.Bead `json:"bead"`
+	UndeferredBy string      `json:"undeferred_by,omitempty"`
 }
 
 type DependencyAdded struct {
 	Dependency *model.Dependency `json:"dependency"`
 }
 
+type DependencyUpdated struct {
+	Dependency *model.Dependency `json:"dependency"`
+	UpdatedBy  string            `json:"updated_by,omitempty"`
+}
+
 type DependencyRemoved struct {
 	BeadID      string `json:"bead_id"`
 	DependsOnID string `json:"depends_on_id"`
 	Type        string `json:"type"`
 }
 
+type ExternalAdded struct {
+	Dep *model.ExternalDep `json:"dep"`
+}
+
+// ExternalUpdated records an external dependency's status changing, by hand
+// or because a probe succeeded (UpdatedBy "beads:prober").
+type ExternalUpdated struct {
+	Dep       *model.ExternalDep `json:"dep"`
+	UpdatedBy string             `json:"updated_by,omitempty"`
+}
+
+type ExternalRemoved struct {
+	BeadID string `json:"bead_id"`
+	ID     int64  `json:"id"`
+	URL    string `json:"url"`
+}
+
+// CommitLinked records a git commit linked to a bead.
+type CommitLinked struct {
+	Commit *model.Commit `json:"commit"`
+}
+
 type LabelAdded struct {
 	BeadID string `json:"bead_id"`
 	Label  string `json:"label"`
@@ -63,8 +165,221 @@ type CommentAdded struct {
 	Comment *model.Comment `json:"comment"`
 }
 
+// CommentResolved is recorded when a comment is marked resolved or
+// unresolved; Comment.ResolvedAt tells which.
+type CommentResolved struct {
+	Comment *model.Comment `json:"comment"`
+}
+
+// Mention records the registered actors a comment @mentions, who are
+// notified of it whether or not they watch the bead. It is recorded
+// alongside the comment.added event.
+type Mention struct {
+	Comment *model.Comment `json:"comment"`
+	Actors  []string       `json:"actors"`
+}
+
+type NoteAppended struct {
+	Note *model.Note `json:"note"`
+}
+
+// ChecklistAdded records items appended to a bead's checklist.
+type ChecklistAdded struct {
+	BeadID string                 `json:"bead_id"`
+	Items  []*model.ChecklistItem `json:"items"`
+}
+
+// ChecklistChecked is recorded when a checklist item is checked or
+// unchecked; Item.Checked tells which.
+type ChecklistChecked struct {
+	Item *model.ChecklistItem `json:"item"`
+}
+
+type AlertFired struct {
+	Name      string  `json:"name"`
+	Metric    string  `json:"metric"`
+	Threshold float64 `json:"threshold"`
+	Value     float64 `json:"value"`
+}
+
+type AlertResolved struct {
+	Name      string  `json:"name"`
+	Metric    string  `json:"metric"`
+	Threshold float64 `json:"threshold"`
+	Value     float64 `json:"value"`
+}
+
+type DecisionResolved struct {
+	BeadID     string `json:"bead_id"`
+	Chosen     string `json:"chosen"`
+	ResolvedBy string `json:"resolved_by,omitempty"`
+}
+
+type DecisionExpired struct {
+	BeadID    string `json:"bead_id"`
+	Chosen    string `json:"chosen,omitempty"` // default option applied; empty when cancelled
+	Cancelled bool   `json:"cancelled"`
+}
+
+// JackRaised records a new jack: a temporary, time-boxed change to shared
+// state that must be taken down again.
+type JackRaised struct {
+	Bead     *model.Bead `json:"bead"`
+	RaisedBy string      `json:"raised_by,omitempty"`
+}
+
+type JackExtended struct {
+	BeadID     string    `json:"bead_id"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Extensions int       `json:"extensions"` // extensions used so far, including this one
+	Reason     string    `json:"reason,omitempty"`
+	ExtendedBy string    `json:"extended_by,omitempty"`
+}
+
+// JackChanged records an entry appended to a jack's change log.
+type JackChanged struct {
+	Note *model.Note `json:"note"`
+}
+
+type JackDown struct {
+	Bead   *model.Bead `json:"bead"`
+	Reason string      `json:"reason,omitempty"`
+	DownBy string      `json:"down_by,omitempty"`
+}
+
+type DigestGenerated struct {
+	Digest *model.Digest `json:"digest"`
+}
+
+type ConfigChanged struct {
+	Key       string          `json:"key"`
+	Rev       int64           `json:"rev"`
+	Value     json.RawMessage `json:"value,omitempty"`
+	Deleted   bool            `json:"deleted,omitempty"`
+	ChangedBy string          `json:"changed_by,omitempty"`
+	// RestoredRev is set when the change rolled the config back to an
+	// earlier revision.
+	RestoredRev int64 `json:"restored_rev,omitempty"`
+}
+
+// RuleFired records that a rule:<name> config matched a bead and what it
+// did to it.
+type RuleFired struct {
+	Rule       string   `json:"rule"`
+	BeadID     string   `json:"bead_id"`
+	Trigger    string   `json:"trigger"` // "created" or "updated"
+	Actions    []string `json:"actions"` // e.g. "assign:team/security", "label:triage"
+	FollowUpID string   `json:"follow_up_id,omitempty"`
+}
+
 // Publisher is the interface for emitting events.
 type Publisher interface {
 	Publish(ctx context.Context, topic string, event any) error
 	Close() error
 }
+
+type sequenceKey struct{}
+
+// WithSequence returns a context carrying a recorded event's sequence
+// number. Delivery is at-least-once, so publishers that can attach it to the
+// message do, letting consumers drop redeliveries.
+func WithSequence(ctx context.Context, seq int64) context.Context {
+	return context.WithValue(ctx, sequenceKey{}, seq)
+}
+
+// SequenceFrom returns the sequence number set by WithSequence.
+func SequenceFrom(ctx context.Context) (int64, bool) {
+	seq, ok := ctx.Value(sequenceKey{}).(int64)
+	return seq, ok
+}
+
+type actorKey struct{}
+
+// WithActor returns a context carrying the actor who caused a recorded
+// event, for publishers that act on behalf of others, such as notifiers
+// that should not tell people about their own changes.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor set by WithActor, or "".
+func ActorFrom(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// topicTypes maps each topic to its event type, for Decode.
+var topicTypes = map[string]func() any{
+	TopicBeadCreated:       func() any { return &BeadCreated{} },
+	TopicBeadUpdated:       func() any { return &BeadUpdated{} },
+	TopicBeadClosed:        func() any { return &BeadClosed{} },
+	TopicBeadDeleted:       func() any { return &BeadDeleted{} },
+	TopicBeadRestored:      func() any { return &BeadRestored{} },
+	TopicBeadMerged:        func() any { return &BeadMerged{} },
+	TopicBeadArchived:      func() any { return &BeadArchived{} },
+	TopicBeadReopened:      func() any { return &BeadReopened{} },
+	TopicBeadDeferred:      func() any { return &BeadDeferred{} },
+	TopicBeadUndeferred:    func() any { return &BeadUndeferred{} },
+	TopicDependencyAdded:   func() any { return &DependencyAdded{} },
+	TopicDependencyUpdated: func() any { return &DependencyUpdated{} },
+	TopicDependencyRemoved: func() any { return &DependencyRemoved{} },
+	TopicExternalAdded:     func() any { return &ExternalAdded{} },
+	TopicExternalUpdated:   func() any { return &ExternalUpdated{} },
+	TopicExternalRemoved:   func() any { return &ExternalRemoved{} },
+	TopicCommitLinked:      func() any { return &CommitLinked{} },
+	TopicLabelAdded:        func() any { return &LabelAdded{} },
+	TopicLabelRemoved:      func() any { return &LabelRemoved{} },
+	TopicCommentAdded:      func() any { return &CommentAdded{} },
+	TopicCommentResolved:   func() any { return &CommentResolved{} },
+	TopicMention:           func() any { return &Mention{} },
+	TopicNoteAppended:      func() any { return &NoteAppended{} },
+	TopicChecklistAdded:    func() any { return &ChecklistAdded{} },
+	TopicChecklistChecked:  func() any { return &ChecklistChecked{} },
+	TopicAlertFired:        func() any { return &AlertFired{} },
+	TopicAlertResolved:     func() any { return &AlertResolved{} },
+	TopicDecisionResolved:  func() any { return &DecisionResolved{} },
+	TopicDecisionExpired:   func() any { return &DecisionExpired{} },
+	TopicJackRaised:        func() any { return &JackRaised{} },
+	TopicJackExtended:      func() any { return &JackExtended{} },
+	TopicJackChanged:       func() any { return &JackChanged{} },
+	TopicJackDown:          func() any { return &JackDown{} },
+	TopicAgentRegistered:   func() any { return &AgentRegistered{} },
+	TopicAgentUnassigned:   func() any { return &AgentUnassigned{} },
+	TopicDigestGenerated:   func() any { return &DigestGenerated{} },
+	TopicConfigChanged:     func() any { return &ConfigChanged{} },
+	TopicRuleFired:         func() any { return &RuleFired{} },
+}
+
+// Decode unmarshals a recorded payload into the event type for topic, as a
+// value (e.g. BeadCreated), so publishers see what was originally emitted.
+// Payloads of an older schema_version are upgraded first. Payloads of
+// unknown topics are returned as json.RawMessage.
+func Decode(topic string, payload json.RawMessage) (any, error) {
+	newEvent, ok := topicTypes[topic]
+	if !ok {
+		return payload, nil
+	}
+	payload, err := upgrade(topic, payload)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s event: %w", topic, err)
+	}
+	ptr := newEvent()
+	if err := json.Unmarshal(payload, ptr); err != nil {
+		return nil, fmt.Errorf("decoding %s event: %w", topic, err)
+	}
+	return reflect.ValueOf(ptr).Elem().Interface(), nil
+}
+
+type AgentRegistered struct {
+	Agent        *model.Agent `json:"agent"`
+	RegisteredBy string       `json:"registered_by,omitempty"`
+}
+
+// AgentUnassigned records that a gone agent's in-progress beads were put
+// back to open and unassigned.
+type AgentUnassigned struct {
+	Agent   string    `json:"agent"`
+	Reason  string    `json:"reason"` // "reaped" or "stale"
+	Since   time.Time `json:"since"`
+	BeadIDs []string  `json:"bead_ids"`
+}
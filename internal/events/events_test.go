@@ -30,6 +30,25 @@ func TestNoopPublisher_ImplementsPublisher(t *testing.T) {
 	var _ Publisher = (*NoopPublisher)(nil)
 }
 
+func TestDecode(t *testing.T) {
+	event, err := Decode(TopicBeadClosed, json.RawMessage(`{"bead":{"id":"bd-a"},"closed_by":"alice"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed, ok := event.(BeadClosed)
+	if !ok || closed.Bead.ID != "bd-a" || closed.ClosedBy != "alice" {
+		t.Fatalf("got %T %+v", event, event)
+	}
+
+	event, err = Decode("beads.unknown", json.RawMessage(`{"x":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw, ok := event.(json.RawMessage); !ok || string(raw) != `{"x":1}` {
+		t.Fatalf("got %T %v", event, event)
+	}
+}
+
 func TestNATSPublisher_ImplementsPublisher(t *testing.T) {
 	var _ Publisher = (*NATSPublisher)(nil)
 }
@@ -78,6 +97,48 @@ func TestNATSPublisher_Publish(t *testing.T) {
 	}
 }
 
+func TestNATSPublisher_PublishSequenceHeaders(t *testing.T) {
+	url := startTestNATS(t)
+
+	pub, err := NewNATSPublisher(url)
+	if err != nil {
+		t.Fatalf("creating publisher: %v", err)
+	}
+	defer pub.Close()
+
+	nc, err := nats.Connect(url)
+	if err != nil {
+		t.Fatalf("connecting subscriber: %v", err)
+	}
+	defer nc.Close()
+
+	ch := make(chan *nats.Msg, 1)
+	sub, err := nc.ChanSubscribe(TopicBeadClosed, ch)
+	if err != nil {
+		t.Fatalf("subscribing: %v", err)
+	}
+	defer sub.Unsubscribe() //nolint:errcheck
+	nc.Flush()
+
+	ctx := WithSequence(context.Background(), 42)
+	if err := pub.Publish(ctx, TopicBeadClosed, BeadClosed{}); err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	pub.conn.Flush()
+
+	select {
+	case msg := <-ch:
+		if got := msg.Header.Get("Beads-Seq"); got != "42" {
+			t.Errorf("Beads-Seq = %q, want 42", got)
+		}
+		if got := msg.Header.Get("Nats-Msg-Id"); got != "beads-42" {
+			t.Errorf("Nats-Msg-Id = %q, want beads-42", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for published message")
+	}
+}
+
 func TestNATSPublisher_PublishMultipleTopics(t *testing.T) {
 	url := startTestNATS(t)
 
@@ -143,3 +204,50 @@ func TestNATSPublisher_Close(t *testing.T) {
 		t.Error("expected error publishing after close")
 	}
 }
+
+func TestMarshal_StampsSchemaVersion(t *testing.T) {
+	data, err := Marshal(TopicLabelAdded, LabelAdded{BeadID: "bd-a", Label: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"schema_version":1,"bead_id":"bd-a","label":"x"}`; string(data) != want {
+		t.Fatalf("Marshal = %s, want %s", data, want)
+	}
+	data, err = Marshal("beads.unknown", map[string]int{"x": 1})
+	if err != nil || string(data) != `{"x":1}` {
+		t.Fatalf("Marshal(unknown topic) = %s, %v", data, err)
+	}
+}
+
+func TestDecode_UpgradesOlderVersions(t *testing.T) {
+	// Pretend label.added renamed label to name in version 2.
+	schemaVersions[TopicLabelAdded] = 2
+	upgrades[TopicLabelAdded] = map[int]func(map[string]json.RawMessage) error{
+		1: func(fields map[string]json.RawMessage) error {
+			fields["name"] = fields["label"]
+			delete(fields, "label")
+			return nil
+		},
+	}
+	t.Cleanup(func() {
+		delete(schemaVersions, TopicLabelAdded)
+		delete(upgrades, TopicLabelAdded)
+	})
+
+	payload, err := upgrade(TopicLabelAdded, json.RawMessage(`{"bead_id":"bd-a","label":"x"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields["name"] != "x" || fields["label"] != nil || fields[SchemaVersionField] != float64(2) {
+		t.Fatalf("upgraded payload = %s", payload)
+	}
+
+	current := json.RawMessage(`{"schema_version":2,"bead_id":"bd-a","name":"y"}`)
+	if payload, err := upgrade(TopicLabelAdded, current); err != nil || string(payload) != string(current) {
+		t.Fatalf("upgrade(current) = %s, %v", payload, err)
+	}
+}
@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// JetStreamPublisher publishes events to a JetStream stream capturing
+// "beads.>", so consumers elsewhere in the cluster can replay them. A publish
+// succeeds only once the stream acknowledges it.
+type JetStreamPublisher struct {
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+// NewJetStreamPublisher connects to NATS and creates or updates stream to
+// capture every beads subject.
+func NewJetStreamPublisher(ctx context.Context, url, stream string) (*JetStreamPublisher, error) {
+	nc, err := nats.Connect(url)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to NATS at %s: %w", url, err)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("opening JetStream: %w", err)
+	}
+	if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     stream,
+		Subjects: []string{"beads.>"},
+	}); err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("creating stream %s: %w", stream, err)
+	}
+	return &JetStreamPublisher{conn: nc, js: js}, nil
+}
+
+func (p *JetStreamPublisher) Publish(ctx context.Context, topic string, event any) error {
+	data, err := Marshal(topic, event)
+	if err != nil {
+		return fmt.Errorf("marshaling event: %w", err)
+	}
+	if _, err := p.js.PublishMsg(ctx, natsMsg(ctx, topic, data)); err != nil {
+		return fmt.Errorf("publishing to JetStream: %w", err)
+	}
+	return nil
+}
+
+func (p *JetStreamPublisher) Close() error {
+	p.conn.Close()
+	return nil
+}
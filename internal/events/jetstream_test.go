@@ -0,0 +1,81 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// startTestJetStream starts an embedded NATS server with JetStream enabled.
+func startTestJetStream(t *testing.T) string {
+	t.Helper()
+	opts := &natsserver.Options{Host: "127.0.0.1", Port: -1, JetStream: true, StoreDir: t.TempDir()}
+	srv, err := natsserver.NewServer(opts)
+	if err != nil {
+		t.Fatalf("starting embedded NATS: %v", err)
+	}
+	srv.Start()
+	t.Cleanup(srv.Shutdown)
+	if !srv.ReadyForConnections(5 * time.Second) {
+		t.Fatal("embedded NATS not ready")
+	}
+	return srv.ClientURL()
+}
+
+func TestJetStreamPublisher_ImplementsPublisher(t *testing.T) {
+	var _ Publisher = (*JetStreamPublisher)(nil)
+}
+
+func TestJetStreamPublisher_Publish(t *testing.T) {
+	url := startTestJetStream(t)
+	ctx := context.Background()
+
+	pub, err := NewJetStreamPublisher(ctx, url, "BEADS")
+	if err != nil {
+		t.Fatalf("creating publisher: %v", err)
+	}
+	defer pub.Close()
+
+	// Publishing the same sequence number twice is deduplicated.
+	seqCtx := WithSequence(ctx, 1)
+	for range 2 {
+		if err := pub.Publish(seqCtx, TopicBeadClosed, BeadClosed{ClosedBy: "alice"}); err != nil {
+			t.Fatalf("Publish error: %v", err)
+		}
+	}
+	if err := pub.Publish(WithSequence(ctx, 2), TopicLabelAdded, LabelAdded{}); err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+
+	nc, err := nats.Connect(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+	js, err := jetstream.New(nc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, err := js.Stream(ctx, "BEADS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := stream.Info(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.State.Msgs != 2 {
+		t.Errorf("stream holds %d messages, want 2", info.State.Msgs)
+	}
+	msg, err := stream.GetMsg(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Subject != TopicBeadClosed || msg.Header.Get("Beads-Seq") != "1" {
+		t.Errorf("first message = %s %v", msg.Subject, msg.Header)
+	}
+}
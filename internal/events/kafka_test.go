@@ -0,0 +1,81 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestKafkaPublisher_ImplementsPublisher(t *testing.T) {
+	var _ Publisher = (*KafkaPublisher)(nil)
+}
+
+func TestKafkaPublisher_Publish(t *testing.T) {
+	var gotPath, gotType string
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotType = r.URL.Path, r.Header.Get("Content-Type")
+		body, _ = io.ReadAll(r.Body)
+		w.Write([]byte(`{"offsets":[{"partition":0,"offset":12}]}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	pub := NewKafkaPublisher(srv.URL+"/", "beads-events")
+	ctx := WithSequence(context.Background(), 7)
+	if err := pub.Publish(ctx, TopicBeadCreated, BeadCreated{Bead: &model.Bead{ID: "bd-k1"}}); err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+
+	if gotPath != "/topics/beads-events" || gotType != "application/vnd.kafka.json.v2+json" {
+		t.Errorf("request = %s (%s)", gotPath, gotType)
+	}
+	var req struct {
+		Records []struct {
+			Key   string `json:"key"`
+			Value struct {
+				Topic string      `json:"topic"`
+				Seq   int64       `json:"seq"`
+				Event BeadCreated `json:"event"`
+			} `json:"value"`
+		} `json:"records"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Fatal(err)
+	}
+	if len(req.Records) != 1 {
+		t.Fatalf("got %d records", len(req.Records))
+	}
+	r := req.Records[0]
+	if r.Key != TopicBeadCreated || r.Value.Topic != TopicBeadCreated || r.Value.Seq != 7 || r.Value.Event.Bead.ID != "bd-k1" {
+		t.Errorf("record = %+v", r)
+	}
+}
+
+func TestKafkaPublisher_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"HTTPError", http.StatusNotFound, `{"error_code":40401,"message":"Topic not found."}`},
+		{"RecordError", http.StatusOK, `{"offsets":[{"error_code":50002,"error":"Kafka error"}]}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body)) //nolint:errcheck
+			}))
+			defer srv.Close()
+
+			pub := NewKafkaPublisher(srv.URL, "beads-events")
+			if err := pub.Publish(context.Background(), TopicBeadClosed, BeadClosed{}); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -2,8 +2,8 @@ package events
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -25,11 +25,23 @@ func NewNATSPublisher(url string) (*NATSPublisher, error) {
 }
 
 func (p *NATSPublisher) Publish(ctx context.Context, topic string, event any) error {
-	data, err := json.Marshal(event)
+	data, err := Marshal(topic, event)
 	if err != nil {
 		return fmt.Errorf("marshaling event: %w", err)
 	}
-	return p.conn.Publish(topic, data)
+	return p.conn.PublishMsg(natsMsg(ctx, topic, data))
+}
+
+// natsMsg builds a message for topic, with the event's sequence number in
+// the Beads-Seq header when ctx carries one. Nats-Msg-Id lets JetStream
+// streams deduplicate redeliveries.
+func natsMsg(ctx context.Context, topic string, data []byte) *nats.Msg {
+	msg := &nats.Msg{Subject: topic, Data: data}
+	if seq, ok := SequenceFrom(ctx); ok {
+		id := strconv.FormatInt(seq, 10)
+		msg.Header = nats.Header{"Beads-Seq": []string{id}, "Nats-Msg-Id": []string{"beads-" + id}}
+	}
+	return msg
 }
 
 func (p *NATSPublisher) Close() error {
@@ -0,0 +1,170 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SchemaVersionField is the payload field carrying the version of the
+// payload's shape. Payloads recorded before versioning have none and are
+// version 1.
+const SchemaVersionField = "schema_version"
+
+// schemaVersions holds each topic's current payload version, where it is
+// past 1. Adding a field needs no new version; renaming or removing one, or
+// changing what it means, does, along with an entry in upgrades.
+var schemaVersions = map[string]int{}
+
+// upgrades maps a topic and a payload version to the function rewriting a
+// payload of that version into the next. Decode applies them in turn, so
+// consumers of the typed events only ever see the current shape.
+var upgrades = map[string]map[int]func(fields map[string]json.RawMessage) error{}
+
+// SchemaVersion returns the current payload version of topic.
+func SchemaVersion(topic string) int {
+	if v, ok := schemaVersions[topic]; ok {
+		return v
+	}
+	return 1
+}
+
+// Marshal encodes event as the payload of topic, stamped with the topic's
+// schema_version. Raw payloads and those of unknown topics are encoded
+// as-is.
+func Marshal(topic string, event any) ([]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	if _, raw := event.(json.RawMessage); raw || topicTypes[topic] == nil || len(data) < 2 || data[0] != '{' {
+		return data, nil
+	}
+	stamp := fmt.Sprintf(`{"%s":%d`, SchemaVersionField, SchemaVersion(topic))
+	if data[1] != '}' {
+		stamp += ","
+	}
+	return append([]byte(stamp), data[1:]...), nil
+}
+
+// upgrade rewrites an older payload of topic into its current version.
+// Payloads at or past the current version are returned unchanged: a newer
+// server only adds fields this one ignores, or bumps the version.
+func upgrade(topic string, payload json.RawMessage) (json.RawMessage, error) {
+	current := SchemaVersion(topic)
+	if current == 1 {
+		return payload, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil || fields == nil {
+		return payload, err
+	}
+	v := 1
+	if raw, ok := fields[SchemaVersionField]; ok {
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", SchemaVersionField, err)
+		}
+	}
+	if v >= current {
+		return payload, nil
+	}
+	for ; v < current; v++ {
+		up := upgrades[topic][v]
+		if up == nil {
+			return nil, fmt.Errorf("no upgrade from %s %d", SchemaVersionField, v)
+		}
+		if err := up(fields); err != nil {
+			return nil, fmt.Errorf("upgrading from %s %d: %w", SchemaVersionField, v, err)
+		}
+	}
+	fields[SchemaVersionField], _ = json.Marshal(current)
+	return json.Marshal(fields)
+}
+
+// Schema describes the payload of one topic.
+type Schema struct {
+	Topic         string  `json:"topic"`
+	Type          string  `json:"type"` // e.g. "BeadCreated"
+	SchemaVersion int     `json:"schema_version"`
+	Fields        []Field `json:"fields"`
+}
+
+// Field is one top-level payload field. Type is a JSON type ("string",
+// "integer", "number", "boolean", "timestamp", "json", "any"), a model
+// type name such as "Bead", or "array<T>" / "object<T>" of one of those.
+type Field struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// Schemas describes the payload of every known topic, sorted by topic.
+func Schemas() []Schema {
+	schemas := make([]Schema, 0, len(topicTypes))
+	for topic, newEvent := range topicTypes {
+		t := reflect.TypeOf(newEvent()).Elem()
+		s := Schema{
+			Topic:         topic,
+			Type:          t.Name(),
+			SchemaVersion: SchemaVersion(topic),
+			Fields:        []Field{{Name: SchemaVersionField, Type: "integer"}},
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			s.Fields = append(s.Fields, Field{
+				Name:     name,
+				Type:     jsonType(f.Type),
+				Optional: strings.Contains(opts, "omitempty"),
+			})
+		}
+		schemas = append(schemas, s)
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Topic < schemas[j].Topic })
+	return schemas
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	rawType  = reflect.TypeOf(json.RawMessage(nil))
+)
+
+// jsonType names the JSON encoding of t for Field.Type.
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return "timestamp"
+	case t == rawType:
+		return "json"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array<" + jsonType(t.Elem()) + ">"
+	case reflect.Map:
+		return "object<" + jsonType(t.Elem()) + ">"
+	case reflect.Struct:
+		return t.Name()
+	}
+	return "any"
+}
@@ -3,6 +3,7 @@ package idgen
 
 import (
 	"fmt"
+	"strings"
 
 	nanoid "github.com/matoous/go-nanoid/v2"
 )
@@ -29,3 +30,38 @@ func GenerateWithPrefix(prefix string) (string, error) {
 	}
 	return prefix + id, nil
 }
+
+// SlugLength caps the title-derived part of a slug.
+var SlugLength = 40
+
+// Slug returns a human-readable name for a bead titled title: the default
+// prefix followed by the title's lowercase letters and digits, with runs of
+// anything else collapsed to "-", e.g. "bd-fix-login-bug". Long titles are
+// cut at a word boundary. Returns "" if the title has no letters or digits.
+func Slug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	slug := b.String()
+	if len(slug) > SlugLength {
+		cut := slug[:SlugLength]
+		if i := strings.LastIndexByte(cut, '-'); i > 0 && slug[SlugLength] != '-' {
+			cut = cut[:i]
+		}
+		slug = strings.TrimSuffix(cut, "-")
+	}
+	if slug == "" {
+		return ""
+	}
+	return DefaultPrefix + slug
+}
@@ -75,3 +75,18 @@ func TestGenerateWithPrefix(t *testing.T) {
 		t.Errorf("GenerateWithPrefix(%q) = %q, does not match expected charset pattern", prefix, id)
 	}
 }
+
+func TestSlug(t *testing.T) {
+	for title, want := range map[string]string{
+		"Fix login bug":                 DefaultPrefix + "fix-login-bug",
+		"  Crash on /v1/beads (500)!! ": DefaultPrefix + "crash-on-v1-beads-500",
+		"Ünïcode ✨ only":                DefaultPrefix + "n-code-only",
+		"!!!":                           "",
+		"Migrate the event outbox to a partitioned table per month": DefaultPrefix + "migrate-the-event-outbox-to-a",
+		"Rename the field to something else entirely":               DefaultPrefix + "rename-the-field-to-something-else",
+	} {
+		if got := Slug(title); got != want {
+			t.Errorf("Slug(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// Package metrics computes user-defined gauges from beads and renders them in
+// the Prometheus text exposition format.
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// Definition is a gauge declared by a "metric:<name>" config. The gauge
+// counts the beads matching Filter, or sums the numeric field named by Sum,
+// optionally split into one series per value of GroupBy.
+//
+// GroupBy and Sum name a bead attribute: "status", "type", "kind",
+// "assignee", "owner", "priority", the computed "age_days" or
+// "blocked_count", or "fields.<name>" for a custom field.
+type Definition struct {
+	Name    string           `json:"-"`
+	Help    string           `json:"help,omitempty"`
+	Filter  model.BeadFilter `json:"filter"`
+	GroupBy string           `json:"group_by,omitempty"`
+	Sum     string           `json:"sum,omitempty"`
+}
+
+// Sample is a single series of a gauge.
+type Sample struct {
+	Label string // value of the group_by attribute; empty when ungrouped
+	Value float64
+}
+
+// Gauge is a computed Definition.
+type Gauge struct {
+	Definition
+	Samples []Sample
+}
+
+// Source is the subset of store.Store the collector reads from.
+type Source interface {
+	ListBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error)
+	ListConfigs(ctx context.Context, namespace string) ([]*model.Config, error)
+}
+
+// Collector caches computed gauges. It wraps an events.Publisher so that any
+// bead event marks the cache stale; the next scrape recomputes. Changes to
+// the metric configs themselves are picked up on the next scrape as well.
+type Collector struct {
+	inner  events.Publisher
+	source Source
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	stale   bool
+	defsKey string // fingerprint of the configs the cached gauges were built from
+	gauges  []Gauge
+}
+
+// NewCollector returns a collector reading from src and forwarding events to inner.
+func NewCollector(inner events.Publisher, src Source, logger *slog.Logger) *Collector {
+	return &Collector{inner: inner, source: src, logger: logger, stale: true}
+}
+
+// Publish forwards the event and invalidates the cached gauges.
+func (c *Collector) Publish(ctx context.Context, topic string, event any) error {
+	if strings.HasPrefix(topic, "beads.") {
+		c.Invalidate()
+	}
+	return c.inner.Publish(ctx, topic, event)
+}
+
+// Close closes the inner publisher.
+func (c *Collector) Close() error {
+	return c.inner.Close()
+}
+
+// Invalidate marks the cached gauges stale.
+func (c *Collector) Invalidate() {
+	c.mu.Lock()
+	c.stale = true
+	c.mu.Unlock()
+}
+
+// Gauges returns the current gauges, recomputing them if any event has been
+// published since the last computation.
+func (c *Collector) Gauges(ctx context.Context) ([]Gauge, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	defs, defsKey, err := c.definitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !c.stale && defsKey == c.defsKey {
+		return c.gauges, nil
+	}
+
+	gauges := make([]Gauge, 0, len(defs))
+	for _, d := range defs {
+		samples, err := c.compute(ctx, d)
+		if err != nil {
+			c.logger.Warn("metric compute failed", "metric", d.Name, "err", err)
+			continue
+		}
+		gauges = append(gauges, Gauge{Definition: d, Samples: samples})
+	}
+	c.gauges = gauges
+	c.defsKey = defsKey
+	c.stale = false
+	return gauges, nil
+}
+
+// WriteText renders the gauges in the Prometheus text exposition format.
+func (c *Collector) WriteText(ctx context.Context, w io.Writer) error {
+	gauges, err := c.Gauges(ctx)
+	if err != nil {
+		return err
+	}
+	for _, g := range gauges {
+		if g.Help != "" {
+			fmt.Fprintf(w, "# HELP %s %s\n", g.Name, escapeHelp(g.Help))
+		}
+		fmt.Fprintf(w, "# TYPE %s gauge\n", g.Name)
+		label := labelName(g.GroupBy)
+		for _, s := range g.Samples {
+			if label == "" {
+				fmt.Fprintf(w, "%s %s\n", g.Name, formatValue(s.Value))
+			} else {
+				fmt.Fprintf(w, "%s{%s=\"%s\"} %s\n", g.Name, label, escapeLabel(s.Label), formatValue(s.Value))
+			}
+		}
+	}
+	return nil
+}
+
+// definitions loads all "metric:*" configs, sorted by name, along with a
+// fingerprint of their raw values.
+func (c *Collector) definitions(ctx context.Context) ([]Definition, string, error) {
+	configs, err := c.source.ListConfigs(ctx, "metric")
+	if err != nil {
+		return nil, "", err
+	}
+	var key strings.Builder
+	defs := make([]Definition, 0, len(configs))
+	for _, cfg := range configs {
+		fmt.Fprintf(&key, "%s=%s\n", cfg.Key, cfg.Value)
+		var d Definition
+		if err := json.Unmarshal(cfg.Value, &d); err != nil {
+			c.logger.Warn("invalid metric config", "key", cfg.Key, "err", err)
+			continue
+		}
+		d.Name = MetricName(strings.TrimPrefix(cfg.Key, "metric:"))
+		defs = append(defs, d)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs, key.String(), nil
+}
+
+func (c *Collector) compute(ctx context.Context, d Definition) ([]Sample, error) {
+	filter := d.Filter
+	filter.Limit, filter.Offset = 0, 0
+	beads, _, err := c.source.ListBeads(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	byLabel := map[string]float64{}
+	for _, b := range beads {
+		label := ""
+		if d.GroupBy != "" {
+			label = attribute(b, d.GroupBy)
+		}
+		v := 1.0
+		if d.Sum != "" {
+			n, err := strconv.ParseFloat(attribute(b, d.Sum), 64)
+			if err != nil {
+				continue
+			}
+			v = n
+		}
+		byLabel[label] += v
+	}
+	if d.GroupBy == "" && len(byLabel) == 0 {
+		byLabel[""] = 0
+	}
+
+	samples := make([]Sample, 0, len(byLabel))
+	for l, v := range byLabel {
+		samples = append(samples, Sample{Label: l, Value: v})
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Label < samples[j].Label })
+	return samples, nil
+}
+
+// attribute returns the string value of a bead attribute expression.
+func attribute(b *model.Bead, expr string) string {
+	switch expr {
+	case "status":
+		return string(b.Status)
+	case "type":
+		return string(b.Type)
+	case "kind":
+		return string(b.Kind)
+	case "assignee":
+		return b.Assignee
+	case "owner":
+		return b.Owner
+	case "priority":
+		return strconv.Itoa(b.Priority)
+	case "age_days":
+		return strconv.Itoa(b.AgeDays)
+	case "blocked_count":
+		return strconv.Itoa(b.BlockedCount)
+	}
+	name, ok := strings.CutPrefix(expr, "fields.")
+	if !ok {
+		return ""
+	}
+	v, _ := b.FieldString(name)
+	return v
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// MetricName returns the exported gauge name for a metric config name:
+// "beads_" followed by the name with invalid characters replaced by "_".
+func MetricName(name string) string {
+	return "beads_" + invalidNameChars.ReplaceAllString(name, "_")
+}
+
+// labelName returns the Prometheus label for a group_by expression.
+func labelName(groupBy string) string {
+	if groupBy == "" {
+		return ""
+	}
+	name := strings.TrimPrefix(groupBy, "fields.")
+	name = invalidNameChars.ReplaceAllString(name, "_")
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
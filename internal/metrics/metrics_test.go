@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// fakeSource filters only on status and fields, which is all these tests use.
+type fakeSource struct {
+	beads     []*model.Bead
+	configs   []*model.Config
+	listCalls int
+}
+
+func (f *fakeSource) ListBeads(_ context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	f.listCalls++
+	var out []*model.Bead
+	for _, b := range f.beads {
+		if len(filter.Status) > 0 && b.Status != filter.Status[0] {
+			continue
+		}
+		if v, ok := filter.Fields["sev"]; ok && attribute(b, "fields.sev") != v {
+			continue
+		}
+		out = append(out, b)
+	}
+	return out, len(out), nil
+}
+
+func (f *fakeSource) ListConfigs(_ context.Context, namespace string) ([]*model.Config, error) {
+	return f.configs, nil
+}
+
+func bead(status model.Status, fields string) *model.Bead {
+	return &model.Bead{Status: status, Fields: json.RawMessage(fields)}
+}
+
+func newTestCollector(src *fakeSource) *Collector {
+	return NewCollector(&events.NoopPublisher{}, src, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestWriteText(t *testing.T) {
+	src := &fakeSource{
+		beads: []*model.Bead{
+			bead(model.StatusOpen, `{"sev":1,"team":"core","points":3}`),
+			bead(model.StatusOpen, `{"sev":1,"team":"core","points":2}`),
+			bead(model.StatusOpen, `{"sev":1,"team":"web \"ui\""}`),
+			bead(model.StatusOpen, `{"sev":2,"team":"core","points":8}`),
+			bead(model.StatusClosed, `{"sev":1,"team":"core"}`),
+		},
+		configs: []*model.Config{
+			{Key: "metric:open-sev1", Value: json.RawMessage(`{"help":"Open sev1 beads per team","filter":{"status":["open"],"fields":{"sev":"1"}},"group_by":"fields.team"}`)},
+			{Key: "metric:open_points", Value: json.RawMessage(`{"filter":{"status":["open"]},"sum":"fields.points"}`)},
+			{Key: "metric:bad", Value: json.RawMessage(`not json`)},
+		},
+	}
+	c := newTestCollector(src)
+
+	var buf bytes.Buffer
+	if err := c.WriteText(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `# TYPE beads_open_points gauge
+beads_open_points 13
+# HELP beads_open_sev1 Open sev1 beads per team
+# TYPE beads_open_sev1 gauge
+beads_open_sev1{team="core"} 2
+beads_open_sev1{team="web \"ui\""} 1
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGaugesRecomputeOnEvent(t *testing.T) {
+	src := &fakeSource{
+		beads:   []*model.Bead{bead(model.StatusOpen, `{}`)},
+		configs: []*model.Config{{Key: "metric:open", Value: json.RawMessage(`{"filter":{"status":["open"]}}`)}},
+	}
+	c := newTestCollector(src)
+	ctx := context.Background()
+
+	value := func() float64 {
+		t.Helper()
+		g, err := c.Gauges(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g[0].Samples[0].Value
+	}
+
+	if v := value(); v != 1 {
+		t.Fatalf("value = %v, want 1", v)
+	}
+	src.beads = append(src.beads, bead(model.StatusOpen, `{}`))
+	if v := value(); v != 1 {
+		t.Errorf("value = %v, want cached 1", v)
+	}
+	if src.listCalls != 1 {
+		t.Errorf("ListBeads called %d times, want 1", src.listCalls)
+	}
+
+	_ = c.Publish(ctx, events.TopicBeadCreated, events.BeadCreated{})
+	if v := value(); v != 2 {
+		t.Errorf("value = %v, want 2 after event", v)
+	}
+
+	// Editing the definition also forces a recompute.
+	src.configs[0] = &model.Config{Key: "metric:open", Value: json.RawMessage(`{"filter":{"status":["closed"]}}`)}
+	if v := value(); v != 0 {
+		t.Errorf("value = %v, want 0 after config change", v)
+	}
+}
+
+func TestMetricName(t *testing.T) {
+	if got := MetricName("open-sev1.by team"); got != "beads_open_sev1_by_team" {
+		t.Errorf("MetricName = %q", got)
+	}
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ActorType says whether an actor is a person or an agent.
+type ActorType string
+
+const (
+	ActorHuman ActorType = "human"
+	ActorAgent ActorType = "agent"
+)
+
+// Actor is a registered identity. Writes that name an actor by its ID or
+// any of its aliases, in any case, are recorded under ID, so "Alice",
+// "alice" and "alice@corp" need not fragment history.
+type Actor struct {
+	ID          string    `json:"id"`
+	DisplayName string    `json:"display_name,omitempty"`
+	Type        ActorType `json:"type"`
+	Aliases     []string  `json:"aliases"` // lowercase, sorted
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+var actorNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._@/+-]{0,127}$`)
+
+// ValidateActorName checks that an actor ID or alias is 1-128 letters,
+// digits, '.', '_', '@', '/', '+' or '-', starting with a letter or digit.
+// Names starting "beads:" are reserved for the server.
+func ValidateActorName(name string) error {
+	if !actorNamePattern.MatchString(name) {
+		return fmt.Errorf("actor name %q must be 1-128 letters, digits, '.', '_', '@', '/', '+' or '-', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// ValidateActorType checks that t is human or agent.
+func ValidateActorType(t ActorType) error {
+	switch t {
+	case ActorHuman, ActorAgent:
+		return nil
+	}
+	return fmt.Errorf("actor type %q must be human or agent", t)
+}
+
+// NormalizeAlias returns the form aliases are stored and matched in.
+func NormalizeAlias(alias string) string {
+	return strings.ToLower(strings.TrimSpace(alias))
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Agent is a registered agent identity, backed by an agent bead. The bearer
+// token itself is never stored; TokenHash is its hex-encoded SHA-256.
+// HookTokenHash is likewise the hash of the agent's hook token, if one was
+// issued; only that token may emit hooks for the agent.
+type Agent struct {
+	Name          string    `json:"name"`
+	BeadID        string    `json:"bead_id"`
+	TokenHash     string    `json:"-"`
+	HookTokenHash string    `json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+	CreatedBy     string    `json:"created_by,omitempty"`
+}
@@ -0,0 +1,24 @@
+package model
+
+// Dimensions beads can be grouped by in an aggregate.
+const (
+	GroupByStatus      = "status"
+	GroupByType        = "type"
+	GroupByAssignee    = "assignee"
+	GroupByLabel       = "label"
+	GroupByPriority    = "priority"
+	GroupByCreatedWeek = "created_week" // Monday of the week, as YYYY-MM-DD
+)
+
+// GroupByDimensions lists the dimensions beads can be grouped by.
+var GroupByDimensions = []string{GroupByStatus, GroupByType, GroupByAssignee, GroupByLabel, GroupByPriority, GroupByCreatedWeek}
+
+// AggregateGroup is the beads sharing one value of a group-by dimension.
+// A bead with several labels counts in each of their groups, and one with
+// none in no label group. AvgAgeHours is the mean time from creation to
+// closing, or to now for beads not closed.
+type AggregateGroup struct {
+	Key         string  `json:"key"`
+	Count       int     `json:"count"`
+	AvgAgeHours float64 `json:"avg_age_hours"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Alias is a hand-picked name for a bead. Aliases, like slugs, are accepted
+// anywhere a bead ID is.
+type Alias struct {
+	Alias     string    `json:"alias"`
+	BeadID    string    `json:"bead_id"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+var aliasPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// ValidateAlias checks that name is 1-64 letters, digits, '.', '_' or '-',
+// starting with a letter or digit.
+func ValidateAlias(name string) error {
+	if !aliasPattern.MatchString(name) {
+		return fmt.Errorf("alias %q must be 1-64 letters, digits, '.', '_' or '-', starting with a letter or digit", name)
+	}
+	return nil
+}
@@ -108,10 +108,35 @@ type Bead struct {
 	ClosedBy           string          `json:"closed_by,omitempty"`
 	DueAt              *time.Time      `json:"due_at,omitempty"`
 	DeferUntil         *time.Time      `json:"defer_until,omitempty"`
+	Estimate           string          `json:"estimate,omitempty"` // points or a duration; see ParseEstimate
+	Actual             string          `json:"actual,omitempty"`   // the effort spent, in the same form
 	Fields json.RawMessage `json:"fields,omitempty"`
 
+	// Computed by the store on read (GetBead, ListBeads); ignored on write.
+	AgeDays        int        `json:"age_days"`                   // whole days since created_at
+	BlockedCount   int        `json:"blocked_count"`              // unclosed beads this one blocks
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty"` // latest of updated_at, comments, events
+	ChecklistDone  int        `json:"checklist_done,omitempty"`   // checked checklist items
+	ChecklistTotal int        `json:"checklist_total,omitempty"`  // checklist items
+	// EffectivePriority is the highest priority (lowest number) among the
+	// bead and the unclosed beads it blocks, directly or through a chain.
+	EffectivePriority *int `json:"effective_priority,omitempty"`
+	// PriorityChain, set by GetBead when the effective priority is
+	// inherited, is the blocking chain from this bead to the bead it is
+	// inherited from.
+	PriorityChain []string `json:"priority_chain,omitempty"`
+
+	// Set only on beads in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy string     `json:"deleted_by,omitempty"`
+
+	// Set on closed beads the archival policy has hidden from default
+	// listings. Reopening a bead unarchives it.
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+
 	// Relational data -- populated by queries, not stored in the beads table.
-	Labels       []string      `json:"labels,omitempty"`
-	Dependencies []*Dependency `json:"dependencies,omitempty"`
-	Comments     []*Comment    `json:"comments,omitempty"`
+	Labels       []string         `json:"labels,omitempty"`
+	Dependencies []*Dependency    `json:"dependencies,omitempty"`
+	Comments     []*Comment       `json:"comments,omitempty"`
+	Checklist    []*ChecklistItem `json:"checklist,omitempty"`
 }
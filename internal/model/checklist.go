@@ -0,0 +1,35 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxChecklistItemLength caps the text of one checklist item.
+const MaxChecklistItemLength = 500
+
+// ChecklistItem is one entry of a bead's checklist, such as an acceptance
+// criterion, checked off on its own so partial progress shows. Index is its
+// 1-based position, fixed when it is added.
+type ChecklistItem struct {
+	BeadID    string     `json:"bead_id"`
+	Index     int        `json:"index"`
+	Text      string     `json:"text"`
+	Checked   bool       `json:"checked"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
+	CheckedBy string     `json:"checked_by,omitempty"`
+	CreatedBy string     `json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ValidateChecklistText checks the text of a new checklist item.
+func ValidateChecklistText(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("checklist item text is required")
+	}
+	if len(text) > MaxChecklistItemLength {
+		return fmt.Errorf("checklist item text exceeds %d bytes", MaxChecklistItemLength)
+	}
+	return nil
+}
@@ -9,4 +9,15 @@ type Comment struct {
 	Author    string    `json:"author"`
 	Text      string    `json:"text"`
 	CreatedAt time.Time `json:"created_at"`
+
+	// Verified is set when Author is the caller's authenticated identity
+	// rather than one it claimed. ClaimedAuthor keeps the author the caller
+	// claimed when that was someone else.
+	Verified      bool   `json:"verified,omitempty"`
+	ClaimedAuthor string `json:"claimed_author,omitempty"`
+
+	// Reactions counts the actors who reacted with each emoji.
+	Reactions  map[string]int `json:"reactions,omitempty"`
+	ResolvedAt *time.Time     `json:"resolved_at,omitempty"` // set once the discussion is marked handled
+	ResolvedBy string         `json:"resolved_by,omitempty"`
 }
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{7,64}$`)
+
+// Commit is a git commit linked to a bead, usually by the post-commit hook
+// bd hook install-git installs when the commit message names the bead.
+type Commit struct {
+	ID        int64     `json:"id"`
+	BeadID    string    `json:"bead_id"`
+	Repo      string    `json:"repo"` // remote URL, or the repository path without one
+	SHA       string    `json:"sha"`
+	Message   string    `json:"message"`
+	Author    string    `json:"author,omitempty"` // the commit's git author
+	LinkedBy  string    `json:"linked_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validate checks the repo and SHA.
+func (c *Commit) Validate() error {
+	if c.Repo == "" {
+		return fmt.Errorf("repo is required")
+	}
+	if !commitSHAPattern.MatchString(c.SHA) {
+		return fmt.Errorf("sha must be 7 to 64 lowercase hex digits")
+	}
+	return nil
+}
@@ -12,4 +12,20 @@ type Config struct {
 	Value     json.RawMessage `json:"value"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
+
+	// Rev is the revision written by the last SetConfig; it is not populated
+	// on reads. UpdatedBy is recorded on that revision.
+	Rev       int64  `json:"rev,omitempty"`
+	UpdatedBy string `json:"updated_by,omitempty"`
+}
+
+// ConfigRevision is one saved version of a config. Every write appends a
+// revision; a delete appends one with Deleted set and no value.
+type ConfigRevision struct {
+	Key       string          `json:"key"`
+	Rev       int64           `json:"rev"`
+	Value     json.RawMessage `json:"value,omitempty"`
+	Deleted   bool            `json:"deleted,omitempty"`
+	Actor     string          `json:"actor,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
 }
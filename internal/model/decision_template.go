@@ -0,0 +1,93 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DecisionTemplatePrefix is the namespace of decision template configs:
+// "decision-template:<name>", e.g. "decision-template:deploy-approval".
+const DecisionTemplatePrefix = "decision-template:"
+
+// ContextPlaceholder is replaced with the caller's context when a decision
+// is created from a template.
+const ContextPlaceholder = "{context}"
+
+// Urgencies a decision can be created with, most urgent first. Each stands
+// for the bead priority of its index, so "critical" is priority 0.
+var Urgencies = []string{"critical", "high", "normal", "low"}
+
+// UrgencyPriority returns the bead priority for urgency and whether it is
+// a known urgency.
+func UrgencyPriority(urgency string) (int, bool) {
+	p := slices.Index(Urgencies, urgency)
+	return p, p >= 0
+}
+
+// DecisionTemplate is the value of a decision-template config: the skeleton
+// of a decision that agents fill in with their context instead of writing
+// the options by hand. Title and Prompt may contain ContextPlaceholder.
+type DecisionTemplate struct {
+	Title         string   `json:"title,omitempty"`          // defaults to the context
+	Prompt        string   `json:"prompt,omitempty"`         // the decision's description
+	Options       []string `json:"options"`                  // e.g. approve, reject, defer
+	DefaultOption string   `json:"default_option,omitempty"` // chosen when the decision expires
+	Urgency       string   `json:"urgency,omitempty"`        // sets the priority; one of Urgencies
+	Approvers     []string `json:"approvers,omitempty"`      // the only actors who may resolve it
+	ExpiresIn     string   `json:"expires_in,omitempty"`     // Go duration until it expires
+	Labels        []string `json:"labels,omitempty"`
+}
+
+// Validate checks the template's options, urgency and expiry.
+func (t *DecisionTemplate) Validate() error {
+	if len(t.Options) == 0 {
+		return fmt.Errorf("options are required")
+	}
+	seen := make(map[string]bool, len(t.Options))
+	for _, o := range t.Options {
+		if strings.TrimSpace(o) == "" {
+			return fmt.Errorf("options must not be empty")
+		}
+		if seen[o] {
+			return fmt.Errorf("option %q is listed twice", o)
+		}
+		seen[o] = true
+	}
+	if t.DefaultOption != "" && !seen[t.DefaultOption] {
+		return fmt.Errorf("default_option %q is not one of the options", t.DefaultOption)
+	}
+	if _, ok := UrgencyPriority(t.Urgency); t.Urgency != "" && !ok {
+		return fmt.Errorf("urgency must be one of %s", strings.Join(Urgencies, ", "))
+	}
+	if slices.Contains(t.Approvers, "") {
+		return fmt.Errorf("approvers must not be empty")
+	}
+	if t.ExpiresIn != "" {
+		d, err := time.ParseDuration(t.ExpiresIn)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("expires_in must be a positive duration such as 4h, got %q", t.ExpiresIn)
+		}
+	}
+	return nil
+}
+
+// Render fills the template's title and prompt with context. Context the
+// prompt has no place for is appended to it, so it is never lost.
+func (t *DecisionTemplate) Render(context string) (title, prompt string) {
+	title = t.Title
+	if title == "" {
+		title = ContextPlaceholder
+	}
+	title = strings.ReplaceAll(title, ContextPlaceholder, context)
+	switch {
+	case strings.Contains(t.Prompt, ContextPlaceholder):
+		prompt = strings.ReplaceAll(t.Prompt, ContextPlaceholder, context)
+	case t.Prompt != "" && context != "":
+		prompt = t.Prompt + "\n\n" + context
+	default:
+		prompt = t.Prompt + context
+	}
+	return title, prompt
+}
@@ -0,0 +1,45 @@
+package model
+
+import "testing"
+
+func TestDecisionTemplateValidate(t *testing.T) {
+	ok := DecisionTemplate{Options: []string{"approve", "reject", "defer"}, DefaultOption: "defer", Urgency: "high", ExpiresIn: "4h", Approvers: []string{"alice"}}
+	if err := ok.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, bad := range []DecisionTemplate{
+		{},
+		{Options: []string{"yes", ""}},
+		{Options: []string{"yes", "yes"}},
+		{Options: []string{"yes", "no"}, DefaultOption: "maybe"},
+		{Options: []string{"yes", "no"}, Urgency: "asap"},
+		{Options: []string{"yes", "no"}, Approvers: []string{""}},
+		{Options: []string{"yes", "no"}, ExpiresIn: "tomorrow"},
+		{Options: []string{"yes", "no"}, ExpiresIn: "-1h"},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("expected an error for %+v", bad)
+		}
+	}
+}
+
+func TestDecisionTemplateRender(t *testing.T) {
+	tmpl := DecisionTemplate{Title: "Deploy {context}?", Prompt: "Approve deploying {context} to production."}
+	title, prompt := tmpl.Render("api v2.3")
+	if title != "Deploy api v2.3?" || prompt != "Approve deploying api v2.3 to production." {
+		t.Fatalf("Render = %q, %q", title, prompt)
+	}
+	if title, _ := (&DecisionTemplate{}).Render("Ship it?"); title != "Ship it?" {
+		t.Fatalf("untitled Render = %q", title)
+	}
+	if _, prompt := (&DecisionTemplate{Title: "Deploy", Prompt: "Approve the deploy."}).Render("PR #12"); prompt != "Approve the deploy.\n\nPR #12" {
+		t.Fatalf("Render without a placeholder = %q", prompt)
+	}
+	if p, ok := UrgencyPriority("critical"); !ok || p != 0 {
+		t.Fatalf("UrgencyPriority(critical) = %d, %v", p, ok)
+	}
+	if _, ok := UrgencyPriority("soon"); ok {
+		t.Fatal("UrgencyPriority(soon) should not be known")
+	}
+}
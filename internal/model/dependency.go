@@ -1,6 +1,11 @@
 package model
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+)
 
 // DependencyType categorizes the relationship between two beads.
 // Well-known constants are provided below, but dependency types are extensible.
@@ -14,6 +19,29 @@ const (
 	DepSupersedes  DependencyType = "supersedes"
 )
 
+// Relation types link beads without blocking either one. They are stored as
+// dependencies from the bead the relation is about, e.g. a bug caused-by the
+// change that introduced it.
+const (
+	RelDuplicateOf DependencyType = "duplicate-of"
+	RelRelatesTo   DependencyType = "relates-to"
+	RelCausedBy    DependencyType = "caused-by"
+	RelFixedBy     DependencyType = "fixed-by"
+)
+
+// RelationTypes lists the relation types in display order.
+var RelationTypes = []DependencyType{RelDuplicateOf, RelRelatesTo, RelCausedBy, RelFixedBy}
+
+// IsRelation reports whether d is one of the RelationTypes.
+func (d DependencyType) IsRelation() bool {
+	for _, t := range RelationTypes {
+		if d == t {
+			return true
+		}
+	}
+	return false
+}
+
 // IsValid reports whether the dependency type is a non-empty string of at most 50 characters.
 // Dependency types are extensible, so any non-empty value within the length limit is accepted.
 func (d DependencyType) IsValid() bool {
@@ -22,10 +50,48 @@ func (d DependencyType) IsValid() bool {
 
 // Dependency represents a directional relationship between two beads.
 type Dependency struct {
-	BeadID      string         `json:"bead_id"`
-	DependsOnID string         `json:"depends_on_id"`
-	Type        DependencyType `json:"type"`
-	CreatedAt   time.Time      `json:"created_at"`
-	CreatedBy   string         `json:"created_by,omitempty"`
-	Metadata    string         `json:"metadata,omitempty"`
+	BeadID      string          `json:"bead_id"`
+	DependsOnID string          `json:"depends_on_id"`
+	Type        DependencyType  `json:"type"`
+	CreatedAt   time.Time       `json:"created_at"`
+	CreatedBy   string          `json:"created_by,omitempty"`
+	Metadata    json.RawMessage `json:"metadata,omitempty"` // JSON object, arbitrary edge attributes
+}
+
+// ValidateDependencyMetadata checks that edge metadata, if present, is a JSON
+// object.
+func ValidateDependencyMetadata(raw json.RawMessage) error {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil || m == nil {
+		return errors.New("metadata must be a JSON object")
+	}
+	return nil
+}
+
+// DepTypeConfig is the value of a "deptype:<name>" config, which defines how
+// a dependency type behaves and is displayed. Beads with an unclosed
+// dependency of a blocking type are not ready; other types are informational.
+// Types without a config are informational.
+type DepTypeConfig struct {
+	Blocking     bool   `json:"blocking"`
+	Label        string `json:"label,omitempty"`         // display name, e.g. "blocked by"
+	InverseLabel string `json:"inverse_label,omitempty"` // seen from the other bead, e.g. "blocks"
+	Description  string `json:"description,omitempty"`
+}
+
+// Relation is a relation seen from one bead: the bead at the other end, and
+// whether the relation was made from this bead (outgoing) or to it
+// (incoming).
+type Relation struct {
+	BeadID    string         `json:"bead_id"`
+	Title     string         `json:"title,omitempty"`
+	Status    Status         `json:"status,omitempty"`
+	Type      DependencyType `json:"type"`
+	Direction string         `json:"direction"` // "outgoing" or "incoming"
+	Label     string         `json:"label"`
+	CreatedAt time.Time      `json:"created_at"`
+	CreatedBy string         `json:"created_by,omitempty"`
 }
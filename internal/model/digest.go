@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Digest is one run of a saved search subscription: every bead matching the
+// subscription's filter at GeneratedAt, and those that did not match on the
+// previous run.
+type Digest struct {
+	ID           int64     `json:"id"`
+	Subscription string    `json:"subscription"` // config key without the "subscription:" prefix
+	GeneratedAt  time.Time `json:"generated_at"`
+	BeadIDs      []string  `json:"bead_ids"`
+	NewIDs       []string  `json:"new_ids"`
+}
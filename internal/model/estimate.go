@@ -0,0 +1,43 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Estimate is the size of a bead's work: story points, or a duration of
+// effort. Beads carry it as text in Estimate and Actual, such as "3" or
+// "4h30m".
+type Estimate struct {
+	Points   float64       // set for a point estimate
+	Duration time.Duration // set for a duration estimate
+}
+
+// IsDuration reports whether e is a duration rather than points.
+func (e Estimate) IsDuration() bool {
+	return e.Duration > 0
+}
+
+// ParseEstimate parses a non-negative number of points, such as "3" or
+// "0.5", or a positive Go duration, such as "90m" or "4h". An empty string
+// is the zero Estimate.
+func ParseEstimate(s string) (Estimate, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Estimate{}, nil
+	}
+	if p, err := strconv.ParseFloat(s, 64); err == nil {
+		if p < 0 || math.IsInf(p, 0) || math.IsNaN(p) {
+			return Estimate{}, fmt.Errorf("points must be a non-negative number, got %q", s)
+		}
+		return Estimate{Points: p}, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return Estimate{}, fmt.Errorf("want points, such as 3, or a duration, such as 4h; got %q", s)
+	}
+	return Estimate{Duration: d}, nil
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Estimate
+		wantErr bool
+	}{
+		{in: "", want: Estimate{}},
+		{in: "3", want: Estimate{Points: 3}},
+		{in: " 0.5 ", want: Estimate{Points: 0.5}},
+		{in: "0", want: Estimate{}},
+		{in: "90m", want: Estimate{Duration: 90 * time.Minute}},
+		{in: "4h30m", want: Estimate{Duration: 4*time.Hour + 30*time.Minute}},
+		{in: "-2", wantErr: true},
+		{in: "NaN", wantErr: true},
+		{in: "0s", wantErr: true},
+		{in: "2 days", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseEstimate(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseEstimate(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseEstimate(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
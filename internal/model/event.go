@@ -14,3 +14,16 @@ type Event struct {
 	Payload   json.RawMessage `json:"payload"`
 	CreatedAt time.Time       `json:"created_at"`
 }
+
+// EventFilter holds criteria for querying the event log. Matching events
+// are returned oldest first.
+type EventFilter struct {
+	Topics  []string  `json:"topics,omitempty"` // any of; empty means every topic
+	BeadID  string    `json:"bead_id,omitempty"`
+	Actor   string    `json:"actor,omitempty"`
+	Label   string    `json:"label,omitempty"`    // the event's bead carries this label
+	Project string    `json:"project,omitempty"`  // the event's bead is this bead or a parent-child descendant of it
+	Since   time.Time `json:"since,omitzero"`     // only events recorded at or after this time
+	AfterID int64     `json:"after_id,omitempty"` // keyset cursor: only events with a greater ID
+	Limit   int       `json:"limit,omitempty"`
+}
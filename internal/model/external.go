@@ -0,0 +1,58 @@
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ExternalStatus is the state of an external dependency.
+type ExternalStatus string
+
+const (
+	// ExternalWaiting blocks the bead until the resource is ready.
+	ExternalWaiting ExternalStatus = "waiting"
+	// ExternalSatisfied no longer blocks the bead.
+	ExternalSatisfied ExternalStatus = "satisfied"
+)
+
+// Probes the server can run to satisfy an external dependency.
+const (
+	ProbeHTTP     = "http"      // the URL answers GET with 200
+	ProbeGitHubPR = "github-pr" // the GitHub pull request at the URL is merged
+)
+
+// ExternalDep is a dependency of a bead on something outside the bead
+// graph, such as another team's pull request or a service being up. While
+// waiting it blocks the bead like an unclosed blocker does.
+type ExternalDep struct {
+	ID          int64          `json:"id"`
+	BeadID      string         `json:"bead_id"`
+	URL         string         `json:"url"`
+	Description string         `json:"description,omitempty"`
+	Status      ExternalStatus `json:"status"`
+	Probe       string         `json:"probe,omitempty"`      // "", "http" or "github-pr"
+	CheckedAt   *time.Time     `json:"checked_at,omitempty"` // last probe
+	LastError   string         `json:"last_error,omitempty"` // why the last probe did not succeed
+	CreatedBy   string         `json:"created_by,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// Validate checks the URL, status and probe.
+func (d *ExternalDep) Validate() error {
+	u, err := url.Parse(d.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL")
+	}
+	switch d.Status {
+	case ExternalWaiting, ExternalSatisfied:
+	default:
+		return fmt.Errorf("status must be waiting or satisfied")
+	}
+	switch d.Probe {
+	case "", ProbeHTTP, ProbeGitHubPR:
+	default:
+		return fmt.Errorf("probe must be http or github-pr")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Field returns the decoded value of the named custom field, or nil if it is
+// absent or Fields is not a JSON object. Numbers decode as float64.
+func (b *Bead) Field(name string) any {
+	if len(b.Fields) == 0 {
+		return nil
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b.Fields, &m); err != nil {
+		return nil
+	}
+	return m[name]
+}
+
+// FieldString returns the named custom field as a string. Numbers and
+// booleans are formatted; ok is false if the field is absent or null.
+func (b *Bead) FieldString(name string) (s string, ok bool) {
+	switch v := b.Field(name).(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// FieldFloat returns the named custom field as a number. Numeric strings are
+// parsed; ok is false if the field is absent or not numeric.
+func (b *Bead) FieldFloat(name string) (f float64, ok bool) {
+	switch v := b.Field(name).(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// FieldTime returns the named custom field parsed as an RFC 3339 timestamp;
+// ok is false if the field is absent or not a valid timestamp.
+func (b *Bead) FieldTime(name string) (t time.Time, ok bool) {
+	s, isString := b.Field(name).(string)
+	if !isString {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
+
+// FieldStrings returns the named custom field as a string list (e.g. a
+// string[] or enum[] field). Non-string elements are skipped.
+func (b *Bead) FieldStrings(name string) []string {
+	list, _ := b.Field(name).([]any)
+	out := make([]string, 0, len(list))
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
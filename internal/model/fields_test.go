@@ -0,0 +1,59 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestBeadFieldAccessors(t *testing.T) {
+	b := &Bead{Fields: json.RawMessage(`{
+		"team": "core",
+		"points": 5,
+		"estimate": "2.5",
+		"done": true,
+		"due": "2026-01-09T17:00:00Z",
+		"options": ["yes", "no", 3],
+		"empty": null
+	}`)}
+
+	if s, ok := b.FieldString("team"); !ok || s != "core" {
+		t.Errorf("FieldString(team) = %q, %v", s, ok)
+	}
+	if s, ok := b.FieldString("points"); !ok || s != "5" {
+		t.Errorf("FieldString(points) = %q, %v", s, ok)
+	}
+	if s, ok := b.FieldString("done"); !ok || s != "true" {
+		t.Errorf("FieldString(done) = %q, %v", s, ok)
+	}
+	for _, name := range []string{"missing", "empty"} {
+		if _, ok := b.FieldString(name); ok {
+			t.Errorf("FieldString(%s) should not be ok", name)
+		}
+	}
+
+	if f, ok := b.FieldFloat("points"); !ok || f != 5 {
+		t.Errorf("FieldFloat(points) = %v, %v", f, ok)
+	}
+	if f, ok := b.FieldFloat("estimate"); !ok || f != 2.5 {
+		t.Errorf("FieldFloat(estimate) = %v, %v", f, ok)
+	}
+	if _, ok := b.FieldFloat("team"); ok {
+		t.Error("FieldFloat(team) should not be ok")
+	}
+
+	want := time.Date(2026, 1, 9, 17, 0, 0, 0, time.UTC)
+	if tm, ok := b.FieldTime("due"); !ok || !tm.Equal(want) {
+		t.Errorf("FieldTime(due) = %v, %v", tm, ok)
+	}
+	if _, ok := b.FieldTime("team"); ok {
+		t.Error("FieldTime(team) should not be ok")
+	}
+
+	if got := b.FieldStrings("options"); len(got) != 2 || got[0] != "yes" || got[1] != "no" {
+		t.Errorf("FieldStrings(options) = %v", got)
+	}
+	if got := (&Bead{}).FieldStrings("options"); len(got) != 0 {
+		t.Errorf("FieldStrings on empty bead = %v", got)
+	}
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // BeadFilter holds criteria for querying beads.
 type BeadFilter struct {
 	Status   []Status   `json:"status,omitempty"`
@@ -11,6 +13,15 @@ type BeadFilter struct {
 	Search   string            `json:"search,omitempty"` // full-text search on title/description
 	Fields   map[string]string `json:"fields,omitempty"` // custom field key=value filters (JSONB)
 	Sort     string            `json:"sort,omitempty"`   // e.g. "-priority", "created_at"; prefix "-" = descending
+	Query    string            `json:"q,omitempty"`      // query language expression, ANDed with the other filters; see internal/query
+	IncludeArchived bool `json:"include_archived,omitempty"` // archived beads are left out unless set
+	// Time ranges: an "after" bound is inclusive, a "before" bound exclusive.
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+	UpdatedAfter  *time.Time `json:"updated_after,omitempty"`
+	UpdatedBefore *time.Time `json:"updated_before,omitempty"`
+	ClosedAfter   *time.Time `json:"closed_after,omitempty"`
+	ClosedBefore  *time.Time `json:"closed_before,omitempty"`
 	Limit    int        `json:"limit,omitempty"`
 	Offset   int        `json:"offset,omitempty"`
 }
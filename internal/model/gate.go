@@ -0,0 +1,62 @@
+package model
+
+import "fmt"
+
+// GateSeverity controls what an unsatisfied gate does when a hook is emitted.
+type GateSeverity string
+
+const (
+	GateBlock GateSeverity = "block" // the hook is refused
+	GateWarn  GateSeverity = "warn"  // the hook proceeds with a warning
+)
+
+// GateDef is one named check in a role's gate checklist, e.g. "tests-passed".
+// Hooks limits the hooks the gate applies to; empty means all of them.
+type GateDef struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Severity    GateSeverity `json:"severity,omitempty"`
+	Hooks       []string     `json:"hooks,omitempty"`
+}
+
+// GateConfig is the value of a "gate:<role>" config. The "gate:*" config
+// applies to every role.
+type GateConfig struct {
+	Gates []GateDef `json:"gates"`
+}
+
+// Validate checks gate names and severities, defaulting severity to block.
+func (c *GateConfig) Validate() error {
+	seen := make(map[string]bool, len(c.Gates))
+	for i := range c.Gates {
+		g := &c.Gates[i]
+		if g.Name == "" {
+			return fmt.Errorf("gate %d: name is required", i)
+		}
+		if seen[g.Name] {
+			return fmt.Errorf("gate %q: defined twice", g.Name)
+		}
+		seen[g.Name] = true
+		switch g.Severity {
+		case "":
+			g.Severity = GateBlock
+		case GateBlock, GateWarn:
+		default:
+			return fmt.Errorf("gate %q: severity must be %q or %q", g.Name, GateBlock, GateWarn)
+		}
+	}
+	return nil
+}
+
+// AppliesTo reports whether the gate is checked for hook.
+func (g GateDef) AppliesTo(hook string) bool {
+	if len(g.Hooks) == 0 {
+		return true
+	}
+	for _, h := range g.Hooks {
+		if h == hook {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package model
+
+import "testing"
+
+func TestGateConfigValidate(t *testing.T) {
+	gc := GateConfig{Gates: []GateDef{{Name: "tests-passed"}, {Name: "commit-pushed", Severity: GateWarn}}}
+	if err := gc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if gc.Gates[0].Severity != GateBlock {
+		t.Fatalf("expected default severity block, got %q", gc.Gates[0].Severity)
+	}
+
+	for _, bad := range []GateConfig{
+		{Gates: []GateDef{{Severity: GateWarn}}},
+		{Gates: []GateDef{{Name: "a"}, {Name: "a"}}},
+		{Gates: []GateDef{{Name: "a", Severity: "fatal"}}},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("expected an error for %+v", bad)
+		}
+	}
+}
+
+func TestGateDefAppliesTo(t *testing.T) {
+	if !(GateDef{Name: "a"}).AppliesTo("stop") {
+		t.Error("a gate without hooks applies to every hook")
+	}
+	g := GateDef{Name: "a", Hooks: []string{"stop"}}
+	if !g.AppliesTo("stop") || g.AppliesTo("pre-push") {
+		t.Error("a gate with hooks applies only to those hooks")
+	}
+}
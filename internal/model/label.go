@@ -0,0 +1,68 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LabelConfigKey is the config entry restricting label namespaces.
+const LabelConfigKey = "label:namespaces"
+
+// SplitLabel splits a namespaced label "team:backend" into its namespace and
+// value. A label without a colon, or starting with one, has no namespace and
+// is its own value.
+func SplitLabel(label string) (namespace, value string) {
+	if i := strings.IndexByte(label, ':'); i > 0 {
+		return label[:i], label[i+1:]
+	}
+	return "", label
+}
+
+// MatchLabel reports whether label matches a label filter: either the exact
+// label, or "ns:*" for any label in namespace ns.
+func MatchLabel(pattern, label string) bool {
+	if ns, ok := strings.CutSuffix(pattern, ":*"); ok && ns != "" {
+		got, _ := SplitLabel(label)
+		return got == ns
+	}
+	return pattern == label
+}
+
+// LabelConfig is the value of the "label:namespaces" config. When it lists
+// namespaces, namespaced labels must use one of them; labels without a
+// namespace are always allowed.
+type LabelConfig struct {
+	Namespaces []string `json:"namespaces"`
+}
+
+// Validate checks that every namespace is a usable label prefix.
+func (c *LabelConfig) Validate() error {
+	for _, ns := range c.Namespaces {
+		if ns == "" || strings.ContainsAny(ns, ":*") {
+			return fmt.Errorf("invalid namespace %q", ns)
+		}
+	}
+	return nil
+}
+
+// Check returns an error if label may not be added under c.
+func (c *LabelConfig) Check(label string) error {
+	ns, _ := SplitLabel(label)
+	if ns == "" || len(c.Namespaces) == 0 {
+		return nil
+	}
+	for _, allowed := range c.Namespaces {
+		if ns == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("label %q: namespace %q is not allowed (allowed: %s)", label, ns, strings.Join(c.Namespaces, ", "))
+}
+
+// LabelCount is a label in use and the number of beads carrying it.
+type LabelCount struct {
+	Label     string `json:"label"`
+	Namespace string `json:"namespace,omitempty"`
+	Value     string `json:"value"`
+	Count     int    `json:"count"`
+}
@@ -0,0 +1,64 @@
+package model
+
+import "testing"
+
+func TestSplitLabel(t *testing.T) {
+	for _, tc := range []struct {
+		label, ns, value string
+	}{
+		{"urgent", "", "urgent"},
+		{"team:backend", "team", "backend"},
+		{"jack:debug:verbose", "jack", "debug:verbose"},
+		{":odd", "", ":odd"},
+		{"team/backend", "", "team/backend"},
+	} {
+		ns, value := SplitLabel(tc.label)
+		if ns != tc.ns || value != tc.value {
+			t.Errorf("SplitLabel(%q) = %q, %q; want %q, %q", tc.label, ns, value, tc.ns, tc.value)
+		}
+	}
+}
+
+func TestMatchLabel(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, label string
+		want           bool
+	}{
+		{"urgent", "urgent", true},
+		{"urgent", "team:urgent", false},
+		{"team:*", "team:backend", true},
+		{"team:*", "team", false},
+		{"team:*", "teams:backend", false},
+		{":*", ":*", true},
+	} {
+		if got := MatchLabel(tc.pattern, tc.label); got != tc.want {
+			t.Errorf("MatchLabel(%q, %q) = %v, want %v", tc.pattern, tc.label, got, tc.want)
+		}
+	}
+}
+
+func TestLabelConfig(t *testing.T) {
+	lc := LabelConfig{Namespaces: []string{"team", "area"}}
+	if err := lc.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	for label, ok := range map[string]bool{
+		"urgent":       true,
+		"team:backend": true,
+		"area:ui":      true,
+		"jack:debug":   false,
+	} {
+		if err := lc.Check(label); (err == nil) != ok {
+			t.Errorf("Check(%q) = %v, want ok=%v", label, err, ok)
+		}
+	}
+
+	if err := (&LabelConfig{}).Check("anything:goes"); err != nil {
+		t.Errorf("empty config rejected label: %v", err)
+	}
+	for _, bad := range []string{"", "a:b", "x*"} {
+		if err := (&LabelConfig{Namespaces: []string{bad}}).Validate(); err == nil {
+			t.Errorf("Validate accepted namespace %q", bad)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// BeadLock is a short-lived advisory lock on a bead: while it is held, only
+// Owner may update the bead. A lock past ExpiresAt is released.
+type BeadLock struct {
+	BeadID     string    `json:"bead_id"`
+	Owner      string    `json:"owner"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// HeldAt reports whether the lock is still held at t.
+func (l *BeadLock) HeldAt(t time.Time) bool {
+	return l != nil && t.Before(l.ExpiresAt)
+}
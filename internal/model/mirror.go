@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// MirroredBead is a read-only copy of a bead held by another beads server,
+// kept by the mirror worker. Remote names the "mirror:<remote>" config it
+// was copied under.
+type MirroredBead struct {
+	Remote   string    `json:"remote"`
+	Bead     *Bead     `json:"bead"`
+	SyncedAt time.Time `json:"synced_at"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Note is a timestamped, attributed entry appended to a bead's notes. The
+// bead's Notes field holds the concatenated entries; Notes rows keep the
+// individual history.
+type Note struct {
+	ID        int64     `json:"id"`
+	BeadID    string    `json:"bead_id"`
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Entry formats the note as the line appended to the bead's Notes field.
+func (n *Note) Entry() string {
+	entry := "[" + n.CreatedAt.UTC().Format(time.RFC3339) + "]"
+	if n.Author != "" {
+		entry += " " + n.Author + ":"
+	}
+	return entry + " " + n.Text
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// Notification tells an actor that something happened on a bead they watch.
+// ReadAt is nil until the actor marks it read.
+type Notification struct {
+	ID        int64      `json:"id"`
+	Actor     string     `json:"actor"`
+	Event     *Event     `json:"event"`
+	CreatedAt time.Time  `json:"created_at"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+}
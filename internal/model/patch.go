@@ -0,0 +1,313 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrPatchTestFailed is returned by ApplyPatch when a "test" operation does
+// not match the document.
+var ErrPatchTestFailed = errors.New("test failed")
+
+// PatchOp is one operation of an RFC 6902 JSON Patch: add, remove, replace,
+// move, copy or test. Path and From are JSON Pointers (RFC 6901); a path
+// ending in "/-" adds to the end of an array.
+type PatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ApplyPatch applies ops to the JSON document doc in order and returns the
+// result. An empty or null doc is patched as an empty object. Either every
+// operation applies or an error is returned; a failed "test" wraps
+// ErrPatchTestFailed.
+func ApplyPatch(doc json.RawMessage, ops []PatchOp) (json.RawMessage, error) {
+	var v any = map[string]any{}
+	if len(doc) > 0 && string(doc) != "null" {
+		if err := decodePatchValue(doc, &v); err != nil {
+			return nil, fmt.Errorf("invalid document: %w", err)
+		}
+	}
+	for i, op := range ops {
+		var err error
+		if v, err = op.apply(v); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(v)
+}
+
+// decodePatchValue decodes data keeping numbers as json.Number, so integers
+// beyond float64 precision survive the round trip.
+func decodePatchValue(data []byte, v *any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// apply returns doc with op applied. Containers along the path may be
+// modified in place.
+func (op PatchOp) apply(doc any) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, errors.New("value is required")
+		}
+		if err := decodePatchValue(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("invalid value: %w", err)
+		}
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		if op.Op == "move" {
+			if len(path) > len(from) && slices.Equal(path[:len(from)], from) {
+				return nil, errors.New("cannot move a value into itself")
+			}
+			if doc, value, err = removeAt(doc, from); err != nil {
+				return nil, err
+			}
+			return addAt(doc, path, value)
+		}
+		if value, err = lookup(doc, from); err != nil {
+			return nil, err
+		}
+		return addAt(doc, path, clonePatchValue(value))
+	}
+
+	switch op.Op {
+	case "add":
+		return addAt(doc, path, value)
+	case "remove":
+		doc, _, err = removeAt(doc, path)
+		return doc, err
+	case "replace":
+		return replaceAt(doc, path, value)
+	case "test":
+		got, err := lookup(doc, path)
+		if err != nil || !reflect.DeepEqual(normalizeNumbers(got), normalizeNumbers(value)) {
+			return nil, ErrPatchTestFailed
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unknown op %q (want add, remove, replace, move, copy or test)", op.Op)
+	}
+}
+
+// parsePointer splits a JSON Pointer into its unescaped reference tokens.
+// The empty pointer, the whole document, has none.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("path %q must start with /", p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// arrayIndex parses an array index token for an array of length n. With
+// appending, "-" and n itself are allowed, naming the end of the array.
+func arrayIndex(token string, n int, appending bool) (int, error) {
+	if appending && token == "-" {
+		return n, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if i > n || (i == n && !appending) {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+	return i, nil
+}
+
+// lookup returns the value at path.
+func lookup(doc any, path []string) (any, error) {
+	for _, t := range path {
+		switch c := doc.(type) {
+		case map[string]any:
+			v, ok := c[t]
+			if !ok {
+				return nil, fmt.Errorf("no member %q", t)
+			}
+			doc = v
+		case []any:
+			i, err := arrayIndex(t, len(c), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = c[i]
+		default:
+			return nil, fmt.Errorf("cannot index %q into a scalar", t)
+		}
+	}
+	return doc, nil
+}
+
+// patchAt calls fn with the container holding the last token of path and
+// that token, and returns doc with the container fn returns in its place.
+// The empty path names the document itself, which fn receives with a nil
+// container.
+func patchAt(doc any, path []string, fn func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 0 {
+		return fn(nil, "")
+	}
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	child, err := lookup(doc, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	if child, err = patchAt(child, path[1:], fn); err != nil {
+		return nil, err
+	}
+	switch c := doc.(type) {
+	case map[string]any:
+		c[path[0]] = child
+	case []any:
+		i, _ := arrayIndex(path[0], len(c), false)
+		c[i] = child
+	}
+	return doc, nil
+}
+
+// addAt adds value at path: it sets an object member, or inserts into an
+// array, shifting later elements.
+func addAt(doc any, path []string, value any) (any, error) {
+	return patchAt(doc, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case nil:
+			return value, nil
+		case map[string]any:
+			c[token] = value
+			return c, nil
+		case []any:
+			i, err := arrayIndex(token, len(c), true)
+			if err != nil {
+				return nil, err
+			}
+			return slices.Insert(c, i, value), nil
+		default:
+			return nil, fmt.Errorf("cannot add %q to a scalar", token)
+		}
+	})
+}
+
+// replaceAt replaces the existing value at path.
+func replaceAt(doc any, path []string, value any) (any, error) {
+	return patchAt(doc, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case nil:
+			return value, nil
+		case map[string]any:
+			if _, ok := c[token]; !ok {
+				return nil, fmt.Errorf("no member %q", token)
+			}
+			c[token] = value
+			return c, nil
+		case []any:
+			i, err := arrayIndex(token, len(c), false)
+			if err != nil {
+				return nil, err
+			}
+			c[i] = value
+			return c, nil
+		default:
+			return nil, fmt.Errorf("cannot index %q into a scalar", token)
+		}
+	})
+}
+
+// removeAt removes the value at path and returns it.
+func removeAt(doc any, path []string) (any, any, error) {
+	var removed any
+	doc, err := patchAt(doc, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case nil:
+			return nil, errors.New("cannot remove the whole document")
+		case map[string]any:
+			v, ok := c[token]
+			if !ok {
+				return nil, fmt.Errorf("no member %q", token)
+			}
+			removed = v
+			delete(c, token)
+			return c, nil
+		case []any:
+			i, err := arrayIndex(token, len(c), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = c[i]
+			return slices.Delete(c, i, i+1), nil
+		default:
+			return nil, fmt.Errorf("cannot index %q into a scalar", token)
+		}
+	})
+	return doc, removed, err
+}
+
+// clonePatchValue deep-copies v, so a copied value is not changed through
+// its source.
+func clonePatchValue(v any) any {
+	switch c := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(c))
+		for k, e := range c {
+			out[k] = clonePatchValue(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(c))
+		for i, e := range c {
+			out[i] = clonePatchValue(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// normalizeNumbers returns v with json.Numbers as float64, so "test"
+// compares 1 and 1.0 as equal.
+func normalizeNumbers(v any) any {
+	switch c := v.(type) {
+	case json.Number:
+		f, _ := c.Float64()
+		return f
+	case map[string]any:
+		out := make(map[string]any, len(c))
+		for k, e := range c {
+			out[k] = normalizeNumbers(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(c))
+		for i, e := range c {
+			out[i] = normalizeNumbers(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
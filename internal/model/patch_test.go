@@ -0,0 +1,74 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestApplyPatch(t *testing.T) {
+	const doc = `{"log":[{"at":"a"}],"meta":{"owner":"alice","n":1},"a/b":0,"big":12345678901234567890}`
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr bool
+	}{
+		{name: "add member", doc: doc, patch: `[{"op":"add","path":"/meta/team","value":"api"}]`,
+			want: `{"a/b":0,"big":12345678901234567890,"log":[{"at":"a"}],"meta":{"n":1,"owner":"alice","team":"api"}}`},
+		{name: "append", doc: doc, patch: `[{"op":"add","path":"/log/-","value":{"at":"b"}}]`,
+			want: `{"a/b":0,"big":12345678901234567890,"log":[{"at":"a"},{"at":"b"}],"meta":{"n":1,"owner":"alice"}}`},
+		{name: "insert", doc: doc, patch: `[{"op":"add","path":"/log/0","value":{"at":"z"}}]`,
+			want: `{"a/b":0,"big":12345678901234567890,"log":[{"at":"z"},{"at":"a"}],"meta":{"n":1,"owner":"alice"}}`},
+		{name: "replace nested", doc: doc, patch: `[{"op":"replace","path":"/log/0/at","value":"x"},{"op":"replace","path":"/a~1b","value":2}]`,
+			want: `{"a/b":2,"big":12345678901234567890,"log":[{"at":"x"}],"meta":{"n":1,"owner":"alice"}}`},
+		{name: "remove", doc: doc, patch: `[{"op":"remove","path":"/meta/owner"},{"op":"remove","path":"/log/0"}]`,
+			want: `{"a/b":0,"big":12345678901234567890,"log":[],"meta":{"n":1}}`},
+		{name: "move and copy", doc: doc, patch: `[{"op":"copy","from":"/meta","path":"/old"},{"op":"move","from":"/meta/owner","path":"/owner"}]`,
+			want: `{"a/b":0,"big":12345678901234567890,"log":[{"at":"a"}],"meta":{"n":1},"old":{"n":1,"owner":"alice"},"owner":"alice"}`},
+		{name: "test passes", doc: doc, patch: `[{"op":"test","path":"/meta/n","value":1.0},{"op":"add","path":"/ok","value":true}]`,
+			want: `{"a/b":0,"big":12345678901234567890,"log":[{"at":"a"}],"meta":{"n":1,"owner":"alice"},"ok":true}`},
+		{name: "empty document", doc: ``, patch: `[{"op":"add","path":"/log","value":[]},{"op":"add","path":"/log/-","value":1}]`,
+			want: `{"log":[1]}`},
+		{name: "replace missing", doc: doc, patch: `[{"op":"replace","path":"/nope","value":1}]`, wantErr: true},
+		{name: "remove missing", doc: doc, patch: `[{"op":"remove","path":"/log/1"}]`, wantErr: true},
+		{name: "index out of range", doc: doc, patch: `[{"op":"add","path":"/log/2","value":1}]`, wantErr: true},
+		{name: "leading zero", doc: doc, patch: `[{"op":"add","path":"/log/01","value":1}]`, wantErr: true},
+		{name: "missing parent", doc: doc, patch: `[{"op":"add","path":"/x/y","value":1}]`, wantErr: true},
+		{name: "missing value", doc: doc, patch: `[{"op":"add","path":"/x"}]`, wantErr: true},
+		{name: "bad path", doc: doc, patch: `[{"op":"add","path":"x","value":1}]`, wantErr: true},
+		{name: "unknown op", doc: doc, patch: `[{"op":"merge","path":"/x","value":1}]`, wantErr: true},
+		{name: "move into child", doc: doc, patch: `[{"op":"move","from":"/meta","path":"/meta/inner"}]`, wantErr: true},
+		{name: "remove root", doc: doc, patch: `[{"op":"remove","path":""}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		var ops []PatchOp
+		if err := json.Unmarshal([]byte(tt.patch), &ops); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got, err := ApplyPatch(json.RawMessage(tt.doc), ops)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && string(got) != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyPatchTestFailed(t *testing.T) {
+	ops := []PatchOp{
+		{Op: "add", Path: "/log/-", Value: json.RawMessage(`"b"`)},
+		{Op: "test", Path: "/owner", Value: json.RawMessage(`"alice"`)},
+	}
+	_, err := ApplyPatch(json.RawMessage(`{"log":[],"owner":"bob"}`), ops)
+	if !errors.Is(err, ErrPatchTestFailed) {
+		t.Fatalf("err = %v, want ErrPatchTestFailed", err)
+	}
+	ops[1].Path = "/missing"
+	if _, err := ApplyPatch(json.RawMessage(`{"log":[]}`), ops); !errors.Is(err, ErrPatchTestFailed) {
+		t.Fatalf("test of a missing member: err = %v", err)
+	}
+}
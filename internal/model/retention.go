@@ -0,0 +1,111 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RetentionRule keeps events of the topics Topic matches for MaxAge before
+// compaction summarizes and deletes them. Topic is an exact topic, a prefix
+// ending in ".*" such as "beads.comment.*", or "*" for every topic. A zero
+// MaxAge keeps matching events forever.
+type RetentionRule struct {
+	Topic  string
+	MaxAge time.Duration
+}
+
+// Covers reports whether the rule applies to every topic pattern matches;
+// for an exact topic, whether the rule applies to it.
+func (r RetentionRule) Covers(pattern string) bool {
+	switch {
+	case r.Topic == "*" || r.Topic == pattern:
+		return true
+	case strings.HasSuffix(r.Topic, ".*"):
+		return strings.HasPrefix(pattern, strings.TrimSuffix(r.Topic, "*")) && pattern != "*"
+	}
+	return false
+}
+
+// ParseRetention parses a retention spec such as
+// "*=2160h,beads.comment.*=8760h,beads.bead.updated=720h" into rules, most
+// general first. Each topic pattern may appear once.
+func ParseRetention(spec string) ([]RetentionRule, error) {
+	var rules []RetentionRule
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		topic, v, ok := strings.Cut(part, "=")
+		topic = strings.TrimSpace(topic)
+		if !ok || !validRetentionTopic(topic) {
+			return nil, fmt.Errorf("invalid retention rule %q: want topic=duration", part)
+		}
+		age, err := time.ParseDuration(strings.TrimSpace(v))
+		if err != nil || age < 0 {
+			return nil, fmt.Errorf("invalid retention age for %s: %q", topic, v)
+		}
+		if slices.ContainsFunc(rules, func(r RetentionRule) bool { return r.Topic == topic }) {
+			return nil, fmt.Errorf("duplicate retention rule for %s", topic)
+		}
+		rules = append(rules, RetentionRule{Topic: topic, MaxAge: age})
+	}
+	slices.SortStableFunc(rules, func(a, b RetentionRule) int {
+		return strings.Compare(retentionSortKey(a.Topic), retentionSortKey(b.Topic))
+	})
+	return rules, nil
+}
+
+// RetentionFor returns the most specific rule in rules that matches topic,
+// and false if none does.
+func RetentionFor(rules []RetentionRule, topic string) (RetentionRule, bool) {
+	var best RetentionRule
+	found := false
+	for _, r := range rules {
+		if r.Covers(topic) && (!found || best.Covers(r.Topic)) {
+			best, found = r, true
+		}
+	}
+	return best, found
+}
+
+// validRetentionTopic reports whether topic is "*", an exact topic or a
+// prefix ending in ".*", with no other wildcards.
+func validRetentionTopic(topic string) bool {
+	if topic == "*" {
+		return true
+	}
+	name := strings.TrimSuffix(topic, ".*")
+	return name != "" && !strings.Contains(name, "*") && !strings.HasSuffix(name, ".")
+}
+
+// retentionSortKey orders "*" first and prefixes before the topics under
+// them.
+func retentionSortKey(topic string) string {
+	if topic == "*" {
+		return ""
+	}
+	return strings.TrimSuffix(topic, "*")
+}
+
+// EventSummary counts the events of one topic on one bead and UTC day that
+// compaction deleted, keeping the audit trail after the raw events are gone.
+type EventSummary struct {
+	Topic   string    `json:"topic"`
+	BeadID  string    `json:"bead_id"`
+	Day     string    `json:"day"` // YYYY-MM-DD
+	Count   int64     `json:"count"`
+	Actors  []string  `json:"actors,omitempty"` // distinct, sorted
+	FirstAt time.Time `json:"first_at"`
+	LastAt  time.Time `json:"last_at"`
+}
+
+// EventSummaryFilter holds criteria for listing event summaries, newest
+// day first.
+type EventSummaryFilter struct {
+	BeadID string
+	Topic  string
+	Limit  int
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRetention(t *testing.T) {
+	rules, err := ParseRetention(" beads.comment.added=0, *=2160h ,beads.comment.*=8760h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RetentionRule{
+		{"*", 2160 * time.Hour},
+		{"beads.comment.*", 8760 * time.Hour},
+		{"beads.comment.added", 0},
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("rules = %+v, want %+v", rules, want)
+	}
+	for i := range want {
+		if rules[i] != want[i] {
+			t.Errorf("rule %d = %+v, want %+v", i, rules[i], want[i])
+		}
+	}
+
+	if rules, err := ParseRetention(""); err != nil || len(rules) != 0 {
+		t.Errorf("empty spec: %v, %v", rules, err)
+	}
+	for _, spec := range []string{"*", "*=forever", "*=-1h", "beads.*.added=1h", "beads.=1h", "=1h", "*=1h,*=2h"} {
+		if _, err := ParseRetention(spec); err == nil {
+			t.Errorf("ParseRetention(%q): want an error", spec)
+		}
+	}
+}
+
+func TestRetentionFor(t *testing.T) {
+	rules := []RetentionRule{
+		{"*", 90 * 24 * time.Hour},
+		{"beads.comment.*", 365 * 24 * time.Hour},
+		{"beads.comment.added", 0},
+	}
+	for _, tc := range []struct {
+		topic string
+		want  string
+	}{
+		{"beads.bead.updated", "*"},
+		{"beads.comment.resolved", "beads.comment.*"},
+		{"beads.comment.added", "beads.comment.added"},
+		{"beads.commentary", "*"},
+	} {
+		r, ok := RetentionFor(rules, tc.topic)
+		if !ok || r.Topic != tc.want {
+			t.Errorf("RetentionFor(%q) = %q, want %q", tc.topic, r.Topic, tc.want)
+		}
+	}
+	if _, ok := RetentionFor(rules[1:], "beads.bead.updated"); ok {
+		t.Error("want no rule without a default")
+	}
+}
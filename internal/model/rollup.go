@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// Rollup summarizes the progress of the beads matching a filter, such as
+// the ones labeled for an epic. Each bead weighs the number in its "weight"
+// field, or 1 without one.
+type Rollup struct {
+	Total           int            `json:"total"`
+	ByStatus        map[string]int `json:"by_status"`
+	TotalWeight     float64        `json:"total_weight"`
+	CompletedWeight float64        `json:"completed_weight"` // of closed beads
+	Blocked         int            `json:"blocked"`          // unclosed beads waiting on a blocker
+	RecentWeight    float64        `json:"recent_weight"`    // closed in the last WindowDays
+	WindowDays      int            `json:"window_days"`
+	// ETA projects when the remaining weight will be closed at the rate of
+	// the last WindowDays. It is nil when nothing is left or nothing was
+	// closed in the window.
+	ETA *time.Time `json:"eta,omitempty"`
+}
+
+// EstimateETA sets r.ETA from the weight left and the weight closed in the
+// WindowDays before now.
+func (r *Rollup) EstimateETA(now time.Time) {
+	r.ETA = nil
+	remaining := r.TotalWeight - r.CompletedWeight
+	if remaining <= 0 || r.RecentWeight <= 0 || r.WindowDays <= 0 {
+		return
+	}
+	perDay := r.RecentWeight / float64(r.WindowDays)
+	eta := now.Add(time.Duration(remaining / perDay * float64(24*time.Hour))).UTC()
+	r.ETA = &eta
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRollupEstimateETA(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	r := Rollup{TotalWeight: 10, CompletedWeight: 4, RecentWeight: 3, WindowDays: 14}
+	r.EstimateETA(now)
+	// 6 left at 3 per 14 days is 28 days.
+	if r.ETA == nil || !r.ETA.Equal(now.AddDate(0, 0, 28)) {
+		t.Fatalf("ETA = %v", r.ETA)
+	}
+
+	r.RecentWeight = 0
+	r.EstimateETA(now)
+	if r.ETA != nil {
+		t.Fatalf("ETA = %v with nothing closed recently", r.ETA)
+	}
+	r.RecentWeight, r.CompletedWeight = 3, 10
+	r.EstimateETA(now)
+	if r.ETA != nil {
+		t.Fatalf("ETA = %v with nothing left", r.ETA)
+	}
+}
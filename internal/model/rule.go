@@ -0,0 +1,139 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Rule triggers: the bead events a rule is evaluated on.
+const (
+	RuleOnCreated = "created"
+	RuleOnUpdated = "updated"
+)
+
+// RuleConfig is the value of a "rule:<name>" config: when a bead matching
+// When is created or updated, the actions in Then are applied to it. Each
+// rule fires at most once per bead.
+type RuleConfig struct {
+	On   []string      `json:"on,omitempty"` // "created", "updated"; empty means both
+	When RuleCondition `json:"when"`
+	Then RuleActions   `json:"then"`
+}
+
+// RuleCondition matches beads. Every set criterion must hold: the bead's
+// type is one of Types, it has all of Labels, and each of Fields equals the
+// given value (or, for list fields, contains it).
+type RuleCondition struct {
+	Types  []BeadType        `json:"types,omitempty"`
+	Labels []string          `json:"labels,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// RuleActions are applied to a matching bead. FollowUp creates a new bead
+// linked to it.
+type RuleActions struct {
+	Assign    string        `json:"assign,omitempty"` // e.g. a team queue such as "team/security"
+	AddLabels []string      `json:"add_labels,omitempty"`
+	Priority  *int          `json:"priority,omitempty"`
+	FollowUp  *RuleFollowUp `json:"follow_up,omitempty"`
+}
+
+// RuleFollowUp describes the bead a rule creates. "{id}" and "{title}" in
+// Title are replaced with the matching bead's. The follow-up gets a DepType
+// dependency (default relates-to) on the matching bead.
+type RuleFollowUp struct {
+	Title    string         `json:"title"`
+	Type     BeadType       `json:"type,omitempty"` // default task
+	Assignee string         `json:"assignee,omitempty"`
+	Labels   []string       `json:"labels,omitempty"`
+	DepType  DependencyType `json:"dep_type,omitempty"`
+}
+
+// Validate checks the rule and fills in defaults.
+func (r *RuleConfig) Validate() error {
+	for _, on := range r.On {
+		if on != RuleOnCreated && on != RuleOnUpdated {
+			return fmt.Errorf("on must be %q or %q, got %q", RuleOnCreated, RuleOnUpdated, on)
+		}
+	}
+	if len(r.When.Types) == 0 && len(r.When.Labels) == 0 && len(r.When.Fields) == 0 {
+		return errors.New("when needs at least one of types, labels or fields")
+	}
+	t := r.Then
+	if t.Assign == "" && len(t.AddLabels) == 0 && t.Priority == nil && t.FollowUp == nil {
+		return errors.New("then needs at least one action")
+	}
+	if t.Priority != nil && (*t.Priority < 0 || *t.Priority > 4) {
+		return errors.New("then.priority must be between 0 and 4")
+	}
+	if f := t.FollowUp; f != nil {
+		if f.Title == "" {
+			return errors.New("then.follow_up.title is required")
+		}
+		if f.Type == "" {
+			f.Type = "task"
+		}
+		if f.DepType == "" {
+			f.DepType = RelRelatesTo
+		}
+		if !f.DepType.IsValid() {
+			return errors.New("then.follow_up.dep_type is invalid")
+		}
+	}
+	return nil
+}
+
+// Triggers reports whether the rule is evaluated on the given trigger.
+func (r *RuleConfig) Triggers(on string) bool {
+	return len(r.On) == 0 || slices.Contains(r.On, on)
+}
+
+// Matches reports whether b satisfies every criterion of the condition.
+func (c RuleCondition) Matches(b *Bead) bool {
+	if len(c.Types) > 0 && !slices.Contains(c.Types, b.Type) {
+		return false
+	}
+	for _, l := range c.Labels {
+		if !slices.Contains(b.Labels, l) {
+			return false
+		}
+	}
+	if len(c.Fields) == 0 {
+		return true
+	}
+	var fields map[string]any
+	if len(b.Fields) == 0 || json.Unmarshal(b.Fields, &fields) != nil {
+		return false
+	}
+	for name, want := range c.Fields {
+		if !fieldMatches(fields[name], want) {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldMatches compares a decoded field value with a condition value: list
+// fields match if any element does, other values by their JSON text
+// (without quotes for strings).
+func fieldMatches(v any, want string) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case string:
+		return v == want
+	case []any:
+		return slices.ContainsFunc(v, func(e any) bool { return fieldMatches(e, want) })
+	default:
+		raw, _ := json.Marshal(v)
+		return string(raw) == want
+	}
+}
+
+// ExpandTitle expands the follow-up title template for b.
+func (f *RuleFollowUp) ExpandTitle(b *Bead) string {
+	return strings.NewReplacer("{id}", b.ID, "{title}", b.Title).Replace(f.Title)
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRuleConfigValidate(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		ok    bool
+	}{
+		{`{"when":{"labels":["security"]},"then":{"assign":"team/security"}}`, true},
+		{`{"on":["created"],"when":{"types":["bug"]},"then":{"follow_up":{"title":"Triage {id}"}}}`, true},
+		{`{"on":["closed"],"when":{"types":["bug"]},"then":{"assign":"x"}}`, false},
+		{`{"when":{},"then":{"assign":"x"}}`, false},
+		{`{"when":{"types":["bug"]},"then":{}}`, false},
+		{`{"when":{"types":["bug"]},"then":{"priority":7}}`, false},
+		{`{"when":{"types":["bug"]},"then":{"follow_up":{}}}`, false},
+	} {
+		var rc RuleConfig
+		if err := json.Unmarshal([]byte(tc.value), &rc); err != nil {
+			t.Fatal(err)
+		}
+		if err := rc.Validate(); (err == nil) != tc.ok {
+			t.Errorf("Validate(%s) = %v", tc.value, err)
+		}
+	}
+
+	rc := RuleConfig{When: RuleCondition{Types: []BeadType{"bug"}}, Then: RuleActions{FollowUp: &RuleFollowUp{Title: "x"}}}
+	if err := rc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if rc.Then.FollowUp.Type != "task" || rc.Then.FollowUp.DepType != RelRelatesTo {
+		t.Errorf("follow-up defaults = %+v", rc.Then.FollowUp)
+	}
+}
+
+func TestRuleConditionMatches(t *testing.T) {
+	b := &Bead{
+		Type:   "bug",
+		Labels: []string{"security", "backend"},
+		Fields: json.RawMessage(`{"severity":"high","count":3,"tags":["auth","api"]}`),
+	}
+	for _, tc := range []struct {
+		cond RuleCondition
+		want bool
+	}{
+		{RuleCondition{Types: []BeadType{"task", "bug"}}, true},
+		{RuleCondition{Types: []BeadType{"task"}}, false},
+		{RuleCondition{Labels: []string{"security", "backend"}}, true},
+		{RuleCondition{Labels: []string{"security", "frontend"}}, false},
+		{RuleCondition{Fields: map[string]string{"severity": "high"}}, true},
+		{RuleCondition{Fields: map[string]string{"count": "3", "tags": "auth"}}, true},
+		{RuleCondition{Fields: map[string]string{"tags": "ui"}}, false},
+		{RuleCondition{Fields: map[string]string{"missing": "x"}}, false},
+	} {
+		if got := tc.cond.Matches(b); got != tc.want {
+			t.Errorf("%+v.Matches = %v, want %v", tc.cond, got, tc.want)
+		}
+	}
+}
+
+func TestRuleFollowUpExpandTitle(t *testing.T) {
+	f := &RuleFollowUp{Title: "Postmortem for {id}: {title}"}
+	if got := f.ExpandTitle(&Bead{ID: "bd-1", Title: "Outage"}); got != "Postmortem for bd-1: Outage" {
+		t.Errorf("ExpandTitle = %q", got)
+	}
+}
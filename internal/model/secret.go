@@ -0,0 +1,118 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// RedactedSecret replaces the value of a secret config field shown to a
+// caller who may not read it.
+const RedactedSecret = "********"
+
+// secretSuffixes are the field names, or "_"-separated name endings, that
+// mark a config field as secret, e.g. "token", "bot_token" or
+// "signing_secret".
+var secretSuffixes = []string{"secret", "token", "password", "api_key", "private_key"}
+
+// IsSecretField reports whether a config value field with this name holds a
+// secret.
+func IsSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range secretSuffixes {
+		if name == s || strings.HasSuffix(name, "_"+s) {
+			return true
+		}
+	}
+	return false
+}
+
+// MapSecrets returns value with fn applied to every string held by a secret
+// field, at any depth of nested objects and arrays. Value is returned as is,
+// with changed false, when fn changes nothing or it is not a JSON object.
+func MapSecrets(value json.RawMessage, fn func(string) (string, error)) (out json.RawMessage, changed bool, err error) {
+	if len(bytes.TrimSpace(value)) == 0 || bytes.TrimSpace(value)[0] != '{' {
+		return value, false, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return value, false, nil
+	}
+	if changed, err = mapSecrets(v, false, fn); err != nil || !changed {
+		return value, false, err
+	}
+	if out, err = json.Marshal(v); err != nil {
+		return value, false, err
+	}
+	return out, true, nil
+}
+
+// mapSecrets applies fn in place to the strings under v; secret is set when
+// v is held by a secret field.
+func mapSecrets(v any, secret bool, fn func(string) (string, error)) (bool, error) {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if s, ok := child.(string); ok && (secret || IsSecretField(k)) {
+				mapped, err := fn(s)
+				if err != nil {
+					return false, err
+				}
+				if mapped != s {
+					v[k], changed = mapped, true
+				}
+				continue
+			}
+			c, err := mapSecrets(child, secret || IsSecretField(k), fn)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || c
+		}
+	case []any:
+		for i, child := range v {
+			if s, ok := child.(string); ok && secret {
+				mapped, err := fn(s)
+				if err != nil {
+					return false, err
+				}
+				if mapped != s {
+					v[i], changed = mapped, true
+				}
+				continue
+			}
+			c, err := mapSecrets(child, secret, fn)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || c
+		}
+	}
+	return changed, nil
+}
+
+// RedactSecrets returns value with every secret string replaced by
+// RedactedSecret.
+func RedactSecrets(value json.RawMessage) json.RawMessage {
+	out, _, _ := MapSecrets(value, func(s string) (string, error) {
+		if s == "" {
+			return s, nil
+		}
+		return RedactedSecret, nil
+	})
+	return out
+}
+
+// HasRedactedSecret reports whether a secret field of value holds
+// RedactedSecret, as in a value read back redacted.
+func HasRedactedSecret(value json.RawMessage) bool {
+	found := false
+	MapSecrets(value, func(s string) (string, error) {
+		found = found || s == RedactedSecret
+		return s, nil
+	})
+	return found
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestIsSecretField(t *testing.T) {
+	for name, want := range map[string]bool{
+		"token":          true,
+		"bot_token":      true,
+		"Signing_Secret": true,
+		"api_key":        true,
+		"password":       true,
+		"channel":        false,
+		"tokens_used":    false,
+		"key":            false,
+	} {
+		if got := IsSecretField(name); got != want {
+			t.Errorf("IsSecretField(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	value := json.RawMessage(`{"bot_token":"xoxb-1","channel":"#ops","limit":10,"hooks":[{"name":"ci","secret":"s3"}],"tokens":{"a":"t1"},"password":""}`)
+	got := string(RedactSecrets(value))
+	for _, leaked := range []string{"xoxb-1", "s3"} {
+		if strings.Contains(got, leaked) {
+			t.Errorf("redacted value leaks %q: %s", leaked, got)
+		}
+	}
+	for _, kept := range []string{`"channel":"#ops"`, `"limit":10`, `"name":"ci"`, `"tokens":{"a":"t1"}`, `"password":""`} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("redacted value lost %s: %s", kept, got)
+		}
+	}
+	if !HasRedactedSecret(json.RawMessage(got)) || HasRedactedSecret(value) {
+		t.Error("HasRedactedSecret disagrees with RedactSecrets")
+	}
+
+	// Values without secrets come back untouched, byte for byte.
+	plain := json.RawMessage(`{"b": 1, "a": 2}`)
+	if string(RedactSecrets(plain)) != string(plain) {
+		t.Errorf("RedactSecrets(%s) = %s", plain, RedactSecrets(plain))
+	}
+}
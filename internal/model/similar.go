@@ -0,0 +1,8 @@
+package model
+
+// SimilarBead is a bead whose title resembles another's, with the trigram
+// similarity of the two titles (0 to 1).
+type SimilarBead struct {
+	Bead       *Bead   `json:"bead"`
+	Similarity float64 `json:"similarity"`
+}
@@ -90,6 +90,13 @@ func ValidateBead(b *Bead) error {
 		})
 	}
 
+	// Estimate and Actual: points or a duration, if present.
+	for _, f := range []struct{ name, value string }{{"estimate", b.Estimate}, {"actual", b.Actual}} {
+		if _, err := ParseEstimate(f.value); err != nil {
+			ve.Errors = append(ve.Errors, FieldError{Field: f.name, Message: err.Error()})
+		}
+	}
+
 	// Fields: must be valid JSON if present.
 	if len(b.Fields) > 0 && !json.Valid(b.Fields) {
 		ve.Errors = append(ve.Errors, FieldError{
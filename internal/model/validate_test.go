@@ -240,3 +240,16 @@ func TestValidationError_HasErrors(t *testing.T) {
 		t.Error("HasErrors() should be true when Errors is non-empty")
 	}
 }
+
+func TestValidate_Estimate(t *testing.T) {
+	b := validBead()
+	b.Estimate, b.Actual = "3", "4h30m"
+	if err := ValidateBead(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b.Estimate, b.Actual = "-1", "soon"
+	errs := fieldErrors(t, ValidateBead(&b))
+	if !hasFieldError(errs, "estimate") || !hasFieldError(errs, "actual") {
+		t.Errorf("expected errors on estimate and actual, got %v", errs)
+	}
+}
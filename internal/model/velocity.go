@@ -0,0 +1,65 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Velocity totals the estimates of beads closed in a window, in points and
+// in hours, as a duration estimate cannot be added to points.
+type Velocity struct {
+	Key          string  `json:"key,omitempty"` // the group's value; empty for the total
+	Closed       int     `json:"closed"`
+	Estimated    int     `json:"estimated"` // closed beads with an estimate
+	Points       float64 `json:"points"`
+	Hours        float64 `json:"hours"`
+	ActualPoints float64 `json:"actual_points"`
+	ActualHours  float64 `json:"actual_hours"`
+	// Estimated points and hours closed per week of the window.
+	PointsPerWeek float64 `json:"points_per_week"`
+	HoursPerWeek  float64 `json:"hours_per_week"`
+}
+
+// Add counts closed bead b. Estimates that do not parse count as none.
+func (v *Velocity) Add(b *Bead) {
+	v.Closed++
+	if est, err := ParseEstimate(b.Estimate); err == nil && b.Estimate != "" {
+		v.Estimated++
+		v.Points += est.Points
+		v.Hours += est.Duration.Hours()
+	}
+	if act, err := ParseEstimate(b.Actual); err == nil {
+		v.ActualPoints += act.Points
+		v.ActualHours += act.Duration.Hours()
+	}
+}
+
+// SetRates sets the per-week rates for a window of the given length.
+func (v *Velocity) SetRates(window time.Duration) {
+	weeks := window.Hours() / (7 * 24)
+	if weeks <= 0 {
+		return
+	}
+	v.PointsPerWeek = v.Points / weeks
+	v.HoursPerWeek = v.Hours / weeks
+}
+
+// ParseWindow parses a reporting window: a number of days or weeks, such as
+// "10d" or "2w", or a Go duration, such as "36h".
+func ParseWindow(s string) (time.Duration, error) {
+	var unit time.Duration
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	default:
+		return time.ParseDuration(s)
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(n) * unit, nil
+}
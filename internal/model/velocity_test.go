@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVelocity_Add(t *testing.T) {
+	var v Velocity
+	v.Add(&Bead{Estimate: "3", Actual: "2.5"})
+	v.Add(&Bead{Estimate: "90m", Actual: "2h"})
+	v.Add(&Bead{})
+	v.Add(&Bead{Estimate: "lots"}) // unparseable counts as none
+	v.SetRates(2 * 7 * 24 * time.Hour)
+
+	want := Velocity{Closed: 4, Estimated: 2, Points: 3, Hours: 1.5, ActualPoints: 2.5, ActualHours: 2, PointsPerWeek: 1.5, HoursPerWeek: 0.75}
+	if v != want {
+		t.Fatalf("velocity = %+v, want %+v", v, want)
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"10d": 10 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"36h": 36 * time.Hour,
+	} {
+		got, err := ParseWindow(in)
+		if err != nil || got != want {
+			t.Errorf("ParseWindow(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "xd", "2y", "w"} {
+		if _, err := ParseWindow(in); err == nil {
+			t.Errorf("ParseWindow(%q): expected an error", in)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package model
+
+import "fmt"
+
+// WorkflowConfigKey is the config entry holding the status workflow.
+const WorkflowConfigKey = "workflow:status"
+
+// WorkflowConfig is the value of the "workflow:status" config: for each
+// status, the statuses an update may move a bead to. Setting a bead's status
+// to the one it already has is always allowed. Closing a bead through the
+// close operation is not subject to the workflow.
+type WorkflowConfig struct {
+	Transitions map[Status][]Status `json:"transitions"`
+}
+
+// Validate checks that every status named in the workflow is known.
+func (c *WorkflowConfig) Validate() error {
+	if len(c.Transitions) == 0 {
+		return fmt.Errorf("transitions are required")
+	}
+	for from, tos := range c.Transitions {
+		if !from.IsValid() {
+			return fmt.Errorf("unknown status %q", from)
+		}
+		for _, to := range tos {
+			if !to.IsValid() {
+				return fmt.Errorf("%s: unknown status %q", from, to)
+			}
+		}
+	}
+	return nil
+}
+
+// Allows reports whether an update may move a bead from one status to
+// another.
+func (c *WorkflowConfig) Allows(from, to Status) bool {
+	if from == to {
+		return true
+	}
+	for _, s := range c.Transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package model
+
+import "testing"
+
+func TestWorkflowConfig(t *testing.T) {
+	wc := WorkflowConfig{Transitions: map[Status][]Status{
+		StatusOpen:   {StatusInProgress},
+		StatusClosed: {StatusOpen},
+	}}
+	if err := wc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if !wc.Allows(StatusOpen, StatusInProgress) || !wc.Allows(StatusClosed, StatusOpen) || !wc.Allows(StatusDeferred, StatusDeferred) {
+		t.Error("expected listed and unchanged statuses to be allowed")
+	}
+	if wc.Allows(StatusClosed, StatusInProgress) || wc.Allows(StatusInProgress, StatusOpen) {
+		t.Error("expected unlisted transitions to be refused")
+	}
+
+	for _, bad := range []WorkflowConfig{
+		{},
+		{Transitions: map[Status][]Status{"blocked": {StatusOpen}}},
+		{Transitions: map[Status][]Status{StatusOpen: {"done"}}},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("expected an error for %+v", bad)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+// Package oidc verifies OpenID Connect bearer tokens: JWTs signed by an
+// identity provider with keys it publishes as a JWKS. The keys are fetched
+// on first use and refreshed periodically, and early when a token names a
+// key the verifier has not seen, so provider key rotation needs no restart.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for the optional Config fields.
+const (
+	DefaultActorClaim = "preferred_username"
+	DefaultRolesClaim = "roles"
+	DefaultRefresh    = time.Hour
+)
+
+// leeway is the clock skew tolerated on exp and nbf.
+const leeway = time.Minute
+
+// minRefetch is the least time between key fetches triggered by tokens
+// naming unknown keys, so forged key IDs cannot hammer the provider.
+const minRefetch = time.Minute
+
+// ErrInvalidToken is wrapped by every error for a token that fails
+// verification, as opposed to a failure to fetch the keys.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Config describes the identity provider tokens must come from.
+type Config struct {
+	Issuer   string // required; must equal the iss claim
+	Audience string // required; must be in the aud claim
+	JWKSURL  string // default: jwks_uri of the issuer's discovery document
+
+	// ActorClaim names the claim holding the caller's actor name; when it is
+	// DefaultActorClaim and absent, sub is used. RolesClaim names the claim
+	// holding the caller's roles, a string or list of strings; a dotted
+	// name such as "realm_access.roles" reaches into nested objects.
+	ActorClaim string
+	RolesClaim string
+
+	Refresh time.Duration // how often keys are refetched; default DefaultRefresh
+	Client  *http.Client  // default: a client with a 10s timeout
+}
+
+// Claims is what a verified token says about its bearer.
+type Claims struct {
+	Subject   string
+	Actor     string
+	Roles     []string
+	ExpiresAt time.Time
+}
+
+// Verifier checks tokens against a provider's published keys. It is safe
+// for concurrent use.
+type Verifier struct {
+	cfg Config
+	now func() time.Time
+
+	mu      sync.Mutex
+	keys    map[string]crypto.PublicKey // by kid
+	fetched time.Time
+}
+
+// NewVerifier returns a verifier for cfg. Keys are not fetched until the
+// first token is verified.
+func NewVerifier(cfg Config) (*Verifier, error) {
+	if cfg.Issuer == "" {
+		return nil, errors.New("oidc: issuer is required")
+	}
+	if cfg.Audience == "" {
+		return nil, errors.New("oidc: audience is required")
+	}
+	if cfg.ActorClaim == "" {
+		cfg.ActorClaim = DefaultActorClaim
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = DefaultRolesClaim
+	}
+	if cfg.Refresh <= 0 {
+		cfg.Refresh = DefaultRefresh
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Verifier{cfg: cfg, now: time.Now}, nil
+}
+
+// LooksLikeJWT reports whether token has the three dot-separated parts of a
+// JWT, to tell it apart from opaque bearer tokens.
+func LooksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2 && !strings.ContainsAny(token, " \t")
+}
+
+// header is the JOSE header of a JWT.
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify checks token's signature, issuer, audience and validity period and
+// returns its claims. Errors for a bad token wrap ErrInvalidToken.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: not a JWT", ErrInvalidToken)
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+	key, err := v.key(ctx, h.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(h.Alg, key, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	return v.checkClaims(claims)
+}
+
+// checkClaims validates the registered claims and extracts the actor and
+// roles.
+func (v *Verifier) checkClaims(claims map[string]any) (*Claims, error) {
+	now := v.now()
+	if iss, _ := claims["iss"].(string); iss != v.cfg.Issuer {
+		return nil, fmt.Errorf("%w: issuer %q is not %q", ErrInvalidToken, iss, v.cfg.Issuer)
+	}
+	if !slices.Contains(stringList(claims["aud"]), v.cfg.Audience) {
+		return nil, fmt.Errorf("%w: audience does not include %q", ErrInvalidToken, v.cfg.Audience)
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("%w: no exp claim", ErrInvalidToken)
+	}
+	expiresAt := time.Unix(int64(exp), 0)
+	if now.After(expiresAt.Add(leeway)) {
+		return nil, fmt.Errorf("%w: expired at %s", ErrInvalidToken, expiresAt.UTC().Format(time.RFC3339))
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(leeway).Before(time.Unix(int64(nbf), 0)) {
+		return nil, fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	}
+
+	c := &Claims{ExpiresAt: expiresAt}
+	c.Subject, _ = claims["sub"].(string)
+	c.Actor, _ = lookup(claims, v.cfg.ActorClaim).(string)
+	if c.Actor == "" && v.cfg.ActorClaim == DefaultActorClaim {
+		c.Actor = c.Subject
+	}
+	if c.Actor == "" {
+		return nil, fmt.Errorf("%w: no %s claim", ErrInvalidToken, v.cfg.ActorClaim)
+	}
+	c.Roles = stringList(lookup(claims, v.cfg.RolesClaim))
+	return c, nil
+}
+
+// lookup returns the claim at a dotted path, or nil.
+func lookup(claims map[string]any, path string) any {
+	var cur any = claims
+	for _, name := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[name]
+	}
+	return cur
+}
+
+// stringList returns a claim that is a string or a list of strings as a
+// list.
+func stringList(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var out []string
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// verifySignature checks sig over signed with key for alg. Only asymmetric
+// algorithms are accepted: a provider never shares an HMAC secret.
+func verifySignature(alg string, key crypto.PublicKey, signed, sig []byte) error {
+	var h hash.Hash
+	var ch crypto.Hash
+	switch alg {
+	case "RS256", "ES256", "PS256":
+		h, ch = sha256.New(), crypto.SHA256
+	case "RS384", "ES384", "PS384":
+		h, ch = sha512.New384(), crypto.SHA384
+	case "RS512", "ES512", "PS512":
+		h, ch = sha512.New(), crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported alg %q", alg)
+	}
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		switch alg[0] {
+		case 'R':
+			return rsa.VerifyPKCS1v15(k, ch, digest, sig)
+		case 'P':
+			return rsa.VerifyPSS(k, ch, digest, sig, nil)
+		}
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if alg[0] != 'E' || len(sig) != 2*size {
+			break
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New("bad signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("alg %q does not match the key", alg)
+}
+
+// key returns the signing key kid, fetching the key set when it is stale or
+// does not have kid.
+func (v *Verifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	now := v.now()
+	_, known := v.keys[kid]
+	if v.keys == nil || now.Sub(v.fetched) > v.cfg.Refresh || (!known && now.Sub(v.fetched) > minRefetch) {
+		keys, err := v.fetchKeys(ctx)
+		switch {
+		case err == nil:
+			v.keys, v.fetched = keys, now
+		case v.keys == nil:
+			return nil, fmt.Errorf("oidc: fetching signing keys: %w", err)
+		}
+		// Otherwise keep verifying with the keys we have.
+	}
+	if k, ok := v.keys[kid]; ok {
+		return k, nil
+	}
+	// A provider with a single key may omit kid.
+	if kid == "" && len(v.keys) == 1 {
+		for _, k := range v.keys {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+}
+
+// jwk is one key of a JWKS. Only the fields of RSA and EC public keys are
+// read.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// fetchKeys fetches the provider's signing keys, discovering the JWKS URL
+// from the issuer when none is configured.
+func (v *Verifier) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	url := v.cfg.JWKSURL
+	if url == "" {
+		var doc struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := v.getJSON(ctx, strings.TrimRight(v.cfg.Issuer, "/")+"/.well-known/openid-configuration", &doc); err != nil {
+			return nil, fmt.Errorf("discovery: %w", err)
+		}
+		if doc.JWKSURI == "" {
+			return nil, errors.New("discovery document has no jwks_uri")
+		}
+		url = doc.JWKSURI
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := v.getJSON(ctx, url, &set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if pub, err := k.publicKey(); err == nil {
+			keys[k.Kid] = pub
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("key set has no usable signing keys")
+	}
+	return keys, nil
+}
+
+func (v *Verifier) getJSON(ctx context.Context, url string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(dst)
+}
+
+// publicKey decodes an RSA or EC public key.
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent too large")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("EC coordinates have the wrong length")
+		}
+		point := append(append([]byte{4}, x...), y...)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
@@ -0,0 +1,159 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// provider is a test identity provider serving discovery and a JWKS.
+type provider struct {
+	*httptest.Server
+	rsaKey  *rsa.PrivateKey
+	ecKey   *ecdsa.PrivateKey
+	fetches atomic.Int32
+}
+
+func newProvider(t *testing.T) *provider {
+	t.Helper()
+	p := &provider{}
+	var err error
+	if p.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		t.Fatal(err)
+	}
+	if p.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	b64 := base64.RawURLEncoding.EncodeToString
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": p.URL, "jwks_uri": p.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		p.fetches.Add(1)
+		ecPoint, _ := p.ecKey.PublicKey.Bytes()
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa1", "use": "sig", "n": b64(p.rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(p.rsaKey.E)).Bytes())},
+			{"kty": "EC", "kid": "ec1", "crv": "P-256", "x": b64(ecPoint[1:33]), "y": b64(ecPoint[33:])},
+		}})
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+// sign returns a token for claims signed with the provider's key kid.
+func (p *provider) sign(t *testing.T, kid string, claims map[string]any) string {
+	t.Helper()
+	alg := map[string]string{"rsa1": "RS256", "ec1": "ES256"}[kid]
+	b64 := base64.RawURLEncoding.EncodeToString
+	h, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	c, _ := json.Marshal(claims)
+	signed := b64(h) + "." + b64(c)
+	digest := sha256.Sum256([]byte(signed))
+	var sig []byte
+	var err error
+	if alg == "RS256" {
+		sig, err = rsa.SignPKCS1v15(rand.Reader, p.rsaKey, crypto.SHA256, digest[:])
+	} else {
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, p.ecKey, digest[:])
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + b64(sig)
+}
+
+func (p *provider) claims(extra map[string]any) map[string]any {
+	c := map[string]any{
+		"iss": p.URL,
+		"aud": "beads",
+		"sub": "u-123",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range extra {
+		c[k] = v
+	}
+	return c
+}
+
+func TestVerify(t *testing.T) {
+	p := newProvider(t)
+	v, err := NewVerifier(Config{Issuer: p.URL, Audience: "beads", RolesClaim: "realm_access.roles"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, kid := range []string{"rsa1", "ec1"} {
+		token := p.sign(t, kid, p.claims(map[string]any{
+			"preferred_username": "alice",
+			"aud":                []string{"other", "beads"},
+			"realm_access":       map[string]any{"roles": []string{"beads-admin", "dev"}},
+		}))
+		c, err := v.Verify(ctx, token)
+		if err != nil {
+			t.Fatalf("%s: %v", kid, err)
+		}
+		if c.Actor != "alice" || c.Subject != "u-123" || !slices.Equal(c.Roles, []string{"beads-admin", "dev"}) {
+			t.Fatalf("%s: claims = %+v", kid, c)
+		}
+	}
+
+	// Without preferred_username the subject is the actor.
+	c, err := v.Verify(ctx, p.sign(t, "rsa1", p.claims(nil)))
+	if err != nil || c.Actor != "u-123" {
+		t.Fatalf("claims = %+v, %v", c, err)
+	}
+	if n := p.fetches.Load(); n != 1 {
+		t.Fatalf("keys fetched %d times, want 1", n)
+	}
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	p := newProvider(t)
+	v, err := NewVerifier(Config{Issuer: p.URL, Audience: "beads"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	good := p.sign(t, "rsa1", p.claims(nil))
+
+	for name, token := range map[string]string{
+		"wrong issuer":   p.sign(t, "rsa1", p.claims(map[string]any{"iss": "https://evil.example"})),
+		"wrong audience": p.sign(t, "rsa1", p.claims(map[string]any{"aud": "other"})),
+		"expired":        p.sign(t, "rsa1", p.claims(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})),
+		"not yet valid":  p.sign(t, "rsa1", p.claims(map[string]any{"nbf": time.Now().Add(time.Hour).Unix()})),
+		"no exp":         p.sign(t, "rsa1", p.claims(map[string]any{"exp": nil})),
+		"tampered":       good[:len(good)-4] + "AAAA",
+		"unknown key":    p.sign(t, "nope", p.claims(nil)),
+		"hmac":           "eyJhbGciOiJIUzI1NiIsImtpZCI6InJzYTEifQ.e30.c2ln",
+		"not a jwt":      "opaque-token",
+	} {
+		if _, err := v.Verify(ctx, token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: err = %v, want ErrInvalidToken", name, err)
+		}
+	}
+}
+
+func TestLooksLikeJWT(t *testing.T) {
+	if !LooksLikeJWT("a.b.c") || LooksLikeJWT("bd_agent_abc") || LooksLikeJWT("a.b") {
+		t.Fatal("LooksLikeJWT misclassified a token")
+	}
+}
@@ -0,0 +1,389 @@
+// Package query parses the bead query language accepted by
+// GET /v1/beads?q=, `bd list -q` and saved views, e.g.
+//
+//	status:open AND (label:urgent OR priority<=1) AND updated>-7d
+//
+// A query is a boolean combination of conditions. Conditions are joined
+// with AND, OR and NOT (AND binds tighter than OR; adjacent conditions
+// are ANDed) and grouped with parentheses. A condition is field, operator
+// and value with no spaces in between; a value containing spaces is
+// double-quoted. A bare word is a text search on title and description.
+//
+// Fields:
+//
+//	status, type, kind, assignee, owner   : = !=   (a,b matches either)
+//	label                                 : = !=   (ns:* matches the namespace)
+//	priority                              : = != < <= > >=
+//	created, updated, closed, due         < <= > >= (date, RFC 3339 time, or -7d/+12h relative to now)
+//	text                                  :
+//	field.<key>                           : = !=   (custom field)
+//
+// The package only parses; the store compiles the tree to SQL.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Node is a node of a parsed query: And, Or, Not or Cond.
+type Node interface {
+	node()
+}
+
+// And matches beads matching both Left and Right.
+type And struct{ Left, Right Node }
+
+// Or matches beads matching either Left or Right.
+type Or struct{ Left, Right Node }
+
+// Not matches beads not matching X.
+type Not struct{ X Node }
+
+// Op is a comparison operator. ":" and "=" both parse as OpEq.
+type Op string
+
+const (
+	OpEq Op = "="
+	OpNe Op = "!="
+	OpLt Op = "<"
+	OpLe Op = "<="
+	OpGt Op = ">"
+	OpGe Op = ">="
+)
+
+// Cond is a single field comparison.
+type Cond struct {
+	Field  string // one of the fields above; custom fields as "field.<key>"
+	Op     Op
+	Values []string  // string and text fields: the alternatives to match
+	Int    int       // priority
+	Time   time.Time // date fields, with relative values resolved
+}
+
+func (And) node()  {}
+func (Or) node()   {}
+func (Not) node()  {}
+func (Cond) node() {}
+
+// IsTime reports whether c compares a date field, whose value is c.Time.
+func (c Cond) IsTime() bool { return fields[c.Field] == kindTime }
+
+// Field kinds, deciding which operators and values a field takes.
+const (
+	kindString = iota
+	kindInt
+	kindTime
+	kindText
+)
+
+var fields = map[string]int{
+	"status":   kindString,
+	"type":     kindString,
+	"kind":     kindString,
+	"assignee": kindString,
+	"owner":    kindString,
+	"label":    kindString,
+	"priority": kindInt,
+	"created":  kindTime,
+	"updated":  kindTime,
+	"closed":   kindTime,
+	"due":      kindTime,
+	"defer":    kindTime,
+	"text":     kindText,
+}
+
+// now is the time relative dates are resolved against; replaced in tests.
+var now = time.Now
+
+// Parse parses a query. An empty query parses to a nil Node.
+func Parse(s string) (Node, error) {
+	toks, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) == 0 {
+		return nil, nil
+	}
+	p := &parser{toks: toks}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, fmt.Errorf("unexpected %q", p.toks[p.pos].text)
+	}
+	return n, nil
+}
+
+// Token kinds.
+const (
+	tokTerm = iota
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+)
+
+type token struct {
+	kind int
+	text string // as written, for errors
+	cond *Cond  // tokTerm
+}
+
+// lex splits s into parentheses, keywords and conditions.
+func lex(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			toks = append(toks, token{kind: tokLParen, text: "("})
+			i++
+		case c == ')':
+			toks = append(toks, token{kind: tokRParen, text: ")"})
+			i++
+		default:
+			start := i
+			field := ""
+			for i < len(s) && isFieldChar(s[i]) {
+				i++
+			}
+			opStart := i
+			for i < len(s) && strings.IndexByte(":=!<>", s[i]) >= 0 {
+				i++
+			}
+			op := s[opStart:i]
+			if op != "" && opStart > start {
+				field = s[start:opStart]
+			} else {
+				// A bare word: a keyword or a text search.
+				i = start
+				op = ":"
+				field = "text"
+			}
+			value, end, err := lexValue(s, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			text := s[start:i]
+			if field == "text" && op == ":" && i > start && s[start] != '"' {
+				switch text {
+				case "AND":
+					toks = append(toks, token{kind: tokAnd, text: text})
+					continue
+				case "OR":
+					toks = append(toks, token{kind: tokOr, text: text})
+					continue
+				case "NOT":
+					toks = append(toks, token{kind: tokNot, text: text})
+					continue
+				}
+			}
+			cond, err := newCond(field, op, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", text, err)
+			}
+			toks = append(toks, token{kind: tokTerm, text: text, cond: cond})
+		}
+	}
+	return toks, nil
+}
+
+func isFieldChar(c byte) bool {
+	return c == '_' || c == '.' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// lexValue reads the value starting at s[i]: a double-quoted string, or
+// everything up to the next space or parenthesis.
+func lexValue(s string, i int) (string, int, error) {
+	if i < len(s) && s[i] == '"' {
+		end := strings.IndexByte(s[i+1:], '"')
+		if end < 0 {
+			return "", 0, fmt.Errorf("unterminated quote in %q", s[i:])
+		}
+		return s[i+1 : i+1+end], i + end + 2, nil
+	}
+	start := i
+	for i < len(s) && strings.IndexByte(" \t\n()", s[i]) < 0 {
+		i++
+	}
+	return s[start:i], i, nil
+}
+
+// newCond checks that field takes op and value and builds its condition.
+func newCond(field, op, value string) (*Cond, error) {
+	kind, ok := fields[field]
+	if key, custom := strings.CutPrefix(field, "field."); custom && key != "" {
+		kind, ok = kindString, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", field)
+	}
+	if value == "" {
+		return nil, fmt.Errorf("missing value")
+	}
+
+	c := &Cond{Field: field, Op: Op(op)}
+	if op == ":" {
+		c.Op = OpEq
+	}
+	switch c.Op {
+	case OpEq, OpNe, OpLt, OpLe, OpGt, OpGe:
+	default:
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+
+	switch kind {
+	case kindString:
+		if c.Op != OpEq && c.Op != OpNe {
+			return nil, fmt.Errorf("%s takes : or !=", field)
+		}
+		c.Values = strings.Split(value, ",")
+	case kindText:
+		if c.Op != OpEq {
+			return nil, fmt.Errorf("text takes :")
+		}
+		c.Values = []string{value}
+	case kindInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number", field)
+		}
+		c.Int = n
+	case kindTime:
+		if c.Op == OpEq || c.Op == OpNe {
+			return nil, fmt.Errorf("%s takes <, <=, > or >=", field)
+		}
+		t, err := ParseTime(value)
+		if err != nil {
+			return nil, err
+		}
+		c.Time = t
+	}
+	return c, nil
+}
+
+// ParseTime parses a date (2006-01-02), an RFC 3339 time, or a time
+// relative to now such as -7d, +12h or -30m. Units are m, h, d and w.
+func ParseTime(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, fmt.Errorf("missing time")
+	}
+	if v[0] == '-' || v[0] == '+' {
+		if len(v) < 3 {
+			return time.Time{}, fmt.Errorf("invalid relative time %q (want e.g. -7d)", v)
+		}
+		units := map[byte]time.Duration{'m': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+		unit, ok := units[v[len(v)-1]]
+		n, err := strconv.Atoi(v[1 : len(v)-1])
+		if !ok || err != nil || n < 0 {
+			return time.Time{}, fmt.Errorf("invalid relative time %q (want e.g. -7d)", v)
+		}
+		d := time.Duration(n) * unit
+		if v[0] == '-' {
+			d = -d
+		}
+		return now().Add(d), nil
+	}
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q (want 2006-01-02, RFC 3339 or -7d)", v)
+	}
+	return t, nil
+}
+
+// parser is a recursive-descent parser over lexed tokens.
+type parser struct {
+	toks []token
+	pos  int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.toks) {
+		return token{}, false
+	}
+	return p.toks[p.pos], true
+}
+
+// or parses and (OR and)*.
+func (p *parser) or() (Node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != tokOr {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = Or{left, right}
+	}
+}
+
+// and parses unary ([AND] unary)*.
+func (p *parser) and() (Node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind == tokOr || t.kind == tokRParen {
+			return left, nil
+		}
+		if t.kind == tokAnd {
+			p.pos++
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = And{left, right}
+	}
+}
+
+// unary parses NOT unary, ( or ), or a condition.
+func (p *parser) unary() (Node, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	p.pos++
+	switch t.kind {
+	case tokNot:
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{x}, nil
+	case tokLParen:
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := p.peek(); !ok || t.kind != tokRParen {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return n, nil
+	case tokTerm:
+		return *t.cond, nil
+	default:
+		return nil, fmt.Errorf("unexpected %q", t.text)
+	}
+}
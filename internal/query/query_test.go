@@ -0,0 +1,88 @@
+package query
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	fixed := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	t.Cleanup(func() { now = time.Now })
+
+	got, err := Parse(`status:open AND (label:urgent OR priority<=1) AND updated>-7d`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := And{
+		And{
+			Cond{Field: "status", Op: OpEq, Values: []string{"open"}},
+			Or{
+				Cond{Field: "label", Op: OpEq, Values: []string{"urgent"}},
+				Cond{Field: "priority", Op: OpLe, Int: 1},
+			},
+		},
+		Cond{Field: "updated", Op: OpGt, Time: fixed.Add(-7 * 24 * time.Hour)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v\nwant %#v", got, want)
+	}
+}
+
+func TestParse_Forms(t *testing.T) {
+	tests := []struct {
+		q    string
+		want Node
+	}{
+		{"", nil},
+		{"assignee!=alice,bob", Cond{Field: "assignee", Op: OpNe, Values: []string{"alice", "bob"}}},
+		{"label:team:*", Cond{Field: "label", Op: OpEq, Values: []string{"team:*"}}},
+		{`title bug`, And{Cond{Field: "text", Op: OpEq, Values: []string{"title"}}, Cond{Field: "text", Op: OpEq, Values: []string{"bug"}}}},
+		{`"login page"`, Cond{Field: "text", Op: OpEq, Values: []string{"login page"}}},
+		{`field.team:"core infra"`, Cond{Field: "field.team", Op: OpEq, Values: []string{"core infra"}}},
+		{"NOT status:closed", Not{Cond{Field: "status", Op: OpEq, Values: []string{"closed"}}}},
+		{"due<2026-04-01", Cond{Field: "due", Op: OpLt, Time: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)}},
+		{"created>0001-01-01", Cond{Field: "created", Op: OpGt, Time: time.Time{}}},
+		{"defer>=2026-04-01", Cond{Field: "defer", Op: OpGe, Time: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)}},
+		{"type:bug OR type:task status:open", Or{
+			Cond{Field: "type", Op: OpEq, Values: []string{"bug"}},
+			And{Cond{Field: "type", Op: OpEq, Values: []string{"task"}}, Cond{Field: "status", Op: OpEq, Values: []string{"open"}}},
+		}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.q)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.q, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %#v, want %#v", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		"colour:red":         "unknown field",
+		"status<open":        "takes : or !=",
+		"priority:high":      "must be a number",
+		"updated:-7d":        "takes <",
+		"updated>-7x":        "invalid relative time",
+		"created>-":          "invalid relative time",
+		"created>yesterday":  "invalid time",
+		"status:":            "missing value",
+		"(status:open":       "missing )",
+		"status:open)":       `unexpected ")"`,
+		"status:open AND":    "unexpected end",
+		`text:"unterminated`: "unterminated quote",
+		"priority=>1":        "unknown operator",
+	}
+	for q, want := range tests {
+		_, err := Parse(q)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) error = %v, want %q", q, err, want)
+		}
+	}
+}
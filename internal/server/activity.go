@@ -0,0 +1,308 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Activity entry kinds.
+const (
+	activityBead       = "bead"
+	activityComment    = "comment"
+	activityLabel      = "label"
+	activityDependency = "dependency"
+	activityNote       = "note"
+	activityChecklist  = "checklist"
+	activityDecision   = "decision"
+	activityJack       = "jack"
+	activityRule       = "rule"
+	activityOther      = "other"
+)
+
+// commentExcerptLen caps the comment text quoted in an activity summary, in
+// runes.
+const commentExcerptLen = 80
+
+// activityEntry is one line of a bead's activity feed.
+type activityEntry struct {
+	Kind      string    `json:"kind"`
+	Topic     string    `json:"topic,omitempty"` // empty for comments
+	Actor     string    `json:"actor,omitempty"`
+	Summary   string    `json:"summary"`
+	CreatedAt time.Time `json:"created_at"`
+	EventID   int64     `json:"event_id,omitempty"`
+	CommentID int64     `json:"comment_id,omitempty"`
+}
+
+// beadActivity returns a bead's events and comments as one feed, oldest
+// first. A comment.added event is folded into its comment. With a positive
+// limit only the latest limit entries are kept. Returns sql.ErrNoRows if the
+// bead does not exist.
+func (s *BeadsServer) beadActivity(ctx context.Context, id string, limit int) ([]activityEntry, error) {
+	b, err := s.store.GetBead(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, sql.ErrNoRows
+	}
+	comments, err := s.store.GetComments(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("loading comments: %w", err)
+	}
+	evts, err := s.store.GetEvents(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("loading events: %w", err)
+	}
+
+	feed := make([]activityEntry, 0, len(comments)+len(evts))
+	seen := make(map[int64]bool, len(comments))
+	for _, c := range comments {
+		seen[c.ID] = true
+		feed = append(feed, activityEntry{
+			Kind:      activityComment,
+			Actor:     c.Author,
+			Summary:   "commented: " + excerpt(c.Text, commentExcerptLen),
+			CreatedAt: c.CreatedAt,
+			CommentID: c.ID,
+		})
+	}
+	for _, e := range evts {
+		if e.Topic == events.TopicCommentAdded {
+			if ev, err := events.Decode(e.Topic, e.Payload); err == nil {
+				if c := ev.(events.CommentAdded).Comment; c != nil && seen[c.ID] {
+					continue
+				}
+			}
+		}
+		kind, summary := describeEvent(e)
+		feed = append(feed, activityEntry{
+			Kind:      kind,
+			Topic:     e.Topic,
+			Actor:     e.Actor,
+			Summary:   summary,
+			CreatedAt: e.CreatedAt,
+			EventID:   e.ID,
+		})
+	}
+
+	sort.SliceStable(feed, func(i, j int) bool { return feed[i].CreatedAt.Before(feed[j].CreatedAt) })
+	if limit > 0 && len(feed) > limit {
+		feed = feed[len(feed)-limit:]
+	}
+	return feed, nil
+}
+
+// describeEvent returns the activity kind of a recorded event and a short
+// human-readable summary of it.
+func describeEvent(e *model.Event) (string, string) {
+	ev, err := events.Decode(e.Topic, e.Payload)
+	if err != nil {
+		return activityOther, e.Topic
+	}
+	switch ev := ev.(type) {
+	case events.BeadCreated:
+		if ev.Bead != nil {
+			return activityBead, fmt.Sprintf("created %s %q", ev.Bead.Type, ev.Bead.Title)
+		}
+		return activityBead, "created"
+	case events.BeadUpdated:
+		return activityBead, describeChanges(ev.Changes)
+	case events.BeadClosed:
+		return activityBead, "closed"
+	case events.BeadDeleted:
+		if ev.Soft {
+			return activityBead, "moved to the trash"
+		}
+		return activityBead, "deleted"
+	case events.BeadRestored:
+		return activityBead, "restored from the trash"
+	case events.BeadArchived:
+		return activityBead, "archived"
+	case events.BeadReopened:
+		return activityBead, "reopened"
+	case events.BeadDeferred:
+		if ev.Bead != nil && ev.Bead.DeferUntil != nil {
+			return activityBead, "deferred until " + ev.Bead.DeferUntil.Format(time.DateOnly)
+		}
+		return activityBead, "deferred"
+	case events.BeadMerged:
+		return activityBead, "merged " + ev.SourceID + " into this bead"
+	case events.LabelAdded:
+		return activityLabel, "added label " + ev.Label
+	case events.LabelRemoved:
+		return activityLabel, "removed label " + ev.Label
+	case events.DependencyAdded:
+		if d := ev.Dependency; d != nil {
+			return activityDependency, fmt.Sprintf("added %s dependency on %s", d.Type, d.DependsOnID)
+		}
+	case events.DependencyUpdated:
+		if d := ev.Dependency; d != nil {
+			return activityDependency, fmt.Sprintf("updated %s dependency on %s", d.Type, d.DependsOnID)
+		}
+	case events.DependencyRemoved:
+		return activityDependency, fmt.Sprintf("removed %s dependency on %s", ev.Type, ev.DependsOnID)
+	case events.CommentAdded:
+		if ev.Comment != nil {
+			return activityComment, "commented: " + excerpt(ev.Comment.Text, commentExcerptLen)
+		}
+	case events.NoteAppended:
+		return activityNote, "appended a note"
+	case events.ChecklistAdded:
+		if len(ev.Items) == 1 {
+			return activityChecklist, "added checklist item: " + excerpt(ev.Items[0].Text, commentExcerptLen)
+		}
+		return activityChecklist, fmt.Sprintf("added %d checklist items", len(ev.Items))
+	case events.ChecklistChecked:
+		if it := ev.Item; it != nil {
+			verb := "checked"
+			if !it.Checked {
+				verb = "unchecked"
+			}
+			return activityChecklist, fmt.Sprintf("%s item %d: %s", verb, it.Index, excerpt(it.Text, commentExcerptLen))
+		}
+	case events.DecisionResolved:
+		return activityDecision, "resolved: " + ev.Chosen
+	case events.DecisionExpired:
+		if ev.Cancelled {
+			return activityDecision, "expired without a default; cancelled"
+		}
+		return activityDecision, "expired; resolved to the default " + ev.Chosen
+	case events.JackRaised:
+		return activityJack, "raised"
+	case events.JackExtended:
+		summary := fmt.Sprintf("extended until %s (%d/%d)", ev.ExpiresAt.Format(time.RFC3339), ev.Extensions, jackMaxExtensions)
+		if ev.Reason != "" {
+			summary += ": " + ev.Reason
+		}
+		return activityJack, summary
+	case events.JackChanged:
+		if ev.Note != nil {
+			return activityJack, "changed: " + excerpt(ev.Note.Text, commentExcerptLen)
+		}
+	case events.JackDown:
+		if ev.Reason != "" {
+			return activityJack, "taken down: " + ev.Reason
+		}
+		return activityJack, "taken down"
+	case events.RuleFired:
+		summary := "rule " + ev.Rule + " fired"
+		if len(ev.Actions) > 0 {
+			summary += ": " + strings.Join(ev.Actions, ", ")
+		}
+		return activityRule, summary
+	}
+	return activityOther, e.Topic
+}
+
+// describeChanges summarizes a bead update's changed fields, in name order.
+// Long or structured values are named without their value.
+func describeChanges(changes map[string]any) string {
+	if len(changes) == 0 {
+		return "updated"
+	}
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		switch v := changes[name].(type) {
+		case string:
+			if v == "" {
+				parts[i] = "cleared " + name
+			} else if len(v) <= 40 && !strings.Contains(v, "\n") {
+				parts[i] = fmt.Sprintf("set %s to %s", name, v)
+			} else {
+				parts[i] = "edited " + name
+			}
+		case float64, bool:
+			parts[i] = fmt.Sprintf("set %s to %v", name, v)
+		case nil:
+			parts[i] = "cleared " + name
+		default:
+			parts[i] = "edited " + name
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// excerpt returns the first line of s, truncated to n runes.
+func excerpt(s string, n int) string {
+	s, _, cut := strings.Cut(strings.TrimSpace(s), "\n")
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	if cut {
+		return s + " …"
+	}
+	return s
+}
+
+// handleGetActivity handles GET /v1/beads/{id}/activity?limit=N.
+func (s *BeadsServer) handleGetActivity(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = n
+	}
+
+	feed, err := s.beadActivity(r.Context(), r.PathValue("id"), limit)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "bead not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"activity": feed})
+}
+
+// GetActivity returns a bead's activity feed, oldest first.
+func (s *BeadsServer) GetActivity(ctx context.Context, req *beadsv1.GetActivityRequest) (*beadsv1.GetActivityResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+	feed, err := s.beadActivity(ctx, req.GetBeadId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, storeError(err, "bead")
+	}
+
+	entries := make([]*beadsv1.ActivityEntry, len(feed))
+	for i, a := range feed {
+		entries[i] = &beadsv1.ActivityEntry{
+			Kind:      a.Kind,
+			Topic:     a.Topic,
+			Actor:     a.Actor,
+			Summary:   a.Summary,
+			CreatedAt: timestamppb.New(a.CreatedAt),
+			EventId:   a.EventID,
+			CommentId: a.CommentID,
+		}
+	}
+	return &beadsv1.GetActivityResponse{Activity: entries}, nil
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+// seedActivity gives bd-act a creation, an update, a label, a dependency and
+// a comment (with its comment.added event), a minute apart.
+func seedActivity(t *testing.T, ms *mockStore) {
+	t.Helper()
+	t0 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	ms.beads["bd-act"] = &model.Bead{ID: "bd-act", Title: "Login fails", Type: "bug", Status: model.StatusOpen}
+	comment := &model.Comment{ID: 1, BeadID: "bd-act", Author: "bob", Text: "Repro on staging\nstack trace attached", CreatedAt: t0.Add(3 * time.Minute)}
+	ms.comments["bd-act"] = []*model.Comment{comment}
+
+	add := func(topic, actor string, at time.Duration, payload any) {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ms.events = append(ms.events, &model.Event{
+			ID: int64(len(ms.events) + 1), Topic: topic, BeadID: "bd-act", Actor: actor, Payload: raw, CreatedAt: t0.Add(at),
+		})
+	}
+	add(events.TopicBeadCreated, "alice", 0, events.BeadCreated{Bead: ms.beads["bd-act"]})
+	add(events.TopicBeadUpdated, "alice", time.Minute, events.BeadUpdated{Changes: map[string]any{"status": "in_progress", "priority": 1, "description": strings.Repeat("x", 100)}})
+	add(events.TopicLabelAdded, "alice", 2*time.Minute, events.LabelAdded{BeadID: "bd-act", Label: "auth"})
+	add(events.TopicCommentAdded, "bob", 3*time.Minute, events.CommentAdded{Comment: comment})
+	add(events.TopicDependencyAdded, "carol", 4*time.Minute, events.DependencyAdded{Dependency: &model.Dependency{BeadID: "bd-act", DependsOnID: "bd-sso", Type: model.DepBlocks}})
+}
+
+func TestHandleGetActivity(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedActivity(t, ms)
+
+	rec := doJSON(t, h, "GET", "/v1/beads/bd-act/activity", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var body struct {
+		Activity []activityEntry `json:"activity"`
+	}
+	decodeJSON(t, rec, &body)
+
+	want := []struct{ kind, actor, summary string }{
+		{activityBead, "alice", `created bug "Login fails"`},
+		{activityBead, "alice", "edited description, set priority to 1, set status to in_progress"},
+		{activityLabel, "alice", "added label auth"},
+		{activityComment, "bob", "commented: Repro on staging …"},
+		{activityDependency, "carol", "added blocks dependency on bd-sso"},
+	}
+	if len(body.Activity) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(body.Activity), len(want), body.Activity)
+	}
+	for i, w := range want {
+		a := body.Activity[i]
+		if a.Kind != w.kind || a.Actor != w.actor || a.Summary != w.summary {
+			t.Errorf("entry %d = %s/%s %q, want %s/%s %q", i, a.Kind, a.Actor, a.Summary, w.kind, w.actor, w.summary)
+		}
+	}
+	if c := body.Activity[3]; c.CommentID != 1 || c.Topic != "" {
+		t.Errorf("comment entry = %+v", c)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/beads/bd-act/activity?limit=2", nil)
+	requireStatus(t, rec, http.StatusOK)
+	body.Activity = nil
+	decodeJSON(t, rec, &body)
+	if len(body.Activity) != 2 || body.Activity[1].Kind != activityDependency {
+		t.Errorf("limit=2: %+v", body.Activity)
+	}
+
+	requireStatus(t, doJSON(t, h, "GET", "/v1/beads/bd-act/activity?limit=0", nil), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "GET", "/v1/beads/bd-missing/activity", nil), http.StatusNotFound)
+}
+
+func TestGRPCGetActivity(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	seedActivity(t, ms)
+
+	resp, err := srv.GetActivity(ctx, &beadsv1.GetActivityRequest{BeadId: "bd-act", Limit: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.GetActivity(); len(got) != 3 || got[0].GetSummary() != "added label auth" || got[0].GetEventId() != 3 {
+		t.Fatalf("activity = %+v", got)
+	}
+
+	_, err = srv.GetActivity(ctx, &beadsv1.GetActivityRequest{BeadId: "bd-missing"})
+	requireCode(t, err, codes.NotFound)
+}
@@ -0,0 +1,303 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// canonicalActor returns the ID of the registered actor whose ID or alias
+// is name, or name itself if none is. Server actors ("beads:...") are
+// never registered and are returned as is.
+func (s *BeadsServer) canonicalActor(ctx context.Context, name string) string {
+	if name == "" || strings.HasPrefix(name, "beads:") {
+		return name
+	}
+	id, err := s.store.ResolveActor(ctx, name)
+	if err != nil {
+		return name
+	}
+	return id
+}
+
+// checkActorNameFree returns a conflictError if name is already the ID or
+// an alias of an actor.
+func checkActorNameFree(ctx context.Context, tx store.Store, name string) error {
+	id, err := tx.ResolveActor(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return conflictError(fmt.Sprintf("%s already names actor %s", name, id))
+}
+
+// actorAliases validates and lowercases aliases for the actor id, dropping
+// duplicates and any alias that only repeats the ID.
+func actorAliases(id string, aliases []string) ([]string, error) {
+	out := []string{}
+	for _, a := range aliases {
+		a = model.NormalizeAlias(a)
+		if err := model.ValidateActorName(a); err != nil {
+			return nil, inputError(err.Error())
+		}
+		if a == strings.ToLower(id) || slices.Contains(out, a) {
+			continue
+		}
+		out = append(out, a)
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+// createActorInput is the JSON body for POST /v1/actors.
+type createActorInput struct {
+	ID          string   `json:"id"`
+	DisplayName string   `json:"display_name"`
+	Type        string   `json:"type"` // human (default) or agent
+	Aliases     []string `json:"aliases"`
+	CreatedBy   string   `json:"created_by"`
+}
+
+// createActor registers an actor. Returns inputError for an invalid name
+// or type and conflictError if its ID or an alias already names an actor.
+func (s *BeadsServer) createActor(ctx context.Context, in createActorInput) (*model.Actor, error) {
+	a := &model.Actor{
+		ID:          strings.TrimSpace(in.ID),
+		DisplayName: strings.TrimSpace(in.DisplayName),
+		Type:        model.ActorType(in.Type),
+		CreatedBy:   s.actorFor(ctx, in.CreatedBy),
+	}
+	if a.Type == "" {
+		a.Type = model.ActorHuman
+	}
+	if err := model.ValidateActorName(a.ID); err != nil {
+		return nil, inputError(err.Error())
+	}
+	if err := model.ValidateActorType(a.Type); err != nil {
+		return nil, inputError(err.Error())
+	}
+	aliases, err := actorAliases(a.ID, in.Aliases)
+	if err != nil {
+		return nil, err
+	}
+	a.Aliases = aliases
+
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		for _, name := range append([]string{a.ID}, a.Aliases...) {
+			if err := checkActorNameFree(ctx, tx, name); err != nil {
+				return err
+			}
+		}
+		return tx.CreateActor(ctx, a)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// getActor returns the actor whose ID or alias is ref, or sql.ErrNoRows.
+func (s *BeadsServer) getActor(ctx context.Context, ref string) (*model.Actor, error) {
+	id, err := s.store.ResolveActor(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.GetActor(ctx, id)
+}
+
+// updateActorInput is the JSON body for PATCH /v1/actors/{id}.
+type updateActorInput struct {
+	DisplayName *string `json:"display_name"`
+	Type        *string `json:"type"`
+}
+
+// updateActor changes an actor's display name or type.
+func (s *BeadsServer) updateActor(ctx context.Context, ref string, in updateActorInput) (*model.Actor, error) {
+	var a *model.Actor
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		id, err := tx.ResolveActor(ctx, ref)
+		if err != nil {
+			return err
+		}
+		if a, err = tx.GetActor(ctx, id); err != nil {
+			return err
+		}
+		if in.DisplayName != nil {
+			a.DisplayName = strings.TrimSpace(*in.DisplayName)
+		}
+		if in.Type != nil {
+			a.Type = model.ActorType(*in.Type)
+			if err := model.ValidateActorType(a.Type); err != nil {
+				return inputError(err.Error())
+			}
+		}
+		return tx.UpdateActor(ctx, a)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// deleteActor removes an actor and its aliases. History recorded under
+// its ID is unchanged.
+func (s *BeadsServer) deleteActor(ctx context.Context, ref string) error {
+	return s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		id, err := tx.ResolveActor(ctx, ref)
+		if err != nil {
+			return err
+		}
+		return tx.DeleteActor(ctx, id)
+	})
+}
+
+// addActorAlias gives an actor another alias and returns the actor.
+func (s *BeadsServer) addActorAlias(ctx context.Context, ref, alias string) (*model.Actor, error) {
+	var a *model.Actor
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		id, err := tx.ResolveActor(ctx, ref)
+		if err != nil {
+			return err
+		}
+		aliases, err := actorAliases(id, []string{alias})
+		if err != nil {
+			return err
+		}
+		for _, alias := range aliases {
+			if err := checkActorNameFree(ctx, tx, alias); err != nil {
+				return err
+			}
+			if err := tx.AddActorAlias(ctx, id, alias); err != nil {
+				return err
+			}
+		}
+		a, err = tx.GetActor(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// removeActorAlias removes one of an actor's aliases and returns the actor.
+func (s *BeadsServer) removeActorAlias(ctx context.Context, ref, alias string) (*model.Actor, error) {
+	var a *model.Actor
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		id, err := tx.ResolveActor(ctx, ref)
+		if err != nil {
+			return err
+		}
+		if err := tx.RemoveActorAlias(ctx, id, model.NormalizeAlias(alias)); err != nil {
+			return err
+		}
+		a, err = tx.GetActor(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// writeActorResult writes actor with status code, or the error from
+// reading or changing it.
+func writeActorResult(w http.ResponseWriter, code int, actor *model.Actor, err error) {
+	var (
+		ie inputError
+		ce conflictError
+	)
+	switch {
+	case errors.As(err, &ie):
+		writeError(w, http.StatusBadRequest, ie.Error())
+	case errors.As(err, &ce):
+		writeError(w, http.StatusConflict, ce.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "actor not found")
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "failed to update actor")
+	case actor == nil:
+		w.WriteHeader(code)
+	default:
+		writeJSON(w, code, actor)
+	}
+}
+
+// handleListActors handles GET /v1/actors.
+func (s *BeadsServer) handleListActors(w http.ResponseWriter, r *http.Request) {
+	actors, err := s.store.ListActors(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list actors")
+		return
+	}
+	if actors == nil {
+		actors = []*model.Actor{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"actors": actors})
+}
+
+// handleCreateActor handles POST /v1/actors.
+func (s *BeadsServer) handleCreateActor(w http.ResponseWriter, r *http.Request) {
+	var in createActorInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	a, err := s.createActor(r.Context(), in)
+	writeActorResult(w, http.StatusCreated, a, err)
+}
+
+// handleGetActor handles GET /v1/actors/{id}, where id may be an alias.
+func (s *BeadsServer) handleGetActor(w http.ResponseWriter, r *http.Request) {
+	a, err := s.getActor(r.Context(), r.PathValue("id"))
+	writeActorResult(w, http.StatusOK, a, err)
+}
+
+// handleUpdateActor handles PATCH /v1/actors/{id}.
+func (s *BeadsServer) handleUpdateActor(w http.ResponseWriter, r *http.Request) {
+	var in updateActorInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	a, err := s.updateActor(r.Context(), r.PathValue("id"), in)
+	writeActorResult(w, http.StatusOK, a, err)
+}
+
+// handleDeleteActor handles DELETE /v1/actors/{id}.
+func (s *BeadsServer) handleDeleteActor(w http.ResponseWriter, r *http.Request) {
+	err := s.deleteActor(r.Context(), r.PathValue("id"))
+	writeActorResult(w, http.StatusNoContent, nil, err)
+}
+
+// actorAliasRequest is the JSON body for POST /v1/actors/{id}/aliases.
+type actorAliasRequest struct {
+	Alias string `json:"alias"`
+}
+
+// handleAddActorAlias handles POST /v1/actors/{id}/aliases.
+func (s *BeadsServer) handleAddActorAlias(w http.ResponseWriter, r *http.Request) {
+	var req actorAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	a, err := s.addActorAlias(r.Context(), r.PathValue("id"), req.Alias)
+	writeActorResult(w, http.StatusOK, a, err)
+}
+
+// handleRemoveActorAlias handles DELETE /v1/actors/{id}/aliases/{alias}.
+func (s *BeadsServer) handleRemoveActorAlias(w http.ResponseWriter, r *http.Request) {
+	a, err := s.removeActorAlias(r.Context(), r.PathValue("id"), r.PathValue("alias"))
+	writeActorResult(w, http.StatusOK, a, err)
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestHandleActors(t *testing.T) {
+	_, ms, h := newTestServer()
+
+	rec := doJSON(t, h, "POST", "/v1/actors", map[string]any{
+		"id": "alice", "display_name": "Alice Liddell", "aliases": []string{"Alice@Corp", "alice"},
+	})
+	requireStatus(t, rec, 201)
+	var a model.Actor
+	decodeJSON(t, rec, &a)
+	if a.Type != model.ActorHuman || len(a.Aliases) != 1 || a.Aliases[0] != "alice@corp" {
+		t.Fatalf("actor = %+v", a)
+	}
+
+	// Neither the ID, in any case, nor an alias may be registered twice.
+	requireStatus(t, doJSON(t, h, "POST", "/v1/actors", map[string]any{"id": "ALICE"}), 409)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/actors", map[string]any{"id": "al", "aliases": []string{"alice@corp"}}), 409)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/actors", map[string]any{"id": "bob", "type": "robot"}), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/actors", map[string]any{"id": "-bob"}), 400)
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/actors/alice/aliases", map[string]any{"alias": "A.Liddell"}), 200)
+	rec = doJSON(t, h, "GET", "/v1/actors/alice@corp", nil)
+	requireStatus(t, rec, 200)
+	a = model.Actor{}
+	decodeJSON(t, rec, &a)
+	if a.ID != "alice" || len(a.Aliases) != 2 {
+		t.Fatalf("actor by alias = %+v", a)
+	}
+
+	rec = doJSON(t, h, "PATCH", "/v1/actors/alice", map[string]any{"display_name": "Alice L."})
+	requireStatus(t, rec, 200)
+	if ms.actors["alice"].DisplayName != "Alice L." {
+		t.Fatalf("display name = %q", ms.actors["alice"].DisplayName)
+	}
+
+	// Writes naming the actor by an alias are recorded under its ID.
+	ms.beads["bd-act1"] = &model.Bead{ID: "bd-act1", Title: "Attributed", Status: model.StatusOpen}
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-act1/comments", map[string]any{"author": "Alice@Corp", "text": "mine"}), 201)
+	if got := ms.comments["bd-act1"][0].Author; got != "alice" {
+		t.Fatalf("author = %q, want alice", got)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/actors", nil)
+	var list struct {
+		Actors []model.Actor `json:"actors"`
+	}
+	decodeJSON(t, rec, &list)
+	if len(list.Actors) != 1 {
+		t.Fatalf("actors = %+v", list.Actors)
+	}
+
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/actors/alice/aliases/nope", nil), 404)
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/actors/alice/aliases/a.liddell", nil), 200)
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/actors/alice", nil), 204)
+	requireStatus(t, doJSON(t, h, "GET", "/v1/actors/alice", nil), 404)
+}
@@ -0,0 +1,225 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adviceExpiryActor is recorded as closed_by on advice closed by the expiry
+// worker.
+const adviceExpiryActor = "beads:expiry"
+
+// adviceFields is the subset of an advice bead's fields used for expiry.
+type adviceFields struct {
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// adviceExpired reports whether an advice bead's expires_at is at or before
+// now. Advice without a valid expires_at never expires.
+func adviceExpired(b *model.Bead, now time.Time) bool {
+	var af adviceFields
+	if len(b.Fields) == 0 || json.Unmarshal(b.Fields, &af) != nil || af.ExpiresAt == "" {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, af.ExpiresAt)
+	return err == nil && !expiresAt.After(now)
+}
+
+// openAdvice returns every open advice bead.
+func (s *BeadsServer) openAdvice(ctx context.Context) ([]*model.Bead, error) {
+	beads, _, err := s.store.ListBeads(ctx, model.BeadFilter{
+		Type:   []model.BeadType{"advice"},
+		Status: []model.Status{model.StatusOpen, model.StatusInProgress},
+	})
+	return beads, err
+}
+
+// listAdvice returns the open advice for actor: unless all is set, advice
+// the actor has acknowledged and advice past its expires_at are left out.
+func (s *BeadsServer) listAdvice(ctx context.Context, actor string, all bool) ([]*model.Bead, error) {
+	actor = s.actorFor(ctx, actor)
+	if actor == "" && !all {
+		return nil, inputError("actor is required")
+	}
+	beads, err := s.openAdvice(ctx)
+	if err != nil || all {
+		return beads, err
+	}
+
+	acked, err := s.store.ListAdviceAcks(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(acked))
+	for _, id := range acked {
+		seen[id] = true
+	}
+	now := time.Now().UTC()
+	var out []*model.Bead
+	for _, b := range beads {
+		if !seen[b.ID] && !adviceExpired(b, now) {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+// ackAdvice records that actor has read an advice bead, hiding it from their
+// advice listing. Returns sql.ErrNoRows if the bead does not exist and
+// inputError if it is not advice.
+func (s *BeadsServer) ackAdvice(ctx context.Context, id, actor string) error {
+	actor = s.actorFor(ctx, actor)
+	if actor == "" {
+		return inputError("actor is required")
+	}
+	b, err := s.store.GetBead(ctx, id)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return sql.ErrNoRows
+	}
+	if b.Type != "advice" {
+		return inputError("bead " + id + " is not advice")
+	}
+	return s.store.AckAdvice(ctx, id, actor)
+}
+
+// RunAdviceExpiry closes expired advice every interval until ctx is
+// cancelled.
+func (s *BeadsServer) RunAdviceExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.ExpireAdvice(ctx, time.Now().UTC()); err != nil {
+				slog.Error("advice expiry failed", "err", err)
+			} else if n > 0 {
+				slog.Info("expired advice", "count", n)
+			}
+		}
+	}
+}
+
+// ExpireAdvice closes every open advice bead whose expires_at is at or
+// before now. Returns the number of beads closed.
+func (s *BeadsServer) ExpireAdvice(ctx context.Context, now time.Time) (int, error) {
+	beads, err := s.openAdvice(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("listing advice: %w", err)
+	}
+	expired := 0
+	for _, b := range beads {
+		if !adviceExpired(b, now) {
+			continue
+		}
+		if _, err := s.closeBead(ctx, b.ID, adviceExpiryActor); err != nil {
+			slog.Warn("failed to expire advice", "bead_id", b.ID, "err", err)
+			continue
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// handleListAdvice handles GET /v1/advice?actor=&all=.
+func (s *BeadsServer) handleListAdvice(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	advice, err := s.listAdvice(r.Context(), q.Get("actor"), q.Get("all") == "true")
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			writeError(w, http.StatusBadRequest, ie.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to list advice")
+		return
+	}
+	if advice == nil {
+		advice = []*model.Bead{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"advice": advice})
+}
+
+// ackAdviceRequest is the optional JSON body for POST /v1/advice/{id}/ack.
+type ackAdviceRequest struct {
+	Actor string `json:"actor"`
+}
+
+// handleAckAdvice handles POST /v1/advice/{id}/ack.
+func (s *BeadsServer) handleAckAdvice(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req ackAdviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	if err := s.ackAdvice(r.Context(), id, req.Actor); err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "bead not found")
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to acknowledge advice")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAdvice lists the open advice for an actor.
+func (s *BeadsServer) ListAdvice(ctx context.Context, req *beadsv1.ListAdviceRequest) (*beadsv1.ListAdviceResponse, error) {
+	advice, err := s.listAdvice(ctx, req.GetActor(), req.GetAll())
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list advice: %v", err)
+	}
+
+	pbAdvice := make([]*beadsv1.Bead, len(advice))
+	for i, b := range advice {
+		pbAdvice[i] = beadToProto(b)
+	}
+	return &beadsv1.ListAdviceResponse{Advice: pbAdvice}, nil
+}
+
+// AckAdvice records that an actor has read an advice bead.
+func (s *BeadsServer) AckAdvice(ctx context.Context, req *beadsv1.AckAdviceRequest) (*beadsv1.AckAdviceResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	if err := s.ackAdvice(ctx, req.GetBeadId(), req.GetActor()); err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		return nil, storeError(err, "bead")
+	}
+	return &beadsv1.AckAdviceResponse{}, nil
+}
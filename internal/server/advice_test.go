@@ -0,0 +1,91 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestListAdvice_HidesAckedAndExpired(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-a1"] = &model.Bead{ID: "bd-a1", Type: "advice", Status: model.StatusOpen}
+	ms.beads["bd-a2"] = &model.Bead{ID: "bd-a2", Type: "advice", Status: model.StatusOpen,
+		Fields: []byte(`{"expires_at":"2000-01-01T00:00:00Z"}`)}
+	ms.beads["bd-a3"] = &model.Bead{ID: "bd-a3", Type: "advice", Status: model.StatusOpen,
+		Fields: []byte(`{"expires_at":"2999-01-01T00:00:00Z"}`)}
+	ms.beads["bd-t1"] = &model.Bead{ID: "bd-t1", Type: "task", Status: model.StatusOpen}
+
+	if _, err := srv.AckAdvice(ctx, &beadsv1.AckAdviceRequest{BeadId: "bd-a1", Actor: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := srv.ListAdvice(ctx, &beadsv1.ListAdviceRequest{Actor: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Advice) != 1 || resp.Advice[0].Id != "bd-a3" {
+		t.Fatalf("alice's advice = %v, want only bd-a3", resp.Advice)
+	}
+	resp, err = srv.ListAdvice(ctx, &beadsv1.ListAdviceRequest{Actor: "bob"})
+	if err != nil || len(resp.Advice) != 2 {
+		t.Fatalf("bob's advice = %v (err %v), want bd-a1 and bd-a3", resp.Advice, err)
+	}
+	resp, err = srv.ListAdvice(ctx, &beadsv1.ListAdviceRequest{Actor: "alice", All: true})
+	if err != nil || len(resp.Advice) != 3 {
+		t.Fatalf("all advice = %v (err %v), want 3", resp.Advice, err)
+	}
+
+	_, err = srv.AckAdvice(ctx, &beadsv1.AckAdviceRequest{BeadId: "bd-t1", Actor: "alice"})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = srv.AckAdvice(ctx, &beadsv1.AckAdviceRequest{BeadId: "bd-none", Actor: "alice"})
+	requireCode(t, err, codes.NotFound)
+}
+
+func TestHandleAckAdvice(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-a1"] = &model.Bead{ID: "bd-a1", Type: "advice", Status: model.StatusOpen}
+
+	rec := doJSON(t, h, "POST", "/v1/advice/bd-a1/ack", map[string]any{"actor": "alice"})
+	requireStatus(t, rec, http.StatusNoContent)
+	if got := ms.adviceAcks["alice"]; len(got) != 1 || got[0] != "bd-a1" {
+		t.Fatalf("acks = %v", got)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/advice?actor=alice", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var body struct {
+		Advice []*model.Bead `json:"advice"`
+	}
+	decodeJSON(t, rec, &body)
+	if body.Advice == nil || len(body.Advice) != 0 {
+		t.Errorf("advice = %v, want an empty list", body.Advice)
+	}
+
+	requireStatus(t, doJSON(t, h, "GET", "/v1/advice", nil), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/advice/bd-none/ack", map[string]any{"actor": "alice"}), http.StatusNotFound)
+}
+
+func TestExpireAdvice(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	ms.beads["bd-old"] = &model.Bead{ID: "bd-old", Type: "advice", Status: model.StatusOpen,
+		Fields: []byte(`{"expires_at":"2026-01-02T12:00:00Z"}`)}
+	ms.beads["bd-new"] = &model.Bead{ID: "bd-new", Type: "advice", Status: model.StatusOpen,
+		Fields: []byte(`{"expires_at":"2026-01-03T00:00:00Z"}`)}
+	ms.beads["bd-forever"] = &model.Bead{ID: "bd-forever", Type: "advice", Status: model.StatusOpen}
+
+	n, err := srv.ExpireAdvice(ctx, now)
+	if err != nil || n != 1 {
+		t.Fatalf("expired %d (err %v), want 1", n, err)
+	}
+	if b := ms.beads["bd-old"]; b.Status != model.StatusClosed || b.ClosedBy != adviceExpiryActor {
+		t.Errorf("bd-old: status=%q closed_by=%q", b.Status, b.ClosedBy)
+	}
+	if ms.beads["bd-new"].Status != model.StatusOpen || ms.beads["bd-forever"].Status != model.StatusOpen {
+		t.Error("unexpired advice was closed")
+	}
+	requireEvent(t, ms, 1, "beads.bead.closed")
+}
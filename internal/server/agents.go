@@ -0,0 +1,453 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/idgen"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// agentNamePattern restricts agent names to path-like identifiers such as
+// "crew/test-agent", which are safe to embed in shell exports.
+var agentNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._/-]{0,99}$`)
+
+// agentTokenPrefix marks bearer tokens issued to agents.
+const agentTokenPrefix = "bd_"
+
+// newAgentToken returns a random bearer token and its hash.
+func newAgentToken() (token, hash string, err error) {
+	return newToken(agentTokenPrefix)
+}
+
+// newToken returns a random token with prefix and its hash.
+func newToken(prefix string) (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("generating token: %w", err)
+	}
+	token = prefix + hex.EncodeToString(buf)
+	return token, hashToken(token), nil
+}
+
+// hashToken returns the hex-encoded SHA-256 of a bearer token.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer" value.
+func bearerToken(header string) string {
+	token, _ := strings.CutPrefix(header, "Bearer ")
+	if token == header {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// grpcBearerToken returns the bearer token from incoming gRPC metadata.
+func grpcBearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, v := range md.Get("authorization") {
+		if token := bearerToken(v); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// tokenIdentity returns the name of the agent a bearer token was issued to,
+// or "" if it belongs to no registered agent.
+func (s *BeadsServer) tokenIdentity(ctx context.Context, token string) string {
+	if !strings.HasPrefix(token, agentTokenPrefix) {
+		return ""
+	}
+	agent, err := s.store.GetAgentByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			slog.Warn("agent token lookup failed", "err", err)
+		}
+		return ""
+	}
+	return agent.Name
+}
+
+// TokenInterceptor attaches the identity of an agent or OIDC bearer token
+// to the context of every unary RPC, unless a client certificate already
+// did. An OIDC token that fails verification is rejected as Unauthenticated.
+func (s *BeadsServer) TokenInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, err := s.authenticate(ctx, grpcBearerToken(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return handler(ctx, req)
+}
+
+// tokenMiddleware attaches the identity of an agent or OIDC bearer token to
+// the request context, unless a client certificate already did. An OIDC
+// token that fails verification is answered with 401.
+func (s *BeadsServer) tokenMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := s.authenticate(r.Context(), bearerToken(r.Header.Get("Authorization")))
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// authorizeRegistration checks token against the admin and bootstrap
+// tokens; OIDC callers with the admin role need neither.
+func (s *BeadsServer) authorizeRegistration(ctx context.Context, token string) error {
+	if s.isOIDCAdmin(ctx) {
+		return nil
+	}
+	if s.adminToken == "" && s.bootstrapToken == "" {
+		return authError("agent registration is disabled; set BEADS_ADMIN_TOKEN or BEADS_BOOTSTRAP_TOKEN")
+	}
+	for _, want := range []string{s.adminToken, s.bootstrapToken} {
+		if want != "" && subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1 {
+			return nil
+		}
+	}
+	return authError("admin or bootstrap token required")
+}
+
+// registerAgentInput is the transport-agnostic input for agent registration.
+type registerAgentInput struct {
+	Name          string   `json:"name"`
+	Subscriptions []string `json:"subscriptions,omitempty"` // event topics the agent follows
+	Gates         []string `json:"gates,omitempty"`         // one blocking gate bead is created per name
+	Labels        []string `json:"labels,omitempty"`
+	CreatedBy     string   `json:"created_by,omitempty"`
+}
+
+// agentRegistration is everything a new agent needs to start work.
+type agentRegistration struct {
+	Agent   *model.Bead       `json:"agent"`
+	Gates   []*model.Bead     `json:"gates"`
+	Token   string            `json:"token"`
+	Env     map[string]string `json:"env"`
+	Exports string            `json:"exports"`
+}
+
+// newTypedBead builds an open bead of a configured type, validating its
+// fields against the type config.
+func (s *BeadsServer) newTypedBead(ctx context.Context, beadType model.BeadType, title, actor string, fields map[string]any) (*model.Bead, error) {
+	tc, err := s.resolveTypeConfig(ctx, beadType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve type config: %w", err)
+	}
+	if tc == nil {
+		return nil, fmt.Errorf("unknown bead type %s", beadType)
+	}
+	id, err := idgen.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ID: %w", err)
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	bead := &model.Bead{
+		ID:        id,
+		Kind:      tc.Kind,
+		Type:      beadType,
+		Title:     title,
+		Status:    model.StatusOpen,
+		Priority:  2,
+		CreatedAt: now,
+		CreatedBy: actor,
+		UpdatedAt: now,
+		Fields:    raw,
+	}
+	if err := model.ValidateBead(bead); err != nil {
+		return nil, inputError("invalid bead: " + err.Error())
+	}
+	if err := model.ValidateFields(bead.Fields, tc.Fields); err != nil {
+		return nil, inputError("invalid fields: " + err.Error())
+	}
+	return bead, nil
+}
+
+// registerAgent provisions a new agent in one transaction: its agent bead,
+// a gate bead blocking it for each requested gate, and a bearer token. token
+// must be the admin or bootstrap token. The agent's token is returned once
+// and only its hash is stored.
+func (s *BeadsServer) registerAgent(ctx context.Context, token string, in registerAgentInput) (*agentRegistration, error) {
+	if err := s.authorizeRegistration(ctx, token); err != nil {
+		return nil, err
+	}
+	if !agentNamePattern.MatchString(in.Name) {
+		return nil, inputError("name must be lowercase letters, digits, '.', '_', '-' or '/' (e.g. crew/test-agent)")
+	}
+	actor := s.actorFor(ctx, in.CreatedBy)
+
+	fields := map[string]any{"name": in.Name}
+	if len(in.Subscriptions) > 0 {
+		fields["subscriptions"] = in.Subscriptions
+	}
+	agentBead, err := s.newTypedBead(ctx, "agent", in.Name, actor, fields)
+	if err != nil {
+		return nil, err
+	}
+	agentBead.Assignee = in.Name
+	agentBead.Labels = in.Labels
+
+	gates := make([]*model.Bead, 0, len(in.Gates))
+	deps := make([]*model.Dependency, 0, len(in.Gates))
+	for _, g := range in.Gates {
+		gate, err := s.newTypedBead(ctx, "gate", g+" gate for "+in.Name, actor, map[string]any{"agent": in.Name, "gate": g})
+		if err != nil {
+			return nil, err
+		}
+		gates = append(gates, gate)
+		deps = append(deps, &model.Dependency{
+			BeadID:      agentBead.ID,
+			DependsOnID: gate.ID,
+			Type:        model.DepBlocks,
+			CreatedAt:   gate.CreatedAt,
+			CreatedBy:   actor,
+		})
+	}
+
+	secret, hash, err := newAgentToken()
+	if err != nil {
+		return nil, err
+	}
+	agent := &model.Agent{Name: in.Name, BeadID: agentBead.ID, TokenHash: hash, CreatedBy: actor}
+
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if _, err := tx.GetAgent(ctx, in.Name); err == nil {
+			return conflictError("agent " + in.Name + " is already registered")
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		for _, b := range append([]*model.Bead{agentBead}, gates...) {
+			if err := tx.CreateBead(ctx, b); err != nil {
+				return fmt.Errorf("failed to create bead: %w", err)
+			}
+			if err := s.recordEvent(ctx, tx, events.TopicBeadCreated, b.ID, actor, events.BeadCreated{Bead: b}); err != nil {
+				return err
+			}
+		}
+		for _, label := range agentBead.Labels {
+			if err := tx.AddLabel(ctx, agentBead.ID, label); err != nil {
+				return fmt.Errorf("failed to add label %q: %w", label, err)
+			}
+		}
+		for _, dep := range deps {
+			if err := tx.AddDependency(ctx, dep); err != nil {
+				return fmt.Errorf("failed to add gate: %w", err)
+			}
+			if err := s.recordEvent(ctx, tx, events.TopicDependencyAdded, dep.BeadID, actor, events.DependencyAdded{Dependency: dep}); err != nil {
+				return err
+			}
+		}
+		if err := tx.CreateAgent(ctx, agent); err != nil {
+			return err
+		}
+		// Register the agent as an actor too, unless its name is already
+		// taken by one.
+		if _, err := tx.ResolveActor(ctx, in.Name); errors.Is(err, sql.ErrNoRows) {
+			if err := tx.CreateActor(ctx, &model.Actor{ID: in.Name, Type: model.ActorAgent, CreatedBy: actor}); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicAgentRegistered, agentBead.ID, actor, events.AgentRegistered{
+			Agent:        agent,
+			RegisteredBy: actor,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+
+	env := map[string]string{
+		"BEADS_ACTOR": in.Name,
+		"BEADS_TOKEN": secret,
+	}
+	return &agentRegistration{
+		Agent:   agentBead,
+		Gates:   gates,
+		Token:   secret,
+		Env:     env,
+		Exports: fmt.Sprintf("export BEADS_ACTOR='%s'\nexport BEADS_TOKEN='%s'\n", in.Name, secret),
+	}, nil
+}
+
+// handleRegisterAgent handles POST /v1/agents/register.
+func (s *BeadsServer) handleRegisterAgent(w http.ResponseWriter, r *http.Request) {
+	var in registerAgentInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	reg, err := s.registerAgent(r.Context(), bearerToken(r.Header.Get("Authorization")), in)
+	if err != nil {
+		var (
+			ie inputError
+			ae authError
+			ce conflictError
+		)
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.As(err, &ae):
+			writeError(w, http.StatusUnauthorized, ae.Error())
+		case errors.As(err, &ce):
+			writeError(w, http.StatusConflict, ce.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, reg)
+}
+
+// RegisterAgent provisions a new agent. The call must carry the admin or
+// bootstrap token as a bearer token.
+func (s *BeadsServer) RegisterAgent(ctx context.Context, req *beadsv1.RegisterAgentRequest) (*beadsv1.RegisterAgentResponse, error) {
+	reg, err := s.registerAgent(ctx, grpcBearerToken(ctx), registerAgentInput{
+		Name:          req.GetName(),
+		Subscriptions: req.GetSubscriptions(),
+		Gates:         req.GetGates(),
+		Labels:        req.GetLabels(),
+		CreatedBy:     req.GetCreatedBy(),
+	})
+	if err != nil {
+		var (
+			ie inputError
+			ae authError
+			ce conflictError
+		)
+		switch {
+		case errors.As(err, &ie):
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		case errors.As(err, &ae):
+			return nil, status.Error(codes.Unauthenticated, ae.Error())
+		case errors.As(err, &ce):
+			return nil, status.Error(codes.AlreadyExists, ce.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to register agent: %v", err)
+	}
+
+	pbGates := make([]*beadsv1.Bead, 0, len(reg.Gates))
+	for _, g := range reg.Gates {
+		pbGates = append(pbGates, beadToProto(g))
+	}
+	return &beadsv1.RegisterAgentResponse{
+		Agent:   beadToProto(reg.Agent),
+		Gates:   pbGates,
+		Token:   reg.Token,
+		Env:     reg.Env,
+		Exports: reg.Exports,
+	}, nil
+}
+
+// rosterEntry is one registered agent: its role, the status of its agent
+// bead, and the in-progress beads assigned to it.
+type rosterEntry struct {
+	Name      string       `json:"name"`
+	BeadID    string       `json:"bead_id"`
+	Role      string       `json:"role"`
+	Status    model.Status `json:"status,omitempty"`
+	Held      []string     `json:"held"`
+	CreatedAt time.Time    `json:"created_at"`
+	CreatedBy string       `json:"created_by,omitempty"`
+}
+
+// listRoster returns every registered agent in name order.
+func (s *BeadsServer) listRoster(ctx context.Context) ([]rosterEntry, error) {
+	agents, err := s.store.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roster := make([]rosterEntry, 0, len(agents))
+	for _, a := range agents {
+		e := rosterEntry{Name: a.Name, BeadID: a.BeadID, Held: []string{}, CreatedAt: a.CreatedAt, CreatedBy: a.CreatedBy}
+		agentBead, err := s.store.GetBead(ctx, a.BeadID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if agentBead != nil {
+			e.Status = agentBead.Status
+		}
+		e.Role = agentRole(a.Name, agentBead)
+		held, _, err := s.store.ListBeads(ctx, model.BeadFilter{Assignee: a.Name, Status: []model.Status{model.StatusInProgress}})
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range held {
+			e.Held = append(e.Held, b.ID)
+		}
+		roster = append(roster, e)
+	}
+	return roster, nil
+}
+
+// handleListAgents handles GET /v1/agents.
+func (s *BeadsServer) handleListAgents(w http.ResponseWriter, r *http.Request) {
+	roster, err := s.listRoster(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list agents")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"agents": roster})
+}
+
+// ListAgents returns the agent roster.
+func (s *BeadsServer) ListAgents(ctx context.Context, _ *beadsv1.ListAgentsRequest) (*beadsv1.ListAgentsResponse, error) {
+	roster, err := s.listRoster(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list agents: %v", err)
+	}
+	agents := make([]*beadsv1.Agent, len(roster))
+	for i, e := range roster {
+		agents[i] = &beadsv1.Agent{
+			Name:      e.Name,
+			BeadId:    e.BeadID,
+			Role:      e.Role,
+			Status:    string(e.Status),
+			Held:      e.Held,
+			CreatedAt: timestamppb.New(e.CreatedAt),
+			CreatedBy: e.CreatedBy,
+		}
+	}
+	return &beadsv1.ListAgentsResponse{Agents: agents}, nil
+}
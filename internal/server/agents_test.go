@@ -0,0 +1,171 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// doBearer performs a JSON request carrying a bearer token.
+func doBearer(t *testing.T, h http.Handler, method, path, token string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, path, bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleRegisterAgent(t *testing.T) {
+	s, ms, h := newTestServer()
+	s.SetRegistrationTokens("admin-secret", "boot-secret")
+
+	rec := doBearer(t, h, "POST", "/v1/agents/register", "boot-secret", map[string]any{
+		"name":          "crew/test-agent",
+		"subscriptions": []string{"beads.bead.created"},
+		"gates":         []string{"onboarding"},
+		"created_by":    "alice",
+	})
+	requireStatus(t, rec, 201)
+	var reg agentRegistration
+	decodeJSON(t, rec, &reg)
+
+	if reg.Agent == nil || reg.Agent.Type != "agent" || reg.Agent.Title != "crew/test-agent" {
+		t.Fatalf("unexpected agent bead: %+v", reg.Agent)
+	}
+	if len(reg.Gates) != 1 || reg.Gates[0].Type != "gate" {
+		t.Fatalf("unexpected gates: %+v", reg.Gates)
+	}
+	if !strings.HasPrefix(reg.Token, agentTokenPrefix) || reg.Env["BEADS_TOKEN"] != reg.Token || reg.Env["BEADS_ACTOR"] != "crew/test-agent" {
+		t.Fatalf("unexpected credentials: token=%q env=%v", reg.Token, reg.Env)
+	}
+	if !strings.Contains(reg.Exports, "export BEADS_TOKEN='"+reg.Token+"'") {
+		t.Fatalf("unexpected exports: %q", reg.Exports)
+	}
+
+	agent := ms.agents["crew/test-agent"]
+	if agent == nil || agent.BeadID != reg.Agent.ID || agent.TokenHash != hashToken(reg.Token) {
+		t.Fatalf("unexpected stored agent: %+v", agent)
+	}
+	if a := ms.actors["crew/test-agent"]; a == nil || a.Type != model.ActorAgent {
+		t.Fatalf("agent not registered as an actor: %+v", a)
+	}
+	deps := ms.deps[reg.Agent.ID]
+	if len(deps) != 1 || deps[0].DependsOnID != reg.Gates[0].ID || deps[0].Type != model.DepBlocks {
+		t.Fatalf("expected gate to block agent, got %+v", deps)
+	}
+	requireEvent(t, ms, 4, "beads.agent.registered")
+	if ms.events[0].Actor != "alice" {
+		t.Fatalf("unexpected actor: %q", ms.events[0].Actor)
+	}
+
+	// The issued token now identifies the agent.
+	rec = doBearer(t, h, "POST", "/v1/beads", reg.Token, map[string]any{"title": "From agent", "type": "task"})
+	requireStatus(t, rec, 201)
+	var bead model.Bead
+	decodeJSON(t, rec, &bead)
+	if bead.CreatedBy != "crew/test-agent" {
+		t.Fatalf("created_by = %q, want agent identity", bead.CreatedBy)
+	}
+}
+
+func TestHandleRegisterAgent_Errors(t *testing.T) {
+	s, ms, h := newTestServer()
+
+	body := map[string]any{"name": "crew/a"}
+	requireStatus(t, doBearer(t, h, "POST", "/v1/agents/register", "anything", body), 401)
+
+	s.SetRegistrationTokens("admin-secret", "")
+	requireStatus(t, doBearer(t, h, "POST", "/v1/agents/register", "", body), 401)
+	requireStatus(t, doBearer(t, h, "POST", "/v1/agents/register", "wrong", body), 401)
+	requireStatus(t, doBearer(t, h, "POST", "/v1/agents/register", "admin-secret", map[string]any{"name": "Bad Name"}), 400)
+	if len(ms.beads) != 0 || len(ms.agents) != 0 {
+		t.Fatalf("rejected registrations should create nothing")
+	}
+
+	requireStatus(t, doBearer(t, h, "POST", "/v1/agents/register", "admin-secret", body), 201)
+	requireStatus(t, doBearer(t, h, "POST", "/v1/agents/register", "admin-secret", body), 409)
+	if len(ms.beads) != 1 {
+		t.Fatalf("expected one agent bead, got %d", len(ms.beads))
+	}
+}
+
+func TestGRPCRegisterAgent(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	srv.SetRegistrationTokens("", "boot-secret")
+
+	_, err := srv.RegisterAgent(ctx, &beadsv1.RegisterAgentRequest{Name: "crew/g"})
+	requireCode(t, err, codes.Unauthenticated)
+
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer boot-secret"))
+	resp, err := srv.RegisterAgent(ctx, &beadsv1.RegisterAgentRequest{Name: "crew/g", Gates: []string{"review", "budget"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.GetAgent().GetType() != "agent" || len(resp.GetGates()) != 2 || resp.GetToken() == "" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if ms.agents["crew/g"] == nil {
+		t.Fatal("agent not stored")
+	}
+
+	_, err = srv.RegisterAgent(ctx, &beadsv1.RegisterAgentRequest{Name: "crew/g"})
+	requireCode(t, err, codes.AlreadyExists)
+
+	// The agent's token sets the caller identity.
+	agentCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+resp.GetToken()))
+	var got string
+	_, err = srv.TokenInterceptor(agentCtx, nil, nil, func(ctx context.Context, _ any) (any, error) {
+		got = identityFrom(ctx)
+		return nil, nil
+	})
+	if err != nil || got != "crew/g" {
+		t.Fatalf("identity = %q, err = %v", got, err)
+	}
+}
+
+func TestListAgents(t *testing.T) {
+	s, ms, h := newTestServer()
+	s.SetRegistrationTokens("admin-secret", "")
+	ctx := context.Background()
+	for _, name := range []string{"crew/b", "witness/a"} {
+		if _, err := s.registerAgent(ctx, "admin-secret", registerAgentInput{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ms.beads["bd-work"] = &model.Bead{ID: "bd-work", Title: "Work", Status: model.StatusInProgress, Assignee: "crew/b"}
+
+	rec := doJSON(t, h, "GET", "/v1/agents", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var body struct {
+		Agents []rosterEntry `json:"agents"`
+	}
+	decodeJSON(t, rec, &body)
+	if len(body.Agents) != 2 || body.Agents[0].Name != "crew/b" || body.Agents[1].Role != "witness" {
+		t.Fatalf("roster = %+v", body.Agents)
+	}
+	if a := body.Agents[0]; a.Status != model.StatusOpen || len(a.Held) != 1 || a.Held[0] != "bd-work" {
+		t.Errorf("crew/b = %+v", a)
+	}
+
+	resp, err := s.ListAgents(ctx, &beadsv1.ListAgentsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetAgents()) != 2 || resp.GetAgents()[0].GetRole() != "crew" || resp.GetAgents()[0].GetBeadId() == "" {
+		t.Fatalf("ListAgents = %+v", resp.GetAgents())
+	}
+}
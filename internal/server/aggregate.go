@@ -0,0 +1,100 @@
+package server
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// Metrics GET /v1/aggregate can order groups by.
+const (
+	metricCount  = "count"
+	metricAvgAge = "avg_age"
+)
+
+// aggregateResponse is the body of GET /v1/aggregate.
+type aggregateResponse struct {
+	GroupBy string                  `json:"group_by"`
+	Metric  string                  `json:"metric"`
+	Groups  []*model.AggregateGroup `json:"groups"`
+}
+
+// handleAggregate handles GET /v1/aggregate?group_by=D&metric=M. It groups
+// the beads matching the list filters of GET /v1/beads by dimension D and
+// orders the groups by metric M, count (the default) or avg_age, largest
+// first. Both metrics are computed in the database, so no beads are loaded.
+func (s *BeadsServer) handleAggregate(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	groupBy := q.Get("group_by")
+	if !slices.Contains(model.GroupByDimensions, groupBy) {
+		writeError(w, http.StatusBadRequest, "group_by must be one of "+strings.Join(model.GroupByDimensions, ", "))
+		return
+	}
+	metric := cmp.Or(q.Get("metric"), metricCount)
+	if metric != metricCount && metric != metricAvgAge {
+		writeError(w, http.StatusBadRequest, "metric must be count or avg_age")
+		return
+	}
+	filter, err := parseBeadFilter(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	groups, err := s.store.AggregateBeads(r.Context(), filter, groupBy)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to aggregate beads")
+		return
+	}
+	if groups == nil {
+		groups = []*model.AggregateGroup{}
+	}
+	slices.SortStableFunc(groups, func(a, b *model.AggregateGroup) int {
+		if metric == metricAvgAge {
+			return cmp.Compare(b.AvgAgeHours, a.AvgAgeHours)
+		}
+		return cmp.Compare(b.Count, a.Count)
+	})
+	writeJSON(w, http.StatusOK, aggregateResponse{GroupBy: groupBy, Metric: metric, Groups: groups})
+}
+
+// defaultRollupWindow is how many days of closures GET /v1/rollup projects
+// its ETA from by default.
+const defaultRollupWindow = 14
+
+// handleRollup handles GET /v1/rollup: the progress of the beads matching
+// the list filters of GET /v1/beads, e.g. ?labels=epic:payments, with counts
+// by status, total and completed weight, how many are blocked, and an ETA
+// from the weight closed in the last window_days (default 14).
+func (s *BeadsServer) handleRollup(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	window := defaultRollupWindow
+	if v := q.Get("window_days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 365 {
+			writeError(w, http.StatusBadRequest, "window_days must be between 1 and 365")
+			return
+		}
+		window = n
+	}
+	filter, err := parseBeadFilter(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	now := time.Now().UTC()
+	rollup, err := s.store.RollupBeads(r.Context(), filter, now.AddDate(0, 0, -window))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to roll up beads")
+		return
+	}
+	rollup.WindowDays = window
+	rollup.EstimateETA(now)
+	writeJSON(w, http.StatusOK, rollup)
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestHandleAggregate(t *testing.T) {
+	_, ms, h := newTestServer()
+	now := time.Now()
+	closed := now.Add(-time.Hour)
+	ms.beads["bd-1"] = &model.Bead{ID: "bd-1", Status: model.StatusOpen, Type: "task", Assignee: "alice", CreatedAt: now.Add(-10 * time.Hour)}
+	ms.beads["bd-2"] = &model.Bead{ID: "bd-2", Status: model.StatusOpen, Type: "bug", Assignee: "alice", CreatedAt: now.Add(-20 * time.Hour)}
+	ms.beads["bd-3"] = &model.Bead{ID: "bd-3", Status: model.StatusClosed, Type: "task", Assignee: "bob", CreatedAt: now.Add(-101 * time.Hour), ClosedAt: &closed}
+	ms.labels["bd-1"] = []string{"team:backend", "urgent"}
+	ms.labels["bd-3"] = []string{"team:backend"}
+
+	get := func(path string) aggregateResponse {
+		t.Helper()
+		rec := doJSON(t, h, "GET", path, nil)
+		requireStatus(t, rec, http.StatusOK)
+		var body aggregateResponse
+		decodeJSON(t, rec, &body)
+		return body
+	}
+	keys := func(groups []*model.AggregateGroup) []string {
+		var out []string
+		for _, g := range groups {
+			out = append(out, g.Key)
+		}
+		return out
+	}
+
+	body := get("/v1/aggregate?group_by=assignee")
+	if body.Metric != "count" || !slices.Equal(keys(body.Groups), []string{"alice", "bob"}) || body.Groups[0].Count != 2 {
+		t.Fatalf("by assignee = %+v", body)
+	}
+	if age := body.Groups[0].AvgAgeHours; age < 14.9 || age > 15.1 {
+		t.Errorf("alice avg age = %v, want 15", age)
+	}
+
+	// avg_age orders the oldest group first; a closed bead ages until it closed.
+	body = get("/v1/aggregate?group_by=assignee&metric=avg_age")
+	if !slices.Equal(keys(body.Groups), []string{"bob", "alice"}) || body.Groups[0].AvgAgeHours < 99.9 || body.Groups[0].AvgAgeHours > 100.1 {
+		t.Fatalf("by avg age = %+v", body.Groups)
+	}
+
+	// List filters apply before grouping.
+	body = get("/v1/aggregate?group_by=type&status=open")
+	if !slices.Equal(keys(body.Groups), []string{"bug", "task"}) {
+		t.Fatalf("open by type = %v", keys(body.Groups))
+	}
+
+	body = get("/v1/aggregate?group_by=label")
+	if !slices.Equal(keys(body.Groups), []string{"team:backend", "urgent"}) || body.Groups[0].Count != 2 {
+		t.Fatalf("by label = %+v", body.Groups)
+	}
+
+	for _, path := range []string{"/v1/aggregate", "/v1/aggregate?group_by=owner", "/v1/aggregate?group_by=status&metric=sum"} {
+		requireStatus(t, doJSON(t, h, "GET", path, nil), http.StatusBadRequest)
+	}
+}
+
+func TestHandleRollup(t *testing.T) {
+	_, ms, h := newTestServer()
+	now := time.Now()
+	recent, old := now.Add(-24*time.Hour), now.AddDate(0, 0, -30)
+	ms.beads["bd-1"] = &model.Bead{ID: "bd-1", Status: model.StatusClosed, ClosedAt: &recent, Fields: []byte(`{"weight":2}`)}
+	ms.beads["bd-2"] = &model.Bead{ID: "bd-2", Status: model.StatusClosed, ClosedAt: &old}
+	ms.beads["bd-3"] = &model.Bead{ID: "bd-3", Status: model.StatusOpen, Fields: []byte(`{"weight":3}`)}
+	ms.beads["bd-4"] = &model.Bead{ID: "bd-4", Status: model.StatusInProgress}
+	ms.beads["bd-5"] = &model.Bead{ID: "bd-5", Status: model.StatusOpen}
+	for id := range ms.beads {
+		if id != "bd-5" {
+			ms.labels[id] = []string{"epic:payments"}
+		}
+	}
+	ms.deps["bd-3"] = []*model.Dependency{{BeadID: "bd-3", DependsOnID: "bd-4", Type: model.DepBlocks}}
+
+	rec := doJSON(t, h, "GET", "/v1/rollup?labels=epic:payments", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var r model.Rollup
+	decodeJSON(t, rec, &r)
+	if r.Total != 4 || r.ByStatus["closed"] != 2 || r.TotalWeight != 7 || r.CompletedWeight != 3 || r.Blocked != 1 {
+		t.Fatalf("rollup = %+v", r)
+	}
+	// 4 weight left at 2 per 14 days.
+	if r.RecentWeight != 2 || r.WindowDays != 14 || r.ETA == nil || r.ETA.Sub(now) < 27*24*time.Hour || r.ETA.Sub(now) > 29*24*time.Hour {
+		t.Fatalf("rollup = %+v, ETA %v", r, r.ETA)
+	}
+
+	for _, path := range []string{"/v1/rollup?window_days=0", "/v1/rollup?window_days=400"} {
+		requireStatus(t, doJSON(t, h, "GET", path, nil), http.StatusBadRequest)
+	}
+}
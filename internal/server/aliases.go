@@ -0,0 +1,258 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/idgen"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// uniqueSlug returns the slug for a new bead titled title, suffixed with
+// -2, -3, ... if a bead ID, slug or alias already has it. It returns "" if
+// the title yields no slug.
+func (s *BeadsServer) uniqueSlug(ctx context.Context, title string) (string, error) {
+	return s.uniqueSlugExcept(ctx, title, nil)
+}
+
+// uniqueSlugExcept is uniqueSlug that also skips the slugs in taken, those
+// of beads about to be created alongside this one.
+func (s *BeadsServer) uniqueSlugExcept(ctx context.Context, title string, taken map[string]bool) (string, error) {
+	base := idgen.Slug(title)
+	if base == "" {
+		return "", nil
+	}
+	for n := 1; ; n++ {
+		slug := base
+		if n > 1 {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		if taken[slug] {
+			continue
+		}
+		_, err := s.store.ResolveBeadRef(ctx, slug)
+		if errors.Is(err, sql.ErrNoRows) {
+			return slug, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// resolveBeadID maps a bead ID, slug or alias to the bead's ID. Refs that
+// match nothing are returned unchanged, so callers report them as not found.
+func (s *BeadsServer) resolveBeadID(ctx context.Context, ref string) string {
+	if ref == "" {
+		return ref
+	}
+	id, err := s.store.ResolveBeadRef(ctx, ref)
+	if err != nil {
+		return ref
+	}
+	return id
+}
+
+// beadRefParams are the query parameters that name a bead.
+var beadRefParams = []string{"depends_on_id", "into"}
+
+// withBeadRef resolves the {id} path value and bead-naming query parameters
+// of a bead route from slugs and aliases to bead IDs.
+func (s *BeadsServer) withBeadRef(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.SetPathValue("id", s.resolveBeadID(r.Context(), r.PathValue("id")))
+		q := r.URL.Query()
+		changed := false
+		for _, key := range beadRefParams {
+			if ref := q.Get(key); ref != "" {
+				if id := s.resolveBeadID(r.Context(), ref); id != ref {
+					q.Set(key, id)
+					changed = true
+				}
+			}
+		}
+		if changed {
+			r.URL.RawQuery = q.Encode()
+		}
+		h(w, r)
+	}
+}
+
+// beadRefFields are the request fields that name a bead.
+var beadRefFields = []protoreflect.Name{"id", "bead_id", "depends_on_id", "other_id", "into"}
+
+// BeadRefInterceptor resolves slugs and aliases in the bead-naming fields
+// of BeadsService requests to bead IDs before the handler runs.
+func (s *BeadsServer) BeadRefInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if !strings.HasPrefix(info.FullMethod, "/"+beadsv1.BeadsService_ServiceDesc.ServiceName+"/") {
+		return handler(ctx, req)
+	}
+	if m, ok := req.(proto.Message); ok {
+		msg := m.ProtoReflect()
+		fields := msg.Descriptor().Fields()
+		for _, name := range beadRefFields {
+			fd := fields.ByName(name)
+			if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+				continue
+			}
+			if ref := msg.Get(fd).String(); ref != "" {
+				msg.Set(fd, protoreflect.ValueOfString(s.resolveBeadID(ctx, ref)))
+			}
+		}
+	}
+	return handler(ctx, req)
+}
+
+// addAlias gives a bead a hand-picked alias. It returns inputError for an
+// invalid alias, conflictError if the alias already names a bead, and
+// sql.ErrNoRows if the bead does not exist.
+func (s *BeadsServer) addAlias(ctx context.Context, beadID, alias, actor string) (*model.Alias, error) {
+	if err := model.ValidateAlias(alias); err != nil {
+		return nil, inputError(err.Error())
+	}
+	bead, err := s.store.GetBead(ctx, beadID)
+	if err != nil {
+		return nil, err
+	}
+	if bead == nil {
+		return nil, sql.ErrNoRows
+	}
+	taken, err := s.store.ResolveBeadRef(ctx, alias)
+	switch {
+	case err == nil:
+		return nil, conflictError(fmt.Sprintf("%s already names bead %s", alias, taken))
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+	a := &model.Alias{Alias: alias, BeadID: beadID, CreatedBy: actor}
+	if err := s.store.AddAlias(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// addAliasRequest is the JSON body for POST /v1/beads/{id}/aliases.
+type addAliasRequest struct {
+	Alias     string `json:"alias"`
+	CreatedBy string `json:"created_by"`
+}
+
+// handleListAliases handles GET /v1/beads/{id}/aliases.
+func (s *BeadsServer) handleListAliases(w http.ResponseWriter, r *http.Request) {
+	aliases, err := s.store.GetAliases(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get aliases")
+		return
+	}
+	if aliases == nil {
+		aliases = []*model.Alias{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"aliases": aliases})
+}
+
+// handleAddAlias handles POST /v1/beads/{id}/aliases.
+func (s *BeadsServer) handleAddAlias(w http.ResponseWriter, r *http.Request) {
+	var req addAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	a, err := s.addAlias(r.Context(), r.PathValue("id"), req.Alias, s.actorFor(r.Context(), req.CreatedBy))
+	if err != nil {
+		var (
+			ie inputError
+			ce conflictError
+		)
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.As(err, &ce):
+			writeError(w, http.StatusConflict, ce.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "bead not found")
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to add alias")
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, a)
+}
+
+// handleRemoveAlias handles DELETE /v1/beads/{id}/aliases/{alias}.
+func (s *BeadsServer) handleRemoveAlias(w http.ResponseWriter, r *http.Request) {
+	if err := s.store.RemoveAlias(r.Context(), r.PathValue("id"), r.PathValue("alias")); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "alias not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to remove alias")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddAlias gives a bead a hand-picked alias.
+func (s *BeadsServer) AddAlias(ctx context.Context, req *beadsv1.AddAliasRequest) (*beadsv1.AddAliasResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	a, err := s.addAlias(ctx, req.GetBeadId(), req.GetAlias(), s.actorFor(ctx, req.GetCreatedBy()))
+	if err != nil {
+		var (
+			ie inputError
+			ce conflictError
+		)
+		switch {
+		case errors.As(err, &ie):
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		case errors.As(err, &ce):
+			return nil, status.Error(codes.AlreadyExists, ce.Error())
+		}
+		return nil, storeError(err, "bead")
+	}
+	return &beadsv1.AddAliasResponse{Alias: aliasToProto(a)}, nil
+}
+
+// RemoveAlias removes one of a bead's aliases.
+func (s *BeadsServer) RemoveAlias(ctx context.Context, req *beadsv1.RemoveAliasRequest) (*beadsv1.RemoveAliasResponse, error) {
+	if req.GetBeadId() == "" || req.GetAlias() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id and alias are required")
+	}
+	if err := s.store.RemoveAlias(ctx, req.GetBeadId(), req.GetAlias()); err != nil {
+		return nil, storeError(err, "alias")
+	}
+	return &beadsv1.RemoveAliasResponse{}, nil
+}
+
+// ListAliases lists a bead's aliases.
+func (s *BeadsServer) ListAliases(ctx context.Context, req *beadsv1.ListAliasesRequest) (*beadsv1.ListAliasesResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	aliases, err := s.store.GetAliases(ctx, req.GetBeadId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get aliases: %v", err)
+	}
+	pb := make([]*beadsv1.Alias, 0, len(aliases))
+	for _, a := range aliases {
+		pb = append(pb, aliasToProto(a))
+	}
+	return &beadsv1.ListAliasesResponse{Aliases: pb}, nil
+}
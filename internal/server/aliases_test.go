@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestHandleCreateBead_Slug(t *testing.T) {
+	_, _, h := newTestServer()
+
+	var first, second model.Bead
+	rec := doJSON(t, h, "POST", "/v1/beads", map[string]any{"title": "Fix login bug", "type": "task"})
+	requireStatus(t, rec, http.StatusCreated)
+	decodeJSON(t, rec, &first)
+	rec = doJSON(t, h, "POST", "/v1/beads", map[string]any{"title": "Fix login bug!", "type": "task"})
+	requireStatus(t, rec, http.StatusCreated)
+	decodeJSON(t, rec, &second)
+	if first.Slug != "bd-fix-login-bug" || second.Slug != "bd-fix-login-bug-2" {
+		t.Fatalf("slugs = %q, %q", first.Slug, second.Slug)
+	}
+
+	// Slugs are stable across title changes and accepted in place of IDs.
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-fix-login-bug", map[string]any{"title": "Fix SSO login"}), http.StatusOK)
+	var got model.Bead
+	rec = doJSON(t, h, "GET", "/v1/beads/bd-fix-login-bug", nil)
+	requireStatus(t, rec, http.StatusOK)
+	decodeJSON(t, rec, &got)
+	if got.ID != first.ID || got.Title != "Fix SSO login" || got.Slug != first.Slug {
+		t.Errorf("got %s %q (%s), want %s", got.ID, got.Title, got.Slug, first.ID)
+	}
+}
+
+func TestHandleCreateBead_SlugTakenConcurrently(t *testing.T) {
+	_, ms, h := newTestServer()
+	// Another create takes the slug between choosing it and inserting.
+	ms.onCreate = func(b *model.Bead) {
+		ms.onCreate = nil
+		ms.beads["bd-racer"] = &model.Bead{ID: "bd-racer", Slug: b.Slug, Title: b.Title}
+	}
+
+	rec := doJSON(t, h, "POST", "/v1/beads", map[string]any{"title": "Fix login bug", "type": "task"})
+	requireStatus(t, rec, http.StatusCreated)
+	var got model.Bead
+	decodeJSON(t, rec, &got)
+	if got.Slug != "bd-fix-login-bug-2" {
+		t.Fatalf("slug = %q, want bd-fix-login-bug-2", got.Slug)
+	}
+}
+
+func TestHandleAliases(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-a1"] = &model.Bead{ID: "bd-a1", Slug: "bd-fix-login-bug", Title: "Fix login bug", Status: model.StatusOpen}
+	ms.beads["bd-b2"] = &model.Bead{ID: "bd-b2", Title: "Ship it", Status: model.StatusOpen}
+
+	var a model.Alias
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-fix-login-bug/aliases", map[string]any{"alias": "login", "created_by": "alice"})
+	requireStatus(t, rec, http.StatusCreated)
+	decodeJSON(t, rec, &a)
+	if a.BeadID != "bd-a1" || a.CreatedBy != "alice" {
+		t.Fatalf("alias = %+v", a)
+	}
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-b2/aliases", map[string]any{"alias": "login"}), http.StatusConflict)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-b2/aliases", map[string]any{"alias": "bd-a1"}), http.StatusConflict)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-b2/aliases", map[string]any{"alias": "-bad name"}), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-none/aliases", map[string]any{"alias": "ghost"}), http.StatusNotFound)
+
+	// Aliases resolve in paths and in bead-naming bodies.
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-b2/dependencies", map[string]any{"depends_on_id": "login", "type": "blocks"}), http.StatusCreated)
+	if deps := ms.deps["bd-b2"]; len(deps) != 1 || deps[0].DependsOnID != "bd-a1" {
+		t.Errorf("deps = %+v, want one on bd-a1", deps)
+	}
+
+	var list struct {
+		Aliases []*model.Alias `json:"aliases"`
+	}
+	rec = doJSON(t, h, "GET", "/v1/beads/login/aliases", nil)
+	requireStatus(t, rec, http.StatusOK)
+	decodeJSON(t, rec, &list)
+	if len(list.Aliases) != 1 || list.Aliases[0].Alias != "login" {
+		t.Fatalf("aliases = %+v", list.Aliases)
+	}
+
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/beads/bd-a1/aliases/login", nil), http.StatusNoContent)
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/beads/bd-a1/aliases/login", nil), http.StatusNotFound)
+}
+
+func TestBeadRefInterceptor(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-a1"] = &model.Bead{ID: "bd-a1", Slug: "bd-fix-login-bug", Status: model.StatusOpen}
+	ms.beads["bd-b2"] = &model.Bead{ID: "bd-b2", Status: model.StatusOpen}
+	if _, err := srv.AddAlias(ctx, &beadsv1.AddAliasRequest{BeadId: "bd-b2", Alias: "ship"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := srv.AddAlias(ctx, &beadsv1.AddAliasRequest{BeadId: "bd-a1", Alias: "ship"})
+	requireCode(t, err, codes.AlreadyExists)
+
+	req := &beadsv1.AddDependencyRequest{BeadId: "ship", DependsOnId: "bd-fix-login-bug"}
+	_, err = srv.BeadRefInterceptor(context.Background(), req,
+		&grpc.UnaryServerInfo{FullMethod: beadsv1.BeadsService_AddDependency_FullMethodName},
+		func(context.Context, any) (any, error) { return nil, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.GetBeadId() != "bd-b2" || req.GetDependsOnId() != "bd-a1" {
+		t.Errorf("resolved to %s -> %s, want bd-b2 -> bd-a1", req.GetBeadId(), req.GetDependsOnId())
+	}
+
+	unknown := &beadsv1.GetBeadRequest{Id: "nothing"}
+	_, _ = srv.BeadRefInterceptor(context.Background(), unknown,
+		&grpc.UnaryServerInfo{FullMethod: beadsv1.BeadsService_GetBead_FullMethodName},
+		func(context.Context, any) (any, error) { return nil, nil })
+	if unknown.GetId() != "nothing" {
+		t.Errorf("unknown ref rewritten to %q", unknown.GetId())
+	}
+}
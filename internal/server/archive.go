@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// archiveActor is recorded on events for beads archived by the policy.
+const archiveActor = "beads:archive"
+
+// SetArchivePolicy sets how long a bead stays closed before it is archived.
+// Zero disables the policy; POST /v1/archive/run then needs an explicit age.
+func (s *BeadsServer) SetArchivePolicy(after time.Duration) {
+	s.archiveAfter = after
+}
+
+// RunArchiver archives beads closed longer than the archive policy, checking
+// every interval until ctx is cancelled.
+func (s *BeadsServer) RunArchiver(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if ids, err := s.ArchiveClosed(ctx, time.Now().UTC().Add(-s.archiveAfter), archiveActor); err != nil {
+				slog.Error("archival failed", "err", err)
+			} else if len(ids) > 0 {
+				slog.Info("archived closed beads", "count", len(ids))
+			}
+		}
+	}
+}
+
+// ArchiveClosed archives beads closed before cutoff and emits a
+// bead.archived event for each. Returns the IDs archived.
+func (s *BeadsServer) ArchiveClosed(ctx context.Context, cutoff time.Time, actor string) ([]string, error) {
+	var ids []string
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		var err error
+		if ids, err = tx.ArchiveClosedBeads(ctx, cutoff); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := s.recordEvent(ctx, tx, events.TopicBeadArchived, id, actor, events.BeadArchived{BeadID: id, ArchivedBy: actor}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("archiving closed beads: %w", err)
+	}
+	s.flushEvents(ctx)
+	return ids, nil
+}
+
+// authorizeAdmin checks token against the admin token; OIDC callers with
+// the admin role need none.
+func (s *BeadsServer) authorizeAdmin(ctx context.Context, token string) error {
+	if s.isOIDCAdmin(ctx) {
+		return nil
+	}
+	if s.adminToken == "" {
+		return authError("admin operations are disabled; set BEADS_ADMIN_TOKEN")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+		return authError("admin token required")
+	}
+	return nil
+}
+
+// handleRunArchive handles POST /v1/archive/run?older_than_days=N. It
+// archives beads closed more than N days ago, or longer than the archive
+// policy when N is omitted, and requires the admin token.
+func (s *BeadsServer) handleRunArchive(w http.ResponseWriter, r *http.Request) {
+	if err := s.authorizeAdmin(r.Context(), bearerToken(r.Header.Get("Authorization"))); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	after := s.archiveAfter
+	if v := r.URL.Query().Get("older_than_days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "older_than_days must be a non-negative integer")
+			return
+		}
+		after = time.Duration(n) * 24 * time.Hour
+	} else if after <= 0 {
+		writeError(w, http.StatusBadRequest, "no archive policy is set; pass older_than_days or set BEADS_ARCHIVE_AFTER")
+		return
+	}
+
+	actor := s.actorFor(r.Context(), archiveActor)
+	ids, err := s.ArchiveClosed(r.Context(), time.Now().UTC().Add(-after), actor)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"archived": ids})
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// seedArchive adds an open bead, one closed yesterday and one closed 100
+// days ago.
+func seedArchive(ms *mockStore) {
+	now := time.Now().UTC()
+	recent, old := now.Add(-24*time.Hour), now.Add(-100*24*time.Hour)
+	ms.beads["bd-open"] = &model.Bead{ID: "bd-open", Title: "Open", Status: model.StatusOpen}
+	ms.beads["bd-recent"] = &model.Bead{ID: "bd-recent", Title: "Closed yesterday", Status: model.StatusClosed, ClosedAt: &recent}
+	ms.beads["bd-old"] = &model.Bead{ID: "bd-old", Title: "Closed long ago", Status: model.StatusClosed, ClosedAt: &old}
+}
+
+func TestHandleRunArchive(t *testing.T) {
+	s, ms, h := newTestServer()
+	seedArchive(ms)
+
+	requireStatus(t, doBearer(t, h, "POST", "/v1/archive/run?older_than_days=30", "", nil), http.StatusUnauthorized)
+	s.SetRegistrationTokens("admin-secret", "boot-secret")
+	requireStatus(t, doBearer(t, h, "POST", "/v1/archive/run?older_than_days=30", "boot-secret", nil), http.StatusUnauthorized)
+	requireStatus(t, doBearer(t, h, "POST", "/v1/archive/run", "admin-secret", nil), http.StatusBadRequest)
+	requireStatus(t, doBearer(t, h, "POST", "/v1/archive/run?older_than_days=-1", "admin-secret", nil), http.StatusBadRequest)
+
+	rec := doBearer(t, h, "POST", "/v1/archive/run?older_than_days=30", "admin-secret", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var body struct {
+		Archived []string `json:"archived"`
+	}
+	decodeJSON(t, rec, &body)
+	if len(body.Archived) != 1 || body.Archived[0] != "bd-old" {
+		t.Fatalf("archived = %v, want [bd-old]", body.Archived)
+	}
+	if last := ms.events[len(ms.events)-1]; last.Topic != events.TopicBeadArchived || last.BeadID != "bd-old" {
+		t.Errorf("event = %+v", last)
+	}
+
+	// With a policy set, the age may be omitted.
+	s.SetArchivePolicy(time.Hour)
+	rec = doBearer(t, h, "POST", "/v1/archive/run", "admin-secret", nil)
+	requireStatus(t, rec, http.StatusOK)
+	body.Archived = nil
+	decodeJSON(t, rec, &body)
+	if len(body.Archived) != 1 || body.Archived[0] != "bd-recent" {
+		t.Fatalf("archived = %v, want [bd-recent]", body.Archived)
+	}
+}
+
+func TestListBeads_ExcludesArchived(t *testing.T) {
+	srv, ms, h := newTestServer()
+	seedArchive(ms)
+	if _, err := srv.ArchiveClosed(context.Background(), time.Now().UTC().Add(-30*24*time.Hour), archiveActor); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := doJSON(t, h, "GET", "/v1/beads?status=closed", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var page beadPage
+	decodeJSON(t, rec, &page)
+	if page.Total != 1 || page.Beads[0].ID != "bd-recent" {
+		t.Fatalf("closed beads = %+v, want only bd-recent", page.Beads)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/beads?status=closed&include_archived=true", nil)
+	requireStatus(t, rec, http.StatusOK)
+	page = beadPage{}
+	decodeJSON(t, rec, &page)
+	if page.Total != 2 {
+		t.Fatalf("include_archived: got %d beads, want 2", page.Total)
+	}
+
+	resp, err := srv.ListBeads(context.Background(), &beadsv1.ListBeadsRequest{IncludeArchived: true, Status: []string{"closed"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	archived := 0
+	for _, b := range resp.GetBeads() {
+		if b.GetArchivedAt() != nil {
+			archived++
+		}
+	}
+	if resp.GetTotal() != 2 || archived != 1 {
+		t.Fatalf("gRPC include_archived: total %d, archived %d", resp.GetTotal(), archived)
+	}
+
+	// An archived bead is still served by ID.
+	requireStatus(t, doJSON(t, h, "GET", "/v1/beads/bd-old", nil), http.StatusOK)
+}
@@ -1,11 +1,14 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
@@ -32,11 +35,64 @@ type createBeadInput struct {
 	Fields      json.RawMessage `json:"fields"`
 	DueAt       *time.Time      `json:"due_at,omitempty"`
 	DeferUntil  *time.Time      `json:"defer_until,omitempty"`
+	Estimate    string          `json:"estimate,omitempty"`
+	Actual      string          `json:"actual,omitempty"`
+
+	// IdempotencyKey, when set, makes a repeated create return the bead the
+	// first one created.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
+// errRepeatedCreate rolls back a create whose idempotency key already
+// created a bead.
+var errRepeatedCreate = errors.New("idempotency key already used")
+
 // createBead validates input, persists a new bead with labels, and publishes
-// a BeadCreated event. Returns inputError for validation failures.
+// a BeadCreated event. Returns inputError for validation failures. A create
+// repeating an earlier one's idempotency key returns the earlier bead.
 func (s *BeadsServer) createBead(ctx context.Context, in createBeadInput) (*model.Bead, error) {
+	bead, err := s.prepareBead(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	var existing string
+	err = s.withFreeSlug(ctx, bead, func() error {
+		return s.store.RunInTransaction(ctx, func(tx store.Store) error {
+			if err := s.insertBead(ctx, tx, bead); err != nil {
+				return err
+			}
+			if in.IdempotencyKey == "" {
+				return nil
+			}
+			id, err := tx.RecordCreateKey(ctx, in.IdempotencyKey, bead.ID)
+			if err != nil {
+				return fmt.Errorf("failed to record idempotency key: %w", err)
+			}
+			if id != bead.ID {
+				existing = id
+				return errRepeatedCreate
+			}
+			return nil
+		})
+	})
+	if errors.Is(err, errRepeatedCreate) {
+		b, err := s.store.GetBead(ctx, existing)
+		if err == nil && b == nil {
+			err = sql.ErrNoRows
+		}
+		return b, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+
+	return bead, nil
+}
+
+// prepareBead validates input and builds the bead createBead would insert.
+// Returns inputError for validation failures.
+func (s *BeadsServer) prepareBead(ctx context.Context, in createBeadInput) (*model.Bead, error) {
 	if in.Title == "" {
 		return nil, inputError("title is required")
 	}
@@ -58,8 +114,14 @@ func (s *BeadsServer) createBead(ctx context.Context, in createBeadInput) (*mode
 		return nil, inputError("unknown bead type " + string(beadType))
 	}
 
+	slug, err := s.uniqueSlug(ctx, in.Title)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate slug: %w", err)
+	}
+
 	bead := &model.Bead{
 		ID:          id,
+		Slug:        slug,
 		Kind:        tc.Kind,
 		Type:        beadType,
 		Title:       in.Title,
@@ -70,10 +132,12 @@ func (s *BeadsServer) createBead(ctx context.Context, in createBeadInput) (*mode
 		Assignee:    in.Assignee,
 		Owner:       in.Owner,
 		CreatedAt:   now,
-		CreatedBy:   in.CreatedBy,
+		CreatedBy:   s.actorFor(ctx, in.CreatedBy),
 		UpdatedAt:   now,
 		DueAt:       in.DueAt,
 		DeferUntil:  in.DeferUntil,
+		Estimate:    strings.TrimSpace(in.Estimate),
+		Actual:      strings.TrimSpace(in.Actual),
 		Labels:      in.Labels,
 	}
 
@@ -88,26 +152,39 @@ func (s *BeadsServer) createBead(ctx context.Context, in createBeadInput) (*mode
 	if err := model.ValidateFields(bead.Fields, tc.Fields); err != nil {
 		return nil, inputError("invalid fields: " + err.Error())
 	}
+	if err := s.checkLabels(ctx, bead.Labels); err != nil {
+		return nil, err
+	}
+	return bead, nil
+}
 
-	// Bug 5 fix: wrap CreateBead + label inserts in a transaction.
-	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
-		if err := tx.CreateBead(ctx, bead); err != nil {
-			return fmt.Errorf("failed to create bead: %w", err)
+// withFreeSlug runs create, a transaction inserting bead. If a concurrent
+// create took bead's slug first, it moves bead to the next free slug and runs
+// create again. bead may be nil when create inserts no bead.
+func (s *BeadsServer) withFreeSlug(ctx context.Context, bead *model.Bead, create func() error) error {
+	for n := 1; ; n++ {
+		err := create()
+		if bead == nil || n == conflictRetries || !errors.Is(err, store.ErrSlugTaken) {
+			return err
 		}
-		for _, label := range bead.Labels {
-			if err := tx.AddLabel(ctx, bead.ID, label); err != nil {
-				return fmt.Errorf("failed to add label %q: %w", label, err)
-			}
+		if bead.Slug, err = s.uniqueSlug(ctx, bead.Title); err != nil {
+			return fmt.Errorf("failed to generate slug: %w", err)
 		}
-		return nil
-	})
-	if err != nil {
-		return nil, err
 	}
+}
 
-	s.recordAndPublish(ctx, events.TopicBeadCreated, bead.ID, bead.CreatedBy, events.BeadCreated{Bead: bead})
-
-	return bead, nil
+// insertBead writes a prepared bead and its labels through tx and records
+// its BeadCreated event.
+func (s *BeadsServer) insertBead(ctx context.Context, tx store.Store, bead *model.Bead) error {
+	if err := tx.CreateBead(ctx, bead); err != nil {
+		return fmt.Errorf("failed to create bead: %w", err)
+	}
+	for _, label := range bead.Labels {
+		if err := tx.AddLabel(ctx, bead.ID, label); err != nil {
+			return fmt.Errorf("failed to add label %q: %w", label, err)
+		}
+	}
+	return s.recordEvent(ctx, tx, events.TopicBeadCreated, bead.ID, bead.CreatedBy, events.BeadCreated{Bead: bead})
 }
 
 // CreateBead validates the request, persists a new bead, publishes a BeadCreated event,
@@ -127,6 +204,10 @@ func (s *BeadsServer) CreateBead(ctx context.Context, req *beadsv1.CreateBeadReq
 		Fields:      json.RawMessage(req.GetFields()),
 		DueAt:       protoTimestamp(req.GetDueAt()),
 		DeferUntil:  protoTimestamp(req.GetDeferUntil()),
+		Estimate:    req.GetEstimate(),
+		Actual:      req.GetActual(),
+
+		IdempotencyKey: req.GetIdempotencyKey(),
 	})
 	if err != nil {
 		var ie inputError
@@ -156,15 +237,18 @@ func (s *BeadsServer) GetBead(ctx context.Context, req *beadsv1.GetBeadRequest)
 	return &beadsv1.GetBeadResponse{Bead: beadToProto(bead)}, nil
 }
 
-// ListBeads returns a filtered, paginated list of beads.
-func (s *BeadsServer) ListBeads(ctx context.Context, req *beadsv1.ListBeadsRequest) (*beadsv1.ListBeadsResponse, error) {
+// filterFromProto builds a bead filter from a list request.
+func filterFromProto(req *beadsv1.ListBeadsRequest) model.BeadFilter {
 	filter := model.BeadFilter{
 		Assignee: req.GetAssignee(),
 		Labels:   req.GetLabels(),
 		Search:   req.GetSearch(),
 		Sort:     req.GetSort(),
+		Query:    req.GetQuery(),
 		Limit:    int(req.GetLimit()),
 		Offset:   int(req.GetOffset()),
+
+		IncludeArchived: req.GetIncludeArchived(),
 	}
 
 	for _, st := range req.GetStatus() {
@@ -183,6 +267,21 @@ func (s *BeadsServer) ListBeads(ctx context.Context, req *beadsv1.ListBeadsReque
 	if len(req.GetFieldFilters()) > 0 {
 		filter.Fields = req.GetFieldFilters()
 	}
+	filter.CreatedAfter = protoTimestamp(req.GetCreatedAfter())
+	filter.CreatedBefore = protoTimestamp(req.GetCreatedBefore())
+	filter.UpdatedAfter = protoTimestamp(req.GetUpdatedAfter())
+	filter.UpdatedBefore = protoTimestamp(req.GetUpdatedBefore())
+	filter.ClosedAfter = protoTimestamp(req.GetClosedAfter())
+	filter.ClosedBefore = protoTimestamp(req.GetClosedBefore())
+	return filter
+}
+
+// ListBeads returns a filtered, paginated list of beads.
+func (s *BeadsServer) ListBeads(ctx context.Context, req *beadsv1.ListBeadsRequest) (*beadsv1.ListBeadsResponse, error) {
+	filter := filterFromProto(req)
+	if err := checkQuery(filter); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	beads, total, err := s.store.ListBeads(ctx, filter)
 	if err != nil {
@@ -212,19 +311,137 @@ type updateBeadInput struct {
 	Owner       *string         `json:"owner,omitempty"`
 	DueAt       *time.Time      `json:"due_at,omitempty"`
 	DeferUntil  *time.Time      `json:"defer_until,omitempty"`
+	Estimate    *string         `json:"estimate,omitempty"`
+	Actual      *string         `json:"actual,omitempty"`
 	Fields      json.RawMessage `json:"fields,omitempty"`
 	Labels      []string        `json:"labels,omitempty"`
 
+	// Clear names fields to empty, for fields whose empty value cannot be
+	// told apart from an absent one (e.g. "due_at": null).
+	Clear []string `json:"clear,omitempty"`
+
+	// Append makes Description and Notes append to the existing text rather
+	// than replace it; notes are recorded as attributed entries.
+	Append    bool   `json:"-"`
+	UpdatedBy string `json:"updated_by,omitempty"`
+
 	// dueAtSet / deferUntilSet track whether the field was provided at all
 	// (since a nil *time.Time means "clear the field", distinct from "not provided").
 	dueAtSet      bool
 	deferUntilSet bool
 	labelsSet     bool
+
+	// fieldsPatch is applied to the fields as read, so each retry after a
+	// concurrent write patches the latest fields.
+	fieldsPatch []model.PatchOp
+}
+
+// empty reports whether the input changes no fields.
+func (in updateBeadInput) empty() bool {
+	return in.Title == nil && in.Description == nil && in.Notes == nil && in.Status == nil &&
+		in.Priority == nil && in.Assignee == nil && in.Owner == nil &&
+		in.Estimate == nil && in.Actual == nil &&
+		!in.dueAtSet && !in.deferUntilSet && in.Fields == nil && !in.labelsSet && in.fieldsPatch == nil
+}
+
+// clearableFields are the fields an update may name in Clear.
+var clearableFields = []string{"description", "notes", "assignee", "owner", "due_at", "defer_until", "estimate", "actual", "labels"}
+
+// applyClear folds the fields named in Clear into the input as explicit empty
+// values. A field may not be both set and cleared, and text being appended
+// to cannot be cleared.
+func (in *updateBeadInput) applyClear() error {
+	empty := ""
+	for _, f := range in.Clear {
+		var set bool
+		switch f {
+		case "description":
+			set, in.Description = in.Description != nil, &empty
+		case "notes":
+			set, in.Notes = in.Notes != nil, &empty
+		case "assignee":
+			set, in.Assignee = in.Assignee != nil, &empty
+		case "owner":
+			set, in.Owner = in.Owner != nil, &empty
+		case "due_at":
+			set, in.DueAt, in.dueAtSet = in.DueAt != nil, nil, true
+		case "defer_until":
+			set, in.DeferUntil, in.deferUntilSet = in.DeferUntil != nil, nil, true
+		case "estimate":
+			set, in.Estimate = in.Estimate != nil, &empty
+		case "actual":
+			set, in.Actual = in.Actual != nil, &empty
+		case "labels":
+			set, in.Labels, in.labelsSet = len(in.Labels) > 0, nil, true
+		default:
+			return inputError(fmt.Sprintf("cannot clear %q (clearable: %s)", f, strings.Join(clearableFields, ", ")))
+		}
+		if set {
+			return inputError(fmt.Sprintf("%s is both set and cleared", f))
+		}
+		if in.Append && (f == "description" || f == "notes") {
+			return inputError(fmt.Sprintf("cannot clear %s while appending", f))
+		}
+	}
+	return nil
 }
 
 // updateBead applies partial updates to an existing bead, persists them,
-// and publishes a BeadUpdated event. Returns inputError for validation failures.
+// and publishes a BeadUpdated event. Returns inputError for validation
+// failures and a *lockedError if another actor holds a lock on the bead.
+//
+// With in.Append, the other fields are applied first and the description and
+// notes are then appended atomically, so a validation failure appends nothing.
 func (s *BeadsServer) updateBead(ctx context.Context, id string, in updateBeadInput) (*model.Bead, error) {
+	if err := in.applyClear(); err != nil {
+		return nil, err
+	}
+	if !in.Append || (in.Description == nil && in.Notes == nil) {
+		return s.replaceFields(ctx, id, in)
+	}
+	if err := checkLock(ctx, s.store, id, s.actorFor(ctx, in.UpdatedBy)); err != nil {
+		return nil, err
+	}
+	description, notes := in.Description, in.Notes
+	in.Description, in.Notes = nil, nil
+	if !in.empty() {
+		if _, err := s.replaceFields(ctx, id, in); err != nil {
+			return nil, err
+		}
+	}
+	return s.appendToBead(ctx, id, in.UpdatedBy, description, notes)
+}
+
+// conflictRetries is how many times a read-modify-write of a bead is tried
+// before a concurrent writer's store.ErrConflict is returned.
+const conflictRetries = 3
+
+// retryOnConflict calls fn until it does not fail with store.ErrConflict,
+// at most conflictRetries times. fn must re-read what it modifies.
+func retryOnConflict[T any](fn func() (T, error)) (T, error) {
+	var (
+		v   T
+		err error
+	)
+	for range conflictRetries {
+		if v, err = fn(); !errors.Is(err, store.ErrConflict) {
+			break
+		}
+	}
+	return v, err
+}
+
+// replaceFields overwrites the fields set in the input. The bead is written
+// only if nothing else changed it since it was read, retrying otherwise, so
+// a concurrent note or description append is never lost.
+func (s *BeadsServer) replaceFields(ctx context.Context, id string, in updateBeadInput) (*model.Bead, error) {
+	return retryOnConflict(func() (*model.Bead, error) {
+		return s.replaceFieldsOnce(ctx, id, in)
+	})
+}
+
+// replaceFieldsOnce is a single attempt of replaceFields.
+func (s *BeadsServer) replaceFieldsOnce(ctx context.Context, id string, in updateBeadInput) (*model.Bead, error) {
 	bead, err := s.store.GetBead(ctx, id)
 	if err != nil {
 		return nil, err
@@ -247,7 +464,11 @@ func (s *BeadsServer) updateBead(ctx context.Context, id string, in updateBeadIn
 		bead.Notes = *in.Notes
 		changes["notes"] = bead.Notes
 	}
+	from := bead.Status
 	if in.Status != nil {
+		if err := s.checkTransition(ctx, from, model.Status(*in.Status)); err != nil {
+			return nil, err
+		}
 		bead.Status = model.Status(*in.Status)
 		changes["status"] = bead.Status
 	}
@@ -281,11 +502,33 @@ func (s *BeadsServer) updateBead(ctx context.Context, id string, in updateBeadIn
 		}
 		changes["defer_until"] = bead.DeferUntil
 	}
+	if in.Estimate != nil {
+		bead.Estimate = strings.TrimSpace(*in.Estimate)
+		changes["estimate"] = bead.Estimate
+	}
+	if in.Actual != nil {
+		bead.Actual = strings.TrimSpace(*in.Actual)
+		changes["actual"] = bead.Actual
+	}
 
 	if in.Fields != nil {
 		bead.Fields = in.Fields
 		changes["fields"] = bead.Fields
 	}
+	if in.fieldsPatch != nil {
+		fields, err := model.ApplyPatch(bead.Fields, in.fieldsPatch)
+		if errors.Is(err, model.ErrPatchTestFailed) {
+			return nil, err
+		}
+		if err != nil {
+			return nil, inputError("invalid patch: " + err.Error())
+		}
+		if !bytes.HasPrefix(fields, []byte("{")) {
+			return nil, inputError("invalid patch: fields must stay a JSON object")
+		}
+		bead.Fields = fields
+		changes["fields"] = bead.Fields
+	}
 	if in.labelsSet {
 		bead.Labels = in.Labels
 		changes["labels"] = bead.Labels
@@ -302,12 +545,16 @@ func (s *BeadsServer) updateBead(ctx context.Context, id string, in updateBeadIn
 		changes["closed_at"] = bead.ClosedAt
 	}
 
-	bead.UpdatedAt = time.Now().UTC()
-
 	if err := model.ValidateBead(bead); err != nil {
 		return nil, inputError("invalid bead: " + err.Error())
 	}
 
+	if in.labelsSet {
+		if err := s.checkLabels(ctx, bead.Labels); err != nil {
+			return nil, err
+		}
+	}
+
 	// Validate fields against type config if fields were changed.
 	if _, ok := changes["fields"]; ok {
 		tc, err := s.resolveTypeConfig(ctx, bead.Type)
@@ -321,29 +568,42 @@ func (s *BeadsServer) updateBead(ctx context.Context, id string, in updateBeadIn
 		}
 	}
 
-	if err := s.store.UpdateBead(ctx, bead); err != nil {
-		return nil, fmt.Errorf("failed to update bead: %w", err)
-	}
+	actor := s.actorFor(ctx, in.UpdatedBy)
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := checkLock(ctx, tx, bead.ID, actor); err != nil {
+			return err
+		}
+		if err := tx.UpdateBead(ctx, bead); err != nil {
+			return fmt.Errorf("failed to update bead: %w", err)
+		}
 
-	// Bug 1 fix: reconcile labels in the store.
-	if _, ok := changes["labels"]; ok {
-		if err := s.reconcileLabels(ctx, bead.ID, bead.Labels); err != nil {
-			return nil, fmt.Errorf("failed to reconcile labels: %w", err)
+		// Bug 1 fix: reconcile labels in the store.
+		if _, ok := changes["labels"]; ok {
+			if err := reconcileLabels(ctx, tx, bead.ID, bead.Labels); err != nil {
+				return fmt.Errorf("failed to reconcile labels: %w", err)
+			}
 		}
-	}
 
-	s.recordAndPublish(ctx, events.TopicBeadUpdated, bead.ID, "", events.BeadUpdated{
-		Bead:    bead,
-		Changes: changes,
+		if err := s.recordEvent(ctx, tx, events.TopicBeadUpdated, bead.ID, actor, events.BeadUpdated{
+			Bead:    bead,
+			Changes: changes,
+		}); err != nil {
+			return err
+		}
+		return s.recordTransition(ctx, tx, from, bead, actor)
 	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
 
 	return bead, nil
 }
 
 // reconcileLabels compares the desired labels with the existing labels in
-// the store and adds/removes as needed.
-func (s *BeadsServer) reconcileLabels(ctx context.Context, beadID string, newLabels []string) error {
-	existing, err := s.store.GetLabels(ctx, beadID)
+// st and adds/removes as needed.
+func reconcileLabels(ctx context.Context, st store.Store, beadID string, newLabels []string) error {
+	existing, err := st.GetLabels(ctx, beadID)
 	if err != nil {
 		return err
 	}
@@ -360,7 +620,7 @@ func (s *BeadsServer) reconcileLabels(ctx context.Context, beadID string, newLab
 	// Remove labels that are no longer desired.
 	for _, l := range existing {
 		if _, ok := newSet[l]; !ok {
-			if err := s.store.RemoveLabel(ctx, beadID, l); err != nil {
+			if err := st.RemoveLabel(ctx, beadID, l); err != nil {
 				return err
 			}
 		}
@@ -368,7 +628,7 @@ func (s *BeadsServer) reconcileLabels(ctx context.Context, beadID string, newLab
 	// Add labels that are new.
 	for _, l := range newLabels {
 		if _, ok := existingSet[l]; !ok {
-			if err := s.store.AddLabel(ctx, beadID, l); err != nil {
+			if err := st.AddLabel(ctx, beadID, l); err != nil {
 				return err
 			}
 		}
@@ -414,6 +674,12 @@ func (s *BeadsServer) UpdateBead(ctx context.Context, req *beadsv1.UpdateBeadReq
 		in.DeferUntil = protoTimestamp(req.DeferUntil)
 		in.deferUntilSet = true
 	}
+	if req.Estimate != nil {
+		in.Estimate = req.Estimate
+	}
+	if req.Actual != nil {
+		in.Actual = req.Actual
+	}
 	if req.Fields != nil {
 		in.Fields = json.RawMessage(req.Fields)
 	}
@@ -421,6 +687,9 @@ func (s *BeadsServer) UpdateBead(ctx context.Context, req *beadsv1.UpdateBeadReq
 		in.Labels = req.Labels
 		in.labelsSet = true
 	}
+	in.Clear = req.GetClear()
+	in.Append = req.GetAppend()
+	in.UpdatedBy = req.GetUpdatedBy()
 
 	bead, err := s.updateBead(ctx, req.GetId(), in)
 	if err != nil {
@@ -428,35 +697,261 @@ func (s *BeadsServer) UpdateBead(ctx context.Context, req *beadsv1.UpdateBeadReq
 		if errors.As(err, &ie) {
 			return nil, status.Error(codes.InvalidArgument, ie.Error())
 		}
+		var te *transitionError
+		if errors.As(err, &te) {
+			return nil, status.Error(codes.FailedPrecondition, te.Error())
+		}
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Error(codes.NotFound, "bead not found")
 		}
+		if errors.Is(err, store.ErrConflict) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		var le *lockedError
+		if errors.As(err, &le) {
+			return nil, le.status()
+		}
 		return nil, status.Errorf(codes.Internal, "%v", err)
 	}
 
 	return &beadsv1.UpdateBeadResponse{Bead: beadToProto(bead)}, nil
 }
 
+// closeBead closes a bead and records its BeadClosed event in the same
+// transaction. Returns sql.ErrNoRows if the bead does not exist.
+func (s *BeadsServer) closeBead(ctx context.Context, id, closedBy string) (*model.Bead, error) {
+	var bead *model.Bead
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		var err error
+		if bead, err = tx.CloseBead(ctx, id, closedBy); err != nil {
+			return err
+		}
+		if bead == nil {
+			return sql.ErrNoRows
+		}
+		return s.recordEvent(ctx, tx, events.TopicBeadClosed, bead.ID, closedBy, events.BeadClosed{
+			Bead:     bead,
+			ClosedBy: closedBy,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return bead, nil
+}
+
 // CloseBead marks a bead as closed.
 func (s *BeadsServer) CloseBead(ctx context.Context, req *beadsv1.CloseBeadRequest) (*beadsv1.CloseBeadResponse, error) {
 	if req.GetId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "id is required")
 	}
 
-	bead, err := s.store.CloseBead(ctx, req.GetId(), req.GetClosedBy())
+	closedBy := s.actorFor(ctx, req.GetClosedBy())
+	res, err := s.closeWithDependents(ctx, req.GetId(), closedBy, req.GetCascade())
 	if err != nil {
 		return nil, storeError(err, "bead")
 	}
+
+	resp := &beadsv1.CloseBeadResponse{Bead: beadToProto(res.Bead), Warnings: res.Warnings}
+	for _, b := range res.Unblocked {
+		resp.Unblocked = append(resp.Unblocked, beadToProto(b))
+	}
+	for _, b := range res.Cascaded {
+		resp.Cascaded = append(resp.Cascaded, beadToProto(b))
+	}
+	return resp, nil
+}
+
+// Cascade modes accepted by deleteBead.
+const (
+	cascadeNone   = ""
+	cascadeDetach = "detach" // remove inbound dependencies, keep the dependents
+	cascadeDelete = "delete" // delete every bead that transitively depends on the target
+)
+
+// dependentsError is returned by deleteBead when the bead has inbound
+// dependencies and no cascade mode was requested.
+// Transport layers map this to 409 / FailedPrecondition.
+type dependentsError struct {
+	BeadID     string
+	Dependents []*model.Dependency
+}
+
+func (e *dependentsError) Error() string {
+	parts := make([]string, len(e.Dependents))
+	for i, d := range e.Dependents {
+		parts[i] = fmt.Sprintf("%s (%s)", d.BeadID, d.Type)
+	}
+	return fmt.Sprintf("bead %s has %d dependent(s): %s; use cascade=detach or cascade=delete",
+		e.BeadID, len(e.Dependents), strings.Join(parts, ", "))
+}
+
+// deleteResult reports the beads deleted and the dependencies detached.
+type deleteResult struct {
+	DeletedIDs []string
+	Detached   []*model.Dependency
+}
+
+// deleteOptions controls deleteBead.
+type deleteOptions struct {
+	Cascade string // cascadeNone, cascadeDetach or cascadeDelete
+	Hard    bool   // delete permanently instead of moving to the trash
+	Actor   string // recorded as deleted_by for soft deletes
+}
+
+// deleteBead moves a bead to the trash, or deletes it permanently when
+// opts.Hard is set. It refuses with *dependentsError if other beads depend
+// on it through blocking or parent-child edges unless opts.Cascade is
+// "detach" or "delete"; relations and other links are always detached. A hard delete of a bead
+// that is already in the trash purges it. All writes happen in one
+// transaction; events are published afterwards.
+func (s *BeadsServer) deleteBead(ctx context.Context, id string, opts deleteOptions) (*deleteResult, error) {
+	cascade := opts.Cascade
+	if cascade != cascadeNone && cascade != cascadeDetach && cascade != cascadeDelete {
+		return nil, inputError("cascade must be detach or delete")
+	}
+	actor := s.actorFor(ctx, opts.Actor)
+
+	bead, err := s.store.GetBead(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 	if bead == nil {
-		return nil, status.Error(codes.NotFound, "bead not found")
+		if !opts.Hard {
+			return nil, sql.ErrNoRows
+		}
+		// Not live; purge it from the trash if it is there.
+		err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+			if err := tx.DeleteBead(ctx, id); err != nil {
+				return err
+			}
+			return s.recordEvent(ctx, tx, events.TopicBeadDeleted, id, actor, events.BeadDeleted{BeadID: id})
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.flushEvents(ctx)
+		return &deleteResult{DeletedIDs: []string{id}}, nil
 	}
 
-	s.recordAndPublish(ctx, events.TopicBeadClosed, bead.ID, req.GetClosedBy(), events.BeadClosed{
-		Bead:     bead,
-		ClosedBy: req.GetClosedBy(),
+	types, err := s.depTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Dependents are read in the transaction, so an edge added meanwhile
+	// cannot be left pointing at a deleted bead.
+	var res *deleteResult
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		var err error
+		if res, err = planDelete(ctx, tx, id, cascade, types); err != nil {
+			return err
+		}
+		for _, d := range res.Detached {
+			if err := tx.RemoveDependency(ctx, d.BeadID, d.DependsOnID, d.Type); err != nil {
+				return fmt.Errorf("failed to detach %s: %w", d.BeadID, err)
+			}
+		}
+		// Dependents first, so the target goes last.
+		for i := len(res.DeletedIDs) - 1; i >= 0; i-- {
+			var err error
+			if opts.Hard {
+				err = tx.DeleteBead(ctx, res.DeletedIDs[i])
+			} else {
+				err = tx.SoftDeleteBead(ctx, res.DeletedIDs[i], actor)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, d := range res.Detached {
+			if err := s.recordEvent(ctx, tx, events.TopicDependencyRemoved, d.BeadID, actor, events.DependencyRemoved{
+				BeadID:      d.BeadID,
+				DependsOnID: d.DependsOnID,
+				Type:        string(d.Type),
+			}); err != nil {
+				return err
+			}
+		}
+		for _, deleted := range res.DeletedIDs {
+			if err := s.recordEvent(ctx, tx, events.TopicBeadDeleted, deleted, actor, events.BeadDeleted{
+				BeadID: deleted,
+				Soft:   !opts.Hard,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
 
-	return &beadsv1.CloseBeadResponse{Bead: beadToProto(bead)}, nil
+	return res, nil
+}
+
+// planDelete reads through st what deleting id with the given cascade
+// involves: the beads to delete, target first, and the dependencies to
+// detach. It returns *dependentsError if id is held in place and cascade is
+// cascadeNone.
+func planDelete(ctx context.Context, st store.Store, id, cascade string, types depTypeSet) (*deleteResult, error) {
+	// Only blocking and parent-child edges hold a bead in place; relations
+	// and other informational links into deleted beads are detached.
+	var links []*model.Dependency
+	dependentsOf := func(id string) ([]*model.Dependency, error) {
+		inbound, err := st.GetDependents(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependents: %w", err)
+		}
+		var holding []*model.Dependency
+		for _, d := range inbound {
+			if d.Type == model.DepParentChild || types.blocking(d.Type) {
+				holding = append(holding, d)
+			} else {
+				links = append(links, d)
+			}
+		}
+		return holding, nil
+	}
+	dependents, err := dependentsOf(id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &deleteResult{DeletedIDs: []string{id}}
+	switch {
+	case len(dependents) == 0:
+	case cascade == cascadeNone:
+		return nil, &dependentsError{BeadID: id, Dependents: dependents}
+	case cascade == cascadeDetach:
+		res.Detached = dependents
+	case cascade == cascadeDelete:
+		// Breadth-first over inbound edges collects everything that would
+		// otherwise be left depending on a deleted bead.
+		seen := map[string]bool{id: true}
+		for i := 0; i < len(res.DeletedIDs); i++ {
+			deps := dependents
+			if i > 0 {
+				if deps, err = dependentsOf(res.DeletedIDs[i]); err != nil {
+					return nil, err
+				}
+			}
+			for _, d := range deps {
+				if !seen[d.BeadID] {
+					seen[d.BeadID] = true
+					res.DeletedIDs = append(res.DeletedIDs, d.BeadID)
+				}
+			}
+		}
+	}
+	for _, d := range links {
+		if !slices.Contains(res.DeletedIDs, d.BeadID) {
+			res.Detached = append(res.Detached, d)
+		}
+	}
+	return res, nil
 }
 
 // DeleteBead removes a bead by ID.
@@ -465,7 +960,20 @@ func (s *BeadsServer) DeleteBead(ctx context.Context, req *beadsv1.DeleteBeadReq
 		return nil, status.Error(codes.InvalidArgument, "id is required")
 	}
 
-	if err := s.store.DeleteBead(ctx, req.GetId()); err != nil {
+	res, err := s.deleteBead(ctx, req.GetId(), deleteOptions{
+		Cascade: req.GetCascade(),
+		Hard:    req.GetHard(),
+		Actor:   req.GetDeletedBy(),
+	})
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		var de *dependentsError
+		if errors.As(err, &de) {
+			return nil, status.Error(codes.FailedPrecondition, de.Error())
+		}
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Error(codes.NotFound, "bead not found")
 		}
@@ -476,7 +984,9 @@ func (s *BeadsServer) DeleteBead(ctx context.Context, req *beadsv1.DeleteBeadReq
 		return nil, status.Errorf(codes.Internal, "failed to delete bead: %v", err)
 	}
 
-	s.recordAndPublish(ctx, events.TopicBeadDeleted, req.GetId(), "", events.BeadDeleted{BeadID: req.GetId()})
-
-	return &beadsv1.DeleteBeadResponse{}, nil
+	resp := &beadsv1.DeleteBeadResponse{DeletedIds: res.DeletedIDs}
+	for _, d := range res.Detached {
+		resp.Detached = append(resp.Detached, dependencyToProto(d))
+	}
+	return resp, nil
 }
@@ -1,12 +1,18 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
 	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -121,6 +127,107 @@ func TestGRPCDeleteBead(t *testing.T) {
 	requireEvent(t, ms, 1, "beads.bead.deleted")
 }
 
+func TestGRPCDeleteBead_Dependents(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-a"] = &model.Bead{ID: "bd-a", Title: "Target", Status: model.StatusOpen}
+	ms.beads["bd-b"] = &model.Bead{ID: "bd-b", Title: "Dependent", Status: model.StatusOpen}
+	ms.deps["bd-b"] = []*model.Dependency{{BeadID: "bd-b", DependsOnID: "bd-a", Type: model.DepBlocks}}
+
+	_, err := srv.DeleteBead(ctx, &beadsv1.DeleteBeadRequest{Id: "bd-a"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "bd-b (blocks)") {
+		t.Fatalf("expected dependents in message, got %q", err)
+	}
+	if _, ok := ms.beads["bd-a"]; !ok {
+		t.Fatal("expected bead to be kept")
+	}
+	if len(ms.events) != 0 {
+		t.Fatalf("expected no events, got %d", len(ms.events))
+	}
+}
+
+func TestGRPCDeleteBead_CascadeDetach(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-a"] = &model.Bead{ID: "bd-a", Title: "Target", Status: model.StatusOpen}
+	ms.beads["bd-b"] = &model.Bead{ID: "bd-b", Title: "Dependent", Status: model.StatusOpen}
+	ms.deps["bd-b"] = []*model.Dependency{{BeadID: "bd-b", DependsOnID: "bd-a", Type: model.DepBlocks}}
+
+	resp, err := srv.DeleteBead(ctx, &beadsv1.DeleteBeadRequest{Id: "bd-a", Cascade: "detach"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.DeletedIds) != 1 || len(resp.Detached) != 1 || resp.Detached[0].BeadId != "bd-b" {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+	if _, ok := ms.beads["bd-b"]; !ok {
+		t.Fatal("expected dependent to be kept")
+	}
+	if len(ms.deps["bd-b"]) != 0 {
+		t.Fatalf("expected dependency removed, got %v", ms.deps["bd-b"])
+	}
+	if len(ms.events) != 2 || ms.events[0].Topic != "beads.dependency.removed" {
+		t.Fatalf("unexpected events: %+v", ms.events)
+	}
+	requireEvent(t, ms, 2, "beads.bead.deleted")
+}
+
+func TestGRPCDeleteBead_CascadeDelete(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	for _, id := range []string{"bd-a", "bd-b", "bd-c", "bd-d"} {
+		ms.beads[id] = &model.Bead{ID: id, Title: id, Status: model.StatusOpen}
+	}
+	// bd-c -> bd-b -> bd-a; bd-d is unrelated.
+	ms.deps["bd-b"] = []*model.Dependency{{BeadID: "bd-b", DependsOnID: "bd-a", Type: model.DepBlocks}}
+	ms.deps["bd-c"] = []*model.Dependency{{BeadID: "bd-c", DependsOnID: "bd-b", Type: model.DepBlocks}}
+
+	resp, err := srv.DeleteBead(ctx, &beadsv1.DeleteBeadRequest{Id: "bd-a", Cascade: "delete"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(resp.DeletedIds) != "[bd-a bd-b bd-c]" {
+		t.Fatalf("got deleted_ids=%v", resp.DeletedIds)
+	}
+	if len(ms.beads) != 1 || ms.beads["bd-d"] == nil {
+		t.Fatalf("expected only bd-d to remain, got %v", ms.beads)
+	}
+	requireEvent(t, ms, 3, "beads.bead.deleted")
+}
+
+// txDependentsStore fails GetDependents outside a transaction.
+type txDependentsStore struct{ *mockStore }
+
+func (s txDependentsStore) GetDependents(context.Context, string) ([]*model.Dependency, error) {
+	return nil, errors.New("dependents read outside the transaction")
+}
+
+func (s txDependentsStore) RunInTransaction(_ context.Context, fn func(tx store.Store) error) error {
+	return fn(s.mockStore)
+}
+
+func TestGRPCDeleteBead_ReadsDependentsInTransaction(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	srv.store = txDependentsStore{ms}
+	for _, id := range []string{"bd-a", "bd-b", "bd-c"} {
+		ms.beads[id] = &model.Bead{ID: id, Title: id, Status: model.StatusOpen}
+	}
+	ms.deps["bd-b"] = []*model.Dependency{{BeadID: "bd-b", DependsOnID: "bd-a", Type: model.DepBlocks}}
+	ms.deps["bd-c"] = []*model.Dependency{{BeadID: "bd-c", DependsOnID: "bd-b", Type: model.DepParentChild}}
+
+	_, err := srv.DeleteBead(ctx, &beadsv1.DeleteBeadRequest{Id: "bd-a"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	resp, err := srv.DeleteBead(ctx, &beadsv1.DeleteBeadRequest{Id: "bd-a", Cascade: "delete"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(resp.DeletedIds) != "[bd-a bd-b bd-c]" {
+		t.Fatalf("got deleted_ids=%v", resp.DeletedIds)
+	}
+}
+
 func TestGRPCUpdateBead_ClearDeferUntil(t *testing.T) {
 	srv, ms, ctx := testCtx(t)
 	future := time.Now().Add(24 * time.Hour)
@@ -243,3 +350,17 @@ func TestGRPCCreateBead_LabelFailure_ReturnsError(t *testing.T) {
 		t.Fatal("expected error when AddLabel fails")
 	}
 }
+
+func TestUpdateBead_ClearGRPC(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-upd6"] = &model.Bead{ID: "bd-upd6", Title: "Owned", Kind: model.KindIssue, Type: model.TypeTask, Status: model.StatusOpen, Owner: "alice"}
+	resp, err := srv.UpdateBead(ctx, &beadsv1.UpdateBeadRequest{Id: "bd-upd6", Clear: []string{"owner"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetBead().GetOwner() != "" {
+		t.Errorf("owner = %q after clear", resp.GetBead().GetOwner())
+	}
+	_, err = srv.UpdateBead(ctx, &beadsv1.UpdateBeadRequest{Id: "bd-upd6", Clear: []string{"priority"}})
+	requireCode(t, err, codes.InvalidArgument)
+}
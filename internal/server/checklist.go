@@ -0,0 +1,174 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxChecklistItems caps the items on one bead's checklist.
+const maxChecklistItems = 100
+
+// addChecklistItems appends an item per text to beadID's checklist, added
+// by actor, and records their event. Returns inputError for empty or
+// oversized texts or a full checklist, and sql.ErrNoRows if the bead does
+// not exist.
+func (s *BeadsServer) addChecklistItems(ctx context.Context, beadID string, texts []string, actor string) ([]*model.ChecklistItem, error) {
+	if len(texts) == 0 {
+		return nil, inputError("items is required")
+	}
+	for i, t := range texts {
+		texts[i] = strings.TrimSpace(t)
+		if err := model.ValidateChecklistText(texts[i]); err != nil {
+			return nil, inputError(err.Error())
+		}
+	}
+	items, err := retryOnConflict(func() ([]*model.ChecklistItem, error) {
+		var items []*model.ChecklistItem
+		err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+			if err := requireBead(ctx, tx, beadID); err != nil {
+				return err
+			}
+			existing, err := tx.GetChecklist(ctx, beadID)
+			if err != nil {
+				return err
+			}
+			if len(existing)+len(texts) > maxChecklistItems {
+				return inputError(fmt.Sprintf("a checklist holds at most %d items", maxChecklistItems))
+			}
+			if items, err = tx.AddChecklistItems(ctx, beadID, texts, actor); err != nil {
+				return err
+			}
+			return s.recordEvent(ctx, tx, events.TopicChecklistAdded, beadID, actor, events.ChecklistAdded{BeadID: beadID, Items: items})
+		})
+		return items, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return items, nil
+}
+
+// checkChecklistItem checks item index of beadID's checklist by actor, or
+// unchecks it when checked is false, and records its event. Returns
+// sql.ErrNoRows if the bead or the item does not exist.
+func (s *BeadsServer) checkChecklistItem(ctx context.Context, beadID string, index int, actor string, checked bool) (*model.ChecklistItem, error) {
+	var item *model.ChecklistItem
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := requireBead(ctx, tx, beadID); err != nil {
+			return err
+		}
+		var err error
+		if item, err = tx.CheckChecklistItem(ctx, beadID, index, actor, checked); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicChecklistChecked, beadID, actor, events.ChecklistChecked{Item: item})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return item, nil
+}
+
+// writeChecklistResult writes v with status, or the error from reading or
+// changing a checklist.
+func writeChecklistResult(w http.ResponseWriter, status int, v any, err error) {
+	var ie inputError
+	switch {
+	case errors.As(err, &ie):
+		writeError(w, http.StatusBadRequest, ie.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "bead or checklist item not found")
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "failed to update checklist")
+	default:
+		writeJSON(w, status, v)
+	}
+}
+
+// handleGetChecklist handles GET /v1/beads/{id}/checklist.
+func (s *BeadsServer) handleGetChecklist(w http.ResponseWriter, r *http.Request) {
+	items, err := s.store.GetChecklist(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get checklist")
+		return
+	}
+	done := 0
+	for _, it := range items {
+		if it.Checked {
+			done++
+		}
+	}
+	if items == nil {
+		items = []*model.ChecklistItem{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items, "done": done, "total": len(items)})
+}
+
+// addChecklistRequest is the JSON body for POST /v1/beads/{id}/checklist.
+type addChecklistRequest struct {
+	Items []string `json:"items"`
+	Actor string   `json:"actor"`
+}
+
+// handleAddChecklist handles POST /v1/beads/{id}/checklist. It answers 201
+// with the items added.
+func (s *BeadsServer) handleAddChecklist(w http.ResponseWriter, r *http.Request) {
+	var req addChecklistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	items, err := s.addChecklistItems(r.Context(), r.PathValue("id"), req.Items, s.actorFor(r.Context(), req.Actor))
+	writeChecklistResult(w, http.StatusCreated, map[string]any{"items": items}, err)
+}
+
+// checkChecklistRequest is the JSON body for POST
+// /v1/beads/{id}/checklist/{index}/check.
+type checkChecklistRequest struct {
+	Checked *bool  `json:"checked"` // default true; false unchecks the item
+	Actor   string `json:"actor"`
+}
+
+// handleCheckChecklist handles POST /v1/beads/{id}/checklist/{index}/check.
+func (s *BeadsServer) handleCheckChecklist(w http.ResponseWriter, r *http.Request) {
+	index, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil || index < 1 {
+		writeError(w, http.StatusBadRequest, "invalid checklist item index")
+		return
+	}
+	var req checkChecklistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	checked := req.Checked == nil || *req.Checked
+	item, err := s.checkChecklistItem(r.Context(), r.PathValue("id"), index, s.actorFor(r.Context(), req.Actor), checked)
+	writeChecklistResult(w, http.StatusOK, item, err)
+}
+
+func checklistItemToProto(it *model.ChecklistItem) *beadsv1.ChecklistItem {
+	pb := &beadsv1.ChecklistItem{
+		Index:     int32(it.Index),
+		Text:      it.Text,
+		Checked:   it.Checked,
+		CheckedBy: it.CheckedBy,
+	}
+	if it.CheckedAt != nil {
+		pb.CheckedAt = timestamppb.New(*it.CheckedAt)
+	}
+	return pb
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestChecklist(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-k1"] = &model.Bead{ID: "bd-k1", Title: "Ship login", Status: model.StatusOpen}
+
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-k1/checklist", map[string]any{"items": []string{" Docs updated ", "Tests pass"}, "actor": "alice"})
+	requireStatus(t, rec, 201)
+	var added struct {
+		Items []model.ChecklistItem `json:"items"`
+	}
+	decodeJSON(t, rec, &added)
+	if len(added.Items) != 2 || added.Items[0].Index != 1 || added.Items[0].Text != "Docs updated" || added.Items[1].CreatedBy != "alice" {
+		t.Fatalf("added = %+v", added.Items)
+	}
+	requireEvent(t, ms, 1, "beads.checklist.added")
+
+	rec = doJSON(t, h, "POST", "/v1/beads/bd-k1/checklist/2/check", map[string]any{"actor": "bob"})
+	requireStatus(t, rec, 200)
+	var item model.ChecklistItem
+	decodeJSON(t, rec, &item)
+	if !item.Checked || item.CheckedBy != "bob" || item.CheckedAt == nil {
+		t.Fatalf("checked item = %+v", item)
+	}
+	requireEvent(t, ms, 2, "beads.checklist.checked")
+
+	rec = doJSON(t, h, "GET", "/v1/beads/bd-k1/checklist", nil)
+	requireStatus(t, rec, 200)
+	var list struct {
+		Items []model.ChecklistItem `json:"items"`
+		Done  int                   `json:"done"`
+		Total int                   `json:"total"`
+	}
+	decodeJSON(t, rec, &list)
+	if list.Done != 1 || list.Total != 2 || list.Items[0].Checked || !list.Items[1].Checked {
+		t.Fatalf("checklist = %+v", list)
+	}
+
+	rec = doJSON(t, h, "POST", "/v1/beads/bd-k1/checklist/2/check", map[string]any{"checked": false})
+	requireStatus(t, rec, 200)
+	var unchecked model.ChecklistItem
+	decodeJSON(t, rec, &unchecked)
+	if unchecked.Checked || unchecked.CheckedBy != "" || unchecked.CheckedAt != nil {
+		t.Fatalf("unchecked item = %+v", unchecked)
+	}
+
+	// Items are numbered after the last one.
+	rec = doJSON(t, h, "POST", "/v1/beads/bd-k1/checklist", map[string]any{"items": []string{"Changelog"}})
+	requireStatus(t, rec, 201)
+	decodeJSON(t, rec, &added)
+	if added.Items[0].Index != 3 {
+		t.Fatalf("new item index = %d, want 3", added.Items[0].Index)
+	}
+}
+
+func TestChecklist_Errors(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-k2"] = &model.Bead{ID: "bd-k2", Title: "Ship", Status: model.StatusOpen}
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-k2/checklist", map[string]any{"items": []string{}}), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-k2/checklist", map[string]any{"items": []string{"  "}}), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-k2/checklist", map[string]any{"items": []string{strings.Repeat("x", model.MaxChecklistItemLength+1)}}), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-missing/checklist", map[string]any{"items": []string{"A"}}), 404)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-k2/checklist/0/check", map[string]any{}), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-k2/checklist/1/check", map[string]any{}), 404)
+
+	full := make([]string, maxChecklistItems)
+	for i := range full {
+		full[i] = "item"
+	}
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-k2/checklist", map[string]any{"items": full}), 201)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-k2/checklist", map[string]any{"items": []string{"one more"}}), 400)
+	if len(ms.events) != 1 {
+		t.Fatalf("recorded %d events, want 1", len(ms.events))
+	}
+}
+
+func TestDescribeEvent_Checklist(t *testing.T) {
+	for _, tc := range []struct {
+		topic, payload, want string
+	}{
+		{"beads.checklist.added", `{"bead_id":"bd-1","items":[{"index":1,"text":"Docs"}]}`, "added checklist item: Docs"},
+		{"beads.checklist.added", `{"bead_id":"bd-1","items":[{"index":1},{"index":2}]}`, "added 2 checklist items"},
+		{"beads.checklist.checked", `{"item":{"index":2,"text":"Tests","checked":true}}`, "checked item 2: Tests"},
+		{"beads.checklist.checked", `{"item":{"index":2,"text":"Tests"}}`, "unchecked item 2: Tests"},
+	} {
+		kind, summary := describeEvent(&model.Event{Topic: tc.topic, Payload: []byte(tc.payload)})
+		if kind != activityChecklist || summary != tc.want {
+			t.Errorf("%s: got %s %q, want %q", tc.topic, kind, summary, tc.want)
+		}
+	}
+}
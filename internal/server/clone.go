@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cloneBeadInput holds transport-agnostic options for cloning a bead. Nil
+// options keep the source's value; the copy_* options default to true.
+type cloneBeadInput struct {
+	Title            *string `json:"title,omitempty"`
+	Assignee         *string `json:"assignee,omitempty"` // "" leaves the clone unassigned
+	CopyLabels       *bool   `json:"copy_labels,omitempty"`
+	CopyFields       *bool   `json:"copy_fields,omitempty"`
+	CopyDependencies *bool   `json:"copy_dependencies,omitempty"`
+	ClonedBy         string  `json:"cloned_by,omitempty"`
+}
+
+// cloneBead creates a new open bead from bead id with its type, priority,
+// description and owner. Notes, comments, history and dates are not copied,
+// and only the source's outgoing dependencies are: the clone waits on the
+// same blockers and sits under the same parent, but nothing depends on it.
+// Returns sql.ErrNoRows if the source does not exist.
+func (s *BeadsServer) cloneBead(ctx context.Context, id string, in cloneBeadInput) (*model.Bead, error) {
+	src, err := s.store.GetBead(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if src == nil {
+		return nil, sql.ErrNoRows
+	}
+	actor := s.actorFor(ctx, in.ClonedBy)
+
+	create := createBeadInput{
+		Title:       src.Title,
+		Kind:        string(src.Kind),
+		Type:        string(src.Type),
+		Description: src.Description,
+		Priority:    src.Priority,
+		Assignee:    src.Assignee,
+		Owner:       src.Owner,
+		CreatedBy:   actor,
+	}
+	if in.Title != nil {
+		create.Title = *in.Title
+	}
+	if in.Assignee != nil {
+		create.Assignee = *in.Assignee
+	}
+	if in.CopyLabels == nil || *in.CopyLabels {
+		create.Labels = src.Labels
+	}
+	if in.CopyFields == nil || *in.CopyFields {
+		create.Fields = src.Fields
+	}
+
+	var deps []*model.Dependency
+	if in.CopyDependencies == nil || *in.CopyDependencies {
+		if deps, err = s.store.GetDependencies(ctx, src.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	bead, err := s.prepareBead(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	err = s.withFreeSlug(ctx, bead, func() error {
+		return s.store.RunInTransaction(ctx, func(tx store.Store) error {
+			if err := s.insertBead(ctx, tx, bead); err != nil {
+				return err
+			}
+			for _, d := range deps {
+				dep := &model.Dependency{
+					BeadID:      bead.ID,
+					DependsOnID: d.DependsOnID,
+					Type:        d.Type,
+					CreatedAt:   bead.CreatedAt,
+					CreatedBy:   actor,
+					Metadata:    d.Metadata,
+				}
+				if err := s.insertDependency(ctx, tx, dep); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	if len(deps) == 0 {
+		return bead, nil
+	}
+	return s.store.GetBead(ctx, bead.ID)
+}
+
+// handleCloneBead handles POST /v1/beads/{id}/clone.
+func (s *BeadsServer) handleCloneBead(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var in cloneBeadInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	bead, err := s.cloneBead(r.Context(), id, in)
+	if err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "bead not found")
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to clone bead")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, bead)
+}
+
+// CloneBead creates a new bead from an existing one.
+func (s *BeadsServer) CloneBead(ctx context.Context, req *beadsv1.CloneBeadRequest) (*beadsv1.CloneBeadResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	bead, err := s.cloneBead(ctx, req.GetId(), cloneBeadInput{
+		Title:            req.Title,
+		Assignee:         req.Assignee,
+		CopyLabels:       req.CopyLabels,
+		CopyFields:       req.CopyFields,
+		CopyDependencies: req.CopyDependencies,
+		ClonedBy:         req.GetClonedBy(),
+	})
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "bead not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to clone bead: %v", err)
+	}
+
+	return &beadsv1.CloneBeadResponse{Bead: beadToProto(bead)}, nil
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandleCloneBead(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-tpl"] = &model.Bead{
+		ID: "bd-tpl", Kind: model.KindIssue, Type: "gate", Title: "Release checklist",
+		Description: "1. Tag\n2. Publish", Notes: "last run went fine", Status: model.StatusClosed,
+		Priority: 2, Assignee: "alice", Fields: json.RawMessage(`{"gate":"release"}`),
+	}
+	ms.beads["bd-epic"] = &model.Bead{ID: "bd-epic", Title: "Releases", Status: model.StatusOpen}
+	ms.labels["bd-tpl"] = []string{"release"}
+	ms.deps["bd-tpl"] = []*model.Dependency{{BeadID: "bd-tpl", DependsOnID: "bd-epic", Type: model.DepParentChild}}
+
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-tpl/clone", map[string]any{"title": "Release 1.4", "assignee": "bob", "cloned_by": "carol"})
+	requireStatus(t, rec, 201)
+	var clone model.Bead
+	decodeJSON(t, rec, &clone)
+
+	if clone.ID == "bd-tpl" || clone.Title != "Release 1.4" || clone.Assignee != "bob" || clone.CreatedBy != "carol" {
+		t.Fatalf("unexpected clone: %+v", clone)
+	}
+	if clone.Status != model.StatusOpen || clone.Notes != "" || clone.Description != "1. Tag\n2. Publish" || clone.Priority != 2 {
+		t.Fatalf("clone did not start fresh from the template: %+v", clone)
+	}
+	if len(clone.Labels) != 1 || clone.Labels[0] != "release" || string(clone.Fields) != `{"gate":"release"}` {
+		t.Fatalf("labels or fields not copied: %+v", clone)
+	}
+	deps := ms.deps[clone.ID]
+	if len(deps) != 1 || deps[0].DependsOnID != "bd-epic" || deps[0].Type != model.DepParentChild {
+		t.Fatalf("dependencies not copied: %+v", deps)
+	}
+	requireEvent(t, ms, 2, "beads.dependency.added")
+}
+
+func TestHandleCloneBead_Options(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-tpl"] = &model.Bead{ID: "bd-tpl", Kind: model.KindIssue, Type: model.TypeTask, Title: "Checklist", Status: model.StatusOpen, Assignee: "alice"}
+	ms.beads["bd-b"] = &model.Bead{ID: "bd-b", Title: "Blocker", Status: model.StatusOpen}
+	ms.labels["bd-tpl"] = []string{"release"}
+	ms.deps["bd-tpl"] = []*model.Dependency{{BeadID: "bd-tpl", DependsOnID: "bd-b", Type: model.DepBlocks}}
+
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-tpl/clone", map[string]any{"assignee": "", "copy_labels": false, "copy_dependencies": false})
+	requireStatus(t, rec, 201)
+	var clone model.Bead
+	decodeJSON(t, rec, &clone)
+	if clone.Title != "Checklist" || clone.Assignee != "" || len(clone.Labels) != 0 || len(ms.deps[clone.ID]) != 0 {
+		t.Fatalf("options not applied: %+v, deps %+v", clone, ms.deps[clone.ID])
+	}
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-missing/clone", nil), 404)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-tpl/clone", map[string]any{"title": ""}), 400)
+}
+
+func TestCloneBeadGRPC(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-g1"] = &model.Bead{ID: "bd-g1", Kind: model.KindIssue, Type: model.TypeTask, Title: "Checklist", Status: model.StatusOpen}
+
+	resp, err := srv.CloneBead(ctx, &beadsv1.CloneBeadRequest{Id: "bd-g1", Title: proto.String("Checklist v2")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetBead().GetTitle() != "Checklist v2" || resp.GetBead().GetId() == "bd-g1" {
+		t.Fatalf("unexpected clone: %+v", resp.GetBead())
+	}
+
+	_, err = srv.CloneBead(ctx, &beadsv1.CloneBeadRequest{Id: "bd-missing"})
+	requireCode(t, err, codes.NotFound)
+}
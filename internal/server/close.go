@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// closeResult is a closed bead and what closing it changed for the beads
+// around it.
+type closeResult struct {
+	*model.Bead
+	Unblocked []*model.Bead `json:"unblocked"`          // dependents left with no unclosed blocker
+	Cascaded  []*model.Bead `json:"cascaded,omitempty"` // children closed along with the bead
+	Warnings  []string      `json:"warnings,omitempty"`
+}
+
+// closeWithDependents closes a bead and reports the dependents it unblocks.
+// It warns when the bead is closed ahead of its own unclosed blockers, since
+// its dependents were ordered after them. With cascade, the bead's unclosed
+// children (parent-child dependents, recursively) are closed in the same
+// transaction, except those in progress, which are left open with a warning.
+func (s *BeadsServer) closeWithDependents(ctx context.Context, id, closedBy string, cascade bool) (*closeResult, error) {
+	bead, err := s.store.GetBead(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if bead == nil {
+		return nil, sql.ErrNoRows
+	}
+	types, err := s.depTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &closeResult{Unblocked: []*model.Bead{}}
+	blockers, err := s.unclosedBlockers(ctx, types, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range blockers {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("%s is closed before its blocker %s (%s)", id, b.ID, b.Status))
+	}
+
+	var children []*model.Bead
+	if cascade {
+		if children, err = s.closableChildren(ctx, id, res); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		// Children first, so the parent is never closed alone on failure.
+		for _, c := range children {
+			closed, err := tx.CloseBead(ctx, c.ID, closedBy)
+			if err != nil {
+				return err
+			}
+			if err := s.recordEvent(ctx, tx, events.TopicBeadClosed, c.ID, closedBy, events.BeadClosed{Bead: closed, ClosedBy: closedBy}); err != nil {
+				return err
+			}
+			res.Cascaded = append(res.Cascaded, closed)
+		}
+		closed, err := tx.CloseBead(ctx, id, closedBy)
+		if err != nil {
+			return err
+		}
+		if closed == nil {
+			return sql.ErrNoRows
+		}
+		res.Bead = closed
+		return s.recordEvent(ctx, tx, events.TopicBeadClosed, id, closedBy, events.BeadClosed{Bead: closed, ClosedBy: closedBy})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+
+	closedIDs := []string{id}
+	for _, c := range res.Cascaded {
+		closedIDs = append(closedIDs, c.ID)
+	}
+	if res.Unblocked, err = s.unblockedBy(ctx, types, closedIDs); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// unclosedBlockers returns the beads that block id and are not closed.
+func (s *BeadsServer) unclosedBlockers(ctx context.Context, types depTypeSet, id string) ([]*model.Bead, error) {
+	deps, err := s.store.GetDependencies(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var blockers []*model.Bead
+	for _, d := range deps {
+		if !types.blocking(d.Type) {
+			continue
+		}
+		b, err := s.store.GetBead(ctx, d.DependsOnID)
+		if err == nil && b != nil && b.Status != model.StatusClosed {
+			blockers = append(blockers, b)
+		}
+	}
+	return blockers, nil
+}
+
+// closableChildren returns the unclosed descendants of id through
+// parent-child dependencies, deepest first. Children in progress are
+// skipped along with their own children, and noted in res.Warnings.
+func (s *BeadsServer) closableChildren(ctx context.Context, id string, res *closeResult) ([]*model.Bead, error) {
+	var out []*model.Bead
+	seen := map[string]bool{id: true}
+	var walk func(parent string) error
+	walk = func(parent string) error {
+		deps, err := s.store.GetDependents(ctx, parent)
+		if err != nil {
+			return err
+		}
+		for _, d := range deps {
+			if d.Type != model.DepParentChild || seen[d.BeadID] {
+				continue
+			}
+			seen[d.BeadID] = true
+			child, err := s.store.GetBead(ctx, d.BeadID)
+			if err != nil || child == nil || child.Status == model.StatusClosed {
+				continue
+			}
+			if child.Status == model.StatusInProgress {
+				res.Warnings = append(res.Warnings, fmt.Sprintf("child %s is in progress; left open", child.ID))
+				continue
+			}
+			if err := walk(child.ID); err != nil {
+				return err
+			}
+			out = append(out, child)
+		}
+		return nil
+	}
+	if err := walk(id); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// unblockedBy returns the unclosed beads blocked by any of closedIDs that no
+// longer have an unclosed blocker.
+func (s *BeadsServer) unblockedBy(ctx context.Context, types depTypeSet, closedIDs []string) ([]*model.Bead, error) {
+	unblocked := []*model.Bead{}
+	seen := map[string]bool{}
+	for _, id := range closedIDs {
+		seen[id] = true
+	}
+	for _, id := range closedIDs {
+		deps, err := s.store.GetDependents(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range deps {
+			if !types.blocking(d.Type) || seen[d.BeadID] {
+				continue
+			}
+			seen[d.BeadID] = true
+			dependent, err := s.store.GetBead(ctx, d.BeadID)
+			if err != nil || dependent == nil || dependent.Status == model.StatusClosed {
+				continue
+			}
+			blockers, err := s.unclosedBlockers(ctx, types, dependent.ID)
+			if err != nil {
+				return nil, err
+			}
+			if len(blockers) == 0 {
+				unblocked = append(unblocked, dependent)
+			}
+		}
+	}
+	return unblocked, nil
+}
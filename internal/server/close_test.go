@@ -0,0 +1,91 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// seedCloseGraph builds an epic with two children, one in progress, and
+// two beads blocked by the epic, one of which is also blocked elsewhere.
+func seedCloseGraph(ms *mockStore) {
+	for id, st := range map[string]model.Status{
+		"bd-epic": model.StatusOpen, "bd-child": model.StatusOpen, "bd-grandchild": model.StatusOpen,
+		"bd-busy": model.StatusInProgress, "bd-next": model.StatusOpen, "bd-later": model.StatusOpen,
+		"bd-other": model.StatusOpen, "bd-first": model.StatusOpen,
+	} {
+		ms.beads[id] = &model.Bead{ID: id, Title: id, Status: st}
+	}
+	ms.deps["bd-epic"] = []*model.Dependency{{BeadID: "bd-epic", DependsOnID: "bd-first", Type: model.DepBlocks}}
+	ms.deps["bd-child"] = []*model.Dependency{{BeadID: "bd-child", DependsOnID: "bd-epic", Type: model.DepParentChild}}
+	ms.deps["bd-grandchild"] = []*model.Dependency{{BeadID: "bd-grandchild", DependsOnID: "bd-child", Type: model.DepParentChild}}
+	ms.deps["bd-busy"] = []*model.Dependency{{BeadID: "bd-busy", DependsOnID: "bd-epic", Type: model.DepParentChild}}
+	ms.deps["bd-next"] = []*model.Dependency{{BeadID: "bd-next", DependsOnID: "bd-epic", Type: model.DepBlocks}}
+	ms.deps["bd-later"] = []*model.Dependency{
+		{BeadID: "bd-later", DependsOnID: "bd-epic", Type: model.DepBlocks},
+		{BeadID: "bd-later", DependsOnID: "bd-other", Type: model.DepBlocks},
+	}
+}
+
+func TestHandleCloseBead_ReportsUnblocked(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedCloseGraph(ms)
+
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-epic/close", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var res struct {
+		ID        string        `json:"id"`
+		Status    string        `json:"status"`
+		Unblocked []*model.Bead `json:"unblocked"`
+		Cascaded  []*model.Bead `json:"cascaded"`
+		Warnings  []string      `json:"warnings"`
+	}
+	decodeJSON(t, rec, &res)
+	if res.ID != "bd-epic" || res.Status != "closed" {
+		t.Fatalf("bead = %s (%s)", res.ID, res.Status)
+	}
+	if len(res.Unblocked) != 1 || res.Unblocked[0].ID != "bd-next" {
+		t.Errorf("unblocked = %+v, want [bd-next]", res.Unblocked)
+	}
+	if len(res.Cascaded) != 0 || ms.beads["bd-child"].Status != model.StatusOpen {
+		t.Errorf("children closed without cascade: %+v", res.Cascaded)
+	}
+	if len(res.Warnings) != 1 {
+		t.Errorf("warnings = %v, want one about bd-first", res.Warnings)
+	}
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-next/close?cascade=maybe", nil), http.StatusBadRequest)
+}
+
+func TestGRPCCloseBead_Cascade(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	seedCloseGraph(ms)
+
+	resp, err := srv.CloseBead(ctx, &beadsv1.CloseBeadRequest{Id: "bd-epic", ClosedBy: "alice", Cascade: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cascaded []string
+	for _, b := range resp.GetCascaded() {
+		cascaded = append(cascaded, b.GetId())
+	}
+	if len(cascaded) != 2 || cascaded[0] != "bd-grandchild" || cascaded[1] != "bd-child" {
+		t.Errorf("cascaded = %v, want [bd-grandchild bd-child]", cascaded)
+	}
+	for _, id := range []string{"bd-epic", "bd-child", "bd-grandchild"} {
+		if ms.beads[id].Status != model.StatusClosed || ms.beads[id].ClosedBy != "alice" {
+			t.Errorf("%s = %s by %q", id, ms.beads[id].Status, ms.beads[id].ClosedBy)
+		}
+	}
+	if ms.beads["bd-busy"].Status != model.StatusInProgress {
+		t.Error("in-progress child was closed")
+	}
+	if len(resp.GetWarnings()) != 2 {
+		t.Errorf("warnings = %v, want the early close and the busy child", resp.GetWarnings())
+	}
+	if len(resp.GetUnblocked()) != 1 || resp.GetUnblocked()[0].GetId() != "bd-next" {
+		t.Errorf("unblocked = %v", resp.GetUnblocked())
+	}
+}
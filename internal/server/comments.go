@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// maxEmojiLen bounds a reaction, which is meant to be a single emoji or
+// short name such as "+1".
+const maxEmojiLen = 32
+
+// SetStrictAuthors makes a comment claiming an author other than the
+// caller's authenticated identity fail instead of being attributed to the
+// caller.
+func (s *BeadsServer) SetStrictAuthors(strict bool) {
+	s.strictAuthors = strict
+}
+
+// attributeComment sets c's author from claimed, the author the caller
+// sent. An authenticated caller is the author and c is marked verified;
+// if it claimed someone else, the claim is kept in ClaimedAuthor, or
+// rejected with a forbiddenError in strict mode. Without authentication
+// the claim is taken as is.
+func (s *BeadsServer) attributeComment(ctx context.Context, c *model.Comment, claimed string) error {
+	id := identityFrom(ctx)
+	if id == "" {
+		c.Author = s.canonicalActor(ctx, claimed)
+		return nil
+	}
+	c.Author, c.Verified = s.canonicalActor(ctx, id), true
+	if claimed == "" || claimed == id {
+		return nil
+	}
+	if claimed = s.canonicalActor(ctx, claimed); claimed == c.Author {
+		return nil
+	}
+	if s.strictAuthors {
+		return forbiddenError(fmt.Sprintf("authenticated as %s; cannot comment as %s", c.Author, claimed))
+	}
+	c.ClaimedAuthor = claimed
+	return nil
+}
+
+// commentOn returns comment id if it is on beadID, or sql.ErrNoRows.
+func commentOn(ctx context.Context, st store.Store, beadID string, id int64) (*model.Comment, error) {
+	c, err := st.GetComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil || c.BeadID != beadID {
+		return nil, sql.ErrNoRows
+	}
+	return c, nil
+}
+
+// reactToComment adds actor's emoji reaction to a comment on beadID, or
+// removes it when add is false, and returns the comment with its updated
+// counts. Returns sql.ErrNoRows if the bead has no such comment.
+func (s *BeadsServer) reactToComment(ctx context.Context, beadID string, id int64, actor, emoji string, add bool) (*model.Comment, error) {
+	if emoji == "" {
+		return nil, inputError("emoji is required")
+	}
+	if len(emoji) > maxEmojiLen {
+		return nil, inputError("emoji is too long")
+	}
+	if actor == "" {
+		return nil, inputError("actor is required")
+	}
+	var comment *model.Comment
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if _, err := commentOn(ctx, tx, beadID, id); err != nil {
+			return err
+		}
+		var err error
+		if add {
+			err = tx.AddReaction(ctx, id, actor, emoji)
+		} else {
+			err = tx.RemoveReaction(ctx, id, actor, emoji)
+		}
+		if err != nil {
+			return err
+		}
+		comment, err = tx.GetComment(ctx, id)
+		return err
+	})
+	return comment, err
+}
+
+// resolveComment marks a comment on beadID resolved by actor, or
+// unresolved, and records a CommentResolved event. Returns sql.ErrNoRows if
+// the bead has no such comment.
+func (s *BeadsServer) resolveComment(ctx context.Context, beadID string, id int64, actor string, resolved bool) (*model.Comment, error) {
+	var comment *model.Comment
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if _, err := commentOn(ctx, tx, beadID, id); err != nil {
+			return err
+		}
+		if err := tx.ResolveComment(ctx, id, actor, resolved); err != nil {
+			return err
+		}
+		var err error
+		if comment, err = tx.GetComment(ctx, id); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicCommentResolved, beadID, actor, events.CommentResolved{Comment: comment})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return comment, nil
+}
+
+// commentID parses the {cid} path value.
+func commentID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("cid"), 10, 64)
+	if err != nil {
+		return 0, inputError("invalid comment id")
+	}
+	return id, nil
+}
+
+// writeCommentResult writes comment, or the error from changing it.
+func writeCommentResult(w http.ResponseWriter, comment *model.Comment, err error) {
+	var ie inputError
+	switch {
+	case errors.As(err, &ie):
+		writeError(w, http.StatusBadRequest, ie.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "comment not found")
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "failed to update comment")
+	default:
+		writeJSON(w, http.StatusOK, comment)
+	}
+}
+
+// reactionRequest is the JSON body for POST
+// /v1/beads/{id}/comments/{cid}/reactions.
+type reactionRequest struct {
+	Emoji string `json:"emoji"`
+	Actor string `json:"actor"`
+}
+
+// handleAddReaction handles POST /v1/beads/{id}/comments/{cid}/reactions.
+func (s *BeadsServer) handleAddReaction(w http.ResponseWriter, r *http.Request) {
+	id, err := commentID(r)
+	if err != nil {
+		writeCommentResult(w, nil, err)
+		return
+	}
+	var req reactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	comment, err := s.reactToComment(r.Context(), r.PathValue("id"), id, s.actorFor(r.Context(), req.Actor), req.Emoji, true)
+	writeCommentResult(w, comment, err)
+}
+
+// handleRemoveReaction handles DELETE
+// /v1/beads/{id}/comments/{cid}/reactions/{emoji}?actor=.
+func (s *BeadsServer) handleRemoveReaction(w http.ResponseWriter, r *http.Request) {
+	id, err := commentID(r)
+	if err != nil {
+		writeCommentResult(w, nil, err)
+		return
+	}
+	actor := s.actorFor(r.Context(), r.URL.Query().Get("actor"))
+	comment, err := s.reactToComment(r.Context(), r.PathValue("id"), id, actor, r.PathValue("emoji"), false)
+	writeCommentResult(w, comment, err)
+}
+
+// resolveCommentRequest is the JSON body for POST
+// /v1/beads/{id}/comments/{cid}/resolve.
+type resolveCommentRequest struct {
+	Resolved *bool  `json:"resolved"` // default true; false reopens the discussion
+	Actor    string `json:"actor"`
+}
+
+// handleResolveComment handles POST /v1/beads/{id}/comments/{cid}/resolve.
+func (s *BeadsServer) handleResolveComment(w http.ResponseWriter, r *http.Request) {
+	id, err := commentID(r)
+	if err != nil {
+		writeCommentResult(w, nil, err)
+		return
+	}
+	var req resolveCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	resolved := req.Resolved == nil || *req.Resolved
+	comment, err := s.resolveComment(r.Context(), r.PathValue("id"), id, s.actorFor(r.Context(), req.Actor), resolved)
+	writeCommentResult(w, comment, err)
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestHandleCommentReactions(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-r1"] = &model.Bead{ID: "bd-r1", Title: "Review me", Status: model.StatusOpen}
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-r1/comments", map[string]any{"author": "alice", "text": "Is this safe?"}), 201)
+	cid := ms.comments["bd-r1"][0].ID
+	base := "/v1/beads/bd-r1/comments/" + strconv.FormatInt(cid, 10)
+
+	for _, actor := range []string{"bob", "carol", "bob"} {
+		requireStatus(t, doJSON(t, h, "POST", base+"/reactions", map[string]any{"emoji": "+1", "actor": actor}), 200)
+	}
+	rec := doJSON(t, h, "POST", base+"/reactions", map[string]any{"emoji": "eyes", "actor": "bob"})
+	requireStatus(t, rec, 200)
+	var c model.Comment
+	decodeJSON(t, rec, &c)
+	if c.Reactions["+1"] != 2 || c.Reactions["eyes"] != 1 {
+		t.Fatalf("reactions = %v, want +1:2 eyes:1", c.Reactions)
+	}
+
+	rec = doJSON(t, h, "DELETE", base+"/reactions/eyes?actor=bob", nil)
+	requireStatus(t, rec, 200)
+	c = model.Comment{}
+	decodeJSON(t, rec, &c)
+	if _, ok := c.Reactions["eyes"]; ok || c.Reactions["+1"] != 2 {
+		t.Fatalf("reactions after removal = %v", c.Reactions)
+	}
+
+	// Counts come back with the comments.
+	rec = doJSON(t, h, "GET", "/v1/beads/bd-r1/comments", nil)
+	var list struct {
+		Comments []model.Comment `json:"comments"`
+	}
+	decodeJSON(t, rec, &list)
+	if len(list.Comments) != 1 || list.Comments[0].Reactions["+1"] != 2 {
+		t.Fatalf("comments = %+v", list.Comments)
+	}
+
+	requireStatus(t, doJSON(t, h, "POST", base+"/reactions", map[string]any{"actor": "bob"}), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-r1/comments/x/reactions", map[string]any{"emoji": "+1", "actor": "bob"}), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-r1/comments/999/reactions", map[string]any{"emoji": "+1", "actor": "bob"}), 404)
+}
+
+func TestHandleResolveComment(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-r2"] = &model.Bead{ID: "bd-r2", Title: "Review me", Status: model.StatusOpen}
+	ms.beads["bd-r3"] = &model.Bead{ID: "bd-r3", Title: "Other", Status: model.StatusOpen}
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-r2/comments", map[string]any{"author": "alice", "text": "Rename this"}), 201)
+	base := "/v1/beads/bd-r2/comments/" + strconv.FormatInt(ms.comments["bd-r2"][0].ID, 10)
+	before := len(ms.events)
+
+	rec := doJSON(t, h, "POST", base+"/resolve", map[string]any{"actor": "bob"})
+	requireStatus(t, rec, 200)
+	var c model.Comment
+	decodeJSON(t, rec, &c)
+	if c.ResolvedAt == nil || c.ResolvedBy != "bob" {
+		t.Fatalf("resolved comment = %+v", c)
+	}
+	requireEvent(t, ms, before+1, "beads.comment.resolved")
+
+	rec = doJSON(t, h, "POST", base+"/resolve", map[string]any{"actor": "bob", "resolved": false})
+	requireStatus(t, rec, 200)
+	c = model.Comment{}
+	decodeJSON(t, rec, &c)
+	if c.ResolvedAt != nil || c.ResolvedBy != "" {
+		t.Fatalf("reopened comment = %+v", c)
+	}
+
+	// A comment is only reachable through its own bead.
+	other := "/v1/beads/bd-r3/comments/" + strconv.FormatInt(ms.comments["bd-r2"][0].ID, 10)
+	requireStatus(t, doJSON(t, h, "POST", other+"/resolve", map[string]any{"actor": "bob"}), 404)
+}
+
+func TestParseMentions(t *testing.T) {
+	got := parseMentions("@alice, can you and @crew/bob look? cc @alice. mail carol@corp.com")
+	if len(got) != 2 || got[0] != "alice" || got[1] != "crew/bob" {
+		t.Fatalf("parseMentions = %q", got)
+	}
+}
+
+func TestAddComment_Mentions(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-m1"] = &model.Bead{ID: "bd-m1", Title: "Ping", Status: model.StatusOpen}
+	ms.actors["bob"] = &model.Actor{ID: "bob", Aliases: []string{"bobby"}}
+	ms.actors["carol"] = &model.Actor{ID: "carol"}
+	ms.watchers["bd-m1"] = []string{"carol"}
+
+	text := "@Bobby and @carol, see this. @alice and @param are not registered."
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-m1/comments", map[string]any{"author": "alice", "text": text}), 201)
+	requireEvent(t, ms, 2, "beads.mention")
+	if e := ms.events[1]; e.Actor != "alice" || e.BeadID != "bd-m1" {
+		t.Fatalf("mention event = %+v", e)
+	}
+
+	rec := doJSON(t, h, "GET", "/v1/notifications?actor=bob", nil)
+	requireStatus(t, rec, 200)
+	var feed struct {
+		Notifications []model.Notification `json:"notifications"`
+	}
+	decodeJSON(t, rec, &feed)
+	if len(feed.Notifications) != 1 || feed.Notifications[0].Event.Topic != "beads.mention" {
+		t.Fatalf("bob's notifications = %+v", feed.Notifications)
+	}
+
+	// A watcher who is mentioned hears of the mention once.
+	n := 0
+	for _, nt := range ms.notifications {
+		if nt.Actor == "carol" && nt.Event.Topic == "beads.mention" {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Fatalf("carol has %d mention notifications, want 1", n)
+	}
+
+	// No registered mention, no mention event.
+	before := len(ms.events)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-m1/comments", map[string]any{"author": "bob", "text": "thanks @bob"}), 201)
+	if len(ms.events) != before+1 {
+		t.Fatalf("expected only comment.added, got %d new events", len(ms.events)-before)
+	}
+}
+
+func TestAddComment_AuthenticatedAuthor(t *testing.T) {
+	s, ms, h := newTestServer()
+	ms.beads["bd-v1"] = &model.Bead{ID: "bd-v1", Title: "Verify", Status: model.StatusOpen}
+	ms.actors["alice"] = &model.Actor{ID: "alice", Aliases: []string{"al"}}
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/v1/beads/bd-v1/comments", bytes.NewReader([]byte(body)))
+		req = req.WithContext(withIdentity(req.Context(), "alice"))
+		req.SetPathValue("id", "bd-v1")
+		rec := httptest.NewRecorder()
+		s.handleAddComment(rec, req)
+		return rec
+	}
+
+	// A claim of the caller's own alias is no spoof.
+	rec := post(`{"author":"al","text":"mine"}`)
+	requireStatus(t, rec, 201)
+	var c model.Comment
+	decodeJSON(t, rec, &c)
+	if c.Author != "alice" || !c.Verified || c.ClaimedAuthor != "" {
+		t.Fatalf("comment = %+v", c)
+	}
+
+	// Claiming someone else is attributed to the caller, keeping the claim.
+	rec = post(`{"author":"bob","text":"spoofed"}`)
+	requireStatus(t, rec, 201)
+	c = model.Comment{}
+	decodeJSON(t, rec, &c)
+	if c.Author != "alice" || !c.Verified || c.ClaimedAuthor != "bob" {
+		t.Fatalf("comment = %+v", c)
+	}
+	if e := ms.events[len(ms.events)-1]; e.Actor != "alice" || !bytes.Contains(e.Payload, []byte(`"claimed_author":"bob"`)) {
+		t.Fatalf("event = %+v, payload %s", e, e.Payload)
+	}
+
+	// Unauthenticated callers are taken at their word, unverified.
+	rec = doJSON(t, h, "POST", "/v1/beads/bd-v1/comments", map[string]any{"author": "bob", "text": "hi"})
+	requireStatus(t, rec, 201)
+	c = model.Comment{}
+	decodeJSON(t, rec, &c)
+	if c.Author != "bob" || c.Verified {
+		t.Fatalf("comment = %+v", c)
+	}
+
+	s.SetStrictAuthors(true)
+	before := len(ms.comments["bd-v1"])
+	requireStatus(t, post(`{"author":"bob","text":"spoofed"}`), 403)
+	requireStatus(t, post(`{"text":"fine"}`), 201)
+	_, err := s.AddComment(withIdentity(context.Background(), "alice"), &beadsv1.AddCommentRequest{BeadId: "bd-v1", Author: "bob", Text: "spoofed"})
+	requireCode(t, err, codes.PermissionDenied)
+	if n := len(ms.comments["bd-v1"]) - before; n != 1 {
+		t.Fatalf("%d comments added in strict mode, want 1", n)
+	}
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// linkCommit links a git commit to beadID and records its event. Linking
+// the same repo and SHA again returns the existing link with created false
+// and records nothing. Returns inputError for a missing repo or invalid SHA,
+// and sql.ErrNoRows if the bead does not exist.
+func (s *BeadsServer) linkCommit(ctx context.Context, c *model.Commit) (created bool, err error) {
+	c.Repo = strings.TrimSpace(c.Repo)
+	c.SHA = strings.ToLower(strings.TrimSpace(c.SHA))
+	c.Message = strings.TrimSpace(c.Message)
+	c.LinkedBy = s.actorFor(ctx, c.LinkedBy)
+	if err := c.Validate(); err != nil {
+		return false, inputError(err.Error())
+	}
+	b, err := s.store.GetBead(ctx, c.BeadID)
+	if err != nil {
+		return false, err
+	}
+	if b == nil {
+		return false, sql.ErrNoRows
+	}
+
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if created, err = tx.LinkCommit(ctx, c); err != nil || !created {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicCommitLinked, c.BeadID, c.LinkedBy, events.CommitLinked{Commit: c})
+	})
+	if err != nil {
+		return false, err
+	}
+	if created {
+		s.flushEvents(ctx)
+	}
+	return created, nil
+}
+
+// linkCommitRequest is the JSON body for POST /v1/beads/{id}/commits.
+type linkCommitRequest struct {
+	Repo     string `json:"repo"`
+	SHA      string `json:"sha"`
+	Message  string `json:"message"`
+	Author   string `json:"author"`
+	LinkedBy string `json:"linked_by"`
+}
+
+// handleLinkCommit handles POST /v1/beads/{id}/commits. It answers 201 for
+// a new link and 200 when the commit was already linked.
+func (s *BeadsServer) handleLinkCommit(w http.ResponseWriter, r *http.Request) {
+	var req linkCommitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	c := &model.Commit{
+		BeadID:   r.PathValue("id"),
+		Repo:     req.Repo,
+		SHA:      req.SHA,
+		Message:  req.Message,
+		Author:   req.Author,
+		LinkedBy: req.LinkedBy,
+	}
+	created, err := s.linkCommit(r.Context(), c)
+	var ie inputError
+	switch {
+	case errors.As(err, &ie):
+		writeError(w, http.StatusBadRequest, ie.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "bead not found")
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "failed to link commit")
+	case created:
+		writeJSON(w, http.StatusCreated, c)
+	default:
+		writeJSON(w, http.StatusOK, c)
+	}
+}
+
+// handleListCommits handles GET /v1/beads/{id}/commits.
+func (s *BeadsServer) handleListCommits(w http.ResponseWriter, r *http.Request) {
+	commits, err := s.store.GetCommits(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list commits")
+		return
+	}
+	if commits == nil {
+		commits = []*model.Commit{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"commits": commits})
+}
+
+func commitToProto(c *model.Commit) *beadsv1.Commit {
+	return &beadsv1.Commit{
+		Id:        c.ID,
+		BeadId:    c.BeadID,
+		Repo:      c.Repo,
+		Sha:       c.SHA,
+		Message:   c.Message,
+		Author:    c.Author,
+		LinkedBy:  c.LinkedBy,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}
+}
+
+// LinkCommit links a git commit to a bead.
+func (s *BeadsServer) LinkCommit(ctx context.Context, req *beadsv1.LinkCommitRequest) (*beadsv1.LinkCommitResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	c := &model.Commit{
+		BeadID:   req.GetBeadId(),
+		Repo:     req.GetRepo(),
+		SHA:      req.GetSha(),
+		Message:  req.GetMessage(),
+		Author:   req.GetAuthor(),
+		LinkedBy: req.GetLinkedBy(),
+	}
+	created, err := s.linkCommit(ctx, c)
+	var ie inputError
+	if errors.As(err, &ie) {
+		return nil, status.Error(codes.InvalidArgument, ie.Error())
+	}
+	if err != nil {
+		return nil, storeError(err, "bead")
+	}
+	return &beadsv1.LinkCommitResponse{Commit: commitToProto(c), Created: created}, nil
+}
+
+// ListCommits lists the commits linked to a bead, newest first.
+func (s *BeadsServer) ListCommits(ctx context.Context, req *beadsv1.ListCommitsRequest) (*beadsv1.ListCommitsResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	commits, err := s.store.GetCommits(ctx, req.GetBeadId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list commits: %v", err)
+	}
+	pb := make([]*beadsv1.Commit, len(commits))
+	for i, c := range commits {
+		pb[i] = commitToProto(c)
+	}
+	return &beadsv1.ListCommitsResponse{Commits: pb}, nil
+}
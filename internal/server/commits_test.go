@@ -0,0 +1,61 @@
+package server
+
+import (
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestLinkCommit(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-c1"] = &model.Bead{ID: "bd-c1", Title: "Fix login", Status: model.StatusOpen}
+
+	body := map[string]any{"repo": "git@github.com:acme/app.git", "sha": "ABC1234DEF", "message": "Fix login (bd-c1)\n", "linked_by": "alice"}
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-c1/commits", body)
+	requireStatus(t, rec, 201)
+	var c model.Commit
+	decodeJSON(t, rec, &c)
+	if c.SHA != "abc1234def" || c.Message != "Fix login (bd-c1)" || c.LinkedBy != "alice" {
+		t.Fatalf("unexpected commit: %+v", c)
+	}
+	requireEvent(t, ms, 1, "beads.commit.linked")
+
+	// Linking the same commit again is not a new link.
+	rec = doJSON(t, h, "POST", "/v1/beads/bd-c1/commits", body)
+	requireStatus(t, rec, 200)
+	if len(ms.events) != 1 {
+		t.Fatalf("relinking recorded an event")
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/beads/bd-c1/commits", nil)
+	requireStatus(t, rec, 200)
+	var list struct {
+		Commits []model.Commit `json:"commits"`
+	}
+	decodeJSON(t, rec, &list)
+	if len(list.Commits) != 1 || list.Commits[0].Repo != "git@github.com:acme/app.git" {
+		t.Fatalf("commits = %+v", list.Commits)
+	}
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-c1/commits", map[string]any{"repo": "app", "sha": "xyz"}), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-c1/commits", map[string]any{"sha": "abc1234"}), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-missing/commits", map[string]any{"repo": "app", "sha": "abc1234"}), 404)
+}
+
+func TestLinkCommit_GRPC(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-c2"] = &model.Bead{ID: "bd-c2", Title: "Docs", Status: model.StatusOpen}
+
+	resp, err := srv.LinkCommit(ctx, &beadsv1.LinkCommitRequest{BeadId: "bd-c2", Repo: "app", Sha: "0123abcd"})
+	if err != nil || !resp.GetCreated() || resp.GetCommit().GetSha() != "0123abcd" {
+		t.Fatalf("LinkCommit = %v, %v", resp, err)
+	}
+	list, err := srv.ListCommits(ctx, &beadsv1.ListCommitsRequest{BeadId: "bd-c2"})
+	if err != nil || len(list.GetCommits()) != 1 {
+		t.Fatalf("ListCommits = %v, %v", list, err)
+	}
+	_, err = srv.LinkCommit(ctx, &beadsv1.LinkCommitRequest{BeadId: "bd-c2", Repo: "app", Sha: "nothex!"})
+	requireCode(t, err, codes.InvalidArgument)
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// compressMinSize is the smallest response body worth compressing; smaller
+// bodies are sent as they are, unless the handler flushes them as the start
+// of a stream.
+const compressMinSize = 1024
+
+// compressMiddleware compresses response bodies with gzip or deflate when the
+// client accepts one of them and the body is JSON or text of at least
+// compressMinSize bytes. Bodies are compressed as they are written, so long
+// streams such as JSONL exports stay streams; event streams are not
+// compressed.
+func compressMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := acceptedEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// acceptedEncoding returns the encoding to compress with per an
+// Accept-Encoding header: "gzip", "deflate" or "" for none. gzip wins ties.
+func acceptedEncoding(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "*" {
+			name = "gzip"
+		}
+		if (name == "gzip" || name == "deflate") && q > 0 && (q > bestQ || q == bestQ && name == "gzip") {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
+
+// compressible reports whether a response of the given content type is
+// worth compressing.
+func compressible(contentType string) bool {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mt == "text/event-stream":
+		return false
+	case strings.HasPrefix(mt, "text/"), strings.HasSuffix(mt, "json"), mt == "application/x-ndjson",
+		mt == "application/yaml", mt == "application/xml":
+		return true
+	}
+	return false
+}
+
+// encoder is a compressing writer that can flush what it holds.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// compressWriter holds back the start of a response until it knows whether
+// the body is big enough to compress, then writes it through an encoder or
+// as it is.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	status   int
+	buf      []byte  // body held back until compressMinSize or a flush
+	decided  bool    // the header has been written
+	enc      encoder // set if the body is being compressed
+}
+
+func (c *compressWriter) WriteHeader(status int) {
+	if c.decided || c.status != 0 {
+		return
+	}
+	c.status = status
+}
+
+func (c *compressWriter) Write(p []byte) (int, error) {
+	if !c.decided {
+		c.buf = append(c.buf, p...)
+		if len(c.buf) < compressMinSize {
+			return len(p), nil
+		}
+		if err := c.decide(false); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if c.enc != nil {
+		return c.enc.Write(p)
+	}
+	return c.ResponseWriter.Write(p)
+}
+
+// decide writes the header, compressing if the body is of a compressible
+// type and either big enough or a stream being flushed, and then the
+// held-back body.
+func (c *compressWriter) decide(flushing bool) error {
+	c.decided = true
+	if c.status == 0 {
+		c.status = http.StatusOK
+	}
+	h := c.Header()
+	if (flushing || len(c.buf) >= compressMinSize) && c.status != http.StatusNoContent && c.status != http.StatusNotModified &&
+		h.Get("Content-Encoding") == "" && compressible(h.Get("Content-Type")) {
+		h.Set("Content-Encoding", c.encoding)
+		h.Del("Content-Length")
+		// The compressed bytes differ from those the tag was computed on.
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+		if c.encoding == "gzip" {
+			c.enc = gzip.NewWriter(c.ResponseWriter)
+		} else {
+			c.enc, _ = flate.NewWriter(c.ResponseWriter, flate.DefaultCompression)
+		}
+	}
+	c.ResponseWriter.WriteHeader(c.status)
+	buf := c.buf
+	c.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if c.enc != nil {
+		_, err = c.enc.Write(buf)
+	} else {
+		_, err = c.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// Flush sends what has been written so far, deciding on compression first
+// if it has not been decided.
+func (c *compressWriter) Flush() {
+	if !c.decided {
+		_ = c.decide(true)
+	}
+	if c.enc != nil {
+		_ = c.enc.Flush()
+	}
+	if f, ok := c.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// close ends the response once the handler returns.
+func (c *compressWriter) close() {
+	if !c.decided {
+		if c.status == 0 && len(c.buf) == 0 {
+			return // nothing written; let net/http send its default
+		}
+		_ = c.decide(false)
+	}
+	if c.enc != nil {
+		_ = c.enc.Close()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (c *compressWriter) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestAcceptedEncoding(t *testing.T) {
+	tests := map[string]string{
+		"":                          "",
+		"gzip":                      "gzip",
+		"deflate, gzip":             "gzip",
+		"deflate":                   "deflate",
+		"gzip;q=0.5, deflate":       "deflate",
+		"gzip;q=0, deflate;q=0":     "",
+		"br, *":                     "gzip",
+		"identity":                  "",
+		" GZIP ; q=0.8 , br;q=1.0 ": "gzip",
+	}
+	for header, want := range tests {
+		if got := acceptedEncoding(header); got != want {
+			t.Errorf("acceptedEncoding(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestCompressMiddleware(t *testing.T) {
+	_, ms, h := newTestServer()
+	for i := range 40 {
+		id := fmt.Sprintf("bd-z%02d", i)
+		ms.beads[id] = &model.Bead{ID: id, Title: "A bead with a reasonably long title", Type: "task", Kind: model.KindIssue, Status: model.StatusOpen}
+	}
+
+	for _, encoding := range []string{"gzip", "deflate"} {
+		req := httptest.NewRequest("GET", "/v1/beads", nil)
+		req.Header.Set("Accept-Encoding", encoding)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		requireStatus(t, rec, 200)
+		if got := rec.Header().Get("Content-Encoding"); got != encoding {
+			t.Fatalf("Content-Encoding = %q, want %q", got, encoding)
+		}
+		if etag := rec.Header().Get("ETag"); !strings.HasPrefix(etag, `W/"`) {
+			t.Errorf("compressed response has ETag %q, want a weak tag", etag)
+		}
+		var r io.Reader
+		if encoding == "gzip" {
+			zr, err := gzip.NewReader(rec.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = zr
+		} else {
+			r = flate.NewReader(rec.Body)
+		}
+		var list struct {
+			Beads []model.Bead `json:"beads"`
+		}
+		if err := json.NewDecoder(r).Decode(&list); err != nil || len(list.Beads) != 40 {
+			t.Fatalf("%s: decoded %d beads, err %v", encoding, len(list.Beads), err)
+		}
+	}
+
+	// Small bodies and clients without gzip get the body as it is.
+	req := httptest.NewRequest("GET", "/v1/beads/bd-z01", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	requireStatus(t, rec, 200)
+	if rec.Header().Get("Content-Encoding") != "" || !strings.Contains(rec.Header().Get("Vary"), "Accept-Encoding") {
+		t.Fatalf("small response headers = %v", rec.Header())
+	}
+	rec = doJSON(t, h, "GET", "/v1/beads", nil)
+	if rec.Header().Get("Content-Encoding") != "" {
+		t.Fatalf("uncompressed client got Content-Encoding %q", rec.Header().Get("Content-Encoding"))
+	}
+}
+
+func TestCompressWriter_Flush(t *testing.T) {
+	// A flushed stream is compressed as it goes and still decodes whole.
+	h := compressMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		for i := range 300 {
+			fmt.Fprintf(w, "{\"line\":%d}\n", i)
+			if i%100 == 0 {
+				w.(http.Flusher).Flush()
+			}
+		}
+	}))
+	req := httptest.NewRequest("GET", "/v1/export", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if !rec.Flushed || rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("flushed = %v, headers = %v", rec.Flushed, rec.Header())
+	}
+	zr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(zr)
+	if err != nil || strings.Count(string(body), "\n") != 300 {
+		t.Fatalf("read %d lines, err %v", strings.Count(string(body), "\n"), err)
+	}
+
+	// Event streams are never compressed.
+	h = compressMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, strings.Repeat("data: x\n\n", 200))
+	}))
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Header().Get("Content-Encoding") != "" {
+		t.Fatalf("event stream was compressed")
+	}
+}
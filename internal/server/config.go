@@ -5,10 +5,15 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
 	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -26,6 +31,47 @@ var builtinConfigs = map[string]*model.Config{
 	"type:feature": {Key: "type:feature", Value: json.RawMessage(`{"kind":"issue","fields":[]}`)},
 	"type:chore":   {Key: "type:chore", Value: json.RawMessage(`{"kind":"issue","fields":[]}`)},
 	"type:bug":     {Key: "type:bug", Value: json.RawMessage(`{"kind":"issue","fields":[]}`)},
+	"type:decision": {Key: "type:decision", Value: json.RawMessage(`{"kind":"data","fields":[` +
+		`{"name":"options","type":"string[]"},` +
+		`{"name":"default_option","type":"string"},` +
+		`{"name":"expires_at","type":"timestamp"},` +
+		`{"name":"chosen","type":"string"},` +
+		`{"name":"context_beads","type":"string[]"},` +
+		`{"name":"diff","type":"string"},` +
+		`{"name":"links","type":"string[]"},` +
+		`{"name":"approvers","type":"string[]"},` +
+		`{"name":"template","type":"string"}]}`)},
+	"type:agent": {Key: "type:agent", Value: json.RawMessage(`{"kind":"data","fields":[` +
+		`{"name":"name","type":"string","required":true},` +
+		`{"name":"role","type":"string"},` +
+		`{"name":"subscriptions","type":"string[]"}]}`)},
+	"type:gate": {Key: "type:gate", Value: json.RawMessage(`{"kind":"issue","fields":[` +
+		`{"name":"agent","type":"string"},` +
+		`{"name":"gate","type":"string"},` +
+		`{"name":"waived_until","type":"timestamp"},` +
+		`{"name":"waived_by","type":"string"},` +
+		`{"name":"waive_reason","type":"string"}]}`)},
+	"type:advice": {Key: "type:advice", Value: json.RawMessage(`{"kind":"data","fields":[` +
+		`{"name":"expires_at","type":"timestamp"}]}`)},
+	"type:jack": {Key: "type:jack", Value: json.RawMessage(`{"kind":"data","fields":[` +
+		`{"name":"target","type":"string","required":true},` +
+		`{"name":"revert","type":"string"},` +
+		`{"name":"expires_at","type":"timestamp","required":true},` +
+		`{"name":"extensions","type":"integer"}]}`)},
+	"deptype:blocks":       {Key: "deptype:blocks", Value: json.RawMessage(`{"blocking":true,"label":"blocked by"}`)},
+	"deptype:parent-child": {Key: "deptype:parent-child", Value: json.RawMessage(`{"blocking":false,"label":"child of"}`)},
+	"deptype:related":      {Key: "deptype:related", Value: json.RawMessage(`{"blocking":false,"label":"related to"}`)},
+	"deptype:duplicates":   {Key: "deptype:duplicates", Value: json.RawMessage(`{"blocking":false,"label":"duplicates"}`)},
+	"deptype:supersedes":   {Key: "deptype:supersedes", Value: json.RawMessage(`{"blocking":false,"label":"supersedes"}`)},
+	"deptype:duplicate-of": {Key: "deptype:duplicate-of", Value: json.RawMessage(`{"blocking":false,"label":"duplicate of","inverse_label":"duplicated by"}`)},
+	"deptype:relates-to":   {Key: "deptype:relates-to", Value: json.RawMessage(`{"blocking":false,"label":"relates to","inverse_label":"relates to"}`)},
+	"deptype:caused-by":    {Key: "deptype:caused-by", Value: json.RawMessage(`{"blocking":false,"label":"caused by","inverse_label":"causes"}`)},
+	"deptype:fixed-by":     {Key: "deptype:fixed-by", Value: json.RawMessage(`{"blocking":false,"label":"fixed by","inverse_label":"fixes"}`)},
+	model.WorkflowConfigKey: {Key: model.WorkflowConfigKey, Value: json.RawMessage(`{"transitions":{` +
+		`"open":["in_progress","deferred","closed"],` +
+		`"in_progress":["open","deferred","closed"],` +
+		`"deferred":["open","in_progress","closed"],` +
+		`"closed":["open"]}}`)},
 }
 
 var builtinConfigsByNamespace = func() map[string][]*model.Config {
@@ -64,22 +110,258 @@ func (s *BeadsServer) resolveTypeConfig(ctx context.Context, beadType model.Bead
 	return &tc, nil
 }
 
+// SetConfigPolicy sets the config namespaces only admins may read or write.
+// Other callers still see every other config, with secret fields redacted.
+func (s *BeadsServer) SetConfigPolicy(adminNamespaces []string) {
+	s.adminConfigNamespaces = adminNamespaces
+}
+
+// configAdmin reports whether the caller presenting token may read secrets
+// and use admin-only config namespaces: it holds the admin token or the
+// OIDC admin role. Without either configured, every caller may.
+func (s *BeadsServer) configAdmin(ctx context.Context, token string) bool {
+	if s.adminToken == "" && s.oidc == nil {
+		return true
+	}
+	return s.authorizeAdmin(ctx, token) == nil
+}
+
+// checkConfigAccess returns whether the caller presenting token is a config
+// admin, and a forbiddenError if it is not and key, or namespace when key
+// is "", is in an admin-only namespace.
+func (s *BeadsServer) checkConfigAccess(ctx context.Context, token, key string) (admin bool, err error) {
+	admin = s.configAdmin(ctx, token)
+	ns, _, _ := strings.Cut(key, ":")
+	if !admin && slices.Contains(s.adminConfigNamespaces, ns) {
+		return false, forbiddenError("config namespace " + ns + " requires the admin token")
+	}
+	return admin, nil
+}
+
+// redactConfig returns c with its secret fields redacted unless admin.
+func redactConfig(c *model.Config, admin bool) *model.Config {
+	if admin || c == nil {
+		return c
+	}
+	out := *c
+	out.Value = model.RedactSecrets(c.Value)
+	return &out
+}
+
+// visibleConfigs returns configs without those in admin-only namespaces
+// and with secret fields redacted, unless admin. It never returns nil.
+func (s *BeadsServer) visibleConfigs(configs []*model.Config, admin bool) []*model.Config {
+	out := make([]*model.Config, 0, len(configs))
+	for _, c := range configs {
+		ns, _, _ := strings.Cut(c.Key, ":")
+		if !admin && slices.Contains(s.adminConfigNamespaces, ns) {
+			continue
+		}
+		out = append(out, redactConfig(c, admin))
+	}
+	return out
+}
+
+// redactRevisions returns revs with their secret fields redacted unless
+// admin.
+func redactRevisions(revs []*model.ConfigRevision, admin bool) []*model.ConfigRevision {
+	if admin {
+		return revs
+	}
+	out := make([]*model.ConfigRevision, len(revs))
+	for i, r := range revs {
+		c := *r
+		c.Value = model.RedactSecrets(r.Value)
+		out[i] = &c
+	}
+	return out
+}
+
+// setConfig saves a config, recording a new revision and a ConfigChanged
+// event in the same transaction.
+func (s *BeadsServer) setConfig(ctx context.Context, key string, value json.RawMessage, actor string) (*model.Config, error) {
+	if err := validateConfig(key, value); err != nil {
+		return nil, err
+	}
+	if model.HasRedactedSecret(value) {
+		return nil, inputError("a secret field holds the redaction placeholder " + model.RedactedSecret + "; send the real value")
+	}
+	config := &model.Config{
+		Key:       key,
+		Value:     value,
+		UpdatedBy: s.actorFor(ctx, actor),
+	}
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := tx.SetConfig(ctx, config); err != nil {
+			return err
+		}
+		return s.recordConfigChanged(ctx, tx, events.ConfigChanged{
+			Key:       config.Key,
+			Rev:       config.Rev,
+			Value:     config.Value,
+			ChangedBy: config.UpdatedBy,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return config, nil
+}
+
+// validateConfig checks the values of configs the server interprets itself.
+// Returns inputError when the value is invalid.
+func validateConfig(key string, value json.RawMessage) error {
+	if strings.HasPrefix(key, "gate:") {
+		var gc model.GateConfig
+		if err := json.Unmarshal(value, &gc); err != nil {
+			return inputError("invalid gate config: " + err.Error())
+		}
+		if err := gc.Validate(); err != nil {
+			return inputError("invalid gate config: " + err.Error())
+		}
+	}
+	if strings.HasPrefix(key, "rule:") {
+		var rc model.RuleConfig
+		if err := json.Unmarshal(value, &rc); err != nil {
+			return inputError("invalid rule config: " + err.Error())
+		}
+		if err := rc.Validate(); err != nil {
+			return inputError("invalid rule config: " + err.Error())
+		}
+	}
+	if strings.HasPrefix(key, model.DecisionTemplatePrefix) {
+		var dt model.DecisionTemplate
+		if err := json.Unmarshal(value, &dt); err != nil {
+			return inputError("invalid decision template: " + err.Error())
+		}
+		if err := dt.Validate(); err != nil {
+			return inputError("invalid decision template: " + err.Error())
+		}
+	}
+	if key == model.LabelConfigKey {
+		var lc model.LabelConfig
+		if err := json.Unmarshal(value, &lc); err != nil {
+			return inputError("invalid label config: " + err.Error())
+		}
+		if err := lc.Validate(); err != nil {
+			return inputError("invalid label config: " + err.Error())
+		}
+	}
+	if key == model.WorkflowConfigKey {
+		var wc model.WorkflowConfig
+		if err := json.Unmarshal(value, &wc); err != nil {
+			return inputError("invalid workflow config: " + err.Error())
+		}
+		if err := wc.Validate(); err != nil {
+			return inputError("invalid workflow config: " + err.Error())
+		}
+	}
+	if strings.HasPrefix(key, prefNamespace+":") {
+		return validatePrefConfig(key, value)
+	}
+	if name, ok := strings.CutPrefix(key, "deptype:"); ok {
+		if !model.DependencyType(name).IsValid() {
+			return inputError("invalid dependency type name " + strconv.Quote(name))
+		}
+		var dc model.DepTypeConfig
+		if err := json.Unmarshal(value, &dc); err != nil {
+			return inputError("invalid deptype config: " + err.Error())
+		}
+		if model.DependencyType(name) == model.DepBlocks && !dc.Blocking {
+			return inputError("deptype:blocks is always blocking")
+		}
+		if model.DependencyType(name).IsRelation() && dc.Blocking {
+			return inputError("relation type " + name + " cannot be blocking")
+		}
+	}
+	return nil
+}
+
+// deleteConfig deletes a config, recording the deletion as a revision and a
+// ConfigChanged event in the same transaction.
+func (s *BeadsServer) deleteConfig(ctx context.Context, key string) error {
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := tx.DeleteConfig(ctx, key); err != nil {
+			return err
+		}
+		return s.recordConfigChanged(ctx, tx, events.ConfigChanged{
+			Key:       key,
+			Deleted:   true,
+			ChangedBy: s.actorFor(ctx, ""),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	s.flushEvents(ctx)
+	return nil
+}
+
+// rollbackConfig restores key to the value saved at rev by writing it as a
+// new revision. Returns sql.ErrNoRows for an unknown revision and
+// inputError when rev recorded a deletion.
+func (s *BeadsServer) rollbackConfig(ctx context.Context, key string, rev int64, actor string) (*model.Config, error) {
+	old, err := s.store.GetConfigRevision(ctx, key, rev)
+	if err != nil {
+		return nil, err
+	}
+	if old.Deleted {
+		return nil, inputError(fmt.Sprintf("revision %d deleted the config; delete it instead", rev))
+	}
+	config := &model.Config{
+		Key:       key,
+		Value:     old.Value,
+		UpdatedBy: s.actorFor(ctx, actor),
+	}
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := tx.SetConfig(ctx, config); err != nil {
+			return err
+		}
+		return s.recordConfigChanged(ctx, tx, events.ConfigChanged{
+			Key:         config.Key,
+			Rev:         config.Rev,
+			Value:       config.Value,
+			ChangedBy:   config.UpdatedBy,
+			RestoredRev: rev,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return config, nil
+}
+
+// recordConfigChanged records e with the config's secrets redacted: the
+// events table is not sealed, and events reach callers who may not read
+// secrets.
+func (s *BeadsServer) recordConfigChanged(ctx context.Context, tx store.Store, e events.ConfigChanged) error {
+	e.Value = model.RedactSecrets(e.Value)
+	return s.recordEvent(ctx, tx, events.TopicConfigChanged, "", e.ChangedBy, e)
+}
+
 // SetConfig creates or updates a config entry.
 func (s *BeadsServer) SetConfig(ctx context.Context, req *beadsv1.SetConfigRequest) (*beadsv1.SetConfigResponse, error) {
 	if req.GetKey() == "" {
 		return nil, status.Error(codes.InvalidArgument, "key is required")
 	}
 
-	config := &model.Config{
-		Key:   req.GetKey(),
-		Value: json.RawMessage(req.GetValue()),
+	admin, err := s.checkConfigAccess(ctx, grpcBearerToken(ctx), req.GetKey())
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
 	}
 
-	if err := s.store.SetConfig(ctx, config); err != nil {
+	config, err := s.setConfig(ctx, req.GetKey(), json.RawMessage(req.GetValue()), req.GetUpdatedBy())
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to set config: %v", err)
 	}
 
-	return &beadsv1.SetConfigResponse{Config: configToProto(config)}, nil
+	return &beadsv1.SetConfigResponse{Config: configToProto(redactConfig(config, admin))}, nil
 }
 
 // GetConfig retrieves a config by key.
@@ -88,6 +370,11 @@ func (s *BeadsServer) GetConfig(ctx context.Context, req *beadsv1.GetConfigReque
 		return nil, status.Error(codes.InvalidArgument, "key is required")
 	}
 
+	admin, err := s.checkConfigAccess(ctx, grpcBearerToken(ctx), req.GetKey())
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
 	config, err := s.store.GetConfig(ctx, req.GetKey())
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -98,7 +385,7 @@ func (s *BeadsServer) GetConfig(ctx context.Context, req *beadsv1.GetConfigReque
 		return nil, storeError(err, "config")
 	}
 
-	return &beadsv1.GetConfigResponse{Config: configToProto(config)}, nil
+	return &beadsv1.GetConfigResponse{Config: configToProto(redactConfig(config, admin))}, nil
 }
 
 // listConfigsWithBuiltins fetches configs from the store and merges in builtin
@@ -129,13 +416,18 @@ func (s *BeadsServer) ListConfigs(ctx context.Context, req *beadsv1.ListConfigsR
 		return nil, status.Error(codes.InvalidArgument, "namespace is required")
 	}
 
+	admin, err := s.checkConfigAccess(ctx, grpcBearerToken(ctx), req.GetNamespace())
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
 	configs, err := s.listConfigsWithBuiltins(ctx, req.GetNamespace())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list configs: %v", err)
 	}
 
 	pbConfigs := make([]*beadsv1.Config, 0, len(configs))
-	for _, c := range configs {
+	for _, c := range s.visibleConfigs(configs, admin) {
 		pbConfigs = append(pbConfigs, configToProto(c))
 	}
 
@@ -148,7 +440,11 @@ func (s *BeadsServer) DeleteConfig(ctx context.Context, req *beadsv1.DeleteConfi
 		return nil, status.Error(codes.InvalidArgument, "key is required")
 	}
 
-	if err := s.store.DeleteConfig(ctx, req.GetKey()); err != nil {
+	if _, err := s.checkConfigAccess(ctx, grpcBearerToken(ctx), req.GetKey()); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if err := s.deleteConfig(ctx, req.GetKey()); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Error(codes.NotFound, "config not found")
 		}
@@ -157,3 +453,52 @@ func (s *BeadsServer) DeleteConfig(ctx context.Context, req *beadsv1.DeleteConfi
 
 	return &beadsv1.DeleteConfigResponse{}, nil
 }
+
+// GetConfigHistory lists a config's revisions, newest first.
+func (s *BeadsServer) GetConfigHistory(ctx context.Context, req *beadsv1.GetConfigHistoryRequest) (*beadsv1.GetConfigHistoryResponse, error) {
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+
+	admin, err := s.checkConfigAccess(ctx, grpcBearerToken(ctx), req.GetKey())
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	revs, err := s.store.ListConfigRevisions(ctx, req.GetKey())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list config history: %v", err)
+	}
+
+	pbRevs := make([]*beadsv1.ConfigRevision, 0, len(revs))
+	for _, r := range redactRevisions(revs, admin) {
+		pbRevs = append(pbRevs, configRevisionToProto(r))
+	}
+	return &beadsv1.GetConfigHistoryResponse{Revisions: pbRevs}, nil
+}
+
+// RollbackConfig restores a config to an earlier revision.
+func (s *BeadsServer) RollbackConfig(ctx context.Context, req *beadsv1.RollbackConfigRequest) (*beadsv1.RollbackConfigResponse, error) {
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+	if req.GetRev() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "rev is required")
+	}
+
+	admin, err := s.checkConfigAccess(ctx, grpcBearerToken(ctx), req.GetKey())
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	config, err := s.rollbackConfig(ctx, req.GetKey(), req.GetRev(), req.GetUpdatedBy())
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.FailedPrecondition, ie.Error())
+		}
+		return nil, storeError(err, "config revision")
+	}
+
+	return &beadsv1.RollbackConfigResponse{Config: configToProto(redactConfig(config, admin))}, nil
+}
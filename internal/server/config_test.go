@@ -1,11 +1,17 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
 	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
 )
 
 func TestGRPCSetConfig(t *testing.T) {
@@ -70,3 +76,179 @@ func TestGRPCDeleteConfig(t *testing.T) {
 		t.Fatal("expected config to be deleted")
 	}
 }
+
+// configPublisher captures published ConfigChanged events.
+type configPublisher struct {
+	mu      sync.Mutex
+	changes []events.ConfigChanged
+}
+
+func (p *configPublisher) Publish(_ context.Context, topic string, e any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if c, ok := e.(events.ConfigChanged); ok && topic == events.TopicConfigChanged {
+		p.changes = append(p.changes, c)
+	}
+	return nil
+}
+
+func (p *configPublisher) Close() error { return nil }
+
+func TestConfigHistoryAndRollback(t *testing.T) {
+	srv, _, h := newTestServer()
+	pub := &configPublisher{}
+	srv.publisher = pub
+
+	requireStatus(t, doJSON(t, h, "PUT", "/v1/configs/view:inbox", map[string]any{"value": map[string]any{"limit": 5}, "updated_by": "alice"}), http.StatusOK)
+	requireStatus(t, doJSON(t, h, "PUT", "/v1/configs/view:inbox", map[string]any{"value": map[string]any{"limit": 500}, "updated_by": "bob"}), http.StatusOK)
+
+	rec := doJSON(t, h, "GET", "/v1/configs/view:inbox/history", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var history struct {
+		Revisions []model.ConfigRevision `json:"revisions"`
+	}
+	decodeJSON(t, rec, &history)
+	if len(history.Revisions) != 2 || history.Revisions[0].Rev != 2 || history.Revisions[0].Actor != "bob" {
+		t.Fatalf("unexpected history: %+v", history.Revisions)
+	}
+
+	rec = doJSON(t, h, "POST", "/v1/configs/view:inbox/rollback?rev=1", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var config model.Config
+	decodeJSON(t, rec, &config)
+	if config.Rev != 3 || string(config.Value) != `{"limit":5}` {
+		t.Fatalf("unexpected rollback result: rev=%d value=%s", config.Rev, config.Value)
+	}
+
+	// The plain key route still resolves to the config itself.
+	rec = doJSON(t, h, "GET", "/v1/configs/view:inbox", nil)
+	requireStatus(t, rec, http.StatusOK)
+
+	if len(pub.changes) != 3 {
+		t.Fatalf("expected 3 config.changed events, got %d", len(pub.changes))
+	}
+	if last := pub.changes[2]; last.Rev != 3 || last.RestoredRev != 1 {
+		t.Fatalf("unexpected rollback event: %+v", last)
+	}
+}
+
+func TestConfigChangesAreRecorded(t *testing.T) {
+	_, ms, h := newTestServer()
+	requireStatus(t, doJSON(t, h, "PUT", "/v1/configs/view:ci", map[string]any{"value": map[string]any{"api_key": "k-1", "filter": map[string]any{}}}), http.StatusOK)
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/configs/view:ci", nil), http.StatusNoContent)
+
+	if len(ms.events) != 2 {
+		t.Fatalf("recorded %d events, want 2", len(ms.events))
+	}
+	for _, e := range ms.events {
+		if e.Topic != events.TopicConfigChanged || !ms.published[e.ID] {
+			t.Fatalf("event %+v: want a published config.changed", e)
+		}
+		if strings.Contains(string(e.Payload), "k-1") {
+			t.Fatalf("secret stored in the event log: %s", e.Payload)
+		}
+	}
+	if !strings.Contains(string(ms.events[0].Payload), model.RedactedSecret) {
+		t.Fatalf("payload = %s, want the secret redacted", ms.events[0].Payload)
+	}
+}
+
+func TestRollbackConfig_Errors(t *testing.T) {
+	srv, _, h := newTestServer()
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/configs/view:inbox/rollback", nil), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/configs/view:inbox/rollback?rev=4", nil), http.StatusNotFound)
+
+	// Rolling back to a deletion is refused.
+	ctx := context.Background()
+	if _, err := srv.setConfig(ctx, "view:inbox", json.RawMessage(`{}`), ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.deleteConfig(ctx, "view:inbox"); err != nil {
+		t.Fatal(err)
+	}
+	requireStatus(t, doJSON(t, h, "POST", "/v1/configs/view:inbox/rollback?rev=2", nil), http.StatusConflict)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/configs/view:inbox/rollback?rev=1", nil), http.StatusOK)
+}
+
+func TestGRPCConfigHistoryAndRollback(t *testing.T) {
+	srv, _, ctx := testCtx(t)
+	for _, v := range []string{`{"a":1}`, `{"a":2}`} {
+		if _, err := srv.SetConfig(ctx, &beadsv1.SetConfigRequest{Key: "view:inbox", Value: []byte(v), UpdatedBy: "alice"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hist, err := srv.GetConfigHistory(ctx, &beadsv1.GetConfigHistoryRequest{Key: "view:inbox"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hist.GetRevisions()) != 2 || hist.GetRevisions()[1].GetActor() != "alice" {
+		t.Fatalf("unexpected history: %v", hist.GetRevisions())
+	}
+
+	resp, err := srv.RollbackConfig(ctx, &beadsv1.RollbackConfigRequest{Key: "view:inbox", Rev: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetConfig().GetRev() != 3 || string(resp.GetConfig().GetValue()) != `{"a":1}` {
+		t.Fatalf("unexpected config: %v", resp.GetConfig())
+	}
+
+	_, err = srv.RollbackConfig(ctx, &beadsv1.RollbackConfigRequest{Key: "view:inbox", Rev: 9})
+	requireCode(t, err, codes.NotFound)
+}
+
+func TestConfigAccess(t *testing.T) {
+	s, ms, h := newTestServer()
+	s.SetRegistrationTokens("admin-secret", "boot-secret")
+	s.SetConfigPolicy([]string{"integration"})
+	ms.configs["integration:slack"] = &model.Config{Key: "integration:slack", Value: json.RawMessage(`{"bot_token":"xoxb-1","channel":"#ops"}`)}
+	ms.configs["view:ci"] = &model.Config{Key: "view:ci", Value: json.RawMessage(`{"api_key":"k-1","filter":{}}`)}
+
+	// Admin-only namespaces need the admin token for every operation.
+	requireStatus(t, doJSON(t, h, "GET", "/v1/configs/integration:slack", nil), http.StatusForbidden)
+	requireStatus(t, doJSON(t, h, "GET", "/v1/configs?namespace=integration", nil), http.StatusForbidden)
+	requireStatus(t, doJSON(t, h, "GET", "/v1/configs/integration:slack/history", nil), http.StatusForbidden)
+	requireStatus(t, doBearer(t, h, "PUT", "/v1/configs/integration:slack", "boot-secret", map[string]any{"value": map[string]string{"bot_token": "x"}}), http.StatusForbidden)
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/configs/integration:slack", nil), http.StatusForbidden)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/configs/integration:slack/rollback?rev=1", nil), http.StatusForbidden)
+
+	rec := doBearer(t, h, "GET", "/v1/configs/integration:slack", "admin-secret", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var c model.Config
+	decodeJSON(t, rec, &c)
+	if string(c.Value) != `{"bot_token":"xoxb-1","channel":"#ops"}` {
+		t.Fatalf("admin sees %s", c.Value)
+	}
+
+	// Secrets elsewhere are redacted for everyone but admins.
+	rec = doJSON(t, h, "GET", "/v1/configs/view:ci", nil)
+	requireStatus(t, rec, http.StatusOK)
+	decodeJSON(t, rec, &c)
+	if string(c.Value) != `{"api_key":"********","filter":{}}` {
+		t.Fatalf("caller sees %s", c.Value)
+	}
+	rec = doBearer(t, h, "GET", "/v1/configs/view:ci", "admin-secret", nil)
+	decodeJSON(t, rec, &c)
+	if string(c.Value) != `{"api_key":"k-1","filter":{}}` {
+		t.Fatalf("admin sees %s", c.Value)
+	}
+
+	// A read-modify-write that sends the placeholder back is rejected.
+	requireStatus(t, doJSON(t, h, "PUT", "/v1/configs/view:ci", map[string]any{"value": map[string]any{"api_key": model.RedactedSecret, "filter": map[string]any{}}}), http.StatusBadRequest)
+
+	// Over gRPC, the admin namespace is denied and secrets are redacted.
+	ctx := context.Background()
+	_, err := s.GetConfig(ctx, &beadsv1.GetConfigRequest{Key: "integration:slack"})
+	requireCode(t, err, codes.PermissionDenied)
+	resp, err := s.ListConfigs(ctx, &beadsv1.ListConfigsRequest{Namespace: "view"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range resp.GetConfigs() {
+		if c.GetKey() == "view:ci" && string(c.GetValue()) != `{"api_key":"********","filter":{}}` {
+			t.Fatalf("gRPC caller sees %s", c.GetValue())
+		}
+	}
+}
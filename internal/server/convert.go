@@ -6,6 +6,7 @@ import (
 	"time"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/alerts"
 	"github.com/alfredjeanlab/beads/internal/model"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -19,22 +20,29 @@ func beadToProto(b *model.Bead) *beadsv1.Bead {
 	}
 
 	pb := &beadsv1.Bead{
-		Id:          b.ID,
-		Slug:        b.Slug,
-		Kind:        string(b.Kind),
-		Type:        string(b.Type),
-		Title:       b.Title,
-		Description: b.Description,
-		Notes:       b.Notes,
-		Status:      string(b.Status),
-		Priority:    int32(b.Priority),
-		Assignee:    b.Assignee,
-		Owner:       b.Owner,
-		CreatedAt:   timestamppb.New(b.CreatedAt),
-		CreatedBy:   b.CreatedBy,
-		UpdatedAt:   timestamppb.New(b.UpdatedAt),
-		Fields:      []byte(b.Fields),
-		Labels:      b.Labels,
+		Id:             b.ID,
+		Slug:           b.Slug,
+		Kind:           string(b.Kind),
+		Type:           string(b.Type),
+		Title:          b.Title,
+		Description:    b.Description,
+		Notes:          b.Notes,
+		Status:         string(b.Status),
+		Priority:       int32(b.Priority),
+		Assignee:       b.Assignee,
+		Owner:          b.Owner,
+		CreatedAt:      timestamppb.New(b.CreatedAt),
+		CreatedBy:      b.CreatedBy,
+		UpdatedAt:      timestamppb.New(b.UpdatedAt),
+		Fields:         []byte(b.Fields),
+		Labels:         b.Labels,
+		AgeDays:        int32(b.AgeDays),
+		BlockedCount:   int32(b.BlockedCount),
+		ChecklistDone:  int32(b.ChecklistDone),
+		ChecklistTotal: int32(b.ChecklistTotal),
+		PriorityChain:  b.PriorityChain,
+		Estimate:       b.Estimate,
+		Actual:         b.Actual,
 	}
 
 	if b.ClosedAt != nil {
@@ -46,6 +54,16 @@ func beadToProto(b *model.Bead) *beadsv1.Bead {
 	if b.DeferUntil != nil {
 		pb.DeferUntil = timestamppb.New(*b.DeferUntil)
 	}
+	if b.LastActivityAt != nil {
+		pb.LastActivityAt = timestamppb.New(*b.LastActivityAt)
+	}
+	if b.ArchivedAt != nil {
+		pb.ArchivedAt = timestamppb.New(*b.ArchivedAt)
+	}
+	if b.EffectivePriority != nil {
+		p := int32(*b.EffectivePriority)
+		pb.EffectivePriority = &p
+	}
 
 	for _, d := range b.Dependencies {
 		pb.Dependencies = append(pb.Dependencies, dependencyToProto(d))
@@ -53,6 +71,9 @@ func beadToProto(b *model.Bead) *beadsv1.Bead {
 	for _, c := range b.Comments {
 		pb.Comments = append(pb.Comments, commentToProto(c))
 	}
+	for _, it := range b.Checklist {
+		pb.Checklist = append(pb.Checklist, checklistItemToProto(it))
+	}
 
 	return pb
 }
@@ -68,21 +89,60 @@ func dependencyToProto(d *model.Dependency) *beadsv1.Dependency {
 		Type:        string(d.Type),
 		CreatedAt:   timestamppb.New(d.CreatedAt),
 		CreatedBy:   d.CreatedBy,
-		Metadata:    d.Metadata,
+		Metadata:    string(d.Metadata),
 	}
 }
 
 // commentToProto converts a model.Comment to a proto Comment message.
+func aliasToProto(a *model.Alias) *beadsv1.Alias {
+	if a == nil {
+		return nil
+	}
+	return &beadsv1.Alias{
+		Alias:     a.Alias,
+		BeadId:    a.BeadID,
+		CreatedBy: a.CreatedBy,
+		CreatedAt: timestamppb.New(a.CreatedAt),
+	}
+}
+
 func commentToProto(c *model.Comment) *beadsv1.Comment {
 	if c == nil {
 		return nil
 	}
-	return &beadsv1.Comment{
-		Id:        c.ID,
-		BeadId:    c.BeadID,
-		Author:    c.Author,
-		Text:      c.Text,
-		CreatedAt: timestamppb.New(c.CreatedAt),
+	pb := &beadsv1.Comment{
+		Id:            c.ID,
+		BeadId:        c.BeadID,
+		Author:        c.Author,
+		Text:          c.Text,
+		CreatedAt:     timestamppb.New(c.CreatedAt),
+		ResolvedBy:    c.ResolvedBy,
+		Verified:      c.Verified,
+		ClaimedAuthor: c.ClaimedAuthor,
+	}
+	if c.ResolvedAt != nil {
+		pb.ResolvedAt = timestamppb.New(*c.ResolvedAt)
+	}
+	if len(c.Reactions) > 0 {
+		pb.Reactions = make(map[string]int32, len(c.Reactions))
+		for emoji, n := range c.Reactions {
+			pb.Reactions[emoji] = int32(n)
+		}
+	}
+	return pb
+}
+
+// noteToProto converts a model.Note to a proto Note message.
+func noteToProto(n *model.Note) *beadsv1.Note {
+	if n == nil {
+		return nil
+	}
+	return &beadsv1.Note{
+		Id:        n.ID,
+		BeadId:    n.BeadID,
+		Author:    n.Author,
+		Text:      n.Text,
+		CreatedAt: timestamppb.New(n.CreatedAt),
 	}
 }
 
@@ -101,6 +161,23 @@ func eventToProto(e *model.Event) *beadsv1.Event {
 	}
 }
 
+// notificationToProto converts a model.Notification to a proto Notification message.
+func notificationToProto(n *model.Notification) *beadsv1.Notification {
+	if n == nil {
+		return nil
+	}
+	pb := &beadsv1.Notification{
+		Id:        n.ID,
+		Actor:     n.Actor,
+		Event:     eventToProto(n.Event),
+		CreatedAt: timestamppb.New(n.CreatedAt),
+	}
+	if n.ReadAt != nil {
+		pb.ReadAt = timestamppb.New(*n.ReadAt)
+	}
+	return pb
+}
+
 // configToProto converts a model.Config to a proto Config message.
 func configToProto(c *model.Config) *beadsv1.Config {
 	if c == nil {
@@ -111,7 +188,42 @@ func configToProto(c *model.Config) *beadsv1.Config {
 		Value:     []byte(c.Value),
 		CreatedAt: timestamppb.New(c.CreatedAt),
 		UpdatedAt: timestamppb.New(c.UpdatedAt),
+		Rev:       c.Rev,
+	}
+}
+
+// configRevisionToProto converts a model.ConfigRevision to a proto ConfigRevision.
+func configRevisionToProto(r *model.ConfigRevision) *beadsv1.ConfigRevision {
+	if r == nil {
+		return nil
 	}
+	return &beadsv1.ConfigRevision{
+		Key:       r.Key,
+		Rev:       r.Rev,
+		Value:     []byte(r.Value),
+		Deleted:   r.Deleted,
+		Actor:     r.Actor,
+		CreatedAt: timestamppb.New(r.CreatedAt),
+	}
+}
+
+// alertToProto converts an alerts.Alert to a proto Alert message.
+func alertToProto(a *alerts.Alert) *beadsv1.Alert {
+	if a == nil {
+		return nil
+	}
+	pb := &beadsv1.Alert{
+		Name:        a.Name,
+		Metric:      string(a.Metric),
+		Threshold:   a.Threshold,
+		Value:       a.Value,
+		Firing:      a.Firing,
+		EvaluatedAt: timestamppb.New(a.EvaluatedAt),
+	}
+	if a.Since != nil {
+		pb.Since = timestamppb.New(*a.Since)
+	}
+	return pb
 }
 
 // protoTimestamp converts an optional proto Timestamp to a *time.Time.
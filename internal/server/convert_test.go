@@ -3,7 +3,9 @@ package server
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/alfredjeanlab/beads/internal/model"
 	"google.golang.org/grpc/codes"
 )
 
@@ -35,3 +37,28 @@ func TestStoreError_InternalError(t *testing.T) {
 	err := storeError(fmt.Errorf("something went wrong"), "bead")
 	requireCode(t, err, codes.Internal)
 }
+
+func TestBeadToProto_ComputedFields(t *testing.T) {
+	last := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	pb := beadToProto(&model.Bead{ID: "bd-c1", AgeDays: 4, BlockedCount: 2, LastActivityAt: &last})
+	if pb.GetAgeDays() != 4 || pb.GetBlockedCount() != 2 {
+		t.Fatalf("got age_days=%d blocked_count=%d", pb.GetAgeDays(), pb.GetBlockedCount())
+	}
+	if !pb.GetLastActivityAt().AsTime().Equal(last) {
+		t.Fatalf("got last_activity_at=%v", pb.GetLastActivityAt().AsTime())
+	}
+	if beadToProto(&model.Bead{ID: "bd-c2"}).LastActivityAt != nil {
+		t.Fatal("expected nil last_activity_at when unset")
+	}
+}
+
+func TestBeadToProto_EffectivePriority(t *testing.T) {
+	effective := 0
+	pb := beadToProto(&model.Bead{ID: "bd-p1", Priority: 3, EffectivePriority: &effective, PriorityChain: []string{"bd-p1", "bd-p0"}})
+	if pb.EffectivePriority == nil || pb.GetEffectivePriority() != 0 || len(pb.GetPriorityChain()) != 2 {
+		t.Fatalf("got effective_priority=%v chain=%v", pb.EffectivePriority, pb.GetPriorityChain())
+	}
+	if beadToProto(&model.Bead{ID: "bd-p2"}).EffectivePriority != nil {
+		t.Fatal("expected nil effective_priority when unset")
+	}
+}
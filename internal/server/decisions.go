@@ -0,0 +1,416 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// decisionExpiryActor is recorded as closed_by on decisions closed by the
+// expiry worker.
+const decisionExpiryActor = "beads:expiry"
+
+// decisionFields is the subset of a decision bead's fields used for expiry
+// and the context view.
+type decisionFields struct {
+	Options       []string `json:"options,omitempty"`
+	DefaultOption string   `json:"default_option,omitempty"`
+	ExpiresAt     string   `json:"expires_at,omitempty"`
+	ContextBeads  []string `json:"context_beads,omitempty"`
+	Diff          string   `json:"diff,omitempty"`
+	Links         []string `json:"links,omitempty"`
+	Approvers     []string `json:"approvers,omitempty"`
+}
+
+// RunDecisionExpiry expires overdue decisions every interval until ctx is
+// cancelled.
+func (s *BeadsServer) RunDecisionExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.ExpireDecisions(ctx, time.Now().UTC()); err != nil {
+				slog.Error("decision expiry failed", "err", err)
+			} else if n > 0 {
+				slog.Info("expired decisions", "count", n)
+			}
+		}
+	}
+}
+
+// ExpireDecisions closes every open decision whose expires_at is at or before
+// now. A decision with a default_option is resolved to it (fields.chosen);
+// one without is cancelled. Each expiry emits a DecisionExpired event.
+// Returns the number of decisions expired.
+func (s *BeadsServer) ExpireDecisions(ctx context.Context, now time.Time) (int, error) {
+	beads, _, err := s.store.ListBeads(ctx, model.BeadFilter{
+		Type:   []model.BeadType{"decision"},
+		Status: []model.Status{model.StatusOpen, model.StatusInProgress},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("listing decisions: %w", err)
+	}
+
+	expired := 0
+	for _, b := range beads {
+		var df decisionFields
+		if len(b.Fields) > 0 {
+			if err := json.Unmarshal(b.Fields, &df); err != nil {
+				slog.Warn("invalid decision fields", "bead_id", b.ID, "err", err)
+				continue
+			}
+		}
+		if df.ExpiresAt == "" {
+			continue
+		}
+		expiresAt, err := time.Parse(time.RFC3339, df.ExpiresAt)
+		if err != nil || expiresAt.After(now) {
+			continue
+		}
+		if err := s.expireDecision(ctx, b, df.DefaultOption); err != nil {
+			slog.Warn("failed to expire decision", "bead_id", b.ID, "err", err)
+			continue
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// expireDecision resolves the decision to its default option, or cancels it
+// when there is none, and emits DecisionExpired.
+func (s *BeadsServer) expireDecision(ctx context.Context, b *model.Bead, defaultOption string) error {
+	_, err := retryOnConflict(func() (*model.Bead, error) {
+		return s.closeDecision(ctx, b.ID, defaultOption, decisionExpiryActor, true)
+	})
+	return err
+}
+
+// resolveDecision records option as the decision's chosen value and closes
+// it. An empty option closes the decision without a choice (cancelled).
+// A non-empty option must be one of the decision's listed options, if any.
+// Returns sql.ErrNoRows if the bead does not exist, inputError if it is
+// not an open decision, forbiddenError if it lists approvers and actor is
+// not one of them, and *lockedError if another actor holds its lock.
+func (s *BeadsServer) resolveDecision(ctx context.Context, id, option, actor string) (*model.Bead, error) {
+	return retryOnConflict(func() (*model.Bead, error) {
+		return s.closeDecision(ctx, id, option, actor, false)
+	})
+}
+
+// closeDecision implements resolveDecision, also recording DecisionExpired
+// when expired is set. The choice, the close and their events commit
+// together.
+func (s *BeadsServer) closeDecision(ctx context.Context, id, option, actor string, expired bool) (*model.Bead, error) {
+	b, err := s.store.GetBead(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, sql.ErrNoRows
+	}
+	if b.Type != "decision" {
+		return nil, inputError("bead " + id + " is not a decision")
+	}
+	if b.Status == model.StatusClosed {
+		return nil, inputError("decision " + id + " is already closed")
+	}
+	if !expired {
+		if err := s.checkApprover(ctx, b, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	if option != "" {
+		fields := map[string]any{}
+		if len(b.Fields) > 0 {
+			if err := json.Unmarshal(b.Fields, &fields); err != nil {
+				return nil, err
+			}
+		}
+		if opts, ok := fields["options"].([]any); ok && len(opts) > 0 && !slices.Contains(opts, any(option)) {
+			return nil, inputError(fmt.Sprintf("%q is not an option of decision %s", option, id))
+		}
+		fields["chosen"] = option
+		if b.Fields, err = json.Marshal(fields); err != nil {
+			return nil, err
+		}
+	}
+
+	var closed *model.Bead
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := checkLock(ctx, tx, id, actor); err != nil {
+			return err
+		}
+		if option != "" {
+			if err := tx.UpdateBead(ctx, b); err != nil {
+				return err
+			}
+			if err := s.recordEvent(ctx, tx, events.TopicBeadUpdated, id, actor, events.BeadUpdated{
+				Bead:    b,
+				Changes: map[string]any{"fields": b.Fields},
+			}); err != nil {
+				return err
+			}
+		}
+		var err error
+		if closed, err = tx.CloseBead(ctx, id, actor); err != nil {
+			return err
+		}
+		if closed == nil {
+			return sql.ErrNoRows
+		}
+		if err := s.recordEvent(ctx, tx, events.TopicBeadClosed, closed.ID, actor, events.BeadClosed{
+			Bead:     closed,
+			ClosedBy: actor,
+		}); err != nil {
+			return err
+		}
+		if option != "" {
+			if err := s.recordEvent(ctx, tx, events.TopicDecisionResolved, closed.ID, actor, events.DecisionResolved{
+				BeadID:     closed.ID,
+				Chosen:     option,
+				ResolvedBy: actor,
+			}); err != nil {
+				return err
+			}
+		}
+		if !expired {
+			return nil
+		}
+		return s.recordEvent(ctx, tx, events.TopicDecisionExpired, closed.ID, actor, events.DecisionExpired{
+			BeadID:    closed.ID,
+			Chosen:    option,
+			Cancelled: option == "",
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return closed, nil
+}
+
+// checkApprover returns a forbiddenError if decision b lists approvers and
+// actor is not one of them. Approvers are compared as canonical actors, so
+// an alias counts as its actor.
+func (s *BeadsServer) checkApprover(ctx context.Context, b *model.Bead, actor string) error {
+	var df decisionFields
+	if len(b.Fields) > 0 {
+		if err := json.Unmarshal(b.Fields, &df); err != nil {
+			return fmt.Errorf("decision %s: %w", b.ID, err)
+		}
+	}
+	if len(df.Approvers) == 0 {
+		return nil
+	}
+	actor = s.canonicalActor(ctx, actor)
+	for _, a := range df.Approvers {
+		if s.canonicalActor(ctx, a) == actor {
+			return nil
+		}
+	}
+	return forbiddenError(fmt.Sprintf("%s is not an approver of decision %s (approvers: %s)", actor, b.ID, strings.Join(df.Approvers, ", ")))
+}
+
+// summaryMaxLen caps the description excerpt in a beadSummary, in runes.
+const summaryMaxLen = 280
+
+// beadSummary is the compact view of a linked bead inlined into a decision's
+// context.
+type beadSummary struct {
+	ID       string         `json:"id"`
+	Title    string         `json:"title"`
+	Type     model.BeadType `json:"type"`
+	Status   model.Status   `json:"status"`
+	Priority int            `json:"priority"`
+	Assignee string         `json:"assignee,omitempty"`
+	Labels   []string       `json:"labels,omitempty"`
+	Summary  string         `json:"summary,omitempty"` // description, truncated
+}
+
+func summarizeBead(b *model.Bead) *beadSummary {
+	summary := b.Description
+	if r := []rune(summary); len(r) > summaryMaxLen {
+		summary = string(r[:summaryMaxLen-1]) + "…"
+	}
+	return &beadSummary{
+		ID:       b.ID,
+		Title:    b.Title,
+		Type:     b.Type,
+		Status:   b.Status,
+		Priority: b.Priority,
+		Assignee: b.Assignee,
+		Labels:   b.Labels,
+		Summary:  summary,
+	}
+}
+
+// decisionContext is everything a responder needs to answer a decision: the
+// decision itself, its options, the attached diff and links, and summaries of
+// the linked beads.
+type decisionContext struct {
+	Decision *model.Bead    `json:"decision"`
+	Options  []string       `json:"options"`
+	Diff     string         `json:"diff,omitempty"`
+	Links    []string       `json:"links"`
+	Beads    []*beadSummary `json:"beads"`
+	Missing  []string       `json:"missing,omitempty"` // linked IDs that no longer exist
+}
+
+// getDecisionContext composes the context view of decision id. Returns
+// sql.ErrNoRows if the bead does not exist and inputError if it is not a
+// decision.
+func (s *BeadsServer) getDecisionContext(ctx context.Context, id string) (*decisionContext, error) {
+	b, err := s.store.GetBead(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, sql.ErrNoRows
+	}
+	if b.Type != "decision" {
+		return nil, inputError("bead " + id + " is not a decision")
+	}
+
+	var df decisionFields
+	if len(b.Fields) > 0 {
+		if err := json.Unmarshal(b.Fields, &df); err != nil {
+			return nil, fmt.Errorf("decision %s: %w", id, err)
+		}
+	}
+
+	dc := &decisionContext{
+		Decision: b,
+		Options:  df.Options,
+		Diff:     df.Diff,
+		Links:    df.Links,
+		Beads:    []*beadSummary{},
+	}
+	if dc.Options == nil {
+		dc.Options = []string{}
+	}
+	if dc.Links == nil {
+		dc.Links = []string{}
+	}
+	for _, linkedID := range df.ContextBeads {
+		linked, err := s.store.GetBead(ctx, linkedID)
+		if err != nil {
+			return nil, fmt.Errorf("loading linked bead %s: %w", linkedID, err)
+		}
+		if linked == nil {
+			dc.Missing = append(dc.Missing, linkedID)
+			continue
+		}
+		dc.Beads = append(dc.Beads, summarizeBead(linked))
+	}
+	return dc, nil
+}
+
+// handleGetDecisionContext handles GET /v1/decisions/{id}/context.
+func (s *BeadsServer) handleGetDecisionContext(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	dc, err := s.getDecisionContext(r.Context(), id)
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			writeError(w, http.StatusBadRequest, ie.Error())
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "decision not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dc)
+}
+
+// grpcDecisionError maps a decision helper error to a gRPC status.
+func grpcDecisionError(err error) error {
+	var ie inputError
+	if errors.As(err, &ie) {
+		return status.Error(codes.InvalidArgument, ie.Error())
+	}
+	var fe forbiddenError
+	if errors.As(err, &fe) {
+		return status.Error(codes.PermissionDenied, fe.Error())
+	}
+	var le *lockedError
+	if errors.As(err, &le) {
+		return le.status()
+	}
+	return storeError(err, "decision")
+}
+
+// ResolveDecision records a decision's chosen option and closes it.
+func (s *BeadsServer) ResolveDecision(ctx context.Context, req *beadsv1.ResolveDecisionRequest) (*beadsv1.ResolveDecisionResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.GetOption() == "" {
+		return nil, status.Error(codes.InvalidArgument, "option is required")
+	}
+	b, err := s.resolveDecision(ctx, req.GetId(), req.GetOption(), s.actorFor(ctx, req.GetResolvedBy()))
+	if err != nil {
+		return nil, grpcDecisionError(err)
+	}
+	return &beadsv1.ResolveDecisionResponse{Bead: beadToProto(b)}, nil
+}
+
+// GetDecisionContext returns a decision with its options, diff, links and
+// linked beads.
+func (s *BeadsServer) GetDecisionContext(ctx context.Context, req *beadsv1.GetDecisionContextRequest) (*beadsv1.GetDecisionContextResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	dc, err := s.getDecisionContext(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcDecisionError(err)
+	}
+
+	summaries := make([]*beadsv1.BeadSummary, len(dc.Beads))
+	for i, b := range dc.Beads {
+		summaries[i] = &beadsv1.BeadSummary{
+			Id:       b.ID,
+			Title:    b.Title,
+			Type:     string(b.Type),
+			Status:   string(b.Status),
+			Priority: int32(b.Priority),
+			Assignee: b.Assignee,
+			Labels:   b.Labels,
+			Summary:  b.Summary,
+		}
+	}
+	return &beadsv1.GetDecisionContextResponse{
+		Decision: beadToProto(dc.Decision),
+		Options:  dc.Options,
+		Diff:     dc.Diff,
+		Links:    dc.Links,
+		Beads:    summaries,
+		Missing:  dc.Missing,
+	}, nil
+}
@@ -0,0 +1,335 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/slack"
+	"google.golang.org/grpc/codes"
+)
+
+func TestExpireDecisions(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	create := func(title, fields string) string {
+		t.Helper()
+		resp, err := srv.CreateBead(ctx, &beadsv1.CreateBeadRequest{
+			Title: title, Type: "decision", Fields: []byte(fields),
+		})
+		if err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		return resp.Bead.Id
+	}
+	withDefault := create("Ship?", `{"options":["yes","no"],"default_option":"no","expires_at":"2026-01-02T11:00:00Z"}`)
+	noDefault := create("Rename?", `{"options":["a","b"],"expires_at":"2026-01-02T12:00:00Z"}`)
+	future := create("Later?", `{"options":["a"],"default_option":"a","expires_at":"2026-01-03T00:00:00Z"}`)
+	noExpiry := create("Forever?", `{"options":["a"]}`)
+
+	n, err := srv.ExpireDecisions(ctx, now)
+	if err != nil {
+		t.Fatalf("ExpireDecisions: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expired %d decisions, want 2", n)
+	}
+
+	b := ms.beads[withDefault]
+	if b.Status != model.StatusClosed || b.ClosedBy != decisionExpiryActor {
+		t.Errorf("default decision: status=%q closed_by=%q", b.Status, b.ClosedBy)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(b.Fields, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields["chosen"] != "no" {
+		t.Errorf("chosen = %v, want %q", fields["chosen"], "no")
+	}
+
+	if b := ms.beads[noDefault]; b.Status != model.StatusClosed {
+		t.Errorf("cancelled decision status = %q, want closed", b.Status)
+	}
+	for _, id := range []string{future, noExpiry} {
+		if b := ms.beads[id]; b.Status != model.StatusOpen {
+			t.Errorf("decision %s status = %q, want open", id, b.Status)
+		}
+	}
+
+	var expired []events.DecisionExpired
+	for _, e := range ms.events {
+		if e.Topic != events.TopicDecisionExpired {
+			continue
+		}
+		var de events.DecisionExpired
+		if err := json.Unmarshal(e.Payload, &de); err != nil {
+			t.Fatal(err)
+		}
+		expired = append(expired, de)
+	}
+	if len(expired) != 2 {
+		t.Fatalf("got %d decision.expired events, want 2", len(expired))
+	}
+	for _, de := range expired {
+		switch de.BeadID {
+		case withDefault:
+			if de.Chosen != "no" || de.Cancelled {
+				t.Errorf("default event = %+v", de)
+			}
+		case noDefault:
+			if de.Chosen != "" || !de.Cancelled {
+				t.Errorf("cancel event = %+v", de)
+			}
+		default:
+			t.Errorf("unexpected event for %s", de.BeadID)
+		}
+	}
+
+	// A second pass finds nothing left to expire.
+	if n, err := srv.ExpireDecisions(ctx, now); err != nil || n != 0 {
+		t.Errorf("second pass: n=%d err=%v", n, err)
+	}
+}
+
+func TestHandleResolveDecision(t *testing.T) {
+	srv, ms, h := newTestServer()
+	resp, err := srv.CreateBead(context.Background(), &beadsv1.CreateBeadRequest{
+		Title: "Ship?", Type: "decision", Fields: []byte(`{"options":["yes","no"]}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := resp.Bead.Id
+
+	rec := doJSON(t, h, "POST", "/v1/beads/"+id+"/resolve", map[string]string{"option": "maybe"})
+	requireStatus(t, rec, http.StatusBadRequest)
+
+	rec = doJSON(t, h, "POST", "/v1/beads/"+id+"/resolve", map[string]string{"option": "yes", "resolved_by": "alice"})
+	requireStatus(t, rec, http.StatusOK)
+	if b := ms.beads[id]; b.Status != model.StatusClosed || b.ClosedBy != "alice" {
+		t.Errorf("status=%q closed_by=%q", b.Status, b.ClosedBy)
+	}
+	requireEvent(t, ms, 4, events.TopicDecisionResolved) // created, updated, closed, resolved
+
+	rec = doJSON(t, h, "POST", "/v1/beads/"+id+"/resolve", map[string]string{"option": "no"})
+	requireStatus(t, rec, http.StatusBadRequest)
+
+	rec = doJSON(t, h, "POST", "/v1/beads/bd-missing/resolve", map[string]string{"option": "no"})
+	requireStatus(t, rec, http.StatusNotFound)
+}
+
+func TestResolveDecision_Approvers(t *testing.T) {
+	srv, ms, h := newTestServer()
+	resp, err := srv.CreateBead(context.Background(), &beadsv1.CreateBeadRequest{
+		Title: "Deploy api?", Type: "decision",
+		Fields: []byte(`{"options":["approve","reject"],"default_option":"reject","approvers":["alice","bob"]}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := resp.Bead.Id
+
+	rec := doJSON(t, h, "POST", "/v1/beads/"+id+"/resolve", map[string]string{"option": "approve", "resolved_by": "mallory"})
+	requireStatus(t, rec, http.StatusForbidden)
+	_, err = srv.ResolveDecision(context.Background(), &beadsv1.ResolveDecisionRequest{Id: id, Option: "approve", ResolvedBy: "carol"})
+	requireCode(t, err, codes.PermissionDenied)
+	if ms.beads[id].Status == model.StatusClosed {
+		t.Fatal("a non-approver resolved the decision")
+	}
+
+	rec = doJSON(t, h, "POST", "/v1/beads/"+id+"/resolve", map[string]string{"option": "approve", "resolved_by": "bob"})
+	requireStatus(t, rec, http.StatusOK)
+
+	// Expiry resolves to the default whoever the approvers are.
+	resp, _ = srv.CreateBead(context.Background(), &beadsv1.CreateBeadRequest{
+		Title: "Deploy web?", Type: "decision",
+		Fields: []byte(`{"options":["approve","reject"],"default_option":"reject","approvers":["alice"],"expires_at":"2020-01-01T00:00:00Z"}`),
+	})
+	if n, err := srv.ExpireDecisions(context.Background(), time.Now()); err != nil || n != 1 {
+		t.Fatalf("ExpireDecisions = %d, %v", n, err)
+	}
+	if b := ms.beads[resp.Bead.Id]; b.Status != model.StatusClosed {
+		t.Fatalf("expired decision status = %q", b.Status)
+	}
+}
+
+func TestResolveDecision_Locked(t *testing.T) {
+	srv, ms, h := newTestServer()
+	resp, err := srv.CreateBead(context.Background(), &beadsv1.CreateBeadRequest{
+		Title: "Ship?", Type: "decision", Fields: []byte(`{"options":["yes","no"]}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := resp.Bead.Id
+	ms.locks[id] = &model.BeadLock{BeadID: id, Owner: "alice", ExpiresAt: time.Now().Add(time.Hour)}
+
+	rec := doJSON(t, h, "POST", "/v1/beads/"+id+"/resolve", map[string]string{"option": "no", "resolved_by": "bob"})
+	requireStatus(t, rec, http.StatusLocked)
+	_, err = srv.ResolveDecision(context.Background(), &beadsv1.ResolveDecisionRequest{Id: id, Option: "no", ResolvedBy: "bob"})
+	requireCode(t, err, codes.FailedPrecondition)
+	if b := ms.beads[id]; b.Status == model.StatusClosed || strings.Contains(string(b.Fields), "chosen") {
+		t.Fatalf("locked decision changed: %s %s", b.Status, b.Fields)
+	}
+
+	rec = doJSON(t, h, "POST", "/v1/beads/"+id+"/resolve", map[string]string{"option": "yes", "resolved_by": "alice"})
+	requireStatus(t, rec, http.StatusOK)
+}
+
+func TestValidateConfig_DecisionTemplate(t *testing.T) {
+	good := `{"title":"Deploy {context}?","options":["approve","reject","defer"],"default_option":"defer","urgency":"high","approvers":["alice"],"expires_in":"4h"}`
+	if err := validateConfig("decision-template:deploy-approval", json.RawMessage(good)); err != nil {
+		t.Fatal(err)
+	}
+	for _, bad := range []string{`{"options":[]}`, `{"options":["a"],"urgency":"now"}`, `{"options":"a"}`} {
+		if err := validateConfig("decision-template:x", json.RawMessage(bad)); err == nil {
+			t.Errorf("expected an error for %s", bad)
+		}
+	}
+}
+
+func TestHandleSlackInteraction(t *testing.T) {
+	srv, ms, h := newTestServer()
+
+	post := func(body string, sign bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/v1/integrations/slack/interactions", strings.NewReader(body))
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set("X-Slack-Request-Timestamp", ts)
+		if sign {
+			req.Header.Set("X-Slack-Signature", slack.Sign("s3cret", ts, []byte(body)))
+		} else {
+			req.Header.Set("X-Slack-Signature", "v0=bogus")
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Not configured yet.
+	requireStatus(t, post("", true), http.StatusNotFound)
+
+	ms.configs[slack.ConfigKey] = &model.Config{
+		Key:   slack.ConfigKey,
+		Value: json.RawMessage(`{"bot_token":"x","signing_secret":"s3cret","users":{"alice":"U1"}}`),
+	}
+	resp, err := srv.CreateBead(context.Background(), &beadsv1.CreateBeadRequest{
+		Title: "Ship?", Type: "decision", Fields: []byte(`{"options":["yes","no"]}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := resp.Bead.Id
+	payload := `{"type":"block_actions","user":{"id":"U1"},"actions":[{"block_id":"decision:` + id + `","value":"no"}]}`
+	body := url.Values{"payload": {payload}}.Encode()
+
+	requireStatus(t, post(body, false), http.StatusUnauthorized)
+	requireStatus(t, post(body, true), http.StatusOK)
+
+	b := ms.beads[id]
+	if b.Status != model.StatusClosed || b.ClosedBy != "alice" {
+		t.Errorf("status=%q closed_by=%q", b.Status, b.ClosedBy)
+	}
+	if !strings.Contains(string(b.Fields), `"chosen":"no"`) {
+		t.Errorf("fields = %s", b.Fields)
+	}
+}
+
+func TestHandleGetDecisionContext(t *testing.T) {
+	srv, ms, h := newTestServer()
+	ms.beads["bd-ctx1"] = &model.Bead{
+		ID: "bd-ctx1", Title: "Flaky login test", Type: "bug", Status: model.StatusInProgress, Priority: 1,
+		Assignee: "alice", Labels: []string{"auth"}, Description: strings.Repeat("x", 300),
+	}
+	resp, err := srv.CreateBead(context.Background(), &beadsv1.CreateBeadRequest{
+		Title: "Quarantine the test?", Type: "decision", Fields: []byte(`{
+			"options": ["quarantine", "fix now"],
+			"context_beads": ["bd-ctx1", "bd-gone"],
+			"diff": "-retries: 0\n+retries: 3",
+			"links": ["https://ci.example.com/run/42"]
+		}`),
+	})
+	if err != nil {
+		t.Fatalf("create decision: %v", err)
+	}
+
+	rec := doJSON(t, h, "GET", "/v1/decisions/"+resp.Bead.Id+"/context", nil)
+	requireStatus(t, rec, 200)
+	var dc struct {
+		Decision model.Bead `json:"decision"`
+		Options  []string   `json:"options"`
+		Diff     string     `json:"diff"`
+		Links    []string   `json:"links"`
+		Beads    []struct {
+			ID       string `json:"id"`
+			Status   string `json:"status"`
+			Assignee string `json:"assignee"`
+			Summary  string `json:"summary"`
+		} `json:"beads"`
+		Missing []string `json:"missing"`
+	}
+	decodeJSON(t, rec, &dc)
+	if dc.Decision.ID != resp.Bead.Id || len(dc.Options) != 2 || dc.Diff != "-retries: 0\n+retries: 3" || len(dc.Links) != 1 {
+		t.Fatalf("unexpected context: %+v", dc)
+	}
+	if len(dc.Beads) != 1 || dc.Beads[0].ID != "bd-ctx1" || dc.Beads[0].Assignee != "alice" || dc.Beads[0].Status != "in_progress" {
+		t.Fatalf("unexpected linked beads: %+v", dc.Beads)
+	}
+	if n := len([]rune(dc.Beads[0].Summary)); n != summaryMaxLen {
+		t.Fatalf("summary length = %d, want %d", n, summaryMaxLen)
+	}
+	if len(dc.Missing) != 1 || dc.Missing[0] != "bd-gone" {
+		t.Fatalf("missing = %v", dc.Missing)
+	}
+}
+
+func TestHandleGetDecisionContext_Errors(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-task"] = &model.Bead{ID: "bd-task", Title: "Task", Type: "task", Status: model.StatusOpen}
+
+	requireStatus(t, doJSON(t, h, "GET", "/v1/decisions/bd-task/context", nil), 400)
+	requireStatus(t, doJSON(t, h, "GET", "/v1/decisions/bd-nope/context", nil), 404)
+}
+
+func TestGRPCDecisions(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	resp, err := srv.CreateBead(ctx, &beadsv1.CreateBeadRequest{
+		Title: "Ship?", Type: "decision", Fields: []byte(`{"options":["yes","no"],"links":["https://example.com/pr/1"]}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := resp.Bead.Id
+
+	dc, err := srv.GetDecisionContext(ctx, &beadsv1.GetDecisionContextRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dc.GetDecision().GetId() != id || len(dc.GetOptions()) != 2 || len(dc.GetLinks()) != 1 {
+		t.Fatalf("unexpected context: %+v", dc)
+	}
+	_, err = srv.GetDecisionContext(ctx, &beadsv1.GetDecisionContextRequest{Id: "bd-missing"})
+	requireCode(t, err, codes.NotFound)
+
+	_, err = srv.ResolveDecision(ctx, &beadsv1.ResolveDecisionRequest{Id: id, Option: "maybe"})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = srv.ResolveDecision(ctx, &beadsv1.ResolveDecisionRequest{Id: id})
+	requireCode(t, err, codes.InvalidArgument)
+
+	res, err := srv.ResolveDecision(ctx, &beadsv1.ResolveDecisionRequest{Id: id, Option: "yes", ResolvedBy: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetBead().GetStatus() != string(model.StatusClosed) || ms.beads[id].ClosedBy != "alice" {
+		t.Fatalf("resolved bead = %+v", res.GetBead())
+	}
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// depTypeSet maps each configured dependency type to its deptype: config.
+type depTypeSet map[model.DependencyType]model.DepTypeConfig
+
+// depTypes loads the deptype: configs, including the builtin ones. Invalid
+// configs are logged and skipped.
+func (s *BeadsServer) depTypes(ctx context.Context) (depTypeSet, error) {
+	configs, err := s.listConfigsWithBuiltins(ctx, "deptype")
+	if err != nil {
+		return nil, err
+	}
+	set := make(depTypeSet, len(configs))
+	for _, c := range configs {
+		name := strings.TrimPrefix(c.Key, "deptype:")
+		var dc model.DepTypeConfig
+		if err := json.Unmarshal(c.Value, &dc); err != nil {
+			slog.Warn("skipping invalid deptype config", "key", c.Key, "err", err)
+			continue
+		}
+		set[model.DependencyType(name)] = dc
+	}
+	return set, nil
+}
+
+// blocking reports whether an unclosed dependency of type t blocks its bead.
+// It mirrors the store's readiness query: blocks always blocks, other types
+// only when their config says so.
+func (set depTypeSet) blocking(t model.DependencyType) bool {
+	return t == model.DepBlocks || set[t].Blocking
+}
+
+// label returns the display label for t, falling back to the type name.
+func (set depTypeSet) label(t model.DependencyType) string {
+	if l := set[t].Label; l != "" {
+		return l
+	}
+	return string(t)
+}
+
+// inverseLabel returns the label for t seen from the bead it points at,
+// falling back to "<label> (inverse)".
+func (set depTypeSet) inverseLabel(t model.DependencyType) string {
+	if l := set[t].InverseLabel; l != "" {
+		return l
+	}
+	return set.label(t) + " (inverse)"
+}
+
+// updateDependency replaces the metadata on an existing dependency and
+// publishes a DependencyUpdated event. Returns sql.ErrNoRows if the
+// dependency does not exist.
+func (s *BeadsServer) updateDependency(ctx context.Context, dep *model.Dependency, actor string) error {
+	if err := model.ValidateDependencyMetadata(dep.Metadata); err != nil {
+		return inputError(err.Error())
+	}
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := tx.UpdateDependencyMetadata(ctx, dep); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicDependencyUpdated, dep.BeadID, actor, events.DependencyUpdated{
+			Dependency: dep,
+			UpdatedBy:  actor,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	s.flushEvents(ctx)
+	return nil
+}
+
+// updateDependencyRequest is the JSON body for PATCH /v1/beads/{id}/dependencies.
+type updateDependencyRequest struct {
+	DependsOnID string          `json:"depends_on_id"`
+	Type        string          `json:"type"`
+	Metadata    json.RawMessage `json:"metadata"`
+	UpdatedBy   string          `json:"updated_by"`
+}
+
+// handleUpdateDependency handles PATCH /v1/beads/{id}/dependencies.
+func (s *BeadsServer) handleUpdateDependency(w http.ResponseWriter, r *http.Request) {
+	beadID := r.PathValue("id")
+	if beadID == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req updateDependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if req.DependsOnID == "" {
+		writeError(w, http.StatusBadRequest, "depends_on_id is required")
+		return
+	}
+	if req.Type == "" {
+		req.Type = string(model.DepBlocks)
+	}
+	if string(req.Metadata) == "null" {
+		req.Metadata = nil
+	}
+
+	dep := &model.Dependency{
+		BeadID:      beadID,
+		DependsOnID: s.resolveBeadID(r.Context(), req.DependsOnID),
+		Type:        model.DependencyType(req.Type),
+		Metadata:    req.Metadata,
+	}
+	if err := s.updateDependency(r.Context(), dep, s.actorFor(r.Context(), req.UpdatedBy)); err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "dependency not found")
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to update dependency")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dep)
+}
+
+// UpdateDependency replaces the metadata of a dependency.
+func (s *BeadsServer) UpdateDependency(ctx context.Context, req *beadsv1.UpdateDependencyRequest) (*beadsv1.UpdateDependencyResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	if req.GetDependsOnId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "depends_on_id is required")
+	}
+	depType := req.GetType()
+	if depType == "" {
+		depType = string(model.DepBlocks)
+	}
+
+	dep := &model.Dependency{
+		BeadID:      req.GetBeadId(),
+		DependsOnID: req.GetDependsOnId(),
+		Type:        model.DependencyType(depType),
+	}
+	if req.GetMetadata() != "" {
+		dep.Metadata = json.RawMessage(req.GetMetadata())
+	}
+	if err := s.updateDependency(ctx, dep, s.actorFor(ctx, req.GetUpdatedBy())); err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Error(codes.NotFound, "dependency not found")
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to update dependency: %v", err)
+		}
+	}
+
+	return &beadsv1.UpdateDependencyResponse{Dependency: dependencyToProto(dep)}, nil
+}
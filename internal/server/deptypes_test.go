@@ -0,0 +1,133 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+// seedReviewDepType defines a blocking "needs-review" dependency type.
+func seedReviewDepType(ms *mockStore) {
+	ms.configs["deptype:needs-review"] = &model.Config{
+		Key:   "deptype:needs-review",
+		Value: json.RawMessage(`{"blocking":true,"label":"awaiting review from"}`),
+	}
+}
+
+func TestAddDependency_Metadata(t *testing.T) {
+	_, ms, h := newTestServer()
+
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-m1/dependencies", map[string]any{
+		"depends_on_id": "bd-m2", "type": "related", "metadata": map[string]any{"weight": 3},
+	})
+	requireStatus(t, rec, http.StatusCreated)
+	if got := string(ms.deps["bd-m1"][0].Metadata); got != `{"weight":3}` {
+		t.Errorf("metadata = %s", got)
+	}
+
+	rec = doJSON(t, h, "POST", "/v1/beads/bd-m1/dependencies", map[string]any{
+		"depends_on_id": "bd-m3", "metadata": []int{1},
+	})
+	requireStatus(t, rec, http.StatusBadRequest)
+	if len(ms.deps["bd-m1"]) != 1 {
+		t.Errorf("invalid metadata should not add a dependency")
+	}
+}
+
+func TestHandleUpdateDependency(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.deps["bd-u1"] = []*model.Dependency{{BeadID: "bd-u1", DependsOnID: "bd-u2", Type: model.DepBlocks, CreatedBy: "alice"}}
+
+	rec := doJSON(t, h, "PATCH", "/v1/beads/bd-u1/dependencies", map[string]any{
+		"depends_on_id": "bd-u2", "metadata": map[string]any{"reason": "api first"}, "updated_by": "bob",
+	})
+	requireStatus(t, rec, http.StatusOK)
+	var dep model.Dependency
+	decodeJSON(t, rec, &dep)
+	if dep.CreatedBy != "alice" || string(dep.Metadata) != `{"reason":"api first"}` {
+		t.Errorf("response = %+v", dep)
+	}
+	if got := string(ms.deps["bd-u1"][0].Metadata); got != `{"reason":"api first"}` {
+		t.Errorf("stored metadata = %s", got)
+	}
+	requireEvent(t, ms, 1, "beads.dependency.updated")
+	if ms.events[0].Actor != "bob" {
+		t.Errorf("actor = %q, want bob", ms.events[0].Actor)
+	}
+
+	rec = doJSON(t, h, "PATCH", "/v1/beads/bd-u1/dependencies", map[string]any{
+		"depends_on_id": "bd-u2", "type": "related",
+	})
+	requireStatus(t, rec, http.StatusNotFound)
+
+	rec = doJSON(t, h, "PATCH", "/v1/beads/bd-u1/dependencies", map[string]any{
+		"depends_on_id": "bd-u2", "metadata": "text",
+	})
+	requireStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestGRPCUpdateDependency(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.deps["bd-u1"] = []*model.Dependency{{BeadID: "bd-u1", DependsOnID: "bd-u2", Type: model.DepRelated}}
+
+	resp, err := srv.UpdateDependency(ctx, &beadsv1.UpdateDependencyRequest{
+		BeadId: "bd-u1", DependsOnId: "bd-u2", Type: "related", Metadata: `{"note":"see thread"}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetDependency().GetMetadata() != `{"note":"see thread"}` {
+		t.Errorf("metadata = %q", resp.GetDependency().GetMetadata())
+	}
+
+	_, err = srv.UpdateDependency(ctx, &beadsv1.UpdateDependencyRequest{BeadId: "bd-u1", DependsOnId: "bd-u9"})
+	requireCode(t, err, codes.NotFound)
+	_, err = srv.AddDependency(ctx, &beadsv1.AddDependencyRequest{BeadId: "bd-u1", DependsOnId: "bd-u3", Metadata: "[]"})
+	requireCode(t, err, codes.InvalidArgument)
+}
+
+func TestScanReady_CustomBlockingType(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	seedReviewDepType(ms)
+	ms.beads["bd-c1"] = &model.Bead{ID: "bd-c1", Status: model.StatusOpen}
+	ms.beads["bd-c2"] = &model.Bead{ID: "bd-c2", Status: model.StatusOpen}
+	ms.beads["bd-c3"] = &model.Bead{ID: "bd-c3", Status: model.StatusOpen}
+	ms.deps["bd-c1"] = []*model.Dependency{{BeadID: "bd-c1", DependsOnID: "bd-c3", Type: "needs-review"}}
+	ms.deps["bd-c2"] = []*model.Dependency{{BeadID: "bd-c2", DependsOnID: "bd-c3", Type: model.DepRelated}}
+
+	page, err := srv.scanReady(ctx, model.BeadFilter{Status: []model.Status{model.StatusOpen}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range page.Beads {
+		if b.ID == "bd-c1" {
+			t.Fatalf("bd-c1 has an open needs-review dependency and should not be ready")
+		}
+	}
+	if page.Total != 2 {
+		t.Errorf("total = %d, want 2", page.Total)
+	}
+}
+
+func TestValidateConfig_DepType(t *testing.T) {
+	for _, tc := range []struct {
+		key, value string
+		ok         bool
+	}{
+		{"deptype:needs-review", `{"blocking":true,"label":"awaiting review"}`, true},
+		{"deptype:related", `{"blocking":false}`, true},
+		{"deptype:blocks", `{"blocking":false}`, false},
+		{"deptype:caused-by", `{"blocking":true}`, false},
+		{"deptype:caused-by", `{"blocking":false,"inverse_label":"causes"}`, true},
+		{"deptype:bad", `{"blocking":"yes"}`, false},
+	} {
+		err := validateConfig(tc.key, json.RawMessage(tc.value))
+		if (err == nil) != tc.ok {
+			t.Errorf("validateConfig(%s, %s) = %v", tc.key, tc.value, err)
+		}
+	}
+}
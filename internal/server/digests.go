@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// subscriptionNamespace is the config namespace of saved search
+// subscriptions, keyed by owner and name, e.g.
+// "subscription:alice:overdue-backend".
+const subscriptionNamespace = "subscription"
+
+// defaultDigestEvery is how often a subscription is digested unless its
+// config sets "every".
+const defaultDigestEvery = 24 * time.Hour
+
+// subscriptionConfig is the value of a "subscription:<owner>:<name>" config.
+type subscriptionConfig struct {
+	Filter model.BeadFilter `json:"filter"`
+	Every  string           `json:"every,omitempty"` // Go duration; default 24h
+}
+
+// every returns how often the subscription should be digested.
+func (c subscriptionConfig) every() (time.Duration, error) {
+	if c.Every == "" {
+		return defaultDigestEvery, nil
+	}
+	d, err := time.ParseDuration(c.Every)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid every %q", c.Every)
+	}
+	return d, nil
+}
+
+// parseSubscription decodes a subscription config value.
+func parseSubscription(cfg *model.Config) (subscriptionConfig, error) {
+	var sc subscriptionConfig
+	if err := json.Unmarshal(cfg.Value, &sc); err != nil {
+		return sc, inputError(fmt.Sprintf("invalid subscription %s: %v", cfg.Key, err))
+	}
+	if _, err := sc.every(); err != nil {
+		return sc, inputError(fmt.Sprintf("invalid subscription %s: %v", cfg.Key, err))
+	}
+	if err := checkQuery(sc.Filter); err != nil {
+		return sc, inputError(fmt.Sprintf("invalid subscription %s: %v", cfg.Key, err))
+	}
+	return sc, nil
+}
+
+// generateDigest runs subscription name now and records which matching beads
+// are new since its previous digest. On the first run every match is new.
+// Returns sql.ErrNoRows if the subscription does not exist.
+func (s *BeadsServer) generateDigest(ctx context.Context, name string, now time.Time) (*model.Digest, error) {
+	cfg, err := s.store.GetConfig(ctx, subscriptionNamespace+":"+name)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := parseSubscription(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := sc.Filter
+	filter.Limit, filter.Offset = 0, 0
+	beads, _, err := s.store.ListBeads(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing matches: %w", err)
+	}
+
+	var seen []string
+	prev, err := s.store.GetLatestDigest(ctx, name)
+	if err == nil {
+		seen = prev.BeadIDs
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	digest := &model.Digest{
+		Subscription: name,
+		GeneratedAt:  now,
+		BeadIDs:      make([]string, 0, len(beads)),
+		NewIDs:       []string{},
+	}
+	for _, b := range beads {
+		digest.BeadIDs = append(digest.BeadIDs, b.ID)
+		if !slices.Contains(seen, b.ID) {
+			digest.NewIDs = append(digest.NewIDs, b.ID)
+		}
+	}
+	// The digest is also the subscription's last-run marker, so it and its
+	// event commit together.
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := tx.CreateDigest(ctx, digest); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicDigestGenerated, "", "", events.DigestGenerated{Digest: digest})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return digest, nil
+}
+
+// RunDigests digests due subscriptions every interval until ctx is cancelled.
+func (s *BeadsServer) RunDigests(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.GenerateDueDigests(ctx, time.Now().UTC()); err != nil {
+				slog.Error("digest generation failed", "err", err)
+			} else if n > 0 {
+				slog.Info("generated digests", "count", n)
+			}
+		}
+	}
+}
+
+// GenerateDueDigests digests every subscription whose last digest is older
+// than its period, or that has never run. Invalid subscriptions are logged
+// and skipped. Returns the number of digests generated.
+func (s *BeadsServer) GenerateDueDigests(ctx context.Context, now time.Time) (int, error) {
+	configs, err := s.store.ListConfigs(ctx, subscriptionNamespace)
+	if err != nil {
+		return 0, fmt.Errorf("listing subscriptions: %w", err)
+	}
+
+	generated := 0
+	for _, cfg := range configs {
+		name := strings.TrimPrefix(cfg.Key, subscriptionNamespace+":")
+		sc, err := parseSubscription(cfg)
+		if err != nil {
+			slog.Warn("skipping subscription", "subscription", name, "err", err)
+			continue
+		}
+		every, _ := sc.every()
+
+		last, err := s.store.GetLatestDigest(ctx, name)
+		if err == nil && now.Sub(last.GeneratedAt) < every {
+			continue
+		} else if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return generated, err
+		}
+
+		if _, err := s.generateDigest(ctx, name, now); err != nil {
+			slog.Warn("digest failed", "subscription", name, "err", err)
+			continue
+		}
+		generated++
+	}
+	return generated, nil
+}
+
+// digestView is a digest with its new beads resolved.
+type digestView struct {
+	Subscription string        `json:"subscription"`
+	GeneratedAt  time.Time     `json:"generated_at"`
+	Total        int           `json:"total"` // beads matching at GeneratedAt
+	New          []*model.Bead `json:"new"`
+}
+
+// getDigest returns the latest digest for a subscription, generating one
+// first if refresh is set or the subscription has never run. Beads deleted
+// since the digest was generated are omitted. Returns sql.ErrNoRows if the
+// subscription does not exist.
+func (s *BeadsServer) getDigest(ctx context.Context, name string, refresh bool) (*digestView, error) {
+	var digest *model.Digest
+	if !refresh {
+		d, err := s.store.GetLatestDigest(ctx, name)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		digest = d
+	}
+	if digest == nil {
+		d, err := s.generateDigest(ctx, name, time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+		digest = d
+	}
+
+	view := &digestView{
+		Subscription: digest.Subscription,
+		GeneratedAt:  digest.GeneratedAt,
+		Total:        len(digest.BeadIDs),
+		New:          make([]*model.Bead, 0, len(digest.NewIDs)),
+	}
+	for _, id := range digest.NewIDs {
+		b, err := s.store.GetBead(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && b == nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		view.New = append(view.New, b)
+	}
+	return view, nil
+}
+
+// handleGetDigest handles GET /v1/digests/{name}?refresh=true.
+func (s *BeadsServer) handleGetDigest(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	view, err := s.getDigest(r.Context(), name, r.URL.Query().Get("refresh") == "true")
+	if err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "subscription not found")
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to get digest")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, view)
+}
+
+// GetDigest returns the latest digest of a saved search subscription.
+func (s *BeadsServer) GetDigest(ctx context.Context, req *beadsv1.GetDigestRequest) (*beadsv1.GetDigestResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	view, err := s.getDigest(ctx, req.GetName(), req.GetRefresh())
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		return nil, storeError(err, "subscription")
+	}
+
+	pbBeads := make([]*beadsv1.Bead, 0, len(view.New))
+	for _, b := range view.New {
+		pbBeads = append(pbBeads, beadToProto(b))
+	}
+
+	return &beadsv1.GetDigestResponse{
+		Subscription: view.Subscription,
+		GeneratedAt:  timestamppb.New(view.GeneratedAt),
+		Total:        int32(view.Total),
+		New:          pbBeads,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestGenerateDueDigests(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.configs["subscription:alice:open-bugs"] = &model.Config{
+		Key:   "subscription:alice:open-bugs",
+		Value: json.RawMessage(`{"filter":{"status":["open"],"type":["bug"]},"every":"1h"}`),
+	}
+	ms.configs["subscription:bob:broken"] = &model.Config{Key: "subscription:bob:broken", Value: json.RawMessage(`{"every":"soon"}`)}
+	ms.beads["bd-d1"] = &model.Bead{ID: "bd-d1", Title: "Crash", Type: "bug", Status: model.StatusOpen}
+	ms.beads["bd-d2"] = &model.Bead{ID: "bd-d2", Title: "Feature", Type: "feature", Status: model.StatusOpen}
+
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	n, err := srv.GenerateDueDigests(ctx, now)
+	if err != nil || n != 1 {
+		t.Fatalf("generated %d, err %v; want 1 (invalid subscription skipped)", n, err)
+	}
+	first := ms.digests[0]
+	if first.Subscription != "alice:open-bugs" || len(first.NewIDs) != 1 || first.NewIDs[0] != "bd-d1" {
+		t.Fatalf("unexpected first digest: %+v", first)
+	}
+	if len(ms.events) != 1 || ms.events[0].Topic != events.TopicDigestGenerated || !ms.published[ms.events[0].ID] {
+		t.Fatalf("events = %+v, want a published digest.generated", ms.events)
+	}
+
+	// Not due again until an hour has passed.
+	ms.beads["bd-d3"] = &model.Bead{ID: "bd-d3", Title: "Leak", Type: "bug", Status: model.StatusOpen}
+	if n, _ := srv.GenerateDueDigests(ctx, now.Add(30*time.Minute)); n != 0 {
+		t.Fatalf("generated %d digests before the period elapsed", n)
+	}
+	if n, _ := srv.GenerateDueDigests(ctx, now.Add(time.Hour)); n != 1 {
+		t.Fatalf("generated %d digests after the period, want 1", n)
+	}
+	second := ms.digests[1]
+	if len(second.BeadIDs) != 2 || len(second.NewIDs) != 1 || second.NewIDs[0] != "bd-d3" {
+		t.Fatalf("expected only bd-d3 new, got %+v", second)
+	}
+}
+
+func TestHandleGetDigest(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.configs["subscription:alice:open"] = &model.Config{
+		Key:   "subscription:alice:open",
+		Value: json.RawMessage(`{"filter":{"status":["open"]}}`),
+	}
+	ms.beads["bd-d4"] = &model.Bead{ID: "bd-d4", Title: "Open one", Status: model.StatusOpen}
+
+	// The first request generates a digest on demand.
+	rec := doJSON(t, h, "GET", "/v1/digests/alice:open", nil)
+	requireStatus(t, rec, 200)
+	var view digestView
+	decodeJSON(t, rec, &view)
+	if view.Subscription != "alice:open" || view.Total != 1 || len(view.New) != 1 || view.New[0].ID != "bd-d4" {
+		t.Fatalf("unexpected digest: %+v", view)
+	}
+
+	// Later requests serve the stored digest until refreshed.
+	ms.beads["bd-d5"] = &model.Bead{ID: "bd-d5", Title: "Open two", Status: model.StatusOpen}
+	rec = doJSON(t, h, "GET", "/v1/digests/alice:open", nil)
+	decodeJSON(t, rec, &view)
+	if view.Total != 1 || len(ms.digests) != 1 {
+		t.Fatalf("expected stored digest, got %+v", view)
+	}
+	rec = doJSON(t, h, "GET", "/v1/digests/alice:open?refresh=true", nil)
+	decodeJSON(t, rec, &view)
+	if view.Total != 2 || len(view.New) != 1 || view.New[0].ID != "bd-d5" {
+		t.Fatalf("unexpected refreshed digest: %+v", view)
+	}
+
+	requireStatus(t, doJSON(t, h, "GET", "/v1/digests/nobody:nothing", nil), 404)
+}
+
+func TestGRPCGetDigest(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.configs["subscription:carol:all"] = &model.Config{Key: "subscription:carol:all", Value: json.RawMessage(`{"filter":{}}`)}
+	ms.beads["bd-d6"] = &model.Bead{ID: "bd-d6", Title: "Anything", Status: model.StatusOpen}
+
+	resp, err := srv.GetDigest(ctx, &beadsv1.GetDigestRequest{Name: "carol:all"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.GetTotal() != 1 || len(resp.GetNew()) != 1 || resp.GetGeneratedAt() == nil {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	_, err = srv.GetDigest(ctx, &beadsv1.GetDigestRequest{Name: "carol:missing"})
+	requireCode(t, err, codes.NotFound)
+	_, err = srv.GetDigest(ctx, &beadsv1.GetDigestRequest{})
+	requireCode(t, err, codes.InvalidArgument)
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Machine-readable error codes. HTTP error bodies carry them in "code"; gRPC
+// errors carry them upper-cased as the reason of an ErrorInfo detail in the
+// "beads" domain, e.g. BEAD_NOT_FOUND.
+const (
+	CodeValidationFailed   = "validation_failed"
+	CodeBeadNotFound       = "bead_not_found"
+	CodeNotFound           = "not_found"
+	CodeConflict           = "conflict"
+	CodeDependencyCycle    = "dependency_cycle"
+	CodeLocked             = "locked"
+	CodeFailedPrecondition = "failed_precondition"
+	CodeUnauthenticated    = "unauthenticated"
+	CodePermissionDenied   = "permission_denied"
+	CodeClientTooOld       = "client_too_old"
+	CodeUnavailable        = "unavailable"
+	CodeInternal           = "internal"
+)
+
+// errorBody is the JSON body of every HTTP error response.
+type errorBody struct {
+	Error   string         `json:"error"`
+	Code    string         `json:"code"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// httpErrorCode derives the error code of an HTTP error from its status and
+// message.
+func httpErrorCode(status int, message string) string {
+	switch {
+	case status == http.StatusNotFound && strings.HasPrefix(message, "bead not found"):
+		return CodeBeadNotFound
+	case status == http.StatusNotFound:
+		return CodeNotFound
+	case status == http.StatusBadRequest, status == http.StatusUnprocessableEntity:
+		return CodeValidationFailed
+	case status == http.StatusConflict:
+		return CodeConflict
+	case status == http.StatusLocked:
+		return CodeLocked
+	case status == http.StatusPreconditionFailed:
+		return CodeFailedPrecondition
+	case status == http.StatusUnauthorized:
+		return CodeUnauthenticated
+	case status == http.StatusForbidden:
+		return CodePermissionDenied
+	case status == http.StatusUpgradeRequired:
+		return CodeClientTooOld
+	case status == http.StatusServiceUnavailable:
+		return CodeUnavailable
+	}
+	return CodeInternal
+}
+
+// grpcErrorCode derives the error code of a gRPC status.
+func grpcErrorCode(st *status.Status) string {
+	switch st.Code() {
+	case codes.NotFound:
+		if strings.HasPrefix(st.Message(), "bead not found") {
+			return CodeBeadNotFound
+		}
+		return CodeNotFound
+	case codes.InvalidArgument, codes.OutOfRange:
+		return CodeValidationFailed
+	case codes.AlreadyExists, codes.Aborted:
+		return CodeConflict
+	case codes.FailedPrecondition:
+		return CodeFailedPrecondition
+	case codes.Unauthenticated:
+		return CodeUnauthenticated
+	case codes.PermissionDenied:
+		return CodePermissionDenied
+	case codes.Unavailable:
+		return CodeUnavailable
+	}
+	return CodeInternal
+}
+
+// errorWithCode returns a gRPC error carrying code and metadata as an
+// ErrorInfo detail.
+func errorWithCode(c codes.Code, code, msg string, metadata map[string]string) error {
+	st, err := status.New(c, msg).WithDetails(&errdetails.ErrorInfo{
+		Reason:   strings.ToUpper(code),
+		Domain:   "beads",
+		Metadata: metadata,
+	})
+	if err != nil {
+		return status.Error(c, msg)
+	}
+	return st.Err()
+}
+
+// ErrorCodeInterceptor attaches an ErrorInfo with the derived error code to
+// gRPC errors that do not already carry details.
+func ErrorCodeInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	st, ok := status.FromError(err)
+	if !ok || st.Code() == codes.OK || len(st.Details()) > 0 {
+		return resp, err
+	}
+	return resp, errorWithCode(st.Code(), grpcErrorCode(st), st.Message(), nil)
+}
+
+// cycleError reports that a dependency would close a loop of blocking
+// dependencies, leaving every bead on it blocked forever. Transport layers
+// map it to 409 / FailedPrecondition with code dependency_cycle.
+type cycleError struct {
+	Cycle []string // bead IDs from the new dependency's bead back to itself
+}
+
+func (e *cycleError) Error() string {
+	return "dependency cycle: " + strings.Join(e.Cycle, " -> ")
+}
+
+// checkDependencyCycle returns a *cycleError if adding dep would close a
+// loop of blocking dependencies. Non-blocking types never form cycles.
+func (s *BeadsServer) checkDependencyCycle(ctx context.Context, st store.Store, dep *model.Dependency) error {
+	types, err := s.depTypes(ctx)
+	if err != nil {
+		return err
+	}
+	if !types.blocking(dep.Type) {
+		return nil
+	}
+	if dep.DependsOnID == dep.BeadID {
+		return &cycleError{Cycle: []string{dep.BeadID, dep.BeadID}}
+	}
+
+	// Walk blocking dependencies from the new target, remembering how each
+	// bead was reached so the loop can be reported.
+	via := map[string]string{dep.DependsOnID: dep.BeadID}
+	queue := []string{dep.DependsOnID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		deps, err := st.GetDependencies(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, d := range deps {
+			if !types.blocking(d.Type) {
+				continue
+			}
+			if d.DependsOnID == dep.BeadID {
+				var path []string
+				for at := id; at != dep.BeadID; at = via[at] {
+					path = append(path, at)
+				}
+				slices.Reverse(path)
+				cycle := append(append([]string{dep.BeadID}, path...), dep.BeadID)
+				return &cycleError{Cycle: cycle}
+			}
+			if _, seen := via[d.DependsOnID]; !seen {
+				via[d.DependsOnID] = id
+				queue = append(queue, d.DependsOnID)
+			}
+		}
+	}
+	return nil
+}
+
+// writeCycleError writes a 409 dependency_cycle response if err is a
+// *cycleError, reporting whether it did.
+func writeCycleError(w http.ResponseWriter, err error) bool {
+	var ce *cycleError
+	if !errors.As(err, &ce) {
+		return false
+	}
+	writeJSON(w, http.StatusConflict, errorBody{
+		Error:   ce.Error(),
+		Code:    CodeDependencyCycle,
+		Details: map[string]any{"cycle": ce.Cycle},
+	})
+	return true
+}
+
+// status returns e as a FailedPrecondition status with a DEPENDENCY_CYCLE
+// ErrorInfo whose "cycle" metadata lists the bead IDs, comma-separated.
+func (e *cycleError) status() error {
+	return errorWithCode(codes.FailedPrecondition, CodeDependencyCycle, e.Error(),
+		map[string]string{"cycle": strings.Join(e.Cycle, ",")})
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWriteError_Codes(t *testing.T) {
+	_, _, h := newTestServer()
+
+	var body errorBody
+	rec := doJSON(t, h, "GET", "/v1/beads/bd-none", nil)
+	requireStatus(t, rec, http.StatusNotFound)
+	decodeJSON(t, rec, &body)
+	if body.Code != CodeBeadNotFound || body.Error != "bead not found" {
+		t.Errorf("404 body = %+v", body)
+	}
+
+	rec = doJSON(t, h, "POST", "/v1/beads", map[string]any{"type": "task"})
+	requireStatus(t, rec, http.StatusBadRequest)
+	decodeJSON(t, rec, &body)
+	if body.Code != CodeValidationFailed {
+		t.Errorf("400 code = %q", body.Code)
+	}
+}
+
+func TestHandleAddDependency_Cycle(t *testing.T) {
+	_, ms, h := newTestServer()
+	for _, id := range []string{"bd-a", "bd-b", "bd-c"} {
+		ms.beads[id] = &model.Bead{ID: id, Status: model.StatusOpen}
+	}
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-a/dependencies", map[string]any{"depends_on_id": "bd-b", "type": "blocks"}), http.StatusCreated)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-b/dependencies", map[string]any{"depends_on_id": "bd-c", "type": "blocks"}), http.StatusCreated)
+
+	var body errorBody
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-c/dependencies", map[string]any{"depends_on_id": "bd-a", "type": "blocks"})
+	requireStatus(t, rec, http.StatusConflict)
+	decodeJSON(t, rec, &body)
+	cycle, _ := body.Details["cycle"].([]any)
+	if body.Code != CodeDependencyCycle || len(cycle) != 4 || cycle[0] != "bd-c" || cycle[1] != "bd-a" || cycle[2] != "bd-b" || cycle[3] != "bd-c" {
+		t.Fatalf("body = %+v", body)
+	}
+	if len(ms.deps["bd-c"]) != 0 {
+		t.Errorf("cyclic dependency was stored: %+v", ms.deps["bd-c"])
+	}
+
+	// Loops through non-blocking types are fine.
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-c/dependencies", map[string]any{"depends_on_id": "bd-a", "type": "related"}), http.StatusCreated)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-a/dependencies", map[string]any{"depends_on_id": "bd-a", "type": "blocks"}), http.StatusConflict)
+}
+
+// errorInfo returns the ErrorInfo detail of a gRPC error, if any.
+func errorInfo(err error) *errdetails.ErrorInfo {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
+func TestAddDependency_CycleGRPC(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-a"] = &model.Bead{ID: "bd-a", Status: model.StatusOpen}
+	ms.beads["bd-b"] = &model.Bead{ID: "bd-b", Status: model.StatusOpen}
+	if _, err := srv.AddDependency(ctx, &beadsv1.AddDependencyRequest{BeadId: "bd-a", DependsOnId: "bd-b", Type: "blocks"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := srv.AddDependency(ctx, &beadsv1.AddDependencyRequest{BeadId: "bd-b", DependsOnId: "bd-a", Type: "blocks"})
+	requireCode(t, err, codes.FailedPrecondition)
+	if info := errorInfo(err); info == nil || info.GetReason() != "DEPENDENCY_CYCLE" || info.GetMetadata()["cycle"] != "bd-b,bd-a,bd-b" {
+		t.Errorf("error info = %v", info)
+	}
+}
+
+func TestErrorCodeInterceptor(t *testing.T) {
+	call := func(err error) error {
+		_, got := ErrorCodeInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{},
+			func(context.Context, any) (any, error) { return nil, err })
+		return got
+	}
+
+	err := call(storeError(errors.New("boom"), "bead"))
+	if info := errorInfo(err); info == nil || info.GetReason() != "INTERNAL" {
+		t.Errorf("internal: info = %v", info)
+	}
+	err = call(status.Error(codes.NotFound, "bead not found"))
+	requireCode(t, err, codes.NotFound)
+	if info := errorInfo(err); info == nil || info.GetReason() != "BEAD_NOT_FOUND" || info.GetDomain() != "beads" {
+		t.Errorf("not found: info = %v", info)
+	}
+	if info := errorInfo(call(status.Error(codes.InvalidArgument, "title is required"))); info.GetReason() != "VALIDATION_FAILED" {
+		t.Errorf("invalid: info = %v", info)
+	}
+
+	// Errors that already carry a reason keep it.
+	cyc := (&cycleError{Cycle: []string{"bd-a", "bd-a"}}).status()
+	if info := errorInfo(call(cyc)); info.GetReason() != "DEPENDENCY_CYCLE" {
+		t.Errorf("cycle: info = %v", info)
+	}
+}
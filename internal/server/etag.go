@@ -0,0 +1,124 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// etagMiddleware tags successful GET responses with an ETag computed from
+// the body and answers a request whose If-None-Match names the current tag
+// with 304 Not Modified and no body, so pollers do not re-download data
+// that has not changed. Responses the handler flushes early, such as event
+// streams, are passed through untagged.
+func etagMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ew := &etagWriter{ResponseWriter: w}
+		next.ServeHTTP(ew, r)
+		ew.finish(r)
+	})
+}
+
+// etagFor returns the strong entity tag of body.
+func etagFor(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// noneMatch reports whether an If-None-Match header names etag, comparing
+// weakly as RFC 9110 requires.
+func noneMatch(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || strings.TrimPrefix(t, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// etagWriter holds a response back until the handler returns, unless the
+// handler flushes it, so the tag can be computed from the whole body.
+type etagWriter struct {
+	http.ResponseWriter
+	status    int
+	buf       bytes.Buffer
+	streaming bool // flushed: everything passes straight through
+}
+
+func (e *etagWriter) WriteHeader(status int) {
+	if e.streaming {
+		e.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if e.status == 0 {
+		e.status = status
+	}
+}
+
+func (e *etagWriter) Write(p []byte) (int, error) {
+	if e.streaming {
+		return e.ResponseWriter.Write(p)
+	}
+	return e.buf.Write(p)
+}
+
+// Flush gives up on tagging and sends what has been held back.
+func (e *etagWriter) Flush() {
+	if !e.streaming {
+		e.streaming = true
+		e.ResponseWriter.WriteHeader(e.statusOrOK())
+		_, _ = e.ResponseWriter.Write(e.buf.Bytes())
+		e.buf = bytes.Buffer{}
+	}
+	if f, ok := e.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (e *etagWriter) statusOrOK() int {
+	if e.status == 0 {
+		return http.StatusOK
+	}
+	return e.status
+}
+
+// finish tags and sends the held-back response, or a 304 if the client
+// already has it.
+func (e *etagWriter) finish(r *http.Request) {
+	if e.streaming {
+		return
+	}
+	status := e.statusOrOK()
+	h := e.Header()
+	if status == http.StatusOK {
+		etag := h.Get("ETag")
+		if etag == "" {
+			etag = etagFor(e.buf.Bytes())
+			h.Set("ETag", etag)
+		}
+		if noneMatch(r.Header.Get("If-None-Match"), etag) {
+			h.Del("Content-Type")
+			h.Del("Content-Length")
+			e.ResponseWriter.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	e.ResponseWriter.WriteHeader(status)
+	_, _ = e.ResponseWriter.Write(e.buf.Bytes())
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (e *etagWriter) Unwrap() http.ResponseWriter {
+	return e.ResponseWriter
+}
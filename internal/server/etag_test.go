@@ -0,0 +1,72 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestETagMiddleware(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-e1"] = &model.Bead{ID: "bd-e1", Title: "Tagged", Type: "task", Kind: model.KindIssue, Status: model.StatusOpen}
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/v1/beads/bd-e1", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("")
+	requireStatus(t, rec, 200)
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag on GET")
+	}
+
+	for _, inm := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		rec = get(inm)
+		requireStatus(t, rec, http.StatusNotModified)
+		if rec.Body.Len() != 0 || rec.Header().Get("ETag") != etag {
+			t.Fatalf("If-None-Match %s: body %q, ETag %q", inm, rec.Body.String(), rec.Header().Get("ETag"))
+		}
+	}
+
+	// A change gives the bead a new tag.
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-e1", map[string]any{"title": "Retagged"}), 200)
+	rec = get(etag)
+	requireStatus(t, rec, 200)
+	if rec.Header().Get("ETag") == etag {
+		t.Fatal("ETag unchanged after update")
+	}
+
+	// Errors and other methods are not tagged.
+	rec = doJSON(t, h, "GET", "/v1/beads/bd-nope", nil)
+	requireStatus(t, rec, 404)
+	if rec.Header().Get("ETag") != "" {
+		t.Fatal("404 response has an ETag")
+	}
+	rec = doJSON(t, h, "PATCH", "/v1/beads/bd-e1", map[string]any{"title": "Again"})
+	if rec.Header().Get("ETag") != "" {
+		t.Fatal("PATCH response has an ETag")
+	}
+}
+
+func TestETagMiddleware_Streaming(t *testing.T) {
+	h := etagMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("event: ready\n\n"))
+		w.(http.Flusher).Flush()
+		w.Write([]byte("event: update\n\n"))
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/events/stream", nil))
+	if !rec.Flushed || rec.Header().Get("ETag") != "" || rec.Body.String() != "event: ready\n\nevent: update\n\n" {
+		t.Fatalf("flushed = %v, ETag = %q, body = %q", rec.Flushed, rec.Header().Get("ETag"), rec.Body.String())
+	}
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// Page sizes for GET /v1/events.
+const (
+	defaultEventPage = 100
+	maxEventPage     = 1000
+)
+
+// eventFilter reads the event filters shared by GET /v1/events and the
+// event stream: topic (comma-separated), bead_id, actor, label and project,
+// plus since (RFC 3339), the after cursor and limit. Bead refs may be slugs
+// or aliases.
+func (s *BeadsServer) eventFilter(r *http.Request) (model.EventFilter, error) {
+	q := r.URL.Query()
+	filter := model.EventFilter{
+		BeadID:  s.resolveBeadID(r.Context(), q.Get("bead_id")),
+		Actor:   q.Get("actor"),
+		Label:   q.Get("label"),
+		Project: s.resolveBeadID(r.Context(), q.Get("project")),
+		Limit:   defaultEventPage,
+	}
+	if v := q.Get("topic"); v != "" {
+		filter.Topics = strings.Split(v, ",")
+	}
+	if v := q.Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, inputError("since must be an RFC 3339 timestamp")
+		}
+		filter.Since = t
+	}
+	if v := q.Get("after"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return filter, inputError("after must be an event ID")
+		}
+		filter.AfterID = n
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return filter, inputError("limit must be a positive integer")
+		}
+		filter.Limit = min(n, maxEventPage)
+	}
+	return filter, nil
+}
+
+// matchEvent reports whether a streamed event passes filter. Since, the
+// cursor and limit do not apply to live streams. Label and project are checked against the
+// bead's current labels and parents.
+func (s *BeadsServer) matchEvent(ctx context.Context, filter model.EventFilter, e *model.Event) bool {
+	switch {
+	case len(filter.Topics) > 0 && !slices.Contains(filter.Topics, e.Topic),
+		filter.BeadID != "" && e.BeadID != filter.BeadID,
+		filter.Actor != "" && e.Actor != filter.Actor:
+		return false
+	}
+	if filter.Label != "" {
+		labels, err := s.store.GetLabels(ctx, e.BeadID)
+		if err != nil || !slices.Contains(labels, filter.Label) {
+			return false
+		}
+	}
+	if filter.Project != "" {
+		in, err := s.inProject(ctx, e.BeadID, filter.Project)
+		if err != nil || !in {
+			return false
+		}
+	}
+	return true
+}
+
+// inProject reports whether id is project or one of its parent-child
+// descendants, walking up from id.
+func (s *BeadsServer) inProject(ctx context.Context, id, project string) (bool, error) {
+	seen := map[string]bool{}
+	queue := []string{id}
+	for len(queue) > 0 {
+		id, queue = queue[0], queue[1:]
+		if id == project {
+			return true, nil
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		deps, err := s.store.GetDependencies(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		for _, d := range deps {
+			if d.Type == model.DepParentChild {
+				queue = append(queue, d.DependsOnID)
+			}
+		}
+	}
+	return false, nil
+}
+
+// eventPage is one page of GET /v1/events. NextAfter, when set, is the
+// after cursor of the next page.
+type eventPage struct {
+	Events    []*model.Event `json:"events"`
+	NextAfter int64          `json:"next_after,omitempty"`
+}
+
+// handleListEvents handles GET /v1/events, the global event log, oldest
+// first. Pages are keyed by event ID: pass next_after back as after. A
+// consumer catching up after downtime starts from since, or from the last
+// event ID it saw.
+func (s *BeadsServer) handleListEvents(w http.ResponseWriter, r *http.Request) {
+	filter, err := s.eventFilter(r)
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			writeError(w, http.StatusBadRequest, ie.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	events, err := s.store.ListEvents(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list events")
+		return
+	}
+	page := eventPage{Events: events}
+	if page.Events == nil {
+		page.Events = []*model.Event{}
+	}
+	if len(events) == filter.Limit {
+		page.NextAfter = events[len(events)-1].ID
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// handleEventSchemas handles GET /v1/events/schemas: the payload shape and
+// current schema_version of every event topic.
+func (s *BeadsServer) handleEventSchemas(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"schemas": events.Schemas()})
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestHandleListEvents(t *testing.T) {
+	srv, ms, h := newTestServer()
+	ms.labels["bd-a"] = []string{"backend"}
+	ctx := context.Background()
+	for i := range 5 {
+		emitEvent(t, srv, ctx, "beads.bead.updated", "bd-a", "alice", map[string]int{"n": i})
+	}
+	emitEvent(t, srv, ctx, "beads.bead.closed", "bd-b", "bob", map[string]string{})
+
+	var page eventPage
+	rec := doJSON(t, h, "GET", "/v1/events?label=backend&limit=3", nil)
+	requireStatus(t, rec, http.StatusOK)
+	decodeJSON(t, rec, &page)
+	if len(page.Events) != 3 || page.NextAfter != page.Events[2].ID {
+		t.Fatalf("page = %d events, next_after %d", len(page.Events), page.NextAfter)
+	}
+
+	rec = doJSON(t, h, "GET", fmt.Sprintf("/v1/events?label=backend&limit=3&after=%d", page.NextAfter), nil)
+	requireStatus(t, rec, http.StatusOK)
+	page = eventPage{}
+	decodeJSON(t, rec, &page)
+	if len(page.Events) != 2 || page.NextAfter != 0 {
+		t.Fatalf("last page = %d events, next_after %d", len(page.Events), page.NextAfter)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/events?topic=beads.bead.closed,beads.bead.deleted&actor=bob", nil)
+	requireStatus(t, rec, http.StatusOK)
+	page = eventPage{}
+	decodeJSON(t, rec, &page)
+	if len(page.Events) != 1 || page.Events[0].BeadID != "bd-b" {
+		t.Fatalf("events = %+v", page.Events)
+	}
+
+	requireStatus(t, doJSON(t, h, "GET", "/v1/events?after=x", nil), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "GET", "/v1/events?since=yesterday", nil), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "GET", "/v1/events?limit=0", nil), http.StatusBadRequest)
+}
+
+func TestInProject(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.deps["bd-task"] = []*model.Dependency{{BeadID: "bd-task", DependsOnID: "bd-story", Type: model.DepParentChild}}
+	ms.deps["bd-story"] = []*model.Dependency{
+		{BeadID: "bd-story", DependsOnID: "bd-blocker", Type: model.DepBlocks},
+		{BeadID: "bd-story", DependsOnID: "bd-epic", Type: model.DepParentChild},
+	}
+	for id, want := range map[string]bool{"bd-epic": true, "bd-story": true, "bd-task": true, "bd-blocker": false} {
+		if got, err := srv.inProject(ctx, id, "bd-epic"); err != nil || got != want {
+			t.Errorf("inProject(%s) = %v, %v; want %v", id, got, err, want)
+		}
+	}
+}
+
+func TestHandleListEvents_Since(t *testing.T) {
+	_, ms, h := newTestServer()
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		ms.events = append(ms.events, &model.Event{ID: int64(i + 1), Topic: "beads.bead.updated", BeadID: "bd-a", CreatedAt: base.Add(time.Duration(i) * time.Hour)})
+	}
+
+	var page eventPage
+	rec := doJSON(t, h, "GET", "/v1/events?since=2026-03-02T10:00:00Z", nil)
+	requireStatus(t, rec, http.StatusOK)
+	decodeJSON(t, rec, &page)
+	if len(page.Events) != 2 || page.Events[0].ID != 2 {
+		t.Fatalf("events = %+v, want 2 and 3", page.Events)
+	}
+}
+
+func TestRecordedEventsCarrySchemaVersion(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	emitEvent(t, srv, ctx, events.TopicLabelAdded, "bd-a", "alice", events.LabelAdded{BeadID: "bd-a", Label: "x"})
+	want := `{"schema_version":1,"bead_id":"bd-a","label":"x"}`
+	if got := string(ms.events[0].Payload); got != want {
+		t.Fatalf("payload = %s, want %s", got, want)
+	}
+	ev, err := events.Decode(events.TopicLabelAdded, ms.events[0].Payload)
+	if err != nil || ev.(events.LabelAdded).Label != "x" {
+		t.Fatalf("Decode = %+v, %v", ev, err)
+	}
+}
+
+func TestHandleEventSchemas(t *testing.T) {
+	_, _, h := newTestServer()
+	rec := doJSON(t, h, "GET", "/v1/events/schemas", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var resp struct {
+		Schemas []events.Schema `json:"schemas"`
+	}
+	decodeJSON(t, rec, &resp)
+	for _, s := range resp.Schemas {
+		if s.Topic != events.TopicBeadClosed {
+			continue
+		}
+		want := []events.Field{
+			{Name: "schema_version", Type: "integer"},
+			{Name: "bead", Type: "Bead"},
+			{Name: "closed_by", Type: "string", Optional: true},
+		}
+		if s.Type != "BeadClosed" || s.SchemaVersion != 1 || !slices.Equal(s.Fields, want) {
+			t.Fatalf("schema = %+v", s)
+		}
+		return
+	}
+	t.Fatalf("no schema for %s in %d schemas", events.TopicBeadClosed, len(resp.Schemas))
+}
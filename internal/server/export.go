@@ -0,0 +1,62 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	beadsync "github.com/alfredjeanlab/beads/internal/sync"
+)
+
+// streamFlushEvery is how many JSONL lines are written between flushes.
+const streamFlushEvery = 100
+
+// flushWriter flushes an http.ResponseWriter after every streamFlushEvery
+// writes so clients see progress on long streams.
+type flushWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	n       int
+}
+
+func newFlushWriter(w http.ResponseWriter) *flushWriter {
+	f, _ := w.(http.Flusher)
+	return &flushWriter{w: w, flusher: f}
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.n++
+	if f.flusher != nil && f.n%streamFlushEvery == 0 {
+		f.flusher.Flush()
+	}
+	return n, err
+}
+
+// streamBeadsJSONL writes the beads matching filter as JSONL, one bead per
+// line, as they are read from the store. Errors after the first line can
+// only end the stream early; they are logged.
+func (s *BeadsServer) streamBeadsJSONL(w http.ResponseWriter, r *http.Request, filter model.BeadFilter) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	fw := newFlushWriter(w)
+	enc := json.NewEncoder(fw)
+	enc.SetEscapeHTML(false)
+
+	err := s.store.StreamBeads(r.Context(), filter, func(b *model.Bead) error {
+		return enc.Encode(b)
+	})
+	if err != nil && r.Context().Err() == nil {
+		slog.Warn("bead stream ended early", "error", err)
+	}
+}
+
+// handleExport handles GET /v1/export, the JSONL backup of every bead and
+// config that sync destinations receive. The export is streamed and stops
+// if the client disconnects.
+func (s *BeadsServer) handleExport(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if err := beadsync.ExportJSONL(r.Context(), s.store, newFlushWriter(w)); err != nil && r.Context().Err() == nil {
+		slog.Warn("export ended early", "error", err)
+	}
+}
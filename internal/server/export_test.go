@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestHandleListBeads_JSONL(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-j1"] = &model.Bead{ID: "bd-j1", Title: "One", Status: model.StatusOpen}
+	ms.beads["bd-j2"] = &model.Bead{ID: "bd-j2", Title: "Two", Status: model.StatusOpen}
+	ms.beads["bd-j3"] = &model.Bead{ID: "bd-j3", Title: "Done", Status: model.StatusClosed}
+
+	rec := doJSON(t, h, "GET", "/v1/beads?format=jsonl&status=open", nil)
+	requireStatus(t, rec, 200)
+	if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Fatalf("content type %q", ct)
+	}
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), rec.Body.String())
+	}
+	for _, line := range lines {
+		var b model.Bead
+		if err := json.Unmarshal([]byte(line), &b); err != nil {
+			t.Fatalf("line %q: %v", line, err)
+		}
+		if b.Status != model.StatusOpen {
+			t.Fatalf("unexpected bead %+v", b)
+		}
+	}
+}
+
+func TestHandleListBeads_JSONLClientGone(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-j1"] = &model.Bead{ID: "bd-j1", Title: "One", Status: model.StatusOpen}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest("GET", "/v1/beads?format=jsonl", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Body.Len() != 0 {
+		t.Fatalf("expected nothing written after disconnect, got %q", rec.Body.String())
+	}
+}
+
+func TestHandleExport(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-e1"] = &model.Bead{ID: "bd-e1", Title: "One", Status: model.StatusOpen}
+	ms.labels["bd-e1"] = []string{"backend"}
+	ms.configs["view:mine"] = &model.Config{Key: "view:mine", Value: json.RawMessage(`{}`)}
+
+	rec := doJSON(t, h, "GET", "/v1/export", nil)
+	requireStatus(t, rec, 200)
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header, bead and config lines, got:\n%s", rec.Body.String())
+	}
+	if !strings.Contains(lines[0], `"bead_count":1`) || !strings.Contains(lines[1], `"labels":["backend"]`) ||
+		!strings.Contains(lines[2], `"type":"config"`) {
+		t.Fatalf("unexpected export:\n%s", rec.Body.String())
+	}
+}
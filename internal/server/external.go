@@ -0,0 +1,318 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// addExternalDep makes beadID wait on rawURL. Returns inputError for an
+// invalid URL or probe, and sql.ErrNoRows if the bead does not exist.
+func (s *BeadsServer) addExternalDep(ctx context.Context, beadID, rawURL, description, probe, actor string) (*model.ExternalDep, error) {
+	dep := &model.ExternalDep{
+		BeadID:      beadID,
+		URL:         strings.TrimSpace(rawURL),
+		Description: strings.TrimSpace(description),
+		Status:      model.ExternalWaiting,
+		Probe:       probe,
+		CreatedBy:   s.actorFor(ctx, actor),
+	}
+	if err := dep.Validate(); err != nil {
+		return nil, inputError(err.Error())
+	}
+	if dep.Probe == model.ProbeGitHubPR {
+		if _, _, _, err := parsePullRequestURL(dep.URL); err != nil {
+			return nil, inputError(err.Error())
+		}
+	}
+	b, err := s.store.GetBead(ctx, beadID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := tx.AddExternalDep(ctx, dep); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicExternalAdded, beadID, dep.CreatedBy, events.ExternalAdded{Dep: dep})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return dep, nil
+}
+
+// findExternalDep returns the external dependency id of beadID as tx sees
+// it, or sql.ErrNoRows.
+func findExternalDep(ctx context.Context, tx store.Store, beadID string, id int64) (*model.ExternalDep, error) {
+	deps, err := tx.GetExternalDeps(ctx, beadID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deps {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// setExternalStatus marks an external dependency waiting or satisfied by
+// hand. Nothing is recorded when the status is unchanged.
+func (s *BeadsServer) setExternalStatus(ctx context.Context, beadID string, id int64, st model.ExternalStatus, actor string) (*model.ExternalDep, error) {
+	actor = s.actorFor(ctx, actor)
+	var dep *model.ExternalDep
+	changed := false
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		var err error
+		if dep, err = findExternalDep(ctx, tx, beadID, id); err != nil {
+			return err
+		}
+		if dep.Status == st {
+			return nil
+		}
+		dep.Status = st
+		if err := dep.Validate(); err != nil {
+			return inputError(err.Error())
+		}
+		if err := tx.UpdateExternalDep(ctx, dep); err != nil {
+			return err
+		}
+		changed = true
+		return s.recordEvent(ctx, tx, events.TopicExternalUpdated, beadID, actor, events.ExternalUpdated{Dep: dep, UpdatedBy: actor})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		s.flushEvents(ctx)
+	}
+	return dep, nil
+}
+
+// removeExternalDep removes an external dependency and records its event.
+func (s *BeadsServer) removeExternalDep(ctx context.Context, beadID string, id int64, actor string) error {
+	actor = s.actorFor(ctx, actor)
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		dep, err := findExternalDep(ctx, tx, beadID, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.RemoveExternalDep(ctx, beadID, id); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicExternalRemoved, beadID, actor, events.ExternalRemoved{BeadID: beadID, ID: id, URL: dep.URL})
+	})
+	if err != nil {
+		return err
+	}
+	s.flushEvents(ctx)
+	return nil
+}
+
+// waitingOn returns the URLs of beadID's waiting external dependencies.
+func (s *BeadsServer) waitingOn(ctx context.Context, beadID string) ([]string, error) {
+	deps, err := s.store.GetExternalDeps(ctx, beadID)
+	if err != nil {
+		return nil, err
+	}
+	urls := []string{}
+	for _, d := range deps {
+		if d.Status == model.ExternalWaiting {
+			urls = append(urls, d.URL)
+		}
+	}
+	return urls, nil
+}
+
+// writeExternalResult writes dep with status code, or the error from
+// reading or changing it.
+func writeExternalResult(w http.ResponseWriter, code int, dep *model.ExternalDep, err error) {
+	var ie inputError
+	switch {
+	case errors.As(err, &ie):
+		writeError(w, http.StatusBadRequest, ie.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "external dependency not found")
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "failed to update external dependency")
+	case dep == nil:
+		w.WriteHeader(code)
+	default:
+		writeJSON(w, code, dep)
+	}
+}
+
+// externalID parses the {xid} path value.
+func externalID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("xid"), 10, 64)
+	if err != nil {
+		return 0, inputError("invalid external dependency id")
+	}
+	return id, nil
+}
+
+// addExternalRequest is the JSON body for POST /v1/beads/{id}/external.
+type addExternalRequest struct {
+	URL         string `json:"url"`
+	Description string `json:"description"`
+	Probe       string `json:"probe"`
+	CreatedBy   string `json:"created_by"`
+}
+
+// handleAddExternal handles POST /v1/beads/{id}/external.
+func (s *BeadsServer) handleAddExternal(w http.ResponseWriter, r *http.Request) {
+	var req addExternalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	dep, err := s.addExternalDep(r.Context(), r.PathValue("id"), req.URL, req.Description, req.Probe, req.CreatedBy)
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, "bead not found")
+		return
+	}
+	writeExternalResult(w, http.StatusCreated, dep, err)
+}
+
+// handleListExternal handles GET /v1/beads/{id}/external.
+func (s *BeadsServer) handleListExternal(w http.ResponseWriter, r *http.Request) {
+	deps, err := s.store.GetExternalDeps(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list external dependencies")
+		return
+	}
+	if deps == nil {
+		deps = []*model.ExternalDep{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"external": deps})
+}
+
+// updateExternalRequest is the JSON body for PATCH
+// /v1/beads/{id}/external/{xid}.
+type updateExternalRequest struct {
+	Status    string `json:"status"`
+	UpdatedBy string `json:"updated_by"`
+}
+
+// handleUpdateExternal handles PATCH /v1/beads/{id}/external/{xid}.
+func (s *BeadsServer) handleUpdateExternal(w http.ResponseWriter, r *http.Request) {
+	id, err := externalID(r)
+	if err != nil {
+		writeExternalResult(w, 0, nil, err)
+		return
+	}
+	var req updateExternalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	dep, err := s.setExternalStatus(r.Context(), r.PathValue("id"), id, model.ExternalStatus(req.Status), req.UpdatedBy)
+	writeExternalResult(w, http.StatusOK, dep, err)
+}
+
+// handleRemoveExternal handles DELETE /v1/beads/{id}/external/{xid}.
+func (s *BeadsServer) handleRemoveExternal(w http.ResponseWriter, r *http.Request) {
+	id, err := externalID(r)
+	if err == nil {
+		err = s.removeExternalDep(r.Context(), r.PathValue("id"), id, r.URL.Query().Get("actor"))
+	}
+	writeExternalResult(w, http.StatusNoContent, nil, err)
+}
+
+func externalDepToProto(d *model.ExternalDep) *beadsv1.ExternalDep {
+	pb := &beadsv1.ExternalDep{
+		Id:          d.ID,
+		BeadId:      d.BeadID,
+		Url:         d.URL,
+		Description: d.Description,
+		Status:      string(d.Status),
+		Probe:       d.Probe,
+		LastError:   d.LastError,
+		CreatedBy:   d.CreatedBy,
+		CreatedAt:   timestamppb.New(d.CreatedAt),
+	}
+	if d.CheckedAt != nil {
+		pb.CheckedAt = timestamppb.New(*d.CheckedAt)
+	}
+	return pb
+}
+
+// externalError maps an external dependency error to a gRPC status.
+func externalError(err error) error {
+	var ie inputError
+	if errors.As(err, &ie) {
+		return status.Error(codes.InvalidArgument, ie.Error())
+	}
+	return storeError(err, "external dependency")
+}
+
+// AddExternalDep makes a bead wait on an external URL.
+func (s *BeadsServer) AddExternalDep(ctx context.Context, req *beadsv1.AddExternalDepRequest) (*beadsv1.AddExternalDepResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	dep, err := s.addExternalDep(ctx, req.GetBeadId(), req.GetUrl(), req.GetDescription(), req.GetProbe(), req.GetCreatedBy())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "bead not found")
+	}
+	if err != nil {
+		return nil, externalError(err)
+	}
+	return &beadsv1.AddExternalDepResponse{Dep: externalDepToProto(dep)}, nil
+}
+
+// ListExternalDeps lists a bead's external dependencies.
+func (s *BeadsServer) ListExternalDeps(ctx context.Context, req *beadsv1.ListExternalDepsRequest) (*beadsv1.ListExternalDepsResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	deps, err := s.store.GetExternalDeps(ctx, req.GetBeadId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list external dependencies: %v", err)
+	}
+	pb := make([]*beadsv1.ExternalDep, len(deps))
+	for i, d := range deps {
+		pb[i] = externalDepToProto(d)
+	}
+	return &beadsv1.ListExternalDepsResponse{Deps: pb}, nil
+}
+
+// UpdateExternalDep marks an external dependency waiting or satisfied.
+func (s *BeadsServer) UpdateExternalDep(ctx context.Context, req *beadsv1.UpdateExternalDepRequest) (*beadsv1.UpdateExternalDepResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	dep, err := s.setExternalStatus(ctx, req.GetBeadId(), req.GetId(), model.ExternalStatus(req.GetStatus()), req.GetUpdatedBy())
+	if err != nil {
+		return nil, externalError(err)
+	}
+	return &beadsv1.UpdateExternalDepResponse{Dep: externalDepToProto(dep)}, nil
+}
+
+// RemoveExternalDep removes an external dependency.
+func (s *BeadsServer) RemoveExternalDep(ctx context.Context, req *beadsv1.RemoveExternalDepRequest) (*beadsv1.RemoveExternalDepResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	if err := s.removeExternalDep(ctx, req.GetBeadId(), req.GetId(), req.GetRemovedBy()); err != nil {
+		return nil, externalError(err)
+	}
+	return &beadsv1.RemoveExternalDepResponse{}, nil
+}
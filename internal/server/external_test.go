@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestExternalDeps_BlockReadiness(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-api"] = &model.Bead{ID: "bd-api", Title: "Use new SDK", Kind: model.KindIssue, Type: "task", Status: model.StatusOpen}
+
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-api/external", map[string]string{
+		"url": "https://github.com/acme/sdk/pull/42", "description": "SDK release", "probe": "github-pr", "created_by": "alice",
+	})
+	requireStatus(t, rec, http.StatusCreated)
+	var dep model.ExternalDep
+	decodeJSON(t, rec, &dep)
+	if dep.ID == 0 || dep.Status != model.ExternalWaiting || dep.CreatedBy != "alice" {
+		t.Fatalf("dep = %+v", dep)
+	}
+	requireEvent(t, ms, 1, "beads.external.added")
+
+	var ready beadPage
+	rec = doJSON(t, h, "GET", "/v1/ready", nil)
+	requireStatus(t, rec, http.StatusOK)
+	decodeJSON(t, rec, &ready)
+	if ready.Total != 0 {
+		t.Fatalf("ready = %+v, want bd-api blocked", ready.Beads)
+	}
+	var blocked blockedPage
+	rec = doJSON(t, h, "GET", "/v1/blocked", nil)
+	requireStatus(t, rec, http.StatusOK)
+	decodeJSON(t, rec, &blocked)
+	if len(blocked.Beads) != 1 || len(blocked.Beads[0].WaitingOn) != 1 || blocked.Beads[0].WaitingOn[0] != dep.URL {
+		t.Fatalf("blocked = %+v", blocked.Beads)
+	}
+
+	rec = doJSON(t, h, "PATCH", fmt.Sprintf("/v1/beads/bd-api/external/%d", dep.ID), map[string]string{"status": "satisfied", "updated_by": "bob"})
+	requireStatus(t, rec, http.StatusOK)
+	requireEvent(t, ms, 2, "beads.external.updated")
+	rec = doJSON(t, h, "GET", "/v1/ready", nil)
+	decodeJSON(t, rec, &ready)
+	if ready.Total != 1 {
+		t.Fatalf("ready after satisfying = %+v", ready.Beads)
+	}
+
+	rec = doJSON(t, h, "DELETE", fmt.Sprintf("/v1/beads/bd-api/external/%d", dep.ID), nil)
+	requireStatus(t, rec, http.StatusNoContent)
+	requireEvent(t, ms, 3, "beads.external.removed")
+	rec = doJSON(t, h, "DELETE", fmt.Sprintf("/v1/beads/bd-api/external/%d", dep.ID), nil)
+	requireStatus(t, rec, http.StatusNotFound)
+}
+
+func TestAddExternalDep_Invalid(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-a"] = &model.Bead{ID: "bd-a", Status: model.StatusOpen}
+
+	for _, req := range []*beadsv1.AddExternalDepRequest{
+		{BeadId: "bd-a", Url: "ftp://example.com/x"},
+		{BeadId: "bd-a", Url: "https://example.com", Probe: "ping"},
+		{BeadId: "bd-a", Url: "https://example.com/acme/sdk/pull/1", Probe: "github-pr"},
+		{BeadId: "bd-a", Url: "https://github.com/acme/sdk/issues/1", Probe: "github-pr"},
+	} {
+		_, err := srv.AddExternalDep(ctx, req)
+		requireCode(t, err, codes.InvalidArgument)
+	}
+	_, err := srv.AddExternalDep(ctx, &beadsv1.AddExternalDepRequest{BeadId: "bd-none", Url: "https://example.com"})
+	requireCode(t, err, codes.NotFound)
+	_, err = srv.UpdateExternalDep(ctx, &beadsv1.UpdateExternalDepRequest{BeadId: "bd-a", Id: 99, Status: "satisfied"})
+	requireCode(t, err, codes.NotFound)
+}
+
+func TestProbeExternalDeps(t *testing.T) {
+	s, ms, _ := newTestServer()
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer up.Close()
+	var gotAuth string
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/repos/acme/sdk/pulls/1":
+			fmt.Fprint(w, `{"state":"closed","merged":true}`)
+		case "/repos/acme/sdk/pulls/2":
+			fmt.Fprint(w, `{"state":"open","merged":false}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer github.Close()
+	s.githubAPI = github.URL
+	// The test servers are on loopback, which the default client refuses.
+	s.SetExternalProber(http.DefaultClient, "gh-token")
+
+	ms.beads["bd-a"] = &model.Bead{ID: "bd-a", Status: model.StatusOpen}
+	for _, d := range []*model.ExternalDep{
+		{BeadID: "bd-a", URL: up.URL + "/healthz", Probe: model.ProbeHTTP},
+		{BeadID: "bd-a", URL: up.URL + "/missing", Probe: model.ProbeHTTP},
+		{BeadID: "bd-a", URL: "https://github.com/acme/sdk/pull/1", Probe: model.ProbeGitHubPR},
+		{BeadID: "bd-a", URL: "https://github.com/acme/sdk/pull/2", Probe: model.ProbeGitHubPR},
+		{BeadID: "bd-a", URL: "https://example.com/manual"},
+	} {
+		d.Status = model.ExternalWaiting
+		if err := ms.AddExternalDep(ctx, d); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n, err := s.ProbeExternalDeps(ctx, now)
+	if err != nil {
+		t.Fatalf("ProbeExternalDeps: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("satisfied %d, want 2", n)
+	}
+	if gotAuth != "Bearer gh-token" {
+		t.Errorf("Authorization = %q", gotAuth)
+	}
+	want := []model.ExternalStatus{model.ExternalSatisfied, model.ExternalWaiting, model.ExternalSatisfied, model.ExternalWaiting, model.ExternalWaiting}
+	for i, d := range ms.externals["bd-a"] {
+		if d.Status != want[i] {
+			t.Errorf("%s = %s, want %s", d.URL, d.Status, want[i])
+		}
+		if probed := d.Probe != ""; probed != (d.CheckedAt != nil) {
+			t.Errorf("%s checked_at = %v", d.URL, d.CheckedAt)
+		}
+		if (d.Status == model.ExternalWaiting && d.Probe != "") != (d.LastError != "") {
+			t.Errorf("%s last_error = %q", d.URL, d.LastError)
+		}
+	}
+	requireEvent(t, ms, 2, "beads.external.updated")
+}
+
+func TestProbeExternalDeps_RefusesInternalAddresses(t *testing.T) {
+	s, ms, _ := newTestServer()
+	ctx := context.Background()
+	hit := false
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hit = true }))
+	defer internal.Close()
+
+	ms.beads["bd-a"] = &model.Bead{ID: "bd-a", Status: model.StatusOpen}
+	for _, url := range []string{internal.URL, "http://169.254.169.254/latest/meta-data/", "http://10.0.0.1/"} {
+		if err := ms.AddExternalDep(ctx, &model.ExternalDep{BeadID: "bd-a", URL: url, Probe: model.ProbeHTTP, Status: model.ExternalWaiting}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n, err := s.ProbeExternalDeps(ctx, time.Now().UTC()); err != nil || n != 0 {
+		t.Fatalf("ProbeExternalDeps = %d, %v; want none satisfied", n, err)
+	}
+	if hit {
+		t.Fatal("probe reached a loopback server")
+	}
+	for _, d := range ms.externals["bd-a"] {
+		if d.Status != model.ExternalWaiting || !strings.Contains(d.LastError, "internal address") {
+			t.Errorf("%s: status %s, last_error %q", d.URL, d.Status, d.LastError)
+		}
+	}
+}
+
+func TestProbeExternalDeps_KeepsEditsMadeDuringProbe(t *testing.T) {
+	s, ms, _ := newTestServer()
+	ctx := context.Background()
+	ms.beads["bd-a"] = &model.Bead{ID: "bd-a", Status: model.StatusOpen}
+	dep := &model.ExternalDep{BeadID: "bd-a", Probe: model.ProbeHTTP, Status: model.ExternalWaiting}
+	// Someone marks the dependency satisfied while the probe is failing.
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := s.setExternalStatus(ctx, "bd-a", dep.ID, model.ExternalSatisfied, "alice"); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer slow.Close()
+	s.SetExternalProber(http.DefaultClient, "")
+	dep.URL = slow.URL
+	if err := ms.AddExternalDep(ctx, dep); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.ProbeExternalDeps(ctx, time.Now().UTC()); err != nil {
+		t.Fatal(err)
+	}
+	if d := ms.externals["bd-a"][0]; d.Status != model.ExternalSatisfied || d.LastError != "" {
+		t.Fatalf("dependency = %+v, want alice's edit kept", d)
+	}
+}
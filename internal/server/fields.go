@@ -0,0 +1,52 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// handlePatchFields handles PATCH /v1/beads/{id}/fields?actor=: an RFC 6902
+// JSON Patch of the bead's custom fields, such as appending to a change log
+// with {"op":"add","path":"/log/-","value":...}. The patch is applied to the
+// fields as stored when the bead is written, so concurrent patches do not
+// overwrite each other; a "test" operation that fails answers 409 and
+// changes nothing.
+func (s *BeadsServer) handlePatchFields(w http.ResponseWriter, r *http.Request) {
+	var ops []model.PatchOp
+	if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+		writeError(w, http.StatusBadRequest, "body must be a JSON Patch: an array of operations")
+		return
+	}
+	if len(ops) == 0 {
+		writeError(w, http.StatusBadRequest, "patch has no operations")
+		return
+	}
+
+	bead, err := s.updateBead(r.Context(), r.PathValue("id"), updateBeadInput{
+		UpdatedBy:   r.URL.Query().Get("actor"),
+		fieldsPatch: ops,
+	})
+	if err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "bead not found")
+		case errors.Is(err, model.ErrPatchTestFailed):
+			writeError(w, http.StatusConflict, "patch not applied: "+err.Error())
+		case errors.Is(err, store.ErrConflict):
+			writeError(w, http.StatusConflict, err.Error())
+		case writeLockedError(w, err):
+		default:
+			writeError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, bead)
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestHandlePatchFields(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-1"] = &model.Bead{ID: "bd-1", Title: "Spike", Kind: model.KindIssue, Type: "spike", Status: model.StatusOpen,
+		Fields: []byte(`{"log":["up"],"owner":"alice"}`)}
+	// The first write loses to a concurrent one; the retry patches again.
+	ms.updateConflicts = 1
+
+	rec := doJSON(t, h, "PATCH", "/v1/beads/bd-1/fields?actor=bob", []map[string]any{
+		{"op": "test", "path": "/owner", "value": "alice"},
+		{"op": "add", "path": "/log/-", "value": "extended"},
+		{"op": "replace", "path": "/owner", "value": "bob"},
+	})
+	requireStatus(t, rec, http.StatusOK)
+	var bead model.Bead
+	decodeJSON(t, rec, &bead)
+	if got := string(ms.beads["bd-1"].Fields); got != `{"log":["up","extended"],"owner":"bob"}` {
+		t.Fatalf("fields = %s", got)
+	}
+	if string(bead.Fields) != string(ms.beads["bd-1"].Fields) {
+		t.Errorf("response fields = %s", bead.Fields)
+	}
+	last := ms.events[len(ms.events)-1]
+	if last.Topic != events.TopicBeadUpdated || last.Actor != "bob" || !strings.Contains(string(last.Payload), `"fields"`) {
+		t.Errorf("event = %+v", last)
+	}
+
+	// A failed test changes nothing.
+	rec = doJSON(t, h, "PATCH", "/v1/beads/bd-1/fields", []map[string]any{
+		{"op": "add", "path": "/log/-", "value": "lost"},
+		{"op": "test", "path": "/owner", "value": "alice"},
+	})
+	requireStatus(t, rec, http.StatusConflict)
+	if got := string(ms.beads["bd-1"].Fields); got != `{"log":["up","extended"],"owner":"bob"}` {
+		t.Fatalf("fields after failed test = %s", got)
+	}
+
+	for _, patch := range []any{
+		[]map[string]any{},
+		[]map[string]any{{"op": "remove", "path": "/missing"}},
+		[]map[string]any{{"op": "replace", "path": "", "value": []string{"x"}}},
+		map[string]any{"owner": "carol"},
+	} {
+		requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-1/fields", patch), http.StatusBadRequest)
+	}
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-404/fields", []map[string]any{{"op": "add", "path": "/x", "value": 1}}), http.StatusNotFound)
+
+	req := httptest.NewRequest("PATCH", "/v1/beads/bd-1/fields", strings.NewReader(`[{"op":"add","path":"/n","value":1}]`))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	requireStatus(t, rec, http.StatusOK)
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// Forensics report sizes.
+const (
+	defaultForensicsLimit = 20
+	maxForensicsLimit     = 200
+)
+
+// agentForensics is what is left of an agent's context once it is gone:
+// enough for another agent to pick up its work.
+type agentForensics struct {
+	Agent       string           `json:"agent"`
+	GeneratedAt time.Time        `json:"generated_at"`
+	AgentBead   *model.Bead      `json:"agent_bead,omitempty"` // nil if the actor is not a registered agent
+	Presence    []*model.Event   `json:"presence"`             // events on the agent bead, newest first
+	Held        []*model.Bead    `json:"held"`                 // in-progress beads assigned to the agent
+	Events      []*model.Event   `json:"events"`               // events the agent caused, newest first
+	Comments    []*model.Comment `json:"comments"`             // comments the agent wrote, newest first
+	Gates       []gateState      `json:"gates"`
+}
+
+// agentForensics assembles the forensics report for agent, keeping the
+// latest limit presence events, events and comments. agent may be any
+// actor; the agent bead, presence and gates are only filled in for
+// registered agents. Returns sql.ErrNoRows if the actor left no trace.
+func (s *BeadsServer) agentForensics(ctx context.Context, agent string, limit int) (*agentForensics, error) {
+	if agent == "" {
+		return nil, inputError("agent is required")
+	}
+	if limit <= 0 {
+		limit = defaultForensicsLimit
+	}
+	limit = min(limit, maxForensicsLimit)
+
+	report := &agentForensics{
+		Agent:       agent,
+		GeneratedAt: time.Now().UTC(),
+		Presence:    []*model.Event{},
+		Gates:       []gateState{},
+	}
+
+	reg, err := s.store.GetAgent(ctx, agent)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return nil, err
+	default:
+		if report.AgentBead, err = s.store.GetBead(ctx, reg.BeadID); err != nil {
+			return nil, err
+		}
+		presence, err := s.store.GetEvents(ctx, reg.BeadID)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(presence) - 1; i >= 0 && len(report.Presence) < limit; i-- {
+			report.Presence = append(report.Presence, presence[i])
+		}
+		gates, err := s.listGates(ctx, agent)
+		if err != nil {
+			return nil, err
+		}
+		report.Gates = gates.Gates
+	}
+
+	if report.Held, _, err = s.store.ListBeads(ctx, model.BeadFilter{
+		Assignee: agent,
+		Status:   []model.Status{model.StatusInProgress},
+	}); err != nil {
+		return nil, err
+	}
+	if report.Events, err = s.store.ListEventsByActor(ctx, agent, limit); err != nil {
+		return nil, err
+	}
+	if report.Comments, err = s.store.ListCommentsByAuthor(ctx, agent, limit); err != nil {
+		return nil, err
+	}
+
+	if report.AgentBead == nil && len(report.Held) == 0 && len(report.Events) == 0 && len(report.Comments) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	if report.Held == nil {
+		report.Held = []*model.Bead{}
+	}
+	if report.Events == nil {
+		report.Events = []*model.Event{}
+	}
+	if report.Comments == nil {
+		report.Comments = []*model.Comment{}
+	}
+	return report, nil
+}
+
+// handleAgentForensics handles GET /v1/agents/{id}/forensics?limit=N. The id
+// is the agent's name, with any "/" escaped as %2F.
+func (s *BeadsServer) handleAgentForensics(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = n
+	}
+
+	report, err := s.agentForensics(r.Context(), r.PathValue("id"), limit)
+	if err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "no record of agent "+r.PathValue("id"))
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to build forensics report")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
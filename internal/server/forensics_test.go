@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestHandleAgentForensics(t *testing.T) {
+	s, ms, h := newGatedAgent(t)
+	ctx := withIdentity(context.Background(), "crew/test-agent")
+	ms.beads["bd-work"] = &model.Bead{ID: "bd-work", Title: "Port the parser", Status: model.StatusInProgress, Assignee: "crew/test-agent"}
+	ms.beads["bd-done"] = &model.Bead{ID: "bd-done", Status: model.StatusClosed, Assignee: "crew/test-agent"}
+	if _, err := s.AddComment(ctx, &beadsv1.AddCommentRequest{BeadId: "bd-work", Text: "lexer done, parser next"}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := doJSON(t, h, "GET", "/v1/agents/crew%2Ftest-agent/forensics?limit=5", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var report agentForensics
+	decodeJSON(t, rec, &report)
+
+	if report.Agent != "crew/test-agent" || report.AgentBead == nil || report.AgentBead.Type != "agent" {
+		t.Fatalf("agent = %q, bead = %+v", report.Agent, report.AgentBead)
+	}
+	if len(report.Held) != 1 || report.Held[0].ID != "bd-work" {
+		t.Errorf("held = %+v, want bd-work", report.Held)
+	}
+	if len(report.Comments) != 1 || report.Comments[0].Text != "lexer done, parser next" {
+		t.Errorf("comments = %+v", report.Comments)
+	}
+	if len(report.Events) != 1 || report.Events[0].Topic != "beads.comment.added" {
+		t.Errorf("events = %+v", report.Events)
+	}
+	if len(report.Presence) == 0 || report.Presence[0].BeadID != report.AgentBead.ID {
+		t.Errorf("presence = %+v", report.Presence)
+	}
+	if len(report.Gates) != 3 {
+		t.Errorf("gates = %+v, want 3", report.Gates)
+	}
+}
+
+func TestHandleAgentForensics_UnknownActor(t *testing.T) {
+	_, ms, h := newTestServer()
+	requireStatus(t, doJSON(t, h, "GET", "/v1/agents/ghost/forensics", nil), http.StatusNotFound)
+	requireStatus(t, doJSON(t, h, "GET", "/v1/agents/ghost/forensics?limit=0", nil), http.StatusBadRequest)
+
+	// An unregistered actor with history still gets a report, without gates.
+	ms.beads["bd-a"] = &model.Bead{ID: "bd-a", Status: model.StatusInProgress, Assignee: "alice"}
+	rec := doJSON(t, h, "GET", "/v1/agents/alice/forensics", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var report agentForensics
+	decodeJSON(t, rec, &report)
+	if report.AgentBead != nil || len(report.Held) != 1 || report.Gates == nil {
+		t.Fatalf("report = %+v", report)
+	}
+}
@@ -0,0 +1,596 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Gates are named checks an agent must pass, such as "tests-passed". Each
+// role's checklist is a "gate:<role>" config (model.GateConfig), plus the
+// "gate:*" checklist shared by all roles. An agent's state for a gate is its
+// gate bead: closed means satisfied, open or missing means not. Open gate
+// beads also block the agent bead, as those created at registration do.
+
+// gateNamePattern restricts gate names to simple identifiers.
+var gateNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// Hook decisions returned by emitHook.
+const (
+	hookAllow = "allow"
+	hookWarn  = "warn"
+	hookBlock = "block"
+)
+
+// maxGateWaiver bounds how long a gate can be waived at once.
+const maxGateWaiver = 7 * 24 * time.Hour
+
+// gateState is one gate of an agent's checklist and whether it is met.
+type gateState struct {
+	model.GateDef
+	Satisfied bool        `json:"satisfied"`
+	BeadID    string      `json:"bead_id,omitempty"`
+	Waiver    *gateWaiver `json:"waiver,omitempty"` // set while a waiver is in force
+}
+
+// gateWaiver suppresses an unsatisfied gate until it expires. It is kept
+// in the gate bead's fields.
+type gateWaiver struct {
+	Until  time.Time `json:"waived_until"`
+	By     string    `json:"waived_by"`
+	Reason string    `json:"waive_reason"`
+}
+
+// agentGates is an agent's full gate checklist.
+type agentGates struct {
+	Agent string      `json:"agent"`
+	Role  string      `json:"role"`
+	Gates []gateState `json:"gates"`
+}
+
+// hookResult is the outcome of evaluating the gates that apply to a hook.
+type hookResult struct {
+	Agent    string      `json:"agent"`
+	Role     string      `json:"role"`
+	Hook     string      `json:"hook"`
+	Decision string      `json:"decision"`
+	Message  string      `json:"message,omitempty"`
+	Gates    []gateState `json:"gates"`
+}
+
+// agentRole returns an agent's role: its "role" field if set, otherwise the
+// first segment of its name ("crew" for "crew/test-agent").
+func agentRole(name string, agentBead *model.Bead) string {
+	if agentBead != nil && len(agentBead.Fields) > 0 {
+		var f struct {
+			Role string `json:"role"`
+		}
+		if json.Unmarshal(agentBead.Fields, &f) == nil && f.Role != "" {
+			return f.Role
+		}
+	}
+	role, _, _ := strings.Cut(name, "/")
+	return role
+}
+
+// loadAgent returns the bead of a registered agent. Unknown agents are an
+// inputError.
+func (s *BeadsServer) loadAgent(ctx context.Context, name string) (*model.Bead, error) {
+	if name == "" {
+		return nil, inputError("agent is required")
+	}
+	agent, err := s.store.GetAgent(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, inputError("unknown agent " + name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.store.GetBead(ctx, agent.BeadID)
+}
+
+// gateDefs returns the gate checklist for role. A role's own definition of
+// a gate overrides the shared one of the same name.
+func (s *BeadsServer) gateDefs(ctx context.Context, role string) ([]model.GateDef, error) {
+	var defs []model.GateDef
+	index := map[string]int{}
+	for _, key := range []string{"gate:*", "gate:" + role} {
+		config, err := s.store.GetConfig(ctx, key)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var gc model.GateConfig
+		if err := json.Unmarshal(config.Value, &gc); err != nil {
+			return nil, fmt.Errorf("invalid config %s: %w", key, err)
+		}
+		if err := gc.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid config %s: %w", key, err)
+		}
+		for _, g := range gc.Gates {
+			if i, ok := index[g.Name]; ok {
+				defs[i] = g
+				continue
+			}
+			index[g.Name] = len(defs)
+			defs = append(defs, g)
+		}
+	}
+	return defs, nil
+}
+
+// gateBeads returns an agent's gate beads keyed by gate name. Beads from
+// before gates were named are matched by their "<gate> gate for <agent>"
+// title.
+func (s *BeadsServer) gateBeads(ctx context.Context, agent string) (map[string]*model.Bead, error) {
+	beads, _, err := s.store.ListBeads(ctx, model.BeadFilter{
+		Type:   []model.BeadType{"gate"},
+		Fields: map[string]string{"agent": agent},
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]*model.Bead, len(beads))
+	for _, b := range beads {
+		var f struct {
+			Agent string `json:"agent"`
+			Gate  string `json:"gate"`
+		}
+		if json.Unmarshal(b.Fields, &f) != nil || f.Agent != agent {
+			continue
+		}
+		name := f.Gate
+		if name == "" {
+			name, _ = strings.CutSuffix(b.Title, " gate for "+agent)
+		}
+		if name != "" {
+			out[name] = b
+		}
+	}
+	return out, nil
+}
+
+// listGates evaluates every gate of an agent: those its role defines and
+// any ad hoc gate beads, which block like gates of severity block.
+func (s *BeadsServer) listGates(ctx context.Context, agent string) (*agentGates, error) {
+	agentBead, err := s.loadAgent(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+	role := agentRole(agent, agentBead)
+	defs, err := s.gateDefs(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	beads, err := s.gateBeads(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &agentGates{Agent: agent, Role: role, Gates: []gateState{}}
+	defined := make(map[string]bool, len(defs))
+	for _, d := range defs {
+		defined[d.Name] = true
+		out.Gates = append(out.Gates, newGateState(d, beads[d.Name]))
+	}
+	adhoc := make([]string, 0, len(beads))
+	for name := range beads {
+		if !defined[name] {
+			adhoc = append(adhoc, name)
+		}
+	}
+	sort.Strings(adhoc)
+	for _, name := range adhoc {
+		out.Gates = append(out.Gates, newGateState(model.GateDef{Name: name, Severity: model.GateBlock}, beads[name]))
+	}
+	return out, nil
+}
+
+func newGateState(d model.GateDef, b *model.Bead) gateState {
+	g := gateState{GateDef: d}
+	if b != nil {
+		g.BeadID = b.ID
+		g.Satisfied = b.Status == model.StatusClosed
+		var w gateWaiver
+		if json.Unmarshal(b.Fields, &w) == nil && w.Until.After(time.Now()) {
+			g.Waiver = &w
+		}
+	}
+	return g
+}
+
+// gateDef returns the definition of gate in role's checklist, or an ad hoc
+// block gate if the checklist has none.
+func (s *BeadsServer) gateDef(ctx context.Context, role, gate string) (model.GateDef, error) {
+	defs, err := s.gateDefs(ctx, role)
+	if err != nil {
+		return model.GateDef{}, err
+	}
+	def := model.GateDef{Name: gate, Severity: model.GateBlock}
+	for _, d := range defs {
+		if d.Name == gate {
+			def = d
+		}
+	}
+	return def, nil
+}
+
+// setGate marks an agent's gate satisfied (closing its gate bead) or
+// unsatisfied (reopening it), creating the bead if the agent has none.
+func (s *BeadsServer) setGate(ctx context.Context, agent, gate string, satisfied bool, actor string) (*gateState, error) {
+	if !gateNamePattern.MatchString(gate) {
+		return nil, inputError("gate must be lowercase letters, digits, '.', '_' or '-' (e.g. tests-passed)")
+	}
+	agentBead, err := s.loadAgent(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+	actor = s.actorFor(ctx, actor)
+	beads, err := s.gateBeads(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+
+	b := beads[gate]
+	if b == nil {
+		if b, err = s.createGateBead(ctx, agent, agentBead.ID, gate, actor); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case satisfied && b.Status != model.StatusClosed:
+		if b, err = s.closeBead(ctx, b.ID, actor); err != nil {
+			return nil, err
+		}
+	case !satisfied && b.Status == model.StatusClosed:
+		open := string(model.StatusOpen)
+		if b, err = s.updateBead(ctx, b.ID, updateBeadInput{Status: &open, UpdatedBy: actor}); err != nil {
+			return nil, err
+		}
+	}
+
+	def, err := s.gateDef(ctx, agentRole(agent, agentBead), gate)
+	if err != nil {
+		return nil, err
+	}
+	g := newGateState(def, b)
+	return &g, nil
+}
+
+// waiveGate suppresses an agent's gate for ttl, recording who waived it
+// and why on its gate bead. The waiver lapses on its own; waiving again
+// replaces it.
+func (s *BeadsServer) waiveGate(ctx context.Context, agent, gate string, ttl time.Duration, reason, actor string) (*gateState, error) {
+	if !gateNamePattern.MatchString(gate) {
+		return nil, inputError("gate must be lowercase letters, digits, '.', '_' or '-' (e.g. tests-passed)")
+	}
+	if ttl <= 0 || ttl > maxGateWaiver {
+		return nil, inputError("ttl must be positive and at most " + maxGateWaiver.String())
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, inputError("reason is required")
+	}
+	agentBead, err := s.loadAgent(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+	actor = s.actorFor(ctx, actor)
+	beads, err := s.gateBeads(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+	b := beads[gate]
+	if b == nil {
+		if b, err = s.createGateBead(ctx, agent, agentBead.ID, gate, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := map[string]any{}
+	if len(b.Fields) > 0 {
+		if err := json.Unmarshal(b.Fields, &fields); err != nil {
+			return nil, fmt.Errorf("invalid fields on gate bead %s: %w", b.ID, err)
+		}
+	}
+	fields["waived_until"] = time.Now().UTC().Add(ttl).Truncate(time.Second)
+	fields["waived_by"] = actor
+	fields["waive_reason"] = reason
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	if b, err = s.updateBead(ctx, b.ID, updateBeadInput{Fields: raw, UpdatedBy: actor}); err != nil {
+		return nil, err
+	}
+
+	def, err := s.gateDef(ctx, agentRole(agent, agentBead), gate)
+	if err != nil {
+		return nil, err
+	}
+	g := newGateState(def, b)
+	return &g, nil
+}
+
+// createGateBead creates an open gate bead blocking the agent bead.
+func (s *BeadsServer) createGateBead(ctx context.Context, agent, agentBeadID, gate, actor string) (*model.Bead, error) {
+	b, err := s.newTypedBead(ctx, "gate", gate+" gate for "+agent, actor, map[string]any{"agent": agent, "gate": gate})
+	if err != nil {
+		return nil, err
+	}
+	dep := &model.Dependency{
+		BeadID:      agentBeadID,
+		DependsOnID: b.ID,
+		Type:        model.DepBlocks,
+		CreatedAt:   b.CreatedAt,
+		CreatedBy:   actor,
+	}
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := tx.CreateBead(ctx, b); err != nil {
+			return fmt.Errorf("failed to create bead: %w", err)
+		}
+		if err := s.recordEvent(ctx, tx, events.TopicBeadCreated, b.ID, actor, events.BeadCreated{Bead: b}); err != nil {
+			return err
+		}
+		if err := tx.AddDependency(ctx, dep); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicDependencyAdded, dep.BeadID, actor, events.DependencyAdded{Dependency: dep})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return b, nil
+}
+
+// emitHookInput is the body of POST /v1/hooks/emit.
+type emitHookInput struct {
+	Agent string `json:"agent,omitempty"` // defaults to the caller's identity
+	Hook  string `json:"hook"`            // e.g. "stop", "pre-push"
+
+	hookToken string // from HookTokenHeader or its gRPC metadata
+}
+
+// emitHook evaluates the gates of an agent that apply to a hook. Any
+// unsatisfied block gate blocks it; otherwise any unsatisfied warn gate
+// makes it a warning.
+func (s *BeadsServer) emitHook(ctx context.Context, in emitHookInput) (*hookResult, error) {
+	if in.Hook == "" {
+		return nil, inputError("hook is required")
+	}
+	agent, err := s.hookAgent(ctx, in.Agent, in.hookToken)
+	if err != nil {
+		return nil, err
+	}
+	all, err := s.listGates(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &hookResult{Agent: all.Agent, Role: all.Role, Hook: in.Hook, Decision: hookAllow, Gates: []gateState{}}
+	var blocking, warning, waived []string
+	for _, g := range all.Gates {
+		if !g.AppliesTo(in.Hook) {
+			continue
+		}
+		res.Gates = append(res.Gates, g)
+		if g.Satisfied {
+			continue
+		}
+		if g.Waiver != nil {
+			waived = append(waived, g.Name)
+			continue
+		}
+		if g.Severity == model.GateWarn {
+			warning = append(warning, g.Name)
+		} else {
+			blocking = append(blocking, g.Name)
+		}
+	}
+	switch {
+	case len(blocking) > 0:
+		res.Decision = hookBlock
+		res.Message = "unsatisfied gates: " + strings.Join(blocking, ", ")
+	case len(warning) > 0:
+		res.Decision = hookWarn
+		res.Message = "unsatisfied gates: " + strings.Join(warning, ", ")
+	case len(waived) > 0:
+		res.Message = "waived gates: " + strings.Join(waived, ", ")
+	}
+	return res, nil
+}
+
+// writeGateError maps gate errors to HTTP responses.
+func writeGateError(w http.ResponseWriter, err error) {
+	var (
+		ie inputError
+		ae authError
+		fe forbiddenError
+	)
+	switch {
+	case errors.As(err, &ie):
+		writeError(w, http.StatusBadRequest, ie.Error())
+	case errors.As(err, &ae):
+		writeError(w, http.StatusUnauthorized, ae.Error())
+	case errors.As(err, &fe):
+		writeError(w, http.StatusForbidden, fe.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "agent bead not found")
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// grpcGateError maps gate errors to gRPC status errors.
+func grpcGateError(err error) error {
+	var (
+		ie inputError
+		ae authError
+		fe forbiddenError
+	)
+	switch {
+	case errors.As(err, &ie):
+		return status.Error(codes.InvalidArgument, ie.Error())
+	case errors.As(err, &ae):
+		return status.Error(codes.Unauthenticated, ae.Error())
+	case errors.As(err, &fe):
+		return status.Error(codes.PermissionDenied, fe.Error())
+	}
+	return storeError(err, "agent bead")
+}
+
+// handleListGates handles GET /v1/gates?agent=NAME. The agent defaults to
+// the caller's identity.
+func (s *BeadsServer) handleListGates(w http.ResponseWriter, r *http.Request) {
+	gates, err := s.listGates(r.Context(), s.actorFor(r.Context(), r.URL.Query().Get("agent")))
+	if err != nil {
+		writeGateError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, gates)
+}
+
+// handleSetGate handles PUT /v1/gates/{gate}?agent=NAME, marking the gate
+// satisfied.
+func (s *BeadsServer) handleSetGate(w http.ResponseWriter, r *http.Request) {
+	s.writeSetGate(w, r, true)
+}
+
+// handleClearGate handles DELETE /v1/gates/{gate}?agent=NAME, marking the
+// gate unsatisfied.
+func (s *BeadsServer) handleClearGate(w http.ResponseWriter, r *http.Request) {
+	s.writeSetGate(w, r, false)
+}
+
+func (s *BeadsServer) writeSetGate(w http.ResponseWriter, r *http.Request, satisfied bool) {
+	agent := s.actorFor(r.Context(), r.URL.Query().Get("agent"))
+	g, err := s.setGate(r.Context(), agent, r.PathValue("gate"), satisfied, r.URL.Query().Get("actor"))
+	if err != nil {
+		writeGateError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, g)
+}
+
+// handleWaiveGate handles POST
+// /v1/agents/{id}/gates/{gate}/waive?ttl=2h&reason=, where {id} is the
+// agent's name.
+func (s *BeadsServer) handleWaiveGate(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	ttl, err := time.ParseDuration(q.Get("ttl"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "ttl must be a duration, e.g. 2h")
+		return
+	}
+	g, err := s.waiveGate(r.Context(), r.PathValue("id"), r.PathValue("gate"), ttl, q.Get("reason"), q.Get("actor"))
+	if err != nil {
+		writeGateError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, g)
+}
+
+// handleEmitHook handles POST /v1/hooks/emit. The response is 200 whatever
+// the decision; callers act on its "decision" field.
+func (s *BeadsServer) handleEmitHook(w http.ResponseWriter, r *http.Request) {
+	var in emitHookInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	in.hookToken = r.Header.Get(HookTokenHeader)
+	res, err := s.emitHook(r.Context(), in)
+	if err != nil {
+		writeGateError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func gateStateToProto(g gateState) *beadsv1.Gate {
+	pb := &beadsv1.Gate{
+		Name:        g.Name,
+		Description: g.Description,
+		Severity:    string(g.Severity),
+		Hooks:       g.Hooks,
+		Satisfied:   g.Satisfied,
+		BeadId:      g.BeadID,
+	}
+	if g.Waiver != nil {
+		pb.WaivedUntil = timestamppb.New(g.Waiver.Until)
+		pb.WaivedBy = g.Waiver.By
+		pb.WaiveReason = g.Waiver.Reason
+	}
+	return pb
+}
+
+func gateStatesToProto(gates []gateState) []*beadsv1.Gate {
+	out := make([]*beadsv1.Gate, 0, len(gates))
+	for _, g := range gates {
+		out = append(out, gateStateToProto(g))
+	}
+	return out
+}
+
+// ListGates returns an agent's gate checklist.
+func (s *BeadsServer) ListGates(ctx context.Context, req *beadsv1.ListGatesRequest) (*beadsv1.ListGatesResponse, error) {
+	gates, err := s.listGates(ctx, s.actorFor(ctx, req.GetAgent()))
+	if err != nil {
+		return nil, grpcGateError(err)
+	}
+	return &beadsv1.ListGatesResponse{Agent: gates.Agent, Role: gates.Role, Gates: gateStatesToProto(gates.Gates)}, nil
+}
+
+// SetGate marks an agent's gate satisfied or unsatisfied.
+func (s *BeadsServer) SetGate(ctx context.Context, req *beadsv1.SetGateRequest) (*beadsv1.SetGateResponse, error) {
+	g, err := s.setGate(ctx, s.actorFor(ctx, req.GetAgent()), req.GetGate(), req.GetSatisfied(), req.GetActor())
+	if err != nil {
+		return nil, grpcGateError(err)
+	}
+	return &beadsv1.SetGateResponse{Gate: gateStateToProto(*g)}, nil
+}
+
+// WaiveGate suppresses an agent's gate for a while.
+func (s *BeadsServer) WaiveGate(ctx context.Context, req *beadsv1.WaiveGateRequest) (*beadsv1.WaiveGateResponse, error) {
+	ttl, err := time.ParseDuration(req.GetTtl())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "ttl must be a duration, e.g. 2h")
+	}
+	g, err := s.waiveGate(ctx, s.actorFor(ctx, req.GetAgent()), req.GetGate(), ttl, req.GetReason(), req.GetActor())
+	if err != nil {
+		return nil, grpcGateError(err)
+	}
+	return &beadsv1.WaiveGateResponse{Gate: gateStateToProto(*g)}, nil
+}
+
+// EmitHook evaluates the gates of an agent that apply to a hook.
+func (s *BeadsServer) EmitHook(ctx context.Context, req *beadsv1.EmitHookRequest) (*beadsv1.EmitHookResponse, error) {
+	res, err := s.emitHook(ctx, emitHookInput{Agent: req.GetAgent(), Hook: req.GetHook(), hookToken: grpcHookToken(ctx)})
+	if err != nil {
+		return nil, grpcGateError(err)
+	}
+	return &beadsv1.EmitHookResponse{
+		Agent:    res.Agent,
+		Role:     res.Role,
+		Decision: res.Decision,
+		Message:  res.Message,
+		Gates:    gateStatesToProto(res.Gates),
+	}, nil
+}
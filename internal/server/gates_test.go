@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+// newGatedAgent registers crew/test-agent with an ad hoc onboarding gate and
+// a crew checklist of tests-passed (block) and commit-pushed (warn, stop
+// hook only).
+func newGatedAgent(t *testing.T) (*BeadsServer, *mockStore, http.Handler) {
+	t.Helper()
+	s, ms, h := newTestServer()
+	s.SetRegistrationTokens("admin-secret", "")
+	if _, err := s.registerAgent(context.Background(), "admin-secret", registerAgentInput{
+		Name: "crew/test-agent", Gates: []string{"onboarding"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	requireStatus(t, doJSON(t, h, "PUT", "/v1/configs/gate:crew", map[string]any{"value": map[string]any{
+		"gates": []map[string]any{
+			{"name": "tests-passed"},
+			{"name": "commit-pushed", "severity": "warn", "hooks": []string{"stop"}},
+		},
+	}}), http.StatusOK)
+	return s, ms, h
+}
+
+func emit(t *testing.T, h http.Handler, hook string) hookResult {
+	t.Helper()
+	rec := doJSON(t, h, "POST", "/v1/hooks/emit", map[string]any{"agent": "crew/test-agent", "hook": hook})
+	requireStatus(t, rec, http.StatusOK)
+	var res hookResult
+	decodeJSON(t, rec, &res)
+	return res
+}
+
+func TestEmitHook(t *testing.T) {
+	_, _, h := newGatedAgent(t)
+
+	res := emit(t, h, "stop")
+	if res.Decision != hookBlock || res.Role != "crew" || len(res.Gates) != 3 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if res.Message != "unsatisfied gates: tests-passed, onboarding" {
+		t.Fatalf("unexpected message: %q", res.Message)
+	}
+
+	for _, g := range []string{"tests-passed", "onboarding"} {
+		requireStatus(t, doJSON(t, h, "PUT", "/v1/gates/"+g+"?agent=crew/test-agent", nil), http.StatusOK)
+	}
+	if res := emit(t, h, "stop"); res.Decision != hookWarn {
+		t.Fatalf("expected warn, got %+v", res)
+	}
+	// commit-pushed only applies to the stop hook.
+	if res := emit(t, h, "pre-push"); res.Decision != hookAllow || len(res.Gates) != 2 {
+		t.Fatalf("expected allow, got %+v", res)
+	}
+
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/gates/tests-passed?agent=crew/test-agent", nil), http.StatusOK)
+	if res := emit(t, h, "pre-push"); res.Decision != hookBlock {
+		t.Fatalf("expected block after clearing, got %+v", res)
+	}
+}
+
+func TestWaiveGate(t *testing.T) {
+	_, ms, h := newGatedAgent(t)
+	for _, g := range []string{"tests-passed", "commit-pushed"} {
+		requireStatus(t, doJSON(t, h, "PUT", "/v1/gates/"+g+"?agent=crew/test-agent", nil), http.StatusOK)
+	}
+	if res := emit(t, h, "stop"); res.Decision != hookBlock {
+		t.Fatalf("expected onboarding to block, got %+v", res)
+	}
+
+	base := "/v1/agents/crew%2Ftest-agent/gates/onboarding/waive"
+	requireStatus(t, doJSON(t, h, "POST", base+"?ttl=2h", nil), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "POST", base+"?ttl=200h&reason=x", nil), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "POST", base+"?ttl=soon&reason=x", nil), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/agents/crew%2Fnobody/gates/onboarding/waive?ttl=2h&reason=x", nil), http.StatusBadRequest)
+
+	rec := doJSON(t, h, "POST", base+"?ttl=2h&reason=CI+is+down&actor=alice", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var g gateState
+	decodeJSON(t, rec, &g)
+	if g.Waiver == nil || g.Waiver.By != "alice" || g.Waiver.Reason != "CI is down" || time.Until(g.Waiver.Until) < time.Hour {
+		t.Fatalf("unexpected waiver: %+v", g.Waiver)
+	}
+	res := emit(t, h, "stop")
+	if res.Decision != hookAllow || res.Message != "waived gates: onboarding" {
+		t.Fatalf("expected the waiver to allow the hook, got %+v", res)
+	}
+
+	// Once the waiver lapses the gate blocks again.
+	b := ms.beads[g.BeadID]
+	b.Fields = []byte(`{"agent":"crew/test-agent","gate":"onboarding","waived_until":"2020-01-01T00:00:00Z","waived_by":"alice","waive_reason":"CI is down"}`)
+	if res := emit(t, h, "stop"); res.Decision != hookBlock || res.Gates[2].Waiver != nil {
+		t.Fatalf("expected an expired waiver to block, got %+v", res)
+	}
+}
+
+func TestListGates(t *testing.T) {
+	_, ms, h := newGatedAgent(t)
+	requireStatus(t, doJSON(t, h, "PUT", "/v1/gates/tests-passed?agent=crew/test-agent", nil), http.StatusOK)
+
+	rec := doJSON(t, h, "GET", "/v1/gates?agent=crew/test-agent", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var gates agentGates
+	decodeJSON(t, rec, &gates)
+	if len(gates.Gates) != 3 {
+		t.Fatalf("expected 3 gates, got %+v", gates.Gates)
+	}
+	tp := gates.Gates[0]
+	if tp.Name != "tests-passed" || !tp.Satisfied || tp.Severity != model.GateBlock || tp.BeadID == "" {
+		t.Fatalf("unexpected tests-passed gate: %+v", tp)
+	}
+	// The new gate bead blocks the agent bead like registration gates do.
+	agent := ms.agents["crew/test-agent"]
+	var blocked bool
+	for _, d := range ms.deps[agent.BeadID] {
+		blocked = blocked || d.DependsOnID == tp.BeadID
+	}
+	if !blocked {
+		t.Fatal("expected the gate bead to block the agent bead")
+	}
+	if cp := gates.Gates[1]; cp.Name != "commit-pushed" || cp.Satisfied || cp.BeadID != "" {
+		t.Fatalf("unexpected commit-pushed gate: %+v", cp)
+	}
+}
+
+func TestGates_Errors(t *testing.T) {
+	_, _, h := newGatedAgent(t)
+
+	requireStatus(t, doJSON(t, h, "GET", "/v1/gates?agent=crew/nobody", nil), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "PUT", "/v1/gates/Not%20A%20Gate?agent=crew/test-agent", nil), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/hooks/emit", map[string]any{"agent": "crew/test-agent"}), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "PUT", "/v1/configs/gate:crew", map[string]any{"value": map[string]any{
+		"gates": []map[string]any{{"name": "x", "severity": "fatal"}},
+	}}), http.StatusBadRequest)
+}
+
+func TestGRPCGates(t *testing.T) {
+	srv, _, _ := newGatedAgent(t)
+	ctx := context.Background()
+
+	if _, err := srv.SetGate(ctx, &beadsv1.SetGateRequest{Agent: "crew/test-agent", Gate: "tests-passed", Satisfied: true}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := srv.ListGates(ctx, &beadsv1.ListGatesRequest{Agent: "crew/test-agent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.GetGates()) != 3 || !list.GetGates()[0].GetSatisfied() {
+		t.Fatalf("unexpected gates: %v", list.GetGates())
+	}
+	resp, err := srv.EmitHook(ctx, &beadsv1.EmitHookRequest{Agent: "crew/test-agent", Hook: "pre-push"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetDecision() != hookBlock || resp.GetMessage() != "unsatisfied gates: onboarding" {
+		t.Fatalf("unexpected decision: %v", resp)
+	}
+
+	_, err = srv.EmitHook(ctx, &beadsv1.EmitHookRequest{Agent: "crew/nobody", Hook: "stop"})
+	requireCode(t, err, codes.InvalidArgument)
+}
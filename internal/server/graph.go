@@ -0,0 +1,539 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// Graph export formats accepted by GET /v1/export/graph.
+const (
+	graphFormatDOT       = "dot"
+	graphFormatGraphML   = "graphml"
+	graphFormatJSONGraph = "jsongraph"
+	graphFormatMermaid   = "mermaid"
+)
+
+// beadGraph is the set of beads matching a filter and the dependency edges
+// between them. Edges whose target falls outside the set are dropped so the
+// result is always a closed graph. Types describes each edge's dependency
+// type for display.
+type beadGraph struct {
+	Nodes []*model.Bead
+	Edges []*model.Dependency
+	Types depTypeSet
+}
+
+// loadGraph fetches the beads matching filter together with their labels and
+// the dependencies among them.
+func (s *BeadsServer) loadGraph(ctx context.Context, filter model.BeadFilter) (*beadGraph, error) {
+	beads, _, err := s.store.ListBeads(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list beads: %w", err)
+	}
+	sort.Slice(beads, func(i, j int) bool { return beads[i].ID < beads[j].ID })
+	types, err := s.depTypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load dependency types: %w", err)
+	}
+
+	inSet := make(map[string]struct{}, len(beads))
+	for _, b := range beads {
+		inSet[b.ID] = struct{}{}
+	}
+
+	g := &beadGraph{Nodes: beads, Types: types}
+	for _, b := range beads {
+		labels, err := s.store.GetLabels(ctx, b.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get labels for %s: %w", b.ID, err)
+		}
+		b.Labels = labels
+
+		deps, err := s.store.GetDependencies(ctx, b.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get dependencies for %s: %w", b.ID, err)
+		}
+		for _, d := range deps {
+			if _, ok := inSet[d.DependsOnID]; ok {
+				g.Edges = append(g.Edges, d)
+			}
+		}
+	}
+	return g, nil
+}
+
+// handleExportGraph handles GET /v1/export/graph.
+// format selects dot, graphml, mermaid or jsongraph (the default). root
+// limits the graph to the beads reachable from one bead through its
+// dependencies, at most depth hops away (0 = unlimited), and dep_type keeps
+// only edges of the given comma-separated types. The remaining query
+// parameters are the same filters accepted by GET /v1/beads.
+func (s *BeadsServer) handleExportGraph(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format := q.Get("format")
+	if format == "" {
+		format = graphFormatJSONGraph
+	}
+	switch format {
+	case graphFormatDOT, graphFormatGraphML, graphFormatJSONGraph, graphFormatMermaid:
+	default:
+		writeError(w, http.StatusBadRequest, "format must be dot, graphml, jsongraph or mermaid")
+		return
+	}
+	depth := 0
+	if v := q.Get("depth"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "depth must be a non-negative integer")
+			return
+		}
+		depth = n
+	}
+
+	filter, err := parseBeadFilter(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	g, err := s.loadGraph(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to export graph")
+		return
+	}
+	if v := q.Get("dep_type"); v != "" {
+		g.keepEdgeTypes(strings.Split(v, ","))
+	}
+	if root := q.Get("root"); root != "" {
+		if g, err = g.reachableFrom(root, depth); err != nil {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+	}
+
+	switch format {
+	case graphFormatDOT:
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_ = writeDOT(w, g)
+	case graphFormatMermaid:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_ = writeMermaid(w, g)
+	case graphFormatGraphML:
+		w.Header().Set("Content-Type", "application/graphml+xml")
+		w.WriteHeader(http.StatusOK)
+		_ = writeGraphML(w, g)
+	default:
+		writeJSON(w, http.StatusOK, jsonGraphDocument(g))
+	}
+}
+
+// keepEdgeTypes drops the edges whose dependency type is not in types.
+func (g *beadGraph) keepEdgeTypes(types []string) {
+	keep := make(map[model.DependencyType]bool, len(types))
+	for _, t := range types {
+		keep[model.DependencyType(strings.TrimSpace(t))] = true
+	}
+	edges := g.Edges[:0]
+	for _, d := range g.Edges {
+		if keep[d.Type] {
+			edges = append(edges, d)
+		}
+	}
+	g.Edges = edges
+}
+
+// reachableFrom returns the subgraph of beads reachable from root by
+// following dependencies, at most depth hops away (0 = unlimited). It fails
+// if root is not in the graph.
+func (g *beadGraph) reachableFrom(root string, depth int) (*beadGraph, error) {
+	out := make(map[string][]*model.Dependency)
+	for _, d := range g.Edges {
+		out[d.BeadID] = append(out[d.BeadID], d)
+	}
+
+	dist := map[string]int{}
+	for _, b := range g.Nodes {
+		if b.ID == root {
+			dist[root] = 0
+		}
+	}
+	if _, ok := dist[root]; !ok {
+		return nil, fmt.Errorf("bead %s not found in graph", root)
+	}
+	sub := &beadGraph{Types: g.Types}
+	queue := []string{root}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if depth > 0 && dist[id] >= depth {
+			continue
+		}
+		for _, d := range out[id] {
+			sub.Edges = append(sub.Edges, d)
+			if _, seen := dist[d.DependsOnID]; !seen {
+				dist[d.DependsOnID] = dist[id] + 1
+				queue = append(queue, d.DependsOnID)
+			}
+		}
+	}
+	for _, b := range g.Nodes {
+		if _, ok := dist[b.ID]; ok {
+			sub.Nodes = append(sub.Nodes, b)
+		}
+	}
+	return sub, nil
+}
+
+// Graphviz DOT (https://graphviz.org/doc/info/lang.html). Non-blocking edges
+// are dashed and closed beads grey.
+
+func writeDOT(w io.Writer, g *beadGraph) error {
+	var b strings.Builder
+	b.WriteString("digraph beads {\n  rankdir=LR;\n  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		attrs := ""
+		if n.Status == model.StatusClosed {
+			attrs = ", style=filled, fillcolor=lightgrey, fontcolor=gray30"
+		}
+		label := n.ID + "\n" + n.Title + "\n(" + string(n.Status) + ")"
+		fmt.Fprintf(&b, "  %s [label=%s%s];\n", dotQuote(n.ID), dotQuote(label), attrs)
+	}
+	for _, d := range g.Edges {
+		attrs := ""
+		if !g.Types.blocking(d.Type) {
+			attrs = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %s -> %s [label=%s%s];\n",
+			dotQuote(d.BeadID), dotQuote(d.DependsOnID), dotQuote(g.Types.label(d.Type)), attrs)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote returns s as a DOT double-quoted string.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// Mermaid flowchart (https://mermaid.js.org/syntax/flowchart.html). Node
+// IDs are positional because bead IDs may not be valid Mermaid identifiers;
+// each node's label carries the bead ID.
+
+func writeMermaid(w io.Writer, g *beadGraph) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	ids := make(map[string]string, len(g.Nodes))
+	var closed []string
+	for i, n := range g.Nodes {
+		id := "n" + strconv.Itoa(i)
+		ids[n.ID] = id
+		fmt.Fprintf(&b, "  %s[\"%s<br/>%s\"]\n", id, mermaidEscape(n.ID), mermaidEscape(n.Title))
+		if n.Status == model.StatusClosed {
+			closed = append(closed, id)
+		}
+	}
+	for _, d := range g.Edges {
+		arrow := "-->"
+		if !g.Types.blocking(d.Type) {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s|\"%s\"| %s\n", ids[d.BeadID], arrow, mermaidEscape(g.Types.label(d.Type)), ids[d.DependsOnID])
+	}
+	if len(closed) > 0 {
+		b.WriteString("  classDef closed fill:#eee,stroke:#999,color:#777\n")
+		fmt.Fprintf(&b, "  class %s closed\n", strings.Join(closed, ","))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidEscape makes s safe inside a quoted Mermaid label.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", " ").Replace(s)
+}
+
+// JSON Graph Format (https://jsongraphformat.info), version 2.
+
+type jsonGraph struct {
+	Graph jsonGraphBody `json:"graph"`
+}
+
+type jsonGraphBody struct {
+	Directed bool                     `json:"directed"`
+	Nodes    map[string]jsonGraphNode `json:"nodes"`
+	Edges    []jsonGraphEdge          `json:"edges"`
+}
+
+type jsonGraphNode struct {
+	Label    string         `json:"label"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+type jsonGraphEdge struct {
+	Source   string         `json:"source"`
+	Target   string         `json:"target"`
+	Relation string         `json:"relation,omitempty"`
+	Label    string         `json:"label,omitempty"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+func jsonGraphDocument(g *beadGraph) jsonGraph {
+	doc := jsonGraph{Graph: jsonGraphBody{
+		Directed: true,
+		Nodes:    make(map[string]jsonGraphNode, len(g.Nodes)),
+		Edges:    make([]jsonGraphEdge, 0, len(g.Edges)),
+	}}
+	for _, b := range g.Nodes {
+		meta := map[string]any{
+			"kind":     b.Kind,
+			"type":     b.Type,
+			"status":   b.Status,
+			"priority": b.Priority,
+		}
+		if b.Assignee != "" {
+			meta["assignee"] = b.Assignee
+		}
+		if len(b.Labels) > 0 {
+			meta["labels"] = b.Labels
+		}
+		if len(b.Fields) > 0 {
+			meta["fields"] = b.Fields
+		}
+		doc.Graph.Nodes[b.ID] = jsonGraphNode{Label: b.Title, Metadata: meta}
+	}
+	for _, d := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, jsonGraphEdge{
+			Source:   d.BeadID,
+			Target:   d.DependsOnID,
+			Relation: string(d.Type),
+			Label:    g.Types.label(d.Type),
+			Metadata: edgeMetadata(d, g.Types),
+		})
+	}
+	return doc
+}
+
+// edgeMetadata is the dependency's own metadata plus "blocking", which is
+// derived from its type and overrides any stored key of that name.
+func edgeMetadata(d *model.Dependency, types depTypeSet) map[string]any {
+	meta := map[string]any{}
+	if len(d.Metadata) > 0 {
+		_ = json.Unmarshal(d.Metadata, &meta)
+	}
+	meta["blocking"] = types.blocking(d.Type)
+	return meta
+}
+
+// GraphML (http://graphml.graphdrawing.org).
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+var graphMLKeys = []graphMLKey{
+	{ID: "title", For: "node", AttrName: "title", AttrType: "string"},
+	{ID: "kind", For: "node", AttrName: "kind", AttrType: "string"},
+	{ID: "type", For: "node", AttrName: "type", AttrType: "string"},
+	{ID: "status", For: "node", AttrName: "status", AttrType: "string"},
+	{ID: "priority", For: "node", AttrName: "priority", AttrType: "int"},
+	{ID: "assignee", For: "node", AttrName: "assignee", AttrType: "string"},
+	{ID: "labels", For: "node", AttrName: "labels", AttrType: "string"},
+	{ID: "dep_type", For: "edge", AttrName: "type", AttrType: "string"},
+	{ID: "dep_label", For: "edge", AttrName: "label", AttrType: "string"},
+	{ID: "dep_blocking", For: "edge", AttrName: "blocking", AttrType: "boolean"},
+	{ID: "dep_metadata", For: "edge", AttrName: "metadata", AttrType: "string"},
+}
+
+func writeGraphML(w io.Writer, g *beadGraph) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys:  graphMLKeys,
+		Graph: graphMLGraph{ID: "beads", EdgeDefault: "directed"},
+	}
+	for _, b := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: b.ID,
+			Data: []graphMLData{
+				{Key: "title", Value: b.Title},
+				{Key: "kind", Value: string(b.Kind)},
+				{Key: "type", Value: string(b.Type)},
+				{Key: "status", Value: string(b.Status)},
+				{Key: "priority", Value: strconv.Itoa(b.Priority)},
+				{Key: "assignee", Value: b.Assignee},
+				{Key: "labels", Value: strings.Join(b.Labels, ",")},
+			},
+		})
+	}
+	for _, d := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: d.BeadID,
+			Target: d.DependsOnID,
+			Data: []graphMLData{
+				{Key: "dep_type", Value: string(d.Type)},
+				{Key: "dep_label", Value: g.Types.label(d.Type)},
+				{Key: "dep_blocking", Value: strconv.FormatBool(g.Types.blocking(d.Type))},
+				{Key: "dep_metadata", Value: string(d.Metadata)},
+			},
+		})
+	}
+
+	if _, err := w.Write([]byte(xml.Header)); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
+
+// importGraphRequest is the body of POST /v1/import/graph. It accepts either
+// a plain edge list ({"edges": [...]}) or a JSON Graph document
+// ({"graph": {"edges": [...]}}), so an export can be fed back in directly.
+type importGraphRequest struct {
+	Edges []importGraphEdge `json:"edges"`
+	Graph *struct {
+		Edges []importGraphEdge `json:"edges"`
+	} `json:"graph"`
+	CreatedBy string `json:"created_by"`
+}
+
+type importGraphEdge struct {
+	Source   string         `json:"source"`
+	Target   string         `json:"target"`
+	Type     string         `json:"type"`
+	Relation string         `json:"relation"` // JSON Graph spelling of Type
+	Metadata map[string]any `json:"metadata"`
+}
+
+// handleImportGraph handles POST /v1/import/graph.
+// Every edge becomes a dependency (source depends on target); the type
+// defaults to "blocks". Edge metadata is stored on the dependency, except for
+// the derived "blocking" key written by exports. All edges are validated
+// before any are created.
+func (s *BeadsServer) handleImportGraph(w http.ResponseWriter, r *http.Request) {
+	var req importGraphRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	edges := req.Edges
+	if req.Graph != nil {
+		edges = append(edges, req.Graph.Edges...)
+	}
+	if len(edges) == 0 {
+		writeError(w, http.StatusBadRequest, "edges are required")
+		return
+	}
+
+	ctx := r.Context()
+	now := time.Now().UTC()
+	deps := make([]*model.Dependency, 0, len(edges))
+	for i, e := range edges {
+		if e.Source == "" || e.Target == "" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("edges[%d]: source and target are required", i))
+			return
+		}
+		if e.Source == e.Target {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("edges[%d]: a bead cannot depend on itself", i))
+			return
+		}
+		depType := model.DependencyType(e.Type)
+		if depType == "" {
+			depType = model.DependencyType(e.Relation)
+		}
+		if depType == "" {
+			depType = model.DepBlocks
+		}
+		if !depType.IsValid() {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("edges[%d]: invalid type", i))
+			return
+		}
+		for _, id := range []string{e.Source, e.Target} {
+			b, err := s.store.GetBead(ctx, id)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "failed to look up bead")
+				return
+			}
+			if b == nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("edges[%d]: bead %s not found", i, id))
+				return
+			}
+		}
+		var meta json.RawMessage
+		delete(e.Metadata, "blocking")
+		if len(e.Metadata) > 0 {
+			meta, _ = json.Marshal(e.Metadata)
+		}
+		deps = append(deps, &model.Dependency{
+			BeadID:      e.Source,
+			DependsOnID: e.Target,
+			Type:        depType,
+			CreatedAt:   now,
+			CreatedBy:   s.actorFor(ctx, req.CreatedBy),
+			Metadata:    meta,
+		})
+	}
+
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		for _, d := range deps {
+			if err := s.insertDependency(ctx, tx, d); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if !writeCycleError(w, err) {
+			writeError(w, http.StatusInternalServerError, "failed to import dependencies")
+		}
+		return
+	}
+	s.flushEvents(ctx)
+
+	writeJSON(w, http.StatusCreated, map[string]any{"created": len(deps)})
+}
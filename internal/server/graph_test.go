@@ -0,0 +1,293 @@
+package server
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// seedGraph stores three task beads; a depends on b, and b depends on the
+// closed bead c.
+func seedGraph(ms *mockStore) {
+	for _, b := range []*model.Bead{
+		{ID: "bd-a", Title: "A", Kind: model.KindIssue, Type: "task", Status: model.StatusOpen},
+		{ID: "bd-b", Title: "B", Kind: model.KindIssue, Type: "task", Status: model.StatusOpen},
+		{ID: "bd-c", Title: "C", Kind: model.KindIssue, Type: "task", Status: model.StatusClosed},
+	} {
+		ms.beads[b.ID] = b
+	}
+	ms.labels["bd-a"] = []string{"backend"}
+	ms.deps["bd-a"] = []*model.Dependency{{BeadID: "bd-a", DependsOnID: "bd-b", Type: model.DepBlocks}}
+	ms.deps["bd-b"] = []*model.Dependency{{BeadID: "bd-b", DependsOnID: "bd-c", Type: model.DepRelated}}
+}
+
+func TestHandleExportGraph_JSONGraph(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedGraph(ms)
+
+	rec := doJSON(t, h, "GET", "/v1/export/graph?status=open", nil)
+	requireStatus(t, rec, http.StatusOK)
+
+	var doc jsonGraph
+	if err := json.NewDecoder(rec.Body).Decode(&doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(doc.Graph.Nodes) != 2 {
+		t.Fatalf("got %d nodes, want 2", len(doc.Graph.Nodes))
+	}
+	if got := doc.Graph.Nodes["bd-a"].Label; got != "A" {
+		t.Errorf("bd-a label = %q, want A", got)
+	}
+	// The b->c edge leaves the filtered set and is dropped.
+	if len(doc.Graph.Edges) != 1 {
+		t.Fatalf("got %d edges, want 1", len(doc.Graph.Edges))
+	}
+	if e := doc.Graph.Edges[0]; e.Source != "bd-a" || e.Target != "bd-b" || e.Relation != "blocks" {
+		t.Errorf("unexpected edge: %+v", e)
+	}
+}
+
+func TestHandleExportGraph_EdgeMetadata(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedGraph(ms)
+	seedReviewDepType(ms)
+	ms.deps["bd-a"] = []*model.Dependency{{
+		BeadID: "bd-a", DependsOnID: "bd-b", Type: "needs-review",
+		Metadata: json.RawMessage(`{"reviewer":"bob"}`),
+	}}
+
+	rec := doJSON(t, h, "GET", "/v1/export/graph", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var doc jsonGraph
+	decodeJSON(t, rec, &doc)
+	var edge, related jsonGraphEdge
+	for _, e := range doc.Graph.Edges {
+		switch e.Source {
+		case "bd-a":
+			edge = e
+		case "bd-b":
+			related = e
+		}
+	}
+	if edge.Label != "awaiting review from" || edge.Metadata["reviewer"] != "bob" || edge.Metadata["blocking"] != true {
+		t.Errorf("needs-review edge = %+v", edge)
+	}
+	if related.Label != "related to" || related.Metadata["blocking"] != false {
+		t.Errorf("related edge = %+v", related)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/export/graph?format=graphml", nil)
+	requireStatus(t, rec, http.StatusOK)
+	for _, want := range []string{
+		`<data key="dep_blocking">true</data>`,
+		`<data key="dep_metadata">{&#34;reviewer&#34;:&#34;bob&#34;}</data>`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("GraphML missing %s:\n%s", want, rec.Body.String())
+		}
+	}
+}
+
+func TestHandleExportGraph_GraphML(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedGraph(ms)
+
+	rec := doJSON(t, h, "GET", "/v1/export/graph?format=graphml", nil)
+	requireStatus(t, rec, http.StatusOK)
+	if ct := rec.Header().Get("Content-Type"); ct != "application/graphml+xml" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	var doc graphML
+	if err := xml.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(doc.Graph.Nodes) != 3 || len(doc.Graph.Edges) != 2 {
+		t.Fatalf("got %d nodes, %d edges; want 3, 2", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+	if !strings.Contains(rec.Body.String(), `<data key="labels">backend</data>`) {
+		t.Errorf("labels missing from GraphML:\n%s", rec.Body.String())
+	}
+}
+
+func TestHandleExportGraph_BadFormat(t *testing.T) {
+	_, _, h := newTestServer()
+	rec := doJSON(t, h, "GET", "/v1/export/graph?format=svg", nil)
+	requireStatus(t, rec, http.StatusBadRequest)
+	rec = doJSON(t, h, "GET", "/v1/export/graph?depth=-1", nil)
+	requireStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestHandleExportGraph_DOT(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedGraph(ms)
+	ms.beads["bd-b"].Title = `Say "hi"`
+
+	rec := doJSON(t, h, "GET", "/v1/export/graph?format=dot", nil)
+	requireStatus(t, rec, http.StatusOK)
+	body := rec.Body.String()
+	for _, want := range []string{
+		"digraph beads {",
+		`"bd-b" [label="bd-b\nSay \"hi\"\n(open)"];`,
+		`"bd-a" -> "bd-b" [label="blocked by"];`,
+		`"bd-b" -> "bd-c" [label="related to", style=dashed];`,
+		`"bd-c" [label="bd-c\nC\n(closed)", style=filled`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("DOT missing %s:\n%s", want, body)
+		}
+	}
+}
+
+func TestHandleExportGraph_Mermaid(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedGraph(ms)
+
+	rec := doJSON(t, h, "GET", "/v1/export/graph?format=mermaid", nil)
+	requireStatus(t, rec, http.StatusOK)
+	want := `flowchart LR
+  n0["bd-a<br/>A"]
+  n1["bd-b<br/>B"]
+  n2["bd-c<br/>C"]
+  n0 -->|"blocked by"| n1
+  n1 -.->|"related to"| n2
+  classDef closed fill:#eee,stroke:#999,color:#777
+  class n2 closed
+`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("Mermaid =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestHandleExportGraph_Relations(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedGraph(ms)
+	ms.deps["bd-c"] = append(ms.deps["bd-c"], &model.Dependency{BeadID: "bd-c", DependsOnID: "bd-a", Type: model.RelCausedBy})
+
+	rec := doJSON(t, h, "GET", "/v1/export/graph?format=mermaid&dep_type=caused-by", nil)
+	requireStatus(t, rec, http.StatusOK)
+	if got := rec.Body.String(); !strings.Contains(got, `n2 -.->|"caused by"| n0`) || strings.Contains(got, "blocked by") {
+		t.Errorf("Mermaid =\n%s\nwant only the caused-by edge", got)
+	}
+}
+
+func TestHandleExportGraph_RootAndDepth(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedGraph(ms)
+
+	nodes := func(query string) []string {
+		t.Helper()
+		rec := doJSON(t, h, "GET", "/v1/export/graph?"+query, nil)
+		requireStatus(t, rec, http.StatusOK)
+		var doc jsonGraph
+		decodeJSON(t, rec, &doc)
+		var ids []string
+		for id := range doc.Graph.Nodes {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	if got := strings.Join(nodes("root=bd-a&depth=1"), ","); got != "bd-a,bd-b" {
+		t.Errorf("depth 1 = %s, want bd-a,bd-b", got)
+	}
+	if got := strings.Join(nodes("root=bd-a"), ","); got != "bd-a,bd-b,bd-c" {
+		t.Errorf("unlimited = %s", got)
+	}
+	if got := strings.Join(nodes("root=bd-a&dep_type=blocks"), ","); got != "bd-a,bd-b" {
+		t.Errorf("blocks only = %s", got)
+	}
+	if got := strings.Join(nodes("root=bd-b&status=open"), ","); got != "bd-b" {
+		t.Errorf("open from bd-b = %s", got)
+	}
+
+	rec := doJSON(t, h, "GET", "/v1/export/graph?root=bd-c&status=open", nil)
+	requireStatus(t, rec, http.StatusNotFound)
+}
+
+func TestHandleImportGraph(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedGraph(ms)
+
+	rec := doJSON(t, h, "POST", "/v1/import/graph", map[string]any{
+		"graph": map[string]any{"edges": []map[string]string{
+			{"source": "bd-c", "target": "bd-a", "relation": "related"},
+		}},
+		"edges":      []map[string]string{{"source": "bd-c", "target": "bd-b"}},
+		"created_by": "alice",
+	})
+	requireStatus(t, rec, http.StatusCreated)
+
+	got := map[model.DependencyType]*model.Dependency{}
+	for _, d := range ms.deps["bd-c"] {
+		got[d.Type] = d
+	}
+	if len(got) != 2 || got[model.DepRelated].DependsOnID != "bd-a" || got[model.DepBlocks].DependsOnID != "bd-b" || got[model.DepBlocks].CreatedBy != "alice" {
+		t.Errorf("bd-c deps = %+v", ms.deps["bd-c"])
+	}
+	requireEvent(t, ms, 2, "beads.dependency.added")
+}
+
+func TestHandleImportGraph_Metadata(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedGraph(ms)
+
+	rec := doJSON(t, h, "POST", "/v1/import/graph", map[string]any{
+		"edges": []map[string]any{{
+			"source": "bd-c", "target": "bd-a",
+			"metadata": map[string]any{"blocking": true, "weight": 2},
+		}},
+	})
+	requireStatus(t, rec, http.StatusCreated)
+	// blocking is derived from the type on export and is not stored.
+	if d := ms.deps["bd-c"]; len(d) != 1 || string(d[0].Metadata) != `{"weight":2}` {
+		t.Errorf("bd-c deps = %+v", d)
+	}
+}
+
+func TestHandleImportGraph_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		body any
+	}{
+		{"NoEdges", map[string]any{}},
+		{"MissingTarget", map[string]any{"edges": []map[string]string{{"source": "bd-a"}}}},
+		{"UnknownBead", map[string]any{"edges": []map[string]string{{"source": "bd-a", "target": "bd-zzz"}}}},
+		{"SelfEdge", map[string]any{"edges": []map[string]string{{"source": "bd-a", "target": "bd-a", "type": "related"}}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ms, h := newTestServer()
+			seedGraph(ms)
+			rec := doJSON(t, h, "POST", "/v1/import/graph", tc.body)
+			requireStatus(t, rec, http.StatusBadRequest)
+			if len(ms.events) != 0 {
+				t.Errorf("expected no events, got %d", len(ms.events))
+			}
+		})
+	}
+}
+
+func TestHandleImportGraph_Cycle(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedGraph(ms)
+
+	// Each edge is acyclic on its own; the second closes a loop through the
+	// first, which only exists inside the import's transaction.
+	rec := doJSON(t, h, "POST", "/v1/import/graph", map[string]any{
+		"edges": []map[string]string{
+			{"source": "bd-c", "target": "bd-a"},
+			{"source": "bd-b", "target": "bd-c"},
+		},
+	})
+	requireStatus(t, rec, http.StatusConflict)
+	var body errorBody
+	decodeJSON(t, rec, &body)
+	if body.Code != CodeDependencyCycle {
+		t.Fatalf("code = %q, want %q", body.Code, CodeDependencyCycle)
+	}
+}
@@ -3,20 +3,28 @@ package server
 import (
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 // NewGRPCServer creates a gRPC server with standard interceptors,
-// registers the BeadsService, reflection, and returns the server ready to serve.
-func NewGRPCServer(beadsServer *BeadsServer) *grpc.Server {
-	srv := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			RecoveryInterceptor,
-			LoggingInterceptor,
-		),
-	)
+// registers the BeadsService, the standard health service, reflection,
+// and returns the server ready to serve. Extra options (e.g. TLS
+// credentials) are passed through to grpc.NewServer.
+func NewGRPCServer(beadsServer *BeadsServer, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts, tracingServerOption(), grpc.ChainUnaryInterceptor(
+		RecoveryInterceptor,
+		ErrorCodeInterceptor,
+		IdentityInterceptor,
+		beadsServer.TokenInterceptor,
+		beadsServer.VersionInterceptor,
+		beadsServer.BeadRefInterceptor,
+		LoggingInterceptor,
+	))
+	srv := grpc.NewServer(opts...)
 
 	beadsv1.RegisterBeadsServiceServer(srv, beadsServer)
+	healthpb.RegisterHealthServer(srv, beadsServer.health)
 	reflection.Register(srv)
 
 	return srv
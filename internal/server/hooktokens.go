@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// hookTokenPrefix marks hook tokens. A hook token only lets an agent's hook
+// integration emit hooks for that agent; it is no identity for anything
+// else.
+const hookTokenPrefix = "bdh_"
+
+// HookTokenHeader carries a hook token on POST /v1/hooks/emit; gRPC calls
+// use the lowercase metadata key.
+const (
+	HookTokenHeader   = "X-Beads-Hook-Token"
+	hookTokenMetadata = "x-beads-hook-token"
+)
+
+// hookTokenGrant is the response to issuing a hook token. The token is only
+// shown once.
+type hookTokenGrant struct {
+	Agent   string `json:"agent"`
+	BeadID  string `json:"bead_id"`
+	Token   string `json:"token"`
+	Exports string `json:"exports"`
+}
+
+// authorizeHookToken lets the agent itself, an OIDC admin, or the holder of
+// the admin token manage the agent's hook token.
+func (s *BeadsServer) authorizeHookToken(ctx context.Context, token, agent string) error {
+	if identityFrom(ctx) == agent || s.isOIDCAdmin(ctx) {
+		return nil
+	}
+	if s.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) == 1 {
+		return nil
+	}
+	if identityFrom(ctx) == "" && token == "" {
+		return authError("the agent's token or the admin token is required")
+	}
+	return forbiddenError("only " + agent + " or an admin may manage its hook token")
+}
+
+// issueHookToken gives a registered agent a new hook token, replacing any
+// earlier one. From then on hooks for the agent are only accepted with it.
+func (s *BeadsServer) issueHookToken(ctx context.Context, token, agent string) (*hookTokenGrant, error) {
+	if err := s.authorizeHookToken(ctx, token, agent); err != nil {
+		return nil, err
+	}
+	reg, err := s.store.GetAgent(ctx, agent)
+	if err != nil {
+		return nil, err
+	}
+	secret, hash, err := newToken(hookTokenPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.SetAgentHookToken(ctx, agent, hash); err != nil {
+		return nil, err
+	}
+	return &hookTokenGrant{
+		Agent:   reg.Name,
+		BeadID:  reg.BeadID,
+		Token:   secret,
+		Exports: fmt.Sprintf("export BEADS_HOOK_TOKEN='%s'\n", secret),
+	}, nil
+}
+
+// revokeHookToken removes an agent's hook token, so its hooks are accepted
+// without one again.
+func (s *BeadsServer) revokeHookToken(ctx context.Context, token, agent string) error {
+	if err := s.authorizeHookToken(ctx, token, agent); err != nil {
+		return err
+	}
+	return s.store.SetAgentHookToken(ctx, agent, "")
+}
+
+// hookAgent returns the agent a hook is emitted for. With a hook token it is
+// the token's agent, and a claimed agent must be the same. Without one it is
+// the caller's identity or the claimed agent, which must not have a hook
+// token: once issued, only the agent's own hook integration can emit for it.
+func (s *BeadsServer) hookAgent(ctx context.Context, claimed, hookToken string) (string, error) {
+	if hookToken != "" {
+		if !strings.HasPrefix(hookToken, hookTokenPrefix) {
+			return "", authError("invalid hook token")
+		}
+		reg, err := s.store.GetAgentByHookTokenHash(ctx, hashToken(hookToken))
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", authError("invalid hook token")
+		}
+		if err != nil {
+			return "", err
+		}
+		if claimed != "" && claimed != reg.Name {
+			return "", forbiddenError("the hook token is for agent " + reg.Name)
+		}
+		return reg.Name, nil
+	}
+
+	agent := s.actorFor(ctx, claimed)
+	reg, err := s.store.GetAgent(ctx, agent)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+	if err == nil && reg.HookTokenHash != "" {
+		return "", authError("agent " + agent + " only accepts hooks with its hook token")
+	}
+	return agent, nil
+}
+
+// grpcHookToken returns the hook token from incoming gRPC metadata.
+func grpcHookToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(hookTokenMetadata); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// writeHookTokenError maps hook token errors to HTTP responses.
+func writeHookTokenError(w http.ResponseWriter, err error) {
+	var (
+		ae authError
+		fe forbiddenError
+	)
+	switch {
+	case errors.As(err, &ae):
+		writeError(w, http.StatusUnauthorized, ae.Error())
+	case errors.As(err, &fe):
+		writeError(w, http.StatusForbidden, fe.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "agent not found")
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// handleIssueHookToken handles POST /v1/agents/{id}/hook-token.
+func (s *BeadsServer) handleIssueHookToken(w http.ResponseWriter, r *http.Request) {
+	grant, err := s.issueHookToken(r.Context(), bearerToken(r.Header.Get("Authorization")), r.PathValue("id"))
+	if err != nil {
+		writeHookTokenError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, grant)
+}
+
+// handleRevokeHookToken handles DELETE /v1/agents/{id}/hook-token.
+func (s *BeadsServer) handleRevokeHookToken(w http.ResponseWriter, r *http.Request) {
+	if err := s.revokeHookToken(r.Context(), bearerToken(r.Header.Get("Authorization")), r.PathValue("id")); err != nil {
+		writeHookTokenError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// doWithHeaders is doJSON with extra request headers.
+func doWithHeaders(t *testing.T, h http.Handler, method, path string, body any, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHookTokens(t *testing.T) {
+	s, ms, h := newGatedAgent(t)
+	other, err := s.registerAgent(context.Background(), "admin-secret", registerAgentInput{Name: "crew/other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const path = "/v1/agents/crew%2Ftest-agent/hook-token"
+	stop := map[string]any{"hook": "stop"}
+
+	// Only the agent itself or an admin may issue its hook token.
+	requireStatus(t, doJSON(t, h, "POST", path, nil), http.StatusUnauthorized)
+	requireStatus(t, doWithHeaders(t, h, "POST", path, nil, map[string]string{"Authorization": "Bearer " + other.Token}), http.StatusForbidden)
+	requireStatus(t, doWithHeaders(t, h, "POST", "/v1/agents/crew%2Fnobody/hook-token", nil, map[string]string{"Authorization": "Bearer admin-secret"}), http.StatusNotFound)
+
+	rec := doWithHeaders(t, h, "POST", path, nil, map[string]string{"Authorization": "Bearer admin-secret"})
+	requireStatus(t, rec, http.StatusCreated)
+	var grant hookTokenGrant
+	decodeJSON(t, rec, &grant)
+	agent := ms.agents["crew/test-agent"]
+	if grant.Agent != "crew/test-agent" || grant.BeadID != agent.BeadID || len(grant.Token) != len(hookTokenPrefix)+64 ||
+		agent.HookTokenHash != hashToken(grant.Token) {
+		t.Fatalf("grant = %+v", grant)
+	}
+
+	// The token names the agent; nobody else can emit for it.
+	rec = doWithHeaders(t, h, "POST", "/v1/hooks/emit", stop, map[string]string{HookTokenHeader: grant.Token})
+	requireStatus(t, rec, http.StatusOK)
+	var res hookResult
+	decodeJSON(t, rec, &res)
+	if res.Agent != "crew/test-agent" || res.Decision != hookBlock {
+		t.Fatalf("emit = %+v", res)
+	}
+	requireStatus(t, doJSON(t, h, "POST", "/v1/hooks/emit", map[string]any{"agent": "crew/test-agent", "hook": "stop"}), http.StatusUnauthorized)
+	requireStatus(t, doWithHeaders(t, h, "POST", "/v1/hooks/emit", stop, map[string]string{"Authorization": "Bearer " + other.Token}), http.StatusOK)
+	requireStatus(t, doWithHeaders(t, h, "POST", "/v1/hooks/emit", map[string]any{"agent": "crew/other", "hook": "stop"},
+		map[string]string{HookTokenHeader: grant.Token}), http.StatusForbidden)
+	requireStatus(t, doWithHeaders(t, h, "POST", "/v1/hooks/emit", stop, map[string]string{HookTokenHeader: "bdh_forged"}), http.StatusUnauthorized)
+
+	// The agent can rotate its own token; the old one stops working.
+	rec = doWithHeaders(t, h, "POST", path, nil, map[string]string{"Authorization": "Bearer " + agentToken(t, s, "crew/test-agent")})
+	requireStatus(t, rec, http.StatusCreated)
+	requireStatus(t, doWithHeaders(t, h, "POST", "/v1/hooks/emit", stop, map[string]string{HookTokenHeader: grant.Token}), http.StatusUnauthorized)
+
+	requireStatus(t, doWithHeaders(t, h, "DELETE", path, nil, map[string]string{"Authorization": "Bearer admin-secret"}), http.StatusNoContent)
+	if agent.HookTokenHash != "" {
+		t.Fatal("hook token was not revoked")
+	}
+	requireStatus(t, doJSON(t, h, "POST", "/v1/hooks/emit", map[string]any{"agent": "crew/test-agent", "hook": "stop"}), http.StatusOK)
+}
+
+// agentToken gives a registered agent a fresh bearer token and returns it.
+func agentToken(t *testing.T, s *BeadsServer, name string) string {
+	t.Helper()
+	token, hash, err := newAgentToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.store.(*mockStore).agents[name].TokenHash = hash
+	return token
+}
@@ -1,16 +1,23 @@
 package server
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/alerts"
 	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/query"
+	"github.com/alfredjeanlab/beads/internal/slack"
+	"github.com/alfredjeanlab/beads/internal/store"
 )
 
 // NewHTTPHandler returns an http.Handler with all routes registered.
@@ -18,25 +25,124 @@ func (s *BeadsServer) NewHTTPHandler() http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("POST /v1/beads", s.handleCreateBead)
 	mux.HandleFunc("GET /v1/beads", s.handleListBeads)
-	mux.HandleFunc("GET /v1/beads/{id}", s.handleGetBead)
-	mux.HandleFunc("PATCH /v1/beads/{id}", s.handleUpdateBead)
-	mux.HandleFunc("POST /v1/beads/{id}/close", s.handleCloseBead)
-	mux.HandleFunc("DELETE /v1/beads/{id}", s.handleDeleteBead)
-	mux.HandleFunc("GET /v1/beads/{id}/dependencies", s.handleGetDependencies)
-	mux.HandleFunc("POST /v1/beads/{id}/dependencies", s.handleAddDependency)
-	mux.HandleFunc("DELETE /v1/beads/{id}/dependencies", s.handleRemoveDependency)
-	mux.HandleFunc("GET /v1/beads/{id}/labels", s.handleGetLabels)
-	mux.HandleFunc("POST /v1/beads/{id}/labels", s.handleAddLabel)
-	mux.HandleFunc("DELETE /v1/beads/{id}/labels/{label}", s.handleRemoveLabel)
-	mux.HandleFunc("GET /v1/beads/{id}/comments", s.handleGetComments)
-	mux.HandleFunc("POST /v1/beads/{id}/comments", s.handleAddComment)
-	mux.HandleFunc("GET /v1/beads/{id}/events", s.handleGetEvents)
+	mux.HandleFunc("POST /v1/transactions", s.handleTransaction)
+	mux.HandleFunc("GET /v1/ready", s.handleGetReady)
+	mux.HandleFunc("GET /v1/blocked", s.handleGetBlocked)
+	mux.HandleFunc("POST /v1/queue/next", s.handlePopQueue)
+	mux.HandleFunc("POST /v1/archive/run", s.handleRunArchive)
+	mux.HandleFunc("POST /v1/admin/snapshot", s.handleSnapshot)
+	mux.HandleFunc("POST /v1/admin/restore", s.handleRestore)
+	mux.HandleFunc("GET /v1/admin/compaction", s.handleGetCompaction)
+	mux.HandleFunc("POST /v1/admin/compaction/run", s.handleRunCompaction)
+	mux.HandleFunc("GET /v1/events", s.handleListEvents)
+	mux.HandleFunc("GET /v1/events/stream", s.handleStreamEvents)
+	mux.HandleFunc("GET /v1/events/schemas", s.handleEventSchemas)
+	mux.HandleFunc("GET /v1/events/summaries", s.handleListEventSummaries)
+	mux.HandleFunc("GET /v1/beads/{id}", s.withBeadRef(s.handleGetBead))
+	mux.HandleFunc("PATCH /v1/beads/{id}", s.withBeadRef(s.handleUpdateBead))
+	mux.HandleFunc("PATCH /v1/beads/{id}/fields", s.withBeadRef(s.handlePatchFields))
+	mux.HandleFunc("POST /v1/beads/{id}/close", s.withBeadRef(s.handleCloseBead))
+	mux.HandleFunc("POST /v1/beads/{id}/resolve", s.withBeadRef(s.handleResolveDecision))
+	mux.HandleFunc("GET /v1/decisions/{id}/context", s.withBeadRef(s.handleGetDecisionContext))
+	mux.HandleFunc("DELETE /v1/beads/{id}", s.withBeadRef(s.handleDeleteBead))
+	mux.HandleFunc("POST /v1/beads/{id}/restore", s.withBeadRef(s.handleRestoreBead))
+	mux.HandleFunc("POST /v1/beads/{id}/merge", s.withBeadRef(s.handleMergeBead))
+	mux.HandleFunc("POST /v1/beads/{id}/clone", s.withBeadRef(s.handleCloneBead))
+	mux.HandleFunc("GET /v1/beads/{id}/similar", s.withBeadRef(s.handleSimilarBeads))
+	mux.HandleFunc("GET /v1/trash", s.handleListTrash)
+	mux.HandleFunc("POST /v1/jacks", s.handleRaiseJack)
+	mux.HandleFunc("POST /v1/jacks/{id}/extend", s.withBeadRef(s.handleExtendJack))
+	mux.HandleFunc("POST /v1/jacks/{id}/change", s.withBeadRef(s.handleChangeJack))
+	mux.HandleFunc("POST /v1/jacks/{id}/down", s.withBeadRef(s.handleDownJack))
+	mux.HandleFunc("GET /v1/beads/{id}/dependencies", s.withBeadRef(s.handleGetDependencies))
+	mux.HandleFunc("POST /v1/beads/{id}/dependencies", s.withBeadRef(s.handleAddDependency))
+	mux.HandleFunc("PATCH /v1/beads/{id}/dependencies", s.withBeadRef(s.handleUpdateDependency))
+	mux.HandleFunc("DELETE /v1/beads/{id}/dependencies", s.withBeadRef(s.handleRemoveDependency))
+	mux.HandleFunc("GET /v1/beads/{id}/relations", s.withBeadRef(s.handleListRelations))
+	mux.HandleFunc("POST /v1/beads/{id}/relations", s.withBeadRef(s.handleAddRelation))
+	mux.HandleFunc("GET /v1/beads/{id}/readiness", s.withBeadRef(s.handleGetReadiness))
+	mux.HandleFunc("GET /v1/beads/{id}/external", s.withBeadRef(s.handleListExternal))
+	mux.HandleFunc("POST /v1/beads/{id}/external", s.withBeadRef(s.handleAddExternal))
+	mux.HandleFunc("PATCH /v1/beads/{id}/external/{xid}", s.withBeadRef(s.handleUpdateExternal))
+	mux.HandleFunc("DELETE /v1/beads/{id}/external/{xid}", s.withBeadRef(s.handleRemoveExternal))
+	mux.HandleFunc("GET /v1/beads/{id}/commits", s.withBeadRef(s.handleListCommits))
+	mux.HandleFunc("POST /v1/beads/{id}/commits", s.withBeadRef(s.handleLinkCommit))
+	mux.HandleFunc("GET /v1/beads/{id}/checklist", s.withBeadRef(s.handleGetChecklist))
+	mux.HandleFunc("POST /v1/beads/{id}/checklist", s.withBeadRef(s.handleAddChecklist))
+	mux.HandleFunc("POST /v1/beads/{id}/checklist/{index}/check", s.withBeadRef(s.handleCheckChecklist))
+	mux.HandleFunc("GET /v1/labels", s.handleListLabels)
+	mux.HandleFunc("GET /v1/beads/{id}/labels", s.withBeadRef(s.handleGetLabels))
+	mux.HandleFunc("POST /v1/beads/{id}/labels", s.withBeadRef(s.handleAddLabel))
+	mux.HandleFunc("DELETE /v1/beads/{id}/labels/{label}", s.withBeadRef(s.handleRemoveLabel))
+	mux.HandleFunc("GET /v1/beads/{id}/aliases", s.withBeadRef(s.handleListAliases))
+	mux.HandleFunc("POST /v1/beads/{id}/aliases", s.withBeadRef(s.handleAddAlias))
+	mux.HandleFunc("DELETE /v1/beads/{id}/aliases/{alias}", s.withBeadRef(s.handleRemoveAlias))
+	mux.HandleFunc("GET /v1/beads/{id}/comments", s.withBeadRef(s.handleGetComments))
+	mux.HandleFunc("POST /v1/beads/{id}/comments", s.withBeadRef(s.handleAddComment))
+	mux.HandleFunc("POST /v1/beads/{id}/comments/{cid}/reactions", s.withBeadRef(s.handleAddReaction))
+	mux.HandleFunc("DELETE /v1/beads/{id}/comments/{cid}/reactions/{emoji}", s.withBeadRef(s.handleRemoveReaction))
+	mux.HandleFunc("POST /v1/beads/{id}/comments/{cid}/resolve", s.withBeadRef(s.handleResolveComment))
+	mux.HandleFunc("GET /v1/beads/{id}/notes", s.withBeadRef(s.handleGetNotes))
+	mux.HandleFunc("POST /v1/beads/{id}/notes", s.withBeadRef(s.handleAddNote))
+	mux.HandleFunc("GET /v1/beads/{id}/events", s.withBeadRef(s.handleGetEvents))
+	mux.HandleFunc("GET /v1/beads/{id}/activity", s.withBeadRef(s.handleGetActivity))
+	mux.HandleFunc("GET /v1/beads/{id}/watchers", s.withBeadRef(s.handleGetWatchers))
+	mux.HandleFunc("POST /v1/beads/{id}/watchers", s.withBeadRef(s.handleWatchBead))
+	mux.HandleFunc("DELETE /v1/beads/{id}/watchers", s.withBeadRef(s.handleUnwatchBead))
+	mux.HandleFunc("GET /v1/beads/{id}/lock", s.withBeadRef(s.handleGetBeadLock))
+	mux.HandleFunc("POST /v1/beads/{id}/lock", s.withBeadRef(s.handleLockBead))
+	mux.HandleFunc("DELETE /v1/beads/{id}/lock", s.withBeadRef(s.handleUnlockBead))
+	mux.HandleFunc("GET /v1/notifications", s.handleListNotifications)
+	mux.HandleFunc("POST /v1/notifications/read", s.handleMarkNotificationsRead)
+	mux.HandleFunc("GET /v1/digests/{name}", s.handleGetDigest)
+	mux.HandleFunc("GET /v1/mirrors/{remote}/beads", s.handleListMirroredBeads)
+	mux.HandleFunc("GET /v1/mirrors/{remote}/beads/{id}", s.handleGetMirroredBead)
 	mux.HandleFunc("PUT /v1/configs/{key...}", s.handleSetConfig)
 	mux.HandleFunc("GET /v1/configs/{key...}", s.handleGetConfig)
 	mux.HandleFunc("GET /v1/configs", s.handleListConfigs)
 	mux.HandleFunc("DELETE /v1/configs/{key...}", s.handleDeleteConfig)
+	mux.HandleFunc("GET /v1/configs/{key}/history", s.handleGetConfigHistory)
+	mux.HandleFunc("POST /v1/configs/{key}/rollback", s.handleRollbackConfig)
+	mux.HandleFunc("GET /v1/views/counts", s.handleViewCounts)
+	mux.HandleFunc("GET /v1/export", s.handleExport)
+	mux.HandleFunc("GET /v1/export/graph", s.handleExportGraph)
+	mux.HandleFunc("POST /v1/import/graph", s.handleImportGraph)
+	mux.HandleFunc("POST /v1/integrations/slack/interactions", s.handleSlackInteraction)
+	mux.HandleFunc("GET /v1/alerts", s.handleListAlerts)
 	mux.HandleFunc("GET /v1/health", s.handleHealth)
-	return mux
+	mux.HandleFunc("GET /v1/info", s.handleGetInfo)
+	mux.HandleFunc("GET /v1/version", s.handleGetVersion)
+	mux.HandleFunc("GET /v1/openapi.json", s.handleOpenAPI)
+	mux.HandleFunc("GET /v1/agents", s.handleListAgents)
+	mux.HandleFunc("POST /v1/agents/register", s.handleRegisterAgent)
+	mux.HandleFunc("GET /v1/agents/{id}/forensics", s.handleAgentForensics)
+	mux.HandleFunc("POST /v1/agents/{id}/hook-token", s.handleIssueHookToken)
+	mux.HandleFunc("DELETE /v1/agents/{id}/hook-token", s.handleRevokeHookToken)
+	mux.HandleFunc("POST /v1/agents/{id}/gates/{gate}/waive", s.handleWaiveGate)
+	mux.HandleFunc("GET /v1/actors", s.handleListActors)
+	mux.HandleFunc("POST /v1/actors", s.handleCreateActor)
+	mux.HandleFunc("GET /v1/actors/{id}", s.handleGetActor)
+	mux.HandleFunc("PATCH /v1/actors/{id}", s.handleUpdateActor)
+	mux.HandleFunc("DELETE /v1/actors/{id}", s.handleDeleteActor)
+	mux.HandleFunc("POST /v1/actors/{id}/aliases", s.handleAddActorAlias)
+	mux.HandleFunc("DELETE /v1/actors/{id}/aliases/{alias}", s.handleRemoveActorAlias)
+	mux.HandleFunc("GET /v1/prefs", s.handleListPrefs)
+	mux.HandleFunc("PUT /v1/prefs/{name}", s.handleSetPref)
+	mux.HandleFunc("DELETE /v1/prefs/{name}", s.handleDeletePref)
+	mux.HandleFunc("GET /v1/reports/daily", s.handleDailyReport)
+	mux.HandleFunc("GET /v1/reports/starvation", s.handleStarvation)
+	mux.HandleFunc("GET /v1/reports/velocity", s.handleVelocity)
+	mux.HandleFunc("GET /v1/aggregate", s.handleAggregate)
+	mux.HandleFunc("GET /v1/rollup", s.handleRollup)
+	mux.HandleFunc("GET /v1/gates", s.handleListGates)
+	mux.HandleFunc("PUT /v1/gates/{gate}", s.handleSetGate)
+	mux.HandleFunc("DELETE /v1/gates/{gate}", s.handleClearGate)
+	mux.HandleFunc("POST /v1/hooks/emit", s.handleEmitHook)
+	mux.HandleFunc("GET /v1/advice", s.handleListAdvice)
+	mux.HandleFunc("POST /v1/advice/{id}/ack", s.withBeadRef(s.handleAckAdvice))
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	return tracingMiddleware(identityMiddleware(s.tokenMiddleware(s.versionMiddleware(
+		compressMiddleware(etagMiddleware(routeNames(mux)))))))
 }
 
 // handleCreateBead handles POST /v1/beads.
@@ -61,13 +167,47 @@ func (s *BeadsServer) handleCreateBead(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, bead)
 }
 
-// handleListBeads handles GET /v1/beads.
+// handleListBeads handles GET /v1/beads. With ?format=jsonl the beads are
+// streamed one per line, without a total.
 func (s *BeadsServer) handleListBeads(w http.ResponseWriter, r *http.Request) {
-	q := r.URL.Query()
+	filter, err := parseBeadFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if r.URL.Query().Get("format") == "jsonl" {
+		s.streamBeadsJSONL(w, r, filter)
+		return
+	}
+
+	beads, total, err := s.store.ListBeads(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list beads")
+		return
+	}
+
+	// Ensure beads is never null in JSON output.
+	if beads == nil {
+		beads = []*model.Bead{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"beads": beads,
+		"total": total,
+	})
+}
+
+// parseBeadFilter builds a bead filter from list query parameters.
+// Multi-valued parameters are comma-separated; malformed numbers are ignored.
+// Times take a date, an RFC 3339 time or a time relative to now such as
+// -7d. It fails on a malformed time or query.
+func parseBeadFilter(q url.Values) (model.BeadFilter, error) {
 	filter := model.BeadFilter{
-		Assignee: q.Get("assignee"),
-		Search:   q.Get("search"),
-		Sort:     q.Get("sort"),
+		Assignee:        q.Get("assignee"),
+		Search:          q.Get("search"),
+		Sort:            q.Get("sort"),
+		Query:           q.Get("q"),
+		IncludeArchived: q.Get("include_archived") == "true",
 	}
 
 	if v := q.Get("status"); v != "" {
@@ -103,22 +243,33 @@ func (s *BeadsServer) handleListBeads(w http.ResponseWriter, r *http.Request) {
 			filter.Offset = n
 		}
 	}
-
-	beads, total, err := s.store.ListBeads(r.Context(), filter)
-	if err != nil {
-		writeError(w, http.StatusInternalServerError, "failed to list beads")
-		return
+	for param, bound := range map[string]**time.Time{
+		"created_after":  &filter.CreatedAfter,
+		"created_before": &filter.CreatedBefore,
+		"updated_after":  &filter.UpdatedAfter,
+		"updated_before": &filter.UpdatedBefore,
+		"closed_after":   &filter.ClosedAfter,
+		"closed_before":  &filter.ClosedBefore,
+	} {
+		if v := q.Get(param); v != "" {
+			t, err := query.ParseTime(v)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s: %w", param, err)
+			}
+			*bound = &t
+		}
 	}
 
-	// Ensure beads is never null in JSON output.
-	if beads == nil {
-		beads = []*model.Bead{}
-	}
+	return filter, checkQuery(filter)
+}
 
-	writeJSON(w, http.StatusOK, map[string]any{
-		"beads": beads,
-		"total": total,
-	})
+// checkQuery reports a filter whose query language expression does not
+// parse, so it can be rejected as bad input instead of failing in the store.
+func checkQuery(filter model.BeadFilter) error {
+	if _, err := query.Parse(filter.Query); err != nil {
+		return fmt.Errorf("invalid q: %w", err)
+	}
+	return nil
 }
 
 // handleGetBead handles GET /v1/beads/{id}.
@@ -146,7 +297,10 @@ func (s *BeadsServer) handleGetBead(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, bead)
 }
 
-// handleDeleteBead handles DELETE /v1/beads/{id}.
+// handleDeleteBead handles DELETE /v1/beads/{id}?cascade=detach|delete&hard=true.
+// Beads are moved to the trash unless hard is set. It returns 204 for a plain
+// delete and 200 with the affected beads and dependencies when a cascade
+// took effect.
 func (s *BeadsServer) handleDeleteBead(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
@@ -154,7 +308,29 @@ func (s *BeadsServer) handleDeleteBead(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.store.DeleteBead(r.Context(), id); err != nil {
+	q := r.URL.Query()
+	hard, _ := strconv.ParseBool(q.Get("hard"))
+	res, err := s.deleteBead(r.Context(), id, deleteOptions{
+		Cascade: q.Get("cascade"),
+		Hard:    hard,
+		Actor:   q.Get("deleted_by"),
+	})
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			writeError(w, http.StatusBadRequest, ie.Error())
+			return
+		}
+		var de *dependentsError
+		if errors.As(err, &de) {
+			writeJSON(w, http.StatusConflict, map[string]any{
+				"error":      de.Error(),
+				"code":       CodeConflict,
+				"details":    map[string]any{"dependents": de.Dependents},
+				"dependents": de.Dependents,
+			})
+			return
+		}
 		if errors.Is(err, sql.ErrNoRows) {
 			writeError(w, http.StatusNotFound, "bead not found")
 			return
@@ -162,8 +338,13 @@ func (s *BeadsServer) handleDeleteBead(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, "failed to delete bead")
 		return
 	}
-
-	s.recordAndPublish(r.Context(), events.TopicBeadDeleted, id, "", events.BeadDeleted{BeadID: id})
+	if len(res.DeletedIDs) > 1 || len(res.Detached) > 0 {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"deleted_ids": res.DeletedIDs,
+			"detached":    res.Detached,
+		})
+		return
+	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -191,6 +372,7 @@ func (s *BeadsServer) handleUpdateBead(w http.ResponseWriter, r *http.Request) {
 	if in.Labels != nil {
 		in.labelsSet = true
 	}
+	in.Append = r.URL.Query().Get("append") == "true"
 
 	bead, err := s.updateBead(r.Context(), id, in)
 	if err != nil {
@@ -199,10 +381,22 @@ func (s *BeadsServer) handleUpdateBead(w http.ResponseWriter, r *http.Request) {
 			writeError(w, http.StatusBadRequest, ie.Error())
 			return
 		}
+		var te *transitionError
+		if errors.As(err, &te) {
+			writeError(w, http.StatusUnprocessableEntity, te.Error())
+			return
+		}
 		if errors.Is(err, sql.ErrNoRows) {
 			writeError(w, http.StatusNotFound, "bead not found")
 			return
 		}
+		if errors.Is(err, store.ErrConflict) {
+			writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if writeLockedError(w, err) {
+			return
+		}
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -215,19 +409,30 @@ type closeBeadRequest struct {
 	ClosedBy string `json:"closed_by"`
 }
 
-// handleCloseBead handles POST /v1/beads/{id}/close.
+// handleCloseBead handles POST /v1/beads/{id}/close?cascade=true. The
+// response is the closed bead with the dependents it unblocked, the children
+// closed with it and any ordering warnings.
 func (s *BeadsServer) handleCloseBead(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
 		writeError(w, http.StatusBadRequest, "id is required")
 		return
 	}
+	cascade := false
+	if v := r.URL.Query().Get("cascade"); v != "" {
+		var err error
+		if cascade, err = strconv.ParseBool(v); err != nil {
+			writeError(w, http.StatusBadRequest, "cascade must be true or false")
+			return
+		}
+	}
 
 	var req closeBeadRequest
 	// Body is optional; ignore decode errors for empty body.
 	_ = json.NewDecoder(r.Body).Decode(&req)
 
-	bead, err := s.store.CloseBead(r.Context(), id, req.ClosedBy)
+	req.ClosedBy = s.actorFor(r.Context(), req.ClosedBy)
+	res, err := s.closeWithDependents(r.Context(), id, req.ClosedBy, cascade)
 	if errors.Is(err, sql.ErrNoRows) {
 		writeError(w, http.StatusNotFound, "bead not found")
 		return
@@ -236,15 +441,56 @@ func (s *BeadsServer) handleCloseBead(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, "failed to close bead")
 		return
 	}
-	if bead == nil {
-		writeError(w, http.StatusNotFound, "bead not found")
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// resolveDecisionRequest is the JSON body for POST /v1/beads/{id}/resolve.
+type resolveDecisionRequest struct {
+	Option     string `json:"option"`
+	ResolvedBy string `json:"resolved_by"`
+}
+
+// handleResolveDecision handles POST /v1/beads/{id}/resolve.
+func (s *BeadsServer) handleResolveDecision(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
 		return
 	}
 
-	s.recordAndPublish(r.Context(), events.TopicBeadClosed, bead.ID, req.ClosedBy, events.BeadClosed{
-		Bead:     bead,
-		ClosedBy: req.ClosedBy,
-	})
+	var req resolveDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if req.Option == "" {
+		writeError(w, http.StatusBadRequest, "option is required")
+		return
+	}
+
+	bead, err := s.resolveDecision(r.Context(), id, req.Option, s.actorFor(r.Context(), req.ResolvedBy))
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			writeError(w, http.StatusBadRequest, ie.Error())
+			return
+		}
+		var fe forbiddenError
+		if errors.As(err, &fe) {
+			writeError(w, http.StatusForbidden, fe.Error())
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "bead not found")
+			return
+		}
+		if writeLockedError(w, err) {
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 
 	writeJSON(w, http.StatusOK, bead)
 }
@@ -272,9 +518,10 @@ func (s *BeadsServer) handleGetDependencies(w http.ResponseWriter, r *http.Reque
 
 // addDependencyRequest is the JSON body for POST /v1/beads/{id}/dependencies.
 type addDependencyRequest struct {
-	DependsOnID string `json:"depends_on_id"`
-	Type        string `json:"type"`
-	CreatedBy   string `json:"created_by"`
+	DependsOnID string          `json:"depends_on_id"`
+	Type        string          `json:"type"`
+	CreatedBy   string          `json:"created_by"`
+	Metadata    json.RawMessage `json:"metadata"`
 }
 
 // handleAddDependency handles POST /v1/beads/{id}/dependencies.
@@ -294,23 +541,31 @@ func (s *BeadsServer) handleAddDependency(w http.ResponseWriter, r *http.Request
 		writeError(w, http.StatusBadRequest, "depends_on_id is required")
 		return
 	}
+	if string(req.Metadata) == "null" {
+		req.Metadata = nil
+	}
+	if err := model.ValidateDependencyMetadata(req.Metadata); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	now := time.Now().UTC()
 	dep := &model.Dependency{
 		BeadID:      beadID,
-		DependsOnID: req.DependsOnID,
+		DependsOnID: s.resolveBeadID(r.Context(), req.DependsOnID),
 		Type:        model.DependencyType(req.Type),
 		CreatedAt:   now,
-		CreatedBy:   req.CreatedBy,
+		CreatedBy:   s.actorFor(r.Context(), req.CreatedBy),
+		Metadata:    req.Metadata,
 	}
 
-	if err := s.store.AddDependency(r.Context(), dep); err != nil {
-		writeError(w, http.StatusInternalServerError, "failed to add dependency")
+	if err := s.addDependency(r.Context(), dep); err != nil {
+		if !writeCycleError(w, err) {
+			writeError(w, http.StatusInternalServerError, "failed to add dependency")
+		}
 		return
 	}
 
-	s.recordAndPublish(r.Context(), events.TopicDependencyAdded, dep.BeadID, dep.CreatedBy, events.DependencyAdded{Dependency: dep})
-
 	writeJSON(w, http.StatusCreated, dep)
 }
 
@@ -331,17 +586,11 @@ func (s *BeadsServer) handleRemoveDependency(w http.ResponseWriter, r *http.Requ
 	}
 	depType := q.Get("type")
 
-	if err := s.store.RemoveDependency(r.Context(), beadID, dependsOnID, model.DependencyType(depType)); err != nil {
+	if err := s.removeDependency(r.Context(), beadID, dependsOnID, model.DependencyType(depType)); err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to remove dependency")
 		return
 	}
 
-	s.recordAndPublish(r.Context(), events.TopicDependencyRemoved, beadID, "", events.DependencyRemoved{
-		BeadID:      beadID,
-		DependsOnID: dependsOnID,
-		Type:        depType,
-	})
-
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -388,17 +637,21 @@ func (s *BeadsServer) handleAddLabel(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "label is required")
 		return
 	}
+	if err := s.checkLabels(r.Context(), []string{req.Label}); err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			writeError(w, http.StatusBadRequest, ie.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to check label")
+		return
+	}
 
-	if err := s.store.AddLabel(r.Context(), beadID, req.Label); err != nil {
+	if err := s.addLabel(r.Context(), beadID, req.Label); err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to add label")
 		return
 	}
 
-	s.recordAndPublish(r.Context(), events.TopicLabelAdded, beadID, "", events.LabelAdded{
-		BeadID: beadID,
-		Label:  req.Label,
-	})
-
 	// Fetch the updated bead to return.
 	bead, err := s.store.GetBead(r.Context(), beadID)
 	if err != nil {
@@ -426,16 +679,11 @@ func (s *BeadsServer) handleRemoveLabel(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := s.store.RemoveLabel(r.Context(), beadID, label); err != nil {
+	if err := s.removeLabel(r.Context(), beadID, label); err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to remove label")
 		return
 	}
 
-	s.recordAndPublish(r.Context(), events.TopicLabelRemoved, beadID, "", events.LabelRemoved{
-		BeadID: beadID,
-		Label:  label,
-	})
-
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -487,18 +735,19 @@ func (s *BeadsServer) handleAddComment(w http.ResponseWriter, r *http.Request) {
 	now := time.Now().UTC()
 	comment := &model.Comment{
 		BeadID:    beadID,
-		Author:    req.Author,
 		Text:      req.Text,
 		CreatedAt: now,
 	}
+	if err := s.attributeComment(r.Context(), comment, req.Author); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
 
-	if err := s.store.AddComment(r.Context(), comment); err != nil {
+	if err := s.addComment(r.Context(), comment); err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to add comment")
 		return
 	}
 
-	s.recordAndPublish(r.Context(), events.TopicCommentAdded, comment.BeadID, comment.Author, events.CommentAdded{Comment: comment})
-
 	writeJSON(w, http.StatusCreated, comment)
 }
 
@@ -525,7 +774,8 @@ func (s *BeadsServer) handleGetEvents(w http.ResponseWriter, r *http.Request) {
 
 // setConfigRequest is the JSON body for PUT /v1/configs/{key}.
 type setConfigRequest struct {
-	Value json.RawMessage `json:"value"`
+	Value     json.RawMessage `json:"value"`
+	UpdatedBy string          `json:"updated_by,omitempty"`
 }
 
 // handleSetConfig handles PUT /v1/configs/{key}.
@@ -536,23 +786,30 @@ func (s *BeadsServer) handleSetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	admin, err := s.checkConfigAccess(r.Context(), bearerToken(r.Header.Get("Authorization")), key)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
 	var req setConfigRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid JSON body")
 		return
 	}
 
-	config := &model.Config{
-		Key:   key,
-		Value: req.Value,
-	}
-
-	if err := s.store.SetConfig(r.Context(), config); err != nil {
+	config, err := s.setConfig(r.Context(), key, req.Value, req.UpdatedBy)
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			writeError(w, http.StatusBadRequest, ie.Error())
+			return
+		}
 		writeError(w, http.StatusInternalServerError, "failed to set config")
 		return
 	}
 
-	writeJSON(w, http.StatusOK, config)
+	writeJSON(w, http.StatusOK, redactConfig(config, admin))
 }
 
 // handleGetConfig handles GET /v1/configs/{key}.
@@ -563,6 +820,12 @@ func (s *BeadsServer) handleGetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	admin, err := s.checkConfigAccess(r.Context(), bearerToken(r.Header.Get("Authorization")), key)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
 	config, err := s.store.GetConfig(r.Context(), key)
 	if errors.Is(err, sql.ErrNoRows) {
 		if builtin, ok := builtinConfigs[key]; ok {
@@ -577,7 +840,7 @@ func (s *BeadsServer) handleGetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, config)
+	writeJSON(w, http.StatusOK, redactConfig(config, admin))
 }
 
 // handleListConfigs handles GET /v1/configs?namespace=...
@@ -588,15 +851,19 @@ func (s *BeadsServer) handleListConfigs(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	admin, err := s.checkConfigAccess(r.Context(), bearerToken(r.Header.Get("Authorization")), namespace)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
 	configs, err := s.listConfigsWithBuiltins(r.Context(), namespace)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "failed to list configs")
 		return
 	}
 
-	if configs == nil {
-		configs = []*model.Config{}
-	}
+	configs = s.visibleConfigs(configs, admin)
 
 	writeJSON(w, http.StatusOK, map[string]any{"configs": configs})
 }
@@ -609,7 +876,12 @@ func (s *BeadsServer) handleDeleteConfig(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := s.store.DeleteConfig(r.Context(), key); err != nil {
+	if _, err := s.checkConfigAccess(r.Context(), bearerToken(r.Header.Get("Authorization")), key); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err := s.deleteConfig(r.Context(), key); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			writeError(w, http.StatusNotFound, "config not found")
 			return
@@ -621,6 +893,141 @@ func (s *BeadsServer) handleDeleteConfig(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleGetConfigHistory handles GET /v1/configs/{key}/history.
+func (s *BeadsServer) handleGetConfigHistory(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	admin, err := s.checkConfigAccess(r.Context(), bearerToken(r.Header.Get("Authorization")), key)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	revs, err := s.store.ListConfigRevisions(r.Context(), key)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list config history")
+		return
+	}
+
+	if revs == nil {
+		revs = []*model.ConfigRevision{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"revisions": redactRevisions(revs, admin)})
+}
+
+// handleRollbackConfig handles POST /v1/configs/{key}/rollback?rev=N.
+func (s *BeadsServer) handleRollbackConfig(w http.ResponseWriter, r *http.Request) {
+	rev, err := strconv.ParseInt(r.URL.Query().Get("rev"), 10, 64)
+	if err != nil || rev <= 0 {
+		writeError(w, http.StatusBadRequest, "rev query parameter must be a positive integer")
+		return
+	}
+
+	key := r.PathValue("key")
+	admin, err := s.checkConfigAccess(r.Context(), bearerToken(r.Header.Get("Authorization")), key)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	config, err := s.rollbackConfig(r.Context(), key, rev, r.URL.Query().Get("updated_by"))
+	if err != nil {
+		var ie inputError
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "config revision not found")
+		case errors.As(err, &ie):
+			writeError(w, http.StatusConflict, ie.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to roll back config")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, redactConfig(config, admin))
+}
+
+// handleSlackInteraction handles POST /v1/integrations/slack/interactions,
+// Slack's callback for button presses on decision messages.
+func (s *BeadsServer) handleSlackInteraction(w http.ResponseWriter, r *http.Request) {
+	cfg, err := slack.LoadConfig(r.Context(), s.store)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load slack config")
+		return
+	}
+	if cfg == nil {
+		writeError(w, http.StatusNotFound, "slack integration not configured")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read body")
+		return
+	}
+	if err := slack.VerifyRequest(cfg.SigningSecret, r.Header, body, time.Now()); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	in, err := slack.ParseInteraction(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err := s.resolveDecision(r.Context(), in.BeadID, in.Option, cfg.ActorFor(in.UserID)); err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			writeError(w, http.StatusBadRequest, ie.Error())
+			return
+		}
+		var fe forbiddenError
+		if errors.As(err, &fe) {
+			writeError(w, http.StatusForbidden, fe.Error())
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "bead not found")
+			return
+		}
+		if writeLockedError(w, err) {
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleListAlerts handles GET /v1/alerts.
+func (s *BeadsServer) handleListAlerts(w http.ResponseWriter, _ *http.Request) {
+	list := s.currentAlerts()
+	if list == nil {
+		list = []alerts.Alert{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"alerts": list})
+}
+
+// handleMetrics handles GET /metrics in the Prometheus text format.
+func (s *BeadsServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if s.metrics == nil && s.shadow == nil && s.cache == nil {
+		writeError(w, http.StatusNotFound, "metrics not enabled")
+		return
+	}
+	var buf bytes.Buffer
+	if s.metrics != nil {
+		if err := s.metrics.WriteText(r.Context(), &buf); err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to compute metrics")
+			return
+		}
+	}
+	s.shadow.WriteText(&buf)
+	s.cache.WriteText(&buf)
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
+
 // handleHealth handles GET /v1/health.
 func (s *BeadsServer) handleHealth(w http.ResponseWriter, _ *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
@@ -633,7 +1040,8 @@ func writeJSON(w http.ResponseWriter, status int, data any) {
 	_ = json.NewEncoder(w).Encode(data)
 }
 
-// writeError writes a JSON error response.
+// writeError writes a JSON error response, with the error code derived from
+// status and message.
 func writeError(w http.ResponseWriter, status int, message string) {
-	writeJSON(w, status, map[string]string{"error": message})
+	writeJSON(w, status, errorBody{Error: message, Code: httpErrorCode(status, message)})
 }
@@ -7,13 +7,14 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/alfredjeanlab/beads/internal/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // LoggingInterceptor logs the method name, duration, and error (if any) for every
-// unary RPC call.
+// unary RPC call, with the trace ID when the call is traced.
 func LoggingInterceptor(
 	ctx context.Context,
 	req any,
@@ -24,17 +25,14 @@ func LoggingInterceptor(
 	resp, err := handler(ctx, req)
 	duration := time.Since(start)
 
+	attrs := []any{"method", info.FullMethod, "duration", duration}
+	if id := tracing.TraceID(ctx); id != "" {
+		attrs = append(attrs, "trace_id", id)
+	}
 	if err != nil {
-		slog.Error("rpc completed",
-			"method", info.FullMethod,
-			"duration", duration,
-			"error", err,
-		)
+		slog.Error("rpc completed", append(attrs, "error", err)...)
 	} else {
-		slog.Info("rpc completed",
-			"method", info.FullMethod,
-			"duration", duration,
-		)
+		slog.Info("rpc completed", attrs...)
 	}
 
 	return resp, err
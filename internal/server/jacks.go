@@ -0,0 +1,356 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// jackType is the bead type of jacks: temporary, time-boxed changes to
+// shared state (a raised limit, a paused job, a pinned version) that must be
+// taken down again. A jack is up while its bead is unclosed.
+const jackType model.BeadType = "jack"
+
+// A jack's TTL, when raised or extended, is at most jackMaxTTL, and it may
+// be extended at most jackMaxExtensions times.
+const (
+	jackMaxTTL        = 24 * time.Hour
+	jackMaxExtensions = 3
+)
+
+// jackFields is the subset of a jack bead's fields the jack endpoints
+// maintain.
+type jackFields struct {
+	Target     string    `json:"target"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Extensions int       `json:"extensions"`
+}
+
+// parseJackTTL parses a Go duration such as "90m" and checks it against
+// jackMaxTTL.
+func parseJackTTL(ttl string) (time.Duration, error) {
+	if ttl == "" {
+		return 0, inputError("ttl is required")
+	}
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		return 0, inputError(fmt.Sprintf("invalid ttl %q: %v", ttl, err))
+	}
+	if d <= 0 || d > jackMaxTTL {
+		return 0, inputError(fmt.Sprintf("ttl must be positive and at most %s", jackMaxTTL))
+	}
+	return d, nil
+}
+
+// loadJack returns an up jack and its fields. Returns sql.ErrNoRows if the
+// bead does not exist and inputError if it is not a jack or is down.
+func loadJack(ctx context.Context, tx store.Store, id string) (*model.Bead, *jackFields, error) {
+	b, err := tx.GetBead(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if b == nil {
+		return nil, nil, sql.ErrNoRows
+	}
+	if b.Type != jackType {
+		return nil, nil, inputError("bead " + id + " is not a jack")
+	}
+	if b.Status == model.StatusClosed {
+		return nil, nil, inputError("jack " + id + " is already down")
+	}
+	var jf jackFields
+	if len(b.Fields) > 0 {
+		if err := json.Unmarshal(b.Fields, &jf); err != nil {
+			return nil, nil, fmt.Errorf("invalid jack fields: %w", err)
+		}
+	}
+	return b, &jf, nil
+}
+
+// raiseJackInput is the JSON body for POST /v1/jacks.
+type raiseJackInput struct {
+	Title       string   `json:"title"` // defaults to "Jack <target>"
+	Target      string   `json:"target"`
+	Revert      string   `json:"revert"` // how to take the jack down by hand
+	TTL         string   `json:"ttl"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
+	CreatedBy   string   `json:"created_by"`
+}
+
+// raiseJack creates a jack expiring ttl from now and emits BeadCreated and
+// JackRaised.
+func (s *BeadsServer) raiseJack(ctx context.Context, in raiseJackInput) (*model.Bead, error) {
+	if in.Target == "" {
+		return nil, inputError("target is required")
+	}
+	ttl, err := parseJackTTL(in.TTL)
+	if err != nil {
+		return nil, err
+	}
+	title := in.Title
+	if title == "" {
+		title = "Jack " + in.Target
+	}
+	actor := s.actorFor(ctx, in.CreatedBy)
+	fields := map[string]any{
+		"target":     in.Target,
+		"expires_at": time.Now().UTC().Add(ttl).Truncate(time.Second).Format(time.RFC3339),
+		"extensions": 0,
+	}
+	if in.Revert != "" {
+		fields["revert"] = in.Revert
+	}
+	bead, err := s.newTypedBead(ctx, jackType, title, actor, fields)
+	if err != nil {
+		return nil, err
+	}
+	bead.Description = in.Description
+	bead.Labels = in.Labels
+
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := tx.CreateBead(ctx, bead); err != nil {
+			return fmt.Errorf("failed to create jack: %w", err)
+		}
+		for _, label := range bead.Labels {
+			if err := tx.AddLabel(ctx, bead.ID, label); err != nil {
+				return fmt.Errorf("failed to add label %q: %w", label, err)
+			}
+		}
+		if err := s.recordEvent(ctx, tx, events.TopicBeadCreated, bead.ID, actor, events.BeadCreated{Bead: bead}); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicJackRaised, bead.ID, actor, events.JackRaised{Bead: bead, RaisedBy: actor})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return bead, nil
+}
+
+// extendJack pushes a jack's expiry back by ttl, counted from its current
+// expiry or from now if it has lapsed. The new expiry may not be more than
+// jackMaxTTL away, and a jack may be extended jackMaxExtensions times.
+func (s *BeadsServer) extendJack(ctx context.Context, id, ttl, reason, actor string) (*model.Bead, error) {
+	d, err := parseJackTTL(ttl)
+	if err != nil {
+		return nil, err
+	}
+	actor = s.actorFor(ctx, actor)
+	bead, err := retryOnConflict(func() (*model.Bead, error) {
+		var bead *model.Bead
+		err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+			b, jf, err := loadJack(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			if err := checkLock(ctx, tx, id, actor); err != nil {
+				return err
+			}
+			if jf.Extensions >= jackMaxExtensions {
+				return inputError(fmt.Sprintf("jack %s has been extended %d times, the limit; take it down and raise a new one", id, jf.Extensions))
+			}
+			now := time.Now().UTC()
+			expiresAt := now
+			if jf.ExpiresAt.After(now) {
+				expiresAt = jf.ExpiresAt
+			}
+			expiresAt = expiresAt.Add(d).Truncate(time.Second)
+			if expiresAt.Sub(now) > jackMaxTTL {
+				return inputError(fmt.Sprintf("jack %s would expire more than %s from now", id, jackMaxTTL))
+			}
+
+			fields := map[string]any{}
+			if err := json.Unmarshal(b.Fields, &fields); err != nil {
+				return fmt.Errorf("invalid jack fields: %w", err)
+			}
+			fields["expires_at"] = expiresAt.Format(time.RFC3339)
+			fields["extensions"] = jf.Extensions + 1
+			if b.Fields, err = json.Marshal(fields); err != nil {
+				return err
+			}
+			if err := tx.UpdateBead(ctx, b); err != nil {
+				return err
+			}
+			bead = b
+			if err := s.recordEvent(ctx, tx, events.TopicBeadUpdated, id, actor, events.BeadUpdated{
+				Bead:    b,
+				Changes: map[string]any{"fields": fields},
+			}); err != nil {
+				return err
+			}
+			return s.recordEvent(ctx, tx, events.TopicJackExtended, id, actor, events.JackExtended{
+				BeadID:     id,
+				ExpiresAt:  expiresAt,
+				Extensions: jf.Extensions + 1,
+				Reason:     reason,
+				ExtendedBy: actor,
+			})
+		})
+		return bead, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return bead, nil
+}
+
+// changeJack appends an entry to an up jack's change log, its notes, and
+// emits JackChanged in the same transaction.
+func (s *BeadsServer) changeJack(ctx context.Context, id, text, actor string) (*model.Note, error) {
+	if text == "" {
+		return nil, inputError("text is required")
+	}
+	note := &model.Note{
+		BeadID:    id,
+		Author:    s.actorFor(ctx, actor),
+		Text:      text,
+		CreatedAt: time.Now().UTC(),
+	}
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if _, _, err := loadJack(ctx, tx, id); err != nil {
+			return err
+		}
+		if err := tx.AppendNote(ctx, note); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicJackChanged, id, note.Author, events.JackChanged{Note: note})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return note, nil
+}
+
+// downJack takes a jack down, closing its bead, and emits BeadClosed and
+// JackDown.
+func (s *BeadsServer) downJack(ctx context.Context, id, reason, actor string) (*model.Bead, error) {
+	actor = s.actorFor(ctx, actor)
+	var closed *model.Bead
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if _, _, err := loadJack(ctx, tx, id); err != nil {
+			return err
+		}
+		var err error
+		if closed, err = tx.CloseBead(ctx, id, actor); err != nil {
+			return err
+		}
+		if closed == nil {
+			return sql.ErrNoRows
+		}
+		if err := s.recordEvent(ctx, tx, events.TopicBeadClosed, id, actor, events.BeadClosed{Bead: closed, ClosedBy: actor}); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicJackDown, id, actor, events.JackDown{Bead: closed, Reason: reason, DownBy: actor})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return closed, nil
+}
+
+// writeJackError maps a jack operation's error to a response.
+func writeJackError(w http.ResponseWriter, err error, action string) {
+	var ie inputError
+	switch {
+	case errors.As(err, &ie):
+		writeError(w, http.StatusBadRequest, ie.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "jack not found")
+	case writeLockedError(w, err):
+	default:
+		writeError(w, http.StatusInternalServerError, "failed to "+action+": "+err.Error())
+	}
+}
+
+// handleRaiseJack handles POST /v1/jacks.
+func (s *BeadsServer) handleRaiseJack(w http.ResponseWriter, r *http.Request) {
+	var in raiseJackInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	bead, err := s.raiseJack(r.Context(), in)
+	if err != nil {
+		writeJackError(w, err, "raise jack")
+		return
+	}
+	writeJSON(w, http.StatusCreated, bead)
+}
+
+// extendJackRequest is the JSON body for POST /v1/jacks/{id}/extend.
+type extendJackRequest struct {
+	TTL        string `json:"ttl"`
+	Reason     string `json:"reason"`
+	ExtendedBy string `json:"extended_by"`
+}
+
+// handleExtendJack handles POST /v1/jacks/{id}/extend.
+func (s *BeadsServer) handleExtendJack(w http.ResponseWriter, r *http.Request) {
+	var req extendJackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	bead, err := s.extendJack(r.Context(), r.PathValue("id"), req.TTL, req.Reason, req.ExtendedBy)
+	if err != nil {
+		writeJackError(w, err, "extend jack")
+		return
+	}
+	writeJSON(w, http.StatusOK, bead)
+}
+
+// changeJackRequest is the JSON body for POST /v1/jacks/{id}/change.
+type changeJackRequest struct {
+	Text   string `json:"text"`
+	Author string `json:"author"`
+}
+
+// handleChangeJack handles POST /v1/jacks/{id}/change.
+func (s *BeadsServer) handleChangeJack(w http.ResponseWriter, r *http.Request) {
+	var req changeJackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	note, err := s.changeJack(r.Context(), r.PathValue("id"), req.Text, req.Author)
+	if err != nil {
+		writeJackError(w, err, "record jack change")
+		return
+	}
+	writeJSON(w, http.StatusCreated, note)
+}
+
+// downJackRequest is the optional JSON body for POST /v1/jacks/{id}/down.
+type downJackRequest struct {
+	Reason string `json:"reason"`
+	DownBy string `json:"down_by"`
+}
+
+// handleDownJack handles POST /v1/jacks/{id}/down.
+func (s *BeadsServer) handleDownJack(w http.ResponseWriter, r *http.Request) {
+	var req downJackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	bead, err := s.downJack(r.Context(), r.PathValue("id"), req.Reason, req.DownBy)
+	if err != nil {
+		writeJackError(w, err, "take jack down")
+		return
+	}
+	writeJSON(w, http.StatusOK, bead)
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestHandleJacks_Lifecycle(t *testing.T) {
+	_, ms, h := newTestServer()
+
+	rec := doJSON(t, h, "POST", "/v1/jacks", map[string]any{
+		"target": "ci/max-runners", "ttl": "2h", "revert": "set max-runners back to 8", "created_by": "alice",
+	})
+	requireStatus(t, rec, http.StatusCreated)
+	var jack model.Bead
+	decodeJSON(t, rec, &jack)
+	if jack.Type != jackType || jack.Title != "Jack ci/max-runners" || jack.Status != model.StatusOpen {
+		t.Fatalf("jack = %s %q (%s)", jack.Type, jack.Title, jack.Status)
+	}
+	var jf jackFields
+	if err := json.Unmarshal(jack.Fields, &jf); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Until(jf.ExpiresAt); d < 119*time.Minute || d > 2*time.Hour {
+		t.Errorf("expires in %s, want 2h", d)
+	}
+
+	path := "/v1/jacks/" + jack.ID
+	for i := 1; i <= jackMaxExtensions; i++ {
+		rec = doJSON(t, h, "POST", path+"/extend", map[string]any{"ttl": "1h", "reason": "still debugging"})
+		requireStatus(t, rec, http.StatusOK)
+	}
+	requireStatus(t, doJSON(t, h, "POST", path+"/extend", map[string]any{"ttl": "1h"}), http.StatusBadRequest)
+	if err := json.Unmarshal(ms.beads[jack.ID].Fields, &jf); err != nil {
+		t.Fatal(err)
+	}
+	if jf.Extensions != jackMaxExtensions {
+		t.Errorf("extensions = %d, want %d", jf.Extensions, jackMaxExtensions)
+	}
+
+	rec = doJSON(t, h, "POST", path+"/change", map[string]any{"text": "bumped to 16 runners", "author": "bob"})
+	requireStatus(t, rec, http.StatusCreated)
+	if notes := ms.notes[jack.ID]; len(notes) != 1 || notes[0].Author != "bob" {
+		t.Errorf("change log = %+v", notes)
+	}
+	requireStatus(t, doJSON(t, h, "POST", path+"/change", map[string]any{}), http.StatusBadRequest)
+
+	rec = doJSON(t, h, "POST", path+"/down", map[string]any{"reason": "load is back to normal"})
+	requireStatus(t, rec, http.StatusOK)
+	if ms.beads[jack.ID].Status != model.StatusClosed {
+		t.Errorf("status = %s after down", ms.beads[jack.ID].Status)
+	}
+	requireStatus(t, doJSON(t, h, "POST", path+"/down", nil), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "POST", path+"/change", map[string]any{"text": "late"}), http.StatusBadRequest)
+
+	var topics []string
+	for _, e := range ms.events {
+		switch e.Topic {
+		case events.TopicJackRaised, events.TopicJackExtended, events.TopicJackChanged, events.TopicJackDown:
+			topics = append(topics, e.Topic)
+		}
+	}
+	want := []string{events.TopicJackRaised, events.TopicJackExtended, events.TopicJackExtended, events.TopicJackExtended, events.TopicJackChanged, events.TopicJackDown}
+	if len(topics) != len(want) {
+		t.Fatalf("jack events = %v, want %v", topics, want)
+	}
+	for i := range want {
+		if topics[i] != want[i] {
+			t.Errorf("jack events = %v, want %v", topics, want)
+			break
+		}
+	}
+}
+
+func TestHandleJacks_ExtendLocked(t *testing.T) {
+	_, ms, h := newTestServer()
+	rec := doJSON(t, h, "POST", "/v1/jacks", map[string]any{"target": "ci/max-runners", "ttl": "1h", "created_by": "alice"})
+	requireStatus(t, rec, http.StatusCreated)
+	var jack model.Bead
+	decodeJSON(t, rec, &jack)
+	ms.locks[jack.ID] = &model.BeadLock{BeadID: jack.ID, Owner: "alice", ExpiresAt: time.Now().Add(time.Hour)}
+
+	path := "/v1/jacks/" + jack.ID + "/extend"
+	requireStatus(t, doJSON(t, h, "POST", path, map[string]any{"ttl": "1h", "extended_by": "bob"}), http.StatusLocked)
+	if string(ms.beads[jack.ID].Fields) != string(jack.Fields) {
+		t.Fatalf("fields = %s, want them untouched", ms.beads[jack.ID].Fields)
+	}
+	requireStatus(t, doJSON(t, h, "POST", path, map[string]any{"ttl": "1h", "extended_by": "alice"}), http.StatusOK)
+}
+
+func TestHandleJacks_Validation(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-task"] = &model.Bead{ID: "bd-task", Type: "task", Status: model.StatusOpen}
+
+	for _, body := range []map[string]any{
+		{"ttl": "1h"},
+		{"target": "x"},
+		{"target": "x", "ttl": "soon"},
+		{"target": "x", "ttl": "-1h"},
+		{"target": "x", "ttl": "25h"},
+	} {
+		if rec := doJSON(t, h, "POST", "/v1/jacks", body); rec.Code != http.StatusBadRequest {
+			t.Errorf("%v: status = %d, want 400", body, rec.Code)
+		}
+	}
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/jacks/bd-task/extend", map[string]any{"ttl": "1h"}), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/jacks/bd-none/down", nil), http.StatusNotFound)
+
+	// An extension may not push the expiry more than jackMaxTTL out.
+	rec := doJSON(t, h, "POST", "/v1/jacks", map[string]any{"target": "x", "ttl": "20h"})
+	requireStatus(t, rec, http.StatusCreated)
+	var jack model.Bead
+	decodeJSON(t, rec, &jack)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/jacks/"+jack.ID+"/extend", map[string]any{"ttl": "5h"}), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/jacks/"+jack.ID+"/extend", map[string]any{"ttl": "3h"}), http.StatusOK)
+}
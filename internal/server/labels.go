@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// labelConfig loads the label:namespaces config. Without one, every
+// namespace is allowed.
+func (s *BeadsServer) labelConfig(ctx context.Context) (*model.LabelConfig, error) {
+	config, err := s.store.GetConfig(ctx, model.LabelConfigKey)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	var lc model.LabelConfig
+	if config == nil {
+		return &lc, nil
+	}
+	if err := json.Unmarshal(config.Value, &lc); err != nil {
+		return nil, fmt.Errorf("invalid label config: %w", err)
+	}
+	return &lc, nil
+}
+
+// checkLabels returns an inputError if any label is malformed or uses a
+// namespace the label config does not allow.
+func (s *BeadsServer) checkLabels(ctx context.Context, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	lc, err := s.labelConfig(ctx)
+	if err != nil {
+		return err
+	}
+	for _, label := range labels {
+		if ns, value := model.SplitLabel(label); ns != "" && (value == "" || value == "*") {
+			return inputError(fmt.Sprintf("label %q has no value", label))
+		}
+		if err := lc.Check(label); err != nil {
+			return inputError(err.Error())
+		}
+	}
+	return nil
+}
+
+// addLabel adds a label to a bead and records its event in one transaction.
+func (s *BeadsServer) addLabel(ctx context.Context, beadID, label string) error {
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := tx.AddLabel(ctx, beadID, label); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicLabelAdded, beadID, "", events.LabelAdded{BeadID: beadID, Label: label})
+	})
+	if err != nil {
+		return err
+	}
+	s.flushEvents(ctx)
+	return nil
+}
+
+// removeLabel removes a label from a bead and records its event in one
+// transaction.
+func (s *BeadsServer) removeLabel(ctx context.Context, beadID, label string) error {
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := tx.RemoveLabel(ctx, beadID, label); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicLabelRemoved, beadID, "", events.LabelRemoved{BeadID: beadID, Label: label})
+	})
+	if err != nil {
+		return err
+	}
+	s.flushEvents(ctx)
+	return nil
+}
+
+// handleListLabels handles GET /v1/labels: every label in use with the
+// number of live beads carrying it, ordered by namespace and value.
+func (s *BeadsServer) handleListLabels(w http.ResponseWriter, r *http.Request) {
+	labels, err := s.store.ListLabels(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list labels")
+		return
+	}
+	if labels == nil {
+		labels = []*model.LabelCount{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"labels": labels})
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc/codes"
+)
+
+// Bead locks last defaultLockTTL unless asked otherwise, and at most
+// maxLockTTL, so a crashed agent never keeps a bead locked for long.
+const (
+	defaultLockTTL = 5 * time.Minute
+	maxLockTTL     = time.Hour
+)
+
+// lockedError is returned when an actor updates or locks a bead that another
+// actor holds a lock on. Transport layers map it to 423 / FailedPrecondition
+// with code locked.
+type lockedError struct {
+	Lock *model.BeadLock
+}
+
+func (e *lockedError) Error() string {
+	return fmt.Sprintf("bead %s is locked by %s until %s",
+		e.Lock.BeadID, e.Lock.Owner, e.Lock.ExpiresAt.UTC().Format(time.RFC3339))
+}
+
+// status returns e as a FailedPrecondition status with a LOCKED ErrorInfo
+// whose metadata names the lock's owner and expiry.
+func (e *lockedError) status() error {
+	return errorWithCode(codes.FailedPrecondition, CodeLocked, e.Error(), map[string]string{
+		"owner":      e.Lock.Owner,
+		"expires_at": e.Lock.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// writeLockedError writes a 423 locked response if err is a *lockedError,
+// reporting whether it did.
+func writeLockedError(w http.ResponseWriter, err error) bool {
+	var le *lockedError
+	if !errors.As(err, &le) {
+		return false
+	}
+	writeJSON(w, http.StatusLocked, errorBody{
+		Error:   le.Error(),
+		Code:    CodeLocked,
+		Details: map[string]any{"lock": le.Lock},
+	})
+	return true
+}
+
+// checkLock returns a *lockedError if an actor other than actor holds a lock
+// on the bead.
+func checkLock(ctx context.Context, st store.Store, beadID, actor string) error {
+	lock, err := st.GetBeadLock(ctx, beadID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if lock.HeldAt(time.Now()) && lock.Owner != actor {
+		return &lockedError{Lock: lock}
+	}
+	return nil
+}
+
+// lockBead takes the lock on a bead for owner, or renews owner's lock, for
+// ttl (a Go duration, defaultLockTTL if empty). Returns sql.ErrNoRows if the
+// bead does not exist and a *lockedError if another actor holds the lock.
+func (s *BeadsServer) lockBead(ctx context.Context, beadID, owner, ttl string) (*model.BeadLock, error) {
+	owner = s.actorFor(ctx, owner)
+	if owner == "" {
+		return nil, inputError("owner is required")
+	}
+	d := defaultLockTTL
+	if ttl != "" {
+		var err error
+		if d, err = time.ParseDuration(ttl); err != nil {
+			return nil, inputError(fmt.Sprintf("invalid ttl %q: %v", ttl, err))
+		}
+		if d <= 0 || d > maxLockTTL {
+			return nil, inputError(fmt.Sprintf("ttl must be positive and at most %s", maxLockTTL))
+		}
+	}
+
+	bead, err := s.store.GetBead(ctx, beadID)
+	if err != nil {
+		return nil, err
+	}
+	if bead == nil {
+		return nil, sql.ErrNoRows
+	}
+	now := time.Now().UTC()
+	lock, err := s.store.AcquireBeadLock(ctx, &model.BeadLock{
+		BeadID:     beadID,
+		Owner:      owner,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(d),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if lock.Owner != owner {
+		return nil, &lockedError{Lock: lock}
+	}
+	return lock, nil
+}
+
+// unlockBead releases owner's lock on a bead. Releasing a bead that is not
+// locked, or whose lock has expired, is a no-op. Returns a *lockedError if
+// another actor holds the lock.
+func (s *BeadsServer) unlockBead(ctx context.Context, beadID, owner string) error {
+	owner = s.actorFor(ctx, owner)
+	if owner == "" {
+		return inputError("owner is required")
+	}
+	return s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := checkLock(ctx, tx, beadID, owner); err != nil {
+			return err
+		}
+		return tx.ReleaseBeadLock(ctx, beadID)
+	})
+}
+
+// lockRequest is the optional JSON body for POST /v1/beads/{id}/lock.
+type lockRequest struct {
+	Owner string `json:"owner"`
+	TTL   string `json:"ttl"`
+}
+
+// handleLockBead handles POST /v1/beads/{id}/lock.
+func (s *BeadsServer) handleLockBead(w http.ResponseWriter, r *http.Request) {
+	var req lockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	lock, err := s.lockBead(r.Context(), r.PathValue("id"), req.Owner, req.TTL)
+	if err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "bead not found")
+		case writeLockedError(w, err):
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to lock bead")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, lock)
+}
+
+// handleGetBeadLock handles GET /v1/beads/{id}/lock.
+func (s *BeadsServer) handleGetBeadLock(w http.ResponseWriter, r *http.Request) {
+	lock, err := s.store.GetBeadLock(r.Context(), r.PathValue("id"))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !lock.HeldAt(time.Now())) {
+		writeError(w, http.StatusNotFound, "bead is not locked")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get lock")
+		return
+	}
+	writeJSON(w, http.StatusOK, lock)
+}
+
+// handleUnlockBead handles DELETE /v1/beads/{id}/lock?owner=.
+func (s *BeadsServer) handleUnlockBead(w http.ResponseWriter, r *http.Request) {
+	err := s.unlockBead(r.Context(), r.PathValue("id"), r.URL.Query().Get("owner"))
+	if err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case writeLockedError(w, err):
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to unlock bead")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
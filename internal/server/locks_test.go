@@ -0,0 +1,75 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestBeadLock(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-l1"] = &model.Bead{ID: "bd-l1", Title: "Jack", Type: "task", Kind: model.KindIssue, Status: model.StatusOpen}
+
+	requireStatus(t, doJSON(t, h, "GET", "/v1/beads/bd-l1/lock", nil), 404)
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-l1/lock", map[string]any{"owner": "alice", "ttl": "10m"})
+	requireStatus(t, rec, 200)
+	var lock model.BeadLock
+	decodeJSON(t, rec, &lock)
+	if lock.Owner != "alice" || lock.ExpiresAt.Sub(lock.AcquiredAt) != 10*time.Minute {
+		t.Fatalf("lock = %+v", lock)
+	}
+
+	// Others can neither update nor take the bead; its owner can.
+	rec = doJSON(t, h, "PATCH", "/v1/beads/bd-l1", map[string]any{"description": "theirs", "updated_by": "bob"})
+	requireStatus(t, rec, 423)
+	var body errorBody
+	decodeJSON(t, rec, &body)
+	if body.Code != CodeLocked {
+		t.Fatalf("code = %q, want %q", body.Code, CodeLocked)
+	}
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-l1?append=true", map[string]any{"notes": "mine", "updated_by": "bob"}), 423)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-l1/lock", map[string]any{"owner": "bob"}), 423)
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/beads/bd-l1/lock?owner=bob", nil), 423)
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-l1", map[string]any{"title": "Mine", "updated_by": "alice"}), 200)
+
+	// Renewing keeps when the lock was taken.
+	rec = doJSON(t, h, "POST", "/v1/beads/bd-l1/lock", map[string]any{"owner": "alice", "ttl": "20m"})
+	requireStatus(t, rec, 200)
+	var renewed model.BeadLock
+	decodeJSON(t, rec, &renewed)
+	if !renewed.AcquiredAt.Equal(lock.AcquiredAt) || !renewed.ExpiresAt.After(lock.ExpiresAt) {
+		t.Fatalf("renewed = %+v, was %+v", renewed, lock)
+	}
+
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/beads/bd-l1/lock?owner=alice", nil), 204)
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-l1", map[string]any{"title": "Theirs", "updated_by": "bob"}), 200)
+
+	// An expired lock no longer holds the bead.
+	ms.locks["bd-l1"] = &model.BeadLock{BeadID: "bd-l1", Owner: "alice", ExpiresAt: time.Now().Add(-time.Second)}
+	requireStatus(t, doJSON(t, h, "GET", "/v1/beads/bd-l1/lock", nil), 404)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-l1/lock", map[string]any{"owner": "bob"}), 200)
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-l1/lock", map[string]any{"owner": "bob", "ttl": "2h"}), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-nope/lock", map[string]any{"owner": "bob"}), 404)
+}
+
+func TestUpdateBead_LockedGRPC(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-l2"] = &model.Bead{ID: "bd-l2", Title: "Jack", Type: "task", Kind: model.KindIssue, Status: model.StatusOpen}
+	if _, err := srv.lockBead(ctx, "bd-l2", "alice", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	title := "Clobbered"
+	_, err := srv.UpdateBead(ctx, &beadsv1.UpdateBeadRequest{Id: "bd-l2", Title: &title, UpdatedBy: "bob"})
+	requireCode(t, err, codes.FailedPrecondition)
+	if info := errorInfo(err); info == nil || info.GetReason() != "LOCKED" || info.GetMetadata()["owner"] != "alice" {
+		t.Fatalf("info = %v", info)
+	}
+	if ms.beads["bd-l2"].Title != "Jack" {
+		t.Fatalf("locked bead was updated: %q", ms.beads["bd-l2"].Title)
+	}
+}
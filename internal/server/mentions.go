@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// mentionPattern matches @name where the @ does not follow a word character,
+// so e-mail addresses are not mentions. Names use the actor name alphabet.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([A-Za-z0-9][A-Za-z0-9._@/+-]*)`)
+
+// parseMentions returns the names @mentioned in text, in order and without
+// duplicates. Trailing punctuation is not part of a name.
+func parseMentions(text string) []string {
+	var names []string
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		name := strings.TrimRight(m[1], ".-/+@")
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// mentionedActors resolves the names comment @mentions through the actor
+// registry and returns the registered actors other than its author. Names
+// that are not registered, such as @param in a code snippet, are ignored.
+func mentionedActors(ctx context.Context, tx store.Store, comment *model.Comment) ([]string, error) {
+	var actors []string
+	for _, name := range parseMentions(comment.Text) {
+		id, err := tx.ResolveActor(ctx, name)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if id != comment.Author && !slices.Contains(actors, id) {
+			actors = append(actors, id)
+		}
+	}
+	return actors, nil
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Similar-bead lookups return this many matches unless asked otherwise.
+const (
+	defaultSimilarLimit = 5
+	maxSimilarLimit     = 50
+)
+
+// mergeBead folds sourceID into targetID: comments, notes, labels,
+// dependencies and events move to the target, and the source is closed with a
+// duplicate-of relation to the target. Returns the closed source and the
+// updated target. Returns sql.ErrNoRows if either bead does not exist.
+func (s *BeadsServer) mergeBead(ctx context.Context, sourceID, targetID, actor string) (source, target *model.Bead, err error) {
+	if targetID == "" {
+		return nil, nil, inputError("into is required")
+	}
+	if sourceID == targetID {
+		return nil, nil, inputError("cannot merge a bead into itself")
+	}
+	actor = s.actorFor(ctx, actor)
+
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		for _, id := range []string{sourceID, targetID} {
+			b, err := tx.GetBead(ctx, id)
+			if err != nil {
+				return err
+			}
+			if b == nil {
+				return sql.ErrNoRows
+			}
+		}
+		if err := tx.MergeBead(ctx, sourceID, targetID); err != nil {
+			return err
+		}
+		link := &model.Dependency{
+			BeadID:      sourceID,
+			DependsOnID: targetID,
+			Type:        model.RelDuplicateOf,
+			CreatedAt:   time.Now().UTC(),
+			CreatedBy:   actor,
+		}
+		if err := s.checkDependencyCycle(ctx, tx, link); err != nil {
+			return err
+		}
+		if err := tx.AddDependency(ctx, link); err != nil {
+			return err
+		}
+		if source, err = tx.CloseBead(ctx, sourceID, actor); err != nil {
+			return err
+		}
+		if target, err = tx.GetBead(ctx, targetID); err != nil {
+			return err
+		}
+
+		if err := s.recordEvent(ctx, tx, events.TopicBeadClosed, source.ID, actor, events.BeadClosed{
+			Bead:     source,
+			ClosedBy: actor,
+		}); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicBeadMerged, target.ID, actor, events.BeadMerged{
+			SourceID: source.ID,
+			Target:   target,
+			MergedBy: actor,
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	s.flushEvents(ctx)
+
+	return source, target, nil
+}
+
+// similarBeads returns open beads whose titles resemble the title of bead id.
+// Returns sql.ErrNoRows if the bead does not exist.
+func (s *BeadsServer) similarBeads(ctx context.Context, id string, limit int) ([]*model.SimilarBead, error) {
+	if limit <= 0 {
+		limit = defaultSimilarLimit
+	}
+	limit = min(limit, maxSimilarLimit)
+
+	bead, err := s.store.GetBead(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if bead == nil {
+		return nil, sql.ErrNoRows
+	}
+	return s.store.SimilarBeads(ctx, bead.Title, bead.ID, limit)
+}
+
+// mergeBeadRequest is the optional JSON body for POST /v1/beads/{id}/merge.
+type mergeBeadRequest struct {
+	MergedBy string `json:"merged_by"`
+}
+
+// handleMergeBead handles POST /v1/beads/{id}/merge?into={target}.
+func (s *BeadsServer) handleMergeBead(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req mergeBeadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	source, target, err := s.mergeBead(r.Context(), id, r.URL.Query().Get("into"), req.MergedBy)
+	if err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "bead not found")
+		case writeCycleError(w, err):
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to merge bead")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"source": source,
+		"target": target,
+	})
+}
+
+// handleSimilarBeads handles GET /v1/beads/{id}/similar.
+func (s *BeadsServer) handleSimilarBeads(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			limit = n
+		}
+	}
+
+	similar, err := s.similarBeads(r.Context(), id, limit)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "bead not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to find similar beads")
+		return
+	}
+
+	if similar == nil {
+		similar = []*model.SimilarBead{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"similar": similar})
+}
+
+// MergeBead merges one bead into another, closing it as a duplicate.
+func (s *BeadsServer) MergeBead(ctx context.Context, req *beadsv1.MergeBeadRequest) (*beadsv1.MergeBeadResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	source, target, err := s.mergeBead(ctx, req.GetId(), req.GetInto(), req.GetMergedBy())
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "bead not found")
+		}
+		var ce *cycleError
+		if errors.As(err, &ce) {
+			return nil, ce.status()
+		}
+		return nil, status.Errorf(codes.Internal, "failed to merge bead: %v", err)
+	}
+
+	return &beadsv1.MergeBeadResponse{
+		Source: beadToProto(source),
+		Target: beadToProto(target),
+	}, nil
+}
+
+// FindSimilarBeads returns open beads whose titles resemble the given bead's.
+func (s *BeadsServer) FindSimilarBeads(ctx context.Context, req *beadsv1.FindSimilarBeadsRequest) (*beadsv1.FindSimilarBeadsResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	similar, err := s.similarBeads(ctx, req.GetId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, storeError(err, "bead")
+	}
+
+	pbSimilar := make([]*beadsv1.SimilarBead, 0, len(similar))
+	for _, sb := range similar {
+		pbSimilar = append(pbSimilar, &beadsv1.SimilarBead{
+			Bead:       beadToProto(sb.Bead),
+			Similarity: sb.Similarity,
+		})
+	}
+
+	return &beadsv1.FindSimilarBeadsResponse{Similar: pbSimilar}, nil
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestHandleMergeBead(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-dup"] = &model.Bead{ID: "bd-dup", Title: "Login broken", Status: model.StatusOpen}
+	ms.beads["bd-orig"] = &model.Bead{ID: "bd-orig", Title: "Fix login", Status: model.StatusInProgress}
+	ms.beads["bd-other"] = &model.Bead{ID: "bd-other", Title: "Release", Status: model.StatusOpen}
+	ms.comments["bd-dup"] = []*model.Comment{{ID: 1, BeadID: "bd-dup", Text: "Seen on mobile"}}
+	ms.labels["bd-dup"] = []string{"auth", "mobile"}
+	ms.labels["bd-orig"] = []string{"auth"}
+	ms.deps["bd-other"] = []*model.Dependency{{BeadID: "bd-other", DependsOnID: "bd-dup", Type: model.DepBlocks}}
+	ms.events = []*model.Event{{ID: 1, Topic: "beads.bead.created", BeadID: "bd-dup"}}
+
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-dup/merge?into=bd-orig", map[string]any{"merged_by": "alice"})
+	requireStatus(t, rec, 200)
+	var result struct {
+		Source model.Bead `json:"source"`
+		Target model.Bead `json:"target"`
+	}
+	decodeJSON(t, rec, &result)
+	if result.Source.Status != model.StatusClosed || result.Source.ClosedBy != "alice" {
+		t.Fatalf("source not closed: %+v", result.Source)
+	}
+	if len(result.Target.Labels) != 2 {
+		t.Fatalf("expected merged labels, got %v", result.Target.Labels)
+	}
+
+	if len(ms.comments["bd-orig"]) != 1 || len(ms.comments["bd-dup"]) != 0 {
+		t.Fatalf("comments not moved: %v", ms.comments)
+	}
+	if ms.deps["bd-other"][0].DependsOnID != "bd-orig" {
+		t.Fatalf("inbound dependency not moved: %+v", ms.deps["bd-other"][0])
+	}
+	if len(ms.deps["bd-dup"]) != 1 || ms.deps["bd-dup"][0].Type != model.RelDuplicateOf || ms.deps["bd-dup"][0].DependsOnID != "bd-orig" {
+		t.Fatalf("expected duplicates link, got %+v", ms.deps["bd-dup"])
+	}
+	if ms.events[0].BeadID != "bd-orig" {
+		t.Fatalf("events not moved: %+v", ms.events[0])
+	}
+
+	requireEvent(t, ms, 3, "beads.bead.merged")
+	if ms.events[1].Topic != "beads.bead.closed" || ms.events[2].Actor != "alice" {
+		t.Fatalf("unexpected events: %+v %+v", ms.events[1], ms.events[2])
+	}
+}
+
+func TestHandleMergeBead_Errors(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-m1"] = &model.Bead{ID: "bd-m1", Title: "One", Status: model.StatusOpen}
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-m1/merge", nil), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-m1/merge?into=bd-m1", nil), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-m1/merge?into=bd-nope", nil), 404)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-nope/merge?into=bd-m1", nil), 404)
+	if ms.beads["bd-m1"].Status != model.StatusOpen || len(ms.events) != 0 {
+		t.Fatalf("failed merge should change nothing: %+v, %d events", ms.beads["bd-m1"], len(ms.events))
+	}
+}
+
+func TestHandleSimilarBeads(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-s1"] = &model.Bead{ID: "bd-s1", Title: "Fix login bug", Status: model.StatusOpen}
+	ms.beads["bd-s2"] = &model.Bead{ID: "bd-s2", Title: "Fix login bug on mobile", Status: model.StatusOpen}
+	ms.beads["bd-s3"] = &model.Bead{ID: "bd-s3", Title: "Fix login bug", Status: model.StatusClosed}
+	ms.beads["bd-s4"] = &model.Bead{ID: "bd-s4", Title: "Quarterly planning", Status: model.StatusOpen}
+
+	rec := doJSON(t, h, "GET", "/v1/beads/bd-s1/similar", nil)
+	requireStatus(t, rec, 200)
+	var result struct {
+		Similar []model.SimilarBead `json:"similar"`
+	}
+	decodeJSON(t, rec, &result)
+	if len(result.Similar) != 1 || result.Similar[0].Bead.ID != "bd-s2" || result.Similar[0].Similarity <= 0 {
+		t.Fatalf("unexpected similar beads: %+v", result.Similar)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/beads/bd-s4/similar", nil)
+	requireStatus(t, rec, 200)
+	decodeJSON(t, rec, &result)
+	if len(result.Similar) != 0 {
+		t.Fatalf("expected no matches, got %+v", result.Similar)
+	}
+
+	requireStatus(t, doJSON(t, h, "GET", "/v1/beads/bd-nope/similar", nil), 404)
+}
+
+func TestGRPCMergeAndSimilar(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-g1"] = &model.Bead{ID: "bd-g1", Title: "Flaky deploy job", Status: model.StatusOpen}
+	ms.beads["bd-g2"] = &model.Bead{ID: "bd-g2", Title: "Flaky deploy job again", Status: model.StatusOpen}
+
+	sim, err := srv.FindSimilarBeads(ctx, &beadsv1.FindSimilarBeadsRequest{Id: "bd-g2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sim.GetSimilar()) != 1 || sim.GetSimilar()[0].GetBead().GetId() != "bd-g1" {
+		t.Fatalf("unexpected similar: %+v", sim.GetSimilar())
+	}
+
+	resp, err := srv.MergeBead(ctx, &beadsv1.MergeBeadRequest{Id: "bd-g2", Into: "bd-g1", MergedBy: "bob"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.GetSource().GetStatus() != "closed" || resp.GetTarget().GetId() != "bd-g1" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	_, err = srv.MergeBead(ctx, &beadsv1.MergeBeadRequest{Id: "bd-g1"})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = srv.FindSimilarBeads(ctx, &beadsv1.FindSimilarBeadsRequest{Id: "bd-nope"})
+	requireCode(t, err, codes.NotFound)
+}
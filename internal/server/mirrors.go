@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// mirrorNamespace is the config namespace of remote mirrors, keyed by the
+// remote's name, e.g. "mirror:east".
+const mirrorNamespace = "mirror"
+
+// mirrorConfig is the value of a "mirror:<remote>" config.
+type mirrorConfig struct {
+	URL   string `json:"url"`             // the remote server's HTTP address
+	Token string `json:"token,omitempty"` // bearer token for the remote
+	Query string `json:"query,omitempty"` // GET /v1/beads filters, e.g. "labels=team:payments&status=open"
+}
+
+// mirrorClient fetches bead listings from remote servers.
+var mirrorClient = &http.Client{Timeout: time.Minute}
+
+// RunMirrors refreshes every mirror each interval until ctx is cancelled.
+func (s *BeadsServer) RunMirrors(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.SyncMirrors(ctx); err != nil {
+				slog.Error("mirror sync failed", "err", err)
+			} else if n > 0 {
+				slog.Info("synced mirrors", "count", n)
+			}
+		}
+	}
+}
+
+// SyncMirrors replaces each mirror's copy with the beads its remote
+// currently lists for the mirror's query. A mirror that cannot be fetched
+// is logged and keeps its previous copy. Returns the number of mirrors
+// refreshed.
+func (s *BeadsServer) SyncMirrors(ctx context.Context) (int, error) {
+	configs, err := s.store.ListConfigs(ctx, mirrorNamespace)
+	if err != nil {
+		return 0, fmt.Errorf("listing mirrors: %w", err)
+	}
+
+	synced := 0
+	for _, cfg := range configs {
+		remote := strings.TrimPrefix(cfg.Key, mirrorNamespace+":")
+		var mc mirrorConfig
+		if err := json.Unmarshal(cfg.Value, &mc); err != nil || mc.URL == "" {
+			slog.Warn("invalid mirror config", "key", cfg.Key, "err", err)
+			continue
+		}
+		beads, err := fetchMirror(ctx, mc)
+		if err != nil {
+			slog.Warn("failed to fetch mirror", "remote", remote, "err", err)
+			continue
+		}
+		err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+			return tx.ReplaceMirroredBeads(ctx, remote, beads)
+		})
+		if err != nil {
+			slog.Warn("failed to store mirror", "remote", remote, "err", err)
+			continue
+		}
+		synced++
+	}
+	return synced, nil
+}
+
+// fetchMirror downloads the beads matching mc's query from its remote, as
+// the remote's JSONL bead listing.
+func fetchMirror(ctx context.Context, mc mirrorConfig) ([]*model.Bead, error) {
+	q, err := url.ParseQuery(mc.Query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", mc.Query, err)
+	}
+	q.Set("format", "jsonl")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(mc.URL, "/")+"/v1/beads?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if mc.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+mc.Token)
+	}
+	resp, err := mirrorClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote returned %s", resp.Status)
+	}
+
+	var beads []*model.Bead
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var b model.Bead
+		if err := dec.Decode(&b); err == io.EOF {
+			return beads, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("decoding bead: %w", err)
+		}
+		beads = append(beads, &b)
+	}
+}
+
+// handleListMirroredBeads handles GET /v1/mirrors/{remote}/beads.
+func (s *BeadsServer) handleListMirroredBeads(w http.ResponseWriter, r *http.Request) {
+	beads, err := s.store.ListMirroredBeads(r.Context(), r.PathValue("remote"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list mirrored beads")
+		return
+	}
+	if beads == nil {
+		beads = []*model.MirroredBead{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"beads": beads})
+}
+
+// handleGetMirroredBead handles GET /v1/mirrors/{remote}/beads/{id}.
+func (s *BeadsServer) handleGetMirroredBead(w http.ResponseWriter, r *http.Request) {
+	m, err := s.store.GetMirroredBead(r.Context(), r.PathValue("remote"), r.PathValue("id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, "mirrored bead not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get mirrored bead")
+		return
+	}
+	writeJSON(w, http.StatusOK, m)
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestSyncMirrors(t *testing.T) {
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/beads" || r.URL.Query().Get("format") != "jsonl" || r.URL.Query().Get("labels") != "team:payments" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		enc := json.NewEncoder(w)
+		enc.Encode(&model.Bead{ID: "bd-1", Title: "Remote one"})
+		enc.Encode(&model.Bead{ID: "bd-2", Title: "Remote two"})
+	}))
+	defer remote.Close()
+
+	srv, ms, h := newTestServer()
+	value, _ := json.Marshal(mirrorConfig{URL: remote.URL, Token: "secret", Query: "labels=team:payments"})
+	ms.configs["mirror:east"] = &model.Config{Key: "mirror:east", Value: value}
+	ms.configs["mirror:broken"] = &model.Config{Key: "mirror:broken", Value: json.RawMessage(`{}`)}
+
+	n, err := srv.SyncMirrors(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("SyncMirrors = %d, %v; want 1 mirror", n, err)
+	}
+
+	rec := doJSON(t, h, "GET", "/v1/mirrors/east/beads", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var list struct {
+		Beads []*model.MirroredBead `json:"beads"`
+	}
+	decodeJSON(t, rec, &list)
+	if len(list.Beads) != 2 || list.Beads[0].Remote != "east" {
+		t.Fatalf("mirrored beads = %+v", list.Beads)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/mirrors/east/beads/bd-2", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var got model.MirroredBead
+	decodeJSON(t, rec, &got)
+	if got.Bead.Title != "Remote two" {
+		t.Errorf("bead = %+v", got.Bead)
+	}
+	requireStatus(t, doJSON(t, h, "GET", "/v1/mirrors/east/beads/bd-9", nil), http.StatusNotFound)
+}
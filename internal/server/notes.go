@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// appendNote atomically appends a timestamped, attributed entry to a bead's
+// notes and publishes a NoteAppended event. Returns sql.ErrNoRows if the bead
+// does not exist.
+func (s *BeadsServer) appendNote(ctx context.Context, beadID, author, text string) (*model.Note, error) {
+	if text == "" {
+		return nil, inputError("text is required")
+	}
+	note := &model.Note{
+		BeadID:    beadID,
+		Author:    s.actorFor(ctx, author),
+		Text:      text,
+		CreatedAt: time.Now().UTC(),
+	}
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		return s.insertNote(ctx, tx, note)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return note, nil
+}
+
+// insertNote appends note through tx and records its event.
+func (s *BeadsServer) insertNote(ctx context.Context, tx store.Store, note *model.Note) error {
+	if err := tx.AppendNote(ctx, note); err != nil {
+		return err
+	}
+	return s.recordEvent(ctx, tx, events.TopicNoteAppended, note.BeadID, note.Author, events.NoteAppended{Note: note})
+}
+
+// appendToBead applies the append half of an update: description gets a new
+// paragraph and notes gets a new entry, each in a single atomic statement so
+// concurrent appends are never lost.
+func (s *BeadsServer) appendToBead(ctx context.Context, id, actor string, description, notes *string) (*model.Bead, error) {
+	var bead *model.Bead
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		changes := make(map[string]any)
+		if description != nil {
+			d, err := tx.AppendDescription(ctx, id, *description)
+			if err != nil {
+				return err
+			}
+			changes["description"] = d
+		}
+		if notes != nil {
+			if *notes == "" {
+				return inputError("text is required")
+			}
+			note := &model.Note{
+				BeadID:    id,
+				Author:    s.actorFor(ctx, actor),
+				Text:      *notes,
+				CreatedAt: time.Now().UTC(),
+			}
+			if err := s.insertNote(ctx, tx, note); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if bead, err = tx.GetBead(ctx, id); err != nil {
+			return err
+		}
+		if bead == nil {
+			return sql.ErrNoRows
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		return s.recordEvent(ctx, tx, events.TopicBeadUpdated, bead.ID, s.actorFor(ctx, actor), events.BeadUpdated{
+			Bead:    bead,
+			Changes: changes,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return bead, nil
+}
+
+// addNoteRequest is the JSON body for POST /v1/beads/{id}/notes.
+type addNoteRequest struct {
+	Author string `json:"author"`
+	Text   string `json:"text"`
+}
+
+// handleAddNote handles POST /v1/beads/{id}/notes.
+func (s *BeadsServer) handleAddNote(w http.ResponseWriter, r *http.Request) {
+	beadID := r.PathValue("id")
+	if beadID == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req addNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	note, err := s.appendNote(r.Context(), beadID, req.Author, req.Text)
+	if err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "bead not found")
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to append note")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, note)
+}
+
+// handleGetNotes handles GET /v1/beads/{id}/notes.
+func (s *BeadsServer) handleGetNotes(w http.ResponseWriter, r *http.Request) {
+	beadID := r.PathValue("id")
+	if beadID == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	notes, err := s.store.GetNotes(r.Context(), beadID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get notes")
+		return
+	}
+
+	if notes == nil {
+		notes = []*model.Note{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"notes": notes})
+}
+
+// AddNote appends a note entry to a bead.
+func (s *BeadsServer) AddNote(ctx context.Context, req *beadsv1.AddNoteRequest) (*beadsv1.AddNoteResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+
+	note, err := s.appendNote(ctx, req.GetBeadId(), req.GetAuthor(), req.GetText())
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "bead not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to append note: %v", err)
+	}
+
+	return &beadsv1.AddNoteResponse{Note: noteToProto(note)}, nil
+}
+
+// GetNotes returns the note history for a bead, oldest first.
+func (s *BeadsServer) GetNotes(ctx context.Context, req *beadsv1.GetNotesRequest) (*beadsv1.GetNotesResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+
+	notes, err := s.store.GetNotes(ctx, req.GetBeadId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get notes: %v", err)
+	}
+
+	pbNotes := make([]*beadsv1.Note, 0, len(notes))
+	for _, n := range notes {
+		pbNotes = append(pbNotes, noteToProto(n))
+	}
+
+	return &beadsv1.GetNotesResponse{Notes: pbNotes}, nil
+}
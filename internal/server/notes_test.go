@@ -0,0 +1,141 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestHandleAddNote(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-n1"] = &model.Bead{ID: "bd-n1", Title: "Noted", Status: model.StatusOpen}
+
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-n1/notes", map[string]any{"author": "alice", "text": "Started on it"})
+	requireStatus(t, rec, 201)
+	var note model.Note
+	decodeJSON(t, rec, &note)
+	if note.Author != "alice" || note.Text != "Started on it" || note.CreatedAt.IsZero() {
+		t.Fatalf("unexpected note: %+v", note)
+	}
+	requireEvent(t, ms, 1, "beads.note.appended")
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-n1/notes", map[string]any{"author": "bob", "text": "Blocked on review"}), 201)
+
+	// Both entries survive in the bead's notes, attributed and in order.
+	lines := strings.Split(ms.beads["bd-n1"].Notes, "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], "alice: Started on it") || !strings.HasSuffix(lines[1], "bob: Blocked on review") {
+		t.Fatalf("unexpected notes: %q", ms.beads["bd-n1"].Notes)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/beads/bd-n1/notes", nil)
+	requireStatus(t, rec, 200)
+	var result struct {
+		Notes []model.Note `json:"notes"`
+	}
+	decodeJSON(t, rec, &result)
+	if len(result.Notes) != 2 || result.Notes[1].Author != "bob" {
+		t.Fatalf("unexpected history: %+v", result.Notes)
+	}
+}
+
+func TestHandleAddNote_Errors(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-n2"] = &model.Bead{ID: "bd-n2", Title: "Noted", Status: model.StatusOpen}
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-n2/notes", map[string]any{"author": "alice"}), 400)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-nope/notes", map[string]any{"text": "hi"}), 404)
+	if len(ms.events) != 0 {
+		t.Fatalf("expected no events, got %d", len(ms.events))
+	}
+}
+
+func TestUpdateBeadAppend(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-n3"] = &model.Bead{
+		ID: "bd-n3", Title: "Append", Type: "task", Kind: model.KindIssue, Status: model.StatusOpen,
+		Description: "Original", Notes: "[2026-01-01T00:00:00Z] alice: first",
+	}
+
+	rec := doJSON(t, h, "PATCH", "/v1/beads/bd-n3?append=true", map[string]any{
+		"description": "More detail",
+		"notes":       "second",
+		"priority":    1,
+		"updated_by":  "bob",
+	})
+	requireStatus(t, rec, 200)
+	var bead model.Bead
+	decodeJSON(t, rec, &bead)
+	if bead.Description != "Original\n\nMore detail" {
+		t.Fatalf("description = %q", bead.Description)
+	}
+	if !strings.HasPrefix(bead.Notes, "[2026-01-01T00:00:00Z] alice: first\n[") || !strings.HasSuffix(bead.Notes, "bob: second") {
+		t.Fatalf("notes = %q", bead.Notes)
+	}
+	if bead.Priority != 1 {
+		t.Fatalf("priority = %d, want 1", bead.Priority)
+	}
+	if len(ms.notes["bd-n3"]) != 1 {
+		t.Fatalf("expected one note in history, got %d", len(ms.notes["bd-n3"]))
+	}
+	requireEvent(t, ms, 3, "beads.bead.updated")
+	if ms.events[1].Topic != "beads.note.appended" || ms.events[1].Actor != "bob" {
+		t.Fatalf("unexpected event: %+v", ms.events[1])
+	}
+
+	// Without append, notes are replaced.
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-n3", map[string]any{"notes": "fresh"}), 200)
+	if ms.beads["bd-n3"].Notes != "fresh" {
+		t.Fatalf("notes = %q", ms.beads["bd-n3"].Notes)
+	}
+}
+
+func TestUpdateBeadAppend_InvalidAppendsNothing(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-n4"] = &model.Bead{ID: "bd-n4", Title: "Append", Type: "task", Kind: model.KindIssue, Status: model.StatusOpen}
+
+	rec := doJSON(t, h, "PATCH", "/v1/beads/bd-n4?append=true", map[string]any{"notes": "x", "status": "bogus"})
+	requireStatus(t, rec, 400)
+	if ms.beads["bd-n4"].Notes != "" || len(ms.notes["bd-n4"]) != 0 {
+		t.Fatalf("expected nothing appended, got %q", ms.beads["bd-n4"].Notes)
+	}
+
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-nope?append=true", map[string]any{"notes": "x"}), 404)
+}
+
+func TestGRPCNotes(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-n5"] = &model.Bead{ID: "bd-n5", Title: "Noted", Status: model.StatusOpen}
+
+	resp, err := srv.AddNote(ctx, &beadsv1.AddNoteRequest{BeadId: "bd-n5", Author: "alice", Text: "hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.GetNote().GetAuthor() != "alice" || resp.GetNote().GetId() == 0 {
+		t.Fatalf("unexpected note: %+v", resp.GetNote())
+	}
+
+	list, err := srv.GetNotes(ctx, &beadsv1.GetNotesRequest{BeadId: "bd-n5"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.GetNotes()) != 1 || list.GetNotes()[0].GetText() != "hello" {
+		t.Fatalf("unexpected notes: %+v", list.GetNotes())
+	}
+
+	_, err = srv.AddNote(ctx, &beadsv1.AddNoteRequest{BeadId: "bd-nope", Text: "hi"})
+	requireCode(t, err, codes.NotFound)
+	_, err = srv.AddNote(ctx, &beadsv1.AddNoteRequest{BeadId: "bd-n5"})
+	requireCode(t, err, codes.InvalidArgument)
+
+	notes := "again"
+	upd, err := srv.UpdateBead(ctx, &beadsv1.UpdateBeadRequest{Id: "bd-n5", Notes: &notes, Append: true, UpdatedBy: "bob"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(upd.GetBead().GetNotes(), "alice: hello\n") || !strings.HasSuffix(upd.GetBead().GetNotes(), "bob: again") {
+		t.Fatalf("notes = %q", upd.GetBead().GetNotes())
+	}
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/alfredjeanlab/beads/internal/oidc"
+)
+
+// DefaultOIDCAdminRole is the role that grants OIDC callers what the admin
+// token grants.
+const DefaultOIDCAdminRole = "beads-admin"
+
+// SetOIDC makes the server accept OIDC bearer tokens verified by v: the
+// token's actor claim becomes the caller's identity, and callers with
+// adminRole may do what the admin token allows.
+func (s *BeadsServer) SetOIDC(v *oidc.Verifier, adminRole string) {
+	s.oidc = v
+	s.oidcAdminRole = adminRole
+}
+
+type rolesKey struct{}
+
+// withRoles returns a context carrying the authenticated caller's roles.
+func withRoles(ctx context.Context, roles []string) context.Context {
+	if len(roles) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, rolesKey{}, roles)
+}
+
+// rolesFrom returns the roles of the authenticated caller, if any.
+func rolesFrom(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesKey{}).([]string)
+	return roles
+}
+
+// isOIDCAdmin reports whether the caller authenticated with an OIDC token
+// carrying the admin role.
+func (s *BeadsServer) isOIDCAdmin(ctx context.Context) bool {
+	return s.oidc != nil && s.oidcAdminRole != "" && slices.Contains(rolesFrom(ctx), s.oidcAdminRole)
+}
+
+// authenticate attaches the identity of a bearer token to ctx, unless a
+// client certificate already did: an agent token's agent, or the actor and
+// roles of a verified OIDC token. A JWT that fails verification is an
+// authError rather than being ignored, so a misconfigured client does not
+// silently act anonymously.
+func (s *BeadsServer) authenticate(ctx context.Context, token string) (context.Context, error) {
+	if identityFrom(ctx) != "" || token == "" {
+		return ctx, nil
+	}
+	if id := s.tokenIdentity(ctx, token); id != "" {
+		return withIdentity(ctx, id), nil
+	}
+	if s.oidc == nil || !oidc.LooksLikeJWT(token) {
+		return ctx, nil
+	}
+	claims, err := s.oidc.Verify(ctx, token)
+	if err != nil {
+		if !errors.Is(err, oidc.ErrInvalidToken) {
+			slog.Warn("OIDC token verification failed", "err", err)
+		}
+		return ctx, authError(err.Error())
+	}
+	return withRoles(withIdentity(ctx, claims.Actor), claims.Roles), nil
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/oidc"
+)
+
+// newOIDCIssuer starts an identity provider and returns its verifier and a
+// function signing tokens for the given preferred_username and roles.
+func newOIDCIssuer(t *testing.T) (*oidc.Verifier, func(actor string, roles ...string) string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := base64.RawURLEncoding.EncodeToString
+	var issuer string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": issuer, "jwks_uri": issuer + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "k1", "n": b64(key.N.Bytes()), "e": b64(big.NewInt(int64(key.E)).Bytes())},
+		}})
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	issuer = ts.URL
+
+	v, err := oidc.NewVerifier(oidc.Config{Issuer: issuer, Audience: "beads"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(actor string, roles ...string) string {
+		h, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "k1"})
+		c, _ := json.Marshal(map[string]any{
+			"iss": issuer, "aud": "beads", "sub": "u-1", "exp": time.Now().Add(time.Hour).Unix(),
+			"preferred_username": actor, "roles": roles,
+		})
+		signed := b64(h) + "." + b64(c)
+		digest := sha256.Sum256([]byte(signed))
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed + "." + b64(sig)
+	}
+	return v, sign
+}
+
+func TestHTTP_OIDC(t *testing.T) {
+	s, _, h := newTestServer()
+	v, sign := newOIDCIssuer(t)
+	s.SetOIDC(v, DefaultOIDCAdminRole)
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The token's actor overrides the one the client claims.
+	rec := do("POST", "/v1/beads", sign("alice"), `{"title":"SSO","type":"task","created_by":"mallory"}`)
+	requireStatus(t, rec, http.StatusCreated)
+	var bead struct {
+		CreatedBy string `json:"created_by"`
+	}
+	decodeJSON(t, rec, &bead)
+	if bead.CreatedBy != "alice" {
+		t.Fatalf("created_by = %q, want alice", bead.CreatedBy)
+	}
+
+	// A bad JWT is rejected rather than treated as anonymous.
+	tampered := sign("alice")
+	requireStatus(t, do("GET", "/v1/beads", tampered[:len(tampered)-4]+"AAAA", ""), http.StatusUnauthorized)
+
+	// Only the admin role stands in for the admin token.
+	requireStatus(t, do("POST", "/v1/archive/run", sign("alice"), ""), http.StatusUnauthorized)
+	requireStatus(t, do("POST", "/v1/archive/run", sign("root", DefaultOIDCAdminRole), ""), http.StatusBadRequest)
+}
@@ -0,0 +1,19 @@
+package server
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// OpenAPISpec is the OpenAPI 3 document describing the HTTP API. It is
+// maintained by hand next to the routes in NewHTTPHandler; a test checks that
+// the two list the same operations.
+//
+//go:embed openapi.json
+var OpenAPISpec []byte
+
+// handleOpenAPI handles GET /v1/openapi.json.
+func (s *BeadsServer) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(OpenAPISpec)
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+)
+
+// openAPIDoc is the part of the OpenAPI document the tests inspect.
+type openAPIDoc struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas   map[string]json.RawMessage `json:"schemas"`
+		Responses map[string]json.RawMessage `json:"responses"`
+	} `json:"components"`
+}
+
+func loadOpenAPI(t *testing.T) openAPIDoc {
+	t.Helper()
+	var doc openAPIDoc
+	if err := json.Unmarshal(OpenAPISpec, &doc); err != nil {
+		t.Fatalf("openapi.json: %v", err)
+	}
+	return doc
+}
+
+var routePattern = regexp.MustCompile(`mux\.HandleFunc\("([A-Z]+) ([^"]+)"`)
+
+func TestOpenAPI_MatchesRoutes(t *testing.T) {
+	src, err := os.ReadFile("http.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var routes []string
+	for _, m := range routePattern.FindAllStringSubmatch(string(src), -1) {
+		// The spec has no wildcard segments; {key...} is documented as {key}.
+		routes = append(routes, m[1]+" "+strings.ReplaceAll(m[2], "...}", "}"))
+	}
+	if len(routes) == 0 {
+		t.Fatal("no routes found in http.go")
+	}
+
+	var documented []string
+	for path, ops := range loadOpenAPI(t).Paths {
+		for method := range ops {
+			if method == "parameters" {
+				continue
+			}
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	sort.Strings(routes)
+	sort.Strings(documented)
+	missing, extra := diffSorted(routes, documented)
+	for _, r := range missing {
+		t.Errorf("route %s is not in openapi.json", r)
+	}
+	for _, r := range extra {
+		t.Errorf("openapi.json documents %s, which is not a route", r)
+	}
+}
+
+// pathVar matches a path variable in a mux pattern ({id}, {key...}) or an
+// HTTP binding ({bead_id}, {key=**}).
+var pathVar = regexp.MustCompile(`\{[^}]*\}`)
+
+// TestHTTPBindings_MatchRoutes checks that every RPC declares the HTTP route
+// serving the same operation in its google.api.http option, and that the
+// route exists, so the two surfaces cannot drift apart silently. Variable
+// names may differ: bindings name request fields, routes name path values.
+func TestHTTPBindings_MatchRoutes(t *testing.T) {
+	src, err := os.ReadFile("http.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	routes := make(map[string]bool)
+	for _, m := range routePattern.FindAllStringSubmatch(string(src), -1) {
+		routes[m[1]+" "+pathVar.ReplaceAllString(m[2], "{}")] = true
+	}
+
+	methods := beadsv1.File_beads_v1_service_proto.Services().ByName("BeadsService").Methods()
+	for i := range methods.Len() {
+		m := methods.Get(i)
+		rule, _ := proto.GetExtension(m.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if rule == nil {
+			t.Errorf("%s has no google.api.http binding", m.Name())
+			continue
+		}
+		for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+			binding := httpBinding(r)
+			if !routes[pathVar.ReplaceAllString(binding, "{}")] {
+				t.Errorf("%s is bound to %s, which is not a route", m.Name(), binding)
+			}
+		}
+	}
+}
+
+// httpBinding returns the method and path of an HTTP rule, as "GET /v1/x".
+func httpBinding(r *annotations.HttpRule) string {
+	switch p := r.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return "GET " + p.Get
+	case *annotations.HttpRule_Put:
+		return "PUT " + p.Put
+	case *annotations.HttpRule_Post:
+		return "POST " + p.Post
+	case *annotations.HttpRule_Delete:
+		return "DELETE " + p.Delete
+	case *annotations.HttpRule_Patch:
+		return "PATCH " + p.Patch
+	case *annotations.HttpRule_Custom:
+		return p.Custom.GetKind() + " " + p.Custom.GetPath()
+	}
+	return ""
+}
+
+// diffSorted returns the elements only in a and only in b.
+func diffSorted(a, b []string) (onlyA, onlyB []string) {
+	seen := make(map[string]int)
+	for _, s := range a {
+		seen[s]++
+	}
+	for _, s := range b {
+		seen[s]--
+	}
+	for _, s := range a {
+		if seen[s] > 0 {
+			onlyA = append(onlyA, s)
+		}
+	}
+	for _, s := range b {
+		if seen[s] < 0 {
+			onlyB = append(onlyB, s)
+		}
+	}
+	return onlyA, onlyB
+}
+
+var refPattern = regexp.MustCompile(`"\$ref":\s*"#/components/(schemas|responses)/([^"]+)"`)
+
+func TestOpenAPI_Valid(t *testing.T) {
+	doc := loadOpenAPI(t)
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Errorf("openapi = %q, want 3.x", doc.OpenAPI)
+	}
+	for _, m := range refPattern.FindAllStringSubmatch(string(OpenAPISpec), -1) {
+		defs := doc.Components.Schemas
+		if m[1] == "responses" {
+			defs = doc.Components.Responses
+		}
+		if _, ok := defs[m[2]]; !ok {
+			t.Errorf("unresolved $ref #/components/%s/%s", m[1], m[2])
+		}
+	}
+	// Path parameters must be declared on every operation that uses them.
+	for path, ops := range doc.Paths {
+		for method, raw := range ops {
+			var op struct {
+				OperationID string `json:"operationId"`
+				Parameters  []struct {
+					Name string `json:"name"`
+					In   string `json:"in"`
+				} `json:"parameters"`
+			}
+			if err := json.Unmarshal(raw, &op); err != nil {
+				t.Fatalf("%s %s: %v", method, path, err)
+			}
+			if op.OperationID == "" {
+				t.Errorf("%s %s has no operationId", method, path)
+			}
+			for _, seg := range regexp.MustCompile(`\{(\w+)\}`).FindAllStringSubmatch(path, -1) {
+				found := false
+				for _, p := range op.Parameters {
+					found = found || (p.In == "path" && p.Name == seg[1])
+				}
+				if !found {
+					t.Errorf("%s %s does not declare path parameter %s", method, path, seg[1])
+				}
+			}
+		}
+	}
+}
+
+func TestHandleOpenAPI(t *testing.T) {
+	_, _, h := newTestServer()
+	rec := doJSON(t, h, "GET", "/v1/openapi.json", nil)
+	requireStatus(t, rec, http.StatusOK)
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if rec.Body.String() != string(OpenAPISpec) {
+		t.Error("served document differs from the embedded spec")
+	}
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// outboxBatch is how many unpublished events one dispatch query fetches.
+const outboxBatch = 100
+
+// recordEvent writes an event to the outbox through st. Called with a
+// transaction's store, the event commits or rolls back with the mutation it
+// describes; dispatchEvents publishes it after the commit.
+func (s *BeadsServer) recordEvent(ctx context.Context, st store.Store, topic, beadID, actor string, event any) error {
+	return s.recordEventNotifying(ctx, st, topic, beadID, actor, event, nil)
+}
+
+// recordEventNotifying is recordEvent that also notifies actors of the
+// event, besides the bead's watchers.
+func (s *BeadsServer) recordEventNotifying(ctx context.Context, st store.Store, topic, beadID, actor string, event any, notify []string) error {
+	payload, err := events.Marshal(topic, event)
+	if err != nil {
+		return fmt.Errorf("marshal %s event: %w", topic, err)
+	}
+	e := &model.Event{
+		Topic:   topic,
+		BeadID:  beadID,
+		Actor:   s.actorFor(ctx, actor),
+		Payload: payload,
+	}
+	if err := st.RecordEvent(ctx, e); err != nil {
+		return fmt.Errorf("record %s event: %w", topic, err)
+	}
+	if len(notify) > 0 {
+		if err := st.NotifyActors(ctx, e.ID, notify); err != nil {
+			return fmt.Errorf("notify %s event: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+// dispatchEvents publishes unpublished events, then evaluates rules against
+// the ones it sent. Rules run outside the outbox lock because the changes
+// they make dispatch events of their own.
+func (s *BeadsServer) dispatchEvents(ctx context.Context) (int, error) {
+	sent, err := s.publishPending(ctx)
+	s.applyRules(ctx, sent)
+	return len(sent), err
+}
+
+// publishPending publishes unpublished events in sequence order, sending
+// each to the publisher and to event stream clients before marking it
+// published, and returns those it sent. It stops at the first failure so
+// later events never overtake it; the next pass retries from there. A crash
+// between publishing and marking means the event is published again, so
+// delivery is at-least-once.
+func (s *BeadsServer) publishPending(ctx context.Context) ([]*model.Event, error) {
+	s.outboxMu.Lock()
+	defer s.outboxMu.Unlock()
+
+	var sent []*model.Event
+	for {
+		pending, err := s.store.ListUnpublishedEvents(ctx, outboxBatch)
+		if err != nil {
+			return sent, fmt.Errorf("list unpublished events: %w", err)
+		}
+		for _, e := range pending {
+			if err := s.publishEvent(ctx, e); err != nil {
+				return sent, fmt.Errorf("publish event %d: %w", e.ID, err)
+			}
+			if err := s.store.MarkEventPublished(ctx, e.ID); err != nil {
+				return sent, fmt.Errorf("mark event %d published: %w", e.ID, err)
+			}
+			s.hub.broadcast(e)
+			sent = append(sent, e)
+		}
+		if len(pending) < outboxBatch {
+			return sent, nil
+		}
+	}
+}
+
+// publishEvent sends a recorded event to the publisher as its typed value,
+// with its sequence number and actor in the context. A payload that no longer decodes
+// is sent raw rather than holding up the events behind it.
+func (s *BeadsServer) publishEvent(ctx context.Context, e *model.Event) error {
+	event, err := events.Decode(e.Topic, e.Payload)
+	if err != nil {
+		slog.Warn("publishing undecodable event payload as raw JSON", "id", e.ID, "error", err)
+		event = e.Payload
+	}
+	return s.publisher.Publish(events.WithActor(events.WithSequence(ctx, e.ID), e.Actor), e.Topic, event)
+}
+
+// flushEvents dispatches pending events after a mutation commits. Failures
+// are logged; RunOutbox retries them.
+func (s *BeadsServer) flushEvents(ctx context.Context) {
+	if _, err := s.dispatchEvents(context.WithoutCancel(ctx)); err != nil {
+		slog.Warn("event dispatch failed; will retry", "error", err)
+	}
+}
+
+// RunOutbox dispatches events left unpublished by failed publishes or a
+// crash, checking every interval until ctx is cancelled.
+func (s *BeadsServer) RunOutbox(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.dispatchEvents(ctx); err != nil {
+				slog.Error("event dispatch failed", "err", err)
+			} else if n > 0 {
+				slog.Info("dispatched outbox events", "count", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// seqPublisher records published topics and sequence numbers, and fails
+// while failing is set.
+type seqPublisher struct {
+	mu      sync.Mutex
+	failing bool
+	topics  []string
+	seqs    []int64
+	events  []any
+}
+
+func (p *seqPublisher) Publish(ctx context.Context, topic string, event any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failing {
+		return errors.New("broker unavailable")
+	}
+	seq, _ := events.SequenceFrom(ctx)
+	p.topics = append(p.topics, topic)
+	p.seqs = append(p.seqs, seq)
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *seqPublisher) Close() error { return nil }
+
+// emitEvent records an event as a committed mutation would and dispatches it.
+func emitEvent(t *testing.T, srv *BeadsServer, ctx context.Context, topic, beadID, actor string, event any) {
+	t.Helper()
+	if err := srv.recordEvent(ctx, srv.store, topic, beadID, actor, event); err != nil {
+		t.Fatal(err)
+	}
+	srv.flushEvents(ctx)
+}
+
+func TestDispatchEvents_PublishesOnceInOrder(t *testing.T) {
+	srv, ms, h := newTestServer()
+	pub := &seqPublisher{}
+	srv.publisher = pub
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads", map[string]any{"title": "Outbox", "type": "task"}), http.StatusCreated)
+	emitEvent(t, srv, context.Background(), events.TopicCommentAdded, "bd-x", "alice", map[string]string{})
+
+	if len(pub.seqs) != 2 || pub.seqs[0] != 1 || pub.seqs[1] != 2 || pub.topics[0] != events.TopicBeadCreated {
+		t.Fatalf("published %v %v", pub.topics, pub.seqs)
+	}
+	// Publishers such as the Slack bridge switch on the event type.
+	if created, ok := pub.events[0].(events.BeadCreated); !ok || created.Bead.Title != "Outbox" {
+		t.Errorf("published %T %+v, want events.BeadCreated", pub.events[0], pub.events[0])
+	}
+	if n, err := srv.dispatchEvents(context.Background()); err != nil || n != 0 {
+		t.Fatalf("second dispatch sent %d (err %v), want 0", n, err)
+	}
+	if !ms.published[1] || !ms.published[2] {
+		t.Errorf("published = %v", ms.published)
+	}
+}
+
+func TestDispatchEvents_RetriesAfterFailure(t *testing.T) {
+	srv, ms, _ := newTestServer()
+	pub := &seqPublisher{failing: true}
+	srv.publisher = pub
+	ctx := context.Background()
+
+	emitEvent(t, srv, ctx, events.TopicBeadUpdated, "bd-r", "alice", map[string]string{})
+	emitEvent(t, srv, ctx, events.TopicBeadClosed, "bd-r", "alice", map[string]string{})
+	if len(ms.events) != 2 || len(ms.published) != 0 {
+		t.Fatalf("events = %d, published = %v; want 2 recorded, none published", len(ms.events), ms.published)
+	}
+
+	pub.failing = false
+	n, err := srv.dispatchEvents(ctx)
+	if err != nil || n != 2 {
+		t.Fatalf("dispatch sent %d (err %v), want 2", n, err)
+	}
+	if pub.topics[0] != events.TopicBeadUpdated || pub.topics[1] != events.TopicBeadClosed {
+		t.Errorf("published out of order: %v", pub.topics)
+	}
+}
+
+func TestCreateBead_FailsWhenEventIsNotRecorded(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.recordEventErr = errors.New("disk full")
+
+	rec := doJSON(t, h, "POST", "/v1/beads", map[string]any{"title": "Lost", "type": "task"})
+	requireStatus(t, rec, http.StatusInternalServerError)
+}
+
+func TestMutations_FailWhenEventIsNotRecorded(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-a"] = &model.Bead{ID: "bd-a", Title: "A", Status: model.StatusOpen}
+	ms.beads["bd-b"] = &model.Bead{ID: "bd-b", Title: "B", Status: model.StatusOpen}
+	ms.configs["view:inbox"] = &model.Config{Key: "view:inbox", Value: json.RawMessage(`{"filter":{}}`)}
+	ms.configs["subscription:alice:open"] = &model.Config{Key: "subscription:alice:open", Value: json.RawMessage(`{"filter":{}}`)}
+	ms.recordEventErr = errors.New("disk full")
+
+	for _, tc := range []struct {
+		method, path string
+		body         any
+	}{
+		{"POST", "/v1/beads/bd-a/dependencies", map[string]any{"depends_on_id": "bd-b", "type": "blocks"}},
+		{"DELETE", "/v1/beads/bd-a/dependencies?depends_on_id=bd-b&type=blocks", nil},
+		{"POST", "/v1/beads/bd-a/labels", map[string]any{"label": "backend"}},
+		{"DELETE", "/v1/beads/bd-a/labels/backend", nil},
+		{"POST", "/v1/beads/bd-a/comments", map[string]any{"text": "hi"}},
+		{"POST", "/v1/beads/bd-a/notes", map[string]any{"text": "note"}},
+		{"PUT", "/v1/configs/view:ci", map[string]any{"value": map[string]any{"filter": map[string]any{}}}},
+		{"DELETE", "/v1/configs/view:inbox", nil},
+		{"GET", "/v1/digests/alice:open", nil},
+	} {
+		rec := doJSON(t, h, tc.method, tc.path, tc.body)
+		if rec.Code < 400 {
+			t.Errorf("%s %s = %d, want an error", tc.method, tc.path, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/email"
+)
+
+// prefNamespace is the config namespace of per-actor preferences, keyed
+// by actor and preference name, e.g. "pref:alice:columns".
+const prefNamespace = "pref"
+
+// prefNames are the preferences bd applies as defaults on startup, then
+// those of email notifications.
+var prefNames = []string{"columns", "sort", "theme", "limit", email.PrefAddress, email.PrefNotify, email.PrefBatch}
+
+// prefThemes are the values of the theme preference.
+var prefThemes = []string{"auto", "color", "none"}
+
+// prefKey returns the config key of actor's preference name.
+func prefKey(actor, name string) string {
+	return prefNamespace + ":" + actor + ":" + name
+}
+
+// validatePref checks the value of preference name. Returns inputError.
+func validatePref(name string, value json.RawMessage) error {
+	bad := func(want string) error {
+		return inputError("invalid " + name + " preference: want " + want)
+	}
+	switch name {
+	case "columns":
+		var cols []string
+		if json.Unmarshal(value, &cols) != nil || len(cols) == 0 || slices.Contains(cols, "") {
+			return bad("a list of column names")
+		}
+	case "sort":
+		var s string
+		if json.Unmarshal(value, &s) != nil || strings.TrimSpace(s) == "" {
+			return bad("a sort key")
+		}
+	case "theme":
+		var s string
+		if json.Unmarshal(value, &s) != nil || !slices.Contains(prefThemes, s) {
+			return bad("one of " + strings.Join(prefThemes, ", "))
+		}
+	case "limit":
+		var n int32
+		if json.Unmarshal(value, &n) != nil || n <= 0 {
+			return bad("a positive integer")
+		}
+	case email.PrefAddress:
+		var s string
+		if json.Unmarshal(value, &s) != nil {
+			return bad("an email address")
+		}
+		if a, err := mail.ParseAddress(s); err != nil || a.Name != "" {
+			return bad("an email address")
+		}
+	case email.PrefNotify:
+		var kinds []email.Kind
+		if json.Unmarshal(value, &kinds) != nil || len(kinds) == 0 ||
+			slices.ContainsFunc(kinds, func(k email.Kind) bool { return !slices.Contains(email.Kinds, k) }) {
+			names := make([]string, len(email.Kinds))
+			for i, k := range email.Kinds {
+				names[i] = string(k)
+			}
+			return bad("a list of " + strings.Join(names, ", "))
+		}
+	case email.PrefBatch:
+		var s string
+		if json.Unmarshal(value, &s) != nil {
+			return bad("a duration such as 1h")
+		}
+		if d, err := time.ParseDuration(s); err != nil || d <= 0 {
+			return bad("a duration such as 1h")
+		}
+	default:
+		return inputError("unknown preference " + strconv.Quote(name) + " (want one of " + strings.Join(prefNames, ", ") + ")")
+	}
+	return nil
+}
+
+// validatePrefConfig checks a "pref:<actor>:<name>" config set through the
+// generic config API.
+func validatePrefConfig(key string, value json.RawMessage) error {
+	rest := strings.TrimPrefix(key, prefNamespace+":")
+	i := strings.LastIndex(rest, ":")
+	if i <= 0 {
+		return inputError("preference keys look like pref:<actor>:<name>")
+	}
+	return validatePref(rest[i+1:], value)
+}
+
+// prefActor returns whose preferences a request reads or writes: the
+// caller's identity if authenticated, so nobody can change another actor's
+// preferences, or else claimed.
+func (s *BeadsServer) prefActor(ctx context.Context, claimed string) (string, error) {
+	a := s.actorFor(ctx, claimed)
+	if a == "" || strings.Contains(a, ":") {
+		return "", inputError("an actor without colons is required")
+	}
+	return a, nil
+}
+
+// listPrefs returns actor's preferences by name.
+func (s *BeadsServer) listPrefs(ctx context.Context, actor string) (map[string]json.RawMessage, error) {
+	prefix := prefKey(actor, "")
+	configs, err := s.store.ListConfigs(ctx, strings.TrimSuffix(prefix, ":"))
+	if err != nil {
+		return nil, err
+	}
+	prefs := map[string]json.RawMessage{}
+	for _, c := range configs {
+		// LIKE treats _ in the actor as a wildcard; keep exact matches only.
+		if name, ok := strings.CutPrefix(c.Key, prefix); ok && !strings.Contains(name, ":") {
+			prefs[name] = c.Value
+		}
+	}
+	return prefs, nil
+}
+
+// prefsView is the response of the /v1/prefs endpoints.
+type prefsView struct {
+	Actor string                     `json:"actor"`
+	Prefs map[string]json.RawMessage `json:"prefs"`
+}
+
+// writePrefs writes actor's preferences, or the error from reading or
+// changing them.
+func (s *BeadsServer) writePrefs(w http.ResponseWriter, r *http.Request, actor string, err error) {
+	var prefs map[string]json.RawMessage
+	if err == nil {
+		prefs, err = s.listPrefs(r.Context(), actor)
+	}
+	var ie inputError
+	switch {
+	case errors.As(err, &ie):
+		writeError(w, http.StatusBadRequest, ie.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "preference not set")
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "failed to read preferences")
+	default:
+		writeJSON(w, http.StatusOK, prefsView{Actor: actor, Prefs: prefs})
+	}
+}
+
+// handleListPrefs handles GET /v1/prefs.
+func (s *BeadsServer) handleListPrefs(w http.ResponseWriter, r *http.Request) {
+	actor, err := s.prefActor(r.Context(), r.URL.Query().Get("actor"))
+	s.writePrefs(w, r, actor, err)
+}
+
+// setPrefRequest is the JSON body for PUT /v1/prefs/{name}.
+type setPrefRequest struct {
+	Value json.RawMessage `json:"value"`
+	Actor string          `json:"actor"`
+}
+
+// handleSetPref handles PUT /v1/prefs/{name}.
+func (s *BeadsServer) handleSetPref(w http.ResponseWriter, r *http.Request) {
+	var req setPrefRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	actor, err := s.prefActor(r.Context(), req.Actor)
+	if err == nil {
+		_, err = s.setConfig(r.Context(), prefKey(actor, r.PathValue("name")), req.Value, actor)
+	}
+	s.writePrefs(w, r, actor, err)
+}
+
+// handleDeletePref handles DELETE /v1/prefs/{name}.
+func (s *BeadsServer) handleDeletePref(w http.ResponseWriter, r *http.Request) {
+	actor, err := s.prefActor(r.Context(), r.URL.Query().Get("actor"))
+	if err == nil {
+		err = s.deleteConfig(r.Context(), prefKey(actor, r.PathValue("name")))
+	}
+	s.writePrefs(w, r, actor, err)
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPrefs_SetListDelete(t *testing.T) {
+	_, ms, h := newTestServer()
+
+	rec := doJSON(t, h, "PUT", "/v1/prefs/columns", map[string]any{"actor": "alice", "value": []string{"id", "title"}})
+	requireStatus(t, rec, 200)
+	rec = doJSON(t, h, "PUT", "/v1/prefs/limit", map[string]any{"actor": "alice", "value": 50})
+	requireStatus(t, rec, 200)
+	rec = doJSON(t, h, "PUT", "/v1/prefs/theme", map[string]any{"actor": "al_ce", "value": "none"})
+	requireStatus(t, rec, 200)
+	if ms.configs["pref:alice:columns"] == nil {
+		t.Fatal("expected the preference stored as pref:alice:columns")
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/prefs?actor=alice", nil)
+	requireStatus(t, rec, 200)
+	var view prefsView
+	decodeJSON(t, rec, &view)
+	if view.Actor != "alice" || len(view.Prefs) != 2 || string(view.Prefs["limit"]) != "50" {
+		t.Fatalf("unexpected prefs: %+v", view)
+	}
+
+	rec = doJSON(t, h, "DELETE", "/v1/prefs/limit?actor=alice", nil)
+	requireStatus(t, rec, 200)
+	view = prefsView{}
+	decodeJSON(t, rec, &view)
+	if _, ok := view.Prefs["limit"]; ok || len(view.Prefs) != 1 {
+		t.Fatalf("limit still set after delete: %+v", view)
+	}
+	rec = doJSON(t, h, "DELETE", "/v1/prefs/limit?actor=alice", nil)
+	requireStatus(t, rec, 404)
+}
+
+func TestPrefs_Invalid(t *testing.T) {
+	_, _, h := newTestServer()
+	for name, value := range map[string]any{
+		"columns": "id,title",
+		"sort":    "",
+		"theme":   "neon",
+		"limit":   0,
+		"pager":   "less",
+
+		"email":        "not an address",
+		"email_notify": []string{"assigned", "everything"},
+		"email_batch":  "-1h",
+	} {
+		rec := doJSON(t, h, "PUT", "/v1/prefs/"+name, map[string]any{"actor": "alice", "value": value})
+		requireStatus(t, rec, 400)
+	}
+	rec := doJSON(t, h, "PUT", "/v1/prefs/limit", map[string]any{"value": 10})
+	requireStatus(t, rec, 400)
+
+	// The generic config API validates the namespace too.
+	rec = doJSON(t, h, "PUT", "/v1/configs/pref:alice:theme", map[string]any{"value": "neon"})
+	requireStatus(t, rec, 400)
+}
+
+func TestPrefs_IdentityWins(t *testing.T) {
+	s, ms, _ := newTestServer()
+	req := httptest.NewRequest("PUT", "/v1/prefs/sort", bytes.NewReader([]byte(`{"actor":"bob","value":"-priority"}`)))
+	req = req.WithContext(withIdentity(req.Context(), "alice"))
+	req.SetPathValue("name", "sort")
+	rec := httptest.NewRecorder()
+	s.handleSetPref(rec, req)
+	requireStatus(t, rec, 200)
+	if ms.configs["pref:bob:sort"] != nil || ms.configs["pref:alice:sort"] == nil {
+		t.Fatal("an authenticated caller must only write their own preferences")
+	}
+}
+
+func TestPrefs_Email(t *testing.T) {
+	_, ms, h := newTestServer()
+	for name, value := range map[string]any{
+		"email":        "alice@example.com",
+		"email_notify": []string{"assigned", "overdue"},
+		"email_batch":  "1h",
+	} {
+		rec := doJSON(t, h, "PUT", "/v1/prefs/"+name, map[string]any{"actor": "alice", "value": value})
+		requireStatus(t, rec, 200)
+	}
+	if got := string(ms.configs["pref:alice:email_notify"].Value); got != `["assigned","overdue"]` {
+		t.Errorf("email_notify = %s", got)
+	}
+}
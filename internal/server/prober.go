@@ -0,0 +1,238 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// proberActor is recorded on the events made when a probe satisfies an
+// external dependency.
+const proberActor = "beads:prober"
+
+// defaultGitHubAPI is used for github-pr probes when no API URL is set.
+const defaultGitHubAPI = "https://api.github.com"
+
+// probeTimeout bounds each probe request.
+const probeTimeout = 10 * time.Second
+
+// defaultProbeClient is the client probes use unless SetExternalProber
+// sets one. Dependency URLs come from any caller who can attach one, so it
+// does not use a proxy and refuses to connect to internal addresses.
+var defaultProbeClient = &http.Client{Transport: probeTransport()}
+
+func probeTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	t.DialContext = (&net.Dialer{Timeout: probeTimeout, Control: refuseInternal}).DialContext
+	return t
+}
+
+// refuseInternal is a net.Dialer Control that refuses loopback, private,
+// link-local and unspecified addresses, such as a cloud metadata service.
+// It sees the address after name resolution and on every redirect.
+func refuseInternal(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("probes may not connect to internal address %s", ip)
+	}
+	return nil
+}
+
+// SetExternalProber sets the client external dependency probes use and the
+// token sent to the GitHub API, which may be empty for public
+// repositories. A nil client refuses to connect to internal addresses.
+func (s *BeadsServer) SetExternalProber(client *http.Client, githubToken string) {
+	s.probeClient = client
+	s.githubToken = githubToken
+}
+
+// RunExternalProber probes the waiting external dependencies that have a
+// probe every interval until ctx is cancelled.
+func (s *BeadsServer) RunExternalProber(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.ProbeExternalDeps(ctx, time.Now().UTC()); err != nil {
+				slog.Error("external prober failed", "err", err)
+			} else if n > 0 {
+				slog.Info("satisfied external dependencies", "count", n)
+			}
+		}
+	}
+}
+
+// ProbeExternalDeps runs the probe of every waiting external dependency,
+// marks those that succeed satisfied, and records when each was checked and
+// why it failed. Returns the number satisfied.
+func (s *BeadsServer) ProbeExternalDeps(ctx context.Context, now time.Time) (int, error) {
+	deps, err := s.store.ListProbedExternalDeps(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("listing external dependencies: %w", err)
+	}
+	satisfied := 0
+	for _, d := range deps {
+		if ctx.Err() != nil {
+			return satisfied, ctx.Err()
+		}
+		perr := s.probe(ctx, d)
+		done := false
+		err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+			// The dependency may have been satisfied by hand or removed
+			// while the probe ran; the result then no longer applies.
+			cur, err := findExternalDep(ctx, tx, d.BeadID, d.ID)
+			if err != nil || cur.Status != model.ExternalWaiting {
+				return err
+			}
+			cur.CheckedAt, cur.LastError = &now, ""
+			if perr != nil {
+				cur.LastError = perr.Error()
+			} else {
+				cur.Status = model.ExternalSatisfied
+			}
+			if err := tx.UpdateExternalDep(ctx, cur); err != nil {
+				return err
+			}
+			if perr != nil {
+				return nil
+			}
+			done = true
+			return s.recordEvent(ctx, tx, events.TopicExternalUpdated, cur.BeadID, proberActor, events.ExternalUpdated{Dep: cur, UpdatedBy: proberActor})
+		})
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+		case err != nil:
+			slog.Warn("failed to record external probe", "bead", d.BeadID, "url", d.URL, "err", err)
+		case done:
+			satisfied++
+		}
+	}
+	if satisfied > 0 {
+		s.flushEvents(ctx)
+	}
+	return satisfied, nil
+}
+
+// probe returns nil if d's resource is ready, or why it is not.
+func (s *BeadsServer) probe(ctx context.Context, d *model.ExternalDep) error {
+	switch d.Probe {
+	case model.ProbeHTTP:
+		_, err := s.probeGet(ctx, d.URL, nil)
+		return err
+	case model.ProbeGitHubPR:
+		return s.probePullRequest(ctx, d.URL)
+	default:
+		return fmt.Errorf("unknown probe %q", d.Probe)
+	}
+}
+
+// probeGet GETs rawURL and returns its body, or an error unless it answers
+// 200.
+func (s *BeadsServer) probeGet(ctx context.Context, rawURL string, header http.Header) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return nil, fmt.Errorf("probes need an http or https URL")
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	client := s.probeClient
+	if client == nil {
+		client = defaultProbeClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", rawURL, resp.Status)
+	}
+	return body, nil
+}
+
+// probePullRequest returns nil once the GitHub pull request at prURL is
+// merged.
+func (s *BeadsServer) probePullRequest(ctx context.Context, prURL string) error {
+	owner, repo, number, err := parsePullRequestURL(prURL)
+	if err != nil {
+		return err
+	}
+	api := s.githubAPI
+	if api == "" {
+		api = defaultGitHubAPI
+	}
+	header := http.Header{"Accept": {"application/vnd.github+json"}}
+	if s.githubToken != "" {
+		header.Set("Authorization", "Bearer "+s.githubToken)
+	}
+	body, err := s.probeGet(ctx, fmt.Sprintf("%s/repos/%s/%s/pulls/%s", strings.TrimSuffix(api, "/"), owner, repo, number), header)
+	if err != nil {
+		return err
+	}
+	var pr struct {
+		Merged bool   `json:"merged"`
+		State  string `json:"state"`
+	}
+	if err := json.Unmarshal(body, &pr); err != nil {
+		return fmt.Errorf("invalid GitHub response: %w", err)
+	}
+	if !pr.Merged {
+		return fmt.Errorf("pull request is %s, not merged", pr.State)
+	}
+	return nil
+}
+
+// parsePullRequestURL splits https://github.com/{owner}/{repo}/pull/{n}.
+func parsePullRequestURL(rawURL string) (owner, repo, number string, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", "", err
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if u.Host != "github.com" || len(parts) < 4 || parts[2] != "pull" || parts[0] == "" || parts[1] == "" || parts[3] == "" {
+		return "", "", "", fmt.Errorf("github-pr probe needs a https://github.com/<owner>/<repo>/pull/<n> URL")
+	}
+	for _, c := range parts[3] {
+		if c < '0' || c > '9' {
+			return "", "", "", fmt.Errorf("github-pr probe needs a https://github.com/<owner>/<repo>/pull/<n> URL")
+		}
+	}
+	return parts[0], parts[1], parts[3], nil
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// popQueue claims the most urgent ready bead that actor may work on: one that
+// is unassigned or already assigned to actor and, when labels is non-empty,
+// carries at least one of them. The claim and its BeadUpdated event commit
+// together. Returns sql.ErrNoRows if no bead qualifies.
+func (s *BeadsServer) popQueue(ctx context.Context, actor string, labels []string) (*model.Bead, error) {
+	if actor == "" {
+		return nil, inputError("actor is required")
+	}
+	var bead *model.Bead
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		claimed, err := tx.ClaimReadyBead(ctx, actor, labels)
+		if err != nil {
+			return err
+		}
+		if bead, err = tx.GetBead(ctx, claimed.ID); err != nil {
+			return fmt.Errorf("loading claimed bead: %w", err)
+		}
+		if bead == nil {
+			return sql.ErrNoRows
+		}
+		return s.recordEvent(ctx, tx, events.TopicBeadUpdated, bead.ID, actor, events.BeadUpdated{
+			Bead:    bead,
+			Changes: map[string]any{"status": string(model.StatusInProgress), "assignee": actor},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return bead, nil
+}
+
+// splitLabels splits a comma-separated label list, dropping empty entries.
+func splitLabels(v string) []string {
+	var labels []string
+	for _, l := range strings.Split(v, ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
+// handlePopQueue handles POST /v1/queue/next?actor=&labels=. It responds
+// 204 No Content when no ready bead matches.
+func (s *BeadsServer) handlePopQueue(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	actor := s.actorFor(r.Context(), q.Get("actor"))
+	bead, err := s.popQueue(r.Context(), actor, splitLabels(q.Get("labels")))
+	if err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, bead)
+}
+
+// PopQueue claims the most urgent ready bead matching the actor's labels.
+// The response carries no bead when the queue is empty.
+func (s *BeadsServer) PopQueue(ctx context.Context, req *beadsv1.PopQueueRequest) (*beadsv1.PopQueueResponse, error) {
+	actor := s.actorFor(ctx, req.GetActor())
+	if actor == "" {
+		return nil, status.Error(codes.InvalidArgument, "actor is required")
+	}
+	bead, err := s.popQueue(ctx, actor, req.GetLabels())
+	if errors.Is(err, sql.ErrNoRows) {
+		return &beadsv1.PopQueueResponse{}, nil
+	}
+	if err != nil {
+		return nil, storeError(err, "bead")
+	}
+	return &beadsv1.PopQueueResponse{Bead: beadToProto(bead)}, nil
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestHandlePopQueue(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedReady(ms)
+	ms.beads["bd-r3"].Assignee = "bob"
+
+	// bd-r3 is most urgent but assigned to someone else.
+	rec := doJSON(t, h, "POST", "/v1/queue/next?actor=alice", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var got model.Bead
+	decodeJSON(t, rec, &got)
+	if got.ID != "bd-r1" || got.Status != model.StatusInProgress || got.Assignee != "alice" {
+		t.Fatalf("popped %+v, want bd-r1 in_progress for alice", got)
+	}
+	if len(got.Labels) != 1 || got.Labels[0] != "backend" {
+		t.Errorf("labels = %v, want [backend]", got.Labels)
+	}
+
+	last := ms.events[len(ms.events)-1]
+	if last.Topic != events.TopicBeadUpdated || last.BeadID != "bd-r1" || last.Actor != "alice" {
+		t.Errorf("event = %+v", last)
+	}
+
+	// bd-r4 is ready but carries none of the requested labels.
+	requireStatus(t, doJSON(t, h, "POST", "/v1/queue/next?actor=alice&labels=backend,frontend", nil), http.StatusNoContent)
+
+	rec = doJSON(t, h, "POST", "/v1/queue/next?actor=alice", nil)
+	requireStatus(t, rec, http.StatusOK)
+	got = model.Bead{}
+	decodeJSON(t, rec, &got)
+	if got.ID != "bd-r4" {
+		t.Fatalf("popped %s, want bd-r4", got.ID)
+	}
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/queue/next?actor=alice", nil), http.StatusNoContent)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/queue/next", nil), http.StatusBadRequest)
+}
+
+func TestGRPCPopQueue(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	seedReady(ms)
+
+	resp, err := srv.PopQueue(ctx, &beadsv1.PopQueueRequest{Actor: "alice", Labels: []string{"backend"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := resp.GetBead(); b.GetId() != "bd-r1" || b.GetAssignee() != "alice" || b.GetStatus() != "in_progress" {
+		t.Fatalf("popped %+v, want bd-r1", b)
+	}
+
+	resp, err = srv.PopQueue(ctx, &beadsv1.PopQueueRequest{Actor: "alice", Labels: []string{"backend"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetBead() != nil {
+		t.Fatalf("expected an empty queue, got %+v", resp.GetBead())
+	}
+
+	_, err = srv.PopQueue(ctx, &beadsv1.PopQueueRequest{})
+	requireCode(t, err, codes.InvalidArgument)
+}
+
+func TestHandlePopQueue_SkipsDataBeads(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-d1"] = &model.Bead{ID: "bd-d1", Kind: model.KindData, Type: "decision", Title: "Pick a DB", Status: model.StatusOpen}
+	ms.beads["bd-g1"] = &model.Bead{ID: "bd-g1", Kind: model.KindData, Type: "gate", Title: "tests gate for bot", Status: model.StatusOpen}
+	ms.beads["bd-t1"] = &model.Bead{ID: "bd-t1", Kind: model.KindIssue, Type: model.TypeTask, Title: "Real work", Status: model.StatusOpen, Priority: 3}
+
+	rec := doJSON(t, h, "POST", "/v1/queue/next?actor=alice", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var got model.Bead
+	decodeJSON(t, rec, &got)
+	if got.ID != "bd-t1" {
+		t.Fatalf("popped %s, want bd-t1", got.ID)
+	}
+	requireStatus(t, doJSON(t, h, "POST", "/v1/queue/next?actor=alice", nil), http.StatusNoContent)
+}
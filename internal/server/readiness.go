@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// Kinds of readinessReason.
+const (
+	reasonStatus   = "status"   // the bead is not open
+	reasonDeferred = "deferred" // the bead is deferred, until defer_until if set
+	reasonArchived = "archived" // hidden from listings by the archival policy
+	reasonBlocker  = "blocker"  // an unclosed bead blocks it
+	reasonGate     = "gate"     // an unsatisfied gate bead blocks it
+	reasonExternal = "external" // it waits on an external dependency
+	reasonAssigned = "assigned" // it is ready, but another actor holds it
+	reasonKind     = "kind"     // it is ready, but bd claim never takes its kind or type
+)
+
+// readinessReason is one thing keeping a bead out of the ready list or
+// from being claimed.
+type readinessReason struct {
+	Kind    string       `json:"kind"`
+	Message string       `json:"message"`
+	BeadID  string       `json:"bead_id,omitempty"` // the blocker or gate
+	Title   string       `json:"title,omitempty"`
+	Status  model.Status `json:"status,omitempty"`
+	URL     string       `json:"url,omitempty"` // the external dependency
+	Until   *time.Time   `json:"until,omitempty"`
+}
+
+// readiness explains whether a bead is in the ready list and whether actor
+// could claim it.
+type readiness struct {
+	BeadID    string            `json:"bead_id"`
+	Ready     bool              `json:"ready"`     // listed by /v1/ready
+	Claimable bool              `json:"claimable"` // bd claim could take it for actor
+	Actor     string            `json:"actor,omitempty"`
+	Reasons   []readinessReason `json:"reasons"`
+}
+
+// explainReadiness returns why beadID is or is not ready, using the same
+// rules as listReady and ClaimReadyBead. Claim conflicts are judged for
+// actor. Returns sql.ErrNoRows if the bead does not exist.
+func (s *BeadsServer) explainReadiness(ctx context.Context, beadID, actor string, now time.Time) (*readiness, error) {
+	b, err := s.store.GetBead(ctx, beadID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, sql.ErrNoRows
+	}
+	types, err := s.depTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r := &readiness{BeadID: b.ID, Actor: actor, Reasons: []readinessReason{}}
+	add := func(reason readinessReason) { r.Reasons = append(r.Reasons, reason) }
+
+	switch {
+	case b.Status == model.StatusDeferred:
+		msg := "deferred"
+		if b.DeferUntil != nil {
+			verb := "until"
+			if !b.DeferUntil.After(now) {
+				verb = "since"
+			}
+			msg = fmt.Sprintf("deferred %s %s", verb, b.DeferUntil.Format(time.RFC3339))
+		}
+		add(readinessReason{Kind: reasonDeferred, Message: msg, Status: b.Status, Until: b.DeferUntil})
+	case b.Status != model.StatusOpen:
+		add(readinessReason{Kind: reasonStatus, Message: fmt.Sprintf("status is %s, not open", b.Status), Status: b.Status})
+	}
+	if b.ArchivedAt != nil {
+		add(readinessReason{Kind: reasonArchived, Message: "archived " + b.ArchivedAt.Format(time.RFC3339), Until: b.ArchivedAt})
+	}
+
+	deps, err := s.store.GetDependencies(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deps {
+		if !types.blocking(d.Type) {
+			continue
+		}
+		blocker, err := s.store.GetBead(ctx, d.DependsOnID)
+		if err != nil || blocker == nil || blocker.Status == model.StatusClosed {
+			continue
+		}
+		reason := readinessReason{Kind: reasonBlocker, BeadID: blocker.ID, Title: blocker.Title, Status: blocker.Status,
+			Message: fmt.Sprintf("blocked by %s (%s): %s", blocker.ID, blocker.Status, blocker.Title)}
+		if blocker.Type == "gate" {
+			reason.Kind = reasonGate
+			reason.Message = fmt.Sprintf("gate %s is not satisfied: %s", blocker.ID, blocker.Title)
+		}
+		add(reason)
+	}
+	external, err := s.store.GetExternalDeps(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, x := range external {
+		if x.Status != model.ExternalWaiting {
+			continue
+		}
+		msg := "waiting on " + x.URL
+		if x.LastError != "" {
+			msg += " (" + x.LastError + ")"
+		}
+		add(readinessReason{Kind: reasonExternal, Message: msg, URL: x.URL})
+	}
+	r.Ready = len(r.Reasons) == 0
+
+	// An open bead is listed even with defer_until in the future. Say so,
+	// since whoever set it likely meant the bead to wait.
+	if b.DeferUntil != nil && b.DeferUntil.After(now) && b.Status == model.StatusOpen {
+		until := *b.DeferUntil
+		add(readinessReason{Kind: reasonDeferred, Message: "defer_until is " + until.Format(time.RFC3339) + ", but the bead is open and still listed", Until: &until})
+	}
+	if r.Ready {
+		switch {
+		case b.Kind != model.KindIssue || b.Type == "gate":
+			add(readinessReason{Kind: reasonKind, Message: fmt.Sprintf("%s %s beads are never claimed", b.Kind, b.Type)})
+		case b.Assignee != "" && b.Assignee != actor:
+			add(readinessReason{Kind: reasonAssigned, Message: "assigned to " + b.Assignee})
+		default:
+			r.Claimable = true
+		}
+	}
+	return r, nil
+}
+
+// handleGetReadiness handles GET /v1/beads/{id}/readiness. Claim conflicts
+// are judged for the caller's identity, or else the actor query parameter.
+func (s *BeadsServer) handleGetReadiness(w http.ResponseWriter, r *http.Request) {
+	actor := s.actorFor(r.Context(), r.URL.Query().Get("actor"))
+	rd, err := s.explainReadiness(r.Context(), r.PathValue("id"), actor, time.Now().UTC())
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, "bead not found")
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "failed to explain readiness")
+	default:
+		writeJSON(w, http.StatusOK, rd)
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestExplainReadiness(t *testing.T) {
+	s, ms, h := newTestServer()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	later := now.Add(24 * time.Hour)
+
+	ms.beads["bd-work"] = &model.Bead{ID: "bd-work", Title: "Work", Kind: model.KindIssue, Type: "task", Status: model.StatusOpen}
+	ms.beads["bd-dep"] = &model.Bead{ID: "bd-dep", Title: "Schema change", Kind: model.KindIssue, Type: "task", Status: model.StatusInProgress}
+	ms.beads["bd-gate"] = &model.Bead{ID: "bd-gate", Title: "tests-passed", Kind: model.KindIssue, Type: "gate", Status: model.StatusOpen}
+	ms.beads["bd-done"] = &model.Bead{ID: "bd-done", Title: "Done", Kind: model.KindIssue, Type: "task", Status: model.StatusClosed}
+	ms.deps["bd-work"] = []*model.Dependency{
+		{BeadID: "bd-work", DependsOnID: "bd-dep", Type: model.DepBlocks},
+		{BeadID: "bd-work", DependsOnID: "bd-gate", Type: model.DepBlocks},
+		{BeadID: "bd-work", DependsOnID: "bd-done", Type: model.DepBlocks},
+	}
+	ms.externals["bd-work"] = []*model.ExternalDep{{ID: 1, BeadID: "bd-work", URL: "https://example.com/up", Status: model.ExternalWaiting}}
+
+	rd, err := s.explainReadiness(ctx, "bd-work", "alice", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rd.Ready || rd.Claimable {
+		t.Fatalf("readiness = %+v, want not ready", rd)
+	}
+	var kinds []string
+	for _, r := range rd.Reasons {
+		kinds = append(kinds, r.Kind)
+	}
+	if len(kinds) != 3 || kinds[0] != reasonBlocker || kinds[1] != reasonGate || kinds[2] != reasonExternal {
+		t.Fatalf("reasons = %+v", rd.Reasons)
+	}
+	if rd.Reasons[0].BeadID != "bd-dep" || rd.Reasons[0].Title != "Schema change" {
+		t.Errorf("blocker = %+v", rd.Reasons[0])
+	}
+
+	// Once unblocked it is ready; another actor's assignment is a claim
+	// conflict, and a future defer_until is reported without changing ready.
+	ms.deps["bd-work"] = nil
+	ms.externals["bd-work"][0].Status = model.ExternalSatisfied
+	ms.beads["bd-work"].Assignee = "bob"
+	ms.beads["bd-work"].DeferUntil = &later
+	rd, err = s.explainReadiness(ctx, "bd-work", "alice", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rd.Ready || rd.Claimable || len(rd.Reasons) != 2 || rd.Reasons[0].Kind != reasonDeferred || rd.Reasons[1].Kind != reasonAssigned {
+		t.Fatalf("readiness = %+v", rd)
+	}
+
+	ms.beads["bd-work"].Status = model.StatusDeferred
+	rd, _ = s.explainReadiness(ctx, "bd-work", "bob", now)
+	if rd.Ready || len(rd.Reasons) == 0 || rd.Reasons[0].Kind != reasonDeferred || rd.Reasons[0].Until == nil {
+		t.Fatalf("deferred readiness = %+v", rd)
+	}
+
+	ms.beads["bd-work"].Status, ms.beads["bd-work"].DeferUntil = model.StatusOpen, nil
+	rec := doJSON(t, h, "GET", "/v1/beads/bd-work/readiness?actor=bob", nil)
+	requireStatus(t, rec, http.StatusOK)
+	decodeJSON(t, rec, &rd)
+	if !rd.Ready || !rd.Claimable || rd.Actor != "bob" || len(rd.Reasons) != 0 {
+		t.Fatalf("readiness = %+v", rd)
+	}
+	rec = doJSON(t, h, "GET", "/v1/beads/bd-none/readiness", nil)
+	requireStatus(t, rec, http.StatusNotFound)
+}
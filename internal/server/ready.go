@@ -0,0 +1,227 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/shadow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// beadPage is one page of a bead listing and the total number of matches.
+type beadPage struct {
+	Beads []*model.Bead `json:"beads"`
+	Total int           `json:"total"`
+}
+
+// blockedBead is a blocked bead with the IDs of its unclosed blockers and
+// the URLs of the external dependencies it is waiting on.
+type blockedBead struct {
+	*model.Bead
+	BlockedBy []string `json:"blocked_by"`
+	WaitingOn []string `json:"waiting_on"`
+}
+
+// blockedPage is one page of blocked beads and the total number of matches.
+type blockedPage struct {
+	Beads []blockedBead `json:"beads"`
+	Total int           `json:"total"`
+}
+
+// listReady returns the beads matching filter that nothing unclosed blocks
+// and that wait on no external dependency, most urgent first. Status defaults to open. The "ready" shadow route
+// compares the store query against scanReady.
+func (s *BeadsServer) listReady(ctx context.Context, filter model.BeadFilter) (beadPage, error) {
+	if len(filter.Status) == 0 {
+		filter.Status = []model.Status{model.StatusOpen}
+	}
+	if filter.Sort == "" {
+		filter.Sort = "priority"
+	}
+	return shadow.Run(ctx, s.shadow, "ready",
+		func(ctx context.Context) (beadPage, error) {
+			beads, total, err := s.store.ListReadyBeads(ctx, filter)
+			return beadPage{Beads: beads, Total: total}, err
+		},
+		func(ctx context.Context) (beadPage, error) {
+			return s.scanReady(ctx, filter)
+		})
+}
+
+// scanReady is the reference readiness computation: it lists every candidate
+// and checks each one's blockers individually. It is far slower than
+// ListReadyBeads and only runs as its shadow.
+func (s *BeadsServer) scanReady(ctx context.Context, filter model.BeadFilter) (beadPage, error) {
+	all := filter
+	all.Limit, all.Offset = 0, 0
+	candidates, _, err := s.store.ListBeads(ctx, all)
+	if err != nil {
+		return beadPage{}, err
+	}
+	types, err := s.depTypes(ctx)
+	if err != nil {
+		return beadPage{}, err
+	}
+
+	var ready []*model.Bead
+	for _, b := range candidates {
+		deps, err := s.store.GetDependencies(ctx, b.ID)
+		if err != nil {
+			return beadPage{}, err
+		}
+		waiting, err := s.waitingOn(ctx, b.ID)
+		if err != nil {
+			return beadPage{}, err
+		}
+		blocked := len(waiting) > 0
+		for _, d := range deps {
+			if !types.blocking(d.Type) {
+				continue
+			}
+			blocker, err := s.store.GetBead(ctx, d.DependsOnID)
+			if err == nil && blocker != nil && blocker.Status != model.StatusClosed {
+				blocked = true
+				break
+			}
+		}
+		if !blocked {
+			ready = append(ready, b)
+		}
+	}
+
+	page := beadPage{Total: len(ready)}
+	if filter.Offset < len(ready) {
+		ready = ready[filter.Offset:]
+		if filter.Limit > 0 && filter.Limit < len(ready) {
+			ready = ready[:filter.Limit]
+		}
+		page.Beads = ready
+	}
+	return page, nil
+}
+
+// handleGetReady handles GET /v1/ready. It accepts the list query parameters.
+func (s *BeadsServer) handleGetReady(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseBeadFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := s.listReady(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list ready beads")
+		return
+	}
+
+	if page.Beads == nil {
+		page.Beads = []*model.Bead{}
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+// ListReadyBeads returns the beads that nothing unclosed blocks.
+func (s *BeadsServer) ListReadyBeads(ctx context.Context, req *beadsv1.ListBeadsRequest) (*beadsv1.ListBeadsResponse, error) {
+	filter := filterFromProto(req)
+	if err := checkQuery(filter); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	page, err := s.listReady(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list ready beads: %v", err)
+	}
+
+	pbBeads := make([]*beadsv1.Bead, 0, len(page.Beads))
+	for _, b := range page.Beads {
+		pbBeads = append(pbBeads, beadToProto(b))
+	}
+
+	return &beadsv1.ListBeadsResponse{
+		Beads: pbBeads,
+		Total: int32(page.Total),
+	}, nil
+}
+
+// listBlocked returns the beads matching filter that something unclosed
+// blocks or that wait on an external dependency, most urgent first, each
+// with its blockers. Status defaults to
+// open, so with the same filter listReady and listBlocked partition the
+// matching beads.
+func (s *BeadsServer) listBlocked(ctx context.Context, filter model.BeadFilter) (blockedPage, error) {
+	if filter.Sort == "" {
+		filter.Sort = "priority"
+	}
+	beads, total, err := s.store.ListBlockedBeads(ctx, filter)
+	if err != nil {
+		return blockedPage{}, err
+	}
+	types, err := s.depTypes(ctx)
+	if err != nil {
+		return blockedPage{}, err
+	}
+
+	page := blockedPage{Beads: make([]blockedBead, 0, len(beads)), Total: total}
+	for _, b := range beads {
+		deps, err := s.store.GetDependencies(ctx, b.ID)
+		if err != nil {
+			return blockedPage{}, err
+		}
+		waiting, err := s.waitingOn(ctx, b.ID)
+		if err != nil {
+			return blockedPage{}, err
+		}
+		bb := blockedBead{Bead: b, BlockedBy: []string{}, WaitingOn: waiting}
+		for _, d := range deps {
+			if !types.blocking(d.Type) {
+				continue
+			}
+			blocker, err := s.store.GetBead(ctx, d.DependsOnID)
+			if err == nil && blocker != nil && blocker.Status != model.StatusClosed {
+				bb.BlockedBy = append(bb.BlockedBy, blocker.ID)
+			}
+		}
+		page.Beads = append(page.Beads, bb)
+	}
+	return page, nil
+}
+
+// handleGetBlocked handles GET /v1/blocked. It accepts the list query
+// parameters.
+func (s *BeadsServer) handleGetBlocked(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseBeadFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := s.listBlocked(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list blocked beads")
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// ListBlockedBeads returns the beads that something unclosed blocks.
+func (s *BeadsServer) ListBlockedBeads(ctx context.Context, req *beadsv1.ListBeadsRequest) (*beadsv1.ListBlockedBeadsResponse, error) {
+	filter := filterFromProto(req)
+	if err := checkQuery(filter); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	page, err := s.listBlocked(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list blocked beads: %v", err)
+	}
+
+	pbBeads := make([]*beadsv1.BlockedBead, 0, len(page.Beads))
+	for _, b := range page.Beads {
+		pbBeads = append(pbBeads, &beadsv1.BlockedBead{Bead: beadToProto(b.Bead), BlockedBy: b.BlockedBy, WaitingOn: b.WaitingOn})
+	}
+
+	return &beadsv1.ListBlockedBeadsResponse{
+		Beads: pbBeads,
+		Total: int32(page.Total),
+	}, nil
+}
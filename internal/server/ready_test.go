@@ -0,0 +1,135 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/shadow"
+)
+
+func seedReady(ms *mockStore) {
+	ms.beads["bd-r1"] = &model.Bead{ID: "bd-r1", Title: "Unblocked", Kind: model.KindIssue, Status: model.StatusOpen, Priority: 2}
+	ms.beads["bd-r2"] = &model.Bead{ID: "bd-r2", Title: "Blocked", Kind: model.KindIssue, Status: model.StatusOpen, Priority: 0}
+	ms.beads["bd-r3"] = &model.Bead{ID: "bd-r3", Title: "Blocker done", Kind: model.KindIssue, Status: model.StatusOpen, Priority: 1}
+	ms.beads["bd-r4"] = &model.Bead{ID: "bd-r4", Title: "Open blocker", Kind: model.KindIssue, Status: model.StatusOpen, Priority: 3}
+	ms.beads["bd-r5"] = &model.Bead{ID: "bd-r5", Title: "Closed blocker", Kind: model.KindIssue, Status: model.StatusClosed}
+	ms.deps["bd-r2"] = []*model.Dependency{{BeadID: "bd-r2", DependsOnID: "bd-r4", Type: model.DepBlocks}}
+	ms.deps["bd-r3"] = []*model.Dependency{{BeadID: "bd-r3", DependsOnID: "bd-r5", Type: model.DepBlocks}}
+	ms.labels["bd-r1"] = []string{"backend"}
+}
+
+func TestHandleGetReady(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedReady(ms)
+
+	rec := doJSON(t, h, "GET", "/v1/ready", nil)
+	requireStatus(t, rec, 200)
+	var page beadPage
+	decodeJSON(t, rec, &page)
+
+	var ids []string
+	for _, b := range page.Beads {
+		ids = append(ids, b.ID)
+	}
+	// bd-r2 is blocked by an open bead; bd-r5 is closed. Most urgent first.
+	if got := strings.Join(ids, ","); got != "bd-r3,bd-r1,bd-r4" || page.Total != 3 {
+		t.Fatalf("ready = %s (total %d), want bd-r3,bd-r1,bd-r4 (total 3)", got, page.Total)
+	}
+}
+
+func TestHandleGetReady_Filters(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedReady(ms)
+
+	rec := doJSON(t, h, "GET", "/v1/ready?limit=1", nil)
+	requireStatus(t, rec, 200)
+	var page beadPage
+	decodeJSON(t, rec, &page)
+	if len(page.Beads) != 1 || page.Beads[0].ID != "bd-r3" || page.Total != 3 {
+		t.Fatalf("limit=1: got %+v", page)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/ready?labels=backend", nil)
+	requireStatus(t, rec, 200)
+	page = beadPage{}
+	decodeJSON(t, rec, &page)
+	if len(page.Beads) != 1 || page.Beads[0].ID != "bd-r1" {
+		t.Fatalf("labels=backend: got %+v", page.Beads)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/ready?priority=0", nil)
+	requireStatus(t, rec, 200)
+	page = beadPage{}
+	decodeJSON(t, rec, &page)
+	if len(page.Beads) != 0 {
+		t.Fatalf("priority=0 should only match the blocked bead, got %+v", page.Beads)
+	}
+}
+
+func TestGRPCListReadyBeads(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	seedReady(ms)
+
+	resp, err := srv.ListReadyBeads(ctx, &beadsv1.ListBeadsRequest{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetTotal() != 3 || len(resp.GetBeads()) != 2 || resp.GetBeads()[0].GetId() != "bd-r3" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestListReady_ShadowMatchesScan(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-r1"] = &model.Bead{ID: "bd-r1", Title: "Unblocked", Status: model.StatusOpen}
+	ms.beads["bd-r2"] = &model.Bead{ID: "bd-r2", Title: "Blocked", Status: model.StatusOpen}
+	ms.deps["bd-r2"] = []*model.Dependency{{BeadID: "bd-r2", DependsOnID: "bd-r1", Type: model.DepBlocks}}
+	sh := shadow.New(map[string]float64{"ready": 1}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	srv.SetShadow(sh)
+
+	if _, err := srv.listReady(ctx, model.BeadFilter{}); err != nil {
+		t.Fatal(err)
+	}
+	sh.Wait()
+
+	var buf bytes.Buffer
+	sh.WriteText(&buf)
+	if !strings.Contains(buf.String(), `beads_shadow_runs_total{route="ready"} 1`) ||
+		!strings.Contains(buf.String(), `beads_shadow_mismatches_total{route="ready"} 0`) {
+		t.Fatalf("expected one matching shadow run:\n%s", buf.String())
+	}
+}
+
+func TestHandleGetBlocked(t *testing.T) {
+	_, ms, h := newTestServer()
+	seedReady(ms)
+
+	rec := doJSON(t, h, "GET", "/v1/blocked", nil)
+	requireStatus(t, rec, 200)
+	var page blockedPage
+	decodeJSON(t, rec, &page)
+	if page.Total != 1 || len(page.Beads) != 1 || page.Beads[0].ID != "bd-r2" {
+		t.Fatalf("blocked = %+v, want bd-r2", page)
+	}
+	if got := page.Beads[0].BlockedBy; len(got) != 1 || got[0] != "bd-r4" {
+		t.Fatalf("blocked_by = %v, want [bd-r4]", got)
+	}
+}
+
+func TestGRPCListBlockedBeads(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	seedReady(ms)
+
+	resp, err := srv.ListBlockedBeads(ctx, &beadsv1.ListBeadsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetTotal() != 1 || resp.GetBeads()[0].GetBead().GetId() != "bd-r2" || resp.GetBeads()[0].GetBlockedBy()[0] != "bd-r4" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// reaperActor is recorded on the updates, comments and events made when a
+// gone agent's beads are unassigned.
+const reaperActor = "beads:reaper"
+
+// SetUnassignPolicy sets how long an agent may be gone before its
+// in-progress beads are unassigned. Zero disables the policy.
+func (s *BeadsServer) SetUnassignPolicy(after time.Duration) {
+	s.unassignAfter = after
+}
+
+// RunAgentReaper unassigns the beads of agents gone longer than the
+// unassign policy, checking every interval until ctx is cancelled.
+func (s *BeadsServer) RunAgentReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.UnassignGoneAgents(ctx, time.Now().UTC()); err != nil {
+				slog.Error("agent reaper failed", "err", err)
+			} else if n > 0 {
+				slog.Info("unassigned beads of gone agents", "count", n)
+			}
+		}
+	}
+}
+
+// agentGone reports whether the agent has been gone since before cutoff,
+// and why. An agent is "reaped" once its agent bead is closed, and
+// "stale" when nothing has happened on its agent bead since cutoff.
+func (s *BeadsServer) agentGone(ctx context.Context, a *model.Agent, cutoff time.Time) (string, time.Time, error) {
+	agentBead, err := s.store.GetBead(ctx, a.BeadID)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && agentBead == nil {
+		return "", time.Time{}, nil
+	}
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if agentBead.Status == model.StatusClosed && agentBead.ClosedAt != nil {
+		if agentBead.ClosedAt.Before(cutoff) {
+			return "reaped", *agentBead.ClosedAt, nil
+		}
+		return "", time.Time{}, nil
+	}
+
+	lastSeen := agentBead.UpdatedAt
+	presence, err := s.store.GetEvents(ctx, a.BeadID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if n := len(presence); n > 0 && presence[n-1].CreatedAt.After(lastSeen) {
+		lastSeen = presence[n-1].CreatedAt
+	}
+	if lastSeen.Before(cutoff) {
+		return "stale", lastSeen, nil
+	}
+	return "", time.Time{}, nil
+}
+
+// UnassignGoneAgents puts the in-progress beads of every agent gone longer
+// than the unassign policy back to open and unassigned, comments on each,
+// and records an agent.unassigned event on the agent bead. Returns the
+// number of beads unassigned.
+func (s *BeadsServer) UnassignGoneAgents(ctx context.Context, now time.Time) (int, error) {
+	if s.unassignAfter <= 0 {
+		return 0, nil
+	}
+	agents, err := s.store.ListAgents(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("listing agents: %w", err)
+	}
+	cutoff := now.Add(-s.unassignAfter)
+	unassigned := 0
+	for _, a := range agents {
+		reason, since, err := s.agentGone(ctx, a, cutoff)
+		if err != nil {
+			slog.Warn("failed to check agent presence", "agent", a.Name, "err", err)
+			continue
+		}
+		if reason == "" {
+			continue
+		}
+		ids, err := s.unassignAgent(ctx, a, reason, since)
+		if err != nil {
+			slog.Warn("failed to unassign agent beads", "agent", a.Name, "err", err)
+		}
+		unassigned += len(ids)
+	}
+	return unassigned, nil
+}
+
+// unassignAgent unassigns the agent's in-progress beads and returns their
+// IDs. Nothing is recorded when the agent holds no beads.
+func (s *BeadsServer) unassignAgent(ctx context.Context, a *model.Agent, reason string, since time.Time) ([]string, error) {
+	held, _, err := s.store.ListBeads(ctx, model.BeadFilter{Assignee: a.Name, Status: []model.Status{model.StatusInProgress}})
+	if err != nil {
+		return nil, err
+	}
+	open, none := string(model.StatusOpen), ""
+	text := fmt.Sprintf("Unassigned from %s: the agent was %s at %s and did not come back. The work may be partly done; check its notes and forensics before resuming.",
+		a.Name, reason, since.Format(time.RFC3339))
+	var ids []string
+	for _, b := range held {
+		if _, err := s.updateBead(ctx, b.ID, updateBeadInput{Status: &open, Assignee: &none, UpdatedBy: reaperActor}); err != nil {
+			return ids, fmt.Errorf("unassigning %s: %w", b.ID, err)
+		}
+		if err := s.addComment(ctx, &model.Comment{BeadID: b.ID, Author: reaperActor, Text: text}); err != nil {
+			return ids, fmt.Errorf("commenting on %s: %w", b.ID, err)
+		}
+		ids = append(ids, b.ID)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	err = s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		return s.recordEvent(ctx, tx, events.TopicAgentUnassigned, a.BeadID, reaperActor, events.AgentUnassigned{
+			Agent:   a.Name,
+			Reason:  reason,
+			Since:   since,
+			BeadIDs: ids,
+		})
+	})
+	if err != nil {
+		return ids, err
+	}
+	s.flushEvents(ctx)
+	return ids, nil
+}
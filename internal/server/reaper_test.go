@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestUnassignGoneAgents(t *testing.T) {
+	s, ms, _ := newTestServer()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	old, recent := now.Add(-2*time.Hour), now.Add(-time.Minute)
+
+	// crew/reaped's agent bead was closed long ago; crew/stale's has been
+	// quiet as long; crew/live was just seen.
+	ms.agents["crew/reaped"] = &model.Agent{Name: "crew/reaped", BeadID: "bd-a1"}
+	ms.agents["crew/stale"] = &model.Agent{Name: "crew/stale", BeadID: "bd-a2"}
+	ms.agents["crew/live"] = &model.Agent{Name: "crew/live", BeadID: "bd-a3"}
+	ms.beads["bd-a1"] = &model.Bead{ID: "bd-a1", Type: "agent", Status: model.StatusClosed, ClosedAt: &old, UpdatedAt: old}
+	ms.beads["bd-a2"] = &model.Bead{ID: "bd-a2", Type: "agent", Status: model.StatusOpen, UpdatedAt: old}
+	ms.beads["bd-a3"] = &model.Bead{ID: "bd-a3", Type: "agent", Status: model.StatusOpen, UpdatedAt: recent}
+	ms.beads["bd-w1"] = &model.Bead{ID: "bd-w1", Title: "Reaped work", Type: "task", Kind: "issue", Status: model.StatusInProgress, Assignee: "crew/reaped"}
+	ms.beads["bd-w2"] = &model.Bead{ID: "bd-w2", Title: "Stale work", Type: "task", Kind: "issue", Status: model.StatusInProgress, Assignee: "crew/stale"}
+	ms.beads["bd-w3"] = &model.Bead{ID: "bd-w3", Title: "Live work", Type: "task", Kind: "issue", Status: model.StatusInProgress, Assignee: "crew/live"}
+
+	// Disabled by default.
+	if n, err := s.UnassignGoneAgents(ctx, now); err != nil || n != 0 {
+		t.Fatalf("UnassignGoneAgents without a policy = %d, %v", n, err)
+	}
+
+	s.SetUnassignPolicy(time.Hour)
+	n, err := s.UnassignGoneAgents(ctx, now)
+	if err != nil {
+		t.Fatalf("UnassignGoneAgents: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("unassigned %d beads, want 2", n)
+	}
+	for _, id := range []string{"bd-w1", "bd-w2"} {
+		if b := ms.beads[id]; b.Status != model.StatusOpen || b.Assignee != "" {
+			t.Errorf("%s = %s/%q, want open and unassigned", id, b.Status, b.Assignee)
+		}
+		if c := ms.comments[id]; len(c) != 1 || c[0].Author != reaperActor {
+			t.Errorf("%s comments = %+v", id, c)
+		}
+	}
+	if b := ms.beads["bd-w3"]; b.Status != model.StatusInProgress || b.Assignee != "crew/live" {
+		t.Errorf("live agent's bead = %s/%q", b.Status, b.Assignee)
+	}
+
+	var unassigned []*model.Event
+	for _, e := range ms.events {
+		if e.Topic == events.TopicAgentUnassigned {
+			unassigned = append(unassigned, e)
+		}
+	}
+	if len(unassigned) != 2 || unassigned[0].BeadID != "bd-a1" || unassigned[1].BeadID != "bd-a2" {
+		t.Fatalf("agent.unassigned events = %+v", unassigned)
+	}
+
+	// Nothing is left to unassign on the next pass.
+	before := len(ms.events)
+	if n, err := s.UnassignGoneAgents(ctx, now); err != nil || n != 0 || len(ms.events) != before {
+		t.Fatalf("second pass = %d, %v, %d new events", n, err, len(ms.events)-before)
+	}
+}
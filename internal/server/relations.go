@@ -0,0 +1,203 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// relationTypeNames lists the relation types for error messages.
+func relationTypeNames() string {
+	names := make([]string, len(model.RelationTypes))
+	for i, t := range model.RelationTypes {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// addRelation relates beadID to otherID, storing the relation as a
+// dependency of beadID. Returns inputError for an unknown relation type or a
+// self-relation, and sql.ErrNoRows if either bead does not exist.
+func (s *BeadsServer) addRelation(ctx context.Context, beadID, otherID string, relType model.DependencyType, actor string) (*model.Dependency, error) {
+	if otherID == "" {
+		return nil, inputError("other_id is required")
+	}
+	if !relType.IsRelation() {
+		return nil, inputError("type must be one of " + relationTypeNames())
+	}
+	if beadID == otherID {
+		return nil, inputError("a bead cannot be related to itself")
+	}
+	for _, id := range []string{beadID, otherID} {
+		b, err := s.store.GetBead(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			return nil, sql.ErrNoRows
+		}
+	}
+
+	dep := &model.Dependency{
+		BeadID:      beadID,
+		DependsOnID: otherID,
+		Type:        relType,
+		CreatedAt:   time.Now().UTC(),
+		CreatedBy:   s.actorFor(ctx, actor),
+	}
+	if err := s.addDependency(ctx, dep); err != nil {
+		return nil, err
+	}
+	return dep, nil
+}
+
+// listRelations returns the relations of beadID: those made from it, then
+// those made to it from other beads, each labelled as read from beadID.
+func (s *BeadsServer) listRelations(ctx context.Context, beadID string) ([]*model.Relation, error) {
+	out, err := s.store.GetDependencies(ctx, beadID)
+	if err != nil {
+		return nil, err
+	}
+	in, err := s.store.GetDependents(ctx, beadID)
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.depTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rels []*model.Relation
+	for _, d := range out {
+		if d.Type.IsRelation() {
+			rels = append(rels, &model.Relation{
+				BeadID: d.DependsOnID, Type: d.Type, Direction: "outgoing", Label: types.label(d.Type),
+				CreatedAt: d.CreatedAt, CreatedBy: d.CreatedBy,
+			})
+		}
+	}
+	for _, d := range in {
+		if d.Type.IsRelation() {
+			rels = append(rels, &model.Relation{
+				BeadID: d.BeadID, Type: d.Type, Direction: "incoming", Label: types.inverseLabel(d.Type),
+				CreatedAt: d.CreatedAt, CreatedBy: d.CreatedBy,
+			})
+		}
+	}
+	for _, r := range rels {
+		if b, err := s.store.GetBead(ctx, r.BeadID); err == nil && b != nil {
+			r.Title, r.Status = b.Title, b.Status
+		}
+	}
+	return rels, nil
+}
+
+// addRelationRequest is the JSON body for POST /v1/beads/{id}/relations.
+type addRelationRequest struct {
+	OtherID   string `json:"other_id"`
+	Type      string `json:"type"`
+	CreatedBy string `json:"created_by"`
+}
+
+// handleAddRelation handles POST /v1/beads/{id}/relations.
+func (s *BeadsServer) handleAddRelation(w http.ResponseWriter, r *http.Request) {
+	beadID := r.PathValue("id")
+	if beadID == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req addRelationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	dep, err := s.addRelation(r.Context(), beadID, s.resolveBeadID(r.Context(), req.OtherID), model.DependencyType(req.Type), req.CreatedBy)
+	if err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "bead not found")
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to add relation")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, dep)
+}
+
+// handleListRelations handles GET /v1/beads/{id}/relations.
+func (s *BeadsServer) handleListRelations(w http.ResponseWriter, r *http.Request) {
+	beadID := r.PathValue("id")
+	if beadID == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	rels, err := s.listRelations(r.Context(), beadID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list relations")
+		return
+	}
+	if rels == nil {
+		rels = []*model.Relation{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"relations": rels})
+}
+
+// AddRelation relates two beads with a non-blocking relation type.
+func (s *BeadsServer) AddRelation(ctx context.Context, req *beadsv1.AddRelationRequest) (*beadsv1.AddRelationResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	dep, err := s.addRelation(ctx, req.GetBeadId(), req.GetOtherId(), model.DependencyType(req.GetType()), req.GetCreatedBy())
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		return nil, storeError(err, "bead")
+	}
+	return &beadsv1.AddRelationResponse{Dependency: dependencyToProto(dep)}, nil
+}
+
+// ListRelations lists the relations of a bead in both directions.
+func (s *BeadsServer) ListRelations(ctx context.Context, req *beadsv1.ListRelationsRequest) (*beadsv1.ListRelationsResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+	rels, err := s.listRelations(ctx, req.GetBeadId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list relations: %v", err)
+	}
+
+	pbRels := make([]*beadsv1.Relation, len(rels))
+	for i, r := range rels {
+		pbRels[i] = &beadsv1.Relation{
+			BeadId:    r.BeadID,
+			Title:     r.Title,
+			Status:    string(r.Status),
+			Type:      string(r.Type),
+			Direction: r.Direction,
+			Label:     r.Label,
+			CreatedAt: timestamppb.New(r.CreatedAt),
+			CreatedBy: r.CreatedBy,
+		}
+	}
+	return &beadsv1.ListRelationsResponse{Relations: pbRels}, nil
+}
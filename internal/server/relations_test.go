@@ -0,0 +1,80 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestAddRelation(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-bug"] = &model.Bead{ID: "bd-bug", Title: "Crash", Status: model.StatusOpen}
+	ms.beads["bd-pr"] = &model.Bead{ID: "bd-pr", Title: "Refactor", Status: model.StatusClosed}
+
+	resp, err := srv.AddRelation(ctx, &beadsv1.AddRelationRequest{BeadId: "bd-bug", OtherId: "bd-pr", Type: "caused-by", CreatedBy: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := resp.Dependency; d.DependsOnId != "bd-pr" || d.Type != "caused-by" || d.CreatedBy != "alice" {
+		t.Fatalf("dependency = %v", d)
+	}
+	requireEvent(t, ms, 1, "beads.dependency.added")
+
+	_, err = srv.AddRelation(ctx, &beadsv1.AddRelationRequest{BeadId: "bd-bug", OtherId: "bd-pr", Type: "blocks"})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = srv.AddRelation(ctx, &beadsv1.AddRelationRequest{BeadId: "bd-bug", OtherId: "bd-bug", Type: "relates-to"})
+	requireCode(t, err, codes.InvalidArgument)
+	_, err = srv.AddRelation(ctx, &beadsv1.AddRelationRequest{BeadId: "bd-bug", OtherId: "bd-none", Type: "relates-to"})
+	requireCode(t, err, codes.NotFound)
+}
+
+func TestListRelations_BothDirections(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	for _, id := range []string{"bd-a", "bd-b", "bd-c"} {
+		ms.beads[id] = &model.Bead{ID: id, Title: "Bead " + id, Status: model.StatusOpen}
+	}
+	ms.deps["bd-a"] = []*model.Dependency{
+		{BeadID: "bd-a", DependsOnID: "bd-b", Type: model.RelDuplicateOf},
+		{BeadID: "bd-a", DependsOnID: "bd-c", Type: model.DepBlocks},
+	}
+	ms.deps["bd-c"] = []*model.Dependency{{BeadID: "bd-c", DependsOnID: "bd-a", Type: model.RelFixedBy}}
+
+	resp, err := srv.ListRelations(ctx, &beadsv1.ListRelationsRequest{BeadId: "bd-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Relations) != 2 {
+		t.Fatalf("relations = %v, want 2 (blocks is not a relation)", resp.Relations)
+	}
+	out, in := resp.Relations[0], resp.Relations[1]
+	if out.BeadId != "bd-b" || out.Direction != "outgoing" || out.Label != "duplicate of" || out.Title != "Bead bd-b" {
+		t.Errorf("outgoing = %v", out)
+	}
+	if in.BeadId != "bd-c" || in.Direction != "incoming" || in.Label != "fixes" {
+		t.Errorf("incoming = %v", in)
+	}
+}
+
+func TestHandleRelations(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-a"] = &model.Bead{ID: "bd-a", Status: model.StatusOpen}
+	ms.beads["bd-b"] = &model.Bead{ID: "bd-b", Status: model.StatusOpen}
+
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-a/relations", map[string]any{"other_id": "bd-b", "type": "relates-to"})
+	requireStatus(t, rec, http.StatusCreated)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-a/relations", map[string]any{"other_id": "bd-b", "type": "related"}), http.StatusBadRequest)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-a/relations", map[string]any{"other_id": "bd-x", "type": "relates-to"}), http.StatusNotFound)
+
+	rec = doJSON(t, h, "GET", "/v1/beads/bd-b/relations", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var body struct {
+		Relations []*model.Relation `json:"relations"`
+	}
+	decodeJSON(t, rec, &body)
+	if len(body.Relations) != 1 || body.Relations[0].BeadID != "bd-a" || body.Relations[0].Label != "relates to" {
+		t.Fatalf("relations = %+v", body.Relations)
+	}
+}
@@ -0,0 +1,350 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// dailyReportTopics are the event topics the daily report counts.
+var dailyReportTopics = []string{
+	events.TopicBeadCreated,
+	events.TopicBeadUpdated,
+	events.TopicBeadClosed,
+	events.TopicBeadUndeferred,
+	events.TopicDecisionResolved,
+	events.TopicDecisionExpired,
+}
+
+// jackReportTopics are the jack event topics the daily report replays to
+// count jacks opened and expired.
+var jackReportTopics = []string{
+	events.TopicJackRaised,
+	events.TopicJackExtended,
+	events.TopicJackDown,
+}
+
+// jackLifetime bounds how long before a day a jack expiring on it can have
+// been raised: its TTL plus every extension.
+const jackLifetime = jackMaxTTL * (jackMaxExtensions + 1)
+
+// dailyReport summarizes one UTC day of activity for standups.
+type dailyReport struct {
+	Date              string          `json:"date"` // YYYY-MM-DD
+	Created           int             `json:"created"`
+	Closed            int             `json:"closed"`
+	Claimed           int             `json:"claimed"`
+	DecisionsResolved int             `json:"decisions_resolved"`
+	DecisionsExpired  int             `json:"decisions_expired"`
+	JacksOpened       int             `json:"jacks_opened"`
+	JacksExpired      int             `json:"jacks_expired"`
+	Resurfacing       int             `json:"resurfacing"` // deferred beads due back on the day, reopened or not
+	Actors            []actorActivity `json:"actors"`      // most active first
+}
+
+// actorActivity is one actor's share of a daily report.
+type actorActivity struct {
+	Actor             string `json:"actor"`
+	Created           int    `json:"created"`
+	Closed            int    `json:"closed"`
+	Claimed           int    `json:"claimed"`
+	DecisionsResolved int    `json:"decisions_resolved"`
+}
+
+func (a *actorActivity) total() int {
+	return a.Created + a.Closed + a.Claimed + a.DecisionsResolved
+}
+
+// dailyReport builds the report for the UTC day starting at day.
+func (s *BeadsServer) dailyReport(ctx context.Context, day time.Time) (*dailyReport, error) {
+	evs, err := s.store.ListEventsBetween(ctx, day, day.AddDate(0, 0, 1), dailyReportTopics)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dailyReport{Date: day.Format(time.DateOnly), Actors: []actorActivity{}}
+	byActor := map[string]*actorActivity{}
+	tally := func(name string) *actorActivity {
+		if name == "" {
+			name = "unknown"
+		}
+		a, ok := byActor[name]
+		if !ok {
+			a = &actorActivity{Actor: name}
+			byActor[name] = a
+		}
+		return a
+	}
+	for _, e := range evs {
+		switch e.Topic {
+		case events.TopicBeadCreated:
+			report.Created++
+			tally(e.Actor).Created++
+		case events.TopicBeadClosed:
+			report.Closed++
+			tally(e.Actor).Closed++
+		case events.TopicBeadUpdated:
+			if assignee, ok := claimedBy(e); ok {
+				report.Claimed++
+				tally(assignee).Claimed++
+			}
+		case events.TopicDecisionResolved:
+			report.DecisionsResolved++
+			tally(e.Actor).DecisionsResolved++
+		case events.TopicDecisionExpired:
+			report.DecisionsExpired++
+		case events.TopicBeadUndeferred:
+			report.Resurfacing++
+		}
+	}
+
+	due, err := s.dueDeferred(ctx, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range due {
+		if !b.DeferUntil.Before(day) {
+			report.Resurfacing++
+		}
+	}
+
+	if report.JacksOpened, report.JacksExpired, err = s.jackActivity(ctx, day); err != nil {
+		return nil, err
+	}
+
+	for _, a := range byActor {
+		report.Actors = append(report.Actors, *a)
+	}
+	sort.Slice(report.Actors, func(i, j int) bool {
+		a, b := report.Actors[i], report.Actors[j]
+		if a.total() != b.total() {
+			return a.total() > b.total()
+		}
+		return a.Actor < b.Actor
+	})
+	return report, nil
+}
+
+// jackActivity counts the jacks raised on the UTC day starting at day, and
+// those whose expiry passed on it while they were still up. Jacks have no
+// expiry event, so it replays the raised, extended and down events of every
+// jack that could still have been up that day.
+func (s *BeadsServer) jackActivity(ctx context.Context, day time.Time) (opened, expired int, err error) {
+	end := day.AddDate(0, 0, 1)
+	evs, err := s.store.ListEventsBetween(ctx, day.Add(-jackLifetime), end, jackReportTopics)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// expiry holds each up jack's current expiry; a jack is removed once it
+	// is down or its expiry has been counted.
+	expiry := map[string]time.Time{}
+	lapse := func(id string, at time.Time) {
+		exp, ok := expiry[id]
+		if !ok || exp.After(at) {
+			return
+		}
+		if !exp.Before(day) && exp.Before(end) {
+			expired++
+		}
+		delete(expiry, id)
+	}
+	for _, e := range evs {
+		lapse(e.BeadID, e.CreatedAt)
+		switch e.Topic {
+		case events.TopicJackRaised:
+			var ev events.JackRaised
+			var jf jackFields
+			if json.Unmarshal(e.Payload, &ev) != nil || ev.Bead == nil || json.Unmarshal(ev.Bead.Fields, &jf) != nil {
+				continue
+			}
+			expiry[e.BeadID] = jf.ExpiresAt
+			if !e.CreatedAt.Before(day) {
+				opened++
+			}
+		case events.TopicJackExtended:
+			var ev events.JackExtended
+			if json.Unmarshal(e.Payload, &ev) == nil {
+				expiry[e.BeadID] = ev.ExpiresAt
+			}
+		case events.TopicJackDown:
+			delete(expiry, e.BeadID)
+		}
+	}
+	now := time.Now()
+	if end.After(now) {
+		end = now
+	}
+	for id := range expiry {
+		lapse(id, end)
+	}
+	return opened, expired, nil
+}
+
+// claimedBy reports whether a bead.updated event is a claim: the bead moved
+// to in_progress and was assigned in the same update. It returns the new
+// assignee.
+func claimedBy(e *model.Event) (string, bool) {
+	var ev events.BeadUpdated
+	if err := json.Unmarshal(e.Payload, &ev); err != nil {
+		return "", false
+	}
+	status, _ := ev.Changes["status"].(string)
+	assignee, _ := ev.Changes["assignee"].(string)
+	if status != string(model.StatusInProgress) || assignee == "" {
+		return "", false
+	}
+	return assignee, true
+}
+
+// handleDailyReport handles GET /v1/reports/daily?date=YYYY-MM-DD. The date
+// is a UTC day and defaults to yesterday.
+func (s *BeadsServer) handleDailyReport(w http.ResponseWriter, r *http.Request) {
+	day := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	if v := r.URL.Query().Get("date"); v != "" {
+		d, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "date must be YYYY-MM-DD")
+			return
+		}
+		day = d
+	}
+
+	report, err := s.dailyReport(r.Context(), day)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to build daily report")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// Velocity report defaults and bounds.
+const (
+	defaultVelocityWindow = "2w"
+	maxVelocityWindow     = 365 * 24 * time.Hour
+)
+
+// velocityGroups are the dimensions GET /v1/reports/velocity can group by.
+var velocityGroups = []string{model.GroupByAssignee, model.GroupByType, model.GroupByLabel}
+
+// velocityReport is the body of GET /v1/reports/velocity.
+type velocityReport struct {
+	Window string            `json:"window"`
+	Since  time.Time         `json:"since"`
+	Until  time.Time         `json:"until"`
+	Group  string            `json:"group,omitempty"`
+	Total  *model.Velocity   `json:"total"`
+	Groups []*model.Velocity `json:"groups,omitempty"` // most points first
+}
+
+// velocityReport totals the estimates of the beads matching filter closed
+// in the window before until, grouped by group when it is set. A bead with
+// several labels counts in each of their groups; one with no value for the
+// group is counted under "".
+func (s *BeadsServer) velocityReport(ctx context.Context, filter model.BeadFilter, window time.Duration, until time.Time, group string) (*velocityReport, error) {
+	since := until.Add(-window)
+	filter.Status = []model.Status{model.StatusClosed}
+	filter.ClosedAfter = &since
+	filter.IncludeArchived = true
+	filter.Limit, filter.Offset = 0, 0
+	beads, _, err := s.store.ListBeads(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &velocityReport{Since: since, Until: until, Group: group, Total: &model.Velocity{}}
+	byKey := map[string]*model.Velocity{}
+	tally := func(key string, b *model.Bead) {
+		v, ok := byKey[key]
+		if !ok {
+			v = &model.Velocity{Key: key}
+			byKey[key] = v
+		}
+		v.Add(b)
+	}
+	for _, b := range beads {
+		report.Total.Add(b)
+		switch group {
+		case model.GroupByAssignee:
+			tally(b.Assignee, b)
+		case model.GroupByType:
+			tally(string(b.Type), b)
+		case model.GroupByLabel:
+			labels, err := s.store.GetLabels(ctx, b.ID)
+			if err != nil {
+				return nil, err
+			}
+			if len(labels) == 0 {
+				tally("", b)
+			}
+			for _, l := range labels {
+				tally(l, b)
+			}
+		}
+	}
+
+	report.Total.SetRates(window)
+	if group != "" {
+		report.Groups = []*model.Velocity{}
+	}
+	for _, v := range byKey {
+		v.SetRates(window)
+		report.Groups = append(report.Groups, v)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		switch {
+		case a.Points != b.Points:
+			return a.Points > b.Points
+		case a.Hours != b.Hours:
+			return a.Hours > b.Hours
+		case a.Closed != b.Closed:
+			return a.Closed > b.Closed
+		}
+		return a.Key < b.Key
+	})
+	return report, nil
+}
+
+// handleVelocity handles GET /v1/reports/velocity?window=2w&group=assignee:
+// the estimates of the beads matching the list filters of GET /v1/beads
+// closed in the window, which is days (10d), weeks (2w) or a Go duration
+// and defaults to two weeks. group is assignee, type or label, or omitted
+// for the total alone.
+func (s *BeadsServer) handleVelocity(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	windowText := q.Get("window")
+	if windowText == "" {
+		windowText = defaultVelocityWindow
+	}
+	window, err := model.ParseWindow(windowText)
+	if err != nil || window <= 0 || window > maxVelocityWindow {
+		writeError(w, http.StatusBadRequest, "window must be a positive number of days (10d), weeks (2w) or a duration of at most 365d")
+		return
+	}
+	group := q.Get("group")
+	if group != "" && !slices.Contains(velocityGroups, group) {
+		writeError(w, http.StatusBadRequest, "group must be one of "+strings.Join(velocityGroups, ", "))
+		return
+	}
+	filter, err := parseBeadFilter(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := s.velocityReport(r.Context(), filter, window, time.Now().UTC(), group)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to build velocity report")
+		return
+	}
+	report.Window = windowText
+	writeJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestHandleDailyReport(t *testing.T) {
+	_, ms, h := newTestServer()
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	add := func(topic, actor string, at time.Time, payload any) {
+		data, _ := json.Marshal(payload)
+		ms.events = append(ms.events, &model.Event{
+			ID: int64(len(ms.events) + 1), Topic: topic, BeadID: "bd-x", Actor: actor, Payload: data, CreatedAt: at,
+		})
+	}
+	claim := events.BeadUpdated{Changes: map[string]any{"status": "in_progress", "assignee": "bob"}}
+	add(events.TopicBeadCreated, "alice", day.Add(-time.Hour), events.BeadCreated{}) // the day before
+	add(events.TopicBeadCreated, "alice", day.Add(9*time.Hour), events.BeadCreated{})
+	add(events.TopicBeadCreated, "alice", day.Add(10*time.Hour), events.BeadCreated{})
+	add(events.TopicBeadUpdated, "bob", day.Add(11*time.Hour), claim)
+	add(events.TopicBeadUpdated, "bob", day.Add(11*time.Hour), events.BeadUpdated{Changes: map[string]any{"title": "x"}})
+	add(events.TopicBeadClosed, "bob", day.Add(15*time.Hour), events.BeadClosed{})
+	add(events.TopicDecisionResolved, "carol", day.Add(16*time.Hour), events.DecisionResolved{})
+	add(events.TopicDecisionExpired, "", day.Add(17*time.Hour), events.DecisionExpired{})
+	add(events.TopicBeadClosed, "bob", day.Add(24*time.Hour), events.BeadClosed{}) // the day after
+
+	rec := doJSON(t, h, "GET", "/v1/reports/daily?date=2026-03-02", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var report dailyReport
+	decodeJSON(t, rec, &report)
+	if report.Date != "2026-03-02" || report.Created != 2 || report.Closed != 1 || report.Claimed != 1 ||
+		report.DecisionsResolved != 1 || report.DecisionsExpired != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	want := []actorActivity{
+		{Actor: "alice", Created: 2},
+		{Actor: "bob", Closed: 1, Claimed: 1},
+		{Actor: "carol", DecisionsResolved: 1},
+	}
+	if len(report.Actors) != len(want) {
+		t.Fatalf("actors = %+v", report.Actors)
+	}
+	for i := range want {
+		if report.Actors[i] != want[i] {
+			t.Errorf("actors[%d] = %+v, want %+v", i, report.Actors[i], want[i])
+		}
+	}
+
+	requireStatus(t, doJSON(t, h, "GET", "/v1/reports/daily?date=yesterday", nil), http.StatusBadRequest)
+
+	rec = doJSON(t, h, "GET", "/v1/reports/daily", nil)
+	requireStatus(t, rec, http.StatusOK)
+	report = dailyReport{}
+	decodeJSON(t, rec, &report)
+	if yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly); report.Date != yesterday || report.Actors == nil {
+		t.Errorf("default report = %+v, want date %s", report, yesterday)
+	}
+}
+
+func TestHandleDailyReport_Jacks(t *testing.T) {
+	_, ms, h := newTestServer()
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	add := func(topic, beadID string, at time.Time, payload any) {
+		data, _ := json.Marshal(payload)
+		ms.events = append(ms.events, &model.Event{
+			ID: int64(len(ms.events) + 1), Topic: topic, BeadID: beadID, Actor: "alice", Payload: data, CreatedAt: at,
+		})
+	}
+	raised := func(expires time.Time) events.JackRaised {
+		fields, _ := json.Marshal(map[string]any{"expires_at": expires.Format(time.RFC3339)})
+		return events.JackRaised{Bead: &model.Bead{Type: jackType, Fields: fields}}
+	}
+	// Raised the day before, expired during the day.
+	add(events.TopicJackRaised, "bd-1", day.Add(-2*time.Hour), raised(day.Add(time.Hour)))
+	// Raised during the day, taken down before it expired.
+	add(events.TopicJackRaised, "bd-2", day.Add(2*time.Hour), raised(day.Add(4*time.Hour)))
+	add(events.TopicJackDown, "bd-2", day.Add(3*time.Hour), events.JackDown{})
+	// Raised during the day, extended past it.
+	add(events.TopicJackRaised, "bd-3", day.Add(5*time.Hour), raised(day.Add(6*time.Hour)))
+	add(events.TopicJackExtended, "bd-3", day.Add(5*time.Hour+30*time.Minute), events.JackExtended{ExpiresAt: day.Add(30 * time.Hour)})
+	// Raised during the day, lapsed, then extended after the fact.
+	add(events.TopicJackRaised, "bd-4", day.Add(7*time.Hour), raised(day.Add(8*time.Hour)))
+	add(events.TopicJackExtended, "bd-4", day.Add(9*time.Hour), events.JackExtended{ExpiresAt: day.Add(10 * time.Hour)})
+	add(events.TopicJackDown, "bd-4", day.Add(9*time.Hour+30*time.Minute), events.JackDown{})
+
+	rec := doJSON(t, h, "GET", "/v1/reports/daily?date=2026-03-02", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var report dailyReport
+	decodeJSON(t, rec, &report)
+	if report.JacksOpened != 3 || report.JacksExpired != 2 {
+		t.Fatalf("jacks opened %d, expired %d; want 3, 2", report.JacksOpened, report.JacksExpired)
+	}
+}
+
+func TestHandleVelocity(t *testing.T) {
+	_, ms, h := newTestServer()
+	recent, old := time.Now().Add(-24*time.Hour), time.Now().AddDate(0, 0, -30)
+	ms.beads["bd-1"] = &model.Bead{ID: "bd-1", Status: model.StatusClosed, ClosedAt: &recent, Assignee: "alice", Estimate: "3", Actual: "5"}
+	ms.beads["bd-2"] = &model.Bead{ID: "bd-2", Status: model.StatusClosed, ClosedAt: &recent, Assignee: "bob", Estimate: "4h"}
+	ms.beads["bd-3"] = &model.Bead{ID: "bd-3", Status: model.StatusClosed, ClosedAt: &recent, Assignee: "alice"}
+	ms.beads["bd-4"] = &model.Bead{ID: "bd-4", Status: model.StatusClosed, ClosedAt: &old, Assignee: "alice", Estimate: "8"}
+	ms.beads["bd-5"] = &model.Bead{ID: "bd-5", Status: model.StatusOpen, Assignee: "alice", Estimate: "2"}
+	ms.labels["bd-1"] = []string{"area:api", "area:cli"}
+
+	rec := doJSON(t, h, "GET", "/v1/reports/velocity?window=2w&group=assignee", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var report velocityReport
+	decodeJSON(t, rec, &report)
+	total := report.Total
+	if report.Window != "2w" || total.Closed != 3 || total.Estimated != 2 || total.Points != 3 || total.Hours != 4 ||
+		total.ActualPoints != 5 || total.PointsPerWeek != 1.5 || total.HoursPerWeek != 2 {
+		t.Fatalf("report = %+v, total %+v", report, total)
+	}
+	if got := report.Until.Sub(report.Since); got != 14*24*time.Hour {
+		t.Errorf("window = %v, want 2 weeks", got)
+	}
+	if len(report.Groups) != 2 || report.Groups[0].Key != "alice" || report.Groups[0].Closed != 2 || report.Groups[0].Points != 3 ||
+		report.Groups[1].Key != "bob" || report.Groups[1].Hours != 4 {
+		t.Fatalf("groups = %+v", report.Groups)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/reports/velocity?window=45d&group=label", nil)
+	requireStatus(t, rec, http.StatusOK)
+	report = velocityReport{}
+	decodeJSON(t, rec, &report)
+	if report.Total.Closed != 4 || report.Total.Points != 11 {
+		t.Fatalf("45d total = %+v", report.Total)
+	}
+	keys := map[string]int{}
+	for _, g := range report.Groups {
+		keys[g.Key] = g.Closed
+	}
+	if len(keys) != 3 || keys["area:api"] != 1 || keys["area:cli"] != 1 || keys[""] != 3 {
+		t.Fatalf("by label = %+v", keys)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/reports/velocity", nil)
+	requireStatus(t, rec, http.StatusOK)
+	report = velocityReport{}
+	decodeJSON(t, rec, &report)
+	if report.Window != "2w" || report.Groups != nil {
+		t.Fatalf("default report = %+v", report)
+	}
+
+	for _, path := range []string{"/v1/reports/velocity?window=0d", "/v1/reports/velocity?window=2y", "/v1/reports/velocity?window=400d", "/v1/reports/velocity?group=status"} {
+		requireStatus(t, doJSON(t, h, "GET", path, nil), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// SetEventRetention sets how long events of each topic are kept before
+// compaction summarizes and deletes them. No rules keeps every event;
+// POST /v1/admin/compaction/run then needs an explicit age.
+func (s *BeadsServer) SetEventRetention(rules []model.RetentionRule) {
+	s.retention = rules
+}
+
+// compactionRun is the result of one event compaction.
+type compactionRun struct {
+	StartedAt  time.Time        `json:"started_at"`
+	FinishedAt time.Time        `json:"finished_at"`
+	Compacted  map[string]int64 `json:"compacted"` // events deleted, by rule topic
+	Total      int64            `json:"total"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// RunEventCompactor compacts events past the retention policy, checking
+// every interval until ctx is cancelled.
+func (s *BeadsServer) RunEventCompactor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if run, err := s.CompactEvents(ctx, s.retention); err != nil {
+				slog.Error("event compaction failed", "err", err)
+			} else if run.Total > 0 {
+				slog.Info("compacted events", "count", run.Total)
+			}
+		}
+	}
+}
+
+// CompactEvents summarizes and deletes the events older than their rule's
+// age: each event falls under the most specific rule matching its topic,
+// and rules with no age keep theirs. Runs are serialized, and the last one
+// is kept for GET /v1/admin/compaction.
+func (s *BeadsServer) CompactEvents(ctx context.Context, rules []model.RetentionRule) (*compactionRun, error) {
+	s.compactionMu.Lock()
+	defer s.compactionMu.Unlock()
+
+	now := time.Now().UTC()
+	run := &compactionRun{StartedAt: now, Compacted: map[string]int64{}}
+	var err error
+	for _, r := range rules {
+		if r.MaxAge <= 0 {
+			continue
+		}
+		// Events under a more specific rule are left to that rule.
+		var except []string
+		for _, o := range rules {
+			if o.Topic != r.Topic && r.Covers(o.Topic) {
+				except = append(except, o.Topic)
+			}
+		}
+		var n int64
+		if n, err = s.store.CompactEvents(ctx, []string{r.Topic}, except, now.Add(-r.MaxAge)); err != nil {
+			err = fmt.Errorf("compacting %s events: %w", r.Topic, err)
+			run.Error = err.Error()
+			break
+		}
+		run.Compacted[r.Topic] = n
+		run.Total += n
+	}
+	run.FinishedAt = time.Now().UTC()
+	s.lastCompaction = run
+	return run, err
+}
+
+// retentionRuleJSON is a retention rule as served by GET
+// /v1/admin/compaction.
+type retentionRuleJSON struct {
+	Topic  string `json:"topic"`
+	MaxAge string `json:"max_age"` // a Go duration; "0s" keeps events forever
+}
+
+// handleGetCompaction handles GET /v1/admin/compaction: the retention
+// policy and the last compaction run, which is null before the first. It
+// requires the admin token.
+func (s *BeadsServer) handleGetCompaction(w http.ResponseWriter, r *http.Request) {
+	if err := s.authorizeAdmin(r.Context(), bearerToken(r.Header.Get("Authorization"))); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	rules := make([]retentionRuleJSON, len(s.retention))
+	for i, rule := range s.retention {
+		rules[i] = retentionRuleJSON{Topic: rule.Topic, MaxAge: rule.MaxAge.String()}
+	}
+	s.compactionMu.Lock()
+	last := s.lastCompaction
+	s.compactionMu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{"rules": rules, "last_run": last})
+}
+
+// handleRunCompaction handles POST /v1/admin/compaction/run?older_than_days=N.
+// It compacts events of every topic recorded more than N days ago, or those
+// past the retention policy when N is omitted, and requires the admin
+// token.
+func (s *BeadsServer) handleRunCompaction(w http.ResponseWriter, r *http.Request) {
+	if err := s.authorizeAdmin(r.Context(), bearerToken(r.Header.Get("Authorization"))); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	rules := s.retention
+	if v := r.URL.Query().Get("older_than_days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "older_than_days must be a positive integer")
+			return
+		}
+		rules = []model.RetentionRule{{Topic: "*", MaxAge: time.Duration(n) * 24 * time.Hour}}
+	} else if len(rules) == 0 {
+		writeError(w, http.StatusBadRequest, "no event retention policy is set; pass older_than_days or set BEADS_EVENT_RETENTION")
+		return
+	}
+
+	run, err := s.CompactEvents(r.Context(), rules)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, run)
+}
+
+// Page sizes for GET /v1/events/summaries.
+const (
+	defaultSummaryPage = 100
+	maxSummaryPage     = 1000
+)
+
+// handleListEventSummaries handles GET /v1/events/summaries, the counts
+// left by compaction, newest day first, filtered by bead_id and topic.
+func (s *BeadsServer) handleListEventSummaries(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := model.EventSummaryFilter{
+		BeadID: s.resolveBeadID(r.Context(), q.Get("bead_id")),
+		Topic:  q.Get("topic"),
+		Limit:  defaultSummaryPage,
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		filter.Limit = min(n, maxSummaryPage)
+	}
+	summaries, err := s.store.ListEventSummaries(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list event summaries")
+		return
+	}
+	if summaries == nil {
+		summaries = []*model.EventSummary{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"summaries": summaries})
+}
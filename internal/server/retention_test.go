@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// seedRetention adds published events 10 and 100 days old on bd-r1 for a
+// comment topic and a bead topic, one more of each that is unpublished, and
+// a recent one.
+func seedRetention(ms *mockStore) {
+	now := time.Now().UTC()
+	add := func(topic string, age time.Duration, published bool) {
+		e := &model.Event{ID: int64(len(ms.events) + 1), Topic: topic, BeadID: "bd-r1", Actor: "alice", Payload: []byte(`{}`), CreatedAt: now.Add(-age)}
+		ms.events = append(ms.events, e)
+		ms.published[e.ID] = published
+	}
+	day := 24 * time.Hour
+	for _, topic := range []string{"beads.comment.added", "beads.bead.updated"} {
+		add(topic, 100*day, true)
+		add(topic, 10*day, true)
+		add(topic, 100*day, false)
+	}
+	add("beads.bead.updated", time.Minute, true)
+}
+
+func TestCompactEvents(t *testing.T) {
+	s, ms, _ := newTestServer()
+	seedRetention(ms)
+	rules, err := model.ParseRetention("*=720h,beads.comment.*=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := s.CompactEvents(context.Background(), rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the published bead event older than 30 days goes; comment events
+	// are kept forever and unpublished ones wait for the outbox.
+	if run.Total != 1 || run.Compacted["*"] != 1 || len(ms.events) != 6 {
+		t.Fatalf("run = %+v, %d events left", run, len(ms.events))
+	}
+	if len(ms.summaries) != 1 || ms.summaries[0].Topic != "beads.bead.updated" || ms.summaries[0].Count != 1 || ms.summaries[0].Actors[0] != "alice" {
+		t.Fatalf("summaries = %+v", ms.summaries)
+	}
+
+	rules, _ = model.ParseRetention("*=720h,beads.comment.*=168h")
+	if run, err = s.CompactEvents(context.Background(), rules); err != nil {
+		t.Fatal(err)
+	}
+	if run.Total != 2 || run.Compacted["beads.comment.*"] != 2 || run.Compacted["*"] != 0 {
+		t.Fatalf("run = %+v", run)
+	}
+}
+
+func TestHandleCompaction(t *testing.T) {
+	s, ms, h := newTestServer()
+	seedRetention(ms)
+
+	requireStatus(t, doBearer(t, h, "POST", "/v1/admin/compaction/run?older_than_days=30", "", nil), http.StatusUnauthorized)
+	s.SetRegistrationTokens("admin-secret", "boot-secret")
+	requireStatus(t, doBearer(t, h, "GET", "/v1/admin/compaction", "boot-secret", nil), http.StatusUnauthorized)
+	requireStatus(t, doBearer(t, h, "POST", "/v1/admin/compaction/run", "admin-secret", nil), http.StatusBadRequest)
+	requireStatus(t, doBearer(t, h, "POST", "/v1/admin/compaction/run?older_than_days=0", "admin-secret", nil), http.StatusBadRequest)
+
+	rec := doBearer(t, h, "GET", "/v1/admin/compaction", "admin-secret", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var status struct {
+		Rules   []retentionRuleJSON `json:"rules"`
+		LastRun *compactionRun      `json:"last_run"`
+	}
+	decodeJSON(t, rec, &status)
+	if len(status.Rules) != 0 || status.LastRun != nil {
+		t.Fatalf("status before any run = %+v", status)
+	}
+
+	rec = doBearer(t, h, "POST", "/v1/admin/compaction/run?older_than_days=30", "admin-secret", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var run compactionRun
+	decodeJSON(t, rec, &run)
+	if run.Total != 2 {
+		t.Fatalf("run = %+v, want 2 events compacted", run)
+	}
+
+	// With a policy set, the age may be omitted, and the status shows it.
+	s.SetEventRetention([]model.RetentionRule{{Topic: "*", MaxAge: 7 * 24 * time.Hour}})
+	requireStatus(t, doBearer(t, h, "POST", "/v1/admin/compaction/run", "admin-secret", nil), http.StatusOK)
+	rec = doBearer(t, h, "GET", "/v1/admin/compaction", "admin-secret", nil)
+	requireStatus(t, rec, http.StatusOK)
+	decodeJSON(t, rec, &status)
+	if len(status.Rules) != 1 || status.Rules[0].MaxAge != "168h0m0s" || status.LastRun == nil || status.LastRun.Total != 2 {
+		t.Fatalf("status = %+v", status)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/events/summaries?bead_id=bd-r1&topic=beads.bead.updated", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var page struct {
+		Summaries []*model.EventSummary `json:"summaries"`
+	}
+	decodeJSON(t, rec, &page)
+	total := int64(0)
+	for _, sum := range page.Summaries {
+		total += sum.Count
+	}
+	if len(page.Summaries) != 2 || total != 2 {
+		t.Fatalf("summaries = %+v", page.Summaries)
+	}
+	requireStatus(t, doJSON(t, h, "GET", "/v1/events/summaries?limit=0", nil), http.StatusBadRequest)
+}
@@ -0,0 +1,208 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// rulesActor is recorded as the actor of every change a rule makes. Events
+// by this actor are not evaluated against rules, so rules never chain.
+const rulesActor = "beads:rules"
+
+// namedRule is a rule: config with its name (the key without "rule:").
+type namedRule struct {
+	Name string
+	model.RuleConfig
+}
+
+// loadRules returns the valid rule: configs in name order. Invalid configs
+// are logged and skipped.
+func (s *BeadsServer) loadRules(ctx context.Context) ([]namedRule, error) {
+	configs, err := s.store.ListConfigs(ctx, "rule")
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]namedRule, 0, len(configs))
+	for _, c := range configs {
+		r := namedRule{Name: strings.TrimPrefix(c.Key, "rule:")}
+		if err := json.Unmarshal(c.Value, &r.RuleConfig); err != nil {
+			slog.Warn("skipping invalid rule config", "key", c.Key, "err", err)
+			continue
+		}
+		if err := r.Validate(); err != nil {
+			slog.Warn("skipping invalid rule config", "key", c.Key, "err", err)
+			continue
+		}
+		rules = append(rules, r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules, nil
+}
+
+// ruleTrigger returns the rule trigger for a dispatched event, or "" if
+// rules don't run on it.
+func ruleTrigger(e *model.Event) string {
+	if e.Actor == rulesActor {
+		return ""
+	}
+	switch e.Topic {
+	case events.TopicBeadCreated:
+		return model.RuleOnCreated
+	case events.TopicBeadUpdated:
+		return model.RuleOnUpdated
+	}
+	return ""
+}
+
+// applyRules evaluates the rule: configs against the beads created or
+// updated by dispatched events, firing each matching rule that has not
+// already fired on the bead. Failures are logged.
+func (s *BeadsServer) applyRules(ctx context.Context, dispatched []*model.Event) {
+	var rules []namedRule
+	for _, e := range dispatched {
+		trigger := ruleTrigger(e)
+		if trigger == "" {
+			continue
+		}
+		if rules == nil {
+			var err error
+			if rules, err = s.loadRules(ctx); err != nil {
+				slog.Warn("failed to load rules", "err", err)
+				return
+			}
+		}
+		if len(rules) == 0 {
+			return
+		}
+		if err := s.applyRulesTo(ctx, e.BeadID, trigger, rules); err != nil {
+			slog.Warn("failed to apply rules", "bead_id", e.BeadID, "err", err)
+		}
+	}
+}
+
+func (s *BeadsServer) applyRulesTo(ctx context.Context, beadID, trigger string, rules []namedRule) error {
+	b, err := s.store.GetBead(ctx, beadID)
+	if err != nil || b == nil || b.Status == model.StatusClosed {
+		return err
+	}
+	fired, err := s.firedRules(ctx, beadID)
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		if fired[r.Name] || !r.Triggers(trigger) || !r.When.Matches(b) {
+			continue
+		}
+		if b, err = s.fireRule(ctx, r, b, trigger); err != nil {
+			return fmt.Errorf("rule %s: %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// firedRules returns the names of the rules that have fired on a bead.
+func (s *BeadsServer) firedRules(ctx context.Context, beadID string) (map[string]bool, error) {
+	evs, err := s.store.GetEvents(ctx, beadID)
+	if err != nil {
+		return nil, err
+	}
+	fired := map[string]bool{}
+	for _, e := range evs {
+		if e.Topic != events.TopicRuleFired {
+			continue
+		}
+		var rf events.RuleFired
+		if json.Unmarshal(e.Payload, &rf) == nil {
+			fired[rf.Rule] = true
+		}
+	}
+	return fired, nil
+}
+
+// fireRule applies a rule's actions to b as rulesActor and records a
+// RuleFired event. Returns the updated bead.
+func (s *BeadsServer) fireRule(ctx context.Context, r namedRule, b *model.Bead, trigger string) (*model.Bead, error) {
+	ctx = withIdentity(ctx, rulesActor)
+	fired := events.RuleFired{Rule: r.Name, BeadID: b.ID, Trigger: trigger, Actions: []string{}}
+
+	in := updateBeadInput{UpdatedBy: rulesActor}
+	if a := r.Then.Assign; a != "" && a != b.Assignee {
+		in.Assignee = &a
+		fired.Actions = append(fired.Actions, "assign:"+a)
+	}
+	labels := slices.Clone(b.Labels)
+	for _, l := range r.Then.AddLabels {
+		if !slices.Contains(labels, l) {
+			labels = append(labels, l)
+			fired.Actions = append(fired.Actions, "label:"+l)
+		}
+	}
+	if len(labels) > len(b.Labels) {
+		in.Labels, in.labelsSet = labels, true
+	}
+	if p := r.Then.Priority; p != nil && *p != b.Priority {
+		in.Priority = p
+		fired.Actions = append(fired.Actions, fmt.Sprintf("priority:%d", *p))
+	}
+	if !in.empty() {
+		updated, err := s.updateBead(ctx, b.ID, in)
+		if err != nil {
+			return b, err
+		}
+		b = updated
+	}
+
+	var followUp *model.Bead
+	if f := r.Then.FollowUp; f != nil {
+		var err error
+		followUp, err = s.prepareBead(ctx, createBeadInput{
+			Title:     f.ExpandTitle(b),
+			Type:      string(f.Type),
+			Priority:  b.Priority,
+			Assignee:  f.Assignee,
+			Labels:    f.Labels,
+			CreatedBy: rulesActor,
+		})
+		if err != nil {
+			return b, fmt.Errorf("creating follow-up: %w", err)
+		}
+		fired.FollowUpID = followUp.ID
+		fired.Actions = append(fired.Actions, "follow_up:"+followUp.ID)
+	}
+
+	// The follow-up, its link and the firing commit together.
+	err := s.withFreeSlug(ctx, followUp, func() error {
+		return s.store.RunInTransaction(ctx, func(tx store.Store) error {
+			if followUp != nil {
+				if err := s.insertBead(ctx, tx, followUp); err != nil {
+					return fmt.Errorf("creating follow-up: %w", err)
+				}
+				dep := &model.Dependency{
+					BeadID:      followUp.ID,
+					DependsOnID: b.ID,
+					Type:        r.Then.FollowUp.DepType,
+					CreatedAt:   followUp.CreatedAt,
+					CreatedBy:   rulesActor,
+				}
+				if err := s.insertDependency(ctx, tx, dep); err != nil {
+					return fmt.Errorf("linking follow-up: %w", err)
+				}
+			}
+			return s.recordEvent(ctx, tx, events.TopicRuleFired, b.ID, rulesActor, fired)
+		})
+	})
+	if err != nil {
+		return b, err
+	}
+	s.flushEvents(ctx)
+	return b, nil
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestRules_FireOnCreateOnce(t *testing.T) {
+	_, ms, h := newTestServer()
+	requireStatus(t, doJSON(t, h, "PUT", "/v1/configs/rule:security", map[string]any{"value": map[string]any{
+		"when": map[string]any{"types": []string{"bug"}, "labels": []string{"security"}},
+		"then": map[string]any{
+			"assign":     "team/security",
+			"add_labels": []string{"triage"},
+			"priority":   0,
+			"follow_up":  map[string]any{"title": "Audit after {id}", "labels": []string{"audit"}},
+		},
+	}}), http.StatusOK)
+
+	rec := doJSON(t, h, "POST", "/v1/beads", map[string]any{
+		"title": "XSS in login", "type": "bug", "labels": []string{"security"}, "priority": 2, "created_by": "alice",
+	})
+	requireStatus(t, rec, http.StatusCreated)
+	var created model.Bead
+	decodeJSON(t, rec, &created)
+
+	b := ms.beads[created.ID]
+	if b.Assignee != "team/security" || b.Priority != 0 {
+		t.Errorf("bead after rule: assignee=%q priority=%d", b.Assignee, b.Priority)
+	}
+	if got := ms.labels[created.ID]; len(got) != 2 || got[1] != "triage" {
+		t.Errorf("labels = %v, want security, triage", got)
+	}
+
+	var fired []events.RuleFired
+	for _, e := range ms.events {
+		if e.Topic == events.TopicRuleFired {
+			var rf events.RuleFired
+			if err := json.Unmarshal(e.Payload, &rf); err != nil {
+				t.Fatal(err)
+			}
+			if e.Actor != rulesActor || e.BeadID != created.ID {
+				t.Errorf("rule.fired event actor=%q bead=%q", e.Actor, e.BeadID)
+			}
+			fired = append(fired, rf)
+		}
+	}
+	if len(fired) != 1 || fired[0].Rule != "security" || fired[0].Trigger != "created" || fired[0].FollowUpID == "" {
+		t.Fatalf("fired = %+v", fired)
+	}
+	followUp := ms.beads[fired[0].FollowUpID]
+	if followUp == nil || followUp.Title != "Audit after "+created.ID || followUp.CreatedBy != rulesActor {
+		t.Fatalf("follow-up = %+v", followUp)
+	}
+	if d := ms.deps[followUp.ID]; len(d) != 1 || d[0].DependsOnID != created.ID || d[0].Type != model.RelRelatesTo {
+		t.Errorf("follow-up deps = %+v", d)
+	}
+
+	// A later update still matches, but the rule has already fired.
+	n := len(ms.events)
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/"+created.ID, map[string]any{"description": "repro attached"}), http.StatusOK)
+	if len(ms.events) != n+1 {
+		t.Errorf("update recorded %d events, want only the update", len(ms.events)-n)
+	}
+}
+
+func TestRules_OnUpdateOnly(t *testing.T) {
+	_, ms, h := newTestServer()
+	requireStatus(t, doJSON(t, h, "PUT", "/v1/configs/rule:escalate", map[string]any{"value": map[string]any{
+		"on":   []string{"updated"},
+		"when": map[string]any{"labels": []string{"p0"}},
+		"then": map[string]any{"priority": 0},
+	}}), http.StatusOK)
+
+	rec := doJSON(t, h, "POST", "/v1/beads", map[string]any{"title": "Slow page", "type": "task", "labels": []string{"p0"}, "priority": 3})
+	requireStatus(t, rec, http.StatusCreated)
+	var created model.Bead
+	decodeJSON(t, rec, &created)
+	if ms.beads[created.ID].Priority != 3 {
+		t.Fatal("rule fired on create")
+	}
+
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/"+created.ID, map[string]any{"title": "Very slow page"}), http.StatusOK)
+	if ms.beads[created.ID].Priority != 0 {
+		t.Error("rule did not fire on update")
+	}
+}
+
+func TestRules_InvalidConfigRejected(t *testing.T) {
+	_, _, h := newTestServer()
+	rec := doJSON(t, h, "PUT", "/v1/configs/rule:bad", map[string]any{"value": map[string]any{
+		"when": map[string]any{"types": []string{"bug"}},
+		"then": map[string]any{},
+	}})
+	requireStatus(t, rec, http.StatusBadRequest)
+}
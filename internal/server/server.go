@@ -3,14 +3,23 @@ package server
 import (
 	"context"
 	"encoding/json"
-	"log/slog"
+	"errors"
+	"net/http"
+	"sync"
 	"time"
 
 	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/alerts"
 	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/metrics"
 	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/oidc"
+	"github.com/alfredjeanlab/beads/internal/shadow"
 	"github.com/alfredjeanlab/beads/internal/store"
+	"github.com/alfredjeanlab/beads/internal/store/cached"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 )
 
@@ -19,35 +28,123 @@ type BeadsServer struct {
 	beadsv1.UnimplementedBeadsServiceServer
 	store     store.Store
 	publisher events.Publisher
+	alerts    *alerts.Evaluator  // optional; nil when alerting is disabled
+	metrics   *metrics.Collector // optional; nil when /metrics is not served
+	health    *health.Server     // grpc.health.v1 status, registered by NewGRPCServer
+	shadow    *shadow.Shadow     // optional; nil when no route is shadowed
+	cache     *cached.Store      // optional; nil when reads are not cached
+	hub       *eventHub          // recorded events, for /v1/events/stream
+	versions  VersionPolicy      // advertised versions; MinClientVersion is enforced
+	outboxMu  sync.Mutex         // serialises dispatchEvents so events leave in order
+
+	// Tokens accepted by agent registration; registration is disabled when
+	// both are empty.
+	adminToken     string
+	bootstrapToken string
+
+	// Verifies OIDC bearer tokens; nil when only static tokens are accepted.
+	// Callers whose token carries oidcAdminRole count as admins.
+	oidc          *oidc.Verifier
+	oidcAdminRole string
+
+	// Config namespaces only admins may read or write.
+	adminConfigNamespaces []string
+
+	// Rejects comments claiming an author other than the authenticated
+	// caller instead of attributing them to the caller.
+	strictAuthors bool
+
+	// How often an idle event stream sends a keepalive comment, and how
+	// long a stream stays open before its client is told to reconnect;
+	// 0 = until the client leaves.
+	streamKeepalive   time.Duration
+	streamMaxLifetime time.Duration
+
+	// How long a bead stays closed before it is archived; 0 = never.
+	archiveAfter time.Duration
+
+	// How long events are kept, by topic, before compaction summarizes and
+	// deletes them; none keeps every event. compactionMu serializes runs and
+	// guards lastCompaction, the latest run's result.
+	retention      []model.RetentionRule
+	compactionMu   sync.Mutex
+	lastCompaction *compactionRun
+
+	// How long an agent may be reaped or stale before its in-progress
+	// beads are unassigned; 0 = never.
+	unassignAfter time.Duration
+
+	// How external dependencies are probed: the client for every probe,
+	// and the GitHub API base URL ("" = api.github.com) and optional token
+	// for github-pr probes.
+	probeClient *http.Client
+	githubAPI   string
+	githubToken string
+
+	// Where admin snapshots are written; nil disables them.
+	snapshots      SnapshotStore
+	snapshotPrefix string
 }
 
 // NewBeadsServer returns a new BeadsServer backed by the given store and publisher.
 func NewBeadsServer(s store.Store, p events.Publisher) *BeadsServer {
+	hs := health.NewServer()
+	hs.SetServingStatus(beadsv1.BeadsService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
 	return &BeadsServer{
 		store:     s,
 		publisher: p,
+		health:    hs,
+		hub:       newEventHub(),
+
+		streamKeepalive: DefaultStreamKeepalive,
 	}
 }
 
-// recordAndPublish persists an event to the store and publishes it to NATS.
-// Both operations are best-effort; failures are logged but do not block the caller.
-func (s *BeadsServer) recordAndPublish(ctx context.Context, topic, beadID, actor string, event any) {
-	payload, err := json.Marshal(event)
-	if err != nil {
-		slog.Warn("failed to marshal event", "topic", topic, "bead_id", beadID, "error", err)
-		return
-	}
-	if err := s.store.RecordEvent(ctx, &model.Event{
-		Topic:   topic,
-		BeadID:  beadID,
-		Actor:   actor,
-		Payload: payload,
-	}); err != nil {
-		slog.Warn("failed to record event", "topic", topic, "bead_id", beadID, "error", err)
-	}
-	if err := s.publisher.Publish(ctx, topic, event); err != nil {
-		slog.Warn("failed to publish event", "topic", topic, "bead_id", beadID, "error", err)
+// Shutdown marks every service NOT_SERVING in the health service so load
+// balancers and probes drain traffic before the servers stop.
+func (s *BeadsServer) Shutdown() {
+	s.health.Shutdown()
+	s.hub.close()
+}
+
+// SetAlertEvaluator attaches an alert evaluator whose state is served by
+// the alerts endpoints, and feeds it the event stream's drop count.
+func (s *BeadsServer) SetAlertEvaluator(e *alerts.Evaluator) {
+	s.alerts = e
+	e.SetGauge(alerts.MetricStreamDrops, s.hub.dropGauge())
+}
+
+// SetMetricsCollector attaches the collector whose gauges are served by
+// GET /metrics.
+func (s *BeadsServer) SetMetricsCollector(c *metrics.Collector) {
+	s.metrics = c
+}
+
+// SetShadow attaches the shadow runner that compares candidate route
+// implementations against the primary ones.
+func (s *BeadsServer) SetShadow(sh *shadow.Shadow) {
+	s.shadow = sh
+}
+
+// SetReadCache attaches the read cache whose hit counters are served by
+// GET /metrics. The cache must already wrap the server's store.
+func (s *BeadsServer) SetReadCache(c *cached.Store) {
+	s.cache = c
+}
+
+// SetRegistrationTokens sets the admin and bootstrap tokens that authorize
+// agent registration.
+func (s *BeadsServer) SetRegistrationTokens(admin, bootstrap string) {
+	s.adminToken = admin
+	s.bootstrapToken = bootstrap
+}
+
+// currentAlerts returns the evaluator's alert state, or nil when alerting is disabled.
+func (s *BeadsServer) currentAlerts() []alerts.Alert {
+	if s.alerts == nil {
+		return nil
 	}
+	return s.alerts.Alerts()
 }
 
 // inputError indicates invalid user input.
@@ -56,6 +153,25 @@ type inputError string
 
 func (e inputError) Error() string { return string(e) }
 
+// authError indicates missing or invalid credentials.
+// Transport layers map this to 401 / Unauthenticated.
+type authError string
+
+func (e authError) Error() string { return string(e) }
+
+// forbiddenError indicates the caller may not do what it asked, such as
+// act as someone else or touch an admin-only config.
+// Transport layers map this to 403 / PermissionDenied.
+type forbiddenError string
+
+func (e forbiddenError) Error() string { return string(e) }
+
+// conflictError indicates the resource already exists.
+// Transport layers map this to 409 / AlreadyExists.
+type conflictError string
+
+func (e conflictError) Error() string { return string(e) }
+
 // AddDependency creates a dependency between two beads.
 func (s *BeadsServer) AddDependency(ctx context.Context, req *beadsv1.AddDependencyRequest) (*beadsv1.AddDependencyResponse, error) {
 	if req.GetBeadId() == "" {
@@ -64,6 +180,9 @@ func (s *BeadsServer) AddDependency(ctx context.Context, req *beadsv1.AddDepende
 	if req.GetDependsOnId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "depends_on_id is required")
 	}
+	if err := model.ValidateDependencyMetadata(json.RawMessage(req.GetMetadata())); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	now := time.Now().UTC()
 	dep := &model.Dependency{
@@ -71,15 +190,20 @@ func (s *BeadsServer) AddDependency(ctx context.Context, req *beadsv1.AddDepende
 		DependsOnID: req.GetDependsOnId(),
 		Type:        model.DependencyType(req.GetType()),
 		CreatedAt:   now,
-		CreatedBy:   req.GetCreatedBy(),
+		CreatedBy:   s.actorFor(ctx, req.GetCreatedBy()),
+	}
+	if req.GetMetadata() != "" {
+		dep.Metadata = json.RawMessage(req.GetMetadata())
 	}
 
-	if err := s.store.AddDependency(ctx, dep); err != nil {
+	if err := s.addDependency(ctx, dep); err != nil {
+		var ce *cycleError
+		if errors.As(err, &ce) {
+			return nil, ce.status()
+		}
 		return nil, status.Errorf(codes.Internal, "failed to add dependency: %v", err)
 	}
 
-	s.recordAndPublish(ctx, events.TopicDependencyAdded, dep.BeadID, dep.CreatedBy, events.DependencyAdded{Dependency: dep})
-
 	return &beadsv1.AddDependencyResponse{
 		Dependency: dependencyToProto(dep),
 	}, nil
@@ -94,17 +218,56 @@ func (s *BeadsServer) RemoveDependency(ctx context.Context, req *beadsv1.RemoveD
 		return nil, status.Error(codes.InvalidArgument, "depends_on_id is required")
 	}
 
-	if err := s.store.RemoveDependency(ctx, req.GetBeadId(), req.GetDependsOnId(), model.DependencyType(req.GetType())); err != nil {
+	if err := s.removeDependency(ctx, req.GetBeadId(), req.GetDependsOnId(), model.DependencyType(req.GetType())); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to remove dependency: %v", err)
 	}
 
-	s.recordAndPublish(ctx, events.TopicDependencyRemoved, req.GetBeadId(), "", events.DependencyRemoved{
-		BeadID:      req.GetBeadId(),
-		DependsOnID: req.GetDependsOnId(),
-		Type:        req.GetType(),
+	return &beadsv1.RemoveDependencyResponse{}, nil
+}
+
+// addDependency adds dep and records its event in one transaction.
+// Returns a *cycleError if dep would close a loop of blocking dependencies.
+func (s *BeadsServer) addDependency(ctx context.Context, dep *model.Dependency) error {
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		return s.insertDependency(ctx, tx, dep)
 	})
+	if err != nil {
+		return err
+	}
+	s.flushEvents(ctx)
+	return nil
+}
 
-	return &beadsv1.RemoveDependencyResponse{}, nil
+// insertDependency adds dep through tx after checking it against the
+// dependencies tx sees, and records its DependencyAdded event.
+func (s *BeadsServer) insertDependency(ctx context.Context, tx store.Store, dep *model.Dependency) error {
+	if err := s.checkDependencyCycle(ctx, tx, dep); err != nil {
+		return err
+	}
+	if err := tx.AddDependency(ctx, dep); err != nil {
+		return err
+	}
+	return s.recordEvent(ctx, tx, events.TopicDependencyAdded, dep.BeadID, dep.CreatedBy, events.DependencyAdded{Dependency: dep})
+}
+
+// removeDependency removes a dependency and records its event in one
+// transaction.
+func (s *BeadsServer) removeDependency(ctx context.Context, beadID, dependsOnID string, depType model.DependencyType) error {
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := tx.RemoveDependency(ctx, beadID, dependsOnID, depType); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicDependencyRemoved, beadID, "", events.DependencyRemoved{
+			BeadID:      beadID,
+			DependsOnID: dependsOnID,
+			Type:        string(depType),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	s.flushEvents(ctx)
+	return nil
 }
 
 // GetDependencies returns all dependencies for a bead.
@@ -134,16 +297,18 @@ func (s *BeadsServer) AddLabel(ctx context.Context, req *beadsv1.AddLabelRequest
 	if req.GetLabel() == "" {
 		return nil, status.Error(codes.InvalidArgument, "label is required")
 	}
+	if err := s.checkLabels(ctx, []string{req.GetLabel()}); err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to check label: %v", err)
+	}
 
-	if err := s.store.AddLabel(ctx, req.GetBeadId(), req.GetLabel()); err != nil {
+	if err := s.addLabel(ctx, req.GetBeadId(), req.GetLabel()); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to add label: %v", err)
 	}
 
-	s.recordAndPublish(ctx, events.TopicLabelAdded, req.GetBeadId(), "", events.LabelAdded{
-		BeadID: req.GetBeadId(),
-		Label:  req.GetLabel(),
-	})
-
 	// Fetch the updated bead to return.
 	bead, err := s.store.GetBead(ctx, req.GetBeadId())
 	if err != nil {
@@ -165,15 +330,10 @@ func (s *BeadsServer) RemoveLabel(ctx context.Context, req *beadsv1.RemoveLabelR
 		return nil, status.Error(codes.InvalidArgument, "label is required")
 	}
 
-	if err := s.store.RemoveLabel(ctx, req.GetBeadId(), req.GetLabel()); err != nil {
+	if err := s.removeLabel(ctx, req.GetBeadId(), req.GetLabel()); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to remove label: %v", err)
 	}
 
-	s.recordAndPublish(ctx, events.TopicLabelRemoved, req.GetBeadId(), "", events.LabelRemoved{
-		BeadID: req.GetBeadId(),
-		Label:  req.GetLabel(),
-	})
-
 	return &beadsv1.RemoveLabelResponse{}, nil
 }
 
@@ -191,6 +351,30 @@ func (s *BeadsServer) GetLabels(ctx context.Context, req *beadsv1.GetLabelsReque
 	return &beadsv1.GetLabelsResponse{Labels: labels}, nil
 }
 
+// addComment adds a comment and records its event in one transaction. The
+// registered actors it @mentions are notified with a mention event.
+func (s *BeadsServer) addComment(ctx context.Context, comment *model.Comment) error {
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		if err := tx.AddComment(ctx, comment); err != nil {
+			return err
+		}
+		if err := s.recordEvent(ctx, tx, events.TopicCommentAdded, comment.BeadID, comment.Author, events.CommentAdded{Comment: comment}); err != nil {
+			return err
+		}
+		mentioned, err := mentionedActors(ctx, tx, comment)
+		if err != nil || len(mentioned) == 0 {
+			return err
+		}
+		return s.recordEventNotifying(ctx, tx, events.TopicMention, comment.BeadID, comment.Author,
+			events.Mention{Comment: comment, Actors: mentioned}, mentioned)
+	})
+	if err != nil {
+		return err
+	}
+	s.flushEvents(ctx)
+	return nil
+}
+
 // AddComment adds a comment to a bead.
 func (s *BeadsServer) AddComment(ctx context.Context, req *beadsv1.AddCommentRequest) (*beadsv1.AddCommentResponse, error) {
 	if req.GetBeadId() == "" {
@@ -203,17 +387,17 @@ func (s *BeadsServer) AddComment(ctx context.Context, req *beadsv1.AddCommentReq
 	now := time.Now().UTC()
 	comment := &model.Comment{
 		BeadID:    req.GetBeadId(),
-		Author:    req.GetAuthor(),
 		Text:      req.GetText(),
 		CreatedAt: now,
 	}
+	if err := s.attributeComment(ctx, comment, req.GetAuthor()); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
 
-	if err := s.store.AddComment(ctx, comment); err != nil {
+	if err := s.addComment(ctx, comment); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to add comment: %v", err)
 	}
 
-	s.recordAndPublish(ctx, events.TopicCommentAdded, comment.BeadID, comment.Author, events.CommentAdded{Comment: comment})
-
 	return &beadsv1.AddCommentResponse{
 		Comment: commentToProto(comment),
 	}, nil
@@ -257,6 +441,16 @@ func (s *BeadsServer) GetEvents(ctx context.Context, req *beadsv1.GetEventsReque
 	return &beadsv1.GetEventsResponse{Events: pbEvents}, nil
 }
 
+// ListAlerts returns the current alert state.
+func (s *BeadsServer) ListAlerts(_ context.Context, _ *beadsv1.ListAlertsRequest) (*beadsv1.ListAlertsResponse, error) {
+	list := s.currentAlerts()
+	pbAlerts := make([]*beadsv1.Alert, 0, len(list))
+	for i := range list {
+		pbAlerts = append(pbAlerts, alertToProto(&list[i]))
+	}
+	return &beadsv1.ListAlertsResponse{Alerts: pbAlerts}, nil
+}
+
 // Health returns the service health status.
 func (s *BeadsServer) Health(_ context.Context, _ *beadsv1.HealthRequest) (*beadsv1.HealthResponse, error) {
 	return &beadsv1.HealthResponse{Status: "ok"}, nil
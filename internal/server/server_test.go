@@ -90,6 +90,10 @@ func TestGRPCErrorCodes(t *testing.T) {
 			_, err := s.DeleteBead(ctx, &beadsv1.DeleteBeadRequest{Id: "nonexistent"})
 			return err
 		}, codes.NotFound},
+		{"DeleteBead/InvalidCascade", func(s *BeadsServer, ctx context.Context) error {
+			_, err := s.DeleteBead(ctx, &beadsv1.DeleteBeadRequest{Id: "x", Cascade: "all"})
+			return err
+		}, codes.InvalidArgument},
 
 		// Dependencies
 		{"AddDependency/MissingBeadID", func(s *BeadsServer, ctx context.Context) error {
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	beadsync "github.com/alfredjeanlab/beads/internal/sync"
+)
+
+// SnapshotStore holds admin snapshots as objects, such as the S3 bucket
+// sync writes to.
+type SnapshotStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// SetSnapshotStore enables POST /v1/admin/snapshot and /v1/admin/restore,
+// storing snapshots in ss under prefix.
+func (s *BeadsServer) SetSnapshotStore(ss SnapshotStore, prefix string) {
+	s.snapshots = ss
+	s.snapshotPrefix = prefix
+}
+
+// Snapshot writes a logical dump of the store to the snapshot store and
+// returns its key and size.
+func (s *BeadsServer) Snapshot(ctx context.Context) (string, int64, error) {
+	// Spool the dump so the upload knows its length, as sync does.
+	f, err := os.CreateTemp("", "beads-snapshot-*.jsonl")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := beadsync.WriteSnapshot(ctx, s.store, f); err != nil {
+		return "", 0, err
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+	key := s.snapshotPrefix + time.Now().UTC().Format("20060102T150405Z") + ".jsonl"
+	if err := s.snapshots.Put(ctx, key, f); err != nil {
+		return "", 0, err
+	}
+	return key, size, nil
+}
+
+// handleSnapshot handles POST /v1/admin/snapshot. It requires the admin
+// token.
+func (s *BeadsServer) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	if err := s.authorizeAdmin(r.Context(), bearerToken(r.Header.Get("Authorization"))); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	if s.snapshots == nil {
+		writeError(w, http.StatusNotFound, "snapshots not configured; set BEADS_SYNC_S3_BUCKET")
+		return
+	}
+	key, size, err := s.Snapshot(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "snapshot failed: "+err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"key": key, "bytes": size})
+}
+
+// restoreRequest is the JSON body for POST /v1/admin/restore.
+type restoreRequest struct {
+	Key string `json:"key"`
+}
+
+// handleRestore handles POST /v1/admin/restore. It loads a snapshot into
+// an empty database and requires the admin token.
+func (s *BeadsServer) handleRestore(w http.ResponseWriter, r *http.Request) {
+	if err := s.authorizeAdmin(r.Context(), bearerToken(r.Header.Get("Authorization"))); err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	if s.snapshots == nil {
+		writeError(w, http.StatusNotFound, "snapshots not configured; set BEADS_SYNC_S3_BUCKET")
+		return
+	}
+	var req restoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if strings.TrimSpace(req.Key) == "" {
+		writeError(w, http.StatusBadRequest, "key is required")
+		return
+	}
+	rc, err := s.snapshots.Get(r.Context(), req.Key)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "snapshot not found: "+err.Error())
+		return
+	}
+	defer rc.Close()
+
+	stats, err := beadsync.RestoreSnapshot(r.Context(), s.store, rc)
+	if errors.Is(err, beadsync.ErrNotEmpty) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "restore failed: "+err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"key": req.Key, "restored": stats})
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// memSnapshots is an in-memory SnapshotStore.
+type memSnapshots map[string][]byte
+
+func (m memSnapshots) Put(_ context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	m[key] = data
+	return err
+}
+
+func (m memSnapshots) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	data, ok := m[key]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func TestHandleSnapshotAndRestore(t *testing.T) {
+	s, ms, h := newTestServer()
+	now := time.Now().UTC()
+	ms.beads["bd-s1"] = &model.Bead{ID: "bd-s1", Title: "Keep me", Status: model.StatusOpen, CreatedAt: now, UpdatedAt: now}
+	ms.labels["bd-s1"] = []string{"team:core"}
+
+	requireStatus(t, doBearer(t, h, "POST", "/v1/admin/snapshot", "", nil), http.StatusUnauthorized)
+	s.SetRegistrationTokens("admin-secret", "")
+	requireStatus(t, doBearer(t, h, "POST", "/v1/admin/snapshot", "admin-secret", nil), http.StatusNotFound)
+
+	snaps := memSnapshots{}
+	s.SetSnapshotStore(snaps, "snaps/")
+	rec := doBearer(t, h, "POST", "/v1/admin/snapshot", "admin-secret", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var snap struct {
+		Key   string `json:"key"`
+		Bytes int64  `json:"bytes"`
+	}
+	decodeJSON(t, rec, &snap)
+	if len(snaps[snap.Key]) == 0 || int64(len(snaps[snap.Key])) != snap.Bytes {
+		t.Fatalf("snapshot %+v not stored (have %v)", snap, len(snaps))
+	}
+
+	// The source still has beads.
+	requireStatus(t, doBearer(t, h, "POST", "/v1/admin/restore", "admin-secret", map[string]string{"key": snap.Key}), http.StatusConflict)
+
+	fresh := NewBeadsServer(newMockStore(), &events.NoopPublisher{})
+	fresh.SetRegistrationTokens("admin-secret", "")
+	fresh.SetSnapshotStore(snaps, "snaps/")
+	fh := fresh.NewHTTPHandler()
+	requireStatus(t, doBearer(t, fh, "POST", "/v1/admin/restore", "admin-secret", map[string]string{}), http.StatusBadRequest)
+	requireStatus(t, doBearer(t, fh, "POST", "/v1/admin/restore", "admin-secret", map[string]string{"key": "snaps/missing.jsonl"}), http.StatusNotFound)
+
+	rec = doBearer(t, fh, "POST", "/v1/admin/restore", "admin-secret", map[string]string{"key": snap.Key})
+	requireStatus(t, rec, http.StatusOK)
+	rec = doJSON(t, fh, "GET", "/v1/beads/bd-s1", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var b model.Bead
+	decodeJSON(t, rec, &b)
+	if b.Title != "Keep me" {
+		t.Fatalf("restored bead = %+v", b)
+	}
+}
@@ -0,0 +1,230 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// Starvation report defaults and bounds.
+const (
+	defaultStarvationAge   = "3d"
+	defaultStarvationLimit = 50
+	maxStarvationLimit     = 500
+)
+
+// starvationGroups are the dimensions GET /v1/reports/starvation can group
+// by.
+var starvationGroups = []string{model.GroupByPriority, model.GroupByLabel}
+
+// starvingBead is a bead that has been ready and unclaimed since ReadySince.
+type starvingBead struct {
+	Bead       *model.Bead `json:"bead"`
+	ReadySince time.Time   `json:"ready_since"`
+	Waiting    string      `json:"waiting"` // how long, to the minute, e.g. "76h5m0s"
+}
+
+// starvationGroup counts the starving beads sharing a priority or label.
+type starvationGroup struct {
+	Key        string    `json:"key"`
+	Count      int       `json:"count"`
+	ReadySince time.Time `json:"ready_since"` // of the longest waiting bead
+}
+
+// starvationReport is the body of GET /v1/reports/starvation.
+type starvationReport struct {
+	OlderThan string             `json:"older_than"`
+	Group     string             `json:"group"`
+	Total     int                `json:"total"`
+	Groups    []*starvationGroup `json:"groups"` // most beads first
+	Beads     []*starvingBead    `json:"beads"`  // longest waiting first, up to the limit
+}
+
+// readySince returns when b last became ready to claim: the latest of its
+// creation, the closing of its blockers, and any later reopen, undefer or
+// release of a claim. Events compacted away are not seen, so the time may
+// be early for beads that changed long ago.
+func readySince(ctx context.Context, st store.Store, types depTypeSet, b *model.Bead) (time.Time, error) {
+	since := b.CreatedAt
+	later := func(t *time.Time) {
+		if t != nil && t.After(since) {
+			since = *t
+		}
+	}
+
+	deps, err := st.GetDependencies(ctx, b.ID)
+	if err != nil {
+		return since, err
+	}
+	for _, d := range deps {
+		if !types.blocking(d.Type) {
+			continue
+		}
+		blocker, err := st.GetBead(ctx, d.DependsOnID)
+		if err != nil || blocker == nil {
+			continue
+		}
+		later(blocker.ClosedAt)
+	}
+
+	evs, err := st.GetEvents(ctx, b.ID)
+	if err != nil {
+		return since, err
+	}
+	for _, e := range evs {
+		switch e.Topic {
+		case events.TopicBeadReopened, events.TopicBeadUndeferred:
+			later(&e.CreatedAt)
+		case events.TopicBeadUpdated:
+			ev, err := events.Decode(e.Topic, e.Payload)
+			if err != nil {
+				continue
+			}
+			u, ok := ev.(events.BeadUpdated)
+			if !ok {
+				continue
+			}
+			assignee, released := u.Changes["assignee"]
+			status, _ := u.Changes["status"].(string)
+			if (released && assignee == "") || status == string(model.StatusOpen) {
+				later(&e.CreatedAt)
+			}
+		}
+	}
+	return since, nil
+}
+
+// starvationReport lists the ready, unassigned beads matching filter that
+// bd claim could take and that have waited at least olderThan at now,
+// longest waiting first, and counts them by group.
+func (s *BeadsServer) starvationReport(ctx context.Context, filter model.BeadFilter, olderThan time.Duration, now time.Time, group string, limit int) (*starvationReport, error) {
+	report := &starvationReport{Group: group, Groups: []*starvationGroup{}, Beads: []*starvingBead{}}
+	err := s.store.RunInSnapshot(ctx, func(tx store.Store) error {
+		types, err := s.depTypes(ctx)
+		if err != nil {
+			return err
+		}
+		filter.Status = []model.Status{model.StatusOpen}
+		filter.Kind = []model.Kind{model.KindIssue}
+		filter.Assignee = ""
+		filter.Limit, filter.Offset = 0, 0
+		// A bead is ready no earlier than it was created.
+		cutoff := now.Add(-olderThan)
+		filter.CreatedBefore = &cutoff
+		ready, _, err := tx.ListReadyBeads(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		var starving []*starvingBead
+		for _, b := range ready {
+			if b.Assignee != "" || b.Type == "gate" {
+				continue
+			}
+			since, err := readySince(ctx, tx, types, b)
+			if err != nil {
+				return err
+			}
+			if since.After(cutoff) {
+				continue
+			}
+			starving = append(starving, &starvingBead{Bead: b, ReadySince: since, Waiting: now.Sub(since).Truncate(time.Minute).String()})
+		}
+		sort.SliceStable(starving, func(i, j int) bool { return starving[i].ReadySince.Before(starving[j].ReadySince) })
+
+		byKey := map[string]*starvationGroup{}
+		tally := func(key string, sb *starvingBead) {
+			g, ok := byKey[key]
+			if !ok {
+				g = &starvationGroup{Key: key, ReadySince: sb.ReadySince}
+				byKey[key] = g
+				report.Groups = append(report.Groups, g)
+			}
+			g.Count++
+		}
+		for _, sb := range starving {
+			switch group {
+			case model.GroupByPriority:
+				tally(strconv.Itoa(sb.Bead.Priority), sb)
+			case model.GroupByLabel:
+				labels, err := tx.GetLabels(ctx, sb.Bead.ID)
+				if err != nil {
+					return err
+				}
+				if len(labels) == 0 {
+					tally("", sb)
+				}
+				for _, l := range labels {
+					tally(l, sb)
+				}
+			}
+		}
+		report.Total = len(starving)
+		report.Beads = starving[:min(limit, len(starving))]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Key < b.Key
+	})
+	return report, nil
+}
+
+// handleStarvation handles GET /v1/reports/starvation?older_than=3d&group=label:
+// the ready, unclaimed beads matching the list filters of GET /v1/beads that
+// have waited longer than older_than (days, weeks or a Go duration; default
+// three days), grouped by priority (the default) or label.
+func (s *BeadsServer) handleStarvation(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	olderText := q.Get("older_than")
+	if olderText == "" {
+		olderText = defaultStarvationAge
+	}
+	olderThan, err := model.ParseWindow(olderText)
+	if err != nil || olderThan <= 0 {
+		writeError(w, http.StatusBadRequest, "older_than must be a positive number of days (3d), weeks (1w) or a duration")
+		return
+	}
+	group := q.Get("group")
+	if group == "" {
+		group = model.GroupByPriority
+	}
+	if !slices.Contains(starvationGroups, group) {
+		writeError(w, http.StatusBadRequest, "group must be one of "+strings.Join(starvationGroups, ", "))
+		return
+	}
+	limit := defaultStarvationLimit
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 || limit > maxStarvationLimit {
+			writeError(w, http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(maxStarvationLimit))
+			return
+		}
+	}
+	filter, err := parseBeadFilter(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := s.starvationReport(r.Context(), filter, olderThan, time.Now().UTC(), group, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to build starvation report")
+		return
+	}
+	report.OlderThan = olderText
+	writeJSON(w, http.StatusOK, report)
+}
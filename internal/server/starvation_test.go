@@ -0,0 +1,71 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestHandleStarvation(t *testing.T) {
+	_, ms, h := newTestServer()
+	now := time.Now()
+	week, day, hour := now.AddDate(0, 0, -7), now.AddDate(0, 0, -1), now.Add(-time.Hour)
+	issue := func(id string, priority int, created time.Time) *model.Bead {
+		b := &model.Bead{ID: id, Kind: model.KindIssue, Type: "task", Status: model.StatusOpen, Priority: priority, CreatedAt: created}
+		ms.beads[id] = b
+		return b
+	}
+	issue("bd-1", 2, week)
+	issue("bd-2", 1, week.Add(time.Hour))
+	issue("bd-3", 2, week).Assignee = "alice"
+	issue("bd-4", 2, day)
+	// Blocked until a day ago.
+	issue("bd-5", 0, week)
+	issue("bd-6", 2, week).Status = model.StatusClosed
+	ms.beads["bd-6"].ClosedAt = &day
+	ms.deps["bd-5"] = []*model.Dependency{{BeadID: "bd-5", DependsOnID: "bd-6", Type: model.DepBlocks}}
+	// Released by its claimant an hour ago.
+	issue("bd-7", 2, week)
+	payload, _ := json.Marshal(events.BeadUpdated{Bead: ms.beads["bd-7"], Changes: map[string]any{"assignee": ""}})
+	ms.events = append(ms.events, &model.Event{Topic: events.TopicBeadUpdated, BeadID: "bd-7", Payload: payload, CreatedAt: hour})
+	ms.labels["bd-1"] = []string{"area:api"}
+	ms.labels["bd-2"] = []string{"area:api", "area:cli"}
+
+	rec := doJSON(t, h, "GET", "/v1/reports/starvation", nil)
+	requireStatus(t, rec, http.StatusOK)
+	var report starvationReport
+	decodeJSON(t, rec, &report)
+	if report.OlderThan != "3d" || report.Group != "priority" || report.Total != 2 || len(report.Beads) != 2 ||
+		report.Beads[0].Bead.ID != "bd-1" || report.Beads[1].Bead.ID != "bd-2" {
+		t.Fatalf("report = %+v", report)
+	}
+	if !report.Beads[0].ReadySince.Equal(week) || report.Beads[0].Waiting != "168h0m0s" {
+		t.Errorf("bd-1 ready since %v, waiting %s", report.Beads[0].ReadySince, report.Beads[0].Waiting)
+	}
+	if len(report.Groups) != 2 || report.Groups[0].Key != "1" || report.Groups[1].Key != "2" || report.Groups[1].Count != 1 {
+		t.Fatalf("groups = %+v", report.Groups)
+	}
+
+	rec = doJSON(t, h, "GET", "/v1/reports/starvation?older_than=12h&group=label&limit=1", nil)
+	requireStatus(t, rec, http.StatusOK)
+	report = starvationReport{}
+	decodeJSON(t, rec, &report)
+	if report.Total != 4 || len(report.Beads) != 1 || report.Beads[0].Bead.ID != "bd-1" {
+		t.Fatalf("12h report = %+v", report)
+	}
+	counts := map[string]int{}
+	for _, g := range report.Groups {
+		counts[g.Key] = g.Count
+	}
+	if len(counts) != 3 || counts["area:api"] != 2 || counts["area:cli"] != 1 || counts[""] != 2 {
+		t.Fatalf("by label = %+v", counts)
+	}
+
+	for _, path := range []string{"/v1/reports/starvation?older_than=0d", "/v1/reports/starvation?group=assignee", "/v1/reports/starvation?limit=0", "/v1/reports/starvation?limit=501"} {
+		requireStatus(t, doJSON(t, h, "GET", path, nil), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,339 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// maxCoalesceWindow caps the coalescing window a stream client may ask for.
+const maxCoalesceWindow = time.Minute
+
+// DefaultStreamKeepalive is how often an idle event stream sends a comment
+// line so proxies do not close it, unless SetStreamTuning says otherwise.
+const DefaultStreamKeepalive = 15 * time.Second
+
+// streamRetry is the reconnection delay advised to stream clients.
+const streamRetry = time.Second
+
+// SetStreamTuning sets how often an idle event stream sends a keepalive
+// comment (0 keeps the default) and how long a stream stays open before
+// the server asks its client to reconnect (0 = no limit). Bounding the
+// lifetime lets load balancers rebalance long-lived watchers and moves
+// them off a draining replica before it goes away.
+func (s *BeadsServer) SetStreamTuning(keepalive, maxLifetime time.Duration) {
+	if keepalive > 0 {
+		s.streamKeepalive = keepalive
+	}
+	s.streamMaxLifetime = maxLifetime
+}
+
+// eventHub fans recorded events out to the server's event stream clients.
+// Slow clients miss events rather than block the writer; dropped counts
+// the events missed this way.
+type eventHub struct {
+	mu      sync.Mutex
+	subs    map[chan *model.Event]struct{}
+	closed  bool
+	dropped atomic.Uint64
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subs: make(map[chan *model.Event]struct{})}
+}
+
+// subscribe returns a channel of future events and a func that ends the
+// subscription. The channel is closed when the hub closes.
+func (h *eventHub) subscribe() (<-chan *model.Event, func()) {
+	ch := make(chan *model.Event, 256)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	h.subs[ch] = struct{}{}
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[ch]; ok {
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// broadcast delivers e to every subscriber with room for it and counts a
+// drop for every subscriber without.
+func (h *eventHub) broadcast(e *model.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		select {
+		case ch <- e:
+		default:
+			h.dropped.Add(1)
+		}
+	}
+}
+
+// dropGauge returns a func reporting the drops since its previous call, for
+// the alert evaluator's MetricStreamDrops.
+func (h *eventHub) dropGauge() func() float64 {
+	var last atomic.Uint64
+	return func() float64 {
+		total := h.dropped.Load()
+		return float64(total - last.Swap(total))
+	}
+}
+
+// close ends every subscription so open streams return.
+func (h *eventHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for ch := range h.subs {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
+
+// beadUpdate summarises the events on one bead within a coalescing window.
+type beadUpdate struct {
+	BeadID string       `json:"bead_id"`
+	Count  int          `json:"count"`
+	Topics []string     `json:"topics"`           // distinct, first seen first
+	Actors []string     `json:"actors,omitempty"` // distinct, first seen first
+	Last   *model.Event `json:"last"`
+}
+
+// coalescer merges events into one beadUpdate per bead until flushed.
+type coalescer struct {
+	pending map[string]*beadUpdate
+	order   []string
+}
+
+func (c *coalescer) add(e *model.Event) {
+	if c.pending == nil {
+		c.pending = make(map[string]*beadUpdate)
+	}
+	u, ok := c.pending[e.BeadID]
+	if !ok {
+		u = &beadUpdate{BeadID: e.BeadID}
+		c.pending[e.BeadID] = u
+		c.order = append(c.order, e.BeadID)
+	}
+	u.Count++
+	if !slices.Contains(u.Topics, e.Topic) {
+		u.Topics = append(u.Topics, e.Topic)
+	}
+	if e.Actor != "" && !slices.Contains(u.Actors, e.Actor) {
+		u.Actors = append(u.Actors, e.Actor)
+	}
+	u.Last = e
+}
+
+// flush returns the pending updates in the order their beads first changed
+// and resets the coalescer.
+func (c *coalescer) flush() []*beadUpdate {
+	updates := make([]*beadUpdate, 0, len(c.order))
+	for _, id := range c.order {
+		updates = append(updates, c.pending[id])
+	}
+	c.pending, c.order = nil, nil
+	return updates
+}
+
+// writeSSE writes one server-sent event with a JSON data line.
+func writeSSE(w http.ResponseWriter, event string, v any) error {
+	return writeSSEWithID(w, event, 0, v)
+}
+
+// writeSSEWithID writes one server-sent event with a JSON data line and,
+// when id is set, an id line clients resume from with Last-Event-ID.
+func writeSSEWithID(w http.ResponseWriter, event string, id int64, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if id > 0 {
+		_, err = fmt.Fprintf(w, "event: %s\nid: %d\ndata: %s\n\n", event, id, data)
+	} else {
+		_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	}
+	return err
+}
+
+// lastEventID reads the event ID a reconnecting client resumes after, from
+// the Last-Event-ID header or, for clients that cannot set it, the
+// last_event_id parameter. It is 0 for a fresh stream.
+func lastEventID(r *http.Request) (int64, error) {
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = r.URL.Query().Get("last_event_id")
+	}
+	if v == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || id < 0 {
+		return 0, inputError("Last-Event-ID must be an event ID")
+	}
+	return id, nil
+}
+
+// replayEvents adds the events matching filter recorded after afterID to
+// c, and returns the ID of the last one, or afterID if there are none.
+func (s *BeadsServer) replayEvents(ctx context.Context, filter model.EventFilter, afterID int64, c *coalescer) (int64, error) {
+	filter.Since, filter.AfterID, filter.Limit = time.Time{}, afterID, maxEventPage
+	for {
+		evs, err := s.store.ListEvents(ctx, filter)
+		if err != nil {
+			return afterID, err
+		}
+		for _, e := range evs {
+			c.add(e)
+			filter.AfterID = e.ID
+		}
+		if len(evs) < filter.Limit {
+			return filter.AfterID, nil
+		}
+	}
+}
+
+// handleStreamEvents handles GET /v1/events/stream?coalesce=2s, a
+// server-sent event stream of bead changes. With a coalesce window, each
+// bead changed within the window is sent once, as a summary, when the window
+// ends; otherwise every event is sent as it happens. The stream opens with a
+// "ready" event carrying the window the server applied. The GET /v1/events
+// filters (topic, bead_id, actor, label, project) narrow what is sent.
+//
+// Each update carries the ID of its bead's last event. A client that
+// reconnects with Last-Event-ID first gets the matching events it missed.
+// An idle stream sends a keepalive comment every streamKeepalive. Once a
+// stream has been open for streamMaxLifetime, the server sends a
+// "reconnect" event and closes it, so that clients reconnect.
+func (s *BeadsServer) handleStreamEvents(w http.ResponseWriter, r *http.Request) {
+	filter, err := s.eventFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	resumeAfter, err := lastEventID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var window time.Duration
+	if v := r.URL.Query().Get("coalesce"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			writeError(w, http.StatusBadRequest, "invalid coalesce duration")
+			return
+		}
+		window = min(d, maxCoalesceWindow)
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	// Subscribe before replaying so nothing recorded in between is lost;
+	// live events the replay already covered are skipped below.
+	events, cancel := s.hub.subscribe()
+	defer cancel()
+
+	var c coalescer
+	replayed := resumeAfter
+	if resumeAfter > 0 {
+		if replayed, err = s.replayEvents(r.Context(), filter, resumeAfter, &c); err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to replay events")
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n", streamRetry.Milliseconds()); err != nil {
+		return
+	}
+	if err := writeSSE(w, "ready", map[string]any{"coalesce_ms": window.Milliseconds()}); err != nil {
+		return
+	}
+	lastID := resumeAfter
+	send := func() bool {
+		for _, u := range c.flush() {
+			if err := writeSSEWithID(w, "update", u.Last.ID, u); err != nil {
+				return false
+			}
+			lastID = max(lastID, u.Last.ID)
+		}
+		flusher.Flush()
+		return true
+	}
+	if !send() {
+		return
+	}
+
+	var windowC <-chan time.Time
+	if window > 0 {
+		t := time.NewTicker(window)
+		defer t.Stop()
+		windowC = t.C
+	}
+	keepalive := time.NewTicker(s.streamKeepalive)
+	defer keepalive.Stop()
+	var lifetimeC <-chan time.Time
+	if s.streamMaxLifetime > 0 {
+		t := time.NewTimer(s.streamMaxLifetime)
+		defer t.Stop()
+		lifetimeC = t.C
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if e.ID <= replayed || !s.matchEvent(r.Context(), filter, e) {
+				continue
+			}
+			c.add(e)
+			if window > 0 {
+				continue
+			}
+		case <-windowC:
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+			continue
+		case <-lifetimeC:
+			if !send() {
+				return
+			}
+			writeSSE(w, "reconnect", map[string]any{"retry_ms": streamRetry.Milliseconds(), "last_event_id": lastID})
+			flusher.Flush()
+			return
+		}
+		if !send() {
+			return
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/client"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestCoalescer(t *testing.T) {
+	var c coalescer
+	c.add(&model.Event{ID: 1, BeadID: "bd-a", Topic: "beads.bead.updated", Actor: "alice"})
+	c.add(&model.Event{ID: 2, BeadID: "bd-b", Topic: "beads.bead.created", Actor: "bob"})
+	c.add(&model.Event{ID: 3, BeadID: "bd-a", Topic: "beads.comment.added", Actor: "bob"})
+	c.add(&model.Event{ID: 4, BeadID: "bd-a", Topic: "beads.bead.updated", Actor: "alice"})
+
+	updates := c.flush()
+	if len(updates) != 2 || updates[0].BeadID != "bd-a" || updates[1].BeadID != "bd-b" {
+		t.Fatalf("unexpected updates: %+v", updates)
+	}
+	a := updates[0]
+	if a.Count != 3 || a.Last.ID != 4 ||
+		strings.Join(a.Topics, ",") != "beads.bead.updated,beads.comment.added" ||
+		strings.Join(a.Actors, ",") != "alice,bob" {
+		t.Fatalf("unexpected summary: %+v", a)
+	}
+	if len(c.flush()) != 0 {
+		t.Fatal("flush should reset the coalescer")
+	}
+}
+
+// watch opens an event stream on h with the beads client. The watch ends
+// with the test, or after a few seconds.
+func watch(t *testing.T, h http.Handler, filter client.EventFilter) <-chan client.Event {
+	t.Helper()
+	ts := httptest.NewServer(h)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(func() {
+		cancel()
+		ts.Close()
+	})
+	events, err := client.New(ts.URL).WatchEvents(ctx, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+// nextEvent returns the next event from a watch.
+func nextEvent(t *testing.T, events <-chan client.Event) client.Event {
+	t.Helper()
+	e, ok := <-events
+	if !ok {
+		t.Fatal("stream ended")
+	}
+	return e
+}
+
+func TestHandleStreamEvents_Coalesced(t *testing.T) {
+	srv, _, h := newTestServer()
+	events := watch(t, h, client.EventFilter{Coalesce: 200 * time.Millisecond})
+
+	ctx := context.Background()
+	for range 5 {
+		emitEvent(t, srv, ctx, "beads.bead.updated", "bd-s1", "alice", map[string]string{})
+	}
+	emitEvent(t, srv, ctx, "beads.bead.closed", "bd-s2", "bob", map[string]string{})
+
+	got := map[string]client.Event{}
+	for len(got) < 2 {
+		e := nextEvent(t, events)
+		if _, dup := got[e.BeadID]; dup {
+			t.Fatalf("bead %s sent twice in one window", e.BeadID)
+		}
+		got[e.BeadID] = e
+	}
+	if got["bd-s1"].Count != 5 || got["bd-s2"].Count != 1 || got["bd-s2"].Actors[0] != "bob" {
+		t.Fatalf("unexpected updates: %+v", got)
+	}
+}
+
+func TestHandleStreamEvents_Uncoalesced(t *testing.T) {
+	srv, _, h := newTestServer()
+	events := watch(t, h, client.EventFilter{})
+
+	emitEvent(t, srv, context.Background(), "beads.bead.updated", "bd-s3", "alice", map[string]string{})
+	if e := nextEvent(t, events); e.BeadID != "bd-s3" || e.Count != 1 || e.Topic != "beads.bead.updated" {
+		t.Fatalf("got %+v", e)
+	}
+}
+
+func TestHandleStreamEvents_Filtered(t *testing.T) {
+	srv, ms, h := newTestServer()
+	ms.deps["bd-child"] = []*model.Dependency{{BeadID: "bd-child", DependsOnID: "bd-epic", Type: model.DepParentChild}}
+	events := watch(t, h, client.EventFilter{Project: "bd-epic", Actor: "alice"})
+
+	ctx := context.Background()
+	emitEvent(t, srv, ctx, "beads.bead.updated", "bd-other", "alice", map[string]string{})
+	emitEvent(t, srv, ctx, "beads.bead.updated", "bd-child", "bob", map[string]string{})
+	emitEvent(t, srv, ctx, "beads.bead.closed", "bd-child", "alice", map[string]string{})
+	if e := nextEvent(t, events); e.BeadID != "bd-child" || e.Count != 1 || strings.Join(e.Topics, ",") != "beads.bead.closed" {
+		t.Fatalf("got %+v, want only alice's close of bd-child", e)
+	}
+}
+
+func TestHandleStreamEvents_ResumesAfterLastEventID(t *testing.T) {
+	srv, _, h := newTestServer()
+	ctx := context.Background()
+	for _, id := range []string{"bd-r1", "bd-r2", "bd-r3"} {
+		emitEvent(t, srv, ctx, "beads.bead.updated", id, "alice", map[string]string{})
+	}
+
+	events := watch(t, h, client.EventFilter{AfterID: 1})
+	for i, want := range []string{"bd-r2", "bd-r3"} {
+		if e := nextEvent(t, events); e.BeadID != want || e.ID != int64(i+2) {
+			t.Fatalf("got %+v, want the missed update of %s", e, want)
+		}
+	}
+
+	// Live events continue after the replay, without repeating it.
+	emitEvent(t, srv, ctx, "beads.bead.closed", "bd-r4", "bob", map[string]string{})
+	if e := nextEvent(t, events); e.ID != 4 || e.BeadID != "bd-r4" {
+		t.Fatalf("got %+v, want the live update of bd-r4", e)
+	}
+
+	requireStatus(t, doJSON(t, h, "GET", "/v1/events/stream?last_event_id=x", nil), http.StatusBadRequest)
+}
+
+// TestHandleStreamEvents_Tuning checks the stream's framing, which the
+// client does not surface: the negotiated window, keepalives and the
+// reconnect advice.
+func TestHandleStreamEvents_Tuning(t *testing.T) {
+	srv, _, h := newTestServer()
+	srv.SetStreamTuning(20*time.Millisecond, 200*time.Millisecond)
+	ts := httptest.NewServer(h)
+	defer ts.Close()
+	resp, err := http.Get(ts.URL + "/v1/events/stream?coalesce=5m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("status %d, content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	var lines []string
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	stream := strings.Join(lines, "\n")
+	for _, want := range []string{
+		"event: ready\ndata: {\"coalesce_ms\":60000}\n",
+		": keepalive\n",
+		"event: reconnect\ndata: {\"last_event_id\":0,\"retry_ms\":1000}\n",
+	} {
+		if !strings.Contains(stream, want) {
+			t.Fatalf("stream lacks %q:\n%s", want, stream)
+		}
+	}
+}
+
+func TestHandleStreamEvents_InvalidCoalesce(t *testing.T) {
+	_, _, h := newTestServer()
+	rec := doJSON(t, h, "GET", "/v1/events/stream?coalesce=soon", nil)
+	requireStatus(t, rec, 400)
+}
+
+func TestEventHub_CloseEndsStreams(t *testing.T) {
+	srv, _, _ := newTestServer()
+	ch, cancel := srv.hub.subscribe()
+	defer cancel()
+	srv.Shutdown()
+	if _, ok := <-ch; ok {
+		t.Fatal("expected the subscription to close on shutdown")
+	}
+}
+
+func TestEventHub_CountsDrops(t *testing.T) {
+	h := newEventHub()
+	_, cancel := h.subscribe()
+	defer cancel()
+	gauge := h.dropGauge()
+
+	for i := 0; i < 260; i++ {
+		h.broadcast(&model.Event{Topic: "beads.bead.updated"})
+	}
+	if got := gauge(); got != 4 {
+		t.Fatalf("drops = %v, want 4", got)
+	}
+	if got := gauge(); got != 0 {
+		t.Fatalf("drops after reading = %v, want 0", got)
+	}
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// LoadTLSConfig builds the server TLS config shared by the gRPC and HTTP
+// listeners. It returns nil when certFile and keyFile are both empty
+// (plaintext). When clientCAFile is set, clients must present a certificate
+// signed by one of its CAs (mTLS).
+func LoadTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, errors.New("client CA requires a server certificate and key")
+		}
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("TLS requires both a certificate and a key")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS key pair: %w", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAFile != "" {
+		pool, err := LoadCertPool(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// LoadCertPool reads a PEM bundle of CA certificates.
+func LoadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", file)
+	}
+	return pool, nil
+}
+
+type identityKey struct{}
+
+// withIdentity returns ctx carrying the authenticated client identity.
+func withIdentity(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// identityFrom returns the authenticated client identity, or "" if the
+// caller did not present a verified certificate.
+func identityFrom(ctx context.Context) string {
+	id, _ := ctx.Value(identityKey{}).(string)
+	return id
+}
+
+// actorFor returns the actor to record for a request: the verified client
+// certificate identity when there is one, otherwise the actor the client
+// claimed, normalized to a registered actor's ID.
+func (s *BeadsServer) actorFor(ctx context.Context, claimed string) string {
+	if id := identityFrom(ctx); id != "" {
+		return s.canonicalActor(ctx, id)
+	}
+	return s.canonicalActor(ctx, claimed)
+}
+
+// certIdentity returns the common name of the verified client certificate,
+// or "" if the connection has none.
+func certIdentity(state *tls.ConnectionState) string {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return state.VerifiedChains[0][0].Subject.CommonName
+}
+
+// IdentityInterceptor attaches the client certificate identity, if any, to
+// the context of every unary RPC.
+func IdentityInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		if ti, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			ctx = withIdentity(ctx, certIdentity(&ti.State))
+		}
+	}
+	return handler(ctx, req)
+}
+
+// identityMiddleware attaches the client certificate identity, if any, to
+// the request context.
+func identityMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := certIdentity(r.TLS); id != "" {
+			r = r.WithContext(withIdentity(r.Context(), id))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,225 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// testPKI is a throwaway CA with a server and a client certificate, written
+// as PEM files under a temp dir.
+type testPKI struct {
+	dir                   string
+	caFile                string
+	serverCert, serverKey string
+	clientCert, clientKey string
+}
+
+func newTestPKI(t *testing.T, clientCN string) *testPKI {
+	t.Helper()
+	dir := t.TempDir()
+	p := &testPKI{dir: dir}
+
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "beads test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, _ := x509.ParseCertificate(caDER)
+	p.caFile = writePEM(t, dir, "ca.crt", "CERTIFICATE", caDER)
+
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyDER, _ := x509.MarshalECPrivateKey(key)
+		return writePEM(t, dir, name+".crt", "CERTIFICATE", der), writePEM(t, dir, name+".key", "EC PRIVATE KEY", keyDER)
+	}
+	p.serverCert, p.serverKey = issue("server", 2, x509.ExtKeyUsageServerAuth)
+	p.clientCert, p.clientKey = issue(clientCN, 3, x509.ExtKeyUsageClientAuth)
+	return p
+}
+
+func writePEM(t *testing.T, dir, name, typ string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// clientTLS returns a client config trusting the test CA, presenting the
+// client certificate when withCert is set.
+func (p *testPKI) clientTLS(t *testing.T, withCert bool) *tls.Config {
+	t.Helper()
+	pool, err := LoadCertPool(p.caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &tls.Config{RootCAs: pool}
+	if withCert {
+		cert, err := tls.LoadX509KeyPair(p.clientCert, p.clientKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg
+}
+
+func TestLoadTLSConfig(t *testing.T) {
+	p := newTestPKI(t, "alice")
+
+	cfg, err := LoadTLSConfig("", "", "")
+	if err != nil || cfg != nil {
+		t.Fatalf("plaintext: got %v, %v", cfg, err)
+	}
+	for _, tc := range []struct {
+		name                string
+		cert, key, clientCA string
+		wantErr             string
+	}{
+		{"ClientCAWithoutCert", "", "", p.caFile, "requires a server certificate"},
+		{"CertWithoutKey", p.serverCert, "", "", "both a certificate and a key"},
+		{"MissingFile", filepath.Join(p.dir, "nope.crt"), p.serverKey, "", "loading TLS key pair"},
+		{"BadClientCA", p.serverCert, p.serverKey, p.serverKey, "no certificates found"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadTLSConfig(tc.cert, tc.key, tc.clientCA)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("got %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+
+	cfg, err = LoadTLSConfig(p.serverCert, p.serverKey, p.caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ClientAuth != tls.RequireAndVerifyClientCert || cfg.ClientCAs == nil {
+		t.Fatalf("expected mTLS, got ClientAuth=%v", cfg.ClientAuth)
+	}
+}
+
+func TestGRPCMutualTLSIdentity(t *testing.T) {
+	p := newTestPKI(t, "alice")
+	tlsCfg, err := LoadTLSConfig(p.serverCert, p.serverKey, p.caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms := newMockStore()
+	srv := NewGRPCServer(NewBeadsServer(ms, &events.NoopPublisher{}), grpc.Creds(credentials.NewTLS(tlsCfg)))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Without a client certificate the handshake is rejected.
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(p.clientTLS(t, false))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := beadsv1.NewBeadsServiceClient(conn).ListBeads(ctx, &beadsv1.ListBeadsRequest{}); err == nil {
+		t.Fatal("expected error without client certificate")
+	}
+	conn.Close()
+
+	// With one, the certificate CN overrides the claimed actor.
+	conn, err = grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(p.clientTLS(t, true))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	resp, err := beadsv1.NewBeadsServiceClient(conn).CreateBead(ctx, &beadsv1.CreateBeadRequest{
+		Title: "Signed", Type: "task", CreatedBy: "mallory",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetBead().GetCreatedBy() != "alice" {
+		t.Fatalf("created_by = %q, want alice", resp.GetBead().GetCreatedBy())
+	}
+	if len(ms.events) != 1 || ms.events[0].Actor != "alice" {
+		t.Fatalf("unexpected events: %+v", ms.events)
+	}
+}
+
+func TestHTTPMutualTLSIdentity(t *testing.T) {
+	p := newTestPKI(t, "bob")
+	tlsCfg, err := LoadTLSConfig(p.serverCert, p.serverKey, p.caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ms, h := newTestServer()
+	ms.beads["bd-tls1"] = &model.Bead{ID: "bd-tls1", Title: "Close me", Status: model.StatusOpen}
+
+	ts := httptest.NewUnstartedServer(h)
+	ts.TLS = tlsCfg
+	ts.StartTLS()
+	defer ts.Close()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: p.clientTLS(t, true)}}
+	resp, err := client.Post(ts.URL+"/v1/beads/bd-tls1/close", "application/json", strings.NewReader(`{"closed_by":"mallory"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+	if got := ms.beads["bd-tls1"].ClosedBy; got != "bob" {
+		t.Fatalf("closed_by = %q, want bob", got)
+	}
+}
+
+func TestActorForWithoutIdentity(t *testing.T) {
+	s, _, _ := newTestServer()
+	if got := s.actorFor(context.Background(), "alice"); got != "alice" {
+		t.Fatalf("actorFor = %q, want alice", got)
+	}
+}
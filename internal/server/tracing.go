@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// tracingMiddleware starts a server span for every HTTP request, continuing
+// the trace from the caller's traceparent header. The span is named after
+// the matched route, which the mux only knows once it has handled the
+// request.
+func tracingMiddleware(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "http", otelhttp.WithSpanNameFormatter(
+		func(operation string, r *http.Request) string {
+			if r.Pattern != "" {
+				return r.Pattern
+			}
+			return operation
+		}))
+}
+
+// routeNames renames the request's span to the route pattern mux matched,
+// e.g. "GET /v1/beads/{id}". It covers requests that middleware replaced
+// with a copy, whose pattern tracingMiddleware never sees.
+func routeNames(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+		if r.Pattern != "" {
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(r.Pattern)
+			span.SetAttributes(attribute.String("http.route", r.Pattern))
+		}
+	})
+}
+
+// tracingServerOption starts a server span for every RPC, continuing the
+// trace from the caller's metadata.
+func tracingServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
@@ -0,0 +1,267 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// maxTxOps caps the operations in one POST /v1/transactions.
+const maxTxOps = 100
+
+// Operations of POST /v1/transactions.
+const (
+	txCreateBead    = "create_bead"
+	txAddDependency = "add_dependency"
+	txAddLabels     = "add_labels"
+)
+
+// txRefPattern matches a reference to the bead created by an earlier
+// operation of the same transaction, e.g. "$0.id".
+var txRefPattern = regexp.MustCompile(`^\$(\d+)\.id$`)
+
+// txOp is one operation of POST /v1/transactions. Op picks the fields that
+// apply: Bead for create_bead; BeadID, DependsOnID, Type and Metadata for
+// add_dependency; BeadID and Labels for add_labels. BeadID and DependsOnID
+// take a bead ID, slug or alias, or "$N.id" for the bead created by
+// operation N.
+type txOp struct {
+	Op          string           `json:"op"`
+	Bead        *createBeadInput `json:"bead,omitempty"`
+	BeadID      string           `json:"bead_id,omitempty"`
+	DependsOnID string           `json:"depends_on_id,omitempty"`
+	Type        string           `json:"type,omitempty"`
+	Metadata    json.RawMessage  `json:"metadata,omitempty"`
+	Labels      []string         `json:"labels,omitempty"`
+}
+
+// txRequest is the JSON body for POST /v1/transactions.
+type txRequest struct {
+	Operations []txOp `json:"operations"`
+	Actor      string `json:"actor"`
+}
+
+// txResult is the outcome of one operation: the bead created, the
+// dependency added, or the labels added to BeadID.
+type txResult struct {
+	Op         string            `json:"op"`
+	Bead       *model.Bead       `json:"bead,omitempty"`
+	Dependency *model.Dependency `json:"dependency,omitempty"`
+	BeadID     string            `json:"bead_id,omitempty"`
+	Labels     []string          `json:"labels,omitempty"`
+}
+
+// runTransaction applies req's operations in order in one store
+// transaction, so either all of them take effect or none does. Every
+// operation is validated before anything is written. Errors name the
+// failing operation and wrap inputError for an invalid one, sql.ErrNoRows
+// for a bead that does not exist, and *cycleError for a dependency loop.
+func (s *BeadsServer) runTransaction(ctx context.Context, req txRequest) ([]*txResult, error) {
+	if len(req.Operations) == 0 {
+		return nil, inputError("operations is required")
+	}
+	if len(req.Operations) > maxTxOps {
+		return nil, inputError(fmt.Sprintf("at most %d operations per transaction", maxTxOps))
+	}
+	actor := s.actorFor(ctx, req.Actor)
+
+	results := make([]*txResult, len(req.Operations))
+	taken := map[string]bool{}
+	for i, op := range req.Operations {
+		r, err := s.prepareTxOp(ctx, op, actor, results[:i], taken)
+		if err != nil {
+			return nil, fmt.Errorf("operations[%d]: %w", i, err)
+		}
+		results[i] = r
+	}
+
+	apply := func(tx store.Store) error {
+		for i, r := range results {
+			if err := s.applyTxOp(ctx, tx, r, actor); err != nil {
+				return fmt.Errorf("operations[%d]: %w", i, err)
+			}
+		}
+		return nil
+	}
+	for n := 1; ; n++ {
+		err := s.store.RunInTransaction(ctx, apply)
+		if err == nil {
+			break
+		}
+		if n == conflictRetries || !errors.Is(err, store.ErrSlugTaken) {
+			return nil, err
+		}
+		// A concurrent create took one of the slugs; pick fresh ones.
+		taken = map[string]bool{}
+		for _, r := range results {
+			if r.Bead == nil {
+				continue
+			}
+			if r.Bead.Slug, err = s.uniqueSlugExcept(ctx, r.Bead.Title, taken); err != nil {
+				return nil, fmt.Errorf("failed to generate slug: %w", err)
+			}
+			taken[r.Bead.Slug] = true
+		}
+	}
+	s.flushEvents(ctx)
+	return results, nil
+}
+
+// prepareTxOp validates op and builds its result, resolving references
+// against the results of the operations before it. taken holds the slugs of
+// beads created earlier in the transaction.
+func (s *BeadsServer) prepareTxOp(ctx context.Context, op txOp, actor string, prev []*txResult, taken map[string]bool) (*txResult, error) {
+	switch op.Op {
+	case txCreateBead:
+		if op.Bead == nil {
+			return nil, inputError("bead is required")
+		}
+		in := *op.Bead
+		if in.IdempotencyKey != "" {
+			return nil, inputError("idempotency_key is not supported in a transaction")
+		}
+		if in.CreatedBy == "" {
+			in.CreatedBy = actor
+		}
+		bead, err := s.prepareBead(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		if taken[bead.Slug] {
+			if bead.Slug, err = s.uniqueSlugExcept(ctx, bead.Title, taken); err != nil {
+				return nil, fmt.Errorf("failed to generate slug: %w", err)
+			}
+		}
+		if bead.Slug != "" {
+			taken[bead.Slug] = true
+		}
+		return &txResult{Op: op.Op, Bead: bead}, nil
+
+	case txAddDependency:
+		beadID, err := s.txRef(ctx, "bead_id", op.BeadID, prev)
+		if err != nil {
+			return nil, err
+		}
+		dependsOnID, err := s.txRef(ctx, "depends_on_id", op.DependsOnID, prev)
+		if err != nil {
+			return nil, err
+		}
+		if string(op.Metadata) == "null" {
+			op.Metadata = nil
+		}
+		if err := model.ValidateDependencyMetadata(op.Metadata); err != nil {
+			return nil, inputError(err.Error())
+		}
+		return &txResult{Op: op.Op, Dependency: &model.Dependency{
+			BeadID:      beadID,
+			DependsOnID: dependsOnID,
+			Type:        model.DependencyType(op.Type),
+			CreatedAt:   time.Now().UTC(),
+			CreatedBy:   actor,
+			Metadata:    op.Metadata,
+		}}, nil
+
+	case txAddLabels:
+		beadID, err := s.txRef(ctx, "bead_id", op.BeadID, prev)
+		if err != nil {
+			return nil, err
+		}
+		if len(op.Labels) == 0 {
+			return nil, inputError("labels is required")
+		}
+		if err := s.checkLabels(ctx, op.Labels); err != nil {
+			return nil, err
+		}
+		return &txResult{Op: op.Op, BeadID: beadID, Labels: op.Labels}, nil
+	}
+	return nil, inputError(fmt.Sprintf("unknown op %q; want %s, %s or %s", op.Op, txCreateBead, txAddDependency, txAddLabels))
+}
+
+// txRef resolves field's value ref to a bead ID. "$N.id" names the bead
+// created by operation N, which must be one of prev.
+func (s *BeadsServer) txRef(ctx context.Context, field, ref string, prev []*txResult) (string, error) {
+	if ref == "" {
+		return "", inputError(field + " is required")
+	}
+	m := txRefPattern.FindStringSubmatch(ref)
+	if m == nil {
+		return s.resolveBeadID(ctx, ref), nil
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil || n >= len(prev) || prev[n].Bead == nil {
+		return "", inputError(fmt.Sprintf("%s %s does not name an earlier %s operation", field, ref, txCreateBead))
+	}
+	return prev[n].Bead.ID, nil
+}
+
+// applyTxOp writes a prepared operation through tx and records its events.
+func (s *BeadsServer) applyTxOp(ctx context.Context, tx store.Store, r *txResult, actor string) error {
+	switch r.Op {
+	case txCreateBead:
+		return s.insertBead(ctx, tx, r.Bead)
+	case txAddDependency:
+		for _, id := range []string{r.Dependency.BeadID, r.Dependency.DependsOnID} {
+			if err := requireBead(ctx, tx, id); err != nil {
+				return err
+			}
+		}
+		return s.insertDependency(ctx, tx, r.Dependency)
+	case txAddLabels:
+		if err := requireBead(ctx, tx, r.BeadID); err != nil {
+			return err
+		}
+		for _, label := range r.Labels {
+			if err := tx.AddLabel(ctx, r.BeadID, label); err != nil {
+				return fmt.Errorf("failed to add label %q: %w", label, err)
+			}
+			if err := s.recordEvent(ctx, tx, events.TopicLabelAdded, r.BeadID, actor, events.LabelAdded{BeadID: r.BeadID, Label: label}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// requireBead returns sql.ErrNoRows, naming id, if st has no bead id.
+func requireBead(ctx context.Context, st store.Store, id string) error {
+	b, err := st.GetBead(ctx, id)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return fmt.Errorf("bead %s not found: %w", id, sql.ErrNoRows)
+	}
+	return nil
+}
+
+// handleTransaction handles POST /v1/transactions. It answers 201 with one
+// result per operation, or with the first failure and nothing applied.
+func (s *BeadsServer) handleTransaction(w http.ResponseWriter, r *http.Request) {
+	var req txRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	results, err := s.runTransaction(r.Context(), req)
+	var ie inputError
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusCreated, map[string]any{"results": results})
+	case errors.As(err, &ie):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, http.StatusNotFound, err.Error())
+	case !writeCycleError(w, err):
+		writeError(w, http.StatusInternalServerError, "transaction failed")
+	}
+}
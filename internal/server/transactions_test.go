@@ -0,0 +1,76 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestHandleTransaction(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-epic"] = &model.Bead{ID: "bd-epic", Slug: "release", Title: "Release", Status: model.StatusOpen}
+
+	rec := doJSON(t, h, "POST", "/v1/transactions", map[string]any{
+		"actor": "alice",
+		"operations": []map[string]any{
+			{"op": "create_bead", "bead": map[string]any{"title": "Tag the release", "type": "task"}},
+			{"op": "create_bead", "bead": map[string]any{"title": "Tag the release", "type": "task"}},
+			{"op": "add_dependency", "bead_id": "$0.id", "depends_on_id": "release", "type": "parent-child"},
+			{"op": "add_dependency", "bead_id": "$1.id", "depends_on_id": "$0.id", "type": "blocks"},
+			{"op": "add_labels", "bead_id": "$1.id", "labels": []string{"release", "ops"}},
+		},
+	})
+	requireStatus(t, rec, 201)
+	var resp struct {
+		Results []*txResult `json:"results"`
+	}
+	decodeJSON(t, rec, &resp)
+	if len(resp.Results) != 5 {
+		t.Fatalf("got %d results", len(resp.Results))
+	}
+	first, second := resp.Results[0].Bead, resp.Results[1].Bead
+	if first.CreatedBy != "alice" || first.Slug == second.Slug {
+		t.Fatalf("beads = %+v, %+v", first, second)
+	}
+	if deps := ms.deps[first.ID]; len(deps) != 1 || deps[0].DependsOnID != "bd-epic" {
+		t.Fatalf("deps of $0 = %+v", deps)
+	}
+	if deps := ms.deps[second.ID]; len(deps) != 1 || deps[0].DependsOnID != first.ID {
+		t.Fatalf("deps of $1 = %+v", deps)
+	}
+	if labels := ms.labels[second.ID]; len(labels) != 2 {
+		t.Fatalf("labels of $1 = %v", labels)
+	}
+	requireEvent(t, ms, 6, "beads.label.added")
+}
+
+func TestHandleTransaction_Invalid(t *testing.T) {
+	_, ms, h := newTestServer()
+	for _, tc := range []struct {
+		ops    []map[string]any
+		status int
+		want   string
+	}{
+		{nil, 400, "operations is required"},
+		{[]map[string]any{{"op": "close_bead"}}, 400, "operations[0]: unknown op"},
+		{[]map[string]any{{"op": "create_bead", "bead": map[string]any{}}}, 400, "operations[0]: title is required"},
+		{[]map[string]any{
+			{"op": "create_bead", "bead": map[string]any{"title": "A", "type": "task"}},
+			{"op": "add_labels", "bead_id": "$1.id", "labels": []string{"x"}},
+		}, 400, "operations[1]: bead_id $1.id does not name an earlier create_bead operation"},
+		{[]map[string]any{
+			{"op": "create_bead", "bead": map[string]any{"title": "A", "type": "task"}},
+			{"op": "add_dependency", "bead_id": "$0.id", "depends_on_id": "bd-missing"},
+		}, 404, "operations[1]: bead bd-missing not found"},
+	} {
+		rec := doJSON(t, h, "POST", "/v1/transactions", map[string]any{"operations": tc.ops})
+		requireStatus(t, rec, tc.status)
+		if !strings.Contains(rec.Body.String(), tc.want) {
+			t.Errorf("body = %s, want %q", rec.Body.String(), tc.want)
+		}
+	}
+	if len(ms.deps) != 0 || len(ms.labels) != 0 {
+		t.Fatalf("failed transactions wrote deps %v, labels %v", ms.deps, ms.labels)
+	}
+}
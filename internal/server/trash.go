@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// trashPurgeActor is recorded on events for beads purged by retention.
+const trashPurgeActor = "beads:purge"
+
+// RunTrashPurge permanently deletes beads that have been in the trash longer
+// than retention, checking every interval until ctx is cancelled.
+func (s *BeadsServer) RunTrashPurge(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.PurgeTrash(ctx, time.Now().UTC().Add(-retention)); err != nil {
+				slog.Error("trash purge failed", "err", err)
+			} else if n > 0 {
+				slog.Info("purged trash", "count", n)
+			}
+		}
+	}
+}
+
+// PurgeTrash permanently deletes beads moved to the trash before cutoff and
+// emits a bead.deleted event for each. Returns the number purged.
+func (s *BeadsServer) PurgeTrash(ctx context.Context, cutoff time.Time) (int, error) {
+	var ids []string
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		var err error
+		if ids, err = tx.PurgeDeletedBeads(ctx, cutoff); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := s.recordEvent(ctx, tx, events.TopicBeadDeleted, id, trashPurgeActor, events.BeadDeleted{BeadID: id}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("purging trash: %w", err)
+	}
+	s.flushEvents(ctx)
+	return len(ids), nil
+}
+
+// restoreBead moves a bead out of the trash. Returns sql.ErrNoRows if the
+// bead is not in the trash.
+func (s *BeadsServer) restoreBead(ctx context.Context, id, actor string) (*model.Bead, error) {
+	actor = s.actorFor(ctx, actor)
+	var bead *model.Bead
+	err := s.store.RunInTransaction(ctx, func(tx store.Store) error {
+		var err error
+		if bead, err = tx.RestoreBead(ctx, id); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, tx, events.TopicBeadRestored, bead.ID, actor, events.BeadRestored{
+			Bead:       bead,
+			RestoredBy: actor,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.flushEvents(ctx)
+	return bead, nil
+}
+
+// restoreBeadRequest is the optional JSON body for POST /v1/beads/{id}/restore.
+type restoreBeadRequest struct {
+	RestoredBy string `json:"restored_by"`
+}
+
+// handleRestoreBead handles POST /v1/beads/{id}/restore.
+func (s *BeadsServer) handleRestoreBead(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req restoreBeadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	bead, err := s.restoreBead(r.Context(), id, req.RestoredBy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "bead not found in trash")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to restore bead")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, bead)
+}
+
+// handleListTrash handles GET /v1/trash.
+func (s *BeadsServer) handleListTrash(w http.ResponseWriter, r *http.Request) {
+	beads, err := s.store.ListDeletedBeads(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list trash")
+		return
+	}
+
+	// Ensure beads is never null in JSON output.
+	if beads == nil {
+		beads = []*model.Bead{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"beads": beads,
+		"total": len(beads),
+	})
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-tr1"] = &model.Bead{ID: "bd-tr1", Title: "Oops", Status: model.StatusInProgress}
+
+	rec := doJSON(t, h, "DELETE", "/v1/beads/bd-tr1?deleted_by=alice", nil)
+	requireStatus(t, rec, 204)
+	requireEvent(t, ms, 1, "beads.bead.deleted")
+
+	// Hidden from reads, listed in the trash.
+	requireStatus(t, doJSON(t, h, "GET", "/v1/beads/bd-tr1", nil), 404)
+	rec = doJSON(t, h, "GET", "/v1/trash", nil)
+	requireStatus(t, rec, 200)
+	var trash struct {
+		Beads []*model.Bead `json:"beads"`
+		Total int           `json:"total"`
+	}
+	decodeJSON(t, rec, &trash)
+	if trash.Total != 1 || trash.Beads[0].DeletedBy != "alice" || trash.Beads[0].DeletedAt == nil {
+		t.Fatalf("unexpected trash: %+v", trash)
+	}
+
+	rec = doJSON(t, h, "POST", "/v1/beads/bd-tr1/restore", map[string]any{"restored_by": "bob"})
+	requireStatus(t, rec, 200)
+	var restored model.Bead
+	decodeJSON(t, rec, &restored)
+	if restored.Status != model.StatusInProgress || restored.DeletedAt != nil {
+		t.Fatalf("unexpected restored bead: %+v", restored)
+	}
+	requireEvent(t, ms, 2, "beads.bead.restored")
+	if ms.events[1].Actor != "bob" {
+		t.Fatalf("expected actor=bob, got %q", ms.events[1].Actor)
+	}
+
+	// A second restore finds nothing in the trash.
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-tr1/restore", nil), 404)
+}
+
+func TestHardDelete(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-hd1"] = &model.Bead{ID: "bd-hd1", Title: "Gone for good", Status: model.StatusOpen}
+	ms.beads["bd-hd2"] = &model.Bead{ID: "bd-hd2", Title: "Trashed first", Status: model.StatusOpen}
+
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/beads/bd-hd1?hard=true", nil), 204)
+	if _, ok := ms.trash["bd-hd1"]; ok {
+		t.Fatal("hard delete should not go through the trash")
+	}
+
+	// Hard-deleting a trashed bead purges it.
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/beads/bd-hd2", nil), 204)
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/beads/bd-hd2?hard=true", nil), 204)
+	if len(ms.trash) != 0 {
+		t.Fatalf("expected empty trash, got %v", ms.trash)
+	}
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/beads/bd-hd2?hard=true", nil), 404)
+}
+
+func TestGRPCDeleteBead_Hard(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-hd3"] = &model.Bead{ID: "bd-hd3", Title: "Gone", Status: model.StatusOpen}
+
+	if _, err := srv.DeleteBead(ctx, &beadsv1.DeleteBeadRequest{Id: "bd-hd3", Hard: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ms.beads) != 0 || len(ms.trash) != 0 {
+		t.Fatalf("expected bead removed, beads=%v trash=%v", ms.beads, ms.trash)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	now := time.Now().UTC()
+	old, recent := now.Add(-48*time.Hour), now.Add(-time.Hour)
+	ms.trash["bd-old"] = &model.Bead{ID: "bd-old", Title: "Old", DeletedAt: &old}
+	ms.trash["bd-new"] = &model.Bead{ID: "bd-new", Title: "New", DeletedAt: &recent}
+
+	n, err := srv.PurgeTrash(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 1 || ms.trash["bd-old"] != nil || ms.trash["bd-new"] == nil {
+		t.Fatalf("purged %d; trash=%v", n, ms.trash)
+	}
+	requireEvent(t, ms, 1, "beads.bead.deleted")
+	if ms.events[0].Actor != trashPurgeActor {
+		t.Fatalf("expected actor=%q, got %q", trashPurgeActor, ms.events[0].Actor)
+	}
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// undeferActor is recorded on the updates and events made when a deferred
+// bead's defer_until passes.
+const undeferActor = "beads:undefer"
+
+// RunUndeferrer reopens deferred beads whose defer_until has passed,
+// checking every interval until ctx is cancelled.
+func (s *BeadsServer) RunUndeferrer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if ids, err := s.UndeferDue(ctx, time.Now().UTC()); err != nil {
+				slog.Error("undeferring beads failed", "err", err)
+			} else if len(ids) > 0 {
+				slog.Info("resurfaced deferred beads", "count", len(ids))
+			}
+		}
+	}
+}
+
+// dueDeferred returns the deferred beads whose defer_until is before end.
+// Beads deferred without a date are never due.
+func (s *BeadsServer) dueDeferred(ctx context.Context, end time.Time) ([]*model.Bead, error) {
+	deferred, _, err := s.store.ListBeads(ctx, model.BeadFilter{Status: []model.Status{model.StatusDeferred}})
+	if err != nil {
+		return nil, err
+	}
+	var due []*model.Bead
+	for _, b := range deferred {
+		if b.DeferUntil != nil && b.DeferUntil.Before(end) {
+			due = append(due, b)
+		}
+	}
+	return due, nil
+}
+
+// UndeferDue moves every deferred bead whose defer_until is at or before
+// now back to open, which records a bead.undeferred event for each. A bead
+// the workflow will not reopen is logged and skipped. Returns the IDs
+// reopened.
+func (s *BeadsServer) UndeferDue(ctx context.Context, now time.Time) ([]string, error) {
+	due, err := s.dueDeferred(ctx, now.Add(time.Nanosecond))
+	if err != nil {
+		return nil, fmt.Errorf("listing deferred beads: %w", err)
+	}
+	open := string(model.StatusOpen)
+	var ids []string
+	for _, b := range due {
+		if _, err := s.updateBead(ctx, b.ID, updateBeadInput{Status: &open, UpdatedBy: undeferActor}); err != nil {
+			slog.Warn("failed to undefer bead", "bead", b.ID, "err", err)
+			continue
+		}
+		ids = append(ids, b.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestUndeferDue(t *testing.T) {
+	s, ms, _ := newTestServer()
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	ms.beads["bd-due"] = &model.Bead{ID: "bd-due", Title: "Due back", Kind: model.KindIssue, Type: "task", Status: model.StatusDeferred, DeferUntil: &past}
+	ms.beads["bd-later"] = &model.Bead{ID: "bd-later", Title: "Later", Kind: model.KindIssue, Type: "task", Status: model.StatusDeferred, DeferUntil: &future}
+	ms.beads["bd-someday"] = &model.Bead{ID: "bd-someday", Title: "Someday", Kind: model.KindIssue, Type: "task", Status: model.StatusDeferred}
+
+	ids, err := s.UndeferDue(ctx, now)
+	if err != nil {
+		t.Fatalf("UndeferDue: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != "bd-due" {
+		t.Fatalf("undeferred %v, want [bd-due]", ids)
+	}
+	if b := ms.beads["bd-due"]; b.Status != model.StatusOpen {
+		t.Errorf("bd-due status = %s, want open", b.Status)
+	}
+	for _, id := range []string{"bd-later", "bd-someday"} {
+		if b := ms.beads[id]; b.Status != model.StatusDeferred {
+			t.Errorf("%s status = %s, want deferred", id, b.Status)
+		}
+	}
+	e := ms.events[len(ms.events)-1]
+	if e.Topic != events.TopicBeadUndeferred || e.BeadID != "bd-due" || e.Actor != undeferActor {
+		t.Fatalf("last event = %s on %s by %s", e.Topic, e.BeadID, e.Actor)
+	}
+	e.CreatedAt = now // stamped by the database
+
+	// bd-later is due back today, and the daily report counts both.
+	report, err := s.dailyReport(ctx, now.Truncate(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Resurfacing != 2 {
+		t.Errorf("resurfacing = %d, want 2", report.Resurfacing)
+	}
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/version"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ClientVersionHeader carries the bd version on HTTP requests; gRPC calls
+// use the lowercase metadata key.
+const (
+	ClientVersionHeader   = "X-Beads-Client-Version"
+	clientVersionMetadata = "x-beads-client-version"
+)
+
+// ClientTooOldReason is the ErrorInfo reason of calls rejected by the
+// minimum client version policy.
+const ClientTooOldReason = "CLIENT_TOO_OLD"
+
+// API features a server may support. Clients check for one with GET
+// /v1/version before calling endpoints that older servers lack.
+const (
+	FeatureActors          = "actors"
+	FeatureAggregate       = "aggregate"
+	FeatureChecklist       = "checklist"
+	FeatureCommits         = "commits"
+	FeatureEventCompaction = "event_compaction"
+	FeatureEventPagination = "event_pagination"
+	FeatureEventSchemas    = "event_schemas"
+	FeatureFieldsPatch     = "fields_patch"
+	FeatureGraphExport     = "graph_export"
+	FeatureHookTokens      = "hook_tokens"
+	FeatureLabelCounts     = "label_counts"
+	FeatureLocks           = "locks"
+	FeatureMentions        = "mentions"
+	FeaturePrefs           = "prefs"
+	FeatureReadiness       = "readiness"
+	FeatureRollup          = "rollup"
+	FeatureStarvation      = "starvation"
+	FeatureTransactions    = "transactions"
+	FeatureTrash           = "trash"
+	FeatureVelocity        = "velocity"
+	FeatureViewCounts      = "view_counts"
+	FeatureWatchers        = "watchers"
+)
+
+// features is what this server supports, sorted. Add a feature when adding
+// an endpoint clients need to probe for; never remove one.
+var features = []string{
+	FeatureActors,
+	FeatureAggregate,
+	FeatureChecklist,
+	FeatureCommits,
+	FeatureEventCompaction,
+	FeatureEventPagination,
+	FeatureEventSchemas,
+	FeatureFieldsPatch,
+	FeatureGraphExport,
+	FeatureHookTokens,
+	FeatureLabelCounts,
+	FeatureLocks,
+	FeatureMentions,
+	FeaturePrefs,
+	FeatureReadiness,
+	FeatureRollup,
+	FeatureStarvation,
+	FeatureTransactions,
+	FeatureTrash,
+	FeatureVelocity,
+	FeatureViewCounts,
+	FeatureWatchers,
+}
+
+// VersionPolicy is the server's version and the client versions it accepts.
+type VersionPolicy struct {
+	ServerVersion    string
+	MinClientVersion string // empty accepts every client
+	ClientVersion    string // release clients should update to
+	ClientReleaseURL string // base URL of release downloads
+}
+
+// SetVersionPolicy sets the version policy advertised by GetServerInfo and
+// enforced on clients that report their version.
+func (s *BeadsServer) SetVersionPolicy(p VersionPolicy) {
+	s.versions = p
+}
+
+// clientTooOld returns a message telling clientVersion to update, or "" if
+// the policy accepts it. Clients that send no version, and dev or nightly
+// builds, are always accepted.
+func (s *BeadsServer) clientTooOld(clientVersion string) string {
+	min := s.versions.MinClientVersion
+	if min == "" || !version.Older(clientVersion, min) {
+		return ""
+	}
+	return fmt.Sprintf("bd %s is older than %s, the minimum this server accepts; run `bd self-update`", clientVersion, min)
+}
+
+// versionExempt lists the RPCs old clients may still call, so they can
+// learn which version to install.
+var versionExempt = map[string]bool{
+	beadsv1.BeadsService_GetServerInfo_FullMethodName: true,
+	beadsv1.BeadsService_Health_FullMethodName:        true,
+}
+
+// VersionInterceptor rejects unary RPCs from clients older than the minimum
+// client version with FailedPrecondition and a CLIENT_TOO_OLD ErrorInfo.
+func (s *BeadsServer) VersionInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if versionExempt[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	var clientVersion string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(clientVersionMetadata); len(v) > 0 {
+			clientVersion = v[0]
+		}
+	}
+	if msg := s.clientTooOld(clientVersion); msg != "" {
+		st, err := status.New(codes.FailedPrecondition, msg).WithDetails(&errdetails.ErrorInfo{
+			Reason: ClientTooOldReason,
+			Domain: "beads",
+			Metadata: map[string]string{
+				"client_version":     clientVersion,
+				"min_client_version": s.versions.MinClientVersion,
+				"update_to":          s.versions.ClientVersion,
+			},
+		})
+		if err != nil {
+			return nil, status.Error(codes.FailedPrecondition, msg)
+		}
+		return nil, st.Err()
+	}
+	return handler(ctx, req)
+}
+
+// versionMiddleware answers requests from clients older than the minimum
+// client version with 426 Upgrade Required.
+func (s *BeadsServer) versionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientVersion := r.Header.Get(ClientVersionHeader)
+		if r.URL.Path != "/v1/info" && r.URL.Path != "/v1/version" && r.URL.Path != "/v1/health" {
+			if msg := s.clientTooOld(clientVersion); msg != "" {
+				writeJSON(w, http.StatusUpgradeRequired, map[string]string{
+					"error":              msg,
+					"code":               CodeClientTooOld,
+					"reason":             ClientTooOldReason,
+					"client_version":     clientVersion,
+					"min_client_version": s.versions.MinClientVersion,
+					"update_to":          s.versions.ClientVersion,
+				})
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleGetInfo handles GET /v1/info.
+func (s *BeadsServer) handleGetInfo(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"version":            s.versions.ServerVersion,
+		"min_client_version": s.versions.MinClientVersion,
+		"client_version":     s.versions.ClientVersion,
+		"client_release_url": s.versions.ClientReleaseURL,
+	})
+}
+
+// versionInfo is the response of GET /v1/version.
+type versionInfo struct {
+	Version          string   `json:"version"`
+	MinClientVersion string   `json:"min_client_version,omitempty"`
+	ClientVersion    string   `json:"client_version,omitempty"`
+	Features         []string `json:"features"`
+}
+
+// handleGetVersion handles GET /v1/version: the server version, the API
+// features it supports and the client versions it accepts. Clients fetch it
+// once to warn about, or work around, an older server.
+func (s *BeadsServer) handleGetVersion(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, versionInfo{
+		Version:          s.versions.ServerVersion,
+		MinClientVersion: s.versions.MinClientVersion,
+		ClientVersion:    s.versions.ClientVersion,
+		Features:         features,
+	})
+}
+
+// GetServerInfo returns the server version, features and client version
+// policy.
+func (s *BeadsServer) GetServerInfo(_ context.Context, _ *beadsv1.GetServerInfoRequest) (*beadsv1.GetServerInfoResponse, error) {
+	return &beadsv1.GetServerInfoResponse{
+		Version:          s.versions.ServerVersion,
+		MinClientVersion: s.versions.MinClientVersion,
+		ClientVersion:    s.versions.ClientVersion,
+		ClientReleaseUrl: s.versions.ClientReleaseURL,
+		Features:         features,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testVersionPolicy = VersionPolicy{
+	ServerVersion:    "v1.4.0",
+	MinClientVersion: "v1.2.0",
+	ClientVersion:    "v1.4.0",
+	ClientReleaseURL: "https://example.com/releases",
+}
+
+func TestVersionInterceptor(t *testing.T) {
+	srv, _, _ := newTestServer()
+	srv.SetVersionPolicy(testVersionPolicy)
+
+	call := func(method, clientVersion string) error {
+		ctx := context.Background()
+		if clientVersion != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(clientVersionMetadata, clientVersion))
+		}
+		_, err := srv.VersionInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(context.Context, any) (any, error) { return "ok", nil })
+		return err
+	}
+
+	for _, v := range []string{"", "dev", "v1.2.0", "v1.3.1"} {
+		if err := call(beadsv1.BeadsService_ListBeads_FullMethodName, v); err != nil {
+			t.Errorf("client %q rejected: %v", v, err)
+		}
+	}
+
+	err := call(beadsv1.BeadsService_ListBeads_FullMethodName, "v1.1.9")
+	requireCode(t, err, codes.FailedPrecondition)
+	var info *errdetails.ErrorInfo
+	for _, d := range status.Convert(err).Details() {
+		if ei, ok := d.(*errdetails.ErrorInfo); ok {
+			info = ei
+		}
+	}
+	if info == nil || info.GetReason() != ClientTooOldReason || info.GetMetadata()["update_to"] != "v1.4.0" {
+		t.Fatalf("expected CLIENT_TOO_OLD details, got %+v", info)
+	}
+
+	// Old clients can still discover which version to install.
+	if err := call(beadsv1.BeadsService_GetServerInfo_FullMethodName, "v1.1.9"); err != nil {
+		t.Fatalf("GetServerInfo rejected: %v", err)
+	}
+}
+
+func TestVersionMiddleware(t *testing.T) {
+	srv, _, h := newTestServer()
+	srv.SetVersionPolicy(testVersionPolicy)
+
+	req := httptest.NewRequest("GET", "/v1/beads", nil)
+	req.Header.Set(ClientVersionHeader, "v1.0.0")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	requireStatus(t, rec, http.StatusUpgradeRequired)
+	var body map[string]string
+	decodeJSON(t, rec, &body)
+	if body["reason"] != ClientTooOldReason || body["min_client_version"] != "v1.2.0" {
+		t.Fatalf("unexpected body: %v", body)
+	}
+
+	req = httptest.NewRequest("GET", "/v1/info", nil)
+	req.Header.Set(ClientVersionHeader, "v1.0.0")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	requireStatus(t, rec, http.StatusOK)
+	body = nil
+	decodeJSON(t, rec, &body)
+	if body["client_version"] != "v1.4.0" || body["client_release_url"] != "https://example.com/releases" {
+		t.Fatalf("unexpected info: %v", body)
+	}
+
+	// Requests without a version header are always served.
+	requireStatus(t, doJSON(t, h, "GET", "/v1/beads", nil), http.StatusOK)
+}
+
+func TestGRPCGetServerInfo(t *testing.T) {
+	srv, _, ctx := testCtx(t)
+	srv.SetVersionPolicy(testVersionPolicy)
+
+	resp, err := srv.GetServerInfo(ctx, &beadsv1.GetServerInfoRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetVersion() != "v1.4.0" || resp.GetMinClientVersion() != "v1.2.0" || len(resp.GetFeatures()) == 0 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestHandleGetVersion(t *testing.T) {
+	srv, _, h := newTestServer()
+	srv.SetVersionPolicy(testVersionPolicy)
+
+	// Clients below the minimum may still ask, to learn what to install.
+	req := httptest.NewRequest("GET", "/v1/version", nil)
+	req.Header.Set(ClientVersionHeader, "v1.0.0")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	requireStatus(t, rec, http.StatusOK)
+	var v versionInfo
+	decodeJSON(t, rec, &v)
+	if v.Version != "v1.4.0" || v.MinClientVersion != "v1.2.0" {
+		t.Fatalf("unexpected version: %+v", v)
+	}
+	if !slices.IsSorted(v.Features) || !slices.Contains(v.Features, FeatureTransactions) {
+		t.Fatalf("unexpected features: %v", v.Features)
+	}
+}
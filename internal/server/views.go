@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// viewActorVar in a view's assignee or query stands for the caller, as it
+// does when bd runs the view.
+const viewActorVar = "$BEADS_ACTOR"
+
+// viewConfig is the part of a "view:<name>" config the server reads.
+type viewConfig struct {
+	Filter model.BeadFilter `json:"filter"`
+}
+
+// viewCount is a view's entry in GET /v1/views/counts.
+type viewCount struct {
+	Name    string `json:"name"`
+	Count   int    `json:"count"`
+	Builtin bool   `json:"builtin,omitempty"`
+	Error   string `json:"error,omitempty"` // set when the view could not be counted
+}
+
+// viewFilter returns the filter of a view config as run by actor, counting
+// every match: the view's sort, limit and offset are dropped.
+func viewFilter(cfg *model.Config, actor string) (model.BeadFilter, error) {
+	var vc viewConfig
+	if err := json.Unmarshal(cfg.Value, &vc); err != nil {
+		return model.BeadFilter{}, err
+	}
+	f := vc.Filter
+	if err := checkQuery(f); err != nil {
+		return model.BeadFilter{}, err
+	}
+	f.Assignee = strings.ReplaceAll(f.Assignee, viewActorVar, actor)
+	f.Query = strings.ReplaceAll(f.Query, viewActorVar, actor)
+	f.Sort, f.Offset, f.Limit = "", 0, 1
+	return f, nil
+}
+
+// viewCounts counts the beads matching every stored and builtin view for
+// actor, in name order. The counts are taken in one snapshot, so they agree
+// with each other. A view that does not parse is reported with an error
+// rather than failing the others.
+func (s *BeadsServer) viewCounts(ctx context.Context, actor string) ([]viewCount, error) {
+	configs, err := s.listConfigsWithBuiltins(ctx, "view")
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Key < configs[j].Key })
+
+	counts := make([]viewCount, len(configs))
+	filters := make([]*model.BeadFilter, len(configs))
+	for i, c := range configs {
+		counts[i] = viewCount{Name: strings.TrimPrefix(c.Key, "view:"), Builtin: builtinConfigs[c.Key] == c}
+		f, err := viewFilter(c, actor)
+		if err != nil {
+			counts[i].Error = err.Error()
+			continue
+		}
+		filters[i] = &f
+	}
+
+	err = s.store.RunInSnapshot(ctx, func(tx store.Store) error {
+		for i, f := range filters {
+			if f == nil {
+				continue
+			}
+			_, total, err := tx.ListBeads(ctx, *f)
+			if err != nil {
+				return err
+			}
+			counts[i].Count = total
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// handleViewCounts handles GET /v1/views/counts?actor=.
+func (s *BeadsServer) handleViewCounts(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.checkConfigAccess(r.Context(), bearerToken(r.Header.Get("Authorization")), "view"); err != nil {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	counts, err := s.viewCounts(r.Context(), s.actorFor(r.Context(), r.URL.Query().Get("actor")))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to count views")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"views": counts})
+}
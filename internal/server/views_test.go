@@ -0,0 +1,44 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestViewCounts(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-v1"] = &model.Bead{ID: "bd-v1", Title: "A", Type: "task", Kind: model.KindIssue, Status: model.StatusOpen, Assignee: "alice"}
+	ms.beads["bd-v2"] = &model.Bead{ID: "bd-v2", Title: "B", Type: "task", Kind: model.KindIssue, Status: model.StatusOpen, Assignee: "bob"}
+	ms.beads["bd-v3"] = &model.Bead{ID: "bd-v3", Title: "C", Type: "task", Kind: model.KindIssue, Status: model.StatusClosed, Assignee: "alice"}
+	ms.configs["view:inbox"] = &model.Config{Key: "view:inbox", Value: json.RawMessage(`{"filter":{"status":["open"],"assignee":"$BEADS_ACTOR"},"limit":1}`)}
+	ms.configs["view:broken"] = &model.Config{Key: "view:broken", Value: json.RawMessage(`{"filter":{"q":"status="}}`)}
+
+	rec := doJSON(t, h, "GET", "/v1/views/counts?actor=alice", nil)
+	requireStatus(t, rec, 200)
+	var resp struct {
+		Views []viewCount `json:"views"`
+	}
+	decodeJSON(t, rec, &resp)
+
+	want := []viewCount{
+		{Name: "broken"},
+		{Name: "inbox", Count: 1},
+		{Name: "ready", Count: 2, Builtin: true},
+	}
+	if len(resp.Views) != len(want) {
+		t.Fatalf("views = %+v, want %d", resp.Views, len(want))
+	}
+	for i, v := range resp.Views {
+		if i == 0 {
+			if v.Name != "broken" || v.Error == "" {
+				t.Errorf("views[0] = %+v, want broken with an error", v)
+			}
+			continue
+		}
+		if v != want[i] {
+			t.Errorf("views[%d] = %+v, want %+v", i, v, want[i])
+		}
+	}
+}
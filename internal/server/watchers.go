@@ -0,0 +1,285 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Notification listings return this many entries unless asked otherwise.
+const (
+	defaultNotificationLimit = 50
+	maxNotificationLimit     = 500
+)
+
+// watchBead subscribes actor to every future event on a bead and returns the
+// bead's watchers. Watching twice is a no-op. Returns sql.ErrNoRows if the
+// bead does not exist.
+func (s *BeadsServer) watchBead(ctx context.Context, beadID, actor string) ([]string, error) {
+	actor = s.actorFor(ctx, actor)
+	if actor == "" {
+		return nil, inputError("actor is required")
+	}
+	bead, err := s.store.GetBead(ctx, beadID)
+	if err != nil {
+		return nil, err
+	}
+	if bead == nil {
+		return nil, sql.ErrNoRows
+	}
+	if err := s.store.AddWatcher(ctx, beadID, actor); err != nil {
+		return nil, err
+	}
+	return s.store.GetWatchers(ctx, beadID)
+}
+
+// unwatchBead removes actor from a bead's watchers and returns the rest.
+func (s *BeadsServer) unwatchBead(ctx context.Context, beadID, actor string) ([]string, error) {
+	actor = s.actorFor(ctx, actor)
+	if actor == "" {
+		return nil, inputError("actor is required")
+	}
+	if err := s.store.RemoveWatcher(ctx, beadID, actor); err != nil {
+		return nil, err
+	}
+	return s.store.GetWatchers(ctx, beadID)
+}
+
+// listNotifications returns actor's notifications, newest first.
+func (s *BeadsServer) listNotifications(ctx context.Context, actor string, unreadOnly bool, limit int) ([]*model.Notification, error) {
+	actor = s.actorFor(ctx, actor)
+	if actor == "" {
+		return nil, inputError("actor is required")
+	}
+	if limit <= 0 {
+		limit = defaultNotificationLimit
+	}
+	return s.store.ListNotifications(ctx, actor, unreadOnly, min(limit, maxNotificationLimit))
+}
+
+// markNotificationsRead marks the given notifications of actor read, or all
+// of them if ids is empty, and returns how many were marked.
+func (s *BeadsServer) markNotificationsRead(ctx context.Context, actor string, ids []int64) (int64, error) {
+	actor = s.actorFor(ctx, actor)
+	if actor == "" {
+		return 0, inputError("actor is required")
+	}
+	return s.store.MarkNotificationsRead(ctx, actor, ids)
+}
+
+// watcherRequest is the optional JSON body for POST /v1/beads/{id}/watchers.
+type watcherRequest struct {
+	Actor string `json:"actor"`
+}
+
+// handleWatchBead handles POST /v1/beads/{id}/watchers.
+func (s *BeadsServer) handleWatchBead(w http.ResponseWriter, r *http.Request) {
+	beadID := r.PathValue("id")
+	if beadID == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	var req watcherRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	watchers, err := s.watchBead(r.Context(), beadID, req.Actor)
+	if err != nil {
+		var ie inputError
+		switch {
+		case errors.As(err, &ie):
+			writeError(w, http.StatusBadRequest, ie.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			writeError(w, http.StatusNotFound, "bead not found")
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to watch bead")
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]any{"watchers": watchers})
+}
+
+// handleUnwatchBead handles DELETE /v1/beads/{id}/watchers?actor=.
+func (s *BeadsServer) handleUnwatchBead(w http.ResponseWriter, r *http.Request) {
+	beadID := r.PathValue("id")
+	if beadID == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	watchers, err := s.unwatchBead(r.Context(), beadID, r.URL.Query().Get("actor"))
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			writeError(w, http.StatusBadRequest, ie.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to unwatch bead")
+		return
+	}
+
+	if watchers == nil {
+		watchers = []string{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"watchers": watchers})
+}
+
+// handleGetWatchers handles GET /v1/beads/{id}/watchers.
+func (s *BeadsServer) handleGetWatchers(w http.ResponseWriter, r *http.Request) {
+	beadID := r.PathValue("id")
+	if beadID == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	watchers, err := s.store.GetWatchers(r.Context(), beadID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to get watchers")
+		return
+	}
+
+	if watchers == nil {
+		watchers = []string{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"watchers": watchers})
+}
+
+// handleListNotifications handles GET /v1/notifications?actor=&unread=&limit=.
+func (s *BeadsServer) handleListNotifications(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 0
+	if v := q.Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			limit = n
+		}
+	}
+
+	notifications, err := s.listNotifications(r.Context(), q.Get("actor"), q.Get("unread") == "true", limit)
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			writeError(w, http.StatusBadRequest, ie.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to list notifications")
+		return
+	}
+
+	if notifications == nil {
+		notifications = []*model.Notification{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"notifications": notifications})
+}
+
+// markReadRequest is the JSON body for POST /v1/notifications/read.
+type markReadRequest struct {
+	Actor string  `json:"actor"`
+	IDs   []int64 `json:"ids"` // empty marks all
+}
+
+// handleMarkNotificationsRead handles POST /v1/notifications/read.
+func (s *BeadsServer) handleMarkNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	var req markReadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	marked, err := s.markNotificationsRead(r.Context(), req.Actor, req.IDs)
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			writeError(w, http.StatusBadRequest, ie.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to mark notifications read")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"marked": marked})
+}
+
+// WatchBead subscribes an actor to notifications for a bead.
+func (s *BeadsServer) WatchBead(ctx context.Context, req *beadsv1.WatchBeadRequest) (*beadsv1.WatchBeadResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+
+	watchers, err := s.watchBead(ctx, req.GetBeadId(), req.GetActor())
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		return nil, storeError(err, "bead")
+	}
+
+	return &beadsv1.WatchBeadResponse{Watchers: watchers}, nil
+}
+
+// UnwatchBead unsubscribes an actor from a bead's notifications.
+func (s *BeadsServer) UnwatchBead(ctx context.Context, req *beadsv1.UnwatchBeadRequest) (*beadsv1.UnwatchBeadResponse, error) {
+	if req.GetBeadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bead_id is required")
+	}
+
+	watchers, err := s.unwatchBead(ctx, req.GetBeadId(), req.GetActor())
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to unwatch bead: %v", err)
+	}
+
+	return &beadsv1.UnwatchBeadResponse{Watchers: watchers}, nil
+}
+
+// ListNotifications returns an actor's notifications, newest first.
+func (s *BeadsServer) ListNotifications(ctx context.Context, req *beadsv1.ListNotificationsRequest) (*beadsv1.ListNotificationsResponse, error) {
+	notifications, err := s.listNotifications(ctx, req.GetActor(), req.GetUnreadOnly(), int(req.GetLimit()))
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list notifications: %v", err)
+	}
+
+	pbNotifications := make([]*beadsv1.Notification, 0, len(notifications))
+	for _, n := range notifications {
+		pbNotifications = append(pbNotifications, notificationToProto(n))
+	}
+
+	return &beadsv1.ListNotificationsResponse{Notifications: pbNotifications}, nil
+}
+
+// MarkNotificationsRead marks an actor's notifications read.
+func (s *BeadsServer) MarkNotificationsRead(ctx context.Context, req *beadsv1.MarkNotificationsReadRequest) (*beadsv1.MarkNotificationsReadResponse, error) {
+	marked, err := s.markNotificationsRead(ctx, req.GetActor(), req.GetIds())
+	if err != nil {
+		var ie inputError
+		if errors.As(err, &ie) {
+			return nil, status.Error(codes.InvalidArgument, ie.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to mark notifications read: %v", err)
+	}
+
+	return &beadsv1.MarkNotificationsReadResponse{Marked: marked}, nil
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+)
+
+func TestWatchBeadNotifications(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-w1"] = &model.Bead{ID: "bd-w1", Title: "Watched", Type: "task", Kind: model.KindIssue, Status: model.StatusOpen}
+
+	rec := doJSON(t, h, "POST", "/v1/beads/bd-w1/watchers", map[string]any{"actor": "alice"})
+	requireStatus(t, rec, 201)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-w1/watchers", map[string]any{"actor": "alice"}), 201)
+	var result struct {
+		Watchers []string `json:"watchers"`
+	}
+	decodeJSON(t, rec, &result)
+	if len(ms.watchers["bd-w1"]) != 1 || result.Watchers[0] != "alice" {
+		t.Fatalf("unexpected watchers: %v", ms.watchers["bd-w1"])
+	}
+
+	// Events by others notify the watcher; their own do not.
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-w1/comments", map[string]any{"author": "bob", "text": "hi"}), 201)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-w1/notes", map[string]any{"author": "alice", "text": "mine"}), 201)
+
+	rec = doJSON(t, h, "GET", "/v1/notifications?actor=alice&unread=true", nil)
+	requireStatus(t, rec, 200)
+	var list struct {
+		Notifications []model.Notification `json:"notifications"`
+	}
+	decodeJSON(t, rec, &list)
+	if len(list.Notifications) != 1 || list.Notifications[0].Event.Actor != "bob" || list.Notifications[0].Event.BeadID != "bd-w1" {
+		t.Fatalf("unexpected notifications: %+v", list.Notifications)
+	}
+
+	rec = doJSON(t, h, "POST", "/v1/notifications/read", map[string]any{"actor": "alice"})
+	requireStatus(t, rec, 200)
+	var marked struct {
+		Marked int64 `json:"marked"`
+	}
+	decodeJSON(t, rec, &marked)
+	if marked.Marked != 1 {
+		t.Fatalf("marked = %d, want 1", marked.Marked)
+	}
+	rec = doJSON(t, h, "GET", "/v1/notifications?actor=alice&unread=true", nil)
+	decodeJSON(t, rec, &list)
+	if len(list.Notifications) != 0 {
+		t.Fatalf("expected inbox empty, got %+v", list.Notifications)
+	}
+
+	requireStatus(t, doJSON(t, h, "DELETE", "/v1/beads/bd-w1/watchers?actor=alice", nil), 200)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-w1/comments", map[string]any{"author": "bob", "text": "again"}), 201)
+	if len(ms.notifications) != 1 {
+		t.Fatalf("unwatched bead should not notify, got %d notifications", len(ms.notifications))
+	}
+}
+
+func TestWatchBead_Errors(t *testing.T) {
+	_, _, h := newTestServer()
+
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-nope/watchers", map[string]any{"actor": "alice"}), 404)
+	requireStatus(t, doJSON(t, h, "POST", "/v1/beads/bd-nope/watchers", nil), 400)
+	requireStatus(t, doJSON(t, h, "GET", "/v1/notifications", nil), 400)
+}
+
+func TestGRPCWatchBead(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-w2"] = &model.Bead{ID: "bd-w2", Title: "Watched", Status: model.StatusOpen}
+
+	resp, err := srv.WatchBead(ctx, &beadsv1.WatchBeadRequest{BeadId: "bd-w2", Actor: "carol"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.GetWatchers()) != 1 {
+		t.Fatalf("unexpected watchers: %v", resp.GetWatchers())
+	}
+
+	if _, err := srv.AddNote(ctx, &beadsv1.AddNoteRequest{BeadId: "bd-w2", Author: "dave", Text: "update"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list, err := srv.ListNotifications(ctx, &beadsv1.ListNotificationsRequest{Actor: "carol", UnreadOnly: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.GetNotifications()) != 1 || list.GetNotifications()[0].GetEvent().GetTopic() != "beads.note.appended" {
+		t.Fatalf("unexpected notifications: %+v", list.GetNotifications())
+	}
+
+	marked, err := srv.MarkNotificationsRead(ctx, &beadsv1.MarkNotificationsReadRequest{
+		Actor: "carol",
+		Ids:   []int64{list.GetNotifications()[0].GetId()},
+	})
+	if err != nil || marked.GetMarked() != 1 {
+		t.Fatalf("marked = %d, err = %v", marked.GetMarked(), err)
+	}
+
+	unwatched, err := srv.UnwatchBead(ctx, &beadsv1.UnwatchBeadRequest{BeadId: "bd-w2", Actor: "carol"})
+	if err != nil || len(unwatched.GetWatchers()) != 0 {
+		t.Fatalf("unexpected unwatch result: %v, %v", unwatched.GetWatchers(), err)
+	}
+
+	_, err = srv.WatchBead(ctx, &beadsv1.WatchBeadRequest{BeadId: "bd-nope", Actor: "carol"})
+	requireCode(t, err, codes.NotFound)
+	_, err = srv.ListNotifications(ctx, &beadsv1.ListNotificationsRequest{})
+	requireCode(t, err, codes.InvalidArgument)
+}
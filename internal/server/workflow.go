@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// transitionError is returned by updateBead when the status workflow does
+// not allow the requested status change.
+// Transport layers map this to 422 / FailedPrecondition.
+type transitionError struct {
+	From, To model.Status
+}
+
+func (e *transitionError) Error() string {
+	return fmt.Sprintf("cannot change status from %s to %s", e.From, e.To)
+}
+
+// statusWorkflow loads the workflow:status config, falling back to the
+// builtin default.
+func (s *BeadsServer) statusWorkflow(ctx context.Context) (*model.WorkflowConfig, error) {
+	config, err := s.store.GetConfig(ctx, model.WorkflowConfigKey)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if config == nil {
+		config = builtinConfigs[model.WorkflowConfigKey]
+	}
+	var wc model.WorkflowConfig
+	if err := json.Unmarshal(config.Value, &wc); err != nil {
+		return nil, fmt.Errorf("invalid workflow config: %w", err)
+	}
+	return &wc, nil
+}
+
+// checkTransition returns *transitionError if the workflow forbids moving a
+// bead from one status to another.
+func (s *BeadsServer) checkTransition(ctx context.Context, from, to model.Status) error {
+	if from == to || !to.IsValid() {
+		return nil // unchanged, or rejected by bead validation
+	}
+	wc, err := s.statusWorkflow(ctx)
+	if err != nil {
+		return err
+	}
+	if !wc.Allows(from, to) {
+		return &transitionError{From: from, To: to}
+	}
+	return nil
+}
+
+// recordTransition records the transition-specific event for a status
+// change, if there is one: bead.reopened when a closed bead is opened again,
+// bead.deferred when a bead is deferred and bead.undeferred when a deferred
+// bead is opened.
+func (s *BeadsServer) recordTransition(ctx context.Context, tx store.Store, from model.Status, bead *model.Bead, actor string) error {
+	switch {
+	case from == bead.Status:
+		return nil
+	case from == model.StatusClosed:
+		return s.recordEvent(ctx, tx, events.TopicBeadReopened, bead.ID, actor, events.BeadReopened{Bead: bead, ReopenedBy: actor})
+	case bead.Status == model.StatusDeferred:
+		return s.recordEvent(ctx, tx, events.TopicBeadDeferred, bead.ID, actor, events.BeadDeferred{Bead: bead, DeferredBy: actor})
+	case from == model.StatusDeferred && bead.Status == model.StatusOpen:
+		return s.recordEvent(ctx, tx, events.TopicBeadUndeferred, bead.ID, actor, events.BeadUndeferred{Bead: bead, UndeferredBy: actor})
+	}
+	return nil
+}
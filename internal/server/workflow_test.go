@@ -0,0 +1,63 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	beadsv1 "github.com/alfredjeanlab/beads/gen/beads/v1"
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandleUpdateBead_StatusTransitions(t *testing.T) {
+	_, ms, h := newTestServer()
+	ms.beads["bd-1"] = &model.Bead{ID: "bd-1", Title: "Done", Type: "task", Kind: model.KindIssue, Status: model.StatusClosed}
+
+	// closed -> in_progress skips the reopen.
+	rec := doJSON(t, h, "PATCH", "/v1/beads/bd-1", map[string]any{"status": "in_progress"})
+	requireStatus(t, rec, http.StatusUnprocessableEntity)
+	if ms.beads["bd-1"].Status != model.StatusClosed {
+		t.Fatalf("status = %s after a refused transition", ms.beads["bd-1"].Status)
+	}
+
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-1", map[string]any{"status": "open"}), http.StatusOK)
+	if last := ms.events[len(ms.events)-1]; last.Topic != events.TopicBeadReopened {
+		t.Errorf("last event = %s, want %s", last.Topic, events.TopicBeadReopened)
+	}
+
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-1", map[string]any{"status": "deferred"}), http.StatusOK)
+	if last := ms.events[len(ms.events)-1]; last.Topic != events.TopicBeadDeferred {
+		t.Errorf("last event = %s, want %s", last.Topic, events.TopicBeadDeferred)
+	}
+
+	// Unchanged status and unknown statuses are not transitions.
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-1", map[string]any{"status": "deferred", "title": "Later"}), http.StatusOK)
+	requireStatus(t, doJSON(t, h, "PATCH", "/v1/beads/bd-1", map[string]any{"status": "blocked"}), http.StatusBadRequest)
+}
+
+func TestGRPCUpdateBead_CustomWorkflow(t *testing.T) {
+	srv, ms, ctx := testCtx(t)
+	ms.beads["bd-1"] = &model.Bead{ID: "bd-1", Title: "Work", Type: "task", Kind: model.KindIssue, Status: model.StatusOpen}
+
+	if _, err := srv.SetConfig(ctx, &beadsv1.SetConfigRequest{
+		Key:   model.WorkflowConfigKey,
+		Value: []byte(`{"transitions":{"open":["in_progress"],"in_progress":["closed"],"closed":["open"]}}`),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := srv.UpdateBead(ctx, &beadsv1.UpdateBeadRequest{Id: "bd-1", Status: proto.String("closed")})
+	requireCode(t, err, codes.FailedPrecondition)
+	if _, err := srv.UpdateBead(ctx, &beadsv1.UpdateBeadRequest{Id: "bd-1", Status: proto.String("in_progress")}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = srv.SetConfig(ctx, &beadsv1.SetConfigRequest{
+		Key:   model.WorkflowConfigKey,
+		Value: json.RawMessage(`{"transitions":{"open":["blocked"]}}`),
+	})
+	requireCode(t, err, codes.InvalidArgument)
+}
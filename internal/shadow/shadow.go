@@ -0,0 +1,188 @@
+// Package shadow runs a candidate implementation of a route alongside the
+// primary one for a sampled fraction of requests and reports where their
+// results differ. The primary result is always the one served; the
+// candidate runs in the background and can never fail or slow a request.
+package shadow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// candidateTimeout bounds how long a candidate may run after the request.
+const candidateTimeout = 30 * time.Second
+
+// maxDiffContext is how many bytes around the first difference are logged.
+const maxDiffContext = 80
+
+// ParseRates parses a per-route sample spec such as "ready=0.1,list=1".
+// Rates must be within [0, 1].
+func ParseRates(spec string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		route, v, ok := strings.Cut(part, "=")
+		if !ok || route == "" {
+			return nil, fmt.Errorf("invalid shadow route %q: want route=rate", part)
+		}
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid shadow rate for %s: %q", route, v)
+		}
+		rates[route] = rate
+	}
+	return rates, nil
+}
+
+// counters are the per-route comparison totals.
+type counters struct {
+	runs       int64
+	mismatches int64
+	errors     int64
+}
+
+// Shadow holds the sample rate of each shadowed route and the comparison
+// counters. A nil *Shadow shadows nothing.
+type Shadow struct {
+	rates  map[string]float64
+	logger *slog.Logger
+
+	mu     sync.Mutex
+	counts map[string]*counters
+	wg     sync.WaitGroup
+}
+
+// New returns a Shadow sampling each route at the given rate.
+func New(rates map[string]float64, logger *slog.Logger) *Shadow {
+	return &Shadow{rates: rates, logger: logger, counts: make(map[string]*counters)}
+}
+
+// sample reports whether this request on route should be shadowed.
+func (s *Shadow) sample(route string) bool {
+	if s == nil {
+		return false
+	}
+	rate := s.rates[route]
+	return rate > 0 && (rate >= 1 || rand.Float64() < rate)
+}
+
+// Wait blocks until every in-flight candidate has finished.
+func (s *Shadow) Wait() {
+	if s != nil {
+		s.wg.Wait()
+	}
+}
+
+// Run returns the result of primary. For sampled requests it also runs
+// candidate in the background, compares the two results as JSON, and logs
+// and counts any difference. Candidates run detached from the request's
+// cancellation but keep its values.
+func Run[T any](ctx context.Context, s *Shadow, route string, primary, candidate func(context.Context) (T, error)) (T, error) {
+	want, err := primary(ctx)
+	if err != nil || !s.sample(route) {
+		return want, err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		cctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), candidateTimeout)
+		defer cancel()
+
+		start := time.Now()
+		got, err := candidate(cctx)
+		if err != nil {
+			s.record(route, false, true)
+			s.logger.Warn("shadow candidate failed", "route", route, "error", err)
+			return
+		}
+		if diff := compare(want, got); diff != "" {
+			s.record(route, true, false)
+			s.logger.Warn("shadow mismatch", "route", route, "diff", diff, "candidate_ms", time.Since(start).Milliseconds())
+			return
+		}
+		s.record(route, false, false)
+	}()
+	return want, nil
+}
+
+func (s *Shadow) record(route string, mismatch, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.counts[route]
+	if !ok {
+		c = &counters{}
+		s.counts[route] = c
+	}
+	c.runs++
+	if mismatch {
+		c.mismatches++
+	}
+	if failed {
+		c.errors++
+	}
+}
+
+// compare returns "" if want and got encode to the same JSON, or a short
+// description of the first difference.
+func compare(want, got any) string {
+	a, errA := json.Marshal(want)
+	b, errB := json.Marshal(got)
+	if errA != nil || errB != nil {
+		return fmt.Sprintf("unencodable result: %v / %v", errA, errB)
+	}
+	if bytes.Equal(a, b) {
+		return ""
+	}
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	from := max(0, i-maxDiffContext/2)
+	return fmt.Sprintf("at byte %d: primary %q, candidate %q", i,
+		a[from:min(len(a), i+maxDiffContext/2)], b[from:min(len(b), i+maxDiffContext/2)])
+}
+
+// WriteText renders the comparison counters in the Prometheus text
+// exposition format.
+func (s *Shadow) WriteText(w io.Writer) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	routes := make([]string, 0, len(s.counts))
+	for r := range s.counts {
+		routes = append(routes, r)
+	}
+	sort.Strings(routes)
+
+	for _, m := range []struct {
+		name, help string
+		value      func(*counters) int64
+	}{
+		{"beads_shadow_runs_total", "Requests whose candidate implementation was compared.", func(c *counters) int64 { return c.runs }},
+		{"beads_shadow_mismatches_total", "Shadowed requests whose candidate result differed.", func(c *counters) int64 { return c.mismatches }},
+		{"beads_shadow_errors_total", "Shadowed requests whose candidate returned an error.", func(c *counters) int64 { return c.errors }},
+	} {
+		fmt.Fprintf(w, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(w, "# TYPE %s counter\n", m.name)
+		for _, r := range routes {
+			fmt.Fprintf(w, "%s{route=%q} %d\n", m.name, r, m.value(s.counts[r]))
+		}
+	}
+}
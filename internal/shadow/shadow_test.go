@@ -0,0 +1,77 @@
+package shadow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestParseRates(t *testing.T) {
+	rates, err := ParseRates("ready=0.1, list=1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rates["ready"] != 0.1 || rates["list"] != 1 {
+		t.Fatalf("unexpected rates: %v", rates)
+	}
+	for _, bad := range []string{"ready", "ready=2", "=0.5", "ready=x"} {
+		if _, err := ParseRates(bad); err == nil {
+			t.Errorf("ParseRates(%q): expected error", bad)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	var logs bytes.Buffer
+	s := New(map[string]float64{"list": 1}, slog.New(slog.NewTextHandler(&logs, nil)))
+	ctx := context.Background()
+	primary := func(context.Context) ([]string, error) { return []string{"a", "b"}, nil }
+
+	got, err := Run(ctx, s, "list", primary, func(context.Context) ([]string, error) { return []string{"a", "b"}, nil })
+	if err != nil || len(got) != 2 {
+		t.Fatalf("got %v, %v", got, err)
+	}
+	// The candidate's result is never served, even when it differs or fails.
+	got, _ = Run(ctx, s, "list", primary, func(context.Context) ([]string, error) { return []string{"a", "c"}, nil })
+	if got[1] != "b" {
+		t.Fatalf("served candidate result: %v", got)
+	}
+	_, _ = Run(ctx, s, "list", primary, func(context.Context) ([]string, error) { return nil, errors.New("boom") })
+	// Unsampled routes never run the candidate.
+	_, _ = Run(ctx, s, "other", primary, func(context.Context) ([]string, error) {
+		t.Error("candidate ran for unsampled route")
+		return nil, nil
+	})
+	s.Wait()
+
+	if !strings.Contains(logs.String(), "shadow mismatch") || !strings.Contains(logs.String(), "shadow candidate failed") {
+		t.Fatalf("expected mismatch and failure logs, got:\n%s", logs.String())
+	}
+
+	var out bytes.Buffer
+	s.WriteText(&out)
+	for _, want := range []string{
+		`beads_shadow_runs_total{route="list"} 3`,
+		`beads_shadow_mismatches_total{route="list"} 1`,
+		`beads_shadow_errors_total{route="list"} 1`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("metrics missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunNil(t *testing.T) {
+	var s *Shadow
+	got, err := Run(context.Background(), s, "list",
+		func(context.Context) (int, error) { return 1, nil },
+		func(context.Context) (int, error) { t.Error("candidate ran"); return 0, nil })
+	if err != nil || got != 1 {
+		t.Fatalf("got %d, %v", got, err)
+	}
+	s.WriteText(io.Discard)
+}
@@ -0,0 +1,89 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSignatureAge bounds how old a signed request may be, to limit replays.
+const maxSignatureAge = 5 * time.Minute
+
+// VerifyRequest checks a request's Slack v0 signature
+// (X-Slack-Signature over "v0:<timestamp>:<body>") against secret.
+func VerifyRequest(secret string, h http.Header, body []byte, now time.Time) error {
+	if secret == "" {
+		return errors.New("signing secret not configured")
+	}
+	ts := h.Get("X-Slack-Request-Timestamp")
+	sig := h.Get("X-Slack-Signature")
+	if ts == "" || sig == "" {
+		return errors.New("missing signature headers")
+	}
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return errors.New("invalid request timestamp")
+	}
+	if age := now.Sub(time.Unix(sec, 0)); age > maxSignatureAge || age < -maxSignatureAge {
+		return errors.New("request timestamp out of range")
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(Sign(secret, ts, body))) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// Sign returns the v0 signature Slack would send for body at timestamp ts.
+func Sign(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:", ts)
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Interaction is a button press on a decision message.
+type Interaction struct {
+	BeadID string // decision bead the message was posted for
+	Option string // the option that was chosen
+	UserID string // Slack user who pressed the button
+}
+
+// ParseInteraction decodes a form-encoded block_actions payload. It returns
+// an error if the payload does not carry a decision button press.
+func ParseInteraction(body []byte) (*Interaction, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid form body: %w", err)
+	}
+	var p struct {
+		Type string `json:"type"`
+		User struct {
+			ID string `json:"id"`
+		} `json:"user"`
+		Actions []struct {
+			BlockID string `json:"block_id"`
+			Value   string `json:"value"`
+		} `json:"actions"`
+	}
+	if err := json.Unmarshal([]byte(form.Get("payload")), &p); err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+	if p.Type != "block_actions" {
+		return nil, fmt.Errorf("unsupported interaction type %q", p.Type)
+	}
+	for _, a := range p.Actions {
+		if id, ok := strings.CutPrefix(a.BlockID, DecisionBlockID("")); ok && id != "" {
+			return &Interaction{BeadID: id, Option: a.Value, UserID: p.User.ID}, nil
+		}
+	}
+	return nil, errors.New("no decision action in payload")
+}
@@ -0,0 +1,288 @@
+// Package slack bridges beads to Slack: decision beads are posted as
+// interactive messages with one button per option, and @mentions in comments
+// are sent to the mapped Slack user as direct messages.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+// ConfigKey is the config entry holding the integration settings.
+const ConfigKey = "integration:slack"
+
+// DefaultAPIURL is the Slack Web API base URL.
+const DefaultAPIURL = "https://slack.com/api"
+
+// Config is the value stored under ConfigKey.
+type Config struct {
+	BotToken      string            `json:"bot_token"`
+	SigningSecret string            `json:"signing_secret"`
+	Channel       string            `json:"channel"`         // channel decisions are posted to
+	Users         map[string]string `json:"users,omitempty"` // beads actor -> Slack user ID
+}
+
+// ActorFor maps a Slack user ID back to a beads actor. Unmapped users are
+// returned as "slack:<id>".
+func (c *Config) ActorFor(slackUserID string) string {
+	for actor, id := range c.Users {
+		if id == slackUserID {
+			return actor
+		}
+	}
+	return "slack:" + slackUserID
+}
+
+// ConfigSource is the subset of store.Store used to read the integration config.
+type ConfigSource interface {
+	GetConfig(ctx context.Context, key string) (*model.Config, error)
+}
+
+// LoadConfig reads the integration config. It returns nil, nil when the
+// integration is not configured.
+func LoadConfig(ctx context.Context, src ConfigSource) (*Config, error) {
+	c, err := src.GetConfig(ctx, ConfigKey)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && c == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := json.Unmarshal(c.Value, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s config: %w", ConfigKey, err)
+	}
+	if cfg.BotToken == "" {
+		return nil, nil
+	}
+	return &cfg, nil
+}
+
+// Bridge is an events.Publisher that forwards every event to an inner
+// publisher and, as a side effect, posts decision and mention notifications
+// to Slack. Slack calls run in the background so publishing never blocks on
+// the Slack API.
+type Bridge struct {
+	inner  events.Publisher
+	source ConfigSource
+	logger *slog.Logger
+
+	// APIURL and HTTPClient may be overridden before the first Publish.
+	APIURL     string
+	HTTPClient *http.Client
+
+	wg sync.WaitGroup
+}
+
+// NewBridge wraps inner with Slack notifications configured from src.
+func NewBridge(inner events.Publisher, src ConfigSource, logger *slog.Logger) *Bridge {
+	return &Bridge{
+		inner:      inner,
+		source:     src,
+		logger:     logger,
+		APIURL:     DefaultAPIURL,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Publish forwards the event to the inner publisher and schedules any Slack
+// notification it triggers.
+func (b *Bridge) Publish(ctx context.Context, topic string, event any) error {
+	err := b.inner.Publish(ctx, topic, event)
+
+	switch e := event.(type) {
+	case events.BeadCreated:
+		if e.Bead != nil && e.Bead.Type == "decision" {
+			bead := e.Bead
+			b.async(func(ctx context.Context, cfg *Config) error { return b.postDecision(ctx, cfg, bead) })
+		}
+	case events.CommentAdded:
+		if e.Comment != nil && len(Mentions(e.Comment.Text)) > 0 {
+			comment := e.Comment
+			b.async(func(ctx context.Context, cfg *Config) error { return b.notifyMentions(ctx, cfg, comment) })
+		}
+	}
+	return err
+}
+
+// Close waits for in-flight Slack calls and closes the inner publisher.
+func (b *Bridge) Close() error {
+	b.wg.Wait()
+	return b.inner.Close()
+}
+
+func (b *Bridge) async(fn func(ctx context.Context, cfg *Config) error) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		cfg, err := LoadConfig(ctx, b.source)
+		if err != nil {
+			b.logger.Warn("slack config load failed", "err", err)
+			return
+		}
+		if cfg == nil {
+			return
+		}
+		if err := fn(ctx, cfg); err != nil {
+			b.logger.Warn("slack notification failed", "err", err)
+		}
+	}()
+}
+
+// decisionFields is the subset of a decision bead's fields shown in Slack.
+type decisionFields struct {
+	Options      []string `json:"options"`
+	ExpiresAt    string   `json:"expires_at,omitempty"`
+	ContextBeads []string `json:"context_beads,omitempty"`
+	Diff         string   `json:"diff,omitempty"`
+	Links        []string `json:"links,omitempty"`
+}
+
+// maxDiffLen keeps a decision's diff, in runes, within Slack's 3000-character
+// limit for section text, leaving room for the code fence.
+const maxDiffLen = 2900
+
+// DecisionBlockID returns the block_id used for a decision's option buttons.
+// Interactions carry it back so the bead can be identified.
+func DecisionBlockID(beadID string) string {
+	return "decision:" + beadID
+}
+
+func (b *Bridge) postDecision(ctx context.Context, cfg *Config, bead *model.Bead) error {
+	if cfg.Channel == "" {
+		return nil
+	}
+	var df decisionFields
+	if len(bead.Fields) > 0 {
+		if err := json.Unmarshal(bead.Fields, &df); err != nil {
+			return fmt.Errorf("decision %s: %w", bead.ID, err)
+		}
+	}
+
+	text := fmt.Sprintf("*Decision needed* `%s`: %s", bead.ID, bead.Title)
+	if df.ExpiresAt != "" {
+		text += fmt.Sprintf("\nExpires %s", df.ExpiresAt)
+	}
+	if len(df.ContextBeads) > 0 {
+		text += "\nContext: `" + strings.Join(df.ContextBeads, "`, `") + "`"
+	}
+	for _, link := range df.Links {
+		text += "\n<" + link + ">"
+	}
+	blocks := []any{
+		map[string]any{"type": "section", "text": map[string]string{"type": "mrkdwn", "text": text}},
+	}
+	if df.Diff != "" {
+		diff := df.Diff
+		if r := []rune(diff); len(r) > maxDiffLen {
+			diff = string(r[:maxDiffLen]) + "\n…"
+		}
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]string{"type": "mrkdwn", "text": "```" + diff + "```"},
+		})
+	}
+	if len(df.Options) > 0 {
+		buttons := make([]any, 0, len(df.Options))
+		for i, opt := range df.Options {
+			buttons = append(buttons, map[string]any{
+				"type":      "button",
+				"action_id": fmt.Sprintf("option_%d", i),
+				"text":      map[string]string{"type": "plain_text", "text": opt},
+				"value":     opt,
+			})
+		}
+		blocks = append(blocks, map[string]any{
+			"type":     "actions",
+			"block_id": DecisionBlockID(bead.ID),
+			"elements": buttons,
+		})
+	}
+
+	return b.postMessage(ctx, cfg.BotToken, map[string]any{
+		"channel": cfg.Channel,
+		"text":    fmt.Sprintf("Decision needed: %s", bead.Title),
+		"blocks":  blocks,
+	})
+}
+
+func (b *Bridge) notifyMentions(ctx context.Context, cfg *Config, c *model.Comment) error {
+	var errs []error
+	for _, name := range Mentions(c.Text) {
+		userID, ok := cfg.Users[name]
+		if !ok {
+			continue
+		}
+		err := b.postMessage(ctx, cfg.BotToken, map[string]any{
+			"channel": userID,
+			"text":    fmt.Sprintf("%s mentioned you on `%s`:\n>%s", c.Author, c.BeadID, c.Text),
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// postMessage calls chat.postMessage.
+func (b *Bridge) postMessage(ctx context.Context, token string, msg map[string]any) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.APIURL+"/chat.postMessage", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := b.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var out struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return fmt.Errorf("chat.postMessage: status %d: %w", resp.StatusCode, err)
+	}
+	if !out.OK {
+		return fmt.Errorf("chat.postMessage: %s", out.Error)
+	}
+	return nil
+}
+
+var mentionRe = regexp.MustCompile(`(?:^|\s)@([\w.-]+)`)
+
+// Mentions returns the distinct @names in text, in order of appearance.
+func Mentions(text string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, m := range mentionRe.FindAllStringSubmatch(text, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
@@ -0,0 +1,191 @@
+package slack
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+type fakeSource struct{ cfg *Config }
+
+func (f fakeSource) GetConfig(_ context.Context, key string) (*model.Config, error) {
+	if f.cfg == nil || key != ConfigKey {
+		return nil, sql.ErrNoRows
+	}
+	v, _ := json.Marshal(f.cfg)
+	return &model.Config{Key: key, Value: v}, nil
+}
+
+// fakeSlack records chat.postMessage calls.
+type fakeSlack struct {
+	mu   sync.Mutex
+	msgs []map[string]any
+	auth []string
+}
+
+func (f *fakeSlack) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var msg map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&msg)
+	f.mu.Lock()
+	f.msgs = append(f.msgs, msg)
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	f.mu.Unlock()
+	_, _ = w.Write([]byte(`{"ok":true}`))
+}
+
+func newTestBridge(t *testing.T, cfg *Config) (*Bridge, *fakeSlack) {
+	t.Helper()
+	fs := &fakeSlack{}
+	srv := httptest.NewServer(fs)
+	t.Cleanup(srv.Close)
+	b := NewBridge(&events.NoopPublisher{}, fakeSource{cfg}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	b.APIURL = srv.URL
+	return b, fs
+}
+
+func TestBridgePostsDecision(t *testing.T) {
+	b, fs := newTestBridge(t, &Config{BotToken: "xoxb-1", Channel: "C1"})
+
+	_ = b.Publish(context.Background(), events.TopicBeadCreated, events.BeadCreated{Bead: &model.Bead{
+		ID: "bd-1", Type: "decision", Title: "Ship?", Fields: json.RawMessage(`{"options":["yes","no"]}`),
+	}})
+	// Non-decision beads are ignored.
+	_ = b.Publish(context.Background(), events.TopicBeadCreated, events.BeadCreated{Bead: &model.Bead{ID: "bd-2", Type: "task"}})
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fs.msgs) != 1 {
+		t.Fatalf("got %d messages, want 1", len(fs.msgs))
+	}
+	if fs.auth[0] != "Bearer xoxb-1" {
+		t.Errorf("Authorization = %q", fs.auth[0])
+	}
+	msg := fs.msgs[0]
+	if msg["channel"] != "C1" {
+		t.Errorf("channel = %v", msg["channel"])
+	}
+	blocks := msg["blocks"].([]any)
+	actions := blocks[1].(map[string]any)
+	if actions["block_id"] != "decision:bd-1" || len(actions["elements"].([]any)) != 2 {
+		t.Errorf("unexpected actions block: %v", actions)
+	}
+}
+
+func TestBridgePostsDecisionContext(t *testing.T) {
+	b, fs := newTestBridge(t, &Config{BotToken: "xoxb-1", Channel: "C1"})
+
+	_ = b.Publish(context.Background(), events.TopicBeadCreated, events.BeadCreated{Bead: &model.Bead{
+		ID: "bd-1", Type: "decision", Title: "Merge?", Fields: json.RawMessage(`{
+			"options": ["yes", "no"],
+			"context_beads": ["bd-7"],
+			"diff": "-old\n+new",
+			"links": ["https://ci.example.com/run/1"]
+		}`),
+	}})
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	blocks := fs.msgs[0]["blocks"].([]any)
+	if len(blocks) != 3 {
+		t.Fatalf("got %d blocks, want 3", len(blocks))
+	}
+	text := blocks[0].(map[string]any)["text"].(map[string]any)["text"].(string)
+	if !strings.Contains(text, "`bd-7`") || !strings.Contains(text, "<https://ci.example.com/run/1>") {
+		t.Errorf("summary missing context: %q", text)
+	}
+	diff := blocks[1].(map[string]any)["text"].(map[string]any)["text"]
+	if diff != "```-old\n+new```" {
+		t.Errorf("diff block = %q", diff)
+	}
+}
+
+func TestBridgeNotifiesMentions(t *testing.T) {
+	b, fs := newTestBridge(t, &Config{BotToken: "x", Users: map[string]string{"alice": "U1"}})
+
+	_ = b.Publish(context.Background(), events.TopicCommentAdded, events.CommentAdded{Comment: &model.Comment{
+		BeadID: "bd-1", Author: "bob", Text: "@alice and @carol please look",
+	}})
+	_ = b.Close()
+
+	if len(fs.msgs) != 1 || fs.msgs[0]["channel"] != "U1" {
+		t.Fatalf("unexpected messages: %v", fs.msgs)
+	}
+}
+
+func TestBridgeUnconfigured(t *testing.T) {
+	b, fs := newTestBridge(t, nil)
+	_ = b.Publish(context.Background(), events.TopicBeadCreated, events.BeadCreated{Bead: &model.Bead{ID: "bd-1", Type: "decision"}})
+	_ = b.Close()
+	if len(fs.msgs) != 0 {
+		t.Fatalf("expected no messages, got %d", len(fs.msgs))
+	}
+}
+
+func TestMentions(t *testing.T) {
+	got := Mentions("@alice hi @bob.smith, ping @alice; mail me@example.com")
+	want := []string{"alice", "bob.smith"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Mentions = %v, want %v", got, want)
+	}
+}
+
+func TestActorFor(t *testing.T) {
+	cfg := &Config{Users: map[string]string{"alice": "U1"}}
+	if got := cfg.ActorFor("U1"); got != "alice" {
+		t.Errorf("ActorFor(U1) = %q", got)
+	}
+	if got := cfg.ActorFor("U9"); got != "slack:U9" {
+		t.Errorf("ActorFor(U9) = %q", got)
+	}
+}
+
+func TestVerifyRequest(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte("payload=x")
+	ts := strconv.FormatInt(now.Unix(), 10)
+
+	h := http.Header{}
+	h.Set("X-Slack-Request-Timestamp", ts)
+	h.Set("X-Slack-Signature", Sign("secret", ts, body))
+
+	if err := VerifyRequest("secret", h, body, now); err != nil {
+		t.Errorf("valid signature rejected: %v", err)
+	}
+	if err := VerifyRequest("other", h, body, now); err == nil {
+		t.Error("wrong secret accepted")
+	}
+	if err := VerifyRequest("secret", h, body, now.Add(10*time.Minute)); err == nil {
+		t.Error("stale timestamp accepted")
+	}
+}
+
+func TestParseInteraction(t *testing.T) {
+	payload := `{"type":"block_actions","user":{"id":"U1"},"actions":[{"block_id":"decision:bd-1","value":"yes"}]}`
+	in, err := ParseInteraction([]byte(url.Values{"payload": {payload}}.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *in != (Interaction{BeadID: "bd-1", Option: "yes", UserID: "U1"}) {
+		t.Errorf("got %+v", in)
+	}
+
+	if _, err := ParseInteraction([]byte(url.Values{"payload": {`{"type":"view_submission"}`}}.Encode())); err == nil {
+		t.Error("expected error for unsupported type")
+	}
+}
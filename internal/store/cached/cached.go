@@ -0,0 +1,334 @@
+// Package cached wraps a store.Store with a short-lived in-memory cache of
+// bead reads, for dashboards that poll the same beads and listings.
+//
+// Only GetBead and ListBeads are cached; every other method goes straight to
+// the inner store. The whole cache is dropped after any bead write made
+// through the wrapper, after a committed transaction, and on any "beads."
+// event passing through Publisher, so this server always reads its own
+// writes. Writes made by other replicas are seen once the TTL expires.
+package cached
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// Store caches GetBead for BeadTTL and ListBeads for ListTTL; a zero TTL
+// disables caching of that read.
+type Store struct {
+	store.Store
+
+	beadTTL time.Duration
+	listTTL time.Duration
+	now     func() time.Time
+
+	mu    sync.Mutex
+	gen   uint64 // bumped by Invalidate; a read that spans a bump is not cached
+	beads map[string]entry[*model.Bead]
+	lists map[string]entry[listResult]
+
+	stats map[string]*counters // by op; fixed at construction
+}
+
+type entry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+type listResult struct {
+	beads []*model.Bead
+	total int
+}
+
+type counters struct {
+	hits, misses atomic.Int64
+}
+
+// Compile-time check that Store implements store.Store.
+var _ store.Store = (*Store)(nil)
+
+// New returns a caching wrapper around inner.
+func New(inner store.Store, beadTTL, listTTL time.Duration) *Store {
+	return &Store{
+		Store:   inner,
+		beadTTL: beadTTL,
+		listTTL: listTTL,
+		now:     time.Now,
+		beads:   map[string]entry[*model.Bead]{},
+		lists:   map[string]entry[listResult]{},
+		stats:   map[string]*counters{"get_bead": {}, "list_beads": {}},
+	}
+}
+
+// Invalidate drops every cached read.
+func (s *Store) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gen++
+	clear(s.beads)
+	clear(s.lists)
+}
+
+// GetBead returns the bead from the cache, reading through on a miss.
+func (s *Store) GetBead(ctx context.Context, id string) (*model.Bead, error) {
+	if s.beadTTL <= 0 {
+		return s.Store.GetBead(ctx, id)
+	}
+	s.mu.Lock()
+	e, ok := s.beads[id]
+	gen := s.gen
+	s.mu.Unlock()
+	if ok && s.now().Before(e.expires) {
+		s.stats["get_bead"].hits.Add(1)
+		return cloneBead(e.value), nil
+	}
+	s.stats["get_bead"].misses.Add(1)
+
+	bead, err := s.Store.GetBead(ctx, id)
+	if err != nil || bead == nil {
+		return bead, err
+	}
+	s.mu.Lock()
+	if s.gen == gen {
+		s.beads[id] = entry[*model.Bead]{value: cloneBead(bead), expires: s.now().Add(s.beadTTL)}
+	}
+	s.mu.Unlock()
+	return bead, nil
+}
+
+// ListBeads returns the listing from the cache, reading through on a miss.
+// Filters are keyed by their JSON encoding.
+func (s *Store) ListBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	if s.listTTL <= 0 {
+		return s.Store.ListBeads(ctx, filter)
+	}
+	raw, err := json.Marshal(filter)
+	if err != nil {
+		return s.Store.ListBeads(ctx, filter)
+	}
+	key := string(raw)
+	s.mu.Lock()
+	e, ok := s.lists[key]
+	gen := s.gen
+	s.mu.Unlock()
+	if ok && s.now().Before(e.expires) {
+		s.stats["list_beads"].hits.Add(1)
+		return cloneBeads(e.value.beads), e.value.total, nil
+	}
+	s.stats["list_beads"].misses.Add(1)
+
+	beads, total, err := s.Store.ListBeads(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.mu.Lock()
+	if s.gen == gen {
+		s.lists[key] = entry[listResult]{
+			value:   listResult{beads: cloneBeads(beads), total: total},
+			expires: s.now().Add(s.listTTL),
+		}
+	}
+	s.mu.Unlock()
+	return beads, total, nil
+}
+
+// RunInTransaction runs fn against the inner store's transaction and drops
+// the cache once it commits, or when it failed with store.ErrConflict so the
+// caller's retry reads the row that won. Reads inside fn are never cached.
+func (s *Store) RunInTransaction(ctx context.Context, fn func(tx store.Store) error) error {
+	err := s.Store.RunInTransaction(ctx, fn)
+	if err == nil || errors.Is(err, store.ErrConflict) {
+		s.Invalidate()
+	}
+	return err
+}
+
+// invalidated drops the cache after a successful or conflicting write.
+func (s *Store) invalidated(err error) error {
+	if err == nil || errors.Is(err, store.ErrConflict) {
+		s.Invalidate()
+	}
+	return err
+}
+
+func (s *Store) CreateBead(ctx context.Context, bead *model.Bead) error {
+	return s.invalidated(s.Store.CreateBead(ctx, bead))
+}
+
+func (s *Store) ClaimReadyBead(ctx context.Context, actor string, labels []string) (*model.Bead, error) {
+	bead, err := s.Store.ClaimReadyBead(ctx, actor, labels)
+	return bead, s.invalidated(err)
+}
+
+func (s *Store) UpdateBead(ctx context.Context, bead *model.Bead) error {
+	return s.invalidated(s.Store.UpdateBead(ctx, bead))
+}
+
+func (s *Store) CloseBead(ctx context.Context, id string, closedBy string) (*model.Bead, error) {
+	bead, err := s.Store.CloseBead(ctx, id, closedBy)
+	return bead, s.invalidated(err)
+}
+
+func (s *Store) DeleteBead(ctx context.Context, id string) error {
+	return s.invalidated(s.Store.DeleteBead(ctx, id))
+}
+
+func (s *Store) SoftDeleteBead(ctx context.Context, id, deletedBy string) error {
+	return s.invalidated(s.Store.SoftDeleteBead(ctx, id, deletedBy))
+}
+
+func (s *Store) RestoreBead(ctx context.Context, id string) (*model.Bead, error) {
+	bead, err := s.Store.RestoreBead(ctx, id)
+	return bead, s.invalidated(err)
+}
+
+func (s *Store) PurgeDeletedBeads(ctx context.Context, before time.Time) ([]string, error) {
+	ids, err := s.Store.PurgeDeletedBeads(ctx, before)
+	return ids, s.invalidated(err)
+}
+
+func (s *Store) ArchiveClosedBeads(ctx context.Context, closedBefore time.Time) ([]string, error) {
+	ids, err := s.Store.ArchiveClosedBeads(ctx, closedBefore)
+	return ids, s.invalidated(err)
+}
+
+func (s *Store) MergeBead(ctx context.Context, sourceID, targetID string) error {
+	return s.invalidated(s.Store.MergeBead(ctx, sourceID, targetID))
+}
+
+func (s *Store) AddDependency(ctx context.Context, dep *model.Dependency) error {
+	return s.invalidated(s.Store.AddDependency(ctx, dep))
+}
+
+func (s *Store) RemoveDependency(ctx context.Context, beadID, dependsOnID string, depType model.DependencyType) error {
+	return s.invalidated(s.Store.RemoveDependency(ctx, beadID, dependsOnID, depType))
+}
+
+func (s *Store) UpdateDependencyMetadata(ctx context.Context, dep *model.Dependency) error {
+	return s.invalidated(s.Store.UpdateDependencyMetadata(ctx, dep))
+}
+
+func (s *Store) AddLabel(ctx context.Context, beadID string, label string) error {
+	return s.invalidated(s.Store.AddLabel(ctx, beadID, label))
+}
+
+func (s *Store) RemoveLabel(ctx context.Context, beadID string, label string) error {
+	return s.invalidated(s.Store.RemoveLabel(ctx, beadID, label))
+}
+
+func (s *Store) AddComment(ctx context.Context, comment *model.Comment) error {
+	return s.invalidated(s.Store.AddComment(ctx, comment))
+}
+
+func (s *Store) AppendNote(ctx context.Context, note *model.Note) error {
+	return s.invalidated(s.Store.AppendNote(ctx, note))
+}
+
+func (s *Store) AppendDescription(ctx context.Context, id, text string) (string, error) {
+	desc, err := s.Store.AppendDescription(ctx, id, text)
+	return desc, s.invalidated(err)
+}
+
+// WriteText appends hit and miss counters in the Prometheus text format. It
+// is safe to call on a nil *Store.
+func (s *Store) WriteText(w io.Writer) {
+	if s == nil {
+		return
+	}
+	ops := make([]string, 0, len(s.stats))
+	for op := range s.stats {
+		ops = append(ops, op)
+	}
+	slices.Sort(ops)
+
+	fmt.Fprintln(w, "# HELP beads_cache_hits_total Bead reads served from the cache.")
+	fmt.Fprintln(w, "# TYPE beads_cache_hits_total counter")
+	for _, op := range ops {
+		fmt.Fprintf(w, "beads_cache_hits_total{op=%q} %d\n", op, s.stats[op].hits.Load())
+	}
+	fmt.Fprintln(w, "# HELP beads_cache_misses_total Bead reads that went to the store.")
+	fmt.Fprintln(w, "# TYPE beads_cache_misses_total counter")
+	for _, op := range ops {
+		fmt.Fprintf(w, "beads_cache_misses_total{op=%q} %d\n", op, s.stats[op].misses.Load())
+	}
+}
+
+// Publisher forwards events to inner and drops the cache on bead events.
+type Publisher struct {
+	inner events.Publisher
+	cache *Store
+}
+
+// NewPublisher returns a publisher that invalidates cache before forwarding
+// each "beads." event to inner.
+func NewPublisher(inner events.Publisher, cache *Store) *Publisher {
+	return &Publisher{inner: inner, cache: cache}
+}
+
+// Publish invalidates the cache and forwards the event.
+func (p *Publisher) Publish(ctx context.Context, topic string, event any) error {
+	if strings.HasPrefix(topic, "beads.") {
+		p.cache.Invalidate()
+	}
+	return p.inner.Publish(ctx, topic, event)
+}
+
+// Close closes the inner publisher.
+func (p *Publisher) Close() error {
+	return p.inner.Close()
+}
+
+// cloneBead copies b deeply enough that callers may modify the copy without
+// touching the cached value.
+func cloneBead(b *model.Bead) *model.Bead {
+	c := *b
+	c.Fields = slices.Clone(b.Fields)
+	c.Labels = slices.Clone(b.Labels)
+	c.PriorityChain = slices.Clone(b.PriorityChain)
+	if b.Dependencies != nil {
+		c.Dependencies = make([]*model.Dependency, len(b.Dependencies))
+		for i, d := range b.Dependencies {
+			dc := *d
+			dc.Metadata = slices.Clone(d.Metadata)
+			c.Dependencies[i] = &dc
+		}
+	}
+	if b.Comments != nil {
+		c.Comments = make([]*model.Comment, len(b.Comments))
+		for i, cm := range b.Comments {
+			cc := *cm
+			c.Comments[i] = &cc
+		}
+	}
+	if b.Checklist != nil {
+		c.Checklist = make([]*model.ChecklistItem, len(b.Checklist))
+		for i, it := range b.Checklist {
+			ic := *it
+			c.Checklist[i] = &ic
+		}
+	}
+	return &c
+}
+
+func cloneBeads(beads []*model.Bead) []*model.Bead {
+	if beads == nil {
+		return nil
+	}
+	out := make([]*model.Bead, len(beads))
+	for i, b := range beads {
+		out[i] = cloneBead(b)
+	}
+	return out
+}
@@ -0,0 +1,271 @@
+package cached
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// fakeStore counts reads and serves a fixed set of beads.
+type fakeStore struct {
+	store.Store // unimplemented methods panic
+	beads       map[string]*model.Bead
+	gets, lists int
+
+	// onRead, when set, runs after a read has loaded its result and before
+	// it returns, to interleave a write with it.
+	onRead func()
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{beads: map[string]*model.Bead{
+		"bd-1": {ID: "bd-1", Title: "first", Labels: []string{"a"}},
+	}}
+}
+
+func (f *fakeStore) GetBead(_ context.Context, id string) (*model.Bead, error) {
+	f.gets++
+	b, ok := f.beads[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	c := *b
+	if f.onRead != nil {
+		f.onRead()
+	}
+	return &c, nil
+}
+
+func (f *fakeStore) ListBeads(_ context.Context, _ model.BeadFilter) ([]*model.Bead, int, error) {
+	f.lists++
+	var out []*model.Bead
+	for _, b := range f.beads {
+		c := *b
+		out = append(out, &c)
+	}
+	if f.onRead != nil {
+		f.onRead()
+	}
+	return out, len(out), nil
+}
+
+func (f *fakeStore) UpdateBead(_ context.Context, b *model.Bead) error {
+	f.beads[b.ID] = b
+	return nil
+}
+
+func (f *fakeStore) RunInTransaction(_ context.Context, fn func(tx store.Store) error) error {
+	return fn(f)
+}
+
+func TestGetBead_CachesUntilTTL(t *testing.T) {
+	inner := newFakeStore()
+	s := New(inner, time.Minute, 0)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for range 3 {
+		if _, err := s.GetBead(ctx, "bd-1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if inner.gets != 1 {
+		t.Errorf("inner gets = %d, want 1", inner.gets)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := s.GetBead(ctx, "bd-1"); err != nil {
+		t.Fatal(err)
+	}
+	if inner.gets != 2 {
+		t.Errorf("inner gets after expiry = %d, want 2", inner.gets)
+	}
+}
+
+func TestGetBead_ErrorsNotCached(t *testing.T) {
+	inner := newFakeStore()
+	s := New(inner, time.Minute, 0)
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := s.GetBead(ctx, "bd-missing"); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+	if inner.gets != 2 {
+		t.Errorf("inner gets = %d, want 2", inner.gets)
+	}
+}
+
+func TestGetBead_ReturnsCopies(t *testing.T) {
+	s := New(newFakeStore(), time.Minute, 0)
+	ctx := context.Background()
+
+	b, _ := s.GetBead(ctx, "bd-1")
+	b.Title = "mutated"
+	b.Labels[0] = "mutated"
+
+	again, _ := s.GetBead(ctx, "bd-1")
+	if again.Title != "first" || again.Labels[0] != "a" {
+		t.Errorf("cached bead changed through a returned copy: %+v", again)
+	}
+}
+
+func TestListBeads_KeyedByFilter(t *testing.T) {
+	inner := newFakeStore()
+	s := New(inner, 0, time.Minute)
+	ctx := context.Background()
+
+	open := model.BeadFilter{Status: []model.Status{model.StatusOpen}}
+	closed := model.BeadFilter{Status: []model.Status{model.StatusClosed}}
+	s.ListBeads(ctx, open)
+	s.ListBeads(ctx, open)
+	s.ListBeads(ctx, closed)
+	if inner.lists != 2 {
+		t.Errorf("inner lists = %d, want 2", inner.lists)
+	}
+}
+
+func TestZeroTTL_PassesThrough(t *testing.T) {
+	inner := newFakeStore()
+	s := New(inner, 0, 0)
+	ctx := context.Background()
+
+	s.GetBead(ctx, "bd-1")
+	s.GetBead(ctx, "bd-1")
+	s.ListBeads(ctx, model.BeadFilter{})
+	s.ListBeads(ctx, model.BeadFilter{})
+	if inner.gets != 2 || inner.lists != 2 {
+		t.Errorf("inner gets, lists = %d, %d; want 2, 2", inner.gets, inner.lists)
+	}
+}
+
+func TestWrites_Invalidate(t *testing.T) {
+	inner := newFakeStore()
+	s := New(inner, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	s.GetBead(ctx, "bd-1")
+	if err := s.UpdateBead(ctx, &model.Bead{ID: "bd-1", Title: "renamed"}); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := s.GetBead(ctx, "bd-1")
+	if b.Title != "renamed" {
+		t.Errorf("title after update = %q, want renamed", b.Title)
+	}
+
+	s.ListBeads(ctx, model.BeadFilter{})
+	err := s.RunInTransaction(ctx, func(tx store.Store) error {
+		return tx.UpdateBead(ctx, &model.Bead{ID: "bd-1", Title: "again"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.ListBeads(ctx, model.BeadFilter{})
+	if inner.lists != 2 {
+		t.Errorf("inner lists = %d, want 2 (transaction should invalidate)", inner.lists)
+	}
+}
+
+func TestConflict_Invalidates(t *testing.T) {
+	inner := newFakeStore()
+	s := New(inner, time.Minute, 0)
+	ctx := context.Background()
+
+	s.GetBead(ctx, "bd-1")
+	inner.beads["bd-1"] = &model.Bead{ID: "bd-1", Title: "elsewhere"}
+	err := s.RunInTransaction(ctx, func(tx store.Store) error {
+		return store.ErrConflict
+	})
+	if !errors.Is(err, store.ErrConflict) {
+		t.Fatalf("err = %v, want ErrConflict", err)
+	}
+	b, _ := s.GetBead(ctx, "bd-1")
+	if b.Title != "elsewhere" {
+		t.Errorf("title after conflict = %q, want elsewhere (conflict should invalidate)", b.Title)
+	}
+}
+
+func TestReadRacingWrite_NotCached(t *testing.T) {
+	inner := newFakeStore()
+	s := New(inner, time.Minute, time.Minute)
+	ctx := context.Background()
+
+	// The write commits and invalidates after the read loaded the old row
+	// but before the read stores it.
+	inner.onRead = func() {
+		inner.onRead = nil
+		if err := s.UpdateBead(ctx, &model.Bead{ID: "bd-1", Title: "renamed"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if b, _ := s.GetBead(ctx, "bd-1"); b.Title != "first" {
+		t.Fatalf("racing read = %q, want first", b.Title)
+	}
+	if b, _ := s.GetBead(ctx, "bd-1"); b.Title != "renamed" {
+		t.Errorf("title after racing write = %q, want renamed (stale read was cached)", b.Title)
+	}
+
+	inner.onRead = func() {
+		inner.onRead = nil
+		s.UpdateBead(ctx, &model.Bead{ID: "bd-1", Title: "again"})
+	}
+	s.ListBeads(ctx, model.BeadFilter{})
+	beads, _, _ := s.ListBeads(ctx, model.BeadFilter{})
+	if len(beads) != 1 || beads[0].Title != "again" {
+		t.Errorf("listing after racing write = %+v (stale listing was cached)", beads)
+	}
+}
+
+func TestPublisher_InvalidatesOnBeadEvents(t *testing.T) {
+	inner := newFakeStore()
+	s := New(inner, time.Minute, 0)
+	p := NewPublisher(&events.NoopPublisher{}, s)
+	ctx := context.Background()
+
+	s.GetBead(ctx, "bd-1")
+	p.Publish(ctx, "other.topic", nil)
+	s.GetBead(ctx, "bd-1")
+	if inner.gets != 1 {
+		t.Errorf("inner gets after unrelated event = %d, want 1", inner.gets)
+	}
+
+	p.Publish(ctx, "beads.bead.updated", nil)
+	s.GetBead(ctx, "bd-1")
+	if inner.gets != 2 {
+		t.Errorf("inner gets after bead event = %d, want 2", inner.gets)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	s := New(newFakeStore(), time.Minute, time.Minute)
+	ctx := context.Background()
+	s.GetBead(ctx, "bd-1")
+	s.GetBead(ctx, "bd-1")
+	s.ListBeads(ctx, model.BeadFilter{})
+
+	var buf bytes.Buffer
+	s.WriteText(&buf)
+	out := buf.String()
+	for _, want := range []string{
+		`beads_cache_hits_total{op="get_bead"} 1`,
+		`beads_cache_misses_total{op="get_bead"} 1`,
+		`beads_cache_hits_total{op="list_beads"} 0`,
+		`beads_cache_misses_total{op="list_beads"} 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	var nilStore *Store
+	nilStore.WriteText(&buf) // must not panic
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_beads_deleted_at;
+ALTER TABLE beads DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE beads DROP COLUMN IF EXISTS deleted_at;
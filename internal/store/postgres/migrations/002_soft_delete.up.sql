@@ -0,0 +1,4 @@
+ALTER TABLE beads ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE beads ADD COLUMN IF NOT EXISTS deleted_by TEXT DEFAULT '';
+
+CREATE INDEX idx_beads_deleted_at ON beads(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_beads_title_trgm;
@@ -0,0 +1,3 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_beads_title_trgm ON beads USING gin (title gin_trgm_ops);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS agents (
+    name TEXT PRIMARY KEY,
+    bead_id TEXT NOT NULL REFERENCES beads(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS watchers (
+    bead_id TEXT NOT NULL REFERENCES beads(id) ON DELETE CASCADE,
+    actor TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (bead_id, actor)
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    actor TEXT NOT NULL,
+    event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_notifications_actor_unread ON notifications(actor, id) WHERE read_at IS NULL;
+CREATE INDEX idx_notifications_actor ON notifications(actor, id);
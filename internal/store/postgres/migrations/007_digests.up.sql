@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS digests (
+    id BIGSERIAL PRIMARY KEY,
+    subscription TEXT NOT NULL,
+    generated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    bead_ids JSONB NOT NULL DEFAULT '[]',
+    new_ids JSONB NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX idx_digests_subscription ON digests(subscription, generated_at DESC);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS config_revisions;
+ALTER TABLE configs DROP COLUMN IF EXISTS rev;
@@ -0,0 +1,15 @@
+ALTER TABLE configs ADD COLUMN IF NOT EXISTS rev BIGINT NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS config_revisions (
+    key TEXT NOT NULL,
+    rev BIGINT NOT NULL,
+    value JSONB,
+    actor TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (key, rev)
+);
+
+-- Seed history with the current value of every existing config.
+INSERT INTO config_revisions (key, rev, value, created_at)
+SELECT key, rev, value, updated_at FROM configs
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_events_unpublished;
+ALTER TABLE events DROP COLUMN IF EXISTS published_at;
@@ -0,0 +1,8 @@
+-- The events table doubles as the outbox: rows are written in the mutation's
+-- transaction and published by the dispatcher, which sets published_at.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ;
+
+-- Events recorded before the outbox were published when they were written.
+UPDATE events SET published_at = created_at WHERE published_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_unpublished ON events (id) WHERE published_at IS NULL;
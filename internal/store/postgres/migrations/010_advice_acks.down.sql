@@ -0,0 +1 @@
+DROP TABLE IF EXISTS advice_acks;
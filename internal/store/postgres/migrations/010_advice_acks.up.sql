@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS advice_acks (
+    bead_id TEXT NOT NULL REFERENCES beads(id) ON DELETE CASCADE,
+    actor TEXT NOT NULL,
+    acked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (bead_id, actor)
+);
+
+CREATE INDEX idx_advice_acks_actor ON advice_acks(actor);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_comments_author;
+DROP INDEX IF EXISTS idx_events_actor;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_events_actor ON events (actor, id);
+CREATE INDEX IF NOT EXISTS idx_comments_author ON comments (author, id);
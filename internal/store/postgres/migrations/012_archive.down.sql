@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_beads_archived_at;
+ALTER TABLE beads DROP COLUMN IF EXISTS archived_at;
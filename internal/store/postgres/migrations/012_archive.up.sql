@@ -0,0 +1,3 @@
+ALTER TABLE beads ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_beads_archived_at ON beads(archived_at) WHERE archived_at IS NOT NULL;
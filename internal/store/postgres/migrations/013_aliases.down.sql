@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bead_aliases;
+DROP INDEX IF EXISTS idx_beads_slug;
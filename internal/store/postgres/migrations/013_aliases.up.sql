@@ -0,0 +1,10 @@
+CREATE INDEX IF NOT EXISTS idx_beads_slug ON beads(slug) WHERE slug IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS bead_aliases (
+    alias TEXT PRIMARY KEY,
+    bead_id TEXT NOT NULL REFERENCES beads(id) ON DELETE CASCADE,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bead_aliases_bead_id ON bead_aliases(bead_id);
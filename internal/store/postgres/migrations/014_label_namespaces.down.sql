@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_labels_namespace;
+ALTER TABLE labels DROP COLUMN IF EXISTS value, DROP COLUMN IF EXISTS namespace;
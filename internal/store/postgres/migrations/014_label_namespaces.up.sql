@@ -0,0 +1,9 @@
+ALTER TABLE labels
+    ADD COLUMN IF NOT EXISTS namespace TEXT NOT NULL GENERATED ALWAYS AS (
+        CASE WHEN strpos(label, ':') > 1 THEN split_part(label, ':', 1) ELSE '' END
+    ) STORED,
+    ADD COLUMN IF NOT EXISTS value TEXT NOT NULL GENERATED ALWAYS AS (
+        CASE WHEN strpos(label, ':') > 1 THEN substr(label, strpos(label, ':') + 1) ELSE label END
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_labels_namespace ON labels(namespace, value);
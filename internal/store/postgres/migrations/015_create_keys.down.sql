@@ -0,0 +1 @@
+DROP TABLE IF EXISTS create_keys;
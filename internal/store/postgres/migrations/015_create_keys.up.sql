@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS create_keys (
+    key TEXT PRIMARY KEY,
+    bead_id TEXT NOT NULL REFERENCES beads(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
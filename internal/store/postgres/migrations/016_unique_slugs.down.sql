@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_beads_slug;
+CREATE INDEX IF NOT EXISTS idx_beads_slug ON beads(slug) WHERE slug IS NOT NULL;
@@ -0,0 +1,11 @@
+-- Rename any live beads that already share a slug, keeping it on the oldest.
+UPDATE beads b SET slug = b.slug || '-' || b.id
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY created_at, id) AS n
+    FROM beads
+    WHERE slug IS NOT NULL AND deleted_at IS NULL
+) d
+WHERE b.id = d.id AND d.n > 1;
+
+DROP INDEX IF EXISTS idx_beads_slug;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_beads_slug ON beads(slug) WHERE slug IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS mirrored_beads (
+    remote TEXT NOT NULL,
+    id TEXT NOT NULL,
+    bead JSONB NOT NULL,
+    synced_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (remote, id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS actor_aliases;
+DROP TABLE IF EXISTS actors;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS actors (
+    id TEXT PRIMARY KEY,
+    display_name TEXT NOT NULL DEFAULT '',
+    type TEXT NOT NULL DEFAULT 'human' CHECK (type IN ('human', 'agent')),
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_actors_lower_id ON actors (lower(id));
+
+-- Aliases are stored lowercase.
+CREATE TABLE IF NOT EXISTS actor_aliases (
+    alias TEXT PRIMARY KEY,
+    actor_id TEXT NOT NULL REFERENCES actors(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_actor_aliases_actor_id ON actor_aliases (actor_id);
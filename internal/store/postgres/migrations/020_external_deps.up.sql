@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS external_deps (
+    id BIGSERIAL PRIMARY KEY,
+    bead_id TEXT NOT NULL REFERENCES beads(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'satisfied')),
+    probe TEXT NOT NULL DEFAULT '',
+    checked_at TIMESTAMPTZ,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_deps_bead_id ON external_deps (bead_id);
+CREATE INDEX IF NOT EXISTS idx_external_deps_waiting ON external_deps (bead_id) WHERE status = 'waiting';
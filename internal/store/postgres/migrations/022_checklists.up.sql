@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS checklist_items (
+    bead_id TEXT NOT NULL REFERENCES beads(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    text TEXT NOT NULL,
+    checked BOOLEAN NOT NULL DEFAULT FALSE,
+    checked_at TIMESTAMPTZ,
+    checked_by TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (bead_id, position)
+);
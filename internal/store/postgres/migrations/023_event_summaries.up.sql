@@ -0,0 +1,15 @@
+-- Event compaction replaces raw events past their retention with one row per
+-- topic, bead and UTC day counting them.
+CREATE TABLE IF NOT EXISTS event_summaries (
+    topic    TEXT NOT NULL,
+    bead_id  TEXT NOT NULL,
+    day      DATE NOT NULL,
+    count    BIGINT NOT NULL,
+    actors   TEXT[] NOT NULL DEFAULT '{}',
+    first_at TIMESTAMPTZ NOT NULL,
+    last_at  TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (topic, bead_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_summaries_bead_id ON event_summaries (bead_id);
+CREATE INDEX IF NOT EXISTS idx_events_created_at ON events (created_at);
@@ -0,0 +1 @@
+ALTER TABLE comments DROP COLUMN IF EXISTS claimed_author, DROP COLUMN IF EXISTS verified;
@@ -0,0 +1,3 @@
+ALTER TABLE comments
+    ADD COLUMN IF NOT EXISTS claimed_author TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS verified BOOLEAN NOT NULL DEFAULT FALSE;
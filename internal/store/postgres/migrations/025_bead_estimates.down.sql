@@ -0,0 +1 @@
+ALTER TABLE beads DROP COLUMN IF EXISTS estimate, DROP COLUMN IF EXISTS actual;
@@ -0,0 +1,3 @@
+ALTER TABLE beads
+    ADD COLUMN IF NOT EXISTS estimate TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS actual TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS bead_locks (
+    bead_id TEXT PRIMARY KEY REFERENCES beads(id) ON DELETE CASCADE,
+    owner TEXT NOT NULL,
+    acquired_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
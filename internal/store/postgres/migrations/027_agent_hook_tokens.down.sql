@@ -0,0 +1 @@
+ALTER TABLE agents DROP COLUMN IF EXISTS hook_token_hash;
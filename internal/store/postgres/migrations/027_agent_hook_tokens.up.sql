@@ -0,0 +1 @@
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS hook_token_hash TEXT UNIQUE;
@@ -22,7 +22,7 @@ var migrationsFS embed.FS
 
 // PostgresStore implements store.Store backed by a PostgreSQL database.
 type PostgresStore struct {
-	db *sql.DB
+	db tracedDB
 }
 
 // Compile-time check that PostgresStore implements store.Store.
@@ -50,7 +50,7 @@ func New(databaseURL string) (*PostgresStore, error) {
 		return nil, fmt.Errorf("run migrations: %w", err)
 	}
 
-	return &PostgresStore{db: db}, nil
+	return &PostgresStore{db: tracedDB{db}}, nil
 }
 
 func runMigrations(db *sql.DB) error {
@@ -93,6 +93,22 @@ func (s *PostgresStore) ListBeads(ctx context.Context, filter model.BeadFilter)
 	return queryListBeads(ctx, s.db, filter)
 }
 
+func (s *PostgresStore) ListReadyBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	return queryListReadyBeads(ctx, s.db, filter)
+}
+
+func (s *PostgresStore) ListBlockedBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	return queryListBlockedBeads(ctx, s.db, filter)
+}
+
+func (s *PostgresStore) ClaimReadyBead(ctx context.Context, actor string, labels []string) (*model.Bead, error) {
+	return queryClaimReadyBead(ctx, s.db, actor, labels)
+}
+
+func (s *PostgresStore) StreamBeads(ctx context.Context, filter model.BeadFilter, fn func(*model.Bead) error) error {
+	return queryStreamBeads(ctx, s.db, filter, fn)
+}
+
 func (s *PostgresStore) UpdateBead(ctx context.Context, bead *model.Bead) error {
 	return queryUpdateBead(ctx, s.db, bead)
 }
@@ -117,6 +133,42 @@ func (s *PostgresStore) GetDependencies(ctx context.Context, beadID string) ([]*
 	return queryGetDependencies(ctx, s.db, beadID)
 }
 
+func (s *PostgresStore) SoftDeleteBead(ctx context.Context, id, deletedBy string) error {
+	return querySoftDeleteBead(ctx, s.db, id, deletedBy)
+}
+
+func (s *PostgresStore) RestoreBead(ctx context.Context, id string) (*model.Bead, error) {
+	return queryRestoreBead(ctx, s.db, id)
+}
+
+func (s *PostgresStore) ListDeletedBeads(ctx context.Context) ([]*model.Bead, error) {
+	return queryListDeletedBeads(ctx, s.db)
+}
+
+func (s *PostgresStore) PurgeDeletedBeads(ctx context.Context, before time.Time) ([]string, error) {
+	return queryPurgeDeletedBeads(ctx, s.db, before)
+}
+
+func (s *PostgresStore) ArchiveClosedBeads(ctx context.Context, closedBefore time.Time) ([]string, error) {
+	return queryArchiveClosedBeads(ctx, s.db, closedBefore)
+}
+
+func (s *PostgresStore) MergeBead(ctx context.Context, sourceID, targetID string) error {
+	return queryMergeBead(ctx, s.db, sourceID, targetID)
+}
+
+func (s *PostgresStore) SimilarBeads(ctx context.Context, title, excludeID string, limit int) ([]*model.SimilarBead, error) {
+	return querySimilarBeads(ctx, s.db, title, excludeID, limit)
+}
+
+func (s *PostgresStore) GetDependents(ctx context.Context, beadID string) ([]*model.Dependency, error) {
+	return queryGetDependents(ctx, s.db, beadID)
+}
+
+func (s *PostgresStore) UpdateDependencyMetadata(ctx context.Context, dep *model.Dependency) error {
+	return queryUpdateDependencyMetadata(ctx, s.db, dep)
+}
+
 func (s *PostgresStore) AddLabel(ctx context.Context, beadID string, label string) error {
 	return queryAddLabel(ctx, s.db, beadID, label)
 }
@@ -129,22 +181,202 @@ func (s *PostgresStore) GetLabels(ctx context.Context, beadID string) ([]string,
 	return queryGetLabels(ctx, s.db, beadID)
 }
 
+func (s *PostgresStore) ListLabels(ctx context.Context) ([]*model.LabelCount, error) {
+	return queryListLabels(ctx, s.db)
+}
+
+func (s *PostgresStore) AggregateBeads(ctx context.Context, filter model.BeadFilter, groupBy string) ([]*model.AggregateGroup, error) {
+	return queryAggregateBeads(ctx, s.db, filter, groupBy)
+}
+
+func (s *PostgresStore) RollupBeads(ctx context.Context, filter model.BeadFilter, since time.Time) (*model.Rollup, error) {
+	return queryRollupBeads(ctx, s.db, filter, since)
+}
+
+func (s *PostgresStore) ResolveBeadRef(ctx context.Context, ref string) (string, error) {
+	return queryResolveBeadRef(ctx, s.db, ref)
+}
+
+func (s *PostgresStore) AddAlias(ctx context.Context, alias *model.Alias) error {
+	return queryAddAlias(ctx, s.db, alias)
+}
+
+func (s *PostgresStore) RemoveAlias(ctx context.Context, beadID, alias string) error {
+	return queryRemoveAlias(ctx, s.db, beadID, alias)
+}
+
+func (s *PostgresStore) GetAliases(ctx context.Context, beadID string) ([]*model.Alias, error) {
+	return queryGetAliases(ctx, s.db, beadID)
+}
+
 func (s *PostgresStore) AddComment(ctx context.Context, comment *model.Comment) error {
 	return queryAddComment(ctx, s.db, comment)
 }
 
+func (s *PostgresStore) ImportComment(ctx context.Context, comment *model.Comment) error {
+	return queryImportComment(ctx, s.db, comment)
+}
+
 func (s *PostgresStore) GetComments(ctx context.Context, beadID string) ([]*model.Comment, error) {
 	return queryGetComments(ctx, s.db, beadID)
 }
 
+func (s *PostgresStore) GetComment(ctx context.Context, id int64) (*model.Comment, error) {
+	return queryGetComment(ctx, s.db, id)
+}
+
+func (s *PostgresStore) AddReaction(ctx context.Context, commentID int64, actor, emoji string) error {
+	return queryAddReaction(ctx, s.db, commentID, actor, emoji)
+}
+
+func (s *PostgresStore) RemoveReaction(ctx context.Context, commentID int64, actor, emoji string) error {
+	return queryRemoveReaction(ctx, s.db, commentID, actor, emoji)
+}
+
+func (s *PostgresStore) ResolveComment(ctx context.Context, commentID int64, actor string, resolved bool) error {
+	return queryResolveComment(ctx, s.db, commentID, actor, resolved)
+}
+
+func (s *PostgresStore) AddChecklistItems(ctx context.Context, beadID string, texts []string, actor string) ([]*model.ChecklistItem, error) {
+	return queryAddChecklistItems(ctx, s.db, beadID, texts, actor)
+}
+
+func (s *PostgresStore) GetChecklist(ctx context.Context, beadID string) ([]*model.ChecklistItem, error) {
+	return queryGetChecklist(ctx, s.db, beadID)
+}
+
+func (s *PostgresStore) CheckChecklistItem(ctx context.Context, beadID string, index int, actor string, checked bool) (*model.ChecklistItem, error) {
+	return queryCheckChecklistItem(ctx, s.db, beadID, index, actor, checked)
+}
+
+func (s *PostgresStore) LoadRelations(ctx context.Context, beads []*model.Bead) error {
+	return queryLoadRelations(ctx, s.db, beads)
+}
+
+func (s *PostgresStore) ListCommentsByAuthor(ctx context.Context, author string, limit int) ([]*model.Comment, error) {
+	return queryListCommentsByAuthor(ctx, s.db, author, limit)
+}
+
+func (s *PostgresStore) RecordCreateKey(ctx context.Context, key, beadID string) (string, error) {
+	return queryRecordCreateKey(ctx, s.db, key, beadID)
+}
+
+func (s *PostgresStore) AppendNote(ctx context.Context, note *model.Note) error {
+	return queryAppendNote(ctx, s.db, note)
+}
+
+func (s *PostgresStore) GetNotes(ctx context.Context, beadID string) ([]*model.Note, error) {
+	return queryGetNotes(ctx, s.db, beadID)
+}
+
+func (s *PostgresStore) AppendDescription(ctx context.Context, id, text string) (string, error) {
+	return queryAppendDescription(ctx, s.db, id, text)
+}
+
 func (s *PostgresStore) RecordEvent(ctx context.Context, event *model.Event) error {
 	return queryRecordEvent(ctx, s.db, event)
 }
 
+func (s *PostgresStore) ImportEvent(ctx context.Context, event *model.Event) error {
+	return queryImportEvent(ctx, s.db, event)
+}
+
 func (s *PostgresStore) GetEvents(ctx context.Context, beadID string) ([]*model.Event, error) {
 	return queryGetEvents(ctx, s.db, beadID)
 }
 
+func (s *PostgresStore) ListEventsByActor(ctx context.Context, actor string, limit int) ([]*model.Event, error) {
+	return queryListEventsByActor(ctx, s.db, actor, limit)
+}
+
+func (s *PostgresStore) ListEventsBetween(ctx context.Context, from, to time.Time, topics []string) ([]*model.Event, error) {
+	return queryListEventsBetween(ctx, s.db, from, to, topics)
+}
+
+func (s *PostgresStore) ListEvents(ctx context.Context, filter model.EventFilter) ([]*model.Event, error) {
+	return queryListEvents(ctx, s.db, filter)
+}
+
+func (s *PostgresStore) CompactEvents(ctx context.Context, topics, except []string, cutoff time.Time) (int64, error) {
+	return queryCompactEvents(ctx, s.db, topics, except, cutoff)
+}
+
+func (s *PostgresStore) ListEventSummaries(ctx context.Context, filter model.EventSummaryFilter) ([]*model.EventSummary, error) {
+	return queryListEventSummaries(ctx, s.db, filter)
+}
+
+func (s *PostgresStore) ListUnpublishedEvents(ctx context.Context, limit int) ([]*model.Event, error) {
+	return queryListUnpublishedEvents(ctx, s.db, limit)
+}
+
+func (s *PostgresStore) MarkEventPublished(ctx context.Context, id int64) error {
+	return queryMarkEventPublished(ctx, s.db, id)
+}
+
+func (s *PostgresStore) AddWatcher(ctx context.Context, beadID, actor string) error {
+	return queryAddWatcher(ctx, s.db, beadID, actor)
+}
+
+func (s *PostgresStore) RemoveWatcher(ctx context.Context, beadID, actor string) error {
+	return queryRemoveWatcher(ctx, s.db, beadID, actor)
+}
+
+func (s *PostgresStore) GetWatchers(ctx context.Context, beadID string) ([]string, error) {
+	return queryGetWatchers(ctx, s.db, beadID)
+}
+
+func (s *PostgresStore) AckAdvice(ctx context.Context, beadID, actor string) error {
+	return queryAckAdvice(ctx, s.db, beadID, actor)
+}
+
+func (s *PostgresStore) ListAdviceAcks(ctx context.Context, actor string) ([]string, error) {
+	return queryListAdviceAcks(ctx, s.db, actor)
+}
+
+func (s *PostgresStore) AcquireBeadLock(ctx context.Context, lock *model.BeadLock) (*model.BeadLock, error) {
+	return queryAcquireBeadLock(ctx, s.db, lock)
+}
+
+func (s *PostgresStore) GetBeadLock(ctx context.Context, beadID string) (*model.BeadLock, error) {
+	return queryGetBeadLock(ctx, s.db, beadID)
+}
+
+func (s *PostgresStore) ReleaseBeadLock(ctx context.Context, beadID string) error {
+	return queryReleaseBeadLock(ctx, s.db, beadID)
+}
+
+func (s *PostgresStore) ListNotifications(ctx context.Context, actor string, unreadOnly bool, limit int) ([]*model.Notification, error) {
+	return queryListNotifications(ctx, s.db, actor, unreadOnly, limit)
+}
+
+func (s *PostgresStore) MarkNotificationsRead(ctx context.Context, actor string, ids []int64) (int64, error) {
+	return queryMarkNotificationsRead(ctx, s.db, actor, ids)
+}
+
+func (s *PostgresStore) NotifyActors(ctx context.Context, eventID int64, actors []string) error {
+	return queryNotifyActors(ctx, s.db, eventID, actors)
+}
+
+func (s *PostgresStore) CreateDigest(ctx context.Context, digest *model.Digest) error {
+	return queryCreateDigest(ctx, s.db, digest)
+}
+
+func (s *PostgresStore) GetLatestDigest(ctx context.Context, subscription string) (*model.Digest, error) {
+	return queryGetLatestDigest(ctx, s.db, subscription)
+}
+
+func (s *PostgresStore) ReplaceMirroredBeads(ctx context.Context, remote string, beads []*model.Bead) error {
+	return queryReplaceMirroredBeads(ctx, s.db, remote, beads)
+}
+
+func (s *PostgresStore) ListMirroredBeads(ctx context.Context, remote string) ([]*model.MirroredBead, error) {
+	return queryListMirroredBeads(ctx, s.db, remote)
+}
+
+func (s *PostgresStore) GetMirroredBead(ctx context.Context, remote, id string) (*model.MirroredBead, error) {
+	return queryGetMirroredBead(ctx, s.db, remote, id)
+}
+
 func (s *PostgresStore) SetConfig(ctx context.Context, config *model.Config) error {
 	return querySetConfig(ctx, s.db, config)
 }
@@ -165,6 +397,98 @@ func (s *PostgresStore) DeleteConfig(ctx context.Context, key string) error {
 	return queryDeleteConfig(ctx, s.db, key)
 }
 
+func (s *PostgresStore) ListConfigRevisions(ctx context.Context, key string) ([]*model.ConfigRevision, error) {
+	return queryListConfigRevisions(ctx, s.db, key)
+}
+
+func (s *PostgresStore) GetConfigRevision(ctx context.Context, key string, rev int64) (*model.ConfigRevision, error) {
+	return queryGetConfigRevision(ctx, s.db, key, rev)
+}
+
+func (s *PostgresStore) CreateAgent(ctx context.Context, agent *model.Agent) error {
+	return queryCreateAgent(ctx, s.db, agent)
+}
+
+func (s *PostgresStore) GetAgent(ctx context.Context, name string) (*model.Agent, error) {
+	return queryGetAgent(ctx, s.db, name)
+}
+
+func (s *PostgresStore) GetAgentByTokenHash(ctx context.Context, tokenHash string) (*model.Agent, error) {
+	return queryGetAgentByTokenHash(ctx, s.db, tokenHash)
+}
+
+func (s *PostgresStore) GetAgentByHookTokenHash(ctx context.Context, tokenHash string) (*model.Agent, error) {
+	return queryGetAgentByHookTokenHash(ctx, s.db, tokenHash)
+}
+
+func (s *PostgresStore) SetAgentHookToken(ctx context.Context, name, tokenHash string) error {
+	return querySetAgentHookToken(ctx, s.db, name, tokenHash)
+}
+
+func (s *PostgresStore) ListAgents(ctx context.Context) ([]*model.Agent, error) {
+	return queryListAgents(ctx, s.db)
+}
+
+func (s *PostgresStore) AddExternalDep(ctx context.Context, dep *model.ExternalDep) error {
+	return queryAddExternalDep(ctx, s.db, dep)
+}
+
+func (s *PostgresStore) GetExternalDeps(ctx context.Context, beadID string) ([]*model.ExternalDep, error) {
+	return queryGetExternalDeps(ctx, s.db, beadID)
+}
+
+func (s *PostgresStore) ListProbedExternalDeps(ctx context.Context) ([]*model.ExternalDep, error) {
+	return queryListProbedExternalDeps(ctx, s.db)
+}
+
+func (s *PostgresStore) UpdateExternalDep(ctx context.Context, dep *model.ExternalDep) error {
+	return queryUpdateExternalDep(ctx, s.db, dep)
+}
+
+func (s *PostgresStore) RemoveExternalDep(ctx context.Context, beadID string, id int64) error {
+	return queryRemoveExternalDep(ctx, s.db, beadID, id)
+}
+
+func (s *PostgresStore) LinkCommit(ctx context.Context, c *model.Commit) (bool, error) {
+	return queryLinkCommit(ctx, s.db, c)
+}
+
+func (s *PostgresStore) GetCommits(ctx context.Context, beadID string) ([]*model.Commit, error) {
+	return queryGetCommits(ctx, s.db, beadID)
+}
+
+func (s *PostgresStore) CreateActor(ctx context.Context, actor *model.Actor) error {
+	return queryCreateActor(ctx, s.db, actor)
+}
+
+func (s *PostgresStore) GetActor(ctx context.Context, id string) (*model.Actor, error) {
+	return queryGetActor(ctx, s.db, id)
+}
+
+func (s *PostgresStore) ListActors(ctx context.Context) ([]*model.Actor, error) {
+	return queryListActors(ctx, s.db)
+}
+
+func (s *PostgresStore) UpdateActor(ctx context.Context, actor *model.Actor) error {
+	return queryUpdateActor(ctx, s.db, actor)
+}
+
+func (s *PostgresStore) DeleteActor(ctx context.Context, id string) error {
+	return queryDeleteActor(ctx, s.db, id)
+}
+
+func (s *PostgresStore) AddActorAlias(ctx context.Context, id, alias string) error {
+	return queryAddActorAlias(ctx, s.db, id, alias)
+}
+
+func (s *PostgresStore) RemoveActorAlias(ctx context.Context, id, alias string) error {
+	return queryRemoveActorAlias(ctx, s.db, id, alias)
+}
+
+func (s *PostgresStore) ResolveActor(ctx context.Context, name string) (string, error) {
+	return queryResolveActor(ctx, s.db, name)
+}
+
 // RunInTransaction begins a database transaction, creates a txStore that
 // delegates to it, calls fn, and commits on success or rolls back on error.
 func (s *PostgresStore) RunInTransaction(ctx context.Context, fn func(tx store.Store) error) error {
@@ -173,7 +497,7 @@ func (s *PostgresStore) RunInTransaction(ctx context.Context, fn func(tx store.S
 		return fmt.Errorf("begin transaction: %w", err)
 	}
 
-	txS := &txStore{tx: tx}
+	txS := &txStore{tx: tracedTx{tx}}
 	if err := fn(txS); err != nil {
 		_ = tx.Rollback()
 		return err
@@ -185,9 +509,21 @@ func (s *PostgresStore) RunInTransaction(ctx context.Context, fn func(tx store.S
 	return nil
 }
 
+// RunInSnapshot begins a read-only, repeatable-read transaction, calls fn
+// with a txStore that delegates to it, and rolls it back: there is nothing
+// to commit.
+func (s *PostgresStore) RunInSnapshot(ctx context.Context, fn func(tx store.Store) error) error {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("begin snapshot: %w", err)
+	}
+	defer tx.Rollback()
+	return fn(&txStore{tx: tracedTx{tx}})
+}
+
 // txStore implements store.Store using a *sql.Tx.
 type txStore struct {
-	tx *sql.Tx
+	tx tracedTx
 }
 
 // Compile-time check that txStore implements store.Store.
@@ -205,6 +541,22 @@ func (s *txStore) ListBeads(ctx context.Context, filter model.BeadFilter) ([]*mo
 	return queryListBeads(ctx, s.tx, filter)
 }
 
+func (s *txStore) ListReadyBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	return queryListReadyBeads(ctx, s.tx, filter)
+}
+
+func (s *txStore) ListBlockedBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	return queryListBlockedBeads(ctx, s.tx, filter)
+}
+
+func (s *txStore) ClaimReadyBead(ctx context.Context, actor string, labels []string) (*model.Bead, error) {
+	return queryClaimReadyBead(ctx, s.tx, actor, labels)
+}
+
+func (s *txStore) StreamBeads(ctx context.Context, filter model.BeadFilter, fn func(*model.Bead) error) error {
+	return queryStreamBeads(ctx, s.tx, filter, fn)
+}
+
 func (s *txStore) UpdateBead(ctx context.Context, bead *model.Bead) error {
 	return queryUpdateBead(ctx, s.tx, bead)
 }
@@ -229,6 +581,42 @@ func (s *txStore) GetDependencies(ctx context.Context, beadID string) ([]*model.
 	return queryGetDependencies(ctx, s.tx, beadID)
 }
 
+func (s *txStore) SoftDeleteBead(ctx context.Context, id, deletedBy string) error {
+	return querySoftDeleteBead(ctx, s.tx, id, deletedBy)
+}
+
+func (s *txStore) RestoreBead(ctx context.Context, id string) (*model.Bead, error) {
+	return queryRestoreBead(ctx, s.tx, id)
+}
+
+func (s *txStore) ListDeletedBeads(ctx context.Context) ([]*model.Bead, error) {
+	return queryListDeletedBeads(ctx, s.tx)
+}
+
+func (s *txStore) PurgeDeletedBeads(ctx context.Context, before time.Time) ([]string, error) {
+	return queryPurgeDeletedBeads(ctx, s.tx, before)
+}
+
+func (s *txStore) ArchiveClosedBeads(ctx context.Context, closedBefore time.Time) ([]string, error) {
+	return queryArchiveClosedBeads(ctx, s.tx, closedBefore)
+}
+
+func (s *txStore) MergeBead(ctx context.Context, sourceID, targetID string) error {
+	return queryMergeBead(ctx, s.tx, sourceID, targetID)
+}
+
+func (s *txStore) SimilarBeads(ctx context.Context, title, excludeID string, limit int) ([]*model.SimilarBead, error) {
+	return querySimilarBeads(ctx, s.tx, title, excludeID, limit)
+}
+
+func (s *txStore) GetDependents(ctx context.Context, beadID string) ([]*model.Dependency, error) {
+	return queryGetDependents(ctx, s.tx, beadID)
+}
+
+func (s *txStore) UpdateDependencyMetadata(ctx context.Context, dep *model.Dependency) error {
+	return queryUpdateDependencyMetadata(ctx, s.tx, dep)
+}
+
 func (s *txStore) AddLabel(ctx context.Context, beadID string, label string) error {
 	return queryAddLabel(ctx, s.tx, beadID, label)
 }
@@ -241,22 +629,202 @@ func (s *txStore) GetLabels(ctx context.Context, beadID string) ([]string, error
 	return queryGetLabels(ctx, s.tx, beadID)
 }
 
+func (s *txStore) ListLabels(ctx context.Context) ([]*model.LabelCount, error) {
+	return queryListLabels(ctx, s.tx)
+}
+
+func (s *txStore) AggregateBeads(ctx context.Context, filter model.BeadFilter, groupBy string) ([]*model.AggregateGroup, error) {
+	return queryAggregateBeads(ctx, s.tx, filter, groupBy)
+}
+
+func (s *txStore) RollupBeads(ctx context.Context, filter model.BeadFilter, since time.Time) (*model.Rollup, error) {
+	return queryRollupBeads(ctx, s.tx, filter, since)
+}
+
+func (s *txStore) ResolveBeadRef(ctx context.Context, ref string) (string, error) {
+	return queryResolveBeadRef(ctx, s.tx, ref)
+}
+
+func (s *txStore) AddAlias(ctx context.Context, alias *model.Alias) error {
+	return queryAddAlias(ctx, s.tx, alias)
+}
+
+func (s *txStore) RemoveAlias(ctx context.Context, beadID, alias string) error {
+	return queryRemoveAlias(ctx, s.tx, beadID, alias)
+}
+
+func (s *txStore) GetAliases(ctx context.Context, beadID string) ([]*model.Alias, error) {
+	return queryGetAliases(ctx, s.tx, beadID)
+}
+
 func (s *txStore) AddComment(ctx context.Context, comment *model.Comment) error {
 	return queryAddComment(ctx, s.tx, comment)
 }
 
+func (s *txStore) ImportComment(ctx context.Context, comment *model.Comment) error {
+	return queryImportComment(ctx, s.tx, comment)
+}
+
 func (s *txStore) GetComments(ctx context.Context, beadID string) ([]*model.Comment, error) {
 	return queryGetComments(ctx, s.tx, beadID)
 }
 
+func (s *txStore) GetComment(ctx context.Context, id int64) (*model.Comment, error) {
+	return queryGetComment(ctx, s.tx, id)
+}
+
+func (s *txStore) AddReaction(ctx context.Context, commentID int64, actor, emoji string) error {
+	return queryAddReaction(ctx, s.tx, commentID, actor, emoji)
+}
+
+func (s *txStore) RemoveReaction(ctx context.Context, commentID int64, actor, emoji string) error {
+	return queryRemoveReaction(ctx, s.tx, commentID, actor, emoji)
+}
+
+func (s *txStore) ResolveComment(ctx context.Context, commentID int64, actor string, resolved bool) error {
+	return queryResolveComment(ctx, s.tx, commentID, actor, resolved)
+}
+
+func (s *txStore) AddChecklistItems(ctx context.Context, beadID string, texts []string, actor string) ([]*model.ChecklistItem, error) {
+	return queryAddChecklistItems(ctx, s.tx, beadID, texts, actor)
+}
+
+func (s *txStore) GetChecklist(ctx context.Context, beadID string) ([]*model.ChecklistItem, error) {
+	return queryGetChecklist(ctx, s.tx, beadID)
+}
+
+func (s *txStore) CheckChecklistItem(ctx context.Context, beadID string, index int, actor string, checked bool) (*model.ChecklistItem, error) {
+	return queryCheckChecklistItem(ctx, s.tx, beadID, index, actor, checked)
+}
+
+func (s *txStore) LoadRelations(ctx context.Context, beads []*model.Bead) error {
+	return queryLoadRelations(ctx, s.tx, beads)
+}
+
+func (s *txStore) ListCommentsByAuthor(ctx context.Context, author string, limit int) ([]*model.Comment, error) {
+	return queryListCommentsByAuthor(ctx, s.tx, author, limit)
+}
+
+func (s *txStore) RecordCreateKey(ctx context.Context, key, beadID string) (string, error) {
+	return queryRecordCreateKey(ctx, s.tx, key, beadID)
+}
+
+func (s *txStore) AppendNote(ctx context.Context, note *model.Note) error {
+	return queryAppendNote(ctx, s.tx, note)
+}
+
+func (s *txStore) GetNotes(ctx context.Context, beadID string) ([]*model.Note, error) {
+	return queryGetNotes(ctx, s.tx, beadID)
+}
+
+func (s *txStore) AppendDescription(ctx context.Context, id, text string) (string, error) {
+	return queryAppendDescription(ctx, s.tx, id, text)
+}
+
 func (s *txStore) RecordEvent(ctx context.Context, event *model.Event) error {
 	return queryRecordEvent(ctx, s.tx, event)
 }
 
+func (s *txStore) ImportEvent(ctx context.Context, event *model.Event) error {
+	return queryImportEvent(ctx, s.tx, event)
+}
+
 func (s *txStore) GetEvents(ctx context.Context, beadID string) ([]*model.Event, error) {
 	return queryGetEvents(ctx, s.tx, beadID)
 }
 
+func (s *txStore) ListEventsByActor(ctx context.Context, actor string, limit int) ([]*model.Event, error) {
+	return queryListEventsByActor(ctx, s.tx, actor, limit)
+}
+
+func (s *txStore) ListEventsBetween(ctx context.Context, from, to time.Time, topics []string) ([]*model.Event, error) {
+	return queryListEventsBetween(ctx, s.tx, from, to, topics)
+}
+
+func (s *txStore) ListEvents(ctx context.Context, filter model.EventFilter) ([]*model.Event, error) {
+	return queryListEvents(ctx, s.tx, filter)
+}
+
+func (s *txStore) CompactEvents(ctx context.Context, topics, except []string, cutoff time.Time) (int64, error) {
+	return queryCompactEvents(ctx, s.tx, topics, except, cutoff)
+}
+
+func (s *txStore) ListEventSummaries(ctx context.Context, filter model.EventSummaryFilter) ([]*model.EventSummary, error) {
+	return queryListEventSummaries(ctx, s.tx, filter)
+}
+
+func (s *txStore) ListUnpublishedEvents(ctx context.Context, limit int) ([]*model.Event, error) {
+	return queryListUnpublishedEvents(ctx, s.tx, limit)
+}
+
+func (s *txStore) MarkEventPublished(ctx context.Context, id int64) error {
+	return queryMarkEventPublished(ctx, s.tx, id)
+}
+
+func (s *txStore) AddWatcher(ctx context.Context, beadID, actor string) error {
+	return queryAddWatcher(ctx, s.tx, beadID, actor)
+}
+
+func (s *txStore) RemoveWatcher(ctx context.Context, beadID, actor string) error {
+	return queryRemoveWatcher(ctx, s.tx, beadID, actor)
+}
+
+func (s *txStore) GetWatchers(ctx context.Context, beadID string) ([]string, error) {
+	return queryGetWatchers(ctx, s.tx, beadID)
+}
+
+func (s *txStore) AckAdvice(ctx context.Context, beadID, actor string) error {
+	return queryAckAdvice(ctx, s.tx, beadID, actor)
+}
+
+func (s *txStore) ListAdviceAcks(ctx context.Context, actor string) ([]string, error) {
+	return queryListAdviceAcks(ctx, s.tx, actor)
+}
+
+func (s *txStore) AcquireBeadLock(ctx context.Context, lock *model.BeadLock) (*model.BeadLock, error) {
+	return queryAcquireBeadLock(ctx, s.tx, lock)
+}
+
+func (s *txStore) GetBeadLock(ctx context.Context, beadID string) (*model.BeadLock, error) {
+	return queryGetBeadLock(ctx, s.tx, beadID)
+}
+
+func (s *txStore) ReleaseBeadLock(ctx context.Context, beadID string) error {
+	return queryReleaseBeadLock(ctx, s.tx, beadID)
+}
+
+func (s *txStore) ListNotifications(ctx context.Context, actor string, unreadOnly bool, limit int) ([]*model.Notification, error) {
+	return queryListNotifications(ctx, s.tx, actor, unreadOnly, limit)
+}
+
+func (s *txStore) MarkNotificationsRead(ctx context.Context, actor string, ids []int64) (int64, error) {
+	return queryMarkNotificationsRead(ctx, s.tx, actor, ids)
+}
+
+func (s *txStore) NotifyActors(ctx context.Context, eventID int64, actors []string) error {
+	return queryNotifyActors(ctx, s.tx, eventID, actors)
+}
+
+func (s *txStore) CreateDigest(ctx context.Context, digest *model.Digest) error {
+	return queryCreateDigest(ctx, s.tx, digest)
+}
+
+func (s *txStore) GetLatestDigest(ctx context.Context, subscription string) (*model.Digest, error) {
+	return queryGetLatestDigest(ctx, s.tx, subscription)
+}
+
+func (s *txStore) ReplaceMirroredBeads(ctx context.Context, remote string, beads []*model.Bead) error {
+	return queryReplaceMirroredBeads(ctx, s.tx, remote, beads)
+}
+
+func (s *txStore) ListMirroredBeads(ctx context.Context, remote string) ([]*model.MirroredBead, error) {
+	return queryListMirroredBeads(ctx, s.tx, remote)
+}
+
+func (s *txStore) GetMirroredBead(ctx context.Context, remote, id string) (*model.MirroredBead, error) {
+	return queryGetMirroredBead(ctx, s.tx, remote, id)
+}
+
 func (s *txStore) SetConfig(ctx context.Context, config *model.Config) error {
 	return querySetConfig(ctx, s.tx, config)
 }
@@ -277,11 +845,109 @@ func (s *txStore) DeleteConfig(ctx context.Context, key string) error {
 	return queryDeleteConfig(ctx, s.tx, key)
 }
 
+func (s *txStore) ListConfigRevisions(ctx context.Context, key string) ([]*model.ConfigRevision, error) {
+	return queryListConfigRevisions(ctx, s.tx, key)
+}
+
+func (s *txStore) GetConfigRevision(ctx context.Context, key string, rev int64) (*model.ConfigRevision, error) {
+	return queryGetConfigRevision(ctx, s.tx, key, rev)
+}
+
+func (s *txStore) CreateAgent(ctx context.Context, agent *model.Agent) error {
+	return queryCreateAgent(ctx, s.tx, agent)
+}
+
+func (s *txStore) GetAgent(ctx context.Context, name string) (*model.Agent, error) {
+	return queryGetAgent(ctx, s.tx, name)
+}
+
+func (s *txStore) GetAgentByTokenHash(ctx context.Context, tokenHash string) (*model.Agent, error) {
+	return queryGetAgentByTokenHash(ctx, s.tx, tokenHash)
+}
+
+func (s *txStore) GetAgentByHookTokenHash(ctx context.Context, tokenHash string) (*model.Agent, error) {
+	return queryGetAgentByHookTokenHash(ctx, s.tx, tokenHash)
+}
+
+func (s *txStore) SetAgentHookToken(ctx context.Context, name, tokenHash string) error {
+	return querySetAgentHookToken(ctx, s.tx, name, tokenHash)
+}
+
+func (s *txStore) ListAgents(ctx context.Context) ([]*model.Agent, error) {
+	return queryListAgents(ctx, s.tx)
+}
+
+func (s *txStore) AddExternalDep(ctx context.Context, dep *model.ExternalDep) error {
+	return queryAddExternalDep(ctx, s.tx, dep)
+}
+
+func (s *txStore) GetExternalDeps(ctx context.Context, beadID string) ([]*model.ExternalDep, error) {
+	return queryGetExternalDeps(ctx, s.tx, beadID)
+}
+
+func (s *txStore) ListProbedExternalDeps(ctx context.Context) ([]*model.ExternalDep, error) {
+	return queryListProbedExternalDeps(ctx, s.tx)
+}
+
+func (s *txStore) UpdateExternalDep(ctx context.Context, dep *model.ExternalDep) error {
+	return queryUpdateExternalDep(ctx, s.tx, dep)
+}
+
+func (s *txStore) RemoveExternalDep(ctx context.Context, beadID string, id int64) error {
+	return queryRemoveExternalDep(ctx, s.tx, beadID, id)
+}
+
+func (s *txStore) LinkCommit(ctx context.Context, c *model.Commit) (bool, error) {
+	return queryLinkCommit(ctx, s.tx, c)
+}
+
+func (s *txStore) GetCommits(ctx context.Context, beadID string) ([]*model.Commit, error) {
+	return queryGetCommits(ctx, s.tx, beadID)
+}
+
+func (s *txStore) CreateActor(ctx context.Context, actor *model.Actor) error {
+	return queryCreateActor(ctx, s.tx, actor)
+}
+
+func (s *txStore) GetActor(ctx context.Context, id string) (*model.Actor, error) {
+	return queryGetActor(ctx, s.tx, id)
+}
+
+func (s *txStore) ListActors(ctx context.Context) ([]*model.Actor, error) {
+	return queryListActors(ctx, s.tx)
+}
+
+func (s *txStore) UpdateActor(ctx context.Context, actor *model.Actor) error {
+	return queryUpdateActor(ctx, s.tx, actor)
+}
+
+func (s *txStore) DeleteActor(ctx context.Context, id string) error {
+	return queryDeleteActor(ctx, s.tx, id)
+}
+
+func (s *txStore) AddActorAlias(ctx context.Context, id, alias string) error {
+	return queryAddActorAlias(ctx, s.tx, id, alias)
+}
+
+func (s *txStore) RemoveActorAlias(ctx context.Context, id, alias string) error {
+	return queryRemoveActorAlias(ctx, s.tx, id, alias)
+}
+
+func (s *txStore) ResolveActor(ctx context.Context, name string) (string, error) {
+	return queryResolveActor(ctx, s.tx, name)
+}
+
 // RunInTransaction on a txStore reuses the existing transaction (no nesting).
 func (s *txStore) RunInTransaction(ctx context.Context, fn func(tx store.Store) error) error {
 	return fn(s)
 }
 
+// RunInSnapshot on a txStore reuses the existing transaction, which already
+// sees one snapshot if it is repeatable-read.
+func (s *txStore) RunInSnapshot(ctx context.Context, fn func(tx store.Store) error) error {
+	return fn(s)
+}
+
 // Close is a no-op for a transaction store; the parent store owns the connection.
 func (s *txStore) Close() error {
 	return nil
@@ -5,12 +5,17 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"github.com/lib/pq"
 )
 
 // newMockDB creates a sqlmock database with automatic cleanup and expectation checking.
@@ -29,19 +34,21 @@ func newMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
 	return db, mock
 }
 
-// beadWithTotalColumns is the column list for queryListBeads results (total_count + bead columns).
+// beadWithTotalColumns is the column list for queryListBeads results
+// (total_count + bead columns + computed columns).
 var beadWithTotalColumns = []string{
 	"total_count",
 	"id", "slug", "kind", "type", "title", "description", "notes",
 	"status", "priority", "assignee", "owner", "created_at", "created_by", "updated_at",
-	"closed_at", "closed_by", "due_at", "defer_until", "fields",
+	"closed_at", "closed_by", "due_at", "defer_until", "fields", "estimate", "actual",
+	"age_days", "blocked_count", "last_activity_at", "checklist_done", "checklist_total", "effective_priority", "archived_at",
 }
 
 // beadRowColumns is the column list for scanBead results (standard bead columns).
 var beadRowColumns = []string{
 	"id", "slug", "kind", "type", "title", "description", "notes",
 	"status", "priority", "assignee", "owner", "created_at", "created_by", "updated_at",
-	"closed_at", "closed_by", "due_at", "defer_until", "fields",
+	"closed_at", "closed_by", "due_at", "defer_until", "fields", "estimate", "actual",
 }
 
 // addBeadWithTotalRow adds a minimal bead row with a leading total_count to a sqlmock.Rows.
@@ -50,19 +57,23 @@ func addBeadWithTotalRow(rows *sqlmock.Rows, total int, id, kind, typ, title, st
 		total,
 		id, nil, kind, typ, title, nil, nil,
 		status, priority, nil, nil, now, nil, now,
-		nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, "", "",
+		0, 0, now, 0, 0, priority, nil,
 	)
 }
 
-// emptyRelationalExpectations sets up sqlmock expectations for the three relational
-// queries (labels, deps, comments) that follow a bead query, returning empty results.
+// emptyRelationalExpectations sets up sqlmock expectations for the relational
+// queries (labels, deps, comments, checklist) that follow a bead query,
+// returning empty results.
 func emptyRelationalExpectations(mock sqlmock.Sqlmock, id string) {
 	mock.ExpectQuery("SELECT label FROM labels WHERE bead_id = \\$1").WithArgs(id).
 		WillReturnRows(sqlmock.NewRows([]string{"label"}))
 	mock.ExpectQuery("SELECT .+ FROM deps WHERE bead_id = \\$1").WithArgs(id).
 		WillReturnRows(sqlmock.NewRows([]string{"bead_id", "depends_on_id", "type", "created_at", "created_by", "metadata"}))
 	mock.ExpectQuery("SELECT .+ FROM comments WHERE bead_id = \\$1").WithArgs(id).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "bead_id", "author", "text", "created_at"}))
+		WillReturnRows(sqlmock.NewRows(commentCols))
+	mock.ExpectQuery("SELECT .+ FROM checklist_items WHERE bead_id = \\$1").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(checklistCols))
 }
 
 func TestParseSortClause(t *testing.T) {
@@ -80,8 +91,14 @@ func TestParseSortClause(t *testing.T) {
 			t.Errorf("parseSortClause(%q) = %q, want %q", tc.input, got, tc.want)
 		}
 	}
+	if got := parseSortClause("urgency"); got != urgencyScore+" DESC, id ASC" {
+		t.Errorf("parseSortClause(urgency) = %q", got)
+	}
+	if got := parseSortClause("-urgency"); got != urgencyScore+" ASC, id ASC" {
+		t.Errorf("parseSortClause(-urgency) = %q", got)
+	}
 	// All allowed columns.
-	for _, col := range []string{"priority", "created_at", "updated_at", "title", "status", "type"} {
+	for _, col := range []string{"priority", "created_at", "updated_at", "title", "status", "type", "age_days", "blocked_count", "last_activity_at", "effective_priority"} {
 		if got := parseSortClause(col); got != col+" ASC" {
 			t.Errorf("parseSortClause(%q) = %q, want %q", col, got, col+" ASC")
 		}
@@ -128,12 +145,13 @@ func TestQueryCreateBead(t *testing.T) {
 	bead := &model.Bead{
 		ID: "bd-test1", Kind: model.KindIssue, Type: model.TypeTask,
 		Title: "Test bead", Status: model.StatusOpen, CreatedAt: now, UpdatedAt: now,
+		Estimate: "3",
 	}
 	mock.ExpectExec("INSERT INTO beads").
 		WithArgs(
 			"bd-test1", sqlmock.AnyArg(), "issue", "task", "Test bead", "", "",
 			"open", 0, "", "", now, "", now,
-			sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "3", "",
 		).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -142,6 +160,17 @@ func TestQueryCreateBead(t *testing.T) {
 	}
 }
 
+func TestQueryCreateBead_SlugTaken(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectExec("INSERT INTO beads").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "idx_beads_slug"})
+
+	err := queryCreateBead(context.Background(), db, &model.Bead{ID: "bd-test2", Slug: "bd-taken"})
+	if !errors.Is(err, store.ErrSlugTaken) {
+		t.Fatalf("expected store.ErrSlugTaken, got %v", err)
+	}
+}
+
 func TestQueryGetBead(t *testing.T) {
 	db, mock := newMockDB(t)
 	now := time.Now().UTC()
@@ -149,18 +178,24 @@ func TestQueryGetBead(t *testing.T) {
 	rows := sqlmock.NewRows([]string{
 		"id", "slug", "kind", "type", "title", "description", "notes",
 		"status", "priority", "assignee", "owner", "created_at", "created_by", "updated_at",
-		"closed_at", "closed_by", "due_at", "defer_until", "fields",
+		"closed_at", "closed_by", "due_at", "defer_until", "fields", "estimate", "actual",
+		"age_days", "blocked_count", "last_activity_at", "checklist_done", "checklist_total", "effective_priority", "archived_at",
 	}).AddRow(
 		"bd-test1", nil, "issue", "task", "Test bead", nil, nil,
-		"open", 0, nil, nil, now, nil, now, nil, nil, nil, nil, nil,
+		"open", 0, nil, nil, now, nil, now, nil, nil, nil, nil, nil, "", "",
+		3, 2, now, 1, 2, 0, nil,
 	)
-	mock.ExpectQuery("SELECT .+ FROM beads WHERE id = \\$1").WithArgs("bd-test1").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT .+ FROM beads WHERE id = \\$1 AND deleted_at IS NULL").WithArgs("bd-test1").WillReturnRows(rows)
 	mock.ExpectQuery("SELECT label FROM labels WHERE bead_id = \\$1").WithArgs("bd-test1").
 		WillReturnRows(sqlmock.NewRows([]string{"label"}).AddRow("urgent"))
 	mock.ExpectQuery("SELECT .+ FROM deps WHERE bead_id = \\$1").WithArgs("bd-test1").
 		WillReturnRows(sqlmock.NewRows([]string{"bead_id", "depends_on_id", "type", "created_at", "created_by", "metadata"}))
 	mock.ExpectQuery("SELECT .+ FROM comments WHERE bead_id = \\$1").WithArgs("bd-test1").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "bead_id", "author", "text", "created_at"}))
+		WillReturnRows(sqlmock.NewRows(commentCols))
+	mock.ExpectQuery("SELECT .+ FROM checklist_items WHERE bead_id = \\$1 ORDER BY position").WithArgs("bd-test1").
+		WillReturnRows(sqlmock.NewRows(checklistCols).
+			AddRow("bd-test1", 1, "Docs updated", true, now, "alice", "bob", now).
+			AddRow("bd-test1", 2, "Tests pass", false, nil, "", "bob", now))
 
 	bead, err := queryGetBead(context.Background(), db, "bd-test1")
 	if err != nil {
@@ -172,6 +207,42 @@ func TestQueryGetBead(t *testing.T) {
 	if len(bead.Labels) != 1 || bead.Labels[0] != "urgent" {
 		t.Fatalf("expected labels=[urgent], got %v", bead.Labels)
 	}
+	if bead.AgeDays != 3 || bead.BlockedCount != 2 || bead.LastActivityAt == nil {
+		t.Fatalf("unexpected computed fields: age_days=%d blocked_count=%d last_activity_at=%v",
+			bead.AgeDays, bead.BlockedCount, bead.LastActivityAt)
+	}
+	if bead.EffectivePriority == nil || *bead.EffectivePriority != 0 || bead.PriorityChain != nil {
+		t.Fatalf("effective priority %v, chain %v; want 0 and no chain", bead.EffectivePriority, bead.PriorityChain)
+	}
+	if bead.ChecklistDone != 1 || bead.ChecklistTotal != 2 || len(bead.Checklist) != 2 {
+		t.Fatalf("checklist %d/%d, items %v", bead.ChecklistDone, bead.ChecklistTotal, bead.Checklist)
+	}
+	if it := bead.Checklist[0]; !it.Checked || it.CheckedAt == nil || it.CheckedBy != "alice" || bead.Checklist[1].CheckedAt != nil {
+		t.Fatalf("unexpected checklist items: %+v, %+v", it, bead.Checklist[1])
+	}
+}
+
+func TestQueryGetBead_InheritedPriority(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	rows := sqlmock.NewRows(beadWithTotalColumns[1:]).AddRow(
+		"bd-low", nil, "issue", "task", "Low", nil, nil,
+		"open", 4, nil, nil, now, nil, now, nil, nil, nil, nil, nil, "", "",
+		0, 1, now, 0, 0, 0, nil,
+	)
+	mock.ExpectQuery("SELECT .+ FROM beads WHERE id = \\$1 AND deleted_at IS NULL").WithArgs("bd-low").WillReturnRows(rows)
+	emptyRelationalExpectations(mock, "bd-low")
+	mock.ExpectQuery("WITH RECURSIVE chain\\(id, path\\) AS .+d.bead_id <> ALL\\(chain.path\\).+ORDER BY b.priority, cardinality\\(chain.path\\), chain.id\\s+LIMIT 1").
+		WithArgs("bd-low").
+		WillReturnRows(sqlmock.NewRows([]string{"path"}).AddRow("{bd-low,bd-mid,bd-top}"))
+
+	bead, err := queryGetBead(context.Background(), db, "bd-low")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bead.EffectivePriority == nil || *bead.EffectivePriority != 0 || strings.Join(bead.PriorityChain, ",") != "bd-low,bd-mid,bd-top" {
+		t.Fatalf("effective priority %v, chain %v", bead.EffectivePriority, bead.PriorityChain)
+	}
 }
 
 func TestQueryGetBead_NotFound(t *testing.T) {
@@ -204,6 +275,284 @@ func TestQueryDeleteBead_NotFound(t *testing.T) {
 	}
 }
 
+func TestQuerySoftDeleteBead(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectExec("UPDATE beads SET deleted_at = NOW\\(\\), deleted_by = \\$2\\s+WHERE id = \\$1 AND deleted_at IS NULL").
+		WithArgs("bd-del1", "alice").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE beads SET deleted_at").WithArgs("bd-del1", "alice").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := querySoftDeleteBead(context.Background(), db, "bd-del1", "alice"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := querySoftDeleteBead(context.Background(), db, "bd-del1", "alice"); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows for already-deleted bead, got %v", err)
+	}
+}
+
+func TestQueryRestoreBead_NotInTrash(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectExec("UPDATE beads SET deleted_at = NULL.+WHERE id = \\$1 AND deleted_at IS NOT NULL").
+		WithArgs("bd-live").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if _, err := queryRestoreBead(context.Background(), db, "bd-live"); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestQueryListDeletedBeads(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	rows := sqlmock.NewRows(append(append([]string{}, beadRowColumns...), "deleted_at", "deleted_by")).AddRow(
+		"bd-gone", nil, "issue", "task", "Gone", nil, nil,
+		"open", 2, nil, nil, now, nil, now, nil, nil, nil, nil, nil, "", "",
+		now, "alice",
+	)
+	mock.ExpectQuery("SELECT .+, deleted_at, deleted_by\\s+FROM beads\\s+WHERE deleted_at IS NOT NULL").WillReturnRows(rows)
+
+	beads, err := queryListDeletedBeads(context.Background(), db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(beads) != 1 || beads[0].ID != "bd-gone" || beads[0].DeletedBy != "alice" || beads[0].DeletedAt == nil {
+		t.Fatalf("unexpected beads: %+v", beads[0])
+	}
+}
+
+func TestQueryMergeBead(t *testing.T) {
+	db, mock := newMockDB(t)
+	for _, pat := range []string{
+		"UPDATE comments SET bead_id = \\$2",
+		"UPDATE notes SET bead_id = \\$2",
+		"INSERT INTO labels .+ ON CONFLICT DO NOTHING",
+		"DELETE FROM labels WHERE bead_id = \\$1",
+		"INSERT INTO deps .+ WHERE bead_id = \\$1 AND depends_on_id <> \\$2",
+		"INSERT INTO deps .+ WHERE depends_on_id = \\$1 AND bead_id <> \\$2",
+		"DELETE FROM deps WHERE bead_id = \\$1 OR depends_on_id = \\$1",
+		"UPDATE events SET bead_id = \\$2",
+		"UPDATE bead_aliases SET bead_id = \\$2",
+		"UPDATE checklist_items SET bead_id = \\$2,\\s+position = position \\+ \\(SELECT COALESCE\\(MAX\\(position\\), 0\\) FROM checklist_items WHERE bead_id = \\$2\\)",
+	} {
+		mock.ExpectExec(pat).WithArgs("bd-dup", "bd-orig").WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	if err := queryMergeBead(context.Background(), db, "bd-dup", "bd-orig"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryResolveBeadRef(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectQuery("SELECT id FROM \\(.+slug = \\$1.+bead_aliases a.+\\) refs\\s+ORDER BY rank\\s+LIMIT 1").
+		WithArgs("bd-fix-login-bug").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("bd-a1b2"))
+	mock.ExpectQuery("SELECT id FROM").
+		WithArgs("nope").
+		WillReturnError(sql.ErrNoRows)
+
+	id, err := queryResolveBeadRef(context.Background(), db, "bd-fix-login-bug")
+	if err != nil || id != "bd-a1b2" {
+		t.Fatalf("id = %q, err = %v", id, err)
+	}
+	if _, err := queryResolveBeadRef(context.Background(), db, "nope"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("err = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestQueryAliases(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	mock.ExpectQuery("INSERT INTO bead_aliases \\(alias, bead_id, created_by\\)").
+		WithArgs("login", "bd-a1b2", "alice").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(now))
+	mock.ExpectQuery("SELECT alias, bead_id, created_by, created_at\\s+FROM bead_aliases\\s+WHERE bead_id = \\$1\\s+ORDER BY alias").
+		WithArgs("bd-a1b2").
+		WillReturnRows(sqlmock.NewRows([]string{"alias", "bead_id", "created_by", "created_at"}).AddRow("login", "bd-a1b2", "alice", now))
+	mock.ExpectExec("DELETE FROM bead_aliases\\s+WHERE bead_id = \\$1 AND alias = \\$2").
+		WithArgs("bd-a1b2", "gone").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	a := &model.Alias{Alias: "login", BeadID: "bd-a1b2", CreatedBy: "alice"}
+	if err := queryAddAlias(context.Background(), db, a); err != nil || !a.CreatedAt.Equal(now) {
+		t.Fatalf("alias = %+v, err = %v", a, err)
+	}
+	aliases, err := queryGetAliases(context.Background(), db, "bd-a1b2")
+	if err != nil || len(aliases) != 1 || aliases[0].Alias != "login" {
+		t.Fatalf("aliases = %+v, err = %v", aliases, err)
+	}
+	if err := queryRemoveAlias(context.Background(), db, "bd-a1b2", "gone"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("err = %v, want sql.ErrNoRows", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryActors(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	mock.ExpectQuery("WITH a AS \\(\\s+INSERT INTO actors \\(id, display_name, type, created_by\\)").
+		WithArgs("alice", "Alice Liddell", "human", "admin", pq.Array([]string{"alice@corp"})).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(now))
+	mock.ExpectQuery("SELECT id FROM \\(").
+		WithArgs("Alice@Corp").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("alice"))
+	mock.ExpectQuery("FROM actors a\\s+LEFT JOIN actor_aliases al ON al.actor_id = a.id\\s+WHERE a.id = \\$1").
+		WithArgs("alice").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "display_name", "type", "created_by", "created_at", "aliases"}).
+			AddRow("alice", "Alice Liddell", "human", "admin", now, "{alice@corp}"))
+	mock.ExpectExec("DELETE FROM actor_aliases\\s+WHERE actor_id = \\$1 AND alias = \\$2").
+		WithArgs("alice", "gone").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	a := &model.Actor{ID: "alice", DisplayName: "Alice Liddell", Type: model.ActorHuman, Aliases: []string{"alice@corp"}, CreatedBy: "admin"}
+	if err := queryCreateActor(context.Background(), db, a); err != nil || !a.CreatedAt.Equal(now) {
+		t.Fatalf("actor = %+v, err = %v", a, err)
+	}
+	if id, err := queryResolveActor(context.Background(), db, "Alice@Corp"); err != nil || id != "alice" {
+		t.Fatalf("resolve = %q, %v", id, err)
+	}
+	got, err := queryGetActor(context.Background(), db, "alice")
+	if err != nil || got.Type != model.ActorHuman || len(got.Aliases) != 1 || got.Aliases[0] != "alice@corp" {
+		t.Fatalf("actor = %+v, err = %v", got, err)
+	}
+	if err := queryRemoveActorAlias(context.Background(), db, "alice", "gone"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("err = %v, want sql.ErrNoRows", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryExternalDeps(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	mock.ExpectQuery("INSERT INTO external_deps \\(bead_id, url, description, status, probe, created_by\\)").
+		WithArgs("bd-1", "https://github.com/acme/sdk/pull/1", "SDK", "waiting", "github-pr", "alice").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, now))
+	mock.ExpectQuery("FROM external_deps x\\s+WHERE x.status = 'waiting' AND x.probe <> ''").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "bead_id", "url", "description", "status", "probe", "checked_at", "last_error", "created_by", "created_at"}).
+			AddRow(7, "bd-1", "https://github.com/acme/sdk/pull/1", "SDK", "waiting", "github-pr", nil, "", "alice", now))
+	mock.ExpectExec("UPDATE external_deps SET status = \\$3, checked_at = \\$4, last_error = \\$5\\s+WHERE id = \\$1 AND bead_id = \\$2").
+		WithArgs(int64(7), "bd-1", "satisfied", now, "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM external_deps WHERE id = \\$1 AND bead_id = \\$2").
+		WithArgs(int64(8), "bd-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	d := &model.ExternalDep{BeadID: "bd-1", URL: "https://github.com/acme/sdk/pull/1", Description: "SDK", Status: model.ExternalWaiting, Probe: model.ProbeGitHubPR, CreatedBy: "alice"}
+	if err := queryAddExternalDep(context.Background(), db, d); err != nil || d.ID != 7 {
+		t.Fatalf("dep = %+v, err = %v", d, err)
+	}
+	deps, err := queryListProbedExternalDeps(context.Background(), db)
+	if err != nil || len(deps) != 1 || deps[0].CheckedAt != nil || deps[0].Status != model.ExternalWaiting {
+		t.Fatalf("deps = %+v, err = %v", deps, err)
+	}
+	d.Status, d.CheckedAt = model.ExternalSatisfied, &now
+	if err := queryUpdateExternalDep(context.Background(), db, d); err != nil {
+		t.Fatal(err)
+	}
+	if err := queryRemoveExternalDep(context.Background(), db, "bd-1", 8); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("err = %v, want sql.ErrNoRows", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryCommits(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	mock.ExpectQuery("INSERT INTO commits .+ ON CONFLICT \\(bead_id, repo, sha\\) DO NOTHING .+ UNION ALL").
+		WithArgs("bd-1", "app", "abc1234", "Fix bd-1", "Alice", "alice").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "message", "author", "linked_by", "created_at", "bool"}).
+			AddRow(3, "Fix bd-1", "Alice", "bob", now, false))
+	mock.ExpectQuery("SELECT .+ FROM commits\\s+WHERE bead_id = \\$1\\s+ORDER BY created_at DESC").
+		WithArgs("bd-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "bead_id", "repo", "sha", "message", "author", "linked_by", "created_at"}).
+			AddRow(3, "bd-1", "app", "abc1234", "Fix bd-1", "Alice", "bob", now))
+
+	c := &model.Commit{BeadID: "bd-1", Repo: "app", SHA: "abc1234", Message: "Fix bd-1", Author: "Alice", LinkedBy: "alice"}
+	created, err := queryLinkCommit(context.Background(), db, c)
+	if err != nil || created || c.ID != 3 || c.LinkedBy != "bob" {
+		t.Fatalf("commit = %+v, created = %v, err = %v", c, created, err)
+	}
+	commits, err := queryGetCommits(context.Background(), db, "bd-1")
+	if err != nil || len(commits) != 1 || commits[0].SHA != "abc1234" {
+		t.Fatalf("commits = %+v, err = %v", commits, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryMergeBead_Error(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectExec("UPDATE comments").WillReturnError(fmt.Errorf("boom"))
+
+	if err := queryMergeBead(context.Background(), db, "bd-dup", "bd-orig"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestQuerySimilarBeads(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	rows := sqlmock.NewRows(append(append([]string{}, beadRowColumns...), "score")).AddRow(
+		"bd-like", nil, "issue", "task", "Fix login bug", nil, nil,
+		"open", 2, nil, nil, now, nil, now, nil, nil, nil, nil, nil, "", "",
+		0.62,
+	)
+	mock.ExpectQuery("similarity\\(title, \\$1\\) AS score\\s+FROM beads\\s+WHERE deleted_at IS NULL AND status <> 'closed' AND id <> \\$2 AND title % \\$1").
+		WithArgs("Fix the login bug", "bd-new", 5).
+		WillReturnRows(rows)
+
+	similar, err := querySimilarBeads(context.Background(), db, "Fix the login bug", "bd-new", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(similar) != 1 || similar[0].Bead.ID != "bd-like" || similar[0].Similarity != 0.62 {
+		t.Fatalf("unexpected result: %+v", similar)
+	}
+}
+
+func TestQueryPurgeDeletedBeads(t *testing.T) {
+	db, mock := newMockDB(t)
+	cutoff := time.Now().UTC().Add(-30 * 24 * time.Hour)
+	mock.ExpectQuery("DELETE FROM beads\\s+WHERE deleted_at IS NOT NULL AND deleted_at < \\$1\\s+RETURNING id").
+		WithArgs(cutoff).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("bd-old1").AddRow("bd-old2"))
+
+	ids, err := queryPurgeDeletedBeads(context.Background(), db, cutoff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "bd-old1" {
+		t.Fatalf("got ids=%v", ids)
+	}
+}
+
+func TestQueryArchiveClosedBeads(t *testing.T) {
+	db, mock := newMockDB(t)
+	cutoff := time.Now().UTC().Add(-90 * 24 * time.Hour)
+	mock.ExpectQuery("UPDATE beads SET archived_at = NOW\\(\\)\\s+WHERE status = 'closed' AND closed_at < \\$1\\s+AND archived_at IS NULL AND deleted_at IS NULL\\s+RETURNING id").
+		WithArgs(cutoff).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("bd-old1"))
+
+	ids, err := queryArchiveClosedBeads(context.Background(), db, cutoff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != "bd-old1" {
+		t.Fatalf("got ids=%v", ids)
+	}
+}
+
 func TestQueryUpdateBead(t *testing.T) {
 	db, mock := newMockDB(t)
 	now := time.Now().UTC()
@@ -215,7 +564,7 @@ func TestQueryUpdateBead(t *testing.T) {
 		WithArgs(
 			"bd-test1", sqlmock.AnyArg(), "issue", "task", "Updated bead", "", "",
 			"open", 0, "", "",
-			sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), now, "", "",
 		).
 		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(now))
 
@@ -224,6 +573,20 @@ func TestQueryUpdateBead(t *testing.T) {
 	}
 }
 
+func TestQueryUpdateBead_Conflict(t *testing.T) {
+	db, mock := newMockDB(t)
+	read := time.Now().UTC().Add(-time.Second)
+	bead := &model.Bead{ID: "bd-test1", Kind: model.KindIssue, Type: model.TypeTask, Title: "Stale", Status: model.StatusOpen, UpdatedAt: read}
+	mock.ExpectQuery("UPDATE beads SET .+ WHERE id = \\$1 AND deleted_at IS NULL AND updated_at = \\$17").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("bd-test1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	if err := queryUpdateBead(context.Background(), db, bead); !errors.Is(err, store.ErrConflict) {
+		t.Fatalf("expected store.ErrConflict, got %v", err)
+	}
+}
+
 func TestQueryUpdateBead_NotFound(t *testing.T) {
 	db, mock := newMockDB(t)
 	bead := &model.Bead{ID: "nonexistent", Kind: model.KindIssue, Type: model.TypeTask, Title: "Test", Status: model.StatusOpen}
@@ -231,9 +594,11 @@ func TestQueryUpdateBead_NotFound(t *testing.T) {
 		WithArgs(
 			"nonexistent", sqlmock.AnyArg(), "issue", "task", "Test", "", "",
 			"open", 0, "", "",
-			sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), "", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "", "",
 		).
 		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("nonexistent").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
 
 	if err := queryUpdateBead(context.Background(), db, bead); err != sql.ErrNoRows {
 		t.Fatalf("expected sql.ErrNoRows, got %v", err)
@@ -275,6 +640,63 @@ func TestQueryGetDependencies(t *testing.T) {
 	}
 }
 
+func TestQueryGetDependents(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	rows := sqlmock.NewRows([]string{"bead_id", "depends_on_id", "type", "created_at", "created_by", "metadata"}).
+		AddRow("bd-x", "bd-a", "blocks", now, nil, nil)
+	mock.ExpectQuery("SELECT .+ FROM deps d JOIN beads b ON b.id = d.bead_id AND b.deleted_at IS NULL WHERE d.depends_on_id = \\$1").WithArgs("bd-a").WillReturnRows(rows)
+
+	deps, err := queryGetDependents(context.Background(), db, "bd-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deps) != 1 || deps[0].BeadID != "bd-x" {
+		t.Fatalf("got %+v", deps)
+	}
+}
+
+func TestQueryUpdateDependencyMetadata(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	dep := &model.Dependency{BeadID: "bd-a", DependsOnID: "bd-b", Type: "reviews", Metadata: json.RawMessage(`{"weight":2}`)}
+	mock.ExpectQuery("UPDATE deps SET metadata = \\$4 WHERE bead_id = \\$1 AND depends_on_id = \\$2 AND type = \\$3").
+		WithArgs("bd-a", "bd-b", "reviews", `{"weight":2}`).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "created_by"}).AddRow(now, "alice"))
+
+	if err := queryUpdateDependencyMetadata(context.Background(), db, dep); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dep.CreatedBy != "alice" {
+		t.Fatalf("got created_by=%q", dep.CreatedBy)
+	}
+}
+
+func TestScanDependencyMetadata(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	rows := sqlmock.NewRows([]string{"bead_id", "depends_on_id", "type", "created_at", "created_by", "metadata"}).
+		AddRow("bd-a", "bd-b", "reviews", now, "alice", `{"weight":2}`).
+		AddRow("bd-a", "bd-c", "related", now, "alice", "")
+	mock.ExpectQuery("SELECT .+ FROM deps WHERE bead_id = \\$1").WithArgs("bd-a").WillReturnRows(rows)
+
+	deps, err := queryGetDependencies(context.Background(), db, "bd-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(deps[0].Metadata) != `{"weight":2}` || deps[1].Metadata != nil {
+		t.Fatalf("got metadata %q and %q", deps[0].Metadata, deps[1].Metadata)
+	}
+}
+
+func TestReadyClauseHonoursBlockingDepTypes(t *testing.T) {
+	for _, q := range []string{readyClause, computedColumns} {
+		if !strings.Contains(q, "'deptype:' || d.type") {
+			t.Errorf("expected configured blocking dep types in %q", q)
+		}
+	}
+}
+
 func TestQueryRemoveDependency(t *testing.T) {
 	db, mock := newMockDB(t)
 	mock.ExpectExec("DELETE FROM deps").
@@ -306,6 +728,67 @@ func TestQueryRemoveLabel(t *testing.T) {
 	}
 }
 
+func TestQueryAggregateBeads(t *testing.T) {
+	db, mock := newMockDB(t)
+	rows := sqlmock.NewRows([]string{"key", "count", "avg_age"}).
+		AddRow("team:backend", 3, 12.5).
+		AddRow("urgent", 1, 2.0)
+	mock.ExpectQuery(`SELECT l.label, COUNT\(\*\),.+FROM \(SELECT id, .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND status IN \(\$1\).*\) b JOIN labels l ON l.bead_id = b.id\s+GROUP BY 1`).
+		WithArgs("open").
+		WillReturnRows(rows)
+
+	groups, err := queryAggregateBeads(context.Background(), db, model.BeadFilter{Status: []model.Status{model.StatusOpen}, Limit: 10}, model.GroupByLabel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 2 || groups[0].Key != "team:backend" || groups[0].Count != 3 || groups[0].AvgAgeHours != 12.5 {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+
+	if _, err := queryAggregateBeads(context.Background(), db, model.BeadFilter{}, "owner"); err == nil {
+		t.Fatal("expected error for an unknown dimension")
+	}
+}
+
+func TestQueryRollupBeads(t *testing.T) {
+	db, mock := newMockDB(t)
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"status", "count", "weight", "blocked", "recent"}).
+		AddRow("closed", 2, 5.0, 0, 3.0).
+		AddRow("open", 3, 4.0, 1, 0.0)
+	mock.ExpectQuery(`SELECT b.status, COUNT\(\*\), .+closed_at >= \$2\), 0\)\s+FROM \(SELECT status, closed_at, .+ AS weight, NOT \(NOT EXISTS .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND .+\) b\s+GROUP BY 1`).
+		WithArgs("epic:payments", since).
+		WillReturnRows(rows)
+
+	r, err := queryRollupBeads(context.Background(), db, model.BeadFilter{Labels: []string{"epic:payments"}, Limit: 10}, since)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Total != 5 || r.ByStatus["open"] != 3 || r.TotalWeight != 9 || r.CompletedWeight != 5 || r.Blocked != 1 || r.RecentWeight != 3 {
+		t.Fatalf("unexpected rollup: %+v", r)
+	}
+}
+
+func TestQueryListLabels(t *testing.T) {
+	db, mock := newMockDB(t)
+	rows := sqlmock.NewRows([]string{"label", "namespace", "value", "count"}).
+		AddRow("urgent", "", "urgent", 4).
+		AddRow("team:backend", "team", "backend", 2)
+	mock.ExpectQuery("SELECT l.label, l.namespace, l.value, COUNT\\(\\*\\)\\s+FROM labels l JOIN beads b .+ WHERE b.deleted_at IS NULL").
+		WillReturnRows(rows)
+
+	counts, err := queryListLabels(context.Background(), db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(counts) != 2 || counts[1].Namespace != "team" || counts[1].Value != "backend" || counts[1].Count != 2 {
+		t.Fatalf("unexpected counts: %+v", counts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
 func TestQueryGetLabels(t *testing.T) {
 	db, mock := newMockDB(t)
 	rows := sqlmock.NewRows([]string{"label"}).AddRow("urgent").AddRow("frontend")
@@ -359,13 +842,43 @@ func TestQueryGetEvents(t *testing.T) {
 	}
 }
 
+func TestQueryListUnpublishedEvents(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	rows := sqlmock.NewRows([]string{"id", "topic", "bead_id", "actor", "payload", "created_at"}).
+		AddRow(7, "beads.bead.created", "bd-a", "alice", []byte(`{}`), now)
+	mock.ExpectQuery("SELECT .+ FROM events WHERE published_at IS NULL ORDER BY id ASC LIMIT \\$1").
+		WithArgs(50).WillReturnRows(rows)
+
+	evts, err := queryListUnpublishedEvents(context.Background(), db, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(evts) != 1 || evts[0].ID != 7 {
+		t.Fatalf("got %+v", evts)
+	}
+}
+
+func TestQueryMarkEventPublished(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectExec("UPDATE events SET published_at = NOW\\(\\) WHERE id = \\$1").
+		WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := queryMarkEventPublished(context.Background(), db, 7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestQuerySetConfig(t *testing.T) {
 	db, mock := newMockDB(t)
 	now := time.Now().UTC()
-	config := &model.Config{Key: "view:inbox", Value: json.RawMessage(`{"filter":{"status":["open"]}}`)}
-	mock.ExpectQuery("INSERT INTO configs").
-		WithArgs("view:inbox", []byte(`{"filter":{"status":["open"]}}`)).
-		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	config := &model.Config{Key: "view:inbox", Value: json.RawMessage(`{"filter":{"status":["open"]}}`), UpdatedBy: "alice"}
+	mock.ExpectQuery("INSERT INTO configs .+ INSERT INTO config_revisions").
+		WithArgs("view:inbox", []byte(`{"filter":{"status":["open"]}}`), "alice").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at", "rev"}).AddRow(now, now, 3))
 
 	if err := querySetConfig(context.Background(), db, config); err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -373,6 +886,42 @@ func TestQuerySetConfig(t *testing.T) {
 	if config.CreatedAt.IsZero() {
 		t.Fatal("expected created_at to be set")
 	}
+	if config.Rev != 3 {
+		t.Fatalf("got rev=%d, want 3", config.Rev)
+	}
+}
+
+func TestQueryListConfigRevisions(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	mock.ExpectQuery("SELECT .+ FROM config_revisions WHERE key = \\$1\\s+ORDER BY rev DESC").WithArgs("view:inbox").
+		WillReturnRows(sqlmock.NewRows([]string{"key", "rev", "value", "actor", "created_at"}).
+			AddRow("view:inbox", 2, nil, "", now).
+			AddRow("view:inbox", 1, []byte(`{"limit":5}`), "alice", now))
+
+	revs, err := queryListConfigRevisions(context.Background(), db, "view:inbox")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(revs) != 2 {
+		t.Fatalf("expected 2 revisions, got %d", len(revs))
+	}
+	if !revs[0].Deleted || revs[0].Value != nil {
+		t.Fatalf("expected rev 2 to be a deletion, got %+v", revs[0])
+	}
+	if revs[1].Deleted || string(revs[1].Value) != `{"limit":5}` || revs[1].Actor != "alice" {
+		t.Fatalf("unexpected rev 1: %+v", revs[1])
+	}
+}
+
+func TestQueryGetConfigRevision_NotFound(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectQuery("SELECT .+ FROM config_revisions WHERE key = \\$1 AND rev = \\$2").WithArgs("view:inbox", int64(9)).
+		WillReturnError(sql.ErrNoRows)
+
+	if _, err := queryGetConfigRevision(context.Background(), db, "view:inbox", 9); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
 }
 
 func TestQueryGetConfig(t *testing.T) {
@@ -463,7 +1012,7 @@ func TestQueryAddComment(t *testing.T) {
 	now := time.Now().UTC()
 	comment := &model.Comment{BeadID: "bd-a", Author: "alice", Text: "Hello world"}
 	mock.ExpectQuery("INSERT INTO comments").
-		WithArgs("bd-a", "alice", "Hello world").
+		WithArgs("bd-a", "alice", "", false, "Hello world").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(int64(1), now))
 
 	if err := queryAddComment(context.Background(), db, comment); err != nil {
@@ -474,12 +1023,17 @@ func TestQueryAddComment(t *testing.T) {
 	}
 }
 
+// commentCols are the columns of commentColumns.
+var commentCols = []string{"id", "bead_id", "author", "claimed_author", "verified", "text", "created_at", "resolved_at", "resolved_by", "reactions"}
+
+var checklistCols = []string{"bead_id", "position", "text", "checked", "checked_at", "checked_by", "created_by", "created_at"}
+
 func TestQueryGetComments(t *testing.T) {
 	db, mock := newMockDB(t)
 	now := time.Now().UTC()
-	rows := sqlmock.NewRows([]string{"id", "bead_id", "author", "text", "created_at"}).
-		AddRow(int64(1), "bd-a", "alice", "First", now).
-		AddRow(int64(2), "bd-a", nil, "Second", now)
+	rows := sqlmock.NewRows(commentCols).
+		AddRow(int64(1), "bd-a", "alice", "mallory", true, "First", now, now, "bob", []byte(`{"+1": 2}`)).
+		AddRow(int64(2), "bd-a", nil, "", false, "Second", now, nil, nil, nil)
 	mock.ExpectQuery("SELECT .+ FROM comments WHERE bead_id = \\$1").WithArgs("bd-a").WillReturnRows(rows)
 
 	comments, err := queryGetComments(context.Background(), db, "bd-a")
@@ -492,14 +1046,507 @@ func TestQueryGetComments(t *testing.T) {
 	if comments[0].Author != "alice" || comments[1].Author != "" {
 		t.Fatalf("got authors=%q %q", comments[0].Author, comments[1].Author)
 	}
+	if !comments[0].Verified || comments[0].ClaimedAuthor != "mallory" || comments[1].Verified {
+		t.Fatalf("got authorship %+v, %+v", comments[0], comments[1])
+	}
+	if comments[0].ResolvedAt == nil || comments[0].ResolvedBy != "bob" || comments[0].Reactions["+1"] != 2 {
+		t.Fatalf("got first comment %+v", comments[0])
+	}
+	if comments[1].ResolvedAt != nil || comments[1].Reactions != nil {
+		t.Fatalf("got second comment %+v", comments[1])
+	}
 }
 
-func TestQueryListBeads(t *testing.T) {
+func TestQueryResolveComment(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectExec("UPDATE comments SET resolved_at = COALESCE\\(resolved_at, NOW\\(\\)\\), resolved_by = COALESCE\\(resolved_by, \\$2\\)").
+		WithArgs(int64(7), "bob").WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := queryResolveComment(context.Background(), db, 7, "bob", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mock.ExpectExec("UPDATE comments SET resolved_at = NULL, resolved_by = NULL WHERE id = \\$1").
+		WithArgs(int64(8)).WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := queryResolveComment(context.Background(), db, 8, "bob", false); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows for a missing comment, got %v", err)
+	}
+}
+
+func TestQueryAddChecklistItems(t *testing.T) {
+	db, mock := newMockDB(t)
 	now := time.Now().UTC()
-	pri := func(v int) *int { return &v }
+	texts := []string{"Docs updated", "Tests pass"}
+	mock.ExpectQuery("INSERT INTO checklist_items .+ COALESCE\\(\\(SELECT MAX\\(position\\) FROM checklist_items WHERE bead_id = \\$1\\), 0\\) \\+ t.n.+unnest\\(\\$2::text\\[\\]\\) WITH ORDINALITY").
+		WithArgs("bd-a", pq.Array(texts), "alice").
+		WillReturnRows(sqlmock.NewRows(checklistCols).
+			AddRow("bd-a", 4, "Tests pass", false, nil, "", "alice", now).
+			AddRow("bd-a", 3, "Docs updated", false, nil, "", "alice", now))
+
+	items, err := queryAddChecklistItems(context.Background(), db, "bd-a", texts, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].Index != 3 || items[0].Text != "Docs updated" || items[1].Index != 4 {
+		t.Fatalf("items = %+v", items)
+	}
 
-	for _, tc := range []struct {
-		name      string
+	mock.ExpectQuery("INSERT INTO checklist_items").WillReturnError(&pq.Error{Code: "23505"})
+	if _, err := queryAddChecklistItems(context.Background(), db, "bd-a", texts, "alice"); !errors.Is(err, store.ErrConflict) {
+		t.Fatalf("expected store.ErrConflict for a clashing add, got %v", err)
+	}
+}
+
+func TestQueryCheckChecklistItem(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	mock.ExpectQuery("UPDATE checklist_items\\s+SET checked_at = CASE WHEN checked THEN checked_at ELSE NOW\\(\\) END,.+checked = TRUE\\s+WHERE bead_id = \\$1 AND position = \\$2").
+		WithArgs("bd-a", 2, "bob").
+		WillReturnRows(sqlmock.NewRows(checklistCols).AddRow("bd-a", 2, "Tests pass", true, now, "bob", "alice", now))
+	it, err := queryCheckChecklistItem(context.Background(), db, "bd-a", 2, "bob", true)
+	if err != nil || !it.Checked || it.CheckedBy != "bob" || it.CheckedAt == nil {
+		t.Fatalf("item %+v, err %v", it, err)
+	}
+
+	mock.ExpectQuery("UPDATE checklist_items SET checked = FALSE, checked_at = NULL, checked_by = ''").
+		WithArgs("bd-a", 9).WillReturnError(sql.ErrNoRows)
+	if _, err := queryCheckChecklistItem(context.Background(), db, "bd-a", 9, "bob", false); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows for a missing item, got %v", err)
+	}
+}
+
+func TestQueryLoadRelations(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	ids := pq.Array([]string{"bd-a", "bd-b"})
+	mock.ExpectQuery("SELECT bead_id, label FROM labels WHERE bead_id = ANY\\(\\$1\\)").WithArgs(ids).
+		WillReturnRows(sqlmock.NewRows([]string{"bead_id", "label"}).AddRow("bd-a", "urgent").AddRow("bd-b", "ui"))
+	mock.ExpectQuery("SELECT .+ FROM deps WHERE bead_id = ANY\\(\\$1\\)").WithArgs(ids).
+		WillReturnRows(sqlmock.NewRows([]string{"bead_id", "depends_on_id", "type", "created_at", "created_by", "metadata"}).
+			AddRow("bd-b", "bd-a", "blocks", now, nil, nil))
+	mock.ExpectQuery("SELECT .+ FROM comments WHERE bead_id = ANY\\(\\$1\\) ORDER BY created_at ASC").WithArgs(ids).
+		WillReturnRows(sqlmock.NewRows(commentCols).
+			AddRow(int64(1), "bd-a", "alice", "", false, "First", now, nil, nil, nil))
+
+	a, b := &model.Bead{ID: "bd-a"}, &model.Bead{ID: "bd-b"}
+	if err := queryLoadRelations(context.Background(), db, []*model.Bead{a, b}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(a.Labels) != 1 || a.Labels[0] != "urgent" || len(a.Dependencies) != 0 || len(a.Comments) != 1 {
+		t.Fatalf("bd-a relations: labels=%v deps=%v comments=%v", a.Labels, a.Dependencies, a.Comments)
+	}
+	if len(b.Labels) != 1 || len(b.Dependencies) != 1 || b.Dependencies[0].DependsOnID != "bd-a" || len(b.Comments) != 0 {
+		t.Fatalf("bd-b relations: labels=%v deps=%v comments=%v", b.Labels, b.Dependencies, b.Comments)
+	}
+
+	// No beads, no queries.
+	if err := queryLoadRelations(context.Background(), db, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestQueryListBeads_InvalidQuery(t *testing.T) {
+	db, _ := newMockDB(t)
+	_, _, err := queryListBeads(context.Background(), db, model.BeadFilter{Query: "status<open"})
+	if err == nil || !strings.Contains(err.Error(), "invalid query") {
+		t.Fatalf("expected invalid query error, got %v", err)
+	}
+}
+
+func TestQueryMirroredBeads(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectExec("DELETE FROM mirrored_beads WHERE remote = \\$1").WithArgs("east").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO mirrored_beads .+ ON CONFLICT \\(remote, id\\) DO UPDATE").
+		WithArgs("east", "bd-a", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := queryReplaceMirroredBeads(context.Background(), db, "east", []*model.Bead{{ID: "bd-a", Title: "Remote"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now := time.Now().UTC()
+	mock.ExpectQuery("SELECT remote, bead, synced_at FROM mirrored_beads").WithArgs("east", "bd-a").
+		WillReturnRows(sqlmock.NewRows([]string{"remote", "bead", "synced_at"}).
+			AddRow("east", []byte(`{"id":"bd-a","title":"Remote"}`), now))
+
+	m, err := queryGetMirroredBead(context.Background(), db, "east", "bd-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Remote != "east" || m.Bead.ID != "bd-a" || m.Bead.Title != "Remote" {
+		t.Fatalf("got %+v", m)
+	}
+}
+
+func TestQueryRecordCreateKey(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectQuery("INSERT INTO create_keys .+ ON CONFLICT \\(key\\) DO NOTHING").
+		WithArgs("k1", "bd-new").
+		WillReturnRows(sqlmock.NewRows([]string{"bead_id"}).AddRow("bd-new"))
+
+	id, err := queryRecordCreateKey(context.Background(), db, "k1", "bd-new")
+	if err != nil || id != "bd-new" {
+		t.Fatalf("got %q, %v", id, err)
+	}
+
+	// A key that was already used returns its bead.
+	mock.ExpectQuery("INSERT INTO create_keys").WithArgs("k1", "bd-again").
+		WillReturnRows(sqlmock.NewRows([]string{"bead_id"}))
+	mock.ExpectQuery("SELECT bead_id FROM create_keys WHERE key = \\$1").WithArgs("k1").
+		WillReturnRows(sqlmock.NewRows([]string{"bead_id"}).AddRow("bd-new"))
+	id, err = queryRecordCreateKey(context.Background(), db, "k1", "bd-again")
+	if err != nil || id != "bd-new" {
+		t.Fatalf("got %q, %v", id, err)
+	}
+}
+
+func TestQueryAppendNote(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Date(2026, 1, 9, 17, 0, 0, 0, time.UTC)
+	note := &model.Note{BeadID: "bd-a", Author: "alice", Text: "Picked up", CreatedAt: now}
+	mock.ExpectQuery("WITH bead AS \\(\\s*UPDATE beads SET\\s*notes = .+ INSERT INTO notes").
+		WithArgs("bd-a", "alice", "Picked up", "[2026-01-09T17:00:00Z] alice: Picked up", now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(7)))
+
+	if err := queryAppendNote(context.Background(), db, note); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if note.ID != 7 {
+		t.Fatalf("got id=%d", note.ID)
+	}
+
+	// A missing or trashed bead inserts nothing.
+	mock.ExpectQuery("INSERT INTO notes").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	if err := queryAppendNote(context.Background(), db, &model.Note{BeadID: "bd-nope", Text: "x"}); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestQueryGetNotes(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	rows := sqlmock.NewRows([]string{"id", "bead_id", "author", "text", "created_at"}).
+		AddRow(int64(1), "bd-a", "alice", "First", now).
+		AddRow(int64(2), "bd-a", nil, "Second", now)
+	mock.ExpectQuery("SELECT .+ FROM notes WHERE bead_id = \\$1").WithArgs("bd-a").WillReturnRows(rows)
+
+	notes, err := queryGetNotes(context.Background(), db, "bd-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notes) != 2 || notes[0].Author != "alice" || notes[1].Author != "" {
+		t.Fatalf("unexpected notes: %+v", notes)
+	}
+}
+
+func TestQueryAppendDescription(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectQuery("UPDATE beads SET\\s*description = .+ WHERE id = \\$1 AND deleted_at IS NULL").
+		WithArgs("bd-a", "More detail").
+		WillReturnRows(sqlmock.NewRows([]string{"description"}).AddRow("Original\n\nMore detail"))
+
+	got, err := queryAppendDescription(context.Background(), db, "bd-a", "More detail")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "Original\n\nMore detail" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestQueryAgents(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	agent := &model.Agent{Name: "crew/a", BeadID: "bd-a", TokenHash: "abc", CreatedBy: "alice"}
+	mock.ExpectQuery("INSERT INTO agents").
+		WithArgs("crew/a", "bd-a", "abc", "alice").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(now))
+	if err := queryCreateAgent(context.Background(), db, agent); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !agent.CreatedAt.Equal(now) {
+		t.Fatalf("created_at = %v", agent.CreatedAt)
+	}
+
+	cols := []string{"name", "bead_id", "token_hash", "hook_token_hash", "created_at", "created_by"}
+	mock.ExpectQuery("SELECT .+ FROM agents WHERE token_hash = \\$1").WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("crew/a", "bd-a", "abc", "", now, "alice"))
+	got, err := queryGetAgentByTokenHash(context.Background(), db, "abc")
+	if err != nil || got.Name != "crew/a" || got.BeadID != "bd-a" {
+		t.Fatalf("got %+v, err %v", got, err)
+	}
+
+	mock.ExpectExec("UPDATE agents SET hook_token_hash = NULLIF\\(\\$2, ''\\) WHERE name = \\$1").WithArgs("crew/a", "def").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := querySetAgentHookToken(context.Background(), db, "crew/a", "def"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mock.ExpectQuery("SELECT .+ FROM agents WHERE hook_token_hash = \\$1").WithArgs("def").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("crew/a", "bd-a", "abc", "def", now, "alice"))
+	if got, err := queryGetAgentByHookTokenHash(context.Background(), db, "def"); err != nil || got.HookTokenHash != "def" {
+		t.Fatalf("got %+v, err %v", got, err)
+	}
+	mock.ExpectExec("UPDATE agents SET hook_token_hash").WithArgs("crew/nope", "").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := querySetAgentHookToken(context.Background(), db, "crew/nope", ""); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+
+	mock.ExpectQuery("SELECT .+ FROM agents WHERE name = \\$1").WithArgs("crew/nope").
+		WillReturnRows(sqlmock.NewRows(cols))
+	if _, err := queryGetAgent(context.Background(), db, "crew/nope"); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestQueryRecordEventNotifiesWatchers(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectQuery("WITH ev AS \\(\\s*INSERT INTO events .+ INSERT INTO notifications .+ FROM watchers w, ev\\s+WHERE w.bead_id = ev.bead_id AND w.actor <> ev.actor").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+
+	if err := queryRecordEvent(context.Background(), db, &model.Event{Topic: "t", BeadID: "bd-a", Payload: json.RawMessage(`{}`)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestQueryWatchers(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectExec("INSERT INTO watchers .+ ON CONFLICT DO NOTHING").WithArgs("bd-a", "alice").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := queryAddWatcher(context.Background(), db, "bd-a", "alice"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mock.ExpectQuery("SELECT actor FROM watchers WHERE bead_id = \\$1").WithArgs("bd-a").
+		WillReturnRows(sqlmock.NewRows([]string{"actor"}).AddRow("alice").AddRow("crew/bot"))
+	watchers, err := queryGetWatchers(context.Background(), db, "bd-a")
+	if err != nil || len(watchers) != 2 || watchers[1] != "crew/bot" {
+		t.Fatalf("got %v, err %v", watchers, err)
+	}
+}
+
+func TestQueryAcquireBeadLock(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	lock := &model.BeadLock{BeadID: "bd-a", Owner: "bob", AcquiredAt: now, ExpiresAt: now.Add(5 * time.Minute)}
+	cols := []string{"bead_id", "owner", "acquired_at", "expires_at"}
+
+	mock.ExpectQuery("INSERT INTO bead_locks .+ ON CONFLICT \\(bead_id\\) DO UPDATE .+ WHERE bead_locks.owner = EXCLUDED.owner").
+		WithArgs("bd-a", "bob", now, lock.ExpiresAt).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("bd-a", "bob", now, lock.ExpiresAt))
+	got, err := queryAcquireBeadLock(context.Background(), db, lock)
+	if err != nil || got.Owner != "bob" {
+		t.Fatalf("got %+v, err %v", got, err)
+	}
+
+	// Held by someone else: the upsert returns nothing and the holder is read.
+	mock.ExpectQuery("INSERT INTO bead_locks").WillReturnRows(sqlmock.NewRows(cols))
+	mock.ExpectQuery("SELECT bead_id, owner, acquired_at, expires_at FROM bead_locks WHERE bead_id = \\$1").WithArgs("bd-a").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("bd-a", "alice", now, now.Add(time.Hour)))
+	got, err = queryAcquireBeadLock(context.Background(), db, lock)
+	if err != nil || got.Owner != "alice" {
+		t.Fatalf("got %+v, err %v", got, err)
+	}
+
+	mock.ExpectExec("DELETE FROM bead_locks WHERE bead_id = \\$1").WithArgs("bd-a").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := queryReleaseBeadLock(context.Background(), db, "bd-a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestQueryAdviceAcks(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectExec("INSERT INTO advice_acks .+ ON CONFLICT DO NOTHING").WithArgs("bd-tip", "alice").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := queryAckAdvice(context.Background(), db, "bd-tip", "alice"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mock.ExpectQuery("SELECT bead_id FROM advice_acks WHERE actor = \\$1").WithArgs("alice").
+		WillReturnRows(sqlmock.NewRows([]string{"bead_id"}).AddRow("bd-tip"))
+	ids, err := queryListAdviceAcks(context.Background(), db, "alice")
+	if err != nil || len(ids) != 1 || ids[0] != "bd-tip" {
+		t.Fatalf("got %v, err %v", ids, err)
+	}
+}
+
+func TestQueryListEventsBetween(t *testing.T) {
+	db, mock := newMockDB(t)
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	cols := []string{"id", "topic", "bead_id", "actor", "payload", "created_at"}
+
+	mock.ExpectQuery("FROM events\\s+WHERE created_at >= \\$1 AND created_at < \\$2\\s+AND topic IN \\(\\$3, \\$4\\)\\s+ORDER BY id ASC").
+		WithArgs(from, to, "beads.bead.created", "beads.bead.closed").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(4), "beads.bead.created", "bd-a", "alice", []byte(`{}`), from.Add(time.Hour)))
+	events, err := queryListEventsBetween(context.Background(), db, from, to, []string{"beads.bead.created", "beads.bead.closed"})
+	if err != nil || len(events) != 1 || events[0].Actor != "alice" {
+		t.Fatalf("events %v, err %v", events, err)
+	}
+
+	mock.ExpectQuery("WHERE created_at >= \\$1 AND created_at < \\$2\\s+ORDER BY id ASC").
+		WithArgs(from, to).
+		WillReturnRows(sqlmock.NewRows(cols))
+	if _, err := queryListEventsBetween(context.Background(), db, from, to, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryListEvents(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	cols := []string{"id", "topic", "bead_id", "actor", "payload", "created_at"}
+
+	mock.ExpectQuery("FROM events\\s+WHERE id > \\$1\\s+AND topic IN \\(\\$2\\)\\s+AND actor = \\$3\\s+"+
+		"AND EXISTS \\(SELECT 1 FROM labels l WHERE l.bead_id = events.bead_id AND l.label = \\$4\\)\\s+"+
+		"AND bead_id IN \\(\\s+WITH RECURSIVE tree\\(id\\) AS \\(\\s+SELECT \\$5::text.+d.type = 'parent-child'.+"+
+		"ORDER BY id ASC\\s+LIMIT \\$6").
+		WithArgs(int64(40), "beads.bead.updated", "alice", "backend", "bd-epic", 50).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(41), "beads.bead.updated", "bd-child", "alice", []byte(`{}`), now))
+	events, err := queryListEvents(context.Background(), db, model.EventFilter{
+		Topics: []string{"beads.bead.updated"}, Actor: "alice", Label: "backend", Project: "bd-epic", AfterID: 40, Limit: 50,
+	})
+	if err != nil || len(events) != 1 || events[0].ID != 41 {
+		t.Fatalf("events %v, err %v", events, err)
+	}
+
+	since := now.Add(-time.Hour)
+	mock.ExpectQuery("FROM events\\s+WHERE id > \\$1\\s+AND bead_id = \\$2\\s+AND created_at >= \\$3\\s+ORDER BY id ASC$").
+		WithArgs(int64(0), "bd-a", since).
+		WillReturnRows(sqlmock.NewRows(cols))
+	if _, err := queryListEvents(context.Background(), db, model.EventFilter{BeadID: "bd-a", Since: since}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTopicLikePatterns(t *testing.T) {
+	got := topicLikePatterns([]string{"*", "beads.comment.*", "beads.bead.updated", "odd_topic%"})
+	want := []string{"%", "beads.comment.%", "beads.bead.updated", `odd\_topic\%`}
+	if !slices.Equal(got, want) {
+		t.Fatalf("patterns = %q, want %q", got, want)
+	}
+}
+
+func TestQueryCompactEvents(t *testing.T) {
+	db, mock := newMockDB(t)
+	cutoff := time.Now().UTC().Add(-90 * 24 * time.Hour)
+	mock.ExpectQuery("DELETE FROM events\\s+WHERE created_at < \\$1 AND published_at IS NOT NULL\\s+"+
+		"AND topic LIKE ANY\\(\\$2::text\\[\\]\\) AND NOT topic LIKE ANY\\(\\$3::text\\[\\]\\).+"+
+		"INSERT INTO event_summaries .+ON CONFLICT \\(topic, bead_id, day\\) DO UPDATE.+SELECT COUNT\\(\\*\\) FROM gone").
+		WithArgs(cutoff, pq.Array([]string{"beads.comment.%"}), pq.Array([]string{"beads.comment.added"})).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(12)))
+
+	n, err := queryCompactEvents(context.Background(), db, []string{"beads.comment.*"}, []string{"beads.comment.added"}, cutoff)
+	if err != nil || n != 12 {
+		t.Fatalf("compacted %d, err %v", n, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryListEventSummaries(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	mock.ExpectQuery("FROM event_summaries\\s+WHERE TRUE\\s+AND bead_id = \\$1\\s+ORDER BY day DESC, topic, bead_id\\s+LIMIT \\$2").
+		WithArgs("bd-a", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"topic", "bead_id", "day", "count", "actors", "first_at", "last_at"}).
+			AddRow("beads.bead.updated", "bd-a", "2026-01-02", int64(7), "{alice,bob}", now, now))
+
+	sums, err := queryListEventSummaries(context.Background(), db, model.EventSummaryFilter{BeadID: "bd-a", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sums) != 1 || sums[0].Day != "2026-01-02" || sums[0].Count != 7 || !slices.Equal(sums[0].Actors, []string{"alice", "bob"}) {
+		t.Fatalf("summaries = %+v", sums)
+	}
+}
+
+func TestQueryActorHistory(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	mock.ExpectQuery("FROM events\\s+WHERE actor = \\$1\\s+ORDER BY id DESC\\s+LIMIT \\$2").WithArgs("crew/bot", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "topic", "bead_id", "actor", "payload", "created_at"}).
+			AddRow(int64(7), "beads.bead.updated", "bd-a", "crew/bot", []byte(`{}`), now))
+	events, err := queryListEventsByActor(context.Background(), db, "crew/bot", 5)
+	if err != nil || len(events) != 1 || events[0].ID != 7 {
+		t.Fatalf("events %v, err %v", events, err)
+	}
+
+	mock.ExpectQuery("FROM comments\\s+WHERE author = \\$1\\s+ORDER BY id DESC\\s+LIMIT \\$2").WithArgs("crew/bot", 5).
+		WillReturnRows(sqlmock.NewRows(commentCols).
+			AddRow(int64(3), "bd-a", "crew/bot", "", true, "halfway there", now, nil, nil, nil))
+	comments, err := queryListCommentsByAuthor(context.Background(), db, "crew/bot", 5)
+	if err != nil || len(comments) != 1 || comments[0].Text != "halfway there" {
+		t.Fatalf("comments %v, err %v", comments, err)
+	}
+}
+
+func TestQueryNotifications(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	cols := []string{"id", "actor", "created_at", "read_at", "id", "topic", "bead_id", "actor", "payload", "created_at"}
+	mock.ExpectQuery("FROM notifications n\\s+JOIN events e .+ WHERE n.actor = \\$1 AND n.read_at IS NULL ORDER BY n.id DESC LIMIT \\$2").
+		WithArgs("alice", 10).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(int64(3), "alice", now, nil, int64(9), "beads.comment.added", "bd-a", "bob", []byte(`{}`), now))
+
+	notifications, err := queryListNotifications(context.Background(), db, "alice", true, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifications) != 1 || notifications[0].ReadAt != nil || notifications[0].Event.Actor != "bob" {
+		t.Fatalf("unexpected notifications: %+v", notifications)
+	}
+
+	mock.ExpectExec("UPDATE notifications SET read_at = NOW\\(\\) WHERE actor = \\$1 AND read_at IS NULL AND id IN \\(\\$2, \\$3\\)").
+		WithArgs("alice", int64(3), int64(4)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	marked, err := queryMarkNotificationsRead(context.Background(), db, "alice", []int64{3, 4})
+	if err != nil || marked != 2 {
+		t.Fatalf("marked = %d, err = %v", marked, err)
+	}
+
+	mock.ExpectExec("INSERT INTO notifications .+ unnest\\(\\$2::text\\[\\]\\) .+ NOT EXISTS").
+		WithArgs(int64(9), pq.Array([]string{"carol"})).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := queryNotifyActors(context.Background(), db, 9, []string{"carol"}); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+}
+
+func TestQueryDigests(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	mock.ExpectQuery("INSERT INTO digests").
+		WithArgs("alice:open", now, []byte(`["bd-a","bd-b"]`), []byte(`[]`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(4)))
+	d := &model.Digest{Subscription: "alice:open", GeneratedAt: now, BeadIDs: []string{"bd-a", "bd-b"}}
+	if err := queryCreateDigest(context.Background(), db, d); err != nil || d.ID != 4 {
+		t.Fatalf("id = %d, err = %v", d.ID, err)
+	}
+
+	mock.ExpectQuery("SELECT .+ FROM digests\\s+WHERE subscription = \\$1\\s+ORDER BY generated_at DESC").WithArgs("alice:open").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "subscription", "generated_at", "bead_ids", "new_ids"}).
+			AddRow(int64(4), "alice:open", now, []byte(`["bd-a","bd-b"]`), []byte(`["bd-b"]`)))
+	got, err := queryGetLatestDigest(context.Background(), db, "alice:open")
+	if err != nil || len(got.BeadIDs) != 2 || len(got.NewIDs) != 1 || got.NewIDs[0] != "bd-b" {
+		t.Fatalf("got %+v, err %v", got, err)
+	}
+}
+
+func TestQueryListBeads(t *testing.T) {
+	now := time.Now().UTC()
+	pri := func(v int) *int { return &v }
+
+	for _, tc := range []struct {
+		name      string
 		filter    model.BeadFilter
 		queryPat  string
 		args      []driver.Value
@@ -509,14 +1556,22 @@ func TestQueryListBeads(t *testing.T) {
 		{
 			name:      "NoFilter",
 			filter:    model.BeadFilter{},
-			queryPat:  "SELECT COUNT\\(\\*\\) OVER\\(\\) AS total_count, .+ FROM beads ORDER BY created_at DESC",
+			queryPat:  "SELECT COUNT\\(\\*\\) OVER\\(\\) AS total_count, .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL ORDER BY created_at DESC",
+			wantCount: 2,
+			wantTotal: 2,
+		},
+		{
+			name:      "IncludeArchived",
+			filter:    model.BeadFilter{Status: []model.Status{model.StatusClosed}, IncludeArchived: true},
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND status IN \\(\\$1\\) ORDER BY",
+			args:      []driver.Value{"closed"},
 			wantCount: 2,
 			wantTotal: 2,
 		},
 		{
 			name:      "FilterByStatus",
 			filter:    model.BeadFilter{Status: []model.Status{model.StatusOpen, model.StatusDeferred}},
-			queryPat:  "SELECT .+ FROM beads WHERE status IN \\(\\$1, \\$2\\) ORDER BY",
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND status IN \\(\\$1, \\$2\\) ORDER BY",
 			args:      []driver.Value{"open", "deferred"},
 			wantCount: 1,
 			wantTotal: 1,
@@ -524,7 +1579,7 @@ func TestQueryListBeads(t *testing.T) {
 		{
 			name:      "FilterByType",
 			filter:    model.BeadFilter{Type: []model.BeadType{model.TypeBug}},
-			queryPat:  "SELECT .+ FROM beads WHERE type IN \\(\\$1\\) ORDER BY",
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND type IN \\(\\$1\\) ORDER BY",
 			args:      []driver.Value{"bug"},
 			wantCount: 1,
 			wantTotal: 1,
@@ -532,13 +1587,13 @@ func TestQueryListBeads(t *testing.T) {
 		{
 			name:     "FilterByKind",
 			filter:   model.BeadFilter{Kind: []model.Kind{model.KindData}},
-			queryPat: "SELECT .+ FROM beads WHERE kind IN \\(\\$1\\) ORDER BY",
+			queryPat: "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND kind IN \\(\\$1\\) ORDER BY",
 			args:     []driver.Value{"data"},
 		},
 		{
 			name:      "FilterByPriority",
 			filter:    model.BeadFilter{Priority: pri(3)},
-			queryPat:  "SELECT .+ FROM beads WHERE priority = \\$1 ORDER BY",
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND priority = \\$1 ORDER BY",
 			args:      []driver.Value{3},
 			wantCount: 1,
 			wantTotal: 1,
@@ -546,7 +1601,7 @@ func TestQueryListBeads(t *testing.T) {
 		{
 			name:      "FilterByAssignee",
 			filter:    model.BeadFilter{Assignee: "alice"},
-			queryPat:  "SELECT .+ FROM beads WHERE assignee = \\$1 ORDER BY",
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND assignee = \\$1 ORDER BY",
 			args:      []driver.Value{"alice"},
 			wantCount: 1,
 			wantTotal: 1,
@@ -554,15 +1609,23 @@ func TestQueryListBeads(t *testing.T) {
 		{
 			name:      "FilterByLabels",
 			filter:    model.BeadFilter{Labels: []string{"urgent"}},
-			queryPat:  "SELECT .+ FROM beads WHERE EXISTS \\(SELECT 1 FROM labels .+\\) ORDER BY",
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND EXISTS \\(SELECT 1 FROM labels .+\\) ORDER BY",
 			args:      []driver.Value{"urgent"},
 			wantCount: 1,
 			wantTotal: 1,
 		},
+		{
+			name:      "FilterByLabelNamespace",
+			filter:    model.BeadFilter{Labels: []string{"team:*"}},
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND EXISTS \\(SELECT 1 FROM labels WHERE labels.bead_id = beads.id AND labels.namespace = \\$1\\) ORDER BY",
+			args:      []driver.Value{"team"},
+			wantCount: 1,
+			wantTotal: 1,
+		},
 		{
 			name:      "FilterBySearch",
 			filter:    model.BeadFilter{Search: "login"},
-			queryPat:  "SELECT .+ FROM beads WHERE \\(title ILIKE .+\\) ORDER BY",
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND \\(title ILIKE .+\\) ORDER BY",
 			args:      []driver.Value{"login"},
 			wantCount: 1,
 			wantTotal: 1,
@@ -570,7 +1633,7 @@ func TestQueryListBeads(t *testing.T) {
 		{
 			name:      "WithLimitAndOffset",
 			filter:    model.BeadFilter{Limit: 10, Offset: 5},
-			queryPat:  "SELECT .+ FROM beads ORDER BY .+ LIMIT \\$1 OFFSET \\$2",
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL ORDER BY .+ LIMIT \\$1 OFFSET \\$2",
 			args:      []driver.Value{10, 5},
 			wantCount: 1,
 			wantTotal: 20,
@@ -578,20 +1641,44 @@ func TestQueryListBeads(t *testing.T) {
 		{
 			name:     "WithSort",
 			filter:   model.BeadFilter{Sort: "-priority"},
-			queryPat: "SELECT .+ FROM beads ORDER BY priority DESC",
+			queryPat: "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL ORDER BY priority DESC",
 		},
 		{
 			name:      "FilterByField",
 			filter:    model.BeadFilter{Fields: map[string]string{"sprint": "3"}},
-			queryPat:  "SELECT .+ FROM beads WHERE fields->>\\$1 = \\$2 ORDER BY",
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND fields->>\\$1 = \\$2 ORDER BY",
 			args:      []driver.Value{"sprint", "3"},
 			wantCount: 1,
 			wantTotal: 1,
 		},
+		{
+			name:      "FilterByTimeRange",
+			filter:    model.BeadFilter{UpdatedAfter: &now, ClosedBefore: &now},
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND updated_at >= \\$1 AND closed_at < \\$2 ORDER BY",
+			args:      []driver.Value{now, now},
+			wantCount: 1,
+			wantTotal: 1,
+		},
+		{
+			name:      "FilterByQuery",
+			filter:    model.BeadFilter{Query: "status:open AND (label:urgent OR priority<=1) AND created>2026-01-02"},
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND \\(\\(\\(COALESCE\\(status, ''\\) = \\$1\\) AND \\(\\(EXISTS \\(SELECT 1 FROM labels .+ = \\$2\\)\\) OR priority <= \\$3\\)\\) AND created_at > \\$4\\) ORDER BY",
+			args:      []driver.Value{"open", "urgent", 1, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)},
+			wantCount: 1,
+			wantTotal: 1,
+		},
+		{
+			name:      "FilterByZeroDate",
+			filter:    model.BeadFilter{Query: "created>0001-01-01"},
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND created_at > \\$1 ORDER BY",
+			args:      []driver.Value{time.Time{}},
+			wantCount: 1,
+			wantTotal: 1,
+		},
 		{
 			name:      "CombinedFilters",
 			filter:    model.BeadFilter{Status: []model.Status{model.StatusOpen}, Assignee: "bob", Limit: 5},
-			queryPat:  "SELECT .+ FROM beads WHERE status IN \\(\\$1\\) AND assignee = \\$2 ORDER BY .+ LIMIT \\$3",
+			queryPat:  "SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND status IN \\(\\$1\\) AND assignee = \\$2 ORDER BY .+ LIMIT \\$3",
 			args:      []driver.Value{"open", "bob", 5},
 			wantCount: 1,
 			wantTotal: 3,
@@ -623,6 +1710,100 @@ func TestQueryListBeads(t *testing.T) {
 	}
 }
 
+func TestQueryListReadyBeads(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	pri := 1
+
+	r := sqlmock.NewRows(beadWithTotalColumns)
+	addBeadWithTotalRow(r, 4, "bd-1", "issue", "task", "T", "open", 1, now)
+	mock.ExpectQuery("SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND NOT EXISTS \\(SELECT 1 FROM deps d JOIN beads blocker .+ blocker.status <> 'closed' .+\\)\\s+AND NOT EXISTS \\(SELECT 1 FROM external_deps x WHERE x.bead_id = beads.id AND x.status = 'waiting'\\) AND status IN \\(\\$1\\) AND priority = \\$2 .*ORDER BY priority .+ LIMIT \\$3").
+		WithArgs("open", 1, 1).
+		WillReturnRows(r)
+
+	beads, total, err := queryListReadyBeads(context.Background(), db, model.BeadFilter{Priority: &pri, Sort: "priority", Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(beads) != 1 || total != 4 {
+		t.Fatalf("expected 1 bead of 4, got %d of %d", len(beads), total)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryListBlockedBeads(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+
+	r := sqlmock.NewRows(beadWithTotalColumns)
+	addBeadWithTotalRow(r, 1, "bd-2", "issue", "task", "T", "open", 2, now)
+	mock.ExpectQuery("SELECT .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND NOT \\(NOT EXISTS \\(SELECT 1 FROM deps d JOIN beads blocker .+ FROM external_deps x .+\\) AND status IN \\(\\$1\\)").
+		WithArgs("open").
+		WillReturnRows(r)
+
+	beads, total, err := queryListBlockedBeads(context.Background(), db, model.BeadFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(beads) != 1 || total != 1 || beads[0].ID != "bd-2" {
+		t.Fatalf("expected bd-2, got %d of %d", len(beads), total)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryListAgents(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+	mock.ExpectQuery("FROM agents ORDER BY name").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "bead_id", "token_hash", "hook_token_hash", "created_at", "created_by"}).
+			AddRow("crew/a", "bd-a", "h1", "", now, "admin").
+			AddRow("crew/b", "bd-b", "h2", "", now, "admin"))
+	agents, err := queryListAgents(context.Background(), db)
+	if err != nil || len(agents) != 2 || agents[1].BeadID != "bd-b" {
+		t.Fatalf("agents %v, err %v", agents, err)
+	}
+}
+
+func TestQueryStreamBeads(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+
+	r := sqlmock.NewRows(beadWithTotalColumns[1:])
+	for _, id := range []string{"bd-1", "bd-2", "bd-3"} {
+		r.AddRow(
+			id, nil, "issue", "task", "T", nil, nil,
+			"open", 0, nil, nil, now, nil, now,
+			nil, nil, nil, nil, nil, "", "",
+			2, 1, now, 0, 0, 0, nil,
+		)
+	}
+	mock.ExpectQuery("SELECT id, .+ FROM beads WHERE deleted_at IS NULL AND archived_at IS NULL AND status IN \\(\\$1\\) ORDER BY id ASC$").
+		WithArgs("open").
+		WillReturnRows(r)
+
+	var got []string
+	err := queryStreamBeads(context.Background(), db, model.BeadFilter{Status: []model.Status{model.StatusOpen}, Sort: "id"}, func(b *model.Bead) error {
+		got = append(got, b.ID)
+		if b.BlockedCount != 1 {
+			t.Errorf("%s: blocked_count = %d, want 1", b.ID, b.BlockedCount)
+		}
+		if len(got) == 2 {
+			return errors.New("stop")
+		}
+		return nil
+	})
+	if err == nil || err.Error() != "stop" {
+		t.Fatalf("expected the callback error, got %v", err)
+	}
+	if strings.Join(got, ",") != "bd-1,bd-2" {
+		t.Fatalf("streamed %v, want bd-1,bd-2", got)
+	}
+}
+
 func TestQueryCloseBead(t *testing.T) {
 	db, mock := newMockDB(t)
 	now := time.Now().UTC()
@@ -631,7 +1812,7 @@ func TestQueryCloseBead(t *testing.T) {
 	rows.AddRow(
 		"bd-cls1", nil, "issue", "task", "Close me", nil, nil,
 		"closed", 0, nil, nil, now, nil, now,
-		now, "alice", nil, nil, nil,
+		now, "alice", nil, nil, nil, "", "",
 	)
 	mock.ExpectQuery("UPDATE beads SET").WithArgs("bd-cls1", "alice").WillReturnRows(rows)
 	emptyRelationalExpectations(mock, "bd-cls1")
@@ -648,6 +1829,37 @@ func TestQueryCloseBead(t *testing.T) {
 	}
 }
 
+func TestQueryClaimReadyBead(t *testing.T) {
+	db, mock := newMockDB(t)
+	now := time.Now().UTC()
+
+	rows := sqlmock.NewRows(beadRowColumns)
+	rows.AddRow(
+		"bd-q1", nil, "issue", "task", "Next up", nil, nil,
+		"in_progress", 0, "crew/bot", nil, now, nil, now,
+		nil, nil, nil, nil, nil, "", "",
+	)
+	mock.ExpectQuery("UPDATE beads SET status = 'in_progress', assignee = \\$1, .+ WHERE id = \\(\\s+SELECT id FROM beads .+ status = 'open'\\s+AND kind = 'issue' AND type <> 'gate' .+ NOT EXISTS .+ labels.label IN \\(\\$2, \\$3\\)\\).+ORDER BY priority ASC, created_at ASC\\s+LIMIT 1\\s+FOR UPDATE SKIP LOCKED").
+		WithArgs("crew/bot", "go", "db").
+		WillReturnRows(rows)
+
+	bead, err := queryClaimReadyBead(context.Background(), db, "crew/bot", []string{"go", "db"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bead.ID != "bd-q1" || bead.Assignee != "crew/bot" || bead.Status != model.StatusInProgress {
+		t.Fatalf("got %+v", bead)
+	}
+
+	mock.ExpectQuery("FOR UPDATE SKIP LOCKED").WithArgs("crew/bot").WillReturnError(sql.ErrNoRows)
+	if _, err := queryClaimReadyBead(context.Background(), db, "crew/bot", nil); err != sql.ErrNoRows {
+		t.Fatalf("expected sql.ErrNoRows, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestQueryCloseBead_NotFound(t *testing.T) {
 	db, mock := newMockDB(t)
 	mock.ExpectQuery("UPDATE beads SET").WithArgs("nonexistent", "").WillReturnError(sql.ErrNoRows)
@@ -666,7 +1878,7 @@ func TestQueryCloseBead_WithRelationalData(t *testing.T) {
 	rows.AddRow(
 		"bd-cls2", nil, "issue", "task", "Close me", nil, nil,
 		"closed", 0, nil, nil, now, nil, now,
-		now, "bob", nil, nil, nil,
+		now, "bob", nil, nil, nil, "", "",
 	)
 	mock.ExpectQuery("UPDATE beads SET").WithArgs("bd-cls2", "bob").WillReturnRows(rows)
 
@@ -679,8 +1891,12 @@ func TestQueryCloseBead_WithRelationalData(t *testing.T) {
 			AddRow("bd-cls2", "bd-other", "blocks", now, nil, nil))
 	// Comments
 	mock.ExpectQuery("SELECT .+ FROM comments WHERE bead_id = \\$1").WithArgs("bd-cls2").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "bead_id", "author", "text", "created_at"}).
-			AddRow(int64(1), "bd-cls2", "alice", "Done!", now))
+		WillReturnRows(sqlmock.NewRows(commentCols).
+			AddRow(int64(1), "bd-cls2", "alice", "", false, "Done!", now, nil, nil, nil))
+	// Checklist
+	mock.ExpectQuery("SELECT .+ FROM checklist_items WHERE bead_id = \\$1").WithArgs("bd-cls2").
+		WillReturnRows(sqlmock.NewRows(checklistCols).
+			AddRow("bd-cls2", 1, "Shipped", true, now, "bob", "alice", now))
 
 	bead, err := queryCloseBead(context.Background(), db, "bd-cls2", "bob")
 	if err != nil {
@@ -695,6 +1911,9 @@ func TestQueryCloseBead_WithRelationalData(t *testing.T) {
 	if len(bead.Comments) != 1 {
 		t.Fatalf("expected 1 comment, got %d", len(bead.Comments))
 	}
+	if len(bead.Checklist) != 1 {
+		t.Fatalf("expected 1 checklist item, got %d", len(bead.Checklist))
+	}
 }
 
 func TestScanBead_WithOptionalFields(t *testing.T) {
@@ -706,11 +1925,11 @@ func TestScanBead_WithOptionalFields(t *testing.T) {
 	rows := sqlmock.NewRows([]string{
 		"id", "slug", "kind", "type", "title", "description", "notes",
 		"status", "priority", "assignee", "owner", "created_at", "created_by", "updated_at",
-		"closed_at", "closed_by", "due_at", "defer_until", "fields",
+		"closed_at", "closed_by", "due_at", "defer_until", "fields", "estimate", "actual",
 	}).AddRow(
 		"bd-full", "test-slug", "issue", "task", "Full bead", "A description", "Some notes",
 		"closed", 2, "bob", "alice", now, "carol", now,
-		closedAt, "dave", dueAt, nil, []byte(`{"foo":"bar"}`),
+		closedAt, "dave", dueAt, nil, []byte(`{"foo":"bar"}`), "5", "6h",
 	)
 	mock.ExpectQuery("SELECT").WillReturnRows(rows)
 
@@ -733,4 +1952,7 @@ func TestScanBead_WithOptionalFields(t *testing.T) {
 	if string(bead.Fields) != `{"foo":"bar"}` {
 		t.Fatalf("got fields=%s", bead.Fields)
 	}
+	if bead.Estimate != "5" || bead.Actual != "6h" {
+		t.Fatalf("got estimate=%q actual=%q", bead.Estimate, bead.Actual)
+	}
 }
@@ -3,34 +3,93 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/query"
+	"github.com/alfredjeanlab/beads/internal/store"
+	"github.com/lib/pq"
 )
 
 // beadColumns is the column list used for SELECT statements on the beads table.
 const beadColumns = `id, slug, kind, type, title, description, notes,
 	status, priority, assignee, owner, created_at, created_by, updated_at,
-	closed_at, closed_by, due_at, defer_until, fields`
+	closed_at, closed_by, due_at, defer_until, fields, estimate, actual`
 
-// executor is the interface satisfied by both *sql.DB and *sql.Tx.
+// blockingDep matches deps d of a blocking type: "blocks", or any type whose
+// "deptype:<name>" config sets "blocking": true.
+const blockingDep = `(d.type = 'blocks' OR EXISTS (SELECT 1 FROM configs dt
+	WHERE dt.key = 'deptype:' || d.type AND dt.value->>'blocking' = 'true'))`
+
+// computedColumns are virtual columns derived at read time, appended after
+// beadColumns by queryGetBead and queryListBeads (see scanComputed), followed
+// by archived_at, which only the archival policy writes.
+// The computed aliases are also accepted as sort keys.
+const computedColumns = `,
+	FLOOR(EXTRACT(EPOCH FROM NOW() - beads.created_at) / 86400)::int AS age_days,
+	` + blockedCount + ` AS blocked_count,
+	GREATEST(beads.updated_at,
+		(SELECT MAX(created_at) FROM comments WHERE comments.bead_id = beads.id),
+		(SELECT MAX(created_at) FROM events WHERE events.bead_id = beads.id)) AS last_activity_at,
+	(SELECT COUNT(*) FILTER (WHERE checked) FROM checklist_items ci WHERE ci.bead_id = beads.id) AS checklist_done,
+	(SELECT COUNT(*) FROM checklist_items ci WHERE ci.bead_id = beads.id) AS checklist_total,
+	` + effectivePriority + ` AS effective_priority,
+	beads.archived_at`
+
+// blockedCount counts the unclosed beads a bead blocks.
+const blockedCount = `(SELECT COUNT(*) FROM deps d JOIN beads b2 ON b2.id = d.bead_id
+		WHERE d.depends_on_id = beads.id AND ` + blockingDep + `
+		AND b2.status <> 'closed' AND b2.deleted_at IS NULL)`
+
+// effectivePriority is the highest priority (lowest number) among a bead
+// and the unclosed beads it blocks, directly or through other blocked
+// beads: a P4 blocking a P0 is effectively a P0.
+const effectivePriority = `(WITH RECURSIVE blocked(id) AS (
+		SELECT beads.id
+		UNION
+		SELECT d.bead_id FROM deps d JOIN blocked ON d.depends_on_id = blocked.id
+			JOIN beads b2 ON b2.id = d.bead_id
+		WHERE ` + blockingDep + `
+			AND b2.status <> 'closed' AND b2.deleted_at IS NULL)
+	SELECT MIN(b3.priority) FROM blocked JOIN beads b3 ON b3.id = blocked.id)`
+
+// urgencyScore is the "urgency" sort key: higher is more urgent. It adds
+//   - 10 points per priority level above P4 (P0 = 40),
+//   - up to 30 points as due_at approaches, rising over the last 14 days
+//     and capped once overdue,
+//   - 5 points per unclosed bead it blocks, up to 20,
+//   - 1 point per week of age, up to 10.
+const urgencyScore = `(
+	(4 - LEAST(GREATEST(beads.priority, 0), 4)) * 10
+	+ COALESCE(LEAST(30, GREATEST(0, 30 - EXTRACT(EPOCH FROM beads.due_at - NOW()) / 86400 * 30 / 14)), 0)
+	+ LEAST(` + blockedCount + ` * 5, 20)
+	+ LEAST(FLOOR(EXTRACT(EPOCH FROM NOW() - beads.created_at) / 604800), 10))`
+
+// executor is the interface satisfied by both *sql.DB and *sql.Tx, and by
+// their traced wrappers.
 type executor interface {
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
+// queryCreateBead inserts a bead. Returns store.ErrSlugTaken if a live bead
+// already has its slug.
 func queryCreateBead(ctx context.Context, db executor, b *model.Bead) error {
 	_, err := db.ExecContext(ctx, `
 		INSERT INTO beads (
 			id, slug, kind, type, title, description, notes,
 			status, priority, assignee, owner, created_at, created_by, updated_at,
-			closed_at, closed_by, due_at, defer_until, fields
+			closed_at, closed_by, due_at, defer_until, fields, estimate, actual
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7,
 			$8, $9, $10, $11, $12, $13, $14,
-			$15, $16, $17, $18, $19
+			$15, $16, $17, $18, $19, $20, $21
 		)`,
 		b.ID,
 		nullString(b.Slug),
@@ -51,13 +110,19 @@ func queryCreateBead(ctx context.Context, db executor, b *model.Bead) error {
 		nullTimePtr(b.DueAt),
 		nullTimePtr(b.DeferUntil),
 		jsonbBytes(b.Fields),
+		b.Estimate,
+		b.Actual,
 	)
+	var pe *pq.Error
+	if errors.As(err, &pe) && pe.Code == "23505" && pe.Constraint == "idx_beads_slug" {
+		return store.ErrSlugTaken
+	}
 	return err
 }
 
 func queryGetBead(ctx context.Context, db executor, id string) (*model.Bead, error) {
-	row := db.QueryRowContext(ctx, `SELECT `+beadColumns+` FROM beads WHERE id = $1`, id)
-	b, err := scanBead(row)
+	row := db.QueryRowContext(ctx, `SELECT `+beadColumns+computedColumns+` FROM beads WHERE id = $1 AND deleted_at IS NULL`, id)
+	b, err := scanComputed(row, scanBead)
 	if err != nil {
 		return nil, err
 	}
@@ -83,15 +148,158 @@ func queryGetBead(ctx context.Context, db executor, id string) (*model.Bead, err
 	}
 	b.Comments = comments
 
+	// Fetch the checklist.
+	checklist, err := queryGetChecklist(ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	b.Checklist = checklist
+
+	// Explain an inherited priority.
+	if b.EffectivePriority != nil && *b.EffectivePriority < b.Priority {
+		if b.PriorityChain, err = queryPriorityChain(ctx, db, id); err != nil {
+			return nil, err
+		}
+	}
+
 	return b, nil
 }
 
+// queryPriorityChain returns the blocking chain of bead IDs from id to the
+// highest-priority unclosed bead it transitively blocks, preferring the
+// shortest chain.
+func queryPriorityChain(ctx context.Context, db executor, id string) ([]string, error) {
+	var chain []string
+	err := db.QueryRowContext(ctx, `
+		WITH RECURSIVE chain(id, path) AS (
+			SELECT $1::text, ARRAY[$1::text]
+			UNION ALL
+			SELECT d.bead_id, chain.path || d.bead_id
+			FROM deps d JOIN chain ON d.depends_on_id = chain.id
+				JOIN beads b2 ON b2.id = d.bead_id
+			WHERE `+blockingDep+`
+				AND b2.status <> 'closed' AND b2.deleted_at IS NULL
+				AND d.bead_id <> ALL(chain.path)
+		)
+		SELECT chain.path FROM chain JOIN beads b ON b.id = chain.id
+		ORDER BY b.priority, cardinality(chain.path), chain.id
+		LIMIT 1`,
+		id,
+	).Scan(pq.Array(&chain))
+	if err != nil {
+		return nil, fmt.Errorf("priority chain: %w", err)
+	}
+	return chain, nil
+}
+
 func queryListBeads(ctx context.Context, db executor, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	return queryListBeadsWhere(ctx, db, filter)
+}
+
+// readyClause excludes beads with an unclosed, non-deleted blocker or a
+// waiting external dependency.
+const readyClause = `NOT EXISTS (SELECT 1 FROM deps d JOIN beads blocker ON blocker.id = d.depends_on_id
+	WHERE d.bead_id = beads.id AND ` + blockingDep + `
+	AND blocker.status <> 'closed' AND blocker.deleted_at IS NULL)
+	AND NOT EXISTS (SELECT 1 FROM external_deps x WHERE x.bead_id = beads.id AND x.status = 'waiting')`
+
+// queryListReadyBeads lists the beads matching filter that nothing unclosed
+// blocks, in a single query. Status defaults to open.
+func queryListReadyBeads(ctx context.Context, db executor, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	if len(filter.Status) == 0 {
+		filter.Status = []model.Status{model.StatusOpen}
+	}
+	return queryListBeadsWhere(ctx, db, filter, readyClause)
+}
+
+// queryListBlockedBeads lists the beads matching filter that have an
+// unclosed blocker or a waiting external dependency. Status defaults to
+// open.
+func queryListBlockedBeads(ctx context.Context, db executor, filter model.BeadFilter) ([]*model.Bead, int, error) {
+	if len(filter.Status) == 0 {
+		filter.Status = []model.Status{model.StatusOpen}
+	}
+	return queryListBeadsWhere(ctx, db, filter, "NOT ("+readyClause+")")
+}
+
+// queryListBeadsWhere lists beads matching filter and any extra WHERE
+// clauses, which must not take arguments.
+func queryListBeadsWhere(ctx context.Context, db executor, filter model.BeadFilter, extra ...string) ([]*model.Bead, int, error) {
+	// Single query with COUNT(*) OVER() to get total and rows atomically.
+	dataQuery, args, err := beadListQuery(filter, "COUNT(*) OVER() AS total_count, "+beadColumns+computedColumns, extra...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := db.QueryContext(ctx, dataQuery, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list beads: %w", err)
+	}
+	defer rows.Close()
+
+	var beads []*model.Bead
+	var total int
+	for rows.Next() {
+		b, err := scanComputed(rows, func(row scannable) (*model.Bead, error) {
+			b, t, err := scanBeadWithTotal(row)
+			total = t
+			return b, err
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan beads: %w", err)
+		}
+		beads = append(beads, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("scan beads: %w", err)
+	}
+
+	return beads, total, nil
+}
+
+// queryStreamBeads calls fn with each bead matching filter as its row is
+// read, so memory use does not grow with the result. It stops at the first
+// error from fn. Cancelling ctx aborts the query.
+func queryStreamBeads(ctx context.Context, db executor, filter model.BeadFilter, fn func(*model.Bead) error) error {
+	dataQuery, args, err := beadListQuery(filter, beadColumns+computedColumns)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.QueryContext(ctx, dataQuery, args...)
+	if err != nil {
+		return fmt.Errorf("stream beads: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		b, err := scanComputed(rows, scanBead)
+		if err != nil {
+			return fmt.Errorf("scan beads: %w", err)
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("scan beads: %w", err)
+	}
+	return nil
+}
+
+// beadListQuery builds the SELECT of selectList from beads matching filter
+// and any extra WHERE clauses, with its arguments. It fails only if
+// filter.Query does not parse.
+func beadListQuery(filter model.BeadFilter, selectList string, extra ...string) (string, []any, error) {
 	var (
-		whereClauses []string
+		whereClauses = []string{"deleted_at IS NULL"}
 		args         []any
 		argIdx       int
 	)
+	if !filter.IncludeArchived {
+		whereClauses = append(whereClauses, "archived_at IS NULL")
+	}
+	whereClauses = append(whereClauses, extra...)
 
 	nextArg := func() string {
 		argIdx++
@@ -137,9 +345,14 @@ func queryListBeads(ctx context.Context, db executor, filter model.BeadFilter) (
 
 	if len(filter.Labels) > 0 {
 		for _, label := range filter.Labels {
+			// "ns:*" matches any label in the namespace.
+			column := "label"
+			if ns, ok := strings.CutSuffix(label, ":*"); ok && ns != "" {
+				column, label = "namespace", ns
+			}
 			p := nextArg()
 			whereClauses = append(whereClauses,
-				fmt.Sprintf("EXISTS (SELECT 1 FROM labels WHERE labels.bead_id = beads.id AND labels.label = %s)", p))
+				fmt.Sprintf("EXISTS (SELECT 1 FROM labels WHERE labels.bead_id = beads.id AND labels.%s = %s)", column, p))
 			args = append(args, label)
 		}
 	}
@@ -158,13 +371,37 @@ func queryListBeads(ctx context.Context, db executor, filter model.BeadFilter) (
 		args = append(args, key, val)
 	}
 
-	whereSQL := ""
-	if len(whereClauses) > 0 {
-		whereSQL = " WHERE " + strings.Join(whereClauses, " AND ")
+	for _, r := range []struct {
+		clause string
+		bound  *time.Time
+	}{
+		{"created_at >= ", filter.CreatedAfter},
+		{"created_at < ", filter.CreatedBefore},
+		{"updated_at >= ", filter.UpdatedAfter},
+		{"updated_at < ", filter.UpdatedBefore},
+		{"closed_at >= ", filter.ClosedAfter},
+		{"closed_at < ", filter.ClosedBefore},
+	} {
+		if r.bound != nil {
+			whereClauses = append(whereClauses, r.clause+nextArg())
+			args = append(args, *r.bound)
+		}
 	}
 
-	// Single query with COUNT(*) OVER() to get total and rows atomically.
-	dataQuery := "SELECT COUNT(*) OVER() AS total_count, " + beadColumns + " FROM beads" + whereSQL + " ORDER BY " + parseSortClause(filter.Sort)
+	q, err := query.Parse(filter.Query)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid query: %w", err)
+	}
+	if q != nil {
+		whereClauses = append(whereClauses, queryClause(q, func(v any) string {
+			args = append(args, v)
+			return nextArg()
+		}))
+	}
+
+	whereSQL := " WHERE " + strings.Join(whereClauses, " AND ")
+
+	dataQuery := "SELECT " + selectList + " FROM beads" + whereSQL + " ORDER BY " + parseSortClause(filter.Sort)
 
 	if filter.Limit > 0 {
 		dataQuery += " LIMIT " + nextArg()
@@ -174,32 +411,113 @@ func queryListBeads(ctx context.Context, db executor, filter model.BeadFilter) (
 		dataQuery += " OFFSET " + nextArg()
 		args = append(args, filter.Offset)
 	}
+	return dataQuery, args, nil
+}
 
-	rows, err := db.QueryContext(ctx, dataQuery, args...)
-	if err != nil {
-		return nil, 0, fmt.Errorf("list beads: %w", err)
+// queryColumns maps query language fields to bead columns.
+var queryColumns = map[string]string{
+	"status":   "status",
+	"type":     "type",
+	"kind":     "kind",
+	"assignee": "assignee",
+	"owner":    "owner",
+	"priority": "priority",
+	"created":  "created_at",
+	"updated":  "updated_at",
+	"closed":   "closed_at",
+	"due":      "due_at",
+	"defer":    "defer_until",
+}
+
+// queryClause compiles a parsed query to a WHERE clause, passing each value
+// to arg, which returns its placeholder.
+func queryClause(n query.Node, arg func(any) string) string {
+	switch n := n.(type) {
+	case query.And:
+		return "(" + queryClause(n.Left, arg) + " AND " + queryClause(n.Right, arg) + ")"
+	case query.Or:
+		return "(" + queryClause(n.Left, arg) + " OR " + queryClause(n.Right, arg) + ")"
+	case query.Not:
+		return "NOT " + queryClause(n.X, arg)
 	}
-	defer rows.Close()
 
-	var beads []*model.Bead
-	var total int
-	for rows.Next() {
-		b, t, err := scanBeadWithTotal(rows)
-		if err != nil {
-			return nil, 0, fmt.Errorf("scan beads: %w", err)
+	c := n.(query.Cond)
+	op := string(c.Op)
+	if c.Op == query.OpNe {
+		op = "<>"
+	}
+	switch {
+	case c.Field == "priority":
+		return "priority " + op + " " + arg(c.Int)
+	case c.IsTime():
+		return queryColumns[c.Field] + " " + op + " " + arg(c.Time)
+	}
+
+	// String fields: any of the values matches; != matches none of them.
+	alts := make([]string, len(c.Values))
+	for i, v := range c.Values {
+		switch key, custom := strings.CutPrefix(c.Field, "field."); {
+		case custom:
+			alts[i] = "fields->>" + arg(key) + " = " + arg(v)
+		case c.Field == "label":
+			column := "label"
+			if ns, ok := strings.CutSuffix(v, ":*"); ok && ns != "" {
+				column, v = "namespace", ns
+			}
+			alts[i] = "EXISTS (SELECT 1 FROM labels WHERE labels.bead_id = beads.id AND labels." + column + " = " + arg(v) + ")"
+		case c.Field == "text":
+			p := arg(v)
+			alts[i] = "title ILIKE '%' || " + p + " || '%' OR description ILIKE '%' || " + p + " || '%'"
+		default:
+			alts[i] = "COALESCE(" + queryColumns[c.Field] + ", '') = " + arg(v)
 		}
-		total = t
-		beads = append(beads, b)
 	}
-	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("scan beads: %w", err)
+	clause := "(" + strings.Join(alts, " OR ") + ")"
+	if c.Op == query.OpNe {
+		clause = "NOT " + clause
 	}
+	return clause
+}
 
-	return beads, total, nil
+// queryClaimReadyBead claims the next bead of the work queue for actor in one
+// statement. FOR UPDATE SKIP LOCKED makes concurrent claims pass over a bead
+// another claim has locked instead of waiting for it and claiming it again.
+func queryClaimReadyBead(ctx context.Context, db executor, actor string, labels []string) (*model.Bead, error) {
+	args := []any{actor}
+	labelClause := ""
+	if len(labels) > 0 {
+		placeholders := make([]string, len(labels))
+		for i, l := range labels {
+			args = append(args, l)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		labelClause = `
+				AND EXISTS (SELECT 1 FROM labels WHERE labels.bead_id = beads.id AND labels.label IN (` + strings.Join(placeholders, ", ") + `))`
+	}
+	row := db.QueryRowContext(ctx, `
+		UPDATE beads SET status = 'in_progress', assignee = $1, updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM beads
+			WHERE deleted_at IS NULL AND status = 'open'
+				AND kind = 'issue' AND type <> 'gate'
+				AND (assignee = '' OR assignee IS NULL OR assignee = $1)
+				AND `+readyClause+labelClause+`
+			ORDER BY priority ASC, created_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+beadColumns,
+		args...,
+	)
+	return scanBead(row)
 }
 
+// queryUpdateBead writes b only if the row's updated_at still equals
+// b.UpdatedAt, so a change committed since b was read (such as a note
+// append) is never overwritten. It returns store.ErrConflict when the row
+// has moved on and sql.ErrNoRows when it is gone.
 func queryUpdateBead(ctx context.Context, db executor, b *model.Bead) error {
-	return db.QueryRowContext(ctx, `
+	err := db.QueryRowContext(ctx, `
 		UPDATE beads SET
 			slug = $2,
 			kind = $3,
@@ -216,8 +534,11 @@ func queryUpdateBead(ctx context.Context, db executor, b *model.Bead) error {
 			closed_by = $13,
 			due_at = $14,
 			defer_until = $15,
-			fields = $16
-		WHERE id = $1
+			fields = $16,
+			estimate = $18,
+			actual = $19,
+			archived_at = CASE WHEN $8 = 'closed' THEN archived_at END
+		WHERE id = $1 AND deleted_at IS NULL AND updated_at = $17
 		RETURNING updated_at`,
 		b.ID,
 		nullString(b.Slug),
@@ -235,14 +556,28 @@ func queryUpdateBead(ctx context.Context, db executor, b *model.Bead) error {
 		nullTimePtr(b.DueAt),
 		nullTimePtr(b.DeferUntil),
 		jsonbBytes(b.Fields),
+		b.UpdatedAt,
+		b.Estimate,
+		b.Actual,
 	).Scan(&b.UpdatedAt)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	var exists bool
+	if err := db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM beads WHERE id = $1 AND deleted_at IS NULL)`, b.ID).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return store.ErrConflict
+	}
+	return sql.ErrNoRows
 }
 
 func queryCloseBead(ctx context.Context, db executor, id string, closedBy string) (*model.Bead, error) {
 	row := db.QueryRowContext(ctx, `
 		UPDATE beads
 		SET status = 'closed', closed_at = NOW(), closed_by = $2, updated_at = NOW()
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 		RETURNING `+beadColumns,
 		id, closedBy,
 	)
@@ -270,6 +605,12 @@ func queryCloseBead(ctx context.Context, db executor, id string, closedBy string
 	}
 	b.Comments = comments
 
+	checklist, err := queryGetChecklist(ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	b.Checklist = checklist
+
 	return b, nil
 }
 
@@ -288,6 +629,184 @@ func queryDeleteBead(ctx context.Context, db executor, id string) error {
 	return nil
 }
 
+func querySoftDeleteBead(ctx context.Context, db executor, id, deletedBy string) error {
+	res, err := db.ExecContext(ctx, `
+		UPDATE beads SET deleted_at = NOW(), deleted_by = $2
+		WHERE id = $1 AND deleted_at IS NULL`,
+		id, deletedBy,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// queryRestoreBead brings a trashed bead back, dropping its slug if a live
+// bead has taken it since.
+func queryRestoreBead(ctx context.Context, db executor, id string) (*model.Bead, error) {
+	res, err := db.ExecContext(ctx, `
+		UPDATE beads SET deleted_at = NULL, deleted_by = '', updated_at = NOW(),
+			slug = CASE WHEN EXISTS (
+				SELECT 1 FROM beads o WHERE o.slug = beads.slug AND o.deleted_at IS NULL
+			) THEN NULL ELSE slug END
+		WHERE id = $1 AND deleted_at IS NOT NULL`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("rows affected: %w", err)
+	}
+	if n == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return queryGetBead(ctx, db, id)
+}
+
+func queryListDeletedBeads(ctx context.Context, db executor) ([]*model.Bead, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+beadColumns+`, deleted_at, deleted_by
+		FROM beads
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id`)
+	if err != nil {
+		return nil, fmt.Errorf("list deleted beads: %w", err)
+	}
+	defer rows.Close()
+
+	var beads []*model.Bead
+	for rows.Next() {
+		b, err := scanDeletedBead(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan deleted beads: %w", err)
+		}
+		beads = append(beads, b)
+	}
+	return beads, rows.Err()
+}
+
+func queryPurgeDeletedBeads(ctx context.Context, db executor, before time.Time) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		DELETE FROM beads
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+		RETURNING id`,
+		before,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("purge deleted beads: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func queryArchiveClosedBeads(ctx context.Context, db executor, closedBefore time.Time) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		UPDATE beads SET archived_at = NOW()
+		WHERE status = 'closed' AND closed_at < $1
+			AND archived_at IS NULL AND deleted_at IS NULL
+		RETURNING id`,
+		closedBefore,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("archive closed beads: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// mergeStatements move everything hanging off bead $1 onto bead $2. Labels
+// and dependencies the target already has are skipped, as are dependencies
+// that would link the target to itself.
+var mergeStatements = []string{
+	`UPDATE comments SET bead_id = $2 WHERE bead_id = $1`,
+	`UPDATE notes SET bead_id = $2 WHERE bead_id = $1`,
+	`INSERT INTO labels (bead_id, label)
+		SELECT $2, label FROM labels WHERE bead_id = $1
+		ON CONFLICT DO NOTHING`,
+	`DELETE FROM labels WHERE bead_id = $1`,
+	`INSERT INTO deps (bead_id, depends_on_id, type, created_at, created_by, metadata)
+		SELECT $2, depends_on_id, type, created_at, created_by, metadata FROM deps
+		WHERE bead_id = $1 AND depends_on_id <> $2
+		ON CONFLICT DO NOTHING`,
+	`INSERT INTO deps (bead_id, depends_on_id, type, created_at, created_by, metadata)
+		SELECT bead_id, $2, type, created_at, created_by, metadata FROM deps
+		WHERE depends_on_id = $1 AND bead_id <> $2
+		ON CONFLICT DO NOTHING`,
+	`DELETE FROM deps WHERE bead_id = $1 OR depends_on_id = $1`,
+	`UPDATE events SET bead_id = $2 WHERE bead_id = $1`,
+	`UPDATE bead_aliases SET bead_id = $2 WHERE bead_id = $1`,
+	`UPDATE checklist_items SET bead_id = $2,
+		position = position + (SELECT COALESCE(MAX(position), 0) FROM checklist_items WHERE bead_id = $2)
+		WHERE bead_id = $1`,
+}
+
+// queryMergeBead moves comments, notes, labels, dependencies, events and
+// checklist items, numbered after the target's own, from sourceID to
+// targetID. Run it in a transaction.
+func queryMergeBead(ctx context.Context, db executor, sourceID, targetID string) error {
+	for _, stmt := range mergeStatements {
+		if _, err := db.ExecContext(ctx, stmt, sourceID, targetID); err != nil {
+			return fmt.Errorf("merge bead: %w", err)
+		}
+	}
+	return nil
+}
+
+// querySimilarBeads returns unclosed beads whose titles match title by
+// trigram similarity (pg_trgm's % operator), best match first.
+func querySimilarBeads(ctx context.Context, db executor, title, excludeID string, limit int) ([]*model.SimilarBead, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+beadColumns+`, similarity(title, $1) AS score
+		FROM beads
+		WHERE deleted_at IS NULL AND status <> 'closed' AND id <> $2 AND title % $1
+		ORDER BY score DESC, created_at DESC
+		LIMIT $3`,
+		title, excludeID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("similar beads: %w", err)
+	}
+	defer rows.Close()
+
+	var similar []*model.SimilarBead
+	for rows.Next() {
+		var score float64
+		b, err := scanBead(trailingScanner{rows, []any{&score}})
+		if err != nil {
+			return nil, fmt.Errorf("scan similar beads: %w", err)
+		}
+		similar = append(similar, &model.SimilarBead{Bead: b, Similarity: score})
+	}
+	return similar, rows.Err()
+}
+
 func queryAddDependency(ctx context.Context, db executor, dep *model.Dependency) error {
 	_, err := db.ExecContext(ctx, `
 		INSERT INTO deps (bead_id, depends_on_id, type, created_at, created_by, metadata)
@@ -297,11 +816,20 @@ func queryAddDependency(ctx context.Context, db executor, dep *model.Dependency)
 		string(dep.Type),
 		dep.CreatedAt,
 		dep.CreatedBy,
-		dep.Metadata,
+		string(dep.Metadata),
 	)
 	return err
 }
 
+func queryUpdateDependencyMetadata(ctx context.Context, db executor, dep *model.Dependency) error {
+	return db.QueryRowContext(ctx, `
+		UPDATE deps SET metadata = $4
+		WHERE bead_id = $1 AND depends_on_id = $2 AND type = $3
+		RETURNING created_at, created_by`,
+		dep.BeadID, dep.DependsOnID, string(dep.Type), string(dep.Metadata),
+	).Scan(&dep.CreatedAt, &dep.CreatedBy)
+}
+
 func queryRemoveDependency(ctx context.Context, db executor, beadID, dependsOnID string, depType model.DependencyType) error {
 	_, err := db.ExecContext(ctx, `
 		DELETE FROM deps
@@ -325,6 +853,24 @@ func queryGetDependencies(ctx context.Context, db executor, beadID string) ([]*m
 	return scanDependencies(rows)
 }
 
+// queryGetDependents returns the inbound dependencies of beadID from live
+// beads; edges from beads in the trash are left out.
+func queryGetDependents(ctx context.Context, db executor, beadID string) ([]*model.Dependency, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT d.bead_id, d.depends_on_id, d.type, d.created_at, d.created_by, d.metadata
+		FROM deps d
+		JOIN beads b ON b.id = d.bead_id AND b.deleted_at IS NULL
+		WHERE d.depends_on_id = $1
+		ORDER BY d.bead_id, d.type`,
+		beadID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanDependencies(rows)
+}
+
 func queryAddLabel(ctx context.Context, db executor, beadID, label string) error {
 	_, err := db.ExecContext(ctx, `
 		INSERT INTO labels (bead_id, label)
@@ -365,62 +911,810 @@ func queryGetLabels(ctx context.Context, db executor, beadID string) ([]string,
 	return labels, rows.Err()
 }
 
-func queryAddComment(ctx context.Context, db executor, c *model.Comment) error {
-	return db.QueryRowContext(ctx, `
-		INSERT INTO comments (bead_id, author, text)
-		VALUES ($1, $2, $3)
-		RETURNING id, created_at`,
-		c.BeadID, c.Author, c.Text,
-	).Scan(&c.ID, &c.CreatedAt)
-}
-
-func queryGetComments(ctx context.Context, db executor, beadID string) ([]*model.Comment, error) {
+// queryListLabels returns every label on a live bead with the number of
+// beads carrying it, ordered by namespace and value.
+func queryListLabels(ctx context.Context, db executor) ([]*model.LabelCount, error) {
 	rows, err := db.QueryContext(ctx, `
-		SELECT id, bead_id, author, text, created_at
-		FROM comments
-		WHERE bead_id = $1
-		ORDER BY created_at ASC`,
-		beadID,
-	)
+		SELECT l.label, l.namespace, l.value, COUNT(*)
+		FROM labels l JOIN beads b ON b.id = l.bead_id
+		WHERE b.deleted_at IS NULL
+		GROUP BY l.label, l.namespace, l.value
+		ORDER BY l.namespace, l.value`)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	return scanComments(rows)
+
+	var counts []*model.LabelCount
+	for rows.Next() {
+		var c model.LabelCount
+		if err := rows.Scan(&c.Label, &c.Namespace, &c.Value, &c.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, &c)
+	}
+	return counts, rows.Err()
 }
 
-func queryRecordEvent(ctx context.Context, db executor, e *model.Event) error {
-	return db.QueryRowContext(ctx, `
-		INSERT INTO events (topic, bead_id, actor, payload)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, created_at`,
-		e.Topic, e.BeadID, e.Actor, []byte(e.Payload),
-	).Scan(&e.ID, &e.CreatedAt)
+// aggregateKeys maps each group-by dimension to the SQL expression of a
+// bead's group key, over the beads as b and, for labels, their labels as l.
+var aggregateKeys = map[string]string{
+	model.GroupByStatus:      "b.status",
+	model.GroupByType:        "b.type",
+	model.GroupByAssignee:    "COALESCE(b.assignee, '')",
+	model.GroupByLabel:       "l.label",
+	model.GroupByPriority:    "b.priority::text",
+	model.GroupByCreatedWeek: "to_char(date_trunc('week', b.created_at), 'YYYY-MM-DD')",
 }
 
-func queryGetEvents(ctx context.Context, db executor, beadID string) ([]*model.Event, error) {
-	rows, err := db.QueryContext(ctx, `
-		SELECT id, topic, bead_id, actor, payload, created_at
-		FROM events
-		WHERE bead_id = $1
-		ORDER BY created_at ASC`,
-		beadID,
-	)
+// queryAggregateBeads counts the beads matching filter, and averages their
+// age, per value of groupBy, ordered by key.
+func queryAggregateBeads(ctx context.Context, db executor, filter model.BeadFilter, groupBy string) ([]*model.AggregateGroup, error) {
+	key, ok := aggregateKeys[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown group-by dimension %q", groupBy)
+	}
+	filter.Limit, filter.Offset, filter.Sort = 0, 0, ""
+	inner, args, err := beadListQuery(filter, "id, status, type, assignee, priority, created_at, closed_at")
 	if err != nil {
 		return nil, err
 	}
+	from := "(" + inner + ") b"
+	if groupBy == model.GroupByLabel {
+		from += " JOIN labels l ON l.bead_id = b.id"
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+key+`, COUNT(*),
+			COALESCE(AVG(EXTRACT(EPOCH FROM COALESCE(b.closed_at, now()) - b.created_at)), 0) / 3600
+		FROM `+from+`
+		GROUP BY 1
+		ORDER BY 1`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate beads: %w", err)
+	}
 	defer rows.Close()
-	return scanEvents(rows)
-}
 
-func querySetConfig(ctx context.Context, db executor, c *model.Config) error {
+	var groups []*model.AggregateGroup
+	for rows.Next() {
+		var g model.AggregateGroup
+		if err := rows.Scan(&g.Key, &g.Count, &g.AvgAgeHours); err != nil {
+			return nil, err
+		}
+		groups = append(groups, &g)
+	}
+	return groups, rows.Err()
+}
+
+// beadWeight is a bead's numeric "weight" field, or 1 without one.
+const beadWeight = `COALESCE(CASE WHEN jsonb_typeof(fields->'weight') = 'number' THEN (fields->>'weight')::float8 END, 1)`
+
+// queryRollupBeads counts the beads matching filter per status, with their
+// weight, how many unclosed ones are blocked, and the weight closed since
+// since, in a single query.
+func queryRollupBeads(ctx context.Context, db executor, filter model.BeadFilter, since time.Time) (*model.Rollup, error) {
+	filter.Limit, filter.Offset, filter.Sort = 0, 0, ""
+	inner, args, err := beadListQuery(filter, "status, closed_at, "+beadWeight+" AS weight, NOT ("+readyClause+") AS blocked")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, since)
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT b.status, COUNT(*), COALESCE(SUM(b.weight), 0),
+			COUNT(*) FILTER (WHERE b.blocked AND b.status <> 'closed'),
+			COALESCE(SUM(b.weight) FILTER (WHERE b.status = 'closed' AND b.closed_at >= $%d), 0)
+		FROM (%s) b
+		GROUP BY 1
+		ORDER BY 1`, len(args), inner), args...)
+	if err != nil {
+		return nil, fmt.Errorf("rollup beads: %w", err)
+	}
+	defer rows.Close()
+
+	r := &model.Rollup{ByStatus: map[string]int{}}
+	for rows.Next() {
+		var (
+			status         string
+			count, blocked int
+			weight, recent float64
+		)
+		if err := rows.Scan(&status, &count, &weight, &blocked, &recent); err != nil {
+			return nil, err
+		}
+		r.ByStatus[status] = count
+		r.Total += count
+		r.TotalWeight += weight
+		if status == string(model.StatusClosed) {
+			r.CompletedWeight += weight
+		}
+		r.Blocked += blocked
+		r.RecentWeight += recent
+	}
+	return r, rows.Err()
+}
+
+// queryResolveBeadRef returns the ID of the live bead whose ID, slug or
+// alias is ref, in that order of preference.
+func queryResolveBeadRef(ctx context.Context, db executor, ref string) (string, error) {
+	var id string
+	err := db.QueryRowContext(ctx, `
+		SELECT id FROM (
+			SELECT id, 1 AS rank FROM beads WHERE id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT id, 2 FROM beads WHERE slug = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT a.bead_id, 3 FROM bead_aliases a
+			JOIN beads b ON b.id = a.bead_id
+			WHERE a.alias = $1 AND b.deleted_at IS NULL
+		) refs
+		ORDER BY rank
+		LIMIT 1`,
+		ref,
+	).Scan(&id)
+	return id, err
+}
+
+func queryAddAlias(ctx context.Context, db executor, a *model.Alias) error {
+	return db.QueryRowContext(ctx, `
+		INSERT INTO bead_aliases (alias, bead_id, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING created_at`,
+		a.Alias, a.BeadID, a.CreatedBy,
+	).Scan(&a.CreatedAt)
+}
+
+func queryRemoveAlias(ctx context.Context, db executor, beadID, alias string) error {
+	res, err := db.ExecContext(ctx, `
+		DELETE FROM bead_aliases
+		WHERE bead_id = $1 AND alias = $2`,
+		beadID, alias,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func queryGetAliases(ctx context.Context, db executor, beadID string) ([]*model.Alias, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT alias, bead_id, created_by, created_at
+		FROM bead_aliases
+		WHERE bead_id = $1
+		ORDER BY alias`,
+		beadID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aliases []*model.Alias
+	for rows.Next() {
+		a := &model.Alias{}
+		if err := rows.Scan(&a.Alias, &a.BeadID, &a.CreatedBy, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, a)
+	}
+	return aliases, rows.Err()
+}
+
+// commentColumns is the column list used for SELECT statements on the
+// comments table, with each comment's reaction counts.
+const commentColumns = `id, bead_id, author, claimed_author, verified, text, created_at, resolved_at, resolved_by,
+	(SELECT jsonb_object_agg(emoji, n) FROM (
+		SELECT emoji, COUNT(*) AS n FROM comment_reactions
+		WHERE comment_reactions.comment_id = comments.id GROUP BY emoji) r) AS reactions`
+
+func queryAddComment(ctx context.Context, db executor, c *model.Comment) error {
+	return db.QueryRowContext(ctx, `
+		INSERT INTO comments (bead_id, author, claimed_author, verified, text)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`,
+		c.BeadID, c.Author, c.ClaimedAuthor, c.Verified, c.Text,
+	).Scan(&c.ID, &c.CreatedAt)
+}
+
+// queryImportComment inserts c with its own ID and timestamps, then moves
+// the ID sequence past it so later comments do not collide.
+func queryImportComment(ctx context.Context, db executor, c *model.Comment) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO comments (id, bead_id, author, claimed_author, verified, text, created_at, resolved_at, resolved_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		c.ID, c.BeadID, c.Author, c.ClaimedAuthor, c.Verified, c.Text, c.CreatedAt, nullTimePtr(c.ResolvedAt), nullString(c.ResolvedBy),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, `SELECT setval(pg_get_serial_sequence('comments', 'id'), (SELECT MAX(id) FROM comments))`)
+	return err
+}
+
+func queryGetComments(ctx context.Context, db executor, beadID string) ([]*model.Comment, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+commentColumns+`
+		FROM comments
+		WHERE bead_id = $1
+		ORDER BY created_at ASC`,
+		beadID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanComments(rows)
+}
+
+func queryGetComment(ctx context.Context, db executor, id int64) (*model.Comment, error) {
+	return scanComment(db.QueryRowContext(ctx, `
+		SELECT `+commentColumns+`
+		FROM comments
+		WHERE id = $1`,
+		id,
+	))
+}
+
+func queryAddReaction(ctx context.Context, db executor, commentID int64, actor, emoji string) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO comment_reactions (comment_id, actor, emoji) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`,
+		commentID, actor, emoji,
+	)
+	return err
+}
+
+func queryRemoveReaction(ctx context.Context, db executor, commentID int64, actor, emoji string) error {
+	_, err := db.ExecContext(ctx, `
+		DELETE FROM comment_reactions WHERE comment_id = $1 AND actor = $2 AND emoji = $3`,
+		commentID, actor, emoji,
+	)
+	return err
+}
+
+// queryResolveComment sets or clears a comment's resolution. Resolving an
+// already resolved comment keeps its original resolver.
+func queryResolveComment(ctx context.Context, db executor, commentID int64, actor string, resolved bool) error {
+	var res sql.Result
+	var err error
+	if resolved {
+		res, err = db.ExecContext(ctx, `
+			UPDATE comments SET resolved_at = COALESCE(resolved_at, NOW()), resolved_by = COALESCE(resolved_by, $2)
+			WHERE id = $1`,
+			commentID, actor,
+		)
+	} else {
+		res, err = db.ExecContext(ctx, `
+			UPDATE comments SET resolved_at = NULL, resolved_by = NULL WHERE id = $1`,
+			commentID,
+		)
+	}
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// checklistColumns is the column list scanned by scanChecklistItem.
+const checklistColumns = `bead_id, position, text, checked, checked_at, checked_by, created_by, created_at`
+
+// queryAddChecklistItems appends an item per text to beadID's checklist in
+// one statement, numbering them after its highest index. A concurrent add
+// that took the same numbers is store.ErrConflict.
+func queryAddChecklistItems(ctx context.Context, db executor, beadID string, texts []string, actor string) ([]*model.ChecklistItem, error) {
+	rows, err := db.QueryContext(ctx, `
+		INSERT INTO checklist_items (bead_id, position, text, created_by)
+		SELECT $1, COALESCE((SELECT MAX(position) FROM checklist_items WHERE bead_id = $1), 0) + t.n, t.text, $3
+		FROM unnest($2::text[]) WITH ORDINALITY AS t(text, n)
+		RETURNING `+checklistColumns,
+		beadID, pq.Array(texts), actor,
+	)
+	if err == nil {
+		defer rows.Close()
+		var items []*model.ChecklistItem
+		if items, err = scanChecklist(rows); err == nil {
+			// RETURNING need not keep the input order; positions do.
+			slices.SortFunc(items, func(a, b *model.ChecklistItem) int { return a.Index - b.Index })
+			return items, nil
+		}
+	}
+	var pe *pq.Error
+	if errors.As(err, &pe) && pe.Code == "23505" {
+		return nil, store.ErrConflict
+	}
+	return nil, err
+}
+
+func queryGetChecklist(ctx context.Context, db executor, beadID string) ([]*model.ChecklistItem, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+checklistColumns+`
+		FROM checklist_items
+		WHERE bead_id = $1
+		ORDER BY position`,
+		beadID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanChecklist(rows)
+}
+
+// queryCheckChecklistItem sets or clears a checklist item's check.
+// Checking an already checked item keeps who checked it and when.
+func queryCheckChecklistItem(ctx context.Context, db executor, beadID string, index int, actor string, checked bool) (*model.ChecklistItem, error) {
+	if checked {
+		return scanChecklistItem(db.QueryRowContext(ctx, `
+			UPDATE checklist_items
+			SET checked_at = CASE WHEN checked THEN checked_at ELSE NOW() END,
+				checked_by = CASE WHEN checked THEN checked_by ELSE $3 END,
+				checked = TRUE
+			WHERE bead_id = $1 AND position = $2
+			RETURNING `+checklistColumns,
+			beadID, index, actor,
+		))
+	}
+	return scanChecklistItem(db.QueryRowContext(ctx, `
+		UPDATE checklist_items SET checked = FALSE, checked_at = NULL, checked_by = ''
+		WHERE bead_id = $1 AND position = $2
+		RETURNING `+checklistColumns,
+		beadID, index,
+	))
+}
+
+// queryLoadRelations sets the labels, dependencies and comments of beads,
+// loading each relation for all of them in one query.
+func queryLoadRelations(ctx context.Context, db executor, beads []*model.Bead) error {
+	if len(beads) == 0 {
+		return nil
+	}
+	ids := make([]string, len(beads))
+	byID := make(map[string]*model.Bead, len(beads))
+	for i, b := range beads {
+		ids[i] = b.ID
+		byID[b.ID] = b
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT bead_id, label FROM labels WHERE bead_id = ANY($1)`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return fmt.Errorf("load labels: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var beadID, label string
+		if err := rows.Scan(&beadID, &label); err != nil {
+			return fmt.Errorf("load labels: %w", err)
+		}
+		byID[beadID].Labels = append(byID[beadID].Labels, label)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("load labels: %w", err)
+	}
+
+	depRows, err := db.QueryContext(ctx, `
+		SELECT bead_id, depends_on_id, type, created_at, created_by, metadata
+		FROM deps
+		WHERE bead_id = ANY($1)`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return fmt.Errorf("load dependencies: %w", err)
+	}
+	defer depRows.Close()
+	deps, err := scanDependencies(depRows)
+	if err != nil {
+		return fmt.Errorf("load dependencies: %w", err)
+	}
+	for _, d := range deps {
+		byID[d.BeadID].Dependencies = append(byID[d.BeadID].Dependencies, d)
+	}
+
+	commentRows, err := db.QueryContext(ctx, `
+		SELECT `+commentColumns+`
+		FROM comments
+		WHERE bead_id = ANY($1)
+		ORDER BY created_at ASC`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return fmt.Errorf("load comments: %w", err)
+	}
+	defer commentRows.Close()
+	comments, err := scanComments(commentRows)
+	if err != nil {
+		return fmt.Errorf("load comments: %w", err)
+	}
+	for _, c := range comments {
+		byID[c.BeadID].Comments = append(byID[c.BeadID].Comments, c)
+	}
+	return nil
+}
+
+// queryListCommentsByAuthor returns the latest limit comments by author,
+// newest first.
+func queryListCommentsByAuthor(ctx context.Context, db executor, author string, limit int) ([]*model.Comment, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+commentColumns+`
+		FROM comments
+		WHERE author = $1
+		ORDER BY id DESC
+		LIMIT $2`,
+		author, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanComments(rows)
+}
+
+// queryRecordCreateKey claims key for beadID. A concurrent claim of the same
+// key waits for the first to commit and then reads its bead ID.
+func queryRecordCreateKey(ctx context.Context, db executor, key, beadID string) (string, error) {
+	var id string
+	err := db.QueryRowContext(ctx, `
+		INSERT INTO create_keys (key, bead_id) VALUES ($1, $2)
+		ON CONFLICT (key) DO NOTHING
+		RETURNING bead_id`,
+		key, beadID,
+	).Scan(&id)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return id, err
+	}
+	err = db.QueryRowContext(ctx, `SELECT bead_id FROM create_keys WHERE key = $1`, key).Scan(&id)
+	return id, err
+}
+
+// queryAppendNote appends the note's entry to the bead's notes column and
+// records the note row in one statement, so concurrent appends never lose
+// each other's entries. Returns sql.ErrNoRows if the bead does not exist.
+func queryAppendNote(ctx context.Context, db executor, n *model.Note) error {
+	return db.QueryRowContext(ctx, `
+		WITH bead AS (
+			UPDATE beads SET
+				notes = CASE WHEN COALESCE(notes, '') = '' THEN $4 ELSE notes || E'\n' || $4 END,
+				updated_at = NOW()
+			WHERE id = $1 AND deleted_at IS NULL
+			RETURNING id
+		)
+		INSERT INTO notes (bead_id, author, text, created_at)
+		SELECT id, $2, $3, $5 FROM bead
+		RETURNING id`,
+		n.BeadID, n.Author, n.Text, n.Entry(), n.CreatedAt,
+	).Scan(&n.ID)
+}
+
+func queryGetNotes(ctx context.Context, db executor, beadID string) ([]*model.Note, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, bead_id, author, text, created_at
+		FROM notes
+		WHERE bead_id = $1
+		ORDER BY created_at ASC, id ASC`,
+		beadID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanNotes(rows)
+}
+
+// queryAppendDescription appends a paragraph to the bead's description and
+// returns the result. Returns sql.ErrNoRows if the bead does not exist.
+func queryAppendDescription(ctx context.Context, db executor, id, text string) (string, error) {
+	var description string
+	err := db.QueryRowContext(ctx, `
+		UPDATE beads SET
+			description = CASE WHEN COALESCE(description, '') = '' THEN $2 ELSE description || E'\n\n' || $2 END,
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING description`,
+		id, text,
+	).Scan(&description)
+	return description, err
+}
+
+// queryRecordEvent inserts an event and, in the same statement, a
+// notification for every watcher of the bead other than the event's actor.
+func queryRecordEvent(ctx context.Context, db executor, e *model.Event) error {
+	return db.QueryRowContext(ctx, `
+		WITH ev AS (
+			INSERT INTO events (topic, bead_id, actor, payload)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, bead_id, actor, created_at
+		), notified AS (
+			INSERT INTO notifications (actor, event_id, created_at)
+			SELECT w.actor, ev.id, ev.created_at
+			FROM watchers w, ev
+			WHERE w.bead_id = ev.bead_id AND w.actor <> ev.actor
+		)
+		SELECT id, created_at FROM ev`,
+		e.Topic, e.BeadID, e.Actor, []byte(e.Payload),
+	).Scan(&e.ID, &e.CreatedAt)
+}
+
+// queryImportEvent inserts e with its own ID and timestamp, already
+// published, then moves the ID sequence past it.
+func queryImportEvent(ctx context.Context, db executor, e *model.Event) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO events (id, topic, bead_id, actor, payload, created_at, published_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)`,
+		e.ID, e.Topic, e.BeadID, e.Actor, []byte(e.Payload), e.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, `SELECT setval(pg_get_serial_sequence('events', 'id'), (SELECT MAX(id) FROM events))`)
+	return err
+}
+
+func queryGetEvents(ctx context.Context, db executor, beadID string) ([]*model.Event, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, topic, bead_id, actor, payload, created_at
+		FROM events
+		WHERE bead_id = $1
+		ORDER BY created_at ASC`,
+		beadID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanEvents(rows)
+}
+
+// queryListEventsByActor returns the latest limit events recorded by actor,
+// newest first.
+func queryListEventsByActor(ctx context.Context, db executor, actor string, limit int) ([]*model.Event, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, topic, bead_id, actor, payload, created_at
+		FROM events
+		WHERE actor = $1
+		ORDER BY id DESC
+		LIMIT $2`,
+		actor, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanEvents(rows)
+}
+
+// queryListEventsBetween returns the events recorded in [from, to) with any
+// of topics, or any topic when topics is empty, oldest first.
+func queryListEventsBetween(ctx context.Context, db executor, from, to time.Time, topics []string) ([]*model.Event, error) {
+	query := `
+		SELECT id, topic, bead_id, actor, payload, created_at
+		FROM events
+		WHERE created_at >= $1 AND created_at < $2`
+	args := []any{from, to}
+	if len(topics) > 0 {
+		placeholders := make([]string, len(topics))
+		for i, t := range topics {
+			args = append(args, t)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		query += `
+			AND topic IN (` + strings.Join(placeholders, ", ") + `)`
+	}
+	query += `
+		ORDER BY id ASC`
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanEvents(rows)
+}
+
+// queryListEvents returns the events matching filter, oldest first. The
+// project filter walks parent-child dependencies down from the project bead.
+func queryListEvents(ctx context.Context, db executor, filter model.EventFilter) ([]*model.Event, error) {
+	query := `
+		SELECT id, topic, bead_id, actor, payload, created_at
+		FROM events
+		WHERE id > $1`
+	args := []any{filter.AfterID}
+	if len(filter.Topics) > 0 {
+		placeholders := make([]string, len(filter.Topics))
+		for i, t := range filter.Topics {
+			args = append(args, t)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		query += `
+			AND topic IN (` + strings.Join(placeholders, ", ") + `)`
+	}
+	if filter.BeadID != "" {
+		args = append(args, filter.BeadID)
+		query += fmt.Sprintf(`
+			AND bead_id = $%d`, len(args))
+	}
+	if filter.Actor != "" {
+		args = append(args, filter.Actor)
+		query += fmt.Sprintf(`
+			AND actor = $%d`, len(args))
+	}
+	if !filter.Since.IsZero() {
+		args = append(args, filter.Since)
+		query += fmt.Sprintf(`
+			AND created_at >= $%d`, len(args))
+	}
+	if filter.Label != "" {
+		args = append(args, filter.Label)
+		query += fmt.Sprintf(`
+			AND EXISTS (SELECT 1 FROM labels l WHERE l.bead_id = events.bead_id AND l.label = $%d)`, len(args))
+	}
+	if filter.Project != "" {
+		args = append(args, filter.Project)
+		query += fmt.Sprintf(`
+			AND bead_id IN (
+				WITH RECURSIVE tree(id) AS (
+					SELECT $%d::text
+					UNION
+					SELECT d.bead_id FROM deps d JOIN tree t ON d.depends_on_id = t.id
+					WHERE d.type = 'parent-child'
+				)
+				SELECT id FROM tree
+			)`, len(args))
+	}
+	query += `
+		ORDER BY id ASC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(`
+		LIMIT $%d`, len(args))
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanEvents(rows)
+}
+
+func queryListUnpublishedEvents(ctx context.Context, db executor, limit int) ([]*model.Event, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, topic, bead_id, actor, payload, created_at
+		FROM events
+		WHERE published_at IS NULL
+		ORDER BY id ASC
+		LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanEvents(rows)
+}
+
+func queryMarkEventPublished(ctx context.Context, db executor, id int64) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE events SET published_at = NOW()
+		WHERE id = $1 AND published_at IS NULL`,
+		id,
+	)
+	return err
+}
+
+// topicLikePatterns converts retention topic patterns to LIKE patterns:
+// "*" matches every topic and a trailing ".*" any topic under the prefix.
+func topicLikePatterns(patterns []string) []string {
+	likes := make([]string, len(patterns))
+	for i, p := range patterns {
+		like := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.TrimSuffix(p, "*"))
+		if strings.HasSuffix(p, "*") {
+			like += "%"
+		}
+		likes[i] = like
+	}
+	return likes
+}
+
+// queryCompactEvents deletes the matching events and folds them into
+// event_summaries in one statement, adding to the summary of a topic, bead
+// and day that an earlier run already wrote. Unpublished events are left
+// for the outbox.
+func queryCompactEvents(ctx context.Context, db executor, topics, except []string, cutoff time.Time) (int64, error) {
+	var n int64
+	err := db.QueryRowContext(ctx, `
+		WITH gone AS (
+			DELETE FROM events
+			WHERE created_at < $1 AND published_at IS NOT NULL
+				AND topic LIKE ANY($2::text[]) AND NOT topic LIKE ANY($3::text[])
+			RETURNING topic, bead_id, actor, created_at
+		), summed AS (
+			INSERT INTO event_summaries (topic, bead_id, day, count, actors, first_at, last_at)
+			SELECT topic, bead_id, (created_at AT TIME ZONE 'UTC')::date, COUNT(*),
+				COALESCE(array_agg(DISTINCT actor ORDER BY actor) FILTER (WHERE actor <> ''), '{}'),
+				MIN(created_at), MAX(created_at)
+			FROM gone
+			GROUP BY 1, 2, 3
+			ON CONFLICT (topic, bead_id, day) DO UPDATE SET
+				count = event_summaries.count + EXCLUDED.count,
+				actors = ARRAY(SELECT DISTINCT a FROM unnest(event_summaries.actors || EXCLUDED.actors) AS a ORDER BY a),
+				first_at = LEAST(event_summaries.first_at, EXCLUDED.first_at),
+				last_at = GREATEST(event_summaries.last_at, EXCLUDED.last_at)
+		)
+		SELECT COUNT(*) FROM gone`,
+		cutoff, pq.Array(topicLikePatterns(topics)), pq.Array(topicLikePatterns(except)),
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("compact events: %w", err)
+	}
+	return n, nil
+}
+
+func queryListEventSummaries(ctx context.Context, db executor, filter model.EventSummaryFilter) ([]*model.EventSummary, error) {
+	query := `
+		SELECT topic, bead_id, to_char(day, 'YYYY-MM-DD'), count, actors, first_at, last_at
+		FROM event_summaries
+		WHERE TRUE`
+	var args []any
+	if filter.BeadID != "" {
+		args = append(args, filter.BeadID)
+		query += fmt.Sprintf(`
+			AND bead_id = $%d`, len(args))
+	}
+	if filter.Topic != "" {
+		args = append(args, filter.Topic)
+		query += fmt.Sprintf(`
+			AND topic = $%d`, len(args))
+	}
+	query += `
+		ORDER BY day DESC, topic, bead_id`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(`
+		LIMIT $%d`, len(args))
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var summaries []*model.EventSummary
+	for rows.Next() {
+		sum, err := scanEventSummary(rows)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, sum)
+	}
+	return summaries, rows.Err()
+}
+
+func querySetConfig(ctx context.Context, db executor, c *model.Config) error {
+	// Each write bumps the row's rev and appends it to config_revisions. A
+	// re-created key continues from its last recorded revision.
 	return db.QueryRowContext(ctx, `
-		INSERT INTO configs (key, value)
-		VALUES ($1, $2)
-		ON CONFLICT (key) DO UPDATE SET value = $2, updated_at = NOW()
-		RETURNING created_at, updated_at`,
-		c.Key, []byte(c.Value),
-	).Scan(&c.CreatedAt, &c.UpdatedAt)
+		WITH c AS (
+			INSERT INTO configs (key, value, rev)
+			VALUES ($1, $2, (SELECT COALESCE(MAX(rev), 0) + 1 FROM config_revisions WHERE key = $1))
+			ON CONFLICT (key) DO UPDATE SET value = $2, rev = configs.rev + 1, updated_at = NOW()
+			RETURNING key, value, rev, created_at, updated_at
+		), r AS (
+			INSERT INTO config_revisions (key, rev, value, actor)
+			SELECT key, rev, value, $3 FROM c
+		)
+		SELECT created_at, updated_at, rev FROM c`,
+		c.Key, []byte(c.Value), c.UpdatedBy,
+	).Scan(&c.CreatedAt, &c.UpdatedAt, &c.Rev)
 }
 
 func queryGetConfig(ctx context.Context, db executor, key string) (*model.Config, error) {
@@ -454,7 +1748,10 @@ func queryListAllConfigs(ctx context.Context, db executor) ([]*model.Config, err
 }
 
 func queryDeleteConfig(ctx context.Context, db executor, key string) error {
-	res, err := db.ExecContext(ctx, `DELETE FROM configs WHERE key = $1`, key)
+	// The deletion is recorded as a revision without a value.
+	res, err := db.ExecContext(ctx, `
+		WITH d AS (DELETE FROM configs WHERE key = $1 RETURNING key, rev)
+		INSERT INTO config_revisions (key, rev) SELECT key, rev + 1 FROM d`, key)
 	if err != nil {
 		return err
 	}
@@ -468,15 +1765,53 @@ func queryDeleteConfig(ctx context.Context, db executor, key string) error {
 	return nil
 }
 
+func queryListConfigRevisions(ctx context.Context, db executor, key string) ([]*model.ConfigRevision, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT key, rev, value, actor, created_at
+		FROM config_revisions WHERE key = $1
+		ORDER BY rev DESC`, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var revs []*model.ConfigRevision
+	for rows.Next() {
+		r, err := scanConfigRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revs = append(revs, r)
+	}
+	return revs, rows.Err()
+}
+
+func queryGetConfigRevision(ctx context.Context, db executor, key string, rev int64) (*model.ConfigRevision, error) {
+	row := db.QueryRowContext(ctx, `
+		SELECT key, rev, value, actor, created_at
+		FROM config_revisions WHERE key = $1 AND rev = $2`, key, rev)
+	return scanConfigRevision(row)
+}
+
+// parseSortClause returns the ORDER BY clause for a sort key. "urgency"
+// sorts most urgent first (see urgencyScore) and "-urgency" least urgent
+// first, ties broken by ID so the order is stable.
 func parseSortClause(sort string) string {
 	if sort == "" {
 		return "created_at DESC"
 	}
 	desc := strings.HasPrefix(sort, "-")
 	col := strings.TrimPrefix(sort, "-")
+	if col == "urgency" {
+		if desc {
+			return urgencyScore + " ASC, id ASC"
+		}
+		return urgencyScore + " DESC, id ASC"
+	}
 	allowed := map[string]bool{
-		"priority": true, "created_at": true, "updated_at": true,
+		"id": true, "priority": true, "created_at": true, "updated_at": true,
 		"title": true, "status": true, "type": true,
+		"age_days": true, "blocked_count": true, "last_activity_at": true,
+		"effective_priority": true,
 	}
 	if !allowed[col] {
 		return "created_at DESC"
@@ -486,3 +1821,519 @@ func parseSortClause(sort string) string {
 	}
 	return col + " ASC"
 }
+
+// agentColumns is the column list for SELECTs on agents.
+const agentColumns = `name, bead_id, token_hash, COALESCE(hook_token_hash, ''), created_at, created_by`
+
+func queryCreateAgent(ctx context.Context, db executor, a *model.Agent) error {
+	return db.QueryRowContext(ctx, `
+		INSERT INTO agents (name, bead_id, token_hash, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at`,
+		a.Name, a.BeadID, a.TokenHash, a.CreatedBy,
+	).Scan(&a.CreatedAt)
+}
+
+func queryGetAgent(ctx context.Context, db executor, name string) (*model.Agent, error) {
+	row := db.QueryRowContext(ctx, `
+		SELECT `+agentColumns+`
+		FROM agents WHERE name = $1`, name)
+	return scanAgent(row)
+}
+
+func queryGetAgentByTokenHash(ctx context.Context, db executor, tokenHash string) (*model.Agent, error) {
+	row := db.QueryRowContext(ctx, `
+		SELECT `+agentColumns+`
+		FROM agents WHERE token_hash = $1`, tokenHash)
+	return scanAgent(row)
+}
+
+func queryGetAgentByHookTokenHash(ctx context.Context, db executor, tokenHash string) (*model.Agent, error) {
+	row := db.QueryRowContext(ctx, `
+		SELECT `+agentColumns+`
+		FROM agents WHERE hook_token_hash = $1`, tokenHash)
+	return scanAgent(row)
+}
+
+func querySetAgentHookToken(ctx context.Context, db executor, name, tokenHash string) error {
+	res, err := db.ExecContext(ctx, `
+		UPDATE agents SET hook_token_hash = NULLIF($2, '') WHERE name = $1`, name, tokenHash)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func queryListAgents(ctx context.Context, db executor) ([]*model.Agent, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+agentColumns+`
+		FROM agents ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanAgents(rows)
+}
+
+// externalDepColumns is the column list for SELECTs on external_deps.
+const externalDepColumns = `id, bead_id, url, description, status, probe, checked_at, last_error, created_by, created_at`
+
+func queryAddExternalDep(ctx context.Context, db executor, d *model.ExternalDep) error {
+	return db.QueryRowContext(ctx, `
+		INSERT INTO external_deps (bead_id, url, description, status, probe, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`,
+		d.BeadID, d.URL, d.Description, string(d.Status), d.Probe, d.CreatedBy,
+	).Scan(&d.ID, &d.CreatedAt)
+}
+
+func queryGetExternalDeps(ctx context.Context, db executor, beadID string) ([]*model.ExternalDep, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+externalDepColumns+`
+		FROM external_deps
+		WHERE bead_id = $1
+		ORDER BY id`,
+		beadID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanExternalDeps(rows)
+}
+
+// queryListProbedExternalDeps lists the waiting external dependencies that
+// have a probe, on beads that are neither closed nor deleted.
+func queryListProbedExternalDeps(ctx context.Context, db executor) ([]*model.ExternalDep, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+externalDepColumns+`
+		FROM external_deps x
+		WHERE x.status = 'waiting' AND x.probe <> ''
+			AND EXISTS (SELECT 1 FROM beads WHERE beads.id = x.bead_id
+				AND beads.status <> 'closed' AND beads.deleted_at IS NULL)
+		ORDER BY x.checked_at NULLS FIRST, x.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanExternalDeps(rows)
+}
+
+// queryUpdateExternalDep writes d's status, checked_at and last_error.
+func queryUpdateExternalDep(ctx context.Context, db executor, d *model.ExternalDep) error {
+	res, err := db.ExecContext(ctx, `
+		UPDATE external_deps SET status = $3, checked_at = $4, last_error = $5
+		WHERE id = $1 AND bead_id = $2`,
+		d.ID, d.BeadID, string(d.Status), nullTimePtr(d.CheckedAt), d.LastError,
+	)
+	return requireRow(res, err)
+}
+
+func queryRemoveExternalDep(ctx context.Context, db executor, beadID string, id int64) error {
+	res, err := db.ExecContext(ctx, `DELETE FROM external_deps WHERE id = $1 AND bead_id = $2`, id, beadID)
+	return requireRow(res, err)
+}
+
+// queryLinkCommit inserts c unless the bead already has its repo and SHA,
+// and reads back whichever row is stored. Returns true if it was inserted.
+func queryLinkCommit(ctx context.Context, db executor, c *model.Commit) (bool, error) {
+	var created bool
+	err := db.QueryRowContext(ctx, `
+		WITH ins AS (
+			INSERT INTO commits (bead_id, repo, sha, message, author, linked_by)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (bead_id, repo, sha) DO NOTHING
+			RETURNING id, message, author, linked_by, created_at
+		)
+		SELECT id, message, author, linked_by, created_at, true FROM ins
+		UNION ALL
+		SELECT id, message, author, linked_by, created_at, false FROM commits
+		WHERE bead_id = $1 AND repo = $2 AND sha = $3 AND NOT EXISTS (SELECT 1 FROM ins)`,
+		c.BeadID, c.Repo, c.SHA, c.Message, c.Author, c.LinkedBy,
+	).Scan(&c.ID, &c.Message, &c.Author, &c.LinkedBy, &c.CreatedAt, &created)
+	return created, err
+}
+
+func queryGetCommits(ctx context.Context, db executor, beadID string) ([]*model.Commit, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, bead_id, repo, sha, message, author, linked_by, created_at
+		FROM commits
+		WHERE bead_id = $1
+		ORDER BY created_at DESC, id DESC`,
+		beadID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanCommits(rows)
+}
+
+// actorSelect selects actors with their aliases; callers append WHERE or
+// GROUP BY clauses.
+const actorSelect = `
+	SELECT a.id, a.display_name, a.type, a.created_by, a.created_at,
+		COALESCE(array_agg(al.alias ORDER BY al.alias) FILTER (WHERE al.alias IS NOT NULL), '{}')
+	FROM actors a
+	LEFT JOIN actor_aliases al ON al.actor_id = a.id`
+
+func queryCreateActor(ctx context.Context, db executor, a *model.Actor) error {
+	// One statement, so the actor and its aliases are created together
+	// even outside a transaction.
+	return db.QueryRowContext(ctx, `
+		WITH a AS (
+			INSERT INTO actors (id, display_name, type, created_by)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, created_at
+		), al AS (
+			INSERT INTO actor_aliases (alias, actor_id)
+			SELECT unnest($5::text[]), id FROM a
+		)
+		SELECT created_at FROM a`,
+		a.ID, a.DisplayName, string(a.Type), a.CreatedBy, pq.Array(a.Aliases),
+	).Scan(&a.CreatedAt)
+}
+
+func queryGetActor(ctx context.Context, db executor, id string) (*model.Actor, error) {
+	row := db.QueryRowContext(ctx, actorSelect+`
+		WHERE a.id = $1
+		GROUP BY a.id`, id)
+	return scanActor(row)
+}
+
+func queryListActors(ctx context.Context, db executor) ([]*model.Actor, error) {
+	rows, err := db.QueryContext(ctx, actorSelect+`
+		GROUP BY a.id
+		ORDER BY a.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var actors []*model.Actor
+	for rows.Next() {
+		a, err := scanActor(rows)
+		if err != nil {
+			return nil, err
+		}
+		actors = append(actors, a)
+	}
+	return actors, rows.Err()
+}
+
+func queryUpdateActor(ctx context.Context, db executor, a *model.Actor) error {
+	res, err := db.ExecContext(ctx, `
+		UPDATE actors SET display_name = $2, type = $3
+		WHERE id = $1`,
+		a.ID, a.DisplayName, string(a.Type),
+	)
+	return requireRow(res, err)
+}
+
+func queryDeleteActor(ctx context.Context, db executor, id string) error {
+	res, err := db.ExecContext(ctx, `DELETE FROM actors WHERE id = $1`, id)
+	return requireRow(res, err)
+}
+
+func queryAddActorAlias(ctx context.Context, db executor, id, alias string) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO actor_aliases (alias, actor_id)
+		VALUES ($1, $2)`,
+		alias, id,
+	)
+	return err
+}
+
+func queryRemoveActorAlias(ctx context.Context, db executor, id, alias string) error {
+	res, err := db.ExecContext(ctx, `
+		DELETE FROM actor_aliases
+		WHERE actor_id = $1 AND alias = $2`,
+		id, alias,
+	)
+	return requireRow(res, err)
+}
+
+// queryResolveActor matches name against actor IDs case-insensitively,
+// then against aliases.
+func queryResolveActor(ctx context.Context, db executor, name string) (string, error) {
+	var id string
+	err := db.QueryRowContext(ctx, `
+		SELECT id FROM (
+			SELECT id, 0 AS rank FROM actors WHERE lower(id) = lower($1)
+			UNION ALL
+			SELECT actor_id, 1 FROM actor_aliases WHERE alias = lower($1)
+		) m
+		ORDER BY rank
+		LIMIT 1`,
+		name,
+	).Scan(&id)
+	return id, err
+}
+
+// requireRow returns sql.ErrNoRows if an Exec affected no rows.
+func requireRow(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func queryAddWatcher(ctx context.Context, db executor, beadID, actor string) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO watchers (bead_id, actor)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`,
+		beadID, actor,
+	)
+	return err
+}
+
+func queryRemoveWatcher(ctx context.Context, db executor, beadID, actor string) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM watchers WHERE bead_id = $1 AND actor = $2`, beadID, actor)
+	return err
+}
+
+func queryGetWatchers(ctx context.Context, db executor, beadID string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT actor FROM watchers
+		WHERE bead_id = $1
+		ORDER BY created_at ASC, actor ASC`,
+		beadID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var watchers []string
+	for rows.Next() {
+		var actor string
+		if err := rows.Scan(&actor); err != nil {
+			return nil, err
+		}
+		watchers = append(watchers, actor)
+	}
+	return watchers, rows.Err()
+}
+
+func queryAckAdvice(ctx context.Context, db executor, beadID, actor string) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO advice_acks (bead_id, actor)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`,
+		beadID, actor,
+	)
+	return err
+}
+
+func queryListAdviceAcks(ctx context.Context, db executor, actor string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT bead_id FROM advice_acks WHERE actor = $1 ORDER BY bead_id`, actor)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// queryAcquireBeadLock takes or renews lock in one statement, so two
+// owners racing for a free lock cannot both get it. A renewal keeps the
+// original acquired_at. If another owner holds the lock the upsert changes
+// nothing and the holder's lock is read back instead.
+func queryAcquireBeadLock(ctx context.Context, db executor, lock *model.BeadLock) (*model.BeadLock, error) {
+	row := db.QueryRowContext(ctx, `
+		INSERT INTO bead_locks (bead_id, owner, acquired_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (bead_id) DO UPDATE SET
+			owner = EXCLUDED.owner,
+			acquired_at = CASE
+				WHEN bead_locks.owner = EXCLUDED.owner AND bead_locks.expires_at > EXCLUDED.acquired_at
+				THEN bead_locks.acquired_at ELSE EXCLUDED.acquired_at END,
+			expires_at = EXCLUDED.expires_at
+		WHERE bead_locks.owner = EXCLUDED.owner OR bead_locks.expires_at <= EXCLUDED.acquired_at
+		RETURNING bead_id, owner, acquired_at, expires_at`,
+		lock.BeadID, lock.Owner, lock.AcquiredAt, lock.ExpiresAt,
+	)
+	held, err := scanBeadLock(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return queryGetBeadLock(ctx, db, lock.BeadID)
+	}
+	return held, err
+}
+
+func queryGetBeadLock(ctx context.Context, db executor, beadID string) (*model.BeadLock, error) {
+	row := db.QueryRowContext(ctx, `
+		SELECT bead_id, owner, acquired_at, expires_at FROM bead_locks
+		WHERE bead_id = $1`,
+		beadID,
+	)
+	return scanBeadLock(row)
+}
+
+func queryReleaseBeadLock(ctx context.Context, db executor, beadID string) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM bead_locks WHERE bead_id = $1`, beadID)
+	return err
+}
+
+func queryListNotifications(ctx context.Context, db executor, actor string, unreadOnly bool, limit int) ([]*model.Notification, error) {
+	query := `
+		SELECT n.id, n.actor, n.created_at, n.read_at,
+			e.id, e.topic, e.bead_id, e.actor, e.payload, e.created_at
+		FROM notifications n
+		JOIN events e ON e.id = n.event_id
+		WHERE n.actor = $1`
+	if unreadOnly {
+		query += ` AND n.read_at IS NULL`
+	}
+	query += ` ORDER BY n.id DESC LIMIT $2`
+
+	rows, err := db.QueryContext(ctx, query, actor, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanNotifications(rows)
+}
+
+// queryMarkNotificationsRead marks the given notifications read, or all of
+// the actor's unread notifications if ids is empty. Returns the number marked.
+func queryMarkNotificationsRead(ctx context.Context, db executor, actor string, ids []int64) (int64, error) {
+	query := `UPDATE notifications SET read_at = NOW() WHERE actor = $1 AND read_at IS NULL`
+	args := []any{actor}
+	if len(ids) > 0 {
+		placeholders := make([]string, len(ids))
+		for i, id := range ids {
+			placeholders[i] = fmt.Sprintf("$%d", i+2)
+			args = append(args, id)
+		}
+		query += ` AND id IN (` + strings.Join(placeholders, ", ") + `)`
+	}
+	res, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// queryNotifyActors adds notifications of an event for actors other than
+// its own actor who are not already notified of it.
+func queryNotifyActors(ctx context.Context, db executor, eventID int64, actors []string) error {
+	if len(actors) == 0 {
+		return nil
+	}
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO notifications (actor, event_id, created_at)
+		SELECT DISTINCT a.actor, e.id, e.created_at
+		FROM events e, unnest($2::text[]) AS a(actor)
+		WHERE e.id = $1 AND a.actor <> e.actor
+			AND NOT EXISTS (SELECT 1 FROM notifications n WHERE n.event_id = e.id AND n.actor = a.actor)`,
+		eventID, pq.Array(actors))
+	return err
+}
+
+func queryCreateDigest(ctx context.Context, db executor, d *model.Digest) error {
+	beadIDs, err := json.Marshal(nonNil(d.BeadIDs))
+	if err != nil {
+		return err
+	}
+	newIDs, err := json.Marshal(nonNil(d.NewIDs))
+	if err != nil {
+		return err
+	}
+	return db.QueryRowContext(ctx, `
+		INSERT INTO digests (subscription, generated_at, bead_ids, new_ids)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		d.Subscription, d.GeneratedAt, beadIDs, newIDs,
+	).Scan(&d.ID)
+}
+
+func queryGetLatestDigest(ctx context.Context, db executor, subscription string) (*model.Digest, error) {
+	row := db.QueryRowContext(ctx, `
+		SELECT id, subscription, generated_at, bead_ids, new_ids
+		FROM digests
+		WHERE subscription = $1
+		ORDER BY generated_at DESC, id DESC
+		LIMIT 1`,
+		subscription,
+	)
+	return scanDigest(row)
+}
+
+// queryReplaceMirroredBeads deletes remote's mirrored beads and inserts
+// beads in their place.
+func queryReplaceMirroredBeads(ctx context.Context, db executor, remote string, beads []*model.Bead) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM mirrored_beads WHERE remote = $1`, remote); err != nil {
+		return fmt.Errorf("clear mirror %s: %w", remote, err)
+	}
+	for _, b := range beads {
+		data, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+		if _, err := db.ExecContext(ctx, `
+			INSERT INTO mirrored_beads (remote, id, bead) VALUES ($1, $2, $3)
+			ON CONFLICT (remote, id) DO UPDATE SET bead = EXCLUDED.bead`,
+			remote, b.ID, data,
+		); err != nil {
+			return fmt.Errorf("mirror bead %s: %w", b.ID, err)
+		}
+	}
+	return nil
+}
+
+func queryListMirroredBeads(ctx context.Context, db executor, remote string) ([]*model.MirroredBead, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT remote, bead, synced_at FROM mirrored_beads
+		WHERE remote = $1
+		ORDER BY id`,
+		remote,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []*model.MirroredBead
+	for rows.Next() {
+		m, err := scanMirroredBead(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+func queryGetMirroredBead(ctx context.Context, db executor, remote, id string) (*model.MirroredBead, error) {
+	row := db.QueryRowContext(ctx, `
+		SELECT remote, bead, synced_at FROM mirrored_beads
+		WHERE remote = $1 AND id = $2`,
+		remote, id,
+	)
+	return scanMirroredBead(row)
+}
+
+// nonNil returns ids, or an empty slice if ids is nil, so it encodes as [].
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
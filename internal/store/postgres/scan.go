@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/lib/pq"
 )
 
 // scannable is the interface satisfied by both *sql.Row and *sql.Rows.
@@ -51,6 +52,8 @@ func scanBead(row scannable) (*model.Bead, error) {
 		&dueAt,
 		&deferUntil,
 		&fields,
+		&b.Estimate,
+		&b.Actual,
 	)
 	if err != nil {
 		return nil, err
@@ -124,6 +127,8 @@ func scanBeadWithTotal(row scannable) (*model.Bead, int, error) {
 		&dueAt,
 		&deferUntil,
 		&fields,
+		&b.Estimate,
+		&b.Actual,
 	)
 	if err != nil {
 		return nil, 0, err
@@ -175,7 +180,9 @@ func scanDependency(row scannable) (*model.Dependency, error) {
 		return nil, err
 	}
 	d.CreatedBy = createdBy.String
-	d.Metadata = metadata.String
+	if metadata.String != "" {
+		d.Metadata = json.RawMessage(metadata.String)
+	}
 	return &d, nil
 }
 
@@ -198,18 +205,34 @@ func scanDependencies(rows *sql.Rows) ([]*model.Dependency, error) {
 // scanComment scans a single row into a model.Comment.
 func scanComment(row scannable) (*model.Comment, error) {
 	var c model.Comment
-	var author sql.NullString
+	var author, resolvedBy sql.NullString
+	var resolvedAt sql.NullTime
+	var reactions []byte
 	err := row.Scan(
 		&c.ID,
 		&c.BeadID,
 		&author,
+		&c.ClaimedAuthor,
+		&c.Verified,
 		&c.Text,
 		&c.CreatedAt,
+		&resolvedAt,
+		&resolvedBy,
+		&reactions,
 	)
 	if err != nil {
 		return nil, err
 	}
 	c.Author = author.String
+	if resolvedAt.Valid {
+		c.ResolvedAt = &resolvedAt.Time
+	}
+	c.ResolvedBy = resolvedBy.String
+	if len(reactions) > 0 {
+		if err := json.Unmarshal(reactions, &c.Reactions); err != nil {
+			return nil, err
+		}
+	}
 	return &c, nil
 }
 
@@ -229,6 +252,67 @@ func scanComments(rows *sql.Rows) ([]*model.Comment, error) {
 	return comments, nil
 }
 
+// scanChecklistItem scans a row of checklistColumns.
+func scanChecklistItem(row scannable) (*model.ChecklistItem, error) {
+	var it model.ChecklistItem
+	var checkedAt sql.NullTime
+	if err := row.Scan(&it.BeadID, &it.Index, &it.Text, &it.Checked, &checkedAt, &it.CheckedBy, &it.CreatedBy, &it.CreatedAt); err != nil {
+		return nil, err
+	}
+	if checkedAt.Valid {
+		t := checkedAt.Time
+		it.CheckedAt = &t
+	}
+	return &it, nil
+}
+
+// scanChecklist scans rows of checklistColumns.
+func scanChecklist(rows *sql.Rows) ([]*model.ChecklistItem, error) {
+	var items []*model.ChecklistItem
+	for rows.Next() {
+		it, err := scanChecklistItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
+
+// scanNote scans a single row into a model.Note.
+func scanNote(row scannable) (*model.Note, error) {
+	var n model.Note
+	var author sql.NullString
+	err := row.Scan(
+		&n.ID,
+		&n.BeadID,
+		&author,
+		&n.Text,
+		&n.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n.Author = author.String
+	return &n, nil
+}
+
+// scanNotes scans multiple rows into a slice of model.Note pointers.
+func scanNotes(rows *sql.Rows) ([]*model.Note, error) {
+	var notes []*model.Note
+	for rows.Next() {
+		n, err := scanNote(rows)
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
 // scanEvent scans a single row into a model.Event.
 func scanEvent(row scannable) (*model.Event, error) {
 	var e model.Event
@@ -263,6 +347,16 @@ func scanEvents(rows *sql.Rows) ([]*model.Event, error) {
 	return events, nil
 }
 
+// scanEventSummary scans a single row into a model.EventSummary.
+func scanEventSummary(row scannable) (*model.EventSummary, error) {
+	var sum model.EventSummary
+	err := row.Scan(&sum.Topic, &sum.BeadID, &sum.Day, &sum.Count, pq.Array(&sum.Actors), &sum.FirstAt, &sum.LastAt)
+	if err != nil {
+		return nil, err
+	}
+	return &sum, nil
+}
+
 // scanConfig scans a single row into a model.Config.
 func scanConfig(row scannable) (*model.Config, error) {
 	var c model.Config
@@ -291,6 +385,22 @@ func scanConfigs(rows *sql.Rows) ([]*model.Config, error) {
 	return configs, nil
 }
 
+// scanConfigRevision scans a single row into a model.ConfigRevision. A NULL
+// value marks a deletion.
+func scanConfigRevision(row scannable) (*model.ConfigRevision, error) {
+	var r model.ConfigRevision
+	var value []byte
+	if err := row.Scan(&r.Key, &r.Rev, &value, &r.Actor, &r.CreatedAt); err != nil {
+		return nil, err
+	}
+	if value == nil {
+		r.Deleted = true
+	} else {
+		r.Value = json.RawMessage(value)
+	}
+	return &r, nil
+}
+
 // nullTimePtr converts a *time.Time to a sql.NullTime.
 func nullTimePtr(t *time.Time) sql.NullTime {
 	if t == nil {
@@ -314,3 +424,218 @@ func jsonbBytes(m json.RawMessage) []byte {
 	}
 	return []byte(m)
 }
+
+// trailingScanner appends extra scan destinations after the ones passed to
+// Scan, so scanBead can read rows with additional trailing columns.
+type trailingScanner struct {
+	scannable
+	extra []any
+}
+
+func (s trailingScanner) Scan(dest ...any) error {
+	return s.scannable.Scan(append(dest, s.extra...)...)
+}
+
+// scanComputed scans a row of bead columns followed by computedColumns,
+// using scan for the bead columns.
+func scanComputed(row scannable, scan func(scannable) (*model.Bead, error)) (*model.Bead, error) {
+	var (
+		ageDays        int
+		blockedCount   int
+		lastActivityAt sql.NullTime
+		checklistDone  int
+		checklistTotal int
+		effective      int
+		archivedAt     sql.NullTime
+	)
+	b, err := scan(trailingScanner{row, []any{&ageDays, &blockedCount, &lastActivityAt, &checklistDone, &checklistTotal, &effective, &archivedAt}})
+	if err != nil {
+		return nil, err
+	}
+	b.AgeDays = ageDays
+	b.BlockedCount = blockedCount
+	b.ChecklistDone = checklistDone
+	b.ChecklistTotal = checklistTotal
+	b.EffectivePriority = &effective
+	if lastActivityAt.Valid {
+		t := lastActivityAt.Time
+		b.LastActivityAt = &t
+	}
+	if archivedAt.Valid {
+		t := archivedAt.Time
+		b.ArchivedAt = &t
+	}
+	return b, nil
+}
+
+// scanDeletedBead scans the standard bead columns followed by deleted_at and
+// deleted_by. Used by queryListDeletedBeads.
+func scanDeletedBead(row scannable) (*model.Bead, error) {
+	var (
+		deletedAt sql.NullTime
+		deletedBy sql.NullString
+	)
+	b, err := scanBead(trailingScanner{row, []any{&deletedAt, &deletedBy}})
+	if err != nil {
+		return nil, err
+	}
+	if deletedAt.Valid {
+		t := deletedAt.Time
+		b.DeletedAt = &t
+	}
+	b.DeletedBy = deletedBy.String
+	return b, nil
+}
+
+// scanAgent scans a single row into a model.Agent.
+func scanAgent(row scannable) (*model.Agent, error) {
+	var a model.Agent
+	if err := row.Scan(&a.Name, &a.BeadID, &a.TokenHash, &a.HookTokenHash, &a.CreatedAt, &a.CreatedBy); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func scanAgents(rows *sql.Rows) ([]*model.Agent, error) {
+	var agents []*model.Agent
+	for rows.Next() {
+		a, err := scanAgent(rows)
+		if err != nil {
+			return nil, err
+		}
+		agents = append(agents, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return agents, nil
+}
+
+func scanExternalDeps(rows *sql.Rows) ([]*model.ExternalDep, error) {
+	var deps []*model.ExternalDep
+	for rows.Next() {
+		var (
+			d         model.ExternalDep
+			status    string
+			checkedAt sql.NullTime
+		)
+		err := rows.Scan(&d.ID, &d.BeadID, &d.URL, &d.Description, &status, &d.Probe,
+			&checkedAt, &d.LastError, &d.CreatedBy, &d.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		d.Status = model.ExternalStatus(status)
+		if checkedAt.Valid {
+			d.CheckedAt = &checkedAt.Time
+		}
+		deps = append(deps, &d)
+	}
+	return deps, rows.Err()
+}
+
+func scanCommits(rows *sql.Rows) ([]*model.Commit, error) {
+	var commits []*model.Commit
+	for rows.Next() {
+		var c model.Commit
+		if err := rows.Scan(&c.ID, &c.BeadID, &c.Repo, &c.SHA, &c.Message, &c.Author, &c.LinkedBy, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		commits = append(commits, &c)
+	}
+	return commits, rows.Err()
+}
+
+func scanActor(row scannable) (*model.Actor, error) {
+	var (
+		a   model.Actor
+		typ string
+	)
+	if err := row.Scan(&a.ID, &a.DisplayName, &typ, &a.CreatedBy, &a.CreatedAt, pq.Array(&a.Aliases)); err != nil {
+		return nil, err
+	}
+	a.Type = model.ActorType(typ)
+	return &a, nil
+}
+
+// scanNotification scans a single row into a model.Notification and its event.
+func scanNotification(row scannable) (*model.Notification, error) {
+	var (
+		n       model.Notification
+		e       model.Event
+		readAt  sql.NullTime
+		actor   sql.NullString
+		payload []byte
+	)
+	err := row.Scan(
+		&n.ID, &n.Actor, &n.CreatedAt, &readAt,
+		&e.ID, &e.Topic, &e.BeadID, &actor, &payload, &e.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if readAt.Valid {
+		n.ReadAt = &readAt.Time
+	}
+	e.Actor = actor.String
+	e.Payload = json.RawMessage(payload)
+	n.Event = &e
+	return &n, nil
+}
+
+// scanNotifications scans multiple rows into a slice of model.Notification pointers.
+func scanNotifications(rows *sql.Rows) ([]*model.Notification, error) {
+	var notifications []*model.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// scanDigest scans a single row into a model.Digest.
+func scanDigest(row scannable) (*model.Digest, error) {
+	var (
+		d               model.Digest
+		beadIDs, newIDs []byte
+	)
+	if err := row.Scan(&d.ID, &d.Subscription, &d.GeneratedAt, &beadIDs, &newIDs); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(beadIDs, &d.BeadIDs); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(newIDs, &d.NewIDs); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// scanMirroredBead scans a single row into a model.MirroredBead.
+func scanMirroredBead(row scannable) (*model.MirroredBead, error) {
+	var (
+		m    model.MirroredBead
+		bead []byte
+	)
+	if err := row.Scan(&m.Remote, &bead, &m.SyncedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bead, &m.Bead); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// scanBeadLock scans a single row into a model.BeadLock.
+func scanBeadLock(row scannable) (*model.BeadLock, error) {
+	var l model.BeadLock
+	if err := row.Scan(&l.BeadID, &l.Owner, &l.AcquiredAt, &l.ExpiresAt); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
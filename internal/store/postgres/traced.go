@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"runtime"
+	"strings"
+
+	"github.com/alfredjeanlab/beads/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedDB and tracedTx record a client span for every statement. The span
+// is named after the queryXxx function that issued it, so traces show which
+// store call was slow without parsing SQL.
+type tracedDB struct{ *sql.DB }
+
+type tracedTx struct{ *sql.Tx }
+
+func (db tracedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	res, err := db.DB.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+	return res, err
+}
+
+func (db tracedDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	tracing.End(span, err)
+	return rows, err
+}
+
+func (db tracedDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startQuerySpan(ctx, query)
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	tracing.End(span, row.Err())
+	return row
+}
+
+func (tx tracedTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	res, err := tx.Tx.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+	return res, err
+}
+
+func (tx tracedTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	rows, err := tx.Tx.QueryContext(ctx, query, args...)
+	tracing.End(span, err)
+	return rows, err
+}
+
+func (tx tracedTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startQuerySpan(ctx, query)
+	row := tx.Tx.QueryRowContext(ctx, query, args...)
+	tracing.End(span, row.Err())
+	return row
+}
+
+// startQuerySpan starts a span for query, named after the function two
+// frames up: the queryXxx helper calling the traced executor.
+func startQuerySpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	name := "postgres"
+	if pc, _, _, ok := runtime.Caller(2); ok {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			name = "postgres." + fn.Name()[strings.LastIndex(fn.Name(), ".")+1:]
+		}
+	}
+	return tracing.Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "postgresql"),
+			attribute.String("db.query.text", strings.TrimSpace(query)),
+		),
+	)
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracedDB(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	db, mock := newMockDB(t)
+	cols := []string{"id", "topic", "bead_id", "actor", "payload", "created_at"}
+	mock.ExpectQuery("FROM events").WillReturnRows(sqlmock.NewRows(cols))
+	mock.ExpectExec("DELETE FROM labels").WillReturnError(errors.New("connection reset"))
+
+	if _, err := queryListEventsByActor(context.Background(), tracedDB{db}, "alice", 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := queryRemoveLabel(context.Background(), tracedDB{db}, "bd-a", "x"); err == nil {
+		t.Fatal("exec error was swallowed")
+	}
+
+	spans := rec.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if spans[0].Name() != "postgres.queryListEventsByActor" || spans[1].Name() != "postgres.queryRemoveLabel" {
+		t.Errorf("span names = %q, %q", spans[0].Name(), spans[1].Name())
+	}
+	if spans[1].Status().Code != codes.Error {
+		t.Errorf("failed exec status = %v, want error", spans[1].Status())
+	}
+}
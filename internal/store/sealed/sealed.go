@@ -0,0 +1,172 @@
+// Package sealed wraps a store.Store so that secret config fields are
+// encrypted at rest.
+//
+// Every string held by a secret field of a config value (see
+// model.IsSecretField) is sealed with AES-256-GCM on its way into the store
+// and opened on every read, including config revisions, so callers only
+// ever see plaintext. Sealed strings are stored as "enc:v1:" followed by the
+// base64 nonce and ciphertext. Strings without that prefix are read as is,
+// so configs saved before a key was set keep working and are sealed the next
+// time they are written.
+package sealed
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// KeySize is the length of the encryption key in bytes.
+const KeySize = 32
+
+// sealedPrefix marks a sealed secret.
+const sealedPrefix = "enc:v1:"
+
+// Store seals secret config fields written through it and opens them on
+// read. Every other method goes straight to the inner store.
+type Store struct {
+	store.Store
+	aead cipher.AEAD
+}
+
+// Compile-time check that Store implements store.Store.
+var _ store.Store = (*Store)(nil)
+
+// New wraps inner, sealing secrets with key, which must be KeySize bytes.
+func New(inner store.Store, key []byte) (*Store, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("config encryption key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Store: inner, aead: aead}, nil
+}
+
+// seal encrypts a secret; sealed secrets are left alone.
+func (s *Store) seal(plain string) (string, error) {
+	if plain == "" || strings.HasPrefix(plain, sealedPrefix) {
+		return plain, nil
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+	return sealedPrefix + base64.StdEncoding.EncodeToString(s.aead.Seal(nonce, nonce, []byte(plain), nil)), nil
+}
+
+// open decrypts a sealed secret; other strings are returned as is.
+func (s *Store) open(v string) (string, error) {
+	enc, ok := strings.CutPrefix(v, sealedPrefix)
+	if !ok {
+		return v, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(enc)
+	if err != nil || len(raw) < s.aead.NonceSize() {
+		return "", errors.New("malformed sealed secret")
+	}
+	plain, err := s.aead.Open(nil, raw[:s.aead.NonceSize()], raw[s.aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("opening sealed secret: %w", err)
+	}
+	return string(plain), nil
+}
+
+func (s *Store) openValue(key string, value json.RawMessage) (json.RawMessage, error) {
+	out, _, err := model.MapSecrets(value, s.open)
+	if err != nil {
+		return nil, fmt.Errorf("config %s: %w", key, err)
+	}
+	return out, nil
+}
+
+func (s *Store) openConfigs(configs []*model.Config, err error) ([]*model.Config, error) {
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range configs {
+		if c.Value, err = s.openValue(c.Key, c.Value); err != nil {
+			return nil, err
+		}
+	}
+	return configs, nil
+}
+
+// SetConfig stores config with its secrets sealed. config.Value is left in
+// plaintext for the caller.
+func (s *Store) SetConfig(ctx context.Context, config *model.Config) error {
+	plain := config.Value
+	sealed, _, err := model.MapSecrets(plain, s.seal)
+	if err != nil {
+		return fmt.Errorf("config %s: %w", config.Key, err)
+	}
+	config.Value = sealed
+	err = s.Store.SetConfig(ctx, config)
+	config.Value = plain
+	return err
+}
+
+func (s *Store) GetConfig(ctx context.Context, key string) (*model.Config, error) {
+	c, err := s.Store.GetConfig(ctx, key)
+	if err != nil || c == nil {
+		return c, err
+	}
+	if c.Value, err = s.openValue(c.Key, c.Value); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *Store) ListConfigs(ctx context.Context, namespace string) ([]*model.Config, error) {
+	return s.openConfigs(s.Store.ListConfigs(ctx, namespace))
+}
+
+func (s *Store) ListAllConfigs(ctx context.Context) ([]*model.Config, error) {
+	return s.openConfigs(s.Store.ListAllConfigs(ctx))
+}
+
+func (s *Store) ListConfigRevisions(ctx context.Context, key string) ([]*model.ConfigRevision, error) {
+	revs, err := s.Store.ListConfigRevisions(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range revs {
+		if r.Value, err = s.openValue(r.Key, r.Value); err != nil {
+			return nil, err
+		}
+	}
+	return revs, nil
+}
+
+func (s *Store) GetConfigRevision(ctx context.Context, key string, rev int64) (*model.ConfigRevision, error) {
+	r, err := s.Store.GetConfigRevision(ctx, key, rev)
+	if err != nil || r == nil {
+		return r, err
+	}
+	if r.Value, err = s.openValue(r.Key, r.Value); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// RunInTransaction runs fn against the inner store's transaction, wrapped so
+// configs written and read inside it are sealed too.
+func (s *Store) RunInTransaction(ctx context.Context, fn func(tx store.Store) error) error {
+	return s.Store.RunInTransaction(ctx, func(tx store.Store) error {
+		return fn(&Store{Store: tx, aead: s.aead})
+	})
+}
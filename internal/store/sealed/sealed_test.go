@@ -0,0 +1,116 @@
+package sealed
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// fakeStore keeps configs and their revisions as written, to show what
+// reaches the database.
+type fakeStore struct {
+	store.Store // unimplemented methods panic
+	configs     map[string]*model.Config
+	revs        []*model.ConfigRevision
+}
+
+func (f *fakeStore) SetConfig(_ context.Context, c *model.Config) error {
+	stored := *c
+	f.configs[c.Key] = &stored
+	f.revs = append(f.revs, &model.ConfigRevision{Key: c.Key, Rev: int64(len(f.revs) + 1), Value: c.Value})
+	return nil
+}
+
+func (f *fakeStore) GetConfig(_ context.Context, key string) (*model.Config, error) {
+	c, ok := f.configs[key]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	out := *c
+	return &out, nil
+}
+
+func (f *fakeStore) ListConfigs(_ context.Context, _ string) ([]*model.Config, error) {
+	var out []*model.Config
+	for _, c := range f.configs {
+		cp := *c
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+func (f *fakeStore) GetConfigRevision(_ context.Context, _ string, rev int64) (*model.ConfigRevision, error) {
+	r := *f.revs[rev-1]
+	return &r, nil
+}
+
+func (f *fakeStore) RunInTransaction(_ context.Context, fn func(tx store.Store) error) error {
+	return fn(f)
+}
+
+func TestStore_SealsSecrets(t *testing.T) {
+	inner := &fakeStore{configs: map[string]*model.Config{}}
+	s, err := New(inner, bytes.Repeat([]byte{7}, KeySize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	value := json.RawMessage(`{"bot_token":"xoxb-secret","channel":"#ops"}`)
+	c := &model.Config{Key: "integration:slack", Value: value}
+	if err := s.SetConfig(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	if string(c.Value) != string(value) {
+		t.Fatalf("caller's value = %s, want it in plaintext", c.Value)
+	}
+	stored := string(inner.configs["integration:slack"].Value)
+	if strings.Contains(stored, "xoxb-secret") || !strings.Contains(stored, `"bot_token":"enc:v1:`) || !strings.Contains(stored, `"channel":"#ops"`) {
+		t.Fatalf("stored value = %s", stored)
+	}
+
+	got, err := s.GetConfig(ctx, "integration:slack")
+	if err != nil || !strings.Contains(string(got.Value), `"bot_token":"xoxb-secret"`) {
+		t.Fatalf("GetConfig = %+v, %v", got, err)
+	}
+	list, err := s.ListConfigs(ctx, "integration")
+	if err != nil || len(list) != 1 || !strings.Contains(string(list[0].Value), "xoxb-secret") {
+		t.Fatalf("ListConfigs = %+v, %v", list, err)
+	}
+	rev, err := s.GetConfigRevision(ctx, "integration:slack", 1)
+	if err != nil || !strings.Contains(string(rev.Value), "xoxb-secret") {
+		t.Fatalf("GetConfigRevision = %+v, %v", rev, err)
+	}
+
+	// Writes inside a transaction are sealed too.
+	err = s.RunInTransaction(ctx, func(tx store.Store) error {
+		return tx.SetConfig(ctx, &model.Config{Key: "integration:ci", Value: json.RawMessage(`{"token":"ci-secret"}`)})
+	})
+	if err != nil || strings.Contains(string(inner.configs["integration:ci"].Value), "ci-secret") {
+		t.Fatalf("transactional write stored %s, %v", inner.configs["integration:ci"].Value, err)
+	}
+
+	// Plaintext saved before a key was set still reads back.
+	inner.configs["integration:old"] = &model.Config{Key: "integration:old", Value: json.RawMessage(`{"password":"hunter2"}`)}
+	if got, err := s.GetConfig(ctx, "integration:old"); err != nil || !strings.Contains(string(got.Value), "hunter2") {
+		t.Fatalf("legacy config = %+v, %v", got, err)
+	}
+
+	// Another key cannot open the secrets.
+	other, _ := New(inner, bytes.Repeat([]byte{8}, KeySize))
+	if _, err := other.GetConfig(ctx, "integration:slack"); err == nil {
+		t.Fatal("expected an error opening with the wrong key")
+	}
+}
+
+func TestNew_KeySize(t *testing.T) {
+	if _, err := New(&fakeStore{}, []byte("short")); err == nil {
+		t.Fatal("expected an error for a short key")
+	}
+}
@@ -2,37 +2,193 @@ package store
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/alfredjeanlab/beads/internal/model"
 )
 
+// ErrConflict is returned by UpdateBead when the bead changed after it was
+// read. Callers re-read it and try again.
+var ErrConflict = errors.New("bead was modified concurrently")
+
+// ErrSlugTaken is returned by CreateBead when a live bead already has the new
+// bead's slug. Callers pick the next free slug and try again.
+var ErrSlugTaken = errors.New("slug is taken")
+
 // Store defines the persistence interface for beads.
 type Store interface {
 	// Bead CRUD
 	CreateBead(ctx context.Context, bead *model.Bead) error
 	GetBead(ctx context.Context, id string) (*model.Bead, error)
 	ListBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error) // returns beads, total count, error
+	// ListReadyBeads is ListBeads restricted to beads with no unclosed
+	// blocker. Status defaults to open.
+	ListReadyBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error)
+	// ListBlockedBeads is ListBeads restricted to beads with at least one
+	// unclosed blocker. Status defaults to open.
+	ListBlockedBeads(ctx context.Context, filter model.BeadFilter) ([]*model.Bead, int, error)
+	// ClaimReadyBead atomically assigns the most urgent ready, open issue bead
+	// that is unassigned (or already assigned to actor) and has any of
+	// labels to actor, and sets it in progress. Concurrent calls never claim
+	// the same bead. Returns sql.ErrNoRows if no bead qualifies.
+	ClaimReadyBead(ctx context.Context, actor string, labels []string) (*model.Bead, error)
+	// StreamBeads calls fn with each bead matching filter as it is read,
+	// without buffering the result; computed fields are set, relations are
+	// not. fn must not use the same transaction.
+	StreamBeads(ctx context.Context, filter model.BeadFilter, fn func(*model.Bead) error) error
+	// AggregateBeads groups the beads matching filter by groupBy, one of
+	// model.GroupByDimensions, ordered by key. Limit, Offset and Sort are
+	// ignored.
+	AggregateBeads(ctx context.Context, filter model.BeadFilter, groupBy string) ([]*model.AggregateGroup, error)
+	// RollupBeads summarizes the progress of the beads matching filter,
+	// counting as recent the weight closed since since. ETA and WindowDays
+	// are left to the caller. Limit, Offset and Sort are ignored.
+	RollupBeads(ctx context.Context, filter model.BeadFilter, since time.Time) (*model.Rollup, error)
+	// UpdateBead writes bead if its row is unchanged since it was read:
+	// bead.UpdatedAt must be the updated_at that was read, and is set to the
+	// new one. Returns ErrConflict if the row has changed since and
+	// sql.ErrNoRows if it is gone.
 	UpdateBead(ctx context.Context, bead *model.Bead) error
 	CloseBead(ctx context.Context, id string, closedBy string) (*model.Bead, error)
-	DeleteBead(ctx context.Context, id string) error
+	// RecordCreateKey records that the idempotency key created beadID,
+	// unless the key is already recorded, and returns the bead ID recorded
+	// for it. Use it in the transaction that creates the bead.
+	RecordCreateKey(ctx context.Context, key, beadID string) (string, error)
+	DeleteBead(ctx context.Context, id string) error // permanent; also removes trashed beads
+
+	// Trash (soft delete). Trashed beads are hidden from GetBead and ListBeads.
+	SoftDeleteBead(ctx context.Context, id, deletedBy string) error
+	RestoreBead(ctx context.Context, id string) (*model.Bead, error)
+	ListDeletedBeads(ctx context.Context) ([]*model.Bead, error)               // newest first, with DeletedAt/DeletedBy set
+	PurgeDeletedBeads(ctx context.Context, before time.Time) ([]string, error) // permanently deletes beads trashed before the cutoff; returns their IDs
+
+	// Archive
+	ArchiveClosedBeads(ctx context.Context, closedBefore time.Time) ([]string, error) // archives beads closed before the cutoff; returns their IDs
+
+	// Duplicates
+	MergeBead(ctx context.Context, sourceID, targetID string) error                                     // moves comments, notes, labels, deps and events; use in a transaction
+	SimilarBeads(ctx context.Context, title, excludeID string, limit int) ([]*model.SimilarBead, error) // unclosed beads with trigram-similar titles, best first
 
 	// Dependencies
 	AddDependency(ctx context.Context, dep *model.Dependency) error
 	RemoveDependency(ctx context.Context, beadID, dependsOnID string, depType model.DependencyType) error
 	GetDependencies(ctx context.Context, beadID string) ([]*model.Dependency, error)
+	GetDependents(ctx context.Context, beadID string) ([]*model.Dependency, error) // inbound: deps whose depends_on_id is beadID
+	// UpdateDependencyMetadata replaces the metadata of the dependency
+	// identified by dep's bead, target and type, filling in the rest of dep.
+	// Returns sql.ErrNoRows if there is no such dependency.
+	UpdateDependencyMetadata(ctx context.Context, dep *model.Dependency) error
 
 	// Labels
 	AddLabel(ctx context.Context, beadID string, label string) error
 	RemoveLabel(ctx context.Context, beadID string, label string) error
 	GetLabels(ctx context.Context, beadID string) ([]string, error)
+	ListLabels(ctx context.Context) ([]*model.LabelCount, error) // labels on live beads with bead counts, by namespace and value
+
+	// Slugs and aliases. ResolveBeadRef maps a bead ID, slug or alias to the
+	// ID of a live bead, preferring an exact ID, then a slug, then an alias.
+	// It and RemoveAlias return sql.ErrNoRows when there is no match.
+	ResolveBeadRef(ctx context.Context, ref string) (string, error)
+	AddAlias(ctx context.Context, alias *model.Alias) error
+	RemoveAlias(ctx context.Context, beadID, alias string) error
+	GetAliases(ctx context.Context, beadID string) ([]*model.Alias, error) // alias order
 
 	// Comments
 	AddComment(ctx context.Context, comment *model.Comment) error
 	GetComments(ctx context.Context, beadID string) ([]*model.Comment, error)
+	GetComment(ctx context.Context, id int64) (*model.Comment, error)
+	// Reactions are one per actor and emoji; adding an existing one and
+	// removing a missing one are no-ops.
+	AddReaction(ctx context.Context, commentID int64, actor, emoji string) error
+	RemoveReaction(ctx context.Context, commentID int64, actor, emoji string) error
+	// ResolveComment marks a comment resolved by actor, or unresolved when
+	// resolved is false.
+	ResolveComment(ctx context.Context, commentID int64, actor string, resolved bool) error
+	// ImportComment inserts a comment restored from a snapshot, keeping its
+	// ID, created_at and resolution.
+	ImportComment(ctx context.Context, comment *model.Comment) error
+
+	// LoadRelations sets the labels, dependencies and comments of every bead
+	// in beads, with one query per relation rather than one per bead.
+	LoadRelations(ctx context.Context, beads []*model.Bead) error
+	ListCommentsByAuthor(ctx context.Context, author string, limit int) ([]*model.Comment, error) // newest first
+
+	// Notes. Appends are atomic: the entry is added to the bead's Notes field
+	// and recorded in the note history in one step.
+	AppendNote(ctx context.Context, note *model.Note) error
+	GetNotes(ctx context.Context, beadID string) ([]*model.Note, error)
+	AppendDescription(ctx context.Context, id, text string) (string, error) // returns the new description
 
-	// Events
+	// Checklists. AddChecklistItems appends an item per text to a bead's
+	// checklist, added by actor and numbered after its last item; it returns
+	// ErrConflict if a concurrent add took the same numbers.
+	// CheckChecklistItem checks item index of a bead, recording actor, or
+	// unchecks it when checked is false, and returns it; it returns
+	// sql.ErrNoRows if the bead has no such item.
+	AddChecklistItems(ctx context.Context, beadID string, texts []string, actor string) ([]*model.ChecklistItem, error)
+	GetChecklist(ctx context.Context, beadID string) ([]*model.ChecklistItem, error) // index order
+	CheckChecklistItem(ctx context.Context, beadID string, index int, actor string, checked bool) (*model.ChecklistItem, error)
+
+	// Events. Recorded events form an outbox: each stays unpublished, in ID
+	// order, until the dispatcher marks it published.
 	RecordEvent(ctx context.Context, event *model.Event) error
 	GetEvents(ctx context.Context, beadID string) ([]*model.Event, error)
+	ListEventsByActor(ctx context.Context, actor string, limit int) ([]*model.Event, error) // newest first
+	// ListEventsBetween returns the events recorded in [from, to) with any
+	// of topics, or any topic when topics is empty, oldest first.
+	ListEventsBetween(ctx context.Context, from, to time.Time, topics []string) ([]*model.Event, error)
+	// ListEvents returns the events matching filter across all beads,
+	// oldest first; page through them with filter.AfterID.
+	ListEvents(ctx context.Context, filter model.EventFilter) ([]*model.Event, error)
+	ListUnpublishedEvents(ctx context.Context, limit int) ([]*model.Event, error) // oldest first
+	MarkEventPublished(ctx context.Context, id int64) error
+	// ImportEvent inserts an event restored from a snapshot, keeping its ID
+	// and created_at. It is recorded as already published and notifies no
+	// one.
+	ImportEvent(ctx context.Context, event *model.Event) error
+	// CompactEvents deletes the published events recorded before cutoff
+	// whose topic matches any of topics and none of except (patterns as in
+	// model.RetentionRule), counting them into the event summaries, and
+	// returns how many it deleted.
+	CompactEvents(ctx context.Context, topics, except []string, cutoff time.Time) (int64, error)
+	ListEventSummaries(ctx context.Context, filter model.EventSummaryFilter) ([]*model.EventSummary, error) // newest day first
+
+	// Watchers. Recording an event on a watched bead creates a notification
+	// for each watcher other than the event's actor.
+	AddWatcher(ctx context.Context, beadID, actor string) error
+	RemoveWatcher(ctx context.Context, beadID, actor string) error
+	GetWatchers(ctx context.Context, beadID string) ([]string, error)
+	ListNotifications(ctx context.Context, actor string, unreadOnly bool, limit int) ([]*model.Notification, error)
+	MarkNotificationsRead(ctx context.Context, actor string, ids []int64) (int64, error) // empty ids marks all
+	// NotifyActors notifies actors of an event beyond its bead's watchers,
+	// skipping its own actor and anyone already notified of it.
+	NotifyActors(ctx context.Context, eventID int64, actors []string) error
+
+	// Advice acknowledgments. Acknowledging twice is a no-op.
+	AckAdvice(ctx context.Context, beadID, actor string) error
+	ListAdviceAcks(ctx context.Context, actor string) ([]string, error) // IDs of the advice beads actor acknowledged
+
+	// Bead locks. AcquireBeadLock takes lock, or renews it if lock.Owner
+	// already holds it, unless another owner holds a lock that has not
+	// expired by lock.AcquiredAt; either way it returns the lock then held.
+	// GetBeadLock returns sql.ErrNoRows when the bead has no lock; the lock
+	// it returns may have expired.
+	AcquireBeadLock(ctx context.Context, lock *model.BeadLock) (*model.BeadLock, error)
+	GetBeadLock(ctx context.Context, beadID string) (*model.BeadLock, error)
+	ReleaseBeadLock(ctx context.Context, beadID string) error
+
+	// Digests. GetLatestDigest returns sql.ErrNoRows if the subscription has
+	// never run.
+	CreateDigest(ctx context.Context, digest *model.Digest) error
+	GetLatestDigest(ctx context.Context, subscription string) (*model.Digest, error)
+
+	// Mirrors. ReplaceMirroredBeads swaps the whole mirrored set of remote
+	// for beads; use it in a transaction. GetMirroredBead returns
+	// sql.ErrNoRows when remote has no mirrored bead id.
+	ReplaceMirroredBeads(ctx context.Context, remote string, beads []*model.Bead) error
+	ListMirroredBeads(ctx context.Context, remote string) ([]*model.MirroredBead, error) // by bead ID
+	GetMirroredBead(ctx context.Context, remote, id string) (*model.MirroredBead, error)
 
 	// Configs
 	SetConfig(ctx context.Context, config *model.Config) error
@@ -41,8 +197,60 @@ type Store interface {
 	ListAllConfigs(ctx context.Context) ([]*model.Config, error)
 	DeleteConfig(ctx context.Context, key string) error
 
+	// Config revisions. Every SetConfig and DeleteConfig appends one.
+	// GetConfigRevision returns sql.ErrNoRows for an unknown revision.
+	ListConfigRevisions(ctx context.Context, key string) ([]*model.ConfigRevision, error)
+	GetConfigRevision(ctx context.Context, key string, rev int64) (*model.ConfigRevision, error)
+
+	// Agents. GetAgent, GetAgentByTokenHash and GetAgentByHookTokenHash
+	// return sql.ErrNoRows when there is no match. ListAgents returns every
+	// agent in name order. SetAgentHookToken replaces the agent's hook token
+	// hash, or revokes it when tokenHash is empty; it returns sql.ErrNoRows
+	// for an unknown agent.
+	CreateAgent(ctx context.Context, agent *model.Agent) error
+	GetAgent(ctx context.Context, name string) (*model.Agent, error)
+	GetAgentByTokenHash(ctx context.Context, tokenHash string) (*model.Agent, error)
+	GetAgentByHookTokenHash(ctx context.Context, tokenHash string) (*model.Agent, error)
+	SetAgentHookToken(ctx context.Context, name, tokenHash string) error
+	ListAgents(ctx context.Context) ([]*model.Agent, error)
+
+	// External dependencies. A waiting one keeps its bead out of the ready
+	// list. UpdateExternalDep writes Status, CheckedAt and LastError.
+	// UpdateExternalDep and RemoveExternalDep return sql.ErrNoRows if the
+	// bead has no such dependency. ListProbedExternalDeps returns the
+	// waiting ones with a probe on beads neither closed nor deleted, least
+	// recently checked first.
+	AddExternalDep(ctx context.Context, dep *model.ExternalDep) error
+	GetExternalDeps(ctx context.Context, beadID string) ([]*model.ExternalDep, error) // ID order
+	ListProbedExternalDeps(ctx context.Context) ([]*model.ExternalDep, error)
+	UpdateExternalDep(ctx context.Context, dep *model.ExternalDep) error
+	RemoveExternalDep(ctx context.Context, beadID string, id int64) error
+
+	// Commits linked to beads. LinkCommit fills in c's ID and CreatedAt and
+	// returns true, or, if the bead already has that repo and SHA, fills in
+	// the existing link and returns false.
+	LinkCommit(ctx context.Context, c *model.Commit) (bool, error)
+	GetCommits(ctx context.Context, beadID string) ([]*model.Commit, error) // newest first
+
+	// Actors. Aliases are matched lowercase and IDs case-insensitively.
+	// GetActor, UpdateActor, DeleteActor, RemoveActorAlias and ResolveActor
+	// return sql.ErrNoRows when there is no match. ListActors returns every
+	// actor in ID order. ResolveActor returns the ID of the actor whose ID
+	// or alias is name.
+	CreateActor(ctx context.Context, actor *model.Actor) error
+	GetActor(ctx context.Context, id string) (*model.Actor, error)
+	ListActors(ctx context.Context) ([]*model.Actor, error)
+	UpdateActor(ctx context.Context, actor *model.Actor) error
+	DeleteActor(ctx context.Context, id string) error
+	AddActorAlias(ctx context.Context, id, alias string) error
+	RemoveActorAlias(ctx context.Context, id, alias string) error
+	ResolveActor(ctx context.Context, name string) (string, error)
+
 	// Transaction support
 	RunInTransaction(ctx context.Context, fn func(tx Store) error) error
+	// RunInSnapshot runs fn in a read-only, repeatable-read transaction, so
+	// every read in fn sees the same snapshot of the store.
+	RunInSnapshot(ctx context.Context, fn func(tx Store) error) error
 
 	// Lifecycle
 	Close() error
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"sort"
 	"time"
 
 	"github.com/alfredjeanlab/beads/internal/model"
@@ -27,41 +26,29 @@ type record struct {
 	Data interface{} `json:"data"`
 }
 
+// exportPageSize is how many beads ExportJSONL reads, and loads the
+// relations of, at a time.
+const exportPageSize = 500
+
 // ExportJSONL writes all beads and configs from the store as JSONL to w.
 // Beads are sorted by ID and include embedded labels, dependencies, and comments.
+// Everything is read in one snapshot, so the header's counts match the
+// records that follow. Beads are read a page at a time, with each page's
+// relations loaded together, so memory use does not grow with the number of
+// beads.
 func ExportJSONL(ctx context.Context, s store.Store, w io.Writer) error {
-	// Fetch all beads (no filter, no limit).
-	beads, _, err := s.ListBeads(ctx, model.BeadFilter{Sort: "created_at"})
-	if err != nil {
-		return fmt.Errorf("list beads: %w", err)
-	}
-
-	// Populate relational data for each bead.
-	for _, b := range beads {
-		labels, err := s.GetLabels(ctx, b.ID)
-		if err != nil {
-			return fmt.Errorf("get labels for %s: %w", b.ID, err)
-		}
-		b.Labels = labels
-
-		deps, err := s.GetDependencies(ctx, b.ID)
-		if err != nil {
-			return fmt.Errorf("get dependencies for %s: %w", b.ID, err)
-		}
-		b.Dependencies = deps
+	return s.RunInSnapshot(ctx, func(tx store.Store) error {
+		return exportSnapshot(ctx, tx, w)
+	})
+}
 
-		comments, err := s.GetComments(ctx, b.ID)
-		if err != nil {
-			return fmt.Errorf("get comments for %s: %w", b.ID, err)
-		}
-		b.Comments = comments
+func exportSnapshot(ctx context.Context, s store.Store, w io.Writer) error {
+	// Count beads without fetching them all.
+	_, beadCount, err := s.ListBeads(ctx, model.BeadFilter{Limit: 1, IncludeArchived: true})
+	if err != nil {
+		return fmt.Errorf("count beads: %w", err)
 	}
 
-	// Sort beads by ID.
-	sort.Slice(beads, func(i, j int) bool {
-		return beads[i].ID < beads[j].ID
-	})
-
 	// Fetch all configs.
 	configs, err := s.ListAllConfigs(ctx)
 	if err != nil {
@@ -76,16 +63,39 @@ func ExportJSONL(ctx context.Context, s store.Store, w io.Writer) error {
 		Version:     "1",
 		Type:        "header",
 		Timestamp:   time.Now().UTC(),
-		BeadCount:   len(beads),
+		BeadCount:   beadCount,
 		ConfigCount: len(configs),
 	}); err != nil {
 		return fmt.Errorf("encode header: %w", err)
 	}
 
-	// Write beads.
-	for _, b := range beads {
-		if err := enc.Encode(record{Type: "bead", Data: b}); err != nil {
-			return fmt.Errorf("encode bead %s: %w", b.ID, err)
+	// Write beads a page at a time. Each page is read in full before its
+	// relations are loaded, since the snapshot has a single connection.
+	page := make([]*model.Bead, 0, exportPageSize)
+	for offset := 0; ; offset += exportPageSize {
+		page = page[:0]
+		err := s.StreamBeads(ctx, model.BeadFilter{
+			Sort:            "id",
+			IncludeArchived: true,
+			Limit:           exportPageSize,
+			Offset:          offset,
+		}, func(b *model.Bead) error {
+			page = append(page, b)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.LoadRelations(ctx, page); err != nil {
+			return fmt.Errorf("load relations: %w", err)
+		}
+		for _, b := range page {
+			if err := enc.Encode(record{Type: "bead", Data: b}); err != nil {
+				return fmt.Errorf("encode bead %s: %w", b.ID, err)
+			}
+		}
+		if len(page) < exportPageSize {
+			break
 		}
 	}
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -125,3 +126,31 @@ func nonEmptyLines(s string) []string {
 	}
 	return result
 }
+
+func TestExportJSONL_Paged(t *testing.T) {
+	ms := newMockStore()
+	for i := 0; i <= exportPageSize; i++ {
+		id := fmt.Sprintf("bd-%04d", i)
+		ms.beads[id] = &model.Bead{ID: id, Kind: model.KindIssue, Type: model.TypeTask, Title: id, Status: model.StatusOpen}
+	}
+	last := fmt.Sprintf("bd-%04d", exportPageSize)
+	ms.labels[last] = []string{"tail"}
+
+	var buf bytes.Buffer
+	if err := ExportJSONL(context.Background(), ms, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := nonEmptyLines(buf.String())
+	if len(lines) != exportPageSize+2 {
+		t.Fatalf("expected %d lines, got %d", exportPageSize+2, len(lines))
+	}
+	var rec struct {
+		Data model.Bead `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &rec); err != nil {
+		t.Fatalf("unmarshal last bead: %v", err)
+	}
+	if rec.Data.ID != last || len(rec.Data.Labels) != 1 {
+		t.Fatalf("last bead = %s with labels %v, want %s with its label", rec.Data.ID, rec.Data.Labels, last)
+	}
+}
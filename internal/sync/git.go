@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -26,7 +27,7 @@ func NewGitDestination(repo, file, branch string) *GitDestination {
 }
 
 // Write writes data to the configured file, commits, and pushes.
-func (d *GitDestination) Write(ctx context.Context, data []byte) error {
+func (d *GitDestination) Write(ctx context.Context, r io.Reader) error {
 	// Ensure we're on the right branch.
 	if err := d.git(ctx, "checkout", d.branch); err != nil {
 		return fmt.Errorf("git checkout: %w", err)
@@ -41,7 +42,15 @@ func (d *GitDestination) Write(ctx context.Context, data []byte) error {
 	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
 		return fmt.Errorf("mkdir: %w", err)
 	}
-	if err := os.WriteFile(filePath, data, 0o644); err != nil {
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("write file: %w", err)
+	}
+	if err := f.Close(); err != nil {
 		return fmt.Errorf("write file: %w", err)
 	}
 
@@ -1,6 +1,7 @@
 package sync
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"os/exec"
@@ -41,7 +42,7 @@ func TestGitDestination(t *testing.T) {
 
 	// First write.
 	data1 := []byte(`{"version":"1","type":"header"}` + "\n")
-	if err := dest.Write(context.Background(), data1); err != nil {
+	if err := dest.Write(context.Background(), bytes.NewReader(data1)); err != nil {
 		t.Fatalf("first write: %v", err)
 	}
 
@@ -55,13 +56,13 @@ func TestGitDestination(t *testing.T) {
 	}
 
 	// Second write with same data should be a no-op (no commit).
-	if err := dest.Write(context.Background(), data1); err != nil {
+	if err := dest.Write(context.Background(), bytes.NewReader(data1)); err != nil {
 		t.Fatalf("second write (no-op): %v", err)
 	}
 
 	// Third write with different data should commit.
 	data2 := []byte(`{"version":"1","type":"header","bead_count":1}` + "\n")
-	if err := dest.Write(context.Background(), data2); err != nil {
+	if err := dest.Write(context.Background(), bytes.NewReader(data2)); err != nil {
 		t.Fatalf("third write: %v", err)
 	}
 
@@ -101,7 +102,7 @@ func TestGitDestination_SubDirectory(t *testing.T) {
 	dest := NewGitDestination(repoDir, "data/beads.jsonl", "main")
 
 	data := []byte(`{"type":"header"}` + "\n")
-	if err := dest.Write(context.Background(), data); err != nil {
+	if err := dest.Write(context.Background(), bytes.NewReader(data)); err != nil {
 		t.Fatalf("write: %v", err)
 	}
 
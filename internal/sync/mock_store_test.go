@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/alfredjeanlab/beads/internal/model"
 	"github.com/alfredjeanlab/beads/internal/store"
@@ -17,6 +18,7 @@ type mockStore struct {
 	labels   map[string][]string
 	deps     map[string][]*model.Dependency
 	comments map[string][]*model.Comment
+	events   []*model.Event
 }
 
 func newMockStore() *mockStore {
@@ -53,6 +55,33 @@ func (m *mockStore) ListBeads(_ context.Context, _ model.BeadFilter) ([]*model.B
 	return result, len(result), nil
 }
 
+func (m *mockStore) ListReadyBeads(_ context.Context, _ model.BeadFilter) ([]*model.Bead, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockStore) ListBlockedBeads(_ context.Context, _ model.BeadFilter) ([]*model.Bead, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockStore) ClaimReadyBead(_ context.Context, _ string, _ []string) (*model.Bead, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockStore) StreamBeads(ctx context.Context, filter model.BeadFilter, fn func(*model.Bead) error) error {
+	beads, _, _ := m.ListBeads(ctx, filter)
+	sort.Slice(beads, func(i, j int) bool { return beads[i].ID < beads[j].ID })
+	beads = beads[min(filter.Offset, len(beads)):]
+	if filter.Limit > 0 && filter.Limit < len(beads) {
+		beads = beads[:filter.Limit]
+	}
+	for _, b := range beads {
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *mockStore) UpdateBead(_ context.Context, bead *model.Bead) error {
 	m.beads[bead.ID] = bead
 	return nil
@@ -72,6 +101,10 @@ func (m *mockStore) AddDependency(_ context.Context, dep *model.Dependency) erro
 	return nil
 }
 
+func (m *mockStore) UpdateDependencyMetadata(_ context.Context, _ *model.Dependency) error {
+	return nil
+}
+
 func (m *mockStore) RemoveDependency(_ context.Context, _ string, _ string, _ model.DependencyType) error {
 	return nil
 }
@@ -80,6 +113,30 @@ func (m *mockStore) GetDependencies(_ context.Context, beadID string) ([]*model.
 	return m.deps[beadID], nil
 }
 
+func (m *mockStore) SoftDeleteBead(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockStore) RestoreBead(_ context.Context, _ string) (*model.Bead, error) {
+	return nil, nil
+}
+
+func (m *mockStore) ListDeletedBeads(_ context.Context) ([]*model.Bead, error) {
+	return nil, nil
+}
+
+func (m *mockStore) PurgeDeletedBeads(_ context.Context, _ time.Time) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockStore) ArchiveClosedBeads(_ context.Context, _ time.Time) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockStore) GetDependents(_ context.Context, _ string) ([]*model.Dependency, error) {
+	return nil, nil
+}
+
 func (m *mockStore) AddLabel(_ context.Context, beadID string, label string) error {
 	m.labels[beadID] = append(m.labels[beadID], label)
 	return nil
@@ -93,6 +150,45 @@ func (m *mockStore) GetLabels(_ context.Context, beadID string) ([]string, error
 	return m.labels[beadID], nil
 }
 
+func (m *mockStore) ListLabels(_ context.Context) ([]*model.LabelCount, error) {
+	return nil, nil
+}
+
+func (m *mockStore) AggregateBeads(_ context.Context, _ model.BeadFilter, _ string) ([]*model.AggregateGroup, error) {
+	return nil, nil
+}
+
+func (m *mockStore) RollupBeads(_ context.Context, _ model.BeadFilter, _ time.Time) (*model.Rollup, error) {
+	return nil, nil
+}
+
+func (m *mockStore) ResolveBeadRef(_ context.Context, ref string) (string, error) {
+	if _, ok := m.beads[ref]; ok {
+		return ref, nil
+	}
+	return "", sql.ErrNoRows
+}
+
+func (m *mockStore) AddAlias(_ context.Context, _ *model.Alias) error {
+	return nil
+}
+
+func (m *mockStore) RemoveAlias(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockStore) GetAliases(_ context.Context, _ string) ([]*model.Alias, error) {
+	return nil, nil
+}
+
+func (m *mockStore) MergeBead(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockStore) SimilarBeads(_ context.Context, _, _ string, _ int) ([]*model.SimilarBead, error) {
+	return nil, nil
+}
+
 func (m *mockStore) AddComment(_ context.Context, comment *model.Comment) error {
 	m.comments[comment.BeadID] = append(m.comments[comment.BeadID], comment)
 	return nil
@@ -102,14 +198,103 @@ func (m *mockStore) GetComments(_ context.Context, beadID string) ([]*model.Comm
 	return m.comments[beadID], nil
 }
 
+func (m *mockStore) GetComment(_ context.Context, _ int64) (*model.Comment, error) {
+	return nil, nil
+}
+
+func (m *mockStore) AddReaction(_ context.Context, _ int64, _, _ string) error {
+	return nil
+}
+
+func (m *mockStore) RemoveReaction(_ context.Context, _ int64, _, _ string) error {
+	return nil
+}
+
+func (m *mockStore) ResolveComment(_ context.Context, _ int64, _ string, _ bool) error {
+	return nil
+}
+
+func (m *mockStore) ImportComment(_ context.Context, comment *model.Comment) error {
+	m.comments[comment.BeadID] = append(m.comments[comment.BeadID], comment)
+	return nil
+}
+
+func (m *mockStore) LoadRelations(_ context.Context, beads []*model.Bead) error {
+	for _, b := range beads {
+		b.Labels = m.labels[b.ID]
+		b.Dependencies = m.deps[b.ID]
+		b.Comments = m.comments[b.ID]
+	}
+	return nil
+}
+
+func (m *mockStore) ListCommentsByAuthor(_ context.Context, _ string, _ int) ([]*model.Comment, error) {
+	return nil, nil
+}
+
+func (m *mockStore) AppendNote(_ context.Context, _ *model.Note) error {
+	return nil
+}
+
+func (m *mockStore) GetNotes(_ context.Context, _ string) ([]*model.Note, error) {
+	return nil, nil
+}
+
+func (m *mockStore) RecordCreateKey(_ context.Context, _, beadID string) (string, error) {
+	return beadID, nil
+}
+
+func (m *mockStore) AppendDescription(_ context.Context, _, _ string) (string, error) {
+	return "", nil
+}
+
 func (m *mockStore) RecordEvent(_ context.Context, _ *model.Event) error {
 	return nil
 }
 
+func (m *mockStore) ListUnpublishedEvents(_ context.Context, _ int) ([]*model.Event, error) {
+	return nil, nil
+}
+
+func (m *mockStore) MarkEventPublished(_ context.Context, _ int64) error {
+	return nil
+}
+
 func (m *mockStore) GetEvents(_ context.Context, _ string) ([]*model.Event, error) {
 	return nil, nil
 }
 
+func (m *mockStore) ListEventsByActor(_ context.Context, _ string, _ int) ([]*model.Event, error) {
+	return nil, nil
+}
+
+func (m *mockStore) ListEventsBetween(_ context.Context, _, _ time.Time, _ []string) ([]*model.Event, error) {
+	return nil, nil
+}
+
+func (m *mockStore) ListEvents(_ context.Context, filter model.EventFilter) ([]*model.Event, error) {
+	var result []*model.Event
+	for _, e := range m.events {
+		if e.ID > filter.AfterID && (filter.Limit == 0 || len(result) < filter.Limit) {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockStore) ImportEvent(_ context.Context, event *model.Event) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockStore) CompactEvents(_ context.Context, _, _ []string, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockStore) ListEventSummaries(_ context.Context, _ model.EventSummaryFilter) ([]*model.EventSummary, error) {
+	return nil, nil
+}
+
 func (m *mockStore) SetConfig(_ context.Context, config *model.Config) error {
 	m.configs[config.Key] = config
 	return nil
@@ -150,10 +335,182 @@ func (m *mockStore) DeleteConfig(_ context.Context, key string) error {
 	return nil
 }
 
+func (m *mockStore) ListConfigRevisions(_ context.Context, _ string) ([]*model.ConfigRevision, error) {
+	return nil, nil
+}
+
+func (m *mockStore) GetConfigRevision(_ context.Context, _ string, _ int64) (*model.ConfigRevision, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockStore) AddWatcher(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockStore) RemoveWatcher(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockStore) GetWatchers(_ context.Context, _ string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockStore) AckAdvice(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockStore) ListAdviceAcks(_ context.Context, _ string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockStore) AcquireBeadLock(_ context.Context, lock *model.BeadLock) (*model.BeadLock, error) {
+	return lock, nil
+}
+
+func (m *mockStore) GetBeadLock(_ context.Context, _ string) (*model.BeadLock, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockStore) ReleaseBeadLock(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockStore) ListNotifications(_ context.Context, _ string, _ bool, _ int) ([]*model.Notification, error) {
+	return nil, nil
+}
+
+func (m *mockStore) LinkCommit(_ context.Context, _ *model.Commit) (bool, error) {
+	return true, nil
+}
+
+func (m *mockStore) GetCommits(_ context.Context, _ string) ([]*model.Commit, error) {
+	return nil, nil
+}
+
+func (m *mockStore) AddChecklistItems(_ context.Context, _ string, _ []string, _ string) ([]*model.ChecklistItem, error) {
+	return nil, nil
+}
+
+func (m *mockStore) GetChecklist(_ context.Context, _ string) ([]*model.ChecklistItem, error) {
+	return nil, nil
+}
+
+func (m *mockStore) CheckChecklistItem(_ context.Context, _ string, _ int, _ string, _ bool) (*model.ChecklistItem, error) {
+	return nil, nil
+}
+
+func (m *mockStore) NotifyActors(_ context.Context, _ int64, _ []string) error {
+	return nil
+}
+
+func (m *mockStore) MarkNotificationsRead(_ context.Context, _ string, _ []int64) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockStore) CreateDigest(_ context.Context, _ *model.Digest) error {
+	return nil
+}
+
+func (m *mockStore) GetLatestDigest(_ context.Context, _ string) (*model.Digest, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockStore) ReplaceMirroredBeads(_ context.Context, _ string, _ []*model.Bead) error {
+	return nil
+}
+
+func (m *mockStore) ListMirroredBeads(_ context.Context, _ string) ([]*model.MirroredBead, error) {
+	return nil, nil
+}
+
+func (m *mockStore) GetMirroredBead(_ context.Context, _, _ string) (*model.MirroredBead, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockStore) CreateAgent(_ context.Context, _ *model.Agent) error {
+	return nil
+}
+
+func (m *mockStore) GetAgent(_ context.Context, _ string) (*model.Agent, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockStore) GetAgentByTokenHash(_ context.Context, _ string) (*model.Agent, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockStore) GetAgentByHookTokenHash(_ context.Context, _ string) (*model.Agent, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockStore) SetAgentHookToken(_ context.Context, _, _ string) error {
+	return sql.ErrNoRows
+}
+
+func (m *mockStore) ListAgents(_ context.Context) ([]*model.Agent, error) {
+	return nil, nil
+}
+
+func (m *mockStore) CreateActor(_ context.Context, _ *model.Actor) error {
+	return nil
+}
+
+func (m *mockStore) GetActor(_ context.Context, _ string) (*model.Actor, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockStore) ListActors(_ context.Context) ([]*model.Actor, error) {
+	return nil, nil
+}
+
+func (m *mockStore) UpdateActor(_ context.Context, _ *model.Actor) error {
+	return sql.ErrNoRows
+}
+
+func (m *mockStore) DeleteActor(_ context.Context, _ string) error {
+	return sql.ErrNoRows
+}
+
+func (m *mockStore) AddActorAlias(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockStore) RemoveActorAlias(_ context.Context, _, _ string) error {
+	return sql.ErrNoRows
+}
+
+func (m *mockStore) ResolveActor(_ context.Context, _ string) (string, error) {
+	return "", sql.ErrNoRows
+}
+
+func (m *mockStore) AddExternalDep(_ context.Context, _ *model.ExternalDep) error {
+	return nil
+}
+
+func (m *mockStore) GetExternalDeps(_ context.Context, _ string) ([]*model.ExternalDep, error) {
+	return nil, nil
+}
+
+func (m *mockStore) ListProbedExternalDeps(_ context.Context) ([]*model.ExternalDep, error) {
+	return nil, nil
+}
+
+func (m *mockStore) UpdateExternalDep(_ context.Context, _ *model.ExternalDep) error {
+	return sql.ErrNoRows
+}
+
+func (m *mockStore) RemoveExternalDep(_ context.Context, _ string, _ int64) error {
+	return sql.ErrNoRows
+}
+
 func (m *mockStore) RunInTransaction(_ context.Context, fn func(tx store.Store) error) error {
 	return fn(m)
 }
 
+func (m *mockStore) RunInSnapshot(_ context.Context, fn func(tx store.Store) error) error {
+	return fn(m)
+}
+
 func (m *mockStore) Close() error {
 	return nil
 }
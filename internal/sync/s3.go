@@ -1,9 +1,9 @@
 package sync
 
 import (
-	"bytes"
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
@@ -46,12 +46,17 @@ func NewS3Destination(ctx context.Context, bucket, key, region, endpoint string)
 }
 
 // Write uploads data to S3 as the configured object key.
-func (d *S3Destination) Write(ctx context.Context, data []byte) error {
+func (d *S3Destination) Write(ctx context.Context, r io.Reader) error {
+	return d.Put(ctx, d.key, r)
+}
+
+// Put uploads JSONL data read from r to the bucket as key.
+func (d *S3Destination) Put(ctx context.Context, key string, r io.Reader) error {
 	contentType := "application/x-ndjson"
 	_, err := d.client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:      aws.String(d.bucket),
-		Key:         aws.String(d.key),
-		Body:        bytes.NewReader(data),
+		Key:         aws.String(key),
+		Body:        r,
 		ContentType: &contentType,
 	})
 	if err != nil {
@@ -59,3 +64,15 @@ func (d *S3Destination) Write(ctx context.Context, data []byte) error {
 	}
 	return nil
 }
+
+// Get opens the object key in the bucket. The caller closes it.
+func (d *S3Destination) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := d.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 get object: %w", err)
+	}
+	return out.Body, nil
+}
@@ -0,0 +1,170 @@
+package sync
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+	"github.com/alfredjeanlab/beads/internal/store"
+)
+
+// ErrNotEmpty is returned by RestoreSnapshot when the store already has
+// beads.
+var ErrNotEmpty = errors.New("store already has beads; restore into an empty database")
+
+// RestoreStats counts the records RestoreSnapshot loaded.
+type RestoreStats struct {
+	Beads        int `json:"beads"`
+	Labels       int `json:"labels"`
+	Dependencies int `json:"dependencies"`
+	Comments     int `json:"comments"`
+	Events       int `json:"events"`
+	Configs      int `json:"configs"`
+}
+
+// WriteSnapshot writes a full logical dump of the store to w: the
+// ExportJSONL records followed by every event, oldest first, all read in
+// one snapshot.
+func WriteSnapshot(ctx context.Context, s store.Store, w io.Writer) error {
+	return s.RunInSnapshot(ctx, func(tx store.Store) error {
+		if err := exportSnapshot(ctx, tx, w); err != nil {
+			return err
+		}
+		return exportEvents(ctx, tx, w)
+	})
+}
+
+// exportEvents writes every event as an "event" record, a page at a time.
+func exportEvents(ctx context.Context, s store.Store, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	var after int64
+	for {
+		page, err := s.ListEvents(ctx, model.EventFilter{AfterID: after, Limit: exportPageSize})
+		if err != nil {
+			return fmt.Errorf("list events: %w", err)
+		}
+		for _, e := range page {
+			if err := enc.Encode(record{Type: "event", Data: e}); err != nil {
+				return fmt.Errorf("encode event %d: %w", e.ID, err)
+			}
+			after = e.ID
+		}
+		if len(page) < exportPageSize {
+			return nil
+		}
+	}
+}
+
+// RestoreSnapshot loads a dump written by WriteSnapshot, or an ExportJSONL
+// backup, into s in one transaction. Beads, comments and events keep their
+// IDs and timestamps; restored events count as already published.
+// Comment reactions and config revision history are not part of a dump.
+// Returns ErrNotEmpty if s already has beads.
+func RestoreSnapshot(ctx context.Context, s store.Store, r io.Reader) (RestoreStats, error) {
+	var stats RestoreStats
+	err := s.RunInTransaction(ctx, func(tx store.Store) error {
+		stats = RestoreStats{}
+		_, n, err := tx.ListBeads(ctx, model.BeadFilter{Limit: 1, IncludeArchived: true})
+		if err != nil {
+			return fmt.Errorf("count beads: %w", err)
+		}
+		if n > 0 {
+			return ErrNotEmpty
+		}
+		return restoreRecords(ctx, tx, r, &stats)
+	})
+	return stats, err
+}
+
+func restoreRecords(ctx context.Context, s store.Store, r io.Reader, stats *RestoreStats) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	// Dependencies can point at beads later in the dump, so they are added
+	// once every bead exists.
+	var deps []*model.Dependency
+	line := 0
+	for sc.Scan() {
+		line++
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var rec struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if line == 1 {
+			if rec.Type != "header" {
+				return fmt.Errorf("line 1: not a beads snapshot")
+			}
+			continue
+		}
+
+		switch rec.Type {
+		case "bead":
+			var b model.Bead
+			if err := json.Unmarshal(rec.Data, &b); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			if err := s.CreateBead(ctx, &b); err != nil {
+				return fmt.Errorf("restore bead %s: %w", b.ID, err)
+			}
+			for _, label := range b.Labels {
+				if err := s.AddLabel(ctx, b.ID, label); err != nil {
+					return fmt.Errorf("restore label %s on %s: %w", label, b.ID, err)
+				}
+			}
+			for _, c := range b.Comments {
+				if err := s.ImportComment(ctx, c); err != nil {
+					return fmt.Errorf("restore comment %d: %w", c.ID, err)
+				}
+			}
+			deps = append(deps, b.Dependencies...)
+			stats.Beads++
+			stats.Labels += len(b.Labels)
+			stats.Comments += len(b.Comments)
+		case "config":
+			var c model.Config
+			if err := json.Unmarshal(rec.Data, &c); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			if err := s.SetConfig(ctx, &c); err != nil {
+				return fmt.Errorf("restore config %s: %w", c.Key, err)
+			}
+			stats.Configs++
+		case "event":
+			var e model.Event
+			if err := json.Unmarshal(rec.Data, &e); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			if err := s.ImportEvent(ctx, &e); err != nil {
+				return fmt.Errorf("restore event %d: %w", e.ID, err)
+			}
+			stats.Events++
+		default:
+			return fmt.Errorf("line %d: unknown record type %q", line, rec.Type)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("reading snapshot: %w", err)
+	}
+	if line == 0 {
+		return fmt.Errorf("empty snapshot")
+	}
+
+	for _, d := range deps {
+		if err := s.AddDependency(ctx, d); err != nil {
+			return fmt.Errorf("restore dependency %s -> %s: %w", d.BeadID, d.DependsOnID, err)
+		}
+	}
+	stats.Dependencies = len(deps)
+	return nil
+}
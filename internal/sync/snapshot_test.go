@@ -0,0 +1,83 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alfredjeanlab/beads/internal/model"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	src := newMockStore()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	src.beads["bd-aaa"] = &model.Bead{ID: "bd-aaa", Kind: model.KindIssue, Type: model.TypeBug, Title: "First", Status: model.StatusOpen, CreatedAt: now, UpdatedAt: now}
+	src.beads["bd-bbb"] = &model.Bead{ID: "bd-bbb", Kind: model.KindIssue, Type: model.TypeTask, Title: "Second", Status: model.StatusOpen, CreatedAt: now, UpdatedAt: now}
+	src.labels["bd-aaa"] = []string{"urgent"}
+	// bd-aaa depends on a bead later in the dump.
+	src.deps["bd-aaa"] = []*model.Dependency{{BeadID: "bd-aaa", DependsOnID: "bd-bbb", Type: model.DepBlocks, CreatedAt: now}}
+	src.comments["bd-aaa"] = []*model.Comment{{ID: 7, BeadID: "bd-aaa", Author: "alice", Text: "Fix this", CreatedAt: now}}
+	src.configs["view:inbox"] = &model.Config{Key: "view:inbox", Value: json.RawMessage(`{"filter":{}}`), CreatedAt: now, UpdatedAt: now}
+	src.events = []*model.Event{
+		{ID: 3, Topic: "beads.bead.created", BeadID: "bd-aaa", Payload: json.RawMessage(`{}`), CreatedAt: now},
+		{ID: 9, Topic: "beads.bead.created", BeadID: "bd-bbb", Payload: json.RawMessage(`{}`), CreatedAt: now},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSnapshot(context.Background(), src, &buf); err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+
+	dst := newMockStore()
+	stats, err := RestoreSnapshot(context.Background(), dst, &buf)
+	if err != nil {
+		t.Fatalf("RestoreSnapshot: %v", err)
+	}
+	want := RestoreStats{Beads: 2, Labels: 1, Dependencies: 1, Comments: 1, Events: 2, Configs: 1}
+	if stats != want {
+		t.Fatalf("stats = %+v, want %+v", stats, want)
+	}
+	if len(dst.beads) != 2 || dst.beads["bd-bbb"].Title != "Second" {
+		t.Fatalf("beads = %v", dst.beads)
+	}
+	if !reflect.DeepEqual(dst.labels["bd-aaa"], []string{"urgent"}) || len(dst.deps["bd-aaa"]) != 1 {
+		t.Fatalf("labels = %v, deps = %v", dst.labels, dst.deps)
+	}
+	if c := dst.comments["bd-aaa"]; len(c) != 1 || c[0].ID != 7 || !c[0].CreatedAt.Equal(now) {
+		t.Fatalf("comments = %+v", c)
+	}
+	if len(dst.events) != 2 || dst.events[1].ID != 9 {
+		t.Fatalf("events = %+v", dst.events)
+	}
+	if _, ok := dst.configs["view:inbox"]; !ok {
+		t.Fatalf("configs = %v", dst.configs)
+	}
+}
+
+func TestRestoreSnapshot_NotEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSnapshot(context.Background(), newMockStore(), &buf); err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+	dst := newMockStore()
+	dst.beads["bd-x"] = &model.Bead{ID: "bd-x"}
+	if _, err := RestoreSnapshot(context.Background(), dst, &buf); !errors.Is(err, ErrNotEmpty) {
+		t.Fatalf("expected ErrNotEmpty, got %v", err)
+	}
+}
+
+func TestRestoreSnapshot_Invalid(t *testing.T) {
+	for name, in := range map[string]string{
+		"empty":     "",
+		"no header": `{"type":"bead","data":{"id":"bd-a"}}` + "\n",
+		"unknown":   `{"type":"header"}` + "\n" + `{"type":"widget","data":{}}` + "\n",
+	} {
+		if _, err := RestoreSnapshot(context.Background(), newMockStore(), bytes.NewBufferString(in)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -1,10 +1,11 @@
 package sync
 
 import (
-	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
 	"sync"
 	"time"
 
@@ -13,8 +14,8 @@ import (
 
 // Destination is the interface for a sync target (S3, git, etc.).
 type Destination interface {
-	// Write sends the JSONL payload to the destination.
-	Write(ctx context.Context, data []byte) error
+	// Write sends the JSONL payload read from r to the destination.
+	Write(ctx context.Context, r io.Reader) error
 }
 
 // Scheduler runs periodic syncs to one or more destinations.
@@ -78,18 +79,35 @@ func (s *Scheduler) run(ctx context.Context) {
 }
 
 func (s *Scheduler) syncOnce(ctx context.Context) {
-	var buf bytes.Buffer
-	if err := ExportJSONL(ctx, s.store, &buf); err != nil {
+	// Spool the export to a temporary file rather than memory; each
+	// destination then reads it from the start.
+	f, err := os.CreateTemp("", "beads-sync-*.jsonl")
+	if err != nil {
+		s.logger.Error("sync export failed", "err", err)
+		return
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := ExportJSONL(ctx, s.store, f); err != nil {
+		s.logger.Error("sync export failed", "err", err)
+		return
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
 		s.logger.Error("sync export failed", "err", err)
 		return
 	}
-	data := buf.Bytes()
 
 	for i, dest := range s.destinations {
-		if err := dest.Write(ctx, data); err != nil {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			s.logger.Error("sync export failed", "err", err)
+			return
+		}
+		if err := dest.Write(ctx, f); err != nil {
 			s.logger.Error("sync destination write failed", "destination", fmt.Sprintf("%d", i), "err", err)
 		}
 	}
 
-	s.logger.Info("sync completed", "destinations", len(s.destinations), "bytes", len(data))
+	s.logger.Info("sync completed", "destinations", len(s.destinations), "bytes", size)
 }
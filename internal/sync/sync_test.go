@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"log/slog"
 	"os"
 	"sync/atomic"
@@ -18,11 +19,13 @@ type mockDestination struct {
 	last   atomic.Value // []byte
 }
 
-func (d *mockDestination) Write(_ context.Context, data []byte) error {
+func (d *mockDestination) Write(_ context.Context, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
 	d.writes.Add(1)
-	cp := make([]byte, len(data))
-	copy(cp, data)
-	d.last.Store(cp)
+	d.last.Store(data)
 	return nil
 }
 
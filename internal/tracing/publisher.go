@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Publisher wraps an events.Publisher, recording a producer span for every
+// event it publishes.
+type Publisher struct {
+	inner events.Publisher
+}
+
+// NewPublisher returns a publisher that traces calls to inner.
+func NewPublisher(inner events.Publisher) *Publisher {
+	return &Publisher{inner: inner}
+}
+
+// Publish publishes the event under a "publish <topic>" span.
+func (p *Publisher) Publish(ctx context.Context, topic string, event any) error {
+	attrs := []attribute.KeyValue{attribute.String("messaging.destination.name", topic)}
+	if seq, ok := events.SequenceFrom(ctx); ok {
+		attrs = append(attrs, attribute.Int64("beads.event.id", seq))
+	}
+	ctx, span := Tracer().Start(ctx, "publish "+topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attrs...),
+	)
+	err := p.inner.Publish(ctx, topic, event)
+	End(span, err)
+	return err
+}
+
+// Close closes the wrapped publisher.
+func (p *Publisher) Close() error {
+	return p.inner.Close()
+}
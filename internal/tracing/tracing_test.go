@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alfredjeanlab/beads/internal/events"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// failingPublisher fails every publish.
+type failingPublisher struct{ events.NoopPublisher }
+
+func (failingPublisher) Publish(context.Context, string, any) error { return errors.New("nats down") }
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return rec
+}
+
+func TestPublisher(t *testing.T) {
+	rec := recordSpans(t)
+
+	ctx := events.WithSequence(context.Background(), 42)
+	if err := NewPublisher(&events.NoopPublisher{}).Publish(ctx, events.TopicBeadCreated, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewPublisher(&failingPublisher{}).Publish(context.Background(), events.TopicBeadClosed, nil); err == nil {
+		t.Fatal("publish error was swallowed")
+	}
+
+	spans := rec.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if spans[0].Name() != "publish "+events.TopicBeadCreated || spans[0].SpanKind() != trace.SpanKindProducer {
+		t.Errorf("span = %s (%s)", spans[0].Name(), spans[0].SpanKind())
+	}
+	var seq int64
+	for _, a := range spans[0].Attributes() {
+		if a.Key == "beads.event.id" {
+			seq = a.Value.AsInt64()
+		}
+	}
+	if seq != 42 {
+		t.Errorf("beads.event.id = %d, want 42", seq)
+	}
+	if spans[1].Status().Code != codes.Error {
+		t.Errorf("failed publish status = %v, want error", spans[1].Status())
+	}
+}
+
+func TestTraceID(t *testing.T) {
+	recordSpans(t)
+	if id := TraceID(context.Background()); id != "" {
+		t.Errorf("untraced context has trace ID %q", id)
+	}
+	ctx, span := Tracer().Start(context.Background(), "op")
+	defer span.End()
+	if id := TraceID(ctx); id != span.SpanContext().TraceID().String() {
+		t.Errorf("TraceID = %q, want %s", id, span.SpanContext().TraceID())
+	}
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	mdHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdBullet  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdOrdered = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	mdQuote   = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdRule    = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	mdFence   = regexp.MustCompile("^\\s*(```|~~~)")
+	mdInline  = regexp.MustCompile("`([^`]+)`|\\*\\*([^*]+)\\*\\*|__([^_]+)__|\\[([^\\]]+)\\]\\(([^)\\s]+)\\)")
+	mdANSI    = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+// SGR attributes for bold and underlined text.
+const (
+	sgrBold      = "1"
+	sgrUnderline = "4"
+)
+
+// RenderMarkdown renders the Markdown in src for the terminal: headings,
+// bullet and numbered lists, block quotes, code fences, inline code, bold
+// and links. Paragraphs are wrapped to width; zero leaves line breaks as
+// written. Styling is omitted when color is disabled.
+func RenderMarkdown(src string, width int) string {
+	var out, para []string
+	flush := func() {
+		if len(para) > 0 {
+			out = append(out, wrap(renderInline(strings.Join(para, " ")), width, "", "")...)
+			para = nil
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case mdFence.MatchString(line):
+			flush()
+			fence := mdFence.FindStringSubmatch(line)[1]
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				out = append(out, "    "+style(lines[i], fmt.Sprintf("38;5;%d", colorCmd)))
+			}
+		case strings.TrimSpace(line) == "":
+			flush()
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+		case mdHeading.MatchString(line):
+			flush()
+			m := mdHeading.FindStringSubmatch(line)
+			out = append(out, style(m[2], fmt.Sprintf("1;38;5;%d", colorAccent)))
+		case mdRule.MatchString(line):
+			flush()
+			out = append(out, RenderMuted(strings.Repeat("─", ruleWidth(width))))
+		case mdBullet.MatchString(line):
+			flush()
+			m := mdBullet.FindStringSubmatch(line)
+			out = append(out, wrap(renderInline(m[2]), width, m[1]+"  • ", m[1]+"    ")...)
+		case mdOrdered.MatchString(line):
+			flush()
+			m := mdOrdered.FindStringSubmatch(line)
+			marker := m[1] + "  " + m[2] + ". "
+			out = append(out, wrap(renderInline(m[3]), width, marker, strings.Repeat(" ", len(marker)))...)
+		case mdQuote.MatchString(line):
+			flush()
+			bar := RenderMuted("│ ")
+			out = append(out, wrap(renderInline(mdQuote.FindStringSubmatch(line)[1]), width, bar, bar)...)
+		case width > 0:
+			para = append(para, strings.TrimSpace(line))
+		default:
+			out = append(out, renderInline(line))
+		}
+	}
+	flush()
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n")
+}
+
+// renderInline styles inline code, bold text and links in s.
+func renderInline(s string) string {
+	return mdInline.ReplaceAllStringFunc(s, func(tok string) string {
+		m := mdInline.FindStringSubmatch(tok)
+		switch {
+		case m[1] != "":
+			return style(m[1], fmt.Sprintf("38;5;%d", colorCmd))
+		case m[2] != "":
+			return style(m[2], sgrBold)
+		case m[3] != "":
+			return style(m[3], sgrBold)
+		case m[4] == m[5]:
+			return style(m[5], sgrUnderline)
+		default:
+			return m[4] + " (" + style(m[5], fmt.Sprintf("4;38;5;%d", colorMuted)) + ")"
+		}
+	})
+}
+
+// style wraps s in the SGR attributes attrs unless color is disabled.
+func style(s, attrs string) string {
+	if noColor {
+		return s
+	}
+	return "\x1b[" + attrs + "m" + s + "\x1b[0m"
+}
+
+// wrap breaks s into lines of at most width visible characters, starting
+// the first with first and the rest with indent. Words longer than a line
+// are left whole. A width of zero disables wrapping.
+func wrap(s string, width int, first, indent string) []string {
+	if width <= 0 {
+		return []string{first + s}
+	}
+	var lines []string
+	line, n := first, visibleLen(first)
+	empty := true
+	for _, word := range strings.Fields(s) {
+		w := visibleLen(word)
+		if !empty && n+1+w > width {
+			lines = append(lines, line)
+			line, n, empty = indent, visibleLen(indent), true
+		}
+		if !empty {
+			line += " "
+			n++
+		}
+		line += word
+		n += w
+		empty = false
+	}
+	return append(lines, line)
+}
+
+// visibleLen returns the number of characters s occupies on screen.
+func visibleLen(s string) int {
+	return utf8.RuneCountInString(mdANSI.ReplaceAllString(s, ""))
+}
+
+func ruleWidth(width int) int {
+	if width <= 0 || width > 40 {
+		return 40
+	}
+	return width
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown_Plain(t *testing.T) {
+	noColor = true
+	t.Cleanup(func() { noColor = false })
+
+	src := "## Steps\n\n" +
+		"Run the **migration** with `make migrate`,\nthen see [the docs](https://example.com/docs).\n\n" +
+		"- first item\n  - nested item\n1. numbered\n\n" +
+		"> quoted\n\n" +
+		"```go\nfmt.Println(\"hi\")\n```\n"
+	want := strings.Join([]string{
+		"Steps",
+		"",
+		"Run the migration with make migrate,",
+		"then see the docs (https://example.com/docs).",
+		"",
+		"  • first item",
+		"    • nested item",
+		"  1. numbered",
+		"",
+		"│ quoted",
+		"",
+		"    fmt.Println(\"hi\")",
+	}, "\n")
+	if got := RenderMarkdown(src, 0); got != want {
+		t.Errorf("RenderMarkdown(width 0) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderMarkdown_Wraps(t *testing.T) {
+	noColor = true
+	t.Cleanup(func() { noColor = false })
+
+	got := RenderMarkdown("one two three\nfour five\n\n- six seven eight nine", 14)
+	want := "one two three\nfour five\n\n  • six seven\n    eight nine"
+	if got != want {
+		t.Errorf("got\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRenderMarkdown_Color(t *testing.T) {
+	noColor = false
+	got := RenderMarkdown("# Title\nsome **bold** text", 0)
+	if !strings.Contains(got, "\x1b[1;38;5;74mTitle\x1b[0m") || !strings.Contains(got, "\x1b[1mbold\x1b[0m") {
+		t.Errorf("missing styling: %q", got)
+	}
+	if n := visibleLen(got); n != len("Title\nsome bold text") {
+		t.Errorf("visibleLen = %d", n)
+	}
+}
@@ -39,3 +39,8 @@ func RenderCommand(s string) string {
 func ForceNoColor() {
 	noColor = true
 }
+
+// ForceColor enables color output globally, even without a terminal.
+func ForceColor() {
+	noColor = false
+}
@@ -0,0 +1,62 @@
+// Package version compares the release versions of bd clients and servers.
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parse splits a release version such as "v1.2.3" or "1.2.3-rc.1" into its
+// major, minor and patch numbers. Pre-release and build suffixes are
+// ignored. Nightly and dev builds do not parse.
+func parse(v string) ([3]int, bool) {
+	var parts [3]int
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	fields := strings.Split(v, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parts, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// Valid reports whether v is a release version Compare understands.
+func Valid(v string) bool {
+	_, ok := parse(v)
+	return ok
+}
+
+// Compare returns -1, 0 or +1 as a is older than, the same as, or newer
+// than b. ok is false if either is not a release version.
+func Compare(a, b string) (cmp int, ok bool) {
+	pa, okA := parse(a)
+	pb, okB := parse(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	for i := range pa {
+		switch {
+		case pa[i] < pb[i]:
+			return -1, true
+		case pa[i] > pb[i]:
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+// Older reports whether a is a release version older than b. Versions that
+// do not parse are never older.
+func Older(a, b string) bool {
+	c, ok := Compare(a, b)
+	return ok && c < 0
+}
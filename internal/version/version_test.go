@@ -0,0 +1,35 @@
+package version
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want int
+		ok   bool
+	}{
+		{"v1.2.3", "v1.2.3", 0, true},
+		{"1.2.3", "v1.2.3", 0, true},
+		{"v1.2.3", "v1.10.0", -1, true},
+		{"v2.0.0", "v1.99.99", 1, true},
+		{"v1.2", "v1.2.0", 0, true},
+		{"v1.3.0-rc.1", "v1.3.0", 0, true},
+		{"dev", "v1.0.0", 0, false},
+		{"nightly-20260101-abc1234", "v1.0.0", 0, false},
+		{"v1.2.3.4", "v1.0.0", 0, false},
+	} {
+		got, ok := Compare(tc.a, tc.b)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("Compare(%q, %q) = %d, %v; want %d, %v", tc.a, tc.b, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestOlder(t *testing.T) {
+	if !Older("v0.9.0", "v0.10.0") {
+		t.Error("v0.9.0 should be older than v0.10.0")
+	}
+	if Older("dev", "v0.10.0") || Older("v0.10.0", "v0.10.0") {
+		t.Error("dev builds and equal versions are never older")
+	}
+}